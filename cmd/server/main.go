@@ -5,23 +5,73 @@ import (
     "expvar"
     "fmt"
     "log"
+    "net"
     "net/http"
     "net/http/pprof"
     "os"
     "os/signal"
+    "runtime"
+    "strings"
     "syscall"
     "time"
 
     "github.com/prometheus/client_golang/prometheus"
     "github.com/prometheus/client_golang/prometheus/promhttp"
     "github.com/rs/cors"
+    "go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
     "go.uber.org/zap"
+    "google.golang.org/grpc"
 
+    "github.com/yourusername/sports-chat/internal/achievements"
+    "github.com/yourusername/sports-chat/internal/analytics"
     "github.com/yourusername/sports-chat/internal/api"
+    "github.com/yourusername/sports-chat/internal/appeals"
+    "github.com/yourusername/sports-chat/internal/attachments"
     "github.com/yourusername/sports-chat/internal/auth"
+    "github.com/yourusername/sports-chat/internal/avatars"
+    "github.com/yourusername/sports-chat/internal/broker"
+    "github.com/yourusername/sports-chat/internal/cdn"
+    "github.com/yourusername/sports-chat/internal/commentary"
     "github.com/yourusername/sports-chat/internal/config"
+    "github.com/yourusername/sports-chat/internal/evasion"
+    "github.com/yourusername/sports-chat/internal/events"
+    "github.com/yourusername/sports-chat/internal/evidence"
+    "github.com/yourusername/sports-chat/internal/grpcapi"
+    "github.com/yourusername/sports-chat/internal/highlights"
+    "github.com/yourusername/sports-chat/internal/incidents"
+    "github.com/yourusername/sports-chat/internal/jobs"
+    "github.com/yourusername/sports-chat/internal/journal"
+    "github.com/yourusername/sports-chat/internal/lifecycle"
+    "github.com/yourusername/sports-chat/internal/localization"
+    "github.com/yourusername/sports-chat/internal/mail"
     "github.com/yourusername/sports-chat/internal/metrics"
+    "github.com/yourusername/sports-chat/internal/moderation"
+    "github.com/yourusername/sports-chat/internal/objectstore"
+    "github.com/yourusername/sports-chat/internal/predictions"
+    "github.com/yourusername/sports-chat/internal/presence"
+    "github.com/yourusername/sports-chat/internal/prewarm"
+    "github.com/yourusername/sports-chat/internal/privacy"
+    "github.com/yourusername/sports-chat/internal/ratelimit"
+    "github.com/yourusername/sports-chat/internal/ratings"
+    "github.com/yourusername/sports-chat/internal/rbac"
+    "github.com/yourusername/sports-chat/internal/reconciliation"
+    "github.com/yourusername/sports-chat/internal/recovery"
+    "github.com/yourusername/sports-chat/internal/resume"
+    "github.com/yourusername/sports-chat/internal/retention"
+    "github.com/yourusername/sports-chat/internal/rollups"
+    "github.com/yourusername/sports-chat/internal/scim"
+    "github.com/yourusername/sports-chat/internal/search/opensearch"
+    "github.com/yourusername/sports-chat/internal/securitylog"
+    "github.com/yourusername/sports-chat/internal/sequence"
+    "github.com/yourusername/sports-chat/internal/sportsdata"
+    "github.com/yourusername/sports-chat/internal/store"
+    "github.com/yourusername/sports-chat/internal/store/instrumented"
+    "github.com/yourusername/sports-chat/internal/store/memory"
     "github.com/yourusername/sports-chat/internal/store/postgres"
+    "github.com/yourusername/sports-chat/internal/store/resilient"
+    "github.com/yourusername/sports-chat/internal/tracing"
+    "github.com/yourusername/sports-chat/internal/unfurl"
+    "github.com/yourusername/sports-chat/internal/userstats"
     "github.com/yourusername/sports-chat/internal/websocket"
 )
 
@@ -45,44 +95,530 @@ func main() {
         logger.Fatal("Failed to load config", zap.Error(err))
     }
 
+    // Initialize tracing
+    shutdownTracing, err := tracing.Setup(context.Background(), tracing.Options{
+        Endpoint:    cfg.OTLPEndpoint,
+        Insecure:    cfg.OTLPInsecure,
+        ServiceName: cfg.TraceServiceName,
+        SampleRatio: cfg.TraceSampleRatio,
+    })
+    if err != nil {
+        logger.Fatal("Failed to initialize tracing", zap.Error(err))
+    }
+
     // Initialize metrics
     metricsRegistry := prometheus.NewRegistry()
     metrics := metrics.NewMetrics(metricsRegistry)
 
-    // Initialize stores
-    db, err := postgres.New(cfg.DatabaseURL, logger)
+    // Initialize stores. The base store is the one the wrappers below
+    // decorate; dead letters bypass them.
+    var base store.Store
+    var deadLetters jobs.DeadLetterStore
+    switch cfg.StoreDriver {
+    case "memory":
+        mem := memory.New()
+        base, deadLetters = mem, mem
+        logger.Warn("Using the in-memory store; nothing is kept across restarts")
+    default:
+        db, err := postgres.New(context.Background(), postgres.Options{
+            URL:             cfg.DatabaseURL,
+            MaxConns:        cfg.MaxDBConnections,
+            MinConns:        cfg.MaxIdleConns,
+            ConnMaxLifetime: cfg.ConnMaxLifetime,
+            QueryTimeout:    cfg.DBQueryTimeout,
+        }, logger)
+        if err != nil {
+            logger.Fatal("Failed to initialize postgres", zap.Error(err))
+        }
+        defer db.Close()
+
+        // Innermost, so retries go straight to postgres and wrappers act
+        // on calls that succeeded
+        base = resilient.New(db, postgres.Classify, resilient.Policy{
+            MaxAttempts:      cfg.DBRetryAttempts,
+            BaseDelay:        cfg.DBRetryBaseDelay,
+            MaxDelay:         cfg.DBRetryMaxDelay,
+            BreakerThreshold: cfg.DBBreakerThreshold,
+            BreakerCooldown:  cfg.DBBreakerCooldown,
+        }, metrics, logger)
+        deadLetters = db
+    }
+
+    // Initialize background jobs
+    jobQueue := jobs.NewQueue(jobs.Options{
+        Workers:    cfg.JobWorkers,
+        QueueSize:  cfg.JobQueueSize,
+        MaxRetries: cfg.JobMaxRetries,
+
+        // Dead letters go straight to the base store, not through the
+        // search store that enqueues onto this queue
+        DeadLetters: deadLetters,
+    }, metrics, logger)
+    jobQueue.Start()
+
+    // Initialize object storage
+    var bucket objectstore.Bucket
+    switch cfg.ObjectStore {
+    case "dir":
+        dir, err := objectstore.NewDir(cfg.ObjectStoreDir)
+        if err != nil {
+            logger.Fatal("Failed to initialize object storage", zap.Error(err))
+        }
+        bucket = dir
+    case "s3":
+        s3, err := objectstore.NewS3(objectstore.S3Config{
+            Endpoint:        cfg.S3Endpoint,
+            Region:          cfg.S3Region,
+            Bucket:          cfg.S3Bucket,
+            AccessKeyID:     cfg.S3AccessKeyID,
+            SecretAccessKey: cfg.S3SecretAccessKey,
+        })
+        if err != nil {
+            logger.Fatal("Failed to initialize object storage", zap.Error(err))
+        }
+        bucket = s3
+    }
+
+    st := base
+    // Set when search is served from OpenSearch, for the searches the
+    // store interface cannot express
+    var searchStore *opensearch.Store
+    if cfg.SearchBackend == "opensearch" {
+        searchClient, err := opensearch.NewClient(opensearch.Config{
+            URL:         cfg.OpenSearchURL,
+            Username:    cfg.OpenSearchUsername,
+            Password:    cfg.OpenSearchPassword,
+            IndexPrefix: cfg.OpenSearchIndexPrefix,
+        })
+        if err != nil {
+            logger.Fatal("Failed to initialize opensearch", zap.Error(err))
+        }
+        // Before anything is indexed, so no field is mapped dynamically
+        if err := searchClient.EnsureIndices(context.Background()); err != nil {
+            logger.Fatal("Failed to create opensearch indices", zap.Error(err))
+        }
+        searchStore = opensearch.NewStore(st, searchClient, jobQueue, logger)
+        st = searchStore
+    }
+    if cfg.MessageRetention > 0 && cfg.RetentionHydrate {
+        st = retention.NewStore(st, bucket, logger)
+    }
+    // Outermost, so every caller's store operations are measured
+    st = instrumented.New(st, metrics)
+
+    // Initialize auth service. The shared secret has no key ID; it signs
+    // tokens until an asymmetric key is configured, then only verifies.
+    var signingKey *auth.SigningKey
+    var verificationKeys []*auth.SigningKey
+    if cfg.JWTSecret != "" {
+        signingKey = auth.NewHMACKey("", []byte(cfg.JWTSecret))
+    }
+    if cfg.JWTSigningKeyFile != "" {
+        if signingKey != nil {
+            verificationKeys = append(verificationKeys, signingKey)
+        }
+        signingKey, err = auth.LoadPrivateKeyFile(cfg.JWTSigningKeyID, cfg.JWTSigningKeyFile)
+        if err != nil {
+            logger.Fatal("Failed to load JWT signing key", zap.Error(err))
+        }
+    }
+    keys, err := auth.LoadVerificationKeys(cfg.JWTVerificationKeys)
     if err != nil {
-        logger.Fatal("Failed to initialize postgres", zap.Error(err))
+        logger.Fatal("Failed to load JWT verification keys", zap.Error(err))
     }
-    defer db.Close()
+    authService := auth.NewService(signingKey, append(verificationKeys, keys...), logger)
+
+    // Initialize domain events
+    bus := events.NewBus(logger)
 
-    // Initialize auth service
-    authService := auth.NewService(cfg.JWTSecret, logger)
+    // Initialize product analytics
+    var analyticsBatcher *analytics.Batcher
+    if cfg.EnableAnalytics {
+        analyticsBatcher = analytics.NewBatcher(analytics.NewHTTPExporter(cfg.AnalyticsURL, cfg.AnalyticsWriteKey), analytics.BatcherOptions{
+            BufferSize:    cfg.AnalyticsBufferSize,
+            BatchSize:     cfg.AnalyticsBatchSize,
+            FlushInterval: cfg.AnalyticsFlushInterval,
+        }, metrics, logger)
+        analyticsBatcher.Start()
+        analytics.Subscribe(bus, analyticsBatcher)
+    }
+
+    // Initialize CDN purging
+    var invalidator *cdn.Invalidator
+    if cfg.CDNPurgeURL != "" {
+        invalidator = cdn.NewInvalidator(cdn.NewHTTPPurger(cfg.CDNPurgeURL, cfg.CDNPurgeToken), jobQueue, logger)
+        invalidator.Start(bus)
+    }
+
+    // Initialize broadcast journal
+    var broadcastJournal *journal.Journal
+    if cfg.EnableJournal {
+        broadcastJournal = journal.New(st, cfg.JournalBufferSize, metrics, logger)
+        broadcastJournal.Start()
+    }
+
+    // Initialize content moderation
+    profanity := moderation.NewProfanityFilter(st)
+    filters := moderation.NewChain(st, profanity, []moderation.Filter{
+        moderation.NewSpamFilter(),
+        moderation.NewLinkFilter(),
+    }, metrics, logger)
+    if err := filters.Reload(context.Background()); err != nil {
+        logger.Error("Failed to load moderation filters", zap.Error(err))
+    }
+
+    // Match commentary in each client's language
+    commentaryRenderer := commentary.NewRenderer(st)
+    if err := commentaryRenderer.Reload(context.Background()); err != nil {
+        logger.Error("Failed to load commentary templates", zap.Error(err))
+    }
+
+    // Team and competition names in each user's language
+    localizer := localization.NewLocalizer(st)
+    if err := localizer.Reload(context.Background()); err != nil {
+        logger.Error("Failed to load localized names", zap.Error(err))
+    }
+
+    var unfurler *unfurl.Service
+    if cfg.EnableLinkPreviews {
+        unfurler = unfurl.NewService(cfg.UnfurlTimeout, cfg.UnfurlCacheTTL)
+    }
+
+    // Initialize the broker that fans room frames out across instances
+    var msgBroker broker.Broker = broker.NewLocal()
+    if cfg.Broker == "redis" {
+        redisBroker, err := broker.NewRedis(cfg.RedisURL, cfg.RedisChannelPrefix, logger)
+        if err != nil {
+            logger.Fatal("Failed to initialize redis broker", zap.Error(err))
+        }
+        msgBroker = redisBroker
+    }
+
+    // Initialize per-user message rate limits, shared across instances
+    // through Redis when configured
+    wsLimits, err := ratelimit.ParseRules(cfg.WSRateLimits)
+    if err != nil {
+        logger.Fatal("Failed to parse websocket rate limits", zap.Error(err))
+    }
+    var userLimiter ratelimit.Limiter = ratelimit.NewMemory()
+    var redisLimiter *ratelimit.Redis
+    if cfg.RateLimitBackend == "redis" {
+        redisLimiter, err = ratelimit.NewRedis(cfg.RedisURL, cfg.RedisChannelPrefix, userLimiter, metrics, logger)
+        if err != nil {
+            logger.Fatal("Failed to initialize redis rate limiter", zap.Error(err))
+        }
+        userLimiter = redisLimiter
+    }
+    previewReactionLimits, err := ratelimit.ParseRules(cfg.PreviewReactionLimits)
+    if err != nil {
+        logger.Fatal("Failed to parse preview reaction limits", zap.Error(err))
+    }
+
+    // Initialize the sports data provider live scores come from. Record
+    // and replay let development and tests run against saved responses.
+    var provider sportsdata.Provider
+    if cfg.EnableMatchUpdates {
+        switch cfg.SportsAPIMode {
+        case sportsdata.ModeRecord:
+            provider = sportsdata.NewRecordingProvider(cfg.SportsAPIURL, cfg.SportsAPIKey, cfg.SportsAPIFixtures)
+        case sportsdata.ModeReplay:
+            provider = sportsdata.NewReplayProvider(cfg.SportsAPIFixtures)
+        default:
+            provider = sportsdata.NewHTTPProvider(cfg.SportsAPIURL, cfg.SportsAPIKey)
+        }
+        if cfg.SportsAPIMode != sportsdata.ModeLive {
+            logger.Info("Sports data provider using fixtures",
+                zap.String("mode", cfg.SportsAPIMode),
+                zap.String("fixtures", cfg.SportsAPIFixtures))
+        }
+    }
+
+    // Initialize room presence, shared across instances through Redis
+    // alongside the broker. Reports expire after a few missed intervals
+    // so a crashed instance's users drop out.
+    var tracker presence.Tracker = presence.NewLocal()
+    if cfg.Broker == "redis" {
+        tracker, err = presence.NewRedis(cfg.RedisURL, cfg.RedisChannelPrefix, 3*cfg.PresenceInterval)
+        if err != nil {
+            logger.Fatal("Failed to initialize redis presence", zap.Error(err))
+        }
+    }
+
+    // Initialize chat message sequencing, shared across instances
+    // through Redis alongside the broker
+    var sequencer sequence.Sequencer = sequence.NewLocal(st.GetRoomMaxSeq)
+    if cfg.Broker == "redis" {
+        sequencer, err = sequence.NewRedis(cfg.RedisURL, cfg.RedisChannelPrefix, st.GetRoomMaxSeq)
+        if err != nil {
+            logger.Fatal("Failed to initialize redis sequencer", zap.Error(err))
+        }
+    }
+
+    // Keep dropped connections' state for reconnects to resume, shared
+    // across instances through Redis alongside the broker so a client
+    // can resume on any of them
+    var resumeStore resume.Store
+    if cfg.WSResumeWindow > 0 {
+        resumeStore = resume.NewLocal()
+        if cfg.Broker == "redis" {
+            resumeStore, err = resume.NewRedis(cfg.RedisURL, cfg.RedisChannelPrefix)
+            if err != nil {
+                logger.Fatal("Failed to initialize redis resume store", zap.Error(err))
+            }
+        }
+    }
+
+    // Room roles, checked alike by the hub and the API
+    roles := rbac.NewChecker(st, logger)
+
+    // Keep sealed evidence of messages moderators delete
+    var evidenceService *evidence.Service
+    var retainer websocket.EvidenceRetainer
+    if cfg.EnableEvidence {
+        evidenceKeys, err := evidence.ParseKeys(cfg.EvidenceKeys)
+        if err != nil {
+            logger.Fatal("Failed to load evidence keys", zap.Error(err))
+        }
+        evidenceService = evidence.NewService(st, evidenceKeys, cfg.EvidenceRetention, logger)
+        retainer = evidenceService
+    }
 
     // Initialize websocket hub
-    hub := websocket.NewHub(db, metrics, logger)
+    hub := websocket.NewHub(st, msgBroker, bus, broadcastJournal, profanity, unfurler, websocket.Options{
+        FanoutTick:   cfg.FanoutTick,
+        HighBudget:   cfg.FanoutHighBudget,
+        NormalBudget: cfg.FanoutChatBudget,
+        LowBudget:    cfg.FanoutLowBudget,
+        RateLimiter:  userLimiter,
+        RateLimits:   wsLimits,
+        Provider:     provider,
+
+        Presence:         tracker,
+        PresenceInterval: cfg.PresenceInterval,
+
+        Jobs:      jobQueue,
+        Filters:   filters,
+        Sequencer: sequencer,
+        Roles:     roles,
+
+        PersistBatchSize:     cfg.PersistBatchSize,
+        PersistFlushInterval: cfg.PersistFlushInterval,
+        PersistQueueSize:     cfg.PersistQueueSize,
+
+        Shedding: websocket.ShedLimits{
+            MaxConnections: cfg.WSMaxConnections,
+            MaxGoroutines:  cfg.WSMaxGoroutines,
+            MaxHeapBytes:   uint64(cfg.WSMaxHeapMB) << 20,
+            StallTimeout:   cfg.WSHubStallTimeout,
+            RetryAfter:     cfg.WSShedRetryAfter,
+        },
+
+        Compression:          cfg.WSCompression,
+        CompressionLevel:     cfg.WSCompressionLevel,
+        CompressionThreshold: cfg.WSCompressionThreshold,
+        CoalesceWindow:       cfg.WSCoalesceWindow,
+        SendBuffer:           cfg.WSSendBuffer,
+        SlowConsumerPolicy:   cfg.WSSlowConsumerPolicy,
+        Resume:               resumeStore,
+        ResumeWindow:         cfg.WSResumeWindow,
+
+        Evidence:   retainer,
+        Commentary: commentaryRenderer,
+        Localizer:  localizer,
+    }, metrics, logger)
+    if err := hub.Subscribe(); err != nil {
+        logger.Fatal("Failed to subscribe hub to broker", zap.Error(err))
+    }
     go hub.Run()
 
+    // Initialize achievements engine
+    if cfg.EnableAchievements {
+        achievements.NewEngine(st, hub, metrics, logger).Start(bus)
+    }
+
+    // Track engagement for the live leaderboards
+    var statsTracker *userstats.Tracker
+    if cfg.EnableLeaderboards {
+        statsTracker = userstats.NewTracker(st, hub, cfg.LeaderboardInterval, cfg.LeaderboardSize, metrics, logger)
+        statsTracker.Start(bus)
+    }
+
+    // Open post-match votes as matches finish
+    if cfg.EnableMatchVoting {
+        ratings.NewOpener(st, hub, cfg.MatchVoteWindow, logger).Start(bus)
+    }
+
+    if cfg.EnableEvasionDetection {
+        evasion.NewDetector(st, cfg.EvasionThreshold, cfg.EvasionLookback, metrics, logger).Start(bus)
+    }
+
+    // Initialize attachment scanning
+    var attachmentService *attachments.Service
+    if cfg.AttachmentScanner != "" {
+        var scanner attachments.Scanner = attachments.NewClamAV(cfg.ClamAVAddress)
+        if cfg.AttachmentScanner == "http" {
+            scanner = attachments.NewHTTPScanner(cfg.AttachmentScanURL, cfg.AttachmentScanToken)
+        }
+        attachmentService = attachments.NewService(st, scanner, jobQueue, hub, logger)
+    }
+
+    // Initialize incident capture and avatar storage
+    var incidentService *incidents.Service
+    var avatarService *avatars.Service
+    if bucket != nil {
+        incidentService = incidents.NewService(st, bucket, hub, logger)
+        avatarBaseURL := cfg.AvatarBaseURL
+        if avatarBaseURL == "" {
+            avatarBaseURL = "/api/" + api.Version
+        }
+        avatarService = avatars.NewService(bucket, avatarBaseURL, logger)
+    }
+
+    // Initialize match highlights
+    var highlightService *highlights.Service
+    if cfg.EnableHighlights {
+        highlightService = highlights.NewService(st, hub, logger)
+    }
+
+    // Data exports and account erasure; exports are disabled without an
+    // object store
+    var searchIndex privacy.SearchIndex
+    if searchStore != nil {
+        searchIndex = searchStore
+    }
+    privacyService := privacy.NewService(st, bucket, jobQueue, hub, avatarService, searchIndex, cfg.ExportRetention, logger)
+
+    // Appeals of moderation actions
+    appealService := appeals.NewService(st, hub, evidenceService, logger)
+
+    // Log sign-ins to each user's account activity
+    securitylog.NewRecorder(st, logger).Start(bus)
+
+    // Initialize scheduled jobs
+    scheduler := jobs.NewScheduler(metrics, logger)
+    scheduler.Schedule(moderation.NewReloadJob(filters), jobs.Every(cfg.ModerationReloadInterval), 30*time.Second)
+    scheduler.Schedule(commentary.NewReloadJob(commentaryRenderer), jobs.Every(time.Minute), 30*time.Second)
+    scheduler.Schedule(localization.NewReloadJob(localizer), jobs.Every(time.Minute), 30*time.Second)
+    scheduler.Schedule(rollups.NewJob(st, false, logger), jobs.Every(cfg.StatsRollupInterval), 10*time.Minute)
+    scheduler.Schedule(rollups.NewJob(st, true, logger), jobs.DailyAt(cfg.StatsRollupHour, 0), time.Hour)
+    if cfg.MessageRetention > 0 {
+        scheduler.Schedule(retention.NewJob(st, bucket, cfg.MessageRetention, cfg.RetentionBatchSize, metrics, logger), jobs.Every(time.Hour), 30*time.Minute)
+    }
+    if cfg.EnableJournal {
+        scheduler.Schedule(journal.NewPurgeJob(st, cfg.JournalTTL, logger), jobs.Every(time.Hour), 10*time.Minute)
+    }
+    if cfg.EnableEvidence {
+        scheduler.Schedule(evidence.NewPurgeJob(st, logger), jobs.Every(time.Hour), 10*time.Minute)
+    }
+    if bucket != nil {
+        scheduler.Schedule(privacy.NewPurgeJob(privacyService), jobs.Every(time.Hour), 10*time.Minute)
+    }
+    var predictionService *predictions.Service
+    if cfg.EnablePredictions {
+        predictionService = predictions.NewService(st, hub, bus, logger)
+        predictionService.Start()
+        scheduler.Schedule(predictions.NewSeasonJob(st, hub, logger), jobs.Every(5*time.Minute), time.Minute)
+    }
+    if cfg.EnableMatchUpdates {
+        reconciler := reconciliation.NewJob(st, provider, cfg.ReconciliationLookback, logger)
+        if predictionService != nil {
+            reconciler.AddSettler(predictionService)
+        }
+        if invalidator != nil {
+            reconciler.AddSettler(invalidator)
+        }
+        scheduler.Schedule(reconciler, jobs.DailyAt(cfg.ReconciliationHour, 0), time.Hour)
+    }
+    if cfg.EnableRoomLifecycle {
+        lifecycleJob := lifecycle.NewJob(st, hub, cfg.RoomOpenBefore, cfg.RoomArchiveAfter, logger)
+        scheduler.Schedule(lifecycleJob, jobs.Every(time.Minute), 30*time.Second)
+    }
+    scheduler.Schedule(lifecycle.NewPurgeJob(st, cfg.RoomPurgeAfter, logger), jobs.Every(time.Hour), 30*time.Minute)
+    if cfg.EnablePrewarm {
+        scheduler.Schedule(prewarm.NewJob(st, hub, cfg.PrewarmLead, cfg.PrewarmMinFollowers, logger), jobs.Every(time.Minute), 30*time.Second)
+    }
+    if cfg.EnableMatchVoting {
+        scheduler.Schedule(ratings.NewCloseJob(st, hub, logger), jobs.Every(time.Minute), 30*time.Second)
+    }
+    deadLetterMonitor := jobs.NewDeadLetterMonitor(st, cfg.DeadLetterAlertThreshold, metrics, logger)
+    scheduler.Schedule(deadLetterMonitor, jobs.Every(time.Minute), 30*time.Second)
+    scheduler.Start()
+
+    // Account recovery; the recovery email needs a mail server
+    var mailer mail.Sender
+    if cfg.SMTPAddr != "" {
+        mailer = mail.NewSMTP(cfg.SMTPAddr, cfg.SMTPUsername, cfg.SMTPPassword, cfg.MailFrom)
+    }
+    recoveryService := recovery.NewService(st, authService, mailer, userLimiter, []byte(cfg.RecoveryCodeKey), cfg.RecoveryURL, logger)
+
     // Initialize API handlers
-    apiHandler := api.NewHandler(db, authService, metrics, logger)
+    apiHandler := api.NewHandler(st, authService, hub, profanity, api.Options{
+        RateLimitRequests:      cfg.RateLimitRequests,
+        RateLimitWindow:        cfg.RateLimitWindow,
+        PreviewMessages:        cfg.PreviewMessages,
+        PreviewReactions:       cfg.PreviewReactions,
+        PreviewReactionLimiter: userLimiter,
+        PreviewReactionLimits:  previewReactionLimits,
+        AllowedOrigins:         cfg.CORSAllowedOrigins,
+        RequestTimeout:         cfg.RequestTimeout,
+        LongRequestTimeout:     cfg.LongRequestTimeout,
+        Jobs:                   jobQueue,
+        Predictions:            predictionService,
+        UsernameCooldown:       cfg.UsernameChangeCooldown,
+        CountryHeader:          cfg.GeoCountryHeader,
+        StatsRollupInterval:    cfg.StatsRollupInterval,
+        Filters:                filters,
+        Attachments:            attachmentService,
+        Roles:                  roles,
+        MaxAttachmentSize:      cfg.MaxAttachmentSize,
+        Incidents:              incidentService,
+        Evidence:               evidenceService,
+        Appeals:                appealService,
+        Commentary:             commentaryRenderer,
+        Localizer:              localizer,
+        Highlights:             highlightService,
+        Stats:                  statsTracker,
+        IngestSecret:           cfg.SportsWebhookSecret,
+        Avatars:                avatarService,
+        MaxAvatarSize:          cfg.MaxAvatarSize,
+        Privacy:                privacyService,
+        Simulator:              cfg.EnableSimulator,
+        Recovery:               recoveryService,
+        Search:                 searchStore,
+    }, metrics, logger)
 
     // Setup middleware chain
     mw := cors.New(cors.Options{
         AllowedOrigins:   cfg.CORSAllowedOrigins,
         AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-        AllowedHeaders:   []string{"Authorization", "Content-Type"},
+        AllowedHeaders:   []string{"Authorization", "Content-Type", "Last-Event-ID"},
         AllowCredentials: true,
     })
 
     // Setup routes
     mux := http.NewServeMux()
 
-    // API routes
-    mux.Handle("/api/", http.StripPrefix("/api", apiHandler))
-    mux.Handle("/ws", websocket.NewHandler(hub, authService, metrics, logger))
+    // API routes. The unversioned prefix predates versioning and serves
+    // v1 for existing clients.
+    apiPrefix := "/api/" + api.Version
+    mux.Handle(apiPrefix+"/", http.StripPrefix(apiPrefix, apiHandler))
+    mux.Handle("/api/", http.StripPrefix("/api", api.Deprecated(apiHandler, apiPrefix)))
+    wsHandler := websocket.NewHandler(hub, authService, cfg.GeoCountryHeader, metrics, logger)
+    mux.Handle("/ws", wsHandler)
+    mux.Handle("GET /.well-known/jwks.json", authService.JWKSHandler())
+
+    // Enterprise directory sync
+    if cfg.EnableSCIM {
+        groupRoles, err := scim.ParseRoleMap(cfg.SCIMGroupRoles)
+        if err != nil {
+            logger.Fatal("Failed to parse SCIM group roles", zap.Error(err))
+        }
+        mux.Handle("/scim/v2/", http.StripPrefix("/scim/v2", scim.NewHandler(st, bus, cfg.SCIMToken, groupRoles, logger)))
+    }
 
     // Metrics and debugging
     if cfg.Environment == "development" {
+        runtime.SetMutexProfileFraction(cfg.MutexProfileFraction)
         mux.Handle("/debug/vars", expvar.Handler())
         mux.HandleFunc("/debug/pprof/", pprof.Index)
         mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
@@ -100,17 +636,34 @@ func main() {
         fmt.Fprintf(w, "OK")
     })
 
+    // Readiness fails while the instance sheds new connections, steering
+    // the load balancer elsewhere
+    mux.HandleFunc("/ready", wsHandler.ServeReady)
+
     // Version info
     mux.HandleFunc("/version", func(w http.ResponseWriter, r *http.Request) {
         fmt.Fprintf(w, "Version: %s\nCommit: %s\nBuild Date: %s\n", version, commit, date)
     })
 
+    // WebSocket connections live for hours, so each frame gets its own
+    // trace instead; probes and scrapes aren't worth tracing
+    traced := func(r *http.Request) bool {
+        switch r.URL.Path {
+        case "/ws", "/metrics", "/health", "/ready":
+            return false
+        }
+        // Event streams stay open as long as their viewers
+        return !strings.HasSuffix(r.URL.Path, "/stream")
+    }
+
     // Create server
     srv := &http.Server{
         Addr:         cfg.ServerAddress,
-        Handler:      mw.Handler(mux),
+        Handler:      otelhttp.NewHandler(mw.Handler(mux), "http.request", otelhttp.WithFilter(traced)),
         ReadTimeout:  15 * time.Second,
-        WriteTimeout: 15 * time.Second,
+        // Must outlast the longest route timeout so timed-out requests
+        // still get their 503
+        WriteTimeout: cfg.LongRequestTimeout + 5*time.Second,
         IdleTimeout:  60 * time.Second,
     }
 
@@ -127,6 +680,22 @@ func main() {
         }
     }()
 
+    // Internal integrations
+    var grpcServer *grpc.Server
+    if cfg.GRPCAddress != "" {
+        lis, err := net.Listen("tcp", cfg.GRPCAddress)
+        if err != nil {
+            logger.Fatal("Failed to listen for gRPC", zap.Error(err))
+        }
+        grpcServer = grpcapi.NewServer(st, hub, cfg.GRPCToken, logger).GRPCServer()
+        go func() {
+            logger.Info("Starting gRPC server", zap.String("address", cfg.GRPCAddress))
+            if err := grpcServer.Serve(lis); err != nil {
+                logger.Fatal("gRPC server failed", zap.Error(err))
+            }
+        }()
+    }
+
     // Wait for interrupt signal
     quit := make(chan os.Signal, 1)
     signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -141,6 +710,51 @@ func main() {
     if err := srv.Shutdown(ctx); err != nil {
         logger.Fatal("Server forced to shutdown", zap.Error(err))
     }
+    if grpcServer != nil {
+        grpcServer.GracefulStop()
+    }
+
+    scheduler.Stop()
+    hub.FlushDrafts()
+    if err := hub.FlushMessages(ctx); err != nil {
+        logger.Error("Queued messages did not flush", zap.Error(err))
+    }
+    if statsTracker != nil {
+        statsTracker.Stop()
+    }
+
+    if err := msgBroker.Close(); err != nil {
+        logger.Error("Failed to close broker", zap.Error(err))
+    }
+    if redisLimiter != nil {
+        redisLimiter.Close()
+    }
+    if err := tracker.Close(); err != nil {
+        logger.Error("Failed to close presence tracker", zap.Error(err))
+    }
+    if err := sequencer.Close(); err != nil {
+        logger.Error("Failed to close sequencer", zap.Error(err))
+    }
+    if resumeStore != nil {
+        if err := resumeStore.Close(); err != nil {
+            logger.Error("Failed to close resume store", zap.Error(err))
+        }
+    }
+
+    if broadcastJournal != nil {
+        broadcastJournal.Stop()
+    }
+    if analyticsBatcher != nil {
+        analyticsBatcher.Stop()
+    }
+
+    if err := jobQueue.Stop(ctx); err != nil {
+        logger.Error("Background jobs did not drain", zap.Error(err))
+    }
+
+    if err := shutdownTracing(ctx); err != nil {
+        logger.Error("Failed to flush traces", zap.Error(err))
+    }
 
     logger.Info("Server stopped gracefully")
 }
\ No newline at end of file
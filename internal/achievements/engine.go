@@ -0,0 +1,145 @@
+package achievements
+
+import (
+    "context"
+    "encoding/json"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/events"
+    "github.com/yourusername/sports-chat/internal/metrics"
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/store"
+)
+
+const messageXP = 1
+
+// Notifier delivers frames to every connection of a user. The websocket
+// hub implements it.
+type Notifier interface {
+    SendToUser(userID string, message *models.WSMessage)
+}
+
+type Engine struct {
+    store    store.Store
+    notifier Notifier
+    metrics  *metrics.Metrics
+    logger   *zap.Logger
+    events   chan events.Event
+}
+
+func NewEngine(store store.Store, notifier Notifier, metrics *metrics.Metrics, logger *zap.Logger) *Engine {
+    return &Engine{
+        store:    store,
+        notifier: notifier,
+        metrics:  metrics,
+        logger:   logger,
+        events:   make(chan events.Event, 1024),
+    }
+}
+
+// Start subscribes the engine to the domain events its rules consume and
+// starts the evaluation worker.
+func (e *Engine) Start(bus *events.Bus) {
+    for _, eventType := range []string{
+        events.TypeMessageSent,
+        events.TypeMatchFinished,
+        events.TypePredictionScored,
+    } {
+        bus.Subscribe(eventType, e.enqueue)
+    }
+
+    go e.run()
+}
+
+func (e *Engine) enqueue(event events.Event) {
+    select {
+    case e.events <- event:
+    default:
+        e.logger.Warn("Achievement queue full, dropping event",
+            zap.String("event", event.Type()))
+    }
+}
+
+func (e *Engine) run() {
+    for event := range e.events {
+        e.evaluate(event)
+    }
+}
+
+func (e *Engine) evaluate(event events.Event) {
+    ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+    defer cancel()
+
+    for _, userID := range subjects(event) {
+        var progress *models.UserProgress
+        if sent, ok := event.(events.MessageSent); ok {
+            var err error
+            progress, err = e.store.RecordUserActivity(ctx, userID, sent.At, messageXP)
+            if err != nil {
+                e.logger.Error("Failed to record user activity",
+                    zap.Error(err),
+                    zap.String("user_id", userID))
+                continue
+            }
+        }
+
+        for _, rule := range rules {
+            if rule.EventType != event.Type() || !rule.Match(event, progress) {
+                continue
+            }
+            e.unlock(ctx, userID, rule.Achievement)
+        }
+    }
+}
+
+func subjects(event events.Event) []string {
+    switch e := event.(type) {
+    case events.MessageSent:
+        return []string{e.UserID}
+    case events.PredictionScored:
+        return []string{e.UserID}
+    case events.MatchFinished:
+        return e.UserIDs
+    }
+    return nil
+}
+
+func (e *Engine) unlock(ctx context.Context, userID string, achievement models.Achievement) {
+    unlocked := &models.UserAchievement{
+        UserID:     userID,
+        Code:       achievement.Code,
+        UnlockedAt: time.Now(),
+    }
+
+    awarded, err := e.store.AwardAchievement(ctx, unlocked)
+    if err != nil {
+        e.logger.Error("Failed to award achievement",
+            zap.Error(err),
+            zap.String("user_id", userID),
+            zap.String("achievement", achievement.Code))
+        return
+    }
+    if !awarded {
+        return
+    }
+
+    if _, err := e.store.RecordUserActivity(ctx, userID, unlocked.UnlockedAt, achievement.XP); err != nil {
+        e.logger.Error("Failed to add achievement XP",
+            zap.Error(err),
+            zap.String("user_id", userID))
+    }
+
+    e.metrics.AchievementsUnlocked.WithLabelValues(achievement.Code).Inc()
+
+    data, err := json.Marshal(achievement)
+    if err != nil {
+        return
+    }
+    e.notifier.SendToUser(userID, &models.WSMessage{
+        Type:      models.MessageTypeAchievement,
+        Data:      data,
+        Timestamp: unlocked.UnlockedAt,
+    })
+}
@@ -0,0 +1,94 @@
+package achievements
+
+import (
+    "github.com/yourusername/sports-chat/internal/events"
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+// Achievement codes
+const (
+    CodeFirstMessage          = "first_message"
+    CodeWeekStreak            = "streak_7"
+    CodePerfectPredictionWeek = "perfect_prediction_week"
+    CodeDerbySurvivor         = "derby_survivor"
+)
+
+const minPredictionsForPerfectWeek = 5
+
+// Rule unlocks an achievement when Match returns true for an event and the
+// subject user's progress after that event has been applied.
+type Rule struct {
+    Achievement models.Achievement
+    EventType   string
+    Match       func(event events.Event, progress *models.UserProgress) bool
+}
+
+var rules = []Rule{
+    {
+        Achievement: models.Achievement{
+            Code:        CodeFirstMessage,
+            Name:        "First Words",
+            Description: "Sent your first chat message",
+            XP:          10,
+        },
+        EventType: events.TypeMessageSent,
+        Match: func(events.Event, *models.UserProgress) bool {
+            return true
+        },
+    },
+    {
+        Achievement: models.Achievement{
+            Code:        CodeWeekStreak,
+            Name:        "Ever Present",
+            Description: "Chatted on 7 consecutive days",
+            XP:          50,
+        },
+        EventType: events.TypeMessageSent,
+        Match: func(_ events.Event, progress *models.UserProgress) bool {
+            return progress != nil && progress.CurrentStreak >= 7
+        },
+    },
+    {
+        Achievement: models.Achievement{
+            Code:        CodePerfectPredictionWeek,
+            Name:        "Crystal Ball",
+            Description: "Every prediction correct over a week",
+            XP:          100,
+        },
+        EventType: events.TypePredictionScored,
+        Match: func(event events.Event, _ *models.UserProgress) bool {
+            e := event.(events.PredictionScored)
+            return e.WeekTotal >= minPredictionsForPerfectWeek && e.WeekCorrect == e.WeekTotal
+        },
+    },
+    {
+        Achievement: models.Achievement{
+            Code:        CodeDerbySurvivor,
+            Name:        "Derby Survivor",
+            Description: "Stayed in the room until the final whistle of a derby",
+            XP:          25,
+        },
+        EventType: events.TypeMatchFinished,
+        Match: func(event events.Event, _ *models.UserProgress) bool {
+            return event.(events.MatchFinished).Derby
+        },
+    },
+}
+
+// Definitions returns the catalog of achievements for profile display.
+func Definitions() []models.Achievement {
+    defs := make([]models.Achievement, 0, len(rules))
+    for _, rule := range rules {
+        defs = append(defs, rule.Achievement)
+    }
+    return defs
+}
+
+func Lookup(code string) (models.Achievement, bool) {
+    for _, rule := range rules {
+        if rule.Achievement.Code == code {
+            return rule.Achievement, true
+        }
+    }
+    return models.Achievement{}, false
+}
@@ -0,0 +1,87 @@
+package alerts
+
+// automaton is a byte-level Aho-Corasick automaton over lowercased
+// keywords. It reports every keyword occurrence in a single pass over the
+// text, independent of how many keywords are registered.
+type automaton struct {
+    nodes []node
+}
+
+type node struct {
+    next   map[byte]int
+    fail   int
+    output []int // indexes into the keyword list ending at this node
+}
+
+func newAutomaton(keywords []string) *automaton {
+    a := &automaton{nodes: []node{{next: make(map[byte]int)}}}
+
+    for i, keyword := range keywords {
+        cur := 0
+        for j := 0; j < len(keyword); j++ {
+            c := keyword[j]
+            nxt, ok := a.nodes[cur].next[c]
+            if !ok {
+                a.nodes = append(a.nodes, node{next: make(map[byte]int)})
+                nxt = len(a.nodes) - 1
+                a.nodes[cur].next[c] = nxt
+            }
+            cur = nxt
+        }
+        a.nodes[cur].output = append(a.nodes[cur].output, i)
+    }
+
+    // Breadth-first construction of failure links
+    queue := make([]int, 0, len(a.nodes))
+    for _, child := range a.nodes[0].next {
+        queue = append(queue, child)
+    }
+    for len(queue) > 0 {
+        cur := queue[0]
+        queue = queue[1:]
+
+        for c, child := range a.nodes[cur].next {
+            queue = append(queue, child)
+
+            fail := a.nodes[cur].fail
+            for fail != 0 {
+                if _, ok := a.nodes[fail].next[c]; ok {
+                    break
+                }
+                fail = a.nodes[fail].fail
+            }
+            if target, ok := a.nodes[fail].next[c]; ok && target != child {
+                a.nodes[child].fail = target
+            }
+            a.nodes[child].output = append(a.nodes[child].output, a.nodes[a.nodes[child].fail].output...)
+        }
+    }
+
+    return a
+}
+
+type match struct {
+    keyword int
+    end     int // index one past the last byte of the match
+}
+
+func (a *automaton) find(text string) []match {
+    var matches []match
+    cur := 0
+    for i := 0; i < len(text); i++ {
+        c := text[i]
+        for cur != 0 {
+            if _, ok := a.nodes[cur].next[c]; ok {
+                break
+            }
+            cur = a.nodes[cur].fail
+        }
+        if nxt, ok := a.nodes[cur].next[c]; ok {
+            cur = nxt
+        }
+        for _, kw := range a.nodes[cur].output {
+            matches = append(matches, match{keyword: kw, end: i + 1})
+        }
+    }
+    return matches
+}
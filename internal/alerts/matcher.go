@@ -0,0 +1,150 @@
+package alerts
+
+import (
+    "strings"
+    "sync"
+    "unicode"
+    "unicode/utf8"
+
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+const (
+    MaxAlertsPerUser = 20
+    MinKeywordLength = 2
+    MaxKeywordLength = 50
+)
+
+// Hit is a keyword alert triggered by a message.
+type Hit struct {
+    UserID  string
+    Keyword string
+}
+
+// Matcher holds the alert sets of currently connected users. Only active
+// users are indexed, so the automaton stays small; it is rebuilt lazily
+// on the next match after the active set changes.
+type Matcher struct {
+    mu     sync.Mutex
+    byUser map[string][]*models.KeywordAlert
+
+    dirty     bool
+    automaton *automaton
+    keywords  []string
+    owners    [][]*models.KeywordAlert
+}
+
+func NewMatcher() *Matcher {
+    return &Matcher{byUser: make(map[string][]*models.KeywordAlert)}
+}
+
+// SetUserAlerts replaces the active alert set for a user.
+func (m *Matcher) SetUserAlerts(userID string, alerts []*models.KeywordAlert) {
+    m.mu.Lock()
+    defer m.mu.Unlock()
+
+    if len(alerts) == 0 {
+        if _, ok := m.byUser[userID]; !ok {
+            return
+        }
+        delete(m.byUser, userID)
+    } else {
+        m.byUser[userID] = alerts
+    }
+    m.dirty = true
+}
+
+func (m *Matcher) RemoveUser(userID string) {
+    m.SetUserAlerts(userID, nil)
+}
+
+// Match returns the alerts triggered by content posted in room. Keywords
+// match case-insensitively on word boundaries, so "VAR" does not fire on
+// "various".
+func (m *Matcher) Match(room, content string) []Hit {
+    m.mu.Lock()
+    defer m.mu.Unlock()
+
+    if m.dirty {
+        m.rebuild()
+    }
+    if m.automaton == nil {
+        return nil
+    }
+
+    text := strings.ToLower(content)
+    seen := make(map[Hit]bool)
+    var hits []Hit
+
+    for _, mt := range m.automaton.find(text) {
+        keyword := m.keywords[mt.keyword]
+        if !isWordBoundary(text, mt.end-len(keyword), mt.end) {
+            continue
+        }
+        for _, alert := range m.owners[mt.keyword] {
+            if alert.RoomID != "" && alert.RoomID != room {
+                continue
+            }
+            hit := Hit{UserID: alert.UserID, Keyword: alert.Keyword}
+            if !seen[hit] {
+                seen[hit] = true
+                hits = append(hits, hit)
+            }
+        }
+    }
+
+    return hits
+}
+
+func (m *Matcher) rebuild() {
+    index := make(map[string]int)
+    m.keywords = m.keywords[:0]
+    m.owners = m.owners[:0]
+
+    for _, alerts := range m.byUser {
+        for _, alert := range alerts {
+            keyword := strings.ToLower(alert.Keyword)
+            i, ok := index[keyword]
+            if !ok {
+                i = len(m.keywords)
+                index[keyword] = i
+                m.keywords = append(m.keywords, keyword)
+                m.owners = append(m.owners, nil)
+            }
+            m.owners[i] = append(m.owners[i], alert)
+        }
+    }
+
+    m.automaton = nil
+    if len(m.keywords) > 0 {
+        m.automaton = newAutomaton(m.keywords)
+    }
+    m.dirty = false
+}
+
+func isWordBoundary(text string, start, end int) bool {
+    if start > 0 {
+        r, _ := utf8.DecodeLastRuneInString(text[:start])
+        if isWordRune(r) {
+            return false
+        }
+    }
+    if end < len(text) {
+        r, _ := utf8.DecodeRuneInString(text[end:])
+        if isWordRune(r) {
+            return false
+        }
+    }
+    return true
+}
+
+func isWordRune(r rune) bool {
+    return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
+
+// ValidateKeyword normalizes and checks a keyword submitted by a user.
+func ValidateKeyword(keyword string) (string, bool) {
+    keyword = strings.TrimSpace(keyword)
+    n := utf8.RuneCountInString(keyword)
+    return keyword, n >= MinKeywordLength && n <= MaxKeywordLength
+}
@@ -0,0 +1,168 @@
+// Package analytics sends product analytics events to an external
+// collector, so funnels can be measured without querying the database.
+package analytics
+
+import (
+    "context"
+    "sync"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/metrics"
+)
+
+// Funnel events
+const (
+    EventSignup         = "signup"
+    EventFirstMessage   = "first_message"
+    EventPredictionMade = "prediction_made"
+    EventRoomJoined     = "room_joined"
+)
+
+const (
+    defaultBatchSize     = 100
+    defaultFlushInterval = 10 * time.Second
+)
+
+type Event struct {
+    Name       string
+    UserID     string
+    Properties map[string]interface{}
+    Timestamp  time.Time
+}
+
+// Tracker records analytics events. Track must not block; callers include
+// the websocket hub loop.
+type Tracker interface {
+    Track(event Event)
+}
+
+// Exporter sends a batch of events to a collector.
+type Exporter interface {
+    Export(ctx context.Context, events []Event) error
+}
+
+// Nop discards events, for code that needs a Tracker when analytics is
+// disabled.
+type Nop struct{}
+
+func (Nop) Track(Event) {}
+
+type BatcherOptions struct {
+    BufferSize    int
+    BatchSize     int
+    FlushInterval time.Duration
+}
+
+// Batcher is a Tracker that buffers events and exports them in batches
+// from its own goroutine. When the buffer is full, events are dropped and
+// counted rather than slowing the caller down.
+type Batcher struct {
+    exporter      Exporter
+    batchSize     int
+    flushInterval time.Duration
+    metrics       *metrics.Metrics
+    logger        *zap.Logger
+
+    events chan Event
+    done   chan struct{}
+    mu     sync.RWMutex
+    closed bool
+}
+
+func NewBatcher(exporter Exporter, opts BatcherOptions, metrics *metrics.Metrics, logger *zap.Logger) *Batcher {
+    if opts.BatchSize <= 0 {
+        opts.BatchSize = defaultBatchSize
+    }
+    if opts.FlushInterval <= 0 {
+        opts.FlushInterval = defaultFlushInterval
+    }
+    return &Batcher{
+        exporter:      exporter,
+        batchSize:     opts.BatchSize,
+        flushInterval: opts.FlushInterval,
+        metrics:       metrics,
+        logger:        logger,
+        events:        make(chan Event, opts.BufferSize),
+        done:          make(chan struct{}),
+    }
+}
+
+func (b *Batcher) Start() {
+    go b.run()
+}
+
+// Stop exports buffered events and stops the exporter goroutine.
+func (b *Batcher) Stop() {
+    b.mu.Lock()
+    if !b.closed {
+        b.closed = true
+        close(b.events)
+    }
+    b.mu.Unlock()
+    <-b.done
+}
+
+func (b *Batcher) Track(event Event) {
+    b.mu.RLock()
+    defer b.mu.RUnlock()
+    if b.closed {
+        return
+    }
+
+    if event.Timestamp.IsZero() {
+        event.Timestamp = time.Now()
+    }
+
+    select {
+    case b.events <- event:
+    default:
+        b.metrics.AnalyticsDropped.Inc()
+    }
+}
+
+func (b *Batcher) run() {
+    defer close(b.done)
+
+    ticker := time.NewTicker(b.flushInterval)
+    defer ticker.Stop()
+
+    batch := make([]Event, 0, b.batchSize)
+    for {
+        select {
+        case event, ok := <-b.events:
+            if !ok {
+                b.flush(batch)
+                return
+            }
+            batch = append(batch, event)
+            if len(batch) >= b.batchSize {
+                b.flush(batch)
+                batch = batch[:0]
+            }
+
+        case <-ticker.C:
+            if len(batch) > 0 {
+                b.flush(batch)
+                batch = batch[:0]
+            }
+        }
+    }
+}
+
+func (b *Batcher) flush(batch []Event) {
+    if len(batch) == 0 {
+        return
+    }
+
+    ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+    defer cancel()
+
+    if err := b.exporter.Export(ctx, batch); err != nil {
+        b.metrics.AnalyticsDropped.Add(float64(len(batch)))
+        b.logger.Error("Failed to export analytics events",
+            zap.Error(err),
+            zap.Int("events", len(batch)))
+    }
+}
@@ -0,0 +1,47 @@
+package analytics
+
+import (
+    "github.com/yourusername/sports-chat/internal/events"
+)
+
+// Subscribe tracks the funnel steps from the domain events that mark
+// them. Bus handlers run on the publisher's goroutine, which Track never
+// blocks.
+func Subscribe(bus *events.Bus, tracker Tracker) {
+    bus.Subscribe(events.TypeUserSignedUp, func(event events.Event) {
+        e := event.(events.UserSignedUp)
+        tracker.Track(Event{
+            Name:       EventSignup,
+            UserID:     e.UserID,
+            Properties: map[string]interface{}{"source": e.Source},
+            Timestamp:  e.At,
+        })
+    })
+    bus.Subscribe(events.TypeFirstMessage, func(event events.Event) {
+        e := event.(events.FirstMessage)
+        tracker.Track(Event{
+            Name:       EventFirstMessage,
+            UserID:     e.UserID,
+            Properties: map[string]interface{}{"room_id": e.RoomID},
+            Timestamp:  e.At,
+        })
+    })
+    bus.Subscribe(events.TypePredictionMade, func(event events.Event) {
+        e := event.(events.PredictionMade)
+        tracker.Track(Event{
+            Name:       EventPredictionMade,
+            UserID:     e.UserID,
+            Properties: map[string]interface{}{"match_id": e.MatchID},
+            Timestamp:  e.At,
+        })
+    })
+    bus.Subscribe(events.TypeRoomJoined, func(event events.Event) {
+        e := event.(events.RoomJoined)
+        tracker.Track(Event{
+            Name:       EventRoomJoined,
+            UserID:     e.UserID,
+            Properties: map[string]interface{}{"room_id": e.RoomID},
+            Timestamp:  e.At,
+        })
+    })
+}
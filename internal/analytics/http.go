@@ -0,0 +1,83 @@
+package analytics
+
+import (
+    "bytes"
+    "context"
+    "encoding/json"
+    "fmt"
+    "io"
+    "net/http"
+    "time"
+
+    "github.com/google/uuid"
+)
+
+// SegmentBatchURL is Segment's batch endpoint. Any collector accepting the
+// same payload, such as RudderStack or Jitsu, works as well.
+const SegmentBatchURL = "https://api.segment.io/v1/batch"
+
+// HTTPExporter posts batches in Segment's batch format, authenticating
+// with the write key as the basic auth username.
+type HTTPExporter struct {
+    url      string
+    writeKey string
+    http     *http.Client
+}
+
+func NewHTTPExporter(url, writeKey string) *HTTPExporter {
+    return &HTTPExporter{
+        url:      url,
+        writeKey: writeKey,
+        http:     &http.Client{Timeout: 10 * time.Second},
+    }
+}
+
+type segmentTrack struct {
+    Type       string                 `json:"type"`
+    Event      string                 `json:"event"`
+    UserID     string                 `json:"userId"`
+    MessageID  string                 `json:"messageId"`
+    Properties map[string]interface{} `json:"properties,omitempty"`
+    Timestamp  time.Time              `json:"timestamp"`
+}
+
+func (e *HTTPExporter) Export(ctx context.Context, events []Event) error {
+    batch := make([]segmentTrack, 0, len(events))
+    for _, event := range events {
+        batch = append(batch, segmentTrack{
+            Type:       "track",
+            Event:      event.Name,
+            UserID:     event.UserID,
+            MessageID:  uuid.NewString(),
+            Properties: event.Properties,
+            Timestamp:  event.Timestamp,
+        })
+    }
+
+    body, err := json.Marshal(map[string]interface{}{
+        "batch":  batch,
+        "sentAt": time.Now(),
+    })
+    if err != nil {
+        return fmt.Errorf("failed to marshal analytics batch: %w", err)
+    }
+
+    req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(body))
+    if err != nil {
+        return err
+    }
+    req.Header.Set("Content-Type", "application/json")
+    req.SetBasicAuth(e.writeKey, "")
+
+    resp, err := e.http.Do(req)
+    if err != nil {
+        return fmt.Errorf("analytics request failed: %w", err)
+    }
+    defer resp.Body.Close()
+    io.Copy(io.Discard, resp.Body)
+
+    if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+        return fmt.Errorf("analytics collector returned %d", resp.StatusCode)
+    }
+    return nil
+}
@@ -0,0 +1,67 @@
+package api
+
+import (
+    "net/http"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/achievements"
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+type unlockedAchievement struct {
+    models.Achievement
+    UnlockedAt time.Time `json:"unlocked_at"`
+}
+
+type achievementsResponse struct {
+    Level         int                   `json:"level"`
+    XP            int                   `json:"xp"`
+    CurrentStreak int                   `json:"current_streak"`
+    LongestStreak int                   `json:"longest_streak"`
+    Unlocked      []unlockedAchievement `json:"unlocked"`
+    Available     []models.Achievement  `json:"available"`
+}
+
+func (h *Handler) getUserAchievements(w http.ResponseWriter, r *http.Request) {
+    userID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+
+    progress, err := h.store.GetUserProgress(r.Context(), userID)
+    if err != nil {
+        h.logger.Error("Failed to get user progress", zap.Error(err), zap.String("user_id", userID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to load achievements")
+        return
+    }
+
+    unlocked, err := h.store.GetUserAchievements(r.Context(), userID)
+    if err != nil {
+        h.logger.Error("Failed to get user achievements", zap.Error(err), zap.String("user_id", userID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to load achievements")
+        return
+    }
+
+    resp := achievementsResponse{
+        Level:         progress.Level(),
+        XP:            progress.XP,
+        CurrentStreak: progress.CurrentStreak,
+        LongestStreak: progress.LongestStreak,
+        Unlocked:      make([]unlockedAchievement, 0, len(unlocked)),
+        Available:     achievements.Definitions(),
+    }
+    for _, ua := range unlocked {
+        def, ok := achievements.Lookup(ua.Code)
+        if !ok {
+            continue
+        }
+        resp.Unlocked = append(resp.Unlocked, unlockedAchievement{
+            Achievement: def,
+            UnlockedAt:  ua.UnlockedAt,
+        })
+    }
+
+    h.respondJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,304 @@
+package api
+
+import (
+    "fmt"
+    "net/http"
+    "strconv"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/authctx"
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/websocket"
+)
+
+func (h *Handler) listReconciliationReports(w http.ResponseWriter, r *http.Request) {
+    limit := 20
+    if v, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && v > 0 && v <= 100 {
+        limit = v
+    }
+
+    reports, err := h.store.ListReconciliationReports(r.Context(), limit)
+    if err != nil {
+        h.logger.Error("Failed to list reconciliation reports", zap.Error(err))
+        h.respondError(w, http.StatusInternalServerError, "Failed to load reports")
+        return
+    }
+
+    h.respondJSON(w, http.StatusOK, reports)
+}
+
+type mergeRoomRequest struct {
+    Into string `json:"into"`
+}
+
+func (h *Handler) mergeRoom(w http.ResponseWriter, r *http.Request) {
+    sourceID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+
+    var req mergeRoomRequest
+    if err := h.decodeJSON(r, &req); err != nil || req.Into == "" {
+        h.respondError(w, http.StatusBadRequest, "Target room is required")
+        return
+    }
+    if req.Into == sourceID {
+        h.respondError(w, http.StatusBadRequest, "Cannot merge a room into itself")
+        return
+    }
+
+    for _, id := range []string{sourceID, req.Into} {
+        if _, err := h.store.GetChatRoom(r.Context(), id); err != nil {
+            h.respondError(w, http.StatusNotFound, "Room not found")
+            return
+        }
+    }
+
+    if err := h.store.MergeChatRooms(r.Context(), sourceID, req.Into); err != nil {
+        h.logger.Error("Failed to merge rooms",
+            zap.Error(err),
+            zap.String("source", sourceID),
+            zap.String("target", req.Into))
+        h.respondError(w, http.StatusInternalServerError, "Failed to merge rooms")
+        return
+    }
+
+    h.hub.RedirectRoom(sourceID, []string{req.Into}, "merged")
+
+    w.WriteHeader(http.StatusNoContent)
+}
+
+// maxDeleteReason bounds the reason shown to a deleted room's clients.
+const maxDeleteReason = 500
+
+type deleteRoomRequest struct {
+    // ReplacementID is the room clients are pointed to instead, if any
+    ReplacementID string `json:"replacement_id"`
+    Reason        string `json:"reason"`
+}
+
+// deleteRoom is the first phase of deleting a room: it is deactivated
+// and leaves a tombstone, and its clients are sent a room_deleted frame
+// pointing to the replacement. The room and its messages are purged
+// once the grace period has passed; until then restoreRoom undoes it.
+func (h *Handler) deleteRoom(w http.ResponseWriter, r *http.Request) {
+    principal, _ := authctx.UserFrom(r.Context())
+    roomID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+
+    var req deleteRoomRequest
+    if r.ContentLength != 0 {
+        if err := h.decodeJSON(r, &req); err != nil {
+            h.respondError(w, http.StatusBadRequest, "Invalid request body")
+            return
+        }
+    }
+    if len(req.Reason) > maxDeleteReason {
+        h.respondError(w, http.StatusBadRequest, "Reason is too long")
+        return
+    }
+
+    room, err := h.store.GetChatRoom(r.Context(), roomID)
+    if err != nil {
+        h.respondRoomMissing(w, r, roomID)
+        return
+    }
+    if tombstone, err := h.store.GetRoomTombstone(r.Context(), roomID); err == nil && tombstone.PurgedAt == nil {
+        h.respondError(w, http.StatusConflict, "Room is already deleted")
+        return
+    }
+    if req.ReplacementID != "" {
+        if req.ReplacementID == roomID {
+            h.respondError(w, http.StatusBadRequest, "A room cannot replace itself")
+            return
+        }
+        replacement, err := h.store.GetChatRoom(r.Context(), req.ReplacementID)
+        if err != nil || !replacement.IsActive {
+            h.respondError(w, http.StatusBadRequest, "Replacement room not found")
+            return
+        }
+    }
+
+    tombstone := &models.RoomTombstone{
+        RoomID:        room.ID,
+        Name:          room.Name,
+        MatchID:       room.MatchID,
+        ReplacementID: req.ReplacementID,
+        Reason:        req.Reason,
+        DeletedBy:     principal.UserID,
+    }
+    if err := h.store.TombstoneChatRoom(r.Context(), tombstone); err != nil {
+        h.logger.Error("Failed to delete room", zap.Error(err), zap.String("room", roomID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to delete room")
+        return
+    }
+
+    h.hub.RoomDeleted(tombstone)
+
+    h.logger.Info("Room deleted",
+        zap.String("room", roomID),
+        zap.String("replacement", req.ReplacementID),
+        zap.String("admin_id", principal.UserID))
+    h.respondJSON(w, http.StatusOK, tombstone)
+}
+
+// restoreRoom undoes a room's deletion before it is purged. Its clients
+// were removed from it and subscribe again themselves.
+func (h *Handler) restoreRoom(w http.ResponseWriter, r *http.Request) {
+    principal, _ := authctx.UserFrom(r.Context())
+    roomID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+
+    restored, err := h.store.RestoreChatRoom(r.Context(), roomID)
+    if err != nil {
+        h.logger.Error("Failed to restore room", zap.Error(err), zap.String("room", roomID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to restore room")
+        return
+    }
+    if !restored {
+        h.respondError(w, http.StatusNotFound, "No deleted room to restore")
+        return
+    }
+
+    room, err := h.store.GetChatRoom(r.Context(), roomID)
+    if err == nil {
+        // Drops every instance's cached copy, so the room admits clients
+        // again at once
+        h.hub.RoomStateChanged(&models.RoomStateChange{
+            RoomID:        room.ID,
+            State:         room.State,
+            ChangedAt:     time.Now(),
+            BroadcastOnly: room.BroadcastOnly,
+        })
+    }
+
+    h.logger.Info("Room restored", zap.String("room", roomID), zap.String("admin_id", principal.UserID))
+    w.WriteHeader(http.StatusNoContent)
+}
+
+type splitRoomRequest struct {
+    Shards int `json:"shards"`
+}
+
+const maxRoomShards = 16
+
+func (h *Handler) splitRoom(w http.ResponseWriter, r *http.Request) {
+    ctx := r.Context()
+    roomID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+
+    var req splitRoomRequest
+    if err := h.decodeJSON(r, &req); err != nil || req.Shards < 2 || req.Shards > maxRoomShards {
+        h.respondError(w, http.StatusBadRequest, "Shards must be between 2 and 16")
+        return
+    }
+
+    room, err := h.store.GetChatRoom(ctx, roomID)
+    if err != nil {
+        h.respondError(w, http.StatusNotFound, "Room not found")
+        return
+    }
+
+    shards := make([]*models.ChatRoom, 0, req.Shards)
+    shardIDs := make([]string, 0, req.Shards)
+    for i := 0; i < req.Shards; i++ {
+        shard := &models.ChatRoom{
+            MatchID:     room.MatchID,
+            ParentID:    room.ID,
+            Name:        fmt.Sprintf("%s (%d/%d)", room.Name, i+1, req.Shards),
+            Description: room.Description,
+            IsActive:    true,
+        }
+        if err := h.store.CreateChatRoom(ctx, shard); err != nil {
+            h.logger.Error("Failed to create room shard", zap.Error(err), zap.String("room_id", roomID))
+            h.respondError(w, http.StatusInternalServerError, "Failed to split room")
+            return
+        }
+        shards = append(shards, shard)
+        shardIDs = append(shardIDs, shard.ID)
+    }
+
+    members, err := h.store.GetRoomUsers(ctx, roomID)
+    if err != nil {
+        h.logger.Error("Failed to list room members", zap.Error(err), zap.String("room_id", roomID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to split room")
+        return
+    }
+
+    assigned := make([][]string, req.Shards)
+    for _, member := range members {
+        i := websocket.ShardFor(member.ID, req.Shards)
+        assigned[i] = append(assigned[i], member.ID)
+    }
+    for i, userIDs := range assigned {
+        if len(userIDs) == 0 {
+            continue
+        }
+        if err := h.store.MoveRoomMembers(ctx, roomID, shardIDs[i], userIDs); err != nil {
+            h.logger.Error("Failed to move room members", zap.Error(err), zap.String("room_id", roomID))
+            h.respondError(w, http.StatusInternalServerError, "Failed to split room")
+            return
+        }
+    }
+
+    room.IsActive = false
+    if err := h.store.UpdateChatRoom(ctx, room); err != nil {
+        h.logger.Error("Failed to deactivate split room", zap.Error(err), zap.String("room_id", roomID))
+    }
+
+    h.hub.RedirectRoom(roomID, shardIDs, "split")
+
+    h.respondJSON(w, http.StatusCreated, shards)
+}
+
+// getRoomJournal shows the frames clients in a room actually received in a
+// time window, e.g. ?from=2024-05-01T21:02:00Z&to=2024-05-01T21:04:00Z.
+func (h *Handler) getRoomJournal(w http.ResponseWriter, r *http.Request) {
+    q := r.URL.Query()
+
+    to := time.Now()
+    if v := q.Get("to"); v != "" {
+        t, err := time.Parse(time.RFC3339, v)
+        if err != nil {
+            h.respondError(w, http.StatusBadRequest, "Invalid to timestamp")
+            return
+        }
+        to = t
+    }
+
+    from := to.Add(-5 * time.Minute)
+    if v := q.Get("from"); v != "" {
+        t, err := time.Parse(time.RFC3339, v)
+        if err != nil {
+            h.respondError(w, http.StatusBadRequest, "Invalid from timestamp")
+            return
+        }
+        from = t
+    }
+
+    limit := 500
+    if v, err := strconv.Atoi(q.Get("limit")); err == nil && v > 0 && v <= 5000 {
+        limit = v
+    }
+
+    roomID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+    entries, err := h.store.GetJournalEntries(r.Context(), roomID, from, to, limit)
+    if err != nil {
+        h.logger.Error("Failed to read journal", zap.Error(err), zap.String("room_id", roomID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to read journal")
+        return
+    }
+
+    h.respondJSON(w, http.StatusOK, entries)
+}
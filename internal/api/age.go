@@ -0,0 +1,192 @@
+package api
+
+import (
+    "net/http"
+    "strconv"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/authctx"
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+const (
+    // maxAge bounds declared ages, catching typos in the year
+    maxAge = 120
+    // maxRoomMinAge bounds a room's age gate
+    maxRoomMinAge = 21
+)
+
+type birthDateRequest struct {
+    BirthDate string `json:"birth_date"`
+}
+
+// parseBirthDate reads a YYYY-MM-DD birth date that is plausibly a
+// person's.
+func parseBirthDate(value string, now time.Time) (time.Time, bool) {
+    date, err := time.Parse(time.DateOnly, value)
+    if err != nil || date.After(now) {
+        return time.Time{}, false
+    }
+    age, _ := (&models.User{BirthDate: &date}).Age(now)
+    return date, age <= maxAge
+}
+
+// declareBirthDate records the caller's own birth date. It may be
+// declared once; after that only an admin verifying it can change it, so
+// a minor cannot age their way out of restricted mode.
+func (h *Handler) declareBirthDate(w http.ResponseWriter, r *http.Request) {
+    principal, _ := authctx.UserFrom(r.Context())
+
+    var req birthDateRequest
+    if err := h.decodeJSON(r, &req); err != nil {
+        h.respondError(w, http.StatusBadRequest, "Invalid request body")
+        return
+    }
+    birthDate, ok := parseBirthDate(req.BirthDate, time.Now())
+    if !ok {
+        h.respondError(w, http.StatusBadRequest, "Birth date must be a past YYYY-MM-DD date")
+        return
+    }
+
+    user, err := h.store.GetUser(r.Context(), principal.UserID)
+    if err != nil {
+        h.respondError(w, http.StatusNotFound, "User not found")
+        return
+    }
+    if user.BirthDate != nil {
+        h.respondError(w, http.StatusConflict, "Birth date is already set")
+        return
+    }
+
+    user.BirthDate = &birthDate
+    if err := h.store.UpdateUser(r.Context(), user); err != nil {
+        h.logger.Error("Failed to set birth date", zap.Error(err), zap.String("user_id", user.ID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to set birth date")
+        return
+    }
+    h.hub.UserAgeChanged(user.ID)
+
+    h.respondJSON(w, http.StatusOK, user)
+}
+
+type restrictedModeRequest struct {
+    Enabled bool `json:"enabled"`
+}
+
+// setRestrictedMode turns the caller's restricted mode on or off. Minors
+// are restricted whatever they choose.
+func (h *Handler) setRestrictedMode(w http.ResponseWriter, r *http.Request) {
+    principal, _ := authctx.UserFrom(r.Context())
+
+    var req restrictedModeRequest
+    if err := h.decodeJSON(r, &req); err != nil {
+        h.respondError(w, http.StatusBadRequest, "Invalid request body")
+        return
+    }
+
+    user, err := h.store.GetUser(r.Context(), principal.UserID)
+    if err != nil {
+        h.respondError(w, http.StatusNotFound, "User not found")
+        return
+    }
+    if age, ok := user.Age(time.Now()); !req.Enabled && ok && age < models.AdultAge {
+        h.respondError(w, http.StatusForbidden, "Restricted mode cannot be turned off for minors")
+        return
+    }
+    if user.RestrictedMode == req.Enabled {
+        h.respondJSON(w, http.StatusOK, user)
+        return
+    }
+
+    user.RestrictedMode = req.Enabled
+    if err := h.store.UpdateUser(r.Context(), user); err != nil {
+        h.logger.Error("Failed to set restricted mode", zap.Error(err), zap.String("user_id", user.ID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to set restricted mode")
+        return
+    }
+    h.hub.UserAgeChanged(user.ID)
+
+    h.respondJSON(w, http.StatusOK, user)
+}
+
+// verifyBirthDate sets a user's birth date as verified by an admin,
+// replacing any they declared.
+func (h *Handler) verifyBirthDate(w http.ResponseWriter, r *http.Request) {
+    principal, _ := authctx.UserFrom(r.Context())
+    userID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+
+    var req birthDateRequest
+    if err := h.decodeJSON(r, &req); err != nil {
+        h.respondError(w, http.StatusBadRequest, "Invalid request body")
+        return
+    }
+    now := time.Now()
+    birthDate, ok := parseBirthDate(req.BirthDate, now)
+    if !ok {
+        h.respondError(w, http.StatusBadRequest, "Birth date must be a past YYYY-MM-DD date")
+        return
+    }
+
+    user, err := h.store.GetUser(r.Context(), userID)
+    if err != nil {
+        h.respondError(w, http.StatusNotFound, "User not found")
+        return
+    }
+
+    user.BirthDate = &birthDate
+    user.AgeVerifiedAt = &now
+    if err := h.store.UpdateUser(r.Context(), user); err != nil {
+        h.logger.Error("Failed to verify birth date", zap.Error(err), zap.String("user_id", user.ID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to verify birth date")
+        return
+    }
+    h.hub.UserAgeChanged(user.ID)
+
+    h.logger.Info("Verified user age",
+        zap.String("user_id", user.ID),
+        zap.String("admin_id", principal.UserID))
+    h.respondJSON(w, http.StatusOK, user)
+}
+
+type ageGateRequest struct {
+    MinAge      int  `json:"min_age"`
+    AgeVerified bool `json:"age_verified"`
+}
+
+// setRoomAgeGate gates a room to users of at least min_age, optionally
+// verified; zero removes the gate. Members who no longer qualify are
+// removed from the room.
+func (h *Handler) setRoomAgeGate(w http.ResponseWriter, r *http.Request) {
+    roomID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+
+    var req ageGateRequest
+    if err := h.decodeJSON(r, &req); err != nil || req.MinAge < 0 || req.MinAge > maxRoomMinAge {
+        h.respondError(w, http.StatusBadRequest, "Minimum age must be between 0 and "+strconv.Itoa(maxRoomMinAge))
+        return
+    }
+
+    room, err := h.store.GetChatRoom(r.Context(), roomID)
+    if err != nil {
+        h.respondError(w, http.StatusNotFound, "Room not found")
+        return
+    }
+
+    room.MinAge = req.MinAge
+    room.AgeVerified = req.MinAge > 0 && req.AgeVerified
+    if err := h.store.UpdateChatRoom(r.Context(), room); err != nil {
+        h.logger.Error("Failed to update room", zap.Error(err), zap.String("room", roomID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to update room")
+        return
+    }
+    h.hub.AgeGateChanged(room)
+
+    h.respondJSON(w, http.StatusOK, room)
+}
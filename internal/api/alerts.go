@@ -0,0 +1,91 @@
+package api
+
+import (
+    "net/http"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/alerts"
+    "github.com/yourusername/sports-chat/internal/authctx"
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+func (h *Handler) listKeywordAlerts(w http.ResponseWriter, r *http.Request) {
+    principal, _ := authctx.UserFrom(r.Context())
+
+    userAlerts, err := h.store.GetUserKeywordAlerts(r.Context(), principal.UserID)
+    if err != nil {
+        h.logger.Error("Failed to list keyword alerts", zap.Error(err), zap.String("user_id", principal.UserID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to load alerts")
+        return
+    }
+
+    h.respondJSON(w, http.StatusOK, userAlerts)
+}
+
+type createKeywordAlertRequest struct {
+    Keyword string `json:"keyword"`
+    RoomID  string `json:"room_id"`
+}
+
+func (h *Handler) createKeywordAlert(w http.ResponseWriter, r *http.Request) {
+    principal, _ := authctx.UserFrom(r.Context())
+
+    var req createKeywordAlertRequest
+    if err := h.decodeJSON(r, &req); err != nil {
+        h.respondError(w, http.StatusBadRequest, "Invalid request body")
+        return
+    }
+
+    keyword, ok := alerts.ValidateKeyword(req.Keyword)
+    if !ok {
+        h.respondError(w, http.StatusBadRequest, "Keyword must be between 2 and 50 characters")
+        return
+    }
+
+    existing, err := h.store.GetUserKeywordAlerts(r.Context(), principal.UserID)
+    if err != nil {
+        h.logger.Error("Failed to list keyword alerts", zap.Error(err), zap.String("user_id", principal.UserID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to create alert")
+        return
+    }
+    if len(existing) >= alerts.MaxAlertsPerUser {
+        h.respondError(w, http.StatusConflict, "Alert limit reached")
+        return
+    }
+
+    alert := &models.KeywordAlert{
+        UserID:    principal.UserID,
+        Keyword:   keyword,
+        RoomID:    req.RoomID,
+        CreatedAt: time.Now(),
+    }
+    if err := h.store.CreateKeywordAlert(r.Context(), alert); err != nil {
+        h.logger.Error("Failed to create keyword alert", zap.Error(err), zap.String("user_id", principal.UserID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to create alert")
+        return
+    }
+
+    go h.hub.ReloadUserAlerts(principal.UserID)
+
+    h.respondJSON(w, http.StatusCreated, alert)
+}
+
+func (h *Handler) deleteKeywordAlert(w http.ResponseWriter, r *http.Request) {
+    principal, _ := authctx.UserFrom(r.Context())
+
+    id, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+    if err := h.store.DeleteKeywordAlert(r.Context(), principal.UserID, id); err != nil {
+        h.logger.Error("Failed to delete keyword alert", zap.Error(err), zap.String("user_id", principal.UserID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to delete alert")
+        return
+    }
+
+    go h.hub.ReloadUserAlerts(principal.UserID)
+
+    w.WriteHeader(http.StatusNoContent)
+}
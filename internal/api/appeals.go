@@ -0,0 +1,188 @@
+package api
+
+import (
+    "errors"
+    "net/http"
+    "strconv"
+    "strings"
+    "unicode/utf8"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/appeals"
+    "github.com/yourusername/sports-chat/internal/authctx"
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+const (
+    maxAppealStatementLength = 2000
+    maxReviewNoteLength      = 2000
+)
+
+// appealRequest names the action appealed: a site-wide ban alone, a room
+// ban or mute with its room, or a removal with the message.
+type appealRequest struct {
+    Action    string `json:"action"`
+    RoomID    string `json:"room_id"`
+    MessageID string `json:"message_id"`
+    Statement string `json:"statement"`
+}
+
+// createAppeal files the caller's appeal of a moderation action against
+// them. Banned users can still reach it, as bans only close chat.
+func (h *Handler) createAppeal(w http.ResponseWriter, r *http.Request) {
+    principal, _ := authctx.UserFrom(r.Context())
+
+    var req appealRequest
+    if err := h.decodeJSON(r, &req); err != nil {
+        h.respondError(w, http.StatusBadRequest, "Invalid request body")
+        return
+    }
+    req.Statement = strings.TrimSpace(req.Statement)
+    if req.Statement == "" || utf8.RuneCountInString(req.Statement) > maxAppealStatementLength {
+        h.respondError(w, http.StatusBadRequest, "Statement must be between 1 and "+strconv.Itoa(maxAppealStatementLength)+" characters")
+        return
+    }
+    switch req.Action {
+    case models.AppealBan:
+        req.RoomID, req.MessageID = "", ""
+    case models.AppealRoomBan, models.AppealRoomMute:
+        if req.RoomID == "" {
+            h.respondError(w, http.StatusBadRequest, "Room is required")
+            return
+        }
+        req.MessageID = ""
+    case models.AppealMessageRemoval:
+        if req.MessageID == "" {
+            h.respondError(w, http.StatusBadRequest, "Message is required")
+            return
+        }
+        req.RoomID = ""
+    default:
+        h.respondError(w, http.StatusBadRequest, "Action must be ban, room_ban, room_mute or message_removal")
+        return
+    }
+
+    appeal := &models.Appeal{
+        UserID:    principal.UserID,
+        Action:    req.Action,
+        RoomID:    req.RoomID,
+        MessageID: req.MessageID,
+        Statement: req.Statement,
+    }
+    err := h.appeals.Submit(r.Context(), appeal)
+    if errors.Is(err, appeals.ErrNoAction) {
+        h.respondError(w, http.StatusNotFound, "No such moderation action to appeal")
+        return
+    }
+    if errors.Is(err, appeals.ErrAlreadyAppealed) {
+        h.respondError(w, http.StatusConflict, "This action has already been appealed")
+        return
+    }
+    if err != nil {
+        h.logger.Error("Failed to create appeal", zap.Error(err), zap.String("user_id", principal.UserID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to create appeal")
+        return
+    }
+
+    h.respondJSON(w, http.StatusCreated, appeal)
+}
+
+func (h *Handler) listMyAppeals(w http.ResponseWriter, r *http.Request) {
+    principal, _ := authctx.UserFrom(r.Context())
+
+    list, err := h.store.GetUserAppeals(r.Context(), principal.UserID)
+    if err != nil {
+        h.logger.Error("Failed to get appeals", zap.Error(err), zap.String("user_id", principal.UserID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to load appeals")
+        return
+    }
+    h.respondJSON(w, http.StatusOK, list)
+}
+
+// listAppeals is the review queue: appeals in a status, pending unless
+// ?status= says otherwise, oldest first.
+func (h *Handler) listAppeals(w http.ResponseWriter, r *http.Request) {
+    status := r.URL.Query().Get("status")
+    if status == "" {
+        status = models.AppealPending
+    }
+    if status != models.AppealPending && status != models.AppealAccepted && status != models.AppealRejected {
+        h.respondError(w, http.StatusBadRequest, "Unknown status")
+        return
+    }
+
+    limit := 50
+    if v, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && v > 0 && v <= 200 {
+        limit = v
+    }
+
+    list, err := h.store.ListAppeals(r.Context(), status, limit)
+    if err != nil {
+        h.logger.Error("Failed to list appeals", zap.Error(err))
+        h.respondError(w, http.StatusInternalServerError, "Failed to load appeals")
+        return
+    }
+    h.respondJSON(w, http.StatusOK, list)
+}
+
+func (h *Handler) getAppeal(w http.ResponseWriter, r *http.Request) {
+    id, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+    appeal, err := h.store.GetAppeal(r.Context(), id)
+    if err != nil {
+        h.respondError(w, http.StatusNotFound, "Appeal not found")
+        return
+    }
+    h.respondJSON(w, http.StatusOK, appeal)
+}
+
+type reviewAppealRequest struct {
+    Verdict string `json:"verdict"`
+    Note    string `json:"note"`
+}
+
+// reviewAppeal accepts or rejects a pending appeal. Accepting lifts the
+// ban or sanction, or restores the message, before the verdict is saved.
+func (h *Handler) reviewAppeal(w http.ResponseWriter, r *http.Request) {
+    principal, _ := authctx.UserFrom(r.Context())
+    id, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+
+    var req reviewAppealRequest
+    if err := h.decodeJSON(r, &req); err != nil ||
+        (req.Verdict != models.AppealAccepted && req.Verdict != models.AppealRejected) {
+        h.respondError(w, http.StatusBadRequest, "Verdict must be accepted or rejected")
+        return
+    }
+    req.Note = strings.TrimSpace(req.Note)
+    if utf8.RuneCountInString(req.Note) > maxReviewNoteLength {
+        h.respondError(w, http.StatusBadRequest, "Note must be at most "+strconv.Itoa(maxReviewNoteLength)+" characters")
+        return
+    }
+
+    appeal, err := h.appeals.Review(r.Context(), id, req.Verdict == models.AppealAccepted, principal.UserID, req.Note)
+    if errors.Is(err, appeals.ErrReviewed) {
+        h.respondError(w, http.StatusConflict, "Appeal already reviewed")
+        return
+    }
+    if err != nil {
+        if _, getErr := h.store.GetAppeal(r.Context(), id); getErr != nil {
+            h.respondError(w, http.StatusNotFound, "Appeal not found")
+            return
+        }
+        h.logger.Error("Failed to review appeal", zap.Error(err), zap.String("appeal_id", id))
+        h.respondError(w, http.StatusInternalServerError, "Failed to review appeal")
+        return
+    }
+
+    h.logger.Info("Appeal reviewed",
+        zap.String("appeal_id", id),
+        zap.String("verdict", req.Verdict),
+        zap.String("actor", authctx.Actor(r.Context())))
+    h.respondJSON(w, http.StatusOK, appeal)
+}
@@ -0,0 +1,126 @@
+package api
+
+import (
+    "errors"
+    "io"
+    "net/http"
+    "strconv"
+    "strings"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/attachments"
+    "github.com/yourusername/sports-chat/internal/authctx"
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+// uploadAttachment takes an image as the raw request body. It is stored
+// pending and answered with 202; the room sees it once it has been
+// scanned clean.
+func (h *Handler) uploadAttachment(w http.ResponseWriter, r *http.Request) {
+    if h.attachments == nil {
+        h.respondError(w, http.StatusNotFound, "Attachments are disabled")
+        return
+    }
+    principal, _ := authctx.UserFrom(r.Context())
+    roomID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+
+    room, err := h.store.GetChatRoom(r.Context(), roomID)
+    if err != nil {
+        h.respondError(w, http.StatusNotFound, "Room not found")
+        return
+    }
+    if !room.AcceptsPosts() && !principal.IsAdmin {
+        h.respondError(w, http.StatusForbidden, "This room is not accepting posts")
+        return
+    }
+
+    data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, int64(h.maxAttachmentSize)))
+    var tooLarge *http.MaxBytesError
+    if errors.As(err, &tooLarge) {
+        h.respondError(w, http.StatusRequestEntityTooLarge, "Attachment is too large")
+        return
+    }
+    if err != nil || len(data) == 0 {
+        h.respondError(w, http.StatusBadRequest, "Invalid attachment")
+        return
+    }
+    // Trust the bytes, not the declared type
+    contentType := http.DetectContentType(data)
+    if !strings.HasPrefix(contentType, "image/") {
+        h.respondError(w, http.StatusUnsupportedMediaType, "Only images can be attached")
+        return
+    }
+
+    attachment := &models.Attachment{
+        ChatRoomID:  roomID,
+        UserID:      principal.UserID,
+        ContentType: contentType,
+    }
+    if err := h.attachments.Upload(r.Context(), attachment, data); err != nil {
+        h.logger.Error("Failed to upload attachment", zap.Error(err), zap.String("room", roomID))
+        h.respondError(w, http.StatusServiceUnavailable, "Failed to upload attachment")
+        return
+    }
+
+    h.respondJSON(w, http.StatusAccepted, attachment)
+}
+
+// getAttachment describes an attachment. Until it is clean only its
+// uploader and admins can see it; to anyone else it does not exist.
+func (h *Handler) getAttachment(w http.ResponseWriter, r *http.Request) {
+    attachment, ok := h.visibleAttachment(w, r)
+    if !ok {
+        return
+    }
+    h.respondJSON(w, http.StatusOK, attachment)
+}
+
+// getAttachmentContent serves an attachment's image. Content that was
+// not scanned clean is never served, not even to its uploader.
+func (h *Handler) getAttachmentContent(w http.ResponseWriter, r *http.Request) {
+    attachment, ok := h.visibleAttachment(w, r)
+    if !ok {
+        return
+    }
+    if attachment.Status != models.AttachmentClean {
+        h.respondError(w, http.StatusConflict, "Attachment has not been cleared")
+        return
+    }
+
+    data, err := h.store.GetAttachmentData(r.Context(), attachment.ID)
+    if err != nil {
+        h.logger.Error("Failed to get attachment data", zap.Error(err), zap.String("attachment_id", attachment.ID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to load attachment")
+        return
+    }
+
+    w.Header().Set("Content-Type", attachment.ContentType)
+    w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+    w.Header().Set("X-Content-Type-Options", "nosniff")
+    w.Header().Set("Cache-Control", "private, max-age=86400, immutable")
+    w.WriteHeader(http.StatusOK)
+    w.Write(data)
+}
+
+func (h *Handler) visibleAttachment(w http.ResponseWriter, r *http.Request) (*models.Attachment, bool) {
+    if h.attachments == nil {
+        h.respondError(w, http.StatusNotFound, "Attachments are disabled")
+        return nil, false
+    }
+    principal, _ := authctx.UserFrom(r.Context())
+
+    id, ok := h.pathID(w, r, "id")
+    if !ok {
+        return nil, false
+    }
+    attachment, err := h.store.GetAttachment(r.Context(), id)
+    if err != nil || !(principal.IsAdmin || attachments.Visible(attachment, principal.UserID)) {
+        h.respondError(w, http.StatusNotFound, "Attachment not found")
+        return nil, false
+    }
+    return attachment, true
+}
@@ -0,0 +1,51 @@
+package api
+
+import (
+    "fmt"
+    "net/http"
+    "strings"
+    "time"
+
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+// cachePolicy is how long browsers and the CDN may keep a public
+// response. The CDN may keep it far longer than browsers because every
+// score change purges its surrogate keys there, while a browser copy can
+// only expire.
+type cachePolicy struct {
+    maxAge               time.Duration
+    edgeMaxAge           time.Duration
+    staleWhileRevalidate time.Duration
+}
+
+var (
+    liveCache     = cachePolicy{maxAge: 5 * time.Second, edgeMaxAge: time.Minute, staleWhileRevalidate: 30 * time.Second}
+    upcomingCache = cachePolicy{maxAge: 30 * time.Second, edgeMaxAge: 5 * time.Minute, staleWhileRevalidate: time.Minute}
+    finalCache    = cachePolicy{maxAge: 5 * time.Minute, edgeMaxAge: 24 * time.Hour, staleWhileRevalidate: time.Hour}
+)
+
+// matchCachePolicy caches a match by how likely its score is to change.
+func matchCachePolicy(match *models.Match) cachePolicy {
+    switch match.Status {
+    case models.MatchStatusLive:
+        return liveCache
+    case models.MatchStatusFinished, models.MatchStatusCancelled:
+        return finalCache
+    default:
+        return upcomingCache
+    }
+}
+
+// setCacheHeaders marks a successful public response cacheable and tags
+// it with surrogate keys the CDN can purge by. Only call it on responses
+// that do not depend on who is asking.
+func setCacheHeaders(w http.ResponseWriter, policy cachePolicy, keys ...string) {
+    w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d, s-maxage=%d, stale-while-revalidate=%d",
+        int(policy.maxAge.Seconds()),
+        int(policy.edgeMaxAge.Seconds()),
+        int(policy.staleWhileRevalidate.Seconds())))
+    if len(keys) > 0 {
+        w.Header().Set("Surrogate-Key", strings.Join(keys, " "))
+    }
+}
@@ -0,0 +1,84 @@
+package api
+
+import (
+    "net/http"
+    "strconv"
+    "strings"
+    "unicode/utf8"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/commentary"
+    "github.com/yourusername/sports-chat/internal/locale"
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+const maxCommentaryTemplateLength = 500
+
+func (h *Handler) listCommentaryTemplates(w http.ResponseWriter, r *http.Request) {
+    templates, err := h.store.ListCommentaryTemplates(r.Context())
+    if err != nil {
+        h.logger.Error("Failed to list commentary templates", zap.Error(err))
+        h.respondError(w, http.StatusInternalServerError, "Failed to load templates")
+        return
+    }
+
+    h.respondJSON(w, http.StatusOK, templates)
+}
+
+type commentaryTemplateRequest struct {
+    Template string `json:"template"`
+}
+
+// putCommentaryTemplate sets how events of a type read in a locale. The
+// "default" locale serves clients whose language has no template.
+func (h *Handler) putCommentaryTemplate(w http.ResponseWriter, r *http.Request) {
+    var req commentaryTemplateRequest
+    if err := h.decodeJSON(r, &req); err != nil {
+        h.respondError(w, http.StatusBadRequest, "Invalid request body")
+        return
+    }
+    req.Template = strings.TrimSpace(req.Template)
+    if req.Template == "" || utf8.RuneCountInString(req.Template) > maxCommentaryTemplateLength {
+        h.respondError(w, http.StatusBadRequest, "Template must be 1 to "+strconv.Itoa(maxCommentaryTemplateLength)+" characters")
+        return
+    }
+
+    template := &models.CommentaryTemplate{
+        Locale:    locale.Normalize(r.PathValue("locale")),
+        EventType: commentary.NormalizeEventType(r.PathValue("event_type")),
+        Template:  req.Template,
+    }
+    if err := h.store.UpsertCommentaryTemplate(r.Context(), template); err != nil {
+        h.logger.Error("Failed to save commentary template", zap.Error(err))
+        h.respondError(w, http.StatusInternalServerError, "Failed to save template")
+        return
+    }
+
+    h.reloadCommentary(r)
+    h.respondJSON(w, http.StatusOK, template)
+}
+
+func (h *Handler) deleteCommentaryTemplate(w http.ResponseWriter, r *http.Request) {
+    lang := locale.Normalize(r.PathValue("locale"))
+    eventType := commentary.NormalizeEventType(r.PathValue("event_type"))
+    if err := h.store.DeleteCommentaryTemplate(r.Context(), lang, eventType); err != nil {
+        h.logger.Error("Failed to delete commentary template", zap.Error(err))
+        h.respondError(w, http.StatusInternalServerError, "Failed to delete template")
+        return
+    }
+
+    h.reloadCommentary(r)
+    w.WriteHeader(http.StatusNoContent)
+}
+
+// reloadCommentary applies an admin change on this instance straight
+// away; the reload job brings the others round.
+func (h *Handler) reloadCommentary(r *http.Request) {
+    if h.commentary == nil {
+        return
+    }
+    if err := h.commentary.Reload(r.Context()); err != nil {
+        h.logger.Error("Failed to reload commentary templates", zap.Error(err))
+    }
+}
@@ -0,0 +1,89 @@
+package api
+
+import (
+    "errors"
+    "net/http"
+    "strconv"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/authctx"
+    "github.com/yourusername/sports-chat/internal/jobs"
+)
+
+// listDeadLetters lists jobs that exhausted their retries, newest first.
+// Only letters not yet replayed are listed unless all=true.
+func (h *Handler) listDeadLetters(w http.ResponseWriter, r *http.Request) {
+    pendingOnly := r.URL.Query().Get("all") != "true"
+
+    limit := 50
+    if v, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && v > 0 && v <= 200 {
+        limit = v
+    }
+
+    letters, err := h.store.ListDeadLetters(r.Context(), pendingOnly, limit)
+    if err != nil {
+        h.logger.Error("Failed to list dead letters", zap.Error(err))
+        h.respondError(w, http.StatusInternalServerError, "Failed to load dead letters")
+        return
+    }
+
+    h.respondJSON(w, http.StatusOK, letters)
+}
+
+func (h *Handler) getDeadLetter(w http.ResponseWriter, r *http.Request) {
+    id, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+    letter, err := h.store.GetDeadLetter(r.Context(), id)
+    if err != nil {
+        h.respondError(w, http.StatusNotFound, "Dead letter not found")
+        return
+    }
+
+    h.respondJSON(w, http.StatusOK, letter)
+}
+
+// replayDeadLetter puts a dead-lettered job back on the queue. Letters
+// can be replayed more than once; a replay that fails again becomes a
+// new letter.
+func (h *Handler) replayDeadLetter(w http.ResponseWriter, r *http.Request) {
+    if h.jobs == nil {
+        h.respondError(w, http.StatusServiceUnavailable, "Job queue unavailable")
+        return
+    }
+    id, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+
+    letter, err := h.store.GetDeadLetter(r.Context(), id)
+    if err != nil {
+        h.respondError(w, http.StatusNotFound, "Dead letter not found")
+        return
+    }
+
+    if err := h.jobs.Replay(letter); err != nil {
+        switch {
+        case errors.Is(err, jobs.ErrQueueFull), errors.Is(err, jobs.ErrQueueClosed):
+            h.respondError(w, http.StatusServiceUnavailable, "Job queue unavailable")
+        case errors.Is(err, jobs.ErrUnknownJob):
+            h.respondError(w, http.StatusUnprocessableEntity, "Job cannot be replayed")
+        default:
+            h.logger.Error("Failed to replay dead letter", zap.Error(err), zap.String("dead_letter_id", id))
+            h.respondError(w, http.StatusUnprocessableEntity, "Failed to decode job")
+        }
+        return
+    }
+
+    if err := h.store.MarkDeadLetterReplayed(r.Context(), id, time.Now()); err != nil {
+        h.logger.Error("Failed to mark dead letter replayed", zap.Error(err), zap.String("dead_letter_id", id))
+    }
+    h.logger.Info("Dead letter replayed",
+        zap.String("dead_letter_id", id),
+        zap.String("job", letter.JobName),
+        zap.String("actor", authctx.Actor(r.Context())))
+    w.WriteHeader(http.StatusAccepted)
+}
@@ -0,0 +1,142 @@
+package api
+
+import (
+    "net/http"
+    "strconv"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/authctx"
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/store"
+    "github.com/yourusername/sports-chat/internal/websocket"
+)
+
+const maxConversations = 100
+
+func (h *Handler) listConversations(w http.ResponseWriter, r *http.Request) {
+    principal, _ := authctx.UserFrom(r.Context())
+
+    conversations, err := h.store.GetUserConversations(r.Context(), principal.UserID, maxConversations)
+    if err != nil {
+        h.logger.Error("Failed to list conversations", zap.Error(err), zap.String("user_id", principal.UserID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to load conversations")
+        return
+    }
+
+    h.respondJSON(w, http.StatusOK, conversations)
+}
+
+type directMessagePage struct {
+    Messages   []*models.DirectMessage `json:"messages"`
+    HasMore    bool                    `json:"has_more"`
+    NextCursor string                  `json:"next_cursor,omitempty"`
+}
+
+// getConversationMessages pages back through a conversation, newest first,
+// with ?before=<cursor>&limit=n.
+func (h *Handler) getConversationMessages(w http.ResponseWriter, r *http.Request) {
+    principal, _ := authctx.UserFrom(r.Context())
+    conversationID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+    query := r.URL.Query()
+
+    // Other users' conversations are reported as missing, not forbidden
+    conversation, err := h.store.GetConversation(r.Context(), conversationID)
+    if err != nil || !conversation.Has(principal.UserID) {
+        h.respondError(w, http.StatusNotFound, "Conversation not found")
+        return
+    }
+
+    var before *store.MessageCursor
+    if v := query.Get("before"); v != "" {
+        cursor, err := store.DecodeMessageCursor(v)
+        if err != nil {
+            h.respondError(w, http.StatusBadRequest, "Invalid cursor")
+            return
+        }
+        before = &cursor
+    }
+
+    limit, _ := strconv.Atoi(query.Get("limit"))
+    limit = websocket.ClampHistoryLimit(limit)
+
+    // One extra tells us whether there is another page
+    messages, err := h.store.GetDirectMessagesBeforeCursor(r.Context(), conversation.ID, before, limit+1)
+    if err != nil {
+        h.logger.Error("Failed to get direct messages", zap.Error(err), zap.String("conversation_id", conversation.ID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to load messages")
+        return
+    }
+
+    page := &directMessagePage{Messages: messages}
+    if len(messages) > limit {
+        page.Messages = messages[:limit]
+        page.HasMore = true
+        last := page.Messages[limit-1]
+        page.NextCursor = store.MessageCursor{CreatedAt: last.CreatedAt, ID: last.ID}.Encode()
+    }
+    if page.Messages == nil {
+        page.Messages = []*models.DirectMessage{}
+    }
+
+    h.respondJSON(w, http.StatusOK, page)
+}
+
+func (h *Handler) listBlockedUsers(w http.ResponseWriter, r *http.Request) {
+    principal, _ := authctx.UserFrom(r.Context())
+
+    blocks, err := h.store.GetBlockedUsers(r.Context(), principal.UserID)
+    if err != nil {
+        h.logger.Error("Failed to list blocked users", zap.Error(err), zap.String("user_id", principal.UserID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to load blocked users")
+        return
+    }
+
+    h.respondJSON(w, http.StatusOK, blocks)
+}
+
+func (h *Handler) blockUser(w http.ResponseWriter, r *http.Request) {
+    principal, _ := authctx.UserFrom(r.Context())
+    blockedID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+
+    if blockedID == principal.UserID {
+        h.respondError(w, http.StatusBadRequest, "You cannot block yourself")
+        return
+    }
+    if _, err := h.store.GetUser(r.Context(), blockedID); err != nil {
+        h.respondError(w, http.StatusNotFound, "User not found")
+        return
+    }
+
+    block := &models.UserBlock{UserID: principal.UserID, BlockedID: blockedID, CreatedAt: time.Now()}
+    if err := h.store.BlockUser(r.Context(), block); err != nil {
+        h.logger.Error("Failed to block user", zap.Error(err), zap.String("user_id", principal.UserID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to block user")
+        return
+    }
+
+    w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) unblockUser(w http.ResponseWriter, r *http.Request) {
+    principal, _ := authctx.UserFrom(r.Context())
+
+    blockedID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+    if err := h.store.UnblockUser(r.Context(), principal.UserID, blockedID); err != nil {
+        h.logger.Error("Failed to unblock user", zap.Error(err), zap.String("user_id", principal.UserID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to unblock user")
+        return
+    }
+
+    w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,193 @@
+package api
+
+import (
+    "net/http"
+    "net/url"
+    "regexp"
+    "strconv"
+    "strings"
+    "time"
+    "unicode/utf8"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/authctx"
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/websocket"
+)
+
+const maxEmotePackName = 100
+
+var emoteShortcodePattern = regexp.MustCompile(`^[a-z0-9_]{2,32}$`)
+
+// getRoomEmotes lists the emotes a room can use, or with ?q= searches
+// them by shortcode prefix. The websocket emotes command answers the same.
+func (h *Handler) getRoomEmotes(w http.ResponseWriter, r *http.Request) {
+    roomID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+
+    room, err := h.store.GetChatRoom(r.Context(), roomID)
+    if err != nil {
+        h.respondError(w, http.StatusNotFound, "Room not found")
+        return
+    }
+    limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+    list, err := websocket.LoadRoomEmotes(r.Context(), h.store, room, r.URL.Query().Get("q"), limit)
+    if err != nil {
+        h.logger.Error("Failed to load emotes", zap.Error(err), zap.String("room", roomID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to load emotes")
+        return
+    }
+
+    h.respondJSON(w, http.StatusOK, list)
+}
+
+type createEmotePackRequest struct {
+    Name string `json:"name"`
+    // TeamID limits the pack to the rooms of the team's matches; empty
+    // makes it site-wide
+    TeamID string `json:"team_id"`
+}
+
+// createEmotePack creates an unpublished pack, for emotes to be added to
+// before clients see it.
+func (h *Handler) createEmotePack(w http.ResponseWriter, r *http.Request) {
+    principal, _ := authctx.UserFrom(r.Context())
+
+    var req createEmotePackRequest
+    if err := h.decodeJSON(r, &req); err != nil {
+        h.respondError(w, http.StatusBadRequest, "Invalid request body")
+        return
+    }
+    req.Name = strings.TrimSpace(req.Name)
+    if req.Name == "" || utf8.RuneCountInString(req.Name) > maxEmotePackName {
+        h.respondError(w, http.StatusBadRequest, "Packs need a name of up to 100 characters")
+        return
+    }
+
+    pack := &models.EmotePack{Name: req.Name, TeamID: req.TeamID, CreatedBy: principal.UserID}
+    if err := h.store.CreateEmotePack(r.Context(), pack); err != nil {
+        h.logger.Error("Failed to create emote pack", zap.Error(err))
+        h.respondError(w, http.StatusInternalServerError, "Failed to create emote pack")
+        return
+    }
+
+    h.respondJSON(w, http.StatusCreated, pack)
+}
+
+func (h *Handler) getEmotePack(w http.ResponseWriter, r *http.Request) {
+    id, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+    pack, err := h.store.GetEmotePack(r.Context(), id)
+    if err != nil {
+        h.respondError(w, http.StatusNotFound, "Emote pack not found")
+        return
+    }
+
+    h.respondJSON(w, http.StatusOK, pack)
+}
+
+type addEmoteRequest struct {
+    Shortcode string `json:"shortcode"`
+    ImageURL  string `json:"image_url"`
+}
+
+// addEmote adds an emote to a pack. In a published pack it reaches
+// clients when the pack is published again.
+func (h *Handler) addEmote(w http.ResponseWriter, r *http.Request) {
+    packID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+
+    var req addEmoteRequest
+    if err := h.decodeJSON(r, &req); err != nil {
+        h.respondError(w, http.StatusBadRequest, "Invalid request body")
+        return
+    }
+    req.Shortcode = strings.Trim(strings.TrimSpace(req.Shortcode), ":")
+    if !emoteShortcodePattern.MatchString(req.Shortcode) || !validImageURL(req.ImageURL) {
+        h.respondError(w, http.StatusBadRequest, "Emotes need a shortcode of 2 to 32 lowercase letters, digits or underscores and an http(s) image URL")
+        return
+    }
+
+    if _, err := h.store.GetEmotePack(r.Context(), packID); err != nil {
+        h.respondError(w, http.StatusNotFound, "Emote pack not found")
+        return
+    }
+
+    emote := &models.Emote{PackID: packID, Shortcode: req.Shortcode, ImageURL: req.ImageURL}
+    added, err := h.store.AddEmote(r.Context(), emote)
+    if err != nil {
+        h.logger.Error("Failed to add emote", zap.Error(err), zap.String("pack_id", packID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to add emote")
+        return
+    }
+    if !added {
+        h.respondError(w, http.StatusConflict, "The pack already has :"+req.Shortcode+":")
+        return
+    }
+
+    h.respondJSON(w, http.StatusCreated, emote)
+}
+
+// deleteEmote removes an emote. Clients keep showing it until they next
+// load the room's emotes.
+func (h *Handler) deleteEmote(w http.ResponseWriter, r *http.Request) {
+    id, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+    if err := h.store.DeleteEmote(r.Context(), id); err != nil {
+        h.logger.Error("Failed to delete emote", zap.Error(err), zap.String("emote_id", id))
+        h.respondError(w, http.StatusInternalServerError, "Failed to delete emote")
+        return
+    }
+
+    w.WriteHeader(http.StatusNoContent)
+}
+
+// publishEmotePack makes a pack available to its rooms and pushes it to
+// connected clients, so a pack released mid-season shows up without a
+// reload. Publishing again sends the pack's current emotes.
+func (h *Handler) publishEmotePack(w http.ResponseWriter, r *http.Request) {
+    ctx := r.Context()
+    packID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+
+    pack, err := h.store.GetEmotePack(ctx, packID)
+    if err != nil {
+        h.respondError(w, http.StatusNotFound, "Emote pack not found")
+        return
+    }
+    if len(pack.Emotes) == 0 {
+        h.respondError(w, http.StatusConflict, "Emote pack is empty")
+        return
+    }
+
+    now := time.Now()
+    if err := h.store.PublishEmotePack(ctx, packID, now); err != nil {
+        h.logger.Error("Failed to publish emote pack", zap.Error(err), zap.String("pack_id", packID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to publish emote pack")
+        return
+    }
+    pack.PublishedAt = &now
+    h.hub.EmotePackPublished(pack)
+
+    h.respondJSON(w, http.StatusOK, pack)
+}
+
+func validImageURL(raw string) bool {
+    if raw == "" || len(raw) > 2048 {
+        return false
+    }
+    u, err := url.Parse(raw)
+    return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
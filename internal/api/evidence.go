@@ -0,0 +1,92 @@
+package api
+
+import (
+    "errors"
+    "net/http"
+    "strconv"
+    "strings"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/authctx"
+    "github.com/yourusername/sports-chat/internal/evidence"
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+// maxEvidencePurposeLength bounds the reason an admin gives for reading
+// evidence.
+const maxEvidencePurposeLength = 500
+
+// evidenceResponse is an opened piece of evidence: the deleted message
+// and everyone who has read it, this access included.
+type evidenceResponse struct {
+    *models.ModerationEvidence
+    Message *models.Message          `json:"message"`
+    Access  []*models.EvidenceAccess `json:"access"`
+}
+
+// listEvidence lists retained evidence, newest first, optionally of one
+// user with ?user_id=. Only what was retained and why is listed; reading
+// a message takes getEvidence.
+func (h *Handler) listEvidence(w http.ResponseWriter, r *http.Request) {
+    if h.evidence == nil {
+        h.respondError(w, http.StatusNotFound, "Evidence retention is disabled")
+        return
+    }
+    limit := 50
+    if v, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && v > 0 && v <= 500 {
+        limit = v
+    }
+
+    list, err := h.store.ListModerationEvidence(r.Context(), r.URL.Query().Get("user_id"), limit)
+    if err != nil {
+        h.logger.Error("Failed to list evidence", zap.Error(err))
+        h.respondError(w, http.StatusInternalServerError, "Failed to list evidence")
+        return
+    }
+    h.respondJSON(w, http.StatusOK, list)
+}
+
+// getEvidence opens a piece of evidence. The admin must say why with
+// ?purpose=, which is recorded with the access before the message is
+// decrypted.
+func (h *Handler) getEvidence(w http.ResponseWriter, r *http.Request) {
+    if h.evidence == nil {
+        h.respondError(w, http.StatusNotFound, "Evidence retention is disabled")
+        return
+    }
+    principal, _ := authctx.UserFrom(r.Context())
+    id, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+
+    purpose := strings.TrimSpace(r.URL.Query().Get("purpose"))
+    if purpose == "" || len(purpose) > maxEvidencePurposeLength {
+        h.respondError(w, http.StatusBadRequest, "A purpose of at most "+strconv.Itoa(maxEvidencePurposeLength)+" characters is required")
+        return
+    }
+
+    opened, msg, err := h.evidence.Open(r.Context(), id, principal.UserID, purpose)
+    if errors.Is(err, evidence.ErrNotFound) {
+        h.respondError(w, http.StatusNotFound, "Evidence not found")
+        return
+    }
+    if err != nil {
+        h.logger.Error("Failed to open evidence", zap.Error(err), zap.String("evidence_id", id))
+        h.respondError(w, http.StatusInternalServerError, "Failed to open evidence")
+        return
+    }
+    h.logger.Info("Evidence accessed",
+        zap.String("evidence_id", id),
+        zap.String("purpose", purpose),
+        zap.String("actor", authctx.Actor(r.Context())))
+
+    access, err := h.store.GetEvidenceAccess(r.Context(), id)
+    if err != nil {
+        h.logger.Error("Failed to get evidence access", zap.Error(err), zap.String("evidence_id", id))
+        h.respondError(w, http.StatusInternalServerError, "Failed to open evidence")
+        return
+    }
+    h.respondJSON(w, http.StatusOK, evidenceResponse{ModerationEvidence: opened, Message: msg, Access: access})
+}
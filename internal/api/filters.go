@@ -0,0 +1,140 @@
+package api
+
+import (
+    "net/http"
+    "strconv"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/authctx"
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+// listModerationFilters returns every filter in the chain, in the order
+// they run, with its stored setting. Filters never configured are listed
+// enabled with their defaults.
+func (h *Handler) listModerationFilters(w http.ResponseWriter, r *http.Request) {
+    settings, err := h.store.ListModerationFilters(r.Context())
+    if err != nil {
+        h.logger.Error("Failed to list moderation filters", zap.Error(err))
+        h.respondError(w, http.StatusInternalServerError, "Failed to load filters")
+        return
+    }
+    byName := make(map[string]*models.ModerationFilter, len(settings))
+    for _, s := range settings {
+        byName[s.Name] = s
+    }
+
+    filters := make([]*models.ModerationFilter, 0, len(settings))
+    for _, name := range h.filters.Filters() {
+        setting, ok := byName[name]
+        if !ok {
+            setting = &models.ModerationFilter{Name: name, Enabled: true}
+        }
+        filters = append(filters, setting)
+    }
+
+    h.respondJSON(w, http.StatusOK, filters)
+}
+
+// putModerationFilter stores a filter's setting and reloads the chain.
+// Other instances pick it up on their next scheduled reload.
+func (h *Handler) putModerationFilter(w http.ResponseWriter, r *http.Request) {
+    var setting models.ModerationFilter
+    if err := h.decodeJSON(r, &setting); err != nil {
+        h.respondError(w, http.StatusBadRequest, "Invalid request body")
+        return
+    }
+    setting.Name = r.PathValue("name")
+    if !h.filters.Has(setting.Name) {
+        h.respondError(w, http.StatusNotFound, "Filter not found")
+        return
+    }
+    if err := h.filters.Validate(&setting); err != nil {
+        h.respondError(w, http.StatusBadRequest, err.Error())
+        return
+    }
+    setting.UpdatedAt = time.Now()
+
+    if err := h.store.UpsertModerationFilter(r.Context(), &setting); err != nil {
+        h.logger.Error("Failed to save moderation filter", zap.Error(err), zap.String("filter", setting.Name))
+        h.respondError(w, http.StatusInternalServerError, "Failed to save filter")
+        return
+    }
+
+    h.logger.Info("Moderation filter updated",
+        zap.String("filter", setting.Name),
+        zap.Bool("enabled", setting.Enabled),
+        zap.String("actor", authctx.Actor(r.Context())))
+    h.reloadFilters(r)
+    h.respondJSON(w, http.StatusOK, setting)
+}
+
+func (h *Handler) listMessageFlags(w http.ResponseWriter, r *http.Request) {
+    status := r.URL.Query().Get("status")
+    if status == "" {
+        status = models.FlagPending
+    }
+    if status != models.FlagPending && status != models.FlagConfirmed && status != models.FlagDismissed {
+        h.respondError(w, http.StatusBadRequest, "Unknown status")
+        return
+    }
+
+    limit := 50
+    if v, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && v > 0 && v <= 200 {
+        limit = v
+    }
+
+    flags, err := h.store.ListMessageFlags(r.Context(), status, limit)
+    if err != nil {
+        h.logger.Error("Failed to list message flags", zap.Error(err))
+        h.respondError(w, http.StatusInternalServerError, "Failed to load flags")
+        return
+    }
+
+    h.respondJSON(w, http.StatusOK, flags)
+}
+
+type reviewFlagRequest struct {
+    Verdict string `json:"verdict"`
+}
+
+// reviewMessageFlag records a moderator verdict on a flagged message.
+// Confirming does not delete the message; moderators delete separately.
+func (h *Handler) reviewMessageFlag(w http.ResponseWriter, r *http.Request) {
+    principal, err := authctx.RequireUser(r.Context())
+    if err != nil {
+        h.respondError(w, http.StatusUnauthorized, "Unauthorized")
+        return
+    }
+    id, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+
+    var req reviewFlagRequest
+    if err := h.decodeJSON(r, &req); err != nil ||
+        (req.Verdict != models.FlagConfirmed && req.Verdict != models.FlagDismissed) {
+        h.respondError(w, http.StatusBadRequest, "Verdict must be confirmed or dismissed")
+        return
+    }
+
+    flag, err := h.store.GetMessageFlag(r.Context(), id)
+    if err != nil {
+        h.respondError(w, http.StatusNotFound, "Flag not found")
+        return
+    }
+    if flag.Status != models.FlagPending {
+        h.respondError(w, http.StatusConflict, "Flag already reviewed")
+        return
+    }
+
+    if err := h.store.ReviewMessageFlag(r.Context(), id, req.Verdict, principal.UserID); err != nil {
+        h.logger.Error("Failed to review message flag", zap.Error(err), zap.String("flag_id", id))
+        h.respondError(w, http.StatusInternalServerError, "Failed to review flag")
+        return
+    }
+
+    w.WriteHeader(http.StatusNoContent)
+}
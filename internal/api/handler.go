@@ -0,0 +1,478 @@
+package api
+
+import (
+    "encoding/json"
+    "net/http"
+    "strings"
+    "time"
+
+    "github.com/google/uuid"
+    "go.opentelemetry.io/otel/trace"
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/appeals"
+    "github.com/yourusername/sports-chat/internal/attachments"
+    "github.com/yourusername/sports-chat/internal/auth"
+    "github.com/yourusername/sports-chat/internal/avatars"
+    "github.com/yourusername/sports-chat/internal/commentary"
+    "github.com/yourusername/sports-chat/internal/evidence"
+    "github.com/yourusername/sports-chat/internal/highlights"
+    "github.com/yourusername/sports-chat/internal/incidents"
+    "github.com/yourusername/sports-chat/internal/jobs"
+    "github.com/yourusername/sports-chat/internal/localization"
+    "github.com/yourusername/sports-chat/internal/metrics"
+    "github.com/yourusername/sports-chat/internal/moderation"
+    "github.com/yourusername/sports-chat/internal/predictions"
+    "github.com/yourusername/sports-chat/internal/privacy"
+    "github.com/yourusername/sports-chat/internal/ratelimit"
+    "github.com/yourusername/sports-chat/internal/rbac"
+    "github.com/yourusername/sports-chat/internal/recovery"
+    "github.com/yourusername/sports-chat/internal/search/opensearch"
+    "github.com/yourusername/sports-chat/internal/store"
+    "github.com/yourusername/sports-chat/internal/userstats"
+    "github.com/yourusername/sports-chat/internal/websocket"
+)
+
+// Version is the API version the handler serves. It is mounted under
+// /api/<Version>; breaking changes get a new version alongside it.
+const Version = "v1"
+
+type Options struct {
+    RateLimitRequests int
+    RateLimitWindow   time.Duration
+    // RequestTimeout bounds ordinary routes; LongRequestTimeout bounds
+    // exports and bulk admin operations.
+    RequestTimeout     time.Duration
+    LongRequestTimeout time.Duration
+    // PreviewMessages is how many recent messages the public room
+    // preview includes.
+    PreviewMessages int
+    // PreviewReactions lets logged-out preview viewers react into a
+    // room's hype meter, each address limited by PreviewReactionLimits
+    // through PreviewReactionLimiter. Nil limits use the defaults and
+    // in-process buckets. AllowedOrigins are the sites they may react
+    // from.
+    PreviewReactions       bool
+    PreviewReactionLimiter ratelimit.Limiter
+    PreviewReactionLimits  ratelimit.Rules
+    AllowedOrigins         []string
+    // Jobs replays dead letters; nil disables replay.
+    Jobs *jobs.Queue
+    // Predictions serves predictions and polls; nil when disabled.
+    Predictions *predictions.Service
+    // Filters is the moderation chain admins configure; nil configures
+    // a chain of the profanity filter alone.
+    Filters *moderation.Chain
+    // UsernameCooldown is how long a user waits between renames, and how
+    // long a released name is held.
+    UsernameCooldown time.Duration
+    // CountryHeader is the request header the edge puts the client's
+    // country code in, recorded with account activity; empty records none.
+    CountryHeader string
+    // StatsRollupInterval is how often statistics are rolled up; rollups
+    // older than two intervals are reported stale.
+    StatsRollupInterval time.Duration
+    // Roles checks room-scoped permissions, shared with the hub. Nil
+    // checks against the store alone.
+    Roles *rbac.Checker
+    // Attachments scans and stores uploaded images; nil when disabled.
+    Attachments *attachments.Service
+    // MaxAttachmentSize is the largest image a user may upload, in bytes.
+    MaxAttachmentSize int
+    // Incidents captures room snapshots to object storage; nil when no
+    // object store is configured.
+    Incidents *incidents.Service
+    // Evidence opens the sealed copies of deleted messages; nil when
+    // evidence retention is disabled.
+    Evidence *evidence.Service
+    // Appeals files and reviews appeals of moderation actions.
+    Appeals *appeals.Service
+    // Commentary renders match events in clients' languages; admin
+    // template changes reload it.
+    Commentary *commentary.Renderer
+    // Localizer shows matches in users' languages and time zones; admin
+    // name changes reload it.
+    Localizer *localization.Localizer
+    // Highlights publishes match clips; nil when disabled.
+    Highlights *highlights.Service
+    // Stats tracks engagement for the leaderboards; nil when disabled.
+    Stats *userstats.Tracker
+    // IngestSecret verifies match updates the sports data provider
+    // pushes; empty turns ingestion off.
+    IngestSecret string
+    // Avatars stores profile pictures; nil when no object store is
+    // configured. MaxAvatarSize is the largest upload, in bytes.
+    Avatars       *avatars.Service
+    MaxAvatarSize int
+    // Privacy exports and erases users' data.
+    Privacy *privacy.Service
+    // Simulator turns on the admin endpoints that emit fake goals,
+    // reports and join spikes; never set in production.
+    Simulator bool
+    // Recovery gets users back into their accounts.
+    Recovery *recovery.Service
+    // Search serves highlighted message search with per-room counts; nil
+    // unless search is served from OpenSearch.
+    Search *opensearch.Store
+}
+
+type Handler struct {
+    store           store.Store
+    auth            *auth.Service
+    hub             *websocket.Hub
+    profanity       *moderation.ProfanityFilter
+    filters         *moderation.Chain
+    limiter         *rateLimiter
+    publicLimiter   *rateLimiter
+    previewMessages int
+    previewReactions bool
+    previewReactionLimiter ratelimit.Limiter
+    previewReactionLimits ratelimit.Rules
+    allowedOrigins  []string
+    recovery        *recovery.Service
+    search          *opensearch.Store
+    timeout         time.Duration
+    longTimeout     time.Duration
+    jobs            *jobs.Queue
+    predictions     *predictions.Service
+    attachments     *attachments.Service
+    incidents       *incidents.Service
+    evidence        *evidence.Service
+    appeals         *appeals.Service
+    commentary      *commentary.Renderer
+    localizer       *localization.Localizer
+    highlights      *highlights.Service
+    stats           *userstats.Tracker
+    ingestSecret    []byte
+    simulator       bool
+    avatars         *avatars.Service
+    maxAvatarSize   int
+    privacy         *privacy.Service
+    roles           *rbac.Checker
+    maxAttachmentSize int
+    usernames       *moderation.UsernamePolicy
+    usernameCooldown time.Duration
+    countryHeader   string
+    statsRollupInterval time.Duration
+    revocations     *revocations
+    metrics         *metrics.Metrics
+    logger          *zap.Logger
+    mux             *http.ServeMux
+}
+
+func NewHandler(store store.Store, auth *auth.Service, hub *websocket.Hub, profanity *moderation.ProfanityFilter, opts Options, metrics *metrics.Metrics, logger *zap.Logger) *Handler {
+    h := &Handler{
+        store:           store,
+        auth:            auth,
+        hub:             hub,
+        profanity:       profanity,
+        filters:         opts.Filters,
+        limiter:         newRateLimiter(opts.RateLimitRequests, opts.RateLimitWindow),
+        publicLimiter:   newRateLimiter(opts.RateLimitRequests, opts.RateLimitWindow),
+        previewMessages: opts.PreviewMessages,
+        previewReactions: opts.PreviewReactions,
+        previewReactionLimiter: opts.PreviewReactionLimiter,
+        previewReactionLimits: opts.PreviewReactionLimits,
+        allowedOrigins:  opts.AllowedOrigins,
+        recovery:        opts.Recovery,
+        search:          opts.Search,
+        timeout:         opts.RequestTimeout,
+        longTimeout:     opts.LongRequestTimeout,
+        jobs:            opts.Jobs,
+        predictions:     opts.Predictions,
+        attachments:     opts.Attachments,
+        incidents:       opts.Incidents,
+        evidence:        opts.Evidence,
+        appeals:         opts.Appeals,
+        commentary:      opts.Commentary,
+        localizer:       opts.Localizer,
+        highlights:      opts.Highlights,
+        stats:           opts.Stats,
+        ingestSecret:    []byte(opts.IngestSecret),
+        simulator:       opts.Simulator,
+        avatars:         opts.Avatars,
+        maxAvatarSize:   opts.MaxAvatarSize,
+        privacy:         opts.Privacy,
+        roles:           opts.Roles,
+        maxAttachmentSize: opts.MaxAttachmentSize,
+        usernames:       moderation.NewUsernamePolicy(store, profanity),
+        usernameCooldown: opts.UsernameCooldown,
+        countryHeader:   opts.CountryHeader,
+        statsRollupInterval: opts.StatsRollupInterval,
+        revocations:     newRevocations(),
+        metrics:         metrics,
+        logger:          logger,
+        mux:             http.NewServeMux(),
+    }
+    if h.filters == nil {
+        h.filters = moderation.NewChain(store, profanity, nil, metrics, logger)
+    }
+    if h.roles == nil {
+        h.roles = rbac.NewChecker(store, logger)
+    }
+    if h.previewReactionLimiter == nil {
+        h.previewReactionLimiter = ratelimit.NewMemory()
+    }
+    if h.previewReactionLimits == nil {
+        h.previewReactionLimits = DefaultPreviewReactionLimits
+    }
+    h.routes()
+    return h
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+    // Name the request's span after the route, not the raw path, so
+    // spans for /matches/{id} group together
+    if _, pattern := h.mux.Handler(r); pattern != "" {
+        trace.SpanFromContext(r.Context()).SetName(pattern)
+    }
+    h.mux.ServeHTTP(w, r)
+}
+
+func (h *Handler) routes() {
+    // Profile routes
+    h.mux.Handle("GET /users/{id}", h.authed(h.getUserProfile))
+    h.mux.Handle("GET /users/{id}/achievements", h.authed(h.getUserAchievements))
+    h.mux.Handle("PUT /users/me/goal-flash", h.authed(h.setGoalFlash))
+    h.mux.Handle("PUT /users/me/username", h.authed(h.renameUser))
+    h.mux.Handle("PUT /users/me", h.authed(h.updateProfile))
+    h.mux.Handle("DELETE /users/me", h.authed(h.eraseMe))
+    h.mux.Handle("GET /users/me/export", h.authed(h.exportMyData))
+    h.mux.Handle("GET /users/me/exports/{id}/archive", h.authed(h.downloadMyExport))
+    h.mux.Handle("GET /avatars/{user}/{file}", h.public(h.getAvatar))
+    h.mux.Handle("PUT /users/me/birth-date", h.authed(h.declareBirthDate))
+    h.mux.Handle("PUT /users/me/restricted-mode", h.authed(h.setRestrictedMode))
+    h.mux.Handle("GET /users/me/security/activity", h.authed(h.getSecurityActivity))
+    h.mux.Handle("POST /users/me/security/activity/{id}/dispute", h.authed(h.disputeSecurityActivity))
+    h.mux.Handle("GET /users/me/appeals", h.authed(h.listMyAppeals))
+    h.mux.Handle("POST /users/me/appeals", h.authed(h.createAppeal))
+    h.mux.Handle("POST /users/me/recovery-codes", h.authed(h.generateRecoveryCodes))
+    h.mux.Handle("PUT /users/me/recovery-email", h.authed(h.setRecoveryEmail))
+    h.mux.Handle("DELETE /users/me/recovery-email", h.authed(h.deleteRecoveryEmail))
+
+    // Account recovery, for users who cannot sign in
+    h.mux.Handle("POST /auth/recovery-email/verify", h.public(h.verifyRecoveryEmail))
+    h.mux.Handle("POST /auth/recover/code", h.public(h.recoverByCode))
+    h.mux.Handle("POST /auth/recover/email", h.public(h.recoverByEmail))
+    h.mux.Handle("POST /auth/recover/token", h.public(h.recoverByToken))
+
+    // Direct message routes
+    h.mux.Handle("GET /users/me/conversations", h.authed(h.listConversations))
+    h.mux.Handle("GET /conversations/{id}/messages", h.authed(h.getConversationMessages))
+    h.mux.Handle("GET /users/me/blocks", h.authed(h.listBlockedUsers))
+    h.mux.Handle("PUT /users/me/blocks/{id}", h.authed(h.blockUser))
+    h.mux.Handle("DELETE /users/me/blocks/{id}", h.authed(h.unblockUser))
+
+    // Room routes
+    h.mux.Handle("GET /rooms/{id}/messages", h.authed(h.getRoomMessages))
+    h.mux.Handle("GET /rooms/{id}/preview", h.public(h.getRoomPreview))
+    h.mux.Handle("POST /rooms/{id}/preview/reactions", h.public(h.postPreviewReaction))
+    h.mux.Handle("GET /rooms/{id}/stream", h.streaming(h.streamRoom))
+    h.mux.Handle("GET /rooms/{id}/presence", h.authed(h.getRoomPresence))
+    h.mux.Handle("GET /rooms/{id}/languages", h.authed(h.getRoomLanguages))
+    h.mux.Handle("GET /rooms/{id}/statistics", h.authed(h.getRoomStatistics))
+    h.mux.Handle("GET /rooms/{id}/emotes", h.authed(h.getRoomEmotes))
+    h.mux.Handle("GET /rooms/joined/summary", h.authed(h.getJoinedRoomSummaries))
+    h.mux.Handle("PUT /rooms/{id}/read", h.authed(h.markRoomRead))
+    h.mux.Handle("GET /users/me/unread", h.authed(h.getUnreadCounts))
+    h.mux.Handle("GET /rooms/{id}/topics", h.authed(h.listRoomTopics))
+    h.mux.Handle("GET /rooms/{id}/topics/{topicId}/messages", h.authed(h.getTopicMessages))
+    h.mux.Handle("GET /rooms/{id}/analytics", h.authed(h.getRoomAnalytics))
+    h.mux.Handle("POST /rooms/{id}/attachments", h.authed(h.uploadAttachment))
+    h.mux.Handle("GET /attachments/{id}", h.authed(h.getAttachment))
+    h.mux.Handle("GET /attachments/{id}/content", h.authed(h.getAttachmentContent))
+
+    // Room moderation by the room's own staff
+    h.mux.Handle("GET /rooms/{id}/roles", h.authed(h.listRoomRoles))
+    h.mux.Handle("PUT /rooms/{id}/roles/{userId}", h.roomAction(rbac.PermManageRoles, h.setRoomRole))
+    h.mux.Handle("DELETE /rooms/{id}/roles/{userId}", h.roomAction(rbac.PermManageRoles, h.deleteRoomRole))
+    h.mux.Handle("PUT /rooms/{id}/pin", h.roomAction(rbac.PermPin, h.pinRoomMessage))
+    h.mux.Handle("DELETE /rooms/{id}/pin", h.roomAction(rbac.PermPin, h.unpinRoomMessage))
+    h.mux.Handle("DELETE /rooms/{id}/messages/{messageId}", h.roomAction(rbac.PermDelete, h.deleteRoomMessage))
+    h.mux.Handle("POST /rooms/{id}/mutes", h.roomAction(rbac.PermSanction, h.muteRoomUser))
+    h.mux.Handle("DELETE /rooms/{id}/mutes/{userId}", h.roomAction(rbac.PermSanction, h.unmuteRoomUser))
+    h.mux.Handle("POST /rooms/{id}/bans", h.roomAction(rbac.PermSanction, h.banRoomUser))
+    h.mux.Handle("DELETE /rooms/{id}/bans/{userId}", h.roomAction(rbac.PermSanction, h.unbanRoomUser))
+
+    // Match routes
+    // Pushed by the sports data provider, which signs its requests
+    // rather than authenticating
+    h.mux.Handle("POST /ingest/match-events", h.route(h.timeout, http.HandlerFunc(h.ingestMatchEvents)))
+    h.mux.Handle("GET /matches/live", h.public(h.getLiveMatches))
+    h.mux.Handle("GET /users/me/matches/live", h.authed(h.getMyLiveMatches))
+    h.mux.Handle("GET /matches/{id}", h.public(h.getMatch))
+    h.mux.Handle("GET /matches/{id}/statistics", h.authed(h.getMatchStatistics))
+    h.mux.Handle("GET /matches/{id}/vote", h.authed(h.getMatchVote))
+    h.mux.Handle("PUT /matches/{id}/vote", h.authed(h.castMatchVote))
+    h.mux.Handle("GET /matches/{id}/prediction", h.authed(h.getPrediction))
+    h.mux.Handle("PUT /matches/{id}/prediction", h.authed(h.putPrediction))
+    h.mux.Handle("GET /matches/{id}/polls", h.authed(h.listMatchPolls))
+    h.mux.Handle("GET /matches/{id}/highlights", h.public(h.listMatchHighlights))
+    h.mux.Handle("GET /matches/{id}/leaderboard", h.public(h.getMatchLeaderboard))
+    h.mux.Handle("PUT /polls/{id}/vote", h.authed(h.votePoll))
+
+    // Season routes
+    h.mux.Handle("GET /seasons", h.public(h.listSeasons))
+    h.mux.Handle("GET /seasons/current", h.public(h.getCurrentSeason))
+    h.mux.Handle("GET /seasons/{id}/standings", h.public(h.getSeasonStandings))
+    h.mux.Handle("GET /seasons/{id}/leaderboard", h.public(h.getSeasonLeaderboard))
+
+    // Team routes
+    h.mux.Handle("GET /teams/{a}/vs/{b}", h.authed(h.getHeadToHead))
+
+    // Keyword alert routes
+    h.mux.Handle("GET /users/me/alerts", h.authed(h.listKeywordAlerts))
+    h.mux.Handle("POST /users/me/alerts", h.authed(h.createKeywordAlert))
+    h.mux.Handle("DELETE /users/me/alerts/{id}", h.authed(h.deleteKeywordAlert))
+    h.mux.Handle("GET /users/me/quiet-hours", h.authed(h.getQuietHours))
+    h.mux.Handle("PUT /users/me/quiet-hours", h.authed(h.putQuietHours))
+    h.mux.Handle("DELETE /users/me/quiet-hours", h.authed(h.deleteQuietHours))
+
+    // Admin routes
+    h.mux.Handle("GET /admin/reconciliation/reports", h.adminLong(h.listReconciliationReports))
+    h.mux.Handle("POST /admin/rooms/{id}/merge", h.adminLong(h.mergeRoom))
+    h.mux.Handle("DELETE /admin/rooms/{id}", h.admin(h.deleteRoom))
+    h.mux.Handle("POST /admin/rooms/{id}/restore", h.admin(h.restoreRoom))
+    h.mux.Handle("POST /admin/rooms/{id}/split", h.adminLong(h.splitRoom))
+    h.mux.Handle("GET /admin/rooms/{id}/journal", h.adminLong(h.getRoomJournal))
+    h.mux.Handle("POST /admin/rooms/{id}/incidents", h.adminLong(h.captureIncident))
+    h.mux.Handle("GET /admin/incidents", h.admin(h.listIncidents))
+    h.mux.Handle("GET /admin/incidents/{id}/bundle", h.adminLong(h.getIncidentBundle))
+    h.mux.Handle("GET /admin/evidence", h.admin(h.listEvidence))
+    h.mux.Handle("GET /admin/evidence/{id}", h.admin(h.getEvidence))
+    h.mux.Handle("GET /admin/search/messages", h.admin(h.searchMessages))
+    h.mux.Handle("GET /admin/appeals", h.admin(h.listAppeals))
+    h.mux.Handle("GET /admin/appeals/{id}", h.admin(h.getAppeal))
+    h.mux.Handle("POST /admin/appeals/{id}/review", h.admin(h.reviewAppeal))
+    h.mux.Handle("PUT /admin/rooms/{id}/initial-history", h.admin(h.setRoomInitialHistory))
+    h.mux.Handle("POST /admin/rooms/{id}/topics", h.admin(h.openRoomTopics))
+    h.mux.Handle("POST /admin/rooms/{id}/topics/{topicId}/collapse", h.admin(h.collapseRoomTopic))
+    h.mux.Handle("DELETE /admin/rooms/{id}/messages/{messageId}", h.admin(h.deleteRoomMessage))
+    h.mux.Handle("POST /admin/rooms/{id}/mutes", h.admin(h.muteRoomUser))
+    h.mux.Handle("DELETE /admin/rooms/{id}/mutes/{userId}", h.admin(h.unmuteRoomUser))
+    h.mux.Handle("POST /admin/rooms/{id}/bans", h.admin(h.banRoomUser))
+    h.mux.Handle("DELETE /admin/rooms/{id}/bans/{userId}", h.admin(h.unbanRoomUser))
+    h.mux.Handle("GET /admin/profanity/words", h.admin(h.listProfanityWords))
+    h.mux.Handle("POST /admin/profanity/words", h.admin(h.addProfanityWord))
+    h.mux.Handle("DELETE /admin/profanity/words/{locale}/{word}", h.admin(h.deleteProfanityWord))
+    h.mux.Handle("GET /admin/profanity/policies", h.admin(h.listProfanityPolicies))
+    h.mux.Handle("PUT /admin/profanity/policies/{locale}", h.admin(h.putProfanityPolicy))
+    h.mux.Handle("GET /admin/commentary/templates", h.admin(h.listCommentaryTemplates))
+    h.mux.Handle("PUT /admin/commentary/templates/{locale}/{event_type}", h.admin(h.putCommentaryTemplate))
+    h.mux.Handle("DELETE /admin/commentary/templates/{locale}/{event_type}", h.admin(h.deleteCommentaryTemplate))
+    h.mux.Handle("GET /admin/localized-names", h.admin(h.listLocalizedNames))
+    h.mux.Handle("PUT /admin/localized-names/{kind}/{id}/{locale}", h.admin(h.putLocalizedName))
+    h.mux.Handle("DELETE /admin/localized-names/{kind}/{id}/{locale}", h.admin(h.deleteLocalizedName))
+    h.mux.Handle("POST /admin/users/{id}/ban", h.admin(h.banUser))
+    h.mux.Handle("DELETE /admin/users/{id}/ban", h.admin(h.unbanUser))
+    h.mux.Handle("GET /admin/users/{id}/shadow-ban", h.admin(h.getShadowBan))
+    h.mux.Handle("POST /admin/users/{id}/shadow-ban", h.admin(h.shadowBanUser))
+    h.mux.Handle("DELETE /admin/users/{id}/shadow-ban", h.admin(h.unshadowBanUser))
+    h.mux.Handle("PUT /admin/users/{id}/birth-date", h.admin(h.verifyBirthDate))
+    h.mux.Handle("POST /admin/users/{id}/recovery", h.admin(h.assistRecovery))
+    h.mux.Handle("GET /admin/moderation/filters", h.admin(h.listModerationFilters))
+    h.mux.Handle("PUT /admin/moderation/filters/{name}", h.admin(h.putModerationFilter))
+    h.mux.Handle("GET /admin/moderation/flags", h.admin(h.listMessageFlags))
+    h.mux.Handle("POST /admin/moderation/flags/{id}/review", h.admin(h.reviewMessageFlag))
+    h.mux.Handle("GET /admin/evasion/suspects", h.admin(h.listEvasionSuspects))
+    h.mux.Handle("POST /admin/evasion/suspects/{id}/review", h.admin(h.reviewEvasionSuspect))
+    h.mux.Handle("POST /admin/matches/{id}/polls", h.admin(h.createPoll))
+    h.mux.Handle("POST /admin/polls/{id}/close", h.admin(h.closePoll))
+    h.mux.Handle("POST /admin/seasons", h.admin(h.createSeason))
+    h.mux.Handle("POST /admin/matches/{id}/highlights", h.admin(h.createHighlight))
+    h.mux.Handle("POST /admin/simulate/matches/{id}/goal", h.admin(h.simulateGoal))
+    h.mux.Handle("POST /admin/simulate/rooms/{id}/report", h.admin(h.simulateReport))
+    h.mux.Handle("POST /admin/simulate/rooms/{id}/join-spike", h.admin(h.simulateJoinSpike))
+    h.mux.Handle("DELETE /admin/highlights/{id}", h.admin(h.deleteHighlight))
+    h.mux.Handle("POST /admin/emote-packs", h.admin(h.createEmotePack))
+    h.mux.Handle("GET /admin/emote-packs/{id}", h.admin(h.getEmotePack))
+    h.mux.Handle("POST /admin/emote-packs/{id}/emotes", h.admin(h.addEmote))
+    h.mux.Handle("POST /admin/emote-packs/{id}/publish", h.admin(h.publishEmotePack))
+    h.mux.Handle("DELETE /admin/emotes/{id}", h.admin(h.deleteEmote))
+    h.mux.Handle("GET /admin/dead-letters", h.admin(h.listDeadLetters))
+    h.mux.Handle("GET /admin/dead-letters/{id}", h.admin(h.getDeadLetter))
+    h.mux.Handle("POST /admin/dead-letters/{id}/replay", h.admin(h.replayDeadLetter))
+    h.mux.Handle("GET /admin/connections", h.admin(h.listConnections))
+    h.mux.Handle("POST /admin/users/{id}/disconnect", h.admin(h.disconnectUser))
+    h.mux.Handle("POST /admin/announcements", h.admin(h.createAnnouncement))
+    h.mux.Handle("PUT /admin/rooms/{id}/slow-mode", h.admin(h.setRoomSlowMode))
+    h.mux.Handle("PUT /admin/rooms/{id}/age-gate", h.admin(h.setRoomAgeGate))
+    h.mux.Handle("PUT /admin/rooms/{id}/broadcast-only", h.admin(h.setRoomBroadcastOnly))
+    h.mux.Handle("PUT /admin/rooms/{id}/owner", h.admin(h.setRoomOwner))
+    h.mux.Handle("GET /admin/rooms/throughput", h.admin(h.getRoomThroughput))
+}
+
+// public is for the few routes served without authentication.
+func (h *Handler) public(fn http.HandlerFunc) http.Handler {
+    return h.route(h.timeout, h.publicRateLimit(fn))
+}
+
+// streaming is for long-lived authenticated responses, which the request
+// timeout would cut off. EventSource cannot set headers, so the access
+// token may also be passed as a query parameter, as on the websocket.
+func (h *Handler) streaming(fn http.HandlerFunc) http.Handler {
+    return h.route(0, queryToken(h.auth.AuthMiddleware(h.checkSession(h.rateLimit(fn)))))
+}
+
+// queryToken moves an access token passed as ?token= into the
+// Authorization header when the request has none.
+func queryToken(next http.Handler) http.Handler {
+    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        if token := r.URL.Query().Get("token"); token != "" && r.Header.Get("Authorization") == "" {
+            r.Header.Set("Authorization", "Bearer "+token)
+        }
+        next.ServeHTTP(w, r)
+    })
+}
+
+func (h *Handler) authed(fn http.HandlerFunc) http.Handler {
+    return h.route(h.timeout, h.auth.AuthMiddleware(h.checkSession(h.rateLimit(fn))))
+}
+
+func (h *Handler) admin(fn http.HandlerFunc) http.Handler {
+    return h.route(h.timeout, h.adminChain(fn))
+}
+
+// adminLong is for exports and bulk operations that legitimately take
+// longer than a chat request.
+func (h *Handler) adminLong(fn http.HandlerFunc) http.Handler {
+    return h.route(h.longTimeout, h.adminChain(fn))
+}
+
+func (h *Handler) adminChain(fn http.HandlerFunc) http.Handler {
+    return h.auth.AuthMiddleware(h.checkSession(h.rateLimit(h.auth.AdminMiddleware(fn))))
+}
+
+func (h *Handler) respondJSON(w http.ResponseWriter, status int, data interface{}) {
+    w.Header().Set("Content-Type", "application/json")
+    w.WriteHeader(status)
+    if err := json.NewEncoder(w).Encode(data); err != nil {
+        h.logger.Error("Failed to encode response", zap.Error(err))
+    }
+}
+
+func (h *Handler) decodeJSON(r *http.Request, v interface{}) error {
+    dec := json.NewDecoder(http.MaxBytesReader(nil, r.Body, 1<<20))
+    dec.DisallowUnknownFields()
+    return dec.Decode(v)
+}
+
+func (h *Handler) respondError(w http.ResponseWriter, status int, message string) {
+    h.respondJSON(w, status, map[string]string{"error": message})
+}
+
+// pathID reads an ID path parameter, responding 400 itself when it is not
+// a UUID, so a malformed ID never reaches the store. Parameters are named
+// for what they identify: "userId" is reported as an invalid user ID.
+func (h *Handler) pathID(w http.ResponseWriter, r *http.Request, name string) (string, bool) {
+    id := r.PathValue(name)
+    if _, err := uuid.Parse(id); err != nil {
+        what := "ID"
+        if kind := strings.TrimSuffix(name, "Id"); kind != name {
+            what = kind + " ID"
+        }
+        h.respondError(w, http.StatusBadRequest, "Invalid "+what)
+        return "", false
+    }
+    return id, true
+}
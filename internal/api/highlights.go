@@ -0,0 +1,92 @@
+package api
+
+import (
+    "errors"
+    "net/http"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/authctx"
+    "github.com/yourusername/sports-chat/internal/highlights"
+)
+
+// listMatchHighlights returns a match's clips, newest first.
+func (h *Handler) listMatchHighlights(w http.ResponseWriter, r *http.Request) {
+    if h.highlights == nil {
+        h.respondError(w, http.StatusNotFound, "Highlights are disabled")
+        return
+    }
+    matchID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+
+    clips, err := h.store.GetMatchHighlights(r.Context(), matchID)
+    if err != nil {
+        h.logger.Error("Failed to get highlights", zap.Error(err), zap.String("match_id", matchID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to get highlights")
+        return
+    }
+
+    h.respondJSON(w, http.StatusOK, clips)
+}
+
+type createHighlightRequest struct {
+    Title    string `json:"title"`
+    VideoURL string `json:"video_url"`
+    EventID  string `json:"event_id"`
+}
+
+// createHighlight publishes a clip to the match's room.
+func (h *Handler) createHighlight(w http.ResponseWriter, r *http.Request) {
+    if h.highlights == nil {
+        h.respondError(w, http.StatusNotFound, "Highlights are disabled")
+        return
+    }
+    principal, _ := authctx.UserFrom(r.Context())
+    matchID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+
+    var req createHighlightRequest
+    if err := h.decodeJSON(r, &req); err != nil {
+        h.respondError(w, http.StatusBadRequest, "Invalid request body")
+        return
+    }
+
+    highlight, err := h.highlights.Create(r.Context(), matchID, req.EventID, req.Title, req.VideoURL, principal.UserID)
+    switch {
+    case errors.Is(err, highlights.ErrNoMatch):
+        h.respondError(w, http.StatusNotFound, err.Error())
+        return
+    case errors.Is(err, highlights.ErrInvalidHighlight), errors.Is(err, highlights.ErrNoEvent):
+        h.respondError(w, http.StatusBadRequest, err.Error())
+        return
+    case errors.Is(err, highlights.ErrNoMatchRoom):
+        h.respondError(w, http.StatusConflict, err.Error())
+        return
+    case err != nil:
+        h.logger.Error("Failed to create highlight", zap.Error(err), zap.String("match_id", matchID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to create highlight")
+        return
+    }
+
+    h.respondJSON(w, http.StatusCreated, highlight)
+}
+
+// deleteHighlight removes a clip from the match's feed. Cards already
+// shown in chat stay until clients reload.
+func (h *Handler) deleteHighlight(w http.ResponseWriter, r *http.Request) {
+    id, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+    if err := h.store.DeleteHighlight(r.Context(), id); err != nil {
+        h.logger.Error("Failed to delete highlight", zap.Error(err), zap.String("highlight_id", id))
+        h.respondError(w, http.StatusInternalServerError, "Failed to delete highlight")
+        return
+    }
+
+    w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,164 @@
+package api
+
+import (
+    "context"
+    "net"
+    "net/http"
+    "strconv"
+    "strings"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/ratelimit"
+    "github.com/yourusername/sports-chat/internal/websocket"
+)
+
+// PreviewReactionRoomRule names the rule limiting an address's reactions
+// in one room; the default rule limits them across rooms.
+const PreviewReactionRoomRule = "room"
+
+// DefaultPreviewReactionLimits apply when none are configured.
+var DefaultPreviewReactionLimits = ratelimit.Rules{
+    ratelimit.DefaultRule:   {Requests: 20, Window: time.Minute},
+    PreviewReactionRoomRule: {Requests: 1, Window: 2 * time.Second},
+}
+
+// automatedAgents are User-Agent fragments of crawlers, scripts and
+// headless browsers, lowercased.
+var automatedAgents = []string{
+    "bot", "crawl", "spider", "slurp", "curl", "wget", "python", "go-http-client",
+    "java/", "okhttp", "libwww", "httpclient", "scrapy", "headless", "phantomjs",
+    "puppeteer", "playwright", "selenium",
+}
+
+type previewReactionRequest struct {
+    Emoji string `json:"emoji"`
+}
+
+// postPreviewReaction counts a logged-out viewer's reaction into the
+// room's hype meter and returns the meter. Nothing is stored or shown as
+// theirs: the reaction is on no message and only moves the counts. As
+// anyone can call it, it takes requests only from browsers on an allowed
+// origin, limits each address tightly, and caps what a room counts.
+func (h *Handler) postPreviewReaction(w http.ResponseWriter, r *http.Request) {
+    if !h.previewReactions {
+        h.respondError(w, http.StatusNotFound, "Preview reactions are disabled")
+        return
+    }
+    if !h.browserRequest(r) {
+        h.respondError(w, http.StatusForbidden, "Reactions are only accepted from the site")
+        return
+    }
+
+    roomID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+    room, err := h.store.GetChatRoom(r.Context(), roomID)
+    if err != nil || !room.IsActive {
+        h.respondRoomMissing(w, r, roomID)
+        return
+    }
+    if room.MinAge > 0 {
+        h.respondError(w, http.StatusForbidden, "Room is age restricted")
+        return
+    }
+    if room.State != models.RoomStateOpen && room.State != "" {
+        h.respondError(w, http.StatusConflict, "Room is not open")
+        return
+    }
+
+    var req previewReactionRequest
+    if err := h.decodeJSON(r, &req); err != nil || !websocket.ValidEmoji(req.Emoji) {
+        h.respondError(w, http.StatusBadRequest, "Invalid reaction")
+        return
+    }
+
+    if !h.allowPreviewReaction(w, r, room.ID) {
+        return
+    }
+    if !h.hub.CountAnonymousReaction(room.ID, req.Emoji) {
+        h.metrics.RateLimited.WithLabelValues("preview_reaction_room").Inc()
+        w.Header().Set("Retry-After", "2")
+        h.respondError(w, http.StatusTooManyRequests, "Room is taking too many reactions")
+        return
+    }
+
+    w.Header().Set("Cache-Control", "no-store")
+    h.respondJSON(w, http.StatusAccepted, h.hub.RoomHype(room.ID))
+}
+
+// browserRequest reports whether r looks like the site's own page calling
+// from a browser: an allowed Origin, which browsers always send on a
+// cross-origin POST, and a browser's User-Agent. Scripts can fake both,
+// so the limits behind it still apply.
+func (h *Handler) browserRequest(r *http.Request) bool {
+    origin := r.Header.Get("Origin")
+    if origin == "" || !h.allowedOrigin(origin) {
+        return false
+    }
+
+    agent := r.Header.Get("User-Agent")
+    if !strings.HasPrefix(agent, "Mozilla/") {
+        return false
+    }
+    agent = strings.ToLower(agent)
+    for _, fragment := range automatedAgents {
+        if strings.Contains(agent, fragment) {
+            return false
+        }
+    }
+    return true
+}
+
+func (h *Handler) allowedOrigin(origin string) bool {
+    for _, allowed := range h.allowedOrigins {
+        if allowed == "*" || strings.EqualFold(allowed, origin) {
+            return true
+        }
+    }
+    return false
+}
+
+// allowPreviewReaction takes a token from the address's buckets, across
+// rooms and in this one, responding itself when either is empty. The
+// buckets are shared across instances when the limiter is; unlike chat,
+// reactions are refused while it is unreachable.
+func (h *Handler) allowPreviewReaction(w http.ResponseWriter, r *http.Request, room string) bool {
+    ip, _, err := net.SplitHostPort(r.RemoteAddr)
+    if err != nil {
+        ip = r.RemoteAddr
+    }
+
+    ctx, cancel := context.WithTimeout(r.Context(), time.Second)
+    defer cancel()
+
+    checks := []struct {
+        key  string
+        rule ratelimit.Rule
+    }{
+        {"preview:" + ip, h.previewReactionLimits.For(ratelimit.DefaultRule)},
+        {"preview:" + room + ":" + ip, h.previewReactionLimits.For(PreviewReactionRoomRule)},
+    }
+    for _, check := range checks {
+        result, err := h.previewReactionLimiter.Allow(ctx, check.key, check.rule)
+        if err != nil {
+            h.logger.Warn("Preview reaction rate limit check failed", zap.Error(err))
+            h.respondError(w, http.StatusServiceUnavailable, "Reactions are unavailable")
+            return false
+        }
+        if !result.Allowed {
+            h.metrics.RateLimited.WithLabelValues("preview_reaction").Inc()
+            retry := int(result.RetryAfter.Seconds() + 0.999)
+            if retry < 1 {
+                retry = 1
+            }
+            w.Header().Set("Retry-After", strconv.Itoa(retry))
+            h.respondError(w, http.StatusTooManyRequests, "rate limit exceeded")
+            return false
+        }
+    }
+    return true
+}
@@ -0,0 +1,118 @@
+package api
+
+import (
+    "errors"
+    "net/http"
+    "strconv"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/authctx"
+    "github.com/yourusername/sports-chat/internal/objectstore"
+)
+
+const (
+    defaultIncidentMinutes = 10
+    maxIncidentMinutes     = 60
+)
+
+type captureIncidentRequest struct {
+    Minutes int    `json:"minutes"`
+    Note    string `json:"note"`
+}
+
+// captureIncident freezes a room's last minutes, ten unless the request
+// says otherwise, into an incident bundle for a postmortem.
+func (h *Handler) captureIncident(w http.ResponseWriter, r *http.Request) {
+    if h.incidents == nil {
+        h.respondError(w, http.StatusNotFound, "Incident capture is disabled")
+        return
+    }
+    principal, _ := authctx.UserFrom(r.Context())
+    roomID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+
+    var req captureIncidentRequest
+    if r.ContentLength != 0 {
+        if err := h.decodeJSON(r, &req); err != nil {
+            h.respondError(w, http.StatusBadRequest, "Invalid request body")
+            return
+        }
+    }
+    if req.Minutes == 0 {
+        req.Minutes = defaultIncidentMinutes
+    }
+    if req.Minutes < 0 || req.Minutes > maxIncidentMinutes {
+        h.respondError(w, http.StatusBadRequest, "Minutes must be between 1 and "+strconv.Itoa(maxIncidentMinutes))
+        return
+    }
+
+    room, err := h.store.GetChatRoom(r.Context(), roomID)
+    if err != nil {
+        h.respondError(w, http.StatusNotFound, "Room not found")
+        return
+    }
+
+    incident, err := h.incidents.Capture(r.Context(), room, time.Duration(req.Minutes)*time.Minute, principal.UserID, req.Note)
+    if err != nil {
+        h.logger.Error("Failed to capture incident", zap.Error(err), zap.String("room", roomID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to capture incident")
+        return
+    }
+
+    h.respondJSON(w, http.StatusCreated, incident)
+}
+
+// listIncidents lists captured incidents, newest first, optionally of one
+// room with ?room=.
+func (h *Handler) listIncidents(w http.ResponseWriter, r *http.Request) {
+    limit := 50
+    if v, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && v > 0 && v <= 500 {
+        limit = v
+    }
+
+    incidents, err := h.store.ListIncidents(r.Context(), r.URL.Query().Get("room"), limit)
+    if err != nil {
+        h.logger.Error("Failed to list incidents", zap.Error(err))
+        h.respondError(w, http.StatusInternalServerError, "Failed to list incidents")
+        return
+    }
+
+    h.respondJSON(w, http.StatusOK, incidents)
+}
+
+// getIncidentBundle serves an incident's bundle from object storage.
+func (h *Handler) getIncidentBundle(w http.ResponseWriter, r *http.Request) {
+    if h.incidents == nil {
+        h.respondError(w, http.StatusNotFound, "Incident capture is disabled")
+        return
+    }
+
+    id, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+    incident, err := h.store.GetIncident(r.Context(), id)
+    if err != nil {
+        h.respondError(w, http.StatusNotFound, "Incident not found")
+        return
+    }
+
+    bundle, err := h.incidents.Bundle(r.Context(), incident)
+    if errors.Is(err, objectstore.ErrNotFound) {
+        h.respondError(w, http.StatusGone, "Incident bundle is no longer stored")
+        return
+    }
+    if err != nil {
+        h.logger.Error("Failed to read incident bundle", zap.Error(err), zap.String("incident_id", incident.ID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to read incident bundle")
+        return
+    }
+
+    w.Header().Set("Content-Type", "application/json")
+    w.Header().Set("Content-Disposition", `attachment; filename="incident-`+incident.ID+`.json"`)
+    w.Write(bundle)
+}
@@ -0,0 +1,60 @@
+package api
+
+import (
+    "errors"
+    "io"
+    "net/http"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/sportsdata"
+    "github.com/yourusername/sports-chat/internal/websocket"
+)
+
+// maxPushSize bounds a pushed payload.
+const maxPushSize = 1 << 20
+
+// ingestMatchEvents takes match updates the sports data provider pushes,
+// signed with the shared webhook secret, and sends them to the match
+// room straight away instead of on the next poll. Unknown matches get a
+// 404 so the provider stops pushing them.
+func (h *Handler) ingestMatchEvents(w http.ResponseWriter, r *http.Request) {
+    if len(h.ingestSecret) == 0 {
+        h.respondError(w, http.StatusNotFound, "Match event ingestion is disabled")
+        return
+    }
+
+    body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxPushSize))
+    if err != nil {
+        h.respondError(w, http.StatusRequestEntityTooLarge, "Payload too large")
+        return
+    }
+    if err := sportsdata.VerifySignature(h.ingestSecret, body, r.Header.Get(sportsdata.SignatureHeader), time.Now()); err != nil {
+        h.metrics.MatchPushes.WithLabelValues("invalid_signature").Inc()
+        h.respondError(w, http.StatusUnauthorized, "Invalid signature")
+        return
+    }
+    push, err := sportsdata.ParsePush(body)
+    if err != nil {
+        h.metrics.MatchPushes.WithLabelValues("invalid").Inc()
+        h.respondError(w, http.StatusBadRequest, err.Error())
+        return
+    }
+
+    match, err := h.hub.IngestMatchPush(r.Context(), push)
+    if errors.Is(err, websocket.ErrUnknownMatch) {
+        h.metrics.MatchPushes.WithLabelValues("unknown_match").Inc()
+        h.respondError(w, http.StatusNotFound, "Match not found")
+        return
+    }
+    if err != nil {
+        h.metrics.MatchPushes.WithLabelValues("failed").Inc()
+        h.logger.Error("Failed to ingest match push", zap.Error(err), zap.String("provider_id", push.Match.ProviderID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to ingest match events")
+        return
+    }
+
+    h.metrics.MatchPushes.WithLabelValues("accepted").Inc()
+    h.respondJSON(w, http.StatusOK, match)
+}
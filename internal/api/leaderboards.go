@@ -0,0 +1,79 @@
+package api
+
+import (
+    "net/http"
+    "strconv"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+func leaderboardLimit(r *http.Request) int {
+    limit := 25
+    if v, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && v > 0 && v <= 100 {
+        limit = v
+    }
+    return limit
+}
+
+// getMatchLeaderboard ranks a match's fans by engagement. Rooms are sent
+// changes to the top as they happen; this serves the whole board.
+func (h *Handler) getMatchLeaderboard(w http.ResponseWriter, r *http.Request) {
+    if h.stats == nil {
+        h.respondError(w, http.StatusNotFound, "Leaderboards are disabled")
+        return
+    }
+    matchID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+
+    if _, err := h.store.GetMatch(r.Context(), matchID); err != nil {
+        h.respondError(w, http.StatusNotFound, "Match not found")
+        return
+    }
+
+    board, err := h.store.GetMatchLeaderboard(r.Context(), matchID, leaderboardLimit(r))
+    if err != nil {
+        h.logger.Error("Failed to get match leaderboard", zap.Error(err), zap.String("match_id", matchID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to get leaderboard")
+        return
+    }
+    if board == nil {
+        board = []*models.LeaderboardEntry{}
+    }
+
+    h.respondJSON(w, http.StatusOK, board)
+}
+
+// getSeasonLeaderboard ranks fans by their engagement over a season's
+// matches.
+func (h *Handler) getSeasonLeaderboard(w http.ResponseWriter, r *http.Request) {
+    if h.stats == nil {
+        h.respondError(w, http.StatusNotFound, "Leaderboards are disabled")
+        return
+    }
+
+    seasonID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+    season, err := h.store.GetSeason(r.Context(), seasonID)
+    if err != nil {
+        h.respondError(w, http.StatusNotFound, "Season not found")
+        return
+    }
+
+    board, err := h.store.GetSeasonLeaderboard(r.Context(), season, leaderboardLimit(r))
+    if err != nil {
+        h.logger.Error("Failed to get season leaderboard", zap.Error(err), zap.String("season_id", season.ID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to get leaderboard")
+        return
+    }
+    if board == nil {
+        board = []*models.LeaderboardEntry{}
+    }
+
+    h.respondJSON(w, http.StatusOK, board)
+}
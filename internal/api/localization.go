@@ -0,0 +1,173 @@
+package api
+
+import (
+    "net/http"
+    "strconv"
+    "strings"
+    "unicode/utf8"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/authctx"
+    "github.com/yourusername/sports-chat/internal/commentary"
+    "github.com/yourusername/sports-chat/internal/locale"
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+const maxLocalizedNameLength = 255
+
+// localizeSnapshot adds the match as shown in a locale and time zone.
+// With neither given the snapshot is left as stored.
+func (h *Handler) localizeSnapshot(snapshot *matchSnapshot, match *models.Match, locale, timezone string) *matchSnapshot {
+    if h.localizer == nil || (locale == "" && timezone == "") {
+        return snapshot
+    }
+    snapshot.Localized = h.localizer.Localize(match, locale, timezone)
+    return snapshot
+}
+
+// queryLocale reads the locale and tz query parameters public match
+// routes localize by. They are part of the URL, so the CDN caches each
+// localization apart; the profile is not used, as the routes are public.
+func queryLocale(r *http.Request) (lang, timezone string) {
+    query := r.URL.Query()
+    return locale.Normalize(query.Get("locale")), query.Get("tz")
+}
+
+// getMyLiveMatches lists the matches in play as the caller sees them:
+// names in their profile's locale, or else their Accept-Language, and
+// kickoff in their profile's time zone.
+func (h *Handler) getMyLiveMatches(w http.ResponseWriter, r *http.Request) {
+    principal, _ := authctx.UserFrom(r.Context())
+
+    user, err := h.store.GetUser(r.Context(), principal.UserID)
+    if err != nil {
+        h.respondError(w, http.StatusNotFound, "User not found")
+        return
+    }
+    locale := user.Locale
+    if locale == "" {
+        locale = commentary.PreferredLocale(r.Header.Get("Accept-Language"))
+    }
+
+    matches, err := h.store.GetLiveMatches(r.Context())
+    if err != nil {
+        h.logger.Error("Failed to get live matches", zap.Error(err))
+        h.respondError(w, http.StatusInternalServerError, "Failed to load matches")
+        return
+    }
+
+    snapshots := make([]*matchSnapshot, 0, len(matches))
+    for _, match := range matches {
+        snapshots = append(snapshots, h.localizeSnapshot(newMatchSnapshot(match), match, locale, user.Timezone))
+    }
+
+    w.Header().Set("Cache-Control", "private, no-store")
+    h.respondJSON(w, http.StatusOK, snapshots)
+}
+
+func (h *Handler) listLocalizedNames(w http.ResponseWriter, r *http.Request) {
+    names, err := h.store.ListLocalizedNames(r.Context())
+    if err != nil {
+        h.logger.Error("Failed to list localized names", zap.Error(err))
+        h.respondError(w, http.StatusInternalServerError, "Failed to load names")
+        return
+    }
+
+    h.respondJSON(w, http.StatusOK, names)
+}
+
+type localizedNameRequest struct {
+    Name string `json:"name"`
+}
+
+// putLocalizedName sets a team's or competition's name in a locale. Names
+// set here are kept when the provider later sends its own.
+func (h *Handler) putLocalizedName(w http.ResponseWriter, r *http.Request) {
+    kind, id, ok := h.localizedNameTarget(w, r)
+    if !ok {
+        return
+    }
+    lang := locale.Normalize(r.PathValue("locale"))
+    if lang == "" {
+        h.respondError(w, http.StatusBadRequest, "Invalid locale")
+        return
+    }
+
+    var req localizedNameRequest
+    if err := h.decodeJSON(r, &req); err != nil {
+        h.respondError(w, http.StatusBadRequest, "Invalid request body")
+        return
+    }
+    req.Name = strings.TrimSpace(req.Name)
+    if req.Name == "" || utf8.RuneCountInString(req.Name) > maxLocalizedNameLength {
+        h.respondError(w, http.StatusBadRequest, "Name must be 1 to "+strconv.Itoa(maxLocalizedNameLength)+" characters")
+        return
+    }
+
+    name := &models.LocalizedName{
+        Kind:     kind,
+        EntityID: id,
+        Locale:   lang,
+        Name:     req.Name,
+        Source:   models.NameSourceAdmin,
+    }
+    if err := h.store.UpsertLocalizedNames(r.Context(), []*models.LocalizedName{name}); err != nil {
+        h.logger.Error("Failed to save localized name", zap.Error(err))
+        h.respondError(w, http.StatusInternalServerError, "Failed to save name")
+        return
+    }
+
+    h.reloadLocalizer(r)
+    h.respondJSON(w, http.StatusOK, name)
+}
+
+func (h *Handler) deleteLocalizedName(w http.ResponseWriter, r *http.Request) {
+    kind, id, ok := h.localizedNameTarget(w, r)
+    if !ok {
+        return
+    }
+    lang := locale.Normalize(r.PathValue("locale"))
+    if err := h.store.DeleteLocalizedName(r.Context(), kind, id, lang); err != nil {
+        h.logger.Error("Failed to delete localized name", zap.Error(err))
+        h.respondError(w, http.StatusInternalServerError, "Failed to delete name")
+        return
+    }
+
+    h.reloadLocalizer(r)
+    w.WriteHeader(http.StatusNoContent)
+}
+
+// localizedNameTarget reads the kind and entity a name route is for,
+// responding itself when either is invalid.
+func (h *Handler) localizedNameTarget(w http.ResponseWriter, r *http.Request) (string, string, bool) {
+    kind := r.PathValue("kind")
+    id, ok := h.pathID(w, r, "id")
+    if !ok {
+        return "", "", false
+    }
+    switch kind {
+    case models.NameKindTeam:
+        if _, err := h.store.GetTeam(r.Context(), id); err != nil {
+            h.respondError(w, http.StatusNotFound, "Team not found")
+            return "", "", false
+        }
+    case models.NameKindCompetition:
+        // Competitions have no table of their own to look the ID up in
+    default:
+        h.respondError(w, http.StatusBadRequest, "Kind must be team or competition")
+        return "", "", false
+    }
+    return kind, id, true
+}
+
+// reloadLocalizer applies an admin change on this instance straight away;
+// the reload job brings the others round.
+func (h *Handler) reloadLocalizer(r *http.Request) {
+    if h.localizer == nil {
+        return
+    }
+    if err := h.localizer.Reload(r.Context()); err != nil {
+        h.logger.Error("Failed to reload localized names", zap.Error(err))
+    }
+}
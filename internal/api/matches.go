@@ -0,0 +1,106 @@
+package api
+
+import (
+    "errors"
+    "net/http"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/authctx"
+    "github.com/yourusername/sports-chat/internal/cdn"
+    "github.com/yourusername/sports-chat/internal/ratings"
+)
+
+// getLiveMatches lists the matches in play. It is public and cached at
+// the CDN, which is purged whenever a score changes. The locale and tz
+// query parameters localize the matches.
+func (h *Handler) getLiveMatches(w http.ResponseWriter, r *http.Request) {
+    matches, err := h.store.GetLiveMatches(r.Context())
+    if err != nil {
+        h.logger.Error("Failed to get live matches", zap.Error(err))
+        h.respondError(w, http.StatusInternalServerError, "Failed to load matches")
+        return
+    }
+
+    locale, timezone := queryLocale(r)
+    snapshots := make([]*matchSnapshot, 0, len(matches))
+    keys := []string{cdn.LiveMatchesKey}
+    for _, match := range matches {
+        snapshots = append(snapshots, h.localizeSnapshot(newMatchSnapshot(match), match, locale, timezone))
+        keys = append(keys, cdn.MatchKey(match.ID))
+    }
+
+    setCacheHeaders(w, liveCache, keys...)
+    h.respondJSON(w, http.StatusOK, snapshots)
+}
+
+// getMatch returns one match's score. Like the live list it is public
+// and cached at the CDN.
+func (h *Handler) getMatch(w http.ResponseWriter, r *http.Request) {
+    matchID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+    match, err := h.store.GetMatch(r.Context(), matchID)
+    if err != nil {
+        h.respondError(w, http.StatusNotFound, "Match not found")
+        return
+    }
+
+    locale, timezone := queryLocale(r)
+    setCacheHeaders(w, matchCachePolicy(match), cdn.MatchKey(match.ID))
+    h.respondJSON(w, http.StatusOK, h.localizeSnapshot(newMatchSnapshot(match), match, locale, timezone))
+}
+
+// getMatchVote returns the match rating and player of the match tally,
+// live while voting is open and final once it closes.
+func (h *Handler) getMatchVote(w http.ResponseWriter, r *http.Request) {
+    matchID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+
+    result, err := h.store.GetMatchVoteResult(r.Context(), matchID)
+    if err != nil {
+        h.respondError(w, http.StatusNotFound, "No vote for this match")
+        return
+    }
+
+    h.respondJSON(w, http.StatusOK, result)
+}
+
+type matchBallotRequest struct {
+    Rating int    `json:"rating"`
+    Player string `json:"player"`
+}
+
+func (h *Handler) castMatchVote(w http.ResponseWriter, r *http.Request) {
+    principal, _ := authctx.UserFrom(r.Context())
+    matchID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+
+    var req matchBallotRequest
+    if err := h.decodeJSON(r, &req); err != nil {
+        h.respondError(w, http.StatusBadRequest, "Invalid request body")
+        return
+    }
+
+    err := ratings.Cast(r.Context(), h.store, matchID, principal.UserID, req.Rating, req.Player)
+    switch {
+    case err == nil:
+        w.WriteHeader(http.StatusNoContent)
+    case errors.Is(err, ratings.ErrInvalidBallot):
+        h.respondError(w, http.StatusBadRequest, err.Error())
+    case errors.Is(err, ratings.ErrNoVote):
+        h.respondError(w, http.StatusNotFound, err.Error())
+    case errors.Is(err, ratings.ErrVoteClosed):
+        h.respondError(w, http.StatusConflict, err.Error())
+    case errors.Is(err, ratings.ErrNotParticipant):
+        h.respondError(w, http.StatusForbidden, err.Error())
+    default:
+        h.logger.Error("Failed to cast match vote", zap.Error(err), zap.String("match_id", matchID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to record vote")
+    }
+}
@@ -0,0 +1,60 @@
+package api
+
+import (
+    "net/http"
+    "runtime/debug"
+    "time"
+
+    "go.uber.org/zap"
+)
+
+// timeoutBody is written by http.TimeoutHandler, which cannot set headers.
+const timeoutBody = `{"error":"request timed out"}`
+
+// route bounds a handler's run time and recovers its panics. The timeout
+// cancels the request context, and with it any store queries still
+// running on its behalf. Recovery sits inside the timeout because
+// http.TimeoutHandler re-panics on its own goroutine, losing the stack.
+func (h *Handler) route(timeout time.Duration, next http.Handler) http.Handler {
+    if timeout <= 0 {
+        return h.recoverPanics(next)
+    }
+    return http.TimeoutHandler(h.recoverPanics(next), timeout, timeoutBody)
+}
+
+// Deprecated marks responses served under a retired prefix, pointing
+// clients at the same path under its successor.
+func Deprecated(next http.Handler, successor string) http.Handler {
+    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        w.Header().Set("Deprecation", "true")
+        w.Header().Set("Link", "<"+successor+r.URL.Path+">; rel=\"successor-version\"")
+        next.ServeHTTP(w, r)
+    })
+}
+
+// recoverPanics turns a panicking handler into a 500, logging the stack.
+// Headers may already be out, in which case the client sees a cut-off
+// response; either way the server keeps running.
+func (h *Handler) recoverPanics(next http.Handler) http.Handler {
+    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        defer func() {
+            p := recover()
+            if p == nil {
+                return
+            }
+            // The standard library's signal to abort a response silently
+            if p == http.ErrAbortHandler {
+                panic(p)
+            }
+
+            h.metrics.HTTPPanics.Inc()
+            h.logger.Error("Recovered panic in HTTP handler",
+                zap.Any("panic", p),
+                zap.String("method", r.Method),
+                zap.String("path", r.URL.Path),
+                zap.ByteString("stack", debug.Stack()))
+            h.respondError(w, http.StatusInternalServerError, "Internal server error")
+        }()
+        next.ServeHTTP(w, r)
+    })
+}
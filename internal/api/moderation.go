@@ -0,0 +1,381 @@
+package api
+
+import (
+    "errors"
+    "net/http"
+    "strconv"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/authctx"
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/websocket"
+)
+
+type banUserRequest struct {
+    Reason string `json:"reason"`
+}
+
+func (h *Handler) banUser(w http.ResponseWriter, r *http.Request) {
+    userID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+
+    var req banUserRequest
+    if err := h.decodeJSON(r, &req); err != nil {
+        h.respondError(w, http.StatusBadRequest, "Invalid request body")
+        return
+    }
+
+    user, err := h.store.GetUser(r.Context(), userID)
+    if err != nil {
+        h.respondError(w, http.StatusNotFound, "User not found")
+        return
+    }
+    if user.IsAdmin {
+        h.respondError(w, http.StatusBadRequest, "Cannot ban an admin")
+        return
+    }
+
+    now := time.Now()
+    user.BannedAt = &now
+    user.BanReason = req.Reason
+    if err := h.store.UpdateUser(r.Context(), user); err != nil {
+        h.logger.Error("Failed to ban user", zap.Error(err), zap.String("user_id", userID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to ban user")
+        return
+    }
+
+    h.logger.Info("User banned",
+        zap.String("user_id", userID),
+        zap.String("actor", authctx.Actor(r.Context())))
+    w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) unbanUser(w http.ResponseWriter, r *http.Request) {
+    userID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+
+    user, err := h.store.GetUser(r.Context(), userID)
+    if err != nil {
+        h.respondError(w, http.StatusNotFound, "User not found")
+        return
+    }
+
+    user.BannedAt = nil
+    user.BanReason = ""
+    if err := h.store.UpdateUser(r.Context(), user); err != nil {
+        h.logger.Error("Failed to unban user", zap.Error(err), zap.String("user_id", userID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to unban user")
+        return
+    }
+
+    h.logger.Info("User unbanned",
+        zap.String("user_id", userID),
+        zap.String("actor", authctx.Actor(r.Context())))
+    w.WriteHeader(http.StatusNoContent)
+}
+
+type shadowBanRequest struct {
+    Reason string `json:"reason"`
+}
+
+// shadowBanStatus is a user's shadow ban and its audit trail, newest
+// first.
+type shadowBanStatus struct {
+    ShadowBanned bool                     `json:"shadow_banned"`
+    History      []*models.ShadowBanEntry `json:"history"`
+}
+
+// getShadowBan reports whether a user is shadow-banned and who applied
+// or removed it over time.
+func (h *Handler) getShadowBan(w http.ResponseWriter, r *http.Request) {
+    userID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+
+    user, err := h.store.GetUser(r.Context(), userID)
+    if err != nil {
+        h.respondError(w, http.StatusNotFound, "User not found")
+        return
+    }
+    history, err := h.store.GetShadowBanEntries(r.Context(), userID)
+    if err != nil {
+        h.logger.Error("Failed to get shadow ban history", zap.Error(err), zap.String("user_id", userID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to get shadow ban")
+        return
+    }
+    if history == nil {
+        history = []*models.ShadowBanEntry{}
+    }
+
+    h.respondJSON(w, http.StatusOK, &shadowBanStatus{ShadowBanned: user.ShadowBanned, History: history})
+}
+
+// shadowBanUser has a user's chat messages echoed back to them alone,
+// without telling them; no one else sees or stores them.
+func (h *Handler) shadowBanUser(w http.ResponseWriter, r *http.Request) {
+    var req shadowBanRequest
+    if err := h.decodeJSON(r, &req); err != nil {
+        h.respondError(w, http.StatusBadRequest, "Invalid request body")
+        return
+    }
+    h.setShadowBan(w, r, true, req.Reason)
+}
+
+func (h *Handler) unshadowBanUser(w http.ResponseWriter, r *http.Request) {
+    h.setShadowBan(w, r, false, r.URL.Query().Get("reason"))
+}
+
+func (h *Handler) setShadowBan(w http.ResponseWriter, r *http.Request, banned bool, reason string) {
+    userID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+    principal, _ := authctx.UserFrom(r.Context())
+
+    user, err := h.store.GetUser(r.Context(), userID)
+    if err != nil {
+        h.respondError(w, http.StatusNotFound, "User not found")
+        return
+    }
+    if banned && user.IsAdmin {
+        h.respondError(w, http.StatusBadRequest, "Cannot shadow ban an admin")
+        return
+    }
+
+    changed, err := h.store.SetShadowBan(r.Context(), &models.ShadowBanEntry{
+        UserID:  userID,
+        Banned:  banned,
+        ActorID: principal.UserID,
+        Reason:  reason,
+    })
+    if err != nil {
+        h.logger.Error("Failed to set shadow ban", zap.Error(err), zap.String("user_id", userID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to set shadow ban")
+        return
+    }
+    if !changed {
+        w.WriteHeader(http.StatusNoContent)
+        return
+    }
+    h.hub.ShadowBanChanged(userID)
+
+    h.logger.Info("User shadow ban changed",
+        zap.String("user_id", userID),
+        zap.Bool("shadow_banned", banned),
+        zap.String("actor", authctx.Actor(r.Context())))
+    w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) listEvasionSuspects(w http.ResponseWriter, r *http.Request) {
+    status := r.URL.Query().Get("status")
+    if status == "" {
+        status = models.SuspectPending
+    }
+    if !validSuspectStatus(status) {
+        h.respondError(w, http.StatusBadRequest, "Unknown status")
+        return
+    }
+
+    limit := 50
+    if v, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && v > 0 && v <= 200 {
+        limit = v
+    }
+
+    suspects, err := h.store.ListEvasionSuspects(r.Context(), status, limit)
+    if err != nil {
+        h.logger.Error("Failed to list evasion suspects", zap.Error(err))
+        h.respondError(w, http.StatusInternalServerError, "Failed to load suspects")
+        return
+    }
+
+    h.respondJSON(w, http.StatusOK, suspects)
+}
+
+type reviewSuspectRequest struct {
+    Verdict string `json:"verdict"`
+}
+
+// reviewEvasionSuspect records a moderator verdict. Verdicts are the
+// detector's feedback: dismissals lower the weight of the signals that
+// produced the false positive. Confirming does not ban; moderators ban
+// separately.
+func (h *Handler) reviewEvasionSuspect(w http.ResponseWriter, r *http.Request) {
+    principal, err := authctx.RequireUser(r.Context())
+    if err != nil {
+        h.respondError(w, http.StatusUnauthorized, "Unauthorized")
+        return
+    }
+    id, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+
+    var req reviewSuspectRequest
+    if err := h.decodeJSON(r, &req); err != nil ||
+        (req.Verdict != models.SuspectConfirmed && req.Verdict != models.SuspectDismissed) {
+        h.respondError(w, http.StatusBadRequest, "Verdict must be confirmed or dismissed")
+        return
+    }
+
+    suspect, err := h.store.GetEvasionSuspect(r.Context(), id)
+    if err != nil {
+        h.respondError(w, http.StatusNotFound, "Suspect not found")
+        return
+    }
+    if suspect.Status != models.SuspectPending {
+        h.respondError(w, http.StatusConflict, "Suspect already reviewed")
+        return
+    }
+
+    if err := h.store.ReviewEvasionSuspect(r.Context(), id, req.Verdict, principal.UserID); err != nil {
+        h.logger.Error("Failed to review evasion suspect", zap.Error(err), zap.String("suspect_id", id))
+        h.respondError(w, http.StatusInternalServerError, "Failed to review suspect")
+        return
+    }
+
+    w.WriteHeader(http.StatusNoContent)
+}
+
+func validSuspectStatus(status string) bool {
+    switch status {
+    case models.SuspectPending, models.SuspectConfirmed, models.SuspectDismissed:
+        return true
+    }
+    return false
+}
+
+// deleteRoomMessage deletes a message, with an optional ?reason= kept
+// with its evidence.
+func (h *Handler) deleteRoomMessage(w http.ResponseWriter, r *http.Request) {
+    principal, _ := authctx.UserFrom(r.Context())
+    roomID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+    messageID, ok := h.pathID(w, r, "messageId")
+    if !ok {
+        return
+    }
+
+    err := h.hub.DeleteMessage(r.Context(), roomID, messageID, principal.UserID, r.URL.Query().Get("reason"))
+    if errors.Is(err, websocket.ErrMessageNotFound) {
+        h.respondError(w, http.StatusNotFound, "Message not found")
+        return
+    }
+    if err != nil {
+        h.logger.Error("Failed to delete message", zap.Error(err), zap.String("message_id", messageID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to delete message")
+        return
+    }
+
+    h.logger.Info("Message deleted",
+        zap.String("room", roomID),
+        zap.String("message_id", messageID),
+        zap.String("actor", authctx.Actor(r.Context())))
+    w.WriteHeader(http.StatusNoContent)
+}
+
+// sanctionRequest mutes or bans a user in a room. Duration is in seconds;
+// zero lasts until lifted.
+type sanctionRequest struct {
+    UserID   string `json:"user_id"`
+    Duration int    `json:"duration"`
+    Reason   string `json:"reason"`
+}
+
+func (h *Handler) muteRoomUser(w http.ResponseWriter, r *http.Request) {
+    h.sanctionRoomUser(w, r, models.SanctionMute)
+}
+
+func (h *Handler) banRoomUser(w http.ResponseWriter, r *http.Request) {
+    h.sanctionRoomUser(w, r, models.SanctionBan)
+}
+
+func (h *Handler) sanctionRoomUser(w http.ResponseWriter, r *http.Request, kind string) {
+    roomID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+
+    var req sanctionRequest
+    if err := h.decodeJSON(r, &req); err != nil || req.UserID == "" || req.Duration < 0 {
+        h.respondError(w, http.StatusBadRequest, "Invalid request body")
+        return
+    }
+
+    sanction := &models.RoomSanction{
+        RoomID:    roomID,
+        UserID:    req.UserID,
+        Kind:      kind,
+        Reason:    req.Reason,
+        CreatedBy: authctx.Actor(r.Context()),
+        CreatedAt: time.Now(),
+    }
+    if req.Duration > 0 {
+        expires := sanction.CreatedAt.Add(time.Duration(req.Duration) * time.Second)
+        sanction.ExpiresAt = &expires
+    }
+
+    principal, _ := authctx.UserFrom(r.Context())
+    if !h.roles.Outranks(r.Context(), principal, roomID, req.UserID) {
+        h.respondError(w, http.StatusForbidden, "You can only "+kind+" users below your role")
+        return
+    }
+
+    err := h.hub.Sanction(r.Context(), sanction)
+    if errors.Is(err, websocket.ErrCannotSanction) {
+        h.respondError(w, http.StatusBadRequest, "Cannot "+kind+" an admin")
+        return
+    }
+    if err != nil {
+        h.logger.Error("Failed to sanction user", zap.Error(err), zap.String("user_id", req.UserID), zap.String("kind", kind))
+        h.respondError(w, http.StatusInternalServerError, "Failed to "+kind+" user")
+        return
+    }
+
+    h.logger.Info("User sanctioned in room",
+        zap.String("room", roomID),
+        zap.String("user_id", req.UserID),
+        zap.String("kind", kind),
+        zap.String("actor", sanction.CreatedBy))
+    h.respondJSON(w, http.StatusCreated, sanction)
+}
+
+func (h *Handler) unmuteRoomUser(w http.ResponseWriter, r *http.Request) {
+    h.liftRoomSanction(w, r, models.SanctionMute)
+}
+
+func (h *Handler) unbanRoomUser(w http.ResponseWriter, r *http.Request) {
+    h.liftRoomSanction(w, r, models.SanctionBan)
+}
+
+func (h *Handler) liftRoomSanction(w http.ResponseWriter, r *http.Request, kind string) {
+    roomID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+    userID, ok := h.pathID(w, r, "userId")
+    if !ok {
+        return
+    }
+
+    if err := h.hub.LiftSanction(r.Context(), roomID, userID, kind); err != nil {
+        h.logger.Error("Failed to lift sanction", zap.Error(err), zap.String("user_id", userID), zap.String("kind", kind))
+        h.respondError(w, http.StatusInternalServerError, "Failed to lift "+kind)
+        return
+    }
+
+    h.logger.Info("Room sanction lifted",
+        zap.String("room", roomID),
+        zap.String("user_id", userID),
+        zap.String("kind", kind),
+        zap.String("actor", authctx.Actor(r.Context())))
+    w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,161 @@
+package api
+
+import (
+    "net/http"
+    "strconv"
+    "strings"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/authctx"
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+const (
+    // maxSlowModeSeconds caps slow mode at five minutes between posts.
+    maxSlowModeSeconds = 300
+
+    maxAnnouncementLength = 1000
+)
+
+// The live operations endpoints act on the websocket hub. Connections and
+// throughput are those of the instance serving the request; disconnects,
+// announcements and slow mode reach every instance.
+
+func (h *Handler) listConnections(w http.ResponseWriter, r *http.Request) {
+    h.respondJSON(w, http.StatusOK, h.hub.Connections(r.URL.Query().Get("room")))
+}
+
+func (h *Handler) disconnectUser(w http.ResponseWriter, r *http.Request) {
+    principal, _ := authctx.UserFrom(r.Context())
+    userID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+
+    h.hub.DisconnectUser(userID)
+
+    h.logger.Info("User disconnected by admin",
+        zap.String("user_id", userID),
+        zap.String("admin_id", principal.UserID))
+    w.WriteHeader(http.StatusNoContent)
+}
+
+type announcementRequest struct {
+    // Empty announces to everyone connected
+    Room    string `json:"room"`
+    Content string `json:"content"`
+}
+
+func (h *Handler) createAnnouncement(w http.ResponseWriter, r *http.Request) {
+    principal, _ := authctx.UserFrom(r.Context())
+
+    var req announcementRequest
+    if err := h.decodeJSON(r, &req); err != nil {
+        h.respondError(w, http.StatusBadRequest, "Invalid request body")
+        return
+    }
+    req.Content = strings.TrimSpace(req.Content)
+    if req.Content == "" || len(req.Content) > maxAnnouncementLength {
+        h.respondError(w, http.StatusBadRequest, "Content must be between 1 and "+strconv.Itoa(maxAnnouncementLength)+" bytes")
+        return
+    }
+
+    message := &models.WSMessage{
+        Type:      models.MessageTypeAnnouncement,
+        ChatRoom:  req.Room,
+        Content:   req.Content,
+        Timestamp: time.Now(),
+    }
+    if req.Room == "" {
+        h.hub.AnnounceEverywhere(message)
+    } else {
+        if _, err := h.store.GetChatRoom(r.Context(), req.Room); err != nil {
+            h.respondError(w, http.StatusNotFound, "Room not found")
+            return
+        }
+        h.hub.Announce(req.Room, message)
+    }
+
+    h.logger.Info("Announcement sent",
+        zap.String("room", req.Room),
+        zap.String("admin_id", principal.UserID))
+    w.WriteHeader(http.StatusAccepted)
+}
+
+type slowModeRequest struct {
+    Seconds int `json:"seconds"`
+}
+
+// setRoomSlowMode limits each user to one chat message per interval in
+// the room; zero turns slow mode off. Admins, broadcasters and bots are
+// not limited.
+func (h *Handler) setRoomSlowMode(w http.ResponseWriter, r *http.Request) {
+    roomID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+
+    var req slowModeRequest
+    if err := h.decodeJSON(r, &req); err != nil || req.Seconds < 0 || req.Seconds > maxSlowModeSeconds {
+        h.respondError(w, http.StatusBadRequest, "Seconds must be between 0 and "+strconv.Itoa(maxSlowModeSeconds))
+        return
+    }
+
+    room, err := h.store.GetChatRoom(r.Context(), roomID)
+    if err != nil {
+        h.respondError(w, http.StatusNotFound, "Room not found")
+        return
+    }
+
+    room.SlowModeSeconds = req.Seconds
+    if err := h.store.UpdateChatRoom(r.Context(), room); err != nil {
+        h.logger.Error("Failed to update room", zap.Error(err), zap.String("room", roomID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to update room")
+        return
+    }
+    h.hub.SlowModeChanged(roomID, req.Seconds)
+
+    h.respondJSON(w, http.StatusOK, room)
+}
+
+type broadcastOnlyRequest struct {
+    Enabled bool `json:"enabled"`
+}
+
+// setRoomBroadcastOnly turns a room's broadcaster-only mode on or off.
+// While on, only the room's broadcasters, moderators and owners may post;
+// everyone may still react and vote.
+func (h *Handler) setRoomBroadcastOnly(w http.ResponseWriter, r *http.Request) {
+    roomID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+
+    var req broadcastOnlyRequest
+    if err := h.decodeJSON(r, &req); err != nil {
+        h.respondError(w, http.StatusBadRequest, "Invalid request body")
+        return
+    }
+
+    room, err := h.store.GetChatRoom(r.Context(), roomID)
+    if err != nil {
+        h.respondError(w, http.StatusNotFound, "Room not found")
+        return
+    }
+
+    room.BroadcastOnly = req.Enabled
+    if err := h.store.UpdateChatRoom(r.Context(), room); err != nil {
+        h.logger.Error("Failed to update room", zap.Error(err), zap.String("room", roomID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to update room")
+        return
+    }
+    h.hub.BroadcastOnlyChanged(room)
+
+    h.respondJSON(w, http.StatusOK, room)
+}
+
+func (h *Handler) getRoomThroughput(w http.ResponseWriter, r *http.Request) {
+    h.respondJSON(w, http.StatusOK, h.hub.RoomThroughput())
+}
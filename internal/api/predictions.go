@@ -0,0 +1,172 @@
+package api
+
+import (
+    "errors"
+    "net/http"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/authctx"
+    "github.com/yourusername/sports-chat/internal/predictions"
+)
+
+func (h *Handler) getPrediction(w http.ResponseWriter, r *http.Request) {
+    principal, _ := authctx.UserFrom(r.Context())
+
+    matchID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+    prediction, err := h.store.GetPrediction(r.Context(), matchID, principal.UserID)
+    if err != nil {
+        h.respondError(w, http.StatusNotFound, "No prediction for this match")
+        return
+    }
+
+    h.respondJSON(w, http.StatusOK, prediction)
+}
+
+type predictionRequest struct {
+    HomeScore int `json:"home_score"`
+    AwayScore int `json:"away_score"`
+}
+
+func (h *Handler) putPrediction(w http.ResponseWriter, r *http.Request) {
+    if h.predictions == nil {
+        h.respondError(w, http.StatusNotFound, "Predictions are disabled")
+        return
+    }
+    principal, _ := authctx.UserFrom(r.Context())
+    matchID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+
+    var req predictionRequest
+    if err := h.decodeJSON(r, &req); err != nil {
+        h.respondError(w, http.StatusBadRequest, "Invalid request body")
+        return
+    }
+
+    prediction, err := h.predictions.Predict(r.Context(), matchID, principal.UserID, req.HomeScore, req.AwayScore)
+    if err != nil {
+        h.respondPredictionError(w, err, "Failed to record prediction", zap.String("match_id", matchID))
+        return
+    }
+
+    h.respondJSON(w, http.StatusOK, prediction)
+}
+
+func (h *Handler) listMatchPolls(w http.ResponseWriter, r *http.Request) {
+    if h.predictions == nil {
+        h.respondError(w, http.StatusNotFound, "Predictions are disabled")
+        return
+    }
+    matchID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+
+    polls, err := h.predictions.MatchPolls(r.Context(), matchID)
+    if err != nil {
+        h.logger.Error("Failed to list polls", zap.Error(err), zap.String("match_id", matchID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to load polls")
+        return
+    }
+
+    h.respondJSON(w, http.StatusOK, polls)
+}
+
+type pollVoteRequest struct {
+    Option int `json:"option"`
+}
+
+func (h *Handler) votePoll(w http.ResponseWriter, r *http.Request) {
+    if h.predictions == nil {
+        h.respondError(w, http.StatusNotFound, "Predictions are disabled")
+        return
+    }
+    principal, _ := authctx.UserFrom(r.Context())
+    pollID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+
+    var req pollVoteRequest
+    if err := h.decodeJSON(r, &req); err != nil {
+        h.respondError(w, http.StatusBadRequest, "Invalid request body")
+        return
+    }
+
+    if err := h.predictions.Vote(r.Context(), pollID, principal.UserID, req.Option); err != nil {
+        h.respondPredictionError(w, err, "Failed to record vote", zap.String("poll_id", pollID))
+        return
+    }
+
+    w.WriteHeader(http.StatusNoContent)
+}
+
+type createPollRequest struct {
+    Question string   `json:"question"`
+    Options  []string `json:"options"`
+}
+
+func (h *Handler) createPoll(w http.ResponseWriter, r *http.Request) {
+    if h.predictions == nil {
+        h.respondError(w, http.StatusNotFound, "Predictions are disabled")
+        return
+    }
+    principal, _ := authctx.UserFrom(r.Context())
+    matchID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+
+    var req createPollRequest
+    if err := h.decodeJSON(r, &req); err != nil {
+        h.respondError(w, http.StatusBadRequest, "Invalid request body")
+        return
+    }
+
+    poll, err := h.predictions.CreatePoll(r.Context(), matchID, req.Question, req.Options, principal.UserID)
+    if err != nil {
+        h.respondPredictionError(w, err, "Failed to create poll", zap.String("match_id", matchID))
+        return
+    }
+
+    h.respondJSON(w, http.StatusCreated, poll)
+}
+
+func (h *Handler) closePoll(w http.ResponseWriter, r *http.Request) {
+    if h.predictions == nil {
+        h.respondError(w, http.StatusNotFound, "Predictions are disabled")
+        return
+    }
+    pollID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+
+    if err := h.predictions.ClosePoll(r.Context(), pollID); err != nil {
+        h.respondPredictionError(w, err, "Failed to close poll", zap.String("poll_id", pollID))
+        return
+    }
+
+    w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) respondPredictionError(w http.ResponseWriter, err error, message string, field zap.Field) {
+    switch {
+    case errors.Is(err, predictions.ErrNoMatch), errors.Is(err, predictions.ErrNoPoll):
+        h.respondError(w, http.StatusNotFound, err.Error())
+    case errors.Is(err, predictions.ErrInvalidScore), errors.Is(err, predictions.ErrInvalidPoll),
+        errors.Is(err, predictions.ErrInvalidOption):
+        h.respondError(w, http.StatusBadRequest, err.Error())
+    case errors.Is(err, predictions.ErrMatchStarted), errors.Is(err, predictions.ErrPollClosed),
+        errors.Is(err, predictions.ErrMatchNotUpcoming), errors.Is(err, predictions.ErrNoMatchRoom):
+        h.respondError(w, http.StatusConflict, err.Error())
+    default:
+        h.logger.Error(message, zap.Error(err), field)
+        h.respondError(w, http.StatusInternalServerError, message)
+    }
+}
@@ -0,0 +1,132 @@
+package api
+
+import (
+    "errors"
+    "net/http"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/authctx"
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/objectstore"
+    "github.com/yourusername/sports-chat/internal/privacy"
+)
+
+type dataExportResponse struct {
+    *models.DataExport
+    // DownloadURL is set once the archive is ready
+    DownloadURL string `json:"download_url,omitempty"`
+}
+
+// exportMyData starts an export of the caller's data, or returns the one
+// already underway or ready in that format: 202 while it is being built,
+// 200 with a download link once it is ready.
+func (h *Handler) exportMyData(w http.ResponseWriter, r *http.Request) {
+    principal, _ := authctx.UserFrom(r.Context())
+
+    format := r.URL.Query().Get("format")
+    if format == "" {
+        format = models.ExportFormatJSON
+    }
+    if format != models.ExportFormatJSON && format != models.ExportFormatCSV {
+        h.respondError(w, http.StatusBadRequest, "format must be json or csv")
+        return
+    }
+
+    export, started, err := h.privacy.RequestExport(r.Context(), principal.UserID, format)
+    switch {
+    case errors.Is(err, privacy.ErrDisabled):
+        h.respondError(w, http.StatusNotFound, "Data export is disabled")
+        return
+    case errors.Is(err, privacy.ErrTooManyExports):
+        h.respondError(w, http.StatusTooManyRequests, "Too many exports today")
+        return
+    case err != nil:
+        h.logger.Error("Failed to request data export", zap.Error(err), zap.String("user_id", principal.UserID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to export data")
+        return
+    }
+    if started {
+        h.logger.Info("Data export requested",
+            zap.String("user_id", principal.UserID),
+            zap.String("export_id", export.ID),
+            zap.String("format", format))
+    }
+
+    resp := &dataExportResponse{DataExport: export}
+    status := http.StatusAccepted
+    if export.Status == models.ExportReady {
+        resp.DownloadURL = "/api/users/me/exports/" + export.ID + "/archive"
+        status = http.StatusOK
+    }
+    h.respondJSON(w, status, resp)
+}
+
+// downloadMyExport sends a ready export's archive to the user it belongs
+// to.
+func (h *Handler) downloadMyExport(w http.ResponseWriter, r *http.Request) {
+    principal, _ := authctx.UserFrom(r.Context())
+
+    id, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+    export, err := h.store.GetDataExport(r.Context(), id)
+    if err != nil || export.UserID != principal.UserID {
+        h.respondError(w, http.StatusNotFound, "Export not found")
+        return
+    }
+    if export.Status != models.ExportReady {
+        h.respondError(w, http.StatusConflict, "Export is not ready")
+        return
+    }
+    if export.ExpiresAt != nil && !export.ExpiresAt.After(time.Now()) {
+        h.respondError(w, http.StatusGone, "Export has expired")
+        return
+    }
+
+    archive, err := h.privacy.Archive(r.Context(), export)
+    if errors.Is(err, objectstore.ErrNotFound) {
+        h.respondError(w, http.StatusGone, "Export has expired")
+        return
+    }
+    if err != nil {
+        h.logger.Error("Failed to read data export", zap.Error(err), zap.String("export_id", export.ID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to read export")
+        return
+    }
+
+    w.Header().Set("Content-Type", privacy.ContentType(export.Format))
+    w.Header().Set("Content-Disposition", `attachment; filename="sports-chat-export-`+export.ID+`.`+privacy.Extension(export.Format)+`"`)
+    w.Header().Set("Cache-Control", "no-store")
+    w.Write(archive)
+}
+
+// eraseMe erases the caller's account: by default it is anonymized, its
+// messages kept under a placeholder name; mode=delete removes it with
+// everything it posted. Either way the caller is signed out everywhere.
+func (h *Handler) eraseMe(w http.ResponseWriter, r *http.Request) {
+    principal, _ := authctx.UserFrom(r.Context())
+
+    mode := r.URL.Query().Get("mode")
+    if mode == "" {
+        mode = privacy.EraseAnonymize
+    }
+    if mode != privacy.EraseAnonymize && mode != privacy.EraseDelete {
+        h.respondError(w, http.StatusBadRequest, "mode must be anonymize or delete")
+        return
+    }
+
+    if err := h.privacy.Erase(r.Context(), principal.UserID, mode); err != nil {
+        h.logger.Error("Failed to erase user", zap.Error(err), zap.String("user_id", principal.UserID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to delete account")
+        return
+    }
+
+    // Other instances see an anonymized account's revocation in the store
+    // and no longer find a deleted one; this one refuses the tokens now
+    now := time.Now()
+    h.setRevocation(principal.UserID, &now)
+    w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,103 @@
+package api
+
+import (
+    "net/http"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/locale"
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/moderation"
+)
+
+func (h *Handler) listProfanityWords(w http.ResponseWriter, r *http.Request) {
+    words, err := h.store.ListProfanityWords(r.Context())
+    if err != nil {
+        h.logger.Error("Failed to list profanity words", zap.Error(err))
+        h.respondError(w, http.StatusInternalServerError, "Failed to load words")
+        return
+    }
+
+    h.respondJSON(w, http.StatusOK, words)
+}
+
+func (h *Handler) addProfanityWord(w http.ResponseWriter, r *http.Request) {
+    var word models.ProfanityWord
+    if err := h.decodeJSON(r, &word); err != nil {
+        h.respondError(w, http.StatusBadRequest, "Invalid request body")
+        return
+    }
+    if word.Word == "" || word.Severity < models.SeverityMild || word.Severity > models.SeveritySevere {
+        h.respondError(w, http.StatusBadRequest, "Word and a severity between 1 and 3 are required")
+        return
+    }
+    if word.Locale == "" {
+        word.Locale = moderation.DefaultLocale
+    }
+    word.Locale = locale.Normalize(word.Locale)
+
+    if err := h.store.AddProfanityWord(r.Context(), &word); err != nil {
+        h.logger.Error("Failed to add profanity word", zap.Error(err))
+        h.respondError(w, http.StatusInternalServerError, "Failed to add word")
+        return
+    }
+
+    h.reloadFilters(r)
+    h.respondJSON(w, http.StatusCreated, word)
+}
+
+func (h *Handler) deleteProfanityWord(w http.ResponseWriter, r *http.Request) {
+    lang := locale.Normalize(r.PathValue("locale"))
+    if err := h.store.DeleteProfanityWord(r.Context(), lang, r.PathValue("word")); err != nil {
+        h.logger.Error("Failed to delete profanity word", zap.Error(err))
+        h.respondError(w, http.StatusInternalServerError, "Failed to delete word")
+        return
+    }
+
+    h.reloadFilters(r)
+    w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) listProfanityPolicies(w http.ResponseWriter, r *http.Request) {
+    policies, err := h.store.ListProfanityPolicies(r.Context())
+    if err != nil {
+        h.logger.Error("Failed to list profanity policies", zap.Error(err))
+        h.respondError(w, http.StatusInternalServerError, "Failed to load policies")
+        return
+    }
+
+    h.respondJSON(w, http.StatusOK, policies)
+}
+
+func (h *Handler) putProfanityPolicy(w http.ResponseWriter, r *http.Request) {
+    var policy models.ProfanityPolicy
+    if err := h.decodeJSON(r, &policy); err != nil {
+        h.respondError(w, http.StatusBadRequest, "Invalid request body")
+        return
+    }
+    for _, action := range []string{policy.MildAction, policy.ModerateAction, policy.SevereAction} {
+        if !moderation.ValidAction(action) {
+            h.respondError(w, http.StatusBadRequest, "Actions must be allow, mask, flag or block")
+            return
+        }
+    }
+    policy.Locale = locale.Normalize(r.PathValue("locale"))
+    policy.UpdatedAt = time.Now()
+
+    if err := h.store.UpsertProfanityPolicy(r.Context(), &policy); err != nil {
+        h.logger.Error("Failed to save profanity policy", zap.Error(err))
+        h.respondError(w, http.StatusInternalServerError, "Failed to save policy")
+        return
+    }
+
+    h.reloadFilters(r)
+    h.respondJSON(w, http.StatusOK, policy)
+}
+
+// reloadFilters applies an admin change on this instance straight away.
+func (h *Handler) reloadFilters(r *http.Request) {
+    if err := h.filters.Reload(r.Context()); err != nil {
+        h.logger.Error("Failed to reload moderation filters", zap.Error(err))
+    }
+}
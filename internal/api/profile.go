@@ -0,0 +1,221 @@
+package api
+
+import (
+    "errors"
+    "io"
+    "mime"
+    "net/http"
+    "strconv"
+    "strings"
+    "unicode/utf8"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/authctx"
+    "github.com/yourusername/sports-chat/internal/avatars"
+    "github.com/yourusername/sports-chat/internal/locale"
+    "github.com/yourusername/sports-chat/internal/localization"
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/moderation"
+    "github.com/yourusername/sports-chat/internal/objectstore"
+)
+
+const (
+    maxBioLength          = 280
+    maxFavoriteTeamLength = 64
+    maxLocaleLength       = 35
+)
+
+// profileRequest holds the profile fields being changed; fields left out
+// keep their value.
+type profileRequest struct {
+    FavoriteTeam *string `json:"favorite_team"`
+    Bio          *string `json:"bio"`
+    // Locale and Timezone localize matches; connections pick changes up
+    // when they reconnect
+    Locale   *string `json:"locale"`
+    Timezone *string `json:"timezone"`
+}
+
+// updateProfile changes the caller's favorite team and bio, and with a
+// multipart body, their avatar: the fields are form values and the image
+// the "avatar" file. Rooms the user joined are told, so chat shows the
+// new avatar and flair without reconnecting.
+func (h *Handler) updateProfile(w http.ResponseWriter, r *http.Request) {
+    principal, _ := authctx.UserFrom(r.Context())
+
+    var req profileRequest
+    var avatar []byte
+    mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+    if mediaType == "multipart/form-data" {
+        var ok bool
+        if req, avatar, ok = h.readProfileForm(w, r); !ok {
+            return
+        }
+    } else if err := h.decodeJSON(r, &req); err != nil {
+        h.respondError(w, http.StatusBadRequest, "Invalid request body")
+        return
+    }
+
+    user, err := h.store.GetUser(r.Context(), principal.UserID)
+    if err != nil {
+        h.respondError(w, http.StatusNotFound, "User not found")
+        return
+    }
+    before := *user.Summary()
+
+    if req.FavoriteTeam != nil {
+        team := strings.TrimSpace(*req.FavoriteTeam)
+        if utf8.RuneCountInString(team) > maxFavoriteTeamLength {
+            h.respondError(w, http.StatusBadRequest, "Favorite team must be at most "+strconv.Itoa(maxFavoriteTeamLength)+" characters")
+            return
+        }
+        if h.profanity.Check(moderation.DefaultLocale, team).Action != models.ActionAllow {
+            h.respondError(w, http.StatusBadRequest, "Favorite team contains blocked words")
+            return
+        }
+        user.FavoriteTeam = team
+    }
+    if req.Bio != nil {
+        bio := strings.TrimSpace(*req.Bio)
+        if utf8.RuneCountInString(bio) > maxBioLength {
+            h.respondError(w, http.StatusBadRequest, "Bio must be at most "+strconv.Itoa(maxBioLength)+" characters")
+            return
+        }
+        result := h.profanity.Check(moderation.DefaultLocale, bio)
+        if result.Action == models.ActionBlock {
+            h.respondError(w, http.StatusBadRequest, "Bio contains blocked words")
+            return
+        }
+        user.Bio = result.Content
+    }
+    if req.Locale != nil {
+        lang := locale.Normalize(*req.Locale)
+        if len(lang) > maxLocaleLength {
+            h.respondError(w, http.StatusBadRequest, "Invalid locale")
+            return
+        }
+        user.Locale = lang
+    }
+    if req.Timezone != nil {
+        timezone := strings.TrimSpace(*req.Timezone)
+        if !localization.ValidTimezone(timezone) {
+            h.respondError(w, http.StatusBadRequest, "Unknown time zone")
+            return
+        }
+        user.Timezone = timezone
+    }
+
+    oldAvatar := user.AvatarURL
+    if avatar != nil {
+        url, err := h.avatars.Upload(r.Context(), user.ID, avatar)
+        if errors.Is(err, avatars.ErrInvalidImage) {
+            h.respondError(w, http.StatusUnsupportedMediaType, err.Error())
+            return
+        }
+        if err != nil {
+            h.logger.Error("Failed to store avatar", zap.Error(err), zap.String("user_id", user.ID))
+            h.respondError(w, http.StatusInternalServerError, "Failed to store avatar")
+            return
+        }
+        user.AvatarURL = url
+    }
+
+    if err := h.store.UpdateUser(r.Context(), user); err != nil {
+        h.logger.Error("Failed to update profile", zap.Error(err), zap.String("user_id", user.ID))
+        if avatar != nil {
+            h.avatars.Remove(r.Context(), user.AvatarURL)
+        }
+        h.respondError(w, http.StatusInternalServerError, "Failed to update profile")
+        return
+    }
+    if avatar != nil && oldAvatar != "" {
+        h.avatars.Remove(r.Context(), oldAvatar)
+    }
+
+    if *user.Summary() != before {
+        if err := h.hub.UserUpdated(r.Context(), user); err != nil {
+            h.logger.Error("Failed to announce profile update", zap.Error(err), zap.String("user_id", user.ID))
+        }
+    }
+
+    h.respondJSON(w, http.StatusOK, user)
+}
+
+// readProfileForm reads a multipart profile update, responding itself
+// when the form is refused.
+func (h *Handler) readProfileForm(w http.ResponseWriter, r *http.Request) (profileRequest, []byte, bool) {
+    var req profileRequest
+    // Room for the form fields on top of the image
+    r.Body = http.MaxBytesReader(w, r.Body, int64(h.maxAvatarSize)+64<<10)
+    if err := r.ParseMultipartForm(int64(h.maxAvatarSize)); err != nil {
+        var tooLarge *http.MaxBytesError
+        if errors.As(err, &tooLarge) {
+            h.respondError(w, http.StatusRequestEntityTooLarge, "Avatar is too large")
+            return req, nil, false
+        }
+        h.respondError(w, http.StatusBadRequest, "Invalid form")
+        return req, nil, false
+    }
+    if values, ok := r.MultipartForm.Value["favorite_team"]; ok && len(values) > 0 {
+        req.FavoriteTeam = &values[0]
+    }
+    if values, ok := r.MultipartForm.Value["bio"]; ok && len(values) > 0 {
+        req.Bio = &values[0]
+    }
+    if values, ok := r.MultipartForm.Value["locale"]; ok && len(values) > 0 {
+        req.Locale = &values[0]
+    }
+    if values, ok := r.MultipartForm.Value["timezone"]; ok && len(values) > 0 {
+        req.Timezone = &values[0]
+    }
+
+    file, header, err := r.FormFile("avatar")
+    if errors.Is(err, http.ErrMissingFile) {
+        return req, nil, true
+    }
+    if err != nil {
+        h.respondError(w, http.StatusBadRequest, "Invalid avatar")
+        return req, nil, false
+    }
+    defer file.Close()
+    if h.avatars == nil {
+        h.respondError(w, http.StatusNotFound, "Avatar uploads are disabled")
+        return req, nil, false
+    }
+    if header.Size > int64(h.maxAvatarSize) {
+        h.respondError(w, http.StatusRequestEntityTooLarge, "Avatar is too large")
+        return req, nil, false
+    }
+    avatar, err := io.ReadAll(file)
+    if err != nil || len(avatar) == 0 {
+        h.respondError(w, http.StatusBadRequest, "Invalid avatar")
+        return req, nil, false
+    }
+    return req, avatar, true
+}
+
+// getAvatar serves a stored avatar. Keys are never reused, so avatars are
+// cached for good.
+func (h *Handler) getAvatar(w http.ResponseWriter, r *http.Request) {
+    if h.avatars == nil {
+        h.respondError(w, http.StatusNotFound, "Avatar not found")
+        return
+    }
+    key := "avatars/" + r.PathValue("user") + "/" + r.PathValue("file")
+
+    data, err := h.avatars.Get(r.Context(), key)
+    if errors.Is(err, objectstore.ErrNotFound) {
+        h.respondError(w, http.StatusNotFound, "Avatar not found")
+        return
+    }
+    if err != nil {
+        h.logger.Error("Failed to read avatar", zap.Error(err), zap.String("key", key))
+        h.respondError(w, http.StatusInternalServerError, "Failed to read avatar")
+        return
+    }
+
+    w.Header().Set("Content-Type", "image/png")
+    w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+    w.Write(data)
+}
@@ -0,0 +1,76 @@
+package api
+
+import (
+    "net/http"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/authctx"
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/quiethours"
+)
+
+func (h *Handler) getQuietHours(w http.ResponseWriter, r *http.Request) {
+    principal, _ := authctx.UserFrom(r.Context())
+
+    quiet, err := h.store.GetQuietHours(r.Context(), principal.UserID)
+    if err != nil {
+        h.respondError(w, http.StatusNotFound, "No quiet hours set")
+        return
+    }
+
+    h.respondJSON(w, http.StatusOK, quiet)
+}
+
+type quietHoursRequest struct {
+    Timezone  string `json:"timezone"`
+    Start     string `json:"start"`
+    End       string `json:"end"`
+    TeamGoals bool   `json:"team_goals"`
+}
+
+func (h *Handler) putQuietHours(w http.ResponseWriter, r *http.Request) {
+    principal, _ := authctx.UserFrom(r.Context())
+
+    var req quietHoursRequest
+    if err := h.decodeJSON(r, &req); err != nil {
+        h.respondError(w, http.StatusBadRequest, "Invalid request body")
+        return
+    }
+
+    quiet := &models.QuietHours{
+        UserID:    principal.UserID,
+        Timezone:  req.Timezone,
+        Start:     req.Start,
+        End:       req.End,
+        TeamGoals: req.TeamGoals,
+        UpdatedAt: time.Now(),
+    }
+    if err := quiethours.Validate(quiet); err != nil {
+        h.respondError(w, http.StatusBadRequest, err.Error())
+        return
+    }
+
+    if err := h.store.SetQuietHours(r.Context(), quiet); err != nil {
+        h.logger.Error("Failed to set quiet hours", zap.Error(err), zap.String("user_id", principal.UserID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to save quiet hours")
+        return
+    }
+    go h.hub.ReloadQuietHours(principal.UserID)
+
+    h.respondJSON(w, http.StatusOK, quiet)
+}
+
+func (h *Handler) deleteQuietHours(w http.ResponseWriter, r *http.Request) {
+    principal, _ := authctx.UserFrom(r.Context())
+
+    if err := h.store.DeleteQuietHours(r.Context(), principal.UserID); err != nil {
+        h.logger.Error("Failed to delete quiet hours", zap.Error(err), zap.String("user_id", principal.UserID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to delete quiet hours")
+        return
+    }
+    go h.hub.ReloadQuietHours(principal.UserID)
+
+    w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,108 @@
+package api
+
+import (
+    "net"
+    "net/http"
+    "strconv"
+    "sync"
+    "time"
+
+    "golang.org/x/time/rate"
+
+    "github.com/yourusername/sports-chat/internal/authctx"
+)
+
+type userLimiter struct {
+    limiter  *rate.Limiter
+    lastSeen time.Time
+}
+
+// rateLimiter applies a per-user token bucket to authenticated REST
+// routes. Idle buckets are swept once per window so the map does not grow
+// with every user that ever made a request.
+type rateLimiter struct {
+    limit  rate.Limit
+    burst  int
+    window time.Duration
+
+    mu        sync.Mutex
+    users     map[string]*userLimiter
+    lastSweep time.Time
+}
+
+func newRateLimiter(requests int, window time.Duration) *rateLimiter {
+    return &rateLimiter{
+        limit:     rate.Every(window / time.Duration(requests)),
+        burst:     requests,
+        window:    window,
+        users:     make(map[string]*userLimiter),
+        lastSweep: time.Now(),
+    }
+}
+
+func (l *rateLimiter) allow(userID string) bool {
+    l.mu.Lock()
+    defer l.mu.Unlock()
+
+    now := time.Now()
+    if now.Sub(l.lastSweep) > l.window {
+        for id, u := range l.users {
+            if now.Sub(u.lastSeen) > l.window {
+                delete(l.users, id)
+            }
+        }
+        l.lastSweep = now
+    }
+
+    u, ok := l.users[userID]
+    if !ok {
+        u = &userLimiter{limiter: rate.NewLimiter(l.limit, l.burst)}
+        l.users[userID] = u
+    }
+    u.lastSeen = now
+    return u.limiter.Allow()
+}
+
+// rateLimit must run after AuthMiddleware. Exempt principals skip the
+// limiter but are still counted per exemption so abuse stays visible.
+func (h *Handler) rateLimit(next http.Handler) http.Handler {
+    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        principal, ok := authctx.UserFrom(r.Context())
+        if !ok {
+            next.ServeHTTP(w, r)
+            return
+        }
+
+        if exemption := principal.Exemption(); exemption != "" {
+            h.metrics.RateLimitExemptions.WithLabelValues("rest", exemption).Inc()
+            next.ServeHTTP(w, r)
+            return
+        }
+
+        if !h.limiter.allow(principal.UserID) {
+            h.metrics.RateLimited.WithLabelValues("rest").Inc()
+            w.Header().Set("Retry-After", strconv.Itoa(int(h.limiter.window.Seconds())))
+            h.respondError(w, http.StatusTooManyRequests, "rate limit exceeded")
+            return
+        }
+        next.ServeHTTP(w, r)
+    })
+}
+
+// publicRateLimit limits unauthenticated routes per client address.
+func (h *Handler) publicRateLimit(next http.Handler) http.Handler {
+    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        ip, _, err := net.SplitHostPort(r.RemoteAddr)
+        if err != nil {
+            ip = r.RemoteAddr
+        }
+
+        if !h.publicLimiter.allow(ip) {
+            h.metrics.RateLimited.WithLabelValues("rest_public").Inc()
+            w.Header().Set("Retry-After", strconv.Itoa(int(h.publicLimiter.window.Seconds())))
+            h.respondError(w, http.StatusTooManyRequests, "rate limit exceeded")
+            return
+        }
+        next.ServeHTTP(w, r)
+    })
+}
@@ -0,0 +1,229 @@
+package api
+
+import (
+    "errors"
+    "net"
+    "net/http"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/auth"
+    "github.com/yourusername/sports-chat/internal/authctx"
+    "github.com/yourusername/sports-chat/internal/recovery"
+    "github.com/yourusername/sports-chat/internal/securitylog"
+)
+
+type recoveryCodesResponse struct {
+    // Codes are shown this once
+    Codes []string `json:"codes"`
+}
+
+type recoveryEmailRequest struct {
+    Email string `json:"email"`
+}
+
+type recoveryTokenRequest struct {
+    Token string `json:"token"`
+}
+
+type recoverByCodeRequest struct {
+    Username string `json:"username"`
+    Code     string `json:"code"`
+}
+
+type recoverByEmailRequest struct {
+    Username string `json:"username"`
+}
+
+type assistRecoveryRequest struct {
+    Reason string `json:"reason"`
+}
+
+type assistRecoveryResponse struct {
+    // Link signs the user in once; support hands it to them
+    Link      string    `json:"link"`
+    ExpiresAt time.Time `json:"expires_at"`
+}
+
+// generateRecoveryCodes replaces the caller's backup codes with a fresh
+// set, which the response shows once.
+func (h *Handler) generateRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+    principal, _ := authctx.UserFrom(r.Context())
+
+    codes, err := h.recovery.GenerateCodes(r.Context(), principal.UserID, h.requestOrigin(r))
+    if err != nil {
+        h.logger.Error("Failed to generate recovery codes", zap.Error(err), zap.String("user_id", principal.UserID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to generate recovery codes")
+        return
+    }
+    h.respondJSON(w, http.StatusOK, &recoveryCodesResponse{Codes: codes})
+}
+
+// setRecoveryEmail sends a confirmation link to a new recovery email; it
+// takes effect once the link is followed.
+func (h *Handler) setRecoveryEmail(w http.ResponseWriter, r *http.Request) {
+    principal, _ := authctx.UserFrom(r.Context())
+
+    var req recoveryEmailRequest
+    if err := h.decodeJSON(r, &req); err != nil {
+        h.respondError(w, http.StatusBadRequest, "Invalid request body")
+        return
+    }
+
+    err := h.recovery.SetEmail(r.Context(), principal.UserID, req.Email)
+    switch {
+    case errors.Is(err, recovery.ErrMailDisabled):
+        h.respondError(w, http.StatusNotFound, "Recovery email is disabled")
+        return
+    case errors.Is(err, recovery.ErrInvalidEmail):
+        h.respondError(w, http.StatusBadRequest, "Invalid email address")
+        return
+    case err != nil:
+        h.logger.Error("Failed to set recovery email", zap.Error(err), zap.String("user_id", principal.UserID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to send confirmation")
+        return
+    }
+    w.WriteHeader(http.StatusAccepted)
+}
+
+func (h *Handler) deleteRecoveryEmail(w http.ResponseWriter, r *http.Request) {
+    principal, _ := authctx.UserFrom(r.Context())
+
+    if err := h.recovery.ClearEmail(r.Context(), principal.UserID, h.requestOrigin(r)); err != nil {
+        h.logger.Error("Failed to remove recovery email", zap.Error(err), zap.String("user_id", principal.UserID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to remove recovery email")
+        return
+    }
+    w.WriteHeader(http.StatusNoContent)
+}
+
+// verifyRecoveryEmail follows a confirmation link. It is public, as the
+// link may be opened anywhere; the token identifies the user.
+func (h *Handler) verifyRecoveryEmail(w http.ResponseWriter, r *http.Request) {
+    var req recoveryTokenRequest
+    if err := h.decodeJSON(r, &req); err != nil {
+        h.respondError(w, http.StatusBadRequest, "Invalid request body")
+        return
+    }
+
+    err := h.recovery.VerifyEmail(r.Context(), req.Token, h.requestOrigin(r))
+    if errors.Is(err, recovery.ErrInvalid) {
+        h.respondError(w, http.StatusBadRequest, "Invalid or expired link")
+        return
+    }
+    if err != nil {
+        h.logger.Error("Failed to verify recovery email", zap.Error(err))
+        h.respondError(w, http.StatusInternalServerError, "Failed to verify recovery email")
+        return
+    }
+    w.WriteHeader(http.StatusNoContent)
+}
+
+// recoverByCode signs a user in with a backup code.
+func (h *Handler) recoverByCode(w http.ResponseWriter, r *http.Request) {
+    var req recoverByCodeRequest
+    if err := h.decodeJSON(r, &req); err != nil || req.Username == "" || req.Code == "" {
+        h.respondError(w, http.StatusBadRequest, "username and code are required")
+        return
+    }
+
+    pair, err := h.recovery.RedeemCode(r.Context(), req.Username, req.Code, h.requestOrigin(r))
+    h.respondRecovered(w, pair, err)
+}
+
+// recoverByEmail sends a recovery link to the user's recovery email. It
+// answers the same whether or not the user exists or has one.
+func (h *Handler) recoverByEmail(w http.ResponseWriter, r *http.Request) {
+    var req recoverByEmailRequest
+    if err := h.decodeJSON(r, &req); err != nil || req.Username == "" {
+        h.respondError(w, http.StatusBadRequest, "username is required")
+        return
+    }
+
+    if err := h.recovery.RequestEmailRecovery(r.Context(), req.Username); errors.Is(err, recovery.ErrMailDisabled) {
+        h.respondError(w, http.StatusNotFound, "Recovery email is disabled")
+        return
+    }
+    w.WriteHeader(http.StatusAccepted)
+}
+
+// recoverByToken signs a user in with a recovery link, sent by email or
+// issued by support.
+func (h *Handler) recoverByToken(w http.ResponseWriter, r *http.Request) {
+    var req recoveryTokenRequest
+    if err := h.decodeJSON(r, &req); err != nil {
+        h.respondError(w, http.StatusBadRequest, "Invalid request body")
+        return
+    }
+
+    pair, err := h.recovery.RedeemToken(r.Context(), req.Token, h.requestOrigin(r))
+    h.respondRecovered(w, pair, err)
+}
+
+func (h *Handler) respondRecovered(w http.ResponseWriter, pair *auth.TokenPair, err error) {
+    switch {
+    case errors.Is(err, recovery.ErrInvalid):
+        h.respondError(w, http.StatusUnauthorized, "Invalid or expired recovery credentials")
+    case errors.Is(err, recovery.ErrTooManyAttempts):
+        h.respondError(w, http.StatusTooManyRequests, "Too many attempts")
+    case errors.Is(err, auth.ErrUserBlocked):
+        h.respondError(w, http.StatusForbidden, "Account is suspended")
+    case err != nil:
+        h.logger.Error("Failed to recover account", zap.Error(err))
+        h.respondError(w, http.StatusInternalServerError, "Failed to recover account")
+    default:
+        h.respondJSON(w, http.StatusOK, pair)
+    }
+}
+
+// assistRecovery issues a one-time recovery link for support to hand to
+// a user whose identity they checked. The reason is required and goes in
+// the user's security log.
+func (h *Handler) assistRecovery(w http.ResponseWriter, r *http.Request) {
+    principal, _ := authctx.UserFrom(r.Context())
+    userID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+
+    var req assistRecoveryRequest
+    if err := h.decodeJSON(r, &req); err != nil {
+        h.respondError(w, http.StatusBadRequest, "Invalid request body")
+        return
+    }
+    if _, err := h.store.GetUser(r.Context(), userID); err != nil {
+        h.respondError(w, http.StatusNotFound, "User not found")
+        return
+    }
+
+    actor := recovery.Actor{UserID: principal.UserID, Username: principal.Username}
+    link, token, err := h.recovery.Assist(r.Context(), actor, userID, req.Reason, h.requestOrigin(r))
+    switch {
+    case errors.Is(err, recovery.ErrReasonRequired):
+        h.respondError(w, http.StatusBadRequest, "reason is required")
+        return
+    case err != nil:
+        h.logger.Error("Failed to issue assisted recovery", zap.Error(err), zap.String("user_id", userID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to issue recovery")
+        return
+    }
+
+    h.respondJSON(w, http.StatusCreated, &assistRecoveryResponse{
+        Link:      link,
+        ExpiresAt: token.ExpiresAt,
+    })
+}
+
+// requestOrigin is where a request came from, for the security log.
+func (h *Handler) requestOrigin(r *http.Request) recovery.Origin {
+    ip, _, err := net.SplitHostPort(r.RemoteAddr)
+    if err != nil {
+        ip = r.RemoteAddr
+    }
+    return recovery.Origin{
+        IP:      ip,
+        Country: securitylog.Country(r, h.countryHeader),
+        Device:  securitylog.Device(r.UserAgent()),
+    }
+}
@@ -0,0 +1,192 @@
+package api
+
+import (
+    "errors"
+    "net/http"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/authctx"
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/rbac"
+    "github.com/yourusername/sports-chat/internal/websocket"
+)
+
+// roomAction is authed for routes acting on room {id} that also need a
+// permission there. Site admins hold every permission in every room.
+func (h *Handler) roomAction(perm rbac.Permission, fn http.HandlerFunc) http.Handler {
+    return h.authed(func(w http.ResponseWriter, r *http.Request) {
+        roomID, ok := h.pathID(w, r, "id")
+        if !ok {
+            return
+        }
+        principal, _ := authctx.UserFrom(r.Context())
+        if !h.roles.Can(r.Context(), principal, roomID, perm) {
+            h.respondError(w, http.StatusForbidden, "Insufficient permissions in this room")
+            return
+        }
+        fn(w, r)
+    })
+}
+
+// listRoomRoles shows who holds a role in a room; everyone else is a
+// member.
+func (h *Handler) listRoomRoles(w http.ResponseWriter, r *http.Request) {
+    roomID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+
+    if _, err := h.store.GetChatRoom(r.Context(), roomID); err != nil {
+        h.respondError(w, http.StatusNotFound, "Room not found")
+        return
+    }
+
+    roles, err := h.store.ListRoomRoles(r.Context(), roomID)
+    if err != nil {
+        h.logger.Error("Failed to list room roles", zap.Error(err), zap.String("room", roomID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to load roles")
+        return
+    }
+    if roles == nil {
+        roles = []*models.RoomRole{}
+    }
+
+    h.respondJSON(w, http.StatusOK, roles)
+}
+
+type roomRoleRequest struct {
+    Role string `json:"role"`
+}
+
+// setRoomRole grants a user a role in the room. The granter must outrank
+// both the role and the user's current one, so owners appoint moderators
+// and VIPs while only admins appoint owners.
+func (h *Handler) setRoomRole(w http.ResponseWriter, r *http.Request) {
+    principal, _ := authctx.UserFrom(r.Context())
+    roomID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+    userID, ok := h.pathID(w, r, "userId")
+    if !ok {
+        return
+    }
+
+    var req roomRoleRequest
+    if err := h.decodeJSON(r, &req); err != nil || !rbac.ValidRole(req.Role) {
+        h.respondError(w, http.StatusBadRequest, "Role must be owner, moderator, vip or broadcaster")
+        return
+    }
+    if _, err := h.store.GetUser(r.Context(), userID); err != nil {
+        h.respondError(w, http.StatusNotFound, "User not found")
+        return
+    }
+    if !h.roles.CanGrant(r.Context(), principal, roomID, req.Role) || !h.roles.Outranks(r.Context(), principal, roomID, userID) {
+        h.respondError(w, http.StatusForbidden, "You can only manage roles below your own")
+        return
+    }
+
+    role := &models.RoomRole{
+        RoomID:    roomID,
+        UserID:    userID,
+        Role:      req.Role,
+        GrantedBy: principal.UserID,
+    }
+    if err := h.store.SetRoomRole(r.Context(), role); err != nil {
+        h.logger.Error("Failed to set room role", zap.Error(err), zap.String("room", roomID), zap.String("user_id", userID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to set role")
+        return
+    }
+    h.roles.Forget(roomID, userID)
+    h.hub.RoomRoleChanged(roomID, userID, role.Role)
+
+    h.logger.Info("Room role granted",
+        zap.String("room", roomID),
+        zap.String("user_id", userID),
+        zap.String("role", role.Role),
+        zap.String("actor", principal.UserID))
+    h.respondJSON(w, http.StatusOK, role)
+}
+
+// deleteRoomRole makes a user a plain member of the room again.
+func (h *Handler) deleteRoomRole(w http.ResponseWriter, r *http.Request) {
+    principal, _ := authctx.UserFrom(r.Context())
+    roomID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+    userID, ok := h.pathID(w, r, "userId")
+    if !ok {
+        return
+    }
+
+    if !h.roles.Outranks(r.Context(), principal, roomID, userID) {
+        h.respondError(w, http.StatusForbidden, "You can only manage roles below your own")
+        return
+    }
+
+    found, err := h.store.DeleteRoomRole(r.Context(), roomID, userID)
+    if err != nil {
+        h.logger.Error("Failed to delete room role", zap.Error(err), zap.String("room", roomID), zap.String("user_id", userID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to revoke role")
+        return
+    }
+    if !found {
+        h.respondError(w, http.StatusNotFound, "User has no role in this room")
+        return
+    }
+    h.roles.Forget(roomID, userID)
+    h.hub.RoomRoleChanged(roomID, userID, models.RoomRoleMember)
+
+    h.logger.Info("Room role revoked",
+        zap.String("room", roomID),
+        zap.String("user_id", userID),
+        zap.String("actor", principal.UserID))
+    w.WriteHeader(http.StatusNoContent)
+}
+
+type pinMessageRequest struct {
+    MessageID string `json:"message_id"`
+}
+
+func (h *Handler) pinRoomMessage(w http.ResponseWriter, r *http.Request) {
+    roomID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+
+    var req pinMessageRequest
+    if err := h.decodeJSON(r, &req); err != nil || req.MessageID == "" {
+        h.respondError(w, http.StatusBadRequest, "Invalid request body")
+        return
+    }
+    h.setPinnedMessage(w, r, roomID, req.MessageID)
+}
+
+func (h *Handler) unpinRoomMessage(w http.ResponseWriter, r *http.Request) {
+    roomID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+    h.setPinnedMessage(w, r, roomID, "")
+}
+
+func (h *Handler) setPinnedMessage(w http.ResponseWriter, r *http.Request, roomID, messageID string) {
+    err := h.hub.PinMessage(r.Context(), roomID, messageID)
+    if errors.Is(err, websocket.ErrMessageNotFound) {
+        h.respondError(w, http.StatusNotFound, "Message not found")
+        return
+    }
+    if err != nil {
+        h.logger.Error("Failed to pin message", zap.Error(err), zap.String("room", roomID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to pin message")
+        return
+    }
+
+    h.logger.Info("Pinned message changed",
+        zap.String("room", roomID),
+        zap.String("message_id", messageID),
+        zap.String("actor", authctx.Actor(r.Context())))
+    w.WriteHeader(http.StatusNoContent)
+}
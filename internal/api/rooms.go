@@ -0,0 +1,387 @@
+package api
+
+import (
+    "errors"
+    "net/http"
+    "strconv"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/authctx"
+    "github.com/yourusername/sports-chat/internal/cdn"
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/store"
+    "github.com/yourusername/sports-chat/internal/websocket"
+)
+
+// getRoomMessages is the REST equivalent of the websocket history
+// command, taking the same cursors: ?before pages back for infinite
+// scroll and ?after catches up on messages sent since. ?user and ?kind
+// (media or system) filter the history.
+func (h *Handler) getRoomMessages(w http.ResponseWriter, r *http.Request) {
+    roomID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+    query := r.URL.Query()
+
+    if !h.admitReader(w, r, roomID) {
+        return
+    }
+
+    limit, _ := strconv.Atoi(query.Get("limit"))
+    filter := store.MessageFilter{UserID: query.Get("user"), Kind: query.Get("kind")}
+    page, err := websocket.LoadHistoryPage(r.Context(), h.store, roomID, filter, query.Get("before"), query.Get("after"), websocket.ClampHistoryLimit(limit))
+    if errors.Is(err, websocket.ErrInvalidCursor) {
+        h.respondError(w, http.StatusBadRequest, "Invalid cursor")
+        return
+    }
+    if errors.Is(err, websocket.ErrInvalidFilter) {
+        h.respondError(w, http.StatusBadRequest, "Invalid filter")
+        return
+    }
+    if err != nil {
+        h.logger.Error("Failed to get room messages", zap.Error(err), zap.String("room", roomID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to load messages")
+        return
+    }
+
+    h.respondJSON(w, http.StatusOK, page)
+}
+
+// getRoomPresence returns who is connected to a room across instances.
+func (h *Handler) getRoomPresence(w http.ResponseWriter, r *http.Request) {
+    roomID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+
+    if !h.admitReader(w, r, roomID) {
+        return
+    }
+
+    presence, err := h.hub.RoomPresence(r.Context(), roomID)
+    if err != nil {
+        h.logger.Error("Failed to get room presence", zap.Error(err), zap.String("room", roomID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to load presence")
+        return
+    }
+
+    h.respondJSON(w, http.StatusOK, presence)
+}
+
+// admitReader checks that the caller may read a room, as the websocket
+// checks when they join it: the room must exist, and unless they are an
+// admin they must be old enough for it and not banned from it. It
+// responds itself when they may not.
+func (h *Handler) admitReader(w http.ResponseWriter, r *http.Request, roomID string) bool {
+    ctx := r.Context()
+    principal, _ := authctx.UserFrom(ctx)
+
+    room, err := h.store.GetChatRoom(ctx, roomID)
+    if err != nil {
+        h.respondRoomMissing(w, r, roomID)
+        return false
+    }
+    if principal.IsAdmin {
+        return true
+    }
+
+    now := time.Now()
+    if room.MinAge > 0 {
+        user, err := h.store.GetUser(ctx, principal.UserID)
+        if err != nil {
+            h.logger.Error("Failed to load user", zap.Error(err), zap.String("user_id", principal.UserID))
+            h.respondError(w, http.StatusInternalServerError, "Failed to load room")
+            return false
+        }
+        if !room.Admits(user, now) {
+            h.respondError(w, http.StatusForbidden, "Room is age restricted")
+            return false
+        }
+    }
+
+    sanctions, err := h.store.GetRoomSanctions(ctx, roomID, principal.UserID)
+    if err != nil {
+        h.logger.Error("Failed to load room sanctions", zap.Error(err), zap.String("room", roomID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to load room")
+        return false
+    }
+    for _, sanction := range sanctions {
+        if sanction.Kind == models.SanctionBan && sanction.Active(now) {
+            h.respondError(w, http.StatusForbidden, "You are banned from this room")
+            return false
+        }
+    }
+    return true
+}
+
+// getRoomLanguages lists the languages a room's messages were detected in,
+// most used first, so clients can offer the matching language channel.
+func (h *Handler) getRoomLanguages(w http.ResponseWriter, r *http.Request) {
+    roomID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+
+    if _, err := h.store.GetChatRoom(r.Context(), roomID); err != nil {
+        h.respondError(w, http.StatusNotFound, "Room not found")
+        return
+    }
+
+    languages, err := h.store.GetRoomLanguages(r.Context(), roomID)
+    if err != nil {
+        h.logger.Error("Failed to get room languages", zap.Error(err), zap.String("room", roomID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to load languages")
+        return
+    }
+    if languages == nil {
+        languages = []*models.LanguageShare{}
+    }
+
+    h.respondJSON(w, http.StatusOK, languages)
+}
+
+type initialHistoryRequest struct {
+    Limit int `json:"limit"`
+}
+
+// setRoomInitialHistory sets how many messages a room sends on connect.
+// Zero restores the default.
+func (h *Handler) setRoomInitialHistory(w http.ResponseWriter, r *http.Request) {
+    roomID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+
+    var req initialHistoryRequest
+    if err := h.decodeJSON(r, &req); err != nil || req.Limit < 0 || req.Limit > websocket.MaxHistoryPage {
+        h.respondError(w, http.StatusBadRequest, "Limit must be between 0 and "+strconv.Itoa(websocket.MaxHistoryPage))
+        return
+    }
+
+    room, err := h.store.GetChatRoom(r.Context(), roomID)
+    if err != nil {
+        h.respondError(w, http.StatusNotFound, "Room not found")
+        return
+    }
+
+    room.InitialHistory = req.Limit
+    if err := h.store.UpdateChatRoom(r.Context(), room); err != nil {
+        h.logger.Error("Failed to update room", zap.Error(err), zap.String("room", roomID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to update room")
+        return
+    }
+
+    h.respondJSON(w, http.StatusOK, room)
+}
+
+// roomPreview is the public, read-only view of a room used for link
+// sharing and landing pages. It deliberately carries less than the
+// authenticated endpoints: no user IDs, no per-message reactions, no match
+// data blob.
+type roomPreview struct {
+    ID          string            `json:"id"`
+    Name        string            `json:"name"`
+    Description string            `json:"description,omitempty"`
+    Language    string            `json:"language,omitempty"`
+    Match       *matchSnapshot    `json:"match,omitempty"`
+    Messages    []*previewMessage `json:"messages"`
+    // Hype is the room's hype meter, aggregate counts only
+    Hype *models.RoomHype `json:"hype,omitempty"`
+}
+
+type matchSnapshot struct {
+    ID        string        `json:"id"`
+    HomeTeam  string        `json:"home_team"`
+    AwayTeam  string        `json:"away_team"`
+    HomeScore int           `json:"home_score"`
+    AwayScore int           `json:"away_score"`
+    Status    string        `json:"status"`
+    Period    string        `json:"period,omitempty"`
+    Minute    int           `json:"minute,omitempty"`
+    StartTime time.Time     `json:"start_time"`
+    Venue     *models.Venue `json:"venue,omitempty"`
+    // Localized is the match in the locale and time zone asked for
+    Localized *models.MatchLocale `json:"localized,omitempty"`
+}
+
+type previewMessage struct {
+    Username  string    `json:"username"`
+    AvatarURL string    `json:"avatar_url,omitempty"`
+    Content   string    `json:"content"`
+    CreatedAt time.Time `json:"created_at"`
+}
+
+// getRoomPreview is served without authentication. Messages are run
+// through the room's profanity policy again, with anything that would now
+// be blocked left out.
+func (h *Handler) getRoomPreview(w http.ResponseWriter, r *http.Request) {
+    ctx := r.Context()
+    roomID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+
+    room, err := h.store.GetChatRoom(ctx, roomID)
+    if err != nil || !room.IsActive {
+        h.respondRoomMissing(w, r, roomID)
+        return
+    }
+    // Nobody's age is known without an account
+    if room.MinAge > 0 {
+        h.respondError(w, http.StatusForbidden, "Room is age restricted")
+        return
+    }
+
+    preview := &roomPreview{
+        ID:          room.ID,
+        Name:        room.Name,
+        Description: room.Description,
+        Language:    room.Language,
+        Messages:    []*previewMessage{},
+        Hype:        h.hub.RoomHype(room.ID),
+    }
+
+    if room.MatchID != "" {
+        if match, err := h.store.GetMatch(ctx, room.MatchID); err == nil {
+            locale, timezone := queryLocale(r)
+            preview.Match = h.localizeSnapshot(newMatchSnapshot(match), match, locale, timezone)
+        }
+    }
+
+    if h.previewMessages > 0 {
+        messages, err := h.store.GetRecentMessages(ctx, room.ID, h.previewMessages)
+        if err != nil {
+            h.logger.Error("Failed to get preview messages", zap.Error(err), zap.String("room", room.ID))
+            h.respondError(w, http.StatusInternalServerError, "Failed to load preview")
+            return
+        }
+        for _, msg := range messages {
+            if msg.MessageType != "" && msg.MessageType != models.MessageTypeChat {
+                continue
+            }
+            result := h.profanity.Check(room.Language, msg.Content)
+            if result.Action == models.ActionBlock {
+                continue
+            }
+            pm := &previewMessage{Content: result.Content, CreatedAt: msg.CreatedAt}
+            if msg.User != nil {
+                pm.Username = msg.User.Username
+                pm.AvatarURL = msg.User.AvatarURL
+            }
+            preview.Messages = append(preview.Messages, pm)
+        }
+    }
+
+    w.Header().Set("Cache-Control", "public, max-age=30")
+    if preview.Match != nil {
+        // The messages only age out, but the score is purged on change
+        w.Header().Set("Surrogate-Key", cdn.MatchKey(preview.Match.ID))
+    }
+    h.respondJSON(w, http.StatusOK, preview)
+}
+
+func newMatchSnapshot(match *models.Match) *matchSnapshot {
+    snapshot := &matchSnapshot{
+        ID:        match.ID,
+        HomeTeam:  match.HomeTeamID,
+        AwayTeam:  match.AwayTeamID,
+        HomeScore: match.HomeScore,
+        AwayScore: match.AwayScore,
+        Status:    match.Status,
+        Period:    match.Period,
+        Minute:    match.Minute,
+        StartTime: match.StartTime,
+        Venue:     match.Venue,
+    }
+    if match.HomeTeam != nil {
+        snapshot.HomeTeam = match.HomeTeam.Name
+    }
+    if match.AwayTeam != nil {
+        snapshot.AwayTeam = match.AwayTeam.Name
+    }
+    return snapshot
+}
+
+// getJoinedRoomSummaries returns everything the room switcher shows for
+// each of the caller's rooms, so it loads with one call.
+func (h *Handler) getJoinedRoomSummaries(w http.ResponseWriter, r *http.Request) {
+    principal, _ := authctx.UserFrom(r.Context())
+
+    summaries, err := h.store.GetUserRoomSummaries(r.Context(), principal.UserID)
+    if err != nil {
+        h.logger.Error("Failed to get room summaries", zap.Error(err), zap.String("user_id", principal.UserID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to load rooms")
+        return
+    }
+
+    rooms := make([]string, 0, len(summaries))
+    for _, summary := range summaries {
+        rooms = append(rooms, summary.Room.ID)
+    }
+    // Viewer counts are a nicety; the switcher still loads without them
+    counts, err := h.hub.RoomViewerCounts(r.Context(), rooms)
+    if err != nil {
+        h.logger.Warn("Failed to get viewer counts", zap.Error(err))
+    }
+    for _, summary := range summaries {
+        summary.ViewerCount = counts[summary.Room.ID]
+    }
+
+    h.respondJSON(w, http.StatusOK, summaries)
+}
+
+// markRoomRead clears the caller's unread count for a room.
+func (h *Handler) markRoomRead(w http.ResponseWriter, r *http.Request) {
+    principal, _ := authctx.UserFrom(r.Context())
+    roomID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+
+    if err := h.hub.MarkRoomRead(r.Context(), principal.UserID, roomID, time.Now()); err != nil {
+        h.logger.Error("Failed to mark room read", zap.Error(err), zap.String("room", roomID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to mark room read")
+        return
+    }
+
+    w.WriteHeader(http.StatusNoContent)
+}
+
+// getUnreadCounts returns the caller's unread count and read marker for
+// every joined room, for badges on clients that don't need the full
+// summaries.
+func (h *Handler) getUnreadCounts(w http.ResponseWriter, r *http.Request) {
+    principal, _ := authctx.UserFrom(r.Context())
+
+    counts, err := h.store.GetUnreadCounts(r.Context(), principal.UserID)
+    if err != nil {
+        h.logger.Error("Failed to get unread counts", zap.Error(err), zap.String("user_id", principal.UserID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to load unread counts")
+        return
+    }
+    if counts == nil {
+        counts = []*models.UnreadCount{}
+    }
+
+    h.respondJSON(w, http.StatusOK, counts)
+}
+
+type roomGoneResponse struct {
+    Error string                `json:"error"`
+    Room  *models.RoomTombstone `json:"room"`
+}
+
+// respondRoomMissing answers a request for a room that could not be
+// loaded or is closed: 410 Gone with its tombstone if an admin deleted
+// it, so clients can follow the replacement, and 404 otherwise.
+func (h *Handler) respondRoomMissing(w http.ResponseWriter, r *http.Request, roomID string) {
+    tombstone, err := h.store.GetRoomTombstone(r.Context(), roomID)
+    if err != nil {
+        h.respondError(w, http.StatusNotFound, "Room not found")
+        return
+    }
+    h.respondJSON(w, http.StatusGone, &roomGoneResponse{Error: "Room was deleted", Room: tombstone})
+}
@@ -0,0 +1,42 @@
+package api
+
+import (
+    "net/http"
+    "strconv"
+    "strings"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/search/opensearch"
+)
+
+// searchMessages runs a fuzzy search of chat messages for moderators,
+// optionally in one room with ?room_id=. Each hit carries the matching
+// fragments, and the counts per room show where a phrase is being posted.
+func (h *Handler) searchMessages(w http.ResponseWriter, r *http.Request) {
+    if h.search == nil {
+        h.respondError(w, http.StatusNotFound, "Message search needs the OpenSearch backend")
+        return
+    }
+    query := strings.TrimSpace(r.URL.Query().Get("q"))
+    if query == "" {
+        h.respondError(w, http.StatusBadRequest, "A search query is required")
+        return
+    }
+    limit := 20
+    if v, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && v > 0 && v <= 100 {
+        limit = v
+    }
+
+    result, err := h.search.Search(r.Context(), opensearch.SearchRequest{
+        Query:  query,
+        RoomID: r.URL.Query().Get("room_id"),
+        Limit:  limit,
+    })
+    if err != nil {
+        h.logger.Error("Failed to search messages", zap.Error(err))
+        h.respondError(w, http.StatusInternalServerError, "Failed to search messages")
+        return
+    }
+    h.respondJSON(w, http.StatusOK, result)
+}
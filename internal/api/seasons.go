@@ -0,0 +1,129 @@
+package api
+
+import (
+    "net/http"
+    "strconv"
+    "strings"
+    "time"
+    "unicode/utf8"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/predictions"
+)
+
+const maxSeasonName = 100
+
+type seasonStandingsResponse struct {
+    Season    *models.Season           `json:"season"`
+    Standings []*models.SeasonStanding `json:"standings"`
+}
+
+// listSeasons lists prediction seasons newest first, running and past,
+// optionally of one competition with ?competition=.
+func (h *Handler) listSeasons(w http.ResponseWriter, r *http.Request) {
+    seasons, err := h.store.ListSeasons(r.Context(), r.URL.Query().Get("competition"))
+    if err != nil {
+        h.logger.Error("Failed to list seasons", zap.Error(err))
+        h.respondError(w, http.StatusInternalServerError, "Failed to list seasons")
+        return
+    }
+    if seasons == nil {
+        seasons = []*models.Season{}
+    }
+
+    h.respondJSON(w, http.StatusOK, seasons)
+}
+
+// getCurrentSeason returns the season running now, of the competition in
+// ?competition= or the site-wide one.
+func (h *Handler) getCurrentSeason(w http.ResponseWriter, r *http.Request) {
+    season, err := h.store.GetSeasonAt(r.Context(), r.URL.Query().Get("competition"), time.Now())
+    if err != nil {
+        h.respondError(w, http.StatusNotFound, "No season is running")
+        return
+    }
+
+    h.respondJSON(w, http.StatusOK, season)
+}
+
+// getSeasonStandings returns a season's prediction table: live while the
+// season runs, as frozen with its badges once archived.
+func (h *Handler) getSeasonStandings(w http.ResponseWriter, r *http.Request) {
+    limit := 50
+    if v, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && v > 0 && v <= 500 {
+        limit = v
+    }
+
+    seasonID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+    season, err := h.store.GetSeason(r.Context(), seasonID)
+    if err != nil {
+        h.respondError(w, http.StatusNotFound, "Season not found")
+        return
+    }
+
+    standings, err := h.store.GetSeasonStandings(r.Context(), season, limit)
+    if err != nil {
+        h.logger.Error("Failed to get season standings", zap.Error(err), zap.String("season_id", season.ID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to get season standings")
+        return
+    }
+    if standings == nil {
+        standings = []*models.SeasonStanding{}
+    }
+
+    h.respondJSON(w, http.StatusOK, &seasonStandingsResponse{Season: season, Standings: standings})
+}
+
+type createSeasonRequest struct {
+    // Name defaults to the season's years, such as "2025/26"
+    Name          string    `json:"name"`
+    CompetitionID string    `json:"competition_id"`
+    StartsAt      time.Time `json:"starts_at"`
+    EndsAt        time.Time `json:"ends_at"`
+}
+
+// createSeason sets up a season. Seasons after the first usually need no
+// admin: each one opens the next when it ends.
+func (h *Handler) createSeason(w http.ResponseWriter, r *http.Request) {
+    ctx := r.Context()
+
+    var req createSeasonRequest
+    if err := h.decodeJSON(r, &req); err != nil {
+        h.respondError(w, http.StatusBadRequest, "Invalid request body")
+        return
+    }
+    req.Name = strings.TrimSpace(req.Name)
+    if req.Name == "" && !req.StartsAt.IsZero() {
+        req.Name = predictions.SeasonName(req.StartsAt, req.EndsAt)
+    }
+    if req.StartsAt.IsZero() || !req.EndsAt.After(req.StartsAt) || utf8.RuneCountInString(req.Name) > maxSeasonName {
+        h.respondError(w, http.StatusBadRequest, "Seasons need a start, an end after it and a name of up to 100 characters")
+        return
+    }
+
+    for _, at := range []time.Time{req.StartsAt, req.EndsAt.Add(-time.Nanosecond)} {
+        if existing, err := h.store.GetSeasonAt(ctx, req.CompetitionID, at); err == nil {
+            h.respondError(w, http.StatusConflict, "Season overlaps "+existing.Name)
+            return
+        }
+    }
+
+    season := &models.Season{
+        Name:          req.Name,
+        CompetitionID: req.CompetitionID,
+        StartsAt:      req.StartsAt,
+        EndsAt:        req.EndsAt,
+    }
+    if err := h.store.CreateSeason(ctx, season); err != nil {
+        h.logger.Error("Failed to create season", zap.Error(err))
+        h.respondError(w, http.StatusInternalServerError, "Failed to create season")
+        return
+    }
+
+    h.respondJSON(w, http.StatusCreated, season)
+}
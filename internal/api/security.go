@@ -0,0 +1,164 @@
+package api
+
+import (
+    "net"
+    "net/http"
+    "strconv"
+    "sync"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/authctx"
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/securitylog"
+)
+
+const (
+    defaultSecurityEvents = 50
+    maxSecurityEvents     = 200
+
+    // revocationTTL is how long a user's session revocation time is
+    // cached; a revocation on another instance takes this long to reach
+    // REST requests here. Websockets are closed everywhere at once.
+    revocationTTL = 30 * time.Second
+)
+
+type cachedRevocation struct {
+    at       *time.Time
+    loadedAt time.Time
+}
+
+// revocations caches when each user last had their sessions revoked.
+type revocations struct {
+    mu    sync.RWMutex
+    users map[string]*cachedRevocation
+}
+
+func newRevocations() *revocations {
+    return &revocations{users: make(map[string]*cachedRevocation)}
+}
+
+// checkSession rejects tokens issued before their user's sessions were
+// revoked. It runs after authentication.
+func (h *Handler) checkSession(next http.Handler) http.Handler {
+    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        principal, ok := authctx.UserFrom(r.Context())
+        if ok {
+            if revokedAt := h.sessionsRevokedAt(r, principal.UserID); revokedAt != nil && principal.IssuedAt.Before(*revokedAt) {
+                http.Error(w, "Session revoked", http.StatusUnauthorized)
+                return
+            }
+        }
+        next.ServeHTTP(w, r)
+    })
+}
+
+// sessionsRevokedAt returns the user's revocation time, loading it at
+// most once per TTL. A failed lookup lets the request through rather
+// than locking everyone out during a store outage.
+func (h *Handler) sessionsRevokedAt(r *http.Request, userID string) *time.Time {
+    h.revocations.mu.RLock()
+    cached, ok := h.revocations.users[userID]
+    h.revocations.mu.RUnlock()
+    if ok && time.Since(cached.loadedAt) < revocationTTL {
+        return cached.at
+    }
+
+    user, err := h.store.GetUser(r.Context(), userID)
+    if err != nil {
+        return nil
+    }
+    h.setRevocation(userID, user.SessionsRevokedAt)
+    return user.SessionsRevokedAt
+}
+
+func (h *Handler) setRevocation(userID string, at *time.Time) {
+    h.revocations.mu.Lock()
+    h.revocations.users[userID] = &cachedRevocation{at: at, loadedAt: time.Now()}
+    h.revocations.mu.Unlock()
+}
+
+// getSecurityActivity lists the caller's recent sign-ins and account
+// changes, marking the sign-in of the session making the request.
+func (h *Handler) getSecurityActivity(w http.ResponseWriter, r *http.Request) {
+    principal, _ := authctx.UserFrom(r.Context())
+
+    limit := defaultSecurityEvents
+    if raw := r.URL.Query().Get("limit"); raw != "" {
+        n, err := strconv.Atoi(raw)
+        if err != nil || n <= 0 || n > maxSecurityEvents {
+            h.respondError(w, http.StatusBadRequest, "Invalid limit")
+            return
+        }
+        limit = n
+    }
+
+    activity, err := h.store.GetUserSecurityEvents(r.Context(), principal.UserID, limit)
+    if err != nil {
+        h.logger.Error("Failed to list security activity", zap.Error(err), zap.String("user_id", principal.UserID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to load activity")
+        return
+    }
+    for _, event := range activity {
+        event.Current = event.Kind == models.SecuritySignIn && event.SessionID != "" && event.SessionID == principal.SessionID
+    }
+
+    h.respondJSON(w, http.StatusOK, activity)
+}
+
+// disputeSecurityActivity handles "this wasn't me": the entry is marked
+// disputed and every session issued until now, the caller's included, is
+// signed out.
+func (h *Handler) disputeSecurityActivity(w http.ResponseWriter, r *http.Request) {
+    principal, _ := authctx.UserFrom(r.Context())
+    id, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+
+    now := time.Now()
+    found, err := h.store.DisputeSecurityEvent(r.Context(), principal.UserID, id, now)
+    if err != nil {
+        h.logger.Error("Failed to dispute security event", zap.Error(err), zap.String("user_id", principal.UserID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to revoke sessions")
+        return
+    }
+    if !found {
+        h.respondError(w, http.StatusNotFound, "Activity not found")
+        return
+    }
+
+    h.setRevocation(principal.UserID, &now)
+    h.hub.DisconnectUser(principal.UserID)
+    h.recordSecurityEvent(r, principal, models.SecuritySessionsRevoked, "Disputed activity "+id)
+
+    h.logger.Info("Sessions revoked after disputed activity",
+        zap.String("user_id", principal.UserID),
+        zap.String("event_id", id))
+    w.WriteHeader(http.StatusNoContent)
+}
+
+// recordSecurityEvent logs a sensitive account change made by the caller.
+// Failing to log it does not fail the change.
+func (h *Handler) recordSecurityEvent(r *http.Request, principal *authctx.Principal, kind, detail string) {
+    ip, _, err := net.SplitHostPort(r.RemoteAddr)
+    if err != nil {
+        ip = r.RemoteAddr
+    }
+    event := &models.SecurityEvent{
+        UserID:    principal.UserID,
+        Kind:      kind,
+        SessionID: principal.SessionID,
+        IP:        ip,
+        Country:   securitylog.Country(r, h.countryHeader),
+        Device:    securitylog.Device(r.UserAgent()),
+        Detail:    detail,
+    }
+    if err := h.store.RecordSecurityEvent(r.Context(), event); err != nil {
+        h.logger.Error("Failed to record security event",
+            zap.Error(err),
+            zap.String("user_id", principal.UserID),
+            zap.String("kind", kind))
+    }
+}
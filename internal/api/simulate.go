@@ -0,0 +1,176 @@
+package api
+
+import (
+    "encoding/json"
+    "net/http"
+    "strconv"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/authctx"
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/sportsdata"
+)
+
+const (
+    defaultJoinSpike = 50
+    maxJoinSpike     = 1000
+    // simulatedFilter is the filter name simulated reports are queued
+    // under, so reviewers can tell them apart
+    simulatedFilter = "simulator"
+)
+
+type simulateGoalRequest struct {
+    Side   string `json:"side"`
+    Scorer string `json:"scorer"`
+}
+
+// simulatedGoal is the match as the goal left it, and the push that
+// scored it, signed when ingestion is on, to replay against the ingest
+// endpoint.
+type simulatedGoal struct {
+    Match     *models.Match   `json:"match"`
+    Fixture   json.RawMessage `json:"fixture"`
+    Signature string          `json:"signature,omitempty"`
+}
+
+// simulateGoal scores a goal in a match through the provider push
+// pipeline: the match is saved, its room is sent the goal and the
+// MatchUpdated event fires, as for a real goal.
+func (h *Handler) simulateGoal(w http.ResponseWriter, r *http.Request) {
+    if !h.simulator {
+        h.respondError(w, http.StatusNotFound, "Simulator is disabled")
+        return
+    }
+
+    var req simulateGoalRequest
+    if err := h.decodeJSON(r, &req); err != nil {
+        h.respondError(w, http.StatusBadRequest, "Invalid request body")
+        return
+    }
+
+    matchID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+    match, err := h.store.GetMatch(r.Context(), matchID)
+    if err != nil {
+        h.respondError(w, http.StatusNotFound, "Match not found")
+        return
+    }
+    if match.Status == models.MatchStatusFinished || match.Status == models.MatchStatusCancelled {
+        h.respondError(w, http.StatusConflict, "Match is over")
+        return
+    }
+
+    fixture, err := sportsdata.GoalFixture(match, req.Side, req.Scorer)
+    if err != nil {
+        h.respondError(w, http.StatusBadRequest, "Side must be home or away")
+        return
+    }
+    push, err := sportsdata.ParsePush(fixture)
+    if err != nil {
+        h.respondError(w, http.StatusInternalServerError, "Failed to build goal fixture")
+        return
+    }
+    if match, err = h.hub.PushMatch(r.Context(), match, push); err != nil {
+        h.logger.Error("Failed to simulate goal", zap.Error(err), zap.String("match_id", matchID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to simulate goal")
+        return
+    }
+
+    goal := &simulatedGoal{Match: match, Fixture: fixture}
+    if len(h.ingestSecret) > 0 {
+        goal.Signature = sportsdata.Sign(h.ingestSecret, fixture, time.Now())
+    }
+    h.respondJSON(w, http.StatusOK, goal)
+}
+
+type simulateReportRequest struct {
+    Content string `json:"content"`
+    Reason  string `json:"reason"`
+}
+
+// simulateReport posts a message from the caller into a room and queues
+// it for review, as a moderation filter flagging it would.
+func (h *Handler) simulateReport(w http.ResponseWriter, r *http.Request) {
+    if !h.simulator {
+        h.respondError(w, http.StatusNotFound, "Simulator is disabled")
+        return
+    }
+    principal, _ := authctx.UserFrom(r.Context())
+
+    var req simulateReportRequest
+    if r.ContentLength != 0 {
+        if err := h.decodeJSON(r, &req); err != nil {
+            h.respondError(w, http.StatusBadRequest, "Invalid request body")
+            return
+        }
+    }
+    if req.Content == "" {
+        req.Content = "Simulated message for moderation review"
+    }
+    if req.Reason == "" {
+        req.Reason = "Simulated report"
+    }
+
+    roomID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+    room, err := h.store.GetChatRoom(r.Context(), roomID)
+    if err != nil || !room.IsActive {
+        h.respondError(w, http.StatusNotFound, "Room not found")
+        return
+    }
+    user, err := h.store.GetUser(r.Context(), principal.UserID)
+    if err != nil {
+        h.respondError(w, http.StatusNotFound, "User not found")
+        return
+    }
+
+    h.hub.SimulateMessage(room.ID, user, req.Content, &models.MessageFlag{Filter: simulatedFilter, Reason: req.Reason})
+    w.WriteHeader(http.StatusAccepted)
+}
+
+type simulateJoinSpikeRequest struct {
+    Count int `json:"count"`
+}
+
+// simulateJoinSpike sends a room a burst of joins from made-up users,
+// fifty unless the request says otherwise.
+func (h *Handler) simulateJoinSpike(w http.ResponseWriter, r *http.Request) {
+    if !h.simulator {
+        h.respondError(w, http.StatusNotFound, "Simulator is disabled")
+        return
+    }
+
+    var req simulateJoinSpikeRequest
+    if r.ContentLength != 0 {
+        if err := h.decodeJSON(r, &req); err != nil {
+            h.respondError(w, http.StatusBadRequest, "Invalid request body")
+            return
+        }
+    }
+    if req.Count == 0 {
+        req.Count = defaultJoinSpike
+    }
+    if req.Count < 0 || req.Count > maxJoinSpike {
+        h.respondError(w, http.StatusBadRequest, "Count must be between 1 and "+strconv.Itoa(maxJoinSpike))
+        return
+    }
+
+    roomID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+    room, err := h.store.GetChatRoom(r.Context(), roomID)
+    if err != nil || !room.IsActive {
+        h.respondError(w, http.StatusNotFound, "Room not found")
+        return
+    }
+
+    h.hub.SimulateJoins(room.ID, req.Count)
+    w.WriteHeader(http.StatusAccepted)
+}
@@ -0,0 +1,103 @@
+package api
+
+import (
+    "net/http"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/rollups"
+)
+
+// Statistics sources
+const (
+    statisticsRollup = "rollup"
+    statisticsLive   = "live"
+)
+
+// statisticsResponse carries statistics with how fresh they are.
+type statisticsResponse struct {
+    Statistics interface{} `json:"statistics"`
+    // ComputedAt is when the statistics were counted
+    ComputedAt time.Time `json:"computed_at"`
+    // Source is "rollup", or "live" for a room or match the rollup job
+    // has yet to reach
+    Source string `json:"source"`
+    // Stale is set once the rollup job has missed runs
+    Stale bool `json:"stale"`
+}
+
+func (h *Handler) rolledUp(stats interface{}, computedAt time.Time) *statisticsResponse {
+    return &statisticsResponse{
+        Statistics: stats,
+        ComputedAt: computedAt,
+        Source:     statisticsRollup,
+        Stale:      !rollups.Fresh(computedAt, h.statsRollupInterval),
+    }
+}
+
+// getRoomStatistics serves a room's statistics from its rollup, counting
+// them live only before its first one.
+func (h *Handler) getRoomStatistics(w http.ResponseWriter, r *http.Request) {
+    roomID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+
+    if _, err := h.store.GetChatRoom(r.Context(), roomID); err != nil {
+        h.respondError(w, http.StatusNotFound, "Room not found")
+        return
+    }
+
+    stats, err := h.store.GetRoomStatisticsRollup(r.Context(), roomID)
+    if err != nil {
+        h.logger.Error("Failed to get room statistics", zap.Error(err), zap.String("room", roomID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to load statistics")
+        return
+    }
+    if stats != nil {
+        h.respondJSON(w, http.StatusOK, h.rolledUp(stats, stats.ComputedAt))
+        return
+    }
+
+    stats, err = h.store.GetRoomStatistics(r.Context(), roomID)
+    if err != nil {
+        h.logger.Error("Failed to get room statistics", zap.Error(err), zap.String("room", roomID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to load statistics")
+        return
+    }
+    h.respondJSON(w, http.StatusOK, &statisticsResponse{Statistics: stats, ComputedAt: time.Now(), Source: statisticsLive})
+}
+
+// getMatchStatistics serves a match's statistics from its rollup,
+// counting them live only before its first one.
+func (h *Handler) getMatchStatistics(w http.ResponseWriter, r *http.Request) {
+    matchID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+
+    if _, err := h.store.GetMatch(r.Context(), matchID); err != nil {
+        h.respondError(w, http.StatusNotFound, "Match not found")
+        return
+    }
+
+    stats, err := h.store.GetMatchStatisticsRollup(r.Context(), matchID)
+    if err != nil {
+        h.logger.Error("Failed to get match statistics", zap.Error(err), zap.String("match_id", matchID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to load statistics")
+        return
+    }
+    if stats != nil {
+        h.respondJSON(w, http.StatusOK, h.rolledUp(stats, stats.ComputedAt))
+        return
+    }
+
+    stats, err = h.store.GetMatchStatistics(r.Context(), matchID)
+    if err != nil {
+        h.logger.Error("Failed to get match statistics", zap.Error(err), zap.String("match_id", matchID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to load statistics")
+        return
+    }
+    h.respondJSON(w, http.StatusOK, &statisticsResponse{Statistics: stats, ComputedAt: time.Now(), Source: statisticsLive})
+}
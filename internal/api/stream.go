@@ -0,0 +1,156 @@
+package api
+
+import (
+    "encoding/json"
+    "errors"
+    "fmt"
+    "io"
+    "net/http"
+    "strconv"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/websocket"
+)
+
+const (
+    // streamKeepAlive is how often an idle stream sends a comment, so
+    // proxies do not close it
+    streamKeepAlive = 15 * time.Second
+    // streamRetry is how long EventSource waits before reconnecting
+    streamRetry = 3 * time.Second
+)
+
+// streamRoom serves a room's frames as server-sent events, for embeds and
+// networks that cannot hold a websocket. It is read-only, but sends what
+// a member sees, so it takes a signed-in user the room admits; logged-out
+// viewers get the preview instead. Each event's data is the websocket
+// frame; chat messages carry their room sequence as the event ID, so a
+// reconnecting EventSource sends Last-Event-ID and is caught up on what
+// it missed. The stream opens with a history frame.
+func (h *Handler) streamRoom(w http.ResponseWriter, r *http.Request) {
+    ctx := r.Context()
+    roomID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+
+    room, err := h.store.GetChatRoom(ctx, roomID)
+    if err != nil || !room.IsActive {
+        h.respondRoomMissing(w, r, roomID)
+        return
+    }
+    if !h.admitReader(w, r, roomID) {
+        return
+    }
+
+    lastID := r.Header.Get("Last-Event-ID")
+    if lastID == "" {
+        // For EventSource polyfills that cannot set headers
+        lastID = r.URL.Query().Get("last_event_id")
+    }
+    var since int64
+    if lastID != "" {
+        since, err = strconv.ParseInt(lastID, 10, 64)
+        if err != nil || since < 0 {
+            h.respondError(w, http.StatusBadRequest, "Invalid Last-Event-ID")
+            return
+        }
+    }
+
+    // The server's write timeout is meant for ordinary requests
+    rc := http.NewResponseController(w)
+    if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+        h.respondError(w, http.StatusInternalServerError, "Streaming unsupported")
+        return
+    }
+
+    // Watch before loading history, so nothing sent in between is lost;
+    // clients drop sequences they already have
+    stream, err := h.hub.Watch(roomID)
+    if errors.Is(err, websocket.ErrOverloaded) {
+        w.Header().Set("Retry-After", strconv.Itoa(int(streamRetry.Seconds())))
+        h.respondError(w, http.StatusServiceUnavailable, "Server at capacity")
+        return
+    }
+    defer h.hub.Unwatch(stream)
+
+    page, err := h.hub.StreamBackfill(ctx, roomID, since)
+    if err != nil {
+        h.logger.Error("Failed to load stream history", zap.Error(err), zap.String("room", roomID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to load history")
+        return
+    }
+    history, err := historyFrame(roomID, page)
+    if err != nil {
+        h.respondError(w, http.StatusInternalServerError, "Failed to load history")
+        return
+    }
+
+    w.Header().Set("Content-Type", "text/event-stream")
+    w.Header().Set("Cache-Control", "no-cache")
+    // Stops nginx from buffering the stream
+    w.Header().Set("X-Accel-Buffering", "no")
+    w.WriteHeader(http.StatusOK)
+
+    fmt.Fprintf(w, "retry: %d\n\n", streamRetry.Milliseconds())
+    var lastSeq int64
+    if n := len(page.Messages); n > 0 {
+        lastSeq = page.Messages[n-1].Seq
+    }
+    if err := writeEvent(w, lastSeq, history); err != nil || rc.Flush() != nil {
+        return
+    }
+
+    keepAlive := time.NewTicker(streamKeepAlive)
+    defer keepAlive.Stop()
+
+    for {
+        select {
+        case <-ctx.Done():
+            return
+        case payload, ok := <-stream.Frames():
+            if !ok {
+                // Dropped for falling behind; the client resumes
+                return
+            }
+            if err := writeEvent(w, websocket.FrameSeq(payload), payload); err != nil {
+                return
+            }
+        case <-keepAlive.C:
+            if _, err := io.WriteString(w, ": keep-alive\n\n"); err != nil {
+                return
+            }
+        }
+        if err := rc.Flush(); err != nil {
+            return
+        }
+    }
+}
+
+// writeEvent writes one event. Frames are single-line JSON, so they fit
+// in one data field.
+func writeEvent(w io.Writer, id int64, data []byte) error {
+    if id > 0 {
+        if _, err := fmt.Fprintf(w, "id: %d\n", id); err != nil {
+            return err
+        }
+    }
+    _, err := fmt.Fprintf(w, "data: %s\n\n", data)
+    return err
+}
+
+func historyFrame(roomID string, page *websocket.HistoryPage) ([]byte, error) {
+    data, err := json.Marshal(page)
+    if err != nil {
+        return nil, err
+    }
+    return json.Marshal(&models.WSMessage{
+        Type:      models.MessageTypeHistory,
+        ChatRoom:  roomID,
+        Data:      data,
+        Timestamp: time.Now(),
+    })
+}
@@ -0,0 +1,36 @@
+package api
+
+import (
+    "net/http"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/matchstats"
+)
+
+func (h *Handler) getHeadToHead(w http.ResponseWriter, r *http.Request) {
+    teamA, ok := h.pathID(w, r, "a")
+    if !ok {
+        return
+    }
+    teamB, ok := h.pathID(w, r, "b")
+    if !ok {
+        return
+    }
+    if teamA == teamB {
+        h.respondError(w, http.StatusBadRequest, "Teams must be different")
+        return
+    }
+
+    h2h, err := matchstats.ComputeHeadToHead(r.Context(), h.store, teamA, teamB)
+    if err != nil {
+        h.logger.Error("Failed to compute head-to-head",
+            zap.Error(err),
+            zap.String("team_a", teamA),
+            zap.String("team_b", teamB))
+        h.respondError(w, http.StatusInternalServerError, "Failed to load head-to-head")
+        return
+    }
+
+    h.respondJSON(w, http.StatusOK, h2h)
+}
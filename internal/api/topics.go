@@ -0,0 +1,261 @@
+package api
+
+import (
+    "net/http"
+    "regexp"
+    "strconv"
+    "strings"
+    "time"
+    "unicode/utf8"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/authctx"
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/store"
+    "github.com/yourusername/sports-chat/internal/websocket"
+)
+
+const (
+    maxOpenTopics = 8
+    maxTopicTitle = 100
+)
+
+var topicSlugPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,31}$`)
+
+// defaultTopics are opened when a moderator enables topics mode without
+// naming any.
+var defaultTopics = []topicRequest{
+    {Slug: "lineups", Title: "Lineups"},
+    {Slug: "ratings", Title: "Player ratings"},
+    {Slug: "transfers", Title: "Transfers"},
+}
+
+func (h *Handler) listRoomTopics(w http.ResponseWriter, r *http.Request) {
+    roomID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+
+    topics, err := h.store.GetRoomTopics(r.Context(), roomID)
+    if err != nil {
+        h.logger.Error("Failed to list room topics", zap.Error(err), zap.String("room", roomID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to load topics")
+        return
+    }
+    if topics == nil {
+        topics = []*models.RoomTopic{}
+    }
+
+    h.respondJSON(w, http.StatusOK, topics)
+}
+
+type topicRequest struct {
+    Slug  string `json:"slug"`
+    Title string `json:"title"`
+}
+
+type openTopicsRequest struct {
+    Topics []topicRequest `json:"topics"`
+}
+
+// openRoomTopics puts a room into topics mode, before kickoff or after
+// full time. Topics opened before kickoff collapse into the main feed
+// when the match goes live.
+func (h *Handler) openRoomTopics(w http.ResponseWriter, r *http.Request) {
+    ctx := r.Context()
+    principal, _ := authctx.UserFrom(ctx)
+    roomID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+
+    var req openTopicsRequest
+    if err := h.decodeJSON(r, &req); err != nil {
+        h.respondError(w, http.StatusBadRequest, "Invalid request body")
+        return
+    }
+    if len(req.Topics) == 0 {
+        req.Topics = defaultTopics
+    }
+    for i := range req.Topics {
+        t := &req.Topics[i]
+        t.Title = strings.TrimSpace(t.Title)
+        if !topicSlugPattern.MatchString(t.Slug) || t.Title == "" || utf8.RuneCountInString(t.Title) > maxTopicTitle {
+            h.respondError(w, http.StatusBadRequest, "Topics need a lowercase slug and a title of up to 100 characters")
+            return
+        }
+    }
+
+    room, err := h.store.GetChatRoom(ctx, roomID)
+    if err != nil {
+        h.respondError(w, http.StatusNotFound, "Room not found")
+        return
+    }
+    if room.State == models.RoomStateArchived {
+        h.respondError(w, http.StatusConflict, "Room is archived")
+        return
+    }
+    if room.MatchID != "" {
+        match, err := h.store.GetMatch(ctx, room.MatchID)
+        if err != nil {
+            h.respondError(w, http.StatusNotFound, "Match not found")
+            return
+        }
+        if match.Status == models.MatchStatusLive {
+            h.respondError(w, http.StatusConflict, "Topics can only be opened before kickoff or after full time")
+            return
+        }
+    }
+
+    existing, err := h.store.GetRoomTopics(ctx, roomID)
+    if err != nil {
+        h.logger.Error("Failed to list room topics", zap.Error(err), zap.String("room", roomID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to open topics")
+        return
+    }
+    open := make(map[string]bool)
+    for _, topic := range existing {
+        if topic.Open() {
+            open[topic.Slug] = true
+        }
+    }
+    for _, t := range req.Topics {
+        if open[t.Slug] {
+            h.respondError(w, http.StatusConflict, "Topic "+t.Slug+" is already open")
+            return
+        }
+        open[t.Slug] = true
+    }
+    if len(open) > maxOpenTopics {
+        h.respondError(w, http.StatusBadRequest, "A room can have at most 8 open topics")
+        return
+    }
+
+    now := time.Now()
+    for i, t := range req.Topics {
+        topic := &models.RoomTopic{
+            RoomID:    roomID,
+            Slug:      t.Slug,
+            Title:     t.Title,
+            Position:  len(existing) + i,
+            CreatedBy: principal.UserID,
+            CreatedAt: now,
+        }
+        if err := h.store.CreateRoomTopic(ctx, topic); err != nil {
+            h.logger.Error("Failed to create room topic", zap.Error(err), zap.String("room", roomID))
+            h.respondError(w, http.StatusInternalServerError, "Failed to open topics")
+            return
+        }
+        existing = append(existing, topic)
+    }
+
+    h.hub.TopicsChanged(roomID, existing)
+    h.respondJSON(w, http.StatusCreated, existing)
+}
+
+// collapseRoomTopic folds one topic into the main feed ahead of kickoff,
+// or closes a post-match topic.
+func (h *Handler) collapseRoomTopic(w http.ResponseWriter, r *http.Request) {
+    ctx := r.Context()
+    roomID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+
+    topics, err := h.store.GetRoomTopics(ctx, roomID)
+    if err != nil {
+        h.logger.Error("Failed to list room topics", zap.Error(err), zap.String("room", roomID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to collapse topic")
+        return
+    }
+    room := &models.ChatRoom{ID: roomID, Topics: topics}
+    topic := room.Topic(r.PathValue("topicId"))
+    if topic == nil {
+        h.respondError(w, http.StatusNotFound, "Topic not found")
+        return
+    }
+
+    now := time.Now()
+    collapsed, err := h.store.CollapseRoomTopic(ctx, topic.ID, now)
+    if err != nil {
+        h.logger.Error("Failed to collapse room topic", zap.Error(err), zap.String("topic_id", topic.ID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to collapse topic")
+        return
+    }
+    if !collapsed {
+        h.respondError(w, http.StatusConflict, "Topic is already collapsed")
+        return
+    }
+    topic.CollapsedAt = &now
+
+    h.hub.TopicsChanged(roomID, topics)
+    h.respondJSON(w, http.StatusOK, topic)
+}
+
+// getTopicMessages pages back through a topic's sub-feed with
+// ?before=<cursor>&limit=n. Messages come oldest first, like room
+// history.
+func (h *Handler) getTopicMessages(w http.ResponseWriter, r *http.Request) {
+    ctx := r.Context()
+    roomID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+    query := r.URL.Query()
+
+    if !h.admitReader(w, r, roomID) {
+        return
+    }
+
+    topics, err := h.store.GetRoomTopics(ctx, roomID)
+    if err != nil {
+        h.logger.Error("Failed to list room topics", zap.Error(err), zap.String("room", roomID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to load messages")
+        return
+    }
+    room := &models.ChatRoom{ID: roomID, Topics: topics}
+    topic := room.Topic(r.PathValue("topicId"))
+    if topic == nil {
+        h.respondError(w, http.StatusNotFound, "Topic not found")
+        return
+    }
+
+    var before *store.MessageCursor
+    if v := query.Get("before"); v != "" {
+        cursor, err := store.DecodeMessageCursor(v)
+        if err != nil {
+            h.respondError(w, http.StatusBadRequest, "Invalid cursor")
+            return
+        }
+        before = &cursor
+    }
+
+    limit, _ := strconv.Atoi(query.Get("limit"))
+    limit = websocket.ClampHistoryLimit(limit)
+
+    // One extra tells us whether there is another page
+    messages, err := h.store.GetTopicMessagesBeforeCursor(ctx, topic.ID, before, limit+1)
+    if err != nil {
+        h.logger.Error("Failed to get topic messages", zap.Error(err), zap.String("topic_id", topic.ID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to load messages")
+        return
+    }
+
+    page := &websocket.HistoryPage{}
+    if len(messages) > limit {
+        messages = messages[:limit]
+        page.HasMore = true
+        page.NextCursor = store.CursorOf(messages[limit-1]).Encode()
+    }
+    for i, j := 0, len(messages)-1; i < j; i, j = i+1, j-1 {
+        messages[i], messages[j] = messages[j], messages[i]
+    }
+    if err := websocket.LoadReactions(ctx, h.store, messages); err != nil {
+        h.logger.Warn("Failed to load reactions", zap.Error(err), zap.String("topic_id", topic.ID))
+    }
+
+    page.Messages = websocket.HistoryMessages(messages)
+    page.Returned = len(page.Messages)
+    h.respondJSON(w, http.StatusOK, page)
+}
@@ -0,0 +1,167 @@
+package api
+
+import (
+    "errors"
+    "net/http"
+    "strconv"
+    "strings"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/authctx"
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/moderation"
+)
+
+// getUserProfile is the full profile fetch path. Chat frames and history
+// only carry a models.UserSummary, so clients call this when a profile
+// card is opened. Users see their own private fields; everyone else gets
+// the public profile.
+func (h *Handler) getUserProfile(w http.ResponseWriter, r *http.Request) {
+    userID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+
+    user, err := h.store.GetUser(r.Context(), userID)
+    if err != nil {
+        h.logger.Error("Failed to get user", zap.Error(err), zap.String("user_id", userID))
+        h.respondError(w, http.StatusNotFound, "User not found")
+        return
+    }
+
+    if principal, ok := authctx.UserFrom(r.Context()); ok && principal.UserID == user.ID {
+        h.respondJSON(w, http.StatusOK, user)
+        return
+    }
+
+    h.respondJSON(w, http.StatusOK, user.PublicProfile())
+}
+
+type goalFlashRequest struct {
+    Enabled bool `json:"enabled"`
+}
+
+// setGoalFlash opts the caller in or out of goal flashes from other
+// matches of the competitions they follow.
+func (h *Handler) setGoalFlash(w http.ResponseWriter, r *http.Request) {
+    principal, err := authctx.RequireUser(r.Context())
+    if err != nil {
+        h.respondError(w, http.StatusUnauthorized, "Unauthorized")
+        return
+    }
+
+    var req goalFlashRequest
+    if err := h.decodeJSON(r, &req); err != nil {
+        h.respondError(w, http.StatusBadRequest, "Invalid request body")
+        return
+    }
+
+    user, err := h.store.GetUser(r.Context(), principal.UserID)
+    if err != nil {
+        h.respondError(w, http.StatusNotFound, "User not found")
+        return
+    }
+
+    user.GoalFlashOptOut = !req.Enabled
+    if err := h.store.UpdateUser(r.Context(), user); err != nil {
+        h.logger.Error("Failed to update goal flash preference",
+            zap.Error(err),
+            zap.String("user_id", user.ID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to update preference")
+        return
+    }
+    h.hub.SetGoalFlashOptOut(user.ID, user.GoalFlashOptOut)
+
+    w.WriteHeader(http.StatusNoContent)
+}
+
+type renameRequest struct {
+    Username string `json:"username"`
+}
+
+// renameUser changes the caller's username. Names go through the
+// username policy, may only change once per cooldown, and a name someone
+// gave up stays held for a cooldown so it can't be grabbed to pose as
+// them.
+func (h *Handler) renameUser(w http.ResponseWriter, r *http.Request) {
+    principal, err := authctx.RequireUser(r.Context())
+    if err != nil {
+        h.respondError(w, http.StatusUnauthorized, "Unauthorized")
+        return
+    }
+
+    var req renameRequest
+    if err := h.decodeJSON(r, &req); err != nil {
+        h.respondError(w, http.StatusBadRequest, "Invalid request body")
+        return
+    }
+
+    user, err := h.store.GetUser(r.Context(), principal.UserID)
+    if err != nil {
+        h.respondError(w, http.StatusNotFound, "User not found")
+        return
+    }
+    if user.ExternalID != "" {
+        h.respondError(w, http.StatusForbidden, "Username is managed by your identity provider")
+        return
+    }
+    if req.Username == user.Username {
+        h.respondJSON(w, http.StatusOK, user)
+        return
+    }
+
+    now := time.Now()
+    if user.UsernameChangedAt != nil && now.Sub(*user.UsernameChangedAt) < h.usernameCooldown {
+        next := user.UsernameChangedAt.Add(h.usernameCooldown)
+        w.Header().Set("Retry-After", strconv.Itoa(int(next.Sub(now).Seconds())+1))
+        h.respondError(w, http.StatusTooManyRequests, "Username was changed recently")
+        return
+    }
+
+    if err := h.usernames.Check(r.Context(), req.Username); err != nil {
+        if errors.Is(err, moderation.ErrUsernameFormat) || errors.Is(err, moderation.ErrUsernameReserved) ||
+            errors.Is(err, moderation.ErrUsernameImpersonation) || errors.Is(err, moderation.ErrUsernameProfane) {
+            h.respondError(w, http.StatusBadRequest, err.Error())
+            return
+        }
+        h.logger.Error("Failed to check username", zap.Error(err))
+        h.respondError(w, http.StatusInternalServerError, "Failed to check username")
+        return
+    }
+
+    // A case-only change keeps the same owner, so it skips the taken and
+    // held checks
+    if !strings.EqualFold(req.Username, user.Username) {
+        if _, err := h.store.GetUserByUsername(r.Context(), req.Username); err == nil {
+            h.respondError(w, http.StatusConflict, "Username is taken")
+            return
+        }
+        held, err := h.store.UsernameReleasedSince(r.Context(), req.Username, now.Add(-h.usernameCooldown))
+        if err != nil {
+            h.logger.Error("Failed to check released usernames", zap.Error(err))
+            h.respondError(w, http.StatusInternalServerError, "Failed to check username")
+            return
+        }
+        if held {
+            h.respondError(w, http.StatusConflict, "Username is taken")
+            return
+        }
+    }
+
+    if err := h.store.RenameUser(r.Context(), user.ID, req.Username, now); err != nil {
+        h.logger.Error("Failed to rename user", zap.Error(err), zap.String("user_id", user.ID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to change username")
+        return
+    }
+    h.recordSecurityEvent(r, principal, models.SecurityUsernameChanged, user.Username+" to "+req.Username)
+    user.Username = req.Username
+    user.UsernameChangedAt = &now
+
+    if err := h.hub.UserUpdated(r.Context(), user); err != nil {
+        h.logger.Error("Failed to announce rename", zap.Error(err), zap.String("user_id", user.ID))
+    }
+
+    h.respondJSON(w, http.StatusOK, user)
+}
@@ -0,0 +1,112 @@
+package api
+
+import (
+    "net/http"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/authctx"
+)
+
+const (
+    defaultAnalyticsWindow = 24 * time.Hour
+    maxAnalyticsWindow     = 7 * 24 * time.Hour
+)
+
+// getRoomAnalytics shows a watch party's owner how their room went:
+// attendance over time, the most active members, reactions and the
+// busiest minutes. The window defaults to the last day, e.g.
+// ?from=2024-05-01T19:00:00Z&to=2024-05-01T23:00:00Z. Only the owner and
+// admins may see it.
+func (h *Handler) getRoomAnalytics(w http.ResponseWriter, r *http.Request) {
+    principal, _ := authctx.UserFrom(r.Context())
+    roomID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+
+    room, err := h.store.GetChatRoom(r.Context(), roomID)
+    if err != nil {
+        h.respondError(w, http.StatusNotFound, "Room not found")
+        return
+    }
+    if !principal.IsAdmin && (room.OwnerID == "" || room.OwnerID != principal.UserID) {
+        h.respondError(w, http.StatusForbidden, "Only the room's owner can see its analytics")
+        return
+    }
+
+    q := r.URL.Query()
+    to := time.Now()
+    if v := q.Get("to"); v != "" {
+        t, err := time.Parse(time.RFC3339, v)
+        if err != nil {
+            h.respondError(w, http.StatusBadRequest, "Invalid to timestamp")
+            return
+        }
+        to = t
+    }
+    from := to.Add(-defaultAnalyticsWindow)
+    if v := q.Get("from"); v != "" {
+        t, err := time.Parse(time.RFC3339, v)
+        if err != nil {
+            h.respondError(w, http.StatusBadRequest, "Invalid from timestamp")
+            return
+        }
+        from = t
+    }
+    if !from.Before(to) || to.Sub(from) > maxAnalyticsWindow {
+        h.respondError(w, http.StatusBadRequest, "The window must be positive and at most 7 days")
+        return
+    }
+
+    analytics, err := h.store.GetRoomAnalytics(r.Context(), roomID, from, to)
+    if err != nil {
+        h.logger.Error("Failed to get room analytics", zap.Error(err), zap.String("room", roomID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to load analytics")
+        return
+    }
+
+    h.respondJSON(w, http.StatusOK, analytics)
+}
+
+type roomOwnerRequest struct {
+    UserID string `json:"user_id"`
+}
+
+// setRoomOwner makes a room a watch party hosted by the user, or clears
+// its owner when user_id is empty. Attendance sampling picks the change
+// up with the room's cached settings.
+func (h *Handler) setRoomOwner(w http.ResponseWriter, r *http.Request) {
+    roomID, ok := h.pathID(w, r, "id")
+    if !ok {
+        return
+    }
+
+    var req roomOwnerRequest
+    if err := h.decodeJSON(r, &req); err != nil {
+        h.respondError(w, http.StatusBadRequest, "Invalid request body")
+        return
+    }
+
+    room, err := h.store.GetChatRoom(r.Context(), roomID)
+    if err != nil {
+        h.respondError(w, http.StatusNotFound, "Room not found")
+        return
+    }
+    if req.UserID != "" {
+        if _, err := h.store.GetUser(r.Context(), req.UserID); err != nil {
+            h.respondError(w, http.StatusNotFound, "User not found")
+            return
+        }
+    }
+
+    room.OwnerID = req.UserID
+    if err := h.store.UpdateChatRoom(r.Context(), room); err != nil {
+        h.logger.Error("Failed to update room", zap.Error(err), zap.String("room", roomID))
+        h.respondError(w, http.StatusInternalServerError, "Failed to update room")
+        return
+    }
+
+    h.respondJSON(w, http.StatusOK, room)
+}
@@ -0,0 +1,210 @@
+// Package appeals lets users contest moderation actions taken against
+// them: a site-wide ban, a room ban or mute, or a removed message. Admins
+// work the appeals from a review queue; accepting one undoes the action.
+// Users see the outcome in their list of appeals, and at once on any
+// open connection.
+package appeals
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/evidence"
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/store"
+)
+
+var (
+    // ErrNoAction is returned for appeals of an action not in force
+    // against the user, or a removal with no evidence retained
+    ErrNoAction        = errors.New("no such moderation action against the user")
+    ErrAlreadyAppealed = errors.New("action already appealed")
+    ErrReviewed        = errors.New("appeal already reviewed")
+)
+
+// Hub lifts room sanctions and tells users of their appeal's outcome.
+// The websocket hub implements it.
+type Hub interface {
+    LiftSanction(ctx context.Context, room, userID, kind string) error
+    NotifyUser(userID string, message *models.WSMessage)
+}
+
+type Service struct {
+    store    store.Store
+    hub      Hub
+    evidence *evidence.Service
+    logger   *zap.Logger
+}
+
+// NewService creates the appeals service. evidence may be nil, in which
+// case removed messages cannot be appealed, there being no copy to
+// restore.
+func NewService(store store.Store, hub Hub, evidence *evidence.Service, logger *zap.Logger) *Service {
+    return &Service{store: store, hub: hub, evidence: evidence, logger: logger}
+}
+
+// Submit files an appeal once the action it names is found in force
+// against the user, stamping it with when the action was taken.
+func (s *Service) Submit(ctx context.Context, appeal *models.Appeal) error {
+    actionAt, err := s.findAction(ctx, appeal)
+    if err != nil {
+        return err
+    }
+    appeal.ActionAt = actionAt
+    appeal.Status = models.AppealPending
+    created, err := s.store.CreateAppeal(ctx, appeal)
+    if err != nil {
+        return err
+    }
+    if !created {
+        return ErrAlreadyAppealed
+    }
+    return nil
+}
+
+// findAction returns when the appealed action was taken.
+func (s *Service) findAction(ctx context.Context, appeal *models.Appeal) (time.Time, error) {
+    switch appeal.Action {
+    case models.AppealBan:
+        user, err := s.store.GetUser(ctx, appeal.UserID)
+        if err != nil {
+            return time.Time{}, fmt.Errorf("failed to get user: %w", err)
+        }
+        if user.BannedAt == nil {
+            return time.Time{}, ErrNoAction
+        }
+        return *user.BannedAt, nil
+
+    case models.AppealRoomBan, models.AppealRoomMute:
+        sanctions, err := s.store.GetRoomSanctions(ctx, appeal.RoomID, appeal.UserID)
+        if err != nil {
+            return time.Time{}, fmt.Errorf("failed to get room sanctions: %w", err)
+        }
+        for _, sanction := range sanctions {
+            if sanction.Kind == sanctionKind(appeal.Action) {
+                return sanction.CreatedAt, nil
+            }
+        }
+        return time.Time{}, ErrNoAction
+
+    case models.AppealMessageRemoval:
+        if s.evidence == nil {
+            return time.Time{}, ErrNoAction
+        }
+        retained, err := s.store.GetMessageEvidence(ctx, appeal.MessageID)
+        if err != nil || retained.UserID != appeal.UserID {
+            return time.Time{}, ErrNoAction
+        }
+        appeal.RoomID = retained.RoomID
+        return retained.CreatedAt, nil
+    }
+    return time.Time{}, ErrNoAction
+}
+
+// Review records an admin's verdict on a pending appeal. Accepting it
+// undoes the action first, so an appeal is only ever marked accepted
+// once the user has been reinstated. The user is notified either way.
+func (s *Service) Review(ctx context.Context, id string, accept bool, reviewerID, note string) (*models.Appeal, error) {
+    appeal, err := s.store.GetAppeal(ctx, id)
+    if err != nil {
+        return nil, err
+    }
+    if appeal.Status != models.AppealPending {
+        return nil, ErrReviewed
+    }
+
+    status := models.AppealRejected
+    if accept {
+        if err := s.reinstate(ctx, appeal, reviewerID); err != nil {
+            return nil, fmt.Errorf("failed to reinstate: %w", err)
+        }
+        status = models.AppealAccepted
+    }
+    reviewed, err := s.store.ReviewAppeal(ctx, id, status, reviewerID, note)
+    if err != nil {
+        return nil, err
+    }
+    if !reviewed {
+        return nil, ErrReviewed
+    }
+
+    if appeal, err = s.store.GetAppeal(ctx, id); err != nil {
+        return nil, err
+    }
+    s.notify(appeal)
+    return appeal, nil
+}
+
+// reinstate undoes the appealed action. Each step is safe to repeat, in
+// case two admins accept the same appeal at once.
+func (s *Service) reinstate(ctx context.Context, appeal *models.Appeal, reviewerID string) error {
+    switch appeal.Action {
+    case models.AppealBan:
+        user, err := s.store.GetUser(ctx, appeal.UserID)
+        if err != nil {
+            return fmt.Errorf("failed to get user: %w", err)
+        }
+        user.BannedAt = nil
+        user.BanReason = ""
+        return s.store.UpdateUser(ctx, user)
+
+    case models.AppealRoomBan, models.AppealRoomMute:
+        return s.hub.LiftSanction(ctx, appeal.RoomID, appeal.UserID, sanctionKind(appeal.Action))
+
+    case models.AppealMessageRemoval:
+        return s.restoreMessage(ctx, appeal, reviewerID)
+    }
+    return fmt.Errorf("unknown appeal action %q", appeal.Action)
+}
+
+// restoreMessage puts a removed message back into its room's history,
+// under its original ID and sequence, from the evidence retained when it
+// was deleted. Opening the evidence is logged against the appeal.
+func (s *Service) restoreMessage(ctx context.Context, appeal *models.Appeal, reviewerID string) error {
+    if s.evidence == nil {
+        return errors.New("evidence retention is disabled")
+    }
+    retained, err := s.store.GetMessageEvidence(ctx, appeal.MessageID)
+    if err != nil {
+        return fmt.Errorf("failed to get evidence: %w", err)
+    }
+    _, msg, err := s.evidence.Open(ctx, retained.ID, reviewerID, "appeal "+appeal.ID)
+    if err != nil {
+        return err
+    }
+    if existing, err := s.store.GetMessage(ctx, msg.ID); err == nil && existing != nil {
+        return nil
+    }
+    return s.store.CreateMessage(ctx, msg)
+}
+
+func (s *Service) notify(appeal *models.Appeal) {
+    data, err := json.Marshal(appeal)
+    if err != nil {
+        s.logger.Error("Failed to marshal appeal", zap.Error(err), zap.String("appeal_id", appeal.ID))
+        return
+    }
+    content := "Your appeal was rejected"
+    if appeal.Status == models.AppealAccepted {
+        content = "Your appeal was accepted"
+    }
+    s.hub.NotifyUser(appeal.UserID, &models.WSMessage{
+        Type:      models.MessageTypeAppeal,
+        Content:   content,
+        Data:      data,
+        Timestamp: time.Now(),
+    })
+}
+
+// sanctionKind is the room sanction an appeal action contests.
+func sanctionKind(action string) string {
+    if action == models.AppealRoomMute {
+        return models.SanctionMute
+    }
+    return models.SanctionBan
+}
@@ -0,0 +1,142 @@
+// Package attachments holds uploaded images back until a scanner has
+// checked them. An upload is stored pending, visible only to its uploader;
+// a background job scans it, then either shows it to the room or tells
+// the uploader why it was rejected.
+package attachments
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/jobs"
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/store"
+)
+
+const scanJobName = "attachment.scan"
+
+// Notifier delivers attachment frames. The websocket hub implements it.
+type Notifier interface {
+    Announce(room string, message *models.WSMessage)
+    NotifyUser(userID string, message *models.WSMessage)
+}
+
+type Service struct {
+    store    store.Store
+    scanner  Scanner
+    queue    *jobs.Queue
+    notifier Notifier
+    logger   *zap.Logger
+}
+
+func NewService(store store.Store, scanner Scanner, queue *jobs.Queue, notifier Notifier, logger *zap.Logger) *Service {
+    s := &Service{
+        store:    store,
+        scanner:  scanner,
+        queue:    queue,
+        notifier: notifier,
+        logger:   logger,
+    }
+    queue.Register(scanJobName, func(payload []byte) (jobs.Job, error) {
+        job := &scanJob{service: s}
+        if err := json.Unmarshal(payload, job); err != nil {
+            return nil, err
+        }
+        return job, nil
+    })
+    return s
+}
+
+// Upload stores an attachment as pending and queues its scan. If the scan
+// cannot be queued the attachment stays pending and the error is
+// returned, so the uploader can try again.
+func (s *Service) Upload(ctx context.Context, attachment *models.Attachment, data []byte) error {
+    if err := s.store.CreateAttachment(ctx, attachment, data); err != nil {
+        return err
+    }
+    if err := s.queue.Enqueue(&scanJob{service: s, ID: attachment.ID}); err != nil {
+        return fmt.Errorf("failed to queue attachment scan: %w", err)
+    }
+    return nil
+}
+
+// Visible reports whether a user may see an attachment: anyone once it is
+// clean, otherwise only its uploader.
+func Visible(attachment *models.Attachment, userID string) bool {
+    return attachment.Status == models.AttachmentClean || attachment.UserID == userID
+}
+
+// scan checks a pending attachment and settles it. Scanner failures are
+// returned so the queue retries them.
+func (s *Service) scan(ctx context.Context, id string) error {
+    attachment, err := s.store.GetAttachment(ctx, id)
+    if err != nil {
+        // Deleted with its room or user; nothing left to scan
+        s.logger.Info("Skipping scan of missing attachment", zap.String("attachment_id", id))
+        return nil
+    }
+    if attachment.Status != models.AttachmentPending {
+        return nil
+    }
+    data, err := s.store.GetAttachmentData(ctx, id)
+    if err != nil {
+        return err
+    }
+
+    verdict, err := s.scanner.Scan(ctx, attachment.ContentType, data)
+    if err != nil {
+        return err
+    }
+    status := models.AttachmentClean
+    if !verdict.Clean {
+        status = models.AttachmentRejected
+    }
+    settled, err := s.store.SetAttachmentStatus(ctx, id, status, verdict.Reason)
+    if err != nil || !settled {
+        return err
+    }
+
+    now := time.Now()
+    attachment.Status = status
+    attachment.Reason = verdict.Reason
+    attachment.ScannedAt = &now
+    payload, err := json.Marshal(attachment)
+    if err != nil {
+        return nil
+    }
+    message := &models.WSMessage{
+        ID:        attachment.ID,
+        Type:      models.MessageTypeAttachment,
+        ChatRoom:  attachment.ChatRoomID,
+        Data:      payload,
+        Timestamp: now,
+    }
+
+    if verdict.Clean {
+        s.notifier.Announce(attachment.ChatRoomID, message)
+        return nil
+    }
+    s.logger.Warn("Rejected attachment",
+        zap.String("attachment_id", id),
+        zap.String("user_id", attachment.UserID),
+        zap.String("reason", verdict.Reason))
+    s.notifier.NotifyUser(attachment.UserID, message)
+    return nil
+}
+
+type scanJob struct {
+    service *Service
+    ID      string `json:"id"`
+}
+
+func (j *scanJob) Name() string { return scanJobName }
+
+func (j *scanJob) Payload() ([]byte, error) { return json.Marshal(j) }
+
+func (j *scanJob) Run(ctx context.Context) error {
+    return j.service.scan(ctx, j.ID)
+}
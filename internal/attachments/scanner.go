@@ -0,0 +1,143 @@
+package attachments
+
+import (
+    "bytes"
+    "context"
+    "encoding/binary"
+    "encoding/json"
+    "fmt"
+    "io"
+    "net"
+    "net/http"
+    "strings"
+    "time"
+)
+
+// clamdChunk is how much of an attachment goes in each INSTREAM chunk.
+const clamdChunk = 64 << 10
+
+// Verdict is a scanner's finding. Reason names what was found in content
+// that is not clean.
+type Verdict struct {
+    Clean  bool   `json:"clean"`
+    Reason string `json:"reason,omitempty"`
+}
+
+// Scanner inspects an upload's content. An error means the scan could not
+// be done and should be retried, not that the content is bad.
+type Scanner interface {
+    Scan(ctx context.Context, contentType string, data []byte) (*Verdict, error)
+}
+
+// ClamAV scans with a clamd daemon over its INSTREAM command.
+type ClamAV struct {
+    address string
+    timeout time.Duration
+}
+
+// NewClamAV scans with the clamd listening on address, e.g.
+// clamav:3310.
+func NewClamAV(address string) *ClamAV {
+    return &ClamAV{address: address, timeout: 30 * time.Second}
+}
+
+func (c *ClamAV) Scan(ctx context.Context, contentType string, data []byte) (*Verdict, error) {
+    dialer := net.Dialer{Timeout: c.timeout}
+    conn, err := dialer.DialContext(ctx, "tcp", c.address)
+    if err != nil {
+        return nil, fmt.Errorf("failed to connect to clamd: %w", err)
+    }
+    defer conn.Close()
+
+    deadline := time.Now().Add(c.timeout)
+    if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+        deadline = d
+    }
+    conn.SetDeadline(deadline)
+
+    // Null-terminated command, then length-prefixed chunks ending with an
+    // empty one
+    if _, err := conn.Write([]byte("zINSTREAM\x00")); err != nil {
+        return nil, fmt.Errorf("failed to send to clamd: %w", err)
+    }
+    var size [4]byte
+    for len(data) > 0 {
+        n := min(len(data), clamdChunk)
+        binary.BigEndian.PutUint32(size[:], uint32(n))
+        if _, err := conn.Write(size[:]); err != nil {
+            return nil, fmt.Errorf("failed to send to clamd: %w", err)
+        }
+        if _, err := conn.Write(data[:n]); err != nil {
+            return nil, fmt.Errorf("failed to send to clamd: %w", err)
+        }
+        data = data[n:]
+    }
+    binary.BigEndian.PutUint32(size[:], 0)
+    if _, err := conn.Write(size[:]); err != nil {
+        return nil, fmt.Errorf("failed to send to clamd: %w", err)
+    }
+
+    reply, err := io.ReadAll(conn)
+    if err != nil {
+        return nil, fmt.Errorf("failed to read from clamd: %w", err)
+    }
+    return parseClamdReply(string(reply))
+}
+
+// parseClamdReply reads "stream: OK" or "stream: <signature> FOUND".
+// Anything else, such as a size limit ERROR, is a failed scan.
+func parseClamdReply(reply string) (*Verdict, error) {
+    reply = strings.TrimSpace(strings.TrimRight(reply, "\x00"))
+    result := strings.TrimSpace(strings.TrimPrefix(reply, "stream:"))
+    switch {
+    case result == "OK":
+        return &Verdict{Clean: true}, nil
+    case strings.HasSuffix(result, " FOUND"):
+        return &Verdict{Reason: strings.TrimSuffix(result, " FOUND")}, nil
+    default:
+        return nil, fmt.Errorf("clamd returned %q", reply)
+    }
+}
+
+// HTTPScanner posts the content to an external scanning API, which
+// answers with a Verdict as JSON.
+type HTTPScanner struct {
+    url   string
+    token string
+    http  *http.Client
+}
+
+func NewHTTPScanner(url, token string) *HTTPScanner {
+    return &HTTPScanner{
+        url:   url,
+        token: token,
+        http:  &http.Client{Timeout: 30 * time.Second},
+    }
+}
+
+func (s *HTTPScanner) Scan(ctx context.Context, contentType string, data []byte) (*Verdict, error) {
+    req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(data))
+    if err != nil {
+        return nil, err
+    }
+    req.Header.Set("Content-Type", contentType)
+    if s.token != "" {
+        req.Header.Set("Authorization", "Bearer "+s.token)
+    }
+
+    resp, err := s.http.Do(req)
+    if err != nil {
+        return nil, fmt.Errorf("scan request failed: %w", err)
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+        io.Copy(io.Discard, resp.Body)
+        return nil, fmt.Errorf("scanner returned %d", resp.StatusCode)
+    }
+    var verdict Verdict
+    if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&verdict); err != nil {
+        return nil, fmt.Errorf("failed to decode scan result: %w", err)
+    }
+    return &verdict, nil
+}
@@ -1,10 +1,16 @@
 package auth
 
 import (
-    "context"
+    "crypto/rand"
     "crypto/subtle"
+    "encoding/hex"
+    "encoding/json"
     "errors"
     "fmt"
+    "net/http"
+    "sort"
+    "strings"
+    "sync"
     "time"
 
     "github.com/golang-jwt/jwt/v4"
@@ -12,6 +18,7 @@ import (
     "golang.org/x/crypto/argon2"
     "go.uber.org/zap"
 
+    "github.com/yourusername/sports-chat/internal/authctx"
     "github.com/yourusername/sports-chat/internal/models"
 )
 
@@ -24,7 +31,11 @@ var (
 )
 
 type Service struct {
-    jwtSecret    []byte
+    // signingKey signs new tokens; keys verifies them by kid and holds
+    // the signing key too, so tokens from retired or upcoming keys stay
+    // valid until they expire
+    signingKey   *SigningKey
+    keys         map[string]*SigningKey
     logger       *zap.Logger
     argon2Params *Argon2Params
 }
@@ -39,10 +50,27 @@ type Argon2Params struct {
 
 type Claims struct {
     jwt.RegisteredClaims
-    UserID      string   `json:"uid"`
-    Username    string   `json:"username"`
-    IsAdmin     bool     `json:"is_admin"`
-    SessionID   string   `json:"sid"`
+    UserID          string   `json:"uid"`
+    Username        string   `json:"username"`
+    IsAdmin         bool     `json:"is_admin"`
+    SessionID       string   `json:"sid"`
+    AccountType     string   `json:"acct,omitempty"`
+    RateLimitExempt bool     `json:"rle,omitempty"`
+}
+
+func (c *Claims) Principal() *authctx.Principal {
+    principal := &authctx.Principal{
+        UserID:          c.UserID,
+        Username:        c.Username,
+        IsAdmin:         c.IsAdmin,
+        SessionID:       c.SessionID,
+        AccountType:     c.AccountType,
+        RateLimitExempt: c.RateLimitExempt,
+    }
+    if c.IssuedAt != nil {
+        principal.IssuedAt = c.IssuedAt.Time
+    }
+    return principal
 }
 
 type TokenPair struct {
@@ -51,10 +79,19 @@ type TokenPair struct {
     ExpiresAt     time.Time `json:"expires_at"`
 }
 
-func NewService(jwtSecret string, logger *zap.Logger) *Service {
+// NewService signs tokens with signingKey and accepts tokens signed by it or
+// any of verificationKeys. Key IDs must be unique.
+func NewService(signingKey *SigningKey, verificationKeys []*SigningKey, logger *zap.Logger) *Service {
+    keys := make(map[string]*SigningKey, len(verificationKeys)+1)
+    for _, key := range verificationKeys {
+        keys[key.ID] = key
+    }
+    keys[signingKey.ID] = signingKey
+
     return &Service{
-        jwtSecret: []byte(jwtSecret),
-        logger:    logger,
+        signingKey: signingKey,
+        keys:       keys,
+        logger:     logger,
         argon2Params: &Argon2Params{
             memory:      64 * 1024,
             iterations:  3,
@@ -97,14 +134,19 @@ func (s *Service) generateAccessToken(user *models.User, sessionID string) (stri
             NotBefore: jwt.NewNumericDate(time.Now()),
             ID:        uuid.New().String(),
         },
-        UserID:    user.ID,
-        Username:  user.Username,
-        IsAdmin:   user.IsAdmin,
-        SessionID: sessionID,
+        UserID:          user.ID,
+        Username:        user.Username,
+        IsAdmin:         user.IsAdmin,
+        SessionID:       sessionID,
+        AccountType:     user.AccountType,
+        RateLimitExempt: user.RateLimitExempt,
     }
 
-    token := jwt.NewWithClaims(jwt.SigningMethodHS512, claims)
-    signedToken, err := token.SignedString(s.jwtSecret)
+    token := jwt.NewWithClaims(s.signingKey.method, claims)
+    if s.signingKey.ID != "" {
+        token.Header["kid"] = s.signingKey.ID
+    }
+    signedToken, err := token.SignedString(s.signingKey.sign)
     if err != nil {
         return "", time.Time{}, err
     }
@@ -118,10 +160,18 @@ func (s *Service) generateRefreshToken(userID, sessionID string) (string, error)
 
 func (s *Service) ValidateAccessToken(tokenString string) (*Claims, error) {
     token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-        if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+        // Tokens without a kid come from the unnamed shared secret
+        kid, _ := token.Header["kid"].(string)
+        key, ok := s.keys[kid]
+        if !ok {
+            return nil, fmt.Errorf("unknown signing key: %q", kid)
+        }
+        // The algorithm is pinned to the key so a public key can never
+        // be used as an HMAC secret
+        if token.Method.Alg() != key.method.Alg() {
             return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
         }
-        return s.jwtSecret, nil
+        return key.verify, nil
     })
 
     if err != nil {
@@ -139,9 +189,31 @@ func (s *Service) ValidateAccessToken(tokenString string) (*Claims, error) {
     return claims, nil
 }
 
+// JWKS returns the public keys tokens may be signed with, for services
+// verifying them independently.
+func (s *Service) JWKS() *JWKS {
+    set := &JWKS{Keys: []JWK{}}
+    for _, key := range s.keys {
+        if jwk, ok := key.jwk(); ok {
+            set.Keys = append(set.Keys, jwk)
+        }
+    }
+    sort.Slice(set.Keys, func(i, j int) bool { return set.Keys[i].Kid < set.Keys[j].Kid })
+    return set
+}
+
+// JWKSHandler serves the key set at /.well-known/jwks.json.
+func (s *Service) JWKSHandler() http.Handler {
+    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        w.Header().Set("Content-Type", "application/json")
+        w.Header().Set("Cache-Control", "public, max-age=300")
+        json.NewEncoder(w).Encode(s.JWKS())
+    })
+}
+
 func (s *Service) HashPassword(password string) (string, error) {
     salt := make([]byte, s.argon2Params.saltLength)
-    if _, err := uuid.New().SetVersion(4).SetVariant(uuid.VariantRFC4122); err != nil {
+    if _, err := rand.Read(salt); err != nil {
         return "", fmt.Errorf("failed to generate salt: %w", err)
     }
 
@@ -184,8 +256,9 @@ func (s *Service) VerifyPassword(hashedPassword, password string) (bool, error)
 }
 
 func (s *Service) decodeHash(encodedHash string) (*Argon2Params, []byte, []byte, error) {
+    // "", "argon2id", version, parameters, salt, hash
     vals := strings.Split(encodedHash, "$")
-    if len(vals) != 5 {
+    if len(vals) != 6 {
         return nil, nil, nil, errors.New("invalid hash format")
     }
 
@@ -200,13 +273,13 @@ func (s *Service) decodeHash(encodedHash string) (*Argon2Params, []byte, []byte,
         return nil, nil, nil, err
     }
 
-    salt, err := hex.DecodeString(vals[3])
+    salt, err := hex.DecodeString(vals[4])
     if err != nil {
         return nil, nil, nil, err
     }
     p.saltLength = uint32(len(salt))
 
-    hash, err := hex.DecodeString(vals[4])
+    hash, err := hex.DecodeString(vals[5])
     if err != nil {
         return nil, nil, nil, err
     }
@@ -237,8 +310,8 @@ func (s *Service) AuthMiddleware(next http.Handler) http.Handler {
             return
         }
 
-        // Add claims to request context
-        ctx := context.WithValue(r.Context(), "claims", claims)
+        // Add principal to request context
+        ctx := authctx.WithUser(r.Context(), claims.Principal())
         next.ServeHTTP(w, r.WithContext(ctx))
     })
 }
@@ -246,8 +319,8 @@ func (s *Service) AuthMiddleware(next http.Handler) http.Handler {
 // Middleware for admin-only routes
 func (s *Service) AdminMiddleware(next http.Handler) http.Handler {
     return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-        claims, ok := r.Context().Value("claims").(*Claims)
-        if !ok || !claims.IsAdmin {
+        principal, ok := authctx.UserFrom(r.Context())
+        if !ok || !principal.IsAdmin {
             http.Error(w, "Admin access required", http.StatusForbidden)
             return
         }
@@ -0,0 +1,161 @@
+package auth
+
+import (
+    "crypto/ecdsa"
+    "crypto/elliptic"
+    "crypto/rsa"
+    "encoding/base64"
+    "fmt"
+    "math/big"
+    "os"
+    "strings"
+
+    "github.com/golang-jwt/jwt/v4"
+)
+
+// SigningKey is a key tokens are signed or verified with, identified in
+// token headers by its ID. Verification-only keys have no private half.
+type SigningKey struct {
+    ID     string
+    method jwt.SigningMethod
+    sign   interface{}
+    verify interface{}
+}
+
+// NewHMACKey returns a shared-secret key. HMAC keys are never published
+// in the JWKS.
+func NewHMACKey(id string, secret []byte) *SigningKey {
+    return &SigningKey{ID: id, method: jwt.SigningMethodHS512, sign: secret, verify: secret}
+}
+
+// ParsePrivateKeyPEM parses an RSA or EC private key. RSA keys sign with
+// RS256, P-256 keys with ES256 and P-384 keys with ES384.
+func ParsePrivateKeyPEM(id string, data []byte) (*SigningKey, error) {
+    if key, err := jwt.ParseRSAPrivateKeyFromPEM(data); err == nil {
+        return &SigningKey{ID: id, method: jwt.SigningMethodRS256, sign: key, verify: &key.PublicKey}, nil
+    }
+    key, err := jwt.ParseECPrivateKeyFromPEM(data)
+    if err != nil {
+        return nil, fmt.Errorf("key %q is not an RSA or EC private key", id)
+    }
+    method, err := ecMethod(key.Curve)
+    if err != nil {
+        return nil, fmt.Errorf("key %q: %w", id, err)
+    }
+    return &SigningKey{ID: id, method: method, sign: key, verify: &key.PublicKey}, nil
+}
+
+// ParsePublicKeyPEM parses an RSA or EC public key, or takes the public
+// half of a private key, for verification only.
+func ParsePublicKeyPEM(id string, data []byte) (*SigningKey, error) {
+    if key, err := ParsePrivateKeyPEM(id, data); err == nil {
+        key.sign = nil
+        return key, nil
+    }
+    if key, err := jwt.ParseRSAPublicKeyFromPEM(data); err == nil {
+        return &SigningKey{ID: id, method: jwt.SigningMethodRS256, verify: key}, nil
+    }
+    key, err := jwt.ParseECPublicKeyFromPEM(data)
+    if err != nil {
+        return nil, fmt.Errorf("key %q is not an RSA or EC key", id)
+    }
+    method, err := ecMethod(key.Curve)
+    if err != nil {
+        return nil, fmt.Errorf("key %q: %w", id, err)
+    }
+    return &SigningKey{ID: id, method: method, verify: key}, nil
+}
+
+func ecMethod(curve elliptic.Curve) (jwt.SigningMethod, error) {
+    switch curve {
+    case elliptic.P256():
+        return jwt.SigningMethodES256, nil
+    case elliptic.P384():
+        return jwt.SigningMethodES384, nil
+    default:
+        return nil, fmt.Errorf("unsupported curve %s", curve.Params().Name)
+    }
+}
+
+// LoadPrivateKeyFile reads a PEM private key to sign tokens with.
+func LoadPrivateKeyFile(id, path string) (*SigningKey, error) {
+    data, err := os.ReadFile(path)
+    if err != nil {
+        return nil, fmt.Errorf("failed to read signing key: %w", err)
+    }
+    return ParsePrivateKeyPEM(id, data)
+}
+
+// LoadVerificationKeys reads keys listed as "kid=path,kid=path". Each file
+// may hold a public or private PEM key; only the public half is kept.
+func LoadVerificationKeys(spec string) ([]*SigningKey, error) {
+    var keys []*SigningKey
+    for _, entry := range strings.Split(spec, ",") {
+        entry = strings.TrimSpace(entry)
+        if entry == "" {
+            continue
+        }
+        id, path, ok := strings.Cut(entry, "=")
+        if !ok || id == "" || path == "" {
+            return nil, fmt.Errorf("invalid verification key %q, want kid=path", entry)
+        }
+        data, err := os.ReadFile(path)
+        if err != nil {
+            return nil, fmt.Errorf("failed to read verification key %q: %w", id, err)
+        }
+        key, err := ParsePublicKeyPEM(id, data)
+        if err != nil {
+            return nil, err
+        }
+        keys = append(keys, key)
+    }
+    return keys, nil
+}
+
+// JWK is a public key in JSON Web Key form.
+type JWK struct {
+    Kty string `json:"kty"`
+    Kid string `json:"kid"`
+    Use string `json:"use"`
+    Alg string `json:"alg"`
+    N   string `json:"n,omitempty"`
+    E   string `json:"e,omitempty"`
+    Crv string `json:"crv,omitempty"`
+    X   string `json:"x,omitempty"`
+    Y   string `json:"y,omitempty"`
+}
+
+// JWKS is the document served at /.well-known/jwks.json.
+type JWKS struct {
+    Keys []JWK `json:"keys"`
+}
+
+// jwk returns the key's public half, or false for HMAC keys.
+func (k *SigningKey) jwk() (JWK, bool) {
+    enc := base64.RawURLEncoding
+    switch pub := k.verify.(type) {
+    case *rsa.PublicKey:
+        return JWK{
+            Kty: "RSA",
+            Kid: k.ID,
+            Use: "sig",
+            Alg: k.method.Alg(),
+            N:   enc.EncodeToString(pub.N.Bytes()),
+            E:   enc.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+        }, true
+    case *ecdsa.PublicKey:
+        // Coordinates are padded to the curve size as RFC 7518 requires
+        size := (pub.Curve.Params().BitSize + 7) / 8
+        return JWK{
+            Kty: "EC",
+            Kid: k.ID,
+            Use: "sig",
+            Alg: k.method.Alg(),
+            Crv: pub.Curve.Params().Name,
+            X:   enc.EncodeToString(pub.X.FillBytes(make([]byte, size))),
+            Y:   enc.EncodeToString(pub.Y.FillBytes(make([]byte, size))),
+        }, true
+    default:
+        return JWK{}, false
+    }
+}
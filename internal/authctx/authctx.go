@@ -0,0 +1,88 @@
+package authctx
+
+import (
+    "context"
+    "errors"
+    "time"
+
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+var ErrUnauthenticated = errors.New("unauthenticated")
+
+// SystemActor is reported by Actor for work not triggered by a user, such
+// as scheduled jobs and provider polling.
+const SystemActor = "system"
+
+// Principal is the authenticated caller of a request or websocket
+// connection.
+type Principal struct {
+    UserID          string
+    Username        string
+    IsAdmin         bool
+    SessionID       string
+    AccountType     string
+    RateLimitExempt bool
+    // IssuedAt is when the caller's token was issued, checked against
+    // the user's session revocations
+    IssuedAt time.Time
+}
+
+// Rate limit exemption reasons, reported by Exemption.
+const (
+    ExemptAdmin       = "admin"
+    ExemptBot         = "bot"
+    ExemptBroadcaster = "broadcaster"
+)
+
+// Exemption reports why the principal bypasses rate limits, or "" if it
+// does not. Admins are always exempt; bot and broadcaster accounts only
+// once verified.
+func (p *Principal) Exemption() string {
+    if p == nil {
+        return ""
+    }
+    if p.IsAdmin {
+        return ExemptAdmin
+    }
+    if !p.RateLimitExempt {
+        return ""
+    }
+    switch p.AccountType {
+    case models.AccountTypeBot:
+        return ExemptBot
+    case models.AccountTypeBroadcaster:
+        return ExemptBroadcaster
+    }
+    return ""
+}
+
+type contextKey struct{}
+
+func WithUser(ctx context.Context, principal *Principal) context.Context {
+    return context.WithValue(ctx, contextKey{}, principal)
+}
+
+// UserFrom returns the principal attached to ctx, if any.
+func UserFrom(ctx context.Context) (*Principal, bool) {
+    principal, ok := ctx.Value(contextKey{}).(*Principal)
+    return principal, ok && principal != nil
+}
+
+// RequireUser is UserFrom for code paths that must only run for
+// authenticated callers.
+func RequireUser(ctx context.Context) (*Principal, error) {
+    principal, ok := UserFrom(ctx)
+    if !ok {
+        return nil, ErrUnauthenticated
+    }
+    return principal, nil
+}
+
+// Actor identifies who performed an operation for audit attribution.
+func Actor(ctx context.Context) string {
+    if principal, ok := UserFrom(ctx); ok {
+        return principal.UserID
+    }
+    return SystemActor
+}
@@ -0,0 +1,158 @@
+// Package avatars stores profile pictures in object storage. Uploads are
+// decoded, cropped to a square and scaled down to one size, so whatever a
+// user sends, chat only ever loads a small PNG. Each upload gets its own
+// key, so avatars can be cached forever and a change is picked up by its
+// new URL.
+package avatars
+
+import (
+    "bytes"
+    "context"
+    "errors"
+    "fmt"
+    "image"
+    "image/color"
+    _ "image/gif"
+    _ "image/jpeg"
+    "image/png"
+    "strings"
+
+    "github.com/google/uuid"
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/objectstore"
+)
+
+const (
+    // Size is the width and height avatars are stored at, in pixels
+    Size = 256
+    // maxSourcePixels bounds the images accepted, checked before
+    // decoding, so a small file cannot decode to gigabytes
+    maxSourcePixels = 4096 * 4096
+
+    keyPrefix = "avatars/"
+)
+
+// ErrInvalidImage is returned for uploads that are not a PNG, JPEG or GIF
+// image of an acceptable size.
+var ErrInvalidImage = errors.New("avatar must be a PNG, JPEG or GIF image up to 4096x4096")
+
+type Service struct {
+    bucket objectstore.Bucket
+    // Prefix of the URLs avatars are served from; the key follows it
+    baseURL string
+    logger  *zap.Logger
+}
+
+// NewService stores avatars in bucket. baseURL is where the bucket's keys
+// are served, such as a CDN in front of it or the API's avatar route.
+func NewService(bucket objectstore.Bucket, baseURL string, logger *zap.Logger) *Service {
+    if !strings.HasSuffix(baseURL, "/") {
+        baseURL += "/"
+    }
+    return &Service{bucket: bucket, baseURL: baseURL, logger: logger}
+}
+
+// Upload resizes an image and stores it as the user's new avatar,
+// returning its URL. The previous avatar is left for Remove, once the
+// user's profile no longer points at it.
+func (s *Service) Upload(ctx context.Context, userID string, data []byte) (string, error) {
+    config, _, err := image.DecodeConfig(bytes.NewReader(data))
+    if err != nil || config.Width == 0 || config.Height == 0 || config.Width*config.Height > maxSourcePixels {
+        return "", ErrInvalidImage
+    }
+    src, _, err := image.Decode(bytes.NewReader(data))
+    if err != nil {
+        return "", ErrInvalidImage
+    }
+
+    var out bytes.Buffer
+    if err := png.Encode(&out, resize(squareCrop(src), Size)); err != nil {
+        return "", fmt.Errorf("failed to encode avatar: %w", err)
+    }
+    key := keyPrefix + userID + "/" + uuid.NewString() + ".png"
+    if err := s.bucket.Put(ctx, key, "image/png", out.Bytes()); err != nil {
+        return "", err
+    }
+    return s.baseURL + key, nil
+}
+
+// Remove deletes an avatar this service stored, by its URL. URLs from
+// elsewhere, such as an identity provider's, are left alone.
+func (s *Service) Remove(ctx context.Context, url string) {
+    key, ok := s.Key(url)
+    if !ok {
+        return
+    }
+    if err := s.bucket.Delete(ctx, key); err != nil {
+        s.logger.Warn("Failed to delete old avatar", zap.Error(err), zap.String("key", key))
+    }
+}
+
+// Key returns the object key of an avatar URL this service made.
+func (s *Service) Key(url string) (string, bool) {
+    key, ok := strings.CutPrefix(url, s.baseURL)
+    if !ok || !strings.HasPrefix(key, keyPrefix) || strings.Contains(key, "..") {
+        return "", false
+    }
+    return key, true
+}
+
+// Get reads a stored avatar by key.
+func (s *Service) Get(ctx context.Context, key string) ([]byte, error) {
+    return s.bucket.Get(ctx, key)
+}
+
+// squareCrop returns the largest centered square of img.
+func squareCrop(img image.Image) image.Image {
+    b := img.Bounds()
+    side := b.Dx()
+    if b.Dy() < side {
+        side = b.Dy()
+    }
+    x := b.Min.X + (b.Dx()-side)/2
+    y := b.Min.Y + (b.Dy()-side)/2
+    crop := image.NewNRGBA(image.Rect(0, 0, side, side))
+    for dy := 0; dy < side; dy++ {
+        for dx := 0; dx < side; dx++ {
+            crop.Set(dx, dy, img.At(x+dx, y+dy))
+        }
+    }
+    return crop
+}
+
+// resize scales a square image to size by averaging the source pixels
+// each destination pixel covers. Smaller images are scaled up by
+// repeating pixels.
+func resize(img image.Image, size int) *image.NRGBA {
+    b := img.Bounds()
+    dst := image.NewNRGBA(image.Rect(0, 0, size, size))
+    for y := 0; y < size; y++ {
+        y0 := b.Min.Y + y*b.Dy()/size
+        y1 := b.Min.Y + (y+1)*b.Dy()/size
+        if y1 <= y0 {
+            y1 = y0 + 1
+        }
+        for x := 0; x < size; x++ {
+            x0 := b.Min.X + x*b.Dx()/size
+            x1 := b.Min.X + (x+1)*b.Dx()/size
+            if x1 <= x0 {
+                x1 = x0 + 1
+            }
+
+            var r, g, bl, a, n uint64
+            for sy := y0; sy < y1; sy++ {
+                for sx := x0; sx < x1; sx++ {
+                    c := color.NRGBAModel.Convert(img.At(sx, sy)).(color.NRGBA)
+                    r += uint64(c.R)
+                    g += uint64(c.G)
+                    bl += uint64(c.B)
+                    a += uint64(c.A)
+                    n++
+                }
+            }
+            dst.SetNRGBA(x, y, color.NRGBA{R: uint8(r / n), G: uint8(g / n), B: uint8(bl / n), A: uint8(a / n)})
+        }
+    }
+    return dst
+}
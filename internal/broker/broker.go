@@ -0,0 +1,123 @@
+package broker
+
+import (
+    "context"
+    "encoding/json"
+    "sync"
+)
+
+// Message is a frame published to a room.
+type Message struct {
+    Room    string          `json:"room"`
+    Payload json.RawMessage `json:"payload"`
+    // ExcludeUser, if set, is not delivered the frame on any connection;
+    // typing indicators use it to skip the typist.
+    ExcludeUser string `json:"exclude_user,omitempty"`
+    // ExcludeConn, if set, is a single connection not delivered the frame:
+    // a sender that relies on acks instead of seeing its messages echoed.
+    ExcludeConn string `json:"exclude_conn,omitempty"`
+    // TargetUser, if set, is the only user delivered the frame; voice
+    // signaling uses it to reach one peer through the room.
+    TargetUser string `json:"target_user,omitempty"`
+    // Users, if set, receives the frame on every connection whatever rooms
+    // they are in; Room is empty. Direct messages use it.
+    Users []string `json:"users,omitempty"`
+    // Sanctioned, if set, is a user whose room sanctions just changed.
+    // Every instance drops its cached sanctions for them and evicts them
+    // from the room if they are now banned.
+    Sanctioned string `json:"sanctioned,omitempty"`
+    // RoleChanged, if set, is a user whose role in the room just changed.
+    // Every instance drops its cached role for them.
+    RoleChanged string `json:"role_changed,omitempty"`
+    // RoomChanged marks a change to the room's settings; every instance
+    // drops its cached copy.
+    RoomChanged bool `json:"room_changed,omitempty"`
+    // AgeGated marks a change to the room's age gate; every instance
+    // removes members who no longer meet it.
+    AgeGated bool `json:"age_gated,omitempty"`
+    // RoomDeleted marks the room's deletion; every instance removes its
+    // clients from the room once they have been delivered the frame.
+    RoomDeleted bool `json:"room_deleted,omitempty"`
+    // Renamed, if set, is a user who just changed their username or
+    // profile. Every instance updates its connections for them before
+    // delivering.
+    Renamed string `json:"renamed,omitempty"`
+    // Localize marks a match event frame whose description each instance
+    // renders in its clients' languages before delivering.
+    Localize bool `json:"localize,omitempty"`
+    // MatchPushed marks a match pushed by the sports data provider; the
+    // payload is the match, not a frame. Every instance sends its own
+    // clients the changes, as it would after polling.
+    MatchPushed bool `json:"match_pushed,omitempty"`
+    // Disconnect, if set, is a user whose connections every instance
+    // closes, e.g. after their sessions were revoked; Room is empty.
+    Disconnect string `json:"disconnect,omitempty"`
+    // ShadowBanned, if set, is a user whose shadow ban was just applied or
+    // removed. Every instance reloads it for their connections; Room is
+    // empty and nothing is delivered.
+    ShadowBanned string `json:"shadow_banned,omitempty"`
+    // Ticker, if set, is a competition whose ticker followers on every
+    // instance are delivered the frame; Room is empty.
+    Ticker string `json:"ticker,omitempty"`
+    // Hype marks reaction counts for the room's hype meter; the payload
+    // is the counts, not a frame. Every instance adds them to its meter
+    // and sends its own clients the room's totals.
+    Hype bool `json:"hype,omitempty"`
+    // Everyone delivers the frame to every connection on every instance;
+    // Room is empty. System announcements use it.
+    Everyone bool `json:"everyone,omitempty"`
+    // Priority orders local delivery when a room is busy; lower values
+    // are delivered first.
+    Priority int `json:"priority,omitempty"`
+    // Trace carries the publisher's trace context, so delivery on every
+    // instance joins the trace of the message that caused it.
+    Trace map[string]string `json:"trace,omitempty"`
+}
+
+// Handler receives a frame published to a room, on whichever instance
+// published it.
+type Handler func(msg *Message)
+
+// Broker fans room frames out to every server instance. Each instance
+// subscribes once and delivers what it receives to its own connected
+// clients, so a room may span instances behind a load balancer.
+// Implementations must also deliver an instance's own publications back
+// to it.
+type Broker interface {
+    Publish(ctx context.Context, msg *Message) error
+    Subscribe(handler Handler) error
+    Close() error
+}
+
+// Local is the single-instance broker: Publish hands the frame straight
+// to the subscribed handler.
+type Local struct {
+    mu      sync.RWMutex
+    handler Handler
+}
+
+func NewLocal() *Local {
+    return &Local{}
+}
+
+func (l *Local) Publish(ctx context.Context, msg *Message) error {
+    l.mu.RLock()
+    handler := l.handler
+    l.mu.RUnlock()
+
+    if handler != nil {
+        handler(msg)
+    }
+    return nil
+}
+
+func (l *Local) Subscribe(handler Handler) error {
+    l.mu.Lock()
+    l.handler = handler
+    l.mu.Unlock()
+    return nil
+}
+
+func (l *Local) Close() error {
+    return nil
+}
@@ -0,0 +1,90 @@
+package broker
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+
+    "github.com/redis/go-redis/v9"
+    "go.uber.org/zap"
+)
+
+// Redis fans frames out over Redis Pub/Sub, one channel per room under a
+// shared prefix. Pub/Sub is fire-and-forget: an instance that is
+// disconnected from Redis misses frames published meanwhile, the same as
+// a client with a full send buffer.
+type Redis struct {
+    client *redis.Client
+    prefix string
+    logger *zap.Logger
+
+    pubsub *redis.PubSub
+    done   chan struct{}
+}
+
+func NewRedis(url, prefix string, logger *zap.Logger) (*Redis, error) {
+    opts, err := redis.ParseURL(url)
+    if err != nil {
+        return nil, fmt.Errorf("failed to parse redis url: %w", err)
+    }
+
+    client := redis.NewClient(opts)
+    if err := client.Ping(context.Background()).Err(); err != nil {
+        client.Close()
+        return nil, fmt.Errorf("failed to connect to redis: %w", err)
+    }
+
+    return &Redis{
+        client: client,
+        prefix: prefix + "room:",
+        logger: logger,
+        done:   make(chan struct{}),
+    }, nil
+}
+
+func (r *Redis) Publish(ctx context.Context, msg *Message) error {
+    data, err := json.Marshal(msg)
+    if err != nil {
+        return fmt.Errorf("failed to encode message: %w", err)
+    }
+    if err := r.client.Publish(ctx, r.prefix+msg.Room, data).Err(); err != nil {
+        return fmt.Errorf("failed to publish to room %s: %w", msg.Room, err)
+    }
+    return nil
+}
+
+// Subscribe starts delivering frames for every room to handler. The
+// go-redis client reconnects and resubscribes on its own after errors.
+func (r *Redis) Subscribe(handler Handler) error {
+    ctx := context.Background()
+    r.pubsub = r.client.PSubscribe(ctx, r.prefix+"*")
+    if _, err := r.pubsub.Receive(ctx); err != nil {
+        r.pubsub.Close()
+        return fmt.Errorf("failed to subscribe to rooms: %w", err)
+    }
+
+    go func() {
+        defer close(r.done)
+        for m := range r.pubsub.Channel() {
+            var msg Message
+            if err := json.Unmarshal([]byte(m.Payload), &msg); err != nil {
+                r.logger.Warn("Dropping malformed broker message",
+                    zap.Error(err),
+                    zap.String("channel", m.Channel))
+                continue
+            }
+            handler(&msg)
+        }
+    }()
+    return nil
+}
+
+func (r *Redis) Close() error {
+    if r.pubsub != nil {
+        if err := r.pubsub.Close(); err != nil {
+            r.logger.Warn("Failed to close redis subscription", zap.Error(err))
+        }
+        <-r.done
+    }
+    return r.client.Close()
+}
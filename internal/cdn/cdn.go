@@ -0,0 +1,141 @@
+// Package cdn tags cacheable public responses with surrogate keys and
+// purges those keys from the CDN when the data behind them changes, so
+// read-heavy match traffic can be served from the edge without scores
+// going stale.
+package cdn
+
+import (
+    "bytes"
+    "context"
+    "encoding/json"
+    "fmt"
+    "io"
+    "net/http"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/events"
+    "github.com/yourusername/sports-chat/internal/jobs"
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+// LiveMatchesKey tags the list of live matches.
+const LiveMatchesKey = "matches-live"
+
+const purgeJobName = "cdn.purge"
+
+// MatchKey tags every cached response carrying one match's score.
+func MatchKey(matchID string) string {
+    return "match-" + matchID
+}
+
+// Purger removes everything tagged with any of keys from the CDN.
+type Purger interface {
+    Purge(ctx context.Context, keys []string) error
+}
+
+// HTTPPurger purges keys with Fastly's bulk surrogate key purge, which
+// takes the keys as a JSON body and the API token in a header.
+type HTTPPurger struct {
+    url   string
+    token string
+    http  *http.Client
+}
+
+func NewHTTPPurger(url, token string) *HTTPPurger {
+    return &HTTPPurger{
+        url:   url,
+        token: token,
+        http:  &http.Client{Timeout: 10 * time.Second},
+    }
+}
+
+func (p *HTTPPurger) Purge(ctx context.Context, keys []string) error {
+    body, err := json.Marshal(map[string][]string{"surrogate_keys": keys})
+    if err != nil {
+        return fmt.Errorf("failed to marshal purge request: %w", err)
+    }
+
+    req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(body))
+    if err != nil {
+        return err
+    }
+    req.Header.Set("Content-Type", "application/json")
+    req.Header.Set("Fastly-Key", p.token)
+
+    resp, err := p.http.Do(req)
+    if err != nil {
+        return fmt.Errorf("purge request failed: %w", err)
+    }
+    defer resp.Body.Close()
+    io.Copy(io.Discard, resp.Body)
+
+    if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+        return fmt.Errorf("cdn returned %d", resp.StatusCode)
+    }
+    return nil
+}
+
+// Invalidator purges a match's keys through the job queue when its score
+// or status changes, so a slow CDN API never holds up the score sync and
+// failed purges are retried and dead-lettered like any other job. Every
+// instance syncing scores purges; purging a key twice is harmless.
+type Invalidator struct {
+    purger Purger
+    queue  *jobs.Queue
+    logger *zap.Logger
+}
+
+func NewInvalidator(purger Purger, queue *jobs.Queue, logger *zap.Logger) *Invalidator {
+    inv := &Invalidator{
+        purger: purger,
+        queue:  queue,
+        logger: logger,
+    }
+    queue.Register(purgeJobName, func(payload []byte) (jobs.Job, error) {
+        job := &purgeJob{purger: purger}
+        if err := json.Unmarshal(payload, job); err != nil {
+            return nil, err
+        }
+        return job, nil
+    })
+    return inv
+}
+
+// Start purges on every saved score or status change.
+func (inv *Invalidator) Start(bus *events.Bus) {
+    bus.Subscribe(events.TypeMatchUpdated, func(event events.Event) {
+        e := event.(events.MatchUpdated)
+        inv.InvalidateMatch(e.MatchID)
+    })
+}
+
+// InvalidateMatch queues a purge of the match and of the live list, which
+// it may have joined or left.
+func (inv *Invalidator) InvalidateMatch(matchID string) {
+    job := &purgeJob{purger: inv.purger, Keys: []string{MatchKey(matchID), LiveMatchesKey}}
+    if err := inv.queue.Enqueue(job); err != nil {
+        inv.logger.Warn("Failed to queue cdn purge", zap.Error(err), zap.String("match_id", matchID))
+    }
+}
+
+// ResettleMatch purges a match whose final result was corrected, making
+// the invalidator a reconciliation settler.
+func (inv *Invalidator) ResettleMatch(ctx context.Context, match *models.Match) error {
+    inv.InvalidateMatch(match.ID)
+    return nil
+}
+
+type purgeJob struct {
+    purger Purger
+    Keys   []string `json:"keys"`
+}
+
+func (j *purgeJob) Name() string { return purgeJobName }
+
+func (j *purgeJob) Payload() ([]byte, error) { return json.Marshal(j) }
+
+func (j *purgeJob) Run(ctx context.Context) error {
+    return j.purger.Purge(ctx, j.Keys)
+}
@@ -0,0 +1,158 @@
+// Package commentary renders system-generated match event messages, such
+// as goals, cards and the half-time whistle, in each client's language.
+// Templates are kept per locale and event type; lookups fall back from
+// "pt-BR" to "pt" to DefaultLocale, and an event with no template keeps
+// the description its provider sent.
+package commentary
+
+import (
+    "context"
+    "fmt"
+    "sort"
+    "strconv"
+    "strings"
+    "sync"
+
+    "github.com/yourusername/sports-chat/internal/locale"
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/store"
+)
+
+// DefaultLocale holds the templates for clients whose language has none.
+const DefaultLocale = locale.Default
+
+// Placeholders lists what templates may refer to.
+var Placeholders = []string{"{minute}", "{description}", "{home}", "{away}", "{home_score}", "{away_score}"}
+
+type Renderer struct {
+    store store.Store
+
+    mu        sync.RWMutex
+    templates map[string]map[string]string // locale -> event type -> template
+}
+
+func NewRenderer(store store.Store) *Renderer {
+    return &Renderer{store: store, templates: make(map[string]map[string]string)}
+}
+
+// Reload replaces the templates from the store. Admin changes call it so
+// they take effect without a restart.
+func (r *Renderer) Reload(ctx context.Context) error {
+    list, err := r.store.ListCommentaryTemplates(ctx)
+    if err != nil {
+        return fmt.Errorf("failed to load commentary templates: %w", err)
+    }
+
+    templates := make(map[string]map[string]string)
+    for _, t := range list {
+        lang := locale.Normalize(t.Locale)
+        if templates[lang] == nil {
+            templates[lang] = make(map[string]string)
+        }
+        templates[lang][NormalizeEventType(t.EventType)] = t.Template
+    }
+
+    r.mu.Lock()
+    r.templates = templates
+    r.mu.Unlock()
+    return nil
+}
+
+// Resolve returns the locale whose template renders events of eventType
+// for a client in lang, or "" if none does. Clients resolving to the
+// same locale are sent the same rendering.
+func (r *Renderer) Resolve(lang, eventType string) string {
+    eventType = NormalizeEventType(eventType)
+
+    r.mu.RLock()
+    defer r.mu.RUnlock()
+    for _, candidate := range append(locale.Chain(lang), DefaultLocale) {
+        if _, ok := r.templates[candidate][eventType]; ok {
+            return candidate
+        }
+    }
+    return ""
+}
+
+// Render returns the event's description from the template of a locale
+// Resolve returned. match may be nil, leaving its placeholders empty.
+func (r *Renderer) Render(locale string, event *models.MatchEvent, match *models.Match) string {
+    r.mu.RLock()
+    template, ok := r.templates[locale][NormalizeEventType(event.EventType)]
+    r.mu.RUnlock()
+    if !ok {
+        return event.Description
+    }
+
+    var home, away, homeScore, awayScore string
+    if match != nil {
+        if match.HomeTeam != nil {
+            home = match.HomeTeam.Name
+        }
+        if match.AwayTeam != nil {
+            away = match.AwayTeam.Name
+        }
+        homeScore, awayScore = strconv.Itoa(match.HomeScore), strconv.Itoa(match.AwayScore)
+    }
+    return strings.NewReplacer(
+        "{minute}", strconv.Itoa(event.EventTime),
+        "{description}", event.Description,
+        "{home}", home,
+        "{away}", away,
+        "{home_score}", homeScore,
+        "{away_score}", awayScore,
+    ).Replace(template)
+}
+
+// NormalizeEventType uppercases an event type; providers' are stored
+// lowercased.
+func NormalizeEventType(eventType string) string {
+    return strings.ToUpper(strings.TrimSpace(eventType))
+}
+
+// PreferredLocale returns the language a client prefers most from an
+// Accept-Language header, or "" if it names none.
+func PreferredLocale(header string) string {
+    type choice struct {
+        locale string
+        q      float64
+    }
+    var choices []choice
+    for _, part := range strings.Split(header, ",") {
+        tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+        tag = strings.TrimSpace(tag)
+        if tag == "" || tag == "*" {
+            continue
+        }
+        q := 1.0
+        if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+            parsed, err := strconv.ParseFloat(value, 64)
+            if err != nil || parsed <= 0 {
+                continue
+            }
+            q = parsed
+        }
+        choices = append(choices, choice{locale: locale.Normalize(tag), q: q})
+    }
+    if len(choices) == 0 {
+        return ""
+    }
+    sort.SliceStable(choices, func(i, j int) bool { return choices[i].q > choices[j].q })
+    return choices[0].locale
+}
+
+// ReloadJob reloads the templates on a schedule, so admin changes made
+// through another instance reach this one too.
+type ReloadJob struct {
+    renderer *Renderer
+}
+
+func NewReloadJob(renderer *Renderer) *ReloadJob {
+    return &ReloadJob{renderer: renderer}
+}
+
+func (j *ReloadJob) Name() string { return "commentary.reload" }
+
+func (j *ReloadJob) Run(ctx context.Context) error {
+    return j.renderer.Reload(ctx)
+}
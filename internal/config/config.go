@@ -6,27 +6,52 @@ import (
     "time"
 
     "github.com/spf13/viper"
+
+    "github.com/yourusername/sports-chat/internal/ratelimit"
+    "github.com/yourusername/sports-chat/internal/scim"
 )
 
 type Config struct {
     // Server settings
     ServerAddress      string        `mapstructure:"SERVER_ADDRESS"`
-    GracefulTimeout   time.Duration `mapstructure:"GRACEFUL_TIMEOUT"`
-    ReadTimeout       time.Duration `mapstructure:"READ_TIMEOUT"`
-    WriteTimeout      time.Duration `mapstructure:"WRITE_TIMEOUT"`
-    IdleTimeout       time.Duration `mapstructure:"IDLE_TIMEOUT"`
+    GracefulTimeout    time.Duration `mapstructure:"GRACEFUL_TIMEOUT"`
+    ReadTimeout        time.Duration `mapstructure:"READ_TIMEOUT"`
+    WriteTimeout       time.Duration `mapstructure:"WRITE_TIMEOUT"`
+    IdleTimeout        time.Duration `mapstructure:"IDLE_TIMEOUT"`
+    RequestTimeout     time.Duration `mapstructure:"REQUEST_TIMEOUT"`
+    LongRequestTimeout time.Duration `mapstructure:"LONG_REQUEST_TIMEOUT"`
     
-    // Database settings
+    // Database settings. STORE_DRIVER "memory" keeps everything in
+    // process memory instead, for local development; refused in production
+    StoreDriver       string        `mapstructure:"STORE_DRIVER"`
     DatabaseURL       string        `mapstructure:"DATABASE_URL"`
     MaxDBConnections  int           `mapstructure:"MAX_DB_CONNECTIONS"`
     MaxIdleConns      int           `mapstructure:"MAX_IDLE_CONNECTIONS"`
     ConnMaxLifetime   time.Duration `mapstructure:"CONN_MAX_LIFETIME"`
+    // Longest any single store query may run before it is cancelled
+    DBQueryTimeout    time.Duration `mapstructure:"DB_QUERY_TIMEOUT"`
+    // Retries of store calls that failed without reaching the database,
+    // and the breaker that fails calls fast through an outage
+    DBRetryAttempts    int           `mapstructure:"DB_RETRY_ATTEMPTS"`
+    DBRetryBaseDelay   time.Duration `mapstructure:"DB_RETRY_BASE_DELAY"`
+    DBRetryMaxDelay    time.Duration `mapstructure:"DB_RETRY_MAX_DELAY"`
+    DBBreakerThreshold int           `mapstructure:"DB_BREAKER_THRESHOLD"`
+    DBBreakerCooldown  time.Duration `mapstructure:"DB_BREAKER_COOLDOWN"`
     
     // Authentication
     JWTSecret        string        `mapstructure:"JWT_SECRET"`
     JWTExpiration    time.Duration `mapstructure:"JWT_EXPIRATION"`
     RefreshTokenExp  time.Duration `mapstructure:"REFRESH_TOKEN_EXPIRATION"`
     
+    // Asymmetric token signing. Tokens are signed with the key file and
+    // verified against it and JWT_VERIFICATION_KEYS ("kid=path,..."),
+    // which carries retired keys until their tokens expire and upcoming
+    // keys ahead of a rotation. JWT_SECRET, if also set, keeps verifying
+    // tokens issued before the switch.
+    JWTSigningKeyFile   string `mapstructure:"JWT_SIGNING_KEY_FILE"`
+    JWTSigningKeyID     string `mapstructure:"JWT_SIGNING_KEY_ID"`
+    JWTVerificationKeys string `mapstructure:"JWT_VERIFICATION_KEYS"`
+    
     // WebSocket settings
     WSReadBufferSize     int           `mapstructure:"WS_READ_BUFFER_SIZE"`
     WSWriteBufferSize    int           `mapstructure:"WS_WRITE_BUFFER_SIZE"`
@@ -34,10 +59,37 @@ type Config struct {
     WSPongWait           time.Duration `mapstructure:"WS_PONG_WAIT"`
     WSPingPeriod         time.Duration `mapstructure:"WS_PING_PERIOD"`
     WSMaxMessageSize     int64         `mapstructure:"WS_MAX_MESSAGE_SIZE"`
+    // permessage-deflate for clients that offer it. Level is flate's,
+    // -2 to 9; frames under the threshold, in bytes, go uncompressed.
+    WSCompression          bool        `mapstructure:"WS_COMPRESSION"`
+    WSCompressionLevel     int         `mapstructure:"WS_COMPRESSION_LEVEL"`
+    WSCompressionThreshold int         `mapstructure:"WS_COMPRESSION_THRESHOLD"`
+    // Longest window a client may ask, with ?coalesce=<ms>, for its
+    // frames to be batched into one message; zero disables coalescing
+    WSCoalesceWindow       time.Duration `mapstructure:"WS_COALESCE_WINDOW"`
+    // Frames each connection queues, and what happens to one whose
+    // queue is full: disconnect, after a warning, or drop_oldest
+    WSSendBuffer           int         `mapstructure:"WS_SEND_BUFFER"`
+    WSSlowConsumerPolicy   string      `mapstructure:"WS_SLOW_CONSUMER_POLICY"`
+    // How long after a drop a client can reconnect with its resume token
+    // and carry on without rejoining its rooms; zero disables resuming
+    WSResumeWindow         time.Duration `mapstructure:"WS_RESUME_WINDOW"`
+    // New connections are refused while any of these is reached; zero
+    // disables a limit. Refused clients are told to retry after
+    // WS_SHED_RETRY_AFTER.
+    WSMaxConnections       int           `mapstructure:"WS_MAX_CONNECTIONS"`
+    WSMaxGoroutines        int           `mapstructure:"WS_MAX_GOROUTINES"`
+    WSMaxHeapMB            int           `mapstructure:"WS_MAX_HEAP_MB"`
+    WSShedRetryAfter       time.Duration `mapstructure:"WS_SHED_RETRY_AFTER"`
+    // How long the hub loop may stall before the instance sheds load; a
+    // loop stalled twice as long is restarted
+    WSHubStallTimeout      time.Duration `mapstructure:"WS_HUB_STALL_TIMEOUT"`
     
     // Rate limiting
     RateLimitWindow      time.Duration `mapstructure:"RATE_LIMIT_WINDOW"`
     RateLimitRequests    int           `mapstructure:"RATE_LIMIT_REQUESTS"`
+    RateLimitBackend     string        `mapstructure:"RATE_LIMIT_BACKEND"`
+    WSRateLimits         string        `mapstructure:"WS_RATE_LIMITS"`
     
     // CORS settings
     CORSAllowedOrigins   []string      `mapstructure:"CORS_ALLOWED_ORIGINS"`
@@ -45,15 +97,205 @@ type Config struct {
     // Sports API settings
     SportsAPIKey         string        `mapstructure:"SPORTS_API_KEY"`
     SportsAPIURL         string        `mapstructure:"SPORTS_API_URL"`
+    // live, record or replay; the last two keep responses under
+    // SPORTS_API_FIXTURES
+    SportsAPIMode        string        `mapstructure:"SPORTS_API_MODE"`
+    SportsAPIFixtures    string        `mapstructure:"SPORTS_API_FIXTURES"`
+    // Secret the provider signs pushed match updates with; empty turns
+    // the ingest endpoint off
+    SportsWebhookSecret  string        `mapstructure:"SPORTS_WEBHOOK_SECRET"`
+    ReconciliationHour   int           `mapstructure:"RECONCILIATION_HOUR"`
+    ReconciliationLookback time.Duration `mapstructure:"RECONCILIATION_LOOKBACK"`
+    MatchVoteWindow      time.Duration `mapstructure:"MATCH_VOTE_WINDOW"`
+    // How often engagement stats are written and match rooms sent their
+    // leaderboard's changes, and how many places those cover
+    LeaderboardInterval  time.Duration `mapstructure:"LEADERBOARD_INTERVAL"`
+    LeaderboardSize      int           `mapstructure:"LEADERBOARD_SIZE"`
+    RoomOpenBefore       time.Duration `mapstructure:"ROOM_OPEN_BEFORE"`
+    RoomArchiveAfter     time.Duration `mapstructure:"ROOM_ARCHIVE_AFTER"`
+    // How long a deleted room can be restored before it is purged with
+    // its messages
+    RoomPurgeAfter       time.Duration `mapstructure:"ROOM_PURGE_AFTER"`
+    // How long before kickoff the rooms of matches with at least
+    // PREWARM_MIN_FOLLOWERS followers are readied on every instance
+    PrewarmLead          time.Duration `mapstructure:"PREWARM_LEAD"`
+    PrewarmMinFollowers  int           `mapstructure:"PREWARM_MIN_FOLLOWERS"`
+    
+    // Background jobs
+    JobWorkers           int           `mapstructure:"JOB_WORKERS"`
+    JobQueueSize         int           `mapstructure:"JOB_QUEUE_SIZE"`
+    JobMaxRetries        int           `mapstructure:"JOB_MAX_RETRIES"`
+    // Pending dead letters above which growth is logged as an error
+    DeadLetterAlertThreshold int `mapstructure:"DLQ_ALERT_THRESHOLD"`
+    
+    // Product analytics, exported in Segment's batch format
+    EnableAnalytics        bool          `mapstructure:"ENABLE_ANALYTICS"`
+    AnalyticsURL           string        `mapstructure:"ANALYTICS_URL"`
+    AnalyticsWriteKey      string        `mapstructure:"ANALYTICS_WRITE_KEY"`
+    AnalyticsBufferSize    int           `mapstructure:"ANALYTICS_BUFFER_SIZE"`
+    AnalyticsBatchSize     int           `mapstructure:"ANALYTICS_BATCH_SIZE"`
+    AnalyticsFlushInterval time.Duration `mapstructure:"ANALYTICS_FLUSH_INTERVAL"`
+    
+    // CDN purging of public match responses; an empty URL disables it.
+    // For Fastly, https://api.fastly.com/service/<id>/purge
+    CDNPurgeURL          string        `mapstructure:"CDN_PURGE_URL"`
+    CDNPurgeToken        string        `mapstructure:"CDN_PURGE_TOKEN"`
+    
+    // Image attachments, scanned before anyone else sees them; an empty
+    // scanner disables uploads. clamav scans with clamd at CLAMAV_ADDRESS,
+    // http posts each image to ATTACHMENT_SCAN_URL.
+    AttachmentScanner    string        `mapstructure:"ATTACHMENT_SCANNER"`
+    ClamAVAddress        string        `mapstructure:"CLAMAV_ADDRESS"`
+    AttachmentScanURL    string        `mapstructure:"ATTACHMENT_SCAN_URL"`
+    AttachmentScanToken  string        `mapstructure:"ATTACHMENT_SCAN_TOKEN"`
+    MaxAttachmentSize    int           `mapstructure:"MAX_ATTACHMENT_SIZE"`
+    
+    // Object storage for incident bundles, message archives and avatars;
+    // empty disables them. dir keeps objects under OBJECT_STORE_DIR, s3
+    // in S3_BUCKET on S3 or any compatible service at S3_ENDPOINT, such
+    // as GCS through its XML API with HMAC keys.
+    ObjectStore          string        `mapstructure:"OBJECT_STORE"`
+    ObjectStoreDir       string        `mapstructure:"OBJECT_STORE_DIR"`
+    S3Endpoint           string        `mapstructure:"S3_ENDPOINT"`
+    S3Region             string        `mapstructure:"S3_REGION"`
+    S3Bucket             string        `mapstructure:"S3_BUCKET"`
+    S3AccessKeyID        string        `mapstructure:"S3_ACCESS_KEY_ID"`
+    S3SecretAccessKey    string        `mapstructure:"S3_SECRET_ACCESS_KEY"`
+    // Largest avatar upload in bytes, and where stored avatars are
+    // served from, such as a CDN in front of the bucket; empty serves
+    // them from the API
+    MaxAvatarSize        int           `mapstructure:"MAX_AVATAR_SIZE"`
+    AvatarBaseURL        string        `mapstructure:"AVATAR_BASE_URL"`
+    // How long a user's data export stays downloadable once built;
+    // exports need an object store
+    ExportRetention      time.Duration `mapstructure:"EXPORT_RETENTION"`
+    
+    // Messages older than MESSAGE_RETENTION are moved to object storage,
+    // RETENTION_BATCH_SIZE to an archive; zero keeps them in postgres.
+    // With RETENTION_HYDRATE, history pages continue into the archives.
+    MessageRetention     time.Duration `mapstructure:"MESSAGE_RETENTION"`
+    RetentionBatchSize   int           `mapstructure:"RETENTION_BATCH_SIZE"`
+    RetentionHydrate     bool          `mapstructure:"RETENTION_HYDRATE"`
+    
+    // How often every instance reloads the moderation filters, picking up
+    // admin changes made through another instance
+    ModerationReloadInterval time.Duration `mapstructure:"MODERATION_RELOAD_INTERVAL"`
+
+    // Room and match statistics are served from rollups refreshed every
+    // STATS_ROLLUP_INTERVAL for active rooms and recent matches, and for
+    // everything once a night at STATS_ROLLUP_HOUR UTC
+    StatsRollupInterval time.Duration `mapstructure:"STATS_ROLLUP_INTERVAL"`
+    StatsRollupHour     int           `mapstructure:"STATS_ROLLUP_HOUR"`
+    
+    // Usernames
+    UsernameChangeCooldown time.Duration `mapstructure:"USERNAME_CHANGE_COOLDOWN"`
+
+    // Request header the edge sets to the client's country code, shown
+    // with account activity; empty records no country
+    GeoCountryHeader string `mapstructure:"GEO_COUNTRY_HEADER"`
+    
+    // Broadcast journal
+    EnableJournal        bool          `mapstructure:"ENABLE_JOURNAL"`
+    JournalTTL           time.Duration `mapstructure:"JOURNAL_TTL"`
+    JournalBufferSize    int           `mapstructure:"JOURNAL_BUFFER_SIZE"`
+    
+    // Link previews
+    EnableLinkPreviews   bool          `mapstructure:"ENABLE_LINK_PREVIEWS"`
+    UnfurlTimeout        time.Duration `mapstructure:"UNFURL_TIMEOUT"`
+    UnfurlCacheTTL       time.Duration `mapstructure:"UNFURL_CACHE_TTL"`
+    
+    // Ban evasion detection
+    EnableEvasionDetection bool          `mapstructure:"ENABLE_EVASION_DETECTION"`
+    EvasionThreshold       float64       `mapstructure:"EVASION_THRESHOLD"`
+    EvasionLookback        time.Duration `mapstructure:"EVASION_LOOKBACK"`
+    
+    // Moderation evidence: messages moderators delete are kept sealed
+    // with the first of EVIDENCE_KEYS ("kid=base64key,...", 32-byte
+    // keys; the rest open evidence from before a rotation) and purged
+    // after EVIDENCE_RETENTION
+    EnableEvidence       bool          `mapstructure:"ENABLE_EVIDENCE"`
+    EvidenceKeys         string        `mapstructure:"EVIDENCE_KEYS"`
+    EvidenceRetention    time.Duration `mapstructure:"EVIDENCE_RETENTION"`
+    
+    // Public room previews. Logged-out viewers may react into the hype
+    // meter when PREVIEW_REACTIONS is set, each address limited by
+    // PREVIEW_REACTION_LIMITS: "default" across rooms, "room" per room.
+    PreviewMessages      int           `mapstructure:"PREVIEW_MESSAGES"`
+    PreviewReactions     bool          `mapstructure:"PREVIEW_REACTIONS"`
+    PreviewReactionLimits string       `mapstructure:"PREVIEW_REACTION_LIMITS"`
+    
+    // Multi-instance fan-out
+    Broker               string        `mapstructure:"BROKER"`
+    RedisURL             string        `mapstructure:"REDIS_URL"`
+    RedisChannelPrefix   string        `mapstructure:"REDIS_CHANNEL_PREFIX"`
+    
+    // Room fan-out scheduling
+    FanoutTick           time.Duration `mapstructure:"FANOUT_TICK"`
+    FanoutHighBudget     int           `mapstructure:"FANOUT_HIGH_BUDGET"`
+    FanoutChatBudget     int           `mapstructure:"FANOUT_CHAT_BUDGET"`
+    FanoutLowBudget      int           `mapstructure:"FANOUT_LOW_BUDGET"`
+
+    // Batched chat message persistence
+    PersistBatchSize     int           `mapstructure:"PERSIST_BATCH_SIZE"`
+    PersistFlushInterval time.Duration `mapstructure:"PERSIST_FLUSH_INTERVAL"`
+    PersistQueueSize     int           `mapstructure:"PERSIST_QUEUE_SIZE"`
+    
+    // Room presence
+    PresenceInterval     time.Duration `mapstructure:"PRESENCE_INTERVAL"`
+    
+    // SCIM directory sync
+    EnableSCIM           bool          `mapstructure:"ENABLE_SCIM"`
+    SCIMToken            string        `mapstructure:"SCIM_TOKEN"`
+    SCIMGroupRoles       string        `mapstructure:"SCIM_GROUP_ROLES"`
+    
+    // Account recovery. Outgoing mail through SMTP_ADDR carries recovery
+    // emails; empty turns the recovery email off. Recovery links, emailed
+    // or issued by support, open RECOVERY_URL with the token as ?token=.
+    // Backup codes are looked up by a digest keyed with RECOVERY_CODE_KEY
+    SMTPAddr             string        `mapstructure:"SMTP_ADDR"`
+    SMTPUsername         string        `mapstructure:"SMTP_USERNAME"`
+    SMTPPassword         string        `mapstructure:"SMTP_PASSWORD"`
+    MailFrom             string        `mapstructure:"MAIL_FROM"`
+    RecoveryURL          string        `mapstructure:"RECOVERY_URL"`
+    RecoveryCodeKey      string        `mapstructure:"RECOVERY_CODE_KEY"`
+
+    // gRPC service for internal integrations, listening on GRPC_ADDRESS
+    // for calls bearing GRPC_TOKEN; an empty address turns it off
+    GRPCAddress          string        `mapstructure:"GRPC_ADDRESS"`
+    GRPCToken            string        `mapstructure:"GRPC_TOKEN"`
+    
+    // Search settings
+    SearchBackend        string        `mapstructure:"SEARCH_BACKEND"`
+    OpenSearchURL        string        `mapstructure:"OPENSEARCH_URL"`
+    OpenSearchUsername   string        `mapstructure:"OPENSEARCH_USERNAME"`
+    OpenSearchPassword   string        `mapstructure:"OPENSEARCH_PASSWORD"`
+    OpenSearchIndexPrefix string       `mapstructure:"OPENSEARCH_INDEX_PREFIX"`
     
     // Feature flags
     EnableMatchUpdates   bool          `mapstructure:"ENABLE_MATCH_UPDATES"`
     EnableHighlights     bool          `mapstructure:"ENABLE_HIGHLIGHTS"`
     EnablePredictions    bool          `mapstructure:"ENABLE_PREDICTIONS"`
+    EnableAchievements   bool          `mapstructure:"ENABLE_ACHIEVEMENTS"`
+    EnableMatchVoting    bool          `mapstructure:"ENABLE_MATCH_VOTING"`
+    EnableLeaderboards   bool          `mapstructure:"ENABLE_LEADERBOARDS"`
+    EnableRoomLifecycle  bool          `mapstructure:"ENABLE_ROOM_LIFECYCLE"`
+    EnablePrewarm        bool          `mapstructure:"ENABLE_PREWARM"`
+    // Admin endpoints emitting fake goals, reports and join spikes, for
+    // testing integrations; refused in production
+    EnableSimulator      bool          `mapstructure:"ENABLE_SIMULATOR"`
     
     // Environment
     Environment         string        `mapstructure:"ENVIRONMENT"`
     LogLevel           string        `mapstructure:"LOG_LEVEL"`
+    // Samples 1 in n lock contention events for /debug/pprof/mutex; 0
+    // disables it
+    MutexProfileFraction int         `mapstructure:"MUTEX_PROFILE_FRACTION"`
+    
+    // Tracing. Spans are exported over OTLP gRPC when an endpoint is set.
+    OTLPEndpoint         string        `mapstructure:"OTEL_EXPORTER_OTLP_ENDPOINT"`
+    OTLPInsecure         bool          `mapstructure:"OTEL_EXPORTER_OTLP_INSECURE"`
+    TraceServiceName     string        `mapstructure:"OTEL_SERVICE_NAME"`
+    TraceSampleRatio     float64       `mapstructure:"TRACE_SAMPLE_RATIO"`
 }
 
 func Load() (*Config, error) {
@@ -98,11 +340,20 @@ func setDefaults(v *viper.Viper) {
     v.SetDefault("READ_TIMEOUT", "15s")
     v.SetDefault("WRITE_TIMEOUT", "15s")
     v.SetDefault("IDLE_TIMEOUT", "60s")
+    v.SetDefault("REQUEST_TIMEOUT", "2s")
+    v.SetDefault("LONG_REQUEST_TIMEOUT", "30s")
 
     // Database defaults
+    v.SetDefault("STORE_DRIVER", "postgres")
     v.SetDefault("MAX_DB_CONNECTIONS", 20)
     v.SetDefault("MAX_IDLE_CONNECTIONS", 5)
     v.SetDefault("CONN_MAX_LIFETIME", "1h")
+    v.SetDefault("DB_QUERY_TIMEOUT", "5s")
+    v.SetDefault("DB_RETRY_ATTEMPTS", 3)
+    v.SetDefault("DB_RETRY_BASE_DELAY", "50ms")
+    v.SetDefault("DB_RETRY_MAX_DELAY", "1s")
+    v.SetDefault("DB_BREAKER_THRESHOLD", 5)
+    v.SetDefault("DB_BREAKER_COOLDOWN", "5s")
 
     // Authentication defaults
     v.SetDefault("JWT_EXPIRATION", "24h")
@@ -115,49 +366,441 @@ func setDefaults(v *viper.Viper) {
     v.SetDefault("WS_PONG_WAIT", "60s")
     v.SetDefault("WS_PING_PERIOD", "54s")
     v.SetDefault("WS_MAX_MESSAGE_SIZE", 4096)
+    v.SetDefault("WS_COMPRESSION", false)
+    v.SetDefault("WS_COMPRESSION_LEVEL", 1)
+    v.SetDefault("WS_COMPRESSION_THRESHOLD", 256)
+    v.SetDefault("WS_COALESCE_WINDOW", "50ms")
+    v.SetDefault("WS_SEND_BUFFER", 256)
+    v.SetDefault("WS_SLOW_CONSUMER_POLICY", "disconnect")
+    v.SetDefault("WS_RESUME_WINDOW", "2m")
+    v.SetDefault("WS_MAX_CONNECTIONS", 0)
+    v.SetDefault("WS_MAX_GOROUTINES", 0)
+    v.SetDefault("WS_MAX_HEAP_MB", 0)
+    v.SetDefault("WS_SHED_RETRY_AFTER", "30s")
+    v.SetDefault("WS_HUB_STALL_TIMEOUT", "10s")
 
     // Rate limiting defaults
     v.SetDefault("RATE_LIMIT_WINDOW", "1m")
     v.SetDefault("RATE_LIMIT_REQUESTS", 60)
+    v.SetDefault("RATE_LIMIT_BACKEND", "memory")
+    v.SetDefault("WS_RATE_LIMITS", "default=5/1s,reaction=10/1s,voice=50/1s")
 
     // CORS defaults
     v.SetDefault("CORS_ALLOWED_ORIGINS", []string{"http://localhost:3000"})
 
     // Sports API defaults
     v.SetDefault("SPORTS_API_URL", "https://api.sports-data.io/v1")
+    v.SetDefault("SPORTS_API_MODE", "live")
+    v.SetDefault("SPORTS_API_FIXTURES", "testdata/sportsdata")
+    v.SetDefault("RECONCILIATION_HOUR", 4)
+    v.SetDefault("RECONCILIATION_LOOKBACK", "72h")
+
+    // Background job defaults
+    v.SetDefault("JOB_WORKERS", 4)
+    v.SetDefault("JOB_QUEUE_SIZE", 1024)
+    v.SetDefault("JOB_MAX_RETRIES", 3)
+    v.SetDefault("DLQ_ALERT_THRESHOLD", 100)
+    v.SetDefault("USERNAME_CHANGE_COOLDOWN", "720h") // 30 days
+    v.SetDefault("GEO_COUNTRY_HEADER", "")
+    v.SetDefault("MODERATION_RELOAD_INTERVAL", "1m")
+    v.SetDefault("STATS_ROLLUP_INTERVAL", "1h")
+    v.SetDefault("STATS_ROLLUP_HOUR", 3)
+    v.SetDefault("ATTACHMENT_SCANNER", "")
+    v.SetDefault("MAX_ATTACHMENT_SIZE", 5<<20) // 5 MiB
+    v.SetDefault("OBJECT_STORE", "")
+    v.SetDefault("OBJECT_STORE_DIR", "data/objects")
+    v.SetDefault("MAX_AVATAR_SIZE", 2<<20) // 2 MiB
+    v.SetDefault("AVATAR_BASE_URL", "")
+    v.SetDefault("EXPORT_RETENTION", "168h") // 7 days
+    v.SetDefault("S3_ENDPOINT", "https://s3.amazonaws.com")
+    v.SetDefault("S3_REGION", "us-east-1")
+    v.SetDefault("MESSAGE_RETENTION", "0")
+    v.SetDefault("RETENTION_BATCH_SIZE", 5000)
+    v.SetDefault("RETENTION_HYDRATE", true)
+
+    // Analytics defaults
+    v.SetDefault("ENABLE_ANALYTICS", false)
+    v.SetDefault("ANALYTICS_URL", "https://api.segment.io/v1/batch")
+    v.SetDefault("ANALYTICS_BUFFER_SIZE", 10000)
+    v.SetDefault("ANALYTICS_BATCH_SIZE", 100)
+    v.SetDefault("ANALYTICS_FLUSH_INTERVAL", "10s")
+
+    // Broadcast journal defaults
+    v.SetDefault("ENABLE_JOURNAL", true)
+    v.SetDefault("JOURNAL_TTL", "24h")
+    v.SetDefault("JOURNAL_BUFFER_SIZE", 10000)
+
+    // Link preview defaults
+    v.SetDefault("ENABLE_LINK_PREVIEWS", true)
+    v.SetDefault("UNFURL_TIMEOUT", "5s")
+    v.SetDefault("UNFURL_CACHE_TTL", "1h")
+
+    // Ban evasion defaults
+    v.SetDefault("ENABLE_EVASION_DETECTION", true)
+    v.SetDefault("EVASION_THRESHOLD", 0.7)
+    v.SetDefault("EVASION_LOOKBACK", "720h")
+
+    // Moderation evidence defaults
+    v.SetDefault("ENABLE_EVIDENCE", false)
+    v.SetDefault("EVIDENCE_KEYS", "")
+    v.SetDefault("EVIDENCE_RETENTION", "2160h") // 90 days
+
+    // Public room preview defaults
+    v.SetDefault("PREVIEW_MESSAGES", 5)
+    v.SetDefault("PREVIEW_REACTIONS", true)
+    v.SetDefault("PREVIEW_REACTION_LIMITS", "default=20/1m,room=1/2s")
+
+    // Broker defaults
+    v.SetDefault("BROKER", "local")
+    v.SetDefault("REDIS_CHANNEL_PREFIX", "sports-chat:")
+
+    // Fan-out scheduling defaults
+    v.SetDefault("FANOUT_TICK", "10ms")
+    v.SetDefault("FANOUT_HIGH_BUDGET", 1000)
+    v.SetDefault("FANOUT_CHAT_BUDGET", 200)
+    v.SetDefault("FANOUT_LOW_BUDGET", 50)
+
+    // Message persistence defaults
+    v.SetDefault("PERSIST_BATCH_SIZE", 100)
+    v.SetDefault("PERSIST_FLUSH_INTERVAL", "50ms")
+    v.SetDefault("PERSIST_QUEUE_SIZE", 10000)
+
+    // Presence defaults
+    v.SetDefault("PRESENCE_INTERVAL", "10s")
+
+    // SCIM defaults
+    v.SetDefault("ENABLE_SCIM", false)
+
+    // Account recovery defaults
+    v.SetDefault("SMTP_ADDR", "")
+    v.SetDefault("SMTP_USERNAME", "")
+    v.SetDefault("SMTP_PASSWORD", "")
+    v.SetDefault("MAIL_FROM", "")
+    v.SetDefault("RECOVERY_URL", "http://localhost:3000/recover")
+    v.SetDefault("RECOVERY_CODE_KEY", "")
+
+    // gRPC defaults
+    v.SetDefault("GRPC_ADDRESS", "")
+    v.SetDefault("GRPC_TOKEN", "")
+
+    // Search defaults
+    v.SetDefault("SEARCH_BACKEND", "postgres")
+    v.SetDefault("OPENSEARCH_INDEX_PREFIX", "sports-chat-")
 
     // Feature flags
     v.SetDefault("ENABLE_MATCH_UPDATES", true)
     v.SetDefault("ENABLE_HIGHLIGHTS", true)
     v.SetDefault("ENABLE_PREDICTIONS", true)
+    v.SetDefault("ENABLE_ACHIEVEMENTS", true)
+    v.SetDefault("ENABLE_MATCH_VOTING", true)
+    v.SetDefault("MATCH_VOTE_WINDOW", "30m")
+    v.SetDefault("ENABLE_LEADERBOARDS", true)
+    v.SetDefault("LEADERBOARD_INTERVAL", "5s")
+    v.SetDefault("LEADERBOARD_SIZE", 10)
+    v.SetDefault("ENABLE_ROOM_LIFECYCLE", true)
+    v.SetDefault("ROOM_OPEN_BEFORE", "1h")
+    v.SetDefault("ROOM_ARCHIVE_AFTER", "24h")
+    v.SetDefault("ROOM_PURGE_AFTER", "72h")
+    v.SetDefault("ENABLE_PREWARM", true)
+    v.SetDefault("PREWARM_LEAD", "15m")
+    v.SetDefault("PREWARM_MIN_FOLLOWERS", 1000)
+    v.SetDefault("ENABLE_SIMULATOR", false)
 
     // Environment defaults
     v.SetDefault("ENVIRONMENT", "development")
     v.SetDefault("LOG_LEVEL", "info")
+
+    // Tracing defaults
+    v.SetDefault("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+    v.SetDefault("OTEL_EXPORTER_OTLP_INSECURE", false)
+    v.SetDefault("OTEL_SERVICE_NAME", "sports-chat")
+    v.SetDefault("TRACE_SAMPLE_RATIO", 0.1)
 }
 
 func validateConfig(cfg *Config) error {
     // Required fields
-    if cfg.JWTSecret == "" {
-        return fmt.Errorf("JWT_SECRET is required")
+    if cfg.JWTSecret == "" && cfg.JWTSigningKeyFile == "" {
+        return fmt.Errorf("JWT_SECRET or JWT_SIGNING_KEY_FILE is required")
     }
-    if cfg.DatabaseURL == "" {
-        return fmt.Errorf("DATABASE_URL is required")
+    if cfg.JWTSigningKeyFile != "" && cfg.JWTSigningKeyID == "" {
+        return fmt.Errorf("JWT_SIGNING_KEY_ID is required with JWT_SIGNING_KEY_FILE")
+    }
+    switch cfg.StoreDriver {
+    case "postgres":
+        if cfg.DatabaseURL == "" {
+            return fmt.Errorf("DATABASE_URL is required")
+        }
+    case "memory":
+        if cfg.Environment == "production" {
+            return fmt.Errorf("STORE_DRIVER memory cannot be used in production")
+        }
+    default:
+        return fmt.Errorf("unknown store driver %q", cfg.StoreDriver)
+    }
+    if cfg.MaxDBConnections <= 0 || cfg.MaxIdleConns < 0 || cfg.MaxIdleConns > cfg.MaxDBConnections {
+        return fmt.Errorf("max db connections must be positive and at least max idle connections")
+    }
+    if cfg.DBQueryTimeout <= 0 {
+        return fmt.Errorf("db query timeout must be positive")
+    }
+    if cfg.DBRetryAttempts <= 0 || cfg.DBBreakerThreshold <= 0 {
+        return fmt.Errorf("db retry attempts and breaker threshold must be positive")
+    }
+    if cfg.DBRetryBaseDelay <= 0 || cfg.DBRetryMaxDelay < cfg.DBRetryBaseDelay || cfg.DBBreakerCooldown <= 0 {
+        return fmt.Errorf("db retry delays and breaker cooldown must be positive, the max delay at least the base")
     }
 
     // Validate timeouts
     if cfg.WSPingPeriod >= cfg.WSPongWait {
         return fmt.Errorf("ping period must be less than pong wait")
     }
+    if cfg.RequestTimeout <= 0 || cfg.LongRequestTimeout < cfg.RequestTimeout {
+        return fmt.Errorf("request timeouts must be positive, the long one no shorter")
+    }
 
     // Validate rate limiting
     if cfg.RateLimitRequests <= 0 {
         return fmt.Errorf("rate limit requests must be positive")
     }
+    if cfg.RateLimitWindow <= 0 {
+        return fmt.Errorf("rate limit window must be positive")
+    }
+    switch cfg.RateLimitBackend {
+    case "memory":
+    case "redis":
+        if cfg.RedisURL == "" {
+            return fmt.Errorf("REDIS_URL is required when RATE_LIMIT_BACKEND is redis")
+        }
+    default:
+        return fmt.Errorf("unknown rate limit backend %q", cfg.RateLimitBackend)
+    }
+    if _, err := ratelimit.ParseRules(cfg.WSRateLimits); err != nil {
+        return fmt.Errorf("invalid WS_RATE_LIMITS: %w", err)
+    }
+
+    if cfg.PreviewMessages < 0 || cfg.PreviewMessages > 50 {
+        return fmt.Errorf("PREVIEW_MESSAGES must be between 0 and 50")
+    }
+    if _, err := ratelimit.ParseRules(cfg.PreviewReactionLimits); err != nil {
+        return fmt.Errorf("invalid PREVIEW_REACTION_LIMITS: %w", err)
+    }
+
+    // Validate broker settings
+    switch cfg.Broker {
+    case "local":
+    case "redis":
+        if cfg.RedisURL == "" {
+            return fmt.Errorf("REDIS_URL is required when BROKER is redis")
+        }
+    default:
+        return fmt.Errorf("unknown broker %q", cfg.Broker)
+    }
+
+    if cfg.EnableAnalytics && cfg.AnalyticsWriteKey == "" {
+        return fmt.Errorf("ANALYTICS_WRITE_KEY is required when analytics is enabled")
+    }
+
+    if cfg.ModerationReloadInterval <= 0 {
+        return fmt.Errorf("MODERATION_RELOAD_INTERVAL must be positive")
+    }
+    if cfg.StatsRollupInterval <= 0 {
+        return fmt.Errorf("STATS_ROLLUP_INTERVAL must be positive")
+    }
+    if cfg.StatsRollupHour < 0 || cfg.StatsRollupHour > 23 {
+        return fmt.Errorf("STATS_ROLLUP_HOUR must be between 0 and 23")
+    }
+
+    if cfg.CDNPurgeURL != "" && cfg.CDNPurgeToken == "" {
+        return fmt.Errorf("CDN_PURGE_TOKEN is required when CDN_PURGE_URL is set")
+    }
+
+    // Validate attachment scanning
+    switch cfg.AttachmentScanner {
+    case "":
+    case "clamav":
+        if cfg.ClamAVAddress == "" {
+            return fmt.Errorf("CLAMAV_ADDRESS is required when ATTACHMENT_SCANNER is clamav")
+        }
+    case "http":
+        if cfg.AttachmentScanURL == "" {
+            return fmt.Errorf("ATTACHMENT_SCAN_URL is required when ATTACHMENT_SCANNER is http")
+        }
+    default:
+        return fmt.Errorf("unknown attachment scanner %q", cfg.AttachmentScanner)
+    }
+    if cfg.MaxAttachmentSize <= 0 {
+        return fmt.Errorf("MAX_ATTACHMENT_SIZE must be positive")
+    }
+    if cfg.MaxAvatarSize <= 0 {
+        return fmt.Errorf("MAX_AVATAR_SIZE must be positive")
+    }
+    if cfg.ExportRetention <= 0 {
+        return fmt.Errorf("EXPORT_RETENTION must be positive")
+    }
+
+    switch cfg.ObjectStore {
+    case "":
+    case "dir":
+        if cfg.ObjectStoreDir == "" {
+            return fmt.Errorf("OBJECT_STORE_DIR is required when OBJECT_STORE is dir")
+        }
+    case "s3":
+        if cfg.S3Bucket == "" || cfg.S3AccessKeyID == "" || cfg.S3SecretAccessKey == "" {
+            return fmt.Errorf("S3_BUCKET, S3_ACCESS_KEY_ID and S3_SECRET_ACCESS_KEY are required when OBJECT_STORE is s3")
+        }
+    default:
+        return fmt.Errorf("unknown object store %q", cfg.ObjectStore)
+    }
+    if cfg.MessageRetention < 0 {
+        return fmt.Errorf("MESSAGE_RETENTION must not be negative")
+    }
+    if cfg.MessageRetention > 0 {
+        if cfg.ObjectStore == "" {
+            return fmt.Errorf("OBJECT_STORE is required when MESSAGE_RETENTION is set")
+        }
+        if cfg.MessageRetention < 24*time.Hour || cfg.RetentionBatchSize <= 0 {
+            return fmt.Errorf("MESSAGE_RETENTION must be at least a day and RETENTION_BATCH_SIZE positive")
+        }
+    }
+
+    if cfg.WSCompression {
+        if cfg.WSCompressionLevel < -2 || cfg.WSCompressionLevel > 9 || cfg.WSCompressionLevel == 0 {
+            return fmt.Errorf("WS_COMPRESSION_LEVEL must be between -2 and 9, and not 0")
+        }
+        if cfg.WSCompressionThreshold <= 0 {
+            return fmt.Errorf("WS_COMPRESSION_THRESHOLD must be positive")
+        }
+    }
+
+    if cfg.WSCoalesceWindow < 0 || cfg.WSCoalesceWindow > time.Second {
+        return fmt.Errorf("WS_COALESCE_WINDOW must be between 0 and 1s")
+    }
+    if cfg.WSSendBuffer < 16 || cfg.WSSendBuffer > 65536 {
+        return fmt.Errorf("WS_SEND_BUFFER must be between 16 and 65536")
+    }
+    if cfg.WSSlowConsumerPolicy != "disconnect" && cfg.WSSlowConsumerPolicy != "drop_oldest" {
+        return fmt.Errorf("WS_SLOW_CONSUMER_POLICY must be disconnect or drop_oldest")
+    }
+    if cfg.WSResumeWindow < 0 || cfg.WSResumeWindow > 10*time.Minute {
+        return fmt.Errorf("WS_RESUME_WINDOW must be between 0 and 10m")
+    }
+
+    if cfg.WSMaxConnections < 0 || cfg.WSMaxGoroutines < 0 || cfg.WSMaxHeapMB < 0 {
+        return fmt.Errorf("websocket capacity limits must not be negative")
+    }
+    if cfg.WSShedRetryAfter < time.Second {
+        return fmt.Errorf("WS_SHED_RETRY_AFTER must be at least 1s")
+    }
+    // Below a few heartbeats an idle loop would look stalled
+    if cfg.WSHubStallTimeout < 3*time.Second {
+        return fmt.Errorf("WS_HUB_STALL_TIMEOUT must be at least 3s")
+    }
+
+    if cfg.TraceSampleRatio < 0 || cfg.TraceSampleRatio > 1 {
+        return fmt.Errorf("TRACE_SAMPLE_RATIO must be between 0 and 1")
+    }
+
+    // Validate fan-out scheduling
+    if cfg.FanoutTick <= 0 {
+        return fmt.Errorf("fan-out tick must be positive")
+    }
+    if cfg.FanoutHighBudget <= 0 || cfg.FanoutChatBudget <= 0 || cfg.FanoutLowBudget <= 0 {
+        return fmt.Errorf("fan-out budgets must be positive")
+    }
+
+    // Validate message persistence
+    if cfg.PersistBatchSize <= 0 || cfg.PersistBatchSize > 1000 {
+        return fmt.Errorf("PERSIST_BATCH_SIZE must be between 1 and 1000")
+    }
+    if cfg.PersistFlushInterval <= 0 || cfg.PersistQueueSize <= 0 {
+        return fmt.Errorf("message persistence interval and queue size must be positive")
+    }
+    if cfg.EnableMatchVoting && cfg.MatchVoteWindow <= 0 {
+        return fmt.Errorf("match vote window must be positive")
+    }
+    if cfg.EnableLeaderboards && (cfg.LeaderboardInterval <= 0 || cfg.LeaderboardSize < 1 || cfg.LeaderboardSize > 100) {
+        return fmt.Errorf("leaderboard interval must be positive and LEADERBOARD_SIZE between 1 and 100")
+    }
+    if cfg.EnableRoomLifecycle && (cfg.RoomOpenBefore < 0 || cfg.RoomArchiveAfter < 0) {
+        return fmt.Errorf("room lifecycle durations must not be negative")
+    }
+    if cfg.RoomPurgeAfter < 0 {
+        return fmt.Errorf("ROOM_PURGE_AFTER must not be negative")
+    }
+    if cfg.EnablePrewarm && (cfg.PrewarmLead <= 0 || cfg.PrewarmMinFollowers < 0) {
+        return fmt.Errorf("pre-warm lead must be positive and PREWARM_MIN_FOLLOWERS not negative")
+    }
+    if cfg.PresenceInterval <= 0 {
+        return fmt.Errorf("presence interval must be positive")
+    }
+
+    // Validate search settings
+    switch cfg.SearchBackend {
+    case "postgres":
+    case "opensearch":
+        if cfg.OpenSearchURL == "" {
+            return fmt.Errorf("OPENSEARCH_URL is required when SEARCH_BACKEND is opensearch")
+        }
+    default:
+        return fmt.Errorf("unknown search backend %q", cfg.SearchBackend)
+    }
+
+    // Validate SCIM settings
+    if cfg.EnableSCIM {
+        if cfg.SCIMToken == "" {
+            return fmt.Errorf("SCIM_TOKEN is required when SCIM is enabled")
+        }
+        if _, err := scim.ParseRoleMap(cfg.SCIMGroupRoles); err != nil {
+            return fmt.Errorf("invalid SCIM_GROUP_ROLES: %w", err)
+        }
+    }
+    if cfg.GRPCAddress != "" && len(cfg.GRPCToken) < 32 {
+        return fmt.Errorf("GRPC_TOKEN of at least 32 characters is required when GRPC_ADDRESS is set")
+    }
 
     // Validate sports API settings
-    if cfg.EnableMatchUpdates && cfg.SportsAPIKey == "" {
-        return fmt.Errorf("SPORTS_API_KEY is required when match updates are enabled")
+    switch cfg.SportsAPIMode {
+    case "live", "record":
+        if cfg.EnableMatchUpdates && cfg.SportsAPIKey == "" {
+            return fmt.Errorf("SPORTS_API_KEY is required when match updates are enabled")
+        }
+    case "replay":
+    default:
+        return fmt.Errorf("unknown sports API mode %q", cfg.SportsAPIMode)
+    }
+    if cfg.SportsAPIMode != "live" && cfg.SportsAPIFixtures == "" {
+        return fmt.Errorf("SPORTS_API_FIXTURES is required when SPORTS_API_MODE is %s", cfg.SportsAPIMode)
+    }
+    if cfg.SportsWebhookSecret != "" && len(cfg.SportsWebhookSecret) < 32 {
+        return fmt.Errorf("SPORTS_WEBHOOK_SECRET must be at least 32 characters")
+    }
+    if cfg.EnableSimulator && cfg.Environment == "production" {
+        return fmt.Errorf("ENABLE_SIMULATOR cannot be set in production")
+    }
+    if cfg.ReconciliationHour < 0 || cfg.ReconciliationHour > 23 {
+        return fmt.Errorf("RECONCILIATION_HOUR must be between 0 and 23")
+    }
+    if cfg.EnableEvasionDetection && (cfg.EvasionThreshold <= 0 || cfg.EvasionThreshold > 1) {
+        return fmt.Errorf("EVASION_THRESHOLD must be in (0, 1]")
+    }
+    if cfg.EnableEvidence {
+        if cfg.EvidenceKeys == "" {
+            return fmt.Errorf("EVIDENCE_KEYS is required when ENABLE_EVIDENCE is set")
+        }
+        if cfg.EvidenceRetention < 24*time.Hour {
+            return fmt.Errorf("EVIDENCE_RETENTION must be at least a day")
+        }
+    }
+
+    // Validate account recovery settings
+    if cfg.RecoveryURL == "" {
+        return fmt.Errorf("RECOVERY_URL is required")
+    }
+    if len(cfg.RecoveryCodeKey) < 32 {
+        return fmt.Errorf("RECOVERY_CODE_KEY must be at least 32 characters")
+    }
+    if cfg.SMTPAddr != "" && cfg.MailFrom == "" {
+        return fmt.Errorf("MAIL_FROM is required with SMTP_ADDR")
     }
 
     return nil
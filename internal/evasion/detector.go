@@ -0,0 +1,281 @@
+package evasion
+
+import (
+    "context"
+    "fmt"
+    "sync"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/events"
+    "github.com/yourusername/sports-chat/internal/metrics"
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/store"
+)
+
+// Base signal weights before moderator feedback is applied. A shared
+// device fingerprint alone is strong evidence; a shared IP is weak on its
+// own (NAT, mobile carriers, venues) and needs corroboration.
+var baseWeights = map[string]float64{
+    models.SignalFingerprint: 0.6,
+    models.SignalIP:          0.25,
+    models.SignalBehavior:    0.3,
+}
+
+const statsTTL = 10 * time.Minute
+
+// Detector flags users whose devices, addresses or behavior overlap with
+// banned accounts. It only files suspects for moderator review; it never
+// bans. Moderator verdicts feed back into the signal weights so signals
+// that keep producing false positives count for less.
+type Detector struct {
+    store     store.Store
+    threshold float64
+    lookback  time.Duration
+    metrics   *metrics.Metrics
+    logger    *zap.Logger
+    events    chan events.UserConnected
+
+    mu        sync.Mutex
+    weights   map[string]float64
+    weightsAt time.Time
+}
+
+func NewDetector(store store.Store, threshold float64, lookback time.Duration, metrics *metrics.Metrics, logger *zap.Logger) *Detector {
+    return &Detector{
+        store:     store,
+        threshold: threshold,
+        lookback:  lookback,
+        metrics:   metrics,
+        logger:    logger,
+        events:    make(chan events.UserConnected, 1024),
+    }
+}
+
+// Start subscribes the detector to connection events and starts its
+// worker.
+func (d *Detector) Start(bus *events.Bus) {
+    bus.Subscribe(events.TypeUserConnected, d.enqueue)
+    go d.run()
+}
+
+func (d *Detector) enqueue(event events.Event) {
+    connected, ok := event.(events.UserConnected)
+    if !ok {
+        return
+    }
+    select {
+    case d.events <- connected:
+    default:
+        d.logger.Warn("Evasion queue full, dropping connection event",
+            zap.String("user_id", connected.UserID))
+    }
+}
+
+func (d *Detector) run() {
+    for event := range d.events {
+        ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+        if err := d.Check(ctx, event); err != nil {
+            d.logger.Error("Failed to check for ban evasion",
+                zap.Error(err),
+                zap.String("user_id", event.UserID))
+        }
+        cancel()
+    }
+}
+
+// Check records the sighting and files a suspect for every banned account
+// the user scores above the threshold against.
+func (d *Detector) Check(ctx context.Context, event events.UserConnected) error {
+    err := d.store.RecordDeviceSighting(ctx, &models.DeviceSighting{
+        UserID:      event.UserID,
+        Fingerprint: event.Fingerprint,
+        IP:          event.IP,
+        SeenAt:      event.At,
+    })
+    if err != nil {
+        return fmt.Errorf("failed to record sighting: %w", err)
+    }
+
+    sightings, err := d.store.GetBannedUserSightings(ctx, event.Fingerprint, event.IP, time.Now().Add(-d.lookback))
+    if err != nil {
+        return fmt.Errorf("failed to get banned user sightings: %w", err)
+    }
+
+    candidates := make(map[string][]*models.DeviceSighting)
+    for _, s := range sightings {
+        if s.UserID != event.UserID {
+            candidates[s.UserID] = append(candidates[s.UserID], s)
+        }
+    }
+    if len(candidates) == 0 {
+        return nil
+    }
+
+    user, err := d.store.GetUser(ctx, event.UserID)
+    if err != nil {
+        return fmt.Errorf("failed to get user: %w", err)
+    }
+    if user.BannedAt != nil || user.IsAdmin {
+        return nil
+    }
+    userRooms, err := d.store.GetUserRooms(ctx, user.ID)
+    if err != nil {
+        return fmt.Errorf("failed to get user rooms: %w", err)
+    }
+
+    weights := d.signalWeights(ctx)
+    for bannedID, matches := range candidates {
+        banned, err := d.store.GetUser(ctx, bannedID)
+        if err != nil {
+            d.logger.Warn("Failed to load banned user", zap.Error(err), zap.String("user_id", bannedID))
+            continue
+        }
+        bannedRooms, err := d.store.GetUserRooms(ctx, bannedID)
+        if err != nil {
+            d.logger.Warn("Failed to load banned user rooms", zap.Error(err), zap.String("user_id", bannedID))
+            continue
+        }
+
+        signals := deviceSignals(event, matches, weights)
+        if similarity := behaviorSimilarity(user, banned, userRooms, bannedRooms); similarity > 0 {
+            signals = append(signals, &models.EvasionSignal{
+                Kind:   models.SignalBehavior,
+                Weight: similarity * weights[models.SignalBehavior],
+                Detail: fmt.Sprintf("similarity %.2f", similarity),
+            })
+        }
+
+        score := scoreOf(signals)
+        if score < d.threshold {
+            continue
+        }
+
+        created, err := d.store.CreateEvasionSuspect(ctx, &models.EvasionSuspect{
+            UserID:       user.ID,
+            BannedUserID: bannedID,
+            Score:        score,
+            Signals:      signals,
+            Status:       models.SuspectPending,
+            CreatedAt:    time.Now(),
+        })
+        if err != nil {
+            return fmt.Errorf("failed to create evasion suspect: %w", err)
+        }
+        // A pair already under review, confirmed or dismissed is not
+        // flagged again.
+        if created {
+            d.metrics.EvasionSuspectsFlagged.Inc()
+            d.logger.Info("Flagged suspected ban evasion",
+                zap.String("user_id", user.ID),
+                zap.String("banned_user_id", bannedID),
+                zap.Float64("score", score))
+        }
+    }
+    return nil
+}
+
+func deviceSignals(event events.UserConnected, matches []*models.DeviceSighting, weights map[string]float64) []*models.EvasionSignal {
+    var fingerprint, ip bool
+    for _, s := range matches {
+        fingerprint = fingerprint || (event.Fingerprint != "" && s.Fingerprint == event.Fingerprint)
+        ip = ip || (event.IP != "" && s.IP == event.IP)
+    }
+
+    var signals []*models.EvasionSignal
+    if fingerprint {
+        signals = append(signals, &models.EvasionSignal{Kind: models.SignalFingerprint, Weight: weights[models.SignalFingerprint]})
+    }
+    if ip {
+        signals = append(signals, &models.EvasionSignal{Kind: models.SignalIP, Weight: weights[models.SignalIP], Detail: event.IP})
+    }
+    return signals
+}
+
+func scoreOf(signals []*models.EvasionSignal) float64 {
+    var score float64
+    for _, s := range signals {
+        score += s.Weight
+    }
+    if score > 1 {
+        score = 1
+    }
+    return score
+}
+
+// signalWeights scales each base weight by the signal's precision in past
+// moderator verdicts. With Laplace smoothing an unreviewed signal keeps
+// its base weight, and the factor ranges from 0 (always dismissed) to 2
+// (always confirmed).
+func (d *Detector) signalWeights(ctx context.Context) map[string]float64 {
+    d.mu.Lock()
+    defer d.mu.Unlock()
+
+    if d.weights != nil && time.Since(d.weightsAt) < statsTTL {
+        return d.weights
+    }
+
+    weights := make(map[string]float64, len(baseWeights))
+    for kind, w := range baseWeights {
+        weights[kind] = w
+    }
+
+    stats, err := d.store.GetEvasionSignalStats(ctx)
+    if err != nil {
+        d.logger.Warn("Failed to load evasion feedback, using base weights", zap.Error(err))
+    } else {
+        for _, s := range stats {
+            base, ok := baseWeights[s.Kind]
+            if !ok {
+                continue
+            }
+            precision := float64(s.Confirmed+1) / float64(s.Confirmed+s.Dismissed+2)
+            weights[s.Kind] = base * 2 * precision
+        }
+    }
+
+    d.weights = weights
+    d.weightsAt = time.Now()
+    return weights
+}
+
+// behaviorSimilarity compares profile and room membership, returning a
+// value in [0, 1].
+func behaviorSimilarity(user, banned *models.User, userRooms, bannedRooms []*models.ChatRoom) float64 {
+    var score float64
+
+    score += 0.5 * usernameSimilarity(user.Username, banned.Username)
+    if user.FavoriteTeam != "" && user.FavoriteTeam == banned.FavoriteTeam {
+        score += 0.2
+    }
+    score += 0.3 * roomOverlap(userRooms, bannedRooms)
+
+    return score
+}
+
+// roomOverlap is the Jaccard index of two users' room memberships.
+func roomOverlap(a, b []*models.ChatRoom) float64 {
+    if len(a) == 0 || len(b) == 0 {
+        return 0
+    }
+    set := make(map[string]bool, len(a))
+    for _, r := range a {
+        set[r.ID] = true
+    }
+    var shared int
+    union := len(set)
+    seen := make(map[string]bool, len(b))
+    for _, r := range b {
+        if seen[r.ID] {
+            continue
+        }
+        seen[r.ID] = true
+        if set[r.ID] {
+            shared++
+        } else {
+            union++
+        }
+    }
+    return float64(shared) / float64(union)
+}
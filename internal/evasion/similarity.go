@@ -0,0 +1,54 @@
+package evasion
+
+import (
+    "strings"
+    "unicode"
+)
+
+// usernameSimilarity is 1 minus the normalized edit distance between the
+// two names after lowercasing and dropping digits and separators, so
+// "ultras_fan" and "UltrasFan2" compare as identical.
+func usernameSimilarity(a, b string) float64 {
+    a, b = normalizeUsername(a), normalizeUsername(b)
+    if a == "" || b == "" {
+        return 0
+    }
+
+    ra, rb := []rune(a), []rune(b)
+    longest := len(ra)
+    if len(rb) > longest {
+        longest = len(rb)
+    }
+    return 1 - float64(levenshtein(ra, rb))/float64(longest)
+}
+
+func normalizeUsername(name string) string {
+    var b strings.Builder
+    for _, r := range strings.ToLower(name) {
+        if unicode.IsLetter(r) {
+            b.WriteRune(r)
+        }
+    }
+    return b.String()
+}
+
+func levenshtein(a, b []rune) int {
+    prev := make([]int, len(b)+1)
+    curr := make([]int, len(b)+1)
+    for j := range prev {
+        prev[j] = j
+    }
+
+    for i := 1; i <= len(a); i++ {
+        curr[0] = i
+        for j := 1; j <= len(b); j++ {
+            cost := 1
+            if a[i-1] == b[j-1] {
+                cost = 0
+            }
+            curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+        }
+        prev, curr = curr, prev
+    }
+    return prev[len(b)]
+}
@@ -0,0 +1,179 @@
+package events
+
+import (
+    "sync"
+    "time"
+
+    "go.uber.org/zap"
+)
+
+// Domain event types
+const (
+    TypeMessageSent      = "message_sent"
+    TypeMatchFinished    = "match_finished"
+    TypePredictionScored = "prediction_scored"
+    TypeUserConnected    = "user_connected"
+    TypeUserSignedUp     = "user_signed_up"
+    TypeFirstMessage     = "first_message"
+    TypePredictionMade   = "prediction_made"
+    TypeRoomJoined       = "room_joined"
+    TypeMatchUpdated     = "match_updated"
+    TypeReactionChanged  = "reaction_changed"
+)
+
+type Event interface {
+    Type() string
+}
+
+type MessageSent struct {
+    UserID string
+    RoomID string
+    At     time.Time
+}
+
+func (MessageSent) Type() string { return TypeMessageSent }
+
+// MatchFinished is published once per match when it leaves the live set.
+// UserIDs holds the users connected to the match room at full time.
+type MatchFinished struct {
+    MatchID string
+    RoomID  string
+    Derby   bool
+    UserIDs []string
+}
+
+func (MatchFinished) Type() string { return TypeMatchFinished }
+
+type PredictionScored struct {
+    UserID      string
+    MatchID     string
+    Correct     bool
+    Points      int
+    WeekCorrect int
+    WeekTotal   int
+}
+
+func (PredictionScored) Type() string { return TypePredictionScored }
+
+// UserConnected is published for every websocket connection. Fingerprint
+// is whatever device identifier the client supplied, possibly empty.
+// Country is the edge's guess at the client's country, empty when not
+// configured.
+type UserConnected struct {
+    UserID      string
+    SessionID   string
+    Fingerprint string
+    UserAgent   string
+    IP          string
+    Country     string
+    At          time.Time
+}
+
+func (UserConnected) Type() string { return TypeUserConnected }
+
+// UserSignedUp is published when an account is created. Source is how it
+// was created, e.g. "scim".
+type UserSignedUp struct {
+    UserID string
+    Source string
+    At     time.Time
+}
+
+func (UserSignedUp) Type() string { return TypeUserSignedUp }
+
+// FirstMessage is published once per user, when their first chat message
+// is stored.
+type FirstMessage struct {
+    UserID string
+    RoomID string
+    At     time.Time
+}
+
+func (FirstMessage) Type() string { return TypeFirstMessage }
+
+// PredictionMade is published whenever a user sets or changes their
+// score prediction for a match.
+type PredictionMade struct {
+    UserID  string
+    MatchID string
+    At      time.Time
+}
+
+func (PredictionMade) Type() string { return TypePredictionMade }
+
+// RoomJoined is published for each room a connection joins.
+type RoomJoined struct {
+    UserID string
+    RoomID string
+    At     time.Time
+}
+
+func (RoomJoined) Type() string { return TypeRoomJoined }
+
+// MatchUpdated is published when a match's score or status is saved. The
+// clock ticking over alone does not publish it.
+type MatchUpdated struct {
+    MatchID string
+    Status  string
+    At      time.Time
+}
+
+func (MatchUpdated) Type() string { return TypeMatchUpdated }
+
+// ReactionChanged is published when a user adds a reaction to a message
+// or removes one. AuthorID is who wrote the message.
+type ReactionChanged struct {
+    MessageID string
+    AuthorID  string
+    UserID    string
+    RoomID    string
+    Removed   bool
+    At        time.Time
+}
+
+func (ReactionChanged) Type() string { return TypeReactionChanged }
+
+type Handler func(Event)
+
+// Bus is a synchronous in-process event bus. Handlers run on the
+// publisher's goroutine, so subscribers doing real work must hand events
+// off to their own workers.
+type Bus struct {
+    mu       sync.RWMutex
+    handlers map[string][]Handler
+    logger   *zap.Logger
+}
+
+func NewBus(logger *zap.Logger) *Bus {
+    return &Bus{
+        handlers: make(map[string][]Handler),
+        logger:   logger,
+    }
+}
+
+func (b *Bus) Subscribe(eventType string, handler Handler) {
+    b.mu.Lock()
+    defer b.mu.Unlock()
+    b.handlers[eventType] = append(b.handlers[eventType], handler)
+}
+
+func (b *Bus) Publish(event Event) {
+    b.mu.RLock()
+    handlers := b.handlers[event.Type()]
+    b.mu.RUnlock()
+
+    for _, handler := range handlers {
+        b.dispatch(handler, event)
+    }
+}
+
+func (b *Bus) dispatch(handler Handler, event Event) {
+    defer func() {
+        if r := recover(); r != nil {
+            b.logger.Error("Event handler panicked",
+                zap.Any("panic", r),
+                zap.String("event", event.Type()))
+        }
+    }()
+    handler(event)
+}
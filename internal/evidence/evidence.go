@@ -0,0 +1,205 @@
+// Package evidence keeps a sealed copy of every message moderation
+// deletes. Chat loses the message at once, but reports, appeals and law
+// enforcement requests can still need what was said; the copy is
+// encrypted, readable only through an admin access that is logged, and
+// purged when the retention policy runs out.
+package evidence
+
+import (
+    "context"
+    "crypto/aes"
+    "crypto/cipher"
+    "crypto/rand"
+    "encoding/base64"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "strings"
+    "time"
+
+    "github.com/google/uuid"
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/store"
+)
+
+// KeySize is the length of evidence keys in bytes, for AES-256.
+const KeySize = 32
+
+var (
+    ErrNotFound   = errors.New("evidence not found")
+    ErrUnknownKey = errors.New("evidence sealed with a key no longer configured")
+)
+
+// Key is an evidence encryption key and the ID stored with what it
+// sealed.
+type Key struct {
+    ID  string
+    key []byte
+}
+
+// ParseKeys reads keys from "kid=base64key,...". The first seals new
+// evidence; the others open evidence sealed before a rotation, and can
+// be dropped once it has expired.
+func ParseKeys(spec string) ([]Key, error) {
+    var keys []Key
+    for _, entry := range strings.Split(spec, ",") {
+        entry = strings.TrimSpace(entry)
+        if entry == "" {
+            continue
+        }
+        id, encoded, ok := strings.Cut(entry, "=")
+        if !ok || id == "" || encoded == "" {
+            return nil, fmt.Errorf("invalid evidence key %q, want kid=base64key", entry)
+        }
+        key, err := base64.StdEncoding.DecodeString(encoded)
+        if err != nil || len(key) != KeySize {
+            return nil, fmt.Errorf("evidence key %q must be %d bytes of base64", id, KeySize)
+        }
+        keys = append(keys, Key{ID: id, key: key})
+    }
+    if len(keys) == 0 {
+        return nil, errors.New("no evidence keys")
+    }
+    return keys, nil
+}
+
+type Service struct {
+    store     store.Store
+    keys      []Key
+    retention time.Duration
+    logger    *zap.Logger
+}
+
+// NewService seals evidence with the first of keys and keeps it for
+// retention. keys must not be empty.
+func NewService(store store.Store, keys []Key, retention time.Duration, logger *zap.Logger) *Service {
+    return &Service{store: store, keys: keys, retention: retention, logger: logger}
+}
+
+// Retain seals a message about to be deleted by a moderator. Callers
+// delete the message only once it returns nil, so nothing moderation
+// removes goes unretained.
+func (s *Service) Retain(ctx context.Context, msg *models.Message, deletedBy, reason string) error {
+    plaintext, err := json.Marshal(msg)
+    if err != nil {
+        return fmt.Errorf("failed to encode evidence: %w", err)
+    }
+    key := s.keys[0]
+    evidence := &models.ModerationEvidence{
+        ID:        uuid.NewString(),
+        MessageID: msg.ID,
+        RoomID:    msg.ChatRoomID,
+        UserID:    msg.UserID,
+        DeletedBy: deletedBy,
+        Reason:    reason,
+        KeyID:     key.ID,
+        CreatedAt: time.Now(),
+    }
+    evidence.ExpiresAt = evidence.CreatedAt.Add(s.retention)
+    if evidence.Sealed, err = seal(key, evidence.ID, plaintext); err != nil {
+        return err
+    }
+    return s.store.CreateModerationEvidence(ctx, evidence)
+}
+
+// Open records that an admin read a piece of evidence, and why, and
+// returns it with the message it holds. The access is logged before
+// anything is decrypted, so no read goes unrecorded.
+func (s *Service) Open(ctx context.Context, id, adminID, purpose string) (*models.ModerationEvidence, *models.Message, error) {
+    evidence, err := s.store.GetModerationEvidence(ctx, id)
+    if err != nil {
+        return nil, nil, ErrNotFound
+    }
+    key, ok := s.key(evidence.KeyID)
+    if !ok {
+        return nil, nil, ErrUnknownKey
+    }
+
+    if err := s.store.RecordEvidenceAccess(ctx, &models.EvidenceAccess{
+        EvidenceID: id,
+        AdminID:    adminID,
+        Purpose:    purpose,
+    }); err != nil {
+        return nil, nil, fmt.Errorf("failed to record evidence access: %w", err)
+    }
+
+    plaintext, err := open(key, evidence.ID, evidence.Sealed)
+    if err != nil {
+        return nil, nil, err
+    }
+    var msg models.Message
+    if err := json.Unmarshal(plaintext, &msg); err != nil {
+        return nil, nil, fmt.Errorf("failed to decode evidence: %w", err)
+    }
+    return evidence, &msg, nil
+}
+
+func (s *Service) key(id string) (Key, bool) {
+    for _, key := range s.keys {
+        if key.ID == id {
+            return key, true
+        }
+    }
+    return Key{}, false
+}
+
+// seal encrypts plaintext with AES-GCM, bound to the evidence ID so a
+// sealed copy cannot be passed off as another. The nonce is prepended.
+func seal(key Key, evidenceID string, plaintext []byte) ([]byte, error) {
+    aead, err := newAEAD(key)
+    if err != nil {
+        return nil, err
+    }
+    nonce := make([]byte, aead.NonceSize())
+    if _, err := rand.Read(nonce); err != nil {
+        return nil, fmt.Errorf("failed to generate nonce: %w", err)
+    }
+    return aead.Seal(nonce, nonce, plaintext, []byte(evidenceID)), nil
+}
+
+func open(key Key, evidenceID string, sealed []byte) ([]byte, error) {
+    aead, err := newAEAD(key)
+    if err != nil {
+        return nil, err
+    }
+    if len(sealed) < aead.NonceSize() {
+        return nil, errors.New("sealed evidence is truncated")
+    }
+    nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+    plaintext, err := aead.Open(nil, nonce, ciphertext, []byte(evidenceID))
+    if err != nil {
+        return nil, fmt.Errorf("failed to open evidence: %w", err)
+    }
+    return plaintext, nil
+}
+
+func newAEAD(key Key) (cipher.AEAD, error) {
+    block, err := aes.NewCipher(key.key)
+    if err != nil {
+        return nil, fmt.Errorf("failed to create cipher: %w", err)
+    }
+    return cipher.NewGCM(block)
+}
+
+// PurgeJob deletes evidence past its retention, with its access log.
+type PurgeJob struct {
+    store  store.Store
+    logger *zap.Logger
+}
+
+func NewPurgeJob(store store.Store, logger *zap.Logger) *PurgeJob {
+    return &PurgeJob{store: store, logger: logger}
+}
+
+func (p *PurgeJob) Name() string { return "evidence.purge" }
+
+func (p *PurgeJob) Run(ctx context.Context) error {
+    deleted, err := p.store.PurgeExpiredModerationEvidence(ctx, time.Now())
+    if err != nil {
+        return fmt.Errorf("failed to purge evidence: %w", err)
+    }
+    p.logger.Info("Purged moderation evidence", zap.Int64("deleted", deleted))
+    return nil
+}
@@ -0,0 +1,128 @@
+// Package grpcapi serves chat's core operations over gRPC to internal
+// services, such as stats bots and moderation tooling, so they need not
+// pose as a websocket client. Callers share one token; the service is
+// meant for the private network only.
+package grpcapi
+
+//go:generate protoc -I ../../proto --go_out=../.. --go_opt=module=github.com/yourusername/sports-chat --go-grpc_out=../.. --go-grpc_opt=module=github.com/yourusername/sports-chat chat/v1/chat.proto
+
+import (
+    "context"
+    "crypto/subtle"
+    "errors"
+    "strings"
+
+    "go.uber.org/zap"
+    "google.golang.org/grpc"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/metadata"
+    "google.golang.org/grpc/status"
+
+    "github.com/yourusername/sports-chat/internal/grpcapi/chatv1"
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/sportsdata"
+    "github.com/yourusername/sports-chat/internal/store"
+    "github.com/yourusername/sports-chat/internal/websocket"
+)
+
+type Server struct {
+    chatv1.UnimplementedChatServiceServer
+
+    store  store.Store
+    hub    *websocket.Hub
+    token  []byte
+    logger *zap.Logger
+}
+
+func NewServer(store store.Store, hub *websocket.Hub, token string, logger *zap.Logger) *Server {
+    return &Server{store: store, hub: hub, token: []byte(token), logger: logger}
+}
+
+// GRPCServer returns a gRPC server with the chat service registered,
+// refusing calls without the token.
+func (s *Server) GRPCServer() *grpc.Server {
+    srv := grpc.NewServer(grpc.UnaryInterceptor(s.authenticate))
+    chatv1.RegisterChatServiceServer(srv, s)
+    return srv
+}
+
+func (s *Server) authenticate(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+    md, _ := metadata.FromIncomingContext(ctx)
+    var token string
+    if values := md.Get("authorization"); len(values) > 0 {
+        token, _ = strings.CutPrefix(values[0], "Bearer ")
+    }
+    if subtle.ConstantTimeCompare([]byte(token), s.token) != 1 {
+        return nil, status.Error(codes.Unauthenticated, "invalid bearer token")
+    }
+    return handler(ctx, req)
+}
+
+func (s *Server) SendMessage(ctx context.Context, req *chatv1.SendMessageRequest) (*chatv1.SendMessageResponse, error) {
+    if _, err := s.store.GetChatRoom(ctx, req.RoomId); err != nil {
+        return nil, status.Error(codes.NotFound, "room not found")
+    }
+    user, err := s.store.GetUser(ctx, req.UserId)
+    if err != nil {
+        return nil, status.Error(codes.NotFound, "user not found")
+    }
+    if user.BannedAt != nil || user.DeactivatedAt != nil {
+        return nil, status.Error(codes.FailedPrecondition, "user may not post")
+    }
+
+    err = s.hub.PostMessage(ctx, req.RoomId, user, req.Content)
+    switch {
+    case errors.Is(err, websocket.ErrEmptyMessage), errors.Is(err, websocket.ErrMessageTooLong):
+        return nil, status.Error(codes.InvalidArgument, err.Error())
+    case errors.Is(err, websocket.ErrMessageBlocked):
+        return nil, status.Error(codes.FailedPrecondition, err.Error())
+    case err != nil:
+        s.logger.Error("Failed to post integration message", zap.Error(err), zap.String("room", req.RoomId))
+        return nil, status.Error(codes.Internal, "failed to post message")
+    }
+    return &chatv1.SendMessageResponse{}, nil
+}
+
+func (s *Server) BroadcastMatchEvent(ctx context.Context, req *chatv1.BroadcastMatchEventRequest) (*chatv1.BroadcastMatchEventResponse, error) {
+    if req.Event == nil || req.Event.EventType == "" || req.Event.EventTime < 0 {
+        return nil, status.Error(codes.InvalidArgument, "an event with a type and minute is required")
+    }
+    match, err := s.store.GetMatch(ctx, req.MatchId)
+    if err != nil || match == nil {
+        return nil, status.Error(codes.NotFound, "match not found")
+    }
+
+    // The match itself is pushed unchanged; only the event is new
+    event := &models.MatchEvent{
+        EventType:   req.Event.EventType,
+        EventTime:   int(req.Event.EventTime),
+        Description: req.Event.Description,
+    }
+    if _, err := s.hub.PushMatch(ctx, match, &sportsdata.Push{Match: match, Events: []*models.MatchEvent{event}}); err != nil {
+        s.logger.Error("Failed to broadcast match event", zap.Error(err), zap.String("match_id", match.ID))
+        return nil, status.Error(codes.Internal, "failed to broadcast match event")
+    }
+    return &chatv1.BroadcastMatchEventResponse{}, nil
+}
+
+func (s *Server) GetPresence(ctx context.Context, req *chatv1.GetPresenceRequest) (*chatv1.GetPresenceResponse, error) {
+    if req.RoomId == "" {
+        return nil, status.Error(codes.InvalidArgument, "room_id is required")
+    }
+    presence, err := s.hub.RoomPresence(ctx, req.RoomId)
+    if err != nil {
+        s.logger.Error("Failed to get room presence", zap.Error(err), zap.String("room", req.RoomId))
+        return nil, status.Error(codes.Internal, "failed to get presence")
+    }
+
+    resp := &chatv1.GetPresenceResponse{RoomId: presence.RoomID, UserCount: int32(presence.UserCount)}
+    for _, user := range presence.Users {
+        resp.Users = append(resp.Users, &chatv1.User{
+            Id:        user.ID,
+            Username:  user.Username,
+            AvatarUrl: user.AvatarURL,
+            Flair:     user.Flair,
+        })
+    }
+    return resp, nil
+}
@@ -0,0 +1,118 @@
+// Package highlights publishes video clips of match moments to their
+// match rooms, where they show as cards, typically right after a goal.
+package highlights
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "net/url"
+    "strings"
+    "time"
+    "unicode/utf8"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/store"
+)
+
+const (
+    maxTitleLength = 200
+    maxURLLength   = 2048
+)
+
+var (
+    ErrNoMatch          = errors.New("match not found")
+    ErrNoMatchRoom      = errors.New("match has no room")
+    ErrNoEvent          = errors.New("event is not part of this match")
+    ErrInvalidHighlight = errors.New("a highlight needs a title of up to 200 characters and an http(s) video URL")
+)
+
+// Announcer sends a frame to every client in a room, on every instance.
+// The websocket hub implements it.
+type Announcer interface {
+    Announce(room string, message *models.WSMessage)
+}
+
+type Service struct {
+    store     store.Store
+    announcer Announcer
+    logger    *zap.Logger
+}
+
+func NewService(store store.Store, announcer Announcer, logger *zap.Logger) *Service {
+    return &Service{store: store, announcer: announcer, logger: logger}
+}
+
+// Create stores a highlight and sends it to the match's room. eventID may
+// be empty for clips that show no single event.
+func (s *Service) Create(ctx context.Context, matchID, eventID, title, videoURL, createdBy string) (*models.Highlight, error) {
+    title = strings.TrimSpace(title)
+    videoURL = strings.TrimSpace(videoURL)
+    if title == "" || utf8.RuneCountInString(title) > maxTitleLength || !validURL(videoURL) {
+        return nil, ErrInvalidHighlight
+    }
+
+    if _, err := s.store.GetMatch(ctx, matchID); err != nil {
+        return nil, ErrNoMatch
+    }
+    room, err := s.store.GetMatchChatRoom(ctx, matchID)
+    if err != nil {
+        return nil, ErrNoMatchRoom
+    }
+
+    highlight := &models.Highlight{
+        MatchID:   matchID,
+        EventID:   eventID,
+        Title:     title,
+        VideoURL:  videoURL,
+        CreatedBy: createdBy,
+        CreatedAt: time.Now(),
+    }
+    if eventID != "" {
+        events, err := s.store.GetMatchEvents(ctx, matchID)
+        if err != nil {
+            return nil, fmt.Errorf("failed to load match events: %w", err)
+        }
+        for _, event := range events {
+            if event.ID == eventID {
+                highlight.Event = event
+                break
+            }
+        }
+        if highlight.Event == nil {
+            return nil, ErrNoEvent
+        }
+    }
+
+    if err := s.store.CreateHighlight(ctx, highlight); err != nil {
+        return nil, fmt.Errorf("failed to create highlight: %w", err)
+    }
+
+    data, err := json.Marshal(highlight)
+    if err == nil {
+        s.announcer.Announce(room.ID, &models.WSMessage{
+            Type:      models.MessageTypeHighlight,
+            ChatRoom:  room.ID,
+            Event:     highlight.Event,
+            Data:      data,
+            Timestamp: time.Now(),
+        })
+    }
+
+    s.logger.Info("Published highlight",
+        zap.String("highlight_id", highlight.ID),
+        zap.String("match_id", matchID),
+        zap.String("room", room.ID))
+    return highlight, nil
+}
+
+func validURL(raw string) bool {
+    if raw == "" || len(raw) > maxURLLength {
+        return false
+    }
+    u, err := url.Parse(raw)
+    return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
@@ -0,0 +1,120 @@
+// Package incidents freezes what happened in a room into a bundle for a
+// postmortem: its messages, the frames its clients received, the
+// moderation taken and the hub's state at capture. Bundles are written to
+// object storage, so they survive the journal's TTL and the room itself.
+package incidents
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+    "time"
+
+    "github.com/google/uuid"
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/objectstore"
+    "github.com/yourusername/sports-chat/internal/store"
+    "github.com/yourusername/sports-chat/internal/websocket"
+)
+
+// maxRows bounds each list in a bundle, so a capture of a flooded room
+// stays a size an admin can open.
+const maxRows = 5000
+
+// Bundle is what an incident's object holds.
+type Bundle struct {
+    Incident *models.Incident  `json:"incident"`
+    Room     *models.ChatRoom  `json:"room"`
+    Messages []*models.Message `json:"messages"`
+    // Every frame the room's clients were sent, joins, leaves and
+    // moderation notices included; empty when the journal is disabled
+    Frames    []*models.JournalEntry `json:"frames"`
+    Sanctions []*models.RoomSanction `json:"sanctions"`
+    Flags     []*models.MessageFlag  `json:"flags"`
+    // The capturing instance's hub
+    Hub *websocket.HubStats `json:"hub"`
+    // Lists that hit the row limit
+    Truncated []string `json:"truncated,omitempty"`
+}
+
+// HubStats is the part of the hub a capture reads.
+type HubStats interface {
+    Stats(room string) *websocket.HubStats
+}
+
+type Service struct {
+    store  store.Store
+    bucket objectstore.Bucket
+    hub    HubStats
+    logger *zap.Logger
+}
+
+func NewService(store store.Store, bucket objectstore.Bucket, hub HubStats, logger *zap.Logger) *Service {
+    return &Service{store: store, bucket: bucket, hub: hub, logger: logger}
+}
+
+// Capture snapshots the room's last window and stores the bundle. The
+// bundle is written before its index row, so a listed incident always
+// has one.
+func (s *Service) Capture(ctx context.Context, room *models.ChatRoom, window time.Duration, capturedBy, note string) (*models.Incident, error) {
+    roomID := room.ID
+    to := time.Now()
+    from := to.Add(-window)
+    incident := &models.Incident{
+        ID:         uuid.NewString(),
+        RoomID:     roomID,
+        CapturedBy: capturedBy,
+        Note:       note,
+        From:       from,
+        To:         to,
+    }
+    incident.ObjectKey = fmt.Sprintf("incidents/%s/%s/%s.json", to.UTC().Format("2006/01/02"), roomID, incident.ID)
+
+    bundle := &Bundle{Incident: incident, Room: room, Hub: s.hub.Stats(roomID)}
+    var err error
+    if bundle.Messages, err = s.store.GetMessagesBetween(ctx, roomID, from, to, maxRows); err != nil {
+        return nil, err
+    }
+    if bundle.Frames, err = s.store.GetJournalEntries(ctx, roomID, from, to, maxRows); err != nil {
+        return nil, err
+    }
+    if bundle.Sanctions, err = s.store.ListRoomSanctionsBetween(ctx, roomID, from, to); err != nil {
+        return nil, err
+    }
+    if bundle.Flags, err = s.store.ListRoomMessageFlags(ctx, roomID, from, to); err != nil {
+        return nil, err
+    }
+    if len(bundle.Messages) == maxRows {
+        bundle.Truncated = append(bundle.Truncated, "messages")
+    }
+    if len(bundle.Frames) == maxRows {
+        bundle.Truncated = append(bundle.Truncated, "frames")
+    }
+
+    data, err := json.Marshal(bundle)
+    if err != nil {
+        return nil, fmt.Errorf("failed to encode incident bundle: %w", err)
+    }
+    incident.Size = len(data)
+    if err := s.bucket.Put(ctx, incident.ObjectKey, "application/json", data); err != nil {
+        return nil, err
+    }
+    if err := s.store.CreateIncident(ctx, incident); err != nil {
+        return nil, err
+    }
+
+    s.logger.Info("Captured room incident",
+        zap.String("incident_id", incident.ID),
+        zap.String("room", roomID),
+        zap.Int("messages", len(bundle.Messages)),
+        zap.Int("frames", len(bundle.Frames)),
+        zap.Int("size", incident.Size))
+    return incident, nil
+}
+
+// Bundle reads an incident's bundle back as stored.
+func (s *Service) Bundle(ctx context.Context, incident *models.Incident) ([]byte, error) {
+    return s.bucket.Get(ctx, incident.ObjectKey)
+}
@@ -0,0 +1,128 @@
+package jobs
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/metrics"
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+var ErrUnknownJob = errors.New("no decoder registered for job")
+
+// Replayable is a job that can be rebuilt from its payload. When one
+// exhausts its retries it is written to the dead letter store instead of
+// being dropped, and can be replayed once the cause is fixed.
+type Replayable interface {
+    Job
+    Payload() ([]byte, error)
+}
+
+// Decoder rebuilds a job from the payload of its dead letter.
+type Decoder func(payload []byte) (Job, error)
+
+// DeadLetterStore keeps jobs that exhausted their retries.
+type DeadLetterStore interface {
+    CreateDeadLetter(ctx context.Context, letter *models.DeadLetter) error
+}
+
+// Register sets the decoder used to replay dead letters of the named job.
+func (q *Queue) Register(name string, decoder Decoder) {
+    q.decodersMu.Lock()
+    defer q.decodersMu.Unlock()
+    q.decoders[name] = decoder
+}
+
+// Replay rebuilds a dead-lettered job and enqueues it. A replay that
+// fails again is dead-lettered again as a new letter.
+func (q *Queue) Replay(letter *models.DeadLetter) error {
+    q.decodersMu.RLock()
+    decoder, ok := q.decoders[letter.JobName]
+    q.decodersMu.RUnlock()
+    if !ok {
+        return fmt.Errorf("%w: %s", ErrUnknownJob, letter.JobName)
+    }
+
+    job, err := decoder(letter.Payload)
+    if err != nil {
+        return fmt.Errorf("failed to decode job: %w", err)
+    }
+    return q.Enqueue(job)
+}
+
+// deadLetter records a job that failed every attempt. Jobs that cannot be
+// replayed are only counted and logged, as before.
+func (q *Queue) deadLetter(job Job, attempts int, cause error) {
+    replayable, ok := job.(Replayable)
+    if !ok || q.opts.DeadLetters == nil {
+        return
+    }
+
+    payload, err := replayable.Payload()
+    if err != nil {
+        q.logger.Error("Failed to encode dead letter",
+            zap.Error(err),
+            zap.String("job", job.Name()))
+        return
+    }
+
+    ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+    defer cancel()
+
+    letter := &models.DeadLetter{
+        JobName:   job.Name(),
+        Payload:   payload,
+        Error:     cause.Error(),
+        Attempts:  attempts,
+        CreatedAt: time.Now(),
+    }
+    if err := q.opts.DeadLetters.CreateDeadLetter(ctx, letter); err != nil {
+        q.logger.Error("Failed to store dead letter",
+            zap.Error(err),
+            zap.String("job", job.Name()))
+        return
+    }
+    q.metrics.DeadLetters.WithLabelValues(job.Name()).Inc()
+}
+
+// DeadLetterCounter counts dead letters not yet replayed.
+type DeadLetterCounter interface {
+    CountPendingDeadLetters(ctx context.Context) (int, error)
+}
+
+// DeadLetterMonitor publishes the pending dead letter count and raises an
+// error log, for alerting, whenever the count grows past the threshold.
+type DeadLetterMonitor struct {
+    store     DeadLetterCounter
+    threshold int
+    metrics   *metrics.Metrics
+    logger    *zap.Logger
+    last      int
+}
+
+func NewDeadLetterMonitor(store DeadLetterCounter, threshold int, metrics *metrics.Metrics, logger *zap.Logger) *DeadLetterMonitor {
+    return &DeadLetterMonitor{store: store, threshold: threshold, metrics: metrics, logger: logger}
+}
+
+func (m *DeadLetterMonitor) Name() string { return "jobs.dead_letter_monitor" }
+
+func (m *DeadLetterMonitor) Run(ctx context.Context) error {
+    pending, err := m.store.CountPendingDeadLetters(ctx)
+    if err != nil {
+        return fmt.Errorf("failed to count dead letters: %w", err)
+    }
+    m.metrics.DeadLettersPending.Set(float64(pending))
+
+    if pending > m.threshold && pending > m.last {
+        m.logger.Error("Dead letters growing",
+            zap.Int("pending", pending),
+            zap.Int("previous", m.last),
+            zap.Int("threshold", m.threshold))
+    }
+    m.last = pending
+    return nil
+}
@@ -0,0 +1,173 @@
+package jobs
+
+import (
+    "context"
+    "errors"
+    "sync"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/metrics"
+)
+
+var (
+    ErrQueueFull   = errors.New("job queue is full")
+    ErrQueueClosed = errors.New("job queue is closed")
+)
+
+// Job is a unit of asynchronous work executed by the queue workers.
+type Job interface {
+    Name() string
+    Run(ctx context.Context) error
+}
+
+// JobFunc adapts a plain function to the Job interface.
+type JobFunc struct {
+    JobName string
+    Fn      func(ctx context.Context) error
+}
+
+func (j JobFunc) Name() string                  { return j.JobName }
+func (j JobFunc) Run(ctx context.Context) error { return j.Fn(ctx) }
+
+type Options struct {
+    Workers    int
+    QueueSize  int
+    MaxRetries int
+    Timeout    time.Duration
+
+    // DeadLetters keeps replayable jobs that exhaust their retries. Nil
+    // drops them after logging.
+    DeadLetters DeadLetterStore
+}
+
+type Queue struct {
+    jobs    chan Job
+    opts    Options
+    metrics *metrics.Metrics
+    logger  *zap.Logger
+
+    decodersMu sync.RWMutex
+    decoders   map[string]Decoder
+
+    wg     sync.WaitGroup
+    mu     sync.RWMutex
+    closed bool
+}
+
+func NewQueue(opts Options, metrics *metrics.Metrics, logger *zap.Logger) *Queue {
+    if opts.Workers <= 0 {
+        opts.Workers = 4
+    }
+    if opts.QueueSize <= 0 {
+        opts.QueueSize = 1024
+    }
+    if opts.Timeout <= 0 {
+        opts.Timeout = 30 * time.Second
+    }
+
+    return &Queue{
+        jobs:    make(chan Job, opts.QueueSize),
+        opts:    opts,
+        metrics: metrics,
+        logger:  logger,
+
+        decoders: make(map[string]Decoder),
+    }
+}
+
+func (q *Queue) Start() {
+    for i := 0; i < q.opts.Workers; i++ {
+        q.wg.Add(1)
+        go q.worker()
+    }
+}
+
+// Enqueue schedules a job without blocking. Callers on hot paths should
+// log and drop on ErrQueueFull rather than retry inline.
+func (q *Queue) Enqueue(job Job) error {
+    q.mu.RLock()
+    defer q.mu.RUnlock()
+
+    if q.closed {
+        return ErrQueueClosed
+    }
+
+    select {
+    case q.jobs <- job:
+        q.metrics.JobQueueDepth.Inc()
+        return nil
+    default:
+        return ErrQueueFull
+    }
+}
+
+// Stop stops accepting jobs and waits for queued jobs to drain.
+func (q *Queue) Stop(ctx context.Context) error {
+    q.mu.Lock()
+    if !q.closed {
+        q.closed = true
+        close(q.jobs)
+    }
+    q.mu.Unlock()
+
+    done := make(chan struct{})
+    go func() {
+        q.wg.Wait()
+        close(done)
+    }()
+
+    select {
+    case <-done:
+        return nil
+    case <-ctx.Done():
+        return ctx.Err()
+    }
+}
+
+func (q *Queue) worker() {
+    defer q.wg.Done()
+
+    for job := range q.jobs {
+        q.metrics.JobQueueDepth.Dec()
+        q.process(job)
+    }
+}
+
+func (q *Queue) process(job Job) {
+    var err error
+    for attempt := 0; attempt <= q.opts.MaxRetries; attempt++ {
+        if attempt > 0 {
+            time.Sleep(backoff(attempt))
+        }
+
+        ctx, cancel := context.WithTimeout(context.Background(), q.opts.Timeout)
+        err = job.Run(ctx)
+        cancel()
+
+        if err == nil {
+            q.metrics.JobsProcessed.WithLabelValues(job.Name()).Inc()
+            return
+        }
+
+        q.logger.Warn("Job attempt failed",
+            zap.Error(err),
+            zap.String("job", job.Name()),
+            zap.Int("attempt", attempt+1))
+    }
+
+    q.metrics.JobsFailed.WithLabelValues(job.Name()).Inc()
+    q.logger.Error("Job failed after retries",
+        zap.Error(err),
+        zap.String("job", job.Name()))
+    q.deadLetter(job, q.opts.MaxRetries+1, err)
+}
+
+func backoff(attempt int) time.Duration {
+    d := 100 * time.Millisecond << uint(attempt-1)
+    if d > 10*time.Second {
+        d = 10 * time.Second
+    }
+    return d
+}
@@ -0,0 +1,110 @@
+package jobs
+
+import (
+    "context"
+    "sync"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/metrics"
+)
+
+// NextFunc returns the next run time after now.
+type NextFunc func(now time.Time) time.Time
+
+func Every(interval time.Duration) NextFunc {
+    return func(now time.Time) time.Time {
+        return now.Add(interval)
+    }
+}
+
+// DailyAt runs once a day at the given UTC wall-clock time.
+func DailyAt(hour, minute int) NextFunc {
+    return func(now time.Time) time.Time {
+        now = now.UTC()
+        next := time.Date(now.Year(), now.Month(), now.Day(), hour, minute, 0, 0, time.UTC)
+        if !next.After(now) {
+            next = next.AddDate(0, 0, 1)
+        }
+        return next
+    }
+}
+
+type scheduled struct {
+    job     Job
+    next    NextFunc
+    timeout time.Duration
+}
+
+// Scheduler runs periodic jobs on their own goroutines. Unlike the Queue,
+// scheduled jobs are long-running batch work and are not retried; they
+// simply run again at the next tick.
+type Scheduler struct {
+    entries []scheduled
+    metrics *metrics.Metrics
+    logger  *zap.Logger
+
+    stop chan struct{}
+    wg   sync.WaitGroup
+}
+
+func NewScheduler(metrics *metrics.Metrics, logger *zap.Logger) *Scheduler {
+    return &Scheduler{
+        metrics: metrics,
+        logger:  logger,
+        stop:    make(chan struct{}),
+    }
+}
+
+func (s *Scheduler) Schedule(job Job, next NextFunc, timeout time.Duration) {
+    s.entries = append(s.entries, scheduled{job: job, next: next, timeout: timeout})
+}
+
+func (s *Scheduler) Start() {
+    for _, entry := range s.entries {
+        s.wg.Add(1)
+        go s.loop(entry)
+    }
+}
+
+func (s *Scheduler) Stop() {
+    close(s.stop)
+    s.wg.Wait()
+}
+
+func (s *Scheduler) loop(entry scheduled) {
+    defer s.wg.Done()
+
+    for {
+        wait := time.Until(entry.next(time.Now()))
+        timer := time.NewTimer(wait)
+
+        select {
+        case <-timer.C:
+            s.run(entry)
+        case <-s.stop:
+            timer.Stop()
+            return
+        }
+    }
+}
+
+func (s *Scheduler) run(entry scheduled) {
+    ctx, cancel := context.WithTimeout(context.Background(), entry.timeout)
+    defer cancel()
+
+    start := time.Now()
+    if err := entry.job.Run(ctx); err != nil {
+        s.metrics.JobsFailed.WithLabelValues(entry.job.Name()).Inc()
+        s.logger.Error("Scheduled job failed",
+            zap.Error(err),
+            zap.String("job", entry.job.Name()))
+        return
+    }
+
+    s.metrics.JobsProcessed.WithLabelValues(entry.job.Name()).Inc()
+    s.logger.Info("Scheduled job completed",
+        zap.String("job", entry.job.Name()),
+        zap.Duration("duration", time.Since(start)))
+}
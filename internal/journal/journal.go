@@ -0,0 +1,145 @@
+package journal
+
+import (
+    "context"
+    "fmt"
+    "sync"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/metrics"
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/store"
+)
+
+const (
+    batchSize     = 200
+    flushInterval = 500 * time.Millisecond
+)
+
+// Journal records every frame broadcast to a room. Appends are buffered
+// and written in batches so the hub's fan-out path never waits on the
+// database; when the buffer is full entries are dropped and counted.
+type Journal struct {
+    store   store.Store
+    metrics *metrics.Metrics
+    logger  *zap.Logger
+
+    entries chan *models.JournalEntry
+    done    chan struct{}
+    mu      sync.RWMutex
+    closed  bool
+}
+
+func New(store store.Store, bufferSize int, metrics *metrics.Metrics, logger *zap.Logger) *Journal {
+    return &Journal{
+        store:   store,
+        metrics: metrics,
+        logger:  logger,
+        entries: make(chan *models.JournalEntry, bufferSize),
+        done:    make(chan struct{}),
+    }
+}
+
+func (j *Journal) Start() {
+    go j.run()
+}
+
+// Stop flushes buffered entries and stops the writer.
+func (j *Journal) Stop() {
+    j.mu.Lock()
+    if !j.closed {
+        j.closed = true
+        close(j.entries)
+    }
+    j.mu.Unlock()
+    <-j.done
+}
+
+func (j *Journal) Append(room string, frame []byte) {
+    j.mu.RLock()
+    defer j.mu.RUnlock()
+    if j.closed {
+        return
+    }
+
+    entry := &models.JournalEntry{
+        RoomID:    room,
+        Frame:     frame,
+        CreatedAt: time.Now(),
+    }
+
+    select {
+    case j.entries <- entry:
+    default:
+        j.metrics.JournalDropped.Inc()
+    }
+}
+
+func (j *Journal) run() {
+    defer close(j.done)
+
+    ticker := time.NewTicker(flushInterval)
+    defer ticker.Stop()
+
+    batch := make([]*models.JournalEntry, 0, batchSize)
+    for {
+        select {
+        case entry, ok := <-j.entries:
+            if !ok {
+                j.flush(batch)
+                return
+            }
+            batch = append(batch, entry)
+            if len(batch) >= batchSize {
+                j.flush(batch)
+                batch = batch[:0]
+            }
+
+        case <-ticker.C:
+            if len(batch) > 0 {
+                j.flush(batch)
+                batch = batch[:0]
+            }
+        }
+    }
+}
+
+func (j *Journal) flush(batch []*models.JournalEntry) {
+    if len(batch) == 0 {
+        return
+    }
+
+    ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+    defer cancel()
+
+    if err := j.store.AppendJournalEntries(ctx, batch); err != nil {
+        j.metrics.JournalDropped.Add(float64(len(batch)))
+        j.logger.Error("Failed to write journal entries",
+            zap.Error(err),
+            zap.Int("entries", len(batch)))
+    }
+}
+
+// PurgeJob deletes journal entries older than the retention TTL.
+type PurgeJob struct {
+    store  store.Store
+    ttl    time.Duration
+    logger *zap.Logger
+}
+
+func NewPurgeJob(store store.Store, ttl time.Duration, logger *zap.Logger) *PurgeJob {
+    return &PurgeJob{store: store, ttl: ttl, logger: logger}
+}
+
+func (p *PurgeJob) Name() string { return "journal.purge" }
+
+func (p *PurgeJob) Run(ctx context.Context) error {
+    deleted, err := p.store.PurgeJournalEntries(ctx, time.Now().Add(-p.ttl))
+    if err != nil {
+        return fmt.Errorf("failed to purge journal: %w", err)
+    }
+    p.logger.Info("Purged journal entries", zap.Int64("deleted", deleted))
+    return nil
+}
@@ -0,0 +1,140 @@
+// Package langdetect guesses the language of short chat messages. It is
+// deliberately small: languages with their own script are told apart by
+// script, and Latin-script languages by how many of their most common
+// words a message uses. That is good enough to tally a room's languages;
+// it is not meant to be right about any single "gooooal".
+package langdetect
+
+import (
+    "strings"
+    "unicode"
+)
+
+// minLetters is how many letters a message needs before it is classified
+// at all. Shorter ones are mostly names, scores and emotes.
+const minLetters = 8
+
+// minConfidence is the least share of a message's words (or letters, for
+// scripts) that must point at one language.
+const minConfidence = 0.2
+
+// scripts maps Unicode scripts used by a single language we care about to
+// that language. Han is checked after the Japanese kana.
+var scripts = []struct {
+    table *unicode.RangeTable
+    lang  string
+}{
+    {unicode.Hiragana, "ja"},
+    {unicode.Katakana, "ja"},
+    {unicode.Hangul, "ko"},
+    {unicode.Han, "zh"},
+    {unicode.Cyrillic, "ru"},
+    {unicode.Arabic, "ar"},
+    {unicode.Greek, "el"},
+    {unicode.Hebrew, "he"},
+    {unicode.Thai, "th"},
+    {unicode.Devanagari, "hi"},
+}
+
+// stopwords are frequent words that are rare in the other languages
+// listed, so one hit counts for something.
+var stopwords = map[string][]string{
+    "en": {"the", "and", "is", "that", "what", "this", "was", "with", "for", "you", "are", "not", "have", "they", "just", "he's", "it's", "what's", "how", "why"},
+    "es": {"el", "los", "las", "que", "es", "y", "por", "una", "pero", "muy", "qué", "está", "como", "del", "para", "golazo", "partido", "jugador"},
+    "fr": {"le", "les", "est", "et", "une", "pas", "que", "c'est", "il", "ils", "mais", "très", "avec", "pour", "des", "du", "quel", "match"},
+    "de": {"der", "die", "das", "und", "ist", "nicht", "ein", "eine", "was", "wie", "aber", "sehr", "mit", "für", "tor", "spiel", "schon"},
+    "pt": {"o", "os", "que", "é", "não", "uma", "um", "mas", "muito", "com", "para", "do", "da", "isso", "jogo", "você"},
+    "it": {"il", "che", "è", "non", "una", "gli", "ma", "molto", "con", "per", "del", "della", "questo", "partita", "sono"},
+    "nl": {"de", "het", "een", "en", "is", "niet", "dat", "wat", "maar", "heel", "met", "voor", "van", "wedstrijd", "geen"},
+}
+
+var words = func() map[string][]string {
+    index := make(map[string][]string)
+    for lang, list := range stopwords {
+        for _, word := range list {
+            index[word] = append(index[word], lang)
+        }
+    }
+    return index
+}()
+
+// Detect returns the message's most likely language as an ISO 639-1 code
+// and how sure it is, from 0 to 1. It returns "" when the message is too
+// short or nothing stands out.
+func Detect(text string) (string, float64) {
+    letters := 0
+    byScript := make(map[string]int)
+    for _, r := range text {
+        if !unicode.IsLetter(r) {
+            continue
+        }
+        letters++
+        for _, s := range scripts {
+            if unicode.Is(s.table, r) {
+                byScript[s.lang]++
+                break
+            }
+        }
+    }
+    if letters < minLetters {
+        // A few CJK characters carry a whole sentence
+        if letters == 0 || byScript["zh"]+byScript["ja"]+byScript["ko"] < letters {
+            return "", 0
+        }
+    }
+
+    lang, count := best(byScript)
+    if share := float64(count) / float64(letters); share >= 0.5 {
+        // Kanji are shared with Chinese; any kana at all means Japanese
+        if lang == "zh" && byScript["ja"] > 0 {
+            return "ja", share
+        }
+        return lang, share
+    }
+
+    return detectLatin(text)
+}
+
+func detectLatin(text string) (string, float64) {
+    tokens := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+        return !unicode.IsLetter(r) && r != '\''
+    })
+    if len(tokens) == 0 {
+        return "", 0
+    }
+
+    scores := make(map[string]int)
+    for _, token := range tokens {
+        for _, lang := range words[token] {
+            scores[lang]++
+        }
+    }
+    lang, count := best(scores)
+    if count == 0 {
+        return "", 0
+    }
+    for other, n := range scores {
+        if other != lang && n == count {
+            // A tie says nothing
+            return "", 0
+        }
+    }
+    confidence := float64(count) / float64(len(tokens))
+    if confidence < minConfidence {
+        return "", 0
+    }
+    return lang, confidence
+}
+
+// best picks the highest count, breaking ties by language code so results
+// are stable.
+func best(counts map[string]int) (string, int) {
+    var lang string
+    top := 0
+    for l, n := range counts {
+        if n > top || (n == top && n > 0 && l < lang) {
+            lang, top = l, n
+        }
+    }
+    return lang, top
+}
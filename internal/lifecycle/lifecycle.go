@@ -0,0 +1,208 @@
+package lifecycle
+
+import (
+    "context"
+    "fmt"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/store"
+)
+
+// upcomingLimit bounds how many scheduled matches one run creates rooms
+// for; the rest are picked up as kickoff approaches.
+const upcomingLimit = 500
+
+// Notifier tells a room's clients, on every instance, that its state or
+// topics changed. The websocket hub implements it.
+type Notifier interface {
+    RoomStateChanged(change *models.RoomStateChange)
+    TopicsChanged(room string, topics []*models.RoomTopic)
+}
+
+// Job creates a room for each upcoming match and moves match rooms
+// through their states: scheduled until shortly before kickoff, open
+// during the match, read-only once it ends and archived after a grace
+// period. States only ever move forward, so rooms an admin opened early
+// stay open. Pre-match topics collapse into the main feed at kickoff.
+type Job struct {
+    store        store.Store
+    notifier     Notifier
+    openBefore   time.Duration
+    archiveAfter time.Duration
+    logger       *zap.Logger
+}
+
+func NewJob(store store.Store, notifier Notifier, openBefore, archiveAfter time.Duration, logger *zap.Logger) *Job {
+    return &Job{
+        store:        store,
+        notifier:     notifier,
+        openBefore:   openBefore,
+        archiveAfter: archiveAfter,
+        logger:       logger,
+    }
+}
+
+func (j *Job) Name() string { return "rooms.lifecycle" }
+
+func (j *Job) Run(ctx context.Context) error {
+    if err := j.createRooms(ctx); err != nil {
+        return err
+    }
+
+    for _, state := range []string{models.RoomStateScheduled, models.RoomStateOpen, models.RoomStateReadOnly} {
+        rooms, err := j.store.GetChatRoomsByState(ctx, state)
+        if err != nil {
+            return fmt.Errorf("failed to get %s rooms: %w", state, err)
+        }
+        for _, room := range rooms {
+            // Rooms not tied to a match have no schedule to follow
+            if room.MatchID == "" {
+                continue
+            }
+            match, err := j.store.GetMatch(ctx, room.MatchID)
+            if err != nil {
+                j.logger.Warn("Failed to get room match", zap.Error(err), zap.String("room_id", room.ID))
+                continue
+            }
+            j.advance(ctx, room, match, time.Now())
+            if match.Status == models.MatchStatusLive {
+                j.collapseTopics(ctx, room, time.Now())
+            }
+        }
+    }
+    return nil
+}
+
+// createRooms gives every upcoming or live match without a room one.
+func (j *Job) createRooms(ctx context.Context) error {
+    upcoming, err := j.store.GetUpcomingMatches(ctx, upcomingLimit)
+    if err != nil {
+        return fmt.Errorf("failed to get upcoming matches: %w", err)
+    }
+    live, err := j.store.GetLiveMatches(ctx)
+    if err != nil {
+        return fmt.Errorf("failed to get live matches: %w", err)
+    }
+
+    for _, match := range append(upcoming, live...) {
+        if _, err := j.store.GetMatchChatRoom(ctx, match.ID); err == nil {
+            continue
+        }
+        // An admin deleted it; its tombstone keeps the ID
+        if _, err := j.store.GetRoomTombstone(ctx, match.ID); err == nil {
+            continue
+        }
+
+        now := time.Now()
+        room := &models.ChatRoom{
+            // Match frames are sent to the room with the match's ID
+            ID:             match.ID,
+            MatchID:        match.ID,
+            Name:           matchRoomName(match),
+            IsActive:       true,
+            State:          models.RoomStateScheduled,
+            StateChangedAt: now,
+            CreatedAt:      now,
+            UpdatedAt:      now,
+        }
+        if err := j.store.CreateChatRoom(ctx, room); err != nil {
+            j.logger.Error("Failed to create match room", zap.Error(err), zap.String("match_id", match.ID))
+            continue
+        }
+        j.logger.Info("Created match room", zap.String("match_id", match.ID))
+    }
+    return nil
+}
+
+// advance moves a room to the state its match calls for, if that is
+// later than where it is now.
+func (j *Job) advance(ctx context.Context, room *models.ChatRoom, match *models.Match, now time.Time) {
+    next := j.desiredState(room, match, now)
+    if stateRank(next) <= stateRank(room.State) {
+        return
+    }
+
+    // Every instance runs the job; only the one whose transition lands
+    // tells the room
+    moved, err := j.store.TransitionChatRoom(ctx, room.ID, room.State, next, now)
+    if err != nil {
+        j.logger.Error("Failed to transition room",
+            zap.Error(err),
+            zap.String("room_id", room.ID),
+            zap.String("state", next))
+        return
+    }
+    if !moved {
+        return
+    }
+
+    j.logger.Info("Room state changed",
+        zap.String("room_id", room.ID),
+        zap.String("from", room.State),
+        zap.String("to", next))
+    j.notifier.RoomStateChanged(&models.RoomStateChange{RoomID: room.ID, State: next, ChangedAt: now, BroadcastOnly: room.BroadcastOnly})
+}
+
+// collapseTopics folds a room's open topics into the main feed once its
+// match kicks off. Only the instance whose update collapses them tells
+// the room.
+func (j *Job) collapseTopics(ctx context.Context, room *models.ChatRoom, now time.Time) {
+    collapsed, err := j.store.CollapseRoomTopics(ctx, room.ID, now)
+    if err != nil {
+        j.logger.Error("Failed to collapse room topics", zap.Error(err), zap.String("room_id", room.ID))
+        return
+    }
+    if collapsed == 0 {
+        return
+    }
+
+    topics, err := j.store.GetRoomTopics(ctx, room.ID)
+    if err != nil {
+        j.logger.Error("Failed to get room topics", zap.Error(err), zap.String("room_id", room.ID))
+        return
+    }
+    j.logger.Info("Room topics collapsed at kickoff",
+        zap.String("room_id", room.ID),
+        zap.Int("topics", collapsed))
+    j.notifier.TopicsChanged(room.ID, topics)
+}
+
+func (j *Job) desiredState(room *models.ChatRoom, match *models.Match, now time.Time) string {
+    switch match.Status {
+    case models.MatchStatusFinished, models.MatchStatusCancelled:
+        if room.State == models.RoomStateReadOnly && now.Sub(room.StateChangedAt) >= j.archiveAfter {
+            return models.RoomStateArchived
+        }
+        return models.RoomStateReadOnly
+    case models.MatchStatusLive:
+        return models.RoomStateOpen
+    }
+    if !now.Before(match.StartTime.Add(-j.openBefore)) {
+        return models.RoomStateOpen
+    }
+    return models.RoomStateScheduled
+}
+
+func stateRank(state string) int {
+    switch state {
+    case models.RoomStateScheduled:
+        return 1
+    case models.RoomStateOpen, "":
+        return 2
+    case models.RoomStateReadOnly:
+        return 3
+    case models.RoomStateArchived:
+        return 4
+    }
+    return 0
+}
+
+func matchRoomName(match *models.Match) string {
+    if match.HomeTeam != nil && match.AwayTeam != nil {
+        return fmt.Sprintf("%s vs %s", match.HomeTeam.Name, match.AwayTeam.Name)
+    }
+    return "Match chat"
+}
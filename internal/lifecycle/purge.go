@@ -0,0 +1,48 @@
+package lifecycle
+
+import (
+    "context"
+    "fmt"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/store"
+)
+
+// purgeBatch bounds how many deleted rooms one run purges; each takes
+// its messages with it.
+const purgeBatch = 50
+
+// PurgeJob is the second phase of deleting a room: once the grace period
+// in which the deletion can be undone has passed, the room is deleted
+// with everything in it. Its tombstone stays.
+type PurgeJob struct {
+    store  store.Store
+    grace  time.Duration
+    logger *zap.Logger
+}
+
+func NewPurgeJob(store store.Store, grace time.Duration, logger *zap.Logger) *PurgeJob {
+    return &PurgeJob{store: store, grace: grace, logger: logger}
+}
+
+func (j *PurgeJob) Name() string { return "rooms.purge" }
+
+func (j *PurgeJob) Run(ctx context.Context) error {
+    now := time.Now()
+    tombstones, err := j.store.ListPurgeableRoomTombstones(ctx, now.Add(-j.grace), purgeBatch)
+    if err != nil {
+        return fmt.Errorf("failed to list deleted rooms: %w", err)
+    }
+
+    for _, tombstone := range tombstones {
+        if err := j.store.PurgeChatRoom(ctx, tombstone.RoomID, now); err != nil {
+            return err
+        }
+        j.logger.Info("Purged deleted room",
+            zap.String("room_id", tombstone.RoomID),
+            zap.Time("deleted_at", tombstone.DeletedAt))
+    }
+    return nil
+}
@@ -0,0 +1,33 @@
+// Package locale normalizes locale tags and orders the fallbacks per-locale
+// data is looked up through, for the profanity filter, commentary
+// templates and localized names alike.
+package locale
+
+import "strings"
+
+// Default names data that applies when a locale and its fallbacks have
+// none of their own.
+const Default = "default"
+
+// Normalize lowercases a locale and uses "-" as the separator.
+func Normalize(locale string) string {
+    locale = strings.ToLower(strings.TrimSpace(locale))
+    return strings.ReplaceAll(locale, "_", "-")
+}
+
+// Chain returns the lookup order for a locale, most specific first, e.g.
+// "pt_BR" -> ["pt-br", "pt"]. Default is never included; callers that
+// fall back to it append it.
+func Chain(locale string) []string {
+    locale = Normalize(locale)
+    var chain []string
+    for locale != "" && locale != Default {
+        chain = append(chain, locale)
+        i := strings.LastIndex(locale, "-")
+        if i < 0 {
+            break
+        }
+        locale = locale[:i]
+    }
+    return chain
+}
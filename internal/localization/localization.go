@@ -0,0 +1,192 @@
+// Package localization shows matches in each user's language and time
+// zone: team and competition names in their locale, where a provider or
+// an admin gave one, and kickoff in their zone. Names fall back from
+// "pt-br" to "pt" to the stored name.
+package localization
+
+import (
+    "context"
+    "fmt"
+    "sync"
+    "time"
+
+    "github.com/yourusername/sports-chat/internal/locale"
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/store"
+)
+
+type nameKey struct {
+    kind, id string
+}
+
+type heldName struct {
+    name, source string
+}
+
+type Localizer struct {
+    store store.Store
+
+    mu    sync.RWMutex
+    names map[nameKey]map[string]heldName // entity -> locale -> name
+
+    zonesMu sync.Mutex
+    zones   map[string]*time.Location
+}
+
+func NewLocalizer(store store.Store) *Localizer {
+    return &Localizer{
+        store: store,
+        names: make(map[nameKey]map[string]heldName),
+        zones: make(map[string]*time.Location),
+    }
+}
+
+// Reload replaces the names from the store. Admin changes call it so
+// they take effect without a restart.
+func (l *Localizer) Reload(ctx context.Context) error {
+    list, err := l.store.ListLocalizedNames(ctx)
+    if err != nil {
+        return fmt.Errorf("failed to load localized names: %w", err)
+    }
+
+    names := make(map[nameKey]map[string]heldName)
+    for _, n := range list {
+        key := nameKey{kind: n.Kind, id: n.EntityID}
+        if names[key] == nil {
+            names[key] = make(map[string]heldName)
+        }
+        names[key][locale.Normalize(n.Locale)] = heldName{name: n.Name, source: n.Source}
+    }
+
+    l.mu.Lock()
+    l.names = names
+    l.mu.Unlock()
+    return nil
+}
+
+// Name returns an entity's name in lang, or fallback if it has none.
+func (l *Localizer) Name(kind, id, lang, fallback string) string {
+    if id == "" {
+        return fallback
+    }
+    l.mu.RLock()
+    defer l.mu.RUnlock()
+    names := l.names[nameKey{kind: kind, id: id}]
+    for _, candidate := range locale.Chain(lang) {
+        if held, ok := names[candidate]; ok {
+            return held.name
+        }
+    }
+    return fallback
+}
+
+// Missing returns the names a provider sent for an entity that differ
+// from those held, as provider names ready to store. Admin names held
+// for a locale are left out, as the store would keep them anyway.
+func (l *Localizer) Missing(kind, id string, names map[string]string) []*models.LocalizedName {
+    if id == "" {
+        return nil
+    }
+    l.mu.RLock()
+    defer l.mu.RUnlock()
+    held := l.names[nameKey{kind: kind, id: id}]
+
+    var missing []*models.LocalizedName
+    for lang, name := range names {
+        lang = locale.Normalize(lang)
+        if current, ok := held[lang]; ok && (current.name == name || current.source == models.NameSourceAdmin) {
+            continue
+        }
+        missing = append(missing, &models.LocalizedName{
+            Kind:     kind,
+            EntityID: id,
+            Locale:   lang,
+            Name:     name,
+            Source:   models.NameSourceProvider,
+        })
+    }
+    return missing
+}
+
+// Learn adds names just stored, so they are used before the next reload.
+// As in the store, a provider's name does not replace an admin's.
+func (l *Localizer) Learn(names []*models.LocalizedName) {
+    l.mu.Lock()
+    defer l.mu.Unlock()
+    for _, n := range names {
+        key := nameKey{kind: n.Kind, id: n.EntityID}
+        if l.names[key] == nil {
+            l.names[key] = make(map[string]heldName)
+        }
+        lang := locale.Normalize(n.Locale)
+        if held, ok := l.names[key][lang]; ok && held.source == models.NameSourceAdmin && n.Source != models.NameSourceAdmin {
+            continue
+        }
+        l.names[key][lang] = heldName{name: n.Name, source: n.Source}
+    }
+}
+
+// Location returns the time zone named, or UTC for an empty or unknown
+// name. Known zones are loaded once; only they are kept, as names come
+// from clients.
+func (l *Localizer) Location(name string) *time.Location {
+    if name == "" {
+        return time.UTC
+    }
+    l.zonesMu.Lock()
+    defer l.zonesMu.Unlock()
+    if loc, ok := l.zones[name]; ok {
+        return loc
+    }
+    loc, err := time.LoadLocation(name)
+    if err != nil {
+        return time.UTC
+    }
+    l.zones[name] = loc
+    return loc
+}
+
+// Localize returns match as shown in lang, with kickoff in the named
+// time zone.
+func (l *Localizer) Localize(match *models.Match, lang, timezone string) *models.MatchLocale {
+    loc := l.Location(timezone)
+    localized := &models.MatchLocale{
+        Locale:      locale.Normalize(lang),
+        Timezone:    loc.String(),
+        Competition: l.Name(models.NameKindCompetition, match.CompetitionID, lang, ""),
+        Kickoff:     match.StartTime.In(loc).Format(time.RFC3339),
+    }
+    var home, away string
+    if match.HomeTeam != nil {
+        home = match.HomeTeam.Name
+    }
+    if match.AwayTeam != nil {
+        away = match.AwayTeam.Name
+    }
+    localized.HomeTeam = l.Name(models.NameKindTeam, match.HomeTeamID, lang, home)
+    localized.AwayTeam = l.Name(models.NameKindTeam, match.AwayTeamID, lang, away)
+    return localized
+}
+
+// ValidTimezone reports whether name is a time zone Go knows. The empty
+// name, meaning UTC, is valid.
+func ValidTimezone(name string) bool {
+    _, err := time.LoadLocation(name)
+    return err == nil
+}
+
+// ReloadJob reloads the names on a schedule, so changes made through
+// another instance, or stored from a provider by one, reach this one too.
+type ReloadJob struct {
+    localizer *Localizer
+}
+
+func NewReloadJob(localizer *Localizer) *ReloadJob {
+    return &ReloadJob{localizer: localizer}
+}
+
+func (j *ReloadJob) Name() string { return "localization.reload" }
+
+func (j *ReloadJob) Run(ctx context.Context) error {
+    return j.localizer.Reload(ctx)
+}
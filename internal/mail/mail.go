@@ -0,0 +1,65 @@
+// Package mail sends the service's transactional email, such as links
+// confirming a recovery address.
+package mail
+
+import (
+    "context"
+    "fmt"
+    "net"
+    "net/smtp"
+    "strings"
+    "time"
+)
+
+// Sender delivers a plain text message to one recipient.
+type Sender interface {
+    Send(ctx context.Context, to, subject, body string) error
+}
+
+// SMTP sends through a mail server, authenticating with PLAIN auth when
+// a username is set.
+type SMTP struct {
+    addr string
+    from string
+    auth smtp.Auth
+}
+
+func NewSMTP(addr, username, password, from string) *SMTP {
+    s := &SMTP{addr: addr, from: from}
+    if username != "" {
+        host, _, _ := net.SplitHostPort(addr)
+        s.auth = smtp.PlainAuth("", username, password, host)
+    }
+    return s
+}
+
+func (s *SMTP) Send(ctx context.Context, to, subject, body string) error {
+    // Header injection: addresses and subjects are single lines
+    if strings.ContainsAny(to+subject, "\r\n") {
+        return fmt.Errorf("invalid mail header")
+    }
+    msg := "From: " + s.from + "\r\n" +
+        "To: " + to + "\r\n" +
+        "Subject: " + subject + "\r\n" +
+        "Date: " + time.Now().Format(time.RFC1123Z) + "\r\n" +
+        "MIME-Version: 1.0\r\n" +
+        "Content-Type: text/plain; charset=utf-8\r\n" +
+        "\r\n" +
+        strings.ReplaceAll(body, "\n", "\r\n")
+
+    // net/smtp takes no context; send in the background and stop
+    // waiting when the caller gives up
+    done := make(chan error, 1)
+    go func() {
+        done <- smtp.SendMail(s.addr, s.auth, s.from, []string{to}, []byte(msg))
+    }()
+    select {
+    case err := <-done:
+        if err != nil {
+            return fmt.Errorf("failed to send mail: %w", err)
+        }
+        return nil
+    case <-ctx.Done():
+        return ctx.Err()
+    }
+}
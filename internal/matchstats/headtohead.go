@@ -0,0 +1,120 @@
+package matchstats
+
+import (
+    "context"
+    "fmt"
+
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/store"
+)
+
+const (
+    historyLimit = 50
+    formLength   = 5
+    lastMeetings = 5
+)
+
+// Form results
+const (
+    ResultWin  = "W"
+    ResultDraw = "D"
+    ResultLoss = "L"
+)
+
+type Record struct {
+    Played     int `json:"played"`
+    TeamAWins  int `json:"team_a_wins"`
+    TeamBWins  int `json:"team_b_wins"`
+    Draws      int `json:"draws"`
+    TeamAGoals int `json:"team_a_goals"`
+    TeamBGoals int `json:"team_b_goals"`
+}
+
+type HeadToHead struct {
+    TeamA        *models.Team    `json:"team_a"`
+    TeamB        *models.Team    `json:"team_b"`
+    Record       Record          `json:"record"`
+    TeamAForm    []string        `json:"team_a_form"`
+    TeamBForm    []string        `json:"team_b_form"`
+    LastMeetings []*models.Match `json:"last_meetings"`
+}
+
+// ComputeHeadToHead builds the historical record between two teams from
+// finished matches in the store.
+func ComputeHeadToHead(ctx context.Context, st store.Store, teamAID, teamBID string) (*HeadToHead, error) {
+    teamA, err := st.GetTeam(ctx, teamAID)
+    if err != nil {
+        return nil, fmt.Errorf("failed to get team %s: %w", teamAID, err)
+    }
+    teamB, err := st.GetTeam(ctx, teamBID)
+    if err != nil {
+        return nil, fmt.Errorf("failed to get team %s: %w", teamBID, err)
+    }
+
+    meetings, err := st.GetHeadToHeadMatches(ctx, teamAID, teamBID, historyLimit)
+    if err != nil {
+        return nil, fmt.Errorf("failed to get head-to-head matches: %w", err)
+    }
+
+    h2h := &HeadToHead{
+        TeamA:        teamA,
+        TeamB:        teamB,
+        LastMeetings: meetings,
+    }
+    if len(h2h.LastMeetings) > lastMeetings {
+        h2h.LastMeetings = h2h.LastMeetings[:lastMeetings]
+    }
+
+    for _, match := range meetings {
+        aGoals, bGoals := goalsFor(match, teamAID)
+        h2h.Record.Played++
+        h2h.Record.TeamAGoals += aGoals
+        h2h.Record.TeamBGoals += bGoals
+        switch {
+        case aGoals > bGoals:
+            h2h.Record.TeamAWins++
+        case bGoals > aGoals:
+            h2h.Record.TeamBWins++
+        default:
+            h2h.Record.Draws++
+        }
+    }
+
+    if h2h.TeamAForm, err = recentForm(ctx, st, teamAID); err != nil {
+        return nil, err
+    }
+    if h2h.TeamBForm, err = recentForm(ctx, st, teamBID); err != nil {
+        return nil, err
+    }
+
+    return h2h, nil
+}
+
+func recentForm(ctx context.Context, st store.Store, teamID string) ([]string, error) {
+    matches, err := st.GetTeamRecentMatches(ctx, teamID, formLength)
+    if err != nil {
+        return nil, fmt.Errorf("failed to get recent matches for %s: %w", teamID, err)
+    }
+
+    form := make([]string, 0, len(matches))
+    for _, match := range matches {
+        own, other := goalsFor(match, teamID)
+        switch {
+        case own > other:
+            form = append(form, ResultWin)
+        case own < other:
+            form = append(form, ResultLoss)
+        default:
+            form = append(form, ResultDraw)
+        }
+    }
+    return form, nil
+}
+
+// goalsFor returns the score from teamID's point of view.
+func goalsFor(match *models.Match, teamID string) (int, int) {
+    if match.HomeTeamID == teamID {
+        return match.HomeScore, match.AwayScore
+    }
+    return match.AwayScore, match.HomeScore
+}
@@ -0,0 +1,431 @@
+package metrics
+
+import (
+    "github.com/prometheus/client_golang/prometheus"
+)
+
+type Metrics struct {
+    // WebSocket
+    ConnectedClients prometheus.Gauge
+    MessagesSent     prometheus.Counter
+    WSFrames         *prometheus.CounterVec
+    WSBytes          *prometheus.CounterVec
+
+    // WebSocket compression
+    WSCompressionNegotiated *prometheus.CounterVec
+    WSCompressedMessages    *prometheus.CounterVec
+    WSPreparedFrames        prometheus.Counter
+    WSBatchFrames           prometheus.Histogram
+    WSDroppedFrames         *prometheus.CounterVec
+    WSSlowConsumers         prometheus.Counter
+
+    // Rooms readied ahead of busy kickoffs
+    RoomsPrewarmed prometheus.Counter
+    PrewarmHits    *prometheus.CounterVec
+
+    // WebSocket load shedding
+    WSUpgradesShed *prometheus.CounterVec
+
+    // Hub loop health
+    HubQueueDepth       *prometheus.GaugeVec
+    HubClientGoroutines prometheus.Gauge
+    HubLoopLag          prometheus.Gauge
+    HubStalls           prometheus.Counter
+    HubLoopRestarts     prometheus.Counter
+    HubFramesShed       prometheus.Counter
+
+    // Server-sent event streams
+    SSEStreams        prometheus.Gauge
+    SSEStreamsDropped prometheus.Counter
+
+    // Match updates pushed by the sports data provider
+    MatchPushes *prometheus.CounterVec
+
+    // Background jobs
+    JobsProcessed *prometheus.CounterVec
+    JobsFailed    *prometheus.CounterVec
+    JobQueueDepth prometheus.Gauge
+
+    // Batched message persistence
+    PersistQueueDepth    prometheus.Gauge
+    PersistBatchSize     prometheus.Histogram
+    PersistFlushDuration prometheus.Histogram
+    PersistOverflow      prometheus.Counter
+    PersistBatchFailures prometheus.Counter
+    PersistHeld          prometheus.Gauge
+
+    // Dead letters
+    DeadLetters        *prometheus.CounterVec
+    DeadLettersPending prometheus.Gauge
+
+    // Gamification
+    AchievementsUnlocked *prometheus.CounterVec
+    StatsDeltasWritten   prometheus.Counter
+    StatsDropped         prometheus.Counter
+
+    // Broadcast journal
+    JournalDropped prometheus.Counter
+
+    // Retention
+    MessagesArchived prometheus.Counter
+
+    // Product analytics
+    AnalyticsDropped prometheus.Counter
+
+    // HTTP
+    HTTPPanics prometheus.Counter
+
+    // Rate limiting
+    RateLimited         *prometheus.CounterVec
+    RateLimitExemptions *prometheus.CounterVec
+    RateLimitFallbacks  prometheus.Counter
+
+    // Moderation
+    EvasionSuspectsFlagged prometheus.Counter
+    MessagesFiltered       *prometheus.CounterVec
+
+    // Store
+    StoreDuration *prometheus.HistogramVec
+    StoreErrors   *prometheus.CounterVec
+    StoreInFlight *prometheus.GaugeVec
+    StoreRetries     *prometheus.CounterVec
+    StoreBreakerOpen prometheus.Gauge
+
+    // Room fan-out
+    FanoutQueueDepth *prometheus.GaugeVec
+    FanoutDelay      *prometheus.HistogramVec
+    FanoutDropped    *prometheus.CounterVec
+}
+
+func NewMetrics(reg prometheus.Registerer) *Metrics {
+    m := &Metrics{
+        ConnectedClients: prometheus.NewGauge(prometheus.GaugeOpts{
+            Namespace: "sports_chat",
+            Name:      "connected_clients",
+            Help:      "Number of currently connected websocket clients.",
+        }),
+        MessagesSent: prometheus.NewCounter(prometheus.CounterOpts{
+            Namespace: "sports_chat",
+            Name:      "messages_sent_total",
+            Help:      "Total number of messages broadcast to rooms.",
+        }),
+        WSFrames: prometheus.NewCounterVec(prometheus.CounterOpts{
+            Namespace: "sports_chat",
+            Name:      "ws_frames_total",
+            Help:      "Total number of websocket frames, by direction and message type.",
+        }, []string{"direction", "type"}),
+        WSBytes: prometheus.NewCounterVec(prometheus.CounterOpts{
+            Namespace: "sports_chat",
+            Name:      "ws_bytes_total",
+            Help:      "Total websocket payload bytes, by direction and the size of the room on this instance.",
+        }, []string{"direction", "room_size"}),
+        WSCompressionNegotiated: prometheus.NewCounterVec(prometheus.CounterOpts{
+            Namespace: "sports_chat",
+            Name:      "ws_compression_negotiated_total",
+            Help:      "Total number of websocket connections opened, by whether permessage-deflate was negotiated.",
+        }, []string{"negotiated"}),
+        WSCompressedMessages: prometheus.NewCounterVec(prometheus.CounterOpts{
+            Namespace: "sports_chat",
+            Name:      "ws_compressed_messages_total",
+            Help:      "Total number of compressed websocket messages written, by whether the compression was shared with other recipients.",
+        }, []string{"shared"}),
+        WSPreparedFrames: prometheus.NewCounter(prometheus.CounterOpts{
+            Namespace: "sports_chat",
+            Name:      "ws_prepared_frames_total",
+            Help:      "Total number of room frames compressed once for all of their recipients.",
+        }),
+        WSBatchFrames: prometheus.NewHistogram(prometheus.HistogramOpts{
+            Namespace: "sports_chat",
+            Name:      "ws_batch_frames",
+            Help:      "Frames per websocket message written to connections that negotiated coalescing.",
+            Buckets:   []float64{1, 2, 4, 8, 16, 32, 64, 128, 256},
+        }),
+        WSDroppedFrames: prometheus.NewCounterVec(prometheus.CounterOpts{
+            Namespace: "sports_chat",
+            Name:      "ws_dropped_frames_total",
+            Help:      "Total number of outbound frames dropped because a connection's send buffer was full, by slow consumer policy.",
+        }, []string{"policy"}),
+        WSSlowConsumers: prometheus.NewCounter(prometheus.CounterOpts{
+            Namespace: "sports_chat",
+            Name:      "ws_slow_consumer_disconnects_total",
+            Help:      "Total number of connections closed for falling behind.",
+        }),
+        RoomsPrewarmed: prometheus.NewCounter(prometheus.CounterOpts{
+            Namespace: "sports_chat",
+            Name:      "rooms_prewarmed_total",
+            Help:      "Total number of times a room was readied for a busy kickoff.",
+        }),
+        PrewarmHits: prometheus.NewCounterVec(prometheus.CounterOpts{
+            Namespace: "sports_chat",
+            Name:      "prewarm_hits_total",
+            Help:      "Total number of lookups answered from a pre-warmed room, by what was looked up.",
+        }, []string{"kind"}),
+        WSUpgradesShed: prometheus.NewCounterVec(prometheus.CounterOpts{
+            Namespace: "sports_chat",
+            Name:      "ws_upgrades_shed_total",
+            Help:      "Total number of websocket connections refused because the instance was near capacity, by the limit reached.",
+        }, []string{"reason"}),
+        SSEStreams: prometheus.NewGauge(prometheus.GaugeOpts{
+            Namespace: "sports_chat",
+            Name:      "sse_streams",
+            Help:      "Number of read-only server-sent event streams currently open.",
+        }),
+        SSEStreamsDropped: prometheus.NewCounter(prometheus.CounterOpts{
+            Namespace: "sports_chat",
+            Name:      "sse_streams_dropped_total",
+            Help:      "Total number of server-sent event streams closed for falling too far behind.",
+        }),
+        MatchPushes: prometheus.NewCounterVec(prometheus.CounterOpts{
+            Namespace: "sports_chat",
+            Name:      "match_pushes_total",
+            Help:      "Total number of match updates pushed by the sports data provider, by result.",
+        }, []string{"result"}),
+        JobsProcessed: prometheus.NewCounterVec(prometheus.CounterOpts{
+            Namespace: "sports_chat",
+            Name:      "jobs_processed_total",
+            Help:      "Total number of background jobs processed.",
+        }, []string{"job"}),
+        JobsFailed: prometheus.NewCounterVec(prometheus.CounterOpts{
+            Namespace: "sports_chat",
+            Name:      "jobs_failed_total",
+            Help:      "Total number of background jobs that exhausted their retries.",
+        }, []string{"job"}),
+        JobQueueDepth: prometheus.NewGauge(prometheus.GaugeOpts{
+            Namespace: "sports_chat",
+            Name:      "job_queue_depth",
+            Help:      "Number of background jobs waiting to be processed.",
+        }),
+        DeadLetters: prometheus.NewCounterVec(prometheus.CounterOpts{
+            Namespace: "sports_chat",
+            Name:      "dead_letters_total",
+            Help:      "Total number of jobs written to the dead letter table.",
+        }, []string{"job"}),
+        DeadLettersPending: prometheus.NewGauge(prometheus.GaugeOpts{
+            Namespace: "sports_chat",
+            Name:      "dead_letters_pending",
+            Help:      "Number of dead letters that have not been replayed.",
+        }),
+        AchievementsUnlocked: prometheus.NewCounterVec(prometheus.CounterOpts{
+            Namespace: "sports_chat",
+            Name:      "achievements_unlocked_total",
+            Help:      "Total number of achievements unlocked.",
+        }, []string{"achievement"}),
+        StatsDeltasWritten: prometheus.NewCounter(prometheus.CounterOpts{
+            Namespace: "sports_chat",
+            Name:      "stats_deltas_written_total",
+            Help:      "Total number of per-user match stats deltas written.",
+        }),
+        StatsDropped: prometheus.NewCounter(prometheus.CounterOpts{
+            Namespace: "sports_chat",
+            Name:      "stats_events_dropped_total",
+            Help:      "Total number of engagement events dropped because the stats queue was full.",
+        }),
+        MessagesArchived: prometheus.NewCounter(prometheus.CounterOpts{
+            Namespace: "sports_chat",
+            Name:      "messages_archived_total",
+            Help:      "Total number of messages moved to object storage by the retention job.",
+        }),
+        JournalDropped: prometheus.NewCounter(prometheus.CounterOpts{
+            Namespace: "sports_chat",
+            Name:      "journal_dropped_total",
+            Help:      "Total number of broadcast frames that could not be journaled.",
+        }),
+        AnalyticsDropped: prometheus.NewCounter(prometheus.CounterOpts{
+            Namespace: "sports_chat",
+            Name:      "analytics_dropped_total",
+            Help:      "Total number of analytics events dropped or not exported.",
+        }),
+        RateLimited: prometheus.NewCounterVec(prometheus.CounterOpts{
+            Namespace: "sports_chat",
+            Name:      "rate_limited_total",
+            Help:      "Total number of requests and messages rejected by rate limits.",
+        }, []string{"surface"}),
+        HTTPPanics: prometheus.NewCounter(prometheus.CounterOpts{
+            Namespace: "sports_chat",
+            Name:      "http_panics_total",
+            Help:      "Total number of panics recovered in HTTP handlers.",
+        }),
+        RateLimitExemptions: prometheus.NewCounterVec(prometheus.CounterOpts{
+            Namespace: "sports_chat",
+            Name:      "rate_limit_exemptions_total",
+            Help:      "Total number of requests and messages that bypassed rate limits, by exemption.",
+        }, []string{"surface", "exemption"}),
+        RateLimitFallbacks: prometheus.NewCounter(prometheus.CounterOpts{
+            Namespace: "sports_chat",
+            Name:      "rate_limit_fallbacks_total",
+            Help:      "Total number of rate limit checks decided locally because the shared limiter failed.",
+        }),
+        EvasionSuspectsFlagged: prometheus.NewCounter(prometheus.CounterOpts{
+            Namespace: "sports_chat",
+            Name:      "evasion_suspects_flagged_total",
+            Help:      "Total number of suspected ban evasion accounts flagged for review.",
+        }),
+        MessagesFiltered: prometheus.NewCounterVec(prometheus.CounterOpts{
+            Namespace: "sports_chat",
+            Name:      "messages_filtered_total",
+            Help:      "Total number of chat messages the moderation filters masked, flagged or blocked.",
+        }, []string{"filter", "action"}),
+        StoreDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+            Namespace: "sports_chat",
+            Name:      "store_operation_duration_seconds",
+            Help:      "Duration of store operations.",
+            Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+        }, []string{"operation"}),
+        StoreErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+            Namespace: "sports_chat",
+            Name:      "store_operation_errors_total",
+            Help:      "Total number of store operations that returned an error.",
+        }, []string{"operation"}),
+        StoreInFlight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+            Namespace: "sports_chat",
+            Name:      "store_operations_in_flight",
+            Help:      "Number of store operations currently running.",
+        }, []string{"operation"}),
+        PersistHeld: prometheus.NewGauge(prometheus.GaugeOpts{
+            Namespace: "sports_chat",
+            Name:      "persist_held_messages",
+            Help:      "Number of chat messages held in memory while the store is unavailable.",
+        }),
+        PersistQueueDepth: prometheus.NewGauge(prometheus.GaugeOpts{
+            Namespace: "sports_chat",
+            Name:      "persist_queue_depth",
+            Help:      "Number of chat messages waiting to be written in a batch.",
+        }),
+        PersistBatchSize: prometheus.NewHistogram(prometheus.HistogramOpts{
+            Namespace: "sports_chat",
+            Name:      "persist_batch_size",
+            Help:      "Number of chat messages written per batch.",
+            Buckets:   []float64{1, 2, 5, 10, 25, 50, 100, 250, 500},
+        }),
+        PersistFlushDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+            Namespace: "sports_chat",
+            Name:      "persist_flush_duration_seconds",
+            Help:      "Duration of batched chat message writes.",
+            Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+        }),
+        PersistOverflow: prometheus.NewCounter(prometheus.CounterOpts{
+            Namespace: "sports_chat",
+            Name:      "persist_overflow_total",
+            Help:      "Total number of chat messages persisted one by one because the write-behind queue was full.",
+        }),
+        PersistBatchFailures: prometheus.NewCounter(prometheus.CounterOpts{
+            Namespace: "sports_chat",
+            Name:      "persist_batch_failures_total",
+            Help:      "Total number of message batches that failed and were persisted one by one.",
+        }),
+        StoreRetries: prometheus.NewCounterVec(prometheus.CounterOpts{
+            Namespace: "sports_chat",
+            Name:      "store_operation_retries_total",
+            Help:      "Total number of store operations retried after a transient failure.",
+        }, []string{"operation"}),
+        StoreBreakerOpen: prometheus.NewGauge(prometheus.GaugeOpts{
+            Namespace: "sports_chat",
+            Name:      "store_breaker_open",
+            Help:      "Whether store calls are failing fast because the database is unreachable (1) or not (0).",
+        }),
+        HubQueueDepth: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+            Namespace: "sports_chat",
+            Name:      "hub_queue_depth",
+            Help:      "Number of registrations, unregistrations and inbound frames waiting for the hub loop, by queue.",
+        }, []string{"queue"}),
+        HubClientGoroutines: prometheus.NewGauge(prometheus.GaugeOpts{
+            Namespace: "sports_chat",
+            Name:      "hub_client_goroutines",
+            Help:      "Number of running websocket read and write pumps.",
+        }),
+        HubLoopLag: prometheus.NewGauge(prometheus.GaugeOpts{
+            Namespace: "sports_chat",
+            Name:      "hub_loop_lag_seconds",
+            Help:      "Seconds since the hub loop last started an iteration.",
+        }),
+        HubStalls: prometheus.NewCounter(prometheus.CounterOpts{
+            Namespace: "sports_chat",
+            Name:      "hub_stalls_total",
+            Help:      "Total number of times the hub loop stalled and the instance shed load.",
+        }),
+        HubLoopRestarts: prometheus.NewCounter(prometheus.CounterOpts{
+            Namespace: "sports_chat",
+            Name:      "hub_loop_restarts_total",
+            Help:      "Total number of stalled hub loops replaced by the watchdog.",
+        }),
+        HubFramesShed: prometheus.NewCounter(prometheus.CounterOpts{
+            Namespace: "sports_chat",
+            Name:      "hub_frames_shed_total",
+            Help:      "Total number of client frames refused because the hub loop was stalled and its queue full.",
+        }),
+        FanoutQueueDepth: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+            Namespace: "sports_chat",
+            Name:      "fanout_queue_depth",
+            Help:      "Number of room frames waiting for delivery, by priority.",
+        }, []string{"tier"}),
+        FanoutDelay: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+            Namespace: "sports_chat",
+            Name:      "fanout_delay_seconds",
+            Help:      "Time room frames spent queued before delivery, by priority.",
+            Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+        }, []string{"tier"}),
+        FanoutDropped: prometheus.NewCounterVec(prometheus.CounterOpts{
+            Namespace: "sports_chat",
+            Name:      "fanout_dropped_total",
+            Help:      "Total number of room frames dropped because their queue was full.",
+        }, []string{"tier"}),
+    }
+
+    reg.MustRegister(
+        m.ConnectedClients,
+        m.MessagesSent,
+        m.WSFrames,
+        m.WSBytes,
+        m.WSCompressionNegotiated,
+        m.WSCompressedMessages,
+        m.WSPreparedFrames,
+        m.WSBatchFrames,
+        m.WSDroppedFrames,
+        m.WSSlowConsumers,
+        m.RoomsPrewarmed,
+        m.PrewarmHits,
+        m.WSUpgradesShed,
+        m.HubQueueDepth,
+        m.HubClientGoroutines,
+        m.HubLoopLag,
+        m.HubStalls,
+        m.HubLoopRestarts,
+        m.HubFramesShed,
+        m.SSEStreams,
+        m.SSEStreamsDropped,
+        m.MatchPushes,
+        m.JobsProcessed,
+        m.JobsFailed,
+        m.JobQueueDepth,
+        m.DeadLetters,
+        m.DeadLettersPending,
+        m.AchievementsUnlocked,
+        m.StatsDeltasWritten,
+        m.StatsDropped,
+        m.JournalDropped,
+        m.MessagesArchived,
+        m.AnalyticsDropped,
+        m.HTTPPanics,
+        m.RateLimited,
+        m.RateLimitExemptions,
+        m.RateLimitFallbacks,
+        m.EvasionSuspectsFlagged,
+        m.MessagesFiltered,
+        m.StoreDuration,
+        m.StoreErrors,
+        m.StoreInFlight,
+        m.StoreRetries,
+        m.StoreBreakerOpen,
+        m.PersistQueueDepth,
+        m.PersistBatchSize,
+        m.PersistFlushDuration,
+        m.PersistOverflow,
+        m.PersistBatchFailures,
+        m.PersistHeld,
+        m.FanoutQueueDepth,
+        m.FanoutDelay,
+        m.FanoutDropped,
+    )
+
+    return m
+}
@@ -1,20 +1,138 @@
 package models
 
 import (
+    "database/sql/driver"
     "encoding/json"
+    "fmt"
     "time"
 )
 
 type User struct {
-    ID           string    `json:"id" db:"id"`
-    Username     string    `json:"username" db:"username"`
-    Password     string    `json:"-" db:"password_hash"`
-    Email        string    `json:"email" db:"email"`
-    FavoriteTeam string    `json:"favorite_team" db:"favorite_team"`
-    AvatarURL    string    `json:"avatar_url" db:"avatar_url"`
-    IsAdmin      bool      `json:"is_admin" db:"is_admin"`
-    CreatedAt    time.Time `json:"created_at" db:"created_at"`
-    UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+    ID              string     `json:"id" db:"id"`
+    Username        string     `json:"username" db:"username"`
+    Password        string     `json:"-" db:"password_hash"`
+    Email           string     `json:"email" db:"email"`
+    FavoriteTeam    string     `json:"favorite_team" db:"favorite_team"`
+    AvatarURL       string     `json:"avatar_url" db:"avatar_url"`
+    Bio             string     `json:"bio" db:"bio"`
+    IsAdmin         bool       `json:"is_admin" db:"is_admin"`
+    AccountType     string     `json:"account_type" db:"account_type"`
+    RateLimitExempt bool       `json:"rate_limit_exempt" db:"rate_limit_exempt"`
+    BannedAt        *time.Time `json:"banned_at,omitempty" db:"banned_at"`
+    BanReason       string     `json:"ban_reason,omitempty" db:"ban_reason"`
+    // ShadowBanned users' chat messages reach only their own connections.
+    // It is never sent to them, and only SetShadowBan changes it.
+    ShadowBanned    bool       `json:"-" db:"shadow_banned"`
+    GoalFlashOptOut bool       `json:"goal_flash_opt_out" db:"goal_flash_opt_out"`
+    ExternalID      string     `json:"external_id,omitempty" db:"external_id"`
+    DeactivatedAt   *time.Time `json:"deactivated_at,omitempty" db:"deactivated_at"`
+    // UsernameChangedAt is when the user last renamed themselves
+    UsernameChangedAt *time.Time `json:"username_changed_at,omitempty" db:"username_changed_at"`
+    // SessionsRevokedAt invalidates every token issued before it
+    SessionsRevokedAt *time.Time `json:"-" db:"sessions_revoked_at"`
+    // BirthDate is declared by the user once, or set by an admin who
+    // verified it, which also sets AgeVerifiedAt
+    BirthDate       *time.Time `json:"birth_date,omitempty" db:"birth_date"`
+    AgeVerifiedAt   *time.Time `json:"age_verified_at,omitempty" db:"age_verified_at"`
+    // RestrictedMode is opted into; minors are always restricted
+    RestrictedMode  bool       `json:"restricted_mode" db:"restricted_mode"`
+    // Locale and Timezone localize match names and kickoff times; empty
+    // leaves names as stored and times in UTC
+    Locale          string     `json:"locale,omitempty" db:"locale"`
+    Timezone        string     `json:"timezone,omitempty" db:"timezone"`
+    CreatedAt       time.Time  `json:"created_at" db:"created_at"`
+    UpdatedAt       time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// Account types. Bot and broadcaster accounts that have been verified
+// (RateLimitExempt) bypass chat and REST rate limits.
+const (
+    AccountTypeUser        = "user"
+    AccountTypeBot         = "bot"
+    AccountTypeBroadcaster = "broadcaster"
+)
+
+// AdultAge is the age below which a user is a minor.
+const AdultAge = 18
+
+// Age is the user's age in whole years at now, if they have a birth date.
+func (u *User) Age(now time.Time) (int, bool) {
+    if u.BirthDate == nil {
+        return 0, false
+    }
+    birth := u.BirthDate.UTC()
+    now = now.UTC()
+    age := now.Year() - birth.Year()
+    if now.Month() < birth.Month() || (now.Month() == birth.Month() && now.Day() < birth.Day()) {
+        age--
+    }
+    return age, true
+}
+
+// Restricted reports whether the user chats in restricted mode: their
+// messages are filtered strictly and direct messages are off. Users with
+// no birth date are treated as adults here, though not by age-gated
+// rooms.
+func (u *User) Restricted(now time.Time) bool {
+    if u.RestrictedMode {
+        return true
+    }
+    age, ok := u.Age(now)
+    return ok && age < AdultAge
+}
+
+// DirectoryGroup is a group provisioned by an identity provider over SCIM.
+// Membership grants whatever role the group's name is mapped to.
+type DirectoryGroup struct {
+    ID          string    `json:"id" db:"id"`
+    ExternalID  string    `json:"external_id,omitempty" db:"external_id"`
+    DisplayName string    `json:"display_name" db:"display_name"`
+    MemberIDs   []string  `json:"member_ids" db:"-"`
+    CreatedAt   time.Time `json:"created_at" db:"created_at"`
+    UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// UserSummary is the lean user representation embedded in websocket frames
+// and message history. Full profiles are fetched separately.
+type UserSummary struct {
+    ID        string `json:"id"`
+    Username  string `json:"username"`
+    AvatarURL string `json:"avatar_url,omitempty"`
+    Flair     string `json:"flair,omitempty"`
+}
+
+func (u *User) Summary() *UserSummary {
+    if u == nil {
+        return nil
+    }
+    return &UserSummary{
+        ID:        u.ID,
+        Username:  u.Username,
+        AvatarURL: u.AvatarURL,
+        Flair:     u.FavoriteTeam,
+    }
+}
+
+// PublicProfile is the profile other users see; it omits private fields
+// such as email.
+type PublicProfile struct {
+    ID           string    `json:"id"`
+    Username     string    `json:"username"`
+    FavoriteTeam string    `json:"favorite_team"`
+    AvatarURL    string    `json:"avatar_url"`
+    Bio          string    `json:"bio"`
+    CreatedAt    time.Time `json:"created_at"`
+}
+
+func (u *User) PublicProfile() *PublicProfile {
+    return &PublicProfile{
+        ID:           u.ID,
+        Username:     u.Username,
+        FavoriteTeam: u.FavoriteTeam,
+        AvatarURL:    u.AvatarURL,
+        Bio:          u.Bio,
+        CreatedAt:    u.CreatedAt,
+    }
 }
 
 type Sport struct {
@@ -24,6 +142,13 @@ type Sport struct {
     CreatedAt   time.Time `json:"created_at" db:"created_at"`
 }
 
+type Competition struct {
+    ID        string    `json:"id" db:"id"`
+    Name      string    `json:"name" db:"name"`
+    SportID   string    `json:"sport_id" db:"sport_id"`
+    CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
 type Team struct {
     ID        string    `json:"id" db:"id"`
     Name      string    `json:"name" db:"name"`
@@ -33,49 +158,312 @@ type Team struct {
 }
 
 type Match struct {
-    ID          string          `json:"id" db:"id"`
-    SportID     string          `json:"sport_id" db:"sport_id"`
-    HomeTeamID  string          `json:"home_team_id" db:"home_team_id"`
-    AwayTeamID  string          `json:"away_team_id" db:"away_team_id"`
-    StartTime   time.Time       `json:"start_time" db:"start_time"`
-    Status      string          `json:"status" db:"status"`
-    HomeScore   int            `json:"home_score" db:"home_score"`
-    AwayScore   int            `json:"away_score" db:"away_score"`
-    MatchData   json.RawMessage `json:"match_data" db:"match_data"`
-    CreatedAt   time.Time       `json:"created_at" db:"created_at"`
-    UpdatedAt   time.Time       `json:"updated_at" db:"updated_at"`
+    ID            string          `json:"id" db:"id"`
+    ProviderID    string          `json:"provider_id,omitempty" db:"provider_id"`
+    SportID       string          `json:"sport_id" db:"sport_id"`
+    CompetitionID string          `json:"competition_id,omitempty" db:"competition_id"`
+    HomeTeamID    string          `json:"home_team_id" db:"home_team_id"`
+    AwayTeamID    string          `json:"away_team_id" db:"away_team_id"`
+    StartTime     time.Time       `json:"start_time" db:"start_time"`
+    Status        string          `json:"status" db:"status"`
+    Period        string          `json:"period,omitempty" db:"period"`
+    // Minute is the match clock as the provider reports it; 0 when
+    // unknown
+    Minute        int             `json:"minute,omitempty" db:"minute"`
+    HomeScore     int             `json:"home_score" db:"home_score"`
+    AwayScore     int             `json:"away_score" db:"away_score"`
+    MatchData     json.RawMessage `json:"match_data" db:"match_data"`
+    Shootout      *Shootout       `json:"shootout,omitempty" db:"shootout"`
+    Venue         *Venue          `json:"venue,omitempty" db:"venue"`
+    CreatedAt     time.Time       `json:"created_at" db:"created_at"`
+    UpdatedAt     time.Time       `json:"updated_at" db:"updated_at"`
 
     // Joined fields
     HomeTeam    *Team           `json:"home_team,omitempty" db:"-"`
     AwayTeam    *Team           `json:"away_team,omitempty" db:"-"`
     Sport       *Sport          `json:"sport,omitempty" db:"-"`
     Events      []*MatchEvent   `json:"events,omitempty" db:"-"`
+
+    // Names are the provider's localized names for the teams and
+    // competition; set only on matches read from a provider
+    Names *MatchNames `json:"-" db:"-"`
+}
+
+// Venue is where a match is played. Timezone is the venue's IANA zone.
+type Venue struct {
+    Name     string `json:"name"`
+    City     string `json:"city,omitempty"`
+    Country  string `json:"country,omitempty"`
+    Timezone string `json:"timezone,omitempty"`
+}
+
+// MatchNames holds a provider's names for a match's teams and
+// competition, keyed by locale.
+type MatchNames struct {
+    HomeTeam    map[string]string
+    AwayTeam    map[string]string
+    Competition map[string]string
+}
+
+// LocalizedName is a team's or competition's name in one locale. Names
+// set by an admin are never replaced by a provider's.
+type LocalizedName struct {
+    Kind      string    `json:"kind" db:"kind"`
+    EntityID  string    `json:"entity_id" db:"entity_id"`
+    Locale    string    `json:"locale" db:"locale"`
+    Name      string    `json:"name" db:"name"`
+    Source    string    `json:"source" db:"source"`
+    UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// Localized name kinds and sources
+const (
+    NameKindTeam        = "team"
+    NameKindCompetition = "competition"
+
+    NameSourceProvider = "provider"
+    NameSourceAdmin    = "admin"
+)
+
+// MatchLocale is a match as shown in one locale and time zone. Teams
+// without a name in the locale keep their stored one; the competition is
+// named only where it has one.
+type MatchLocale struct {
+    Locale      string `json:"locale,omitempty"`
+    Timezone    string `json:"timezone"`
+    HomeTeam    string `json:"home_team,omitempty"`
+    AwayTeam    string `json:"away_team,omitempty"`
+    Competition string `json:"competition,omitempty"`
+    // Kickoff is the start time in Timezone, in RFC 3339 with its offset
+    Kickoff string `json:"kickoff"`
+}
+
+// Match periods
+const (
+    PeriodFirstHalf         = "1H"
+    PeriodHalftime          = "HT"
+    PeriodSecondHalf        = "2H"
+    PeriodBreakBeforeET     = "BREAK"
+    PeriodExtraTimeFirst    = "ET1"
+    PeriodExtraTimeHalftime = "ET_HT"
+    PeriodExtraTimeSecond   = "ET2"
+    PeriodPenaltyShootout   = "PENS"
+)
+
+// Shootout kick outcomes
+const (
+    KickScored = "SCORED"
+    KickMissed = "MISSED"
+    KickSaved  = "SAVED"
+)
+
+type ShootoutKick struct {
+    Order   int    `json:"order"`
+    Side    string `json:"side"` // "home" or "away"
+    Player  string `json:"player,omitempty"`
+    Outcome string `json:"outcome"`
+}
+
+type Shootout struct {
+    HomeScore int             `json:"home_score"`
+    AwayScore int             `json:"away_score"`
+    Kicks     []*ShootoutKick `json:"kicks"`
+    Winner    string          `json:"winner,omitempty"` // "home" or "away" once decided
+}
+
+// Scan and Value let the shootout live in a JSONB column.
+func (s *Shootout) Scan(src interface{}) error {
+    if src == nil {
+        return nil
+    }
+    b, ok := src.([]byte)
+    if !ok {
+        return fmt.Errorf("unsupported shootout type %T", src)
+    }
+    return json.Unmarshal(b, s)
+}
+
+func (s *Shootout) Value() (driver.Value, error) {
+    if s == nil {
+        return nil, nil
+    }
+    return json.Marshal(s)
 }
 
 type ChatRoom struct {
-    ID          string    `json:"id" db:"id"`
-    MatchID     string    `json:"match_id" db:"match_id"`
-    Name        string    `json:"name" db:"name"`
-    Description string    `json:"description" db:"description"`
-    IsActive    bool      `json:"is_active" db:"is_active"`
-    CreatedAt   time.Time `json:"created_at" db:"created_at"`
-    UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+    ID                string    `json:"id" db:"id"`
+    MatchID           string    `json:"match_id" db:"match_id"`
+    ParentID          string    `json:"parent_id,omitempty" db:"parent_id"`
+    Language          string    `json:"language,omitempty" db:"language"`
+    AllowLinkPreviews bool      `json:"allow_link_previews" db:"allow_link_previews"`
+    InitialHistory    int       `json:"initial_history,omitempty" db:"initial_history"`
+    SlowModeSeconds   int       `json:"slow_mode_seconds,omitempty" db:"slow_mode_seconds"`
+    // OwnerID is the user hosting a watch party in the room; empty for
+    // rooms run by the site
+    OwnerID           string    `json:"owner_id,omitempty" db:"owner_id"`
+    // PinnedMessageID is the message shown above the room's chat
+    PinnedMessageID   string    `json:"pinned_message_id,omitempty" db:"pinned_message_id"`
+    // MinAge gates the room to users at least that old; zero is ungated.
+    // AgeVerified also requires their age to have been verified.
+    MinAge            int       `json:"min_age,omitempty" db:"min_age"`
+    AgeVerified       bool      `json:"age_verified,omitempty" db:"age_verified"`
+    // BroadcastOnly takes chat only from the room's broadcasters,
+    // moderators and owners; everyone else may still react
+    BroadcastOnly     bool      `json:"broadcast_only,omitempty" db:"broadcast_only"`
+    Name              string    `json:"name" db:"name"`
+    Description       string    `json:"description" db:"description"`
+    IsActive          bool      `json:"is_active" db:"is_active"`
+    State             string    `json:"state" db:"state"`
+    StateChangedAt    time.Time `json:"state_changed_at" db:"state_changed_at"`
+    CreatedAt         time.Time `json:"created_at" db:"created_at"`
+    UpdatedAt         time.Time `json:"updated_at" db:"updated_at"`
 
     // Joined fields
-    Match       *Match     `json:"match,omitempty" db:"-"`
-    UserCount   int        `json:"user_count,omitempty" db:"-"`
+    Match       *Match       `json:"match,omitempty" db:"-"`
+    UserCount   int          `json:"user_count,omitempty" db:"-"`
+    Topics      []*RoomTopic `json:"topics,omitempty" db:"-"`
+}
+
+// Room states, in the order a match room moves through them. Only open
+// rooms take posts from non-admins; archived rooms are also inactive.
+const (
+    RoomStateScheduled = "scheduled"
+    RoomStateOpen      = "open"
+    RoomStateReadOnly  = "read_only"
+    RoomStateArchived  = "archived"
+)
+
+// Admits reports whether the user is old enough for the room. A gated
+// room turns away users with no birth date.
+func (r *ChatRoom) Admits(u *User, now time.Time) bool {
+    if r.MinAge == 0 {
+        return true
+    }
+    if r.AgeVerified && u.AgeVerifiedAt == nil {
+        return false
+    }
+    age, ok := u.Age(now)
+    return ok && age >= r.MinAge
+}
+
+// AcceptsPosts reports whether users may chat in the room. Rooms created
+// before states existed have none and are open.
+func (r *ChatRoom) AcceptsPosts() bool {
+    return r.State == "" || r.State == RoomStateOpen
+}
+
+// AcceptsPostsIn reports whether users may chat in the room under the
+// given topic, or in the main feed when topic is empty. Open topics take
+// posts in read-only rooms too, so post-match discussion carries on in
+// them after full time.
+func (r *ChatRoom) AcceptsPostsIn(topic string) bool {
+    if r.AcceptsPosts() {
+        return true
+    }
+    if r.State != RoomStateReadOnly || topic == "" {
+        return false
+    }
+    t := r.Topic(topic)
+    return t != nil && t.Open()
+}
+
+// Topic returns the room's topic with the given ID, or nil.
+func (r *ChatRoom) Topic(id string) *RoomTopic {
+    for _, topic := range r.Topics {
+        if topic.ID == id {
+            return topic
+        }
+    }
+    return nil
+}
+
+// RoomTopic is a structured discussion thread within a room, such as
+// lineups before kickoff or player ratings after full time. Messages
+// posted to it form its own sub-feed. A collapsed topic takes no more
+// posts of its own; they go to the main feed instead.
+type RoomTopic struct {
+    ID          string     `json:"id" db:"id"`
+    RoomID      string     `json:"room_id" db:"room_id"`
+    Slug        string     `json:"slug" db:"slug"`
+    Title       string     `json:"title" db:"title"`
+    Position    int        `json:"position" db:"position"`
+    CreatedBy   string     `json:"created_by" db:"created_by"`
+    CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+    CollapsedAt *time.Time `json:"collapsed_at,omitempty" db:"collapsed_at"`
+}
+
+func (t *RoomTopic) Open() bool {
+    return t.CollapsedAt == nil
+}
+
+// RoomStateChange is the data of a room_state frame.
+type RoomStateChange struct {
+    RoomID    string    `json:"room_id"`
+    State     string    `json:"state"`
+    ChangedAt time.Time `json:"changed_at"`
+    // BroadcastOnly is the room's posting mode, sent with every state
+    // change and when the mode itself changes
+    BroadcastOnly bool `json:"broadcast_only"`
 }
 
 type Message struct {
-    ID          string    `json:"id" db:"id"`
-    ChatRoomID  string    `json:"chat_room_id" db:"chat_room_id"`
-    UserID      string    `json:"user_id" db:"user_id"`
-    Content     string    `json:"content" db:"content"`
-    MessageType string    `json:"message_type" db:"message_type"`
-    CreatedAt   time.Time `json:"created_at" db:"created_at"`
+    ID          string         `json:"id" db:"id"`
+    ChatRoomID  string         `json:"chat_room_id" db:"chat_room_id"`
+    UserID      string         `json:"user_id" db:"user_id"`
+    Content     string         `json:"content" db:"content"`
+    MessageType string         `json:"message_type" db:"message_type"`
+    Previews    []*LinkPreview `json:"previews,omitempty" db:"previews"`
+    CreatedAt   time.Time      `json:"created_at" db:"created_at"`
+
+    // Match clock when the message was sent, for messages posted to a
+    // live match room whose minute was known
+    MatchMinute *int   `json:"match_minute,omitempty" db:"match_minute"`
+    MatchPeriod string `json:"match_period,omitempty" db:"match_period"`
+
+    // ClientMsgID is the sender's own ID for the message, if it sent one
+    ClientMsgID string `json:"client_msg_id,omitempty" db:"client_msg_id"`
+
+    // Mentions are the IDs of room members @mentioned in the content
+    Mentions []string `json:"mentions,omitempty" db:"mentions"`
+
+    // TopicID is the room topic the message was posted to, if any
+    TopicID string `json:"topic_id,omitempty" db:"topic_id"`
+
+    // Seq is the message's position in its room's broadcast order; zero
+    // for messages sent before rooms were sequenced
+    Seq int64 `json:"seq,omitempty" db:"seq"`
+
+    // Language is the content's detected ISO 639-1 language, empty when
+    // it was too short to tell
+    Language string `json:"language,omitempty" db:"language"`
 
     // Joined fields
-    User        *User      `json:"user,omitempty" db:"-"`
+    User      *UserSummary     `json:"user,omitempty" db:"-"`
+    Reactions []*ReactionCount `json:"reactions,omitempty" db:"-"`
+}
+
+// Reaction is one user's emoji reaction to a message. A user can react
+// with several emoji but each only once.
+type Reaction struct {
+    MessageID string    `json:"message_id" db:"message_id"`
+    UserID    string    `json:"user_id" db:"user_id"`
+    Emoji     string    `json:"emoji" db:"emoji"`
+    CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// ReactionCount aggregates the reactions to a message by emoji.
+type ReactionCount struct {
+    Emoji string `json:"emoji" db:"emoji"`
+    Count int    `json:"count" db:"count"`
+}
+
+// LinkPreview is OpenGraph metadata unfurled from a link in a message.
+type LinkPreview struct {
+    URL         string `json:"url"`
+    Title       string `json:"title,omitempty"`
+    Description string `json:"description,omitempty"`
+    ImageURL    string `json:"image_url,omitempty"`
+    SiteName    string `json:"site_name,omitempty"`
 }
 
 type MatchEvent struct {
@@ -87,22 +475,380 @@ type MatchEvent struct {
     CreatedAt   time.Time `json:"created_at" db:"created_at"`
 }
 
+// CommentaryTemplate renders match events of one type for one locale.
+// Placeholders such as {minute} and {description} are filled from the
+// event and its match.
+type CommentaryTemplate struct {
+    Locale    string    `json:"locale" db:"locale"`
+    EventType string    `json:"event_type" db:"event_type"`
+    Template  string    `json:"template" db:"template"`
+    UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// ReconciliationConflict records one stored match that disagreed with the
+// provider's final data and what was done about it.
+type ReconciliationConflict struct {
+    MatchID    string `json:"match_id"`
+    ProviderID string `json:"provider_id"`
+    Field      string `json:"field"`
+    Stored     string `json:"stored"`
+    Provider   string `json:"provider"`
+    Corrected  bool   `json:"corrected"`
+    Resettled  bool   `json:"resettled"`
+    Error      string `json:"error,omitempty"`
+}
+
+type ReconciliationReport struct {
+    ID             string                    `json:"id" db:"id"`
+    StartedAt      time.Time                 `json:"started_at" db:"started_at"`
+    FinishedAt     time.Time                 `json:"finished_at" db:"finished_at"`
+    MatchesChecked int                       `json:"matches_checked" db:"matches_checked"`
+    Conflicts      []*ReconciliationConflict `json:"conflicts" db:"conflicts"`
+}
+
+// JournalEntry is one frame as it was broadcast to a room.
+type JournalEntry struct {
+    ID        int64           `json:"id" db:"id"`
+    RoomID    string          `json:"room_id" db:"chat_room_id"`
+    Frame     json.RawMessage `json:"frame" db:"frame"`
+    CreatedAt time.Time       `json:"created_at" db:"created_at"`
+}
+
+// Profanity severities
+const (
+    SeverityMild     = 1
+    SeverityModerate = 2
+    SeveritySevere   = 3
+)
+
+// Moderation actions
+const (
+    ActionAllow = "allow"
+    ActionMask  = "mask"
+    // ActionFlag delivers the message and queues it for moderator review
+    ActionFlag  = "flag"
+    // ActionBlock drops the message, telling only the sender
+    ActionBlock = "block"
+)
+
+// ModerationFilter is the stored configuration of one filter in the
+// moderation chain. Config holds the filter's own parameters, such as
+// the spam thresholds or the link allow and deny lists.
+type ModerationFilter struct {
+    Name      string          `json:"name" db:"name"`
+    Enabled   bool            `json:"enabled" db:"enabled"`
+    Action    string          `json:"action" db:"action"`
+    Config    json.RawMessage `json:"config,omitempty" db:"config"`
+    UpdatedAt time.Time       `json:"updated_at" db:"updated_at"`
+}
+
+// Message flag review states
+const (
+    FlagPending   = "pending"
+    FlagConfirmed = "confirmed"
+    FlagDismissed = "dismissed"
+)
+
+// MessageFlag is a delivered chat message a moderation filter queued for
+// review. Confirming a flag does not delete the message; moderators do
+// that separately.
+type MessageFlag struct {
+    ID         string     `json:"id" db:"id"`
+    MessageID  string     `json:"message_id" db:"message_id"`
+    RoomID     string     `json:"room_id" db:"room_id"`
+    UserID     string     `json:"user_id" db:"user_id"`
+    Content    string     `json:"content" db:"content"`
+    Filter     string     `json:"filter" db:"filter"`
+    Reason     string     `json:"reason,omitempty" db:"reason"`
+    Status     string     `json:"status" db:"status"`
+    ReviewedBy string     `json:"reviewed_by,omitempty" db:"reviewed_by"`
+    ReviewedAt *time.Time `json:"reviewed_at,omitempty" db:"reviewed_at"`
+    CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+}
+
+// ModerationEvidence is a sealed copy of a message deleted by
+// moderation, kept for appeals and legal requests until ExpiresAt. It is
+// never changed once stored. Sealed is the message, encrypted under the
+// key KeyID names; it is never sent to clients.
+type ModerationEvidence struct {
+    ID        string    `json:"id" db:"id"`
+    MessageID string    `json:"message_id" db:"message_id"`
+    RoomID    string    `json:"room_id" db:"chat_room_id"`
+    UserID    string    `json:"user_id" db:"user_id"`
+    DeletedBy string    `json:"deleted_by" db:"deleted_by"`
+    Reason    string    `json:"reason,omitempty" db:"reason"`
+    KeyID     string    `json:"key_id" db:"key_id"`
+    Sealed    []byte    `json:"-" db:"sealed"`
+    CreatedAt time.Time `json:"created_at" db:"created_at"`
+    ExpiresAt time.Time `json:"expires_at" db:"expires_at"`
+}
+
+// EvidenceAccess records an admin opening a piece of evidence and the
+// appeal or legal request they opened it for.
+type EvidenceAccess struct {
+    EvidenceID string    `json:"evidence_id" db:"evidence_id"`
+    AdminID    string    `json:"admin_id" db:"admin_id"`
+    Purpose    string    `json:"purpose" db:"purpose"`
+    AccessedAt time.Time `json:"accessed_at" db:"accessed_at"`
+}
+
+// ShadowBanEntry records a shadow ban applied to or removed from a user,
+// by whom and why.
+type ShadowBanEntry struct {
+    ID        string    `json:"id" db:"id"`
+    UserID    string    `json:"user_id" db:"user_id"`
+    Banned    bool      `json:"banned" db:"banned"`
+    ActorID   string    `json:"actor_id,omitempty" db:"actor_id"`
+    Reason    string    `json:"reason,omitempty" db:"reason"`
+    CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// Moderation actions a user can appeal
+const (
+    AppealBan            = "ban"
+    AppealRoomBan        = "room_ban"
+    AppealRoomMute       = "room_mute"
+    AppealMessageRemoval = "message_removal"
+)
+
+// Appeal review states
+const (
+    AppealPending  = "pending"
+    AppealAccepted = "accepted"
+    AppealRejected = "rejected"
+)
+
+// Appeal asks moderators to reverse an action taken against UserID: a
+// site-wide ban, a ban or mute in RoomID, or the removal of MessageID.
+// ActionAt is when the action was taken; each action can be appealed
+// once.
+type Appeal struct {
+    ID         string     `json:"id" db:"id"`
+    UserID     string     `json:"user_id" db:"user_id"`
+    Action     string     `json:"action" db:"action"`
+    RoomID     string     `json:"room_id,omitempty" db:"chat_room_id"`
+    MessageID  string     `json:"message_id,omitempty" db:"message_id"`
+    ActionAt   time.Time  `json:"action_at" db:"action_at"`
+    Statement  string     `json:"statement" db:"statement"`
+    Status     string     `json:"status" db:"status"`
+    ReviewedBy string     `json:"reviewed_by,omitempty" db:"reviewed_by"`
+    ReviewNote string     `json:"review_note,omitempty" db:"review_note"`
+    ReviewedAt *time.Time `json:"reviewed_at,omitempty" db:"reviewed_at"`
+    CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+}
+
+type ProfanityWord struct {
+    Locale   string `json:"locale" db:"locale"`
+    Word     string `json:"word" db:"word"`
+    Severity int    `json:"severity" db:"severity"`
+}
+
+// ProfanityPolicy maps each severity to an action for one locale.
+type ProfanityPolicy struct {
+    Locale         string    `json:"locale" db:"locale"`
+    MildAction     string    `json:"mild_action" db:"mild_action"`
+    ModerateAction string    `json:"moderate_action" db:"moderate_action"`
+    SevereAction   string    `json:"severe_action" db:"severe_action"`
+    UpdatedAt      time.Time `json:"updated_at" db:"updated_at"`
+}
+
+func (p *ProfanityPolicy) ActionFor(severity int) string {
+    switch severity {
+    case SeverityMild:
+        return p.MildAction
+    case SeverityModerate:
+        return p.ModerateAction
+    default:
+        return p.SevereAction
+    }
+}
+
 type UserChatRoom struct {
     UserID      string    `json:"user_id" db:"user_id"`
     ChatRoomID  string    `json:"chat_room_id" db:"chat_room_id"`
     LastReadAt  time.Time `json:"last_read_at" db:"last_read_at"`
 }
 
+// UnreadCount is a joined room's read state for one user.
+type UnreadCount struct {
+    RoomID      string    `json:"room_id" db:"chat_room_id"`
+    UnreadCount int       `json:"unread_count" db:"unread_count"`
+    LastReadAt  time.Time `json:"last_read_at" db:"last_read_at"`
+}
+
+// RoomSummary is what the room switcher shows for one joined room. Match
+// is set for match rooms and carries the live score.
+type RoomSummary struct {
+    Room        *ChatRoom `json:"room"`
+    LastMessage *Message  `json:"last_message,omitempty"`
+    UnreadCount int       `json:"unread_count"`
+    LastReadAt  time.Time `json:"last_read_at"`
+    Match       *Match    `json:"match,omitempty"`
+    ViewerCount int       `json:"viewer_count"`
+}
+
+// Draft is a user's unsent message in a room, synced across their
+// devices.
+type Draft struct {
+    UserID    string    `json:"user_id" db:"user_id"`
+    RoomID    string    `json:"room_id" db:"chat_room_id"`
+    Content   string    `json:"content" db:"content"`
+    UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// KeywordAlert notifies a user when a chat message mentions the keyword.
+// An empty RoomID applies the alert to every room.
+type KeywordAlert struct {
+    ID        string    `json:"id" db:"id"`
+    UserID    string    `json:"user_id" db:"user_id"`
+    Keyword   string    `json:"keyword" db:"keyword"`
+    RoomID    string    `json:"room_id,omitempty" db:"chat_room_id"`
+    CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// QuietHours is a user's daily window, in their own time zone, during
+// which notifications from outside the rooms they are in are held back.
+// Start and End are "HH:MM"; a window may span midnight.
+type QuietHours struct {
+    UserID   string `json:"user_id" db:"user_id"`
+    Timezone string `json:"timezone" db:"timezone"`
+    Start    string `json:"start" db:"start_time"`
+    End      string `json:"end" db:"end_time"`
+    // TeamGoals still notifies for goals involving the user's favorite
+    // team
+    TeamGoals bool      `json:"team_goals" db:"team_goals"`
+    UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+type Achievement struct {
+    Code        string `json:"code"`
+    Name        string `json:"name"`
+    Description string `json:"description"`
+    XP          int    `json:"xp"`
+}
+
+type UserAchievement struct {
+    UserID     string    `json:"user_id" db:"user_id"`
+    Code       string    `json:"code" db:"achievement_code"`
+    UnlockedAt time.Time `json:"unlocked_at" db:"unlocked_at"`
+}
+
+type UserProgress struct {
+    UserID        string    `json:"user_id" db:"user_id"`
+    XP            int       `json:"xp" db:"xp"`
+    CurrentStreak int       `json:"current_streak" db:"current_streak"`
+    LongestStreak int       `json:"longest_streak" db:"longest_streak"`
+    LastActiveOn  time.Time `json:"last_active_on" db:"last_active_on"`
+}
+
+// Level derives the user's level from XP: each level needs
+// progressively more XP (100, 400, 900, ... total).
+func (p *UserProgress) Level() int {
+    level := 1
+    for (level * level * 100) <= p.XP {
+        level++
+    }
+    return level
+}
+
+// IsDerby reports whether the provider flagged the match as a derby in
+// its sport-specific match data.
+func (m *Match) IsDerby() bool {
+    if len(m.MatchData) == 0 {
+        return false
+    }
+    var data struct {
+        Derby bool `json:"derby"`
+    }
+    if err := json.Unmarshal(m.MatchData, &data); err != nil {
+        return false
+    }
+    return data.Derby
+}
+
 // WebSocket message types
 const (
-    MessageTypeChat     = "chat"
-    MessageTypeJoin     = "join"
-    MessageTypeLeave    = "leave"
-    MessageTypeTyping   = "typing"
-    MessageTypeEvent    = "event"
-    MessageTypeError    = "error"
+    MessageTypeChat        = "chat"
+    MessageTypeJoin        = "join"
+    MessageTypeLeave       = "leave"
+    MessageTypeTyping      = "typing"
+    MessageTypeEvent       = "event"
+    MessageTypeError       = "error"
+    MessageTypeAchievement = "achievement"
+    MessageTypeAlert       = "alert"
+    MessageTypeMention     = "mention"
+    MessageTypeTopics      = "topics"
+    MessageTypeRedirect    = "room_redirect"
+    MessageTypeLinkPreview = "link_preview"
+    MessageTypeShootout    = "shootout"
+    MessageTypeHistory     = "history"
+    MessageTypeGoalFlash   = "goal_flash"
+    MessageTypeDraft       = "draft"
+    MessageTypeRead        = "read"
+    MessageTypeReaction    = "reaction"
+    MessageTypeVoice       = "voice"
+    MessageTypeModerate    = "moderate"
+    MessageTypeDeleted     = "message_deleted"
+    MessageTypeSanctioned  = "sanctioned"
+    MessageTypePresence    = "presence"
+    MessageTypeMatchVote   = "match_vote"
+    MessageTypeRoomState   = "room_state"
+    MessageTypeDM          = "dm"
+    MessageTypeAck         = "ack"
+    MessageTypePoll        = "poll"
+    MessageTypeUserUpdated = "user_updated"
+    MessageTypeAnnouncement = "announcement"
+    MessageTypeSlowMode    = "slow_mode"
+    MessageTypeTicker      = "ticker"
+    MessageTypeSubscribe   = "subscribe"
+    MessageTypeUnsubscribe = "unsubscribe"
+    MessageTypeAttachment  = "attachment"
+    MessageTypePinned      = "pinned"
+    MessageTypeRoomRole    = "room_role"
+    MessageTypeHighlight   = "highlight"
+    MessageTypeEmotes      = "emotes"
+    MessageTypeEmotePack   = "emote_pack"
+    MessageTypeLeaderboard = "leaderboard"
+    MessageTypeAgeGate     = "age_gate"
+    MessageTypeAppeal      = "appeal"
+    MessageTypeHype        = "hype"
+    MessageTypeRoomDeleted = "room_deleted"
+    MessageTypeResume      = "resume"
+)
+
+// Error codes carried by error frames, stable for clients to switch on
+// where the content is for people
+const (
+    ErrorRateLimited      = "RATE_LIMITED"
+    ErrorSlowMode         = "SLOW_MODE"
+    ErrorRoomAccessDenied = "ROOM_ACCESS_DENIED"
+    ErrorRoomClosed       = "ROOM_CLOSED"
+    ErrorMessageTooLong   = "MESSAGE_TOO_LONG"
+    ErrorMuted            = "MUTED"
+    ErrorBanned           = "BANNED"
+    ErrorContentBlocked   = "CONTENT_BLOCKED"
+    ErrorInvalidRequest   = "INVALID_REQUEST"
+    ErrorNotFound         = "NOT_FOUND"
+    ErrorForbidden        = "FORBIDDEN"
+    ErrorAgeRestricted    = "AGE_RESTRICTED"
+    ErrorSlowConsumer     = "SLOW_CONSUMER"
+    ErrorBroadcastOnly    = "BROADCAST_ONLY"
+    ErrorInternal         = "INTERNAL_ERROR"
+    ErrorServerBusy       = "SERVER_BUSY"
+    ErrorRoomDeleted      = "ROOM_DELETED"
 )
 
+// WSError is the data of an error frame. MessageType and ClientMsgID
+// name the message that was refused, so a client can match the error to
+// what it sent; RetryAfterMs is set when the message may be retried.
+type WSError struct {
+    Code         string `json:"code"`
+    Message      string `json:"message"`
+    MessageType  string `json:"message_type,omitempty"`
+    ClientMsgID  string `json:"client_msg_id,omitempty"`
+    RetryAfterMs int64  `json:"retry_after_ms,omitempty"`
+}
+
 // Match statuses
 const (
     MatchStatusScheduled = "SCHEDULED"
@@ -113,25 +859,689 @@ const (
 
 // Event types
 const (
-    EventTypeGoal         = "GOAL"
-    EventTypeYellowCard   = "YELLOW_CARD"
-    EventTypeRedCard      = "RED_CARD"
-    EventTypeSubstitution = "SUBSTITUTION"
-    EventTypePenalty      = "PENALTY"
-    EventTypeKickoff      = "KICKOFF"
-    EventTypeHalftime     = "HALFTIME"
-    EventTypeFulltime     = "FULLTIME"
+    EventTypeGoal              = "GOAL"
+    EventTypeYellowCard        = "YELLOW_CARD"
+    EventTypeRedCard           = "RED_CARD"
+    EventTypeSubstitution      = "SUBSTITUTION"
+    EventTypePenalty           = "PENALTY"
+    EventTypeKickoff           = "KICKOFF"
+    EventTypeHalftime          = "HALFTIME"
+    EventTypeFulltime          = "FULLTIME"
+    EventTypeExtraTimeStart    = "EXTRA_TIME_START"
+    EventTypeExtraTimeHalftime = "EXTRA_TIME_HALFTIME"
+    EventTypeExtraTimeEnd      = "EXTRA_TIME_END"
+    EventTypeShootoutStart     = "SHOOTOUT_START"
+    EventTypeShootoutKick      = "SHOOTOUT_KICK"
+    EventTypeShootoutEnd       = "SHOOTOUT_END"
 )
 
+// RoomRedirect tells clients to move from one room to another after a
+// merge or split.
+type RoomRedirect struct {
+    From   string `json:"from"`
+    To     string `json:"to"`
+    Reason string `json:"reason"`
+}
+
+// RoomTombstone is what is left of a deleted room. Requests for the room
+// get it with 410 Gone, and its clients get it in a room_deleted frame,
+// so they can follow ReplacementID instead of just losing the room. The
+// room is deactivated at once and purged later; until PurgedAt the
+// deletion can be undone.
+type RoomTombstone struct {
+    RoomID        string     `json:"room_id" db:"room_id"`
+    Name          string     `json:"name" db:"name"`
+    MatchID       string     `json:"match_id,omitempty" db:"match_id"`
+    ReplacementID string     `json:"replacement_id,omitempty" db:"replacement_id"`
+    Reason        string     `json:"reason,omitempty" db:"reason"`
+    DeletedBy     string     `json:"-" db:"deleted_by"`
+    DeletedAt     time.Time  `json:"deleted_at" db:"deleted_at"`
+    PurgedAt      *time.Time `json:"-" db:"purged_at"`
+}
+
+// ResumeInfo is sent to a connection when it opens: the token to
+// reconnect with to carry on where it left off, and, when it did carry
+// on, where it stands in each room it was restored to.
+type ResumeInfo struct {
+    Token string `json:"token"`
+    // ExpiresIn is how long after a drop the token still resumes, in
+    // seconds
+    ExpiresIn int            `json:"expires_in"`
+    Resumed   bool           `json:"resumed"`
+    Rooms     []*ResumedRoom `json:"rooms,omitempty"`
+}
+
+// ResumedRoom is a room a connection was restored to on resuming.
+type ResumedRoom struct {
+    RoomID string `json:"room_id"`
+    // LastSeq is the last sequence the client is known to have received;
+    // the missed messages after it follow
+    LastSeq int64 `json:"last_seq,omitempty"`
+    Muted   bool  `json:"muted,omitempty"`
+    // SlowModeUntil is when the user may next post while the room is in
+    // slow mode
+    SlowModeUntil *time.Time `json:"slow_mode_until,omitempty"`
+}
+
 // WebSocket message struct
 type WSMessage struct {
-    Type      string          `json:"type"`
-    ChatRoom  string          `json:"chat_room,omitempty"`
-    Content   string          `json:"content,omitempty"`
-    User      *User           `json:"user,omitempty"`
-    Match     *Match          `json:"match,omitempty"`
-    Event     *MatchEvent     `json:"event,omitempty"`
-    Timestamp time.Time       `json:"timestamp"`
-    Error     string          `json:"error,omitempty"`
-    Data      json.RawMessage `json:"data,omitempty"`
-}
\ No newline at end of file
+    ID        string           `json:"id,omitempty"`
+    Type      string           `json:"type"`
+    ChatRoom  string           `json:"chat_room,omitempty"`
+    Content   string           `json:"content,omitempty"`
+    User      *UserSummary     `json:"user,omitempty"`
+    Match     *Match           `json:"match,omitempty"`
+    Event     *MatchEvent      `json:"event,omitempty"`
+    Timestamp time.Time        `json:"timestamp"`
+    Error     string           `json:"error,omitempty"`
+    Data      json.RawMessage  `json:"data,omitempty"`
+    Reactions []*ReactionCount `json:"reactions,omitempty"`
+
+    // ClientMsgID is the sender's own ID for a chat message, echoed in
+    // its ack and used to drop retries
+    ClientMsgID string `json:"client_msg_id,omitempty"`
+
+    // Match clock stamped on chat messages in live match rooms
+    MatchMinute *int   `json:"match_minute,omitempty"`
+    MatchPeriod string `json:"match_period,omitempty"`
+
+    // Mentions are the IDs of room members @mentioned in a chat message.
+    // Set server-side; whatever a client sends is replaced.
+    Mentions []string `json:"mentions,omitempty"`
+
+    // TopicID is the room topic a chat message is posted to; empty for
+    // the main feed
+    TopicID string `json:"topic_id,omitempty"`
+
+    // Seq orders a room's chat messages; a client reconnecting with the
+    // last one it saw is sent only those after it
+    Seq int64 `json:"seq,omitempty"`
+
+    // Exemption is set server-side for senders that bypass rate limits;
+    // it is never read from or written to the wire.
+    Exemption string `json:"-"`
+
+    // Flag is set server-side when a moderation filter queued the chat
+    // message for review; never on the wire.
+    Flag *MessageFlag `json:"-"`
+
+    // ExcludeConn is set server-side to the sending connection when it
+    // asked not to have its chat messages echoed; never on the wire.
+    ExcludeConn string `json:"-"`
+
+    // Shadowed is set server-side on chat messages of a shadow-banned
+    // sender, which are echoed to their own connections only; never on
+    // the wire.
+    Shadowed bool `json:"-"`
+}
+
+// RoomPresence is who is connected to a room across all instances. Users
+// may list only part of UserCount for very busy rooms.
+type RoomPresence struct {
+    RoomID    string         `json:"room_id"`
+    UserCount int            `json:"user_count"`
+    Users     []*UserSummary `json:"users,omitempty"`
+}
+
+// RoomHype is a room's hype meter: the reactions sent in it over the
+// last WindowSeconds, by members and by logged-out preview viewers alike,
+// counted by emoji only.
+type RoomHype struct {
+    RoomID        string           `json:"room_id"`
+    Total         int              `json:"total"`
+    Reactions     []*ReactionCount `json:"reactions"`
+    WindowSeconds int              `json:"window_seconds"`
+}
+
+// RoomAnalytics summarizes a watch party over a time window for its
+// owner. Attendance is sampled once a minute while the room has viewers.
+type RoomAnalytics struct {
+    RoomID        string              `json:"room_id"`
+    From          time.Time           `json:"from"`
+    To            time.Time           `json:"to"`
+    Attendance    []*AttendanceSample `json:"attendance"`
+    PeakViewers   int                 `json:"peak_viewers"`
+    MessageCount  int                 `json:"message_count"`
+    ReactionCount int                 `json:"reaction_count"`
+    TopMembers    []*MemberActivity   `json:"top_members"`
+    PeakMoments   []*PeakMoment       `json:"peak_moments"`
+    Languages     []*LanguageShare    `json:"languages"`
+}
+
+// AttendanceSample is how many users were in a room during one minute.
+type AttendanceSample struct {
+    At      time.Time `json:"at"`
+    Viewers int       `json:"viewers"`
+}
+
+// MemberActivity is what one member posted in a room.
+type MemberActivity struct {
+    User      *UserSummary `json:"user"`
+    Messages  int          `json:"messages"`
+    Reactions int          `json:"reactions"`
+}
+
+// PeakMoment is one of a room's busiest minutes, by messages and
+// reactions together.
+type PeakMoment struct {
+    At        time.Time `json:"at"`
+    Messages  int       `json:"messages"`
+    Reactions int       `json:"reactions"`
+}
+
+// LanguageShare is how much of a room's chat is in one language.
+type LanguageShare struct {
+    Language string  `json:"language"`
+    Messages int64   `json:"messages"`
+    Share    float64 `json:"share"`
+}
+
+// Incident is the index entry of a room snapshot captured for a
+// postmortem. The bundle itself is kept in object storage under
+// ObjectKey.
+type Incident struct {
+    ID         string    `json:"id" db:"id"`
+    RoomID     string    `json:"room_id" db:"chat_room_id"`
+    CapturedBy string    `json:"captured_by" db:"captured_by"`
+    Note       string    `json:"note,omitempty" db:"note"`
+    From       time.Time `json:"from" db:"window_start"`
+    To         time.Time `json:"to" db:"window_end"`
+    ObjectKey  string    `json:"object_key" db:"object_key"`
+    Size       int       `json:"size" db:"size"`
+    CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
+
+// Data export statuses and formats. A CSV export is a zip of one CSV per
+// section.
+const (
+    ExportPending = "pending"
+    ExportReady   = "ready"
+    ExportFailed  = "failed"
+
+    ExportFormatJSON = "json"
+    ExportFormatCSV  = "csv"
+)
+
+// DataExport is an archive of a user's data made at their request: their
+// profile, messages and room memberships. The archive is kept in object
+// storage under ObjectKey until ExpiresAt.
+type DataExport struct {
+    ID          string     `json:"id" db:"id"`
+    UserID      string     `json:"user_id" db:"user_id"`
+    Format      string     `json:"format" db:"format"`
+    Status      string     `json:"status" db:"status"`
+    ObjectKey   string     `json:"-" db:"object_key"`
+    Size        int        `json:"size,omitempty" db:"size"`
+    Error       string     `json:"error,omitempty" db:"error"`
+    CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+    CompletedAt *time.Time `json:"completed_at,omitempty" db:"completed_at"`
+    ExpiresAt   *time.Time `json:"expires_at,omitempty" db:"expires_at"`
+}
+
+// MessageArchive is the index entry of messages the retention job moved
+// out of postgres: a run of one room's messages from one UTC day, kept
+// in object storage under ObjectKey. Busy days take several archives.
+type MessageArchive struct {
+    ID        string    `json:"id" db:"id"`
+    RoomID    string    `json:"room_id" db:"chat_room_id"`
+    Day       time.Time `json:"day" db:"day"`
+    ObjectKey string    `json:"object_key" db:"object_key"`
+    Messages  int       `json:"messages" db:"messages"`
+    Size      int       `json:"size" db:"size"`
+    FirstAt   time.Time `json:"first_at" db:"first_at"`
+    LastAt    time.Time `json:"last_at" db:"last_at"`
+    CreatedAt time.Time `json:"created_at" db:"created_at"`
+
+    // UserIDs are the senders of the archived messages; nil for archives
+    // written before senders were recorded
+    UserIDs []string `json:"user_ids,omitempty" db:"user_ids"`
+}
+
+// Attachment statuses. An upload stays pending, visible only to its
+// uploader, until the scanner clears or rejects it.
+const (
+    AttachmentPending  = "pending"
+    AttachmentClean    = "clean"
+    AttachmentRejected = "rejected"
+)
+
+// Attachment is an image a user uploaded to a room. Its content is served
+// separately, and only once it is clean.
+type Attachment struct {
+    ID          string     `json:"id"`
+    ChatRoomID  string     `json:"chat_room_id"`
+    UserID      string     `json:"user_id"`
+    ContentType string     `json:"content_type"`
+    Size        int        `json:"size"`
+    Status      string     `json:"status"`
+    // Reason says why the scanner rejected the attachment
+    Reason      string     `json:"reason,omitempty"`
+    CreatedAt   time.Time  `json:"created_at"`
+    ScannedAt   *time.Time `json:"scanned_at,omitempty"`
+}
+
+// Room sanction kinds. A muted user can read a room but not post to it;
+// a banned user cannot join it at all.
+const (
+    SanctionMute = "mute"
+    SanctionBan  = "ban"
+)
+
+// RoomSanction is a moderator's mute or ban of a user in one room. A nil
+// ExpiresAt lasts until lifted.
+type RoomSanction struct {
+    RoomID    string     `json:"room_id" db:"room_id"`
+    UserID    string     `json:"user_id" db:"user_id"`
+    Kind      string     `json:"kind" db:"kind"`
+    Reason    string     `json:"reason,omitempty" db:"reason"`
+    CreatedBy string     `json:"created_by" db:"created_by"`
+    ExpiresAt *time.Time `json:"expires_at,omitempty" db:"expires_at"`
+    CreatedAt time.Time  `json:"created_at" db:"created_at"`
+}
+
+// Active reports whether the sanction is in force at now.
+func (s *RoomSanction) Active(now time.Time) bool {
+    return s.ExpiresAt == nil || now.Before(*s.ExpiresAt)
+}
+
+// Room roles, from most to least trusted. Users without a role are
+// members; a watch party's owner holds the owner role. Broadcasters rank
+// with VIPs and may post in broadcaster-only rooms.
+const (
+    RoomRoleOwner       = "owner"
+    RoomRoleModerator   = "moderator"
+    RoomRoleVIP         = "vip"
+    RoomRoleBroadcaster = "broadcaster"
+    RoomRoleMember      = "member"
+)
+
+// RoomRole is a role granted to a user in one room.
+type RoomRole struct {
+    RoomID    string       `json:"room_id" db:"room_id"`
+    UserID    string       `json:"user_id" db:"user_id"`
+    Role      string       `json:"role" db:"role"`
+    GrantedBy string       `json:"granted_by,omitempty" db:"granted_by"`
+    CreatedAt time.Time    `json:"created_at" db:"created_at"`
+    User      *UserSummary `json:"user,omitempty" db:"-"`
+}
+
+// Voice roles. Hosts manage who may speak; only speakers and hosts may
+// publish audio to the SFU.
+const (
+    VoiceRoleListener = "listener"
+    VoiceRoleSpeaker  = "speaker"
+    VoiceRoleHost     = "host"
+)
+
+// VoiceParticipant is a user in a room's voice session. Audio flows
+// through the SFU; the chat server only relays signaling and tracks roles.
+type VoiceParticipant struct {
+    RoomID     string       `json:"room_id" db:"room_id"`
+    UserID     string       `json:"user_id" db:"user_id"`
+    Role       string       `json:"role" db:"role"`
+    HandRaised bool         `json:"hand_raised" db:"hand_raised"`
+    JoinedAt   time.Time    `json:"joined_at" db:"joined_at"`
+    User       *UserSummary `json:"user,omitempty" db:"-"`
+}
+
+// DeadLetter is background work that exhausted its retries, kept with
+// its payload so it can be inspected and replayed.
+type DeadLetter struct {
+    ID          string          `json:"id" db:"id"`
+    JobName     string          `json:"job_name" db:"job_name"`
+    Payload     json.RawMessage `json:"payload" db:"payload"`
+    Error       string          `json:"error" db:"error"`
+    Attempts    int             `json:"attempts" db:"attempts"`
+    ReplayCount int             `json:"replay_count" db:"replay_count"`
+    ReplayedAt  *time.Time      `json:"replayed_at,omitempty" db:"replayed_at"`
+    CreatedAt   time.Time       `json:"created_at" db:"created_at"`
+}
+
+// Conversation is a direct message thread between two users. UserA sorts
+// before UserB, so each pair has a single conversation.
+type Conversation struct {
+    ID            string     `json:"id" db:"id"`
+    UserA         string     `json:"user_a" db:"user_a"`
+    UserB         string     `json:"user_b" db:"user_b"`
+    LastMessageAt *time.Time `json:"last_message_at,omitempty" db:"last_message_at"`
+    CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+
+    // Joined fields
+    Other       *UserSummary   `json:"other,omitempty" db:"-"`
+    LastMessage *DirectMessage `json:"last_message,omitempty" db:"-"`
+}
+
+// Has reports whether the user takes part in the conversation.
+func (c *Conversation) Has(userID string) bool {
+    return c.UserA == userID || c.UserB == userID
+}
+
+type DirectMessage struct {
+    ID             string    `json:"id" db:"id"`
+    ConversationID string    `json:"conversation_id" db:"conversation_id"`
+    SenderID       string    `json:"sender_id" db:"sender_id"`
+    Content        string    `json:"content" db:"content"`
+    CreatedAt      time.Time `json:"created_at" db:"created_at"`
+}
+
+// DirectMessageData is the data of a dm frame. Clients send To; the
+// server fills in the rest on delivery.
+type DirectMessageData struct {
+    To             string `json:"to"`
+    ConversationID string `json:"conversation_id,omitempty"`
+}
+
+// UserBlock stops UserID and BlockedID from messaging each other, in
+// both directions.
+type UserBlock struct {
+    UserID    string    `json:"user_id" db:"user_id"`
+    BlockedID string    `json:"blocked_id" db:"blocked_id"`
+    CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// MatchVote is the rating and player of the match vote opened at full
+// time. Only users in the match room at full time may vote.
+type MatchVote struct {
+    MatchID  string     `json:"match_id" db:"match_id"`
+    RoomID   string     `json:"room_id" db:"room_id"`
+    OpensAt  time.Time  `json:"opens_at" db:"opens_at"`
+    ClosesAt time.Time  `json:"closes_at" db:"closes_at"`
+    ClosedAt *time.Time `json:"closed_at,omitempty" db:"closed_at"`
+}
+
+// MatchBallot is one user's vote. Either half may be left empty.
+type MatchBallot struct {
+    MatchID   string    `json:"match_id" db:"match_id"`
+    UserID    string    `json:"user_id" db:"user_id"`
+    Rating    int       `json:"rating,omitempty" db:"rating"`
+    Player    string    `json:"player,omitempty" db:"player"`
+    CreatedAt time.Time `json:"created_at" db:"created_at"`
+    UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+type PlayerVotes struct {
+    Player string `json:"player"`
+    Votes  int    `json:"votes"`
+}
+
+// MatchVoteResult aggregates a match vote, live while it is open and as
+// persisted once closed. Players is ordered by votes, most first.
+type MatchVoteResult struct {
+    MatchID       string         `json:"match_id"`
+    Open          bool           `json:"open"`
+    ClosesAt      time.Time      `json:"closes_at"`
+    Ratings       int            `json:"ratings"`
+    AverageRating float64        `json:"average_rating"`
+    Players       []*PlayerVotes `json:"players"`
+}
+
+// Prediction is a user's final score prediction for a match, accepted
+// until kickoff and scored at full time.
+type Prediction struct {
+    ID        string     `json:"id" db:"id"`
+    MatchID   string     `json:"match_id" db:"match_id"`
+    UserID    string     `json:"user_id" db:"user_id"`
+    HomeScore int        `json:"home_score" db:"home_score"`
+    AwayScore int        `json:"away_score" db:"away_score"`
+    Correct   bool       `json:"correct" db:"correct"`
+    Points    int        `json:"points" db:"points"`
+    ScoredAt  *time.Time `json:"scored_at,omitempty" db:"scored_at"`
+    CreatedAt time.Time  `json:"created_at" db:"created_at"`
+    UpdatedAt time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// Season bounds the prediction points that count towards one table:
+// predictions on matches kicking off in [StartsAt, EndsAt), of the
+// competition or, without one, of every match. When a season ends its
+// standings are frozen and the next season starts from zero.
+type Season struct {
+    ID            string     `json:"id" db:"id"`
+    Name          string     `json:"name" db:"name"`
+    CompetitionID string     `json:"competition_id,omitempty" db:"competition_id"`
+    StartsAt      time.Time  `json:"starts_at" db:"starts_at"`
+    EndsAt        time.Time  `json:"ends_at" db:"ends_at"`
+    ArchivedAt    *time.Time `json:"archived_at,omitempty" db:"archived_at"`
+    CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+}
+
+// Season badges, awarded by final rank when a season is archived
+const (
+    SeasonBadgeChampion = "season_champion"
+    SeasonBadgePodium   = "season_podium"
+    SeasonBadgeTopTen   = "season_top_ten"
+)
+
+// SeasonStanding is a user's place in a season's prediction table. Users
+// level on points and correct predictions share a rank.
+type SeasonStanding struct {
+    SeasonID    string `json:"season_id" db:"season_id"`
+    UserID      string `json:"user_id" db:"user_id"`
+    Username    string `json:"username" db:"username"`
+    Rank        int    `json:"rank" db:"rank"`
+    Points      int    `json:"points" db:"points"`
+    Correct     int    `json:"correct" db:"correct"`
+    Predictions int    `json:"predictions" db:"predictions"`
+    // Badge is set on archived standings that earned one
+    Badge string `json:"badge,omitempty" db:"badge"`
+}
+
+// UserMatchStats counts a user's engagement in one match: messages posted
+// in its room, reactions their messages got there and their prediction's
+// result. Score weighs them as the leaderboards rank: a message is 1, a
+// reaction received 2 and each prediction point 10. The tracker also
+// uses it for the deltas it has yet to write.
+type UserMatchStats struct {
+    MatchID            string    `json:"match_id" db:"match_id"`
+    UserID             string    `json:"user_id" db:"user_id"`
+    Messages           int       `json:"messages" db:"messages"`
+    ReactionsReceived  int       `json:"reactions_received" db:"reactions_received"`
+    CorrectPredictions int       `json:"correct_predictions" db:"correct_predictions"`
+    PredictionPoints   int       `json:"prediction_points" db:"prediction_points"`
+    Score              int       `json:"score" db:"score"`
+    UpdatedAt          time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// LeaderboardEntry is a user's place on a match or season leaderboard.
+// Users with the same score share a rank.
+type LeaderboardEntry struct {
+    Rank               int    `json:"rank"`
+    UserID             string `json:"user_id"`
+    Username           string `json:"username"`
+    Score              int    `json:"score"`
+    Messages           int    `json:"messages"`
+    ReactionsReceived  int    `json:"reactions_received"`
+    CorrectPredictions int    `json:"correct_predictions"`
+}
+
+// Poll is an admin-created multiple choice question in a match room. It
+// closes when an admin closes it or the match finishes.
+type Poll struct {
+    ID        string     `json:"id" db:"id"`
+    MatchID   string     `json:"match_id" db:"match_id"`
+    RoomID    string     `json:"room_id" db:"room_id"`
+    Question  string     `json:"question" db:"question"`
+    Options   []string   `json:"options" db:"options"`
+    CreatedBy string     `json:"created_by" db:"created_by"`
+    ClosedAt  *time.Time `json:"closed_at,omitempty" db:"closed_at"`
+    CreatedAt time.Time  `json:"created_at" db:"created_at"`
+
+    // Joined fields
+    Tally *PollTally `json:"tally,omitempty" db:"-"`
+}
+
+// Highlight is a video clip of a moment in a match, shown in its room as
+// a card. EventID ties it to the match event it shows, such as a goal.
+type Highlight struct {
+    ID        string    `json:"id" db:"id"`
+    MatchID   string    `json:"match_id" db:"match_id"`
+    EventID   string    `json:"event_id,omitempty" db:"event_id"`
+    Title     string    `json:"title" db:"title"`
+    VideoURL  string    `json:"video_url" db:"video_url"`
+    CreatedBy string    `json:"created_by" db:"created_by"`
+    CreatedAt time.Time `json:"created_at" db:"created_at"`
+
+    // Joined fields
+    Event *MatchEvent `json:"event,omitempty" db:"-"`
+}
+
+// EmotePack is a set of emotes, site-wide or, with a TeamID, for the
+// rooms of that team's matches. Packs reach clients once published;
+// publishing again after adding emotes sends them the new set.
+type EmotePack struct {
+    ID          string     `json:"id" db:"id"`
+    Name        string     `json:"name" db:"name"`
+    TeamID      string     `json:"team_id,omitempty" db:"team_id"`
+    CreatedBy   string     `json:"created_by" db:"created_by"`
+    PublishedAt *time.Time `json:"published_at,omitempty" db:"published_at"`
+    CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+
+    // Joined fields
+    Emotes []*Emote `json:"emotes,omitempty" db:"-"`
+}
+
+// Emote is an image clients put in place of its shortcode, written
+// :shortcode: in messages. Shortcodes are unique within a pack.
+type Emote struct {
+    ID        string    `json:"id" db:"id"`
+    PackID    string    `json:"pack_id" db:"pack_id"`
+    Shortcode string    `json:"shortcode" db:"shortcode"`
+    ImageURL  string    `json:"image_url" db:"image_url"`
+    CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// PollVote is one user's choice in a poll, as an index into its options.
+type PollVote struct {
+    PollID    string    `json:"poll_id" db:"poll_id"`
+    UserID    string    `json:"user_id" db:"user_id"`
+    Option    int       `json:"option" db:"option"`
+    CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// PollTally counts a poll's votes; Counts lines up with the poll's
+// options.
+type PollTally struct {
+    PollID string `json:"poll_id"`
+    Counts []int  `json:"counts"`
+    Total  int    `json:"total"`
+}
+
+// DeviceSighting records a device fingerprint and address a user connected
+// from. Sightings feed ban evasion detection.
+type DeviceSighting struct {
+    UserID      string    `json:"user_id" db:"user_id"`
+    Fingerprint string    `json:"fingerprint,omitempty" db:"fingerprint"`
+    IP          string    `json:"ip" db:"ip"`
+    SeenAt      time.Time `json:"seen_at" db:"seen_at"`
+}
+
+// Security event kinds
+const (
+    SecuritySignIn          = "sign_in"
+    SecurityUsernameChanged = "username_changed"
+    SecuritySessionsRevoked = "sessions_revoked"
+
+    SecurityRecoveryCodesGenerated = "recovery_codes_generated"
+    SecurityRecoveryEmailChanged   = "recovery_email_changed"
+    SecurityAccountRecovered       = "account_recovered"
+    SecurityAssistedRecovery       = "assisted_recovery"
+)
+
+// Recovery token purposes
+const (
+    RecoveryVerifyEmail = "verify_email"
+    RecoveryByEmail     = "email"
+    RecoveryAssisted    = "assisted"
+)
+
+// RecoveryCode is one of a user's one-time backup codes. Only a hash of
+// the code is kept; the user saw it once, when the set was generated.
+// CodeLookup, a keyed digest of the code, finds it without trying the
+// hash of every code; codes generated before it was kept have none.
+type RecoveryCode struct {
+    ID         string     `json:"id" db:"id"`
+    UserID     string     `json:"-" db:"user_id"`
+    CodeHash   string     `json:"-" db:"code_hash"`
+    CodeLookup string     `json:"-" db:"code_lookup"`
+    CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+    UsedAt     *time.Time `json:"used_at,omitempty" db:"used_at"`
+}
+
+// RecoveryToken is a one-time secret that verifies a recovery email
+// address or signs its user back in, sent by email or handed over by
+// support. Only a hash of the secret is kept.
+type RecoveryToken struct {
+    ID        string `json:"id" db:"id"`
+    UserID    string `json:"user_id" db:"user_id"`
+    Purpose   string `json:"purpose" db:"purpose"`
+    TokenHash string `json:"-" db:"token_hash"`
+    // Email is the address a verify_email token confirms
+    Email string `json:"-" db:"email"`
+    // IssuedBy and Reason record who issued an assisted token and why
+    IssuedBy  string     `json:"issued_by,omitempty" db:"issued_by"`
+    Reason    string     `json:"reason,omitempty" db:"reason"`
+    CreatedAt time.Time  `json:"created_at" db:"created_at"`
+    ExpiresAt time.Time  `json:"expires_at" db:"expires_at"`
+    UsedAt    *time.Time `json:"used_at,omitempty" db:"used_at"`
+}
+
+// SecurityEvent is an entry in a user's own account activity log. Sign-ins
+// are kept one per session, LastSeenAt tracking its latest connection.
+type SecurityEvent struct {
+    ID         string     `json:"id" db:"id"`
+    UserID     string     `json:"-" db:"user_id"`
+    Kind       string     `json:"kind" db:"kind"`
+    SessionID  string     `json:"-" db:"session_id"`
+    IP         string     `json:"ip,omitempty" db:"ip"`
+    Country    string     `json:"country,omitempty" db:"country"`
+    Device     string     `json:"device,omitempty" db:"device"`
+    Detail     string     `json:"detail,omitempty" db:"detail"`
+    CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+    LastSeenAt time.Time  `json:"last_seen_at" db:"last_seen_at"`
+    DisputedAt *time.Time `json:"disputed_at,omitempty" db:"disputed_at"`
+
+    // Current marks the requesting session's own sign-in; never stored
+    Current bool `json:"current,omitempty" db:"-"`
+}
+
+// Evasion signal kinds
+const (
+    SignalFingerprint = "fingerprint"
+    SignalIP          = "ip"
+    SignalBehavior    = "behavior"
+)
+
+// Evasion suspect review states
+const (
+    SuspectPending   = "pending"
+    SuspectConfirmed = "confirmed"
+    SuspectDismissed = "dismissed"
+)
+
+type EvasionSignal struct {
+    Kind   string  `json:"kind"`
+    Weight float64 `json:"weight"`
+    Detail string  `json:"detail,omitempty"`
+}
+
+// EvasionSuspect flags UserID as a possible alt account of the banned
+// BannedUserID. Suspects are only ever reviewed by moderators, never
+// acted on automatically.
+type EvasionSuspect struct {
+    ID           string           `json:"id" db:"id"`
+    UserID       string           `json:"user_id" db:"user_id"`
+    BannedUserID string           `json:"banned_user_id" db:"banned_user_id"`
+    Score        float64          `json:"score" db:"score"`
+    Signals      []*EvasionSignal `json:"signals" db:"signals"`
+    Status       string           `json:"status" db:"status"`
+    ReviewedBy   string           `json:"reviewed_by,omitempty" db:"reviewed_by"`
+    ReviewedAt   *time.Time       `json:"reviewed_at,omitempty" db:"reviewed_at"`
+    CreatedAt    time.Time        `json:"created_at" db:"created_at"`
+}
+
+// EvasionSignalStats counts moderator verdicts on suspects that carried a
+// signal kind; it is the feedback used to reweight signals.
+type EvasionSignalStats struct {
+    Kind      string `json:"kind" db:"kind"`
+    Confirmed int    `json:"confirmed" db:"confirmed"`
+    Dismissed int    `json:"dismissed" db:"dismissed"`
+}
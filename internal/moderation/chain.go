@@ -0,0 +1,219 @@
+package moderation
+
+import (
+    "context"
+    "fmt"
+    "sync"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/metrics"
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/store"
+)
+
+// Input is a chat message as the filters see it. Content is the text
+// after any earlier filter masked it.
+type Input struct {
+    UserID  string
+    Room    string
+    Locale  string
+    Content string
+    At      time.Time
+    // Strict filters a restricted user's message: every filter runs,
+    // disabled or not, the profanity filter applies StrictPolicy and
+    // what would be flagged is blocked
+    Strict  bool
+}
+
+// Verdict is what a filter, or the chain as a whole, decided. Content is
+// the text to deliver for ActionAllow, ActionMask and ActionFlag. Filter
+// and Reason explain the strongest action taken.
+type Verdict struct {
+    Action  string
+    Content string
+    Filter  string
+    Reason  string
+}
+
+// Filter is one stage of the moderation chain. Apply may be called from
+// many client goroutines at once.
+type Filter interface {
+    Name() string
+    Apply(in *Input) Verdict
+}
+
+// Configurable is a filter tuned from its stored settings. Configure is
+// called on every reload, with nil when the filter has no stored row, and
+// leaves the filter unchanged if the setting does not validate.
+type Configurable interface {
+    Validate(setting *models.ModerationFilter) error
+    Configure(setting *models.ModerationFilter) error
+}
+
+// actionRank orders actions from weakest to strongest.
+var actionRank = map[string]int{
+    models.ActionAllow: 0,
+    models.ActionMask:  1,
+    models.ActionFlag:  2,
+    models.ActionBlock: 3,
+}
+
+// Chain runs chat messages through the profanity filter and then every
+// other filter in order, before the hub broadcasts them. The first block
+// wins; masks carry forward to later filters; a flag still delivers the
+// message. Settings are reloaded from the store, so admins tune filters
+// without a redeploy.
+type Chain struct {
+    store     store.Store
+    profanity *ProfanityFilter
+    filters   []Filter
+    metrics   *metrics.Metrics
+    logger    *zap.Logger
+
+    mu       sync.RWMutex
+    disabled map[string]bool
+}
+
+func NewChain(store store.Store, profanity *ProfanityFilter, filters []Filter, metrics *metrics.Metrics, logger *zap.Logger) *Chain {
+    return &Chain{
+        store:     store,
+        profanity: profanity,
+        filters:   append([]Filter{profanity}, filters...),
+        metrics:   metrics,
+        logger:    logger,
+        disabled:  make(map[string]bool),
+    }
+}
+
+// Filters lists the chain's filter names in the order they run.
+func (c *Chain) Filters() []string {
+    names := make([]string, 0, len(c.filters))
+    for _, f := range c.filters {
+        names = append(names, f.Name())
+    }
+    return names
+}
+
+// Has reports whether the chain runs a filter by that name.
+func (c *Chain) Has(name string) bool {
+    for _, f := range c.filters {
+        if f.Name() == name {
+            return true
+        }
+    }
+    return false
+}
+
+// Validate checks an admin's setting for one of the chain's filters
+// before it is stored.
+func (c *Chain) Validate(setting *models.ModerationFilter) error {
+    for _, f := range c.filters {
+        if f.Name() != setting.Name {
+            continue
+        }
+        switch setting.Action {
+        case models.ActionMask, models.ActionFlag, models.ActionBlock:
+        default:
+            return fmt.Errorf("action must be mask, flag or block")
+        }
+        if configurable, ok := f.(Configurable); ok {
+            return configurable.Validate(setting)
+        }
+        return nil
+    }
+    return fmt.Errorf("unknown filter %q", setting.Name)
+}
+
+// Reload replaces the wordlists and every filter's settings from the
+// store. A setting that no longer loads leaves that filter as it was.
+func (c *Chain) Reload(ctx context.Context) error {
+    if err := c.profanity.Reload(ctx); err != nil {
+        return err
+    }
+    settings, err := c.store.ListModerationFilters(ctx)
+    if err != nil {
+        return fmt.Errorf("failed to load moderation filters: %w", err)
+    }
+
+    byName := make(map[string]*models.ModerationFilter, len(settings))
+    for _, s := range settings {
+        byName[s.Name] = s
+    }
+
+    disabled := make(map[string]bool)
+    for _, f := range c.filters {
+        setting := byName[f.Name()]
+        if setting != nil && !setting.Enabled {
+            disabled[f.Name()] = true
+        }
+        if configurable, ok := f.(Configurable); ok {
+            if err := configurable.Configure(setting); err != nil {
+                c.logger.Error("Failed to configure moderation filter",
+                    zap.Error(err),
+                    zap.String("filter", f.Name()))
+            }
+        }
+    }
+
+    c.mu.Lock()
+    c.disabled = disabled
+    c.mu.Unlock()
+    return nil
+}
+
+// Run filters one chat message.
+func (c *Chain) Run(in Input) Verdict {
+    if in.At.IsZero() {
+        in.At = time.Now()
+    }
+    c.mu.RLock()
+    disabled := c.disabled
+    c.mu.RUnlock()
+
+    verdict := Verdict{Action: models.ActionAllow, Content: in.Content}
+    for _, f := range c.filters {
+        if disabled[f.Name()] && !in.Strict {
+            continue
+        }
+        in.Content = verdict.Content
+        v := f.Apply(&in)
+        if v.Action == "" || v.Action == models.ActionAllow {
+            continue
+        }
+        if in.Strict && v.Action == models.ActionFlag {
+            v.Action = models.ActionBlock
+        }
+        c.metrics.MessagesFiltered.WithLabelValues(f.Name(), v.Action).Inc()
+
+        if v.Action != models.ActionBlock {
+            verdict.Content = v.Content
+        }
+        if actionRank[v.Action] > actionRank[verdict.Action] {
+            verdict.Action = v.Action
+            verdict.Filter = f.Name()
+            verdict.Reason = v.Reason
+        }
+        if v.Action == models.ActionBlock {
+            break
+        }
+    }
+    return verdict
+}
+
+// ReloadJob reloads the chain on a schedule, so admin changes made through
+// another instance reach this one too.
+type ReloadJob struct {
+    chain *Chain
+}
+
+func NewReloadJob(chain *Chain) *ReloadJob {
+    return &ReloadJob{chain: chain}
+}
+
+func (j *ReloadJob) Name() string { return "moderation.reload" }
+
+func (j *ReloadJob) Run(ctx context.Context) error {
+    return j.chain.Reload(ctx)
+}
@@ -0,0 +1,138 @@
+package moderation
+
+import (
+    "encoding/json"
+    "fmt"
+    "net/url"
+    "regexp"
+    "strings"
+    "sync"
+
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+const removedLink = "[link removed]"
+
+// linkPattern finds explicit links and bare domains such as "example.com".
+// The scheme and "www." submatch tells the two apart.
+var linkPattern = regexp.MustCompile(`(?i)\b(https?://|www\.)?((?:[a-z0-9](?:[a-z0-9-]*[a-z0-9])?\.)+[a-z]{2,})\b(?:[/?#:][^\s<>"']*)?`)
+
+// LinkConfig is the link filter's stored config. Domains match
+// themselves and their subdomains. Denied domains are caught even when
+// written without a scheme; with AllowlistOnly, any explicit link to a
+// domain not on Allow is caught too.
+type LinkConfig struct {
+    Allow         []string `json:"allow"`
+    Deny          []string `json:"deny"`
+    AllowlistOnly bool     `json:"allowlist_only"`
+}
+
+// LinkFilter applies link allow and deny lists. With no stored setting
+// it lets every link through.
+type LinkFilter struct {
+    mu     sync.RWMutex
+    action string
+    config LinkConfig
+}
+
+func NewLinkFilter() *LinkFilter {
+    return &LinkFilter{action: models.ActionMask}
+}
+
+func (f *LinkFilter) Name() string { return "links" }
+
+func (f *LinkFilter) Validate(setting *models.ModerationFilter) error {
+    _, err := parseLinkConfig(setting)
+    return err
+}
+
+func (f *LinkFilter) Configure(setting *models.ModerationFilter) error {
+    cfg, err := parseLinkConfig(setting)
+    if err != nil {
+        return err
+    }
+
+    f.mu.Lock()
+    defer f.mu.Unlock()
+    f.action = models.ActionMask
+    if setting != nil {
+        f.action = setting.Action
+    }
+    f.config = *cfg
+    return nil
+}
+
+func parseLinkConfig(setting *models.ModerationFilter) (*LinkConfig, error) {
+    cfg := &LinkConfig{}
+    if setting == nil || len(setting.Config) == 0 {
+        return cfg, nil
+    }
+    if err := json.Unmarshal(setting.Config, cfg); err != nil {
+        return nil, fmt.Errorf("invalid links config: %w", err)
+    }
+    for _, list := range [][]string{cfg.Allow, cfg.Deny} {
+        for i, domain := range list {
+            domain = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(domain)), "www.")
+            if domain == "" || strings.ContainsAny(domain, "/: ") {
+                return nil, fmt.Errorf("invalid domain %q", list[i])
+            }
+            list[i] = domain
+        }
+    }
+    return cfg, nil
+}
+
+func (f *LinkFilter) Apply(in *Input) Verdict {
+    f.mu.RLock()
+    defer f.mu.RUnlock()
+
+    verdict := Verdict{Action: models.ActionAllow, Content: in.Content}
+    if len(f.config.Deny) == 0 && !f.config.AllowlistOnly {
+        return verdict
+    }
+
+    var masked strings.Builder
+    last := 0
+    for _, m := range linkPattern.FindAllStringSubmatchIndex(in.Content, -1) {
+        explicit := m[2] >= 0
+        host := strings.TrimPrefix(strings.ToLower(in.Content[m[4]:m[5]]), "www.")
+        if explicit {
+            if u, err := url.Parse(in.Content[m[0]:m[1]]); err == nil && u.Hostname() != "" {
+                host = strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+            }
+        }
+
+        var reason string
+        switch {
+        case matchesDomain(host, f.config.Deny):
+            reason = "denied link to " + host
+        case f.config.AllowlistOnly && explicit && !matchesDomain(host, f.config.Allow):
+            reason = "link to " + host + " is not allowed"
+        default:
+            continue
+        }
+
+        if verdict.Reason == "" {
+            verdict.Reason = reason
+        }
+        verdict.Action = f.action
+        masked.WriteString(in.Content[last:m[0]])
+        masked.WriteString(removedLink)
+        last = m[1]
+    }
+
+    if verdict.Action == models.ActionMask {
+        masked.WriteString(in.Content[last:])
+        verdict.Content = masked.String()
+    }
+    return verdict
+}
+
+func matchesDomain(host string, domains []string) bool {
+    for _, domain := range domains {
+        if host == domain || strings.HasSuffix(host, "."+domain) {
+            return true
+        }
+    }
+    return false
+}
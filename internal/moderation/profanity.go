@@ -0,0 +1,218 @@
+package moderation
+
+import (
+    "context"
+    "fmt"
+    "strings"
+    "sync"
+    "unicode"
+
+    "github.com/yourusername/sports-chat/internal/locale"
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/store"
+)
+
+// DefaultLocale holds the wordlist and policy applied to every locale,
+// and the policy of last resort.
+const DefaultLocale = locale.Default
+
+var defaultPolicy = &models.ProfanityPolicy{
+    Locale:         DefaultLocale,
+    MildAction:     models.ActionAllow,
+    ModerateAction: models.ActionMask,
+    SevereAction:   models.ActionBlock,
+}
+
+// StrictPolicy applies to restricted users whatever their room's locale:
+// nothing profane is let through unmasked.
+var StrictPolicy = &models.ProfanityPolicy{
+    Locale:         DefaultLocale,
+    MildAction:     models.ActionMask,
+    ModerateAction: models.ActionBlock,
+    SevereAction:   models.ActionBlock,
+}
+
+type Result struct {
+    Action   string
+    Content  string
+    Severity int
+}
+
+// ProfanityFilter applies locale-specific wordlists and severity policies.
+// A room's language channel selects the locale; lookups fall back from
+// "pt-BR" to "pt" to DefaultLocale. The default wordlist always applies in
+// addition to the locale's own words.
+type ProfanityFilter struct {
+    store store.Store
+
+    mu       sync.RWMutex
+    words    map[string]map[string]int // locale -> word -> severity
+    policies map[string]*models.ProfanityPolicy
+}
+
+func NewProfanityFilter(store store.Store) *ProfanityFilter {
+    return &ProfanityFilter{
+        store:    store,
+        words:    make(map[string]map[string]int),
+        policies: make(map[string]*models.ProfanityPolicy),
+    }
+}
+
+// Reload replaces the wordlists and policies from the store. Admin
+// changes call it so they take effect without a restart.
+func (f *ProfanityFilter) Reload(ctx context.Context) error {
+    words, err := f.store.ListProfanityWords(ctx)
+    if err != nil {
+        return fmt.Errorf("failed to load profanity words: %w", err)
+    }
+    policies, err := f.store.ListProfanityPolicies(ctx)
+    if err != nil {
+        return fmt.Errorf("failed to load profanity policies: %w", err)
+    }
+
+    wordMap := make(map[string]map[string]int)
+    for _, w := range words {
+        lang := locale.Normalize(w.Locale)
+        if wordMap[lang] == nil {
+            wordMap[lang] = make(map[string]int)
+        }
+        wordMap[lang][strings.ToLower(w.Word)] = w.Severity
+    }
+
+    policyMap := make(map[string]*models.ProfanityPolicy)
+    for _, p := range policies {
+        policyMap[locale.Normalize(p.Locale)] = p
+    }
+
+    f.mu.Lock()
+    f.words = wordMap
+    f.policies = policyMap
+    f.mu.Unlock()
+
+    return nil
+}
+
+// Check classifies content for the given locale and returns the action
+// to take. For ActionMask and ActionFlag, Content holds the masked text.
+func (f *ProfanityFilter) Check(locale, content string) Result {
+    return f.check(locale, content, false)
+}
+
+// CheckStrict is Check under StrictPolicy. The locale still picks the
+// wordlists.
+func (f *ProfanityFilter) CheckStrict(locale, content string) Result {
+    return f.check(locale, content, true)
+}
+
+func (f *ProfanityFilter) check(lang, content string, strict bool) Result {
+    f.mu.RLock()
+    defer f.mu.RUnlock()
+
+    chain := append(locale.Chain(lang), DefaultLocale)
+    policy := f.policyFor(chain)
+    if strict {
+        policy = StrictPolicy
+    }
+
+    result := Result{Action: models.ActionAllow, Content: content}
+    var masked strings.Builder
+    last := 0
+
+    for _, tok := range tokenize(content) {
+        severity := f.severityOf(chain, strings.ToLower(tok.text))
+        if severity == 0 {
+            continue
+        }
+        if severity > result.Severity {
+            result.Severity = severity
+        }
+
+        action := policy.ActionFor(severity)
+        if actionRank[action] > actionRank[result.Action] {
+            result.Action = action
+        }
+        if action == models.ActionMask {
+            masked.WriteString(content[last:tok.start])
+            masked.WriteString(mask(tok.text))
+            last = tok.end
+        }
+    }
+
+    // A flagged message is delivered masked like any other
+    if last > 0 && result.Action != models.ActionBlock {
+        masked.WriteString(content[last:])
+        result.Content = masked.String()
+    }
+    return result
+}
+
+func (f *ProfanityFilter) Name() string { return "profanity" }
+
+// Apply runs Check as a stage of the moderation chain. The locale's
+// policy, not the filter's stored action, decides what happens.
+func (f *ProfanityFilter) Apply(in *Input) Verdict {
+    result := f.check(in.Locale, in.Content, in.Strict)
+    verdict := Verdict{Action: result.Action, Content: result.Content}
+    if result.Severity > 0 {
+        verdict.Reason = fmt.Sprintf("severity %d word", result.Severity)
+    }
+    return verdict
+}
+
+func (f *ProfanityFilter) policyFor(chain []string) *models.ProfanityPolicy {
+    for _, locale := range chain {
+        if p, ok := f.policies[locale]; ok {
+            return p
+        }
+    }
+    return defaultPolicy
+}
+
+func (f *ProfanityFilter) severityOf(chain []string, word string) int {
+    for _, locale := range chain {
+        if severity, ok := f.words[locale][word]; ok {
+            return severity
+        }
+    }
+    return 0
+}
+
+type token struct {
+    text       string
+    start, end int
+}
+
+func tokenize(content string) []token {
+    var tokens []token
+    start := -1
+    for i, r := range content {
+        isWord := unicode.IsLetter(r) || unicode.IsDigit(r)
+        if isWord && start < 0 {
+            start = i
+        } else if !isWord && start >= 0 {
+            tokens = append(tokens, token{text: content[start:i], start: start, end: i})
+            start = -1
+        }
+    }
+    if start >= 0 {
+        tokens = append(tokens, token{text: content[start:], start: start, end: len(content)})
+    }
+    return tokens
+}
+
+// mask keeps the first letter so the sentence stays readable.
+func mask(word string) string {
+    runes := []rune(word)
+    if len(runes) <= 1 {
+        return "*"
+    }
+    return string(runes[0]) + strings.Repeat("*", len(runes)-1)
+}
+
+func ValidAction(action string) bool {
+    switch action {
+    case models.ActionAllow, models.ActionMask, models.ActionFlag, models.ActionBlock:
+        return true
+    }
+    return false
+}
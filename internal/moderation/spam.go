@@ -0,0 +1,155 @@
+package moderation
+
+import (
+    "encoding/json"
+    "fmt"
+    "strings"
+    "sync"
+    "time"
+
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+const (
+    defaultSpamRepeats = 3
+    defaultSpamWindow  = 30 * time.Second
+    maxSpamWindow      = 10 * time.Minute
+
+    // spamSweepEvery is how many messages pass between sweeps of senders
+    // with nothing left in their window.
+    spamSweepEvery = 1000
+)
+
+// SpamConfig is the spam filter's stored config. A message is spam when
+// the sender already sent the same text Repeats-1 times, in any room,
+// within WindowSeconds.
+type SpamConfig struct {
+    Repeats       int `json:"repeats"`
+    WindowSeconds int `json:"window_seconds"`
+}
+
+// SpamFilter catches repeated messages. It remembers each sender's recent
+// messages on this instance only, which is enough: a connection, and so
+// each flood, stays on one instance.
+type SpamFilter struct {
+    mu      sync.Mutex
+    action  string
+    repeats int
+    window  time.Duration
+    recent  map[string][]sentMessage
+    calls   int
+}
+
+type sentMessage struct {
+    text string
+    at   time.Time
+}
+
+func NewSpamFilter() *SpamFilter {
+    return &SpamFilter{
+        action:  models.ActionBlock,
+        repeats: defaultSpamRepeats,
+        window:  defaultSpamWindow,
+        recent:  make(map[string][]sentMessage),
+    }
+}
+
+func (f *SpamFilter) Name() string { return "spam" }
+
+func (f *SpamFilter) Validate(setting *models.ModerationFilter) error {
+    _, err := parseSpamConfig(setting)
+    return err
+}
+
+func (f *SpamFilter) Configure(setting *models.ModerationFilter) error {
+    cfg, err := parseSpamConfig(setting)
+    if err != nil {
+        return err
+    }
+
+    f.mu.Lock()
+    defer f.mu.Unlock()
+    f.action = models.ActionBlock
+    if setting != nil {
+        f.action = setting.Action
+    }
+    f.repeats = cfg.Repeats
+    f.window = time.Duration(cfg.WindowSeconds) * time.Second
+    return nil
+}
+
+func parseSpamConfig(setting *models.ModerationFilter) (*SpamConfig, error) {
+    cfg := &SpamConfig{
+        Repeats:       defaultSpamRepeats,
+        WindowSeconds: int(defaultSpamWindow.Seconds()),
+    }
+    if setting == nil {
+        return cfg, nil
+    }
+    if setting.Action == models.ActionMask {
+        return nil, fmt.Errorf("spam can only be flagged or blocked")
+    }
+    if len(setting.Config) > 0 {
+        if err := json.Unmarshal(setting.Config, cfg); err != nil {
+            return nil, fmt.Errorf("invalid spam config: %w", err)
+        }
+    }
+    if cfg.Repeats < 2 {
+        return nil, fmt.Errorf("repeats must be at least 2")
+    }
+    if cfg.WindowSeconds <= 0 || time.Duration(cfg.WindowSeconds)*time.Second > maxSpamWindow {
+        return nil, fmt.Errorf("window_seconds must be between 1 and %d", int(maxSpamWindow.Seconds()))
+    }
+    return cfg, nil
+}
+
+func (f *SpamFilter) Apply(in *Input) Verdict {
+    text := normalizeSpam(in.Content)
+    verdict := Verdict{Action: models.ActionAllow, Content: in.Content}
+    if text == "" {
+        return verdict
+    }
+
+    f.mu.Lock()
+    defer f.mu.Unlock()
+
+    cutoff := in.At.Add(-f.window)
+    kept := f.recent[in.UserID][:0]
+    repeats := 1
+    for _, sent := range f.recent[in.UserID] {
+        if sent.at.Before(cutoff) {
+            continue
+        }
+        kept = append(kept, sent)
+        if sent.text == text {
+            repeats++
+        }
+    }
+    f.recent[in.UserID] = append(kept, sentMessage{text: text, at: in.At})
+
+    f.calls++
+    if f.calls%spamSweepEvery == 0 {
+        f.sweep(cutoff)
+    }
+
+    if repeats >= f.repeats {
+        verdict.Action = f.action
+        verdict.Reason = fmt.Sprintf("sent %d times in %s", repeats, f.window)
+    }
+    return verdict
+}
+
+// sweep forgets senders whose newest message is older than cutoff.
+func (f *SpamFilter) sweep(cutoff time.Time) {
+    for user, sent := range f.recent {
+        if len(sent) == 0 || sent[len(sent)-1].at.Before(cutoff) {
+            delete(f.recent, user)
+        }
+    }
+}
+
+// normalizeSpam ignores case and spacing, so trivially varied copies
+// still count as repeats.
+func normalizeSpam(content string) string {
+    return strings.Join(strings.Fields(strings.ToLower(content)), " ")
+}
@@ -0,0 +1,162 @@
+package moderation
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "strings"
+    "unicode"
+    "unicode/utf8"
+
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/store"
+)
+
+const (
+    MinUsernameLength = 3
+    MaxUsernameLength = 20
+)
+
+var (
+    ErrUsernameFormat        = errors.New("usernames are 3 to 20 letters, digits or underscores and start with a letter")
+    ErrUsernameReserved      = errors.New("username is reserved")
+    ErrUsernameImpersonation = errors.New("username impersonates a team or staff")
+    ErrUsernameProfane       = errors.New("username is not allowed")
+)
+
+// reservedNames can never be taken, whatever digits or separators are
+// added to them.
+var reservedNames = map[string]bool{
+    "admin": true, "administrator": true, "moderator": true, "mod": true,
+    "staff": true, "support": true, "system": true, "root": true,
+    "official": true, "security": true, "help": true, "sportschat": true,
+    "everyone": true, "here": true, "null": true, "undefined": true,
+}
+
+// staffWords mark a name as speaking for the service or a club when
+// combined with anything else, e.g. "admin_jane" or "arsenalofficial".
+var staffWords = []string{"admin", "moderator", "staff", "official", "sportschat"}
+
+// leet undoes common digit and symbol swaps, so "4dm1n" reads as "admin".
+var leet = strings.NewReplacer(
+    "0", "o", "1", "i", "3", "e", "4", "a", "5", "s", "7", "t", "8", "b", "@", "a", "$", "s",
+)
+
+// UsernamePolicy decides which usernames may be taken: the format, the
+// reserved list, impersonation of teams and staff, and the profanity
+// wordlists, matched inside the name as well as on word boundaries.
+type UsernamePolicy struct {
+    store     store.Store
+    profanity *ProfanityFilter
+}
+
+func NewUsernamePolicy(store store.Store, profanity *ProfanityFilter) *UsernamePolicy {
+    return &UsernamePolicy{store: store, profanity: profanity}
+}
+
+// Check returns nil if the username may be taken, or the rule it breaks.
+func (p *UsernamePolicy) Check(ctx context.Context, username string) error {
+    if !validUsernameFormat(username) {
+        return ErrUsernameFormat
+    }
+
+    name := canonicalUsername(username)
+    if reservedNames[name] {
+        return ErrUsernameReserved
+    }
+    for _, word := range staffWords {
+        if strings.Contains(name, word) {
+            return ErrUsernameImpersonation
+        }
+    }
+
+    teams, err := p.teamNames(ctx)
+    if err != nil {
+        return err
+    }
+    if teams[name] {
+        return ErrUsernameImpersonation
+    }
+
+    if p.profanity.Check(DefaultLocale, username).Action != models.ActionAllow ||
+        p.profanity.embedded(name) >= models.SeverityModerate {
+        return ErrUsernameProfane
+    }
+    return nil
+}
+
+// teamNames returns the canonical name of every team.
+func (p *UsernamePolicy) teamNames(ctx context.Context) (map[string]bool, error) {
+    sports, err := p.store.ListSports(ctx)
+    if err != nil {
+        return nil, fmt.Errorf("failed to list sports: %w", err)
+    }
+
+    names := make(map[string]bool)
+    for _, sport := range sports {
+        teams, err := p.store.ListTeams(ctx, sport.ID)
+        if err != nil {
+            return nil, fmt.Errorf("failed to list teams: %w", err)
+        }
+        for _, team := range teams {
+            if name := canonicalUsername(team.Name); name != "" {
+                names[name] = true
+            }
+        }
+    }
+    return names, nil
+}
+
+func validUsernameFormat(username string) bool {
+    n := utf8.RuneCountInString(username)
+    if n < MinUsernameLength || n > MaxUsernameLength {
+        return false
+    }
+    for i, r := range username {
+        switch {
+        case i == 0 && !unicode.IsLetter(r):
+            return false
+        case !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_':
+            return false
+        }
+    }
+    return true
+}
+
+// canonicalUsername lowercases a name, undoes leetspeak and drops
+// everything but letters, so lookalikes of a name compare equal to it.
+// Trailing digits are dropped before undoing leetspeak, so "admin2"
+// still reads as "admin".
+func canonicalUsername(name string) string {
+    name = strings.TrimRightFunc(strings.ToLower(name), unicode.IsDigit)
+    name = leet.Replace(name)
+
+    var b strings.Builder
+    for _, r := range name {
+        if unicode.IsLetter(r) {
+            b.WriteRune(r)
+        }
+    }
+    return b.String()
+}
+
+// embedded returns the highest severity of any listed word appearing
+// anywhere in text, across every locale. Short words are only matched
+// whole, since they turn up inside innocent names.
+func (f *ProfanityFilter) embedded(text string) int {
+    f.mu.RLock()
+    defer f.mu.RUnlock()
+
+    highest := 0
+    for _, words := range f.words {
+        for word, severity := range words {
+            if severity <= highest {
+                continue
+            }
+            if text == word || (utf8.RuneCountInString(word) >= 4 && strings.Contains(text, word)) {
+                highest = severity
+            }
+        }
+    }
+    return highest
+}
@@ -0,0 +1,92 @@
+// Package objectstore keeps blobs too large or too cold for postgres,
+// such as incident bundles, in an S3-compatible bucket or, for local
+// development, a directory.
+package objectstore
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "os"
+    "path/filepath"
+    "strings"
+)
+
+// ErrNotFound is returned for keys with no object.
+var ErrNotFound = errors.New("object not found")
+
+// Bucket stores objects by key. Keys are slash-separated paths such as
+// "incidents/2024/05/01/<id>.json".
+type Bucket interface {
+    Put(ctx context.Context, key, contentType string, body []byte) error
+    Get(ctx context.Context, key string) ([]byte, error)
+    Delete(ctx context.Context, key string) error
+}
+
+// Dir is a Bucket on the local filesystem. It is meant for development
+// and single-instance deployments; content types are not kept.
+type Dir struct {
+    root string
+}
+
+func NewDir(root string) (*Dir, error) {
+    if err := os.MkdirAll(root, 0o755); err != nil {
+        return nil, fmt.Errorf("failed to create object directory: %w", err)
+    }
+    return &Dir{root: root}, nil
+}
+
+func (d *Dir) path(key string) (string, error) {
+    clean := filepath.Clean("/" + key)
+    if clean == "/" || strings.Contains(key, "..") {
+        return "", fmt.Errorf("invalid object key %q", key)
+    }
+    return filepath.Join(d.root, filepath.FromSlash(clean)), nil
+}
+
+// Put writes through a temporary file, so readers never see half an
+// object.
+func (d *Dir) Put(ctx context.Context, key, contentType string, body []byte) error {
+    path, err := d.path(key)
+    if err != nil {
+        return err
+    }
+    if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+        return fmt.Errorf("failed to store object: %w", err)
+    }
+    tmp := path + ".tmp"
+    if err := os.WriteFile(tmp, body, 0o644); err != nil {
+        return fmt.Errorf("failed to store object: %w", err)
+    }
+    if err := os.Rename(tmp, path); err != nil {
+        os.Remove(tmp)
+        return fmt.Errorf("failed to store object: %w", err)
+    }
+    return nil
+}
+
+func (d *Dir) Get(ctx context.Context, key string) ([]byte, error) {
+    path, err := d.path(key)
+    if err != nil {
+        return nil, err
+    }
+    body, err := os.ReadFile(path)
+    if os.IsNotExist(err) {
+        return nil, ErrNotFound
+    }
+    if err != nil {
+        return nil, fmt.Errorf("failed to read object: %w", err)
+    }
+    return body, nil
+}
+
+func (d *Dir) Delete(ctx context.Context, key string) error {
+    path, err := d.path(key)
+    if err != nil {
+        return err
+    }
+    if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+        return fmt.Errorf("failed to delete object: %w", err)
+    }
+    return nil
+}
@@ -0,0 +1,168 @@
+package objectstore
+
+import (
+    "bytes"
+    "context"
+    "crypto/hmac"
+    "crypto/sha256"
+    "encoding/hex"
+    "fmt"
+    "io"
+    "net/http"
+    "net/url"
+    "strings"
+    "time"
+)
+
+// S3Config locates a bucket on S3 or a compatible service such as MinIO.
+// Objects are addressed path-style, endpoint/bucket/key, which every
+// compatible service supports.
+type S3Config struct {
+    Endpoint        string
+    Region          string
+    Bucket          string
+    AccessKeyID     string
+    SecretAccessKey string
+}
+
+// S3 is a Bucket on S3, signing requests with Signature Version 4.
+type S3 struct {
+    cfg      S3Config
+    endpoint *url.URL
+    http     *http.Client
+}
+
+func NewS3(cfg S3Config) (*S3, error) {
+    endpoint, err := url.Parse(strings.TrimRight(cfg.Endpoint, "/"))
+    if err != nil || endpoint.Host == "" {
+        return nil, fmt.Errorf("invalid s3 endpoint %q", cfg.Endpoint)
+    }
+    if cfg.Bucket == "" {
+        return nil, fmt.Errorf("s3 bucket is required")
+    }
+    if cfg.Region == "" {
+        cfg.Region = "us-east-1"
+    }
+    return &S3{cfg: cfg, endpoint: endpoint, http: &http.Client{Timeout: 30 * time.Second}}, nil
+}
+
+func (s *S3) Put(ctx context.Context, key, contentType string, body []byte) error {
+    header := http.Header{}
+    if contentType != "" {
+        header.Set("Content-Type", contentType)
+    }
+    resp, err := s.do(ctx, http.MethodPut, key, header, body)
+    if err != nil {
+        return fmt.Errorf("failed to store object: %w", err)
+    }
+    resp.Body.Close()
+    return nil
+}
+
+func (s *S3) Get(ctx context.Context, key string) ([]byte, error) {
+    resp, err := s.do(ctx, http.MethodGet, key, nil, nil)
+    if err != nil {
+        return nil, err
+    }
+    defer resp.Body.Close()
+    body, err := io.ReadAll(resp.Body)
+    if err != nil {
+        return nil, fmt.Errorf("failed to read object: %w", err)
+    }
+    return body, nil
+}
+
+func (s *S3) Delete(ctx context.Context, key string) error {
+    resp, err := s.do(ctx, http.MethodDelete, key, nil, nil)
+    if err == ErrNotFound {
+        return nil
+    }
+    if err != nil {
+        return fmt.Errorf("failed to delete object: %w", err)
+    }
+    resp.Body.Close()
+    return nil
+}
+
+// do sends a signed request, returning ErrNotFound for a 404 and an
+// error carrying the body for any other failure.
+func (s *S3) do(ctx context.Context, method, key string, header http.Header, body []byte) (*http.Response, error) {
+    u := *s.endpoint
+    u.Path = "/" + s.cfg.Bucket + "/" + strings.TrimLeft(key, "/")
+    req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
+    if err != nil {
+        return nil, err
+    }
+    for name, values := range header {
+        req.Header[name] = values
+    }
+    s.sign(req, body, time.Now().UTC())
+
+    resp, err := s.http.Do(req)
+    if err != nil {
+        return nil, err
+    }
+    if resp.StatusCode == http.StatusNotFound {
+        resp.Body.Close()
+        return nil, ErrNotFound
+    }
+    if resp.StatusCode >= 300 {
+        detail, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+        resp.Body.Close()
+        return nil, fmt.Errorf("s3: status %d: %s", resp.StatusCode, detail)
+    }
+    return resp, nil
+}
+
+// sign adds a Signature Version 4 Authorization header covering the
+// host, the payload hash, the date and the content type.
+func (s *S3) sign(req *http.Request, body []byte, now time.Time) {
+    payloadHash := sha256Hex(body)
+    amzDate := now.Format("20060102T150405Z")
+    day := now.Format("20060102")
+
+    req.Header.Set("X-Amz-Date", amzDate)
+    req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+    signed := []string{"host", "x-amz-content-sha256", "x-amz-date"}
+    canonicalHeaders := "host:" + req.URL.Host + "\n" +
+        "x-amz-content-sha256:" + payloadHash + "\n" +
+        "x-amz-date:" + amzDate + "\n"
+    if ct := req.Header.Get("Content-Type"); ct != "" {
+        signed = []string{"content-type", "host", "x-amz-content-sha256", "x-amz-date"}
+        canonicalHeaders = "content-type:" + ct + "\n" + canonicalHeaders
+    }
+    signedHeaders := strings.Join(signed, ";")
+
+    canonicalRequest := strings.Join([]string{
+        req.Method,
+        req.URL.EscapedPath(),
+        req.URL.RawQuery,
+        canonicalHeaders,
+        signedHeaders,
+        payloadHash,
+    }, "\n")
+
+    scope := day + "/" + s.cfg.Region + "/s3/aws4_request"
+    stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + sha256Hex([]byte(canonicalRequest))
+
+    key := hmacSHA256([]byte("AWS4"+s.cfg.SecretAccessKey), day)
+    key = hmacSHA256(key, s.cfg.Region)
+    key = hmacSHA256(key, "s3")
+    key = hmacSHA256(key, "aws4_request")
+    signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+    req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+        s.cfg.AccessKeyID, scope, signedHeaders, signature))
+}
+
+func sha256Hex(data []byte) string {
+    sum := sha256.Sum256(data)
+    return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+    mac := hmac.New(sha256.New, key)
+    mac.Write([]byte(data))
+    return mac.Sum(nil)
+}
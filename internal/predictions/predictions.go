@@ -0,0 +1,424 @@
+package predictions
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "strings"
+    "sync"
+    "time"
+    "unicode/utf8"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/events"
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/store"
+)
+
+const (
+    // Points for an exact score and for only the right outcome
+    exactScorePoints = 3
+    outcomePoints    = 1
+
+    maxScore          = 99
+    maxQuestionLength = 200
+    maxOptionLength   = 100
+    minPollOptions    = 2
+    maxPollOptions    = 6
+
+    // tallyInterval is how often rooms are sent the tallies of polls
+    // that got votes, so a busy poll costs one frame a second
+    tallyInterval = time.Second
+)
+
+var (
+    ErrNoMatch          = errors.New("match not found")
+    ErrNoPoll           = errors.New("poll not found")
+    ErrMatchStarted     = errors.New("predictions close at kickoff")
+    ErrInvalidScore     = errors.New("scores must be between 0 and 99")
+    ErrInvalidPoll      = errors.New("a poll needs a question and 2 to 6 distinct options")
+    ErrPollClosed       = errors.New("poll has closed")
+    ErrInvalidOption    = errors.New("no such poll option")
+    ErrNoMatchRoom      = errors.New("match has no room")
+    ErrMatchNotUpcoming = errors.New("polls can only be added to upcoming or live matches")
+)
+
+// Announcer sends a frame to every client in a room, on every instance.
+// The websocket hub implements it.
+type Announcer interface {
+    Announce(room string, message *models.WSMessage)
+}
+
+// pollFrame is the data of a poll frame.
+type pollFrame struct {
+    Status string            `json:"status"` // "open", "tally" or "closed"
+    Poll   *models.Poll      `json:"poll,omitempty"`
+    Tally  *models.PollTally `json:"tally,omitempty"`
+}
+
+// Service takes score predictions and poll votes, streams poll tallies to
+// match rooms and scores predictions when matches finish.
+type Service struct {
+    store     store.Store
+    announcer Announcer
+    bus       *events.Bus
+    logger    *zap.Logger
+    finished  chan events.MatchFinished
+
+    // Polls voted on since the last tally frame, by room
+    dirtyMu sync.Mutex
+    dirty   map[string]string
+}
+
+func NewService(store store.Store, announcer Announcer, bus *events.Bus, logger *zap.Logger) *Service {
+    return &Service{
+        store:     store,
+        announcer: announcer,
+        bus:       bus,
+        logger:    logger,
+        finished:  make(chan events.MatchFinished, 64),
+        dirty:     make(map[string]string),
+    }
+}
+
+// Start subscribes the service to finished matches and starts its
+// workers.
+func (s *Service) Start() {
+    s.bus.Subscribe(events.TypeMatchFinished, func(event events.Event) {
+        select {
+        case s.finished <- event.(events.MatchFinished):
+        default:
+            s.logger.Warn("Prediction queue full, dropping event")
+        }
+    })
+
+    go s.run()
+    go s.tallyLoop()
+}
+
+func (s *Service) run() {
+    for finished := range s.finished {
+        s.settle(finished.MatchID)
+    }
+}
+
+// Predict records a user's score prediction, replacing any earlier one
+// until kickoff.
+func (s *Service) Predict(ctx context.Context, matchID, userID string, homeScore, awayScore int) (*models.Prediction, error) {
+    if homeScore < 0 || awayScore < 0 || homeScore > maxScore || awayScore > maxScore {
+        return nil, ErrInvalidScore
+    }
+
+    match, err := s.store.GetMatch(ctx, matchID)
+    if err != nil {
+        return nil, ErrNoMatch
+    }
+    now := time.Now()
+    if match.Status != models.MatchStatusScheduled || !now.Before(match.StartTime) {
+        return nil, ErrMatchStarted
+    }
+
+    prediction := &models.Prediction{
+        MatchID:   matchID,
+        UserID:    userID,
+        HomeScore: homeScore,
+        AwayScore: awayScore,
+        CreatedAt: now,
+        UpdatedAt: now,
+    }
+    if err := s.store.UpsertPrediction(ctx, prediction); err != nil {
+        return nil, fmt.Errorf("failed to store prediction: %w", err)
+    }
+    s.bus.Publish(events.PredictionMade{UserID: userID, MatchID: matchID, At: now})
+    return prediction, nil
+}
+
+// CreatePoll adds a poll to a match's room and announces it.
+func (s *Service) CreatePoll(ctx context.Context, matchID, question string, options []string, createdBy string) (*models.Poll, error) {
+    question = strings.TrimSpace(question)
+    options, ok := validOptions(options)
+    if !ok || question == "" || utf8.RuneCountInString(question) > maxQuestionLength {
+        return nil, ErrInvalidPoll
+    }
+
+    match, err := s.store.GetMatch(ctx, matchID)
+    if err != nil {
+        return nil, ErrNoMatch
+    }
+    if match.Status != models.MatchStatusScheduled && match.Status != models.MatchStatusLive {
+        return nil, ErrMatchNotUpcoming
+    }
+    room, err := s.store.GetMatchChatRoom(ctx, matchID)
+    if err != nil {
+        return nil, ErrNoMatchRoom
+    }
+
+    poll := &models.Poll{
+        MatchID:   matchID,
+        RoomID:    room.ID,
+        Question:  question,
+        Options:   options,
+        CreatedBy: createdBy,
+        CreatedAt: time.Now(),
+    }
+    if err := s.store.CreatePoll(ctx, poll); err != nil {
+        return nil, fmt.Errorf("failed to create poll: %w", err)
+    }
+    poll.Tally = &models.PollTally{PollID: poll.ID, Counts: make([]int, len(options))}
+
+    s.announce(poll.RoomID, &pollFrame{Status: "open", Poll: poll})
+    return poll, nil
+}
+
+// Vote records a user's choice in an open poll, replacing any earlier
+// one. The room sees the new tally on the next tally frame.
+func (s *Service) Vote(ctx context.Context, pollID, userID string, option int) error {
+    poll, err := s.store.GetPoll(ctx, pollID)
+    if err != nil {
+        return ErrNoPoll
+    }
+    if poll.ClosedAt != nil {
+        return ErrPollClosed
+    }
+    if option < 0 || option >= len(poll.Options) {
+        return ErrInvalidOption
+    }
+
+    if err := s.store.CastPollVote(ctx, &models.PollVote{
+        PollID:    pollID,
+        UserID:    userID,
+        Option:    option,
+        CreatedAt: time.Now(),
+    }); err != nil {
+        return fmt.Errorf("failed to store poll vote: %w", err)
+    }
+
+    s.dirtyMu.Lock()
+    s.dirty[pollID] = poll.RoomID
+    s.dirtyMu.Unlock()
+    return nil
+}
+
+// ClosePoll closes a poll and announces its final tally. Closing a
+// closed poll is not an error.
+func (s *Service) ClosePoll(ctx context.Context, pollID string) error {
+    poll, err := s.store.GetPoll(ctx, pollID)
+    if err != nil {
+        return ErrNoPoll
+    }
+    return s.closePoll(ctx, poll)
+}
+
+func (s *Service) closePoll(ctx context.Context, poll *models.Poll) error {
+    closed, err := s.store.ClosePoll(ctx, poll.ID, time.Now())
+    if err != nil {
+        return fmt.Errorf("failed to close poll: %w", err)
+    }
+    if !closed {
+        return nil
+    }
+
+    s.dirtyMu.Lock()
+    delete(s.dirty, poll.ID)
+    s.dirtyMu.Unlock()
+
+    tally, err := s.store.GetPollTally(ctx, poll.ID)
+    if err != nil {
+        return fmt.Errorf("failed to get poll tally: %w", err)
+    }
+    s.announce(poll.RoomID, &pollFrame{Status: "closed", Tally: tally})
+    return nil
+}
+
+// MatchPolls returns a match's polls with their current tallies.
+func (s *Service) MatchPolls(ctx context.Context, matchID string) ([]*models.Poll, error) {
+    polls, err := s.store.GetMatchPolls(ctx, matchID)
+    if err != nil {
+        return nil, fmt.Errorf("failed to get polls: %w", err)
+    }
+    for _, poll := range polls {
+        tally, err := s.store.GetPollTally(ctx, poll.ID)
+        if err != nil {
+            return nil, fmt.Errorf("failed to get poll tally: %w", err)
+        }
+        poll.Tally = tally
+    }
+    return polls, nil
+}
+
+func (s *Service) tallyLoop() {
+    ticker := time.NewTicker(tallyInterval)
+    defer ticker.Stop()
+
+    for range ticker.C {
+        s.flushTallies()
+    }
+}
+
+func (s *Service) flushTallies() {
+    s.dirtyMu.Lock()
+    dirty := s.dirty
+    s.dirty = make(map[string]string)
+    s.dirtyMu.Unlock()
+    if len(dirty) == 0 {
+        return
+    }
+
+    ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+    defer cancel()
+
+    for pollID, room := range dirty {
+        tally, err := s.store.GetPollTally(ctx, pollID)
+        if err != nil {
+            s.logger.Warn("Failed to get poll tally", zap.Error(err), zap.String("poll_id", pollID))
+            continue
+        }
+        s.announce(room, &pollFrame{Status: "tally", Tally: tally})
+    }
+}
+
+// settle scores a finished match's predictions and closes its polls.
+// Every instance settles the same match; the store scores each
+// prediction once, and only the instance that scored it publishes.
+func (s *Service) settle(matchID string) {
+    ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+    defer cancel()
+
+    match, err := s.store.GetMatch(ctx, matchID)
+    if err != nil {
+        s.logger.Error("Failed to get finished match", zap.Error(err), zap.String("match_id", matchID))
+        return
+    }
+
+    scored, err := s.score(ctx, match, false)
+    if err != nil {
+        s.logger.Error("Failed to score predictions", zap.Error(err), zap.String("match_id", matchID))
+    }
+    for _, prediction := range scored {
+        s.publishScored(ctx, prediction)
+    }
+
+    polls, err := s.store.GetMatchPolls(ctx, matchID)
+    if err != nil {
+        s.logger.Error("Failed to get match polls", zap.Error(err), zap.String("match_id", matchID))
+        return
+    }
+    for _, poll := range polls {
+        if poll.ClosedAt != nil {
+            continue
+        }
+        if err := s.closePoll(ctx, poll); err != nil {
+            s.logger.Error("Failed to close poll", zap.Error(err), zap.String("poll_id", poll.ID))
+        }
+    }
+}
+
+// ResettleMatch rescores a match's predictions after its result was
+// corrected. Achievements already unlocked are left alone.
+func (s *Service) ResettleMatch(ctx context.Context, match *models.Match) error {
+    _, err := s.score(ctx, match, true)
+    return err
+}
+
+// score applies a match's result to its predictions, returning those
+// this call scored.
+func (s *Service) score(ctx context.Context, match *models.Match, rescore bool) ([]*models.Prediction, error) {
+    if match.Status != models.MatchStatusFinished {
+        return nil, nil
+    }
+
+    predictions, err := s.store.GetMatchPredictions(ctx, match.ID)
+    if err != nil {
+        return nil, fmt.Errorf("failed to get predictions: %w", err)
+    }
+
+    now := time.Now()
+    var scored []*models.Prediction
+    for _, prediction := range predictions {
+        if prediction.ScoredAt != nil && !rescore {
+            continue
+        }
+        prediction.Correct, prediction.Points = Score(prediction, match.HomeScore, match.AwayScore)
+        prediction.ScoredAt = &now
+
+        ok, err := s.store.ScorePrediction(ctx, prediction, rescore)
+        if err != nil {
+            return scored, fmt.Errorf("failed to score prediction: %w", err)
+        }
+        if ok {
+            scored = append(scored, prediction)
+        }
+    }
+    return scored, nil
+}
+
+func (s *Service) publishScored(ctx context.Context, prediction *models.Prediction) {
+    correct, total, err := s.store.GetUserPredictionStats(ctx, prediction.UserID, time.Now().AddDate(0, 0, -7))
+    if err != nil {
+        s.logger.Warn("Failed to get prediction stats", zap.Error(err), zap.String("user_id", prediction.UserID))
+        return
+    }
+    s.bus.Publish(events.PredictionScored{
+        UserID:      prediction.UserID,
+        MatchID:     prediction.MatchID,
+        Correct:     prediction.Correct,
+        Points:      prediction.Points,
+        WeekCorrect: correct,
+        WeekTotal:   total,
+    })
+}
+
+// Score rates a prediction against the final score. A prediction is
+// correct when it called the outcome; the exact score earns more.
+func Score(prediction *models.Prediction, homeScore, awayScore int) (bool, int) {
+    if prediction.HomeScore == homeScore && prediction.AwayScore == awayScore {
+        return true, exactScorePoints
+    }
+    if outcome(prediction.HomeScore, prediction.AwayScore) == outcome(homeScore, awayScore) {
+        return true, outcomePoints
+    }
+    return false, 0
+}
+
+func outcome(home, away int) int {
+    switch {
+    case home > away:
+        return 1
+    case home < away:
+        return -1
+    }
+    return 0
+}
+
+func validOptions(options []string) ([]string, bool) {
+    if len(options) < minPollOptions || len(options) > maxPollOptions {
+        return nil, false
+    }
+    seen := make(map[string]bool, len(options))
+    trimmed := make([]string, 0, len(options))
+    for _, option := range options {
+        option = strings.TrimSpace(option)
+        key := strings.ToLower(option)
+        if option == "" || utf8.RuneCountInString(option) > maxOptionLength || seen[key] {
+            return nil, false
+        }
+        seen[key] = true
+        trimmed = append(trimmed, option)
+    }
+    return trimmed, true
+}
+
+func (s *Service) announce(room string, frame *pollFrame) {
+    data, err := json.Marshal(frame)
+    if err != nil {
+        return
+    }
+    s.announcer.Announce(room, &models.WSMessage{
+        Type:      models.MessageTypePoll,
+        ChatRoom:  room,
+        Data:      data,
+        Timestamp: time.Now(),
+    })
+}
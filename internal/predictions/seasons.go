@@ -0,0 +1,144 @@
+package predictions
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/store"
+)
+
+// badgeStandings is how far down an archived table badges are looked for;
+// ties can put more than ten users in the top ten ranks.
+const badgeStandings = 100
+
+// seasonBadges describes each badge to the users who earn it.
+var seasonBadges = map[string]models.Achievement{
+    models.SeasonBadgeChampion: {
+        Code:        models.SeasonBadgeChampion,
+        Name:        "Season Champion",
+        Description: "Topped the season's prediction table",
+    },
+    models.SeasonBadgePodium: {
+        Code:        models.SeasonBadgePodium,
+        Name:        "Season Podium",
+        Description: "Finished the season in the prediction top three",
+    },
+    models.SeasonBadgeTopTen: {
+        Code:        models.SeasonBadgeTopTen,
+        Name:        "Season Top Ten",
+        Description: "Finished the season in the prediction top ten",
+    },
+}
+
+// Notifier sends a frame to every connection of a user, on every
+// instance. The websocket hub implements it.
+type Notifier interface {
+    NotifyUser(userID string, message *models.WSMessage)
+}
+
+// SeasonJob archives seasons once they end: it freezes their standings,
+// tells badge winners and opens the next season, of the same length, when
+// none was set up, so points reset without an admin.
+type SeasonJob struct {
+    store    store.Store
+    notifier Notifier
+    logger   *zap.Logger
+}
+
+func NewSeasonJob(store store.Store, notifier Notifier, logger *zap.Logger) *SeasonJob {
+    return &SeasonJob{store: store, notifier: notifier, logger: logger}
+}
+
+func (j *SeasonJob) Name() string { return "predictions.seasons" }
+
+func (j *SeasonJob) Run(ctx context.Context) error {
+    seasons, err := j.store.GetDueSeasons(ctx, time.Now())
+    if err != nil {
+        return fmt.Errorf("failed to get due seasons: %w", err)
+    }
+
+    for _, season := range seasons {
+        // Instances race to archive; only the winner notifies and rolls over
+        archived, err := j.store.ArchiveSeason(ctx, season.ID, time.Now())
+        if err != nil {
+            j.logger.Error("Failed to archive season", zap.Error(err), zap.String("season_id", season.ID))
+            continue
+        }
+        if !archived {
+            continue
+        }
+        now := time.Now()
+        season.ArchivedAt = &now
+
+        j.awardBadges(ctx, season)
+        if err := j.rollOver(ctx, season); err != nil {
+            j.logger.Error("Failed to open next season", zap.Error(err), zap.String("season_id", season.ID))
+        }
+    }
+    return nil
+}
+
+func (j *SeasonJob) awardBadges(ctx context.Context, season *models.Season) {
+    standings, err := j.store.GetSeasonStandings(ctx, season, badgeStandings)
+    if err != nil {
+        j.logger.Error("Failed to get season standings", zap.Error(err), zap.String("season_id", season.ID))
+        return
+    }
+
+    awarded := 0
+    for _, standing := range standings {
+        badge, ok := seasonBadges[standing.Badge]
+        if !ok {
+            continue
+        }
+        badge.Description += " in " + season.Name
+        data, err := json.Marshal(badge)
+        if err != nil {
+            continue
+        }
+        j.notifier.NotifyUser(standing.UserID, &models.WSMessage{
+            Type:      models.MessageTypeAchievement,
+            Data:      data,
+            Timestamp: *season.ArchivedAt,
+        })
+        awarded++
+    }
+
+    j.logger.Info("Archived season",
+        zap.String("season_id", season.ID),
+        zap.String("name", season.Name),
+        zap.Int("badges", awarded))
+}
+
+// rollOver opens the season after this one unless one already covers its
+// end.
+func (j *SeasonJob) rollOver(ctx context.Context, season *models.Season) error {
+    if _, err := j.store.GetSeasonAt(ctx, season.CompetitionID, season.EndsAt); err == nil {
+        return nil
+    }
+
+    length := season.EndsAt.Sub(season.StartsAt)
+    next := &models.Season{
+        CompetitionID: season.CompetitionID,
+        StartsAt:      season.EndsAt,
+        EndsAt:        season.EndsAt.Add(length),
+    }
+    next.Name = SeasonName(next.StartsAt, next.EndsAt)
+    return j.store.CreateSeason(ctx, next)
+}
+
+// SeasonName names a season by its years, "2025" or "2025/26".
+func SeasonName(start, end time.Time) string {
+    // The end is exclusive, so a season ending at midnight on New Year's
+    // Day belongs to the year before
+    last := end.Add(-time.Nanosecond)
+    if last.Year() == start.Year() {
+        return start.Format("2006")
+    }
+    return start.Format("2006") + "/" + last.Format("06")
+}
@@ -0,0 +1,59 @@
+// Package presence tracks which users are connected to which rooms across
+// server instances.
+package presence
+
+import (
+    "context"
+    "sync"
+)
+
+// Tracker aggregates room membership reported by every instance.
+type Tracker interface {
+    // Report replaces this instance's members of every room it serves.
+    // Rooms left out have no members here any more. Calls must not
+    // overlap.
+    Report(ctx context.Context, rooms map[string][]string) error
+    // Members returns the distinct users in a room on any instance.
+    Members(ctx context.Context, room string) ([]string, error)
+    // Counts returns the number of distinct users in each room.
+    Counts(ctx context.Context, rooms []string) (map[string]int, error)
+    Close() error
+}
+
+// Local is the single-instance tracker: it only remembers the last report.
+type Local struct {
+    mu    sync.RWMutex
+    rooms map[string][]string
+}
+
+func NewLocal() *Local {
+    return &Local{rooms: make(map[string][]string)}
+}
+
+func (l *Local) Report(ctx context.Context, rooms map[string][]string) error {
+    l.mu.Lock()
+    l.rooms = rooms
+    l.mu.Unlock()
+    return nil
+}
+
+func (l *Local) Members(ctx context.Context, room string) ([]string, error) {
+    l.mu.RLock()
+    defer l.mu.RUnlock()
+    return l.rooms[room], nil
+}
+
+func (l *Local) Counts(ctx context.Context, rooms []string) (map[string]int, error) {
+    l.mu.RLock()
+    defer l.mu.RUnlock()
+
+    counts := make(map[string]int, len(rooms))
+    for _, room := range rooms {
+        counts[room] = len(l.rooms[room])
+    }
+    return counts, nil
+}
+
+func (l *Local) Close() error {
+    return nil
+}
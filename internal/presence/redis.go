@@ -0,0 +1,141 @@
+package presence
+
+import (
+    "context"
+    "fmt"
+    "strconv"
+    "strings"
+    "time"
+
+    "github.com/google/uuid"
+    "github.com/redis/go-redis/v9"
+)
+
+// Redis keeps one sorted set per room whose members are
+// "<instance>|<user>" scored by the time they were last reported. Entries
+// an instance stops reporting, including those of a crashed instance, age
+// out after the TTL.
+type Redis struct {
+    client   *redis.Client
+    prefix   string
+    instance string
+    ttl      time.Duration
+
+    // Users reported per room last time, so departures can be removed
+    reported map[string]map[string]bool
+}
+
+func NewRedis(url, prefix string, ttl time.Duration) (*Redis, error) {
+    opts, err := redis.ParseURL(url)
+    if err != nil {
+        return nil, fmt.Errorf("failed to parse redis url: %w", err)
+    }
+
+    client := redis.NewClient(opts)
+    if err := client.Ping(context.Background()).Err(); err != nil {
+        client.Close()
+        return nil, fmt.Errorf("failed to connect to redis: %w", err)
+    }
+
+    return &Redis{
+        client:   client,
+        prefix:   prefix + "presence:",
+        instance: uuid.NewString(),
+        ttl:      ttl,
+        reported: make(map[string]map[string]bool),
+    }, nil
+}
+
+func (r *Redis) Report(ctx context.Context, rooms map[string][]string) error {
+    now := time.Now()
+    expired := "(" + strconv.FormatInt(now.Add(-r.ttl).Unix(), 10)
+    pipe := r.client.Pipeline()
+
+    current := make(map[string]map[string]bool, len(rooms))
+    for room, users := range rooms {
+        key := r.prefix + room
+        current[room] = make(map[string]bool, len(users))
+
+        members := make([]redis.Z, 0, len(users))
+        for _, user := range users {
+            current[room][user] = true
+            members = append(members, redis.Z{Score: float64(now.Unix()), Member: r.member(user)})
+        }
+        if len(members) > 0 {
+            pipe.ZAdd(ctx, key, members...)
+        }
+        pipe.ZRemRangeByScore(ctx, key, "-inf", expired)
+        pipe.Expire(ctx, key, r.ttl)
+    }
+
+    // Users who left since the last report, and rooms emptied here
+    for room, users := range r.reported {
+        var gone []interface{}
+        for user := range users {
+            if !current[room][user] {
+                gone = append(gone, r.member(user))
+            }
+        }
+        if len(gone) > 0 {
+            pipe.ZRem(ctx, r.prefix+room, gone...)
+        }
+    }
+
+    if _, err := pipe.Exec(ctx); err != nil {
+        return fmt.Errorf("failed to report presence: %w", err)
+    }
+    r.reported = current
+    return nil
+}
+
+func (r *Redis) member(user string) string {
+    return r.instance + "|" + user
+}
+
+func (r *Redis) Members(ctx context.Context, room string) ([]string, error) {
+    cutoff := strconv.FormatInt(time.Now().Add(-r.ttl).Unix(), 10)
+    entries, err := r.client.ZRangeByScore(ctx, r.prefix+room, &redis.ZRangeBy{Min: cutoff, Max: "+inf"}).Result()
+    if err != nil {
+        return nil, fmt.Errorf("failed to read presence: %w", err)
+    }
+    return distinctUsers(entries), nil
+}
+
+// Counts reads every room in one pipeline.
+func (r *Redis) Counts(ctx context.Context, rooms []string) (map[string]int, error) {
+    cutoff := strconv.FormatInt(time.Now().Add(-r.ttl).Unix(), 10)
+    pipe := r.client.Pipeline()
+    cmds := make(map[string]*redis.StringSliceCmd, len(rooms))
+    for _, room := range rooms {
+        cmds[room] = pipe.ZRangeByScore(ctx, r.prefix+room, &redis.ZRangeBy{Min: cutoff, Max: "+inf"})
+    }
+    if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+        return nil, fmt.Errorf("failed to read presence: %w", err)
+    }
+
+    counts := make(map[string]int, len(rooms))
+    for room, cmd := range cmds {
+        counts[room] = len(distinctUsers(cmd.Val()))
+    }
+    return counts, nil
+}
+
+// distinctUsers extracts the users of presence entries. A user connected
+// to several instances appears once per instance.
+func distinctUsers(entries []string) []string {
+    seen := make(map[string]bool, len(entries))
+    users := make([]string, 0, len(entries))
+    for _, entry := range entries {
+        _, user, ok := strings.Cut(entry, "|")
+        if !ok || seen[user] {
+            continue
+        }
+        seen[user] = true
+        users = append(users, user)
+    }
+    return users
+}
+
+func (r *Redis) Close() error {
+    return r.client.Close()
+}
@@ -0,0 +1,88 @@
+// Package prewarm readies instances for kickoffs expected to be busy. The
+// first minute of a big match brings most of its crowd at once, each
+// connection loading the room's history, the match and its emotes; a
+// room readied beforehand answers them from memory instead of sending
+// them all to the store together.
+package prewarm
+
+import (
+    "context"
+    "fmt"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/store"
+)
+
+// upcomingLimit bounds how many scheduled matches one run looks at; only
+// those kicking off within the lead are warmed.
+const upcomingLimit = 200
+
+// Warmer readies a room for its match's kickoff. The websocket hub
+// implements it.
+type Warmer interface {
+    Prewarm(ctx context.Context, match *models.Match, room string, followers int) error
+}
+
+// Job warms the rooms of matches kicking off within the lead whose
+// followers, fans of either team and members of its rooms, reach the
+// threshold. Every instance runs it, warming its own hub, and each run
+// refreshes what the last one loaded.
+type Job struct {
+    store        store.Store
+    warmer       Warmer
+    lead         time.Duration
+    minFollowers int
+    logger       *zap.Logger
+}
+
+func NewJob(store store.Store, warmer Warmer, lead time.Duration, minFollowers int, logger *zap.Logger) *Job {
+    return &Job{
+        store:        store,
+        warmer:       warmer,
+        lead:         lead,
+        minFollowers: minFollowers,
+        logger:       logger,
+    }
+}
+
+func (j *Job) Name() string { return "rooms.prewarm" }
+
+func (j *Job) Run(ctx context.Context) error {
+    upcoming, err := j.store.GetUpcomingMatches(ctx, upcomingLimit)
+    if err != nil {
+        return fmt.Errorf("failed to get upcoming matches: %w", err)
+    }
+
+    cutoff := time.Now().Add(j.lead)
+    for _, match := range upcoming {
+        // Upcoming matches come soonest first
+        if match.StartTime.After(cutoff) {
+            break
+        }
+
+        followers, err := j.store.CountMatchFollowers(ctx, match.ID)
+        if err != nil {
+            j.logger.Warn("Failed to count match followers", zap.Error(err), zap.String("match_id", match.ID))
+            continue
+        }
+        if followers < j.minFollowers {
+            continue
+        }
+
+        room, err := j.store.GetMatchChatRoom(ctx, match.ID)
+        if err != nil {
+            // The lifecycle job has yet to create it
+            continue
+        }
+        if err := j.warmer.Prewarm(ctx, match, room.ID, followers); err != nil {
+            j.logger.Error("Failed to pre-warm room",
+                zap.Error(err),
+                zap.String("room_id", room.ID),
+                zap.String("match_id", match.ID))
+        }
+    }
+    return nil
+}
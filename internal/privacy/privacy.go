@@ -0,0 +1,558 @@
+// Package privacy serves users' rights over their data. An export bundles
+// what the service holds about a user, their profile, messages and room
+// memberships, into an archive built in the background and kept in object
+// storage for them to download. Erasure either anonymizes the account,
+// keeping its messages under a placeholder name, or deletes it with
+// everything it posted.
+//
+// Erasure reaches the copies outside postgres too. The per-room archives
+// the retention job moved old messages to are rewritten without the
+// user's messages, or with them under the placeholder name, and a search
+// index drops the messages of a deleted account. Exports leave archived
+// messages out.
+package privacy
+
+import (
+    "archive/zip"
+    "bytes"
+    "context"
+    "encoding/csv"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "strconv"
+    "strings"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/avatars"
+    "github.com/yourusername/sports-chat/internal/jobs"
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/objectstore"
+    "github.com/yourusername/sports-chat/internal/retention"
+    "github.com/yourusername/sports-chat/internal/store"
+)
+
+const (
+    exportJobName = "privacy.export"
+    // exportPage is how many messages an export reads at a time.
+    exportPage = 1000
+    // maxExportMessages bounds an export's messages, so its archive can
+    // be built in memory.
+    maxExportMessages = 100000
+    // staleExport is how long a pending export is waited on before
+    // another request starts over, as one whose job gave up stays pending.
+    staleExport = time.Hour
+    // maxDailyExports bounds the exports a user starts a day.
+    maxDailyExports = 5
+    // expiredBatch is how many expired exports a purge run removes.
+    expiredBatch = 500
+    // archivePage is how many message archives an erasure reads at a
+    // time.
+    archivePage = 100
+)
+
+// Erasure modes
+const (
+    EraseAnonymize = "anonymize"
+    EraseDelete    = "delete"
+)
+
+var (
+    ErrDisabled       = errors.New("data export is disabled")
+    ErrTooManyExports = errors.New("too many exports today")
+)
+
+// Disconnector closes a user's connections on every instance. The
+// websocket hub implements it.
+type Disconnector interface {
+    DisconnectUser(userID string)
+}
+
+// SearchIndex removes a user's messages from a search index. The
+// OpenSearch store implements it.
+type SearchIndex interface {
+    DeleteUserMessages(ctx context.Context, userID string) error
+}
+
+type Service struct {
+    store   store.Store
+    bucket  objectstore.Bucket
+    queue   *jobs.Queue
+    hub     Disconnector
+    avatars *avatars.Service
+    search  SearchIndex
+    ttl     time.Duration
+    logger  *zap.Logger
+}
+
+// NewService creates the service. A nil bucket disables exports but not
+// erasure; avatars may be nil when none are stored, and search when
+// messages are not indexed. Exports are kept for ttl once ready.
+func NewService(store store.Store, bucket objectstore.Bucket, queue *jobs.Queue, hub Disconnector, avatars *avatars.Service, search SearchIndex, ttl time.Duration, logger *zap.Logger) *Service {
+    s := &Service{
+        store:   store,
+        bucket:  bucket,
+        queue:   queue,
+        hub:     hub,
+        avatars: avatars,
+        search:  search,
+        ttl:     ttl,
+        logger:  logger,
+    }
+    queue.Register(exportJobName, func(payload []byte) (jobs.Job, error) {
+        job := &exportJob{service: s}
+        if err := json.Unmarshal(payload, job); err != nil {
+            return nil, err
+        }
+        return job, nil
+    })
+    return s
+}
+
+// RequestExport returns the user's export in format that is being built
+// or ready to download, or starts one; started reports whether it did.
+func (s *Service) RequestExport(ctx context.Context, userID, format string) (export *models.DataExport, started bool, err error) {
+    if s.bucket == nil {
+        return nil, false, ErrDisabled
+    }
+
+    exports, err := s.store.ListUserDataExports(ctx, userID)
+    if err != nil {
+        return nil, false, err
+    }
+    now := time.Now()
+    today := 0
+    for _, e := range exports {
+        if now.Sub(e.CreatedAt) < 24*time.Hour {
+            today++
+        }
+        if e.Format != format {
+            continue
+        }
+        switch {
+        case e.Status == models.ExportPending && now.Sub(e.CreatedAt) < staleExport,
+            e.Status == models.ExportReady && e.ExpiresAt != nil && e.ExpiresAt.After(now):
+            return e, false, nil
+        }
+    }
+    if today >= maxDailyExports {
+        return nil, false, ErrTooManyExports
+    }
+
+    export = &models.DataExport{UserID: userID, Format: format, Status: models.ExportPending}
+    if err := s.store.CreateDataExport(ctx, export); err != nil {
+        return nil, false, err
+    }
+    if err := s.queue.Enqueue(&exportJob{service: s, ID: export.ID}); err != nil {
+        return nil, false, fmt.Errorf("failed to queue data export: %w", err)
+    }
+    return export, true, nil
+}
+
+// Archive reads a ready export's archive back as stored.
+func (s *Service) Archive(ctx context.Context, export *models.DataExport) ([]byte, error) {
+    if s.bucket == nil {
+        return nil, ErrDisabled
+    }
+    return s.bucket.Get(ctx, export.ObjectKey)
+}
+
+// ContentType is the media type of an export's archive.
+func ContentType(format string) string {
+    if format == models.ExportFormatCSV {
+        return "application/zip"
+    }
+    return "application/json"
+}
+
+// Extension is the file extension of an export's archive.
+func Extension(format string) string {
+    if format == models.ExportFormatCSV {
+        return "zip"
+    }
+    return "json"
+}
+
+// Erase anonymizes or deletes a user's account, by mode, removing their
+// exports and stored avatar and erasing their indexed and archived
+// messages first, and closes their connections. A copy that cannot be
+// erased fails the call before the account is touched, so it can be
+// retried.
+func (s *Service) Erase(ctx context.Context, userID, mode string) error {
+    if mode != EraseAnonymize && mode != EraseDelete {
+        return fmt.Errorf("unknown erasure mode %q", mode)
+    }
+    user, err := s.store.GetUser(ctx, userID)
+    if err != nil {
+        return err
+    }
+
+    // Their rows go with the account, so the objects are removed first
+    exports, err := s.store.ListUserDataExports(ctx, userID)
+    if err != nil {
+        return err
+    }
+    for _, export := range exports {
+        if err := s.removeExport(ctx, export); err != nil {
+            return err
+        }
+    }
+    if s.avatars != nil && user.AvatarURL != "" {
+        s.avatars.Remove(ctx, user.AvatarURL)
+    }
+    // Anonymized messages stay indexed; their documents carry no name
+    if mode == EraseDelete && s.search != nil {
+        if err := s.search.DeleteUserMessages(ctx, userID); err != nil {
+            return fmt.Errorf("failed to delete indexed messages: %w", err)
+        }
+    }
+    if err := s.eraseArchived(ctx, userID, mode); err != nil {
+        return fmt.Errorf("failed to erase archived messages: %w", err)
+    }
+
+    if mode == EraseAnonymize {
+        err = s.store.AnonymizeUser(ctx, userID, AnonymousUsername(userID), time.Now())
+    } else {
+        err = s.store.DeleteUser(ctx, userID)
+    }
+    if err != nil {
+        return err
+    }
+
+    s.hub.DisconnectUser(userID)
+    s.logger.Info("Erased user", zap.String("user_id", userID), zap.String("mode", mode))
+    return nil
+}
+
+// AnonymousUsername is the name an anonymized user's messages are shown
+// under. It is derived from their ID, so it is unique and says nothing
+// about them.
+func AnonymousUsername(userID string) string {
+    return "deleted-" + strings.ReplaceAll(userID, "-", "")
+}
+
+// eraseArchived rewrites the archives that may hold the user's messages.
+func (s *Service) eraseArchived(ctx context.Context, userID, mode string) error {
+    after := ""
+    for {
+        archives, err := s.store.ListUserMessageArchives(ctx, userID, after, archivePage)
+        if err != nil {
+            return err
+        }
+        if len(archives) > 0 && s.bucket == nil {
+            return errors.New("messages are archived but no object store is configured")
+        }
+        for _, archive := range archives {
+            if err := s.eraseArchive(ctx, archive, userID, mode); err != nil {
+                return fmt.Errorf("archive %s: %w", archive.ObjectKey, err)
+            }
+        }
+        if len(archives) < archivePage {
+            return nil
+        }
+        after = archives[len(archives)-1].ID
+    }
+}
+
+// eraseArchive drops the user's messages from an archive, or renames
+// their sender, by mode. The rewrite goes to a new object, as readers
+// cache archives by key; an archive left empty is removed.
+func (s *Service) eraseArchive(ctx context.Context, archive *models.MessageArchive, userID, mode string) error {
+    body, err := s.bucket.Get(ctx, archive.ObjectKey)
+    if errors.Is(err, objectstore.ErrNotFound) {
+        // Emptied by an earlier erasure that failed to remove the entry
+        return s.store.DeleteMessageArchive(ctx, archive.ID)
+    }
+    if err != nil {
+        return err
+    }
+    messages, err := retention.Decode(body)
+    if err != nil {
+        return err
+    }
+
+    kept := messages[:0]
+    erased := false
+    for _, message := range messages {
+        if message.UserID == userID {
+            erased = true
+            if mode == EraseDelete {
+                continue
+            }
+            message.User = &models.UserSummary{ID: userID, Username: AnonymousUsername(userID)}
+        }
+        kept = append(kept, message)
+    }
+    if !erased {
+        if archive.UserIDs == nil {
+            // Written before senders were recorded; record them so
+            // later erasures skip it
+            archive.UserIDs = retention.Senders(messages)
+            return s.store.UpdateMessageArchive(ctx, archive)
+        }
+        return nil
+    }
+    if len(kept) == 0 {
+        if err := s.bucket.Delete(ctx, archive.ObjectKey); err != nil {
+            return err
+        }
+        return s.store.DeleteMessageArchive(ctx, archive.ID)
+    }
+
+    body, err = retention.Encode(kept)
+    if err != nil {
+        return err
+    }
+    previous := archive.ObjectKey
+    archive.ObjectKey = fmt.Sprintf("messages/%s/%s/%s-%d.jsonl.gz",
+        archive.RoomID, archive.Day.UTC().Format("2006/01/02"), kept[0].ID, time.Now().UnixNano())
+    archive.Messages, archive.Size = len(kept), len(body)
+    archive.FirstAt, archive.LastAt = kept[0].CreatedAt, kept[len(kept)-1].CreatedAt
+    archive.UserIDs = retention.Senders(kept)
+    if err := s.bucket.Put(ctx, archive.ObjectKey, "application/gzip", body); err != nil {
+        return err
+    }
+    if err := s.store.UpdateMessageArchive(ctx, archive); err != nil {
+        return err
+    }
+    return s.bucket.Delete(ctx, previous)
+}
+
+func (s *Service) removeExport(ctx context.Context, export *models.DataExport) error {
+    if export.ObjectKey != "" && s.bucket != nil {
+        if err := s.bucket.Delete(ctx, export.ObjectKey); err != nil {
+            return err
+        }
+    }
+    return s.store.DeleteDataExport(ctx, export.ID)
+}
+
+// Bundle is what a JSON export holds; a CSV export holds the same in one
+// file per section.
+type Bundle struct {
+    ExportedAt time.Time      `json:"exported_at"`
+    Profile    *models.User   `json:"profile"`
+    Rooms      []*RoomMember  `json:"rooms"`
+    Messages   []*SentMessage `json:"messages"`
+    // Truncated is set when the user sent more messages than an export
+    // holds; the oldest are included
+    Truncated bool `json:"truncated,omitempty"`
+}
+
+// RoomMember is a room the user is a member of.
+type RoomMember struct {
+    ID      string `json:"id"`
+    Name    string `json:"name"`
+    MatchID string `json:"match_id,omitempty"`
+}
+
+// SentMessage is a message the user sent.
+type SentMessage struct {
+    ID        string    `json:"id"`
+    RoomID    string    `json:"room_id"`
+    Type      string    `json:"type"`
+    TopicID   string    `json:"topic_id,omitempty"`
+    Content   string    `json:"content"`
+    CreatedAt time.Time `json:"created_at"`
+}
+
+// build gathers a pending export's data and stores its archive. Store and
+// bucket failures are returned so the queue retries them.
+func (s *Service) build(ctx context.Context, id string) error {
+    export, err := s.store.GetDataExport(ctx, id)
+    if err != nil {
+        // Erased with its user; nothing left to build
+        s.logger.Info("Skipping missing data export", zap.String("export_id", id))
+        return nil
+    }
+    if export.Status != models.ExportPending {
+        return nil
+    }
+
+    bundle, err := s.gather(ctx, export.UserID)
+    if err != nil {
+        return err
+    }
+    var data []byte
+    if export.Format == models.ExportFormatCSV {
+        data, err = encodeCSV(bundle)
+    } else {
+        data, err = json.Marshal(bundle)
+    }
+    if err != nil {
+        return fmt.Errorf("failed to encode data export: %w", err)
+    }
+
+    export.ObjectKey = fmt.Sprintf("exports/%s/%s.%s", export.UserID, export.ID, Extension(export.Format))
+    if err := s.bucket.Put(ctx, export.ObjectKey, ContentType(export.Format), data); err != nil {
+        return err
+    }
+    completed := time.Now()
+    expires := completed.Add(s.ttl)
+    export.Status = models.ExportReady
+    export.Size = len(data)
+    export.CompletedAt, export.ExpiresAt = &completed, &expires
+    if _, err := s.store.CompleteDataExport(ctx, export); err != nil {
+        return err
+    }
+
+    s.logger.Info("Built data export",
+        zap.String("export_id", export.ID),
+        zap.String("user_id", export.UserID),
+        zap.Int("messages", len(bundle.Messages)),
+        zap.Int("size", export.Size))
+    return nil
+}
+
+func (s *Service) gather(ctx context.Context, userID string) (*Bundle, error) {
+    user, err := s.store.GetUser(ctx, userID)
+    if err != nil {
+        return nil, err
+    }
+    bundle := &Bundle{
+        ExportedAt: time.Now(),
+        Profile:    user,
+        Rooms:      []*RoomMember{},
+        Messages:   []*SentMessage{},
+    }
+
+    rooms, err := s.store.GetUserRooms(ctx, userID)
+    if err != nil {
+        return nil, err
+    }
+    for _, room := range rooms {
+        bundle.Rooms = append(bundle.Rooms, &RoomMember{ID: room.ID, Name: room.Name, MatchID: room.MatchID})
+    }
+
+    var cursor *store.MessageCursor
+    for {
+        page, err := s.store.GetUserMessages(ctx, userID, cursor, exportPage)
+        if err != nil {
+            return nil, err
+        }
+        for _, m := range page {
+            if len(bundle.Messages) == maxExportMessages {
+                bundle.Truncated = true
+                return bundle, nil
+            }
+            bundle.Messages = append(bundle.Messages, &SentMessage{
+                ID:        m.ID,
+                RoomID:    m.ChatRoomID,
+                Type:      m.MessageType,
+                TopicID:   m.TopicID,
+                Content:   m.Content,
+                CreatedAt: m.CreatedAt,
+            })
+        }
+        if len(page) < exportPage {
+            return bundle, nil
+        }
+        last := page[len(page)-1]
+        cursor = &store.MessageCursor{CreatedAt: last.CreatedAt, ID: last.ID}
+    }
+}
+
+// encodeCSV writes the bundle as a zip of profile.csv, rooms.csv and
+// messages.csv.
+func encodeCSV(bundle *Bundle) ([]byte, error) {
+    var buf bytes.Buffer
+    archive := zip.NewWriter(&buf)
+
+    p := bundle.Profile
+    profile := [][]string{
+        {"field", "value"},
+        {"id", p.ID},
+        {"username", p.Username},
+        {"email", p.Email},
+        {"favorite_team", p.FavoriteTeam},
+        {"avatar_url", p.AvatarURL},
+        {"bio", p.Bio},
+        {"account_type", p.AccountType},
+        {"locale", p.Locale},
+        {"timezone", p.Timezone},
+        {"birth_date", formatDate(p.BirthDate)},
+        {"created_at", p.CreatedAt.UTC().Format(time.RFC3339)},
+        {"exported_at", bundle.ExportedAt.UTC().Format(time.RFC3339)},
+        {"messages_truncated", strconv.FormatBool(bundle.Truncated)},
+    }
+
+    rooms := [][]string{{"id", "name", "match_id"}}
+    for _, r := range bundle.Rooms {
+        rooms = append(rooms, []string{r.ID, r.Name, r.MatchID})
+    }
+
+    messages := [][]string{{"id", "room_id", "type", "topic_id", "created_at", "content"}}
+    for _, m := range bundle.Messages {
+        messages = append(messages, []string{m.ID, m.RoomID, m.Type, m.TopicID, m.CreatedAt.UTC().Format(time.RFC3339Nano), m.Content})
+    }
+
+    for _, file := range []struct {
+        name string
+        rows [][]string
+    }{
+        {"profile.csv", profile},
+        {"rooms.csv", rooms},
+        {"messages.csv", messages},
+    } {
+        w, err := archive.Create(file.name)
+        if err != nil {
+            return nil, err
+        }
+        if err := csv.NewWriter(w).WriteAll(file.rows); err != nil {
+            return nil, err
+        }
+    }
+    if err := archive.Close(); err != nil {
+        return nil, err
+    }
+    return buf.Bytes(), nil
+}
+
+func formatDate(t *time.Time) string {
+    if t == nil {
+        return ""
+    }
+    return t.Format("2006-01-02")
+}
+
+type exportJob struct {
+    service *Service
+    ID      string `json:"id"`
+}
+
+func (j *exportJob) Name() string { return exportJobName }
+
+func (j *exportJob) Payload() ([]byte, error) { return json.Marshal(j) }
+
+func (j *exportJob) Run(ctx context.Context) error {
+    return j.service.build(ctx, j.ID)
+}
+
+// PurgeJob removes exports past their expiry, archive and row.
+type PurgeJob struct {
+    service *Service
+}
+
+func NewPurgeJob(service *Service) *PurgeJob {
+    return &PurgeJob{service: service}
+}
+
+func (j *PurgeJob) Name() string { return "privacy.purge_exports" }
+
+func (j *PurgeJob) Run(ctx context.Context) error {
+    expired, err := j.service.store.ListExpiredDataExports(ctx, time.Now(), expiredBatch)
+    if err != nil {
+        return fmt.Errorf("failed to list expired data exports: %w", err)
+    }
+    for _, export := range expired {
+        if err := j.service.removeExport(ctx, export); err != nil {
+            return fmt.Errorf("failed to remove data export %s: %w", export.ID, err)
+        }
+    }
+    if len(expired) > 0 {
+        j.service.logger.Info("Purged expired data exports", zap.Int("exports", len(expired)))
+    }
+    return nil
+}
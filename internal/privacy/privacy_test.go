@@ -0,0 +1,203 @@
+package privacy
+
+import (
+    "context"
+    "errors"
+    "testing"
+    "time"
+
+    "github.com/prometheus/client_golang/prometheus"
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/jobs"
+    "github.com/yourusername/sports-chat/internal/metrics"
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/objectstore"
+    "github.com/yourusername/sports-chat/internal/retention"
+    "github.com/yourusername/sports-chat/internal/store/memory"
+)
+
+type fakeIndex struct {
+    err     error
+    deleted []string
+}
+
+func (f *fakeIndex) DeleteUserMessages(ctx context.Context, userID string) error {
+    if f.err != nil {
+        return f.err
+    }
+    f.deleted = append(f.deleted, userID)
+    return nil
+}
+
+type fakeHub struct {
+    disconnected []string
+}
+
+func (f *fakeHub) DisconnectUser(userID string) {
+    f.disconnected = append(f.disconnected, userID)
+}
+
+// TestEraseIndexedAndArchivedMessages erases a user who posted to a room
+// whose messages were indexed and archived, alongside another user. One
+// archive holds both users' messages, the other only the erased user's.
+func TestEraseIndexedAndArchivedMessages(t *testing.T) {
+    tests := []struct {
+        name     string
+        mode     string
+        indexErr error
+        // wantErased is whether the account is erased and its copies
+        // with it; a failed erasure leaves everything in place
+        wantErased bool
+        // wantIndexed is whether the index still holds the messages
+        wantIndexed bool
+    }{
+        {"delete", EraseDelete, nil, true, false},
+        {"anonymize", EraseAnonymize, nil, true, true},
+        {"delete with the index down", EraseDelete, errors.New("opensearch: status 503"), false, true},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            ctx := context.Background()
+            st := memory.New()
+            bucket, err := objectstore.NewDir(t.TempDir())
+            if err != nil {
+                t.Fatal(err)
+            }
+            index := &fakeIndex{err: tt.indexErr}
+            hub := &fakeHub{}
+            queue := jobs.NewQueue(jobs.Options{}, metrics.NewMetrics(prometheus.NewRegistry()), zap.NewNop())
+            s := NewService(st, bucket, queue, hub, nil, index, time.Hour, zap.NewNop())
+
+            erased, other := createUser(t, st, "erased"), createUser(t, st, "other")
+            day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+            shared := archive(t, st, bucket, "room-1", day,
+                message("m1", other.ID, day.Add(time.Hour)),
+                message("m2", erased.ID, day.Add(2*time.Hour)),
+                message("m3", other.ID, day.Add(3*time.Hour)))
+            own := archive(t, st, bucket, "room-1", day.AddDate(0, 0, 1),
+                message("m4", erased.ID, day.Add(25*time.Hour)))
+
+            err = s.Erase(ctx, erased.ID, tt.mode)
+            if tt.wantErased != (err == nil) {
+                t.Fatalf("Erase returned %v", err)
+            }
+
+            if indexed := len(index.deleted) == 0; indexed != tt.wantIndexed {
+                t.Errorf("messages still indexed: %v, want %v", indexed, tt.wantIndexed)
+            }
+            if _, err := st.GetUser(ctx, erased.ID); (err != nil) != (tt.wantErased && tt.mode == EraseDelete) {
+                t.Errorf("account lookup after erasure: %v", err)
+            }
+            if got := len(hub.disconnected) > 0; got != tt.wantErased {
+                t.Errorf("disconnected: %v, want %v", got, tt.wantErased)
+            }
+
+            archives, err := st.ListMessageArchives(ctx, "room-1", day.AddDate(0, 0, 2), 10)
+            if err != nil {
+                t.Fatal(err)
+            }
+            var archived []*models.Message
+            for _, a := range archives {
+                body, err := bucket.Get(ctx, a.ObjectKey)
+                if err != nil {
+                    t.Fatalf("archive %s has no object: %v", a.ObjectKey, err)
+                }
+                messages, err := retention.Decode(body)
+                if err != nil {
+                    t.Fatal(err)
+                }
+                if len(messages) != a.Messages {
+                    t.Errorf("archive %s indexed with %d messages, holds %d", a.ObjectKey, a.Messages, len(messages))
+                }
+                archived = append(archived, messages...)
+            }
+
+            switch {
+            case !tt.wantErased:
+                if len(archived) != 4 {
+                    t.Errorf("archived %d messages after a failed erasure, want 4", len(archived))
+                }
+                for _, key := range []string{shared, own} {
+                    if _, err := bucket.Get(ctx, key); err != nil {
+                        t.Errorf("archive %s removed by a failed erasure: %v", key, err)
+                    }
+                }
+            case tt.mode == EraseDelete:
+                if len(archives) != 1 || len(archived) != 2 {
+                    t.Fatalf("%d archives holding %d messages, want 1 holding 2", len(archives), len(archived))
+                }
+                for _, m := range archived {
+                    if m.UserID == erased.ID {
+                        t.Errorf("erased user's message %s still archived", m.ID)
+                    }
+                }
+            default:
+                if len(archived) != 4 {
+                    t.Fatalf("archived %d messages, want 4", len(archived))
+                }
+                for _, m := range archived {
+                    if m.UserID == erased.ID && (m.User == nil || m.User.Username != AnonymousUsername(erased.ID)) {
+                        t.Errorf("message %s archived under %+v", m.ID, m.User)
+                    }
+                }
+            }
+            if tt.wantErased {
+                // Rewritten archives move, so readers drop cached copies
+                for _, key := range []string{shared, own} {
+                    if _, err := bucket.Get(ctx, key); !errors.Is(err, objectstore.ErrNotFound) {
+                        t.Errorf("original archive %s still stored: %v", key, err)
+                    }
+                }
+            }
+        })
+    }
+}
+
+func createUser(t *testing.T, st *memory.Store, username string) *models.User {
+    t.Helper()
+    user := &models.User{Username: username}
+    if err := st.CreateUser(context.Background(), user); err != nil {
+        t.Fatal(err)
+    }
+    return user
+}
+
+func message(id, userID string, at time.Time) *models.Message {
+    return &models.Message{
+        ID:         id,
+        ChatRoomID: "room-1",
+        UserID:     userID,
+        Content:    "message " + id,
+        CreatedAt:  at,
+        User:       &models.UserSummary{ID: userID, Username: "user " + userID},
+    }
+}
+
+// archive stores messages as the retention job does and returns the
+// object key.
+func archive(t *testing.T, st *memory.Store, bucket objectstore.Bucket, roomID string, day time.Time, messages ...*models.Message) string {
+    t.Helper()
+    ctx := context.Background()
+    body, err := retention.Encode(messages)
+    if err != nil {
+        t.Fatal(err)
+    }
+    a := &models.MessageArchive{
+        RoomID:    roomID,
+        Day:       day,
+        ObjectKey: "messages/" + roomID + "/" + day.Format("2006/01/02") + "/" + messages[0].ID + ".jsonl.gz",
+        Messages:  len(messages),
+        Size:      len(body),
+        FirstAt:   messages[0].CreatedAt,
+        LastAt:    messages[len(messages)-1].CreatedAt,
+        UserIDs:   retention.Senders(messages),
+    }
+    if err := bucket.Put(ctx, a.ObjectKey, "application/gzip", body); err != nil {
+        t.Fatal(err)
+    }
+    if err := st.CreateMessageArchive(ctx, a, nil); err != nil {
+        t.Fatal(err)
+    }
+    return a.ObjectKey
+}
@@ -0,0 +1,73 @@
+package quiethours
+
+import (
+    "errors"
+    "fmt"
+    "time"
+
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+var ErrInvalid = errors.New("quiet hours need an IANA time zone and HH:MM start and end times that differ")
+
+// Validate checks a schedule before it is stored.
+func Validate(q *models.QuietHours) error {
+    if _, err := time.LoadLocation(q.Timezone); err != nil || q.Timezone == "" {
+        return ErrInvalid
+    }
+    start, err := parseClock(q.Start)
+    if err != nil {
+        return ErrInvalid
+    }
+    end, err := parseClock(q.End)
+    if err != nil || start == end {
+        return ErrInvalid
+    }
+    return nil
+}
+
+// Active reports whether now falls inside the schedule's window in the
+// user's time zone. Windows may span midnight, e.g. 22:00 to 07:00. A nil
+// or unreadable schedule is never active.
+func Active(q *models.QuietHours, now time.Time) bool {
+    if q == nil {
+        return false
+    }
+    loc, err := time.LoadLocation(q.Timezone)
+    if err != nil {
+        return false
+    }
+    start, err := parseClock(q.Start)
+    if err != nil {
+        return false
+    }
+    end, err := parseClock(q.End)
+    if err != nil {
+        return false
+    }
+
+    local := now.In(loc)
+    minute := local.Hour()*60 + local.Minute()
+    if start < end {
+        return minute >= start && minute < end
+    }
+    return minute >= start || minute < end
+}
+
+// Allows reports whether a notification may be delivered now. Goals
+// involving the user's own team get through when the schedule says so.
+func Allows(q *models.QuietHours, now time.Time, teamGoal bool) bool {
+    if !Active(q, now) {
+        return true
+    }
+    return teamGoal && q.TeamGoals
+}
+
+// parseClock parses "HH:MM" into minutes after midnight.
+func parseClock(s string) (int, error) {
+    t, err := time.Parse("15:04", s)
+    if err != nil {
+        return 0, fmt.Errorf("invalid time of day %q: %w", s, err)
+    }
+    return t.Hour()*60 + t.Minute(), nil
+}
@@ -0,0 +1,62 @@
+package ratelimit
+
+import (
+    "context"
+    "sync"
+    "time"
+
+    "golang.org/x/time/rate"
+)
+
+type bucket struct {
+    limiter  *rate.Limiter
+    lastSeen time.Time
+}
+
+// Memory keeps buckets in process. It is exact for a single instance and
+// serves as the fallback when Redis is unreachable.
+type Memory struct {
+    mu        sync.Mutex
+    buckets   map[string]*bucket
+    lastSweep time.Time
+}
+
+func NewMemory() *Memory {
+    return &Memory{
+        buckets:   make(map[string]*bucket),
+        lastSweep: time.Now(),
+    }
+}
+
+// sweepInterval bounds how long idle buckets are kept. A bucket idle for
+// longer than its window is full again, so dropping it changes nothing.
+const sweepInterval = time.Minute
+
+func (m *Memory) Allow(ctx context.Context, key string, rule Rule) (Result, error) {
+    m.mu.Lock()
+    defer m.mu.Unlock()
+
+    now := time.Now()
+    if now.Sub(m.lastSweep) > sweepInterval {
+        for k, b := range m.buckets {
+            if now.Sub(b.lastSeen) > sweepInterval {
+                delete(m.buckets, k)
+            }
+        }
+        m.lastSweep = now
+    }
+
+    b, ok := m.buckets[key]
+    if !ok {
+        b = &bucket{limiter: rate.NewLimiter(rate.Every(rule.Window/time.Duration(rule.Requests)), rule.Requests)}
+        m.buckets[key] = b
+    }
+    b.lastSeen = now
+
+    reservation := b.limiter.ReserveN(now, 1)
+    if delay := reservation.DelayFrom(now); delay > 0 {
+        reservation.CancelAt(now)
+        return Result{RetryAfter: delay}, nil
+    }
+    return Result{Allowed: true}, nil
+}
@@ -0,0 +1,80 @@
+// Package ratelimit provides token buckets shared by every connection of a
+// user, so reconnecting or opening another tab does not reset a limit.
+package ratelimit
+
+import (
+    "context"
+    "fmt"
+    "strconv"
+    "strings"
+    "time"
+)
+
+// DefaultRule is the name of the rule applied to message types without
+// their own.
+const DefaultRule = "default"
+
+// Rule allows Requests per Window, with bursts of up to Requests.
+type Rule struct {
+    Requests int
+    Window   time.Duration
+}
+
+// Result is the outcome of taking a token. RetryAfter is how long until a
+// token is available again when the request was refused.
+type Result struct {
+    Allowed    bool
+    RetryAfter time.Duration
+}
+
+type Limiter interface {
+    // Allow takes one token from key's bucket, creating it full if needed.
+    Allow(ctx context.Context, key string, rule Rule) (Result, error)
+}
+
+// Rules maps message types to their rule.
+type Rules map[string]Rule
+
+// For returns the rule for a message type, falling back to the default.
+func (r Rules) For(msgType string) Rule {
+    if rule, ok := r[msgType]; ok {
+        return rule
+    }
+    return r[DefaultRule]
+}
+
+// ParseRules reads rules of the form "default=5/1s,reaction=10/1s". A
+// default rule is required.
+func ParseRules(s string) (Rules, error) {
+    rules := make(Rules)
+    for _, entry := range strings.Split(s, ",") {
+        entry = strings.TrimSpace(entry)
+        if entry == "" {
+            continue
+        }
+
+        name, spec, ok := strings.Cut(entry, "=")
+        if !ok {
+            return nil, fmt.Errorf("invalid rate limit %q", entry)
+        }
+        requests, window, ok := strings.Cut(spec, "/")
+        if !ok {
+            return nil, fmt.Errorf("invalid rate limit %q", entry)
+        }
+
+        n, err := strconv.Atoi(requests)
+        if err != nil || n <= 0 {
+            return nil, fmt.Errorf("invalid request count in rate limit %q", entry)
+        }
+        d, err := time.ParseDuration(window)
+        if err != nil || d < time.Millisecond {
+            return nil, fmt.Errorf("invalid window in rate limit %q", entry)
+        }
+        rules[strings.TrimSpace(name)] = Rule{Requests: n, Window: d}
+    }
+
+    if _, ok := rules[DefaultRule]; !ok {
+        return nil, fmt.Errorf("rate limits must include a %s rule", DefaultRule)
+    }
+    return rules, nil
+}
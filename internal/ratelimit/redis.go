@@ -0,0 +1,93 @@
+package ratelimit
+
+import (
+    "context"
+    "fmt"
+    "time"
+
+    "github.com/redis/go-redis/v9"
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/metrics"
+)
+
+// tokenBucket refills and takes a token atomically. It reads the clock
+// from Redis so instances with skewed clocks agree on refills, and expires
+// buckets once they would be full again.
+var tokenBucket = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1]) or burst
+local ts = tonumber(state[2]) or now
+tokens = math.min(burst, tokens + math.max(0, now - ts) * rate)
+
+local allowed = 0
+local wait = 0
+if tokens >= 1 then
+    tokens = tokens - 1
+    allowed = 1
+else
+    wait = math.ceil((1 - tokens) / rate)
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', now)
+redis.call('PEXPIRE', KEYS[1], math.ceil(burst / rate) + 1000)
+return {allowed, wait}
+`)
+
+// Redis shares buckets across instances. When Redis fails the request is
+// decided by the fallback instead, so an outage loosens limits to
+// per-instance rather than blocking or waving through every message.
+type Redis struct {
+    client   *redis.Client
+    prefix   string
+    fallback Limiter
+    metrics  *metrics.Metrics
+    logger   *zap.Logger
+}
+
+func NewRedis(url, prefix string, fallback Limiter, metrics *metrics.Metrics, logger *zap.Logger) (*Redis, error) {
+    opts, err := redis.ParseURL(url)
+    if err != nil {
+        return nil, fmt.Errorf("failed to parse redis url: %w", err)
+    }
+
+    client := redis.NewClient(opts)
+    if err := client.Ping(context.Background()).Err(); err != nil {
+        client.Close()
+        return nil, fmt.Errorf("failed to connect to redis: %w", err)
+    }
+
+    return &Redis{
+        client:   client,
+        prefix:   prefix + "ratelimit:",
+        fallback: fallback,
+        metrics:  metrics,
+        logger:   logger,
+    }, nil
+}
+
+func (r *Redis) Allow(ctx context.Context, key string, rule Rule) (Result, error) {
+    perMilli := float64(rule.Requests) / float64(rule.Window.Milliseconds())
+    res, err := tokenBucket.Run(ctx, r.client, []string{r.prefix + key}, perMilli, rule.Requests).Int64Slice()
+    if err != nil || len(res) != 2 {
+        r.metrics.RateLimitFallbacks.Inc()
+        r.logger.Warn("Redis rate limiter unavailable, using local buckets",
+            zap.Error(err),
+            zap.String("key", key))
+        return r.fallback.Allow(ctx, key, rule)
+    }
+
+    return Result{
+        Allowed:    res[0] == 1,
+        RetryAfter: time.Duration(res[1]) * time.Millisecond,
+    }, nil
+}
+
+func (r *Redis) Close() error {
+    return r.client.Close()
+}
@@ -0,0 +1,200 @@
+package ratings
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "strings"
+    "time"
+    "unicode/utf8"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/events"
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/store"
+)
+
+const (
+    MinRating       = 1
+    MaxRating       = 10
+    maxPlayerLength = 100
+)
+
+var (
+    ErrNoVote         = errors.New("no vote for this match")
+    ErrVoteClosed     = errors.New("voting has closed")
+    ErrNotParticipant = errors.New("only fans in the room at full time can vote")
+    ErrInvalidBallot  = errors.New("ballot needs a rating from 1 to 10 or a player")
+)
+
+// Announcer sends a frame to every client in a room, on every instance.
+// The websocket hub implements it.
+type Announcer interface {
+    Announce(room string, message *models.WSMessage)
+}
+
+// voteFrame is the data of a match_vote frame.
+type voteFrame struct {
+    Status string                  `json:"status"` // "open" or "closed"
+    Vote   *models.MatchVote       `json:"vote,omitempty"`
+    Result *models.MatchVoteResult `json:"result,omitempty"`
+}
+
+// Opener opens a vote for every match as it finishes.
+type Opener struct {
+    store     store.Store
+    announcer Announcer
+    window    time.Duration
+    logger    *zap.Logger
+    finished  chan events.MatchFinished
+}
+
+func NewOpener(store store.Store, announcer Announcer, window time.Duration, logger *zap.Logger) *Opener {
+    return &Opener{
+        store:     store,
+        announcer: announcer,
+        window:    window,
+        logger:    logger,
+        finished:  make(chan events.MatchFinished, 64),
+    }
+}
+
+// Start subscribes the opener to finished matches and starts its worker.
+func (o *Opener) Start(bus *events.Bus) {
+    bus.Subscribe(events.TypeMatchFinished, func(event events.Event) {
+        select {
+        case o.finished <- event.(events.MatchFinished):
+        default:
+            o.logger.Warn("Match vote queue full, dropping event")
+        }
+    })
+
+    go o.run()
+}
+
+func (o *Opener) run() {
+    for finished := range o.finished {
+        o.open(finished)
+    }
+}
+
+// open is called by every instance for the same match, each with the
+// users connected to it, so all of them become voters while only the
+// first announces the vote.
+func (o *Opener) open(finished events.MatchFinished) {
+    ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+    defer cancel()
+
+    now := time.Now()
+    vote := &models.MatchVote{
+        MatchID:  finished.MatchID,
+        RoomID:   finished.RoomID,
+        OpensAt:  now,
+        ClosesAt: now.Add(o.window),
+    }
+    created, err := o.store.OpenMatchVote(ctx, vote, finished.UserIDs)
+    if err != nil {
+        o.logger.Error("Failed to open match vote",
+            zap.Error(err),
+            zap.String("match_id", finished.MatchID))
+        return
+    }
+    if created {
+        announce(o.announcer, vote.RoomID, &voteFrame{Status: "open", Vote: vote})
+    }
+}
+
+// CloseJob closes votes whose window has passed and announces the result.
+type CloseJob struct {
+    store     store.Store
+    announcer Announcer
+    logger    *zap.Logger
+}
+
+func NewCloseJob(store store.Store, announcer Announcer, logger *zap.Logger) *CloseJob {
+    return &CloseJob{store: store, announcer: announcer, logger: logger}
+}
+
+func (j *CloseJob) Name() string { return "ratings.close" }
+
+func (j *CloseJob) Run(ctx context.Context) error {
+    votes, err := j.store.GetDueMatchVotes(ctx, time.Now())
+    if err != nil {
+        return fmt.Errorf("failed to get due match votes: %w", err)
+    }
+
+    for _, vote := range votes {
+        // Instances race to close; only the winner announces
+        closed, err := j.store.CloseMatchVote(ctx, vote.MatchID, time.Now())
+        if err != nil {
+            j.logger.Error("Failed to close match vote", zap.Error(err), zap.String("match_id", vote.MatchID))
+            continue
+        }
+        if !closed {
+            continue
+        }
+        result, err := j.store.GetMatchVoteResult(ctx, vote.MatchID)
+        if err != nil {
+            j.logger.Error("Failed to get match vote result", zap.Error(err), zap.String("match_id", vote.MatchID))
+            continue
+        }
+        announce(j.announcer, vote.RoomID, &voteFrame{Status: "closed", Result: result})
+    }
+    return nil
+}
+
+func announce(announcer Announcer, room string, frame *voteFrame) {
+    data, err := json.Marshal(frame)
+    if err != nil {
+        return
+    }
+    announcer.Announce(room, &models.WSMessage{
+        Type:      models.MessageTypeMatchVote,
+        ChatRoom:  room,
+        Data:      data,
+        Timestamp: time.Now(),
+    })
+}
+
+// Cast records a user's ballot, replacing any earlier one while the vote
+// is open. A zero rating or empty player leaves that half blank.
+func Cast(ctx context.Context, st store.Store, matchID, userID string, rating int, player string) error {
+    player = strings.TrimSpace(player)
+    if rating != 0 && (rating < MinRating || rating > MaxRating) {
+        return ErrInvalidBallot
+    }
+    if rating == 0 && player == "" {
+        return ErrInvalidBallot
+    }
+    if utf8.RuneCountInString(player) > maxPlayerLength {
+        return ErrInvalidBallot
+    }
+
+    vote, err := st.GetMatchVote(ctx, matchID)
+    if err != nil {
+        return ErrNoVote
+    }
+    now := time.Now()
+    if vote.ClosedAt != nil || !now.Before(vote.ClosesAt) {
+        return ErrVoteClosed
+    }
+
+    voter, err := st.IsMatchVoter(ctx, matchID, userID)
+    if err != nil {
+        return fmt.Errorf("failed to check voter: %w", err)
+    }
+    if !voter {
+        return ErrNotParticipant
+    }
+
+    return st.CastMatchBallot(ctx, &models.MatchBallot{
+        MatchID:   matchID,
+        UserID:    userID,
+        Rating:    rating,
+        Player:    player,
+        CreatedAt: now,
+        UpdatedAt: now,
+    })
+}
@@ -0,0 +1,172 @@
+// Package rbac decides what users may do in a room. Site admins may do
+// anything anywhere; everyone else gets the permissions of their role in
+// the room, which the hub and the API check alike.
+package rbac
+
+import (
+    "context"
+    "sync"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/authctx"
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/store"
+)
+
+// Roles are checked on moderation frames and every slow-mode post, so
+// they are cached briefly. Changes reach other instances through the
+// broker at once; the TTL only bounds how long a missed change lingers.
+const cacheTTL = 30 * time.Second
+
+// Permission is something a role allows in its room.
+type Permission string
+
+const (
+    // PermPin pins and unpins the room's message
+    PermPin Permission = "pin"
+    // PermDelete deletes other users' messages
+    PermDelete Permission = "delete"
+    // PermSanction mutes and bans users who rank below
+    PermSanction Permission = "sanction"
+    // PermBypassSlowMode posts as often as the rate limits allow
+    PermBypassSlowMode Permission = "bypass_slow_mode"
+    // PermManageRoles grants and revokes roles below the granter's own
+    PermManageRoles Permission = "manage_roles"
+    // PermBroadcast posts in broadcaster-only rooms
+    PermBroadcast Permission = "broadcast"
+)
+
+var rolePermissions = map[string][]Permission{
+    models.RoomRoleOwner:       {PermPin, PermDelete, PermSanction, PermBypassSlowMode, PermManageRoles, PermBroadcast},
+    models.RoomRoleModerator:   {PermPin, PermDelete, PermSanction, PermBypassSlowMode, PermBroadcast},
+    models.RoomRoleVIP:         {PermBypassSlowMode},
+    models.RoomRoleBroadcaster: {PermBypassSlowMode, PermBroadcast},
+    models.RoomRoleMember:      nil,
+}
+
+var roleRanks = map[string]int{
+    models.RoomRoleMember:      0,
+    models.RoomRoleVIP:         1,
+    models.RoomRoleBroadcaster: 1,
+    models.RoomRoleModerator:   2,
+    models.RoomRoleOwner:       3,
+}
+
+// adminRank is above every room role.
+const adminRank = 4
+
+// ValidRole reports whether role can be granted. Members hold no grant.
+func ValidRole(role string) bool {
+    _, ok := roleRanks[role]
+    return ok && role != models.RoomRoleMember
+}
+
+// Allows reports whether a role carries a permission.
+func Allows(role string, perm Permission) bool {
+    for _, p := range rolePermissions[role] {
+        if p == perm {
+            return true
+        }
+    }
+    return false
+}
+
+type cacheKey struct {
+    room string
+    user string
+}
+
+type cachedRole struct {
+    role     string
+    loadedAt time.Time
+}
+
+// Checker answers permission questions from the store, caching roles.
+type Checker struct {
+    store  store.Store
+    logger *zap.Logger
+
+    mu    sync.RWMutex
+    cache map[cacheKey]*cachedRole
+}
+
+func NewChecker(store store.Store, logger *zap.Logger) *Checker {
+    return &Checker{
+        store:  store,
+        logger: logger,
+        cache:  make(map[cacheKey]*cachedRole),
+    }
+}
+
+// Role returns the user's role in the room. Lookup failures make the user
+// a member, denying room privileges rather than granting them.
+func (c *Checker) Role(ctx context.Context, roomID, userID string) string {
+    key := cacheKey{room: roomID, user: userID}
+
+    c.mu.RLock()
+    cached, ok := c.cache[key]
+    c.mu.RUnlock()
+    if ok && time.Since(cached.loadedAt) < cacheTTL {
+        return cached.role
+    }
+
+    role, err := c.store.GetRoomRole(ctx, roomID, userID)
+    if err != nil {
+        c.logger.Warn("Failed to load room role",
+            zap.Error(err),
+            zap.String("room", roomID),
+            zap.String("user_id", userID))
+        return models.RoomRoleMember
+    }
+
+    c.mu.Lock()
+    c.cache[key] = &cachedRole{role: role, loadedAt: time.Now()}
+    c.mu.Unlock()
+    return role
+}
+
+// Can reports whether the principal has a permission in the room.
+func (c *Checker) Can(ctx context.Context, principal *authctx.Principal, roomID string, perm Permission) bool {
+    if principal == nil {
+        return false
+    }
+    if principal.IsAdmin {
+        return true
+    }
+    return Allows(c.Role(ctx, roomID, principal.UserID), perm)
+}
+
+// Outranks reports whether the principal ranks above the user in the
+// room, as they must to sanction them or change their role.
+func (c *Checker) Outranks(ctx context.Context, principal *authctx.Principal, roomID, userID string) bool {
+    if principal == nil || principal.UserID == userID {
+        return false
+    }
+    return c.rank(ctx, principal, roomID) > roleRanks[c.Role(ctx, roomID, userID)]
+}
+
+// CanGrant reports whether the principal may give role to others: only
+// roles below their own, so owners name moderators and admins name
+// owners.
+func (c *Checker) CanGrant(ctx context.Context, principal *authctx.Principal, roomID, role string) bool {
+    if principal == nil || !c.Can(ctx, principal, roomID, PermManageRoles) {
+        return false
+    }
+    return c.rank(ctx, principal, roomID) > roleRanks[role]
+}
+
+func (c *Checker) rank(ctx context.Context, principal *authctx.Principal, roomID string) int {
+    if principal.IsAdmin {
+        return adminRank
+    }
+    return roleRanks[c.Role(ctx, roomID, principal.UserID)]
+}
+
+// Forget drops the cached role of a user, after it changed.
+func (c *Checker) Forget(roomID, userID string) {
+    c.mu.Lock()
+    delete(c.cache, cacheKey{room: roomID, user: userID})
+    c.mu.Unlock()
+}
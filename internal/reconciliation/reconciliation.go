@@ -0,0 +1,164 @@
+package reconciliation
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "strconv"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/sportsdata"
+    "github.com/yourusername/sports-chat/internal/store"
+)
+
+// Settler re-settles anything derived from a match result (predictions,
+// polls) after the result has been corrected.
+type Settler interface {
+    ResettleMatch(ctx context.Context, match *models.Match) error
+}
+
+// Job compares recently finished matches with the provider's final data,
+// corrects the stored result when they disagree and records a report of
+// every conflict for admins.
+type Job struct {
+    store    store.Store
+    provider sportsdata.Provider
+    settlers []Settler
+    lookback time.Duration
+    logger   *zap.Logger
+}
+
+func NewJob(store store.Store, provider sportsdata.Provider, lookback time.Duration, logger *zap.Logger) *Job {
+    return &Job{
+        store:    store,
+        provider: provider,
+        lookback: lookback,
+        logger:   logger,
+    }
+}
+
+// AddSettler registers a subsystem to re-settle after a correction.
+func (j *Job) AddSettler(settler Settler) {
+    j.settlers = append(j.settlers, settler)
+}
+
+func (j *Job) Name() string { return "reconciliation.nightly" }
+
+func (j *Job) Run(ctx context.Context) error {
+    report := &models.ReconciliationReport{
+        StartedAt: time.Now(),
+        Conflicts: []*models.ReconciliationConflict{},
+    }
+
+    matches, err := j.store.GetFinishedMatchesSince(ctx, report.StartedAt.Add(-j.lookback))
+    if err != nil {
+        return fmt.Errorf("failed to list finished matches: %w", err)
+    }
+
+    for _, match := range matches {
+        if match.ProviderID == "" {
+            continue
+        }
+        report.MatchesChecked++
+
+        conflicts, err := j.reconcile(ctx, match)
+        if err != nil {
+            j.logger.Warn("Failed to reconcile match",
+                zap.Error(err),
+                zap.String("match_id", match.ID))
+            continue
+        }
+        report.Conflicts = append(report.Conflicts, conflicts...)
+    }
+
+    report.FinishedAt = time.Now()
+    if err := j.store.CreateReconciliationReport(ctx, report); err != nil {
+        return fmt.Errorf("failed to store reconciliation report: %w", err)
+    }
+
+    j.logger.Info("Reconciliation finished",
+        zap.Int("matches_checked", report.MatchesChecked),
+        zap.Int("conflicts", len(report.Conflicts)))
+
+    return nil
+}
+
+func (j *Job) reconcile(ctx context.Context, match *models.Match) ([]*models.ReconciliationConflict, error) {
+    final, err := j.provider.GetMatchResult(ctx, match.ProviderID)
+    if err != nil {
+        if errors.Is(err, sportsdata.ErrMatchNotFound) {
+            return []*models.ReconciliationConflict{{
+                MatchID:    match.ID,
+                ProviderID: match.ProviderID,
+                Field:      "match",
+                Stored:     match.Status,
+                Error:      "match not found at provider",
+            }}, nil
+        }
+        return nil, err
+    }
+
+    // Only final data is authoritative; a provider still reporting the
+    // match as live is not a conflict yet.
+    if final.Status != models.MatchStatusFinished && final.Status != models.MatchStatusCancelled {
+        return nil, nil
+    }
+
+    conflicts := diff(match, final)
+    if len(conflicts) == 0 {
+        return nil, nil
+    }
+
+    corrected := *match
+    corrected.Status = final.Status
+    corrected.HomeScore = final.HomeScore
+    corrected.AwayScore = final.AwayScore
+
+    if err := j.store.UpdateMatch(ctx, &corrected); err != nil {
+        for _, c := range conflicts {
+            c.Error = err.Error()
+        }
+        return conflicts, nil
+    }
+
+    resettled := true
+    for _, settler := range j.settlers {
+        if err := settler.ResettleMatch(ctx, &corrected); err != nil {
+            resettled = false
+            j.logger.Error("Failed to re-settle match",
+                zap.Error(err),
+                zap.String("match_id", match.ID))
+        }
+    }
+
+    for _, c := range conflicts {
+        c.Corrected = true
+        c.Resettled = resettled && len(j.settlers) > 0
+    }
+    return conflicts, nil
+}
+
+func diff(stored, final *models.Match) []*models.ReconciliationConflict {
+    var conflicts []*models.ReconciliationConflict
+    add := func(field, storedValue, providerValue string) {
+        if storedValue == providerValue {
+            return
+        }
+        conflicts = append(conflicts, &models.ReconciliationConflict{
+            MatchID:    stored.ID,
+            ProviderID: stored.ProviderID,
+            Field:      field,
+            Stored:     storedValue,
+            Provider:   providerValue,
+        })
+    }
+
+    add("status", stored.Status, final.Status)
+    add("home_score", strconv.Itoa(stored.HomeScore), strconv.Itoa(final.HomeScore))
+    add("away_score", strconv.Itoa(stored.AwayScore), strconv.Itoa(final.AwayScore))
+
+    return conflicts
+}
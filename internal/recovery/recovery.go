@@ -0,0 +1,487 @@
+// Package recovery gets users back into accounts they can no longer sign
+// in to. A user can hold a set of one-time backup codes and a verified
+// recovery email, which is sent a one-time link on request; failing both,
+// support can issue a link after checking the user's identity by other
+// means. Every recovery, and every change to how an account can be
+// recovered, is written to the user's security log.
+package recovery
+
+import (
+    "context"
+    "crypto/hmac"
+    "crypto/rand"
+    "crypto/sha256"
+    "encoding/base64"
+    "encoding/hex"
+    "errors"
+    "fmt"
+    "math/big"
+    netmail "net/mail"
+    "strings"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/auth"
+    "github.com/yourusername/sports-chat/internal/mail"
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/ratelimit"
+    "github.com/yourusername/sports-chat/internal/store"
+)
+
+const (
+    // codeCount is how many backup codes a user holds at a time.
+    codeCount = 10
+    // codeAlphabet leaves out characters that are easily misread.
+    codeAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+    codeLength   = 10
+
+    // verifyTTL is how long a link confirming a recovery email is valid.
+    verifyTTL = 24 * time.Hour
+    // emailTTL is how long a recovery link sent on request is valid.
+    emailTTL = 30 * time.Minute
+    // assistedTTL is how long a link issued by support is valid.
+    assistedTTL = 24 * time.Hour
+
+    maxReasonLength = 500
+)
+
+// addressAttempts bounds the backup codes one address may try, across
+// usernames, so guessing is not spread over many accounts.
+var addressAttempts = ratelimit.Rule{Requests: 20, Window: 15 * time.Minute}
+
+var (
+    ErrInvalid         = errors.New("invalid or expired recovery credentials")
+    ErrTooManyAttempts = errors.New("too many recovery attempts")
+    ErrMailDisabled    = errors.New("mail is disabled")
+    ErrInvalidEmail    = errors.New("invalid email address")
+    ErrReasonRequired  = errors.New("a reason is required")
+)
+
+// Origin is where a request came from, as recorded in the security log.
+type Origin struct {
+    IP      string
+    Country string
+    Device  string
+}
+
+// Actor is the support agent issuing an assisted recovery.
+type Actor struct {
+    UserID   string
+    Username string
+}
+
+type Service struct {
+    store store.Store
+    auth  *auth.Service
+    // mail is nil when no mail server is configured, which turns the
+    // recovery email off
+    mail mail.Sender
+    // limiter counts backup code attempts per address
+    limiter ratelimit.Limiter
+    // codeKey keys the digests backup codes are looked up by
+    codeKey []byte
+    // linkBase is the page recovery links open, given the token as
+    // ?token=
+    linkBase string
+    logger   *zap.Logger
+}
+
+func NewService(store store.Store, auth *auth.Service, sender mail.Sender, limiter ratelimit.Limiter, codeKey []byte, linkBase string, logger *zap.Logger) *Service {
+    return &Service{
+        store:    store,
+        auth:     auth,
+        mail:     sender,
+        limiter:  limiter,
+        codeKey:  codeKey,
+        linkBase: linkBase,
+        logger:   logger,
+    }
+}
+
+// GenerateCodes replaces the user's backup codes with a fresh set and
+// returns them. This is the only time they are shown; only their hashes
+// are kept.
+func (s *Service) GenerateCodes(ctx context.Context, userID string, origin Origin) ([]string, error) {
+    codes := make([]string, codeCount)
+    stored := make([]*models.RecoveryCode, codeCount)
+    for i := range codes {
+        code, err := newCode()
+        if err != nil {
+            return nil, err
+        }
+        hash, err := s.auth.HashPassword(normalizeCode(code))
+        if err != nil {
+            return nil, err
+        }
+        codes[i] = code
+        stored[i] = &models.RecoveryCode{CodeHash: hash, CodeLookup: s.lookup(normalizeCode(code))}
+    }
+    if err := s.store.ReplaceRecoveryCodes(ctx, userID, stored); err != nil {
+        return nil, err
+    }
+
+    s.record(ctx, userID, models.SecurityRecoveryCodesGenerated, origin, "")
+    return codes, nil
+}
+
+// RedeemCode signs a user in with one of their backup codes, using it
+// up. Failures count towards a lockout per username, and every attempt
+// towards a limit per address.
+func (s *Service) RedeemCode(ctx context.Context, username, code string, origin Origin) (*auth.TokenPair, error) {
+    key := "recovery:" + strings.ToLower(username)
+    if s.auth.IsBlocked(key) {
+        return nil, ErrTooManyAttempts
+    }
+    if origin.IP != "" {
+        result, err := s.limiter.Allow(ctx, "recovery_ip:"+origin.IP, addressAttempts)
+        if err != nil {
+            return nil, fmt.Errorf("failed to check recovery attempts: %w", err)
+        }
+        if !result.Allowed {
+            return nil, ErrTooManyAttempts
+        }
+    }
+
+    user, codeID, err := s.matchCode(ctx, username, normalizeCode(code))
+    if err != nil {
+        return nil, err
+    }
+    if codeID == "" {
+        if s.auth.TrackLoginAttempt(key, false) != nil {
+            return nil, ErrTooManyAttempts
+        }
+        return nil, ErrInvalid
+    }
+    used, err := s.store.UseRecoveryCode(ctx, codeID, time.Now())
+    if err != nil {
+        return nil, err
+    }
+    if !used {
+        // Redeemed by a concurrent request
+        return nil, ErrInvalid
+    }
+    s.auth.TrackLoginAttempt(key, true)
+
+    return s.signIn(ctx, user, origin, "Backup code")
+}
+
+// matchCode finds the unused backup code of the named user matching
+// code; an empty ID if there is none. Only the code whose lookup digest
+// matches, or one without a digest, has its hash checked.
+func (s *Service) matchCode(ctx context.Context, username, code string) (*models.User, string, error) {
+    user, err := s.store.GetUserByUsername(ctx, username)
+    if err != nil {
+        return nil, "", nil
+    }
+    codes, err := s.store.GetUnusedRecoveryCodes(ctx, user.ID)
+    if err != nil {
+        return nil, "", err
+    }
+    lookup := s.lookup(code)
+    for _, stored := range codes {
+        if stored.CodeLookup != "" && !hmac.Equal([]byte(stored.CodeLookup), []byte(lookup)) {
+            continue
+        }
+        ok, err := s.auth.VerifyPassword(stored.CodeHash, code)
+        if err != nil {
+            return nil, "", err
+        }
+        if ok {
+            return user, stored.ID, nil
+        }
+    }
+    return nil, "", nil
+}
+
+// SetEmail sends a confirmation link to a new recovery email. The
+// address replaces the current one once the link is followed.
+func (s *Service) SetEmail(ctx context.Context, userID, email string) error {
+    if s.mail == nil {
+        return ErrMailDisabled
+    }
+    addr, err := netmail.ParseAddress(email)
+    if err != nil || addr.Address != email {
+        return ErrInvalidEmail
+    }
+
+    secret, err := s.issue(ctx, &models.RecoveryToken{
+        UserID:    userID,
+        Purpose:   models.RecoveryVerifyEmail,
+        Email:     email,
+        ExpiresAt: time.Now().Add(verifyTTL),
+    })
+    if err != nil {
+        return err
+    }
+    return s.mail.Send(ctx, email, "Confirm your recovery email",
+        "Follow this link to use this address to recover your account:\n\n"+
+            s.link(secret)+"\n\nIt expires in 24 hours. If you did not ask for this, ignore this email.")
+}
+
+// VerifyEmail makes the address a confirmation link was sent to the
+// user's recovery email. The previous address, if any, is told.
+func (s *Service) VerifyEmail(ctx context.Context, secret string, origin Origin) error {
+    token, err := s.redeem(ctx, secret, models.RecoveryVerifyEmail)
+    if err != nil {
+        return err
+    }
+    previous, err := s.store.GetRecoveryEmail(ctx, token.UserID)
+    if err != nil {
+        return err
+    }
+    if err := s.store.SetRecoveryEmail(ctx, token.UserID, token.Email, time.Now()); err != nil {
+        return err
+    }
+
+    s.record(ctx, token.UserID, models.SecurityRecoveryEmailChanged, origin, "Set to "+token.Email)
+    if previous != "" && previous != token.Email {
+        s.notify(ctx, token.UserID, previous, "Your recovery email was changed",
+            "Your account's recovery email was changed to "+token.Email+".\n\n"+
+                "If you did not do this, contact support.")
+    }
+    return nil
+}
+
+// ClearEmail removes the user's recovery email.
+func (s *Service) ClearEmail(ctx context.Context, userID string, origin Origin) error {
+    if err := s.store.SetRecoveryEmail(ctx, userID, "", time.Time{}); err != nil {
+        return err
+    }
+    s.record(ctx, userID, models.SecurityRecoveryEmailChanged, origin, "Removed")
+    return nil
+}
+
+// RequestEmailRecovery sends a recovery link to the named user's
+// recovery email. It says nothing about whether the user exists or has
+// one; failures after that point are only logged.
+func (s *Service) RequestEmailRecovery(ctx context.Context, username string) error {
+    if s.mail == nil {
+        return ErrMailDisabled
+    }
+    user, err := s.store.GetUserByUsername(ctx, username)
+    if err != nil {
+        return nil
+    }
+    email, err := s.store.GetRecoveryEmail(ctx, user.ID)
+    if err != nil || email == "" {
+        if err != nil {
+            s.logger.Error("Failed to load recovery email", zap.Error(err), zap.String("user_id", user.ID))
+        }
+        return nil
+    }
+
+    secret, err := s.issue(ctx, &models.RecoveryToken{
+        UserID:    user.ID,
+        Purpose:   models.RecoveryByEmail,
+        ExpiresAt: time.Now().Add(emailTTL),
+    })
+    if err != nil {
+        s.logger.Error("Failed to issue recovery token", zap.Error(err), zap.String("user_id", user.ID))
+        return nil
+    }
+    s.notify(ctx, user.ID, email, "Recover your account",
+        "Follow this link to sign in to "+user.Username+":\n\n"+s.link(secret)+
+            "\n\nIt expires in 30 minutes. If you did not ask for this, ignore this email.")
+    return nil
+}
+
+// Assist issues a recovery link for support to hand to a user whose
+// identity they checked. The reason is mandatory and is written to the
+// user's security log before the link exists: if it cannot be recorded,
+// no link is issued.
+func (s *Service) Assist(ctx context.Context, actor Actor, userID, reason string, origin Origin) (string, *models.RecoveryToken, error) {
+    reason = strings.TrimSpace(reason)
+    if reason == "" {
+        return "", nil, ErrReasonRequired
+    }
+    if len(reason) > maxReasonLength {
+        reason = reason[:maxReasonLength]
+    }
+    err := s.store.RecordSecurityEvent(ctx, &models.SecurityEvent{
+        UserID:  userID,
+        Kind:    models.SecurityAssistedRecovery,
+        IP:      origin.IP,
+        Country: origin.Country,
+        Device:  origin.Device,
+        Detail:  "Issued by " + actor.Username + ": " + reason,
+    })
+    if err != nil {
+        return "", nil, fmt.Errorf("failed to audit assisted recovery: %w", err)
+    }
+
+    token := &models.RecoveryToken{
+        UserID:    userID,
+        Purpose:   models.RecoveryAssisted,
+        IssuedBy:  actor.UserID,
+        Reason:    reason,
+        ExpiresAt: time.Now().Add(assistedTTL),
+    }
+    secret, err := s.issue(ctx, token)
+    if err != nil {
+        return "", nil, err
+    }
+    s.logger.Info("Assisted recovery issued",
+        zap.String("user_id", userID),
+        zap.String("issued_by", actor.UserID),
+        zap.String("token_id", token.ID))
+    return s.link(secret), token, nil
+}
+
+// RedeemToken signs a user in with a recovery link, sent to their
+// recovery email or issued by support, using it up.
+func (s *Service) RedeemToken(ctx context.Context, secret string, origin Origin) (*auth.TokenPair, error) {
+    token, err := s.redeem(ctx, secret, models.RecoveryByEmail, models.RecoveryAssisted)
+    if err != nil {
+        return nil, err
+    }
+    user, err := s.store.GetUser(ctx, token.UserID)
+    if err != nil {
+        return nil, ErrInvalid
+    }
+
+    detail := "Recovery email"
+    if token.Purpose == models.RecoveryAssisted {
+        detail = "Support-assisted, token " + token.ID
+    }
+    return s.signIn(ctx, user, origin, detail)
+}
+
+// signIn issues a session to a user who recovered their account.
+// Suspended accounts stay locked out.
+func (s *Service) signIn(ctx context.Context, user *models.User, origin Origin, detail string) (*auth.TokenPair, error) {
+    if user.BannedAt != nil || user.DeactivatedAt != nil {
+        return nil, auth.ErrUserBlocked
+    }
+    pair, err := s.auth.GenerateTokenPair(user)
+    if err != nil {
+        return nil, err
+    }
+    s.record(ctx, user.ID, models.SecurityAccountRecovered, origin, detail)
+    s.logger.Info("Account recovered", zap.String("user_id", user.ID), zap.String("method", detail))
+    return pair, nil
+}
+
+// issue stores a token under the hash of a new secret and returns the
+// secret.
+func (s *Service) issue(ctx context.Context, token *models.RecoveryToken) (string, error) {
+    secret, err := newSecret()
+    if err != nil {
+        return "", err
+    }
+    token.TokenHash = hashSecret(secret)
+    if err := s.store.CreateRecoveryToken(ctx, token); err != nil {
+        return "", err
+    }
+    return secret, nil
+}
+
+// redeem uses up the token a secret stands for, if it has one of the
+// purposes and is still valid.
+func (s *Service) redeem(ctx context.Context, secret string, purposes ...string) (*models.RecoveryToken, error) {
+    if secret == "" {
+        return nil, ErrInvalid
+    }
+    token, err := s.store.GetRecoveryToken(ctx, hashSecret(secret))
+    if err != nil {
+        return nil, ErrInvalid
+    }
+    now := time.Now()
+    if token.UsedAt != nil || !token.ExpiresAt.After(now) || !hasPurpose(token, purposes) {
+        return nil, ErrInvalid
+    }
+    used, err := s.store.UseRecoveryToken(ctx, token.ID, now)
+    if err != nil {
+        return nil, err
+    }
+    if !used {
+        return nil, ErrInvalid
+    }
+    return token, nil
+}
+
+// record writes an entry to the user's security log. Failing to write
+// it does not fail the change.
+func (s *Service) record(ctx context.Context, userID, kind string, origin Origin, detail string) {
+    err := s.store.RecordSecurityEvent(ctx, &models.SecurityEvent{
+        UserID:  userID,
+        Kind:    kind,
+        IP:      origin.IP,
+        Country: origin.Country,
+        Device:  origin.Device,
+        Detail:  detail,
+    })
+    if err != nil {
+        s.logger.Error("Failed to record security event",
+            zap.Error(err),
+            zap.String("user_id", userID),
+            zap.String("kind", kind))
+    }
+}
+
+// notify sends an email, logging rather than returning a failure.
+func (s *Service) notify(ctx context.Context, userID, to, subject, body string) {
+    if s.mail == nil {
+        return
+    }
+    if err := s.mail.Send(ctx, to, subject, body); err != nil {
+        s.logger.Warn("Failed to send recovery email", zap.Error(err), zap.String("user_id", userID))
+    }
+}
+
+func (s *Service) link(secret string) string {
+    return s.linkBase + "?token=" + secret
+}
+
+func hasPurpose(token *models.RecoveryToken, purposes []string) bool {
+    for _, purpose := range purposes {
+        if token.Purpose == purpose {
+            return true
+        }
+    }
+    return false
+}
+
+// newCode returns a backup code formatted as two groups of five.
+func newCode() (string, error) {
+    var b strings.Builder
+    max := big.NewInt(int64(len(codeAlphabet)))
+    for i := 0; i < codeLength; i++ {
+        if i == codeLength/2 {
+            b.WriteByte('-')
+        }
+        n, err := rand.Int(rand.Reader, max)
+        if err != nil {
+            return "", err
+        }
+        b.WriteByte(codeAlphabet[n.Int64()])
+    }
+    return b.String(), nil
+}
+
+// normalizeCode accepts codes typed in any case, with or without their
+// separator.
+func normalizeCode(code string) string {
+    code = strings.ToLower(code)
+    return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
+
+// lookup is the keyed digest a normalized backup code is found by.
+func (s *Service) lookup(code string) string {
+    mac := hmac.New(sha256.New, s.codeKey)
+    mac.Write([]byte(code))
+    return hex.EncodeToString(mac.Sum(nil))
+}
+
+func newSecret() (string, error) {
+    buf := make([]byte, 32)
+    if _, err := rand.Read(buf); err != nil {
+        return "", err
+    }
+    return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func hashSecret(secret string) string {
+    sum := sha256.Sum256([]byte(secret))
+    return hex.EncodeToString(sum[:])
+}
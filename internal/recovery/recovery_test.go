@@ -0,0 +1,119 @@
+package recovery
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "testing"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/auth"
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/ratelimit"
+    "github.com/yourusername/sports-chat/internal/store/memory"
+)
+
+func newTestService(t *testing.T) (*Service, *memory.Store) {
+    t.Helper()
+    st := memory.New()
+    authService := auth.NewService(auth.NewHMACKey("", []byte("test signing secret")), nil, zap.NewNop())
+    s := NewService(st, authService, nil, ratelimit.NewMemory(), []byte("0123456789abcdef0123456789abcdef"), "http://localhost/recover", zap.NewNop())
+    return s, st
+}
+
+// newTestUser creates a user holding a fresh set of backup codes.
+func newTestUser(t *testing.T, s *Service, st *memory.Store, username string) []string {
+    t.Helper()
+    user := &models.User{Username: username}
+    if err := st.CreateUser(context.Background(), user); err != nil {
+        t.Fatal(err)
+    }
+    codes, err := s.GenerateCodes(context.Background(), user.ID, Origin{})
+    if err != nil {
+        t.Fatal(err)
+    }
+    return codes
+}
+
+func TestRedeemCodeRejects(t *testing.T) {
+    ctx := context.Background()
+    s, st := newTestService(t)
+    codes := newTestUser(t, s, st, "rejects-owner")
+    otherCodes := newTestUser(t, s, st, "rejects-other")
+    used := codes[1]
+    if _, err := s.RedeemCode(ctx, "rejects-owner", used, Origin{}); err != nil {
+        t.Fatalf("redeeming a fresh code: %v", err)
+    }
+
+    tests := []struct {
+        name     string
+        username string
+        code     string
+    }{
+        {"wrong code", "rejects-owner", "aaaaa-aaaaa"},
+        {"empty code", "rejects-owner", ""},
+        {"another user's code", "rejects-owner", otherCodes[0]},
+        {"used code", "rejects-owner", used},
+        {"unknown user", "rejects-nobody", codes[0]},
+    }
+    for i, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            origin := Origin{IP: fmt.Sprintf("192.0.2.%d", i+1)}
+            pair, err := s.RedeemCode(ctx, tt.username, tt.code, origin)
+            if !errors.Is(err, ErrInvalid) {
+                t.Fatalf("got %v, want %v", err, ErrInvalid)
+            }
+            if pair != nil {
+                t.Fatal("tokens issued for a rejected code")
+            }
+        })
+    }
+
+    // Rejections use nothing up
+    if _, err := s.RedeemCode(ctx, "rejects-owner", codes[0], Origin{}); err != nil {
+        t.Fatalf("redeeming an unused code after rejections: %v", err)
+    }
+}
+
+func TestRedeemCodeLimitsAddress(t *testing.T) {
+    ctx := context.Background()
+    s, st := newTestService(t)
+    codes := newTestUser(t, s, st, "limited-owner")
+
+    // Spread over usernames, so no per-username lockout kicks in
+    origin := Origin{IP: "198.51.100.7"}
+    for i := 0; i < addressAttempts.Requests; i++ {
+        username := fmt.Sprintf("limited-guess-%d", i)
+        if _, err := s.RedeemCode(ctx, username, "aaaaa-aaaaa", origin); !errors.Is(err, ErrInvalid) {
+            t.Fatalf("attempt %d: got %v, want %v", i, err, ErrInvalid)
+        }
+    }
+    if _, err := s.RedeemCode(ctx, "limited-owner", codes[0], origin); !errors.Is(err, ErrTooManyAttempts) {
+        t.Fatalf("got %v, want %v", err, ErrTooManyAttempts)
+    }
+    if _, err := s.RedeemCode(ctx, "limited-owner", codes[0], Origin{IP: "198.51.100.8"}); err != nil {
+        t.Fatalf("redeeming from another address: %v", err)
+    }
+}
+
+// Codes generated before lookups were kept are still found by hash.
+func TestRedeemCodeWithoutLookup(t *testing.T) {
+    ctx := context.Background()
+    s, st := newTestService(t)
+    user := &models.User{Username: "legacy-owner"}
+    if err := st.CreateUser(ctx, user); err != nil {
+        t.Fatal(err)
+    }
+    hash, err := s.auth.HashPassword(normalizeCode("abcde-fghjk"))
+    if err != nil {
+        t.Fatal(err)
+    }
+    if err := st.ReplaceRecoveryCodes(ctx, user.ID, []*models.RecoveryCode{{CodeHash: hash}}); err != nil {
+        t.Fatal(err)
+    }
+
+    if _, err := s.RedeemCode(ctx, "legacy-owner", "ABCDE FGHJK", Origin{}); err != nil {
+        t.Fatalf("redeeming a code without a lookup: %v", err)
+    }
+}
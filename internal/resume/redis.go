@@ -0,0 +1,76 @@
+package resume
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "time"
+
+    "github.com/redis/go-redis/v9"
+)
+
+// Redis keeps each state as a JSON string expiring with it, so a
+// connection can be resumed on any instance.
+type Redis struct {
+    client *redis.Client
+    prefix string
+}
+
+func NewRedis(url, prefix string) (*Redis, error) {
+    opts, err := redis.ParseURL(url)
+    if err != nil {
+        return nil, fmt.Errorf("failed to parse redis url: %w", err)
+    }
+
+    client := redis.NewClient(opts)
+    if err := client.Ping(context.Background()).Err(); err != nil {
+        client.Close()
+        return nil, fmt.Errorf("failed to connect to redis: %w", err)
+    }
+
+    return &Redis{client: client, prefix: prefix + "resume:"}, nil
+}
+
+func (r *Redis) Save(ctx context.Context, token string, state *State, ttl time.Duration) error {
+    data, err := json.Marshal(state)
+    if err != nil {
+        return err
+    }
+    if err := r.client.Set(ctx, r.prefix+key(token), data, ttl).Err(); err != nil {
+        return fmt.Errorf("failed to save resume state: %w", err)
+    }
+    return nil
+}
+
+func (r *Redis) Refresh(ctx context.Context, token string, state *State, ttl time.Duration) (bool, error) {
+    data, err := json.Marshal(state)
+    if err != nil {
+        return false, err
+    }
+    saved, err := r.client.SetXX(ctx, r.prefix+key(token), data, ttl).Result()
+    if err != nil {
+        return false, fmt.Errorf("failed to refresh resume state: %w", err)
+    }
+    return saved, nil
+}
+
+func (r *Redis) Take(ctx context.Context, token string) (*State, error) {
+    data, err := r.client.GetDel(ctx, r.prefix+key(token)).Bytes()
+    if errors.Is(err, redis.Nil) {
+        return nil, nil
+    }
+    if err != nil {
+        return nil, fmt.Errorf("failed to take resume state: %w", err)
+    }
+
+    var state State
+    if err := json.Unmarshal(data, &state); err != nil {
+        return nil, fmt.Errorf("failed to decode resume state: %w", err)
+    }
+    return &state, nil
+}
+
+func (r *Redis) Close() error {
+    return r.client.Close()
+}
@@ -0,0 +1,133 @@
+// Package resume keeps what a websocket connection had going for it, so
+// a client that drops and reconnects shortly after can carry on where it
+// left off, on whichever instance it lands.
+package resume
+
+import (
+    "context"
+    "crypto/rand"
+    "crypto/sha256"
+    "encoding/base64"
+    "encoding/hex"
+    "sync"
+    "time"
+)
+
+// State is a connection's place, kept under its resume token.
+type State struct {
+    UserID string `json:"user_id"`
+    // ConnID is the connection the state belongs to
+    ConnID string   `json:"conn_id"`
+    Rooms  []string `json:"rooms"`
+    // Since is the last sequence per room the client is known to have
+    // received
+    Since map[string]int64 `json:"since,omitempty"`
+    // SlowModeUntil is when the user may next post in each slow mode
+    // room they posted to
+    SlowModeUntil map[string]time.Time `json:"slow_mode_until,omitempty"`
+}
+
+// Store keeps states until they expire or are taken.
+type Store interface {
+    // Save stores the state under token, replacing any there.
+    Save(ctx context.Context, token string, state *State, ttl time.Duration) error
+    // Refresh replaces the state under token only if there still is one,
+    // reporting false once it has been taken or has expired.
+    Refresh(ctx context.Context, token string, state *State, ttl time.Duration) (bool, error)
+    // Take removes and returns the state under token; nil if there is
+    // none. Only one caller gets a given state.
+    Take(ctx context.Context, token string) (*State, error)
+    Close() error
+}
+
+// NewToken returns a random resume token.
+func NewToken() (string, error) {
+    b := make([]byte, 32)
+    if _, err := rand.Read(b); err != nil {
+        return "", err
+    }
+    return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// key is what a token is stored under: its hash, so whoever can read the
+// store cannot resume connections with what they find there.
+func key(token string) string {
+    sum := sha256.Sum256([]byte(token))
+    return hex.EncodeToString(sum[:])
+}
+
+type entry struct {
+    state   *State
+    expires time.Time
+}
+
+// Local is the single-instance store. Expired states are dropped as
+// others are saved.
+type Local struct {
+    mu      sync.Mutex
+    entries map[string]*entry
+    swept   time.Time
+}
+
+func NewLocal() *Local {
+    return &Local{entries: make(map[string]*entry)}
+}
+
+func (l *Local) Save(ctx context.Context, token string, state *State, ttl time.Duration) error {
+    now := time.Now()
+
+    l.mu.Lock()
+    defer l.mu.Unlock()
+
+    l.sweep(now)
+    l.entries[key(token)] = &entry{state: state, expires: now.Add(ttl)}
+    return nil
+}
+
+func (l *Local) Refresh(ctx context.Context, token string, state *State, ttl time.Duration) (bool, error) {
+    now := time.Now()
+
+    l.mu.Lock()
+    defer l.mu.Unlock()
+
+    e, ok := l.entries[key(token)]
+    if !ok || !now.Before(e.expires) {
+        return false, nil
+    }
+    e.state, e.expires = state, now.Add(ttl)
+    return true, nil
+}
+
+func (l *Local) Take(ctx context.Context, token string) (*State, error) {
+    l.mu.Lock()
+    defer l.mu.Unlock()
+
+    k := key(token)
+    e, ok := l.entries[k]
+    if !ok {
+        return nil, nil
+    }
+    delete(l.entries, k)
+    if !time.Now().Before(e.expires) {
+        return nil, nil
+    }
+    return e.state, nil
+}
+
+// sweep drops expired states, at most once a minute. Must be called
+// with l.mu held.
+func (l *Local) sweep(now time.Time) {
+    if now.Sub(l.swept) < time.Minute {
+        return
+    }
+    l.swept = now
+    for k, e := range l.entries {
+        if !now.Before(e.expires) {
+            delete(l.entries, k)
+        }
+    }
+}
+
+func (l *Local) Close() error {
+    return nil
+}
@@ -0,0 +1,193 @@
+// Package retention moves old messages out of postgres. A scheduled job
+// writes each room's messages older than the retention period to object
+// storage as gzipped JSON lines, one UTC day at a time, and deletes them
+// from the database; Store reads them back for clients paging past the
+// cut.
+package retention
+
+import (
+    "bufio"
+    "bytes"
+    "compress/gzip"
+    "context"
+    "encoding/json"
+    "fmt"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/metrics"
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/objectstore"
+    "github.com/yourusername/sports-chat/internal/store"
+)
+
+// maxCandidates bounds the room days one run takes on; the next run
+// carries on where it stopped.
+const maxCandidates = 500
+
+// Job archives messages older than the retention period. Instances may
+// run it at once: archives are keyed by their first message, so a batch
+// archived twice overwrites the same object and is indexed once.
+type Job struct {
+    store     store.Store
+    bucket    objectstore.Bucket
+    retention time.Duration
+    batchSize int
+    metrics   *metrics.Metrics
+    logger    *zap.Logger
+}
+
+func NewJob(store store.Store, bucket objectstore.Bucket, retention time.Duration, batchSize int, metrics *metrics.Metrics, logger *zap.Logger) *Job {
+    return &Job{
+        store:     store,
+        bucket:    bucket,
+        retention: retention,
+        batchSize: batchSize,
+        metrics:   metrics,
+        logger:    logger,
+    }
+}
+
+func (j *Job) Name() string { return "retention.archive_messages" }
+
+// Run archives whole days only, so each archive holds one day of a room
+// and a day is never split across runs by the cutoff.
+func (j *Job) Run(ctx context.Context) error {
+    cutoff := time.Now().Add(-j.retention).UTC().Truncate(24 * time.Hour)
+    candidates, err := j.store.GetArchiveCandidates(ctx, cutoff, maxCandidates)
+    if err != nil {
+        return fmt.Errorf("failed to get archive candidates: %w", err)
+    }
+
+    archived := 0
+    for _, candidate := range candidates {
+        n, err := j.archiveDay(ctx, candidate)
+        archived += n
+        if err != nil {
+            return fmt.Errorf("failed to archive room %s on %s: %w", candidate.RoomID, candidate.Day.Format("2006-01-02"), err)
+        }
+    }
+
+    if archived > 0 {
+        j.logger.Info("Archived messages",
+            zap.Int("messages", archived),
+            zap.Int("room_days", len(candidates)),
+            zap.Time("cutoff", cutoff))
+    }
+    return nil
+}
+
+// archiveDay archives a room's day in batches, each its own object. Every
+// batch deletes what it archived, so the next one starts after it.
+func (j *Job) archiveDay(ctx context.Context, candidate store.ArchiveCandidate) (int, error) {
+    day := candidate.Day.UTC()
+    archived := 0
+    for ctx.Err() == nil {
+        messages, err := j.store.GetMessagesBetween(ctx, candidate.RoomID, day, day.AddDate(0, 0, 1), j.batchSize)
+        if err != nil {
+            return archived, err
+        }
+        if len(messages) == 0 {
+            break
+        }
+        if err := j.archiveBatch(ctx, candidate.RoomID, day, messages); err != nil {
+            return archived, err
+        }
+        archived += len(messages)
+        j.metrics.MessagesArchived.Add(float64(len(messages)))
+        if len(messages) < j.batchSize {
+            break
+        }
+    }
+    return archived, ctx.Err()
+}
+
+func (j *Job) archiveBatch(ctx context.Context, roomID string, day time.Time, messages []*models.Message) error {
+    ids := make([]string, len(messages))
+    for i, message := range messages {
+        ids[i] = message.ID
+    }
+    // Reactions are deleted with their messages, so their counts go along
+    reactions, err := j.store.GetMessageReactions(ctx, ids)
+    if err != nil {
+        return err
+    }
+    for _, message := range messages {
+        message.Reactions = reactions[message.ID]
+    }
+
+    body, err := Encode(messages)
+    if err != nil {
+        return err
+    }
+    archive := &models.MessageArchive{
+        RoomID:    roomID,
+        Day:       day,
+        ObjectKey: fmt.Sprintf("messages/%s/%s/%s.jsonl.gz", roomID, day.Format("2006/01/02"), messages[0].ID),
+        Messages:  len(messages),
+        Size:      len(body),
+        FirstAt:   messages[0].CreatedAt,
+        LastAt:    messages[len(messages)-1].CreatedAt,
+        UserIDs:   Senders(messages),
+    }
+    if err := j.bucket.Put(ctx, archive.ObjectKey, "application/gzip", body); err != nil {
+        return err
+    }
+    // Written after the object, so an indexed archive always has one
+    return j.store.CreateMessageArchive(ctx, archive, ids)
+}
+
+// Senders returns the distinct senders of messages, in the order first
+// seen.
+func Senders(messages []*models.Message) []string {
+    seen := make(map[string]bool)
+    senders := []string{}
+    for _, message := range messages {
+        if message.UserID != "" && !seen[message.UserID] {
+            seen[message.UserID] = true
+            senders = append(senders, message.UserID)
+        }
+    }
+    return senders
+}
+
+// Encode writes messages as gzipped JSON lines, in the order given.
+func Encode(messages []*models.Message) ([]byte, error) {
+    var buf bytes.Buffer
+    zw := gzip.NewWriter(&buf)
+    enc := json.NewEncoder(zw)
+    for _, message := range messages {
+        if err := enc.Encode(message); err != nil {
+            return nil, fmt.Errorf("failed to encode archive: %w", err)
+        }
+    }
+    if err := zw.Close(); err != nil {
+        return nil, fmt.Errorf("failed to encode archive: %w", err)
+    }
+    return buf.Bytes(), nil
+}
+
+// Decode reads an archive written by Encode.
+func Decode(body []byte) ([]*models.Message, error) {
+    zr, err := gzip.NewReader(bytes.NewReader(body))
+    if err != nil {
+        return nil, fmt.Errorf("failed to decode archive: %w", err)
+    }
+    defer zr.Close()
+
+    var messages []*models.Message
+    scanner := bufio.NewScanner(zr)
+    scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+    for scanner.Scan() {
+        message := &models.Message{}
+        if err := json.Unmarshal(scanner.Bytes(), message); err != nil {
+            return nil, fmt.Errorf("failed to decode archive: %w", err)
+        }
+        messages = append(messages, message)
+    }
+    if err := scanner.Err(); err != nil {
+        return nil, fmt.Errorf("failed to decode archive: %w", err)
+    }
+    return messages, nil
+}
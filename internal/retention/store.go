@@ -0,0 +1,142 @@
+package retention
+
+import (
+    "context"
+    "sync"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/objectstore"
+    "github.com/yourusername/sports-chat/internal/store"
+)
+
+const (
+    // maxArchivesPerPage bounds the archives one history page reads, for
+    // filters that match little
+    maxArchivesPerPage = 10
+    // cachedArchives is how many decoded archives are kept, so a client
+    // paging back reads each object once
+    cachedArchives = 16
+)
+
+// Store wraps the primary store and, where a room's history in postgres
+// runs out, continues it from the room's archives, so clients paging
+// back do not see the retention cut. Catching up after a cursor reads
+// postgres alone; archived messages are too old to be missed that way.
+type Store struct {
+    store.Store
+    bucket objectstore.Bucket
+    logger *zap.Logger
+
+    mu    sync.Mutex
+    cache map[string][]*models.Message
+    order []string
+}
+
+func NewStore(primary store.Store, bucket objectstore.Bucket, logger *zap.Logger) *Store {
+    return &Store{
+        Store:  primary,
+        bucket: bucket,
+        logger: logger,
+        cache:  make(map[string][]*models.Message),
+    }
+}
+
+func (s *Store) GetRecentMessages(ctx context.Context, roomID string, limit int) ([]*models.Message, error) {
+    return s.GetMessagesBeforeCursor(ctx, roomID, store.MessageFilter{}, nil, limit)
+}
+
+// GetMessagesBeforeCursor fills a short page from the archives. Failing to
+// read them only shortens the page.
+func (s *Store) GetMessagesBeforeCursor(ctx context.Context, roomID string, filter store.MessageFilter, cursor *store.MessageCursor, limit int) ([]*models.Message, error) {
+    messages, err := s.Store.GetMessagesBeforeCursor(ctx, roomID, filter, cursor, limit)
+    if err != nil || len(messages) >= limit {
+        return messages, err
+    }
+
+    before := cursor
+    if n := len(messages); n > 0 {
+        before = &store.MessageCursor{CreatedAt: messages[n-1].CreatedAt, ID: messages[n-1].ID}
+    }
+    older, err := s.archived(ctx, roomID, filter, before, limit-len(messages))
+    if err != nil {
+        s.logger.Warn("Failed to read archived messages", zap.Error(err), zap.String("room", roomID))
+        return messages, nil
+    }
+    return append(messages, older...), nil
+}
+
+// archived returns up to limit archived messages before the cursor,
+// newest first.
+func (s *Store) archived(ctx context.Context, roomID string, filter store.MessageFilter, before *store.MessageCursor, limit int) ([]*models.Message, error) {
+    upTo := time.Now()
+    if before != nil {
+        upTo = before.CreatedAt
+    }
+    archives, err := s.Store.ListMessageArchives(ctx, roomID, upTo, maxArchivesPerPage)
+    if err != nil {
+        return nil, err
+    }
+
+    var messages []*models.Message
+    for _, archive := range archives {
+        archived, err := s.load(ctx, archive)
+        if err != nil {
+            return messages, err
+        }
+        for i := len(archived) - 1; i >= 0 && len(messages) < limit; i-- {
+            message := archived[i]
+            if before != nil && !olderThan(message, before) {
+                continue
+            }
+            if filter.Matches(message) {
+                messages = append(messages, message)
+            }
+        }
+        if len(messages) >= limit {
+            break
+        }
+    }
+    return messages, nil
+}
+
+func (s *Store) load(ctx context.Context, archive *models.MessageArchive) ([]*models.Message, error) {
+    s.mu.Lock()
+    messages, ok := s.cache[archive.ObjectKey]
+    s.mu.Unlock()
+    if ok {
+        return messages, nil
+    }
+
+    body, err := s.bucket.Get(ctx, archive.ObjectKey)
+    if err != nil {
+        return nil, err
+    }
+    messages, err = Decode(body)
+    if err != nil {
+        return nil, err
+    }
+
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    if _, ok := s.cache[archive.ObjectKey]; !ok {
+        if len(s.order) >= cachedArchives {
+            delete(s.cache, s.order[0])
+            s.order = s.order[1:]
+        }
+        s.cache[archive.ObjectKey] = messages
+        s.order = append(s.order, archive.ObjectKey)
+    }
+    return messages, nil
+}
+
+// olderThan orders messages as history pages do: by creation time, then
+// ID.
+func olderThan(message *models.Message, cursor *store.MessageCursor) bool {
+    if !message.CreatedAt.Equal(cursor.CreatedAt) {
+        return message.CreatedAt.Before(cursor.CreatedAt)
+    }
+    return message.ID < cursor.ID
+}
@@ -0,0 +1,57 @@
+// Package rollups keeps room and match statistics precomputed. Counting a
+// busy room's messages on every request scans the messages table, so a
+// scheduled job rolls the counters up into summary tables and the
+// statistics routes read those, saying how fresh they are.
+package rollups
+
+import (
+    "context"
+    "fmt"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/store"
+)
+
+// recentMatches is how long after kickoff a match's rollup is refreshed
+// every run; older matches only change on the nightly full run.
+const recentMatches = 48 * time.Hour
+
+// Job refreshes the statistics rollups: those of active rooms and recent
+// matches, or with full, of every room and match. Instances may run it
+// at once, as each run overwrites the rollups with the same counts.
+type Job struct {
+    store  store.Store
+    full   bool
+    logger *zap.Logger
+}
+
+func NewJob(store store.Store, full bool, logger *zap.Logger) *Job {
+    return &Job{store: store, full: full, logger: logger}
+}
+
+func (j *Job) Name() string {
+    if j.full {
+        return "statistics.rollup_full"
+    }
+    return "statistics.rollup"
+}
+
+func (j *Job) Run(ctx context.Context) error {
+    rooms, matches, err := j.store.RefreshStatisticsRollups(ctx, time.Now().Add(-recentMatches), j.full)
+    if err != nil {
+        return fmt.Errorf("failed to refresh statistics rollups: %w", err)
+    }
+    j.logger.Debug("Refreshed statistics rollups",
+        zap.Bool("full", j.full),
+        zap.Int("rooms", rooms),
+        zap.Int("matches", matches))
+    return nil
+}
+
+// Fresh reports whether a rollup computed at computedAt is recent enough
+// to serve, given how often the job runs: a missed run is tolerated.
+func Fresh(computedAt time.Time, interval time.Duration) bool {
+    return time.Since(computedAt) <= 2*interval
+}
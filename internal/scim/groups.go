@@ -0,0 +1,276 @@
+package scim
+
+import (
+    "context"
+    "encoding/json"
+    "net/http"
+    "regexp"
+    "strings"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+func (h *Handler) listGroups(w http.ResponseWriter, r *http.Request) {
+    startIndex, count := pagination(r)
+
+    groups, err := h.store.ListDirectoryGroups(r.Context())
+    if err != nil {
+        h.logger.Error("Failed to list directory groups", zap.Error(err))
+        h.respondError(w, http.StatusInternalServerError, "", "Failed to list groups")
+        return
+    }
+
+    if filter := r.URL.Query().Get("filter"); filter != "" {
+        attr, value, ok := parseFilter(filter)
+        if !ok || (attr != "displayName" && attr != "externalId") {
+            h.respondError(w, http.StatusBadRequest, "invalidFilter", "Groups can be filtered by displayName or externalId")
+            return
+        }
+        matched := groups[:0]
+        for _, g := range groups {
+            if (attr == "displayName" && g.DisplayName == value) || (attr == "externalId" && g.ExternalID == value) {
+                matched = append(matched, g)
+            }
+        }
+        groups = matched
+    }
+
+    total := len(groups)
+    if startIndex-1 < len(groups) {
+        groups = groups[startIndex-1:]
+    } else {
+        groups = nil
+    }
+    if len(groups) > count {
+        groups = groups[:count]
+    }
+
+    resources := make([]*groupResource, 0, len(groups))
+    for _, g := range groups {
+        resources = append(resources, newGroupResource(g))
+    }
+
+    h.respondJSON(w, http.StatusOK, &listResponse{
+        Schemas:      []string{schemaListResponse},
+        TotalResults: total,
+        StartIndex:   startIndex,
+        ItemsPerPage: len(resources),
+        Resources:    resources,
+    })
+}
+
+func (h *Handler) getGroup(w http.ResponseWriter, r *http.Request) {
+    group, err := h.store.GetDirectoryGroup(r.Context(), r.PathValue("id"))
+    if err != nil {
+        h.respondError(w, http.StatusNotFound, "", "Group not found")
+        return
+    }
+    h.respondJSON(w, http.StatusOK, newGroupResource(group))
+}
+
+func (h *Handler) createGroup(w http.ResponseWriter, r *http.Request) {
+    var req groupResource
+    if err := h.decodeJSON(r, &req); err != nil || strings.TrimSpace(req.DisplayName) == "" {
+        h.respondError(w, http.StatusBadRequest, "invalidValue", "displayName is required")
+        return
+    }
+
+    now := time.Now()
+    group := &models.DirectoryGroup{
+        ExternalID:  req.ExternalID,
+        DisplayName: req.DisplayName,
+        MemberIDs:   memberIDs(req.Members),
+        CreatedAt:   now,
+        UpdatedAt:   now,
+    }
+    if err := h.store.CreateDirectoryGroup(r.Context(), group); err != nil {
+        h.logger.Error("Failed to create directory group", zap.Error(err), zap.String("group", req.DisplayName))
+        h.respondError(w, http.StatusInternalServerError, "", "Failed to create group")
+        return
+    }
+
+    h.syncRoles(r.Context(), nil, group.MemberIDs)
+    h.respondJSON(w, http.StatusCreated, newGroupResource(group))
+}
+
+func (h *Handler) replaceGroup(w http.ResponseWriter, r *http.Request) {
+    group, err := h.store.GetDirectoryGroup(r.Context(), r.PathValue("id"))
+    if err != nil {
+        h.respondError(w, http.StatusNotFound, "", "Group not found")
+        return
+    }
+
+    var req groupResource
+    if err := h.decodeJSON(r, &req); err != nil || strings.TrimSpace(req.DisplayName) == "" {
+        h.respondError(w, http.StatusBadRequest, "invalidValue", "displayName is required")
+        return
+    }
+
+    previous := group.MemberIDs
+    group.DisplayName = req.DisplayName
+    group.ExternalID = req.ExternalID
+    group.MemberIDs = memberIDs(req.Members)
+    h.saveGroup(w, r, group, previous)
+}
+
+// memberFilter matches the path identity providers use to remove a single
+// member: members[value eq "<id>"].
+var memberFilter = regexp.MustCompile(`^members\[value eq "([^"]+)"\]$`)
+
+// patchGroup handles membership changes and renames.
+func (h *Handler) patchGroup(w http.ResponseWriter, r *http.Request) {
+    group, err := h.store.GetDirectoryGroup(r.Context(), r.PathValue("id"))
+    if err != nil {
+        h.respondError(w, http.StatusNotFound, "", "Group not found")
+        return
+    }
+
+    var req patchRequest
+    if err := h.decodeJSON(r, &req); err != nil {
+        h.respondError(w, http.StatusBadRequest, "invalidSyntax", "Invalid patch request")
+        return
+    }
+
+    previous := append([]string(nil), group.MemberIDs...)
+    members := make(map[string]bool, len(group.MemberIDs))
+    for _, id := range group.MemberIDs {
+        members[id] = true
+    }
+
+    for _, op := range req.Operations {
+        kind := strings.ToLower(op.Op)
+        switch {
+        case op.Path == "members" || (op.Path == "" && kind == "add"):
+            var refs []reference
+            if op.Path == "" {
+                var partial groupResource
+                if err := json.Unmarshal(op.Value, &partial); err != nil {
+                    h.respondError(w, http.StatusBadRequest, "invalidValue", "Invalid members")
+                    return
+                }
+                refs = partial.Members
+            } else if len(op.Value) > 0 {
+                if err := json.Unmarshal(op.Value, &refs); err != nil {
+                    h.respondError(w, http.StatusBadRequest, "invalidValue", "Invalid members")
+                    return
+                }
+            }
+
+            switch kind {
+            case "add":
+            case "replace":
+                members = make(map[string]bool)
+            case "remove":
+                // Removing "members" without a value empties the group
+                if len(refs) == 0 {
+                    members = make(map[string]bool)
+                }
+                for _, ref := range refs {
+                    delete(members, ref.Value)
+                }
+                continue
+            default:
+                h.respondError(w, http.StatusBadRequest, "invalidSyntax", "Unsupported operation "+op.Op)
+                return
+            }
+            for _, ref := range refs {
+                members[ref.Value] = true
+            }
+
+        case memberFilter.MatchString(op.Path) && kind == "remove":
+            delete(members, memberFilter.FindStringSubmatch(op.Path)[1])
+
+        case op.Path == "displayName" && kind == "replace":
+            if err := json.Unmarshal(op.Value, &group.DisplayName); err != nil || group.DisplayName == "" {
+                h.respondError(w, http.StatusBadRequest, "invalidValue", "Invalid displayName")
+                return
+            }
+
+        case op.Path == "" && kind == "replace":
+            var partial struct {
+                DisplayName string `json:"displayName"`
+                ExternalID  string `json:"externalId"`
+            }
+            if err := json.Unmarshal(op.Value, &partial); err != nil {
+                h.respondError(w, http.StatusBadRequest, "invalidValue", "Invalid patch value")
+                return
+            }
+            if partial.DisplayName != "" {
+                group.DisplayName = partial.DisplayName
+            }
+            if partial.ExternalID != "" {
+                group.ExternalID = partial.ExternalID
+            }
+
+        default:
+            h.respondError(w, http.StatusBadRequest, "invalidPath", "Unsupported patch path "+op.Path)
+            return
+        }
+    }
+
+    group.MemberIDs = group.MemberIDs[:0]
+    for id := range members {
+        group.MemberIDs = append(group.MemberIDs, id)
+    }
+    h.saveGroup(w, r, group, previous)
+}
+
+func (h *Handler) saveGroup(w http.ResponseWriter, r *http.Request, group *models.DirectoryGroup, previous []string) {
+    group.UpdatedAt = time.Now()
+    if err := h.store.UpdateDirectoryGroup(r.Context(), group); err != nil {
+        h.logger.Error("Failed to update directory group", zap.Error(err), zap.String("group_id", group.ID))
+        h.respondError(w, http.StatusInternalServerError, "", "Failed to update group")
+        return
+    }
+
+    // A rename can change the role, so current members are resynced too
+    h.syncRoles(r.Context(), previous, group.MemberIDs)
+    h.respondJSON(w, http.StatusOK, newGroupResource(group))
+}
+
+func (h *Handler) deleteGroup(w http.ResponseWriter, r *http.Request) {
+    group, err := h.store.GetDirectoryGroup(r.Context(), r.PathValue("id"))
+    if err != nil {
+        h.respondError(w, http.StatusNotFound, "", "Group not found")
+        return
+    }
+    if err := h.store.DeleteDirectoryGroup(r.Context(), group.ID); err != nil {
+        h.logger.Error("Failed to delete directory group", zap.Error(err), zap.String("group_id", group.ID))
+        h.respondError(w, http.StatusInternalServerError, "", "Failed to delete group")
+        return
+    }
+
+    h.syncRoles(r.Context(), group.MemberIDs, nil)
+    w.WriteHeader(http.StatusNoContent)
+}
+
+// syncRoles recomputes the role of everyone who was or is in a group.
+// Failures are logged rather than returned: the membership change itself
+// has been stored, and the next change to the user resyncs them.
+func (h *Handler) syncRoles(ctx context.Context, before, after []string) {
+    seen := make(map[string]bool, len(before)+len(after))
+    for _, ids := range [][]string{before, after} {
+        for _, id := range ids {
+            if seen[id] {
+                continue
+            }
+            seen[id] = true
+            if err := h.applyRoles(ctx, id); err != nil {
+                h.logger.Warn("Failed to sync directory roles", zap.Error(err), zap.String("user_id", id))
+            }
+        }
+    }
+}
+
+func memberIDs(refs []reference) []string {
+    ids := make([]string, 0, len(refs))
+    for _, ref := range refs {
+        if ref.Value != "" {
+            ids = append(ids, ref.Value)
+        }
+    }
+    return ids
+}
@@ -0,0 +1,141 @@
+// Package scim implements a SCIM 2.0 (RFC 7643/7644) endpoint so an
+// enterprise identity provider can provision and deprovision users and
+// manage the groups that grant chat roles.
+package scim
+
+import (
+    "crypto/subtle"
+    "encoding/json"
+    "net/http"
+    "regexp"
+    "strconv"
+    "strings"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/events"
+    "github.com/yourusername/sports-chat/internal/store"
+)
+
+const maxPageSize = 200
+
+type Handler struct {
+    store  store.Store
+    events *events.Bus
+    token  string
+    roles  RoleMap
+    logger *zap.Logger
+    mux    *http.ServeMux
+}
+
+// NewHandler serves SCIM requests authenticated with a static bearer
+// token, the scheme identity providers support most widely.
+func NewHandler(store store.Store, bus *events.Bus, token string, roles RoleMap, logger *zap.Logger) *Handler {
+    h := &Handler{
+        store:  store,
+        events: bus,
+        token:  token,
+        roles:  roles,
+        logger: logger,
+        mux:    http.NewServeMux(),
+    }
+    h.routes()
+    return h
+}
+
+func (h *Handler) routes() {
+    h.mux.HandleFunc("GET /ServiceProviderConfig", h.getServiceProviderConfig)
+
+    h.mux.HandleFunc("GET /Users", h.listUsers)
+    h.mux.HandleFunc("POST /Users", h.createUser)
+    h.mux.HandleFunc("GET /Users/{id}", h.getUser)
+    h.mux.HandleFunc("PUT /Users/{id}", h.replaceUser)
+    h.mux.HandleFunc("PATCH /Users/{id}", h.patchUser)
+    h.mux.HandleFunc("DELETE /Users/{id}", h.deleteUser)
+
+    h.mux.HandleFunc("GET /Groups", h.listGroups)
+    h.mux.HandleFunc("POST /Groups", h.createGroup)
+    h.mux.HandleFunc("GET /Groups/{id}", h.getGroup)
+    h.mux.HandleFunc("PUT /Groups/{id}", h.replaceGroup)
+    h.mux.HandleFunc("PATCH /Groups/{id}", h.patchGroup)
+    h.mux.HandleFunc("DELETE /Groups/{id}", h.deleteGroup)
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+    token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+    if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(h.token)) != 1 {
+        h.respondError(w, http.StatusUnauthorized, "", "Invalid bearer token")
+        return
+    }
+    h.mux.ServeHTTP(w, r)
+}
+
+func (h *Handler) getServiceProviderConfig(w http.ResponseWriter, r *http.Request) {
+    supported := func(ok bool) map[string]bool { return map[string]bool{"supported": ok} }
+    h.respondJSON(w, http.StatusOK, map[string]interface{}{
+        "schemas":        []string{schemaSPConfig},
+        "patch":          supported(true),
+        "bulk":           map[string]interface{}{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+        "filter":         map[string]interface{}{"supported": true, "maxResults": maxPageSize},
+        "changePassword": supported(false),
+        "sort":           supported(false),
+        "etag":           supported(false),
+        "authenticationSchemes": []map[string]string{{
+            "type": "oauthbearertoken",
+            "name": "Bearer token",
+        }},
+    })
+}
+
+// eqFilter is the only filter form identity providers rely on for
+// provisioning: a single attribute compared for equality.
+var eqFilter = regexp.MustCompile(`^(\w+) eq "((?:[^"\\]|\\.)*)"$`)
+
+func parseFilter(filter string) (attr, value string, ok bool) {
+    m := eqFilter.FindStringSubmatch(strings.TrimSpace(filter))
+    if m == nil {
+        return "", "", false
+    }
+    value, err := strconv.Unquote(`"` + m[2] + `"`)
+    if err != nil {
+        return "", "", false
+    }
+    return m[1], value, true
+}
+
+// pagination reads SCIM's 1-based startIndex and count.
+func pagination(r *http.Request) (startIndex, count int) {
+    startIndex, _ = strconv.Atoi(r.URL.Query().Get("startIndex"))
+    if startIndex < 1 {
+        startIndex = 1
+    }
+    count, err := strconv.Atoi(r.URL.Query().Get("count"))
+    if err != nil || count > maxPageSize {
+        count = maxPageSize
+    }
+    if count < 0 {
+        count = 0
+    }
+    return startIndex, count
+}
+
+func (h *Handler) decodeJSON(r *http.Request, v interface{}) error {
+    return json.NewDecoder(http.MaxBytesReader(nil, r.Body, 1<<20)).Decode(v)
+}
+
+func (h *Handler) respondJSON(w http.ResponseWriter, status int, data interface{}) {
+    w.Header().Set("Content-Type", "application/scim+json")
+    w.WriteHeader(status)
+    if err := json.NewEncoder(w).Encode(data); err != nil {
+        h.logger.Error("Failed to encode SCIM response", zap.Error(err))
+    }
+}
+
+func (h *Handler) respondError(w http.ResponseWriter, status int, scimType, detail string) {
+    h.respondJSON(w, status, &errorResponse{
+        Schemas:  []string{schemaError},
+        Status:   strconv.Itoa(status),
+        ScimType: scimType,
+        Detail:   detail,
+    })
+}
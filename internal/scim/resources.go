@@ -0,0 +1,140 @@
+package scim
+
+import (
+    "encoding/json"
+    "time"
+
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+const (
+    schemaUser         = "urn:ietf:params:scim:schemas:core:2.0:User"
+    schemaGroup        = "urn:ietf:params:scim:schemas:core:2.0:Group"
+    schemaListResponse = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+    schemaPatchOp      = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+    schemaError        = "urn:ietf:params:scim:api:messages:2.0:Error"
+    schemaSPConfig     = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+)
+
+type meta struct {
+    ResourceType string    `json:"resourceType"`
+    Created      time.Time `json:"created"`
+    LastModified time.Time `json:"lastModified"`
+    Location     string    `json:"location"`
+}
+
+type email struct {
+    Value   string `json:"value"`
+    Primary bool   `json:"primary,omitempty"`
+}
+
+type reference struct {
+    Value   string `json:"value"`
+    Display string `json:"display,omitempty"`
+}
+
+// userResource is the SCIM core User. Only the attributes the chat has a
+// place for are mapped; anything else an identity provider sends is
+// accepted and ignored.
+type userResource struct {
+    Schemas    []string    `json:"schemas"`
+    ID         string      `json:"id,omitempty"`
+    ExternalID string      `json:"externalId,omitempty"`
+    UserName   string      `json:"userName"`
+    Active     *bool       `json:"active,omitempty"`
+    Emails     []email     `json:"emails,omitempty"`
+    Groups     []reference `json:"groups,omitempty"`
+    Meta       *meta       `json:"meta,omitempty"`
+}
+
+type groupResource struct {
+    Schemas     []string    `json:"schemas"`
+    ID          string      `json:"id,omitempty"`
+    ExternalID  string      `json:"externalId,omitempty"`
+    DisplayName string      `json:"displayName"`
+    Members     []reference `json:"members"`
+    Meta        *meta       `json:"meta,omitempty"`
+}
+
+type listResponse struct {
+    Schemas      []string    `json:"schemas"`
+    TotalResults int         `json:"totalResults"`
+    StartIndex   int         `json:"startIndex"`
+    ItemsPerPage int         `json:"itemsPerPage"`
+    Resources    interface{} `json:"Resources"`
+}
+
+type patchRequest struct {
+    Schemas    []string    `json:"schemas"`
+    Operations []operation `json:"Operations"`
+}
+
+type operation struct {
+    Op    string          `json:"op"`
+    Path  string          `json:"path"`
+    Value json.RawMessage `json:"value"`
+}
+
+type errorResponse struct {
+    Schemas  []string `json:"schemas"`
+    Status   string   `json:"status"`
+    ScimType string   `json:"scimType,omitempty"`
+    Detail   string   `json:"detail"`
+}
+
+func newUserResource(user *models.User, groups []*models.DirectoryGroup) *userResource {
+    active := user.DeactivatedAt == nil
+    res := &userResource{
+        Schemas:    []string{schemaUser},
+        ID:         user.ID,
+        ExternalID: user.ExternalID,
+        UserName:   user.Username,
+        Active:     &active,
+        Meta: &meta{
+            ResourceType: "User",
+            Created:      user.CreatedAt,
+            LastModified: user.UpdatedAt,
+            Location:     "/scim/v2/Users/" + user.ID,
+        },
+    }
+    if user.Email != "" {
+        res.Emails = []email{{Value: user.Email, Primary: true}}
+    }
+    for _, g := range groups {
+        res.Groups = append(res.Groups, reference{Value: g.ID, Display: g.DisplayName})
+    }
+    return res
+}
+
+func newGroupResource(group *models.DirectoryGroup) *groupResource {
+    res := &groupResource{
+        Schemas:     []string{schemaGroup},
+        ID:          group.ID,
+        ExternalID:  group.ExternalID,
+        DisplayName: group.DisplayName,
+        Members:     make([]reference, 0, len(group.MemberIDs)),
+        Meta: &meta{
+            ResourceType: "Group",
+            Created:      group.CreatedAt,
+            LastModified: group.UpdatedAt,
+            Location:     "/scim/v2/Groups/" + group.ID,
+        },
+    }
+    for _, id := range group.MemberIDs {
+        res.Members = append(res.Members, reference{Value: id})
+    }
+    return res
+}
+
+// primaryEmail picks the primary address, or the first one listed.
+func (u *userResource) primaryEmail() string {
+    for _, e := range u.Emails {
+        if e.Primary {
+            return e.Value
+        }
+    }
+    if len(u.Emails) > 0 {
+        return u.Emails[0].Value
+    }
+    return ""
+}
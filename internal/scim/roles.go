@@ -0,0 +1,83 @@
+package scim
+
+import (
+    "context"
+    "fmt"
+    "strings"
+
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+// Roles a directory group can grant.
+const (
+    RoleAdmin       = "admin"
+    RoleBroadcaster = "broadcaster"
+    RoleBot         = "bot"
+)
+
+// RoleMap maps directory group display names to roles.
+type RoleMap map[string]string
+
+// ParseRoleMap reads mappings of the form "Chat Admins=admin,Presenters=broadcaster".
+func ParseRoleMap(s string) (RoleMap, error) {
+    roles := make(RoleMap)
+    for _, entry := range strings.Split(s, ",") {
+        if strings.TrimSpace(entry) == "" {
+            continue
+        }
+        group, role, ok := strings.Cut(entry, "=")
+        group, role = strings.TrimSpace(group), strings.TrimSpace(role)
+        if !ok || group == "" {
+            return nil, fmt.Errorf("invalid group mapping %q", entry)
+        }
+        switch role {
+        case RoleAdmin, RoleBroadcaster, RoleBot:
+        default:
+            return nil, fmt.Errorf("unknown role %q for group %q", role, group)
+        }
+        roles[group] = role
+    }
+    return roles, nil
+}
+
+// applyRoles recomputes a directory-managed user's role from their group
+// memberships. Users created outside the directory keep the roles they
+// were given by hand.
+func (h *Handler) applyRoles(ctx context.Context, userID string) error {
+    user, err := h.store.GetUser(ctx, userID)
+    if err != nil {
+        return fmt.Errorf("failed to get user: %w", err)
+    }
+    if user.ExternalID == "" {
+        return nil
+    }
+
+    groups, err := h.store.GetUserDirectoryGroups(ctx, userID)
+    if err != nil {
+        return fmt.Errorf("failed to get user groups: %w", err)
+    }
+
+    granted := make(map[string]bool)
+    for _, g := range groups {
+        granted[h.roles[g.DisplayName]] = true
+    }
+
+    isAdmin := granted[RoleAdmin]
+    accountType := models.AccountTypeUser
+    switch {
+    case granted[RoleBroadcaster]:
+        accountType = models.AccountTypeBroadcaster
+    case granted[RoleBot]:
+        accountType = models.AccountTypeBot
+    }
+
+    if user.IsAdmin == isAdmin && user.AccountType == accountType {
+        return nil
+    }
+    user.IsAdmin = isAdmin
+    user.AccountType = accountType
+    if err := h.store.UpdateUser(ctx, user); err != nil {
+        return fmt.Errorf("failed to update user: %w", err)
+    }
+    return nil
+}
@@ -0,0 +1,256 @@
+package scim
+
+import (
+    "encoding/json"
+    "fmt"
+    "net/http"
+    "strings"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/events"
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+func (h *Handler) listUsers(w http.ResponseWriter, r *http.Request) {
+    startIndex, count := pagination(r)
+
+    var users []*models.User
+    var total int
+    if filter := r.URL.Query().Get("filter"); filter != "" {
+        attr, value, ok := parseFilter(filter)
+        if !ok {
+            h.respondError(w, http.StatusBadRequest, "invalidFilter", "Only equality filters are supported")
+            return
+        }
+
+        var user *models.User
+        var err error
+        switch attr {
+        case "userName":
+            user, err = h.store.GetUserByUsername(r.Context(), value)
+        case "externalId":
+            user, err = h.store.GetUserByExternalID(r.Context(), value)
+        default:
+            h.respondError(w, http.StatusBadRequest, "invalidFilter", "Users can be filtered by userName or externalId")
+            return
+        }
+        // A miss is an empty list, not an error
+        if err == nil && user != nil {
+            users, total = []*models.User{user}, 1
+        }
+    } else {
+        var err error
+        users, total, err = h.store.ListUsers(r.Context(), startIndex-1, count)
+        if err != nil {
+            h.logger.Error("Failed to list users", zap.Error(err))
+            h.respondError(w, http.StatusInternalServerError, "", "Failed to list users")
+            return
+        }
+    }
+
+    resources := make([]*userResource, 0, len(users))
+    for _, user := range users {
+        groups, err := h.store.GetUserDirectoryGroups(r.Context(), user.ID)
+        if err != nil {
+            h.logger.Warn("Failed to load user groups", zap.Error(err), zap.String("user_id", user.ID))
+        }
+        resources = append(resources, newUserResource(user, groups))
+    }
+
+    h.respondJSON(w, http.StatusOK, &listResponse{
+        Schemas:      []string{schemaListResponse},
+        TotalResults: total,
+        StartIndex:   startIndex,
+        ItemsPerPage: len(resources),
+        Resources:    resources,
+    })
+}
+
+func (h *Handler) getUser(w http.ResponseWriter, r *http.Request) {
+    user, err := h.store.GetUser(r.Context(), r.PathValue("id"))
+    if err != nil {
+        h.respondError(w, http.StatusNotFound, "", "User not found")
+        return
+    }
+    h.respondUser(w, r, http.StatusOK, user)
+}
+
+func (h *Handler) createUser(w http.ResponseWriter, r *http.Request) {
+    var req userResource
+    if err := h.decodeJSON(r, &req); err != nil || strings.TrimSpace(req.UserName) == "" {
+        h.respondError(w, http.StatusBadRequest, "invalidValue", "userName is required")
+        return
+    }
+
+    if existing, err := h.store.GetUserByUsername(r.Context(), req.UserName); err == nil && existing != nil {
+        h.respondError(w, http.StatusConflict, "uniqueness", "userName is already taken")
+        return
+    }
+
+    // Directory users sign in through the identity provider, so they
+    // have no password
+    now := time.Now()
+    user := &models.User{
+        Username:    req.UserName,
+        Email:       req.primaryEmail(),
+        ExternalID:  req.ExternalID,
+        AccountType: models.AccountTypeUser,
+        CreatedAt:   now,
+        UpdatedAt:   now,
+    }
+    // Role sync only applies to users with an external ID, so every
+    // provisioned user gets one
+    if user.ExternalID == "" {
+        user.ExternalID = req.UserName
+    }
+    if req.Active != nil && !*req.Active {
+        user.DeactivatedAt = &now
+    }
+
+    if err := h.store.CreateUser(r.Context(), user); err != nil {
+        h.logger.Error("Failed to provision user", zap.Error(err), zap.String("username", req.UserName))
+        h.respondError(w, http.StatusInternalServerError, "", "Failed to create user")
+        return
+    }
+
+    h.logger.Info("User provisioned", zap.String("user_id", user.ID), zap.String("external_id", user.ExternalID))
+    h.events.Publish(events.UserSignedUp{UserID: user.ID, Source: "scim", At: now})
+    h.respondUser(w, r, http.StatusCreated, user)
+}
+
+func (h *Handler) replaceUser(w http.ResponseWriter, r *http.Request) {
+    user, err := h.store.GetUser(r.Context(), r.PathValue("id"))
+    if err != nil {
+        h.respondError(w, http.StatusNotFound, "", "User not found")
+        return
+    }
+
+    var req userResource
+    if err := h.decodeJSON(r, &req); err != nil || strings.TrimSpace(req.UserName) == "" {
+        h.respondError(w, http.StatusBadRequest, "invalidValue", "userName is required")
+        return
+    }
+
+    user.Username = req.UserName
+    user.Email = req.primaryEmail()
+    if req.ExternalID != "" {
+        user.ExternalID = req.ExternalID
+    }
+    setActive(user, req.Active == nil || *req.Active)
+
+    h.saveUser(w, r, user)
+}
+
+// patchUser supports the operations identity providers send in practice:
+// replacing active (deprovisioning), userName, externalId and emails.
+func (h *Handler) patchUser(w http.ResponseWriter, r *http.Request) {
+    user, err := h.store.GetUser(r.Context(), r.PathValue("id"))
+    if err != nil {
+        h.respondError(w, http.StatusNotFound, "", "User not found")
+        return
+    }
+
+    var req patchRequest
+    if err := h.decodeJSON(r, &req); err != nil {
+        h.respondError(w, http.StatusBadRequest, "invalidSyntax", "Invalid patch request")
+        return
+    }
+
+    for _, op := range req.Operations {
+        if !strings.EqualFold(op.Op, "replace") && !strings.EqualFold(op.Op, "add") {
+            h.respondError(w, http.StatusBadRequest, "mutability", "Unsupported operation "+op.Op)
+            return
+        }
+
+        // Without a path the value is a partial resource
+        values := map[string]json.RawMessage{op.Path: op.Value}
+        if op.Path == "" {
+            if err := json.Unmarshal(op.Value, &values); err != nil {
+                h.respondError(w, http.StatusBadRequest, "invalidValue", "Invalid patch value")
+                return
+            }
+        }
+
+        for path, value := range values {
+            if err := patchUserAttribute(user, path, value); err != nil {
+                h.respondError(w, http.StatusBadRequest, "invalidPath", err.Error())
+                return
+            }
+        }
+    }
+
+    h.saveUser(w, r, user)
+}
+
+func patchUserAttribute(user *models.User, path string, value json.RawMessage) error {
+    var err error
+    switch path {
+    case "active":
+        var active bool
+        if err = json.Unmarshal(value, &active); err == nil {
+            setActive(user, active)
+        }
+    case "userName":
+        err = json.Unmarshal(value, &user.Username)
+    case "externalId":
+        err = json.Unmarshal(value, &user.ExternalID)
+    case "emails":
+        var emails userResource
+        if err = json.Unmarshal(value, &emails.Emails); err == nil {
+            user.Email = emails.primaryEmail()
+        }
+    default:
+        // Attributes the chat has no place for are accepted and dropped
+        return nil
+    }
+    if err != nil {
+        return fmt.Errorf("invalid value for %s", path)
+    }
+    return nil
+}
+
+func setActive(user *models.User, active bool) {
+    switch {
+    case active:
+        user.DeactivatedAt = nil
+    case user.DeactivatedAt == nil:
+        now := time.Now()
+        user.DeactivatedAt = &now
+    }
+}
+
+func (h *Handler) saveUser(w http.ResponseWriter, r *http.Request, user *models.User) {
+    user.UpdatedAt = time.Now()
+    if err := h.store.UpdateUser(r.Context(), user); err != nil {
+        h.logger.Error("Failed to update provisioned user", zap.Error(err), zap.String("user_id", user.ID))
+        h.respondError(w, http.StatusInternalServerError, "", "Failed to update user")
+        return
+    }
+    h.respondUser(w, r, http.StatusOK, user)
+}
+
+func (h *Handler) deleteUser(w http.ResponseWriter, r *http.Request) {
+    userID := r.PathValue("id")
+    if _, err := h.store.GetUser(r.Context(), userID); err != nil {
+        h.respondError(w, http.StatusNotFound, "", "User not found")
+        return
+    }
+    if err := h.store.DeleteUser(r.Context(), userID); err != nil {
+        h.logger.Error("Failed to deprovision user", zap.Error(err), zap.String("user_id", userID))
+        h.respondError(w, http.StatusInternalServerError, "", "Failed to delete user")
+        return
+    }
+
+    h.logger.Info("User deprovisioned", zap.String("user_id", userID))
+    w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) respondUser(w http.ResponseWriter, r *http.Request, status int, user *models.User) {
+    groups, err := h.store.GetUserDirectoryGroups(r.Context(), user.ID)
+    if err != nil {
+        h.logger.Warn("Failed to load user groups", zap.Error(err), zap.String("user_id", user.ID))
+    }
+    h.respondJSON(w, status, newUserResource(user, groups))
+}
@@ -0,0 +1,186 @@
+package opensearch
+
+import (
+    "bytes"
+    "context"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "io"
+    "net/http"
+    "net/url"
+    "strings"
+    "time"
+)
+
+const (
+    messagesIndex = "messages"
+    eventsIndex   = "match_events"
+)
+
+// mappings are the field types each index is created with. Room, user
+// and event type are keywords: dynamically mapped they would be analyzed
+// text, which term filters and terms aggregations do not match.
+var mappings = map[string]map[string]string{
+    messagesIndex: {
+        "id":           "keyword",
+        "chat_room_id": "keyword",
+        "user_id":      "keyword",
+        "content":      "text",
+        "message_type": "keyword",
+        "created_at":   "date",
+    },
+    eventsIndex: {
+        "id":          "keyword",
+        "match_id":    "keyword",
+        "event_type":  "keyword",
+        "event_time":  "integer",
+        "description": "text",
+        "created_at":  "date",
+    },
+}
+
+type Config struct {
+    URL         string
+    Username    string
+    Password    string
+    IndexPrefix string
+}
+
+type Client struct {
+    baseURL     string
+    username    string
+    password    string
+    indexPrefix string
+    http        *http.Client
+}
+
+func NewClient(cfg Config) (*Client, error) {
+    if _, err := url.Parse(cfg.URL); err != nil {
+        return nil, fmt.Errorf("invalid opensearch url: %w", err)
+    }
+
+    return &Client{
+        baseURL:     strings.TrimRight(cfg.URL, "/"),
+        username:    cfg.Username,
+        password:    cfg.Password,
+        indexPrefix: cfg.IndexPrefix,
+        http:        &http.Client{Timeout: 10 * time.Second},
+    }, nil
+}
+
+func (c *Client) index(name string) string {
+    return c.indexPrefix + name
+}
+
+// EnsureIndices creates the indices with their mappings. The mappings of
+// an index that already exists are extended with any missing fields; a
+// field mapped with another type is an error, as the index would have to
+// be rebuilt for filters on it to work.
+func (c *Client) EnsureIndices(ctx context.Context) error {
+    for _, name := range []string{messagesIndex, eventsIndex} {
+        properties := make(map[string]interface{}, len(mappings[name]))
+        for field, kind := range mappings[name] {
+            properties[field] = map[string]string{"type": kind}
+        }
+
+        path := "/" + c.index(name)
+        err := c.do(ctx, http.MethodPut, path, map[string]interface{}{
+            "mappings": map[string]interface{}{"properties": properties},
+        }, nil)
+        var apiErr *APIError
+        if errors.As(err, &apiErr) && strings.Contains(apiErr.Body, "resource_already_exists_exception") {
+            err = c.do(ctx, http.MethodPut, path+"/_mapping", map[string]interface{}{"properties": properties}, nil)
+        }
+        if err != nil {
+            return fmt.Errorf("failed to create index %s: %w", c.index(name), err)
+        }
+    }
+    return nil
+}
+
+func (c *Client) IndexDocument(ctx context.Context, index, id string, doc interface{}) error {
+    path := fmt.Sprintf("/%s/_doc/%s", c.index(index), url.PathEscape(id))
+    return c.do(ctx, http.MethodPut, path, doc, nil)
+}
+
+func (c *Client) DeleteDocument(ctx context.Context, index, id string) error {
+    path := fmt.Sprintf("/%s/_doc/%s", c.index(index), url.PathEscape(id))
+    err := c.do(ctx, http.MethodDelete, path, nil, nil)
+    if apiErr, ok := err.(*APIError); ok && apiErr.StatusCode == http.StatusNotFound {
+        return nil
+    }
+    return err
+}
+
+// DeleteByQuery deletes the documents whose field holds value, refreshing
+// the index so they are out of searches on return. A conflict with a
+// concurrent write fails the call rather than leaving the document.
+func (c *Client) DeleteByQuery(ctx context.Context, index, field, value string) error {
+    path := fmt.Sprintf("/%s/_delete_by_query?refresh=true", c.index(index))
+    query := map[string]interface{}{
+        "query": map[string]interface{}{
+            "term": map[string]string{field: value},
+        },
+    }
+    var result struct {
+        Failures []json.RawMessage `json:"failures"`
+    }
+    if err := c.do(ctx, http.MethodPost, path, query, &result); err != nil {
+        return err
+    }
+    if len(result.Failures) > 0 {
+        return fmt.Errorf("opensearch: delete by query in %s failed for %d documents", c.index(index), len(result.Failures))
+    }
+    return nil
+}
+
+func (c *Client) search(ctx context.Context, index string, query interface{}, out interface{}) error {
+    path := fmt.Sprintf("/%s/_search", c.index(index))
+    return c.do(ctx, http.MethodPost, path, query, out)
+}
+
+type APIError struct {
+    StatusCode int
+    Body       string
+}
+
+func (e *APIError) Error() string {
+    return fmt.Sprintf("opensearch: status %d: %s", e.StatusCode, e.Body)
+}
+
+func (c *Client) do(ctx context.Context, method, path string, body, out interface{}) error {
+    var reader io.Reader
+    if body != nil {
+        payload, err := json.Marshal(body)
+        if err != nil {
+            return fmt.Errorf("failed to marshal request: %w", err)
+        }
+        reader = bytes.NewReader(payload)
+    }
+
+    req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+    if err != nil {
+        return err
+    }
+    req.Header.Set("Content-Type", "application/json")
+    if c.username != "" {
+        req.SetBasicAuth(c.username, c.password)
+    }
+
+    resp, err := c.http.Do(req)
+    if err != nil {
+        return fmt.Errorf("opensearch request failed: %w", err)
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode >= 300 {
+        msg, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+        return &APIError{StatusCode: resp.StatusCode, Body: string(msg)}
+    }
+
+    if out == nil {
+        return nil
+    }
+    return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,325 @@
+package opensearch
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/jobs"
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/store"
+)
+
+// Store wraps the primary store, mirrors messages and match events into
+// OpenSearch through the job queue and serves the search operations from
+// the index. Every other operation goes straight to the primary store.
+type Store struct {
+    store.Store
+    client *Client
+    queue  *jobs.Queue
+    logger *zap.Logger
+}
+
+func NewStore(primary store.Store, client *Client, queue *jobs.Queue, logger *zap.Logger) *Store {
+    s := &Store{
+        Store:  primary,
+        client: client,
+        queue:  queue,
+        logger: logger,
+    }
+    for _, name := range []string{"search.index_message", "search.delete_message", "search.index_match_event"} {
+        name := name
+        queue.Register(name, func(payload []byte) (jobs.Job, error) {
+            job := &indexJob{store: s, name: name}
+            if err := json.Unmarshal(payload, job); err != nil {
+                return nil, err
+            }
+            return job, nil
+        })
+    }
+    return s
+}
+
+type messageDoc struct {
+    ID          string    `json:"id"`
+    ChatRoomID  string    `json:"chat_room_id"`
+    UserID      string    `json:"user_id"`
+    Content     string    `json:"content"`
+    MessageType string    `json:"message_type"`
+    CreatedAt   time.Time `json:"created_at"`
+}
+
+type eventDoc struct {
+    ID          string    `json:"id"`
+    MatchID     string    `json:"match_id"`
+    EventType   string    `json:"event_type"`
+    EventTime   int       `json:"event_time"`
+    Description string    `json:"description"`
+    CreatedAt   time.Time `json:"created_at"`
+}
+
+func (s *Store) CreateMessage(ctx context.Context, message *models.Message) error {
+    if err := s.Store.CreateMessage(ctx, message); err != nil {
+        return err
+    }
+
+    doc := messageDoc{
+        ID:          message.ID,
+        ChatRoomID:  message.ChatRoomID,
+        UserID:      message.UserID,
+        Content:     message.Content,
+        MessageType: message.MessageType,
+        CreatedAt:   message.CreatedAt,
+    }
+    s.enqueue("search.index_message", messagesIndex, doc.ID, doc)
+
+    return nil
+}
+
+func (s *Store) CreateMessages(ctx context.Context, messages []*models.Message) error {
+    if err := s.Store.CreateMessages(ctx, messages); err != nil {
+        return err
+    }
+
+    for _, message := range messages {
+        doc := messageDoc{
+            ID:          message.ID,
+            ChatRoomID:  message.ChatRoomID,
+            UserID:      message.UserID,
+            Content:     message.Content,
+            MessageType: message.MessageType,
+            CreatedAt:   message.CreatedAt,
+        }
+        s.enqueue("search.index_message", messagesIndex, doc.ID, doc)
+    }
+
+    return nil
+}
+
+func (s *Store) DeleteMessage(ctx context.Context, id string) error {
+    if err := s.Store.DeleteMessage(ctx, id); err != nil {
+        return err
+    }
+
+    s.enqueue("search.delete_message", messagesIndex, id, nil)
+
+    return nil
+}
+
+// DeleteUserMessages removes every message the user sent from the index,
+// for erasing their account. The primary store is left to the caller.
+func (s *Store) DeleteUserMessages(ctx context.Context, userID string) error {
+    return s.client.DeleteByQuery(ctx, messagesIndex, "user_id", userID)
+}
+
+func (s *Store) CreateMatchEvent(ctx context.Context, event *models.MatchEvent) error {
+    if err := s.Store.CreateMatchEvent(ctx, event); err != nil {
+        return err
+    }
+
+    doc := eventDoc{
+        ID:          event.ID,
+        MatchID:     event.MatchID,
+        EventType:   event.EventType,
+        EventTime:   event.EventTime,
+        Description: event.Description,
+        CreatedAt:   event.CreatedAt,
+    }
+    s.enqueue("search.index_match_event", eventsIndex, doc.ID, doc)
+
+    return nil
+}
+
+// indexJob writes a document to, or with no document deletes it from, an
+// index. It carries the document itself so a dead-lettered job can be
+// replayed.
+type indexJob struct {
+    store *Store
+    name  string
+
+    Index string          `json:"index"`
+    ID    string          `json:"id"`
+    Doc   json.RawMessage `json:"doc,omitempty"`
+}
+
+func (j *indexJob) Name() string { return j.name }
+
+func (j *indexJob) Run(ctx context.Context) error {
+    if j.Doc == nil {
+        return j.store.client.DeleteDocument(ctx, j.Index, j.ID)
+    }
+    return j.store.client.IndexDocument(ctx, j.Index, j.ID, j.Doc)
+}
+
+func (j *indexJob) Payload() ([]byte, error) { return json.Marshal(j) }
+
+// Indexing is best effort: the primary store remains the source of truth,
+// so a full queue only means the document is missing from search results.
+// A nil doc deletes the document.
+func (s *Store) enqueue(name, index, id string, doc interface{}) {
+    job := &indexJob{store: s, name: name, Index: index, ID: id}
+    if doc != nil {
+        data, err := json.Marshal(doc)
+        if err != nil {
+            s.logger.Warn("Failed to encode search document", zap.Error(err), zap.String("job", name))
+            return
+        }
+        job.Doc = data
+    }
+    if err := s.queue.Enqueue(job); err != nil {
+        s.logger.Warn("Failed to enqueue search indexing job",
+            zap.Error(err),
+            zap.String("job", name))
+    }
+}
+
+func (s *Store) SearchMessages(ctx context.Context, query string, limit int) ([]*models.Message, error) {
+    result, err := s.Search(ctx, SearchRequest{Query: query, Limit: limit})
+    if err != nil {
+        return nil, err
+    }
+
+    messages := make([]*models.Message, 0, len(result.Messages))
+    for _, hit := range result.Messages {
+        messages = append(messages, hit.Message)
+    }
+    return messages, nil
+}
+
+func (s *Store) SearchMatchEvents(ctx context.Context, query string, limit int) ([]*models.MatchEvent, error) {
+    var resp searchResponse
+    if err := s.client.search(ctx, eventsIndex, buildQuery(query, "description", "", limit, "event_type"), &resp); err != nil {
+        return nil, fmt.Errorf("failed to search match events: %w", err)
+    }
+
+    events := make([]*models.MatchEvent, 0, len(resp.Hits.Hits))
+    for _, hit := range resp.Hits.Hits {
+        var doc eventDoc
+        if err := json.Unmarshal(hit.Source, &doc); err != nil {
+            continue
+        }
+        events = append(events, &models.MatchEvent{
+            ID:          doc.ID,
+            MatchID:     doc.MatchID,
+            EventType:   doc.EventType,
+            EventTime:   doc.EventTime,
+            Description: doc.Description,
+            CreatedAt:   doc.CreatedAt,
+        })
+    }
+    return events, nil
+}
+
+type SearchRequest struct {
+    Query  string
+    RoomID string
+    Limit  int
+}
+
+type MessageHit struct {
+    Message    *models.Message `json:"message"`
+    Highlights []string        `json:"highlights,omitempty"`
+}
+
+type SearchResult struct {
+    Total    int            `json:"total"`
+    Messages []*MessageHit  `json:"messages"`
+    ByRoom   map[string]int `json:"by_room"`
+}
+
+// Search runs a fuzzy message search returning highlighted fragments and
+// per-room hit counts, which the plain SearchStore methods cannot express.
+func (s *Store) Search(ctx context.Context, req SearchRequest) (*SearchResult, error) {
+    var resp searchResponse
+    if err := s.client.search(ctx, messagesIndex, buildQuery(req.Query, "content", req.RoomID, req.Limit, "chat_room_id"), &resp); err != nil {
+        return nil, fmt.Errorf("failed to search messages: %w", err)
+    }
+
+    result := &SearchResult{
+        Total:    resp.Hits.Total.Value,
+        Messages: make([]*MessageHit, 0, len(resp.Hits.Hits)),
+        ByRoom:   make(map[string]int),
+    }
+
+    for _, hit := range resp.Hits.Hits {
+        var doc messageDoc
+        if err := json.Unmarshal(hit.Source, &doc); err != nil {
+            continue
+        }
+        result.Messages = append(result.Messages, &MessageHit{
+            Message: &models.Message{
+                ID:          doc.ID,
+                ChatRoomID:  doc.ChatRoomID,
+                UserID:      doc.UserID,
+                Content:     doc.Content,
+                MessageType: doc.MessageType,
+                CreatedAt:   doc.CreatedAt,
+            },
+            Highlights: hit.Highlight["content"],
+        })
+    }
+
+    for _, bucket := range resp.Aggregations.Terms.Buckets {
+        result.ByRoom[bucket.Key] = bucket.DocCount
+    }
+
+    return result, nil
+}
+
+type searchResponse struct {
+    Hits struct {
+        Total struct {
+            Value int `json:"value"`
+        } `json:"total"`
+        Hits []struct {
+            Source    json.RawMessage     `json:"_source"`
+            Highlight map[string][]string `json:"highlight"`
+        } `json:"hits"`
+    } `json:"hits"`
+    Aggregations struct {
+        Terms struct {
+            Buckets []struct {
+                Key      string `json:"key"`
+                DocCount int    `json:"doc_count"`
+            } `json:"buckets"`
+        } `json:"terms"`
+    } `json:"aggregations"`
+}
+
+func buildQuery(text, field, roomID string, limit int, aggField string) map[string]interface{} {
+    if limit <= 0 || limit > 100 {
+        limit = 20
+    }
+
+    must := []interface{}{
+        map[string]interface{}{
+            "match": map[string]interface{}{
+                field: map[string]interface{}{
+                    "query":     text,
+                    "fuzziness": "AUTO",
+                },
+            },
+        },
+    }
+    if roomID != "" {
+        must = append(must, map[string]interface{}{
+            "term": map[string]interface{}{"chat_room_id": roomID},
+        })
+    }
+
+    return map[string]interface{}{
+        "size":  limit,
+        "query": map[string]interface{}{"bool": map[string]interface{}{"must": must}},
+        "highlight": map[string]interface{}{
+            "fields": map[string]interface{}{field: map[string]interface{}{}},
+        },
+        "aggs": map[string]interface{}{
+            "terms": map[string]interface{}{
+                "terms": map[string]interface{}{"field": aggField, "size": 10},
+            },
+        },
+    }
+}
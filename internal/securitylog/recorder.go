@@ -0,0 +1,103 @@
+// Package securitylog keeps each user's own account activity log: where
+// and on what device their sessions signed in, and sensitive changes to
+// their account.
+package securitylog
+
+import (
+    "context"
+    "net/http"
+    "strings"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/events"
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/store"
+)
+
+// maxDeviceLength bounds the stored user agent.
+const maxDeviceLength = 256
+
+// Recorder turns connection events into sign-in entries. A session's
+// first connection creates its entry and later ones refresh it.
+type Recorder struct {
+    store  store.Store
+    logger *zap.Logger
+    events chan events.UserConnected
+}
+
+func NewRecorder(store store.Store, logger *zap.Logger) *Recorder {
+    return &Recorder{
+        store:  store,
+        logger: logger,
+        events: make(chan events.UserConnected, 1024),
+    }
+}
+
+// Start subscribes the recorder to connection events and starts its
+// worker.
+func (r *Recorder) Start(bus *events.Bus) {
+    bus.Subscribe(events.TypeUserConnected, r.enqueue)
+    go r.run()
+}
+
+func (r *Recorder) enqueue(event events.Event) {
+    connected, ok := event.(events.UserConnected)
+    if !ok {
+        return
+    }
+    select {
+    case r.events <- connected:
+    default:
+        r.logger.Warn("Security log queue full, dropping connection event",
+            zap.String("user_id", connected.UserID))
+    }
+}
+
+func (r *Recorder) run() {
+    for event := range r.events {
+        ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+        err := r.store.RecordSecurityEvent(ctx, &models.SecurityEvent{
+            UserID:    event.UserID,
+            Kind:      models.SecuritySignIn,
+            SessionID: event.SessionID,
+            IP:        event.IP,
+            Country:   event.Country,
+            Device:    Device(event.UserAgent),
+            CreatedAt: event.At,
+        })
+        if err != nil {
+            r.logger.Error("Failed to record sign-in",
+                zap.Error(err),
+                zap.String("user_id", event.UserID))
+        }
+        cancel()
+    }
+}
+
+// Device is the device description stored for a user agent.
+func Device(userAgent string) string {
+    n := maxDeviceLength
+    if len(userAgent) <= n {
+        return userAgent
+    }
+    // Back off to a rune boundary
+    for n > 0 && userAgent[n]&0xC0 == 0x80 {
+        n--
+    }
+    return userAgent[:n]
+}
+
+// Country returns the two-letter country code the edge tagged r with in
+// header, or empty if header is empty or the value is malformed.
+func Country(r *http.Request, header string) string {
+    if header == "" {
+        return ""
+    }
+    code := strings.ToUpper(strings.TrimSpace(r.Header.Get(header)))
+    if len(code) != 2 || code[0] < 'A' || code[0] > 'Z' || code[1] < 'A' || code[1] > 'Z' {
+        return ""
+    }
+    return code
+}
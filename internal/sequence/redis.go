@@ -0,0 +1,79 @@
+package sequence
+
+import (
+    "context"
+    "fmt"
+    "sync"
+
+    "github.com/redis/go-redis/v9"
+)
+
+// Redis keeps one counter per room, shared by every instance. A counter
+// missing from Redis, because it was never created or was evicted, is
+// seeded before its first increment; seeding with SETNX means racing
+// instances agree on where it starts.
+type Redis struct {
+    client *redis.Client
+    prefix string
+    seed   SeedFunc
+
+    // Rooms this instance has made sure are seeded
+    mu     sync.Mutex
+    seeded map[string]bool
+}
+
+func NewRedis(url, prefix string, seed SeedFunc) (*Redis, error) {
+    opts, err := redis.ParseURL(url)
+    if err != nil {
+        return nil, fmt.Errorf("failed to parse redis url: %w", err)
+    }
+
+    client := redis.NewClient(opts)
+    if err := client.Ping(context.Background()).Err(); err != nil {
+        client.Close()
+        return nil, fmt.Errorf("failed to connect to redis: %w", err)
+    }
+
+    return &Redis{
+        client: client,
+        prefix: prefix + "seq:",
+        seed:   seed,
+        seeded: make(map[string]bool),
+    }, nil
+}
+
+func (r *Redis) Next(ctx context.Context, room string) (int64, error) {
+    key := r.prefix + room
+
+    r.mu.Lock()
+    seeded := r.seeded[room]
+    r.mu.Unlock()
+    if !seeded {
+        exists, err := r.client.Exists(ctx, key).Result()
+        if err != nil {
+            return 0, fmt.Errorf("failed to check room sequence: %w", err)
+        }
+        if exists == 0 {
+            last, err := r.seed(ctx, room)
+            if err != nil {
+                return 0, err
+            }
+            if err := r.client.SetNX(ctx, key, last, 0).Err(); err != nil {
+                return 0, fmt.Errorf("failed to seed room sequence: %w", err)
+            }
+        }
+        r.mu.Lock()
+        r.seeded[room] = true
+        r.mu.Unlock()
+    }
+
+    seq, err := r.client.Incr(ctx, key).Result()
+    if err != nil {
+        return 0, fmt.Errorf("failed to advance room sequence: %w", err)
+    }
+    return seq, nil
+}
+
+func (r *Redis) Close() error {
+    return r.client.Close()
+}
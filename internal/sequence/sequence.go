@@ -0,0 +1,51 @@
+// Package sequence numbers the chat messages broadcast to each room, so
+// clients can tell what they missed while disconnected.
+package sequence
+
+import (
+    "context"
+    "sync"
+)
+
+// Sequencer hands out increasing numbers per room. Numbers are never
+// reused, but a failed broadcast may leave a gap.
+type Sequencer interface {
+    Next(ctx context.Context, room string) (int64, error)
+    Close() error
+}
+
+// SeedFunc returns the highest number a room has already used, so a
+// sequencer starting fresh continues from it.
+type SeedFunc func(ctx context.Context, room string) (int64, error)
+
+// Local is the single-instance sequencer. Each room is seeded on first
+// use, so numbering carries on across restarts.
+type Local struct {
+    seed SeedFunc
+
+    mu    sync.Mutex
+    rooms map[string]int64
+}
+
+func NewLocal(seed SeedFunc) *Local {
+    return &Local{seed: seed, rooms: make(map[string]int64)}
+}
+
+func (l *Local) Next(ctx context.Context, room string) (int64, error) {
+    l.mu.Lock()
+    defer l.mu.Unlock()
+
+    last, ok := l.rooms[room]
+    if !ok {
+        var err error
+        if last, err = l.seed(ctx, room); err != nil {
+            return 0, err
+        }
+    }
+    l.rooms[room] = last + 1
+    return last + 1, nil
+}
+
+func (l *Local) Close() error {
+    return nil
+}
@@ -0,0 +1,57 @@
+package sportsdata
+
+import (
+    "encoding/json"
+    "fmt"
+    "strings"
+
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+// GoalFixture is a push, in the provider's own format, scoring a goal for
+// side, "home" or "away", in match at its current minute. A match not yet
+// live kicks off with it. The simulator sends it through the ingest
+// pipeline, and integrators can sign and replay it against the ingest
+// endpoint.
+func GoalFixture(match *models.Match, side, scorer string) ([]byte, error) {
+    pm := &providerMatch{
+        ID:        match.ProviderID,
+        Status:    match.Status,
+        Period:    match.Period,
+        Minute:    match.Minute,
+        HomeScore: match.HomeScore,
+        AwayScore: match.AwayScore,
+    }
+    // Matches only the simulator pushes to have no provider ID
+    if pm.ID == "" {
+        pm.ID = match.ID
+    }
+    if pm.Status != models.MatchStatusLive {
+        pm.Status = models.MatchStatusLive
+        pm.Period = models.PeriodFirstHalf
+    }
+    if pm.Minute == 0 {
+        pm.Minute = 1
+    }
+
+    switch strings.ToLower(side) {
+    case "home":
+        pm.HomeScore++
+    case "away":
+        pm.AwayScore++
+    default:
+        return nil, fmt.Errorf("%w: side must be home or away", ErrInvalidPush)
+    }
+    if scorer == "" {
+        scorer = "Simulated player"
+    }
+
+    return json.Marshal(&providerPush{
+        Match: pm,
+        Events: []*providerEvent{{
+            Type:        models.EventTypeGoal,
+            Minute:      pm.Minute,
+            Description: fmt.Sprintf("Goal! %s (%s) %d-%d", scorer, strings.ToLower(side), pm.HomeScore, pm.AwayScore),
+        }},
+    })
+}
@@ -0,0 +1,345 @@
+package sportsdata
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "io"
+    "math/rand"
+    "net/http"
+    "net/url"
+    "strconv"
+    "strings"
+    "time"
+
+    "go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+    "go.opentelemetry.io/otel/attribute"
+    "go.opentelemetry.io/otel/trace"
+
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/tracing"
+)
+
+var ErrMatchNotFound = errors.New("match not found at provider")
+
+// Provider is the external sports data source. Matches it returns are
+// normalized into models.Match with ProviderID set; local IDs are unknown
+// to the provider.
+type Provider interface {
+    GetMatchResult(ctx context.Context, providerID string) (*models.Match, error)
+    // GetLiveMatches returns every match the provider reports as in play.
+    GetLiveMatches(ctx context.Context) ([]*models.Match, error)
+    // GetMatchEvents returns a match's events in order, with MatchID
+    // left for the caller to fill in.
+    GetMatchEvents(ctx context.Context, providerID string) ([]*models.MatchEvent, error)
+}
+
+const (
+    defaultMaxRetries = 3
+    defaultBackoff    = 500 * time.Millisecond
+    maxBackoff        = 10 * time.Second
+)
+
+type HTTPProvider struct {
+    baseURL    string
+    apiKey     string
+    http       *http.Client
+    maxRetries int
+    backoff    time.Duration
+}
+
+func NewHTTPProvider(baseURL, apiKey string) *HTTPProvider {
+    return &HTTPProvider{
+        baseURL:    strings.TrimRight(baseURL, "/"),
+        apiKey:     apiKey,
+        http:       &http.Client{Timeout: 10 * time.Second, Transport: otelhttp.NewTransport(http.DefaultTransport)},
+        maxRetries: defaultMaxRetries,
+        backoff:    defaultBackoff,
+    }
+}
+
+type providerMatch struct {
+    ID        string            `json:"id"`
+    Status    string            `json:"status"`
+    Period    string            `json:"period"`
+    Minute    int               `json:"minute"`
+    HomeScore int               `json:"home_score"`
+    AwayScore int               `json:"away_score"`
+    Shootout  *providerShootout `json:"penalty_shootout"`
+    Venue     *providerVenue    `json:"venue"`
+    // Names in other languages, keyed by locale, for providers that send
+    // them
+    HomeTeamNames    map[string]string `json:"home_team_names"`
+    AwayTeamNames    map[string]string `json:"away_team_names"`
+    CompetitionNames map[string]string `json:"competition_names"`
+}
+
+type providerVenue struct {
+    Name     string `json:"name"`
+    City     string `json:"city"`
+    Country  string `json:"country"`
+    Timezone string `json:"timezone"`
+}
+
+type providerEvent struct {
+    Type        string `json:"type"`
+    Minute      int    `json:"minute"`
+    Description string `json:"description"`
+}
+
+type providerShootout struct {
+    Kicks []struct {
+        Team    string `json:"team"`
+        Player  string `json:"player"`
+        Outcome string `json:"outcome"`
+    } `json:"kicks"`
+    Winner string `json:"winner"`
+}
+
+func (p *HTTPProvider) GetMatchResult(ctx context.Context, providerID string) (*models.Match, error) {
+    var pm providerMatch
+    if err := p.get(ctx, "/matches/"+url.PathEscape(providerID), &pm); err != nil {
+        return nil, err
+    }
+    return normalizeMatch(&pm), nil
+}
+
+func (p *HTTPProvider) GetLiveMatches(ctx context.Context) ([]*models.Match, error) {
+    var pms []*providerMatch
+    if err := p.get(ctx, "/matches/live", &pms); err != nil {
+        return nil, err
+    }
+
+    matches := make([]*models.Match, 0, len(pms))
+    for _, pm := range pms {
+        matches = append(matches, normalizeMatch(pm))
+    }
+    return matches, nil
+}
+
+func (p *HTTPProvider) GetMatchEvents(ctx context.Context, providerID string) ([]*models.MatchEvent, error) {
+    var pes []*providerEvent
+    if err := p.get(ctx, "/matches/"+url.PathEscape(providerID)+"/events", &pes); err != nil {
+        return nil, err
+    }
+
+    events := make([]*models.MatchEvent, 0, len(pes))
+    for _, pe := range pes {
+        events = append(events, normalizeEvent(pe))
+    }
+    return events, nil
+}
+
+// retryableError marks failures worth another attempt: transport errors,
+// throttling and server errors. After, if set, is the provider's
+// Retry-After.
+type retryableError struct {
+    err   error
+    after time.Duration
+}
+
+func (e *retryableError) Error() string { return e.err.Error() }
+func (e *retryableError) Unwrap() error { return e.err }
+
+// get retries transient failures with exponential backoff and jitter,
+// honoring Retry-After when the provider sends one.
+func (p *HTTPProvider) get(ctx context.Context, path string, out interface{}) (err error) {
+    ctx, span := tracing.Start(ctx, "sportsdata.get", trace.WithAttributes(attribute.String("path", path)))
+    defer func() { tracing.End(span, err) }()
+
+    backoff := p.backoff
+    for attempt := 0; ; attempt++ {
+        span.SetAttributes(attribute.Int("attempts", attempt+1))
+        err := p.getOnce(ctx, path, out)
+
+        var retryable *retryableError
+        if err == nil || !errors.As(err, &retryable) || attempt >= p.maxRetries {
+            return err
+        }
+
+        wait := backoff/2 + time.Duration(rand.Int63n(int64(backoff)))
+        if retryable.after > 0 {
+            wait = retryable.after
+        }
+        select {
+        case <-ctx.Done():
+            return err
+        case <-time.After(wait):
+        }
+
+        if backoff *= 2; backoff > maxBackoff {
+            backoff = maxBackoff
+        }
+    }
+}
+
+func (p *HTTPProvider) getOnce(ctx context.Context, path string, out interface{}) error {
+    req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL+path, nil)
+    if err != nil {
+        return err
+    }
+    req.Header.Set("X-API-Key", p.apiKey)
+    req.Header.Set("Accept", "application/json")
+
+    resp, err := p.http.Do(req)
+    if err != nil {
+        return &retryableError{err: fmt.Errorf("sports api request failed: %w", err)}
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode == http.StatusNotFound {
+        return ErrMatchNotFound
+    }
+    if resp.StatusCode != http.StatusOK {
+        body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+        err := fmt.Errorf("sports api returned status %d: %s", resp.StatusCode, body)
+        if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+            after, _ := strconv.Atoi(resp.Header.Get("Retry-After"))
+            return &retryableError{err: err, after: time.Duration(after) * time.Second}
+        }
+        return err
+    }
+
+    return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func normalizeMatch(pm *providerMatch) *models.Match {
+    return &models.Match{
+        ProviderID: pm.ID,
+        Status:     normalizeStatus(pm.Status),
+        Period:     normalizePeriod(pm.Period),
+        Minute:     pm.Minute,
+        HomeScore:  pm.HomeScore,
+        AwayScore:  pm.AwayScore,
+        Shootout:   normalizeShootout(pm.Shootout),
+        Venue:      normalizeVenue(pm.Venue),
+        Names:      normalizeNames(pm),
+    }
+}
+
+// normalizeVenue drops a venue without a name, and a time zone Go does
+// not know, which kickoff times could not be shown in.
+func normalizeVenue(pv *providerVenue) *models.Venue {
+    if pv == nil || strings.TrimSpace(pv.Name) == "" {
+        return nil
+    }
+    venue := &models.Venue{
+        Name:     strings.TrimSpace(pv.Name),
+        City:     strings.TrimSpace(pv.City),
+        Country:  strings.TrimSpace(pv.Country),
+        Timezone: strings.TrimSpace(pv.Timezone),
+    }
+    if _, err := time.LoadLocation(venue.Timezone); err != nil {
+        venue.Timezone = ""
+    }
+    return venue
+}
+
+// normalizeNames lowercases the locales of a provider's names and drops
+// empty ones, returning nil when it sent none.
+func normalizeNames(pm *providerMatch) *models.MatchNames {
+    clean := func(names map[string]string) map[string]string {
+        out := make(map[string]string, len(names))
+        for locale, name := range names {
+            locale = strings.ReplaceAll(strings.ToLower(strings.TrimSpace(locale)), "_", "-")
+            if name = strings.TrimSpace(name); locale != "" && name != "" {
+                out[locale] = name
+            }
+        }
+        return out
+    }
+    names := &models.MatchNames{
+        HomeTeam:    clean(pm.HomeTeamNames),
+        AwayTeam:    clean(pm.AwayTeamNames),
+        Competition: clean(pm.CompetitionNames),
+    }
+    if len(names.HomeTeam) == 0 && len(names.AwayTeam) == 0 && len(names.Competition) == 0 {
+        return nil
+    }
+    return names
+}
+
+func normalizeEvent(pe *providerEvent) *models.MatchEvent {
+    return &models.MatchEvent{
+        EventType:   strings.ToLower(pe.Type),
+        EventTime:   pe.Minute,
+        Description: pe.Description,
+    }
+}
+
+func normalizePeriod(period string) string {
+    switch strings.ToUpper(period) {
+    case "1H", "FIRST_HALF":
+        return models.PeriodFirstHalf
+    case "HT", "HALFTIME":
+        return models.PeriodHalftime
+    case "2H", "SECOND_HALF":
+        return models.PeriodSecondHalf
+    case "BREAK", "AWAITING_EXTRA_TIME":
+        return models.PeriodBreakBeforeET
+    case "ET1", "EXTRA_TIME_FIRST_HALF":
+        return models.PeriodExtraTimeFirst
+    case "ET_HT", "EXTRA_TIME_HALFTIME":
+        return models.PeriodExtraTimeHalftime
+    case "ET2", "EXTRA_TIME_SECOND_HALF":
+        return models.PeriodExtraTimeSecond
+    case "PENS", "PENALTIES", "PENALTY_SHOOTOUT":
+        return models.PeriodPenaltyShootout
+    default:
+        return ""
+    }
+}
+
+func normalizeShootout(ps *providerShootout) *models.Shootout {
+    if ps == nil {
+        return nil
+    }
+
+    shootout := &models.Shootout{Kicks: make([]*models.ShootoutKick, 0, len(ps.Kicks))}
+    for i, k := range ps.Kicks {
+        kick := &models.ShootoutKick{
+            Order:   i + 1,
+            Side:    strings.ToLower(k.Team),
+            Player:  k.Player,
+            Outcome: normalizeKickOutcome(k.Outcome),
+        }
+        if kick.Outcome == models.KickScored {
+            if kick.Side == "home" {
+                shootout.HomeScore++
+            } else {
+                shootout.AwayScore++
+            }
+        }
+        shootout.Kicks = append(shootout.Kicks, kick)
+    }
+
+    if winner := strings.ToLower(ps.Winner); winner == "home" || winner == "away" {
+        shootout.Winner = winner
+    }
+    return shootout
+}
+
+func normalizeKickOutcome(outcome string) string {
+    switch strings.ToUpper(outcome) {
+    case "SCORED", "GOAL":
+        return models.KickScored
+    case "SAVED":
+        return models.KickSaved
+    default:
+        return models.KickMissed
+    }
+}
+
+func normalizeStatus(status string) string {
+    switch strings.ToUpper(status) {
+    case "LIVE", "IN_PLAY", "INPROGRESS", "HALFTIME":
+        return models.MatchStatusLive
+    case "FINISHED", "FT", "FINAL", "COMPLETED":
+        return models.MatchStatusFinished
+    case "CANCELLED", "CANCELED", "POSTPONED", "ABANDONED":
+        return models.MatchStatusCancelled
+    default:
+        return models.MatchStatusScheduled
+    }
+}
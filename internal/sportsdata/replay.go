@@ -0,0 +1,189 @@
+package sportsdata
+
+import (
+    "bytes"
+    "encoding/json"
+    "fmt"
+    "io"
+    "net/http"
+    "net/url"
+    "os"
+    "path/filepath"
+    "strings"
+    "sync"
+    "time"
+)
+
+// Provider modes. Record talks to the real API and saves every response
+// under a fixtures directory; replay serves those responses back without
+// any network, so polling, normalization and the broadcast pipeline can
+// run offline and deterministically.
+const (
+    ModeLive   = "live"
+    ModeRecord = "record"
+    ModeReplay = "replay"
+)
+
+// fixture is one recorded response. JSON bodies are kept as JSON so
+// fixtures can be read and edited by hand; anything else is kept as text.
+type fixture struct {
+    Status     int             `json:"status"`
+    RetryAfter string          `json:"retry_after,omitempty"`
+    Body       json.RawMessage `json:"body,omitempty"`
+    Text       string          `json:"text,omitempty"`
+}
+
+// NewRecordingProvider is an HTTPProvider that saves every response it
+// gets, retries included, to dir. Responses are numbered per request path
+// in the order they arrive; recording into a directory that already has
+// fixtures appends to them, so clear it for a fresh capture.
+func NewRecordingProvider(baseURL, apiKey, dir string) *HTTPProvider {
+    p := NewHTTPProvider(baseURL, apiKey)
+    p.http.Transport = &recorder{next: p.http.Transport, dir: dir, counts: make(map[string]int)}
+    return p
+}
+
+// NewReplayProvider is an HTTPProvider serving the fixtures in dir in the
+// order they were recorded. Once a path's fixtures run out the last one
+// is served again, so a finished match stays finished; paths never
+// recorded get a 404.
+func NewReplayProvider(dir string) *HTTPProvider {
+    p := NewHTTPProvider("http://replay.invalid", "")
+    p.http.Transport = &replayer{dir: dir, served: make(map[string]int)}
+    // Recorded retries replay in order; there is nothing to wait for
+    p.backoff = time.Millisecond
+    return p
+}
+
+// fixtureKey names the directory a request path's fixtures live in.
+func fixtureKey(path string) string {
+    return url.PathEscape(strings.ReplaceAll(strings.Trim(path, "/"), "/", "__"))
+}
+
+func fixturePath(dir, key string, n int) string {
+    return filepath.Join(dir, key, fmt.Sprintf("%06d.json", n))
+}
+
+type recorder struct {
+    next   http.RoundTripper
+    dir    string
+    mu     sync.Mutex
+    counts map[string]int
+}
+
+func (r *recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+    resp, err := r.next.RoundTrip(req)
+    if err != nil {
+        return nil, err
+    }
+    body, err := io.ReadAll(resp.Body)
+    resp.Body.Close()
+    if err != nil {
+        return nil, err
+    }
+    resp.Body = io.NopCloser(bytes.NewReader(body))
+
+    f := fixture{Status: resp.StatusCode, RetryAfter: resp.Header.Get("Retry-After")}
+    if json.Valid(body) {
+        f.Body = body
+    } else {
+        f.Text = string(body)
+    }
+    if err := r.save(fixtureKey(req.URL.Path), &f); err != nil {
+        return nil, fmt.Errorf("failed to record sports api response: %w", err)
+    }
+    return resp, nil
+}
+
+func (r *recorder) save(key string, f *fixture) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    if err := os.MkdirAll(filepath.Join(r.dir, key), 0o755); err != nil {
+        return err
+    }
+    n, ok := r.counts[key]
+    if !ok {
+        // Continue after whatever an earlier session recorded
+        existing, err := filepath.Glob(filepath.Join(r.dir, key, "*.json"))
+        if err != nil {
+            return err
+        }
+        n = len(existing)
+    }
+    n++
+
+    data, err := json.MarshalIndent(f, "", "  ")
+    if err != nil {
+        return err
+    }
+    if err := os.WriteFile(fixturePath(r.dir, key, n), data, 0o644); err != nil {
+        return err
+    }
+    r.counts[key] = n
+    return nil
+}
+
+type replayer struct {
+    dir    string
+    mu     sync.Mutex
+    served map[string]int
+}
+
+func (r *replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+    f, err := r.next(fixtureKey(req.URL.Path))
+    if err != nil {
+        return nil, err
+    }
+    if f == nil {
+        f = &fixture{Status: http.StatusNotFound, Text: "no recorded response"}
+    }
+
+    body := []byte(f.Text)
+    if len(f.Body) > 0 {
+        body = f.Body
+    }
+    header := make(http.Header)
+    header.Set("Content-Type", "application/json")
+    if f.RetryAfter != "" {
+        header.Set("Retry-After", f.RetryAfter)
+    }
+    return &http.Response{
+        Status:        fmt.Sprintf("%d %s", f.Status, http.StatusText(f.Status)),
+        StatusCode:    f.Status,
+        Proto:         "HTTP/1.1",
+        ProtoMajor:    1,
+        ProtoMinor:    1,
+        Header:        header,
+        Body:          io.NopCloser(bytes.NewReader(body)),
+        ContentLength: int64(len(body)),
+        Request:       req,
+    }, nil
+}
+
+// next reads the path's next fixture, or its last once they run out. It
+// returns nil if the path has none.
+func (r *replayer) next(key string) (*fixture, error) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+
+    n := r.served[key] + 1
+    data, err := os.ReadFile(fixturePath(r.dir, key, n))
+    if os.IsNotExist(err) && n > 1 {
+        n--
+        data, err = os.ReadFile(fixturePath(r.dir, key, n))
+    }
+    if os.IsNotExist(err) {
+        return nil, nil
+    }
+    if err != nil {
+        return nil, fmt.Errorf("failed to read sports api fixture: %w", err)
+    }
+    r.served[key] = n
+
+    var f fixture
+    if err := json.Unmarshal(data, &f); err != nil {
+        return nil, fmt.Errorf("invalid sports api fixture %s/%06d.json: %w", key, n, err)
+    }
+    return &f, nil
+}
@@ -0,0 +1,102 @@
+package sportsdata
+
+import (
+    "crypto/hmac"
+    "crypto/sha256"
+    "encoding/hex"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "strconv"
+    "strings"
+    "time"
+
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+// SignatureHeader carries a pushed payload's signature:
+//
+//  t=<unix seconds>,v1=<hex HMAC-SHA256 of "<t>.<body>">
+//
+// keyed with the webhook secret shared with the provider. Signing the
+// timestamp with the body keeps a captured push from being replayed
+// later.
+const SignatureHeader = "X-Webhook-Signature"
+
+// signatureTolerance is how far a push's timestamp may be from the
+// receiver's clock.
+const signatureTolerance = 5 * time.Minute
+
+var (
+    ErrInvalidSignature = errors.New("invalid webhook signature")
+    ErrInvalidPush      = errors.New("invalid webhook payload")
+)
+
+// Push is a match update a provider sent rather than one polled: the
+// match's state, with ProviderID set, and events since its last push,
+// with MatchID left for the caller to fill in.
+type Push struct {
+    Match  *models.Match
+    Events []*models.MatchEvent
+}
+
+type providerPush struct {
+    Match  *providerMatch   `json:"match"`
+    Events []*providerEvent `json:"events"`
+}
+
+// Sign returns the signature header value for body at now.
+func Sign(secret, body []byte, now time.Time) string {
+    t := strconv.FormatInt(now.Unix(), 10)
+    return "t=" + t + ",v1=" + signature(secret, t, body)
+}
+
+// VerifySignature checks a signature header against body.
+func VerifySignature(secret, body []byte, header string, now time.Time) error {
+    var t, v1 string
+    for _, part := range strings.Split(header, ",") {
+        key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+        switch key {
+        case "t":
+            t = value
+        case "v1":
+            v1 = value
+        }
+    }
+    unix, err := strconv.ParseInt(t, 10, 64)
+    if err != nil || v1 == "" {
+        return ErrInvalidSignature
+    }
+    if skew := now.Sub(time.Unix(unix, 0)); skew > signatureTolerance || skew < -signatureTolerance {
+        return ErrInvalidSignature
+    }
+    if !hmac.Equal([]byte(v1), []byte(signature(secret, t, body))) {
+        return ErrInvalidSignature
+    }
+    return nil
+}
+
+func signature(secret []byte, t string, body []byte) string {
+    mac := hmac.New(sha256.New, secret)
+    mac.Write([]byte(t))
+    mac.Write([]byte{'.'})
+    mac.Write(body)
+    return hex.EncodeToString(mac.Sum(nil))
+}
+
+// ParsePush normalizes a pushed payload the way polled responses are.
+func ParsePush(body []byte) (*Push, error) {
+    var pp providerPush
+    if err := json.Unmarshal(body, &pp); err != nil {
+        return nil, fmt.Errorf("%w: %v", ErrInvalidPush, err)
+    }
+    if pp.Match == nil || pp.Match.ID == "" {
+        return nil, fmt.Errorf("%w: match id is required", ErrInvalidPush)
+    }
+
+    push := &Push{Match: normalizeMatch(pp.Match), Events: make([]*models.MatchEvent, 0, len(pp.Events))}
+    for _, pe := range pp.Events {
+        push.Events = append(push.Events, normalizeEvent(pe))
+    }
+    return push, nil
+}
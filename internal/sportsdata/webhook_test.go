@@ -0,0 +1,43 @@
+package sportsdata
+
+import (
+    "errors"
+    "strconv"
+    "testing"
+    "time"
+)
+
+func TestVerifySignatureRejects(t *testing.T) {
+    secret := []byte("0123456789abcdef0123456789abcdef")
+    body := []byte(`{"match":{"id":"m1"}}`)
+    now := time.Unix(1700000000, 0)
+    ts := strconv.FormatInt(now.Unix(), 10)
+
+    tests := []struct {
+        name   string
+        body   []byte
+        header string
+    }{
+        {"missing header", body, ""},
+        {"wrong secret", body, Sign([]byte("another secret, also 32 chars.."), body, now)},
+        {"tampered body", []byte(`{"match":{"id":"m2"}}`), Sign(secret, body, now)},
+        {"stale timestamp", body, Sign(secret, body, now.Add(-signatureTolerance-time.Second))},
+        {"future timestamp", body, Sign(secret, body, now.Add(signatureTolerance+time.Second))},
+        {"timestamp not signed", body, "t=" + strconv.FormatInt(now.Unix()+1, 10) + ",v1=" + signature(secret, ts, body)},
+        {"missing signature", body, "t=" + ts},
+        {"malformed timestamp", body, "t=now,v1=" + signature(secret, "now", body)},
+        {"truncated signature", body, Sign(secret, body, now)[:40]},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            err := VerifySignature(secret, tt.body, tt.header, now)
+            if !errors.Is(err, ErrInvalidSignature) {
+                t.Fatalf("got %v, want %v", err, ErrInvalidSignature)
+            }
+        })
+    }
+
+    if err := VerifySignature(secret, body, Sign(secret, body, now), now); err != nil {
+        t.Fatalf("valid signature rejected: %v", err)
+    }
+}
@@ -0,0 +1,41 @@
+package store
+
+import (
+    "encoding/base64"
+    "errors"
+    "strconv"
+    "strings"
+    "time"
+
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// CursorOf returns the position of a message in its room's history.
+func CursorOf(msg *models.Message) MessageCursor {
+    return MessageCursor{CreatedAt: msg.CreatedAt, ID: msg.ID}
+}
+
+// Encode makes the cursor opaque to clients, so the ordering key can
+// change without breaking anyone's saved cursors.
+func (c MessageCursor) Encode() string {
+    raw := strconv.FormatInt(c.CreatedAt.UnixNano(), 10) + ":" + c.ID
+    return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func DecodeMessageCursor(s string) (MessageCursor, error) {
+    raw, err := base64.RawURLEncoding.DecodeString(s)
+    if err != nil {
+        return MessageCursor{}, ErrInvalidCursor
+    }
+    nanos, id, ok := strings.Cut(string(raw), ":")
+    if !ok || id == "" {
+        return MessageCursor{}, ErrInvalidCursor
+    }
+    n, err := strconv.ParseInt(nanos, 10, 64)
+    if err != nil {
+        return MessageCursor{}, ErrInvalidCursor
+    }
+    return MessageCursor{CreatedAt: time.Unix(0, n).UTC(), ID: id}, nil
+}
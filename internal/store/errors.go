@@ -0,0 +1,23 @@
+package store
+
+import "errors"
+
+// ErrUnavailable marks errors from a store that could not be reached, as
+// opposed to calls that failed on their merits. The call did not take
+// effect, so callers holding work may keep it and try again later.
+var ErrUnavailable = errors.New("store unavailable")
+
+// ErrorClass sorts store errors by whether a retry can help.
+type ErrorClass int
+
+const (
+    // ErrorPermanent errors fail the same way if retried, or may already
+    // have taken effect: missing rows, constraint violations, timeouts.
+    ErrorPermanent ErrorClass = iota
+    // ErrorTransient errors rolled the call back and a retry may
+    // succeed, such as serialization failures and deadlocks.
+    ErrorTransient
+    // ErrorUnavailable errors mean the database could not be reached, so
+    // the call never ran.
+    ErrorUnavailable
+)
@@ -0,0 +1,296 @@
+// Command gen writes Store decorators from the Store interface, so every
+// store method is wrapped without hand-written code. -kind picks the
+// decorator: instrumented observes each call, resilient retries it. Run
+// it with go generate after changing the interface.
+package main
+
+import (
+    "bytes"
+    "flag"
+    "fmt"
+    "go/ast"
+    "go/format"
+    "go/parser"
+    "go/token"
+    "log"
+    "os"
+    "sort"
+    "strconv"
+    "strings"
+)
+
+const storeImport = "github.com/yourusername/sports-chat/internal/store"
+
+type method struct {
+    name    string
+    params  []param
+    results []string
+}
+
+type param struct {
+    name     string
+    typ      string
+    variadic bool
+}
+
+func main() {
+    in := flag.String("in", "../store.go", "file declaring the Store interface")
+    out := flag.String("out", "store_gen.go", "output file")
+    kind := flag.String("kind", "instrumented", "decorator to write: instrumented or resilient")
+    flag.Parse()
+
+    writeMethod := writeInstrumented
+    switch *kind {
+    case "instrumented":
+    case "resilient":
+        writeMethod = writeResilient
+    default:
+        log.Fatalf("unknown decorator kind %q", *kind)
+    }
+
+    fset := token.NewFileSet()
+    file, err := parser.ParseFile(fset, *in, nil, 0)
+    if err != nil {
+        log.Fatalf("failed to parse %s: %v", *in, err)
+    }
+
+    interfaces := make(map[string]*ast.InterfaceType)
+    ast.Inspect(file, func(n ast.Node) bool {
+        if spec, ok := n.(*ast.TypeSpec); ok {
+            if iface, ok := spec.Type.(*ast.InterfaceType); ok {
+                interfaces[spec.Name.Name] = iface
+            }
+        }
+        return true
+    })
+
+    if interfaces["Store"] == nil {
+        log.Fatalf("no Store interface in %s", *in)
+    }
+
+    imports := make(map[string]string)
+    for _, spec := range file.Imports {
+        path, _ := strconv.Unquote(spec.Path.Value)
+        name := path[strings.LastIndex(path, "/")+1:]
+        if spec.Name != nil {
+            name = spec.Name.Name
+        }
+        imports[name] = path
+    }
+
+    used := map[string]bool{"store": true}
+    methods := collect(interfaces, "Store", used)
+    sort.Slice(methods, func(i, j int) bool { return methods[i].name < methods[j].name })
+
+    var buf bytes.Buffer
+    fmt.Fprintln(&buf, "// Code generated by gen; DO NOT EDIT.")
+    fmt.Fprintln(&buf)
+    fmt.Fprintf(&buf, "package %s\n", *kind)
+    fmt.Fprintln(&buf)
+    fmt.Fprintln(&buf, "import (")
+    var paths []string
+    for name := range used {
+        if name == "store" {
+            paths = append(paths, storeImport)
+            continue
+        }
+        paths = append(paths, imports[name])
+    }
+    sort.Strings(paths)
+    var std, local []string
+    for _, path := range paths {
+        if strings.Contains(strings.SplitN(path, "/", 2)[0], ".") {
+            local = append(local, path)
+        } else {
+            std = append(std, path)
+        }
+    }
+    for _, path := range std {
+        fmt.Fprintf(&buf, "\t%q\n", path)
+    }
+    fmt.Fprintln(&buf)
+    for _, path := range local {
+        fmt.Fprintf(&buf, "\t%q\n", path)
+    }
+    fmt.Fprintln(&buf, ")")
+    fmt.Fprintln(&buf)
+    fmt.Fprintln(&buf, "var _ store.Store = (*Store)(nil)")
+
+    for _, m := range methods {
+        writeMethod(&buf, m)
+    }
+
+    src, err := format.Source(buf.Bytes())
+    if err != nil {
+        log.Fatalf("failed to format output: %v\n%s", err, buf.Bytes())
+    }
+    if err := os.WriteFile(*out, src, 0o644); err != nil {
+        log.Fatalf("failed to write %s: %v", *out, err)
+    }
+}
+
+// collect flattens an interface, following embedded interfaces declared
+// in the same file.
+func collect(interfaces map[string]*ast.InterfaceType, name string, used map[string]bool) []method {
+    var methods []method
+    for _, field := range interfaces[name].Methods.List {
+        fn, ok := field.Type.(*ast.FuncType)
+        if !ok {
+            embedded, ok := field.Type.(*ast.Ident)
+            if !ok || interfaces[embedded.Name] == nil {
+                log.Fatalf("unsupported embedded type in %s", name)
+            }
+            methods = append(methods, collect(interfaces, embedded.Name, used)...)
+            continue
+        }
+
+        m := method{name: field.Names[0].Name}
+        n := 0
+        for _, p := range fn.Params.List {
+            typ := p.Type
+            variadic := false
+            if ellipsis, ok := typ.(*ast.Ellipsis); ok {
+                typ, variadic = ellipsis.Elt, true
+            }
+            names := p.Names
+            if len(names) == 0 {
+                names = []*ast.Ident{ast.NewIdent(fmt.Sprintf("p%d", n))}
+            }
+            for _, ident := range names {
+                m.params = append(m.params, param{name: ident.Name, typ: typeString(typ, used), variadic: variadic})
+                n++
+            }
+        }
+        if fn.Results != nil {
+            for _, r := range fn.Results.List {
+                count := len(r.Names)
+                if count == 0 {
+                    count = 1
+                }
+                for i := 0; i < count; i++ {
+                    m.results = append(m.results, typeString(r.Type, used))
+                }
+            }
+        }
+        methods = append(methods, m)
+    }
+    return methods
+}
+
+// typeString prints a type as seen from the decorator's package:
+// exported identifiers declared in package store get qualified.
+func typeString(expr ast.Expr, used map[string]bool) string {
+    switch t := expr.(type) {
+    case *ast.Ident:
+        if ast.IsExported(t.Name) {
+            used["store"] = true
+            return "store." + t.Name
+        }
+        return t.Name
+    case *ast.SelectorExpr:
+        pkg := t.X.(*ast.Ident).Name
+        used[pkg] = true
+        return pkg + "." + t.Sel.Name
+    case *ast.StarExpr:
+        return "*" + typeString(t.X, used)
+    case *ast.ArrayType:
+        return "[]" + typeString(t.Elt, used)
+    case *ast.MapType:
+        return "map[" + typeString(t.Key, used) + "]" + typeString(t.Value, used)
+    case *ast.InterfaceType:
+        return "interface{}"
+    }
+    log.Fatalf("unsupported type %T", expr)
+    return ""
+}
+
+// signature returns the method's parameter list, its call arguments, its
+// result list and the variables holding its results, the last named err
+// when it is an error.
+func signature(m method) (params, args []string, results string, vars []string) {
+    for _, p := range m.params {
+        if p.variadic {
+            params = append(params, p.name+" ..."+p.typ)
+            args = append(args, p.name+"...")
+        } else {
+            params = append(params, p.name+" "+p.typ)
+            args = append(args, p.name)
+        }
+    }
+
+    results = strings.Join(m.results, ", ")
+    if len(m.results) > 1 {
+        results = "(" + results + ")"
+    }
+
+    for i := range m.results {
+        if i == len(m.results)-1 && m.results[i] == "error" {
+            vars = append(vars, "err")
+        } else {
+            vars = append(vars, fmt.Sprintf("r%d", i))
+        }
+    }
+    return params, args, results, vars
+}
+
+func writeInstrumented(buf *bytes.Buffer, m method) {
+    params, args, results, vars := signature(m)
+    returnsErr := len(vars) > 0 && vars[len(vars)-1] == "err"
+
+    fmt.Fprintln(buf)
+    fmt.Fprintf(buf, "func (s *Store) %s(%s) %s {\n", m.name, strings.Join(params, ", "), results)
+    // Methods taking a context get a span and pass its context on
+    if len(m.params) > 0 && m.params[0].typ == "context.Context" {
+        ctx := m.params[0].name
+        fmt.Fprintf(buf, "\t%s, done := s.trace(%s, %q)\n", ctx, ctx, m.name)
+    } else {
+        fmt.Fprintf(buf, "\tdone := s.observe(%q)\n", m.name)
+    }
+    call := fmt.Sprintf("s.next.%s(%s)", m.name, strings.Join(args, ", "))
+    if len(vars) == 0 {
+        fmt.Fprintf(buf, "\t%s\n\tdone(nil)\n}\n", call)
+        return
+    }
+    fmt.Fprintf(buf, "\t%s := %s\n", strings.Join(vars, ", "), call)
+    if returnsErr {
+        fmt.Fprintln(buf, "\tdone(err)")
+    } else {
+        fmt.Fprintln(buf, "\tdone(nil)")
+    }
+    fmt.Fprintf(buf, "\treturn %s\n}\n", strings.Join(vars, ", "))
+}
+
+// writeResilient wraps methods taking a context and returning an error in
+// s.do, which retries them; any other method is passed straight through.
+func writeResilient(buf *bytes.Buffer, m method) {
+    params, args, results, vars := signature(m)
+    call := fmt.Sprintf("s.next.%s(%s)", m.name, strings.Join(args, ", "))
+    fmt.Fprintln(buf)
+    fmt.Fprintf(buf, "func (s *Store) %s(%s) %s {\n", m.name, strings.Join(params, ", "), results)
+
+    returnsErr := len(vars) > 0 && vars[len(vars)-1] == "err"
+    if len(m.params) == 0 || m.params[0].typ != "context.Context" || !returnsErr {
+        if len(vars) == 0 {
+            fmt.Fprintf(buf, "\t%s\n}\n", call)
+        } else {
+            fmt.Fprintf(buf, "\treturn %s\n}\n", call)
+        }
+        return
+    }
+
+    ctx := m.params[0].name
+    if len(vars) == 1 {
+        fmt.Fprintf(buf, "\treturn s.do(%s, %q, func(%s context.Context) error {\n", ctx, m.name, ctx)
+        fmt.Fprintf(buf, "\t\treturn %s\n", call)
+        fmt.Fprintln(buf, "\t})\n}")
+        return
+    }
+    for i, v := range vars[:len(vars)-1] {
+        fmt.Fprintf(buf, "\tvar %s %s\n", v, m.results[i])
+    }
+    fmt.Fprintf(buf, "\terr := s.do(%s, %q, func(%s context.Context) (err error) {\n", ctx, m.name, ctx)
+    fmt.Fprintf(buf, "\t\t%s = %s\n", strings.Join(vars, ", "), call)
+    fmt.Fprintln(buf, "\t\treturn err")
+    fmt.Fprintln(buf, "\t})")
+    fmt.Fprintf(buf, "\treturn %s\n}\n", strings.Join(vars, ", "))
+}
@@ -0,0 +1,58 @@
+// Package instrumented wraps a store.Store with per-operation metrics and
+// trace spans.
+// The method wrappers in store_gen.go are generated from the Store
+// interface; run go generate after adding store methods.
+package instrumented
+
+//go:generate go run ../gen -kind instrumented -in ../store.go -out store_gen.go
+
+import (
+    "context"
+    "time"
+
+    "go.opentelemetry.io/otel/attribute"
+    "go.opentelemetry.io/otel/trace"
+
+    "github.com/yourusername/sports-chat/internal/metrics"
+    "github.com/yourusername/sports-chat/internal/store"
+    "github.com/yourusername/sports-chat/internal/tracing"
+)
+
+// Store records the duration, errors and in-flight count of every call
+// to the wrapped store, labeled by operation (the method name).
+type Store struct {
+    next    store.Store
+    metrics *metrics.Metrics
+}
+
+func New(next store.Store, metrics *metrics.Metrics) *Store {
+    return &Store{next: next, metrics: metrics}
+}
+
+func (s *Store) observe(operation string) func(error) {
+    inFlight := s.metrics.StoreInFlight.WithLabelValues(operation)
+    inFlight.Inc()
+    start := time.Now()
+
+    return func(err error) {
+        inFlight.Dec()
+        s.metrics.StoreDuration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+        if err != nil {
+            s.metrics.StoreErrors.WithLabelValues(operation).Inc()
+        }
+    }
+}
+
+// trace observes an operation like observe and also records it as a
+// client span under ctx, named store.<operation>.
+func (s *Store) trace(ctx context.Context, operation string) (context.Context, func(error)) {
+    ctx, span := tracing.Start(ctx, "store."+operation,
+        trace.WithSpanKind(trace.SpanKindClient),
+        trace.WithAttributes(attribute.String("db.operation", operation)))
+    done := s.observe(operation)
+
+    return ctx, func(err error) {
+        done(err)
+        tracing.End(span, err)
+    }
+}
@@ -0,0 +1,1770 @@
+// Code generated by gen; DO NOT EDIT.
+
+package instrumented
+
+import (
+	"context"
+	"time"
+
+	"github.com/yourusername/sports-chat/internal/models"
+	"github.com/yourusername/sports-chat/internal/store"
+)
+
+var _ store.Store = (*Store)(nil)
+
+func (s *Store) AddEmote(ctx context.Context, emote *models.Emote) (bool, error) {
+	ctx, done := s.trace(ctx, "AddEmote")
+	r0, err := s.next.AddEmote(ctx, emote)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) AddProfanityWord(ctx context.Context, word *models.ProfanityWord) error {
+	ctx, done := s.trace(ctx, "AddProfanityWord")
+	err := s.next.AddProfanityWord(ctx, word)
+	done(err)
+	return err
+}
+
+func (s *Store) AddReaction(ctx context.Context, reaction *models.Reaction) (bool, error) {
+	ctx, done := s.trace(ctx, "AddReaction")
+	r0, err := s.next.AddReaction(ctx, reaction)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) AddUserMatchStats(ctx context.Context, deltas []*models.UserMatchStats) error {
+	ctx, done := s.trace(ctx, "AddUserMatchStats")
+	err := s.next.AddUserMatchStats(ctx, deltas)
+	done(err)
+	return err
+}
+
+func (s *Store) AnonymizeUser(ctx context.Context, userID string, username string, at time.Time) error {
+	ctx, done := s.trace(ctx, "AnonymizeUser")
+	err := s.next.AnonymizeUser(ctx, userID, username, at)
+	done(err)
+	return err
+}
+
+func (s *Store) AppendJournalEntries(ctx context.Context, entries []*models.JournalEntry) error {
+	ctx, done := s.trace(ctx, "AppendJournalEntries")
+	err := s.next.AppendJournalEntries(ctx, entries)
+	done(err)
+	return err
+}
+
+func (s *Store) ArchiveSeason(ctx context.Context, id string, at time.Time) (bool, error) {
+	ctx, done := s.trace(ctx, "ArchiveSeason")
+	r0, err := s.next.ArchiveSeason(ctx, id, at)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) AwardAchievement(ctx context.Context, achievement *models.UserAchievement) (bool, error) {
+	ctx, done := s.trace(ctx, "AwardAchievement")
+	r0, err := s.next.AwardAchievement(ctx, achievement)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) BlockUser(ctx context.Context, block *models.UserBlock) error {
+	ctx, done := s.trace(ctx, "BlockUser")
+	err := s.next.BlockUser(ctx, block)
+	done(err)
+	return err
+}
+
+func (s *Store) CastMatchBallot(ctx context.Context, ballot *models.MatchBallot) error {
+	ctx, done := s.trace(ctx, "CastMatchBallot")
+	err := s.next.CastMatchBallot(ctx, ballot)
+	done(err)
+	return err
+}
+
+func (s *Store) CastPollVote(ctx context.Context, vote *models.PollVote) error {
+	ctx, done := s.trace(ctx, "CastPollVote")
+	err := s.next.CastPollVote(ctx, vote)
+	done(err)
+	return err
+}
+
+func (s *Store) Close() error {
+	done := s.observe("Close")
+	err := s.next.Close()
+	done(err)
+	return err
+}
+
+func (s *Store) CloseMatchVote(ctx context.Context, matchID string, at time.Time) (bool, error) {
+	ctx, done := s.trace(ctx, "CloseMatchVote")
+	r0, err := s.next.CloseMatchVote(ctx, matchID, at)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) ClosePoll(ctx context.Context, id string, at time.Time) (bool, error) {
+	ctx, done := s.trace(ctx, "ClosePoll")
+	r0, err := s.next.ClosePoll(ctx, id, at)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) CollapseRoomTopic(ctx context.Context, id string, at time.Time) (bool, error) {
+	ctx, done := s.trace(ctx, "CollapseRoomTopic")
+	r0, err := s.next.CollapseRoomTopic(ctx, id, at)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) CollapseRoomTopics(ctx context.Context, roomID string, at time.Time) (int, error) {
+	ctx, done := s.trace(ctx, "CollapseRoomTopics")
+	r0, err := s.next.CollapseRoomTopics(ctx, roomID, at)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) CompleteDataExport(ctx context.Context, export *models.DataExport) (bool, error) {
+	ctx, done := s.trace(ctx, "CompleteDataExport")
+	r0, err := s.next.CompleteDataExport(ctx, export)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) CountMatchFollowers(ctx context.Context, matchID string) (int, error) {
+	ctx, done := s.trace(ctx, "CountMatchFollowers")
+	r0, err := s.next.CountMatchFollowers(ctx, matchID)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) CountPendingDeadLetters(ctx context.Context) (int, error) {
+	ctx, done := s.trace(ctx, "CountPendingDeadLetters")
+	r0, err := s.next.CountPendingDeadLetters(ctx)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) CreateAppeal(ctx context.Context, appeal *models.Appeal) (bool, error) {
+	ctx, done := s.trace(ctx, "CreateAppeal")
+	r0, err := s.next.CreateAppeal(ctx, appeal)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) CreateAttachment(ctx context.Context, attachment *models.Attachment, data []byte) error {
+	ctx, done := s.trace(ctx, "CreateAttachment")
+	err := s.next.CreateAttachment(ctx, attachment, data)
+	done(err)
+	return err
+}
+
+func (s *Store) CreateChatRoom(ctx context.Context, room *models.ChatRoom) error {
+	ctx, done := s.trace(ctx, "CreateChatRoom")
+	err := s.next.CreateChatRoom(ctx, room)
+	done(err)
+	return err
+}
+
+func (s *Store) CreateDataExport(ctx context.Context, export *models.DataExport) error {
+	ctx, done := s.trace(ctx, "CreateDataExport")
+	err := s.next.CreateDataExport(ctx, export)
+	done(err)
+	return err
+}
+
+func (s *Store) CreateDeadLetter(ctx context.Context, letter *models.DeadLetter) error {
+	ctx, done := s.trace(ctx, "CreateDeadLetter")
+	err := s.next.CreateDeadLetter(ctx, letter)
+	done(err)
+	return err
+}
+
+func (s *Store) CreateDirectMessage(ctx context.Context, msg *models.DirectMessage) error {
+	ctx, done := s.trace(ctx, "CreateDirectMessage")
+	err := s.next.CreateDirectMessage(ctx, msg)
+	done(err)
+	return err
+}
+
+func (s *Store) CreateDirectoryGroup(ctx context.Context, group *models.DirectoryGroup) error {
+	ctx, done := s.trace(ctx, "CreateDirectoryGroup")
+	err := s.next.CreateDirectoryGroup(ctx, group)
+	done(err)
+	return err
+}
+
+func (s *Store) CreateEmotePack(ctx context.Context, pack *models.EmotePack) error {
+	ctx, done := s.trace(ctx, "CreateEmotePack")
+	err := s.next.CreateEmotePack(ctx, pack)
+	done(err)
+	return err
+}
+
+func (s *Store) CreateEvasionSuspect(ctx context.Context, suspect *models.EvasionSuspect) (bool, error) {
+	ctx, done := s.trace(ctx, "CreateEvasionSuspect")
+	r0, err := s.next.CreateEvasionSuspect(ctx, suspect)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) CreateHighlight(ctx context.Context, highlight *models.Highlight) error {
+	ctx, done := s.trace(ctx, "CreateHighlight")
+	err := s.next.CreateHighlight(ctx, highlight)
+	done(err)
+	return err
+}
+
+func (s *Store) CreateIncident(ctx context.Context, incident *models.Incident) error {
+	ctx, done := s.trace(ctx, "CreateIncident")
+	err := s.next.CreateIncident(ctx, incident)
+	done(err)
+	return err
+}
+
+func (s *Store) CreateKeywordAlert(ctx context.Context, alert *models.KeywordAlert) error {
+	ctx, done := s.trace(ctx, "CreateKeywordAlert")
+	err := s.next.CreateKeywordAlert(ctx, alert)
+	done(err)
+	return err
+}
+
+func (s *Store) CreateMatch(ctx context.Context, match *models.Match) error {
+	ctx, done := s.trace(ctx, "CreateMatch")
+	err := s.next.CreateMatch(ctx, match)
+	done(err)
+	return err
+}
+
+func (s *Store) CreateMatchEvent(ctx context.Context, event *models.MatchEvent) error {
+	ctx, done := s.trace(ctx, "CreateMatchEvent")
+	err := s.next.CreateMatchEvent(ctx, event)
+	done(err)
+	return err
+}
+
+func (s *Store) CreateMessage(ctx context.Context, message *models.Message) error {
+	ctx, done := s.trace(ctx, "CreateMessage")
+	err := s.next.CreateMessage(ctx, message)
+	done(err)
+	return err
+}
+
+func (s *Store) CreateMessageArchive(ctx context.Context, archive *models.MessageArchive, messageIDs []string) error {
+	ctx, done := s.trace(ctx, "CreateMessageArchive")
+	err := s.next.CreateMessageArchive(ctx, archive, messageIDs)
+	done(err)
+	return err
+}
+
+func (s *Store) CreateMessageFlag(ctx context.Context, flag *models.MessageFlag) error {
+	ctx, done := s.trace(ctx, "CreateMessageFlag")
+	err := s.next.CreateMessageFlag(ctx, flag)
+	done(err)
+	return err
+}
+
+func (s *Store) CreateMessages(ctx context.Context, messages []*models.Message) error {
+	ctx, done := s.trace(ctx, "CreateMessages")
+	err := s.next.CreateMessages(ctx, messages)
+	done(err)
+	return err
+}
+
+func (s *Store) CreateModerationEvidence(ctx context.Context, evidence *models.ModerationEvidence) error {
+	ctx, done := s.trace(ctx, "CreateModerationEvidence")
+	err := s.next.CreateModerationEvidence(ctx, evidence)
+	done(err)
+	return err
+}
+
+func (s *Store) CreatePoll(ctx context.Context, poll *models.Poll) error {
+	ctx, done := s.trace(ctx, "CreatePoll")
+	err := s.next.CreatePoll(ctx, poll)
+	done(err)
+	return err
+}
+
+func (s *Store) CreateReconciliationReport(ctx context.Context, report *models.ReconciliationReport) error {
+	ctx, done := s.trace(ctx, "CreateReconciliationReport")
+	err := s.next.CreateReconciliationReport(ctx, report)
+	done(err)
+	return err
+}
+
+func (s *Store) CreateRecoveryToken(ctx context.Context, token *models.RecoveryToken) error {
+	ctx, done := s.trace(ctx, "CreateRecoveryToken")
+	err := s.next.CreateRecoveryToken(ctx, token)
+	done(err)
+	return err
+}
+
+func (s *Store) CreateRoomSanction(ctx context.Context, sanction *models.RoomSanction) error {
+	ctx, done := s.trace(ctx, "CreateRoomSanction")
+	err := s.next.CreateRoomSanction(ctx, sanction)
+	done(err)
+	return err
+}
+
+func (s *Store) CreateRoomTopic(ctx context.Context, topic *models.RoomTopic) error {
+	ctx, done := s.trace(ctx, "CreateRoomTopic")
+	err := s.next.CreateRoomTopic(ctx, topic)
+	done(err)
+	return err
+}
+
+func (s *Store) CreateSeason(ctx context.Context, season *models.Season) error {
+	ctx, done := s.trace(ctx, "CreateSeason")
+	err := s.next.CreateSeason(ctx, season)
+	done(err)
+	return err
+}
+
+func (s *Store) CreateSport(ctx context.Context, sport *models.Sport) error {
+	ctx, done := s.trace(ctx, "CreateSport")
+	err := s.next.CreateSport(ctx, sport)
+	done(err)
+	return err
+}
+
+func (s *Store) CreateTeam(ctx context.Context, team *models.Team) error {
+	ctx, done := s.trace(ctx, "CreateTeam")
+	err := s.next.CreateTeam(ctx, team)
+	done(err)
+	return err
+}
+
+func (s *Store) CreateUser(ctx context.Context, user *models.User) error {
+	ctx, done := s.trace(ctx, "CreateUser")
+	err := s.next.CreateUser(ctx, user)
+	done(err)
+	return err
+}
+
+func (s *Store) DeleteChatRoom(ctx context.Context, id string) error {
+	ctx, done := s.trace(ctx, "DeleteChatRoom")
+	err := s.next.DeleteChatRoom(ctx, id)
+	done(err)
+	return err
+}
+
+func (s *Store) DeleteCommentaryTemplate(ctx context.Context, locale string, eventType string) error {
+	ctx, done := s.trace(ctx, "DeleteCommentaryTemplate")
+	err := s.next.DeleteCommentaryTemplate(ctx, locale, eventType)
+	done(err)
+	return err
+}
+
+func (s *Store) DeleteDataExport(ctx context.Context, id string) error {
+	ctx, done := s.trace(ctx, "DeleteDataExport")
+	err := s.next.DeleteDataExport(ctx, id)
+	done(err)
+	return err
+}
+
+func (s *Store) DeleteDirectoryGroup(ctx context.Context, id string) error {
+	ctx, done := s.trace(ctx, "DeleteDirectoryGroup")
+	err := s.next.DeleteDirectoryGroup(ctx, id)
+	done(err)
+	return err
+}
+
+func (s *Store) DeleteDraft(ctx context.Context, userID string, roomID string) error {
+	ctx, done := s.trace(ctx, "DeleteDraft")
+	err := s.next.DeleteDraft(ctx, userID, roomID)
+	done(err)
+	return err
+}
+
+func (s *Store) DeleteEmote(ctx context.Context, id string) error {
+	ctx, done := s.trace(ctx, "DeleteEmote")
+	err := s.next.DeleteEmote(ctx, id)
+	done(err)
+	return err
+}
+
+func (s *Store) DeleteHighlight(ctx context.Context, id string) error {
+	ctx, done := s.trace(ctx, "DeleteHighlight")
+	err := s.next.DeleteHighlight(ctx, id)
+	done(err)
+	return err
+}
+
+func (s *Store) DeleteKeywordAlert(ctx context.Context, userID string, id string) error {
+	ctx, done := s.trace(ctx, "DeleteKeywordAlert")
+	err := s.next.DeleteKeywordAlert(ctx, userID, id)
+	done(err)
+	return err
+}
+
+func (s *Store) DeleteLocalizedName(ctx context.Context, kind string, entityID string, locale string) error {
+	ctx, done := s.trace(ctx, "DeleteLocalizedName")
+	err := s.next.DeleteLocalizedName(ctx, kind, entityID, locale)
+	done(err)
+	return err
+}
+
+func (s *Store) DeleteMatch(ctx context.Context, id string) error {
+	ctx, done := s.trace(ctx, "DeleteMatch")
+	err := s.next.DeleteMatch(ctx, id)
+	done(err)
+	return err
+}
+
+func (s *Store) DeleteMessage(ctx context.Context, id string) error {
+	ctx, done := s.trace(ctx, "DeleteMessage")
+	err := s.next.DeleteMessage(ctx, id)
+	done(err)
+	return err
+}
+
+func (s *Store) DeleteMessageArchive(ctx context.Context, id string) error {
+	ctx, done := s.trace(ctx, "DeleteMessageArchive")
+	err := s.next.DeleteMessageArchive(ctx, id)
+	done(err)
+	return err
+}
+
+func (s *Store) DeleteProfanityWord(ctx context.Context, locale string, word string) error {
+	ctx, done := s.trace(ctx, "DeleteProfanityWord")
+	err := s.next.DeleteProfanityWord(ctx, locale, word)
+	done(err)
+	return err
+}
+
+func (s *Store) DeleteQuietHours(ctx context.Context, userID string) error {
+	ctx, done := s.trace(ctx, "DeleteQuietHours")
+	err := s.next.DeleteQuietHours(ctx, userID)
+	done(err)
+	return err
+}
+
+func (s *Store) DeleteRoomRole(ctx context.Context, roomID string, userID string) (bool, error) {
+	ctx, done := s.trace(ctx, "DeleteRoomRole")
+	r0, err := s.next.DeleteRoomRole(ctx, roomID, userID)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) DeleteRoomSanction(ctx context.Context, roomID string, userID string, kind string) error {
+	ctx, done := s.trace(ctx, "DeleteRoomSanction")
+	err := s.next.DeleteRoomSanction(ctx, roomID, userID, kind)
+	done(err)
+	return err
+}
+
+func (s *Store) DeleteSport(ctx context.Context, id string) error {
+	ctx, done := s.trace(ctx, "DeleteSport")
+	err := s.next.DeleteSport(ctx, id)
+	done(err)
+	return err
+}
+
+func (s *Store) DeleteTeam(ctx context.Context, id string) error {
+	ctx, done := s.trace(ctx, "DeleteTeam")
+	err := s.next.DeleteTeam(ctx, id)
+	done(err)
+	return err
+}
+
+func (s *Store) DeleteUser(ctx context.Context, id string) error {
+	ctx, done := s.trace(ctx, "DeleteUser")
+	err := s.next.DeleteUser(ctx, id)
+	done(err)
+	return err
+}
+
+func (s *Store) DisputeSecurityEvent(ctx context.Context, userID string, id string, at time.Time) (bool, error) {
+	ctx, done := s.trace(ctx, "DisputeSecurityEvent")
+	r0, err := s.next.DisputeSecurityEvent(ctx, userID, id, at)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetAppeal(ctx context.Context, id string) (*models.Appeal, error) {
+	ctx, done := s.trace(ctx, "GetAppeal")
+	r0, err := s.next.GetAppeal(ctx, id)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetArchiveCandidates(ctx context.Context, before time.Time, limit int) ([]store.ArchiveCandidate, error) {
+	ctx, done := s.trace(ctx, "GetArchiveCandidates")
+	r0, err := s.next.GetArchiveCandidates(ctx, before, limit)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetAttachment(ctx context.Context, id string) (*models.Attachment, error) {
+	ctx, done := s.trace(ctx, "GetAttachment")
+	r0, err := s.next.GetAttachment(ctx, id)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetAttachmentData(ctx context.Context, id string) ([]byte, error) {
+	ctx, done := s.trace(ctx, "GetAttachmentData")
+	r0, err := s.next.GetAttachmentData(ctx, id)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetBannedUserSightings(ctx context.Context, fingerprint string, ip string, since time.Time) ([]*models.DeviceSighting, error) {
+	ctx, done := s.trace(ctx, "GetBannedUserSightings")
+	r0, err := s.next.GetBannedUserSightings(ctx, fingerprint, ip, since)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetBlockedUsers(ctx context.Context, userID string) ([]*models.UserBlock, error) {
+	ctx, done := s.trace(ctx, "GetBlockedUsers")
+	r0, err := s.next.GetBlockedUsers(ctx, userID)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetChatRoom(ctx context.Context, id string) (*models.ChatRoom, error) {
+	ctx, done := s.trace(ctx, "GetChatRoom")
+	r0, err := s.next.GetChatRoom(ctx, id)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetChatRoomsByState(ctx context.Context, state string) ([]*models.ChatRoom, error) {
+	ctx, done := s.trace(ctx, "GetChatRoomsByState")
+	r0, err := s.next.GetChatRoomsByState(ctx, state)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetConversation(ctx context.Context, id string) (*models.Conversation, error) {
+	ctx, done := s.trace(ctx, "GetConversation")
+	r0, err := s.next.GetConversation(ctx, id)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetDataExport(ctx context.Context, id string) (*models.DataExport, error) {
+	ctx, done := s.trace(ctx, "GetDataExport")
+	r0, err := s.next.GetDataExport(ctx, id)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetDeadLetter(ctx context.Context, id string) (*models.DeadLetter, error) {
+	ctx, done := s.trace(ctx, "GetDeadLetter")
+	r0, err := s.next.GetDeadLetter(ctx, id)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetDirectMessagesBeforeCursor(ctx context.Context, conversationID string, before *store.MessageCursor, limit int) ([]*models.DirectMessage, error) {
+	ctx, done := s.trace(ctx, "GetDirectMessagesBeforeCursor")
+	r0, err := s.next.GetDirectMessagesBeforeCursor(ctx, conversationID, before, limit)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetDirectoryGroup(ctx context.Context, id string) (*models.DirectoryGroup, error) {
+	ctx, done := s.trace(ctx, "GetDirectoryGroup")
+	r0, err := s.next.GetDirectoryGroup(ctx, id)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetDueMatchVotes(ctx context.Context, now time.Time) ([]*models.MatchVote, error) {
+	ctx, done := s.trace(ctx, "GetDueMatchVotes")
+	r0, err := s.next.GetDueMatchVotes(ctx, now)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetDueSeasons(ctx context.Context, now time.Time) ([]*models.Season, error) {
+	ctx, done := s.trace(ctx, "GetDueSeasons")
+	r0, err := s.next.GetDueSeasons(ctx, now)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetEmotePack(ctx context.Context, id string) (*models.EmotePack, error) {
+	ctx, done := s.trace(ctx, "GetEmotePack")
+	r0, err := s.next.GetEmotePack(ctx, id)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetEvasionSignalStats(ctx context.Context) ([]*models.EvasionSignalStats, error) {
+	ctx, done := s.trace(ctx, "GetEvasionSignalStats")
+	r0, err := s.next.GetEvasionSignalStats(ctx)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetEvasionSuspect(ctx context.Context, id string) (*models.EvasionSuspect, error) {
+	ctx, done := s.trace(ctx, "GetEvasionSuspect")
+	r0, err := s.next.GetEvasionSuspect(ctx, id)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetEvidenceAccess(ctx context.Context, evidenceID string) ([]*models.EvidenceAccess, error) {
+	ctx, done := s.trace(ctx, "GetEvidenceAccess")
+	r0, err := s.next.GetEvidenceAccess(ctx, evidenceID)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetFinishedMatchesSince(ctx context.Context, since time.Time) ([]*models.Match, error) {
+	ctx, done := s.trace(ctx, "GetFinishedMatchesSince")
+	r0, err := s.next.GetFinishedMatchesSince(ctx, since)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetHeadToHeadMatches(ctx context.Context, teamAID string, teamBID string, limit int) ([]*models.Match, error) {
+	ctx, done := s.trace(ctx, "GetHeadToHeadMatches")
+	r0, err := s.next.GetHeadToHeadMatches(ctx, teamAID, teamBID, limit)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetIncident(ctx context.Context, id string) (*models.Incident, error) {
+	ctx, done := s.trace(ctx, "GetIncident")
+	r0, err := s.next.GetIncident(ctx, id)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetJournalEntries(ctx context.Context, roomID string, from time.Time, to time.Time, limit int) ([]*models.JournalEntry, error) {
+	ctx, done := s.trace(ctx, "GetJournalEntries")
+	r0, err := s.next.GetJournalEntries(ctx, roomID, from, to, limit)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetLiveMatches(ctx context.Context) ([]*models.Match, error) {
+	ctx, done := s.trace(ctx, "GetLiveMatches")
+	r0, err := s.next.GetLiveMatches(ctx)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetMatch(ctx context.Context, id string) (*models.Match, error) {
+	ctx, done := s.trace(ctx, "GetMatch")
+	r0, err := s.next.GetMatch(ctx, id)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetMatchByProviderID(ctx context.Context, providerID string) (*models.Match, error) {
+	ctx, done := s.trace(ctx, "GetMatchByProviderID")
+	r0, err := s.next.GetMatchByProviderID(ctx, providerID)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetMatchChatRoom(ctx context.Context, matchID string) (*models.ChatRoom, error) {
+	ctx, done := s.trace(ctx, "GetMatchChatRoom")
+	r0, err := s.next.GetMatchChatRoom(ctx, matchID)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetMatchEvents(ctx context.Context, matchID string) ([]*models.MatchEvent, error) {
+	ctx, done := s.trace(ctx, "GetMatchEvents")
+	r0, err := s.next.GetMatchEvents(ctx, matchID)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetMatchHighlights(ctx context.Context, matchID string) ([]*models.Highlight, error) {
+	ctx, done := s.trace(ctx, "GetMatchHighlights")
+	r0, err := s.next.GetMatchHighlights(ctx, matchID)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetMatchLeaderboard(ctx context.Context, matchID string, limit int) ([]*models.LeaderboardEntry, error) {
+	ctx, done := s.trace(ctx, "GetMatchLeaderboard")
+	r0, err := s.next.GetMatchLeaderboard(ctx, matchID, limit)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetMatchPolls(ctx context.Context, matchID string) ([]*models.Poll, error) {
+	ctx, done := s.trace(ctx, "GetMatchPolls")
+	r0, err := s.next.GetMatchPolls(ctx, matchID)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetMatchPredictions(ctx context.Context, matchID string) ([]*models.Prediction, error) {
+	ctx, done := s.trace(ctx, "GetMatchPredictions")
+	r0, err := s.next.GetMatchPredictions(ctx, matchID)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetMatchStatistics(ctx context.Context, matchID string) (*store.MatchStatistics, error) {
+	ctx, done := s.trace(ctx, "GetMatchStatistics")
+	r0, err := s.next.GetMatchStatistics(ctx, matchID)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetMatchStatisticsRollup(ctx context.Context, matchID string) (*store.MatchStatistics, error) {
+	ctx, done := s.trace(ctx, "GetMatchStatisticsRollup")
+	r0, err := s.next.GetMatchStatisticsRollup(ctx, matchID)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetMatchVote(ctx context.Context, matchID string) (*models.MatchVote, error) {
+	ctx, done := s.trace(ctx, "GetMatchVote")
+	r0, err := s.next.GetMatchVote(ctx, matchID)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetMatchVoteResult(ctx context.Context, matchID string) (*models.MatchVoteResult, error) {
+	ctx, done := s.trace(ctx, "GetMatchVoteResult")
+	r0, err := s.next.GetMatchVoteResult(ctx, matchID)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetMatchesByStatus(ctx context.Context, status string) ([]*models.Match, error) {
+	ctx, done := s.trace(ctx, "GetMatchesByStatus")
+	r0, err := s.next.GetMatchesByStatus(ctx, status)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetMessage(ctx context.Context, id string) (*models.Message, error) {
+	ctx, done := s.trace(ctx, "GetMessage")
+	r0, err := s.next.GetMessage(ctx, id)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetMessageEvidence(ctx context.Context, messageID string) (*models.ModerationEvidence, error) {
+	ctx, done := s.trace(ctx, "GetMessageEvidence")
+	r0, err := s.next.GetMessageEvidence(ctx, messageID)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetMessageFlag(ctx context.Context, id string) (*models.MessageFlag, error) {
+	ctx, done := s.trace(ctx, "GetMessageFlag")
+	r0, err := s.next.GetMessageFlag(ctx, id)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetMessageReactions(ctx context.Context, messageIDs []string) (map[string][]*models.ReactionCount, error) {
+	ctx, done := s.trace(ctx, "GetMessageReactions")
+	r0, err := s.next.GetMessageReactions(ctx, messageIDs)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetMessagesAfterCursor(ctx context.Context, roomID string, filter store.MessageFilter, cursor store.MessageCursor, limit int) ([]*models.Message, error) {
+	ctx, done := s.trace(ctx, "GetMessagesAfterCursor")
+	r0, err := s.next.GetMessagesAfterCursor(ctx, roomID, filter, cursor, limit)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetMessagesAfterSeq(ctx context.Context, roomID string, seq int64, limit int) ([]*models.Message, error) {
+	ctx, done := s.trace(ctx, "GetMessagesAfterSeq")
+	r0, err := s.next.GetMessagesAfterSeq(ctx, roomID, seq, limit)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetMessagesBeforeCursor(ctx context.Context, roomID string, filter store.MessageFilter, cursor *store.MessageCursor, limit int) ([]*models.Message, error) {
+	ctx, done := s.trace(ctx, "GetMessagesBeforeCursor")
+	r0, err := s.next.GetMessagesBeforeCursor(ctx, roomID, filter, cursor, limit)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetMessagesBetween(ctx context.Context, roomID string, from time.Time, to time.Time, limit int) ([]*models.Message, error) {
+	ctx, done := s.trace(ctx, "GetMessagesBetween")
+	r0, err := s.next.GetMessagesBetween(ctx, roomID, from, to, limit)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetModerationEvidence(ctx context.Context, id string) (*models.ModerationEvidence, error) {
+	ctx, done := s.trace(ctx, "GetModerationEvidence")
+	r0, err := s.next.GetModerationEvidence(ctx, id)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetOrCreateConversation(ctx context.Context, userA string, userB string) (*models.Conversation, error) {
+	ctx, done := s.trace(ctx, "GetOrCreateConversation")
+	r0, err := s.next.GetOrCreateConversation(ctx, userA, userB)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetPoll(ctx context.Context, id string) (*models.Poll, error) {
+	ctx, done := s.trace(ctx, "GetPoll")
+	r0, err := s.next.GetPoll(ctx, id)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetPollTally(ctx context.Context, pollID string) (*models.PollTally, error) {
+	ctx, done := s.trace(ctx, "GetPollTally")
+	r0, err := s.next.GetPollTally(ctx, pollID)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetPrediction(ctx context.Context, matchID string, userID string) (*models.Prediction, error) {
+	ctx, done := s.trace(ctx, "GetPrediction")
+	r0, err := s.next.GetPrediction(ctx, matchID, userID)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetPublishedEmotePacks(ctx context.Context, teamIDs []string) ([]*models.EmotePack, error) {
+	ctx, done := s.trace(ctx, "GetPublishedEmotePacks")
+	r0, err := s.next.GetPublishedEmotePacks(ctx, teamIDs)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetQuietHours(ctx context.Context, userID string) (*models.QuietHours, error) {
+	ctx, done := s.trace(ctx, "GetQuietHours")
+	r0, err := s.next.GetQuietHours(ctx, userID)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetRecentMatchEvents(ctx context.Context, matchID string, limit int) ([]*models.MatchEvent, error) {
+	ctx, done := s.trace(ctx, "GetRecentMatchEvents")
+	r0, err := s.next.GetRecentMatchEvents(ctx, matchID, limit)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetRecentMessages(ctx context.Context, roomID string, limit int) ([]*models.Message, error) {
+	ctx, done := s.trace(ctx, "GetRecentMessages")
+	r0, err := s.next.GetRecentMessages(ctx, roomID, limit)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetRecoveryEmail(ctx context.Context, userID string) (string, error) {
+	ctx, done := s.trace(ctx, "GetRecoveryEmail")
+	r0, err := s.next.GetRecoveryEmail(ctx, userID)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetRecoveryToken(ctx context.Context, tokenHash string) (*models.RecoveryToken, error) {
+	ctx, done := s.trace(ctx, "GetRecoveryToken")
+	r0, err := s.next.GetRecoveryToken(ctx, tokenHash)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetRoomAnalytics(ctx context.Context, roomID string, from time.Time, to time.Time) (*models.RoomAnalytics, error) {
+	ctx, done := s.trace(ctx, "GetRoomAnalytics")
+	r0, err := s.next.GetRoomAnalytics(ctx, roomID, from, to)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetRoomLanguages(ctx context.Context, roomID string) ([]*models.LanguageShare, error) {
+	ctx, done := s.trace(ctx, "GetRoomLanguages")
+	r0, err := s.next.GetRoomLanguages(ctx, roomID)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetRoomMaxSeq(ctx context.Context, roomID string) (int64, error) {
+	ctx, done := s.trace(ctx, "GetRoomMaxSeq")
+	r0, err := s.next.GetRoomMaxSeq(ctx, roomID)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetRoomMembersByUsername(ctx context.Context, roomID string, usernames []string) ([]*models.User, error) {
+	ctx, done := s.trace(ctx, "GetRoomMembersByUsername")
+	r0, err := s.next.GetRoomMembersByUsername(ctx, roomID, usernames)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetRoomRole(ctx context.Context, roomID string, userID string) (string, error) {
+	ctx, done := s.trace(ctx, "GetRoomRole")
+	r0, err := s.next.GetRoomRole(ctx, roomID, userID)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetRoomSanctions(ctx context.Context, roomID string, userID string) ([]*models.RoomSanction, error) {
+	ctx, done := s.trace(ctx, "GetRoomSanctions")
+	r0, err := s.next.GetRoomSanctions(ctx, roomID, userID)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetRoomStatistics(ctx context.Context, roomID string) (*store.RoomStatistics, error) {
+	ctx, done := s.trace(ctx, "GetRoomStatistics")
+	r0, err := s.next.GetRoomStatistics(ctx, roomID)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetRoomStatisticsRollup(ctx context.Context, roomID string) (*store.RoomStatistics, error) {
+	ctx, done := s.trace(ctx, "GetRoomStatisticsRollup")
+	r0, err := s.next.GetRoomStatisticsRollup(ctx, roomID)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetRoomTombstone(ctx context.Context, roomID string) (*models.RoomTombstone, error) {
+	ctx, done := s.trace(ctx, "GetRoomTombstone")
+	r0, err := s.next.GetRoomTombstone(ctx, roomID)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetRoomTopics(ctx context.Context, roomID string) ([]*models.RoomTopic, error) {
+	ctx, done := s.trace(ctx, "GetRoomTopics")
+	r0, err := s.next.GetRoomTopics(ctx, roomID)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetRoomUsers(ctx context.Context, roomID string) ([]*models.User, error) {
+	ctx, done := s.trace(ctx, "GetRoomUsers")
+	r0, err := s.next.GetRoomUsers(ctx, roomID)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetSeason(ctx context.Context, id string) (*models.Season, error) {
+	ctx, done := s.trace(ctx, "GetSeason")
+	r0, err := s.next.GetSeason(ctx, id)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetSeasonAt(ctx context.Context, competitionID string, at time.Time) (*models.Season, error) {
+	ctx, done := s.trace(ctx, "GetSeasonAt")
+	r0, err := s.next.GetSeasonAt(ctx, competitionID, at)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetSeasonLeaderboard(ctx context.Context, season *models.Season, limit int) ([]*models.LeaderboardEntry, error) {
+	ctx, done := s.trace(ctx, "GetSeasonLeaderboard")
+	r0, err := s.next.GetSeasonLeaderboard(ctx, season, limit)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetSeasonStandings(ctx context.Context, season *models.Season, limit int) ([]*models.SeasonStanding, error) {
+	ctx, done := s.trace(ctx, "GetSeasonStandings")
+	r0, err := s.next.GetSeasonStandings(ctx, season, limit)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetShadowBanEntries(ctx context.Context, userID string) ([]*models.ShadowBanEntry, error) {
+	ctx, done := s.trace(ctx, "GetShadowBanEntries")
+	r0, err := s.next.GetShadowBanEntries(ctx, userID)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetSport(ctx context.Context, id string) (*models.Sport, error) {
+	ctx, done := s.trace(ctx, "GetSport")
+	r0, err := s.next.GetSport(ctx, id)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetTeam(ctx context.Context, id string) (*models.Team, error) {
+	ctx, done := s.trace(ctx, "GetTeam")
+	r0, err := s.next.GetTeam(ctx, id)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetTeamRecentMatches(ctx context.Context, teamID string, limit int) ([]*models.Match, error) {
+	ctx, done := s.trace(ctx, "GetTeamRecentMatches")
+	r0, err := s.next.GetTeamRecentMatches(ctx, teamID, limit)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetTopicMessagesBeforeCursor(ctx context.Context, topicID string, cursor *store.MessageCursor, limit int) ([]*models.Message, error) {
+	ctx, done := s.trace(ctx, "GetTopicMessagesBeforeCursor")
+	r0, err := s.next.GetTopicMessagesBeforeCursor(ctx, topicID, cursor, limit)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetUnreadCounts(ctx context.Context, userID string) ([]*models.UnreadCount, error) {
+	ctx, done := s.trace(ctx, "GetUnreadCounts")
+	r0, err := s.next.GetUnreadCounts(ctx, userID)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetUnusedRecoveryCodes(ctx context.Context, userID string) ([]*models.RecoveryCode, error) {
+	ctx, done := s.trace(ctx, "GetUnusedRecoveryCodes")
+	r0, err := s.next.GetUnusedRecoveryCodes(ctx, userID)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetUpcomingMatches(ctx context.Context, limit int) ([]*models.Match, error) {
+	ctx, done := s.trace(ctx, "GetUpcomingMatches")
+	r0, err := s.next.GetUpcomingMatches(ctx, limit)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetUser(ctx context.Context, id string) (*models.User, error) {
+	ctx, done := s.trace(ctx, "GetUser")
+	r0, err := s.next.GetUser(ctx, id)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetUserAchievements(ctx context.Context, userID string) ([]*models.UserAchievement, error) {
+	ctx, done := s.trace(ctx, "GetUserAchievements")
+	r0, err := s.next.GetUserAchievements(ctx, userID)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetUserAppeals(ctx context.Context, userID string) ([]*models.Appeal, error) {
+	ctx, done := s.trace(ctx, "GetUserAppeals")
+	r0, err := s.next.GetUserAppeals(ctx, userID)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetUserByExternalID(ctx context.Context, externalID string) (*models.User, error) {
+	ctx, done := s.trace(ctx, "GetUserByExternalID")
+	r0, err := s.next.GetUserByExternalID(ctx, externalID)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetUserByUsername(ctx context.Context, username string) (*models.User, error) {
+	ctx, done := s.trace(ctx, "GetUserByUsername")
+	r0, err := s.next.GetUserByUsername(ctx, username)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetUserConversations(ctx context.Context, userID string, limit int) ([]*models.Conversation, error) {
+	ctx, done := s.trace(ctx, "GetUserConversations")
+	r0, err := s.next.GetUserConversations(ctx, userID, limit)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetUserDirectoryGroups(ctx context.Context, userID string) ([]*models.DirectoryGroup, error) {
+	ctx, done := s.trace(ctx, "GetUserDirectoryGroups")
+	r0, err := s.next.GetUserDirectoryGroups(ctx, userID)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetUserDrafts(ctx context.Context, userID string) ([]*models.Draft, error) {
+	ctx, done := s.trace(ctx, "GetUserDrafts")
+	r0, err := s.next.GetUserDrafts(ctx, userID)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetUserKeywordAlerts(ctx context.Context, userID string) ([]*models.KeywordAlert, error) {
+	ctx, done := s.trace(ctx, "GetUserKeywordAlerts")
+	r0, err := s.next.GetUserKeywordAlerts(ctx, userID)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetUserMessages(ctx context.Context, userID string, cursor *store.MessageCursor, limit int) ([]*models.Message, error) {
+	ctx, done := s.trace(ctx, "GetUserMessages")
+	r0, err := s.next.GetUserMessages(ctx, userID, cursor, limit)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetUserPredictionStats(ctx context.Context, userID string, since time.Time) (int, int, error) {
+	ctx, done := s.trace(ctx, "GetUserPredictionStats")
+	r0, r1, err := s.next.GetUserPredictionStats(ctx, userID, since)
+	done(err)
+	return r0, r1, err
+}
+
+func (s *Store) GetUserProgress(ctx context.Context, userID string) (*models.UserProgress, error) {
+	ctx, done := s.trace(ctx, "GetUserProgress")
+	r0, err := s.next.GetUserProgress(ctx, userID)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetUserRoomSummaries(ctx context.Context, userID string) ([]*models.RoomSummary, error) {
+	ctx, done := s.trace(ctx, "GetUserRoomSummaries")
+	r0, err := s.next.GetUserRoomSummaries(ctx, userID)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetUserRooms(ctx context.Context, userID string) ([]*models.ChatRoom, error) {
+	ctx, done := s.trace(ctx, "GetUserRooms")
+	r0, err := s.next.GetUserRooms(ctx, userID)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetUserSecurityEvents(ctx context.Context, userID string, limit int) ([]*models.SecurityEvent, error) {
+	ctx, done := s.trace(ctx, "GetUserSecurityEvents")
+	r0, err := s.next.GetUserSecurityEvents(ctx, userID, limit)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetUserStatistics(ctx context.Context, userID string) (*store.UserStatistics, error) {
+	ctx, done := s.trace(ctx, "GetUserStatistics")
+	r0, err := s.next.GetUserStatistics(ctx, userID)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetVoiceParticipant(ctx context.Context, roomID string, userID string) (*models.VoiceParticipant, error) {
+	ctx, done := s.trace(ctx, "GetVoiceParticipant")
+	r0, err := s.next.GetVoiceParticipant(ctx, roomID, userID)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) GetVoiceParticipants(ctx context.Context, roomID string) ([]*models.VoiceParticipant, error) {
+	ctx, done := s.trace(ctx, "GetVoiceParticipants")
+	r0, err := s.next.GetVoiceParticipants(ctx, roomID)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) IsBlocked(ctx context.Context, userA string, userB string) (bool, error) {
+	ctx, done := s.trace(ctx, "IsBlocked")
+	r0, err := s.next.IsBlocked(ctx, userA, userB)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) IsMatchVoter(ctx context.Context, matchID string, userID string) (bool, error) {
+	ctx, done := s.trace(ctx, "IsMatchVoter")
+	r0, err := s.next.IsMatchVoter(ctx, matchID, userID)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) JoinChatRoom(ctx context.Context, userID string, roomID string) error {
+	ctx, done := s.trace(ctx, "JoinChatRoom")
+	err := s.next.JoinChatRoom(ctx, userID, roomID)
+	done(err)
+	return err
+}
+
+func (s *Store) JoinVoiceSession(ctx context.Context, participant *models.VoiceParticipant) error {
+	ctx, done := s.trace(ctx, "JoinVoiceSession")
+	err := s.next.JoinVoiceSession(ctx, participant)
+	done(err)
+	return err
+}
+
+func (s *Store) LeaveChatRoom(ctx context.Context, userID string, roomID string) error {
+	ctx, done := s.trace(ctx, "LeaveChatRoom")
+	err := s.next.LeaveChatRoom(ctx, userID, roomID)
+	done(err)
+	return err
+}
+
+func (s *Store) LeaveVoiceSession(ctx context.Context, roomID string, userID string) error {
+	ctx, done := s.trace(ctx, "LeaveVoiceSession")
+	err := s.next.LeaveVoiceSession(ctx, roomID, userID)
+	done(err)
+	return err
+}
+
+func (s *Store) ListAppeals(ctx context.Context, status string, limit int) ([]*models.Appeal, error) {
+	ctx, done := s.trace(ctx, "ListAppeals")
+	r0, err := s.next.ListAppeals(ctx, status, limit)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) ListChatRooms(ctx context.Context) ([]*models.ChatRoom, error) {
+	ctx, done := s.trace(ctx, "ListChatRooms")
+	r0, err := s.next.ListChatRooms(ctx)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) ListCommentaryTemplates(ctx context.Context) ([]*models.CommentaryTemplate, error) {
+	ctx, done := s.trace(ctx, "ListCommentaryTemplates")
+	r0, err := s.next.ListCommentaryTemplates(ctx)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) ListDeadLetters(ctx context.Context, pendingOnly bool, limit int) ([]*models.DeadLetter, error) {
+	ctx, done := s.trace(ctx, "ListDeadLetters")
+	r0, err := s.next.ListDeadLetters(ctx, pendingOnly, limit)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) ListDirectoryGroups(ctx context.Context) ([]*models.DirectoryGroup, error) {
+	ctx, done := s.trace(ctx, "ListDirectoryGroups")
+	r0, err := s.next.ListDirectoryGroups(ctx)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) ListEvasionSuspects(ctx context.Context, status string, limit int) ([]*models.EvasionSuspect, error) {
+	ctx, done := s.trace(ctx, "ListEvasionSuspects")
+	r0, err := s.next.ListEvasionSuspects(ctx, status, limit)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) ListExpiredDataExports(ctx context.Context, before time.Time, limit int) ([]*models.DataExport, error) {
+	ctx, done := s.trace(ctx, "ListExpiredDataExports")
+	r0, err := s.next.ListExpiredDataExports(ctx, before, limit)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) ListIncidents(ctx context.Context, roomID string, limit int) ([]*models.Incident, error) {
+	ctx, done := s.trace(ctx, "ListIncidents")
+	r0, err := s.next.ListIncidents(ctx, roomID, limit)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) ListLocalizedNames(ctx context.Context) ([]*models.LocalizedName, error) {
+	ctx, done := s.trace(ctx, "ListLocalizedNames")
+	r0, err := s.next.ListLocalizedNames(ctx)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) ListMessageArchives(ctx context.Context, roomID string, before time.Time, limit int) ([]*models.MessageArchive, error) {
+	ctx, done := s.trace(ctx, "ListMessageArchives")
+	r0, err := s.next.ListMessageArchives(ctx, roomID, before, limit)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) ListMessageFlags(ctx context.Context, status string, limit int) ([]*models.MessageFlag, error) {
+	ctx, done := s.trace(ctx, "ListMessageFlags")
+	r0, err := s.next.ListMessageFlags(ctx, status, limit)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) ListModerationEvidence(ctx context.Context, userID string, limit int) ([]*models.ModerationEvidence, error) {
+	ctx, done := s.trace(ctx, "ListModerationEvidence")
+	r0, err := s.next.ListModerationEvidence(ctx, userID, limit)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) ListModerationFilters(ctx context.Context) ([]*models.ModerationFilter, error) {
+	ctx, done := s.trace(ctx, "ListModerationFilters")
+	r0, err := s.next.ListModerationFilters(ctx)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) ListProfanityPolicies(ctx context.Context) ([]*models.ProfanityPolicy, error) {
+	ctx, done := s.trace(ctx, "ListProfanityPolicies")
+	r0, err := s.next.ListProfanityPolicies(ctx)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) ListProfanityWords(ctx context.Context) ([]*models.ProfanityWord, error) {
+	ctx, done := s.trace(ctx, "ListProfanityWords")
+	r0, err := s.next.ListProfanityWords(ctx)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) ListPurgeableRoomTombstones(ctx context.Context, before time.Time, limit int) ([]*models.RoomTombstone, error) {
+	ctx, done := s.trace(ctx, "ListPurgeableRoomTombstones")
+	r0, err := s.next.ListPurgeableRoomTombstones(ctx, before, limit)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) ListReconciliationReports(ctx context.Context, limit int) ([]*models.ReconciliationReport, error) {
+	ctx, done := s.trace(ctx, "ListReconciliationReports")
+	r0, err := s.next.ListReconciliationReports(ctx, limit)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) ListRoomMessageFlags(ctx context.Context, roomID string, from time.Time, to time.Time) ([]*models.MessageFlag, error) {
+	ctx, done := s.trace(ctx, "ListRoomMessageFlags")
+	r0, err := s.next.ListRoomMessageFlags(ctx, roomID, from, to)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) ListRoomRoles(ctx context.Context, roomID string) ([]*models.RoomRole, error) {
+	ctx, done := s.trace(ctx, "ListRoomRoles")
+	r0, err := s.next.ListRoomRoles(ctx, roomID)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) ListRoomSanctionsBetween(ctx context.Context, roomID string, from time.Time, to time.Time) ([]*models.RoomSanction, error) {
+	ctx, done := s.trace(ctx, "ListRoomSanctionsBetween")
+	r0, err := s.next.ListRoomSanctionsBetween(ctx, roomID, from, to)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) ListSeasons(ctx context.Context, competitionID string) ([]*models.Season, error) {
+	ctx, done := s.trace(ctx, "ListSeasons")
+	r0, err := s.next.ListSeasons(ctx, competitionID)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) ListSports(ctx context.Context) ([]*models.Sport, error) {
+	ctx, done := s.trace(ctx, "ListSports")
+	r0, err := s.next.ListSports(ctx)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) ListTeams(ctx context.Context, sportID string) ([]*models.Team, error) {
+	ctx, done := s.trace(ctx, "ListTeams")
+	r0, err := s.next.ListTeams(ctx, sportID)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) ListUserDataExports(ctx context.Context, userID string) ([]*models.DataExport, error) {
+	ctx, done := s.trace(ctx, "ListUserDataExports")
+	r0, err := s.next.ListUserDataExports(ctx, userID)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) ListUserMessageArchives(ctx context.Context, userID string, after string, limit int) ([]*models.MessageArchive, error) {
+	ctx, done := s.trace(ctx, "ListUserMessageArchives")
+	r0, err := s.next.ListUserMessageArchives(ctx, userID, after, limit)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) ListUsers(ctx context.Context, offset int, limit int) ([]*models.User, int, error) {
+	ctx, done := s.trace(ctx, "ListUsers")
+	r0, r1, err := s.next.ListUsers(ctx, offset, limit)
+	done(err)
+	return r0, r1, err
+}
+
+func (s *Store) MarkDeadLetterReplayed(ctx context.Context, id string, at time.Time) error {
+	ctx, done := s.trace(ctx, "MarkDeadLetterReplayed")
+	err := s.next.MarkDeadLetterReplayed(ctx, id, at)
+	done(err)
+	return err
+}
+
+func (s *Store) MarkFirstMessage(ctx context.Context, userID string, at time.Time) (bool, error) {
+	ctx, done := s.trace(ctx, "MarkFirstMessage")
+	r0, err := s.next.MarkFirstMessage(ctx, userID, at)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) MarkRoomRead(ctx context.Context, userID string, roomID string, at time.Time) error {
+	ctx, done := s.trace(ctx, "MarkRoomRead")
+	err := s.next.MarkRoomRead(ctx, userID, roomID, at)
+	done(err)
+	return err
+}
+
+func (s *Store) MergeChatRooms(ctx context.Context, sourceID string, targetID string) error {
+	ctx, done := s.trace(ctx, "MergeChatRooms")
+	err := s.next.MergeChatRooms(ctx, sourceID, targetID)
+	done(err)
+	return err
+}
+
+func (s *Store) MoveRoomMembers(ctx context.Context, fromRoomID string, toRoomID string, userIDs []string) error {
+	ctx, done := s.trace(ctx, "MoveRoomMembers")
+	err := s.next.MoveRoomMembers(ctx, fromRoomID, toRoomID, userIDs)
+	done(err)
+	return err
+}
+
+func (s *Store) OpenMatchVote(ctx context.Context, vote *models.MatchVote, voterIDs []string) (bool, error) {
+	ctx, done := s.trace(ctx, "OpenMatchVote")
+	r0, err := s.next.OpenMatchVote(ctx, vote, voterIDs)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) PublishEmotePack(ctx context.Context, id string, at time.Time) error {
+	ctx, done := s.trace(ctx, "PublishEmotePack")
+	err := s.next.PublishEmotePack(ctx, id, at)
+	done(err)
+	return err
+}
+
+func (s *Store) PurgeChatRoom(ctx context.Context, roomID string, at time.Time) error {
+	ctx, done := s.trace(ctx, "PurgeChatRoom")
+	err := s.next.PurgeChatRoom(ctx, roomID, at)
+	done(err)
+	return err
+}
+
+func (s *Store) PurgeExpiredModerationEvidence(ctx context.Context, now time.Time) (int64, error) {
+	ctx, done := s.trace(ctx, "PurgeExpiredModerationEvidence")
+	r0, err := s.next.PurgeExpiredModerationEvidence(ctx, now)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) PurgeJournalEntries(ctx context.Context, before time.Time) (int64, error) {
+	ctx, done := s.trace(ctx, "PurgeJournalEntries")
+	r0, err := s.next.PurgeJournalEntries(ctx, before)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) RecordDeviceSighting(ctx context.Context, sighting *models.DeviceSighting) error {
+	ctx, done := s.trace(ctx, "RecordDeviceSighting")
+	err := s.next.RecordDeviceSighting(ctx, sighting)
+	done(err)
+	return err
+}
+
+func (s *Store) RecordEvidenceAccess(ctx context.Context, access *models.EvidenceAccess) error {
+	ctx, done := s.trace(ctx, "RecordEvidenceAccess")
+	err := s.next.RecordEvidenceAccess(ctx, access)
+	done(err)
+	return err
+}
+
+func (s *Store) RecordRoomAttendance(ctx context.Context, roomID string, at time.Time, viewers int) error {
+	ctx, done := s.trace(ctx, "RecordRoomAttendance")
+	err := s.next.RecordRoomAttendance(ctx, roomID, at, viewers)
+	done(err)
+	return err
+}
+
+func (s *Store) RecordRoomLanguage(ctx context.Context, roomID string, language string) error {
+	ctx, done := s.trace(ctx, "RecordRoomLanguage")
+	err := s.next.RecordRoomLanguage(ctx, roomID, language)
+	done(err)
+	return err
+}
+
+func (s *Store) RecordSecurityEvent(ctx context.Context, event *models.SecurityEvent) error {
+	ctx, done := s.trace(ctx, "RecordSecurityEvent")
+	err := s.next.RecordSecurityEvent(ctx, event)
+	done(err)
+	return err
+}
+
+func (s *Store) RecordUserActivity(ctx context.Context, userID string, at time.Time, xp int) (*models.UserProgress, error) {
+	ctx, done := s.trace(ctx, "RecordUserActivity")
+	r0, err := s.next.RecordUserActivity(ctx, userID, at, xp)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) RefreshStatisticsRollups(ctx context.Context, since time.Time, full bool) (int, int, error) {
+	ctx, done := s.trace(ctx, "RefreshStatisticsRollups")
+	r0, r1, err := s.next.RefreshStatisticsRollups(ctx, since, full)
+	done(err)
+	return r0, r1, err
+}
+
+func (s *Store) RemoveReaction(ctx context.Context, messageID string, userID string, emoji string) (bool, error) {
+	ctx, done := s.trace(ctx, "RemoveReaction")
+	r0, err := s.next.RemoveReaction(ctx, messageID, userID, emoji)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) RenameUser(ctx context.Context, userID string, username string, at time.Time) error {
+	ctx, done := s.trace(ctx, "RenameUser")
+	err := s.next.RenameUser(ctx, userID, username, at)
+	done(err)
+	return err
+}
+
+func (s *Store) ReplaceRecoveryCodes(ctx context.Context, userID string, codes []*models.RecoveryCode) error {
+	ctx, done := s.trace(ctx, "ReplaceRecoveryCodes")
+	err := s.next.ReplaceRecoveryCodes(ctx, userID, codes)
+	done(err)
+	return err
+}
+
+func (s *Store) RestoreChatRoom(ctx context.Context, roomID string) (bool, error) {
+	ctx, done := s.trace(ctx, "RestoreChatRoom")
+	r0, err := s.next.RestoreChatRoom(ctx, roomID)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) ReviewAppeal(ctx context.Context, id string, status string, reviewerID string, note string) (bool, error) {
+	ctx, done := s.trace(ctx, "ReviewAppeal")
+	r0, err := s.next.ReviewAppeal(ctx, id, status, reviewerID, note)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) ReviewEvasionSuspect(ctx context.Context, id string, status string, reviewerID string) error {
+	ctx, done := s.trace(ctx, "ReviewEvasionSuspect")
+	err := s.next.ReviewEvasionSuspect(ctx, id, status, reviewerID)
+	done(err)
+	return err
+}
+
+func (s *Store) ReviewMessageFlag(ctx context.Context, id string, status string, reviewerID string) error {
+	ctx, done := s.trace(ctx, "ReviewMessageFlag")
+	err := s.next.ReviewMessageFlag(ctx, id, status, reviewerID)
+	done(err)
+	return err
+}
+
+func (s *Store) ScorePrediction(ctx context.Context, prediction *models.Prediction, rescore bool) (bool, error) {
+	ctx, done := s.trace(ctx, "ScorePrediction")
+	r0, err := s.next.ScorePrediction(ctx, prediction, rescore)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) SearchEmotes(ctx context.Context, teamIDs []string, prefix string, limit int) ([]*models.Emote, error) {
+	ctx, done := s.trace(ctx, "SearchEmotes")
+	r0, err := s.next.SearchEmotes(ctx, teamIDs, prefix, limit)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) SearchMatchEvents(ctx context.Context, query string, limit int) ([]*models.MatchEvent, error) {
+	ctx, done := s.trace(ctx, "SearchMatchEvents")
+	r0, err := s.next.SearchMatchEvents(ctx, query, limit)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) SearchMessages(ctx context.Context, query string, limit int) ([]*models.Message, error) {
+	ctx, done := s.trace(ctx, "SearchMessages")
+	r0, err := s.next.SearchMessages(ctx, query, limit)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) SetAttachmentStatus(ctx context.Context, id string, status string, reason string) (bool, error) {
+	ctx, done := s.trace(ctx, "SetAttachmentStatus")
+	r0, err := s.next.SetAttachmentStatus(ctx, id, status, reason)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) SetMessagePreviews(ctx context.Context, id string, previews []*models.LinkPreview) error {
+	ctx, done := s.trace(ctx, "SetMessagePreviews")
+	err := s.next.SetMessagePreviews(ctx, id, previews)
+	done(err)
+	return err
+}
+
+func (s *Store) SetPinnedMessage(ctx context.Context, roomID string, messageID string) error {
+	ctx, done := s.trace(ctx, "SetPinnedMessage")
+	err := s.next.SetPinnedMessage(ctx, roomID, messageID)
+	done(err)
+	return err
+}
+
+func (s *Store) SetQuietHours(ctx context.Context, quiet *models.QuietHours) error {
+	ctx, done := s.trace(ctx, "SetQuietHours")
+	err := s.next.SetQuietHours(ctx, quiet)
+	done(err)
+	return err
+}
+
+func (s *Store) SetRecoveryEmail(ctx context.Context, userID string, email string, verifiedAt time.Time) error {
+	ctx, done := s.trace(ctx, "SetRecoveryEmail")
+	err := s.next.SetRecoveryEmail(ctx, userID, email, verifiedAt)
+	done(err)
+	return err
+}
+
+func (s *Store) SetRoomRole(ctx context.Context, role *models.RoomRole) error {
+	ctx, done := s.trace(ctx, "SetRoomRole")
+	err := s.next.SetRoomRole(ctx, role)
+	done(err)
+	return err
+}
+
+func (s *Store) SetShadowBan(ctx context.Context, entry *models.ShadowBanEntry) (bool, error) {
+	ctx, done := s.trace(ctx, "SetShadowBan")
+	r0, err := s.next.SetShadowBan(ctx, entry)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) TombstoneChatRoom(ctx context.Context, tombstone *models.RoomTombstone) error {
+	ctx, done := s.trace(ctx, "TombstoneChatRoom")
+	err := s.next.TombstoneChatRoom(ctx, tombstone)
+	done(err)
+	return err
+}
+
+func (s *Store) TransitionChatRoom(ctx context.Context, id string, from string, to string, at time.Time) (bool, error) {
+	ctx, done := s.trace(ctx, "TransitionChatRoom")
+	r0, err := s.next.TransitionChatRoom(ctx, id, from, to, at)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) UnblockUser(ctx context.Context, userID string, blockedID string) error {
+	ctx, done := s.trace(ctx, "UnblockUser")
+	err := s.next.UnblockUser(ctx, userID, blockedID)
+	done(err)
+	return err
+}
+
+func (s *Store) UpdateChatRoom(ctx context.Context, room *models.ChatRoom) error {
+	ctx, done := s.trace(ctx, "UpdateChatRoom")
+	err := s.next.UpdateChatRoom(ctx, room)
+	done(err)
+	return err
+}
+
+func (s *Store) UpdateDirectoryGroup(ctx context.Context, group *models.DirectoryGroup) error {
+	ctx, done := s.trace(ctx, "UpdateDirectoryGroup")
+	err := s.next.UpdateDirectoryGroup(ctx, group)
+	done(err)
+	return err
+}
+
+func (s *Store) UpdateMatch(ctx context.Context, match *models.Match) error {
+	ctx, done := s.trace(ctx, "UpdateMatch")
+	err := s.next.UpdateMatch(ctx, match)
+	done(err)
+	return err
+}
+
+func (s *Store) UpdateMessageArchive(ctx context.Context, archive *models.MessageArchive) error {
+	ctx, done := s.trace(ctx, "UpdateMessageArchive")
+	err := s.next.UpdateMessageArchive(ctx, archive)
+	done(err)
+	return err
+}
+
+func (s *Store) UpdateSport(ctx context.Context, sport *models.Sport) error {
+	ctx, done := s.trace(ctx, "UpdateSport")
+	err := s.next.UpdateSport(ctx, sport)
+	done(err)
+	return err
+}
+
+func (s *Store) UpdateTeam(ctx context.Context, team *models.Team) error {
+	ctx, done := s.trace(ctx, "UpdateTeam")
+	err := s.next.UpdateTeam(ctx, team)
+	done(err)
+	return err
+}
+
+func (s *Store) UpdateUser(ctx context.Context, user *models.User) error {
+	ctx, done := s.trace(ctx, "UpdateUser")
+	err := s.next.UpdateUser(ctx, user)
+	done(err)
+	return err
+}
+
+func (s *Store) UpdateVoiceParticipant(ctx context.Context, participant *models.VoiceParticipant) error {
+	ctx, done := s.trace(ctx, "UpdateVoiceParticipant")
+	err := s.next.UpdateVoiceParticipant(ctx, participant)
+	done(err)
+	return err
+}
+
+func (s *Store) UpsertCommentaryTemplate(ctx context.Context, template *models.CommentaryTemplate) error {
+	ctx, done := s.trace(ctx, "UpsertCommentaryTemplate")
+	err := s.next.UpsertCommentaryTemplate(ctx, template)
+	done(err)
+	return err
+}
+
+func (s *Store) UpsertDraft(ctx context.Context, draft *models.Draft) error {
+	ctx, done := s.trace(ctx, "UpsertDraft")
+	err := s.next.UpsertDraft(ctx, draft)
+	done(err)
+	return err
+}
+
+func (s *Store) UpsertLocalizedNames(ctx context.Context, names []*models.LocalizedName) error {
+	ctx, done := s.trace(ctx, "UpsertLocalizedNames")
+	err := s.next.UpsertLocalizedNames(ctx, names)
+	done(err)
+	return err
+}
+
+func (s *Store) UpsertModerationFilter(ctx context.Context, filter *models.ModerationFilter) error {
+	ctx, done := s.trace(ctx, "UpsertModerationFilter")
+	err := s.next.UpsertModerationFilter(ctx, filter)
+	done(err)
+	return err
+}
+
+func (s *Store) UpsertPrediction(ctx context.Context, prediction *models.Prediction) error {
+	ctx, done := s.trace(ctx, "UpsertPrediction")
+	err := s.next.UpsertPrediction(ctx, prediction)
+	done(err)
+	return err
+}
+
+func (s *Store) UpsertProfanityPolicy(ctx context.Context, policy *models.ProfanityPolicy) error {
+	ctx, done := s.trace(ctx, "UpsertProfanityPolicy")
+	err := s.next.UpsertProfanityPolicy(ctx, policy)
+	done(err)
+	return err
+}
+
+func (s *Store) UseRecoveryCode(ctx context.Context, id string, at time.Time) (bool, error) {
+	ctx, done := s.trace(ctx, "UseRecoveryCode")
+	r0, err := s.next.UseRecoveryCode(ctx, id, at)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) UseRecoveryToken(ctx context.Context, id string, at time.Time) (bool, error) {
+	ctx, done := s.trace(ctx, "UseRecoveryToken")
+	r0, err := s.next.UseRecoveryToken(ctx, id, at)
+	done(err)
+	return r0, err
+}
+
+func (s *Store) UsernameReleasedSince(ctx context.Context, username string, since time.Time) (bool, error) {
+	ctx, done := s.trace(ctx, "UsernameReleasedSince")
+	r0, err := s.next.UsernameReleasedSince(ctx, username, since)
+	done(err)
+	return r0, err
+}
@@ -0,0 +1,146 @@
+package memory
+
+import (
+    "context"
+    "time"
+
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+func (s *Store) CreateKeywordAlert(ctx context.Context, alert *models.KeywordAlert) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    alert.ID = newID()
+    alert.CreatedAt = now()
+    s.alerts[alert.ID] = clone(alert)
+    return nil
+}
+
+func (s *Store) DeleteKeywordAlert(ctx context.Context, userID, id string) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    if alert, ok := s.alerts[id]; ok && alert.UserID == userID {
+        delete(s.alerts, id)
+    }
+    return nil
+}
+
+func (s *Store) GetUserKeywordAlerts(ctx context.Context, userID string) ([]*models.KeywordAlert, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    var alerts []*models.KeywordAlert
+    for _, alert := range s.alerts {
+        if alert.UserID == userID {
+            alerts = append(alerts, clone(alert))
+        }
+    }
+    sortBy(alerts, func(a, b *models.KeywordAlert) bool { return a.CreatedAt.Before(b.CreatedAt) })
+    return alerts, nil
+}
+
+func (s *Store) GetQuietHours(ctx context.Context, userID string) (*models.QuietHours, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    quiet, ok := s.quietHours[userID]
+    if !ok {
+        return nil, notFound("quiet hours")
+    }
+    return clone(quiet), nil
+}
+
+func (s *Store) SetQuietHours(ctx context.Context, quiet *models.QuietHours) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    quiet.UpdatedAt = now()
+    s.quietHours[quiet.UserID] = clone(quiet)
+    return nil
+}
+
+func (s *Store) DeleteQuietHours(ctx context.Context, userID string) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    delete(s.quietHours, userID)
+    return nil
+}
+
+// AwardAchievement reports false if the user had already unlocked it.
+func (s *Store) AwardAchievement(ctx context.Context, achievement *models.UserAchievement) (bool, error) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    key := membershipKey{scope: achievement.Code, userID: achievement.UserID}
+    if _, exists := s.achievements[key]; exists {
+        return false, nil
+    }
+    stored := clone(achievement)
+    if stored.UnlockedAt.IsZero() {
+        stored.UnlockedAt = now()
+    }
+    s.achievements[key] = stored
+    return true, nil
+}
+
+func (s *Store) GetUserAchievements(ctx context.Context, userID string) ([]*models.UserAchievement, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    var achievements []*models.UserAchievement
+    for key, achievement := range s.achievements {
+        if key.userID == userID {
+            achievements = append(achievements, clone(achievement))
+        }
+    }
+    sortBy(achievements, func(a, b *models.UserAchievement) bool { return a.UnlockedAt.Before(b.UnlockedAt) })
+    return achievements, nil
+}
+
+// RecordUserActivity adds XP and extends the daily streak. Days are UTC:
+// activity the day after the last one extends the streak, a longer gap
+// restarts it.
+func (s *Store) RecordUserActivity(ctx context.Context, userID string, at time.Time, xp int) (*models.UserProgress, error) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    at = at.UTC()
+    day := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, time.UTC)
+    progress, ok := s.progress[userID]
+    if !ok {
+        progress = &models.UserProgress{UserID: userID, XP: xp, CurrentStreak: 1, LongestStreak: 1, LastActiveOn: day}
+        s.progress[userID] = progress
+        return clone(progress), nil
+    }
+
+    progress.XP += xp
+    switch {
+    case !progress.LastActiveOn.Before(day):
+    case progress.LastActiveOn.Equal(day.AddDate(0, 0, -1)):
+        progress.CurrentStreak++
+    default:
+        progress.CurrentStreak = 1
+    }
+    if progress.CurrentStreak > progress.LongestStreak {
+        progress.LongestStreak = progress.CurrentStreak
+    }
+    if day.After(progress.LastActiveOn) {
+        progress.LastActiveOn = day
+    }
+    return clone(progress), nil
+}
+
+// GetUserProgress returns zero progress for users with no activity yet.
+func (s *Store) GetUserProgress(ctx context.Context, userID string) (*models.UserProgress, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    progress, ok := s.progress[userID]
+    if !ok {
+        return &models.UserProgress{UserID: userID}, nil
+    }
+    return clone(progress), nil
+}
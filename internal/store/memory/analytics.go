@@ -0,0 +1,164 @@
+package memory
+
+import (
+    "context"
+    "time"
+
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+const (
+    analyticsTopMembers  = 10
+    analyticsPeakMoments = 5
+)
+
+func (s *Store) RecordRoomAttendance(ctx context.Context, roomID string, at time.Time, viewers int) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    key := attendanceKey{roomID: roomID, minute: at.UTC().Truncate(time.Minute)}
+    if viewers > s.attendance[key] {
+        s.attendance[key] = viewers
+    }
+    return nil
+}
+
+// GetRoomAnalytics counts reactions by when they were made, not when the
+// message they react to was sent, so a late pile-on shows as its own
+// peak.
+func (s *Store) GetRoomAnalytics(ctx context.Context, roomID string, from, to time.Time) (*models.RoomAnalytics, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    analytics := &models.RoomAnalytics{
+        RoomID:      roomID,
+        From:        from,
+        To:          to,
+        Attendance:  []*models.AttendanceSample{},
+        TopMembers:  []*models.MemberActivity{},
+        PeakMoments: []*models.PeakMoment{},
+        Languages:   []*models.LanguageShare{},
+    }
+    within := func(t time.Time) bool { return !t.Before(from) && t.Before(to) }
+
+    for key, viewers := range s.attendance {
+        if key.roomID == roomID && within(key.minute) {
+            analytics.Attendance = append(analytics.Attendance, &models.AttendanceSample{At: key.minute, Viewers: viewers})
+            if viewers > analytics.PeakViewers {
+                analytics.PeakViewers = viewers
+            }
+        }
+    }
+    sortBy(analytics.Attendance, func(a, b *models.AttendanceSample) bool { return a.At.Before(b.At) })
+
+    members := make(map[string]*models.MemberActivity)
+    moments := make(map[time.Time]*models.PeakMoment)
+    languages := make(map[string]int64)
+    tally := func(userID string, at time.Time, messages, reactions int) {
+        minute := at.UTC().Truncate(time.Minute)
+        moment, ok := moments[minute]
+        if !ok {
+            moment = &models.PeakMoment{At: minute}
+            moments[minute] = moment
+        }
+        moment.Messages += messages
+        moment.Reactions += reactions
+
+        user, ok := s.users[userID]
+        if !ok {
+            return
+        }
+        member, ok := members[userID]
+        if !ok {
+            member = &models.MemberActivity{User: user.Summary()}
+            members[userID] = member
+        }
+        member.Messages += messages
+        member.Reactions += reactions
+    }
+    for _, message := range s.messages {
+        if message.ChatRoomID != roomID || !within(message.CreatedAt) {
+            continue
+        }
+        analytics.MessageCount++
+        tally(message.UserID, message.CreatedAt, 1, 0)
+        if message.Language != "" {
+            languages[message.Language]++
+        }
+    }
+    for key, reaction := range s.reactions {
+        message, ok := s.messages[key.messageID]
+        if !ok || message.ChatRoomID != roomID || !within(reaction.CreatedAt) {
+            continue
+        }
+        analytics.ReactionCount++
+        tally(key.userID, reaction.CreatedAt, 0, 1)
+    }
+
+    for _, member := range members {
+        analytics.TopMembers = append(analytics.TopMembers, member)
+    }
+    sortBy(analytics.TopMembers, func(a, b *models.MemberActivity) bool {
+        if a.Messages+a.Reactions != b.Messages+b.Reactions {
+            return a.Messages+a.Reactions > b.Messages+b.Reactions
+        }
+        return a.User.Username < b.User.Username
+    })
+    analytics.TopMembers = limited(analytics.TopMembers, analyticsTopMembers)
+
+    for _, moment := range moments {
+        analytics.PeakMoments = append(analytics.PeakMoments, moment)
+    }
+    sortBy(analytics.PeakMoments, func(a, b *models.PeakMoment) bool {
+        if a.Messages+a.Reactions != b.Messages+b.Reactions {
+            return a.Messages+a.Reactions > b.Messages+b.Reactions
+        }
+        return a.At.Before(b.At)
+    })
+    analytics.PeakMoments = limited(analytics.PeakMoments, analyticsPeakMoments)
+
+    analytics.Languages = append(analytics.Languages, languageShares(languages)...)
+    return analytics, nil
+}
+
+func (s *Store) RecordRoomLanguage(ctx context.Context, roomID, language string) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    s.languages[languageKey{roomID: roomID, language: language}]++
+    return nil
+}
+
+func (s *Store) GetRoomLanguages(ctx context.Context, roomID string) ([]*models.LanguageShare, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    counts := make(map[string]int64)
+    for key, messages := range s.languages {
+        if key.roomID == roomID {
+            counts[key.language] = messages
+        }
+    }
+    return languageShares(counts), nil
+}
+
+// languageShares orders languages by their message counts, most first,
+// and works out each one's share of the total.
+func languageShares(counts map[string]int64) []*models.LanguageShare {
+    var languages []*models.LanguageShare
+    var total int64
+    for language, messages := range counts {
+        languages = append(languages, &models.LanguageShare{Language: language, Messages: messages})
+        total += messages
+    }
+    for _, share := range languages {
+        share.Share = float64(share.Messages) / float64(total)
+    }
+    sortBy(languages, func(a, b *models.LanguageShare) bool {
+        if a.Messages != b.Messages {
+            return a.Messages > b.Messages
+        }
+        return a.Language < b.Language
+    })
+    return languages
+}
@@ -0,0 +1,91 @@
+package memory
+
+import (
+    "context"
+
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+func (s *Store) CreateAppeal(ctx context.Context, appeal *models.Appeal) (bool, error) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    for _, other := range s.appeals {
+        if other.UserID == appeal.UserID && other.Action == appeal.Action && other.RoomID == appeal.RoomID &&
+            other.MessageID == appeal.MessageID && other.ActionAt.Equal(appeal.ActionAt) {
+            return false, nil
+        }
+    }
+    appeal.ID = newID()
+    if appeal.Status == "" {
+        appeal.Status = models.AppealPending
+    }
+    if appeal.CreatedAt.IsZero() {
+        appeal.CreatedAt = now()
+    }
+    stored := clone(appeal)
+    stored.ReviewedBy, stored.ReviewNote, stored.ReviewedAt = "", "", nil
+    s.appeals[stored.ID] = stored
+    return true, nil
+}
+
+func (s *Store) GetAppeal(ctx context.Context, id string) (*models.Appeal, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    appeal, ok := s.appeals[id]
+    if !ok {
+        return nil, notFound("appeal")
+    }
+    return cloneAppeal(appeal), nil
+}
+
+func (s *Store) GetUserAppeals(ctx context.Context, userID string) ([]*models.Appeal, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    var appeals []*models.Appeal
+    for _, appeal := range s.appeals {
+        if appeal.UserID == userID {
+            appeals = append(appeals, cloneAppeal(appeal))
+        }
+    }
+    sortBy(appeals, func(a, b *models.Appeal) bool { return a.CreatedAt.After(b.CreatedAt) })
+    return appeals, nil
+}
+
+func (s *Store) ListAppeals(ctx context.Context, status string, limit int) ([]*models.Appeal, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    var appeals []*models.Appeal
+    for _, appeal := range s.appeals {
+        if appeal.Status == status {
+            appeals = append(appeals, cloneAppeal(appeal))
+        }
+    }
+    sortBy(appeals, func(a, b *models.Appeal) bool { return a.CreatedAt.Before(b.CreatedAt) })
+    return limited(appeals, limit), nil
+}
+
+func (s *Store) ReviewAppeal(ctx context.Context, id, status, reviewerID, note string) (bool, error) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    appeal, ok := s.appeals[id]
+    if !ok || appeal.Status != models.AppealPending {
+        return false, nil
+    }
+    reviewed := now()
+    appeal.Status = status
+    appeal.ReviewedBy = reviewerID
+    appeal.ReviewNote = note
+    appeal.ReviewedAt = &reviewed
+    return true, nil
+}
+
+func cloneAppeal(appeal *models.Appeal) *models.Appeal {
+    a := clone(appeal)
+    a.ReviewedAt = cloneTime(appeal.ReviewedAt)
+    return a
+}
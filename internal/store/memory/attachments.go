@@ -0,0 +1,59 @@
+package memory
+
+import (
+    "context"
+
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+func (s *Store) CreateAttachment(ctx context.Context, attachment *models.Attachment, data []byte) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    attachment.ID = newID()
+    attachment.Size = len(data)
+    attachment.Status = models.AttachmentPending
+    attachment.CreatedAt = now()
+    stored := clone(attachment)
+    stored.Reason, stored.ScannedAt = "", nil
+    s.attachments[stored.ID] = stored
+    s.attachmentData[stored.ID] = append([]byte(nil), data...)
+    return nil
+}
+
+func (s *Store) GetAttachment(ctx context.Context, id string) (*models.Attachment, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    attachment, ok := s.attachments[id]
+    if !ok {
+        return nil, notFound("attachment")
+    }
+    a := clone(attachment)
+    a.ScannedAt = cloneTime(attachment.ScannedAt)
+    return a, nil
+}
+
+func (s *Store) GetAttachmentData(ctx context.Context, id string) ([]byte, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    data, ok := s.attachmentData[id]
+    if !ok {
+        return nil, notFound("attachment")
+    }
+    return append([]byte(nil), data...), nil
+}
+
+func (s *Store) SetAttachmentStatus(ctx context.Context, id, status, reason string) (bool, error) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    attachment, ok := s.attachments[id]
+    if !ok || attachment.Status != models.AttachmentPending {
+        return false, nil
+    }
+    scannedAt := now()
+    attachment.Status, attachment.Reason, attachment.ScannedAt = status, reason, &scannedAt
+    return true, nil
+}
@@ -0,0 +1,193 @@
+package memory
+
+import (
+    "context"
+    "fmt"
+    "strings"
+
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/store"
+)
+
+// GetOrCreateConversation orders the pair the way the postgres table
+// does: UUIDs compare like their lowercase text.
+func (s *Store) GetOrCreateConversation(ctx context.Context, userA, userB string) (*models.Conversation, error) {
+    userA, userB = strings.ToLower(userA), strings.ToLower(userB)
+    if userB < userA {
+        userA, userB = userB, userA
+    }
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    for _, conversation := range s.conversations {
+        if conversation.UserA == userA && conversation.UserB == userB {
+            return cloneConversation(conversation), nil
+        }
+    }
+    conversation := &models.Conversation{ID: newID(), UserA: userA, UserB: userB, CreatedAt: now()}
+    s.conversations[conversation.ID] = conversation
+    return cloneConversation(conversation), nil
+}
+
+func (s *Store) GetConversation(ctx context.Context, id string) (*models.Conversation, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    conversation, ok := s.conversations[id]
+    if !ok {
+        return nil, notFound("conversation")
+    }
+    return cloneConversation(conversation), nil
+}
+
+func (s *Store) GetUserConversations(ctx context.Context, userID string, limit int) ([]*models.Conversation, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    var conversations []*models.Conversation
+    for _, conversation := range s.conversations {
+        if conversation.UserA != userID && conversation.UserB != userID {
+            continue
+        }
+        otherID := conversation.UserA
+        if otherID == userID {
+            otherID = conversation.UserB
+        }
+        other, ok := s.users[otherID]
+        if !ok {
+            continue
+        }
+        c := cloneConversation(conversation)
+        c.Other = other.Summary()
+        c.LastMessage = s.lastDirectMessage(conversation.ID)
+        conversations = append(conversations, c)
+    }
+    sortBy(conversations, func(a, b *models.Conversation) bool {
+        if (a.LastMessageAt == nil) != (b.LastMessageAt == nil) {
+            return a.LastMessageAt != nil
+        }
+        if a.LastMessageAt != nil && !a.LastMessageAt.Equal(*b.LastMessageAt) {
+            return a.LastMessageAt.After(*b.LastMessageAt)
+        }
+        return a.CreatedAt.After(b.CreatedAt)
+    })
+    return limited(conversations, limit), nil
+}
+
+func cloneConversation(conversation *models.Conversation) *models.Conversation {
+    c := clone(conversation)
+    c.LastMessageAt = cloneTime(conversation.LastMessageAt)
+    c.Other, c.LastMessage = nil, nil
+    return c
+}
+
+// lastDirectMessage returns a conversation's latest message, or nil. The
+// caller holds the lock.
+func (s *Store) lastDirectMessage(conversationID string) *models.DirectMessage {
+    var last *models.DirectMessage
+    for _, msg := range s.directMessages {
+        if msg.ConversationID == conversationID && (last == nil || directOrder(last, msg)) {
+            last = msg
+        }
+    }
+    return clone(last)
+}
+
+func (s *Store) CreateDirectMessage(ctx context.Context, msg *models.DirectMessage) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    conversation, ok := s.conversations[msg.ConversationID]
+    if !ok {
+        return fmt.Errorf("failed to create direct message: %w", notFound("conversation"))
+    }
+    if msg.ID == "" {
+        msg.ID = newID()
+    }
+    if _, exists := s.directMessages[msg.ID]; exists {
+        return fmt.Errorf("failed to create direct message: %q exists", msg.ID)
+    }
+    if msg.CreatedAt.IsZero() {
+        msg.CreatedAt = now()
+    }
+    s.directMessages[msg.ID] = clone(msg)
+
+    if conversation.LastMessageAt == nil || msg.CreatedAt.After(*conversation.LastMessageAt) {
+        at := msg.CreatedAt
+        conversation.LastMessageAt = &at
+    }
+    return nil
+}
+
+func (s *Store) GetDirectMessagesBeforeCursor(ctx context.Context, conversationID string, before *store.MessageCursor, limit int) ([]*models.DirectMessage, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    var cursor *models.DirectMessage
+    if before != nil {
+        cursor = &models.DirectMessage{ID: before.ID, CreatedAt: before.CreatedAt}
+    }
+    var messages []*models.DirectMessage
+    for _, msg := range s.directMessages {
+        if msg.ConversationID == conversationID && (cursor == nil || directOrder(msg, cursor)) {
+            messages = append(messages, clone(msg))
+        }
+    }
+    sortBy(messages, func(a, b *models.DirectMessage) bool { return directOrder(b, a) })
+    return limited(messages, limit), nil
+}
+
+// directOrder orders direct messages by (created_at, id).
+func directOrder(a, b *models.DirectMessage) bool {
+    if !a.CreatedAt.Equal(b.CreatedAt) {
+        return a.CreatedAt.Before(b.CreatedAt)
+    }
+    return a.ID < b.ID
+}
+
+func (s *Store) BlockUser(ctx context.Context, block *models.UserBlock) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    key := blockKey{userID: block.UserID, blockedID: block.BlockedID}
+    if stored, exists := s.blocks[key]; exists {
+        block.CreatedAt = stored.CreatedAt
+        return nil
+    }
+    if block.CreatedAt.IsZero() {
+        block.CreatedAt = now()
+    }
+    s.blocks[key] = clone(block)
+    return nil
+}
+
+func (s *Store) UnblockUser(ctx context.Context, userID, blockedID string) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    delete(s.blocks, blockKey{userID: userID, blockedID: blockedID})
+    return nil
+}
+
+func (s *Store) GetBlockedUsers(ctx context.Context, userID string) ([]*models.UserBlock, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    var blocks []*models.UserBlock
+    for key, block := range s.blocks {
+        if key.userID == userID {
+            blocks = append(blocks, clone(block))
+        }
+    }
+    sortBy(blocks, func(a, b *models.UserBlock) bool { return a.CreatedAt.After(b.CreatedAt) })
+    return blocks, nil
+}
+
+func (s *Store) IsBlocked(ctx context.Context, userA, userB string) (bool, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    _, ab := s.blocks[blockKey{userID: userA, blockedID: userB}]
+    _, ba := s.blocks[blockKey{userID: userB, blockedID: userA}]
+    return ab || ba, nil
+}
@@ -0,0 +1,154 @@
+package memory
+
+import (
+    "context"
+    "fmt"
+    "strings"
+    "time"
+
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+func (s *Store) CreateEmotePack(ctx context.Context, pack *models.EmotePack) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    pack.ID = newID()
+    if pack.CreatedAt.IsZero() {
+        pack.CreatedAt = now()
+    }
+    stored := cloneEmotePack(pack)
+    stored.PublishedAt = nil
+    s.emotePacks[stored.ID] = stored
+    return nil
+}
+
+// GetEmotePack loads a pack, published or not, with its emotes.
+func (s *Store) GetEmotePack(ctx context.Context, id string) (*models.EmotePack, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    pack, ok := s.emotePacks[id]
+    if !ok {
+        return nil, notFound("emote pack")
+    }
+    return s.joinEmotePack(pack), nil
+}
+
+func (s *Store) AddEmote(ctx context.Context, emote *models.Emote) (bool, error) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    if _, ok := s.emotePacks[emote.PackID]; !ok {
+        return false, fmt.Errorf("failed to add emote: %w", notFound("emote pack"))
+    }
+    for _, other := range s.emotes {
+        if other.PackID == emote.PackID && other.Shortcode == emote.Shortcode {
+            return false, nil
+        }
+    }
+    emote.ID = newID()
+    if emote.CreatedAt.IsZero() {
+        emote.CreatedAt = now()
+    }
+    s.emotes[emote.ID] = clone(emote)
+    return true, nil
+}
+
+func (s *Store) DeleteEmote(ctx context.Context, id string) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    delete(s.emotes, id)
+    return nil
+}
+
+func (s *Store) PublishEmotePack(ctx context.Context, id string, at time.Time) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    if pack, ok := s.emotePacks[id]; ok {
+        pack.PublishedAt = &at
+    }
+    return nil
+}
+
+// GetPublishedEmotePacks lists the site-wide packs first, then the teams'
+// packs, each in the order it was created.
+func (s *Store) GetPublishedEmotePacks(ctx context.Context, teamIDs []string) ([]*models.EmotePack, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    var packs []*models.EmotePack
+    for _, pack := range s.emotePacks {
+        if s.publishedFor(pack, teamIDs) {
+            packs = append(packs, s.joinEmotePack(pack))
+        }
+    }
+    sortBy(packs, func(a, b *models.EmotePack) bool {
+        if a.TeamID != b.TeamID {
+            return a.TeamID < b.TeamID
+        }
+        return a.CreatedAt.Before(b.CreatedAt)
+    })
+    return packs, nil
+}
+
+// SearchEmotes matches shortcodes case-sensitively, as they are stored
+// lowercase, shortest first so an exact match leads.
+func (s *Store) SearchEmotes(ctx context.Context, teamIDs []string, prefix string, limit int) ([]*models.Emote, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    var emotes []*models.Emote
+    for _, emote := range s.emotes {
+        pack, ok := s.emotePacks[emote.PackID]
+        if ok && s.publishedFor(pack, teamIDs) && strings.HasPrefix(emote.Shortcode, prefix) {
+            emotes = append(emotes, clone(emote))
+        }
+    }
+    sortBy(emotes, func(a, b *models.Emote) bool {
+        if len(a.Shortcode) != len(b.Shortcode) {
+            return len(a.Shortcode) < len(b.Shortcode)
+        }
+        return a.Shortcode < b.Shortcode
+    })
+    return limited(emotes, limit), nil
+}
+
+// publishedFor reports whether a pack is published site-wide or for one
+// of the teams.
+func (s *Store) publishedFor(pack *models.EmotePack, teamIDs []string) bool {
+    if pack.PublishedAt == nil {
+        return false
+    }
+    if pack.TeamID == "" {
+        return true
+    }
+    for _, id := range teamIDs {
+        if equalFold(id, pack.TeamID) {
+            return true
+        }
+    }
+    return false
+}
+
+// joinEmotePack copies a pack with its emotes, by shortcode. The caller
+// holds the lock.
+func (s *Store) joinEmotePack(pack *models.EmotePack) *models.EmotePack {
+    p := cloneEmotePack(pack)
+    for _, emote := range s.emotes {
+        if emote.PackID == pack.ID {
+            p.Emotes = append(p.Emotes, clone(emote))
+        }
+    }
+    sortBy(p.Emotes, func(a, b *models.Emote) bool { return a.Shortcode < b.Shortcode })
+    return p
+}
+
+func cloneEmotePack(pack *models.EmotePack) *models.EmotePack {
+    p := clone(pack)
+    p.PublishedAt = cloneTime(pack.PublishedAt)
+    p.Emotes = nil
+    return p
+}
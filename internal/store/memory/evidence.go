@@ -0,0 +1,117 @@
+package memory
+
+import (
+    "context"
+    "fmt"
+    "time"
+
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+func (s *Store) CreateModerationEvidence(ctx context.Context, evidence *models.ModerationEvidence) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    if evidence.ID == "" {
+        evidence.ID = newID()
+    }
+    if _, exists := s.evidence[evidence.ID]; exists {
+        return fmt.Errorf("failed to create moderation evidence: %q exists", evidence.ID)
+    }
+    if evidence.CreatedAt.IsZero() {
+        evidence.CreatedAt = now()
+    }
+    s.evidence[evidence.ID] = cloneEvidence(evidence)
+    return nil
+}
+
+// GetModerationEvidence treats expired evidence as gone, whether or not
+// it has been purged yet.
+func (s *Store) GetModerationEvidence(ctx context.Context, id string) (*models.ModerationEvidence, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    evidence, ok := s.evidence[id]
+    if !ok || !evidence.ExpiresAt.After(time.Now()) {
+        return nil, notFound("moderation evidence")
+    }
+    return cloneEvidence(evidence), nil
+}
+
+func (s *Store) GetMessageEvidence(ctx context.Context, messageID string) (*models.ModerationEvidence, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    var latest *models.ModerationEvidence
+    for _, evidence := range s.evidence {
+        if evidence.MessageID == messageID && evidence.ExpiresAt.After(time.Now()) &&
+            (latest == nil || evidence.CreatedAt.After(latest.CreatedAt)) {
+            latest = evidence
+        }
+    }
+    if latest == nil {
+        return nil, notFound("moderation evidence")
+    }
+    return cloneEvidence(latest), nil
+}
+
+func (s *Store) ListModerationEvidence(ctx context.Context, userID string, limit int) ([]*models.ModerationEvidence, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    current := time.Now()
+    var list []*models.ModerationEvidence
+    for _, evidence := range s.evidence {
+        if (userID == "" || evidence.UserID == userID) && evidence.ExpiresAt.After(current) {
+            list = append(list, cloneEvidence(evidence))
+        }
+    }
+    sortBy(list, func(a, b *models.ModerationEvidence) bool { return a.CreatedAt.After(b.CreatedAt) })
+    return limited(list, limit), nil
+}
+
+// PurgeExpiredModerationEvidence deletes evidence past its expiry, with
+// its access log.
+func (s *Store) PurgeExpiredModerationEvidence(ctx context.Context, now time.Time) (int64, error) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    var purged int64
+    for id, evidence := range s.evidence {
+        if !evidence.ExpiresAt.After(now) {
+            delete(s.evidence, id)
+            delete(s.evidenceAccess, id)
+            purged++
+        }
+    }
+    return purged, nil
+}
+
+func (s *Store) RecordEvidenceAccess(ctx context.Context, access *models.EvidenceAccess) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    if _, ok := s.evidence[access.EvidenceID]; !ok {
+        return fmt.Errorf("failed to record evidence access: %w", notFound("moderation evidence"))
+    }
+    if access.AccessedAt.IsZero() {
+        access.AccessedAt = now()
+    }
+    s.evidenceAccess[access.EvidenceID] = append(s.evidenceAccess[access.EvidenceID], clone(access))
+    return nil
+}
+
+func (s *Store) GetEvidenceAccess(ctx context.Context, evidenceID string) ([]*models.EvidenceAccess, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    accesses := cloneAll(s.evidenceAccess[evidenceID])
+    sortBy(accesses, func(a, b *models.EvidenceAccess) bool { return a.AccessedAt.Before(b.AccessedAt) })
+    return accesses, nil
+}
+
+func cloneEvidence(evidence *models.ModerationEvidence) *models.ModerationEvidence {
+    e := clone(evidence)
+    e.Sealed = append([]byte(nil), evidence.Sealed...)
+    return e
+}
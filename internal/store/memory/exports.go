@@ -0,0 +1,96 @@
+package memory
+
+import (
+    "context"
+    "fmt"
+    "time"
+
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+func (s *Store) CreateDataExport(ctx context.Context, export *models.DataExport) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    if export.ID == "" {
+        export.ID = newID()
+    }
+    if _, exists := s.exports[export.ID]; exists {
+        return fmt.Errorf("failed to create data export: %q exists", export.ID)
+    }
+    if _, ok := s.users[export.UserID]; !ok {
+        return fmt.Errorf("failed to create data export: user %s not found", export.UserID)
+    }
+    export.CreatedAt = now()
+    s.exports[export.ID] = cloneExport(export)
+    return nil
+}
+
+func (s *Store) GetDataExport(ctx context.Context, id string) (*models.DataExport, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    export, ok := s.exports[id]
+    if !ok {
+        return nil, notFound("data export")
+    }
+    return cloneExport(export), nil
+}
+
+func (s *Store) ListUserDataExports(ctx context.Context, userID string) ([]*models.DataExport, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    var exports []*models.DataExport
+    for _, export := range s.exports {
+        if export.UserID == userID {
+            exports = append(exports, cloneExport(export))
+        }
+    }
+    sortBy(exports, func(a, b *models.DataExport) bool { return a.CreatedAt.After(b.CreatedAt) })
+    return exports, nil
+}
+
+func (s *Store) CompleteDataExport(ctx context.Context, export *models.DataExport) (bool, error) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    stored, ok := s.exports[export.ID]
+    if !ok || stored.Status != models.ExportPending {
+        return false, nil
+    }
+    stored.Status, stored.ObjectKey, stored.Size, stored.Error = export.Status, export.ObjectKey, export.Size, export.Error
+    stored.CompletedAt, stored.ExpiresAt = cloneTime(export.CompletedAt), cloneTime(export.ExpiresAt)
+    return true, nil
+}
+
+// ListExpiredDataExports returns exports that expired before the time,
+// oldest first.
+func (s *Store) ListExpiredDataExports(ctx context.Context, before time.Time, limit int) ([]*models.DataExport, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    var exports []*models.DataExport
+    for _, export := range s.exports {
+        if export.ExpiresAt != nil && export.ExpiresAt.Before(before) {
+            exports = append(exports, cloneExport(export))
+        }
+    }
+    sortBy(exports, func(a, b *models.DataExport) bool { return a.ExpiresAt.Before(*b.ExpiresAt) })
+    return limited(exports, limit), nil
+}
+
+func (s *Store) DeleteDataExport(ctx context.Context, id string) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    delete(s.exports, id)
+    return nil
+}
+
+func cloneExport(export *models.DataExport) *models.DataExport {
+    e := clone(export)
+    e.CompletedAt = cloneTime(export.CompletedAt)
+    e.ExpiresAt = cloneTime(export.ExpiresAt)
+    return e
+}
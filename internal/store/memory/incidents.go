@@ -0,0 +1,50 @@
+package memory
+
+import (
+    "context"
+    "fmt"
+
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+func (s *Store) CreateIncident(ctx context.Context, incident *models.Incident) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    if incident.ID == "" {
+        incident.ID = newID()
+    }
+    if _, exists := s.incidents[incident.ID]; exists {
+        return fmt.Errorf("failed to create incident: %q exists", incident.ID)
+    }
+    incident.CreatedAt = now()
+    s.incidents[incident.ID] = clone(incident)
+    return nil
+}
+
+func (s *Store) GetIncident(ctx context.Context, id string) (*models.Incident, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    incident, ok := s.incidents[id]
+    if !ok {
+        return nil, notFound("incident")
+    }
+    return clone(incident), nil
+}
+
+// ListIncidents lists the latest incidents, of one room or of all for an
+// empty roomID, newest first.
+func (s *Store) ListIncidents(ctx context.Context, roomID string, limit int) ([]*models.Incident, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    var incidents []*models.Incident
+    for _, incident := range s.incidents {
+        if roomID == "" || incident.RoomID == roomID {
+            incidents = append(incidents, clone(incident))
+        }
+    }
+    sortBy(incidents, func(a, b *models.Incident) bool { return a.CreatedAt.After(b.CreatedAt) })
+    return limited(incidents, limit), nil
+}
@@ -0,0 +1,524 @@
+package memory
+
+import (
+    "context"
+    "fmt"
+    "time"
+
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+func (s *Store) CreateSport(ctx context.Context, sport *models.Sport) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    for _, other := range s.sports {
+        if other.Name == sport.Name {
+            return fmt.Errorf("failed to create sport: %q exists", sport.Name)
+        }
+    }
+    sport.ID = newID()
+    sport.CreatedAt = now()
+    s.sports[sport.ID] = clone(sport)
+    return nil
+}
+
+func (s *Store) GetSport(ctx context.Context, id string) (*models.Sport, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    sport, ok := s.sports[id]
+    if !ok {
+        return nil, notFound("sport")
+    }
+    return clone(sport), nil
+}
+
+func (s *Store) ListSports(ctx context.Context) ([]*models.Sport, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    var sports []*models.Sport
+    for _, sport := range s.sports {
+        sports = append(sports, clone(sport))
+    }
+    sortBy(sports, func(a, b *models.Sport) bool { return a.Name < b.Name })
+    return sports, nil
+}
+
+func (s *Store) UpdateSport(ctx context.Context, sport *models.Sport) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    if stored, ok := s.sports[sport.ID]; ok {
+        stored.Name = sport.Name
+        stored.Description = sport.Description
+    }
+    return nil
+}
+
+func (s *Store) DeleteSport(ctx context.Context, id string) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    delete(s.sports, id)
+    for _, team := range s.teams {
+        if team.SportID == id {
+            s.deleteTeam(team.ID)
+        }
+    }
+    for _, match := range s.matches {
+        if match.SportID == id {
+            s.deleteMatch(match.ID)
+        }
+    }
+    return nil
+}
+
+func (s *Store) CreateTeam(ctx context.Context, team *models.Team) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    for _, other := range s.teams {
+        if other.Name == team.Name && other.SportID == team.SportID {
+            return fmt.Errorf("failed to create team: %q exists", team.Name)
+        }
+    }
+    team.ID = newID()
+    team.CreatedAt = now()
+    s.teams[team.ID] = clone(team)
+    return nil
+}
+
+func (s *Store) GetTeam(ctx context.Context, id string) (*models.Team, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    team, ok := s.teams[id]
+    if !ok {
+        return nil, notFound("team")
+    }
+    return clone(team), nil
+}
+
+// ListTeams lists a sport's teams, or every team for an empty sportID.
+func (s *Store) ListTeams(ctx context.Context, sportID string) ([]*models.Team, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    var teams []*models.Team
+    for _, team := range s.teams {
+        if sportID == "" || team.SportID == sportID {
+            teams = append(teams, clone(team))
+        }
+    }
+    sortBy(teams, func(a, b *models.Team) bool { return a.Name < b.Name })
+    return teams, nil
+}
+
+func (s *Store) UpdateTeam(ctx context.Context, team *models.Team) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    if stored, ok := s.teams[team.ID]; ok {
+        stored.Name = team.Name
+        stored.SportID = team.SportID
+        stored.LogoURL = team.LogoURL
+    }
+    return nil
+}
+
+func (s *Store) DeleteTeam(ctx context.Context, id string) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    s.deleteTeam(id)
+    return nil
+}
+
+// deleteTeam removes a team and the matches it played, as the schema's
+// cascades do.
+func (s *Store) deleteTeam(id string) {
+    delete(s.teams, id)
+    for _, match := range s.matches {
+        if match.HomeTeamID == id || match.AwayTeamID == id {
+            s.deleteMatch(match.ID)
+        }
+    }
+}
+
+func (s *Store) CreateMatch(ctx context.Context, match *models.Match) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    if match.ProviderID != "" {
+        for _, other := range s.matches {
+            if other.ProviderID == match.ProviderID {
+                return fmt.Errorf("failed to create match: provider ID %q exists", match.ProviderID)
+            }
+        }
+    }
+    match.ID = newID()
+    match.CreatedAt = now()
+    match.UpdatedAt = match.CreatedAt
+    s.matches[match.ID] = storedMatch(match)
+    return nil
+}
+
+func (s *Store) GetMatch(ctx context.Context, id string) (*models.Match, error) {
+    return s.getMatch(func(m *models.Match) bool { return m.ID == id })
+}
+
+func (s *Store) GetMatchByProviderID(ctx context.Context, providerID string) (*models.Match, error) {
+    return s.getMatch(func(m *models.Match) bool { return providerID != "" && m.ProviderID == providerID })
+}
+
+func (s *Store) getMatch(keep func(*models.Match) bool) (*models.Match, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    for _, match := range s.matches {
+        if keep(match) {
+            return s.joinMatch(match), nil
+        }
+    }
+    return nil, notFound("match")
+}
+
+// listMatches returns the matches keep accepts, ordered by less.
+func (s *Store) listMatches(keep func(*models.Match) bool, less func(a, b *models.Match) bool, limit int) []*models.Match {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    var matches []*models.Match
+    for _, match := range s.matches {
+        if keep(match) {
+            matches = append(matches, match)
+        }
+    }
+    sortBy(matches, less)
+    matches = limited(matches, limit)
+
+    joined := make([]*models.Match, len(matches))
+    for i, match := range matches {
+        joined[i] = s.joinMatch(match)
+    }
+    return joined
+}
+
+func startsFirst(a, b *models.Match) bool { return a.StartTime.Before(b.StartTime) }
+
+func startsLast(a, b *models.Match) bool { return a.StartTime.After(b.StartTime) }
+
+func (s *Store) GetLiveMatches(ctx context.Context) ([]*models.Match, error) {
+    return s.GetMatchesByStatus(ctx, models.MatchStatusLive)
+}
+
+func (s *Store) GetMatchesByStatus(ctx context.Context, status string) ([]*models.Match, error) {
+    return s.listMatches(func(m *models.Match) bool { return m.Status == status }, startsFirst, 0), nil
+}
+
+func (s *Store) GetUpcomingMatches(ctx context.Context, limit int) ([]*models.Match, error) {
+    current := time.Now()
+    return s.listMatches(func(m *models.Match) bool {
+        return m.Status == models.MatchStatusScheduled && m.StartTime.After(current)
+    }, startsFirst, limit), nil
+}
+
+func (s *Store) GetFinishedMatchesSince(ctx context.Context, since time.Time) ([]*models.Match, error) {
+    return s.listMatches(func(m *models.Match) bool {
+        return m.Status == models.MatchStatusFinished && !m.StartTime.Before(since)
+    }, startsFirst, 0), nil
+}
+
+func (s *Store) GetHeadToHeadMatches(ctx context.Context, teamAID, teamBID string, limit int) ([]*models.Match, error) {
+    return s.listMatches(func(m *models.Match) bool {
+        return m.Status == models.MatchStatusFinished &&
+            ((m.HomeTeamID == teamAID && m.AwayTeamID == teamBID) || (m.HomeTeamID == teamBID && m.AwayTeamID == teamAID))
+    }, startsLast, limit), nil
+}
+
+func (s *Store) GetTeamRecentMatches(ctx context.Context, teamID string, limit int) ([]*models.Match, error) {
+    return s.listMatches(func(m *models.Match) bool {
+        return m.Status == models.MatchStatusFinished && (m.HomeTeamID == teamID || m.AwayTeamID == teamID)
+    }, startsLast, limit), nil
+}
+
+func (s *Store) CountMatchFollowers(ctx context.Context, matchID string) (int, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    match, ok := s.matches[matchID]
+    if !ok {
+        return 0, nil
+    }
+    // Favorite teams are free text, holding either a team's ID or its name
+    var fanOf []string
+    for _, teamID := range []string{match.HomeTeamID, match.AwayTeamID} {
+        if team, ok := s.teams[teamID]; ok {
+            fanOf = append(fanOf, team.ID, team.Name)
+        }
+    }
+
+    followers := make(map[string]bool)
+    for _, user := range s.users {
+        for _, team := range fanOf {
+            if user.FavoriteTeam != "" && equalFold(user.FavoriteTeam, team) {
+                followers[user.ID] = true
+            }
+        }
+    }
+    for key := range s.members {
+        if room, ok := s.rooms[key.scope]; ok && room.MatchID == matchID {
+            followers[key.userID] = true
+        }
+    }
+
+    count := 0
+    for userID := range followers {
+        if user, ok := s.users[userID]; ok && user.DeactivatedAt == nil && user.BannedAt == nil {
+            count++
+        }
+    }
+    return count, nil
+}
+
+func (s *Store) UpdateMatch(ctx context.Context, match *models.Match) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    stored, ok := s.matches[match.ID]
+    if !ok {
+        return fmt.Errorf("failed to update match: %w", notFound("match"))
+    }
+    updated := storedMatch(match)
+    updated.CreatedAt = stored.CreatedAt
+    updated.UpdatedAt = now()
+    s.matches[match.ID] = updated
+    match.UpdatedAt = updated.UpdatedAt
+    return nil
+}
+
+func (s *Store) DeleteMatch(ctx context.Context, id string) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    s.deleteMatch(id)
+    return nil
+}
+
+// deleteMatch removes a match with its rooms and events.
+func (s *Store) deleteMatch(id string) {
+    delete(s.matches, id)
+    for _, room := range s.rooms {
+        if room.MatchID == id {
+            s.deleteRoom(room.ID)
+        }
+    }
+    for _, event := range s.matchEvents {
+        if event.MatchID == id {
+            delete(s.matchEvents, event.ID)
+        }
+    }
+    for _, highlight := range s.highlights {
+        if highlight.MatchID == id {
+            delete(s.highlights, highlight.ID)
+        }
+    }
+}
+
+// storedMatch copies a match without its joined fields.
+func storedMatch(match *models.Match) *models.Match {
+    m := clone(match)
+    m.HomeTeam, m.AwayTeam, m.Sport, m.Events, m.Names = nil, nil, nil, nil, nil
+    if match.Shootout != nil {
+        m.Shootout = cloneShootout(match.Shootout)
+    }
+    m.Venue = clone(match.Venue)
+    return m
+}
+
+// joinMatch copies a stored match with both teams, which callers show by
+// name.
+func (s *Store) joinMatch(match *models.Match) *models.Match {
+    m := storedMatch(match)
+    if team, ok := s.teams[m.HomeTeamID]; ok {
+        m.HomeTeam = &models.Team{ID: team.ID, Name: team.Name, SportID: m.SportID, LogoURL: team.LogoURL}
+    }
+    if team, ok := s.teams[m.AwayTeamID]; ok {
+        m.AwayTeam = &models.Team{ID: team.ID, Name: team.Name, SportID: m.SportID, LogoURL: team.LogoURL}
+    }
+    return m
+}
+
+func cloneShootout(shootout *models.Shootout) *models.Shootout {
+    c := clone(shootout)
+    c.Kicks = cloneAll(shootout.Kicks)
+    return c
+}
+
+func (s *Store) CreateMatchEvent(ctx context.Context, event *models.MatchEvent) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    if event.ID == "" {
+        event.ID = newID()
+    }
+    if _, exists := s.matchEvents[event.ID]; exists {
+        return fmt.Errorf("failed to create match event: %q exists", event.ID)
+    }
+    if event.CreatedAt.IsZero() {
+        event.CreatedAt = now()
+    }
+    s.matchEvents[event.ID] = clone(event)
+    return nil
+}
+
+func (s *Store) GetMatchEvents(ctx context.Context, matchID string) ([]*models.MatchEvent, error) {
+    events := s.matchEventsOf(matchID)
+    sortBy(events, func(a, b *models.MatchEvent) bool {
+        if a.EventTime != b.EventTime {
+            return a.EventTime < b.EventTime
+        }
+        return a.CreatedAt.Before(b.CreatedAt)
+    })
+    return events, nil
+}
+
+// GetRecentMatchEvents returns the latest events, newest first.
+func (s *Store) GetRecentMatchEvents(ctx context.Context, matchID string, limit int) ([]*models.MatchEvent, error) {
+    events := s.matchEventsOf(matchID)
+    sortBy(events, func(a, b *models.MatchEvent) bool { return a.CreatedAt.After(b.CreatedAt) })
+    return limited(events, limit), nil
+}
+
+func (s *Store) matchEventsOf(matchID string) []*models.MatchEvent {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    var events []*models.MatchEvent
+    for _, event := range s.matchEvents {
+        if event.MatchID == matchID {
+            events = append(events, clone(event))
+        }
+    }
+    return events
+}
+
+func (s *Store) ListCommentaryTemplates(ctx context.Context) ([]*models.CommentaryTemplate, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    var templates []*models.CommentaryTemplate
+    for _, template := range s.commentary {
+        templates = append(templates, clone(template))
+    }
+    sortBy(templates, func(a, b *models.CommentaryTemplate) bool {
+        if a.Locale != b.Locale {
+            return a.Locale < b.Locale
+        }
+        return a.EventType < b.EventType
+    })
+    return templates, nil
+}
+
+func (s *Store) UpsertCommentaryTemplate(ctx context.Context, template *models.CommentaryTemplate) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    template.UpdatedAt = now()
+    s.commentary[commentaryKey{locale: template.Locale, eventType: template.EventType}] = clone(template)
+    return nil
+}
+
+func (s *Store) DeleteCommentaryTemplate(ctx context.Context, locale, eventType string) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    delete(s.commentary, commentaryKey{locale: locale, eventType: eventType})
+    return nil
+}
+
+func (s *Store) ListLocalizedNames(ctx context.Context) ([]*models.LocalizedName, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    var names []*models.LocalizedName
+    for _, name := range s.names {
+        names = append(names, clone(name))
+    }
+    sortBy(names, func(a, b *models.LocalizedName) bool {
+        if a.Kind != b.Kind {
+            return a.Kind < b.Kind
+        }
+        if a.EntityID != b.EntityID {
+            return a.EntityID < b.EntityID
+        }
+        return a.Locale < b.Locale
+    })
+    return names, nil
+}
+
+func (s *Store) UpsertLocalizedNames(ctx context.Context, names []*models.LocalizedName) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    for _, name := range names {
+        key := nameKey{kind: name.Kind, entityID: name.EntityID, locale: name.Locale}
+        if stored, ok := s.names[key]; ok && stored.Source == models.NameSourceAdmin && name.Source != models.NameSourceAdmin {
+            continue
+        }
+        name.UpdatedAt = now()
+        s.names[key] = clone(name)
+    }
+    return nil
+}
+
+func (s *Store) DeleteLocalizedName(ctx context.Context, kind, entityID, locale string) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    delete(s.names, nameKey{kind: kind, entityID: entityID, locale: locale})
+    return nil
+}
+
+func (s *Store) CreateHighlight(ctx context.Context, highlight *models.Highlight) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    highlight.ID = newID()
+    if highlight.CreatedAt.IsZero() {
+        highlight.CreatedAt = now()
+    }
+    h := clone(highlight)
+    h.Event = nil
+    s.highlights[h.ID] = h
+    return nil
+}
+
+func (s *Store) GetMatchHighlights(ctx context.Context, matchID string) ([]*models.Highlight, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    var highlights []*models.Highlight
+    for _, highlight := range s.highlights {
+        if highlight.MatchID != matchID {
+            continue
+        }
+        h := clone(highlight)
+        if event, ok := s.matchEvents[h.EventID]; ok {
+            h.Event = clone(event)
+        }
+        highlights = append(highlights, h)
+    }
+    sortBy(highlights, func(a, b *models.Highlight) bool { return a.CreatedAt.After(b.CreatedAt) })
+    return highlights, nil
+}
+
+func (s *Store) DeleteHighlight(ctx context.Context, id string) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    delete(s.highlights, id)
+    return nil
+}
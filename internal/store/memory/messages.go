@@ -0,0 +1,536 @@
+package memory
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+    "time"
+
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/store"
+)
+
+func (s *Store) CreateMessage(ctx context.Context, message *models.Message) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    if message.ID == "" {
+        message.ID = newID()
+    }
+    if _, exists := s.messages[message.ID]; exists {
+        return fmt.Errorf("failed to create message: %q exists", message.ID)
+    }
+    s.insertMessage(message)
+    return nil
+}
+
+func (s *Store) CreateMessages(ctx context.Context, messages []*models.Message) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    for _, message := range messages {
+        if _, exists := s.messages[message.ID]; !exists {
+            s.insertMessage(message)
+        }
+    }
+    return nil
+}
+
+// insertMessage stores a message, filling in what the columns default.
+// The caller holds the lock.
+func (s *Store) insertMessage(message *models.Message) {
+    if message.MessageType == "" {
+        message.MessageType = "text"
+    }
+    if message.CreatedAt.IsZero() {
+        message.CreatedAt = now()
+    }
+    s.messages[message.ID] = storedMessage(message)
+}
+
+func (s *Store) GetMessage(ctx context.Context, id string) (*models.Message, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    message, ok := s.messages[id]
+    if !ok {
+        return nil, notFound("message")
+    }
+    return s.joinMessage(message), nil
+}
+
+// GetRecentMessages returns the room's latest messages, newest first.
+func (s *Store) GetRecentMessages(ctx context.Context, roomID string, limit int) ([]*models.Message, error) {
+    return s.GetMessagesBeforeCursor(ctx, roomID, store.MessageFilter{}, nil, limit)
+}
+
+func (s *Store) GetMessagesBeforeCursor(ctx context.Context, roomID string, filter store.MessageFilter, cursor *store.MessageCursor, limit int) ([]*models.Message, error) {
+    return s.messagesBefore(func(m *models.Message) bool {
+        return m.ChatRoomID == roomID && filter.Matches(m)
+    }, cursor, limit), nil
+}
+
+func (s *Store) GetTopicMessagesBeforeCursor(ctx context.Context, topicID string, cursor *store.MessageCursor, limit int) ([]*models.Message, error) {
+    return s.messagesBefore(func(m *models.Message) bool { return m.TopicID == topicID }, cursor, limit), nil
+}
+
+// messagesBefore pages back by (created_at, id), newest first.
+func (s *Store) messagesBefore(keep func(*models.Message) bool, cursor *store.MessageCursor, limit int) []*models.Message {
+    return s.listMessages(func(m *models.Message) bool {
+        return keep(m) && (cursor == nil || beforeCursor(m, *cursor))
+    }, func(a, b *models.Message) bool { return feedOrder(b, a) }, limit)
+}
+
+func (s *Store) GetMessagesAfterCursor(ctx context.Context, roomID string, filter store.MessageFilter, cursor store.MessageCursor, limit int) ([]*models.Message, error) {
+    return s.listMessages(func(m *models.Message) bool {
+        return m.ChatRoomID == roomID && filter.Matches(m) && afterCursor(m, cursor)
+    }, feedOrder, limit), nil
+}
+
+func (s *Store) GetMessagesAfterSeq(ctx context.Context, roomID string, seq int64, limit int) ([]*models.Message, error) {
+    return s.listMessages(func(m *models.Message) bool {
+        return m.ChatRoomID == roomID && m.Seq > seq
+    }, func(a, b *models.Message) bool { return a.Seq < b.Seq }, limit), nil
+}
+
+func (s *Store) GetMessagesBetween(ctx context.Context, roomID string, from, to time.Time, limit int) ([]*models.Message, error) {
+    return s.listMessages(func(m *models.Message) bool {
+        return m.ChatRoomID == roomID && !m.CreatedAt.Before(from) && m.CreatedAt.Before(to)
+    }, feedOrder, limit), nil
+}
+
+func (s *Store) GetUserMessages(ctx context.Context, userID string, cursor *store.MessageCursor, limit int) ([]*models.Message, error) {
+    return s.listMessages(func(m *models.Message) bool {
+        return m.UserID == userID && (cursor == nil || afterCursor(m, *cursor))
+    }, feedOrder, limit), nil
+}
+
+func (s *Store) GetRoomMaxSeq(ctx context.Context, roomID string) (int64, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    var seq int64
+    for _, message := range s.messages {
+        if message.ChatRoomID == roomID && message.Seq > seq {
+            seq = message.Seq
+        }
+    }
+    return seq, nil
+}
+
+// listMessages returns the messages keep accepts, ordered by less, with
+// their senders joined.
+func (s *Store) listMessages(keep func(*models.Message) bool, less func(a, b *models.Message) bool, limit int) []*models.Message {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    var messages []*models.Message
+    for _, message := range s.messages {
+        if keep(message) {
+            messages = append(messages, message)
+        }
+    }
+    sortBy(messages, less)
+    messages = limited(messages, limit)
+
+    joined := make([]*models.Message, len(messages))
+    for i, message := range messages {
+        joined[i] = s.joinMessage(message)
+    }
+    return joined
+}
+
+// feedOrder orders messages by (created_at, id), the room feed's order.
+func feedOrder(a, b *models.Message) bool {
+    if !a.CreatedAt.Equal(b.CreatedAt) {
+        return a.CreatedAt.Before(b.CreatedAt)
+    }
+    return a.ID < b.ID
+}
+
+func beforeCursor(m *models.Message, cursor store.MessageCursor) bool {
+    return feedOrder(m, &models.Message{CreatedAt: cursor.CreatedAt, ID: cursor.ID})
+}
+
+func afterCursor(m *models.Message, cursor store.MessageCursor) bool {
+    return feedOrder(&models.Message{CreatedAt: cursor.CreatedAt, ID: cursor.ID}, m)
+}
+
+// lastMessage returns a room's latest message, or nil. The caller holds
+// the lock.
+func (s *Store) lastMessage(roomID string) *models.Message {
+    var last *models.Message
+    for _, message := range s.messages {
+        if message.ChatRoomID == roomID && (last == nil || feedOrder(last, message)) {
+            last = message
+        }
+    }
+    return last
+}
+
+func (s *Store) DeleteMessage(ctx context.Context, id string) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    s.deleteMessage(id)
+    return nil
+}
+
+// deleteMessage removes a message with its reactions. The caller holds
+// the lock.
+func (s *Store) deleteMessage(id string) {
+    delete(s.messages, id)
+    for key := range s.reactions {
+        if key.messageID == id {
+            delete(s.reactions, key)
+        }
+    }
+}
+
+func (s *Store) SetMessagePreviews(ctx context.Context, id string, previews []*models.LinkPreview) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    if message, ok := s.messages[id]; ok {
+        message.Previews = cloneAll(previews)
+    }
+    return nil
+}
+
+// storedMessage copies a message without its joined fields.
+func storedMessage(message *models.Message) *models.Message {
+    m := clone(message)
+    m.User, m.Reactions = nil, nil
+    m.Previews = cloneAll(message.Previews)
+    m.Mentions = append([]string(nil), message.Mentions...)
+    if message.MatchMinute != nil {
+        minute := *message.MatchMinute
+        m.MatchMinute = &minute
+    }
+    return m
+}
+
+// joinMessage copies a stored message with its sender's summary. The
+// caller holds the lock.
+func (s *Store) joinMessage(message *models.Message) *models.Message {
+    m := storedMessage(message)
+    if user, ok := s.users[m.UserID]; ok {
+        m.User = user.Summary()
+    }
+    return m
+}
+
+func (s *Store) AddReaction(ctx context.Context, reaction *models.Reaction) (bool, error) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    if _, ok := s.messages[reaction.MessageID]; !ok {
+        return false, fmt.Errorf("failed to add reaction: %w", notFound("message"))
+    }
+    key := reactionKey{messageID: reaction.MessageID, userID: reaction.UserID, emoji: reaction.Emoji}
+    if _, exists := s.reactions[key]; exists {
+        return false, nil
+    }
+    if reaction.CreatedAt.IsZero() {
+        reaction.CreatedAt = now()
+    }
+    s.reactions[key] = clone(reaction)
+    return true, nil
+}
+
+func (s *Store) RemoveReaction(ctx context.Context, messageID, userID, emoji string) (bool, error) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    key := reactionKey{messageID: messageID, userID: userID, emoji: emoji}
+    if _, exists := s.reactions[key]; !exists {
+        return false, nil
+    }
+    delete(s.reactions, key)
+    return true, nil
+}
+
+// GetMessageReactions counts each message's reactions by emoji, the most
+// used first.
+func (s *Store) GetMessageReactions(ctx context.Context, messageIDs []string) (map[string][]*models.ReactionCount, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    type tally struct {
+        count int
+        first time.Time
+    }
+    wanted := make(map[string]bool, len(messageIDs))
+    for _, id := range messageIDs {
+        wanted[id] = true
+    }
+    tallies := make(map[string]map[string]*tally)
+    for key, reaction := range s.reactions {
+        if !wanted[key.messageID] {
+            continue
+        }
+        byEmoji, ok := tallies[key.messageID]
+        if !ok {
+            byEmoji = make(map[string]*tally)
+            tallies[key.messageID] = byEmoji
+        }
+        t, ok := byEmoji[key.emoji]
+        if !ok {
+            t = &tally{first: reaction.CreatedAt}
+            byEmoji[key.emoji] = t
+        }
+        t.count++
+        if reaction.CreatedAt.Before(t.first) {
+            t.first = reaction.CreatedAt
+        }
+    }
+
+    counts := make(map[string][]*models.ReactionCount)
+    for messageID, byEmoji := range tallies {
+        var list []*models.ReactionCount
+        for emoji, t := range byEmoji {
+            list = append(list, &models.ReactionCount{Emoji: emoji, Count: t.count})
+        }
+        sortBy(list, func(a, b *models.ReactionCount) bool {
+            if a.Count != b.Count {
+                return a.Count > b.Count
+            }
+            return byEmoji[a.Emoji].first.Before(byEmoji[b.Emoji].first)
+        })
+        counts[messageID] = list
+    }
+    return counts, nil
+}
+
+// SearchMessages matches content case-insensitively, newest first.
+func (s *Store) SearchMessages(ctx context.Context, query string, limit int) ([]*models.Message, error) {
+    return s.listMessages(func(m *models.Message) bool {
+        return containsFold(m.Content, query)
+    }, func(a, b *models.Message) bool { return a.CreatedAt.After(b.CreatedAt) }, limit), nil
+}
+
+func (s *Store) SearchMatchEvents(ctx context.Context, query string, limit int) ([]*models.MatchEvent, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    var events []*models.MatchEvent
+    for _, event := range s.matchEvents {
+        if containsFold(event.Description, query) {
+            events = append(events, clone(event))
+        }
+    }
+    sortBy(events, func(a, b *models.MatchEvent) bool { return a.CreatedAt.After(b.CreatedAt) })
+    return limited(events, limit), nil
+}
+
+func (s *Store) GetArchiveCandidates(ctx context.Context, before time.Time, limit int) ([]store.ArchiveCandidate, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    seen := make(map[store.ArchiveCandidate]bool)
+    var candidates []store.ArchiveCandidate
+    for _, message := range s.messages {
+        if message.ChatRoomID == "" || !message.CreatedAt.Before(before) {
+            continue
+        }
+        created := message.CreatedAt.UTC()
+        c := store.ArchiveCandidate{
+            RoomID: message.ChatRoomID,
+            Day:    time.Date(created.Year(), created.Month(), created.Day(), 0, 0, 0, 0, time.UTC),
+        }
+        if !seen[c] {
+            seen[c] = true
+            candidates = append(candidates, c)
+        }
+    }
+    sortBy(candidates, func(a, b store.ArchiveCandidate) bool {
+        if !a.Day.Equal(b.Day) {
+            return a.Day.Before(b.Day)
+        }
+        return a.RoomID < b.RoomID
+    })
+    return limited(candidates, limit), nil
+}
+
+func (s *Store) CreateMessageArchive(ctx context.Context, archive *models.MessageArchive, messageIDs []string) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    if _, exists := s.archives[archive.ObjectKey]; !exists {
+        stored := cloneArchive(archive)
+        stored.ID = newID()
+        stored.CreatedAt = now()
+        s.archives[archive.ObjectKey] = stored
+    }
+    for _, id := range messageIDs {
+        s.deleteMessage(id)
+    }
+    return nil
+}
+
+func (s *Store) ListMessageArchives(ctx context.Context, roomID string, before time.Time, limit int) ([]*models.MessageArchive, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    var archives []*models.MessageArchive
+    for _, archive := range s.archives {
+        if archive.RoomID == roomID && !archive.FirstAt.After(before) {
+            archives = append(archives, cloneArchive(archive))
+        }
+    }
+    sortBy(archives, func(a, b *models.MessageArchive) bool { return a.FirstAt.After(b.FirstAt) })
+    return limited(archives, limit), nil
+}
+
+func (s *Store) ListUserMessageArchives(ctx context.Context, userID, after string, limit int) ([]*models.MessageArchive, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    var archives []*models.MessageArchive
+    for _, archive := range s.archives {
+        if archive.ID > after && (archive.UserIDs == nil || hasSender(archive, userID)) {
+            archives = append(archives, cloneArchive(archive))
+        }
+    }
+    sortBy(archives, func(a, b *models.MessageArchive) bool { return a.ID < b.ID })
+    return limited(archives, limit), nil
+}
+
+func hasSender(archive *models.MessageArchive, userID string) bool {
+    for _, id := range archive.UserIDs {
+        if id == userID {
+            return true
+        }
+    }
+    return false
+}
+
+// UpdateMessageArchive rekeys the archive when its object moved.
+func (s *Store) UpdateMessageArchive(ctx context.Context, archive *models.MessageArchive) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    for key, stored := range s.archives {
+        if stored.ID != archive.ID {
+            continue
+        }
+        updated := cloneArchive(stored)
+        updated.ObjectKey = archive.ObjectKey
+        updated.Messages, updated.Size = archive.Messages, archive.Size
+        updated.FirstAt, updated.LastAt = archive.FirstAt, archive.LastAt
+        updated.UserIDs = append([]string{}, archive.UserIDs...)
+        delete(s.archives, key)
+        s.archives[updated.ObjectKey] = updated
+        return nil
+    }
+    return nil
+}
+
+func (s *Store) DeleteMessageArchive(ctx context.Context, id string) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    for key, archive := range s.archives {
+        if archive.ID == id {
+            delete(s.archives, key)
+        }
+    }
+    return nil
+}
+
+func cloneArchive(archive *models.MessageArchive) *models.MessageArchive {
+    a := clone(archive)
+    if archive.UserIDs != nil {
+        a.UserIDs = append([]string{}, archive.UserIDs...)
+    }
+    return a
+}
+
+func (s *Store) AppendJournalEntries(ctx context.Context, entries []*models.JournalEntry) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    for _, entry := range entries {
+        e := clone(entry)
+        s.journalSeq++
+        e.ID = s.journalSeq
+        if e.CreatedAt.IsZero() {
+            e.CreatedAt = now()
+        }
+        e.Frame = append(json.RawMessage(nil), entry.Frame...)
+        s.journal = append(s.journal, e)
+    }
+    return nil
+}
+
+// GetJournalEntries returns a room's frames from the window [from, to),
+// oldest first.
+func (s *Store) GetJournalEntries(ctx context.Context, roomID string, from, to time.Time, limit int) ([]*models.JournalEntry, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    var entries []*models.JournalEntry
+    for _, entry := range s.journal {
+        if entry.RoomID == roomID && !entry.CreatedAt.Before(from) && entry.CreatedAt.Before(to) {
+            entries = append(entries, clone(entry))
+        }
+    }
+    sortBy(entries, func(a, b *models.JournalEntry) bool {
+        if !a.CreatedAt.Equal(b.CreatedAt) {
+            return a.CreatedAt.Before(b.CreatedAt)
+        }
+        return a.ID < b.ID
+    })
+    return limited(entries, limit), nil
+}
+
+func (s *Store) PurgeJournalEntries(ctx context.Context, before time.Time) (int64, error) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    kept := s.journal[:0]
+    for _, entry := range s.journal {
+        if !entry.CreatedAt.Before(before) {
+            kept = append(kept, entry)
+        }
+    }
+    purged := int64(len(s.journal) - len(kept))
+    for i := len(kept); i < len(s.journal); i++ {
+        s.journal[i] = nil
+    }
+    s.journal = kept
+    return purged, nil
+}
+
+func (s *Store) UpsertDraft(ctx context.Context, draft *models.Draft) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    if draft.UpdatedAt.IsZero() {
+        draft.UpdatedAt = now()
+    }
+    s.drafts[membershipKey{scope: draft.RoomID, userID: draft.UserID}] = clone(draft)
+    return nil
+}
+
+func (s *Store) DeleteDraft(ctx context.Context, userID, roomID string) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    delete(s.drafts, membershipKey{scope: roomID, userID: userID})
+    return nil
+}
+
+func (s *Store) GetUserDrafts(ctx context.Context, userID string) ([]*models.Draft, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    var drafts []*models.Draft
+    for key, draft := range s.drafts {
+        if key.userID == userID {
+            drafts = append(drafts, clone(draft))
+        }
+    }
+    sortBy(drafts, func(a, b *models.Draft) bool { return a.UpdatedAt.After(b.UpdatedAt) })
+    return drafts, nil
+}
@@ -0,0 +1,345 @@
+package memory
+
+import (
+    "context"
+    "encoding/json"
+    "time"
+
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+func (s *Store) ListProfanityWords(ctx context.Context) ([]*models.ProfanityWord, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    var words []*models.ProfanityWord
+    for _, word := range s.profanityWords {
+        words = append(words, clone(word))
+    }
+    sortBy(words, func(a, b *models.ProfanityWord) bool {
+        if a.Locale != b.Locale {
+            return a.Locale < b.Locale
+        }
+        return a.Word < b.Word
+    })
+    return words, nil
+}
+
+func (s *Store) AddProfanityWord(ctx context.Context, word *models.ProfanityWord) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    s.profanityWords[profanityKey{locale: word.Locale, word: word.Word}] = clone(word)
+    return nil
+}
+
+func (s *Store) DeleteProfanityWord(ctx context.Context, locale, word string) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    delete(s.profanityWords, profanityKey{locale: locale, word: word})
+    return nil
+}
+
+func (s *Store) ListProfanityPolicies(ctx context.Context) ([]*models.ProfanityPolicy, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    var policies []*models.ProfanityPolicy
+    for _, policy := range s.profanityPolicies {
+        policies = append(policies, clone(policy))
+    }
+    sortBy(policies, func(a, b *models.ProfanityPolicy) bool { return a.Locale < b.Locale })
+    return policies, nil
+}
+
+func (s *Store) UpsertProfanityPolicy(ctx context.Context, policy *models.ProfanityPolicy) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    policy.UpdatedAt = now()
+    s.profanityPolicies[policy.Locale] = clone(policy)
+    return nil
+}
+
+func (s *Store) ListModerationFilters(ctx context.Context) ([]*models.ModerationFilter, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    var filters []*models.ModerationFilter
+    for _, filter := range s.filters {
+        filters = append(filters, cloneFilter(filter))
+    }
+    sortBy(filters, func(a, b *models.ModerationFilter) bool { return a.Name < b.Name })
+    return filters, nil
+}
+
+func (s *Store) UpsertModerationFilter(ctx context.Context, filter *models.ModerationFilter) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    filter.UpdatedAt = now()
+    stored := cloneFilter(filter)
+    if len(stored.Config) == 0 {
+        stored.Config = json.RawMessage("{}")
+    }
+    s.filters[filter.Name] = stored
+    return nil
+}
+
+func cloneFilter(filter *models.ModerationFilter) *models.ModerationFilter {
+    f := clone(filter)
+    f.Config = append(json.RawMessage(nil), filter.Config...)
+    return f
+}
+
+// CreateMessageFlag keeps the first flag of a message; a replayed persist
+// job flagging it again is a no-op.
+func (s *Store) CreateMessageFlag(ctx context.Context, flag *models.MessageFlag) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    for _, other := range s.flags {
+        if other.MessageID == flag.MessageID {
+            return nil
+        }
+    }
+    stored := clone(flag)
+    stored.ID = newID()
+    if stored.Status == "" {
+        stored.Status = models.FlagPending
+    }
+    if stored.CreatedAt.IsZero() {
+        stored.CreatedAt = now()
+    }
+    stored.ReviewedBy, stored.ReviewedAt = "", nil
+    s.flags[stored.ID] = stored
+    return nil
+}
+
+func (s *Store) GetMessageFlag(ctx context.Context, id string) (*models.MessageFlag, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    flag, ok := s.flags[id]
+    if !ok {
+        return nil, notFound("message flag")
+    }
+    return cloneFlag(flag), nil
+}
+
+// ListMessageFlags lists flags in a review state, or in any for an empty
+// status, newest first.
+func (s *Store) ListMessageFlags(ctx context.Context, status string, limit int) ([]*models.MessageFlag, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    var flags []*models.MessageFlag
+    for _, flag := range s.flags {
+        if status == "" || flag.Status == status {
+            flags = append(flags, cloneFlag(flag))
+        }
+    }
+    sortBy(flags, func(a, b *models.MessageFlag) bool { return a.CreatedAt.After(b.CreatedAt) })
+    return limited(flags, limit), nil
+}
+
+func (s *Store) ListRoomMessageFlags(ctx context.Context, roomID string, from, to time.Time) ([]*models.MessageFlag, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    var flags []*models.MessageFlag
+    for _, flag := range s.flags {
+        if flag.RoomID == roomID && !flag.CreatedAt.Before(from) && flag.CreatedAt.Before(to) {
+            flags = append(flags, cloneFlag(flag))
+        }
+    }
+    sortBy(flags, func(a, b *models.MessageFlag) bool { return a.CreatedAt.Before(b.CreatedAt) })
+    return flags, nil
+}
+
+func (s *Store) ReviewMessageFlag(ctx context.Context, id, status, reviewerID string) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    if flag, ok := s.flags[id]; ok {
+        reviewedAt := now()
+        flag.Status, flag.ReviewedBy, flag.ReviewedAt = status, reviewerID, &reviewedAt
+    }
+    return nil
+}
+
+func cloneFlag(flag *models.MessageFlag) *models.MessageFlag {
+    f := clone(flag)
+    f.ReviewedAt = cloneTime(flag.ReviewedAt)
+    return f
+}
+
+// RecordDeviceSighting refreshes the sighting's time when the user was
+// seen on the same device and address before.
+func (s *Store) RecordDeviceSighting(ctx context.Context, sighting *models.DeviceSighting) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    seenAt := sighting.SeenAt
+    if seenAt.IsZero() {
+        seenAt = now()
+    }
+    for _, other := range s.sightings {
+        if other.UserID == sighting.UserID && other.Fingerprint == sighting.Fingerprint && other.IP == sighting.IP {
+            other.SeenAt = seenAt
+            return nil
+        }
+    }
+    stored := clone(sighting)
+    stored.SeenAt = seenAt
+    s.sightings = append(s.sightings, stored)
+    return nil
+}
+
+// GetBannedUserSightings returns banned users' sightings on the
+// fingerprint or the address since the given time.
+func (s *Store) GetBannedUserSightings(ctx context.Context, fingerprint, ip string, since time.Time) ([]*models.DeviceSighting, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    var sightings []*models.DeviceSighting
+    for _, sighting := range s.sightings {
+        user, ok := s.users[sighting.UserID]
+        if !ok || user.BannedAt == nil || sighting.SeenAt.Before(since) {
+            continue
+        }
+        if (sighting.Fingerprint != "" && sighting.Fingerprint == fingerprint) || sighting.IP == ip {
+            sightings = append(sightings, clone(sighting))
+        }
+    }
+    return sightings, nil
+}
+
+func (s *Store) CreateEvasionSuspect(ctx context.Context, suspect *models.EvasionSuspect) (bool, error) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    for _, other := range s.evasionSuspects {
+        if other.UserID == suspect.UserID && other.BannedUserID == suspect.BannedUserID {
+            return false, nil
+        }
+    }
+    suspect.ID = newID()
+    if suspect.CreatedAt.IsZero() {
+        suspect.CreatedAt = now()
+    }
+    stored := cloneSuspect(suspect)
+    if stored.Status == "" {
+        stored.Status = models.SuspectPending
+    }
+    stored.ReviewedBy, stored.ReviewedAt = "", nil
+    s.evasionSuspects[stored.ID] = stored
+    return true, nil
+}
+
+func (s *Store) GetEvasionSuspect(ctx context.Context, id string) (*models.EvasionSuspect, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    suspect, ok := s.evasionSuspects[id]
+    if !ok {
+        return nil, notFound("evasion suspect")
+    }
+    return cloneSuspect(suspect), nil
+}
+
+func (s *Store) ListEvasionSuspects(ctx context.Context, status string, limit int) ([]*models.EvasionSuspect, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    var suspects []*models.EvasionSuspect
+    for _, suspect := range s.evasionSuspects {
+        if status == "" || suspect.Status == status {
+            suspects = append(suspects, cloneSuspect(suspect))
+        }
+    }
+    sortBy(suspects, func(a, b *models.EvasionSuspect) bool { return a.CreatedAt.After(b.CreatedAt) })
+    return limited(suspects, limit), nil
+}
+
+func (s *Store) ReviewEvasionSuspect(ctx context.Context, id, status, reviewerID string) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    if suspect, ok := s.evasionSuspects[id]; ok {
+        reviewedAt := now()
+        suspect.Status, suspect.ReviewedBy, suspect.ReviewedAt = status, reviewerID, &reviewedAt
+    }
+    return nil
+}
+
+// GetEvasionSignalStats counts reviewed suspects by each signal kind they
+// carried.
+func (s *Store) GetEvasionSignalStats(ctx context.Context) ([]*models.EvasionSignalStats, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    stats := make(map[string]*models.EvasionSignalStats)
+    var kinds []*models.EvasionSignalStats
+    for _, suspect := range s.evasionSuspects {
+        if suspect.Status == models.SuspectPending {
+            continue
+        }
+        seen := make(map[string]bool)
+        for _, signal := range suspect.Signals {
+            if seen[signal.Kind] {
+                continue
+            }
+            seen[signal.Kind] = true
+            st, ok := stats[signal.Kind]
+            if !ok {
+                st = &models.EvasionSignalStats{Kind: signal.Kind}
+                stats[signal.Kind] = st
+                kinds = append(kinds, st)
+            }
+            switch suspect.Status {
+            case models.SuspectConfirmed:
+                st.Confirmed++
+            case models.SuspectDismissed:
+                st.Dismissed++
+            }
+        }
+    }
+    return kinds, nil
+}
+
+func cloneSuspect(suspect *models.EvasionSuspect) *models.EvasionSuspect {
+    e := clone(suspect)
+    e.Signals = cloneAll(suspect.Signals)
+    e.ReviewedAt = cloneTime(suspect.ReviewedAt)
+    return e
+}
+
+func (s *Store) SetShadowBan(ctx context.Context, entry *models.ShadowBanEntry) (bool, error) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    user, ok := s.users[entry.UserID]
+    if !ok || user.ShadowBanned == entry.Banned {
+        return false, nil
+    }
+    user.ShadowBanned = entry.Banned
+    entry.ID, entry.CreatedAt = newID(), now()
+    s.shadowBans = append(s.shadowBans, clone(entry))
+    return true, nil
+}
+
+func (s *Store) GetShadowBanEntries(ctx context.Context, userID string) ([]*models.ShadowBanEntry, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    var entries []*models.ShadowBanEntry
+    for _, entry := range s.shadowBans {
+        if entry.UserID == userID {
+            entries = append(entries, clone(entry))
+        }
+    }
+    sortBy(entries, func(a, b *models.ShadowBanEntry) bool { return a.CreatedAt.After(b.CreatedAt) })
+    return entries, nil
+}
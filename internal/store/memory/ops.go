@@ -0,0 +1,255 @@
+package memory
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+    "time"
+
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/store"
+)
+
+func (s *Store) CreateDeadLetter(ctx context.Context, letter *models.DeadLetter) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    letter.ID = newID()
+    if letter.CreatedAt.IsZero() {
+        letter.CreatedAt = now()
+    }
+    stored := cloneDeadLetter(letter)
+    stored.ReplayCount, stored.ReplayedAt = 0, nil
+    s.deadLetters[stored.ID] = stored
+    return nil
+}
+
+func (s *Store) GetDeadLetter(ctx context.Context, id string) (*models.DeadLetter, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    letter, ok := s.deadLetters[id]
+    if !ok {
+        return nil, notFound("dead letter")
+    }
+    return cloneDeadLetter(letter), nil
+}
+
+func (s *Store) ListDeadLetters(ctx context.Context, pendingOnly bool, limit int) ([]*models.DeadLetter, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    var letters []*models.DeadLetter
+    for _, letter := range s.deadLetters {
+        if !pendingOnly || letter.ReplayedAt == nil {
+            letters = append(letters, cloneDeadLetter(letter))
+        }
+    }
+    sortBy(letters, func(a, b *models.DeadLetter) bool { return a.CreatedAt.After(b.CreatedAt) })
+    return limited(letters, limit), nil
+}
+
+func (s *Store) MarkDeadLetterReplayed(ctx context.Context, id string, at time.Time) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    letter, ok := s.deadLetters[id]
+    if !ok {
+        return fmt.Errorf("dead letter not found")
+    }
+    letter.ReplayedAt = &at
+    letter.ReplayCount++
+    return nil
+}
+
+func (s *Store) CountPendingDeadLetters(ctx context.Context) (int, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    count := 0
+    for _, letter := range s.deadLetters {
+        if letter.ReplayedAt == nil {
+            count++
+        }
+    }
+    return count, nil
+}
+
+func cloneDeadLetter(letter *models.DeadLetter) *models.DeadLetter {
+    d := clone(letter)
+    d.Payload = append(json.RawMessage(nil), letter.Payload...)
+    d.ReplayedAt = cloneTime(letter.ReplayedAt)
+    return d
+}
+
+func (s *Store) CreateReconciliationReport(ctx context.Context, report *models.ReconciliationReport) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    report.ID = newID()
+    stored := clone(report)
+    stored.Conflicts = cloneAll(report.Conflicts)
+    s.reconciliations[stored.ID] = stored
+    return nil
+}
+
+func (s *Store) ListReconciliationReports(ctx context.Context, limit int) ([]*models.ReconciliationReport, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    var reports []*models.ReconciliationReport
+    for _, report := range s.reconciliations {
+        r := clone(report)
+        r.Conflicts = cloneAll(report.Conflicts)
+        reports = append(reports, r)
+    }
+    sortBy(reports, func(a, b *models.ReconciliationReport) bool { return a.StartedAt.After(b.StartedAt) })
+    return limited(reports, limit), nil
+}
+
+func (s *Store) GetRoomStatistics(ctx context.Context, roomID string) (*store.RoomStatistics, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+    return s.roomStatistics(roomID), nil
+}
+
+// roomStatistics counts a room's statistics. Must be called with s.mu
+// held.
+func (s *Store) roomStatistics(roomID string) *store.RoomStatistics {
+    stats := &store.RoomStatistics{}
+    for _, message := range s.messages {
+        if message.ChatRoomID != roomID {
+            continue
+        }
+        stats.MessageCount++
+        if message.CreatedAt.After(stats.LastActivity) {
+            stats.LastActivity = message.CreatedAt
+        }
+    }
+    for key := range s.members {
+        if key.scope == roomID {
+            stats.UserCount++
+        }
+    }
+    return stats
+}
+
+// GetUserStatistics lists up to three favorite rooms, the ones the user
+// has posted in most.
+func (s *Store) GetUserStatistics(ctx context.Context, userID string) (*store.UserStatistics, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    stats := &store.UserStatistics{}
+    posted := make(map[string]int)
+    for _, message := range s.messages {
+        if message.UserID != userID {
+            continue
+        }
+        stats.MessageCount++
+        if message.CreatedAt.After(stats.LastActive) {
+            stats.LastActive = message.CreatedAt
+        }
+        if _, ok := s.rooms[message.ChatRoomID]; ok {
+            posted[message.ChatRoomID]++
+        }
+    }
+    for key := range s.members {
+        if key.userID == userID {
+            stats.RoomsJoined++
+        }
+    }
+
+    var rooms []*models.ChatRoom
+    for id := range posted {
+        rooms = append(rooms, s.rooms[id])
+    }
+    sortBy(rooms, func(a, b *models.ChatRoom) bool {
+        if posted[a.ID] != posted[b.ID] {
+            return posted[a.ID] > posted[b.ID]
+        }
+        return a.Name < b.Name
+    })
+    stats.FavoriteRooms = []string{}
+    for _, room := range limited(rooms, 3) {
+        stats.FavoriteRooms = append(stats.FavoriteRooms, room.Name)
+    }
+    return stats, nil
+}
+
+// GetMatchStatistics counts viewers as members of any of the match's
+// rooms. Peak viewers are not tracked historically, so the peak is the
+// current count.
+func (s *Store) GetMatchStatistics(ctx context.Context, matchID string) (*store.MatchStatistics, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+    return s.matchStatistics(matchID), nil
+}
+
+// matchStatistics counts a match's statistics. Must be called with s.mu
+// held.
+func (s *Store) matchStatistics(matchID string) *store.MatchStatistics {
+    stats := &store.MatchStatistics{}
+    viewers := make(map[string]bool)
+    for key := range s.members {
+        if room, ok := s.rooms[key.scope]; ok && room.MatchID == matchID {
+            viewers[key.userID] = true
+        }
+    }
+    stats.ViewerCount = len(viewers)
+    for _, message := range s.messages {
+        if room, ok := s.rooms[message.ChatRoomID]; ok && room.MatchID == matchID {
+            stats.MessageCount++
+        }
+    }
+    for _, event := range s.matchEvents {
+        if event.MatchID == matchID {
+            stats.EventCount++
+        }
+    }
+    stats.PeakViewerCount = stats.ViewerCount
+    return stats
+}
+
+func (s *Store) RefreshStatisticsRollups(ctx context.Context, since time.Time, full bool) (int, int, error) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    computedAt := now()
+    var rooms, matches int
+    for id, room := range s.rooms {
+        if _, ok := s.roomRollups[id]; ok && !full && !room.IsActive {
+            continue
+        }
+        stats := s.roomStatistics(id)
+        stats.ComputedAt = computedAt
+        s.roomRollups[id] = stats
+        rooms++
+    }
+    for id, match := range s.matches {
+        previous, ok := s.matchRollups[id]
+        if ok && !full && !match.StartTime.After(since) {
+            continue
+        }
+        stats := s.matchStatistics(id)
+        if ok && previous.PeakViewerCount > stats.PeakViewerCount {
+            stats.PeakViewerCount = previous.PeakViewerCount
+        }
+        stats.ComputedAt = computedAt
+        s.matchRollups[id] = stats
+        matches++
+    }
+    return rooms, matches, nil
+}
+
+func (s *Store) GetRoomStatisticsRollup(ctx context.Context, roomID string) (*store.RoomStatistics, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+    return clone(s.roomRollups[roomID]), nil
+}
+
+func (s *Store) GetMatchStatisticsRollup(ctx context.Context, matchID string) (*store.MatchStatistics, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+    return clone(s.matchRollups[matchID]), nil
+}
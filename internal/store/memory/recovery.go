@@ -0,0 +1,135 @@
+package memory
+
+import (
+    "context"
+    "fmt"
+    "time"
+
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+func (s *Store) ReplaceRecoveryCodes(ctx context.Context, userID string, codes []*models.RecoveryCode) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    if _, ok := s.users[userID]; !ok {
+        return fmt.Errorf("failed to replace recovery codes: user %s not found", userID)
+    }
+    for id, code := range s.recoveryCodes {
+        if code.UserID == userID {
+            delete(s.recoveryCodes, id)
+        }
+    }
+    at := now()
+    for _, code := range codes {
+        code.ID, code.UserID, code.CreatedAt, code.UsedAt = newID(), userID, at, nil
+        s.recoveryCodes[code.ID] = clone(code)
+    }
+    return nil
+}
+
+func (s *Store) GetUnusedRecoveryCodes(ctx context.Context, userID string) ([]*models.RecoveryCode, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    var codes []*models.RecoveryCode
+    for _, code := range s.recoveryCodes {
+        if code.UserID == userID && code.UsedAt == nil {
+            codes = append(codes, clone(code))
+        }
+    }
+    sortBy(codes, func(a, b *models.RecoveryCode) bool { return a.ID < b.ID })
+    return codes, nil
+}
+
+func (s *Store) UseRecoveryCode(ctx context.Context, id string, at time.Time) (bool, error) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    code, ok := s.recoveryCodes[id]
+    if !ok || code.UsedAt != nil {
+        return false, nil
+    }
+    code.UsedAt = &at
+    return true, nil
+}
+
+func (s *Store) SetRecoveryEmail(ctx context.Context, userID, email string, verifiedAt time.Time) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    if _, ok := s.users[userID]; !ok {
+        return fmt.Errorf("failed to set recovery email: user %s not found", userID)
+    }
+    if email == "" {
+        delete(s.recoveryEmails, userID)
+    } else {
+        s.recoveryEmails[userID] = email
+    }
+    return nil
+}
+
+func (s *Store) GetRecoveryEmail(ctx context.Context, userID string) (string, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+    return s.recoveryEmails[userID], nil
+}
+
+func (s *Store) CreateRecoveryToken(ctx context.Context, token *models.RecoveryToken) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    if _, ok := s.users[token.UserID]; !ok {
+        return fmt.Errorf("failed to create recovery token: user %s not found", token.UserID)
+    }
+    token.ID = newID()
+    token.CreatedAt = now()
+    s.recoveryTokens[token.ID] = cloneRecoveryToken(token)
+    return nil
+}
+
+func (s *Store) GetRecoveryToken(ctx context.Context, tokenHash string) (*models.RecoveryToken, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    for _, token := range s.recoveryTokens {
+        if token.TokenHash == tokenHash {
+            return cloneRecoveryToken(token), nil
+        }
+    }
+    return nil, notFound("recovery token")
+}
+
+func (s *Store) UseRecoveryToken(ctx context.Context, id string, at time.Time) (bool, error) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    token, ok := s.recoveryTokens[id]
+    if !ok || token.UsedAt != nil {
+        return false, nil
+    }
+    token.UsedAt = &at
+    return true, nil
+}
+
+// deleteRecovery drops a user's backup codes, recovery email and tokens.
+// Must be called with s.mu held.
+func (s *Store) deleteRecovery(userID string) {
+    for id, code := range s.recoveryCodes {
+        if code.UserID == userID {
+            delete(s.recoveryCodes, id)
+        }
+    }
+    delete(s.recoveryEmails, userID)
+    for id, token := range s.recoveryTokens {
+        if token.UserID == userID {
+            delete(s.recoveryTokens, id)
+        }
+    }
+}
+
+func cloneRecoveryToken(token *models.RecoveryToken) *models.RecoveryToken {
+    t := clone(token)
+    t.UsedAt = cloneTime(token.UsedAt)
+    return t
+}
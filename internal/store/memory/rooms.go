@@ -0,0 +1,619 @@
+package memory
+
+import (
+    "context"
+    "fmt"
+    "time"
+
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+func (s *Store) CreateChatRoom(ctx context.Context, room *models.ChatRoom) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    if room.ID == "" {
+        room.ID = newID()
+    }
+    if _, exists := s.rooms[room.ID]; exists {
+        return fmt.Errorf("failed to create chat room: %q exists", room.ID)
+    }
+    if room.State == "" {
+        room.State = models.RoomStateOpen
+    }
+    room.CreatedAt = now()
+    room.UpdatedAt = room.CreatedAt
+    room.StateChangedAt = room.CreatedAt
+    s.rooms[room.ID] = storedRoom(room)
+    return nil
+}
+
+func (s *Store) GetChatRoom(ctx context.Context, id string) (*models.ChatRoom, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    room, ok := s.rooms[id]
+    if !ok {
+        return nil, notFound("chat room")
+    }
+    return clone(room), nil
+}
+
+// GetMatchChatRoom returns the match's own room, not any of its shards.
+func (s *Store) GetMatchChatRoom(ctx context.Context, matchID string) (*models.ChatRoom, error) {
+    rooms := s.listRooms(func(r *models.ChatRoom) bool {
+        return matchID != "" && r.MatchID == matchID && r.ParentID == ""
+    }, createdFirst)
+    if len(rooms) == 0 {
+        return nil, notFound("chat room")
+    }
+    return rooms[0], nil
+}
+
+func (s *Store) ListChatRooms(ctx context.Context) ([]*models.ChatRoom, error) {
+    return s.listRooms(func(*models.ChatRoom) bool { return true }, createdFirst), nil
+}
+
+func (s *Store) GetChatRoomsByState(ctx context.Context, state string) ([]*models.ChatRoom, error) {
+    return s.listRooms(func(r *models.ChatRoom) bool { return r.State == state }, func(a, b *models.ChatRoom) bool {
+        return a.StateChangedAt.Before(b.StateChangedAt)
+    }), nil
+}
+
+func createdFirst(a, b *models.ChatRoom) bool { return a.CreatedAt.Before(b.CreatedAt) }
+
+func (s *Store) listRooms(keep func(*models.ChatRoom) bool, less func(a, b *models.ChatRoom) bool) []*models.ChatRoom {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    var rooms []*models.ChatRoom
+    for _, room := range s.rooms {
+        if keep(room) {
+            rooms = append(rooms, clone(room))
+        }
+    }
+    sortBy(rooms, less)
+    return rooms
+}
+
+// UpdateChatRoom saves the room's settings. Its state only changes
+// through TransitionChatRoom.
+func (s *Store) UpdateChatRoom(ctx context.Context, room *models.ChatRoom) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    stored, ok := s.rooms[room.ID]
+    if !ok {
+        return fmt.Errorf("failed to update chat room: %w", notFound("chat room"))
+    }
+    updated := storedRoom(room)
+    updated.State = stored.State
+    updated.StateChangedAt = stored.StateChangedAt
+    updated.PinnedMessageID = stored.PinnedMessageID
+    updated.CreatedAt = stored.CreatedAt
+    updated.UpdatedAt = now()
+    s.rooms[room.ID] = updated
+    room.UpdatedAt = updated.UpdatedAt
+    return nil
+}
+
+func (s *Store) DeleteChatRoom(ctx context.Context, id string) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    s.deleteRoom(id)
+    return nil
+}
+
+// deleteRoom removes a room and what belongs to it, as the schema's
+// cascades do.
+func (s *Store) deleteRoom(id string) {
+    delete(s.rooms, id)
+    for key := range s.members {
+        if key.scope == id {
+            delete(s.members, key)
+        }
+    }
+    for key := range s.roles {
+        if key.scope == id {
+            delete(s.roles, key)
+        }
+    }
+    for key := range s.voice {
+        if key.scope == id {
+            delete(s.voice, key)
+        }
+    }
+    for key := range s.sanctions {
+        if key.roomID == id {
+            delete(s.sanctions, key)
+        }
+    }
+    for key := range s.drafts {
+        if key.scope == id {
+            delete(s.drafts, key)
+        }
+    }
+    for _, topic := range s.topics {
+        if topic.RoomID == id {
+            delete(s.topics, topic.ID)
+        }
+    }
+    for _, message := range s.messages {
+        if message.ChatRoomID == id {
+            s.deleteMessage(message.ID)
+        }
+    }
+}
+
+// MergeChatRooms moves the source room's history and members into the
+// target and deactivates the source.
+func (s *Store) MergeChatRooms(ctx context.Context, sourceID, targetID string) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    for _, message := range s.messages {
+        if message.ChatRoomID == sourceID {
+            message.ChatRoomID = targetID
+        }
+    }
+    s.moveMembers(sourceID, targetID, nil)
+    if room, ok := s.rooms[sourceID]; ok {
+        room.IsActive = false
+    }
+    return nil
+}
+
+func (s *Store) MoveRoomMembers(ctx context.Context, fromRoomID, toRoomID string, userIDs []string) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    s.moveMembers(fromRoomID, toRoomID, userIDs)
+    return nil
+}
+
+// moveMembers moves the given members, or all of them for nil userIDs,
+// keeping their read markers.
+func (s *Store) moveMembers(fromRoomID, toRoomID string, userIDs []string) {
+    moving := make(map[string]bool, len(userIDs))
+    for _, id := range userIDs {
+        moving[id] = true
+    }
+    for key, member := range s.members {
+        if key.scope != fromRoomID || (userIDs != nil && !moving[key.userID]) {
+            continue
+        }
+        target := membershipKey{scope: toRoomID, userID: key.userID}
+        if _, exists := s.members[target]; !exists {
+            s.members[target] = &membership{joinedAt: member.joinedAt, lastReadAt: member.lastReadAt}
+        }
+        delete(s.members, key)
+    }
+}
+
+func (s *Store) TransitionChatRoom(ctx context.Context, id, from, to string, at time.Time) (bool, error) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    room, ok := s.rooms[id]
+    if !ok || room.State != from {
+        return false, nil
+    }
+    room.State = to
+    room.StateChangedAt = at
+    if to == models.RoomStateArchived {
+        room.IsActive = false
+    }
+    return true, nil
+}
+
+// storedRoom copies a room without its joined fields.
+func storedRoom(room *models.ChatRoom) *models.ChatRoom {
+    r := clone(room)
+    r.Match, r.UserCount, r.Topics = nil, 0, nil
+    return r
+}
+
+func (s *Store) CreateRoomTopic(ctx context.Context, topic *models.RoomTopic) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    for _, other := range s.topics {
+        if other.RoomID == topic.RoomID && other.Slug == topic.Slug && other.CollapsedAt == nil {
+            return fmt.Errorf("failed to create room topic: %q exists", topic.Slug)
+        }
+    }
+    topic.ID = newID()
+    if topic.CreatedAt.IsZero() {
+        topic.CreatedAt = now()
+    }
+    s.topics[topic.ID] = clone(topic)
+    return nil
+}
+
+func (s *Store) GetRoomTopics(ctx context.Context, roomID string) ([]*models.RoomTopic, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    var topics []*models.RoomTopic
+    for _, topic := range s.topics {
+        if topic.RoomID == roomID {
+            topics = append(topics, clone(topic))
+        }
+    }
+    sortBy(topics, func(a, b *models.RoomTopic) bool {
+        if a.Position != b.Position {
+            return a.Position < b.Position
+        }
+        return a.CreatedAt.Before(b.CreatedAt)
+    })
+    return topics, nil
+}
+
+func (s *Store) CollapseRoomTopic(ctx context.Context, id string, at time.Time) (bool, error) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    topic, ok := s.topics[id]
+    if !ok || topic.CollapsedAt != nil {
+        return false, nil
+    }
+    collapsedAt := at
+    topic.CollapsedAt = &collapsedAt
+    return true, nil
+}
+
+func (s *Store) CollapseRoomTopics(ctx context.Context, roomID string, at time.Time) (int, error) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    collapsed := 0
+    for _, topic := range s.topics {
+        if topic.RoomID == roomID && topic.CollapsedAt == nil {
+            collapsedAt := at
+            topic.CollapsedAt = &collapsedAt
+            collapsed++
+        }
+    }
+    return collapsed, nil
+}
+
+func (s *Store) JoinChatRoom(ctx context.Context, userID, roomID string) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    if _, ok := s.users[userID]; !ok {
+        return fmt.Errorf("failed to join chat room: %w", notFound("user"))
+    }
+    if _, ok := s.rooms[roomID]; !ok {
+        return fmt.Errorf("failed to join chat room: %w", notFound("chat room"))
+    }
+    key := membershipKey{scope: roomID, userID: userID}
+    if _, joined := s.members[key]; !joined {
+        at := now()
+        s.members[key] = &membership{joinedAt: at, lastReadAt: at}
+    }
+    return nil
+}
+
+func (s *Store) LeaveChatRoom(ctx context.Context, userID, roomID string) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    delete(s.members, membershipKey{scope: roomID, userID: userID})
+    return nil
+}
+
+func (s *Store) GetRoomUsers(ctx context.Context, roomID string) ([]*models.User, error) {
+    users := s.roomMembers(roomID, func(*models.User) bool { return true })
+    sortBy(users, func(a, b *models.User) bool { return a.Username < b.Username })
+    return users, nil
+}
+
+func (s *Store) GetRoomMembersByUsername(ctx context.Context, roomID string, usernames []string) ([]*models.User, error) {
+    if len(usernames) == 0 {
+        return nil, nil
+    }
+    return s.roomMembers(roomID, func(u *models.User) bool {
+        for _, name := range usernames {
+            if equalFold(u.Username, name) {
+                return true
+            }
+        }
+        return false
+    }), nil
+}
+
+func (s *Store) roomMembers(roomID string, keep func(*models.User) bool) []*models.User {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    var users []*models.User
+    for key := range s.members {
+        if key.scope != roomID {
+            continue
+        }
+        if user, ok := s.users[key.userID]; ok && keep(user) {
+            users = append(users, clone(user))
+        }
+    }
+    return users
+}
+
+func (s *Store) GetUserRooms(ctx context.Context, userID string) ([]*models.ChatRoom, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    return s.userRooms(userID), nil
+}
+
+// userRooms lists the rooms a user joined, oldest room first. The caller
+// holds the lock.
+func (s *Store) userRooms(userID string) []*models.ChatRoom {
+    var rooms []*models.ChatRoom
+    for key := range s.members {
+        if key.userID != userID {
+            continue
+        }
+        if room, ok := s.rooms[key.scope]; ok {
+            rooms = append(rooms, clone(room))
+        }
+    }
+    sortBy(rooms, createdFirst)
+    return rooms
+}
+
+// unreadCount counts other users' messages in a room since a member's
+// read marker. The caller holds the lock.
+func (s *Store) unreadCount(roomID, userID string, lastReadAt time.Time) int {
+    count := 0
+    for _, message := range s.messages {
+        if message.ChatRoomID == roomID && message.CreatedAt.After(lastReadAt) && message.UserID != userID {
+            count++
+        }
+    }
+    return count
+}
+
+func (s *Store) GetUserRoomSummaries(ctx context.Context, userID string) ([]*models.RoomSummary, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    var summaries []*models.RoomSummary
+    for _, room := range s.userRooms(userID) {
+        member := s.members[membershipKey{scope: room.ID, userID: userID}]
+        summary := &models.RoomSummary{
+            Room:        room,
+            LastReadAt:  member.lastReadAt,
+            UnreadCount: s.unreadCount(room.ID, userID, member.lastReadAt),
+        }
+        if last := s.lastMessage(room.ID); last != nil {
+            summary.LastMessage = s.joinMessage(last)
+        }
+        if match, ok := s.matches[room.MatchID]; ok {
+            summary.Match = s.joinMatch(match)
+        }
+        summaries = append(summaries, summary)
+    }
+    return summaries, nil
+}
+
+func (s *Store) MarkRoomRead(ctx context.Context, userID, roomID string, at time.Time) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    if member, ok := s.members[membershipKey{scope: roomID, userID: userID}]; ok && at.After(member.lastReadAt) {
+        member.lastReadAt = at
+    }
+    return nil
+}
+
+func (s *Store) GetUnreadCounts(ctx context.Context, userID string) ([]*models.UnreadCount, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    var counts []*models.UnreadCount
+    for key, member := range s.members {
+        if key.userID != userID {
+            continue
+        }
+        if _, ok := s.rooms[key.scope]; !ok {
+            continue
+        }
+        counts = append(counts, &models.UnreadCount{
+            RoomID:      key.scope,
+            UnreadCount: s.unreadCount(key.scope, userID, member.lastReadAt),
+            LastReadAt:  member.lastReadAt,
+        })
+    }
+    return counts, nil
+}
+
+func (s *Store) CreateRoomSanction(ctx context.Context, sanction *models.RoomSanction) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    if sanction.CreatedAt.IsZero() {
+        sanction.CreatedAt = now()
+    }
+    stored := clone(sanction)
+    stored.ExpiresAt = cloneTime(sanction.ExpiresAt)
+    s.sanctions[sanctionKey{roomID: sanction.RoomID, userID: sanction.UserID, kind: sanction.Kind}] = stored
+    return nil
+}
+
+func (s *Store) DeleteRoomSanction(ctx context.Context, roomID, userID, kind string) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    delete(s.sanctions, sanctionKey{roomID: roomID, userID: userID, kind: kind})
+    return nil
+}
+
+func (s *Store) GetRoomSanctions(ctx context.Context, roomID, userID string) ([]*models.RoomSanction, error) {
+    current := time.Now()
+    return s.listSanctions(func(r *models.RoomSanction) bool {
+        return r.RoomID == roomID && r.UserID == userID && r.Active(current)
+    }), nil
+}
+
+func (s *Store) ListRoomSanctionsBetween(ctx context.Context, roomID string, from, to time.Time) ([]*models.RoomSanction, error) {
+    return s.listSanctions(func(r *models.RoomSanction) bool {
+        return r.RoomID == roomID && !r.CreatedAt.Before(from) && r.CreatedAt.Before(to)
+    }), nil
+}
+
+func (s *Store) listSanctions(keep func(*models.RoomSanction) bool) []*models.RoomSanction {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    var sanctions []*models.RoomSanction
+    for _, sanction := range s.sanctions {
+        if keep(sanction) {
+            c := clone(sanction)
+            c.ExpiresAt = cloneTime(sanction.ExpiresAt)
+            sanctions = append(sanctions, c)
+        }
+    }
+    sortBy(sanctions, func(a, b *models.RoomSanction) bool { return a.CreatedAt.Before(b.CreatedAt) })
+    return sanctions
+}
+
+func (s *Store) SetPinnedMessage(ctx context.Context, roomID, messageID string) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    if room, ok := s.rooms[roomID]; ok {
+        room.PinnedMessageID = messageID
+    }
+    return nil
+}
+
+// GetRoomRole prefers a granted role over watch party ownership, so an
+// owner can be demoted without clearing the room's owner.
+func (s *Store) GetRoomRole(ctx context.Context, roomID, userID string) (string, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    if role, ok := s.roles[membershipKey{scope: roomID, userID: userID}]; ok {
+        return role.Role, nil
+    }
+    if room, ok := s.rooms[roomID]; ok && room.OwnerID != "" && room.OwnerID == userID {
+        return models.RoomRoleOwner, nil
+    }
+    return models.RoomRoleMember, nil
+}
+
+func (s *Store) ListRoomRoles(ctx context.Context, roomID string) ([]*models.RoomRole, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    var roles []*models.RoomRole
+    for key, role := range s.roles {
+        if key.scope != roomID {
+            continue
+        }
+        user, ok := s.users[key.userID]
+        if !ok {
+            continue
+        }
+        r := clone(role)
+        r.User = user.Summary()
+        roles = append(roles, r)
+    }
+    sortBy(roles, func(a, b *models.RoomRole) bool { return a.CreatedAt.Before(b.CreatedAt) })
+    return roles, nil
+}
+
+func (s *Store) SetRoomRole(ctx context.Context, role *models.RoomRole) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    role.CreatedAt = now()
+    stored := clone(role)
+    stored.User = nil
+    s.roles[membershipKey{scope: role.RoomID, userID: role.UserID}] = stored
+    return nil
+}
+
+func (s *Store) DeleteRoomRole(ctx context.Context, roomID, userID string) (bool, error) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    key := membershipKey{scope: roomID, userID: userID}
+    if _, ok := s.roles[key]; !ok {
+        return false, nil
+    }
+    delete(s.roles, key)
+    return true, nil
+}
+
+func (s *Store) JoinVoiceSession(ctx context.Context, participant *models.VoiceParticipant) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    if participant.JoinedAt.IsZero() {
+        participant.JoinedAt = now()
+    }
+    stored := clone(participant)
+    stored.User = nil
+    s.voice[membershipKey{scope: participant.RoomID, userID: participant.UserID}] = stored
+    return nil
+}
+
+func (s *Store) LeaveVoiceSession(ctx context.Context, roomID, userID string) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    delete(s.voice, membershipKey{scope: roomID, userID: userID})
+    return nil
+}
+
+func (s *Store) GetVoiceParticipant(ctx context.Context, roomID, userID string) (*models.VoiceParticipant, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    participant, ok := s.voice[membershipKey{scope: roomID, userID: userID}]
+    if !ok {
+        return nil, notFound("voice participant")
+    }
+    user, ok := s.users[userID]
+    if !ok {
+        return nil, notFound("voice participant")
+    }
+    p := clone(participant)
+    p.User = user.Summary()
+    return p, nil
+}
+
+func (s *Store) GetVoiceParticipants(ctx context.Context, roomID string) ([]*models.VoiceParticipant, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    var participants []*models.VoiceParticipant
+    for key, participant := range s.voice {
+        if key.scope != roomID {
+            continue
+        }
+        user, ok := s.users[key.userID]
+        if !ok {
+            continue
+        }
+        p := clone(participant)
+        p.User = user.Summary()
+        participants = append(participants, p)
+    }
+    sortBy(participants, func(a, b *models.VoiceParticipant) bool { return a.JoinedAt.Before(b.JoinedAt) })
+    return participants, nil
+}
+
+func (s *Store) UpdateVoiceParticipant(ctx context.Context, participant *models.VoiceParticipant) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    if stored, ok := s.voice[membershipKey{scope: participant.RoomID, userID: participant.UserID}]; ok {
+        stored.Role = participant.Role
+        stored.HandRaised = participant.HandRaised
+    }
+    return nil
+}
@@ -0,0 +1,192 @@
+package memory
+
+import (
+    "context"
+    "time"
+
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+func (s *Store) CreateSeason(ctx context.Context, season *models.Season) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    season.ID = newID()
+    if season.CreatedAt.IsZero() {
+        season.CreatedAt = now()
+    }
+    stored := cloneSeason(season)
+    stored.ArchivedAt = nil
+    s.seasons[stored.ID] = stored
+    return nil
+}
+
+func (s *Store) GetSeason(ctx context.Context, id string) (*models.Season, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    season, ok := s.seasons[id]
+    if !ok {
+        return nil, notFound("season")
+    }
+    return cloneSeason(season), nil
+}
+
+// GetSeasonAt returns the competition's season running at the given time,
+// or the site-wide one for an empty competitionID.
+func (s *Store) GetSeasonAt(ctx context.Context, competitionID string, at time.Time) (*models.Season, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    var running *models.Season
+    for _, season := range s.seasons {
+        if season.CompetitionID != competitionID || season.StartsAt.After(at) || !season.EndsAt.After(at) {
+            continue
+        }
+        if running == nil || season.StartsAt.After(running.StartsAt) {
+            running = season
+        }
+    }
+    if running == nil {
+        return nil, notFound("season")
+    }
+    return cloneSeason(running), nil
+}
+
+// ListSeasons lists seasons newest first, of one competition or of all
+// for an empty competitionID.
+func (s *Store) ListSeasons(ctx context.Context, competitionID string) ([]*models.Season, error) {
+    return s.listSeasons(func(season *models.Season) bool {
+        return competitionID == "" || season.CompetitionID == competitionID
+    }, func(a, b *models.Season) bool { return a.StartsAt.After(b.StartsAt) }), nil
+}
+
+// GetDueSeasons returns the seasons that have ended but are not archived.
+func (s *Store) GetDueSeasons(ctx context.Context, now time.Time) ([]*models.Season, error) {
+    return s.listSeasons(func(season *models.Season) bool {
+        return season.ArchivedAt == nil && !season.EndsAt.After(now)
+    }, func(a, b *models.Season) bool { return a.EndsAt.Before(b.EndsAt) }), nil
+}
+
+func (s *Store) listSeasons(keep func(*models.Season) bool, less func(a, b *models.Season) bool) []*models.Season {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    var seasons []*models.Season
+    for _, season := range s.seasons {
+        if keep(season) {
+            seasons = append(seasons, cloneSeason(season))
+        }
+    }
+    sortBy(seasons, less)
+    return seasons
+}
+
+func (s *Store) GetSeasonStandings(ctx context.Context, season *models.Season, limit int) ([]*models.SeasonStanding, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    var table []*models.SeasonStanding
+    if season.ArchivedAt != nil {
+        table = cloneAll(s.standings[season.ID])
+    } else {
+        table = s.seasonTable(season)
+    }
+
+    var standings []*models.SeasonStanding
+    for _, st := range table {
+        if user, ok := s.users[st.UserID]; ok {
+            st.Username = user.Username
+            standings = append(standings, st)
+        }
+    }
+    sortBy(standings, func(a, b *models.SeasonStanding) bool {
+        if a.Rank != b.Rank {
+            return a.Rank < b.Rank
+        }
+        return a.Username < b.Username
+    })
+    return limited(standings, limit), nil
+}
+
+// ArchiveSeason marks the season archived and freezes its table, so
+// callers racing to archive it snapshot it once. Badges go to the top ten
+// ranks with points: the champion, the rest of the podium and the top ten.
+func (s *Store) ArchiveSeason(ctx context.Context, id string, at time.Time) (bool, error) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    season, ok := s.seasons[id]
+    if !ok || season.ArchivedAt != nil {
+        return false, nil
+    }
+    season.ArchivedAt = &at
+
+    table := s.seasonTable(season)
+    for _, st := range table {
+        switch {
+        case st.Points == 0:
+        case st.Rank == 1:
+            st.Badge = models.SeasonBadgeChampion
+        case st.Rank <= 3:
+            st.Badge = models.SeasonBadgePodium
+        case st.Rank <= 10:
+            st.Badge = models.SeasonBadgeTopTen
+        }
+    }
+    s.standings[id] = table
+    return true, nil
+}
+
+// seasonTable ranks users by the points of their scored predictions on
+// the season's matches, ties on points broken by correct predictions and
+// sharing a rank otherwise. The caller holds the lock.
+func (s *Store) seasonTable(season *models.Season) []*models.SeasonStanding {
+    byUser := make(map[string]*models.SeasonStanding)
+    var table []*models.SeasonStanding
+    for key, prediction := range s.predictions {
+        if prediction.ScoredAt == nil {
+            continue
+        }
+        match, ok := s.matches[key.scope]
+        if !ok || match.StartTime.Before(season.StartsAt) || !match.StartTime.Before(season.EndsAt) {
+            continue
+        }
+        if season.CompetitionID != "" && match.CompetitionID != season.CompetitionID {
+            continue
+        }
+        st, ok := byUser[key.userID]
+        if !ok {
+            st = &models.SeasonStanding{SeasonID: season.ID, UserID: key.userID}
+            byUser[key.userID] = st
+            table = append(table, st)
+        }
+        st.Points += prediction.Points
+        st.Predictions++
+        if prediction.Correct {
+            st.Correct++
+        }
+    }
+
+    ahead := func(a, b *models.SeasonStanding) bool {
+        if a.Points != b.Points {
+            return a.Points > b.Points
+        }
+        return a.Correct > b.Correct
+    }
+    sortBy(table, ahead)
+    for i, st := range table {
+        if i > 0 && !ahead(table[i-1], st) {
+            st.Rank = table[i-1].Rank
+        } else {
+            st.Rank = i + 1
+        }
+    }
+    return table
+}
+
+func cloneSeason(season *models.Season) *models.Season {
+    c := clone(season)
+    c.ArchivedAt = cloneTime(season.ArchivedAt)
+    return c
+}
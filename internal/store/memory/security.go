@@ -0,0 +1,76 @@
+package memory
+
+import (
+    "context"
+    "time"
+
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+// RecordSecurityEvent keeps one sign-in per session: a session signing in
+// again refreshes the address, device and last seen time of its entry.
+func (s *Store) RecordSecurityEvent(ctx context.Context, event *models.SecurityEvent) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    at := event.CreatedAt
+    if at.IsZero() {
+        at = now()
+    }
+    if event.Kind == models.SecuritySignIn && event.SessionID != "" {
+        for _, other := range s.securityEvents {
+            if other.Kind != models.SecuritySignIn || other.UserID != event.UserID || other.SessionID != event.SessionID {
+                continue
+            }
+            other.IP, other.Country, other.Device, other.LastSeenAt = event.IP, event.Country, event.Device, at
+            event.ID, event.CreatedAt, event.LastSeenAt = other.ID, other.CreatedAt, other.LastSeenAt
+            return nil
+        }
+    }
+
+    event.ID = newID()
+    event.CreatedAt, event.LastSeenAt = at, at
+    stored := clone(event)
+    stored.DisputedAt, stored.Current = nil, false
+    s.securityEvents[stored.ID] = stored
+    return nil
+}
+
+// GetUserSecurityEvents returns the most recently active entries first.
+func (s *Store) GetUserSecurityEvents(ctx context.Context, userID string, limit int) ([]*models.SecurityEvent, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    var events []*models.SecurityEvent
+    for _, event := range s.securityEvents {
+        if event.UserID == userID {
+            e := clone(event)
+            e.DisputedAt = cloneTime(event.DisputedAt)
+            events = append(events, e)
+        }
+    }
+    sortBy(events, func(a, b *models.SecurityEvent) bool {
+        if !a.LastSeenAt.Equal(b.LastSeenAt) {
+            return a.LastSeenAt.After(b.LastSeenAt)
+        }
+        return a.ID < b.ID
+    })
+    return limited(events, limit), nil
+}
+
+func (s *Store) DisputeSecurityEvent(ctx context.Context, userID, id string, at time.Time) (bool, error) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    event, ok := s.securityEvents[id]
+    if !ok || event.UserID != userID {
+        return false, nil
+    }
+    if event.DisputedAt == nil {
+        event.DisputedAt = &at
+    }
+    if user, ok := s.users[userID]; ok && (user.SessionsRevokedAt == nil || at.After(*user.SessionsRevokedAt)) {
+        user.SessionsRevokedAt = &at
+    }
+    return true, nil
+}
@@ -0,0 +1,298 @@
+// Package memory is a store kept in process memory, for running the
+// server and its tests without a database. It follows the postgres
+// store's semantics, its orderings, upserts and race-safe "did this call
+// do it" results included, under one lock. Data lasts as long as the
+// process and is not shared, so it serves a single instance.
+//
+// Stored records are copies of what callers pass in, and results are
+// copies of what is stored, so neither side can change the other's.
+package memory
+
+import (
+    "fmt"
+    "sort"
+    "strings"
+    "sync"
+    "time"
+
+    "github.com/google/uuid"
+
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/store"
+)
+
+type Store struct {
+    mu sync.RWMutex
+
+    users           map[string]*models.User
+    usernameHistory []usernameChange
+    firstMessages   map[string]time.Time
+    groups          map[string]*models.DirectoryGroup
+
+    sports  map[string]*models.Sport
+    teams   map[string]*models.Team
+    matches map[string]*models.Match
+
+    rooms     map[string]*models.ChatRoom
+    members   map[membershipKey]*membership
+    topics    map[string]*models.RoomTopic
+    sanctions map[sanctionKey]*models.RoomSanction
+    roles     map[membershipKey]*models.RoomRole
+    voice     map[membershipKey]*models.VoiceParticipant
+    // tombstones outlive their rooms, so deleteRoom leaves them
+    tombstones map[string]*models.RoomTombstone
+
+    messages  map[string]*models.Message
+    reactions map[reactionKey]*models.Reaction
+    archives  map[string]*models.MessageArchive
+
+    conversations  map[string]*models.Conversation
+    directMessages map[string]*models.DirectMessage
+    blocks         map[blockKey]*models.UserBlock
+
+    matchVotes  map[string]*models.MatchVote
+    matchVoters map[membershipKey]bool
+    ballots     map[membershipKey]*models.MatchBallot
+    voteResults map[string]*models.MatchVoteResult
+    predictions map[membershipKey]*models.Prediction
+    seasons     map[string]*models.Season
+    standings   map[string][]*models.SeasonStanding
+    matchStats  map[membershipKey]*models.UserMatchStats
+    polls       map[string]*models.Poll
+    pollVotes   map[membershipKey]*models.PollVote
+    highlights  map[string]*models.Highlight
+
+    emotePacks map[string]*models.EmotePack
+    emotes     map[string]*models.Emote
+
+    matchEvents map[string]*models.MatchEvent
+    commentary  map[commentaryKey]*models.CommentaryTemplate
+    names       map[nameKey]*models.LocalizedName
+
+    roomRollups  map[string]*store.RoomStatistics
+    matchRollups map[string]*store.MatchStatistics
+    journal     []*models.JournalEntry
+    journalSeq  int64
+    incidents   map[string]*models.Incident
+    exports     map[string]*models.DataExport
+
+    profanityWords    map[profanityKey]*models.ProfanityWord
+    profanityPolicies map[string]*models.ProfanityPolicy
+    filters           map[string]*models.ModerationFilter
+    flags             map[string]*models.MessageFlag
+    evidence          map[string]*models.ModerationEvidence
+    evidenceAccess    map[string][]*models.EvidenceAccess
+    appeals           map[string]*models.Appeal
+
+    drafts       map[membershipKey]*models.Draft
+    alerts       map[string]*models.KeywordAlert
+    quietHours   map[string]*models.QuietHours
+    achievements map[membershipKey]*models.UserAchievement
+    progress     map[string]*models.UserProgress
+
+    sightings       []*models.DeviceSighting
+    evasionSuspects map[string]*models.EvasionSuspect
+    shadowBans      []*models.ShadowBanEntry
+    securityEvents  map[string]*models.SecurityEvent
+    recoveryCodes   map[string]*models.RecoveryCode
+    recoveryEmails  map[string]string
+    recoveryTokens  map[string]*models.RecoveryToken
+    attendance      map[attendanceKey]int
+    languages       map[languageKey]int64
+    attachments     map[string]*models.Attachment
+    attachmentData  map[string][]byte
+
+    deadLetters     map[string]*models.DeadLetter
+    reconciliations map[string]*models.ReconciliationReport
+}
+
+var _ store.Store = (*Store)(nil)
+
+// membershipKey pairs a room, match, season or poll with a user.
+type membershipKey struct {
+    scope  string
+    userID string
+}
+
+type sanctionKey struct {
+    roomID, userID, kind string
+}
+
+type reactionKey struct {
+    messageID, userID, emoji string
+}
+
+type blockKey struct {
+    userID, blockedID string
+}
+
+type profanityKey struct {
+    locale, word string
+}
+
+type commentaryKey struct {
+    locale, eventType string
+}
+
+type nameKey struct {
+    kind, entityID, locale string
+}
+
+type attendanceKey struct {
+    roomID string
+    minute time.Time
+}
+
+type languageKey struct {
+    roomID, language string
+}
+
+type usernameChange struct {
+    userID    string
+    username  string
+    changedAt time.Time
+}
+
+// membership is a user's place in a room.
+type membership struct {
+    joinedAt   time.Time
+    lastReadAt time.Time
+}
+
+func New() *Store {
+    return &Store{
+        users:             make(map[string]*models.User),
+        firstMessages:     make(map[string]time.Time),
+        groups:            make(map[string]*models.DirectoryGroup),
+        sports:            make(map[string]*models.Sport),
+        teams:             make(map[string]*models.Team),
+        matches:           make(map[string]*models.Match),
+        rooms:             make(map[string]*models.ChatRoom),
+        members:           make(map[membershipKey]*membership),
+        topics:            make(map[string]*models.RoomTopic),
+        messages:          make(map[string]*models.Message),
+        archives:          make(map[string]*models.MessageArchive),
+        sanctions:         make(map[sanctionKey]*models.RoomSanction),
+        roles:             make(map[membershipKey]*models.RoomRole),
+        reactions:         make(map[reactionKey]*models.Reaction),
+        voice:             make(map[membershipKey]*models.VoiceParticipant),
+        deadLetters:       make(map[string]*models.DeadLetter),
+        conversations:     make(map[string]*models.Conversation),
+        directMessages:    make(map[string]*models.DirectMessage),
+        blocks:            make(map[blockKey]*models.UserBlock),
+        matchVotes:        make(map[string]*models.MatchVote),
+        matchVoters:       make(map[membershipKey]bool),
+        ballots:           make(map[membershipKey]*models.MatchBallot),
+        voteResults:       make(map[string]*models.MatchVoteResult),
+        predictions:       make(map[membershipKey]*models.Prediction),
+        seasons:           make(map[string]*models.Season),
+        standings:         make(map[string][]*models.SeasonStanding),
+        matchStats:        make(map[membershipKey]*models.UserMatchStats),
+        polls:             make(map[string]*models.Poll),
+        pollVotes:         make(map[membershipKey]*models.PollVote),
+        highlights:        make(map[string]*models.Highlight),
+        emotePacks:        make(map[string]*models.EmotePack),
+        emotes:            make(map[string]*models.Emote),
+        matchEvents:       make(map[string]*models.MatchEvent),
+        commentary:        make(map[commentaryKey]*models.CommentaryTemplate),
+        names:             make(map[nameKey]*models.LocalizedName),
+        roomRollups:       make(map[string]*store.RoomStatistics),
+        matchRollups:      make(map[string]*store.MatchStatistics),
+        incidents:         make(map[string]*models.Incident),
+        exports:           make(map[string]*models.DataExport),
+        tombstones:        make(map[string]*models.RoomTombstone),
+        profanityWords:    make(map[profanityKey]*models.ProfanityWord),
+        profanityPolicies: make(map[string]*models.ProfanityPolicy),
+        filters:           make(map[string]*models.ModerationFilter),
+        flags:             make(map[string]*models.MessageFlag),
+        evidence:          make(map[string]*models.ModerationEvidence),
+        evidenceAccess:    make(map[string][]*models.EvidenceAccess),
+        appeals:           make(map[string]*models.Appeal),
+        drafts:            make(map[membershipKey]*models.Draft),
+        alerts:            make(map[string]*models.KeywordAlert),
+        quietHours:        make(map[string]*models.QuietHours),
+        achievements:      make(map[membershipKey]*models.UserAchievement),
+        progress:          make(map[string]*models.UserProgress),
+        evasionSuspects:   make(map[string]*models.EvasionSuspect),
+        securityEvents:    make(map[string]*models.SecurityEvent),
+        recoveryCodes:     make(map[string]*models.RecoveryCode),
+        recoveryEmails:    make(map[string]string),
+        recoveryTokens:    make(map[string]*models.RecoveryToken),
+        attendance:        make(map[attendanceKey]int),
+        languages:         make(map[languageKey]int64),
+        attachments:       make(map[string]*models.Attachment),
+        attachmentData:    make(map[string][]byte),
+        reconciliations:   make(map[string]*models.ReconciliationReport),
+    }
+}
+
+func (s *Store) Close() error {
+    return nil
+}
+
+// notFound matches the postgres store's error for a missing row; callers
+// treat any error from a get as not found.
+func notFound(what string) error {
+    return fmt.Errorf("%s not found", what)
+}
+
+func newID() string {
+    return uuid.NewString()
+}
+
+// now is the time rows are stamped with, at the database's precision.
+func now() time.Time {
+    return time.Now().UTC().Truncate(time.Microsecond)
+}
+
+// clone copies a record, so stored values never escape the lock.
+func clone[T any](v *T) *T {
+    if v == nil {
+        return nil
+    }
+    c := *v
+    return &c
+}
+
+func cloneTime(t *time.Time) *time.Time {
+    if t == nil {
+        return nil
+    }
+    c := *t
+    return &c
+}
+
+func cloneAll[T any](items []*T) []*T {
+    if items == nil {
+        return nil
+    }
+    out := make([]*T, len(items))
+    for i, item := range items {
+        out[i] = clone(item)
+    }
+    return out
+}
+
+// limited returns at most limit items; a limit of zero or less returns
+// all of them.
+func limited[T any](items []T, limit int) []T {
+    if limit > 0 && len(items) > limit {
+        return items[:limit]
+    }
+    return items
+}
+
+// sortBy sorts items with less, keeping equal items in their order.
+func sortBy[T any](items []T, less func(a, b T) bool) {
+    sort.SliceStable(items, func(i, j int) bool { return less(items[i], items[j]) })
+}
+
+func equalFold(a, b string) bool {
+    return strings.EqualFold(a, b)
+}
+
+// containsFold reports whether text contains query, ignoring case, as
+// ILIKE does.
+func containsFold(text, query string) bool {
+    return strings.Contains(strings.ToLower(text), strings.ToLower(query))
+}
@@ -0,0 +1,91 @@
+package memory
+
+import (
+    "context"
+    "fmt"
+    "time"
+
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+func (s *Store) TombstoneChatRoom(ctx context.Context, tombstone *models.RoomTombstone) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    room, ok := s.rooms[tombstone.RoomID]
+    if !ok {
+        return fmt.Errorf("failed to delete chat room: %w", notFound("chat room"))
+    }
+    room.IsActive = false
+    room.UpdatedAt = now()
+
+    tombstone.DeletedAt = now()
+    tombstone.PurgedAt = nil
+    s.tombstones[tombstone.RoomID] = cloneTombstone(tombstone)
+    return nil
+}
+
+func (s *Store) GetRoomTombstone(ctx context.Context, roomID string) (*models.RoomTombstone, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    tombstone, ok := s.tombstones[roomID]
+    if !ok {
+        return nil, notFound("room tombstone")
+    }
+    return cloneTombstone(tombstone), nil
+}
+
+func (s *Store) RestoreChatRoom(ctx context.Context, roomID string) (bool, error) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    tombstone, ok := s.tombstones[roomID]
+    room, exists := s.rooms[roomID]
+    if !ok || tombstone.PurgedAt != nil || !exists {
+        return false, nil
+    }
+    delete(s.tombstones, roomID)
+    room.IsActive = true
+    room.UpdatedAt = now()
+    return true, nil
+}
+
+func (s *Store) ListPurgeableRoomTombstones(ctx context.Context, before time.Time, limit int) ([]*models.RoomTombstone, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    var tombstones []*models.RoomTombstone
+    for _, tombstone := range s.tombstones {
+        if tombstone.PurgedAt == nil && tombstone.DeletedAt.Before(before) {
+            tombstones = append(tombstones, cloneTombstone(tombstone))
+        }
+    }
+    sortBy(tombstones, func(a, b *models.RoomTombstone) bool { return a.DeletedAt.Before(b.DeletedAt) })
+    return limited(tombstones, limit), nil
+}
+
+func (s *Store) PurgeChatRoom(ctx context.Context, roomID string, at time.Time) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    tombstone, ok := s.tombstones[roomID]
+    if !ok {
+        return fmt.Errorf("failed to purge chat room: %w", notFound("room tombstone"))
+    }
+    s.deleteRoom(roomID)
+    for _, t := range s.tombstones {
+        // As the schema's foreign key does
+        if t.ReplacementID == roomID {
+            t.ReplacementID = ""
+        }
+    }
+    tombstone.PurgedAt = &at
+    return nil
+}
+
+func cloneTombstone(tombstone *models.RoomTombstone) *models.RoomTombstone {
+    t := clone(tombstone)
+    t.PurgedAt = cloneTime(tombstone.PurgedAt)
+    return t
+}
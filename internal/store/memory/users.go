@@ -0,0 +1,355 @@
+package memory
+
+import (
+    "context"
+    "fmt"
+    "time"
+
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+func (s *Store) CreateUser(ctx context.Context, user *models.User) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    for _, other := range s.users {
+        if other.Username == user.Username {
+            return fmt.Errorf("failed to create user: username %q is taken", user.Username)
+        }
+        if user.ExternalID != "" && other.ExternalID == user.ExternalID {
+            return fmt.Errorf("failed to create user: external ID %q is taken", user.ExternalID)
+        }
+    }
+
+    user.ID = newID()
+    if user.AccountType == "" {
+        user.AccountType = models.AccountTypeUser
+    }
+    user.CreatedAt = now()
+    user.UpdatedAt = user.CreatedAt
+    s.users[user.ID] = clone(user)
+    return nil
+}
+
+func (s *Store) GetUser(ctx context.Context, id string) (*models.User, error) {
+    return s.getUser(func(u *models.User) bool { return u.ID == id })
+}
+
+func (s *Store) GetUserByUsername(ctx context.Context, username string) (*models.User, error) {
+    return s.getUser(func(u *models.User) bool { return u.Username == username })
+}
+
+func (s *Store) GetUserByExternalID(ctx context.Context, externalID string) (*models.User, error) {
+    return s.getUser(func(u *models.User) bool { return externalID != "" && u.ExternalID == externalID })
+}
+
+func (s *Store) getUser(match func(*models.User) bool) (*models.User, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    for _, user := range s.users {
+        if match(user) {
+            return clone(user), nil
+        }
+    }
+    return nil, notFound("user")
+}
+
+func (s *Store) UpdateUser(ctx context.Context, user *models.User) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    stored, ok := s.users[user.ID]
+    if !ok {
+        return fmt.Errorf("failed to update user: %w", notFound("user"))
+    }
+    for _, other := range s.users {
+        if other.ID != user.ID && other.Username == user.Username {
+            return fmt.Errorf("failed to update user: username %q is taken", user.Username)
+        }
+    }
+
+    // Columns the update does not write keep their stored values
+    updated := clone(user)
+    updated.CreatedAt = stored.CreatedAt
+    updated.UsernameChangedAt = stored.UsernameChangedAt
+    updated.SessionsRevokedAt = stored.SessionsRevokedAt
+    updated.ShadowBanned = stored.ShadowBanned
+    updated.UpdatedAt = now()
+    s.users[user.ID] = updated
+    user.UpdatedAt = updated.UpdatedAt
+    return nil
+}
+
+func (s *Store) DeleteUser(ctx context.Context, id string) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    delete(s.users, id)
+    for key := range s.members {
+        if key.userID == id {
+            delete(s.members, key)
+        }
+    }
+    for key := range s.roles {
+        if key.userID == id {
+            delete(s.roles, key)
+        }
+    }
+    for _, message := range s.messages {
+        if message.UserID == id {
+            delete(s.messages, message.ID)
+        }
+    }
+    for _, export := range s.exports {
+        if export.UserID == id {
+            delete(s.exports, export.ID)
+        }
+    }
+    s.deleteRecovery(id)
+    return nil
+}
+
+func (s *Store) AnonymizeUser(ctx context.Context, userID, username string, at time.Time) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    user, ok := s.users[userID]
+    if !ok {
+        return fmt.Errorf("failed to anonymize user: user %s not found", userID)
+    }
+    user.Username, user.Password, user.Email = username, "", ""
+    user.FavoriteTeam, user.AvatarURL, user.Bio = "", "", ""
+    user.IsAdmin, user.RateLimitExempt = false, false
+    user.ExternalID, user.BirthDate, user.AgeVerifiedAt = "", nil, nil
+    user.Locale, user.Timezone = "", ""
+    user.DeactivatedAt, user.SessionsRevokedAt, user.UsernameChangedAt = &at, &at, &at
+    user.UpdatedAt = now()
+
+    history := s.usernameHistory[:0]
+    for _, change := range s.usernameHistory {
+        if change.userID != userID {
+            history = append(history, change)
+        }
+    }
+    s.usernameHistory = history
+    sightings := s.sightings[:0]
+    for _, sighting := range s.sightings {
+        if sighting.UserID != userID {
+            sightings = append(sightings, sighting)
+        }
+    }
+    s.sightings = sightings
+    for id, event := range s.securityEvents {
+        if event.UserID == userID {
+            delete(s.securityEvents, id)
+        }
+    }
+    s.deleteRecovery(userID)
+    for key := range s.members {
+        if key.userID == userID {
+            delete(s.members, key)
+        }
+    }
+    for key := range s.roles {
+        if key.userID == userID {
+            delete(s.roles, key)
+        }
+    }
+    for key := range s.voice {
+        if key.userID == userID {
+            delete(s.voice, key)
+        }
+    }
+    for key := range s.drafts {
+        if key.userID == userID {
+            delete(s.drafts, key)
+        }
+    }
+    for id, alert := range s.alerts {
+        if alert.UserID == userID {
+            delete(s.alerts, id)
+        }
+    }
+    delete(s.quietHours, userID)
+    for key := range s.blocks {
+        if key.userID == userID || key.blockedID == userID {
+            delete(s.blocks, key)
+        }
+    }
+    for _, group := range s.groups {
+        members := group.MemberIDs[:0]
+        for _, id := range group.MemberIDs {
+            if id != userID {
+                members = append(members, id)
+            }
+        }
+        group.MemberIDs = members
+    }
+    return nil
+}
+
+func (s *Store) RenameUser(ctx context.Context, userID, username string, at time.Time) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    user, ok := s.users[userID]
+    if !ok {
+        return nil
+    }
+    for _, other := range s.users {
+        if other.ID != userID && other.Username == username {
+            return fmt.Errorf("failed to rename user: username %q is taken", username)
+        }
+    }
+    s.usernameHistory = append(s.usernameHistory, usernameChange{userID: userID, username: user.Username, changedAt: at})
+    user.Username = username
+    changedAt := at
+    user.UsernameChangedAt = &changedAt
+    user.UpdatedAt = now()
+    return nil
+}
+
+func (s *Store) UsernameReleasedSince(ctx context.Context, username string, since time.Time) (bool, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    for _, change := range s.usernameHistory {
+        if equalFold(change.username, username) && change.changedAt.After(since) {
+            return true, nil
+        }
+    }
+    return false, nil
+}
+
+func (s *Store) MarkFirstMessage(ctx context.Context, userID string, at time.Time) (bool, error) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    if _, ok := s.users[userID]; !ok {
+        return false, nil
+    }
+    if _, marked := s.firstMessages[userID]; marked {
+        return false, nil
+    }
+    s.firstMessages[userID] = at
+    return true, nil
+}
+
+func (s *Store) ListUsers(ctx context.Context, offset, limit int) ([]*models.User, int, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    users := make([]*models.User, 0, len(s.users))
+    for _, user := range s.users {
+        users = append(users, user)
+    }
+    sortBy(users, func(a, b *models.User) bool {
+        if !a.CreatedAt.Equal(b.CreatedAt) {
+            return a.CreatedAt.Before(b.CreatedAt)
+        }
+        return a.ID < b.ID
+    })
+
+    total := len(users)
+    if offset > total {
+        offset = total
+    }
+    return cloneAll(limited(users[offset:], limit)), total, nil
+}
+
+func (s *Store) CreateDirectoryGroup(ctx context.Context, group *models.DirectoryGroup) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    group.ID = newID()
+    group.CreatedAt = now()
+    group.UpdatedAt = group.CreatedAt
+    group.MemberIDs = groupMembers(group.MemberIDs)
+    s.groups[group.ID] = cloneGroup(group)
+    return nil
+}
+
+func (s *Store) GetDirectoryGroup(ctx context.Context, id string) (*models.DirectoryGroup, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    group, ok := s.groups[id]
+    if !ok {
+        return nil, notFound("directory group")
+    }
+    return cloneGroup(group), nil
+}
+
+func (s *Store) ListDirectoryGroups(ctx context.Context) ([]*models.DirectoryGroup, error) {
+    return s.listDirectoryGroups(func(*models.DirectoryGroup) bool { return true }), nil
+}
+
+func (s *Store) UpdateDirectoryGroup(ctx context.Context, group *models.DirectoryGroup) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    stored, ok := s.groups[group.ID]
+    if !ok {
+        return fmt.Errorf("failed to update directory group: %w", notFound("directory group"))
+    }
+    group.CreatedAt = stored.CreatedAt
+    group.UpdatedAt = now()
+    group.MemberIDs = groupMembers(group.MemberIDs)
+    s.groups[group.ID] = cloneGroup(group)
+    return nil
+}
+
+func (s *Store) DeleteDirectoryGroup(ctx context.Context, id string) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    delete(s.groups, id)
+    return nil
+}
+
+func (s *Store) GetUserDirectoryGroups(ctx context.Context, userID string) ([]*models.DirectoryGroup, error) {
+    return s.listDirectoryGroups(func(group *models.DirectoryGroup) bool {
+        for _, member := range group.MemberIDs {
+            if member == userID {
+                return true
+            }
+        }
+        return false
+    }), nil
+}
+
+func (s *Store) listDirectoryGroups(keep func(*models.DirectoryGroup) bool) []*models.DirectoryGroup {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    var groups []*models.DirectoryGroup
+    for _, group := range s.groups {
+        if keep(group) {
+            groups = append(groups, cloneGroup(group))
+        }
+    }
+    sortBy(groups, func(a, b *models.DirectoryGroup) bool { return a.DisplayName < b.DisplayName })
+    return groups
+}
+
+// groupMembers returns a group's distinct member IDs in order, as the
+// postgres store reads them back.
+func groupMembers(ids []string) []string {
+    seen := make(map[string]bool, len(ids))
+    members := []string{}
+    for _, id := range ids {
+        if !seen[id] {
+            seen[id] = true
+            members = append(members, id)
+        }
+    }
+    sortBy(members, func(a, b string) bool { return a < b })
+    return members
+}
+
+func cloneGroup(group *models.DirectoryGroup) *models.DirectoryGroup {
+    c := clone(group)
+    c.MemberIDs = append([]string{}, group.MemberIDs...)
+    return c
+}
@@ -0,0 +1,119 @@
+package memory
+
+import (
+    "context"
+
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+func (s *Store) AddUserMatchStats(ctx context.Context, deltas []*models.UserMatchStats) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    for _, d := range deltas {
+        key := membershipKey{scope: d.MatchID, userID: d.UserID}
+        stats, ok := s.matchStats[key]
+        if !ok {
+            stats = &models.UserMatchStats{MatchID: d.MatchID, UserID: d.UserID}
+            s.matchStats[key] = stats
+        }
+        stats.Messages += d.Messages
+        stats.ReactionsReceived += d.ReactionsReceived
+        stats.CorrectPredictions += d.CorrectPredictions
+        stats.PredictionPoints += d.PredictionPoints
+        stats.Score = stats.Messages + 2*received(stats) + 10*stats.PredictionPoints
+        stats.UpdatedAt = now()
+    }
+    return nil
+}
+
+func (s *Store) GetMatchLeaderboard(ctx context.Context, matchID string, limit int) ([]*models.LeaderboardEntry, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    var entries []*models.LeaderboardEntry
+    for key, stats := range s.matchStats {
+        if key.scope == matchID {
+            entries = append(entries, leaderboardEntry(stats))
+        }
+    }
+    return s.rankLeaderboard(entries, limit), nil
+}
+
+// GetSeasonLeaderboard sums the season's matches: those kicking off in
+// its window, of its competition or, without one, of any.
+func (s *Store) GetSeasonLeaderboard(ctx context.Context, season *models.Season, limit int) ([]*models.LeaderboardEntry, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    byUser := make(map[string]*models.LeaderboardEntry)
+    var entries []*models.LeaderboardEntry
+    for key, stats := range s.matchStats {
+        match, ok := s.matches[key.scope]
+        if !ok || match.StartTime.Before(season.StartsAt) || !match.StartTime.Before(season.EndsAt) {
+            continue
+        }
+        if season.CompetitionID != "" && match.CompetitionID != season.CompetitionID {
+            continue
+        }
+        entry := leaderboardEntry(stats)
+        total, ok := byUser[key.userID]
+        if !ok {
+            byUser[key.userID] = entry
+            entries = append(entries, entry)
+            continue
+        }
+        total.Score += entry.Score
+        total.Messages += entry.Messages
+        total.ReactionsReceived += entry.ReactionsReceived
+        total.CorrectPredictions += entry.CorrectPredictions
+    }
+    return s.rankLeaderboard(entries, limit), nil
+}
+
+func leaderboardEntry(stats *models.UserMatchStats) *models.LeaderboardEntry {
+    return &models.LeaderboardEntry{
+        UserID:             stats.UserID,
+        Score:              stats.Score,
+        Messages:           stats.Messages,
+        ReactionsReceived:  received(stats),
+        CorrectPredictions: stats.CorrectPredictions,
+    }
+}
+
+// received counts reactions received; retracted reactions can take the
+// running count below zero, which the score ignores.
+func received(stats *models.UserMatchStats) int {
+    if stats.ReactionsReceived < 0 {
+        return 0
+    }
+    return stats.ReactionsReceived
+}
+
+// rankLeaderboard drops entries without a score or a user, then ranks the
+// rest by score, equal scores sharing a rank. The caller holds the lock.
+func (s *Store) rankLeaderboard(entries []*models.LeaderboardEntry, limit int) []*models.LeaderboardEntry {
+    var ranked []*models.LeaderboardEntry
+    for _, entry := range entries {
+        user, ok := s.users[entry.UserID]
+        if !ok || entry.Score <= 0 {
+            continue
+        }
+        entry.Username = user.Username
+        ranked = append(ranked, entry)
+    }
+    sortBy(ranked, func(a, b *models.LeaderboardEntry) bool {
+        if a.Score != b.Score {
+            return a.Score > b.Score
+        }
+        return a.Username < b.Username
+    })
+    for i, entry := range ranked {
+        if i > 0 && ranked[i-1].Score == entry.Score {
+            entry.Rank = ranked[i-1].Rank
+        } else {
+            entry.Rank = i + 1
+        }
+    }
+    return limited(ranked, limit)
+}
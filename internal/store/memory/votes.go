@@ -0,0 +1,325 @@
+package memory
+
+import (
+    "context"
+    "time"
+
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+func (s *Store) OpenMatchVote(ctx context.Context, vote *models.MatchVote, voterIDs []string) (bool, error) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    _, exists := s.matchVotes[vote.MatchID]
+    if !exists {
+        stored := clone(vote)
+        stored.ClosedAt = nil
+        s.matchVotes[vote.MatchID] = stored
+    }
+    for _, userID := range voterIDs {
+        s.matchVoters[membershipKey{scope: vote.MatchID, userID: userID}] = true
+    }
+    return !exists, nil
+}
+
+func (s *Store) GetMatchVote(ctx context.Context, matchID string) (*models.MatchVote, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    vote, ok := s.matchVotes[matchID]
+    if !ok {
+        return nil, notFound("match vote")
+    }
+    return cloneMatchVote(vote), nil
+}
+
+func (s *Store) IsMatchVoter(ctx context.Context, matchID, userID string) (bool, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    return s.matchVoters[membershipKey{scope: matchID, userID: userID}], nil
+}
+
+func (s *Store) CastMatchBallot(ctx context.Context, ballot *models.MatchBallot) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    key := membershipKey{scope: ballot.MatchID, userID: ballot.UserID}
+    ballot.UpdatedAt = now()
+    ballot.CreatedAt = ballot.UpdatedAt
+    if stored, exists := s.ballots[key]; exists {
+        ballot.CreatedAt = stored.CreatedAt
+    }
+    s.ballots[key] = clone(ballot)
+    return nil
+}
+
+func (s *Store) GetDueMatchVotes(ctx context.Context, now time.Time) ([]*models.MatchVote, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    var votes []*models.MatchVote
+    for _, vote := range s.matchVotes {
+        if vote.ClosedAt == nil && !vote.ClosesAt.After(now) {
+            votes = append(votes, cloneMatchVote(vote))
+        }
+    }
+    sortBy(votes, func(a, b *models.MatchVote) bool { return a.ClosesAt.Before(b.ClosesAt) })
+    return votes, nil
+}
+
+func (s *Store) CloseMatchVote(ctx context.Context, matchID string, at time.Time) (bool, error) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    vote, ok := s.matchVotes[matchID]
+    if !ok || vote.ClosedAt != nil {
+        return false, nil
+    }
+    vote.ClosedAt = &at
+    s.voteResults[matchID] = s.tallyBallots(matchID)
+    return true, nil
+}
+
+func (s *Store) GetMatchVoteResult(ctx context.Context, matchID string) (*models.MatchVoteResult, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    vote, ok := s.matchVotes[matchID]
+    if !ok {
+        return nil, notFound("match vote")
+    }
+    result, closed := s.voteResults[matchID]
+    if !closed {
+        result = s.tallyBallots(matchID)
+    }
+    r := clone(result)
+    r.Players = cloneAll(result.Players)
+    r.MatchID, r.ClosesAt, r.Open = matchID, vote.ClosesAt, !closed
+    return r, nil
+}
+
+// tallyBallots aggregates the ballots of a match's vote. The caller holds
+// the lock.
+func (s *Store) tallyBallots(matchID string) *models.MatchVoteResult {
+    result := &models.MatchVoteResult{Players: []*models.PlayerVotes{}}
+    votes := make(map[string]*models.PlayerVotes)
+    total := 0
+    for key, ballot := range s.ballots {
+        if key.scope != matchID {
+            continue
+        }
+        if ballot.Rating != 0 {
+            result.Ratings++
+            total += ballot.Rating
+        }
+        if ballot.Player == "" {
+            continue
+        }
+        p, ok := votes[ballot.Player]
+        if !ok {
+            p = &models.PlayerVotes{Player: ballot.Player}
+            votes[ballot.Player] = p
+            result.Players = append(result.Players, p)
+        }
+        p.Votes++
+    }
+    if result.Ratings > 0 {
+        result.AverageRating = float64(total) / float64(result.Ratings)
+    }
+    sortBy(result.Players, func(a, b *models.PlayerVotes) bool {
+        if a.Votes != b.Votes {
+            return a.Votes > b.Votes
+        }
+        return a.Player < b.Player
+    })
+    return result
+}
+
+func cloneMatchVote(vote *models.MatchVote) *models.MatchVote {
+    v := clone(vote)
+    v.ClosedAt = cloneTime(vote.ClosedAt)
+    return v
+}
+
+func (s *Store) UpsertPrediction(ctx context.Context, prediction *models.Prediction) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    key := membershipKey{scope: prediction.MatchID, userID: prediction.UserID}
+    if stored, exists := s.predictions[key]; exists {
+        stored.HomeScore, stored.AwayScore = prediction.HomeScore, prediction.AwayScore
+        stored.UpdatedAt = now()
+        prediction.ID, prediction.CreatedAt, prediction.UpdatedAt = stored.ID, stored.CreatedAt, stored.UpdatedAt
+        return nil
+    }
+    stored := &models.Prediction{
+        ID:        newID(),
+        MatchID:   prediction.MatchID,
+        UserID:    prediction.UserID,
+        HomeScore: prediction.HomeScore,
+        AwayScore: prediction.AwayScore,
+        CreatedAt: now(),
+    }
+    stored.UpdatedAt = stored.CreatedAt
+    s.predictions[key] = stored
+    prediction.ID, prediction.CreatedAt, prediction.UpdatedAt = stored.ID, stored.CreatedAt, stored.UpdatedAt
+    return nil
+}
+
+func (s *Store) GetPrediction(ctx context.Context, matchID, userID string) (*models.Prediction, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    prediction, ok := s.predictions[membershipKey{scope: matchID, userID: userID}]
+    if !ok {
+        return nil, notFound("prediction")
+    }
+    return clonePrediction(prediction), nil
+}
+
+func (s *Store) GetMatchPredictions(ctx context.Context, matchID string) ([]*models.Prediction, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    var predictions []*models.Prediction
+    for key, prediction := range s.predictions {
+        if key.scope == matchID {
+            predictions = append(predictions, clonePrediction(prediction))
+        }
+    }
+    sortBy(predictions, func(a, b *models.Prediction) bool { return a.CreatedAt.Before(b.CreatedAt) })
+    return predictions, nil
+}
+
+func (s *Store) ScorePrediction(ctx context.Context, prediction *models.Prediction, rescore bool) (bool, error) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    for _, stored := range s.predictions {
+        if stored.ID != prediction.ID {
+            continue
+        }
+        if !rescore && stored.ScoredAt != nil {
+            return false, nil
+        }
+        stored.Correct, stored.Points = prediction.Correct, prediction.Points
+        stored.ScoredAt = cloneTime(prediction.ScoredAt)
+        return true, nil
+    }
+    return false, nil
+}
+
+func (s *Store) GetUserPredictionStats(ctx context.Context, userID string, since time.Time) (correct, total int, err error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    for key, prediction := range s.predictions {
+        if key.userID != userID || prediction.ScoredAt == nil || prediction.ScoredAt.Before(since) {
+            continue
+        }
+        total++
+        if prediction.Correct {
+            correct++
+        }
+    }
+    return correct, total, nil
+}
+
+func clonePrediction(prediction *models.Prediction) *models.Prediction {
+    p := clone(prediction)
+    p.ScoredAt = cloneTime(prediction.ScoredAt)
+    return p
+}
+
+func (s *Store) CreatePoll(ctx context.Context, poll *models.Poll) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    poll.ID = newID()
+    if poll.CreatedAt.IsZero() {
+        poll.CreatedAt = now()
+    }
+    stored := clonePoll(poll)
+    stored.ClosedAt = nil
+    s.polls[stored.ID] = stored
+    return nil
+}
+
+func (s *Store) GetPoll(ctx context.Context, id string) (*models.Poll, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    poll, ok := s.polls[id]
+    if !ok {
+        return nil, notFound("poll")
+    }
+    return clonePoll(poll), nil
+}
+
+func (s *Store) GetMatchPolls(ctx context.Context, matchID string) ([]*models.Poll, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    var polls []*models.Poll
+    for _, poll := range s.polls {
+        if poll.MatchID == matchID {
+            polls = append(polls, clonePoll(poll))
+        }
+    }
+    sortBy(polls, func(a, b *models.Poll) bool { return a.CreatedAt.Before(b.CreatedAt) })
+    return polls, nil
+}
+
+func (s *Store) CastPollVote(ctx context.Context, vote *models.PollVote) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    if _, ok := s.polls[vote.PollID]; !ok {
+        return notFound("poll")
+    }
+    if vote.CreatedAt.IsZero() {
+        vote.CreatedAt = now()
+    }
+    s.pollVotes[membershipKey{scope: vote.PollID, userID: vote.UserID}] = clone(vote)
+    return nil
+}
+
+func (s *Store) GetPollTally(ctx context.Context, pollID string) (*models.PollTally, error) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+
+    poll, ok := s.polls[pollID]
+    if !ok {
+        return nil, notFound("poll")
+    }
+    tally := &models.PollTally{PollID: pollID, Counts: make([]int, len(poll.Options))}
+    for key, vote := range s.pollVotes {
+        if key.scope == pollID && vote.Option >= 0 && vote.Option < len(tally.Counts) {
+            tally.Counts[vote.Option]++
+            tally.Total++
+        }
+    }
+    return tally, nil
+}
+
+func (s *Store) ClosePoll(ctx context.Context, id string, at time.Time) (bool, error) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    poll, ok := s.polls[id]
+    if !ok || poll.ClosedAt != nil {
+        return false, nil
+    }
+    poll.ClosedAt = &at
+    return true, nil
+}
+
+func clonePoll(poll *models.Poll) *models.Poll {
+    p := clone(poll)
+    p.Options = append([]string(nil), poll.Options...)
+    p.ClosedAt = cloneTime(poll.ClosedAt)
+    p.Tally = nil
+    return p
+}
@@ -0,0 +1,206 @@
+package postgres
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "time"
+
+    "github.com/jackc/pgx/v5"
+
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+func (s *Store) CreateKeywordAlert(ctx context.Context, alert *models.KeywordAlert) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    err := s.pool.QueryRow(ctx, `
+        INSERT INTO keyword_alerts (user_id, keyword, chat_room_id)
+        VALUES ($1, $2, NULLIF($3, '')::uuid)
+        RETURNING id, created_at`,
+        alert.UserID, alert.Keyword, alert.RoomID,
+    ).Scan(&alert.ID, &alert.CreatedAt)
+    if err != nil {
+        return fmt.Errorf("failed to create keyword alert: %w", err)
+    }
+    return nil
+}
+
+func (s *Store) DeleteKeywordAlert(ctx context.Context, userID, id string) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    if _, err := s.pool.Exec(ctx, `
+        DELETE FROM keyword_alerts WHERE id = $2 AND user_id = $1`,
+        userID, id); err != nil {
+        return fmt.Errorf("failed to delete keyword alert: %w", err)
+    }
+    return nil
+}
+
+func (s *Store) GetUserKeywordAlerts(ctx context.Context, userID string) ([]*models.KeywordAlert, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    rows, err := s.pool.Query(ctx, `
+        SELECT id, user_id, keyword, COALESCE(chat_room_id::text, ''), created_at
+        FROM keyword_alerts
+        WHERE user_id = $1
+        ORDER BY created_at`,
+        userID)
+    if err != nil {
+        return nil, fmt.Errorf("failed to get keyword alerts: %w", err)
+    }
+    return collect(rows, func(row pgx.Row) (*models.KeywordAlert, error) {
+        a := &models.KeywordAlert{}
+        if err := row.Scan(&a.ID, &a.UserID, &a.Keyword, &a.RoomID, &a.CreatedAt); err != nil {
+            return nil, err
+        }
+        return a, nil
+    })
+}
+
+func (s *Store) GetQuietHours(ctx context.Context, userID string) (*models.QuietHours, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    q := &models.QuietHours{}
+    err := s.pool.QueryRow(ctx, `
+        SELECT user_id, timezone, start_time, end_time, team_goals, updated_at
+        FROM quiet_hours WHERE user_id = $1`,
+        userID,
+    ).Scan(&q.UserID, &q.Timezone, &q.Start, &q.End, &q.TeamGoals, &q.UpdatedAt)
+    if err != nil {
+        return nil, notFound(err, "quiet hours")
+    }
+    return q, nil
+}
+
+func (s *Store) SetQuietHours(ctx context.Context, quiet *models.QuietHours) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    err := s.pool.QueryRow(ctx, `
+        INSERT INTO quiet_hours (user_id, timezone, start_time, end_time, team_goals)
+        VALUES ($1, $2, $3, $4, $5)
+        ON CONFLICT (user_id) DO UPDATE SET
+            timezone = EXCLUDED.timezone, start_time = EXCLUDED.start_time, end_time = EXCLUDED.end_time,
+            team_goals = EXCLUDED.team_goals, updated_at = CURRENT_TIMESTAMP
+        RETURNING updated_at`,
+        quiet.UserID, quiet.Timezone, quiet.Start, quiet.End, quiet.TeamGoals,
+    ).Scan(&quiet.UpdatedAt)
+    if err != nil {
+        return fmt.Errorf("failed to set quiet hours: %w", err)
+    }
+    return nil
+}
+
+func (s *Store) DeleteQuietHours(ctx context.Context, userID string) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    if _, err := s.pool.Exec(ctx, `DELETE FROM quiet_hours WHERE user_id = $1`, userID); err != nil {
+        return fmt.Errorf("failed to delete quiet hours: %w", err)
+    }
+    return nil
+}
+
+// AwardAchievement reports false if the user had already unlocked it.
+func (s *Store) AwardAchievement(ctx context.Context, achievement *models.UserAchievement) (bool, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    awarded, err := affected(s.pool.Exec(ctx, `
+        INSERT INTO user_achievements (user_id, achievement_code, unlocked_at)
+        VALUES ($1, $2, COALESCE($3, CURRENT_TIMESTAMP))
+        ON CONFLICT (user_id, achievement_code) DO NOTHING`,
+        achievement.UserID, achievement.Code, nullTime(achievement.UnlockedAt)))
+    if err != nil {
+        return false, fmt.Errorf("failed to award achievement: %w", err)
+    }
+    return awarded, nil
+}
+
+func (s *Store) GetUserAchievements(ctx context.Context, userID string) ([]*models.UserAchievement, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    rows, err := s.pool.Query(ctx, `
+        SELECT user_id, achievement_code, unlocked_at FROM user_achievements
+        WHERE user_id = $1
+        ORDER BY unlocked_at`,
+        userID)
+    if err != nil {
+        return nil, fmt.Errorf("failed to get achievements: %w", err)
+    }
+    return collect(rows, func(row pgx.Row) (*models.UserAchievement, error) {
+        a := &models.UserAchievement{}
+        if err := row.Scan(&a.UserID, &a.Code, &a.UnlockedAt); err != nil {
+            return nil, err
+        }
+        return a, nil
+    })
+}
+
+const progressColumns = `user_id, xp, current_streak, longest_streak, last_active_on`
+
+func scanProgress(row pgx.Row) (*models.UserProgress, error) {
+    p := &models.UserProgress{}
+    var lastActive *time.Time
+    if err := row.Scan(&p.UserID, &p.XP, &p.CurrentStreak, &p.LongestStreak, &lastActive); err != nil {
+        return nil, err
+    }
+    if lastActive != nil {
+        p.LastActiveOn = *lastActive
+    }
+    return p, nil
+}
+
+// RecordUserActivity adds XP and extends the daily streak in one
+// statement, so concurrent events for a user cannot lose an update. Days
+// are UTC: activity the day after the last one extends the streak, a
+// longer gap restarts it.
+func (s *Store) RecordUserActivity(ctx context.Context, userID string, at time.Time, xp int) (*models.UserProgress, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    day := at.UTC().Format("2006-01-02")
+    progress, err := scanProgress(s.pool.QueryRow(ctx, `
+        INSERT INTO user_progress AS p (user_id, xp, current_streak, longest_streak, last_active_on)
+        VALUES ($1, $2, 1, 1, $3::date)
+        ON CONFLICT (user_id) DO UPDATE SET
+            xp = p.xp + EXCLUDED.xp,
+            current_streak = CASE
+                WHEN p.last_active_on >= EXCLUDED.last_active_on THEN p.current_streak
+                WHEN p.last_active_on = EXCLUDED.last_active_on - 1 THEN p.current_streak + 1
+                ELSE 1
+            END,
+            longest_streak = GREATEST(p.longest_streak, CASE
+                WHEN p.last_active_on = EXCLUDED.last_active_on - 1 THEN p.current_streak + 1
+                ELSE 1
+            END),
+            last_active_on = GREATEST(p.last_active_on, EXCLUDED.last_active_on)
+        RETURNING `+progressColumns,
+        userID, xp, day))
+    if err != nil {
+        return nil, fmt.Errorf("failed to record user activity: %w", err)
+    }
+    return progress, nil
+}
+
+// GetUserProgress returns zero progress for users with no activity yet.
+func (s *Store) GetUserProgress(ctx context.Context, userID string) (*models.UserProgress, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    progress, err := scanProgress(s.pool.QueryRow(ctx, `
+        SELECT `+progressColumns+` FROM user_progress WHERE user_id = $1`, userID))
+    if errors.Is(err, pgx.ErrNoRows) {
+        return &models.UserProgress{UserID: userID}, nil
+    }
+    if err != nil {
+        return nil, fmt.Errorf("failed to get user progress: %w", err)
+    }
+    return progress, nil
+}
@@ -0,0 +1,227 @@
+package postgres
+
+import (
+    "context"
+    "fmt"
+    "time"
+
+    "github.com/jackc/pgx/v5"
+
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+const (
+    analyticsTopMembers  = 10
+    analyticsPeakMoments = 5
+)
+
+func (s *Store) RecordRoomAttendance(ctx context.Context, roomID string, at time.Time, viewers int) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    _, err := s.pool.Exec(ctx, `
+        INSERT INTO room_attendance (chat_room_id, minute, viewers)
+        VALUES ($1, date_trunc('minute', $2::timestamptz), $3)
+        ON CONFLICT (chat_room_id, minute) DO UPDATE
+        SET viewers = GREATEST(room_attendance.viewers, EXCLUDED.viewers)`,
+        roomID, at, viewers)
+    if err != nil {
+        return fmt.Errorf("failed to record room attendance: %w", err)
+    }
+    return nil
+}
+
+// GetRoomAnalytics counts reactions by when they were made, not when the
+// message they react to was sent, so a late pile-on shows as its own
+// peak.
+func (s *Store) GetRoomAnalytics(ctx context.Context, roomID string, from, to time.Time) (*models.RoomAnalytics, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    analytics := &models.RoomAnalytics{
+        RoomID:      roomID,
+        From:        from,
+        To:          to,
+        Attendance:  []*models.AttendanceSample{},
+        TopMembers:  []*models.MemberActivity{},
+        PeakMoments: []*models.PeakMoment{},
+        Languages:   []*models.LanguageShare{},
+    }
+
+    rows, err := s.pool.Query(ctx, `
+        SELECT minute, viewers FROM room_attendance
+        WHERE chat_room_id = $1 AND minute >= $2 AND minute < $3
+        ORDER BY minute`,
+        roomID, from, to)
+    if err != nil {
+        return nil, fmt.Errorf("failed to get room attendance: %w", err)
+    }
+    samples, err := collect(rows, func(row pgx.Row) (*models.AttendanceSample, error) {
+        sample := &models.AttendanceSample{}
+        if err := row.Scan(&sample.At, &sample.Viewers); err != nil {
+            return nil, err
+        }
+        return sample, nil
+    })
+    if err != nil {
+        return nil, fmt.Errorf("failed to get room attendance: %w", err)
+    }
+    for _, sample := range samples {
+        analytics.Attendance = append(analytics.Attendance, sample)
+        if sample.Viewers > analytics.PeakViewers {
+            analytics.PeakViewers = sample.Viewers
+        }
+    }
+
+    err = s.pool.QueryRow(ctx, `
+        SELECT
+            (SELECT COUNT(*) FROM messages
+                WHERE chat_room_id = $1 AND created_at >= $2 AND created_at < $3),
+            (SELECT COUNT(*) FROM message_reactions mr
+                JOIN messages m ON m.id = mr.message_id
+                WHERE m.chat_room_id = $1 AND mr.created_at >= $2 AND mr.created_at < $3)`,
+        roomID, from, to,
+    ).Scan(&analytics.MessageCount, &analytics.ReactionCount)
+    if err != nil {
+        return nil, fmt.Errorf("failed to count room activity: %w", err)
+    }
+
+    rows, err = s.pool.Query(ctx, `
+        WITH activity AS (
+            SELECT user_id, 1 AS messages, 0 AS reactions FROM messages
+            WHERE chat_room_id = $1 AND created_at >= $2 AND created_at < $3
+            UNION ALL
+            SELECT mr.user_id, 0, 1 FROM message_reactions mr
+            JOIN messages m ON m.id = mr.message_id
+            WHERE m.chat_room_id = $1 AND mr.created_at >= $2 AND mr.created_at < $3
+        )
+        SELECT u.id, u.username, COALESCE(u.avatar_url, ''), COALESCE(u.favorite_team, ''),
+            SUM(a.messages), SUM(a.reactions)
+        FROM activity a
+        JOIN users u ON u.id = a.user_id
+        GROUP BY u.id, u.username, u.avatar_url, u.favorite_team
+        ORDER BY SUM(a.messages) + SUM(a.reactions) DESC, u.username
+        LIMIT $4`,
+        roomID, from, to, analyticsTopMembers)
+    if err != nil {
+        return nil, fmt.Errorf("failed to get room top members: %w", err)
+    }
+    members, err := collect(rows, func(row pgx.Row) (*models.MemberActivity, error) {
+        member := &models.MemberActivity{User: &models.UserSummary{}}
+        err := row.Scan(&member.User.ID, &member.User.Username, &member.User.AvatarURL, &member.User.Flair,
+            &member.Messages, &member.Reactions)
+        if err != nil {
+            return nil, err
+        }
+        return member, nil
+    })
+    if err != nil {
+        return nil, fmt.Errorf("failed to get room top members: %w", err)
+    }
+    analytics.TopMembers = append(analytics.TopMembers, members...)
+
+    rows, err = s.pool.Query(ctx, `
+        WITH activity AS (
+            SELECT date_trunc('minute', created_at) AS minute, 1 AS messages, 0 AS reactions FROM messages
+            WHERE chat_room_id = $1 AND created_at >= $2 AND created_at < $3
+            UNION ALL
+            SELECT date_trunc('minute', mr.created_at), 0, 1 FROM message_reactions mr
+            JOIN messages m ON m.id = mr.message_id
+            WHERE m.chat_room_id = $1 AND mr.created_at >= $2 AND mr.created_at < $3
+        )
+        SELECT minute, SUM(messages), SUM(reactions)
+        FROM activity
+        GROUP BY minute
+        ORDER BY SUM(messages) + SUM(reactions) DESC, minute
+        LIMIT $4`,
+        roomID, from, to, analyticsPeakMoments)
+    if err != nil {
+        return nil, fmt.Errorf("failed to get room peak moments: %w", err)
+    }
+    moments, err := collect(rows, func(row pgx.Row) (*models.PeakMoment, error) {
+        moment := &models.PeakMoment{}
+        if err := row.Scan(&moment.At, &moment.Messages, &moment.Reactions); err != nil {
+            return nil, err
+        }
+        return moment, nil
+    })
+    if err != nil {
+        return nil, fmt.Errorf("failed to get room peak moments: %w", err)
+    }
+    analytics.PeakMoments = append(analytics.PeakMoments, moments...)
+
+    rows, err = s.pool.Query(ctx, `
+        SELECT language, COUNT(*) FROM messages
+        WHERE chat_room_id = $1 AND created_at >= $2 AND created_at < $3 AND language IS NOT NULL
+        GROUP BY language
+        ORDER BY COUNT(*) DESC, language`,
+        roomID, from, to)
+    if err != nil {
+        return nil, fmt.Errorf("failed to get room languages: %w", err)
+    }
+    languages, err := collectLanguageShares(rows)
+    if err != nil {
+        return nil, fmt.Errorf("failed to get room languages: %w", err)
+    }
+    analytics.Languages = append(analytics.Languages, languages...)
+
+    return analytics, nil
+}
+
+func (s *Store) RecordRoomLanguage(ctx context.Context, roomID, language string) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    _, err := s.pool.Exec(ctx, `
+        INSERT INTO room_languages (chat_room_id, language, messages)
+        VALUES ($1, $2, 1)
+        ON CONFLICT (chat_room_id, language) DO UPDATE
+        SET messages = room_languages.messages + 1, updated_at = CURRENT_TIMESTAMP`,
+        roomID, language)
+    if err != nil {
+        return fmt.Errorf("failed to record room language: %w", err)
+    }
+    return nil
+}
+
+func (s *Store) GetRoomLanguages(ctx context.Context, roomID string) ([]*models.LanguageShare, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    rows, err := s.pool.Query(ctx, `
+        SELECT language, messages FROM room_languages
+        WHERE chat_room_id = $1
+        ORDER BY messages DESC, language`,
+        roomID)
+    if err != nil {
+        return nil, fmt.Errorf("failed to get room languages: %w", err)
+    }
+    languages, err := collectLanguageShares(rows)
+    if err != nil {
+        return nil, fmt.Errorf("failed to get room languages: %w", err)
+    }
+    return languages, nil
+}
+
+// collectLanguageShares reads (language, count) rows and works out each
+// language's share of the total.
+func collectLanguageShares(rows pgx.Rows) ([]*models.LanguageShare, error) {
+    languages, err := collect(rows, func(row pgx.Row) (*models.LanguageShare, error) {
+        share := &models.LanguageShare{}
+        if err := row.Scan(&share.Language, &share.Messages); err != nil {
+            return nil, err
+        }
+        return share, nil
+    })
+    if err != nil {
+        return nil, err
+    }
+    var total int64
+    for _, share := range languages {
+        total += share.Messages
+    }
+    for _, share := range languages {
+        share.Share = float64(share.Messages) / float64(total)
+    }
+    return languages, nil
+}
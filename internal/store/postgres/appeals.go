@@ -0,0 +1,104 @@
+package postgres
+
+import (
+    "context"
+    "errors"
+    "fmt"
+
+    "github.com/jackc/pgx/v5"
+
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+const appealColumns = `
+    id, user_id, action, COALESCE(chat_room_id::text, ''), COALESCE(message_id::text, ''), action_at,
+    statement, status, COALESCE(reviewed_by::text, ''), COALESCE(review_note, ''), reviewed_at, created_at`
+
+func scanAppeal(row pgx.Row) (*models.Appeal, error) {
+    a := &models.Appeal{}
+    err := row.Scan(&a.ID, &a.UserID, &a.Action, &a.RoomID, &a.MessageID, &a.ActionAt,
+        &a.Statement, &a.Status, &a.ReviewedBy, &a.ReviewNote, &a.ReviewedAt, &a.CreatedAt)
+    if err != nil {
+        return nil, err
+    }
+    return a, nil
+}
+
+func (s *Store) CreateAppeal(ctx context.Context, appeal *models.Appeal) (bool, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    err := s.pool.QueryRow(ctx, `
+        INSERT INTO appeals (user_id, action, chat_room_id, message_id, action_at, statement, status, created_at)
+        VALUES ($1, $2, NULLIF($3, '')::uuid, NULLIF($4, '')::uuid, $5, $6, COALESCE(NULLIF($7, ''), 'pending'),
+            COALESCE($8, CURRENT_TIMESTAMP))
+        ON CONFLICT DO NOTHING
+        RETURNING id, status, created_at`,
+        appeal.UserID, appeal.Action, appeal.RoomID, appeal.MessageID, appeal.ActionAt, appeal.Statement,
+        appeal.Status, nullTime(appeal.CreatedAt),
+    ).Scan(&appeal.ID, &appeal.Status, &appeal.CreatedAt)
+    if errors.Is(err, pgx.ErrNoRows) {
+        return false, nil
+    }
+    if err != nil {
+        return false, fmt.Errorf("failed to create appeal: %w", err)
+    }
+    return true, nil
+}
+
+func (s *Store) GetAppeal(ctx context.Context, id string) (*models.Appeal, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    appeal, err := scanAppeal(s.pool.QueryRow(ctx, `
+        SELECT `+appealColumns+` FROM appeals WHERE id = $1`, id))
+    if err != nil {
+        return nil, notFound(err, "appeal")
+    }
+    return appeal, nil
+}
+
+func (s *Store) GetUserAppeals(ctx context.Context, userID string) ([]*models.Appeal, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    rows, err := s.pool.Query(ctx, `
+        SELECT `+appealColumns+` FROM appeals
+        WHERE user_id = $1
+        ORDER BY created_at DESC`,
+        userID)
+    if err != nil {
+        return nil, fmt.Errorf("failed to get user appeals: %w", err)
+    }
+    return collect(rows, scanAppeal)
+}
+
+func (s *Store) ListAppeals(ctx context.Context, status string, limit int) ([]*models.Appeal, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    rows, err := s.pool.Query(ctx, `
+        SELECT `+appealColumns+` FROM appeals
+        WHERE status = $1
+        ORDER BY created_at
+        LIMIT $2`,
+        status, limit)
+    if err != nil {
+        return nil, fmt.Errorf("failed to list appeals: %w", err)
+    }
+    return collect(rows, scanAppeal)
+}
+
+func (s *Store) ReviewAppeal(ctx context.Context, id, status, reviewerID, note string) (bool, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    tag, err := s.pool.Exec(ctx, `
+        UPDATE appeals SET status = $2, reviewed_by = $3, review_note = NULLIF($4, ''), reviewed_at = CURRENT_TIMESTAMP
+        WHERE id = $1 AND status = 'pending'`,
+        id, status, reviewerID, note)
+    if err != nil {
+        return false, fmt.Errorf("failed to review appeal: %w", err)
+    }
+    return tag.RowsAffected() > 0, nil
+}
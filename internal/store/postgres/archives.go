@@ -0,0 +1,138 @@
+package postgres
+
+import (
+    "context"
+    "fmt"
+    "time"
+
+    "github.com/jackc/pgx/v5"
+
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/store"
+)
+
+const archiveColumns = `id, chat_room_id, day, object_key, messages, size, first_at, last_at, created_at, user_ids`
+
+func scanArchive(row pgx.Row) (*models.MessageArchive, error) {
+    a := &models.MessageArchive{}
+    err := row.Scan(&a.ID, &a.RoomID, &a.Day, &a.ObjectKey, &a.Messages, &a.Size, &a.FirstAt, &a.LastAt, &a.CreatedAt, &a.UserIDs)
+    if err != nil {
+        return nil, err
+    }
+    return a, nil
+}
+
+func (s *Store) GetArchiveCandidates(ctx context.Context, before time.Time, limit int) ([]store.ArchiveCandidate, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    rows, err := s.pool.Query(ctx, `
+        SELECT chat_room_id, (created_at AT TIME ZONE 'UTC')::date AS day
+        FROM messages
+        WHERE created_at < $1 AND chat_room_id IS NOT NULL
+        GROUP BY chat_room_id, day
+        ORDER BY day, chat_room_id
+        LIMIT $2`,
+        before, limit)
+    if err != nil {
+        return nil, fmt.Errorf("failed to get archive candidates: %w", err)
+    }
+    defer rows.Close()
+
+    var candidates []store.ArchiveCandidate
+    for rows.Next() {
+        var c store.ArchiveCandidate
+        if err := rows.Scan(&c.RoomID, &c.Day); err != nil {
+            return nil, fmt.Errorf("failed to get archive candidates: %w", err)
+        }
+        candidates = append(candidates, c)
+    }
+    return candidates, rows.Err()
+}
+
+func (s *Store) CreateMessageArchive(ctx context.Context, archive *models.MessageArchive, messageIDs []string) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    userIDs, err := jsonArray(archive.UserIDs)
+    if err != nil {
+        return fmt.Errorf("failed to create message archive: %w", err)
+    }
+    err = s.inTx(ctx, func(tx pgx.Tx) error {
+        _, err := tx.Exec(ctx, `
+            INSERT INTO message_archives (chat_room_id, day, object_key, messages, size, first_at, last_at, user_ids)
+            VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+            ON CONFLICT (object_key) DO NOTHING`,
+            archive.RoomID, archive.Day, archive.ObjectKey, archive.Messages, archive.Size, archive.FirstAt, archive.LastAt, userIDs)
+        if err != nil {
+            return err
+        }
+        _, err = tx.Exec(ctx, `DELETE FROM messages WHERE id = ANY($1::uuid[])`, messageIDs)
+        return err
+    })
+    if err != nil {
+        return fmt.Errorf("failed to create message archive: %w", err)
+    }
+    return nil
+}
+
+func (s *Store) ListMessageArchives(ctx context.Context, roomID string, before time.Time, limit int) ([]*models.MessageArchive, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    rows, err := s.pool.Query(ctx, `
+        SELECT `+archiveColumns+` FROM message_archives
+        WHERE chat_room_id = $1 AND first_at <= $2
+        ORDER BY first_at DESC
+        LIMIT $3`,
+        roomID, before, limit)
+    if err != nil {
+        return nil, fmt.Errorf("failed to list message archives: %w", err)
+    }
+    return collect(rows, scanArchive)
+}
+
+func (s *Store) ListUserMessageArchives(ctx context.Context, userID, after string, limit int) ([]*models.MessageArchive, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    rows, err := s.pool.Query(ctx, `
+        SELECT `+archiveColumns+` FROM message_archives
+        WHERE (user_ids IS NULL OR user_ids ? $1) AND ($2 = '' OR id > $2::uuid)
+        ORDER BY id
+        LIMIT $3`,
+        userID, after, limit)
+    if err != nil {
+        return nil, fmt.Errorf("failed to list user message archives: %w", err)
+    }
+    return collect(rows, scanArchive)
+}
+
+func (s *Store) UpdateMessageArchive(ctx context.Context, archive *models.MessageArchive) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    userIDs, err := jsonArray(archive.UserIDs)
+    if err != nil {
+        return fmt.Errorf("failed to update message archive: %w", err)
+    }
+    _, err = s.pool.Exec(ctx, `
+        UPDATE message_archives
+        SET object_key = $2, messages = $3, size = $4, first_at = $5, last_at = $6, user_ids = $7
+        WHERE id = $1`,
+        archive.ID, archive.ObjectKey, archive.Messages, archive.Size, archive.FirstAt, archive.LastAt, userIDs)
+    if err != nil {
+        return fmt.Errorf("failed to update message archive: %w", err)
+    }
+    return nil
+}
+
+func (s *Store) DeleteMessageArchive(ctx context.Context, id string) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    if _, err := s.pool.Exec(ctx, `DELETE FROM message_archives WHERE id = $1`, id); err != nil {
+        return fmt.Errorf("failed to delete message archive: %w", err)
+    }
+    return nil
+}
@@ -0,0 +1,68 @@
+package postgres
+
+import (
+    "context"
+    "fmt"
+
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+func (s *Store) CreateAttachment(ctx context.Context, attachment *models.Attachment, data []byte) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    attachment.Size = len(data)
+    attachment.Status = models.AttachmentPending
+    err := s.pool.QueryRow(ctx, `
+        INSERT INTO attachments (chat_room_id, user_id, content_type, size, data, status)
+        VALUES ($1, $2, $3, $4, $5, $6)
+        RETURNING id, created_at`,
+        attachment.ChatRoomID, attachment.UserID, attachment.ContentType, attachment.Size, data, attachment.Status,
+    ).Scan(&attachment.ID, &attachment.CreatedAt)
+    if err != nil {
+        return fmt.Errorf("failed to create attachment: %w", err)
+    }
+    return nil
+}
+
+func (s *Store) GetAttachment(ctx context.Context, id string) (*models.Attachment, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    a := &models.Attachment{}
+    err := s.pool.QueryRow(ctx, `
+        SELECT id, chat_room_id, user_id, content_type, size, status, COALESCE(reason, ''), created_at, scanned_at
+        FROM attachments WHERE id = $1`,
+        id,
+    ).Scan(&a.ID, &a.ChatRoomID, &a.UserID, &a.ContentType, &a.Size, &a.Status, &a.Reason, &a.CreatedAt, &a.ScannedAt)
+    if err != nil {
+        return nil, notFound(err, "attachment")
+    }
+    return a, nil
+}
+
+func (s *Store) GetAttachmentData(ctx context.Context, id string) ([]byte, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    var data []byte
+    err := s.pool.QueryRow(ctx, `SELECT data FROM attachments WHERE id = $1`, id).Scan(&data)
+    if err != nil {
+        return nil, notFound(err, "attachment")
+    }
+    return data, nil
+}
+
+func (s *Store) SetAttachmentStatus(ctx context.Context, id, status, reason string) (bool, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    ok, err := affected(s.pool.Exec(ctx, `
+        UPDATE attachments SET status = $2, reason = NULLIF($3, ''), scanned_at = CURRENT_TIMESTAMP
+        WHERE id = $1 AND status = 'pending'`,
+        id, status, reason))
+    if err != nil {
+        return false, fmt.Errorf("failed to set attachment status: %w", err)
+    }
+    return ok, nil
+}
@@ -0,0 +1,229 @@
+package postgres
+
+import (
+    "context"
+    "fmt"
+    "strings"
+    "time"
+
+    "github.com/jackc/pgx/v5"
+
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/store"
+)
+
+const conversationColumns = `c.id, c.user_a, c.user_b, c.last_message_at, c.created_at`
+
+func scanConversation(row pgx.Row) (*models.Conversation, error) {
+    c := &models.Conversation{}
+    if err := row.Scan(&c.ID, &c.UserA, &c.UserB, &c.LastMessageAt, &c.CreatedAt); err != nil {
+        return nil, err
+    }
+    return c, nil
+}
+
+// GetOrCreateConversation orders the pair the way the table's check does:
+// UUIDs compare like their lowercase text.
+func (s *Store) GetOrCreateConversation(ctx context.Context, userA, userB string) (*models.Conversation, error) {
+    userA, userB = strings.ToLower(userA), strings.ToLower(userB)
+    if userB < userA {
+        userA, userB = userB, userA
+    }
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    conversation, err := scanConversation(s.pool.QueryRow(ctx, `
+        INSERT INTO conversations AS c (user_a, user_b) VALUES ($1, $2)
+        ON CONFLICT (user_a, user_b) DO UPDATE SET user_a = EXCLUDED.user_a
+        RETURNING `+conversationColumns,
+        userA, userB))
+    if err != nil {
+        return nil, fmt.Errorf("failed to get or create conversation: %w", err)
+    }
+    return conversation, nil
+}
+
+func (s *Store) GetConversation(ctx context.Context, id string) (*models.Conversation, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    conversation, err := scanConversation(s.pool.QueryRow(ctx, `
+        SELECT `+conversationColumns+` FROM conversations c WHERE c.id = $1`, id))
+    if err != nil {
+        return nil, notFound(err, "conversation")
+    }
+    return conversation, nil
+}
+
+func (s *Store) GetUserConversations(ctx context.Context, userID string, limit int) ([]*models.Conversation, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    rows, err := s.pool.Query(ctx, `
+        SELECT `+conversationColumns+`,
+            o.id, o.username, COALESCE(o.avatar_url, ''), COALESCE(o.favorite_team, ''),
+            dm.id, dm.sender_id, dm.content, dm.created_at
+        FROM conversations c
+        JOIN users o ON o.id = CASE WHEN c.user_a = $1 THEN c.user_b ELSE c.user_a END
+        LEFT JOIN LATERAL (
+            SELECT id, sender_id, content, created_at FROM direct_messages
+            WHERE conversation_id = c.id
+            ORDER BY created_at DESC, id DESC
+            LIMIT 1
+        ) dm ON true
+        WHERE c.user_a = $1 OR c.user_b = $1
+        ORDER BY c.last_message_at DESC NULLS LAST, c.created_at DESC
+        LIMIT $2`,
+        userID, limit)
+    if err != nil {
+        return nil, fmt.Errorf("failed to get conversations: %w", err)
+    }
+    return collect(rows, func(row pgx.Row) (*models.Conversation, error) {
+        c := &models.Conversation{Other: &models.UserSummary{}}
+        var lastID, lastSender, lastContent *string
+        var lastAt *time.Time
+        err := row.Scan(&c.ID, &c.UserA, &c.UserB, &c.LastMessageAt, &c.CreatedAt,
+            &c.Other.ID, &c.Other.Username, &c.Other.AvatarURL, &c.Other.Flair,
+            &lastID, &lastSender, &lastContent, &lastAt)
+        if err != nil {
+            return nil, err
+        }
+        if lastID != nil {
+            c.LastMessage = &models.DirectMessage{
+                ID:             *lastID,
+                ConversationID: c.ID,
+                SenderID:       *lastSender,
+                Content:        *lastContent,
+                CreatedAt:      *lastAt,
+            }
+        }
+        return c, nil
+    })
+}
+
+func (s *Store) CreateDirectMessage(ctx context.Context, msg *models.DirectMessage) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    err := s.inTx(ctx, func(tx pgx.Tx) error {
+        err := tx.QueryRow(ctx, `
+            INSERT INTO direct_messages (id, conversation_id, sender_id, content, created_at)
+            VALUES (COALESCE(NULLIF($1, '')::uuid, uuid_generate_v4()), $2, $3, $4, COALESCE($5, CURRENT_TIMESTAMP))
+            RETURNING id, created_at`,
+            msg.ID, msg.ConversationID, msg.SenderID, msg.Content, nullTime(msg.CreatedAt),
+        ).Scan(&msg.ID, &msg.CreatedAt)
+        if err != nil {
+            return err
+        }
+        _, err = tx.Exec(ctx, `
+            UPDATE conversations SET last_message_at = GREATEST(COALESCE(last_message_at, $2), $2)
+            WHERE id = $1`,
+            msg.ConversationID, msg.CreatedAt)
+        return err
+    })
+    if err != nil {
+        return fmt.Errorf("failed to create direct message: %w", err)
+    }
+    return nil
+}
+
+func (s *Store) GetDirectMessagesBeforeCursor(ctx context.Context, conversationID string, before *store.MessageCursor, limit int) ([]*models.DirectMessage, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    var rows pgx.Rows
+    var err error
+    if before == nil {
+        rows, err = s.pool.Query(ctx, `
+            SELECT id, conversation_id, sender_id, content, created_at FROM direct_messages
+            WHERE conversation_id = $1
+            ORDER BY created_at DESC, id DESC
+            LIMIT $2`,
+            conversationID, limit)
+    } else {
+        rows, err = s.pool.Query(ctx, `
+            SELECT id, conversation_id, sender_id, content, created_at FROM direct_messages
+            WHERE conversation_id = $1 AND (created_at, id) < ($2, $3::uuid)
+            ORDER BY created_at DESC, id DESC
+            LIMIT $4`,
+            conversationID, before.CreatedAt, before.ID, limit)
+    }
+    if err != nil {
+        return nil, fmt.Errorf("failed to get direct messages: %w", err)
+    }
+    return collect(rows, func(row pgx.Row) (*models.DirectMessage, error) {
+        m := &models.DirectMessage{}
+        if err := row.Scan(&m.ID, &m.ConversationID, &m.SenderID, &m.Content, &m.CreatedAt); err != nil {
+            return nil, err
+        }
+        return m, nil
+    })
+}
+
+func (s *Store) BlockUser(ctx context.Context, block *models.UserBlock) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    err := s.pool.QueryRow(ctx, `
+        INSERT INTO user_blocks (user_id, blocked_id, created_at)
+        VALUES ($1, $2, COALESCE($3, CURRENT_TIMESTAMP))
+        ON CONFLICT (user_id, blocked_id) DO UPDATE SET user_id = EXCLUDED.user_id
+        RETURNING created_at`,
+        block.UserID, block.BlockedID, nullTime(block.CreatedAt),
+    ).Scan(&block.CreatedAt)
+    if err != nil {
+        return fmt.Errorf("failed to block user: %w", err)
+    }
+    return nil
+}
+
+func (s *Store) UnblockUser(ctx context.Context, userID, blockedID string) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    if _, err := s.pool.Exec(ctx, `
+        DELETE FROM user_blocks WHERE user_id = $1 AND blocked_id = $2`,
+        userID, blockedID); err != nil {
+        return fmt.Errorf("failed to unblock user: %w", err)
+    }
+    return nil
+}
+
+func (s *Store) GetBlockedUsers(ctx context.Context, userID string) ([]*models.UserBlock, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    rows, err := s.pool.Query(ctx, `
+        SELECT user_id, blocked_id, created_at FROM user_blocks
+        WHERE user_id = $1
+        ORDER BY created_at DESC`,
+        userID)
+    if err != nil {
+        return nil, fmt.Errorf("failed to get blocked users: %w", err)
+    }
+    return collect(rows, func(row pgx.Row) (*models.UserBlock, error) {
+        b := &models.UserBlock{}
+        if err := row.Scan(&b.UserID, &b.BlockedID, &b.CreatedAt); err != nil {
+            return nil, err
+        }
+        return b, nil
+    })
+}
+
+func (s *Store) IsBlocked(ctx context.Context, userA, userB string) (bool, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    var blocked bool
+    err := s.pool.QueryRow(ctx, `
+        SELECT EXISTS (
+            SELECT 1 FROM user_blocks
+            WHERE (user_id = $1 AND blocked_id = $2) OR (user_id = $2 AND blocked_id = $1)
+        )`,
+        userA, userB,
+    ).Scan(&blocked)
+    if err != nil {
+        return false, fmt.Errorf("failed to check block: %w", err)
+    }
+    return blocked, nil
+}
@@ -0,0 +1,177 @@
+package postgres
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "time"
+
+    "github.com/jackc/pgx/v5"
+
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+const emoteColumns = `e.id, e.pack_id, e.shortcode, e.image_url, e.created_at`
+
+func scanEmote(row pgx.Row) (*models.Emote, error) {
+    e := &models.Emote{}
+    if err := row.Scan(&e.ID, &e.PackID, &e.Shortcode, &e.ImageURL, &e.CreatedAt); err != nil {
+        return nil, err
+    }
+    return e, nil
+}
+
+const emotePackColumns = `
+    id, name, COALESCE(team_id::text, ''), COALESCE(created_by::text, ''), published_at, created_at`
+
+func scanEmotePack(row pgx.Row) (*models.EmotePack, error) {
+    p := &models.EmotePack{}
+    if err := row.Scan(&p.ID, &p.Name, &p.TeamID, &p.CreatedBy, &p.PublishedAt, &p.CreatedAt); err != nil {
+        return nil, err
+    }
+    return p, nil
+}
+
+func (s *Store) CreateEmotePack(ctx context.Context, pack *models.EmotePack) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    err := s.pool.QueryRow(ctx, `
+        INSERT INTO emote_packs (name, team_id, created_by, created_at)
+        VALUES ($1, NULLIF($2, '')::uuid, NULLIF($3, '')::uuid, COALESCE($4, CURRENT_TIMESTAMP))
+        RETURNING id, created_at`,
+        pack.Name, pack.TeamID, pack.CreatedBy, nullTime(pack.CreatedAt),
+    ).Scan(&pack.ID, &pack.CreatedAt)
+    if err != nil {
+        return fmt.Errorf("failed to create emote pack: %w", err)
+    }
+    return nil
+}
+
+// GetEmotePack loads a pack, published or not, with its emotes.
+func (s *Store) GetEmotePack(ctx context.Context, id string) (*models.EmotePack, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    pack, err := scanEmotePack(s.pool.QueryRow(ctx, `SELECT `+emotePackColumns+` FROM emote_packs WHERE id = $1`, id))
+    if err != nil {
+        return nil, notFound(err, "emote pack")
+    }
+
+    rows, err := s.pool.Query(ctx, `
+        SELECT `+emoteColumns+` FROM emotes e
+        WHERE e.pack_id = $1
+        ORDER BY e.shortcode`,
+        id)
+    if err != nil {
+        return nil, fmt.Errorf("failed to get emotes: %w", err)
+    }
+    if pack.Emotes, err = collect(rows, scanEmote); err != nil {
+        return nil, err
+    }
+    return pack, nil
+}
+
+func (s *Store) AddEmote(ctx context.Context, emote *models.Emote) (bool, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    err := s.pool.QueryRow(ctx, `
+        INSERT INTO emotes (pack_id, shortcode, image_url, created_at)
+        VALUES ($1, $2, $3, COALESCE($4, CURRENT_TIMESTAMP))
+        ON CONFLICT (pack_id, shortcode) DO NOTHING
+        RETURNING id, created_at`,
+        emote.PackID, emote.Shortcode, emote.ImageURL, nullTime(emote.CreatedAt),
+    ).Scan(&emote.ID, &emote.CreatedAt)
+    if errors.Is(err, pgx.ErrNoRows) {
+        return false, nil
+    }
+    if err != nil {
+        return false, fmt.Errorf("failed to add emote: %w", err)
+    }
+    return true, nil
+}
+
+func (s *Store) DeleteEmote(ctx context.Context, id string) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    if _, err := s.pool.Exec(ctx, `DELETE FROM emotes WHERE id = $1`, id); err != nil {
+        return fmt.Errorf("failed to delete emote: %w", err)
+    }
+    return nil
+}
+
+func (s *Store) PublishEmotePack(ctx context.Context, id string, at time.Time) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    if _, err := s.pool.Exec(ctx, `UPDATE emote_packs SET published_at = $2 WHERE id = $1`, id, at); err != nil {
+        return fmt.Errorf("failed to publish emote pack: %w", err)
+    }
+    return nil
+}
+
+// GetPublishedEmotePacks lists the site-wide packs first, then the teams'
+// packs, each in the order it was created.
+func (s *Store) GetPublishedEmotePacks(ctx context.Context, teamIDs []string) ([]*models.EmotePack, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    rows, err := s.pool.Query(ctx, `
+        SELECT `+emotePackColumns+` FROM emote_packs
+        WHERE published_at IS NOT NULL AND (team_id IS NULL OR team_id = ANY($1::uuid[]))
+        ORDER BY team_id NULLS FIRST, created_at`,
+        teamIDs)
+    if err != nil {
+        return nil, fmt.Errorf("failed to get emote packs: %w", err)
+    }
+    packs, err := collect(rows, scanEmotePack)
+    if err != nil || len(packs) == 0 {
+        return packs, err
+    }
+
+    byID := make(map[string]*models.EmotePack, len(packs))
+    ids := make([]string, len(packs))
+    for i, pack := range packs {
+        byID[pack.ID] = pack
+        ids[i] = pack.ID
+    }
+    rows, err = s.pool.Query(ctx, `
+        SELECT `+emoteColumns+` FROM emotes e
+        WHERE e.pack_id = ANY($1::uuid[])
+        ORDER BY e.shortcode`,
+        ids)
+    if err != nil {
+        return nil, fmt.Errorf("failed to get emotes: %w", err)
+    }
+    emotes, err := collect(rows, scanEmote)
+    if err != nil {
+        return nil, err
+    }
+    for _, emote := range emotes {
+        pack := byID[emote.PackID]
+        pack.Emotes = append(pack.Emotes, emote)
+    }
+    return packs, nil
+}
+
+// SearchEmotes matches shortcodes case-sensitively, as they are stored
+// lowercase, shortest first so an exact match leads.
+func (s *Store) SearchEmotes(ctx context.Context, teamIDs []string, prefix string, limit int) ([]*models.Emote, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    rows, err := s.pool.Query(ctx, `
+        SELECT `+emoteColumns+` FROM emotes e
+        JOIN emote_packs p ON p.id = e.pack_id
+        WHERE p.published_at IS NOT NULL AND (p.team_id IS NULL OR p.team_id = ANY($1::uuid[]))
+          AND starts_with(e.shortcode, $2)
+        ORDER BY length(e.shortcode), e.shortcode
+        LIMIT $3`,
+        teamIDs, prefix, limit)
+    if err != nil {
+        return nil, fmt.Errorf("failed to search emotes: %w", err)
+    }
+    return collect(rows, scanEmote)
+}
@@ -0,0 +1,35 @@
+package postgres
+
+import (
+    "errors"
+    "strings"
+
+    "github.com/jackc/pgx/v5/pgconn"
+
+    "github.com/yourusername/sports-chat/internal/store"
+)
+
+// Classify sorts an error from this store for retries. Only errors known
+// to leave the database untouched are retryable: an error after a
+// statement was sent may hide a commit, so it is permanent.
+func Classify(err error) store.ErrorClass {
+    var pgErr *pgconn.PgError
+    if errors.As(err, &pgErr) {
+        switch {
+        case pgErr.Code == "40001", pgErr.Code == "40P01":
+            // serialization_failure, deadlock_detected
+            return store.ErrorTransient
+        case pgErr.Code == "57P03", pgErr.Code == "53300", strings.HasPrefix(pgErr.Code, "08"):
+            // cannot_connect_now, too_many_connections, connection
+            // exceptions, all raised before the statement ran
+            return store.ErrorUnavailable
+        }
+        return store.ErrorPermanent
+    }
+
+    var connectErr *pgconn.ConnectError
+    if errors.As(err, &connectErr) || pgconn.SafeToRetry(err) {
+        return store.ErrorUnavailable
+    }
+    return store.ErrorPermanent
+}
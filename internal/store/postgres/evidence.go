@@ -0,0 +1,139 @@
+package postgres
+
+import (
+    "context"
+    "fmt"
+    "time"
+
+    "github.com/jackc/pgx/v5"
+
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+const evidenceColumns = `
+    id, message_id, chat_room_id, user_id, deleted_by, COALESCE(reason, ''), key_id, sealed, created_at, expires_at`
+
+func scanEvidence(row pgx.Row) (*models.ModerationEvidence, error) {
+    e := &models.ModerationEvidence{}
+    err := row.Scan(&e.ID, &e.MessageID, &e.RoomID, &e.UserID, &e.DeletedBy, &e.Reason, &e.KeyID, &e.Sealed,
+        &e.CreatedAt, &e.ExpiresAt)
+    if err != nil {
+        return nil, err
+    }
+    return e, nil
+}
+
+func (s *Store) CreateModerationEvidence(ctx context.Context, evidence *models.ModerationEvidence) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    err := s.pool.QueryRow(ctx, `
+        INSERT INTO moderation_evidence (id, message_id, chat_room_id, user_id, deleted_by, reason, key_id, sealed, created_at, expires_at)
+        VALUES (COALESCE(NULLIF($1, '')::uuid, uuid_generate_v4()), $2, $3, $4, $5, NULLIF($6, ''), $7, $8,
+            COALESCE($9, CURRENT_TIMESTAMP), $10)
+        RETURNING id, created_at`,
+        evidence.ID, evidence.MessageID, evidence.RoomID, evidence.UserID, evidence.DeletedBy, evidence.Reason,
+        evidence.KeyID, evidence.Sealed, nullTime(evidence.CreatedAt), evidence.ExpiresAt,
+    ).Scan(&evidence.ID, &evidence.CreatedAt)
+    if err != nil {
+        return fmt.Errorf("failed to create moderation evidence: %w", err)
+    }
+    return nil
+}
+
+// GetModerationEvidence treats expired evidence as gone, whether or not
+// it has been purged yet.
+func (s *Store) GetModerationEvidence(ctx context.Context, id string) (*models.ModerationEvidence, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    evidence, err := scanEvidence(s.pool.QueryRow(ctx, `
+        SELECT `+evidenceColumns+` FROM moderation_evidence
+        WHERE id = $1 AND expires_at > CURRENT_TIMESTAMP`, id))
+    if err != nil {
+        return nil, notFound(err, "moderation evidence")
+    }
+    return evidence, nil
+}
+
+func (s *Store) GetMessageEvidence(ctx context.Context, messageID string) (*models.ModerationEvidence, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    evidence, err := scanEvidence(s.pool.QueryRow(ctx, `
+        SELECT `+evidenceColumns+` FROM moderation_evidence
+        WHERE message_id = $1 AND expires_at > CURRENT_TIMESTAMP
+        ORDER BY created_at DESC
+        LIMIT 1`, messageID))
+    if err != nil {
+        return nil, notFound(err, "moderation evidence")
+    }
+    return evidence, nil
+}
+
+func (s *Store) ListModerationEvidence(ctx context.Context, userID string, limit int) ([]*models.ModerationEvidence, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    rows, err := s.pool.Query(ctx, `
+        SELECT `+evidenceColumns+` FROM moderation_evidence
+        WHERE ($1 = '' OR user_id::text = $1) AND expires_at > CURRENT_TIMESTAMP
+        ORDER BY created_at DESC
+        LIMIT $2`,
+        userID, limit)
+    if err != nil {
+        return nil, fmt.Errorf("failed to list moderation evidence: %w", err)
+    }
+    return collect(rows, scanEvidence)
+}
+
+// PurgeExpiredModerationEvidence deletes evidence past its expiry, with
+// its access log. now must not run ahead of the database's clock, which
+// refuses to delete unexpired evidence.
+func (s *Store) PurgeExpiredModerationEvidence(ctx context.Context, now time.Time) (int64, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    tag, err := s.pool.Exec(ctx, `DELETE FROM moderation_evidence WHERE expires_at <= LEAST($1, CURRENT_TIMESTAMP)`, now)
+    if err != nil {
+        return 0, fmt.Errorf("failed to purge moderation evidence: %w", err)
+    }
+    return tag.RowsAffected(), nil
+}
+
+func (s *Store) RecordEvidenceAccess(ctx context.Context, access *models.EvidenceAccess) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    err := s.pool.QueryRow(ctx, `
+        INSERT INTO evidence_access (evidence_id, admin_id, purpose, accessed_at)
+        VALUES ($1, $2, $3, COALESCE($4, CURRENT_TIMESTAMP))
+        RETURNING accessed_at`,
+        access.EvidenceID, access.AdminID, access.Purpose, nullTime(access.AccessedAt),
+    ).Scan(&access.AccessedAt)
+    if err != nil {
+        return fmt.Errorf("failed to record evidence access: %w", err)
+    }
+    return nil
+}
+
+func (s *Store) GetEvidenceAccess(ctx context.Context, evidenceID string) ([]*models.EvidenceAccess, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    rows, err := s.pool.Query(ctx, `
+        SELECT evidence_id, admin_id, purpose, accessed_at FROM evidence_access
+        WHERE evidence_id = $1
+        ORDER BY accessed_at`,
+        evidenceID)
+    if err != nil {
+        return nil, fmt.Errorf("failed to get evidence access: %w", err)
+    }
+    return collect(rows, func(row pgx.Row) (*models.EvidenceAccess, error) {
+        a := &models.EvidenceAccess{}
+        if err := row.Scan(&a.EvidenceID, &a.AdminID, &a.Purpose, &a.AccessedAt); err != nil {
+            return nil, err
+        }
+        return a, nil
+    })
+}
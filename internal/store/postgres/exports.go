@@ -0,0 +1,109 @@
+package postgres
+
+import (
+    "context"
+    "fmt"
+    "time"
+
+    "github.com/jackc/pgx/v5"
+
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+const exportColumns = `
+    id, user_id, format, status, object_key, size, error, created_at, completed_at, expires_at`
+
+func scanExport(row pgx.Row) (*models.DataExport, error) {
+    e := &models.DataExport{}
+    err := row.Scan(&e.ID, &e.UserID, &e.Format, &e.Status, &e.ObjectKey, &e.Size, &e.Error,
+        &e.CreatedAt, &e.CompletedAt, &e.ExpiresAt)
+    if err != nil {
+        return nil, err
+    }
+    return e, nil
+}
+
+func (s *Store) CreateDataExport(ctx context.Context, export *models.DataExport) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    err := s.pool.QueryRow(ctx, `
+        INSERT INTO data_exports (id, user_id, format, status)
+        VALUES (COALESCE(NULLIF($1, '')::uuid, uuid_generate_v4()), $2, $3, $4)
+        RETURNING id, created_at`,
+        export.ID, export.UserID, export.Format, export.Status,
+    ).Scan(&export.ID, &export.CreatedAt)
+    if err != nil {
+        return fmt.Errorf("failed to create data export: %w", err)
+    }
+    return nil
+}
+
+func (s *Store) GetDataExport(ctx context.Context, id string) (*models.DataExport, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    export, err := scanExport(s.pool.QueryRow(ctx, `SELECT `+exportColumns+` FROM data_exports WHERE id = $1`, id))
+    if err != nil {
+        return nil, notFound(err, "data export")
+    }
+    return export, nil
+}
+
+func (s *Store) ListUserDataExports(ctx context.Context, userID string) ([]*models.DataExport, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    rows, err := s.pool.Query(ctx, `
+        SELECT `+exportColumns+` FROM data_exports
+        WHERE user_id = $1
+        ORDER BY created_at DESC`,
+        userID)
+    if err != nil {
+        return nil, fmt.Errorf("failed to list data exports: %w", err)
+    }
+    return collect(rows, scanExport)
+}
+
+func (s *Store) CompleteDataExport(ctx context.Context, export *models.DataExport) (bool, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    ok, err := affected(s.pool.Exec(ctx, `
+        UPDATE data_exports
+        SET status = $2, object_key = $3, size = $4, error = $5, completed_at = $6, expires_at = $7
+        WHERE id = $1 AND status = 'pending'`,
+        export.ID, export.Status, export.ObjectKey, export.Size, export.Error, export.CompletedAt, export.ExpiresAt))
+    if err != nil {
+        return false, fmt.Errorf("failed to complete data export: %w", err)
+    }
+    return ok, nil
+}
+
+// ListExpiredDataExports returns exports that expired before the time,
+// oldest first.
+func (s *Store) ListExpiredDataExports(ctx context.Context, before time.Time, limit int) ([]*models.DataExport, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    rows, err := s.pool.Query(ctx, `
+        SELECT `+exportColumns+` FROM data_exports
+        WHERE expires_at < $1
+        ORDER BY expires_at
+        LIMIT $2`,
+        before, limit)
+    if err != nil {
+        return nil, fmt.Errorf("failed to list expired data exports: %w", err)
+    }
+    return collect(rows, scanExport)
+}
+
+func (s *Store) DeleteDataExport(ctx context.Context, id string) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    if _, err := s.pool.Exec(ctx, `DELETE FROM data_exports WHERE id = $1`, id); err != nil {
+        return fmt.Errorf("failed to delete data export: %w", err)
+    }
+    return nil
+}
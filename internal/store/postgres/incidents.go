@@ -0,0 +1,69 @@
+package postgres
+
+import (
+    "context"
+    "fmt"
+
+    "github.com/jackc/pgx/v5"
+
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+const incidentColumns = `
+    id, chat_room_id, COALESCE(captured_by::text, ''), COALESCE(note, ''), window_start, window_end,
+    object_key, size, created_at`
+
+func scanIncident(row pgx.Row) (*models.Incident, error) {
+    i := &models.Incident{}
+    err := row.Scan(&i.ID, &i.RoomID, &i.CapturedBy, &i.Note, &i.From, &i.To, &i.ObjectKey, &i.Size, &i.CreatedAt)
+    if err != nil {
+        return nil, err
+    }
+    return i, nil
+}
+
+func (s *Store) CreateIncident(ctx context.Context, incident *models.Incident) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    err := s.pool.QueryRow(ctx, `
+        INSERT INTO incidents (id, chat_room_id, captured_by, note, window_start, window_end, object_key, size)
+        VALUES (COALESCE(NULLIF($1, '')::uuid, uuid_generate_v4()), $2, NULLIF($3, '')::uuid, NULLIF($4, ''), $5, $6, $7, $8)
+        RETURNING id, created_at`,
+        incident.ID, incident.RoomID, incident.CapturedBy, incident.Note, incident.From, incident.To,
+        incident.ObjectKey, incident.Size,
+    ).Scan(&incident.ID, &incident.CreatedAt)
+    if err != nil {
+        return fmt.Errorf("failed to create incident: %w", err)
+    }
+    return nil
+}
+
+func (s *Store) GetIncident(ctx context.Context, id string) (*models.Incident, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    incident, err := scanIncident(s.pool.QueryRow(ctx, `SELECT `+incidentColumns+` FROM incidents WHERE id = $1`, id))
+    if err != nil {
+        return nil, notFound(err, "incident")
+    }
+    return incident, nil
+}
+
+// ListIncidents lists the latest incidents, of one room or of all for an
+// empty roomID, newest first.
+func (s *Store) ListIncidents(ctx context.Context, roomID string, limit int) ([]*models.Incident, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    rows, err := s.pool.Query(ctx, `
+        SELECT `+incidentColumns+` FROM incidents
+        WHERE $1 = '' OR chat_room_id::text = $1
+        ORDER BY created_at DESC
+        LIMIT $2`,
+        roomID, limit)
+    if err != nil {
+        return nil, fmt.Errorf("failed to list incidents: %w", err)
+    }
+    return collect(rows, scanIncident)
+}
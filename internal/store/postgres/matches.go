@@ -0,0 +1,581 @@
+package postgres
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "time"
+
+    "github.com/jackc/pgx/v5"
+
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+func (s *Store) CreateSport(ctx context.Context, sport *models.Sport) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    err := s.pool.QueryRow(ctx, `
+        INSERT INTO sports (name, description) VALUES ($1, NULLIF($2, ''))
+        RETURNING id, created_at`,
+        sport.Name, sport.Description,
+    ).Scan(&sport.ID, &sport.CreatedAt)
+    if err != nil {
+        return fmt.Errorf("failed to create sport: %w", err)
+    }
+    return nil
+}
+
+const sportColumns = `id, name, COALESCE(description, ''), created_at`
+
+func scanSport(row pgx.Row) (*models.Sport, error) {
+    sport := &models.Sport{}
+    if err := row.Scan(&sport.ID, &sport.Name, &sport.Description, &sport.CreatedAt); err != nil {
+        return nil, err
+    }
+    return sport, nil
+}
+
+func (s *Store) GetSport(ctx context.Context, id string) (*models.Sport, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    sport, err := scanSport(s.pool.QueryRow(ctx, `SELECT `+sportColumns+` FROM sports WHERE id = $1`, id))
+    if err != nil {
+        return nil, notFound(err, "sport")
+    }
+    return sport, nil
+}
+
+func (s *Store) ListSports(ctx context.Context) ([]*models.Sport, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    rows, err := s.pool.Query(ctx, `SELECT `+sportColumns+` FROM sports ORDER BY name`)
+    if err != nil {
+        return nil, fmt.Errorf("failed to list sports: %w", err)
+    }
+    return collect(rows, scanSport)
+}
+
+func (s *Store) UpdateSport(ctx context.Context, sport *models.Sport) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    if _, err := s.pool.Exec(ctx, `
+        UPDATE sports SET name = $2, description = NULLIF($3, '') WHERE id = $1`,
+        sport.ID, sport.Name, sport.Description); err != nil {
+        return fmt.Errorf("failed to update sport: %w", err)
+    }
+    return nil
+}
+
+func (s *Store) DeleteSport(ctx context.Context, id string) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    if _, err := s.pool.Exec(ctx, `DELETE FROM sports WHERE id = $1`, id); err != nil {
+        return fmt.Errorf("failed to delete sport: %w", err)
+    }
+    return nil
+}
+
+const teamColumns = `id, name, COALESCE(sport_id::text, ''), COALESCE(logo_url, ''), created_at`
+
+func scanTeam(row pgx.Row) (*models.Team, error) {
+    team := &models.Team{}
+    if err := row.Scan(&team.ID, &team.Name, &team.SportID, &team.LogoURL, &team.CreatedAt); err != nil {
+        return nil, err
+    }
+    return team, nil
+}
+
+func (s *Store) CreateTeam(ctx context.Context, team *models.Team) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    err := s.pool.QueryRow(ctx, `
+        INSERT INTO teams (name, sport_id, logo_url) VALUES ($1, NULLIF($2, '')::uuid, NULLIF($3, ''))
+        RETURNING id, created_at`,
+        team.Name, team.SportID, team.LogoURL,
+    ).Scan(&team.ID, &team.CreatedAt)
+    if err != nil {
+        return fmt.Errorf("failed to create team: %w", err)
+    }
+    return nil
+}
+
+func (s *Store) GetTeam(ctx context.Context, id string) (*models.Team, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    team, err := scanTeam(s.pool.QueryRow(ctx, `SELECT `+teamColumns+` FROM teams WHERE id = $1`, id))
+    if err != nil {
+        return nil, notFound(err, "team")
+    }
+    return team, nil
+}
+
+// ListTeams lists a sport's teams, or every team for an empty sportID.
+func (s *Store) ListTeams(ctx context.Context, sportID string) ([]*models.Team, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    rows, err := s.pool.Query(ctx, `
+        SELECT `+teamColumns+` FROM teams
+        WHERE $1 = '' OR sport_id::text = $1
+        ORDER BY name`,
+        sportID)
+    if err != nil {
+        return nil, fmt.Errorf("failed to list teams: %w", err)
+    }
+    return collect(rows, scanTeam)
+}
+
+func (s *Store) UpdateTeam(ctx context.Context, team *models.Team) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    if _, err := s.pool.Exec(ctx, `
+        UPDATE teams SET name = $2, sport_id = NULLIF($3, '')::uuid, logo_url = NULLIF($4, '') WHERE id = $1`,
+        team.ID, team.Name, team.SportID, team.LogoURL); err != nil {
+        return fmt.Errorf("failed to update team: %w", err)
+    }
+    return nil
+}
+
+func (s *Store) DeleteTeam(ctx context.Context, id string) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    if _, err := s.pool.Exec(ctx, `DELETE FROM teams WHERE id = $1`, id); err != nil {
+        return fmt.Errorf("failed to delete team: %w", err)
+    }
+    return nil
+}
+
+// matchSelect joins both teams, which callers show by name.
+const matchSelect = `
+    SELECT m.id, COALESCE(m.provider_id, ''), COALESCE(m.sport_id::text, ''), COALESCE(m.competition_id::text, ''),
+        COALESCE(m.home_team_id::text, ''), COALESCE(m.away_team_id::text, ''), m.start_time, m.status,
+        COALESCE(m.period, ''), m.minute, COALESCE(m.home_score, 0), COALESCE(m.away_score, 0),
+        m.match_data, m.shootout, m.venue, m.created_at, m.updated_at,
+        home.name, COALESCE(home.logo_url, ''), away.name, COALESCE(away.logo_url, '')
+    FROM matches m
+    LEFT JOIN teams home ON home.id = m.home_team_id
+    LEFT JOIN teams away ON away.id = m.away_team_id`
+
+func scanMatch(row pgx.Row) (*models.Match, error) {
+    m := &models.Match{}
+    var homeName, awayName *string
+    var homeLogo, awayLogo string
+    err := row.Scan(
+        &m.ID, &m.ProviderID, &m.SportID, &m.CompetitionID,
+        &m.HomeTeamID, &m.AwayTeamID, &m.StartTime, &m.Status,
+        &m.Period, &m.Minute, &m.HomeScore, &m.AwayScore,
+        &m.MatchData, &m.Shootout, &m.Venue, &m.CreatedAt, &m.UpdatedAt,
+        &homeName, &homeLogo, &awayName, &awayLogo,
+    )
+    if err != nil {
+        return nil, err
+    }
+    if homeName != nil {
+        m.HomeTeam = &models.Team{ID: m.HomeTeamID, Name: *homeName, SportID: m.SportID, LogoURL: homeLogo}
+    }
+    if awayName != nil {
+        m.AwayTeam = &models.Team{ID: m.AwayTeamID, Name: *awayName, SportID: m.SportID, LogoURL: awayLogo}
+    }
+    return m, nil
+}
+
+func (s *Store) CreateMatch(ctx context.Context, match *models.Match) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    err := s.pool.QueryRow(ctx, `
+        INSERT INTO matches (
+            provider_id, sport_id, competition_id, home_team_id, away_team_id, start_time, status,
+            period, minute, home_score, away_score, match_data, shootout, venue
+        ) VALUES (
+            NULLIF($1, ''), NULLIF($2, '')::uuid, NULLIF($3, '')::uuid, NULLIF($4, '')::uuid, NULLIF($5, '')::uuid, $6, $7,
+            NULLIF($8, ''), $9, $10, $11, $12, $13, $14
+        )
+        RETURNING id, created_at, updated_at`,
+        match.ProviderID, match.SportID, match.CompetitionID, match.HomeTeamID, match.AwayTeamID, match.StartTime, match.Status,
+        match.Period, match.Minute, match.HomeScore, match.AwayScore, []byte(match.MatchData), match.Shootout, match.Venue,
+    ).Scan(&match.ID, &match.CreatedAt, &match.UpdatedAt)
+    if err != nil {
+        return fmt.Errorf("failed to create match: %w", err)
+    }
+    return nil
+}
+
+func (s *Store) GetMatch(ctx context.Context, id string) (*models.Match, error) {
+    return s.getMatch(ctx, `m.id = $1`, id)
+}
+
+func (s *Store) GetMatchByProviderID(ctx context.Context, providerID string) (*models.Match, error) {
+    return s.getMatch(ctx, `m.provider_id = $1`, providerID)
+}
+
+func (s *Store) getMatch(ctx context.Context, where string, arg any) (*models.Match, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    match, err := scanMatch(s.pool.QueryRow(ctx, matchSelect+` WHERE `+where, arg))
+    if err != nil {
+        return nil, notFound(err, "match")
+    }
+    return match, nil
+}
+
+func (s *Store) listMatches(ctx context.Context, query string, args ...any) ([]*models.Match, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    rows, err := s.pool.Query(ctx, matchSelect+` `+query, args...)
+    if err != nil {
+        return nil, fmt.Errorf("failed to list matches: %w", err)
+    }
+    matches, err := collect(rows, scanMatch)
+    if err != nil {
+        return nil, fmt.Errorf("failed to list matches: %w", err)
+    }
+    return matches, nil
+}
+
+func (s *Store) GetLiveMatches(ctx context.Context) ([]*models.Match, error) {
+    return s.listMatches(ctx, `WHERE m.status = $1 ORDER BY m.start_time`, models.MatchStatusLive)
+}
+
+func (s *Store) GetMatchesByStatus(ctx context.Context, status string) ([]*models.Match, error) {
+    return s.listMatches(ctx, `WHERE m.status = $1 ORDER BY m.start_time`, status)
+}
+
+func (s *Store) GetUpcomingMatches(ctx context.Context, limit int) ([]*models.Match, error) {
+    return s.listMatches(ctx, `
+        WHERE m.status = $1 AND m.start_time > CURRENT_TIMESTAMP
+        ORDER BY m.start_time
+        LIMIT $2`,
+        models.MatchStatusScheduled, limit)
+}
+
+func (s *Store) GetFinishedMatchesSince(ctx context.Context, since time.Time) ([]*models.Match, error) {
+    return s.listMatches(ctx, `
+        WHERE m.status = $1 AND m.start_time >= $2
+        ORDER BY m.start_time`,
+        models.MatchStatusFinished, since)
+}
+
+func (s *Store) GetHeadToHeadMatches(ctx context.Context, teamAID, teamBID string, limit int) ([]*models.Match, error) {
+    return s.listMatches(ctx, `
+        WHERE m.status = $1
+            AND ((m.home_team_id = $2 AND m.away_team_id = $3) OR (m.home_team_id = $3 AND m.away_team_id = $2))
+        ORDER BY m.start_time DESC
+        LIMIT $4`,
+        models.MatchStatusFinished, teamAID, teamBID, limit)
+}
+
+func (s *Store) GetTeamRecentMatches(ctx context.Context, teamID string, limit int) ([]*models.Match, error) {
+    return s.listMatches(ctx, `
+        WHERE m.status = $1 AND (m.home_team_id = $2 OR m.away_team_id = $2)
+        ORDER BY m.start_time DESC
+        LIMIT $3`,
+        models.MatchStatusFinished, teamID, limit)
+}
+
+func (s *Store) CountMatchFollowers(ctx context.Context, matchID string) (int, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    // Favorite teams are free text, holding either a team's ID or its name
+    var count int
+    err := s.pool.QueryRow(ctx, `
+        SELECT COUNT(*) FROM (
+            SELECT u.id FROM matches m
+            JOIN teams t ON t.id IN (m.home_team_id, m.away_team_id)
+            JOIN users u ON LOWER(u.favorite_team) IN (t.id::text, LOWER(t.name))
+            WHERE m.id = $1 AND u.deactivated_at IS NULL AND u.banned_at IS NULL
+            UNION
+            SELECT u.id FROM chat_rooms r
+            JOIN user_chat_rooms ucr ON ucr.chat_room_id = r.id
+            JOIN users u ON u.id = ucr.user_id
+            WHERE r.match_id = $1 AND u.deactivated_at IS NULL AND u.banned_at IS NULL
+        ) followers`,
+        matchID,
+    ).Scan(&count)
+    if err != nil {
+        return 0, fmt.Errorf("failed to count match followers: %w", err)
+    }
+    return count, nil
+}
+
+func (s *Store) UpdateMatch(ctx context.Context, match *models.Match) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    err := s.pool.QueryRow(ctx, `
+        UPDATE matches SET
+            provider_id = NULLIF($2, ''), sport_id = NULLIF($3, '')::uuid, competition_id = NULLIF($4, '')::uuid,
+            home_team_id = NULLIF($5, '')::uuid, away_team_id = NULLIF($6, '')::uuid, start_time = $7, status = $8,
+            period = NULLIF($9, ''), minute = $10, home_score = $11, away_score = $12, match_data = $13, shootout = $14,
+            venue = $15
+        WHERE id = $1
+        RETURNING updated_at`,
+        match.ID, match.ProviderID, match.SportID, match.CompetitionID,
+        match.HomeTeamID, match.AwayTeamID, match.StartTime, match.Status,
+        match.Period, match.Minute, match.HomeScore, match.AwayScore, []byte(match.MatchData), match.Shootout,
+        match.Venue,
+    ).Scan(&match.UpdatedAt)
+    if err != nil {
+        return fmt.Errorf("failed to update match: %w", err)
+    }
+    return nil
+}
+
+func (s *Store) DeleteMatch(ctx context.Context, id string) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    if _, err := s.pool.Exec(ctx, `DELETE FROM matches WHERE id = $1`, id); err != nil {
+        return fmt.Errorf("failed to delete match: %w", err)
+    }
+    return nil
+}
+
+const matchEventColumns = `id, match_id, event_type, event_time, description, created_at`
+
+func scanMatchEvent(row pgx.Row) (*models.MatchEvent, error) {
+    e := &models.MatchEvent{}
+    if err := row.Scan(&e.ID, &e.MatchID, &e.EventType, &e.EventTime, &e.Description, &e.CreatedAt); err != nil {
+        return nil, err
+    }
+    return e, nil
+}
+
+func (s *Store) CreateMatchEvent(ctx context.Context, event *models.MatchEvent) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    err := s.pool.QueryRow(ctx, `
+        INSERT INTO match_events (id, match_id, event_type, event_time, description, created_at)
+        VALUES (COALESCE(NULLIF($1, '')::uuid, uuid_generate_v4()), $2, $3, $4, $5, COALESCE($6, CURRENT_TIMESTAMP))
+        RETURNING id, created_at`,
+        event.ID, event.MatchID, event.EventType, event.EventTime, event.Description, nullTime(event.CreatedAt),
+    ).Scan(&event.ID, &event.CreatedAt)
+    if err != nil {
+        return fmt.Errorf("failed to create match event: %w", err)
+    }
+    return nil
+}
+
+func (s *Store) GetMatchEvents(ctx context.Context, matchID string) ([]*models.MatchEvent, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    rows, err := s.pool.Query(ctx, `
+        SELECT `+matchEventColumns+` FROM match_events
+        WHERE match_id = $1
+        ORDER BY event_time, created_at`,
+        matchID)
+    if err != nil {
+        return nil, fmt.Errorf("failed to get match events: %w", err)
+    }
+    return collect(rows, scanMatchEvent)
+}
+
+// GetRecentMatchEvents returns the latest events, newest first.
+func (s *Store) GetRecentMatchEvents(ctx context.Context, matchID string, limit int) ([]*models.MatchEvent, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    rows, err := s.pool.Query(ctx, `
+        SELECT `+matchEventColumns+` FROM match_events
+        WHERE match_id = $1
+        ORDER BY created_at DESC
+        LIMIT $2`,
+        matchID, limit)
+    if err != nil {
+        return nil, fmt.Errorf("failed to get recent match events: %w", err)
+    }
+    return collect(rows, scanMatchEvent)
+}
+
+func (s *Store) ListCommentaryTemplates(ctx context.Context) ([]*models.CommentaryTemplate, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    rows, err := s.pool.Query(ctx, `
+        SELECT locale, event_type, template, updated_at
+        FROM commentary_templates ORDER BY locale, event_type`)
+    if err != nil {
+        return nil, fmt.Errorf("failed to list commentary templates: %w", err)
+    }
+    return collect(rows, func(row pgx.Row) (*models.CommentaryTemplate, error) {
+        t := &models.CommentaryTemplate{}
+        if err := row.Scan(&t.Locale, &t.EventType, &t.Template, &t.UpdatedAt); err != nil {
+            return nil, err
+        }
+        return t, nil
+    })
+}
+
+func (s *Store) UpsertCommentaryTemplate(ctx context.Context, template *models.CommentaryTemplate) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    err := s.pool.QueryRow(ctx, `
+        INSERT INTO commentary_templates (locale, event_type, template)
+        VALUES ($1, $2, $3)
+        ON CONFLICT (locale, event_type) DO UPDATE SET
+            template = EXCLUDED.template, updated_at = CURRENT_TIMESTAMP
+        RETURNING updated_at`,
+        template.Locale, template.EventType, template.Template,
+    ).Scan(&template.UpdatedAt)
+    if err != nil {
+        return fmt.Errorf("failed to save commentary template: %w", err)
+    }
+    return nil
+}
+
+func (s *Store) DeleteCommentaryTemplate(ctx context.Context, locale, eventType string) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    if _, err := s.pool.Exec(ctx, `
+        DELETE FROM commentary_templates WHERE locale = $1 AND event_type = $2`,
+        locale, eventType); err != nil {
+        return fmt.Errorf("failed to delete commentary template: %w", err)
+    }
+    return nil
+}
+
+func (s *Store) ListLocalizedNames(ctx context.Context) ([]*models.LocalizedName, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    rows, err := s.pool.Query(ctx, `
+        SELECT kind, entity_id::text, locale, name, source, updated_at
+        FROM localized_names ORDER BY kind, entity_id, locale`)
+    if err != nil {
+        return nil, fmt.Errorf("failed to list localized names: %w", err)
+    }
+    return collect(rows, func(row pgx.Row) (*models.LocalizedName, error) {
+        n := &models.LocalizedName{}
+        if err := row.Scan(&n.Kind, &n.EntityID, &n.Locale, &n.Name, &n.Source, &n.UpdatedAt); err != nil {
+            return nil, err
+        }
+        return n, nil
+    })
+}
+
+func (s *Store) UpsertLocalizedNames(ctx context.Context, names []*models.LocalizedName) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    err := s.inTx(ctx, func(tx pgx.Tx) error {
+        for _, name := range names {
+            err := tx.QueryRow(ctx, `
+                INSERT INTO localized_names (kind, entity_id, locale, name, source)
+                VALUES ($1, $2, $3, $4, $5)
+                ON CONFLICT (kind, entity_id, locale) DO UPDATE SET
+                    name = EXCLUDED.name, source = EXCLUDED.source, updated_at = CURRENT_TIMESTAMP
+                WHERE localized_names.source <> $6 OR EXCLUDED.source = $6
+                RETURNING updated_at`,
+                name.Kind, name.EntityID, name.Locale, name.Name, name.Source, models.NameSourceAdmin,
+            ).Scan(&name.UpdatedAt)
+            // An admin's name was kept
+            if errors.Is(err, pgx.ErrNoRows) {
+                continue
+            }
+            if err != nil {
+                return err
+            }
+        }
+        return nil
+    })
+    if err != nil {
+        return fmt.Errorf("failed to save localized names: %w", err)
+    }
+    return nil
+}
+
+func (s *Store) DeleteLocalizedName(ctx context.Context, kind, entityID, locale string) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    if _, err := s.pool.Exec(ctx, `
+        DELETE FROM localized_names WHERE kind = $1 AND entity_id = $2 AND locale = $3`,
+        kind, entityID, locale); err != nil {
+        return fmt.Errorf("failed to delete localized name: %w", err)
+    }
+    return nil
+}
+
+func (s *Store) CreateHighlight(ctx context.Context, highlight *models.Highlight) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    err := s.pool.QueryRow(ctx, `
+        INSERT INTO highlights (match_id, event_id, title, video_url, created_by, created_at)
+        VALUES ($1, NULLIF($2, '')::uuid, $3, $4, NULLIF($5, '')::uuid, COALESCE($6, CURRENT_TIMESTAMP))
+        RETURNING id, created_at`,
+        highlight.MatchID, highlight.EventID, highlight.Title, highlight.VideoURL, highlight.CreatedBy, nullTime(highlight.CreatedAt),
+    ).Scan(&highlight.ID, &highlight.CreatedAt)
+    if err != nil {
+        return fmt.Errorf("failed to create highlight: %w", err)
+    }
+    return nil
+}
+
+func (s *Store) GetMatchHighlights(ctx context.Context, matchID string) ([]*models.Highlight, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    rows, err := s.pool.Query(ctx, `
+        SELECT h.id, h.match_id, COALESCE(h.event_id::text, ''), h.title, h.video_url,
+               COALESCE(h.created_by::text, ''), h.created_at,
+               e.id, e.event_type, e.event_time, e.description, e.created_at
+        FROM highlights h
+        LEFT JOIN match_events e ON e.id = h.event_id
+        WHERE h.match_id = $1
+        ORDER BY h.created_at DESC`,
+        matchID)
+    if err != nil {
+        return nil, fmt.Errorf("failed to get match highlights: %w", err)
+    }
+    return collect(rows, func(row pgx.Row) (*models.Highlight, error) {
+        h := &models.Highlight{}
+        var (
+            eventID, eventType, description *string
+            eventTime                       *int
+            eventCreated                    *time.Time
+        )
+        err := row.Scan(&h.ID, &h.MatchID, &h.EventID, &h.Title, &h.VideoURL, &h.CreatedBy, &h.CreatedAt,
+            &eventID, &eventType, &eventTime, &description, &eventCreated)
+        if err != nil {
+            return nil, err
+        }
+        if eventID != nil {
+            h.Event = &models.MatchEvent{
+                ID:          *eventID,
+                MatchID:     h.MatchID,
+                EventType:   *eventType,
+                EventTime:   *eventTime,
+                Description: *description,
+                CreatedAt:   *eventCreated,
+            }
+        }
+        return h, nil
+    })
+}
+
+func (s *Store) DeleteHighlight(ctx context.Context, id string) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    if _, err := s.pool.Exec(ctx, `DELETE FROM highlights WHERE id = $1`, id); err != nil {
+        return fmt.Errorf("failed to delete highlight: %w", err)
+    }
+    return nil
+}
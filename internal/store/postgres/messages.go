@@ -0,0 +1,503 @@
+package postgres
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "strconv"
+    "strings"
+    "time"
+
+    "github.com/jackc/pgx/v5"
+
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/store"
+)
+
+// messageSelect joins each message's sender summary.
+const messageSelect = `
+    SELECT m.id, COALESCE(m.chat_room_id::text, ''), COALESCE(m.user_id::text, ''), m.content,
+        COALESCE(m.message_type, 'text'), m.previews, m.created_at, m.match_minute,
+        COALESCE(m.match_period, ''), COALESCE(m.client_msg_id, ''), m.mentions, COALESCE(m.topic_id::text, ''),
+        COALESCE(m.seq, 0), COALESCE(m.language, ''), u.username, COALESCE(u.avatar_url, ''), COALESCE(u.favorite_team, '')
+    FROM messages m
+    LEFT JOIN users u ON u.id = m.user_id`
+
+func scanMessage(row pgx.Row) (*models.Message, error) {
+    m := &models.Message{}
+    var username *string
+    var avatarURL, flair string
+    err := row.Scan(
+        &m.ID, &m.ChatRoomID, &m.UserID, &m.Content,
+        &m.MessageType, &m.Previews, &m.CreatedAt, &m.MatchMinute,
+        &m.MatchPeriod, &m.ClientMsgID, &m.Mentions, &m.TopicID,
+        &m.Seq, &m.Language, &username, &avatarURL, &flair,
+    )
+    if err != nil {
+        return nil, err
+    }
+    if username != nil {
+        m.User = &models.UserSummary{ID: m.UserID, Username: *username, AvatarURL: avatarURL, Flair: flair}
+    }
+    return m, nil
+}
+
+func (s *Store) CreateMessage(ctx context.Context, message *models.Message) error {
+    previews, err := jsonOrNil(message.Previews)
+    if err != nil {
+        return fmt.Errorf("failed to encode previews: %w", err)
+    }
+    mentions, err := jsonArray(message.Mentions)
+    if err != nil {
+        return fmt.Errorf("failed to encode mentions: %w", err)
+    }
+
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    err = s.pool.QueryRow(ctx, `
+        INSERT INTO messages (
+            id, chat_room_id, user_id, content, message_type, previews, created_at,
+            match_minute, match_period, client_msg_id, mentions, topic_id, seq, language
+        ) VALUES (
+            COALESCE(NULLIF($1, '')::uuid, uuid_generate_v4()), $2, NULLIF($3, '')::uuid, $4,
+            COALESCE(NULLIF($5, ''), 'text'), $6, COALESCE($7, CURRENT_TIMESTAMP),
+            $8, NULLIF($9, ''), NULLIF($10, ''), $11, NULLIF($12, '')::uuid, NULLIF($13, 0),
+            NULLIF($14, '')
+        )
+        RETURNING id, created_at`,
+        message.ID, message.ChatRoomID, message.UserID, message.Content,
+        message.MessageType, previews, nullTime(message.CreatedAt),
+        message.MatchMinute, message.MatchPeriod, message.ClientMsgID, mentions, message.TopicID, message.Seq,
+        message.Language,
+    ).Scan(&message.ID, &message.CreatedAt)
+    if err != nil {
+        return fmt.Errorf("failed to create message: %w", err)
+    }
+    return nil
+}
+
+// messageInsertColumns is how many values each row of CreateMessages binds.
+const messageInsertColumns = 14
+
+func (s *Store) CreateMessages(ctx context.Context, messages []*models.Message) error {
+    if len(messages) == 0 {
+        return nil
+    }
+
+    var values strings.Builder
+    args := make([]any, 0, len(messages)*messageInsertColumns)
+    for i, message := range messages {
+        previews, err := jsonOrNil(message.Previews)
+        if err != nil {
+            return fmt.Errorf("failed to encode previews: %w", err)
+        }
+        mentions, err := jsonArray(message.Mentions)
+        if err != nil {
+            return fmt.Errorf("failed to encode mentions: %w", err)
+        }
+
+        n := i * messageInsertColumns
+        if i > 0 {
+            values.WriteString(",")
+        }
+        fmt.Fprintf(&values, `(
+            $%d::uuid, $%d::uuid, NULLIF($%d, '')::uuid, $%d,
+            COALESCE(NULLIF($%d, ''), 'text'), $%d::jsonb, COALESCE($%d::timestamptz, CURRENT_TIMESTAMP),
+            $%d::integer, NULLIF($%d, ''), NULLIF($%d, ''), $%d::jsonb, NULLIF($%d, '')::uuid, NULLIF($%d::bigint, 0),
+            NULLIF($%d, ''))`,
+            n+1, n+2, n+3, n+4, n+5, n+6, n+7, n+8, n+9, n+10, n+11, n+12, n+13, n+14)
+        args = append(args,
+            message.ID, message.ChatRoomID, message.UserID, message.Content,
+            message.MessageType, previews, nullTime(message.CreatedAt),
+            message.MatchMinute, message.MatchPeriod, message.ClientMsgID, mentions, message.TopicID, message.Seq,
+            message.Language)
+    }
+
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    _, err := s.pool.Exec(ctx, `
+        INSERT INTO messages (
+            id, chat_room_id, user_id, content, message_type, previews, created_at,
+            match_minute, match_period, client_msg_id, mentions, topic_id, seq, language
+        ) VALUES `+values.String()+`
+        ON CONFLICT (id) DO NOTHING`,
+        args...)
+    if err != nil {
+        return fmt.Errorf("failed to create messages: %w", err)
+    }
+    return nil
+}
+
+func (s *Store) GetMessage(ctx context.Context, id string) (*models.Message, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    message, err := scanMessage(s.pool.QueryRow(ctx, messageSelect+` WHERE m.id = $1`, id))
+    if err != nil {
+        return nil, notFound(err, "message")
+    }
+    return message, nil
+}
+
+func (s *Store) listMessages(ctx context.Context, query string, args ...any) ([]*models.Message, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    rows, err := s.pool.Query(ctx, messageSelect+` `+query, args...)
+    if err != nil {
+        return nil, fmt.Errorf("failed to get messages: %w", err)
+    }
+    messages, err := collect(rows, scanMessage)
+    if err != nil {
+        return nil, fmt.Errorf("failed to get messages: %w", err)
+    }
+    return messages, nil
+}
+
+// GetRecentMessages returns the room's latest messages, newest first.
+func (s *Store) GetRecentMessages(ctx context.Context, roomID string, limit int) ([]*models.Message, error) {
+    return s.GetMessagesBeforeCursor(ctx, roomID, store.MessageFilter{}, nil, limit)
+}
+
+func (s *Store) GetMessagesBeforeCursor(ctx context.Context, roomID string, filter store.MessageFilter, cursor *store.MessageCursor, limit int) ([]*models.Message, error) {
+    where, args := filterMessages(`m.chat_room_id = $1`, []any{roomID}, filter)
+    return s.messagesBefore(ctx, where, args, cursor, limit)
+}
+
+func (s *Store) GetTopicMessagesBeforeCursor(ctx context.Context, topicID string, cursor *store.MessageCursor, limit int) ([]*models.Message, error) {
+    return s.messagesBefore(ctx, `m.topic_id = $1`, []any{topicID}, cursor, limit)
+}
+
+// messagesBefore pages back by (created_at, id), which the feed indexes
+// cover in both directions.
+func (s *Store) messagesBefore(ctx context.Context, where string, args []any, cursor *store.MessageCursor, limit int) ([]*models.Message, error) {
+    if cursor != nil {
+        where += fmt.Sprintf(` AND (m.created_at, m.id) < ($%d, $%d::uuid)`, len(args)+1, len(args)+2)
+        args = append(args, cursor.CreatedAt, cursor.ID)
+    }
+    args = append(args, limit)
+    return s.listMessages(ctx, `
+        WHERE `+where+`
+        ORDER BY m.created_at DESC, m.id DESC
+        LIMIT $`+strconv.Itoa(len(args)),
+        args...)
+}
+
+func (s *Store) GetMessagesAfterCursor(ctx context.Context, roomID string, filter store.MessageFilter, cursor store.MessageCursor, limit int) ([]*models.Message, error) {
+    where, args := filterMessages(`m.chat_room_id = $1`, []any{roomID}, filter)
+    where += fmt.Sprintf(` AND (m.created_at, m.id) > ($%d, $%d::uuid)`, len(args)+1, len(args)+2)
+    args = append(args, cursor.CreatedAt, cursor.ID, limit)
+    return s.listMessages(ctx, `
+        WHERE `+where+`
+        ORDER BY m.created_at, m.id
+        LIMIT $`+strconv.Itoa(len(args)),
+        args...)
+}
+
+// filterMessages narrows a where clause on messages by the filter,
+// numbering its parameters after args. Each kind has a partial index on
+// the room's feed order.
+func filterMessages(where string, args []any, filter store.MessageFilter) (string, []any) {
+    if filter.UserID != "" {
+        args = append(args, filter.UserID)
+        where += fmt.Sprintf(` AND m.user_id = $%d::uuid`, len(args))
+    }
+    switch filter.Kind {
+    case store.MessageKindMedia:
+        where += ` AND m.previews IS NOT NULL`
+    case store.MessageKindSystem:
+        where += ` AND m.message_type NOT IN ('chat', 'text')`
+    }
+    return where, args
+}
+
+func (s *Store) GetMessagesAfterSeq(ctx context.Context, roomID string, seq int64, limit int) ([]*models.Message, error) {
+    return s.listMessages(ctx, `
+        WHERE m.chat_room_id = $1 AND m.seq > $2
+        ORDER BY m.seq
+        LIMIT $3`,
+        roomID, seq, limit)
+}
+
+func (s *Store) GetMessagesBetween(ctx context.Context, roomID string, from, to time.Time, limit int) ([]*models.Message, error) {
+    return s.listMessages(ctx, `
+        WHERE m.chat_room_id = $1 AND m.created_at >= $2 AND m.created_at < $3
+        ORDER BY m.created_at, m.id
+        LIMIT $4`,
+        roomID, from, to, limit)
+}
+
+func (s *Store) GetUserMessages(ctx context.Context, userID string, cursor *store.MessageCursor, limit int) ([]*models.Message, error) {
+    if cursor == nil {
+        return s.listMessages(ctx, `
+            WHERE m.user_id = $1
+            ORDER BY m.created_at, m.id
+            LIMIT $2`,
+            userID, limit)
+    }
+    return s.listMessages(ctx, `
+        WHERE m.user_id = $1 AND (m.created_at, m.id) > ($2, $3::uuid)
+        ORDER BY m.created_at, m.id
+        LIMIT $4`,
+        userID, cursor.CreatedAt, cursor.ID, limit)
+}
+
+func (s *Store) GetRoomMaxSeq(ctx context.Context, roomID string) (int64, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    var seq int64
+    err := s.pool.QueryRow(ctx, `
+        SELECT COALESCE(MAX(seq), 0) FROM messages WHERE chat_room_id = $1`,
+        roomID,
+    ).Scan(&seq)
+    if err != nil {
+        return 0, fmt.Errorf("failed to get room sequence: %w", err)
+    }
+    return seq, nil
+}
+
+func (s *Store) DeleteMessage(ctx context.Context, id string) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    if _, err := s.pool.Exec(ctx, `DELETE FROM messages WHERE id = $1`, id); err != nil {
+        return fmt.Errorf("failed to delete message: %w", err)
+    }
+    return nil
+}
+
+func (s *Store) SetMessagePreviews(ctx context.Context, id string, previews []*models.LinkPreview) error {
+    data, err := jsonOrNil(previews)
+    if err != nil {
+        return fmt.Errorf("failed to encode previews: %w", err)
+    }
+
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    if _, err := s.pool.Exec(ctx, `UPDATE messages SET previews = $2 WHERE id = $1`, id, data); err != nil {
+        return fmt.Errorf("failed to set message previews: %w", err)
+    }
+    return nil
+}
+
+func (s *Store) AddReaction(ctx context.Context, reaction *models.Reaction) (bool, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    err := s.pool.QueryRow(ctx, `
+        INSERT INTO message_reactions (message_id, user_id, emoji, created_at)
+        VALUES ($1, $2, $3, COALESCE($4, CURRENT_TIMESTAMP))
+        ON CONFLICT (message_id, user_id, emoji) DO NOTHING
+        RETURNING created_at`,
+        reaction.MessageID, reaction.UserID, reaction.Emoji, nullTime(reaction.CreatedAt),
+    ).Scan(&reaction.CreatedAt)
+    if errors.Is(err, pgx.ErrNoRows) {
+        return false, nil
+    }
+    if err != nil {
+        return false, fmt.Errorf("failed to add reaction: %w", err)
+    }
+    return true, nil
+}
+
+func (s *Store) RemoveReaction(ctx context.Context, messageID, userID, emoji string) (bool, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    removed, err := affected(s.pool.Exec(ctx, `
+        DELETE FROM message_reactions WHERE message_id = $1 AND user_id = $2 AND emoji = $3`,
+        messageID, userID, emoji))
+    if err != nil {
+        return false, fmt.Errorf("failed to remove reaction: %w", err)
+    }
+    return removed, nil
+}
+
+// GetMessageReactions counts each message's reactions by emoji, the most
+// used first.
+func (s *Store) GetMessageReactions(ctx context.Context, messageIDs []string) (map[string][]*models.ReactionCount, error) {
+    counts := make(map[string][]*models.ReactionCount)
+    if len(messageIDs) == 0 {
+        return counts, nil
+    }
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    rows, err := s.pool.Query(ctx, `
+        SELECT message_id::text, emoji, COUNT(*)
+        FROM message_reactions
+        WHERE message_id = ANY($1::uuid[])
+        GROUP BY message_id, emoji
+        ORDER BY COUNT(*) DESC, MIN(created_at)`,
+        messageIDs)
+    if err != nil {
+        return nil, fmt.Errorf("failed to get message reactions: %w", err)
+    }
+    defer rows.Close()
+    for rows.Next() {
+        var messageID string
+        c := &models.ReactionCount{}
+        if err := rows.Scan(&messageID, &c.Emoji, &c.Count); err != nil {
+            return nil, fmt.Errorf("failed to get message reactions: %w", err)
+        }
+        counts[messageID] = append(counts[messageID], c)
+    }
+    return counts, rows.Err()
+}
+
+// SearchMessages matches content case-insensitively, newest first. The
+// OpenSearch backend serves search when the scan gets too slow.
+func (s *Store) SearchMessages(ctx context.Context, query string, limit int) ([]*models.Message, error) {
+    return s.listMessages(ctx, `
+        WHERE m.content ILIKE '%' || $1 || '%'
+        ORDER BY m.created_at DESC
+        LIMIT $2`,
+        escapeLike(query), limit)
+}
+
+func (s *Store) SearchMatchEvents(ctx context.Context, query string, limit int) ([]*models.MatchEvent, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    rows, err := s.pool.Query(ctx, `
+        SELECT `+matchEventColumns+` FROM match_events
+        WHERE description ILIKE '%' || $1 || '%'
+        ORDER BY created_at DESC
+        LIMIT $2`,
+        escapeLike(query), limit)
+    if err != nil {
+        return nil, fmt.Errorf("failed to search match events: %w", err)
+    }
+    return collect(rows, scanMatchEvent)
+}
+
+// escapeLike makes a search query match literally.
+func escapeLike(query string) string {
+    var escaped []rune
+    for _, r := range query {
+        if r == '\\' || r == '%' || r == '_' {
+            escaped = append(escaped, '\\')
+        }
+        escaped = append(escaped, r)
+    }
+    return string(escaped)
+}
+
+// AppendJournalEntries writes a batch with one COPY, which keeps up with
+// busy rooms far better than row-by-row inserts.
+func (s *Store) AppendJournalEntries(ctx context.Context, entries []*models.JournalEntry) error {
+    if len(entries) == 0 {
+        return nil
+    }
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    _, err := s.pool.CopyFrom(ctx,
+        pgx.Identifier{"broadcast_journal"},
+        []string{"chat_room_id", "frame", "created_at"},
+        pgx.CopyFromSlice(len(entries), func(i int) ([]any, error) {
+            e := entries[i]
+            createdAt := e.CreatedAt
+            if createdAt.IsZero() {
+                createdAt = time.Now()
+            }
+            return []any{e.RoomID, []byte(e.Frame), createdAt}, nil
+        }))
+    if err != nil {
+        return fmt.Errorf("failed to append journal entries: %w", err)
+    }
+    return nil
+}
+
+// GetJournalEntries returns a room's frames from the window [from, to),
+// oldest first.
+func (s *Store) GetJournalEntries(ctx context.Context, roomID string, from, to time.Time, limit int) ([]*models.JournalEntry, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    rows, err := s.pool.Query(ctx, `
+        SELECT id, chat_room_id, frame, created_at FROM broadcast_journal
+        WHERE chat_room_id = $1 AND created_at >= $2 AND created_at < $3
+        ORDER BY created_at, id
+        LIMIT $4`,
+        roomID, from, to, limit)
+    if err != nil {
+        return nil, fmt.Errorf("failed to get journal entries: %w", err)
+    }
+    return collect(rows, func(row pgx.Row) (*models.JournalEntry, error) {
+        e := &models.JournalEntry{}
+        var frame []byte
+        if err := row.Scan(&e.ID, &e.RoomID, &frame, &e.CreatedAt); err != nil {
+            return nil, err
+        }
+        e.Frame = json.RawMessage(frame)
+        return e, nil
+    })
+}
+
+func (s *Store) PurgeJournalEntries(ctx context.Context, before time.Time) (int64, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    tag, err := s.pool.Exec(ctx, `DELETE FROM broadcast_journal WHERE created_at < $1`, before)
+    if err != nil {
+        return 0, fmt.Errorf("failed to purge journal entries: %w", err)
+    }
+    return tag.RowsAffected(), nil
+}
+
+func (s *Store) UpsertDraft(ctx context.Context, draft *models.Draft) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    err := s.pool.QueryRow(ctx, `
+        INSERT INTO message_drafts (user_id, chat_room_id, content, updated_at)
+        VALUES ($1, $2, $3, COALESCE($4, CURRENT_TIMESTAMP))
+        ON CONFLICT (user_id, chat_room_id) DO UPDATE SET
+            content = EXCLUDED.content, updated_at = EXCLUDED.updated_at
+        RETURNING updated_at`,
+        draft.UserID, draft.RoomID, draft.Content, nullTime(draft.UpdatedAt),
+    ).Scan(&draft.UpdatedAt)
+    if err != nil {
+        return fmt.Errorf("failed to save draft: %w", err)
+    }
+    return nil
+}
+
+func (s *Store) DeleteDraft(ctx context.Context, userID, roomID string) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    if _, err := s.pool.Exec(ctx, `
+        DELETE FROM message_drafts WHERE user_id = $1 AND chat_room_id = $2`,
+        userID, roomID); err != nil {
+        return fmt.Errorf("failed to delete draft: %w", err)
+    }
+    return nil
+}
+
+func (s *Store) GetUserDrafts(ctx context.Context, userID string) ([]*models.Draft, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    rows, err := s.pool.Query(ctx, `
+        SELECT user_id, chat_room_id, content, updated_at FROM message_drafts
+        WHERE user_id = $1
+        ORDER BY updated_at DESC`,
+        userID)
+    if err != nil {
+        return nil, fmt.Errorf("failed to get drafts: %w", err)
+    }
+    return collect(rows, func(row pgx.Row) (*models.Draft, error) {
+        d := &models.Draft{}
+        if err := row.Scan(&d.UserID, &d.RoomID, &d.Content, &d.UpdatedAt); err != nil {
+            return nil, err
+        }
+        return d, nil
+    })
+}
@@ -0,0 +1,420 @@
+package postgres
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "time"
+
+    "github.com/jackc/pgx/v5"
+
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+func (s *Store) ListProfanityWords(ctx context.Context) ([]*models.ProfanityWord, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    rows, err := s.pool.Query(ctx, `SELECT locale, word, severity FROM profanity_words ORDER BY locale, word`)
+    if err != nil {
+        return nil, fmt.Errorf("failed to list profanity words: %w", err)
+    }
+    return collect(rows, func(row pgx.Row) (*models.ProfanityWord, error) {
+        w := &models.ProfanityWord{}
+        if err := row.Scan(&w.Locale, &w.Word, &w.Severity); err != nil {
+            return nil, err
+        }
+        return w, nil
+    })
+}
+
+func (s *Store) AddProfanityWord(ctx context.Context, word *models.ProfanityWord) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    if _, err := s.pool.Exec(ctx, `
+        INSERT INTO profanity_words (locale, word, severity) VALUES ($1, $2, $3)
+        ON CONFLICT (locale, word) DO UPDATE SET severity = EXCLUDED.severity`,
+        word.Locale, word.Word, word.Severity); err != nil {
+        return fmt.Errorf("failed to add profanity word: %w", err)
+    }
+    return nil
+}
+
+func (s *Store) DeleteProfanityWord(ctx context.Context, locale, word string) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    if _, err := s.pool.Exec(ctx, `
+        DELETE FROM profanity_words WHERE locale = $1 AND word = $2`,
+        locale, word); err != nil {
+        return fmt.Errorf("failed to delete profanity word: %w", err)
+    }
+    return nil
+}
+
+func (s *Store) ListProfanityPolicies(ctx context.Context) ([]*models.ProfanityPolicy, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    rows, err := s.pool.Query(ctx, `
+        SELECT locale, mild_action, moderate_action, severe_action, updated_at
+        FROM profanity_policies ORDER BY locale`)
+    if err != nil {
+        return nil, fmt.Errorf("failed to list profanity policies: %w", err)
+    }
+    return collect(rows, func(row pgx.Row) (*models.ProfanityPolicy, error) {
+        p := &models.ProfanityPolicy{}
+        if err := row.Scan(&p.Locale, &p.MildAction, &p.ModerateAction, &p.SevereAction, &p.UpdatedAt); err != nil {
+            return nil, err
+        }
+        return p, nil
+    })
+}
+
+func (s *Store) UpsertProfanityPolicy(ctx context.Context, policy *models.ProfanityPolicy) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    err := s.pool.QueryRow(ctx, `
+        INSERT INTO profanity_policies (locale, mild_action, moderate_action, severe_action)
+        VALUES ($1, $2, $3, $4)
+        ON CONFLICT (locale) DO UPDATE SET
+            mild_action = EXCLUDED.mild_action, moderate_action = EXCLUDED.moderate_action,
+            severe_action = EXCLUDED.severe_action, updated_at = CURRENT_TIMESTAMP
+        RETURNING updated_at`,
+        policy.Locale, policy.MildAction, policy.ModerateAction, policy.SevereAction,
+    ).Scan(&policy.UpdatedAt)
+    if err != nil {
+        return fmt.Errorf("failed to save profanity policy: %w", err)
+    }
+    return nil
+}
+
+func (s *Store) ListModerationFilters(ctx context.Context) ([]*models.ModerationFilter, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    rows, err := s.pool.Query(ctx, `
+        SELECT name, enabled, action, config, updated_at FROM moderation_filters ORDER BY name`)
+    if err != nil {
+        return nil, fmt.Errorf("failed to list moderation filters: %w", err)
+    }
+    return collect(rows, func(row pgx.Row) (*models.ModerationFilter, error) {
+        f := &models.ModerationFilter{}
+        var config []byte
+        if err := row.Scan(&f.Name, &f.Enabled, &f.Action, &config, &f.UpdatedAt); err != nil {
+            return nil, err
+        }
+        f.Config = config
+        return f, nil
+    })
+}
+
+func (s *Store) UpsertModerationFilter(ctx context.Context, filter *models.ModerationFilter) error {
+    config := []byte(filter.Config)
+    if len(config) == 0 {
+        config = []byte("{}")
+    }
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    err := s.pool.QueryRow(ctx, `
+        INSERT INTO moderation_filters (name, enabled, action, config)
+        VALUES ($1, $2, $3, $4)
+        ON CONFLICT (name) DO UPDATE SET
+            enabled = EXCLUDED.enabled, action = EXCLUDED.action,
+            config = EXCLUDED.config, updated_at = CURRENT_TIMESTAMP
+        RETURNING updated_at`,
+        filter.Name, filter.Enabled, filter.Action, config,
+    ).Scan(&filter.UpdatedAt)
+    if err != nil {
+        return fmt.Errorf("failed to save moderation filter: %w", err)
+    }
+    return nil
+}
+
+const messageFlagColumns = `
+    id, message_id, room_id, user_id, content, filter, COALESCE(reason, ''), status,
+    COALESCE(reviewed_by::text, ''), reviewed_at, created_at`
+
+func scanMessageFlag(row pgx.Row) (*models.MessageFlag, error) {
+    f := &models.MessageFlag{}
+    err := row.Scan(&f.ID, &f.MessageID, &f.RoomID, &f.UserID, &f.Content, &f.Filter, &f.Reason, &f.Status,
+        &f.ReviewedBy, &f.ReviewedAt, &f.CreatedAt)
+    if err != nil {
+        return nil, err
+    }
+    return f, nil
+}
+
+// CreateMessageFlag keeps the first flag of a message; a replayed persist
+// job flagging it again is a no-op.
+func (s *Store) CreateMessageFlag(ctx context.Context, flag *models.MessageFlag) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    _, err := s.pool.Exec(ctx, `
+        INSERT INTO message_flags (message_id, room_id, user_id, content, filter, reason, status, created_at)
+        VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), COALESCE(NULLIF($7, ''), 'pending'), COALESCE($8, CURRENT_TIMESTAMP))
+        ON CONFLICT (message_id) DO NOTHING`,
+        flag.MessageID, flag.RoomID, flag.UserID, flag.Content, flag.Filter, flag.Reason, flag.Status, nullTime(flag.CreatedAt))
+    if err != nil {
+        return fmt.Errorf("failed to create message flag: %w", err)
+    }
+    return nil
+}
+
+func (s *Store) GetMessageFlag(ctx context.Context, id string) (*models.MessageFlag, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    flag, err := scanMessageFlag(s.pool.QueryRow(ctx, `SELECT `+messageFlagColumns+` FROM message_flags WHERE id = $1`, id))
+    if err != nil {
+        return nil, notFound(err, "message flag")
+    }
+    return flag, nil
+}
+
+// ListMessageFlags lists flags in a review state, or in any for an empty
+// status, newest first.
+func (s *Store) ListMessageFlags(ctx context.Context, status string, limit int) ([]*models.MessageFlag, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    rows, err := s.pool.Query(ctx, `
+        SELECT `+messageFlagColumns+` FROM message_flags
+        WHERE $1 = '' OR status = $1
+        ORDER BY created_at DESC
+        LIMIT $2`,
+        status, limit)
+    if err != nil {
+        return nil, fmt.Errorf("failed to list message flags: %w", err)
+    }
+    return collect(rows, scanMessageFlag)
+}
+
+func (s *Store) ListRoomMessageFlags(ctx context.Context, roomID string, from, to time.Time) ([]*models.MessageFlag, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    rows, err := s.pool.Query(ctx, `
+        SELECT `+messageFlagColumns+` FROM message_flags
+        WHERE room_id = $1 AND created_at >= $2 AND created_at < $3
+        ORDER BY created_at`,
+        roomID, from, to)
+    if err != nil {
+        return nil, fmt.Errorf("failed to list room message flags: %w", err)
+    }
+    return collect(rows, scanMessageFlag)
+}
+
+func (s *Store) ReviewMessageFlag(ctx context.Context, id, status, reviewerID string) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    if _, err := s.pool.Exec(ctx, `
+        UPDATE message_flags SET status = $2, reviewed_by = $3, reviewed_at = CURRENT_TIMESTAMP
+        WHERE id = $1`,
+        id, status, reviewerID); err != nil {
+        return fmt.Errorf("failed to review message flag: %w", err)
+    }
+    return nil
+}
+
+// RecordDeviceSighting refreshes the sighting's time when the user was
+// seen on the same device and address before.
+func (s *Store) RecordDeviceSighting(ctx context.Context, sighting *models.DeviceSighting) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    if _, err := s.pool.Exec(ctx, `
+        INSERT INTO device_sightings (user_id, fingerprint, ip, seen_at)
+        VALUES ($1, $2, $3::inet, COALESCE($4, CURRENT_TIMESTAMP))
+        ON CONFLICT (user_id, fingerprint, ip) DO UPDATE SET seen_at = EXCLUDED.seen_at`,
+        sighting.UserID, sighting.Fingerprint, sighting.IP, nullTime(sighting.SeenAt)); err != nil {
+        return fmt.Errorf("failed to record device sighting: %w", err)
+    }
+    return nil
+}
+
+// GetBannedUserSightings returns banned users' sightings on the
+// fingerprint or the address since the given time.
+func (s *Store) GetBannedUserSightings(ctx context.Context, fingerprint, ip string, since time.Time) ([]*models.DeviceSighting, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    rows, err := s.pool.Query(ctx, `
+        SELECT d.user_id, d.fingerprint, host(d.ip), d.seen_at
+        FROM device_sightings d
+        JOIN users u ON u.id = d.user_id
+        WHERE u.banned_at IS NOT NULL AND d.seen_at >= $3
+            AND ((d.fingerprint <> '' AND d.fingerprint = $1) OR d.ip = $2::inet)`,
+        fingerprint, ip, since)
+    if err != nil {
+        return nil, fmt.Errorf("failed to get banned user sightings: %w", err)
+    }
+    return collect(rows, func(row pgx.Row) (*models.DeviceSighting, error) {
+        d := &models.DeviceSighting{}
+        if err := row.Scan(&d.UserID, &d.Fingerprint, &d.IP, &d.SeenAt); err != nil {
+            return nil, err
+        }
+        return d, nil
+    })
+}
+
+const evasionSuspectColumns = `
+    id, user_id, banned_user_id, score, signals, status,
+    COALESCE(reviewed_by::text, ''), reviewed_at, created_at`
+
+func scanEvasionSuspect(row pgx.Row) (*models.EvasionSuspect, error) {
+    e := &models.EvasionSuspect{}
+    err := row.Scan(&e.ID, &e.UserID, &e.BannedUserID, &e.Score, &e.Signals, &e.Status,
+        &e.ReviewedBy, &e.ReviewedAt, &e.CreatedAt)
+    if err != nil {
+        return nil, err
+    }
+    return e, nil
+}
+
+func (s *Store) CreateEvasionSuspect(ctx context.Context, suspect *models.EvasionSuspect) (bool, error) {
+    signals, err := jsonArray(suspect.Signals)
+    if err != nil {
+        return false, fmt.Errorf("failed to encode evasion signals: %w", err)
+    }
+
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    err = s.pool.QueryRow(ctx, `
+        INSERT INTO evasion_suspects (user_id, banned_user_id, score, signals, status, created_at)
+        VALUES ($1, $2, $3, $4, COALESCE(NULLIF($5, ''), 'pending'), COALESCE($6, CURRENT_TIMESTAMP))
+        ON CONFLICT (user_id, banned_user_id) DO NOTHING
+        RETURNING id, created_at`,
+        suspect.UserID, suspect.BannedUserID, suspect.Score, signals, suspect.Status, nullTime(suspect.CreatedAt),
+    ).Scan(&suspect.ID, &suspect.CreatedAt)
+    if errors.Is(err, pgx.ErrNoRows) {
+        return false, nil
+    }
+    if err != nil {
+        return false, fmt.Errorf("failed to create evasion suspect: %w", err)
+    }
+    return true, nil
+}
+
+func (s *Store) GetEvasionSuspect(ctx context.Context, id string) (*models.EvasionSuspect, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    suspect, err := scanEvasionSuspect(s.pool.QueryRow(ctx, `
+        SELECT `+evasionSuspectColumns+` FROM evasion_suspects WHERE id = $1`, id))
+    if err != nil {
+        return nil, notFound(err, "evasion suspect")
+    }
+    return suspect, nil
+}
+
+func (s *Store) ListEvasionSuspects(ctx context.Context, status string, limit int) ([]*models.EvasionSuspect, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    rows, err := s.pool.Query(ctx, `
+        SELECT `+evasionSuspectColumns+` FROM evasion_suspects
+        WHERE $1 = '' OR status = $1
+        ORDER BY created_at DESC
+        LIMIT $2`,
+        status, limit)
+    if err != nil {
+        return nil, fmt.Errorf("failed to list evasion suspects: %w", err)
+    }
+    return collect(rows, scanEvasionSuspect)
+}
+
+func (s *Store) ReviewEvasionSuspect(ctx context.Context, id, status, reviewerID string) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    if _, err := s.pool.Exec(ctx, `
+        UPDATE evasion_suspects SET status = $2, reviewed_by = $3, reviewed_at = CURRENT_TIMESTAMP
+        WHERE id = $1`,
+        id, status, reviewerID); err != nil {
+        return fmt.Errorf("failed to review evasion suspect: %w", err)
+    }
+    return nil
+}
+
+// GetEvasionSignalStats counts reviewed suspects by each signal kind they
+// carried.
+func (s *Store) GetEvasionSignalStats(ctx context.Context) ([]*models.EvasionSignalStats, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    rows, err := s.pool.Query(ctx, `
+        SELECT k.kind,
+            COUNT(*) FILTER (WHERE e.status = 'confirmed'),
+            COUNT(*) FILTER (WHERE e.status = 'dismissed')
+        FROM evasion_suspects e
+        CROSS JOIN LATERAL (
+            SELECT DISTINCT signal->>'kind' AS kind FROM jsonb_array_elements(e.signals) signal
+        ) k
+        WHERE e.status <> 'pending'
+        GROUP BY k.kind`)
+    if err != nil {
+        return nil, fmt.Errorf("failed to get evasion signal stats: %w", err)
+    }
+    return collect(rows, func(row pgx.Row) (*models.EvasionSignalStats, error) {
+        st := &models.EvasionSignalStats{}
+        if err := row.Scan(&st.Kind, &st.Confirmed, &st.Dismissed); err != nil {
+            return nil, err
+        }
+        return st, nil
+    })
+}
+
+func (s *Store) SetShadowBan(ctx context.Context, entry *models.ShadowBanEntry) (bool, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    changed := false
+    err := s.inTx(ctx, func(tx pgx.Tx) error {
+        tag, err := tx.Exec(ctx, `
+            UPDATE users SET shadow_banned = $2 WHERE id = $1 AND shadow_banned <> $2`,
+            entry.UserID, entry.Banned)
+        if err != nil || tag.RowsAffected() == 0 {
+            return err
+        }
+        changed = true
+        return tx.QueryRow(ctx, `
+            INSERT INTO shadow_ban_audit (user_id, banned, actor_id, reason)
+            VALUES ($1, $2, NULLIF($3, '')::uuid, NULLIF($4, ''))
+            RETURNING id, created_at`,
+            entry.UserID, entry.Banned, entry.ActorID, entry.Reason,
+        ).Scan(&entry.ID, &entry.CreatedAt)
+    })
+    if err != nil {
+        return false, fmt.Errorf("failed to set shadow ban: %w", err)
+    }
+    return changed, nil
+}
+
+func (s *Store) GetShadowBanEntries(ctx context.Context, userID string) ([]*models.ShadowBanEntry, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    rows, err := s.pool.Query(ctx, `
+        SELECT id, user_id, banned, COALESCE(actor_id::text, ''), COALESCE(reason, ''), created_at
+        FROM shadow_ban_audit
+        WHERE user_id = $1
+        ORDER BY created_at DESC`,
+        userID)
+    if err != nil {
+        return nil, fmt.Errorf("failed to get shadow ban entries: %w", err)
+    }
+    return collect(rows, func(row pgx.Row) (*models.ShadowBanEntry, error) {
+        e := &models.ShadowBanEntry{}
+        if err := row.Scan(&e.ID, &e.UserID, &e.Banned, &e.ActorID, &e.Reason, &e.CreatedAt); err != nil {
+            return nil, err
+        }
+        return e, nil
+    })
+}
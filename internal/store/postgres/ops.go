@@ -0,0 +1,330 @@
+package postgres
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "time"
+
+    "github.com/jackc/pgx/v5"
+
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/store"
+)
+
+const deadLetterColumns = `id, job_name, payload, error, attempts, replay_count, replayed_at, created_at`
+
+func scanDeadLetter(row pgx.Row) (*models.DeadLetter, error) {
+    d := &models.DeadLetter{}
+    var payload []byte
+    err := row.Scan(&d.ID, &d.JobName, &payload, &d.Error, &d.Attempts, &d.ReplayCount, &d.ReplayedAt, &d.CreatedAt)
+    if err != nil {
+        return nil, err
+    }
+    d.Payload = payload
+    return d, nil
+}
+
+func (s *Store) CreateDeadLetter(ctx context.Context, letter *models.DeadLetter) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    err := s.pool.QueryRow(ctx, `
+        INSERT INTO dead_letters (job_name, payload, error, attempts, created_at)
+        VALUES ($1, $2, $3, $4, COALESCE($5, CURRENT_TIMESTAMP))
+        RETURNING id, created_at`,
+        letter.JobName, []byte(letter.Payload), letter.Error, letter.Attempts, nullTime(letter.CreatedAt),
+    ).Scan(&letter.ID, &letter.CreatedAt)
+    if err != nil {
+        return fmt.Errorf("failed to create dead letter: %w", err)
+    }
+    return nil
+}
+
+func (s *Store) GetDeadLetter(ctx context.Context, id string) (*models.DeadLetter, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    letter, err := scanDeadLetter(s.pool.QueryRow(ctx, `
+        SELECT `+deadLetterColumns+` FROM dead_letters WHERE id = $1`, id))
+    if err != nil {
+        return nil, notFound(err, "dead letter")
+    }
+    return letter, nil
+}
+
+func (s *Store) ListDeadLetters(ctx context.Context, pendingOnly bool, limit int) ([]*models.DeadLetter, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    rows, err := s.pool.Query(ctx, `
+        SELECT `+deadLetterColumns+` FROM dead_letters
+        WHERE NOT $1 OR replayed_at IS NULL
+        ORDER BY created_at DESC
+        LIMIT $2`,
+        pendingOnly, limit)
+    if err != nil {
+        return nil, fmt.Errorf("failed to list dead letters: %w", err)
+    }
+    return collect(rows, scanDeadLetter)
+}
+
+func (s *Store) MarkDeadLetterReplayed(ctx context.Context, id string, at time.Time) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    found, err := affected(s.pool.Exec(ctx, `
+        UPDATE dead_letters SET replayed_at = $2, replay_count = replay_count + 1
+        WHERE id = $1`,
+        id, at))
+    if err != nil {
+        return fmt.Errorf("failed to mark dead letter replayed: %w", err)
+    }
+    if !found {
+        return fmt.Errorf("dead letter not found")
+    }
+    return nil
+}
+
+func (s *Store) CountPendingDeadLetters(ctx context.Context) (int, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    var count int
+    err := s.pool.QueryRow(ctx, `
+        SELECT COUNT(*) FROM dead_letters WHERE replayed_at IS NULL`,
+    ).Scan(&count)
+    if err != nil {
+        return 0, fmt.Errorf("failed to count dead letters: %w", err)
+    }
+    return count, nil
+}
+
+func (s *Store) CreateReconciliationReport(ctx context.Context, report *models.ReconciliationReport) error {
+    conflicts, err := jsonArray(report.Conflicts)
+    if err != nil {
+        return fmt.Errorf("failed to encode reconciliation conflicts: %w", err)
+    }
+
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    err = s.pool.QueryRow(ctx, `
+        INSERT INTO reconciliation_reports (started_at, finished_at, matches_checked, conflicts)
+        VALUES ($1, $2, $3, $4)
+        RETURNING id`,
+        report.StartedAt, report.FinishedAt, report.MatchesChecked, conflicts,
+    ).Scan(&report.ID)
+    if err != nil {
+        return fmt.Errorf("failed to create reconciliation report: %w", err)
+    }
+    return nil
+}
+
+func (s *Store) ListReconciliationReports(ctx context.Context, limit int) ([]*models.ReconciliationReport, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    rows, err := s.pool.Query(ctx, `
+        SELECT id, started_at, finished_at, matches_checked, conflicts
+        FROM reconciliation_reports
+        ORDER BY started_at DESC
+        LIMIT $1`,
+        limit)
+    if err != nil {
+        return nil, fmt.Errorf("failed to list reconciliation reports: %w", err)
+    }
+    return collect(rows, func(row pgx.Row) (*models.ReconciliationReport, error) {
+        r := &models.ReconciliationReport{}
+        if err := row.Scan(&r.ID, &r.StartedAt, &r.FinishedAt, &r.MatchesChecked, &r.Conflicts); err != nil {
+            return nil, err
+        }
+        return r, nil
+    })
+}
+
+func (s *Store) GetRoomStatistics(ctx context.Context, roomID string) (*store.RoomStatistics, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    stats := &store.RoomStatistics{}
+    var lastActivity *time.Time
+    err := s.pool.QueryRow(ctx, `
+        SELECT
+            (SELECT COUNT(*) FROM messages WHERE chat_room_id = $1),
+            (SELECT COUNT(*) FROM user_chat_rooms WHERE chat_room_id = $1),
+            (SELECT MAX(created_at) FROM messages WHERE chat_room_id = $1)`,
+        roomID,
+    ).Scan(&stats.MessageCount, &stats.UserCount, &lastActivity)
+    if err != nil {
+        return nil, fmt.Errorf("failed to get room statistics: %w", err)
+    }
+    if lastActivity != nil {
+        stats.LastActivity = *lastActivity
+    }
+    return stats, nil
+}
+
+// GetUserStatistics lists up to three favorite rooms, the ones the user
+// has posted in most.
+func (s *Store) GetUserStatistics(ctx context.Context, userID string) (*store.UserStatistics, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    stats := &store.UserStatistics{}
+    var lastActive *time.Time
+    err := s.pool.QueryRow(ctx, `
+        SELECT
+            (SELECT COUNT(*) FROM messages WHERE user_id = $1),
+            (SELECT COUNT(*) FROM user_chat_rooms WHERE user_id = $1),
+            (SELECT MAX(created_at) FROM messages WHERE user_id = $1),
+            ARRAY(
+                SELECT r.name FROM messages m
+                JOIN chat_rooms r ON r.id = m.chat_room_id
+                WHERE m.user_id = $1
+                GROUP BY r.id, r.name
+                ORDER BY COUNT(*) DESC, r.name
+                LIMIT 3
+            )`,
+        userID,
+    ).Scan(&stats.MessageCount, &stats.RoomsJoined, &lastActive, &stats.FavoriteRooms)
+    if err != nil {
+        return nil, fmt.Errorf("failed to get user statistics: %w", err)
+    }
+    if lastActive != nil {
+        stats.LastActive = *lastActive
+    }
+    return stats, nil
+}
+
+// GetMatchStatistics counts viewers as members of any of the match's
+// rooms. Peak viewers are not tracked historically, so the peak is the
+// current count.
+func (s *Store) GetMatchStatistics(ctx context.Context, matchID string) (*store.MatchStatistics, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    stats := &store.MatchStatistics{}
+    err := s.pool.QueryRow(ctx, `
+        SELECT
+            (SELECT COUNT(DISTINCT u.user_id) FROM user_chat_rooms u
+                JOIN chat_rooms r ON r.id = u.chat_room_id WHERE r.match_id = $1),
+            (SELECT COUNT(*) FROM messages m
+                JOIN chat_rooms r ON r.id = m.chat_room_id WHERE r.match_id = $1),
+            (SELECT COUNT(*) FROM match_events WHERE match_id = $1)`,
+        matchID,
+    ).Scan(&stats.ViewerCount, &stats.MessageCount, &stats.EventCount)
+    if err != nil {
+        return nil, fmt.Errorf("failed to get match statistics: %w", err)
+    }
+    stats.PeakViewerCount = stats.ViewerCount
+    return stats, nil
+}
+
+// RefreshStatisticsRollups counts with one grouped pass over each table
+// rather than a query per room. Rooms that are not active take no new
+// messages, so only full runs revisit them, picking up messages
+// retention has since archived. It runs under the caller's deadline, not
+// the query timeout, as a full run counts every table.
+func (s *Store) RefreshStatisticsRollups(ctx context.Context, since time.Time, full bool) (int, int, error) {
+    var rooms, matches int
+    err := s.inTx(ctx, func(tx pgx.Tx) error {
+        tag, err := tx.Exec(ctx, `
+            INSERT INTO room_statistics_rollups (chat_room_id, message_count, user_count, last_activity, computed_at)
+            SELECT r.id, COALESCE(m.count, 0), COALESCE(u.count, 0), m.last_activity, CURRENT_TIMESTAMP
+            FROM chat_rooms r
+            LEFT JOIN (
+                SELECT chat_room_id, COUNT(*) AS count, MAX(created_at) AS last_activity
+                FROM messages GROUP BY chat_room_id
+            ) m ON m.chat_room_id = r.id
+            LEFT JOIN (
+                SELECT chat_room_id, COUNT(*) AS count FROM user_chat_rooms GROUP BY chat_room_id
+            ) u ON u.chat_room_id = r.id
+            WHERE $1 OR r.is_active
+                OR NOT EXISTS (SELECT 1 FROM room_statistics_rollups WHERE chat_room_id = r.id)
+            ON CONFLICT (chat_room_id) DO UPDATE SET
+                message_count = EXCLUDED.message_count, user_count = EXCLUDED.user_count,
+                last_activity = EXCLUDED.last_activity, computed_at = EXCLUDED.computed_at`,
+            full)
+        if err != nil {
+            return err
+        }
+        rooms = int(tag.RowsAffected())
+
+        tag, err = tx.Exec(ctx, `
+            INSERT INTO match_statistics_rollups (match_id, viewer_count, message_count, peak_viewer_count, event_count, computed_at)
+            SELECT mt.id, COALESCE(v.count, 0), COALESCE(m.count, 0), COALESCE(v.count, 0), COALESCE(e.count, 0), CURRENT_TIMESTAMP
+            FROM matches mt
+            LEFT JOIN (
+                SELECT r.match_id, COUNT(DISTINCT u.user_id) AS count FROM user_chat_rooms u
+                JOIN chat_rooms r ON r.id = u.chat_room_id
+                WHERE r.match_id IS NOT NULL GROUP BY r.match_id
+            ) v ON v.match_id = mt.id
+            LEFT JOIN (
+                SELECT r.match_id, COUNT(*) AS count FROM messages m
+                JOIN chat_rooms r ON r.id = m.chat_room_id
+                WHERE r.match_id IS NOT NULL GROUP BY r.match_id
+            ) m ON m.match_id = mt.id
+            LEFT JOIN (
+                SELECT match_id, COUNT(*) AS count FROM match_events GROUP BY match_id
+            ) e ON e.match_id = mt.id
+            WHERE $1 OR mt.start_time > $2
+                OR NOT EXISTS (SELECT 1 FROM match_statistics_rollups WHERE match_id = mt.id)
+            ON CONFLICT (match_id) DO UPDATE SET
+                viewer_count = EXCLUDED.viewer_count, message_count = EXCLUDED.message_count,
+                peak_viewer_count = GREATEST(match_statistics_rollups.peak_viewer_count, EXCLUDED.viewer_count),
+                event_count = EXCLUDED.event_count, computed_at = EXCLUDED.computed_at`,
+            full, since)
+        if err != nil {
+            return err
+        }
+        matches = int(tag.RowsAffected())
+        return nil
+    })
+    if err != nil {
+        return 0, 0, fmt.Errorf("failed to refresh statistics rollups: %w", err)
+    }
+    return rooms, matches, nil
+}
+
+func (s *Store) GetRoomStatisticsRollup(ctx context.Context, roomID string) (*store.RoomStatistics, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    stats := &store.RoomStatistics{}
+    var lastActivity *time.Time
+    err := s.pool.QueryRow(ctx, `
+        SELECT message_count, user_count, last_activity, computed_at
+        FROM room_statistics_rollups WHERE chat_room_id = $1`,
+        roomID,
+    ).Scan(&stats.MessageCount, &stats.UserCount, &lastActivity, &stats.ComputedAt)
+    if errors.Is(err, pgx.ErrNoRows) {
+        return nil, nil
+    }
+    if err != nil {
+        return nil, fmt.Errorf("failed to get room statistics rollup: %w", err)
+    }
+    if lastActivity != nil {
+        stats.LastActivity = *lastActivity
+    }
+    return stats, nil
+}
+
+func (s *Store) GetMatchStatisticsRollup(ctx context.Context, matchID string) (*store.MatchStatistics, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    stats := &store.MatchStatistics{}
+    err := s.pool.QueryRow(ctx, `
+        SELECT viewer_count, message_count, peak_viewer_count, event_count, computed_at
+        FROM match_statistics_rollups WHERE match_id = $1`,
+        matchID,
+    ).Scan(&stats.ViewerCount, &stats.MessageCount, &stats.PeakViewerCount, &stats.EventCount, &stats.ComputedAt)
+    if errors.Is(err, pgx.ErrNoRows) {
+        return nil, nil
+    }
+    if err != nil {
+        return nil, fmt.Errorf("failed to get match statistics rollup: %w", err)
+    }
+    return stats, nil
+}
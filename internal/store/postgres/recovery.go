@@ -0,0 +1,166 @@
+package postgres
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "time"
+
+    "github.com/jackc/pgx/v5"
+
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+const recoveryTokenColumns = `
+    id, user_id, purpose, token_hash, email, COALESCE(issued_by::text, ''), reason,
+    created_at, expires_at, used_at`
+
+func scanRecoveryToken(row pgx.Row) (*models.RecoveryToken, error) {
+    t := &models.RecoveryToken{}
+    err := row.Scan(&t.ID, &t.UserID, &t.Purpose, &t.TokenHash, &t.Email, &t.IssuedBy, &t.Reason,
+        &t.CreatedAt, &t.ExpiresAt, &t.UsedAt)
+    if err != nil {
+        return nil, err
+    }
+    return t, nil
+}
+
+func (s *Store) ReplaceRecoveryCodes(ctx context.Context, userID string, codes []*models.RecoveryCode) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    err := s.inTx(ctx, func(tx pgx.Tx) error {
+        if _, err := tx.Exec(ctx, `DELETE FROM recovery_codes WHERE user_id = $1`, userID); err != nil {
+            return err
+        }
+        for _, code := range codes {
+            err := tx.QueryRow(ctx, `
+                INSERT INTO recovery_codes (user_id, code_hash, code_lookup)
+                VALUES ($1, $2, NULLIF($3, ''))
+                RETURNING id, created_at`,
+                userID, code.CodeHash, code.CodeLookup,
+            ).Scan(&code.ID, &code.CreatedAt)
+            if err != nil {
+                return err
+            }
+            code.UserID, code.UsedAt = userID, nil
+        }
+        return nil
+    })
+    if err != nil {
+        return fmt.Errorf("failed to replace recovery codes: %w", err)
+    }
+    return nil
+}
+
+func (s *Store) GetUnusedRecoveryCodes(ctx context.Context, userID string) ([]*models.RecoveryCode, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    rows, err := s.pool.Query(ctx, `
+        SELECT id, user_id, code_hash, COALESCE(code_lookup, ''), created_at, used_at
+        FROM recovery_codes
+        WHERE user_id = $1 AND used_at IS NULL
+        ORDER BY id`,
+        userID)
+    if err != nil {
+        return nil, fmt.Errorf("failed to get recovery codes: %w", err)
+    }
+    return collect(rows, func(row pgx.Row) (*models.RecoveryCode, error) {
+        c := &models.RecoveryCode{}
+        if err := row.Scan(&c.ID, &c.UserID, &c.CodeHash, &c.CodeLookup, &c.CreatedAt, &c.UsedAt); err != nil {
+            return nil, err
+        }
+        return c, nil
+    })
+}
+
+func (s *Store) UseRecoveryCode(ctx context.Context, id string, at time.Time) (bool, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    used, err := affected(s.pool.Exec(ctx, `
+        UPDATE recovery_codes SET used_at = $2 WHERE id = $1 AND used_at IS NULL`,
+        id, at))
+    if err != nil {
+        return false, fmt.Errorf("failed to use recovery code: %w", err)
+    }
+    return used, nil
+}
+
+func (s *Store) SetRecoveryEmail(ctx context.Context, userID, email string, verifiedAt time.Time) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    var err error
+    if email == "" {
+        _, err = s.pool.Exec(ctx, `DELETE FROM recovery_emails WHERE user_id = $1`, userID)
+    } else {
+        _, err = s.pool.Exec(ctx, `
+            INSERT INTO recovery_emails (user_id, email, verified_at)
+            VALUES ($1, $2, $3)
+            ON CONFLICT (user_id) DO UPDATE SET
+                email = EXCLUDED.email, verified_at = EXCLUDED.verified_at`,
+            userID, email, verifiedAt)
+    }
+    if err != nil {
+        return fmt.Errorf("failed to set recovery email: %w", err)
+    }
+    return nil
+}
+
+func (s *Store) GetRecoveryEmail(ctx context.Context, userID string) (string, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    var email string
+    err := s.pool.QueryRow(ctx, `SELECT email FROM recovery_emails WHERE user_id = $1`, userID).Scan(&email)
+    if errors.Is(err, pgx.ErrNoRows) {
+        return "", nil
+    }
+    if err != nil {
+        return "", fmt.Errorf("failed to get recovery email: %w", err)
+    }
+    return email, nil
+}
+
+func (s *Store) CreateRecoveryToken(ctx context.Context, token *models.RecoveryToken) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    err := s.pool.QueryRow(ctx, `
+        INSERT INTO recovery_tokens (user_id, purpose, token_hash, email, issued_by, reason, expires_at)
+        VALUES ($1, $2, $3, $4, NULLIF($5, '')::uuid, $6, $7)
+        RETURNING id, created_at`,
+        token.UserID, token.Purpose, token.TokenHash, token.Email, token.IssuedBy, token.Reason, token.ExpiresAt,
+    ).Scan(&token.ID, &token.CreatedAt)
+    if err != nil {
+        return fmt.Errorf("failed to create recovery token: %w", err)
+    }
+    return nil
+}
+
+func (s *Store) GetRecoveryToken(ctx context.Context, tokenHash string) (*models.RecoveryToken, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    token, err := scanRecoveryToken(s.pool.QueryRow(ctx, `
+        SELECT `+recoveryTokenColumns+` FROM recovery_tokens WHERE token_hash = $1`, tokenHash))
+    if err != nil {
+        return nil, notFound(err, "recovery token")
+    }
+    return token, nil
+}
+
+func (s *Store) UseRecoveryToken(ctx context.Context, id string, at time.Time) (bool, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    used, err := affected(s.pool.Exec(ctx, `
+        UPDATE recovery_tokens SET used_at = $2 WHERE id = $1 AND used_at IS NULL`,
+        id, at))
+    if err != nil {
+        return false, fmt.Errorf("failed to use recovery token: %w", err)
+    }
+    return used, nil
+}
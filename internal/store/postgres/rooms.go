@@ -0,0 +1,685 @@
+package postgres
+
+import (
+    "context"
+    "fmt"
+    "strings"
+    "time"
+
+    "github.com/jackc/pgx/v5"
+
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+const roomColumns = `
+    r.id, COALESCE(r.match_id::text, ''), COALESCE(r.parent_id::text, ''), COALESCE(r.language, ''),
+    COALESCE(r.allow_link_previews, true), r.initial_history, r.slow_mode_seconds, r.name, COALESCE(r.description, ''),
+    COALESCE(r.is_active, true), r.state, r.state_changed_at, COALESCE(r.owner_id::text, ''),
+    COALESCE(r.pinned_message_id::text, ''), r.min_age, r.age_verified, r.broadcast_only, r.created_at, r.updated_at`
+
+func roomFields(r *models.ChatRoom) []any {
+    return []any{
+        &r.ID, &r.MatchID, &r.ParentID, &r.Language,
+        &r.AllowLinkPreviews, &r.InitialHistory, &r.SlowModeSeconds, &r.Name, &r.Description,
+        &r.IsActive, &r.State, &r.StateChangedAt, &r.OwnerID,
+        &r.PinnedMessageID, &r.MinAge, &r.AgeVerified, &r.BroadcastOnly, &r.CreatedAt, &r.UpdatedAt,
+    }
+}
+
+func scanRoom(row pgx.Row) (*models.ChatRoom, error) {
+    room := &models.ChatRoom{}
+    if err := row.Scan(roomFields(room)...); err != nil {
+        return nil, err
+    }
+    return room, nil
+}
+
+func (s *Store) CreateChatRoom(ctx context.Context, room *models.ChatRoom) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    err := s.pool.QueryRow(ctx, `
+        INSERT INTO chat_rooms (
+            match_id, parent_id, language, allow_link_previews, initial_history,
+            name, description, is_active, state, slow_mode_seconds, owner_id,
+            min_age, age_verified, broadcast_only
+        ) VALUES (
+            NULLIF($1, '')::uuid, NULLIF($2, '')::uuid, NULLIF($3, ''), $4, $5,
+            $6, NULLIF($7, ''), $8, COALESCE(NULLIF($9, ''), 'open'), $10, NULLIF($11, '')::uuid,
+            $12, $13, $14
+        )
+        RETURNING id, state, state_changed_at, created_at, updated_at`,
+        room.MatchID, room.ParentID, room.Language, room.AllowLinkPreviews, room.InitialHistory,
+        room.Name, room.Description, room.IsActive, room.State, room.SlowModeSeconds, room.OwnerID,
+        room.MinAge, room.AgeVerified, room.BroadcastOnly,
+    ).Scan(&room.ID, &room.State, &room.StateChangedAt, &room.CreatedAt, &room.UpdatedAt)
+    if err != nil {
+        return fmt.Errorf("failed to create chat room: %w", err)
+    }
+    return nil
+}
+
+func (s *Store) GetChatRoom(ctx context.Context, id string) (*models.ChatRoom, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    room, err := scanRoom(s.pool.QueryRow(ctx, `SELECT `+roomColumns+` FROM chat_rooms r WHERE r.id = $1`, id))
+    if err != nil {
+        return nil, notFound(err, "chat room")
+    }
+    return room, nil
+}
+
+// GetMatchChatRoom returns the match's own room, not any of its shards.
+func (s *Store) GetMatchChatRoom(ctx context.Context, matchID string) (*models.ChatRoom, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    room, err := scanRoom(s.pool.QueryRow(ctx, `
+        SELECT `+roomColumns+` FROM chat_rooms r
+        WHERE r.match_id = $1 AND r.parent_id IS NULL
+        ORDER BY r.created_at
+        LIMIT 1`,
+        matchID))
+    if err != nil {
+        return nil, notFound(err, "chat room")
+    }
+    return room, nil
+}
+
+func (s *Store) ListChatRooms(ctx context.Context) ([]*models.ChatRoom, error) {
+    return s.listRooms(ctx, `SELECT `+roomColumns+` FROM chat_rooms r ORDER BY r.created_at`)
+}
+
+func (s *Store) GetChatRoomsByState(ctx context.Context, state string) ([]*models.ChatRoom, error) {
+    return s.listRooms(ctx, `SELECT `+roomColumns+` FROM chat_rooms r WHERE r.state = $1 ORDER BY r.state_changed_at`, state)
+}
+
+func (s *Store) listRooms(ctx context.Context, query string, args ...any) ([]*models.ChatRoom, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    rows, err := s.pool.Query(ctx, query, args...)
+    if err != nil {
+        return nil, fmt.Errorf("failed to list chat rooms: %w", err)
+    }
+    rooms, err := collect(rows, scanRoom)
+    if err != nil {
+        return nil, fmt.Errorf("failed to list chat rooms: %w", err)
+    }
+    return rooms, nil
+}
+
+// UpdateChatRoom saves the room's settings. Its state only changes
+// through TransitionChatRoom.
+func (s *Store) UpdateChatRoom(ctx context.Context, room *models.ChatRoom) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    err := s.pool.QueryRow(ctx, `
+        UPDATE chat_rooms SET
+            match_id = NULLIF($2, '')::uuid, parent_id = NULLIF($3, '')::uuid, language = NULLIF($4, ''),
+            allow_link_previews = $5, initial_history = $6, name = $7, description = NULLIF($8, ''), is_active = $9,
+            slow_mode_seconds = $10, owner_id = NULLIF($11, '')::uuid, min_age = $12, age_verified = $13,
+            broadcast_only = $14
+        WHERE id = $1
+        RETURNING updated_at`,
+        room.ID, room.MatchID, room.ParentID, room.Language,
+        room.AllowLinkPreviews, room.InitialHistory, room.Name, room.Description, room.IsActive,
+        room.SlowModeSeconds, room.OwnerID, room.MinAge, room.AgeVerified, room.BroadcastOnly,
+    ).Scan(&room.UpdatedAt)
+    if err != nil {
+        return fmt.Errorf("failed to update chat room: %w", err)
+    }
+    return nil
+}
+
+func (s *Store) DeleteChatRoom(ctx context.Context, id string) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    if _, err := s.pool.Exec(ctx, `DELETE FROM chat_rooms WHERE id = $1`, id); err != nil {
+        return fmt.Errorf("failed to delete chat room: %w", err)
+    }
+    return nil
+}
+
+// MergeChatRooms moves the source room's history and members into the
+// target and deactivates the source.
+func (s *Store) MergeChatRooms(ctx context.Context, sourceID, targetID string) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    err := s.inTx(ctx, func(tx pgx.Tx) error {
+        if _, err := tx.Exec(ctx, `UPDATE messages SET chat_room_id = $2 WHERE chat_room_id = $1`, sourceID, targetID); err != nil {
+            return err
+        }
+        if err := moveMembers(ctx, tx, sourceID, targetID, nil); err != nil {
+            return err
+        }
+        _, err := tx.Exec(ctx, `UPDATE chat_rooms SET is_active = false WHERE id = $1`, sourceID)
+        return err
+    })
+    if err != nil {
+        return fmt.Errorf("failed to merge chat rooms: %w", err)
+    }
+    return nil
+}
+
+func (s *Store) MoveRoomMembers(ctx context.Context, fromRoomID, toRoomID string, userIDs []string) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    err := s.inTx(ctx, func(tx pgx.Tx) error {
+        return moveMembers(ctx, tx, fromRoomID, toRoomID, userIDs)
+    })
+    if err != nil {
+        return fmt.Errorf("failed to move room members: %w", err)
+    }
+    return nil
+}
+
+// moveMembers moves the given members, or all of them for nil userIDs,
+// keeping their read markers.
+func moveMembers(ctx context.Context, q querier, fromRoomID, toRoomID string, userIDs []string) error {
+    all := userIDs == nil
+    if _, err := q.Exec(ctx, `
+        INSERT INTO user_chat_rooms (user_id, chat_room_id, last_read_at)
+        SELECT user_id, $2, last_read_at FROM user_chat_rooms
+        WHERE chat_room_id = $1 AND ($3 OR user_id = ANY($4::uuid[]))
+        ON CONFLICT DO NOTHING`,
+        fromRoomID, toRoomID, all, userIDs); err != nil {
+        return err
+    }
+    _, err := q.Exec(ctx, `
+        DELETE FROM user_chat_rooms
+        WHERE chat_room_id = $1 AND ($2 OR user_id = ANY($3::uuid[]))`,
+        fromRoomID, all, userIDs)
+    return err
+}
+
+func (s *Store) TransitionChatRoom(ctx context.Context, id, from, to string, at time.Time) (bool, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    moved, err := affected(s.pool.Exec(ctx, `
+        UPDATE chat_rooms SET
+            state = $3, state_changed_at = $4,
+            is_active = CASE WHEN $3 = 'archived' THEN false ELSE is_active END
+        WHERE id = $1 AND state = $2`,
+        id, from, to, at))
+    if err != nil {
+        return false, fmt.Errorf("failed to transition chat room: %w", err)
+    }
+    return moved, nil
+}
+
+const topicColumns = `id, room_id, slug, title, position, COALESCE(created_by::text, ''), created_at, collapsed_at`
+
+func scanTopic(row pgx.Row) (*models.RoomTopic, error) {
+    t := &models.RoomTopic{}
+    if err := row.Scan(&t.ID, &t.RoomID, &t.Slug, &t.Title, &t.Position, &t.CreatedBy, &t.CreatedAt, &t.CollapsedAt); err != nil {
+        return nil, err
+    }
+    return t, nil
+}
+
+func (s *Store) CreateRoomTopic(ctx context.Context, topic *models.RoomTopic) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    err := s.pool.QueryRow(ctx, `
+        INSERT INTO room_topics (room_id, slug, title, position, created_by, created_at)
+        VALUES ($1, $2, $3, $4, NULLIF($5, '')::uuid, COALESCE($6, CURRENT_TIMESTAMP))
+        RETURNING id, created_at`,
+        topic.RoomID, topic.Slug, topic.Title, topic.Position, topic.CreatedBy, nullTime(topic.CreatedAt),
+    ).Scan(&topic.ID, &topic.CreatedAt)
+    if err != nil {
+        return fmt.Errorf("failed to create room topic: %w", err)
+    }
+    return nil
+}
+
+func (s *Store) GetRoomTopics(ctx context.Context, roomID string) ([]*models.RoomTopic, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    rows, err := s.pool.Query(ctx, `
+        SELECT `+topicColumns+` FROM room_topics
+        WHERE room_id = $1
+        ORDER BY position, created_at`,
+        roomID)
+    if err != nil {
+        return nil, fmt.Errorf("failed to get room topics: %w", err)
+    }
+    return collect(rows, scanTopic)
+}
+
+func (s *Store) CollapseRoomTopic(ctx context.Context, id string, at time.Time) (bool, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    collapsed, err := affected(s.pool.Exec(ctx, `
+        UPDATE room_topics SET collapsed_at = $2 WHERE id = $1 AND collapsed_at IS NULL`,
+        id, at))
+    if err != nil {
+        return false, fmt.Errorf("failed to collapse room topic: %w", err)
+    }
+    return collapsed, nil
+}
+
+func (s *Store) CollapseRoomTopics(ctx context.Context, roomID string, at time.Time) (int, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    tag, err := s.pool.Exec(ctx, `
+        UPDATE room_topics SET collapsed_at = $2 WHERE room_id = $1 AND collapsed_at IS NULL`,
+        roomID, at)
+    if err != nil {
+        return 0, fmt.Errorf("failed to collapse room topics: %w", err)
+    }
+    return int(tag.RowsAffected()), nil
+}
+
+func (s *Store) JoinChatRoom(ctx context.Context, userID, roomID string) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    if _, err := s.pool.Exec(ctx, `
+        INSERT INTO user_chat_rooms (user_id, chat_room_id) VALUES ($1, $2)
+        ON CONFLICT DO NOTHING`,
+        userID, roomID); err != nil {
+        return fmt.Errorf("failed to join chat room: %w", err)
+    }
+    return nil
+}
+
+func (s *Store) LeaveChatRoom(ctx context.Context, userID, roomID string) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    if _, err := s.pool.Exec(ctx, `
+        DELETE FROM user_chat_rooms WHERE user_id = $1 AND chat_room_id = $2`,
+        userID, roomID); err != nil {
+        return fmt.Errorf("failed to leave chat room: %w", err)
+    }
+    return nil
+}
+
+func (s *Store) GetRoomUsers(ctx context.Context, roomID string) ([]*models.User, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    rows, err := s.pool.Query(ctx, `
+        SELECT `+userColumns+` FROM users u
+        JOIN user_chat_rooms ucr ON ucr.user_id = u.id
+        WHERE ucr.chat_room_id = $1
+        ORDER BY u.username`,
+        roomID)
+    if err != nil {
+        return nil, fmt.Errorf("failed to get room users: %w", err)
+    }
+    return collect(rows, scanUser)
+}
+
+func (s *Store) GetRoomMembersByUsername(ctx context.Context, roomID string, usernames []string) ([]*models.User, error) {
+    if len(usernames) == 0 {
+        return nil, nil
+    }
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    lowered := make([]string, len(usernames))
+    for i, name := range usernames {
+        lowered[i] = strings.ToLower(name)
+    }
+    rows, err := s.pool.Query(ctx, `
+        SELECT `+userColumns+` FROM users u
+        JOIN user_chat_rooms ucr ON ucr.user_id = u.id
+        WHERE ucr.chat_room_id = $1 AND LOWER(u.username) = ANY($2::text[])`,
+        roomID, lowered)
+    if err != nil {
+        return nil, fmt.Errorf("failed to get room members: %w", err)
+    }
+    return collect(rows, scanUser)
+}
+
+func (s *Store) GetUserRooms(ctx context.Context, userID string) ([]*models.ChatRoom, error) {
+    return s.listRooms(ctx, `
+        SELECT `+roomColumns+` FROM chat_rooms r
+        JOIN user_chat_rooms ucr ON ucr.chat_room_id = r.id
+        WHERE ucr.user_id = $1
+        ORDER BY r.created_at`,
+        userID)
+}
+
+// unreadCount counts other users' messages in room r since the member
+// ucr's read marker.
+const unreadCount = `(
+    SELECT COUNT(*) FROM messages m
+    WHERE m.chat_room_id = r.id AND m.created_at > ucr.last_read_at AND m.user_id IS DISTINCT FROM ucr.user_id
+)`
+
+// GetUserRoomSummaries loads the last message and the match as JSON, whose
+// keys are the models' own, so one round trip covers every room.
+func (s *Store) GetUserRoomSummaries(ctx context.Context, userID string) ([]*models.RoomSummary, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    rows, err := s.pool.Query(ctx, `
+        SELECT `+roomColumns+`, ucr.last_read_at, `+unreadCount+`,
+            (
+                SELECT to_jsonb(m) || jsonb_build_object('user', jsonb_build_object(
+                    'id', u.id, 'username', u.username, 'avatar_url', u.avatar_url, 'flair', u.favorite_team))
+                FROM messages m
+                LEFT JOIN users u ON u.id = m.user_id
+                WHERE m.chat_room_id = r.id
+                ORDER BY m.created_at DESC, m.id DESC
+                LIMIT 1
+            ),
+            (
+                SELECT to_jsonb(mt) || jsonb_build_object('home_team', to_jsonb(home), 'away_team', to_jsonb(away))
+                FROM matches mt
+                LEFT JOIN teams home ON home.id = mt.home_team_id
+                LEFT JOIN teams away ON away.id = mt.away_team_id
+                WHERE mt.id = r.match_id
+            )
+        FROM user_chat_rooms ucr
+        JOIN chat_rooms r ON r.id = ucr.chat_room_id
+        WHERE ucr.user_id = $1
+        ORDER BY r.created_at`,
+        userID)
+    if err != nil {
+        return nil, fmt.Errorf("failed to get room summaries: %w", err)
+    }
+    return collect(rows, func(row pgx.Row) (*models.RoomSummary, error) {
+        summary := &models.RoomSummary{Room: &models.ChatRoom{}}
+        fields := append(roomFields(summary.Room), &summary.LastReadAt, &summary.UnreadCount, &summary.LastMessage, &summary.Match)
+        if err := row.Scan(fields...); err != nil {
+            return nil, err
+        }
+        return summary, nil
+    })
+}
+
+func (s *Store) MarkRoomRead(ctx context.Context, userID, roomID string, at time.Time) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    if _, err := s.pool.Exec(ctx, `
+        UPDATE user_chat_rooms SET last_read_at = GREATEST(last_read_at, $3)
+        WHERE user_id = $1 AND chat_room_id = $2`,
+        userID, roomID, at); err != nil {
+        return fmt.Errorf("failed to mark room read: %w", err)
+    }
+    return nil
+}
+
+func (s *Store) GetUnreadCounts(ctx context.Context, userID string) ([]*models.UnreadCount, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    rows, err := s.pool.Query(ctx, `
+        SELECT r.id, `+unreadCount+`, ucr.last_read_at
+        FROM user_chat_rooms ucr
+        JOIN chat_rooms r ON r.id = ucr.chat_room_id
+        WHERE ucr.user_id = $1`,
+        userID)
+    if err != nil {
+        return nil, fmt.Errorf("failed to get unread counts: %w", err)
+    }
+    return collect(rows, func(row pgx.Row) (*models.UnreadCount, error) {
+        c := &models.UnreadCount{}
+        if err := row.Scan(&c.RoomID, &c.UnreadCount, &c.LastReadAt); err != nil {
+            return nil, err
+        }
+        return c, nil
+    })
+}
+
+func (s *Store) CreateRoomSanction(ctx context.Context, sanction *models.RoomSanction) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    err := s.pool.QueryRow(ctx, `
+        INSERT INTO room_sanctions (room_id, user_id, kind, reason, created_by, expires_at, created_at)
+        VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6, COALESCE($7, CURRENT_TIMESTAMP))
+        ON CONFLICT (room_id, user_id, kind) DO UPDATE SET
+            reason = EXCLUDED.reason, created_by = EXCLUDED.created_by,
+            expires_at = EXCLUDED.expires_at, created_at = EXCLUDED.created_at
+        RETURNING created_at`,
+        sanction.RoomID, sanction.UserID, sanction.Kind, sanction.Reason, sanction.CreatedBy,
+        sanction.ExpiresAt, nullTime(sanction.CreatedAt),
+    ).Scan(&sanction.CreatedAt)
+    if err != nil {
+        return fmt.Errorf("failed to create room sanction: %w", err)
+    }
+    return nil
+}
+
+func (s *Store) DeleteRoomSanction(ctx context.Context, roomID, userID, kind string) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    if _, err := s.pool.Exec(ctx, `
+        DELETE FROM room_sanctions WHERE room_id = $1 AND user_id = $2 AND kind = $3`,
+        roomID, userID, kind); err != nil {
+        return fmt.Errorf("failed to delete room sanction: %w", err)
+    }
+    return nil
+}
+
+func (s *Store) GetRoomSanctions(ctx context.Context, roomID, userID string) ([]*models.RoomSanction, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    rows, err := s.pool.Query(ctx, `
+        SELECT room_id, user_id, kind, COALESCE(reason, ''), created_by, expires_at, created_at
+        FROM room_sanctions
+        WHERE room_id = $1 AND user_id = $2 AND (expires_at IS NULL OR expires_at > CURRENT_TIMESTAMP)`,
+        roomID, userID)
+    if err != nil {
+        return nil, fmt.Errorf("failed to get room sanctions: %w", err)
+    }
+    return collect(rows, func(row pgx.Row) (*models.RoomSanction, error) {
+        r := &models.RoomSanction{}
+        if err := row.Scan(&r.RoomID, &r.UserID, &r.Kind, &r.Reason, &r.CreatedBy, &r.ExpiresAt, &r.CreatedAt); err != nil {
+            return nil, err
+        }
+        return r, nil
+    })
+}
+
+func (s *Store) ListRoomSanctionsBetween(ctx context.Context, roomID string, from, to time.Time) ([]*models.RoomSanction, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    rows, err := s.pool.Query(ctx, `
+        SELECT room_id, user_id, kind, COALESCE(reason, ''), created_by, expires_at, created_at
+        FROM room_sanctions
+        WHERE room_id = $1 AND created_at >= $2 AND created_at < $3
+        ORDER BY created_at`,
+        roomID, from, to)
+    if err != nil {
+        return nil, fmt.Errorf("failed to list room sanctions: %w", err)
+    }
+    return collect(rows, func(row pgx.Row) (*models.RoomSanction, error) {
+        r := &models.RoomSanction{}
+        if err := row.Scan(&r.RoomID, &r.UserID, &r.Kind, &r.Reason, &r.CreatedBy, &r.ExpiresAt, &r.CreatedAt); err != nil {
+            return nil, err
+        }
+        return r, nil
+    })
+}
+
+func (s *Store) SetPinnedMessage(ctx context.Context, roomID, messageID string) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    if _, err := s.pool.Exec(ctx, `
+        UPDATE chat_rooms SET pinned_message_id = NULLIF($2, '')::uuid WHERE id = $1`,
+        roomID, messageID); err != nil {
+        return fmt.Errorf("failed to set pinned message: %w", err)
+    }
+    return nil
+}
+
+// GetRoomRole prefers a granted role over watch party ownership, so an
+// owner can be demoted without clearing the room's owner.
+func (s *Store) GetRoomRole(ctx context.Context, roomID, userID string) (string, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    var role string
+    err := s.pool.QueryRow(ctx, `
+        SELECT COALESCE(
+            (SELECT role FROM room_roles WHERE room_id = $1 AND user_id = $2),
+            (SELECT 'owner' FROM chat_rooms WHERE id = $1 AND owner_id = $2),
+            'member')`,
+        roomID, userID,
+    ).Scan(&role)
+    if err != nil {
+        return "", fmt.Errorf("failed to get room role: %w", err)
+    }
+    return role, nil
+}
+
+func (s *Store) ListRoomRoles(ctx context.Context, roomID string) ([]*models.RoomRole, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    rows, err := s.pool.Query(ctx, `
+        SELECT rr.room_id, rr.user_id, rr.role, COALESCE(rr.granted_by::text, ''), rr.created_at,
+            u.username, COALESCE(u.avatar_url, ''), COALESCE(u.favorite_team, '')
+        FROM room_roles rr
+        JOIN users u ON u.id = rr.user_id
+        WHERE rr.room_id = $1
+        ORDER BY rr.created_at`,
+        roomID)
+    if err != nil {
+        return nil, fmt.Errorf("failed to list room roles: %w", err)
+    }
+    return collect(rows, func(row pgx.Row) (*models.RoomRole, error) {
+        r := &models.RoomRole{User: &models.UserSummary{}}
+        err := row.Scan(&r.RoomID, &r.UserID, &r.Role, &r.GrantedBy, &r.CreatedAt,
+            &r.User.Username, &r.User.AvatarURL, &r.User.Flair)
+        if err != nil {
+            return nil, err
+        }
+        r.User.ID = r.UserID
+        return r, nil
+    })
+}
+
+func (s *Store) SetRoomRole(ctx context.Context, role *models.RoomRole) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    err := s.pool.QueryRow(ctx, `
+        INSERT INTO room_roles (room_id, user_id, role, granted_by)
+        VALUES ($1, $2, $3, NULLIF($4, '')::uuid)
+        ON CONFLICT (room_id, user_id) DO UPDATE SET
+            role = EXCLUDED.role, granted_by = EXCLUDED.granted_by, created_at = CURRENT_TIMESTAMP
+        RETURNING created_at`,
+        role.RoomID, role.UserID, role.Role, role.GrantedBy,
+    ).Scan(&role.CreatedAt)
+    if err != nil {
+        return fmt.Errorf("failed to set room role: %w", err)
+    }
+    return nil
+}
+
+func (s *Store) DeleteRoomRole(ctx context.Context, roomID, userID string) (bool, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    ok, err := affected(s.pool.Exec(ctx, `
+        DELETE FROM room_roles WHERE room_id = $1 AND user_id = $2`,
+        roomID, userID))
+    if err != nil {
+        return false, fmt.Errorf("failed to delete room role: %w", err)
+    }
+    return ok, nil
+}
+
+const voiceParticipantSelect = `
+    SELECT v.room_id, v.user_id, v.role, v.hand_raised, v.joined_at,
+        u.username, COALESCE(u.avatar_url, ''), COALESCE(u.favorite_team, '')
+    FROM voice_participants v
+    JOIN users u ON u.id = v.user_id`
+
+func scanVoiceParticipant(row pgx.Row) (*models.VoiceParticipant, error) {
+    p := &models.VoiceParticipant{User: &models.UserSummary{}}
+    err := row.Scan(&p.RoomID, &p.UserID, &p.Role, &p.HandRaised, &p.JoinedAt,
+        &p.User.Username, &p.User.AvatarURL, &p.User.Flair)
+    if err != nil {
+        return nil, err
+    }
+    p.User.ID = p.UserID
+    return p, nil
+}
+
+func (s *Store) JoinVoiceSession(ctx context.Context, participant *models.VoiceParticipant) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    err := s.pool.QueryRow(ctx, `
+        INSERT INTO voice_participants (room_id, user_id, role, hand_raised, joined_at)
+        VALUES ($1, $2, $3, $4, COALESCE($5, CURRENT_TIMESTAMP))
+        ON CONFLICT (room_id, user_id) DO UPDATE SET
+            role = EXCLUDED.role, hand_raised = EXCLUDED.hand_raised, joined_at = EXCLUDED.joined_at
+        RETURNING joined_at`,
+        participant.RoomID, participant.UserID, participant.Role, participant.HandRaised, nullTime(participant.JoinedAt),
+    ).Scan(&participant.JoinedAt)
+    if err != nil {
+        return fmt.Errorf("failed to join voice session: %w", err)
+    }
+    return nil
+}
+
+func (s *Store) LeaveVoiceSession(ctx context.Context, roomID, userID string) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    if _, err := s.pool.Exec(ctx, `
+        DELETE FROM voice_participants WHERE room_id = $1 AND user_id = $2`,
+        roomID, userID); err != nil {
+        return fmt.Errorf("failed to leave voice session: %w", err)
+    }
+    return nil
+}
+
+func (s *Store) GetVoiceParticipant(ctx context.Context, roomID, userID string) (*models.VoiceParticipant, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    participant, err := scanVoiceParticipant(s.pool.QueryRow(ctx,
+        voiceParticipantSelect+` WHERE v.room_id = $1 AND v.user_id = $2`, roomID, userID))
+    if err != nil {
+        return nil, notFound(err, "voice participant")
+    }
+    return participant, nil
+}
+
+func (s *Store) GetVoiceParticipants(ctx context.Context, roomID string) ([]*models.VoiceParticipant, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    rows, err := s.pool.Query(ctx, voiceParticipantSelect+` WHERE v.room_id = $1 ORDER BY v.joined_at`, roomID)
+    if err != nil {
+        return nil, fmt.Errorf("failed to get voice participants: %w", err)
+    }
+    return collect(rows, scanVoiceParticipant)
+}
+
+func (s *Store) UpdateVoiceParticipant(ctx context.Context, participant *models.VoiceParticipant) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    if _, err := s.pool.Exec(ctx, `
+        UPDATE voice_participants SET role = $3, hand_raised = $4 WHERE room_id = $1 AND user_id = $2`,
+        participant.RoomID, participant.UserID, participant.Role, participant.HandRaised); err != nil {
+        return fmt.Errorf("failed to update voice participant: %w", err)
+    }
+    return nil
+}
@@ -0,0 +1,203 @@
+package postgres
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "time"
+
+    "github.com/jackc/pgx/v5"
+
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+const seasonColumns = `id, name, COALESCE(competition_id::text, ''), starts_at, ends_at, archived_at, created_at`
+
+func scanSeason(row pgx.Row) (*models.Season, error) {
+    s := &models.Season{}
+    if err := row.Scan(&s.ID, &s.Name, &s.CompetitionID, &s.StartsAt, &s.EndsAt, &s.ArchivedAt, &s.CreatedAt); err != nil {
+        return nil, err
+    }
+    return s, nil
+}
+
+// seasonTable ranks users by the points of their scored predictions on
+// matches kicking off in [$1, $2), of the competition $3 or, when $3 is
+// empty, of any.
+const seasonTable = `
+    SELECT p.user_id,
+           SUM(p.points)::int AS points,
+           (COUNT(*) FILTER (WHERE p.correct))::int AS correct,
+           COUNT(*)::int AS predictions,
+           (RANK() OVER (ORDER BY SUM(p.points) DESC, COUNT(*) FILTER (WHERE p.correct) DESC))::int AS rank
+    FROM predictions p
+    JOIN matches m ON m.id = p.match_id
+    WHERE p.scored_at IS NOT NULL AND m.start_time >= $1 AND m.start_time < $2
+      AND ($3 = '' OR m.competition_id::text = $3)
+    GROUP BY p.user_id`
+
+func (s *Store) CreateSeason(ctx context.Context, season *models.Season) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    err := s.pool.QueryRow(ctx, `
+        INSERT INTO seasons (name, competition_id, starts_at, ends_at, created_at)
+        VALUES ($1, NULLIF($2, '')::uuid, $3, $4, COALESCE($5, CURRENT_TIMESTAMP))
+        RETURNING id, created_at`,
+        season.Name, season.CompetitionID, season.StartsAt, season.EndsAt, nullTime(season.CreatedAt),
+    ).Scan(&season.ID, &season.CreatedAt)
+    if err != nil {
+        return fmt.Errorf("failed to create season: %w", err)
+    }
+    return nil
+}
+
+func (s *Store) GetSeason(ctx context.Context, id string) (*models.Season, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    season, err := scanSeason(s.pool.QueryRow(ctx, `SELECT `+seasonColumns+` FROM seasons WHERE id = $1`, id))
+    if err != nil {
+        return nil, notFound(err, "season")
+    }
+    return season, nil
+}
+
+// GetSeasonAt returns the competition's season running at the given time,
+// or the site-wide one for an empty competitionID.
+func (s *Store) GetSeasonAt(ctx context.Context, competitionID string, at time.Time) (*models.Season, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    season, err := scanSeason(s.pool.QueryRow(ctx, `
+        SELECT `+seasonColumns+` FROM seasons
+        WHERE COALESCE(competition_id::text, '') = $1 AND starts_at <= $2 AND ends_at > $2
+        ORDER BY starts_at DESC
+        LIMIT 1`,
+        competitionID, at))
+    if err != nil {
+        return nil, notFound(err, "season")
+    }
+    return season, nil
+}
+
+// ListSeasons lists seasons newest first, of one competition or of all
+// for an empty competitionID.
+func (s *Store) ListSeasons(ctx context.Context, competitionID string) ([]*models.Season, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    rows, err := s.pool.Query(ctx, `
+        SELECT `+seasonColumns+` FROM seasons
+        WHERE $1 = '' OR competition_id::text = $1
+        ORDER BY starts_at DESC`,
+        competitionID)
+    if err != nil {
+        return nil, fmt.Errorf("failed to list seasons: %w", err)
+    }
+    return collect(rows, scanSeason)
+}
+
+// GetDueSeasons returns the seasons that have ended but are not archived.
+func (s *Store) GetDueSeasons(ctx context.Context, now time.Time) ([]*models.Season, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    rows, err := s.pool.Query(ctx, `
+        SELECT `+seasonColumns+` FROM seasons
+        WHERE archived_at IS NULL AND ends_at <= $1
+        ORDER BY ends_at`,
+        now)
+    if err != nil {
+        return nil, fmt.Errorf("failed to get due seasons: %w", err)
+    }
+    return collect(rows, scanSeason)
+}
+
+func (s *Store) GetSeasonStandings(ctx context.Context, season *models.Season, limit int) ([]*models.SeasonStanding, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    var rows pgx.Rows
+    var err error
+    if season.ArchivedAt != nil {
+        rows, err = s.pool.Query(ctx, `
+            SELECT t.season_id, t.user_id, u.username, t.rank, t.points, t.correct, t.predictions, COALESCE(t.badge, '')
+            FROM season_standings t
+            JOIN users u ON u.id = t.user_id
+            WHERE t.season_id = $1
+            ORDER BY t.rank, u.username
+            LIMIT $2`,
+            season.ID, limit)
+    } else {
+        rows, err = s.pool.Query(ctx, `
+            SELECT $4::text, t.user_id, u.username, t.rank, t.points, t.correct, t.predictions, ''
+            FROM (`+seasonTable+`) t
+            JOIN users u ON u.id = t.user_id
+            ORDER BY t.rank, u.username
+            LIMIT $5`,
+            season.StartsAt, season.EndsAt, season.CompetitionID, season.ID, limit)
+    }
+    if err != nil {
+        return nil, fmt.Errorf("failed to get season standings: %w", err)
+    }
+    return collect(rows, func(row pgx.Row) (*models.SeasonStanding, error) {
+        st := &models.SeasonStanding{}
+        err := row.Scan(&st.SeasonID, &st.UserID, &st.Username, &st.Rank, &st.Points, &st.Correct, &st.Predictions, &st.Badge)
+        if err != nil {
+            return nil, err
+        }
+        return st, nil
+    })
+}
+
+// ArchiveSeason marks the season archived and freezes its table in one
+// transaction, so instances racing to archive it snapshot it once. Badges
+// go to the top ten ranks with points: the champion, the rest of the
+// podium and the top ten.
+func (s *Store) ArchiveSeason(ctx context.Context, id string, at time.Time) (bool, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    archived := false
+    err := s.inTx(ctx, func(tx pgx.Tx) error {
+        var (
+            startsAt, endsAt time.Time
+            competitionID    string
+        )
+        err := tx.QueryRow(ctx, `
+            UPDATE seasons SET archived_at = $2
+            WHERE id = $1 AND archived_at IS NULL
+            RETURNING starts_at, ends_at, COALESCE(competition_id::text, '')`,
+            id, at,
+        ).Scan(&startsAt, &endsAt, &competitionID)
+        if errors.Is(err, pgx.ErrNoRows) {
+            return nil
+        }
+        if err != nil {
+            return err
+        }
+
+        _, err = tx.Exec(ctx, `
+            INSERT INTO season_standings (season_id, user_id, rank, points, correct, predictions, badge)
+            SELECT $4::uuid, t.user_id, t.rank, t.points, t.correct, t.predictions,
+                   CASE
+                       WHEN t.points = 0 THEN NULL
+                       WHEN t.rank = 1 THEN $5
+                       WHEN t.rank <= 3 THEN $6
+                       WHEN t.rank <= 10 THEN $7
+                   END
+            FROM (`+seasonTable+`) t`,
+            startsAt, endsAt, competitionID, id,
+            models.SeasonBadgeChampion, models.SeasonBadgePodium, models.SeasonBadgeTopTen)
+        if err != nil {
+            return err
+        }
+        archived = true
+        return nil
+    })
+    if err != nil {
+        return false, fmt.Errorf("failed to archive season: %w", err)
+    }
+    return archived, nil
+}
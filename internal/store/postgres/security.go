@@ -0,0 +1,87 @@
+package postgres
+
+import (
+    "context"
+    "fmt"
+    "time"
+
+    "github.com/jackc/pgx/v5"
+
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+// RecordSecurityEvent keeps one sign-in per session: a session signing in
+// again refreshes the address, device and last seen time of its entry.
+func (s *Store) RecordSecurityEvent(ctx context.Context, event *models.SecurityEvent) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    err := s.pool.QueryRow(ctx, `
+        INSERT INTO security_events (user_id, kind, session_id, ip, country, device, detail, created_at, last_seen_at)
+        VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, '')::inet, NULLIF($5, ''), NULLIF($6, ''), NULLIF($7, ''),
+            COALESCE($8, CURRENT_TIMESTAMP), COALESCE($8, CURRENT_TIMESTAMP))
+        ON CONFLICT (user_id, session_id) WHERE kind = 'sign_in' DO UPDATE SET
+            ip = EXCLUDED.ip, country = EXCLUDED.country, device = EXCLUDED.device,
+            last_seen_at = EXCLUDED.last_seen_at
+        RETURNING id, created_at, last_seen_at`,
+        event.UserID, event.Kind, event.SessionID, event.IP, event.Country, event.Device, event.Detail,
+        nullTime(event.CreatedAt),
+    ).Scan(&event.ID, &event.CreatedAt, &event.LastSeenAt)
+    if err != nil {
+        return fmt.Errorf("failed to record security event: %w", err)
+    }
+    return nil
+}
+
+// GetUserSecurityEvents returns the most recently active entries first.
+func (s *Store) GetUserSecurityEvents(ctx context.Context, userID string, limit int) ([]*models.SecurityEvent, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    rows, err := s.pool.Query(ctx, `
+        SELECT id, user_id, kind, COALESCE(session_id, ''), COALESCE(host(ip), ''), COALESCE(country, ''),
+            COALESCE(device, ''), COALESCE(detail, ''), created_at, last_seen_at, disputed_at
+        FROM security_events
+        WHERE user_id = $1
+        ORDER BY last_seen_at DESC, id
+        LIMIT $2`,
+        userID, limit)
+    if err != nil {
+        return nil, fmt.Errorf("failed to get security events: %w", err)
+    }
+    return collect(rows, func(row pgx.Row) (*models.SecurityEvent, error) {
+        e := &models.SecurityEvent{}
+        err := row.Scan(&e.ID, &e.UserID, &e.Kind, &e.SessionID, &e.IP, &e.Country,
+            &e.Device, &e.Detail, &e.CreatedAt, &e.LastSeenAt, &e.DisputedAt)
+        if err != nil {
+            return nil, err
+        }
+        return e, nil
+    })
+}
+
+func (s *Store) DisputeSecurityEvent(ctx context.Context, userID, id string, at time.Time) (bool, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    var found bool
+    err := s.inTx(ctx, func(tx pgx.Tx) error {
+        var err error
+        found, err = affected(tx.Exec(ctx, `
+            UPDATE security_events SET disputed_at = COALESCE(disputed_at, $3)
+            WHERE id = $2 AND user_id = $1`,
+            userID, id, at))
+        if err != nil || !found {
+            return err
+        }
+        _, err = tx.Exec(ctx, `
+            UPDATE users SET sessions_revoked_at = GREATEST(COALESCE(sessions_revoked_at, $2), $2)
+            WHERE id = $1`,
+            userID, at)
+        return err
+    })
+    if err != nil {
+        return false, fmt.Errorf("failed to dispute security event: %w", err)
+    }
+    return found, nil
+}
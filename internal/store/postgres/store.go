@@ -1,98 +1,163 @@
-package store
+// Package postgres is the primary store, on PostgreSQL through a pgx
+// connection pool. Every query runs under the configured query timeout,
+// so one slow statement cannot hold a pooled connection, or the request
+// waiting on it, indefinitely.
+package postgres
 
 import (
     "context"
+    "encoding/json"
+    "errors"
+    "fmt"
     "time"
 
-    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/jackc/pgx/v5"
+    "github.com/jackc/pgx/v5/pgconn"
+    "github.com/jackc/pgx/v5/pgxpool"
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/store"
 )
 
-type Store interface {
-    // User operations
-    CreateUser(ctx context.Context, user *models.User) error
-    GetUser(ctx context.Context, id string) (*models.User, error)
-    GetUserByUsername(ctx context.Context, username string) (*models.User, error)
-    UpdateUser(ctx context.Context, user *models.User) error
-    DeleteUser(ctx context.Context, id string) error
-
-    // Sport operations
-    CreateSport(ctx context.Context, sport *models.Sport) error
-    GetSport(ctx context.Context, id string) (*models.Sport, error)
-    ListSports(ctx context.Context) ([]*models.Sport, error)
-    UpdateSport(ctx context.Context, sport *models.Sport) error
-    DeleteSport(ctx context.Context, id string) error
-
-    // Team operations
-    CreateTeam(ctx context.Context, team *models.Team) error
-    GetTeam(ctx context.Context, id string) (*models.Team, error)
-    ListTeams(ctx context.Context, sportID string) ([]*models.Team, error)
-    UpdateTeam(ctx context.Context, team *models.Team) error
-    DeleteTeam(ctx context.Context, id string) error
-
-    // Match operations
-    CreateMatch(ctx context.Context, match *models.Match) error
-    GetMatch(ctx context.Context, id string) (*models.Match, error)
-    GetLiveMatches(ctx context.Context) ([]*models.Match, error)
-    GetMatchesByStatus(ctx context.Context, status string) ([]*models.Match, error)
-    GetUpcomingMatches(ctx context.Context, limit int) ([]*models.Match, error)
-    UpdateMatch(ctx context.Context, match *models.Match) error
-    DeleteMatch(ctx context.Context, id string) error
-
-    // Chat room operations
-    CreateChatRoom(ctx context.Context, room *models.ChatRoom) error
-    GetChatRoom(ctx context.Context, id string) (*models.ChatRoom, error)
-    GetMatchChatRoom(ctx context.Context, matchID string) (*models.ChatRoom, error)
-    ListChatRooms(ctx context.Context) ([]*models.ChatRoom, error)
-    UpdateChatRoom(ctx context.Context, room *models.ChatRoom) error
-    DeleteChatRoom(ctx context.Context, id string) error
-
-    // Message operations
-    CreateMessage(ctx context.Context, message *models.Message) error
-    GetMessage(ctx context.Context, id string) (*models.Message, error)
-    GetRecentMessages(ctx context.Context, roomID string, limit int) ([]*models.Message, error)
-    GetMessagesBefore(ctx context.Context, roomID string, before time.Time, limit int) ([]*models.Message, error)
-    DeleteMessage(ctx context.Context, id string) error
-
-    // Match event operations
-    CreateMatchEvent(ctx context.Context, event *models.MatchEvent) error
-    GetMatchEvents(ctx context.Context, matchID string) ([]*models.MatchEvent, error)
-    GetRecentMatchEvents(ctx context.Context, matchID string, limit int) ([]*models.MatchEvent, error)
-
-    // User presence operations
-    JoinChatRoom(ctx context.Context, userID, roomID string) error
-    LeaveChatRoom(ctx context.Context, userID, roomID string) error
-    GetRoomUsers(ctx context.Context, roomID string) ([]*models.User, error)
-    GetUserRooms(ctx context.Context, userID string) ([]*models.ChatRoom, error)
-
-    // Search operations
-    SearchMessages(ctx context.Context, query string, limit int) ([]*models.Message, error)
-    SearchMatchEvents(ctx context.Context, query string, limit int) ([]*models.MatchEvent, error)
-
-    // Statistics operations
-    GetRoomStatistics(ctx context.Context, roomID string) (*RoomStatistics, error)
-    GetUserStatistics(ctx context.Context, userID string) (*UserStatistics, error)
-    GetMatchStatistics(ctx context.Context, matchID string) (*MatchStatistics, error)
-
-    // Utility
-    Close() error
+const defaultQueryTimeout = 5 * time.Second
+
+// Options configures the pool. MaxConns caps open connections; MinConns
+// are kept open while idle, so a burst after a quiet spell does not wait
+// on new connections. Connections are recycled after ConnMaxLifetime.
+type Options struct {
+    URL             string
+    MaxConns        int
+    MinConns        int
+    ConnMaxLifetime time.Duration
+    QueryTimeout    time.Duration
+}
+
+type Store struct {
+    pool         *pgxpool.Pool
+    queryTimeout time.Duration
+    logger       *zap.Logger
+}
+
+var _ store.Store = (*Store)(nil)
+
+// querier is what queries run on: the pool, or a transaction.
+type querier interface {
+    Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+    Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+    QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
 }
 
-type RoomStatistics struct {
-    MessageCount  int       `json:"message_count"`
-    UserCount     int       `json:"user_count"`
-    LastActivity  time.Time `json:"last_activity"`
+func New(ctx context.Context, opts Options, logger *zap.Logger) (*Store, error) {
+    cfg, err := pgxpool.ParseConfig(opts.URL)
+    if err != nil {
+        return nil, fmt.Errorf("failed to parse database url: %w", err)
+    }
+    if opts.MaxConns > 0 {
+        cfg.MaxConns = int32(opts.MaxConns)
+    }
+    if opts.MinConns > 0 {
+        cfg.MinConns = int32(opts.MinConns)
+    }
+    if opts.ConnMaxLifetime > 0 {
+        cfg.MaxConnLifetime = opts.ConnMaxLifetime
+        // Spread reconnects out so the whole pool is not recycled at once
+        cfg.MaxConnLifetimeJitter = opts.ConnMaxLifetime / 10
+    }
+
+    pool, err := pgxpool.NewWithConfig(ctx, cfg)
+    if err != nil {
+        return nil, fmt.Errorf("failed to create connection pool: %w", err)
+    }
+    if err := pool.Ping(ctx); err != nil {
+        pool.Close()
+        return nil, fmt.Errorf("failed to connect to database: %w", err)
+    }
+
+    s := &Store{
+        pool:         pool,
+        queryTimeout: opts.QueryTimeout,
+        logger:       logger,
+    }
+    if s.queryTimeout <= 0 {
+        s.queryTimeout = defaultQueryTimeout
+    }
+
+    logger.Info("Connected to postgres",
+        zap.Int32("max_conns", cfg.MaxConns),
+        zap.Int32("min_conns", cfg.MinConns),
+        zap.Duration("query_timeout", s.queryTimeout))
+    return s, nil
 }
 
-type UserStatistics struct {
-    MessageCount      int       `json:"message_count"`
-    RoomsJoined       int       `json:"rooms_joined"`
-    LastActive        time.Time `json:"last_active"`
-    FavoriteRooms     []string  `json:"favorite_rooms"`
+func (s *Store) Close() error {
+    s.pool.Close()
+    return nil
 }
 
-type MatchStatistics struct {
-    ViewerCount       int       `json:"viewer_count"`
-    MessageCount      int       `json:"message_count"`
-    PeakViewerCount   int       `json:"peak_viewer_count"`
-    EventCount        int       `json:"event_count"`
-}
\ No newline at end of file
+// timeout bounds one store operation. A caller's own, shorter deadline
+// still wins.
+func (s *Store) timeout(ctx context.Context) (context.Context, context.CancelFunc) {
+    return context.WithTimeout(ctx, s.queryTimeout)
+}
+
+// inTx runs fn in a transaction, committing if it returns nil. The
+// operation's timeout covers the whole transaction.
+func (s *Store) inTx(ctx context.Context, fn func(tx pgx.Tx) error) error {
+    return pgx.BeginFunc(ctx, s.pool, fn)
+}
+
+// collect scans every row with scan.
+func collect[T any](rows pgx.Rows, scan func(pgx.Row) (*T, error)) ([]*T, error) {
+    defer rows.Close()
+    var items []*T
+    for rows.Next() {
+        item, err := scan(rows)
+        if err != nil {
+            return nil, err
+        }
+        items = append(items, item)
+    }
+    return items, rows.Err()
+}
+
+// affected reports whether a statement changed any row.
+func affected(tag pgconn.CommandTag, err error) (bool, error) {
+    if err != nil {
+        return false, err
+    }
+    return tag.RowsAffected() > 0, nil
+}
+
+// notFound turns a missing row into a clearer error for logs; callers
+// treat any error from a get as not found.
+func notFound(err error, what string) error {
+    if errors.Is(err, pgx.ErrNoRows) {
+        return fmt.Errorf("%s not found", what)
+    }
+    return fmt.Errorf("failed to get %s: %w", what, err)
+}
+
+// nullTime stores a zero time as NULL, so the column default applies.
+func nullTime(t time.Time) *time.Time {
+    if t.IsZero() {
+        return nil
+    }
+    return &t
+}
+
+// jsonOrNil encodes v for a nullable JSONB column, storing empty slices
+// as NULL.
+func jsonOrNil[T any](v []T) ([]byte, error) {
+    if len(v) == 0 {
+        return nil, nil
+    }
+    return json.Marshal(v)
+}
+
+// jsonArray encodes v for a JSONB column that defaults to an empty array.
+func jsonArray[T any](v []T) ([]byte, error) {
+    if v == nil {
+        v = []T{}
+    }
+    return json.Marshal(v)
+}
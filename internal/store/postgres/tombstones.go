@@ -0,0 +1,122 @@
+package postgres
+
+import (
+    "context"
+    "fmt"
+    "time"
+
+    "github.com/jackc/pgx/v5"
+
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+const tombstoneColumns = `
+    room_id, name, COALESCE(match_id::text, ''), COALESCE(replacement_id::text, ''), reason,
+    COALESCE(deleted_by::text, ''), deleted_at, purged_at`
+
+func scanTombstone(row pgx.Row) (*models.RoomTombstone, error) {
+    t := &models.RoomTombstone{}
+    err := row.Scan(&t.RoomID, &t.Name, &t.MatchID, &t.ReplacementID, &t.Reason,
+        &t.DeletedBy, &t.DeletedAt, &t.PurgedAt)
+    if err != nil {
+        return nil, err
+    }
+    return t, nil
+}
+
+// TombstoneChatRoom deactivates the room and records its tombstone,
+// replacing the one a room with the same ID left before.
+func (s *Store) TombstoneChatRoom(ctx context.Context, tombstone *models.RoomTombstone) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    err := s.inTx(ctx, func(tx pgx.Tx) error {
+        found, err := affected(tx.Exec(ctx, `UPDATE chat_rooms SET is_active = false WHERE id = $1`, tombstone.RoomID))
+        if err != nil {
+            return err
+        }
+        if !found {
+            return fmt.Errorf("chat room not found")
+        }
+        return tx.QueryRow(ctx, `
+            INSERT INTO room_tombstones (room_id, name, match_id, replacement_id, reason, deleted_by)
+            VALUES ($1, $2, NULLIF($3, '')::uuid, NULLIF($4, '')::uuid, $5, NULLIF($6, '')::uuid)
+            ON CONFLICT (room_id) DO UPDATE SET
+                name = EXCLUDED.name, match_id = EXCLUDED.match_id, replacement_id = EXCLUDED.replacement_id,
+                reason = EXCLUDED.reason, deleted_by = EXCLUDED.deleted_by, deleted_at = NOW(), purged_at = NULL
+            RETURNING deleted_at`,
+            tombstone.RoomID, tombstone.Name, tombstone.MatchID, tombstone.ReplacementID, tombstone.Reason, tombstone.DeletedBy,
+        ).Scan(&tombstone.DeletedAt)
+    })
+    if err != nil {
+        return fmt.Errorf("failed to delete chat room: %w", err)
+    }
+    tombstone.PurgedAt = nil
+    return nil
+}
+
+func (s *Store) GetRoomTombstone(ctx context.Context, roomID string) (*models.RoomTombstone, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    tombstone, err := scanTombstone(s.pool.QueryRow(ctx, `SELECT `+tombstoneColumns+` FROM room_tombstones WHERE room_id = $1`, roomID))
+    if err != nil {
+        return nil, notFound(err, "room tombstone")
+    }
+    return tombstone, nil
+}
+
+func (s *Store) RestoreChatRoom(ctx context.Context, roomID string) (bool, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    var restored bool
+    err := s.inTx(ctx, func(tx pgx.Tx) error {
+        var err error
+        restored, err = affected(tx.Exec(ctx, `DELETE FROM room_tombstones WHERE room_id = $1 AND purged_at IS NULL`, roomID))
+        if err != nil || !restored {
+            return err
+        }
+        _, err = tx.Exec(ctx, `UPDATE chat_rooms SET is_active = true WHERE id = $1`, roomID)
+        return err
+    })
+    if err != nil {
+        return false, fmt.Errorf("failed to restore chat room: %w", err)
+    }
+    return restored, nil
+}
+
+func (s *Store) ListPurgeableRoomTombstones(ctx context.Context, before time.Time, limit int) ([]*models.RoomTombstone, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    rows, err := s.pool.Query(ctx, `
+        SELECT `+tombstoneColumns+` FROM room_tombstones
+        WHERE purged_at IS NULL AND deleted_at < $1
+        ORDER BY deleted_at
+        LIMIT $2`,
+        before, limit)
+    if err != nil {
+        return nil, fmt.Errorf("failed to list room tombstones: %w", err)
+    }
+    return collect(rows, scanTombstone)
+}
+
+// PurgeChatRoom deletes the room, its messages and members going with it
+// through the schema's cascades, and marks its tombstone purged.
+func (s *Store) PurgeChatRoom(ctx context.Context, roomID string, at time.Time) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    err := s.inTx(ctx, func(tx pgx.Tx) error {
+        if _, err := tx.Exec(ctx, `DELETE FROM chat_rooms WHERE id = $1`, roomID); err != nil {
+            return err
+        }
+        _, err := tx.Exec(ctx, `UPDATE room_tombstones SET purged_at = $2 WHERE room_id = $1`, roomID, at)
+        return err
+    })
+    if err != nil {
+        return fmt.Errorf("failed to purge chat room: %w", err)
+    }
+    return nil
+}
@@ -0,0 +1,353 @@
+package postgres
+
+import (
+    "context"
+    "fmt"
+    "time"
+
+    "github.com/jackc/pgx/v5"
+
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+const userColumns = `
+    u.id, u.username, u.password_hash, COALESCE(u.email, ''), COALESCE(u.favorite_team, ''),
+    COALESCE(u.avatar_url, ''), u.bio, COALESCE(u.is_admin, false), u.account_type, u.rate_limit_exempt,
+    u.banned_at, COALESCE(u.ban_reason, ''), u.goal_flash_opt_out, COALESCE(u.external_id, ''),
+    u.deactivated_at, u.username_changed_at, u.sessions_revoked_at, u.birth_date, u.age_verified_at,
+    u.restricted_mode, u.shadow_banned, u.locale, u.timezone, u.created_at, u.updated_at`
+
+func scanUser(row pgx.Row) (*models.User, error) {
+    u := &models.User{}
+    err := row.Scan(
+        &u.ID, &u.Username, &u.Password, &u.Email, &u.FavoriteTeam,
+        &u.AvatarURL, &u.Bio, &u.IsAdmin, &u.AccountType, &u.RateLimitExempt,
+        &u.BannedAt, &u.BanReason, &u.GoalFlashOptOut, &u.ExternalID,
+        &u.DeactivatedAt, &u.UsernameChangedAt, &u.SessionsRevokedAt, &u.BirthDate, &u.AgeVerifiedAt,
+        &u.RestrictedMode, &u.ShadowBanned, &u.Locale, &u.Timezone, &u.CreatedAt, &u.UpdatedAt,
+    )
+    if err != nil {
+        return nil, err
+    }
+    return u, nil
+}
+
+func (s *Store) CreateUser(ctx context.Context, user *models.User) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    err := s.pool.QueryRow(ctx, `
+        INSERT INTO users (
+            username, password_hash, email, favorite_team, avatar_url, is_admin,
+            account_type, rate_limit_exempt, goal_flash_opt_out, external_id
+        ) VALUES (
+            $1, $2, NULLIF($3, ''), NULLIF($4, ''), NULLIF($5, ''), $6,
+            COALESCE(NULLIF($7, ''), 'user'), $8, $9, NULLIF($10, '')
+        )
+        RETURNING id, account_type, created_at, updated_at`,
+        user.Username, user.Password, user.Email, user.FavoriteTeam, user.AvatarURL, user.IsAdmin,
+        user.AccountType, user.RateLimitExempt, user.GoalFlashOptOut, user.ExternalID,
+    ).Scan(&user.ID, &user.AccountType, &user.CreatedAt, &user.UpdatedAt)
+    if err != nil {
+        return fmt.Errorf("failed to create user: %w", err)
+    }
+    return nil
+}
+
+func (s *Store) GetUser(ctx context.Context, id string) (*models.User, error) {
+    return s.getUser(ctx, `u.id = $1`, id)
+}
+
+func (s *Store) GetUserByUsername(ctx context.Context, username string) (*models.User, error) {
+    return s.getUser(ctx, `u.username = $1`, username)
+}
+
+func (s *Store) GetUserByExternalID(ctx context.Context, externalID string) (*models.User, error) {
+    return s.getUser(ctx, `u.external_id = $1`, externalID)
+}
+
+func (s *Store) getUser(ctx context.Context, where string, arg any) (*models.User, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    user, err := scanUser(s.pool.QueryRow(ctx, `SELECT `+userColumns+` FROM users u WHERE `+where, arg))
+    if err != nil {
+        return nil, notFound(err, "user")
+    }
+    return user, nil
+}
+
+func (s *Store) UpdateUser(ctx context.Context, user *models.User) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    err := s.pool.QueryRow(ctx, `
+        UPDATE users SET
+            username = $2, password_hash = $3, email = NULLIF($4, ''), favorite_team = NULLIF($5, ''),
+            avatar_url = NULLIF($6, ''), is_admin = $7, account_type = $8, rate_limit_exempt = $9,
+            banned_at = $10, ban_reason = NULLIF($11, ''), goal_flash_opt_out = $12,
+            external_id = NULLIF($13, ''), deactivated_at = $14, birth_date = $15,
+            age_verified_at = $16, restricted_mode = $17, bio = $18, locale = $19, timezone = $20
+        WHERE id = $1
+        RETURNING updated_at`,
+        user.ID, user.Username, user.Password, user.Email, user.FavoriteTeam,
+        user.AvatarURL, user.IsAdmin, user.AccountType, user.RateLimitExempt,
+        user.BannedAt, user.BanReason, user.GoalFlashOptOut,
+        user.ExternalID, user.DeactivatedAt, user.BirthDate,
+        user.AgeVerifiedAt, user.RestrictedMode, user.Bio, user.Locale, user.Timezone,
+    ).Scan(&user.UpdatedAt)
+    if err != nil {
+        return fmt.Errorf("failed to update user: %w", err)
+    }
+    return nil
+}
+
+func (s *Store) DeleteUser(ctx context.Context, id string) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    if _, err := s.pool.Exec(ctx, `DELETE FROM users WHERE id = $1`, id); err != nil {
+        return fmt.Errorf("failed to delete user: %w", err)
+    }
+    return nil
+}
+
+// anonymizedTables hold rows that only identify an anonymized user, each
+// keyed by user_id.
+var anonymizedTables = []string{
+    "username_history", "security_events", "device_sightings", "user_chat_rooms",
+    "room_roles", "voice_participants", "message_drafts", "keyword_alerts",
+    "quiet_hours", "directory_group_members", "recovery_codes", "recovery_emails",
+    "recovery_tokens",
+}
+
+func (s *Store) AnonymizeUser(ctx context.Context, userID, username string, at time.Time) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    err := s.inTx(ctx, func(tx pgx.Tx) error {
+        tag, err := tx.Exec(ctx, `
+            UPDATE users SET
+                username = $2, password_hash = '', email = NULL, favorite_team = NULL,
+                avatar_url = NULL, bio = '', is_admin = false, rate_limit_exempt = false,
+                external_id = NULL, birth_date = NULL, age_verified_at = NULL,
+                locale = '', timezone = '', deactivated_at = $3, sessions_revoked_at = $3,
+                username_changed_at = $3
+            WHERE id = $1`,
+            userID, username, at)
+        if err != nil {
+            return err
+        }
+        if tag.RowsAffected() == 0 {
+            return fmt.Errorf("user %s not found", userID)
+        }
+        for _, table := range anonymizedTables {
+            if _, err := tx.Exec(ctx, `DELETE FROM `+table+` WHERE user_id = $1`, userID); err != nil {
+                return err
+            }
+        }
+        _, err = tx.Exec(ctx, `DELETE FROM user_blocks WHERE user_id = $1 OR blocked_id = $1`, userID)
+        return err
+    })
+    if err != nil {
+        return fmt.Errorf("failed to anonymize user: %w", err)
+    }
+    return nil
+}
+
+func (s *Store) RenameUser(ctx context.Context, userID, username string, at time.Time) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    err := s.inTx(ctx, func(tx pgx.Tx) error {
+        if _, err := tx.Exec(ctx, `
+            INSERT INTO username_history (user_id, username, changed_at)
+            SELECT id, username, $2 FROM users WHERE id = $1`,
+            userID, at); err != nil {
+            return err
+        }
+        _, err := tx.Exec(ctx, `
+            UPDATE users SET username = $2, username_changed_at = $3 WHERE id = $1`,
+            userID, username, at)
+        return err
+    })
+    if err != nil {
+        return fmt.Errorf("failed to rename user: %w", err)
+    }
+    return nil
+}
+
+func (s *Store) UsernameReleasedSince(ctx context.Context, username string, since time.Time) (bool, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    var released bool
+    err := s.pool.QueryRow(ctx, `
+        SELECT EXISTS (
+            SELECT 1 FROM username_history WHERE LOWER(username) = LOWER($1) AND changed_at > $2
+        )`,
+        username, since,
+    ).Scan(&released)
+    if err != nil {
+        return false, fmt.Errorf("failed to check username history: %w", err)
+    }
+    return released, nil
+}
+
+func (s *Store) MarkFirstMessage(ctx context.Context, userID string, at time.Time) (bool, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    marked, err := affected(s.pool.Exec(ctx, `
+        UPDATE users SET first_message_at = $2 WHERE id = $1 AND first_message_at IS NULL`,
+        userID, at))
+    if err != nil {
+        return false, fmt.Errorf("failed to mark first message: %w", err)
+    }
+    return marked, nil
+}
+
+func (s *Store) ListUsers(ctx context.Context, offset, limit int) ([]*models.User, int, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    var total int
+    if err := s.pool.QueryRow(ctx, `SELECT COUNT(*) FROM users`).Scan(&total); err != nil {
+        return nil, 0, fmt.Errorf("failed to count users: %w", err)
+    }
+
+    rows, err := s.pool.Query(ctx, `
+        SELECT `+userColumns+` FROM users u
+        ORDER BY u.created_at, u.id
+        OFFSET $1 LIMIT $2`,
+        offset, limit)
+    if err != nil {
+        return nil, 0, fmt.Errorf("failed to list users: %w", err)
+    }
+    users, err := collect(rows, scanUser)
+    if err != nil {
+        return nil, 0, fmt.Errorf("failed to list users: %w", err)
+    }
+    return users, total, nil
+}
+
+const directoryGroupColumns = `
+    g.id, COALESCE(g.external_id, ''), g.display_name,
+    ARRAY(SELECT m.user_id::text FROM directory_group_members m WHERE m.group_id = g.id ORDER BY m.user_id),
+    g.created_at, g.updated_at`
+
+func scanDirectoryGroup(row pgx.Row) (*models.DirectoryGroup, error) {
+    g := &models.DirectoryGroup{}
+    if err := row.Scan(&g.ID, &g.ExternalID, &g.DisplayName, &g.MemberIDs, &g.CreatedAt, &g.UpdatedAt); err != nil {
+        return nil, err
+    }
+    return g, nil
+}
+
+func (s *Store) CreateDirectoryGroup(ctx context.Context, group *models.DirectoryGroup) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    err := s.inTx(ctx, func(tx pgx.Tx) error {
+        err := tx.QueryRow(ctx, `
+            INSERT INTO directory_groups (external_id, display_name)
+            VALUES (NULLIF($1, ''), $2)
+            RETURNING id, created_at, updated_at`,
+            group.ExternalID, group.DisplayName,
+        ).Scan(&group.ID, &group.CreatedAt, &group.UpdatedAt)
+        if err != nil {
+            return err
+        }
+        return setGroupMembers(ctx, tx, group)
+    })
+    if err != nil {
+        return fmt.Errorf("failed to create directory group: %w", err)
+    }
+    return nil
+}
+
+func (s *Store) GetDirectoryGroup(ctx context.Context, id string) (*models.DirectoryGroup, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    group, err := scanDirectoryGroup(s.pool.QueryRow(ctx, `
+        SELECT `+directoryGroupColumns+` FROM directory_groups g WHERE g.id = $1`, id))
+    if err != nil {
+        return nil, notFound(err, "directory group")
+    }
+    return group, nil
+}
+
+func (s *Store) ListDirectoryGroups(ctx context.Context) ([]*models.DirectoryGroup, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    rows, err := s.pool.Query(ctx, `
+        SELECT `+directoryGroupColumns+` FROM directory_groups g ORDER BY g.display_name`)
+    if err != nil {
+        return nil, fmt.Errorf("failed to list directory groups: %w", err)
+    }
+    return collect(rows, scanDirectoryGroup)
+}
+
+func (s *Store) UpdateDirectoryGroup(ctx context.Context, group *models.DirectoryGroup) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    err := s.inTx(ctx, func(tx pgx.Tx) error {
+        err := tx.QueryRow(ctx, `
+            UPDATE directory_groups SET external_id = NULLIF($2, ''), display_name = $3, updated_at = CURRENT_TIMESTAMP
+            WHERE id = $1
+            RETURNING updated_at`,
+            group.ID, group.ExternalID, group.DisplayName,
+        ).Scan(&group.UpdatedAt)
+        if err != nil {
+            return err
+        }
+        if _, err := tx.Exec(ctx, `DELETE FROM directory_group_members WHERE group_id = $1`, group.ID); err != nil {
+            return err
+        }
+        return setGroupMembers(ctx, tx, group)
+    })
+    if err != nil {
+        return fmt.Errorf("failed to update directory group: %w", err)
+    }
+    return nil
+}
+
+func setGroupMembers(ctx context.Context, q querier, group *models.DirectoryGroup) error {
+    if len(group.MemberIDs) == 0 {
+        return nil
+    }
+    _, err := q.Exec(ctx, `
+        INSERT INTO directory_group_members (group_id, user_id)
+        SELECT $1, unnest($2::text[])::uuid
+        ON CONFLICT DO NOTHING`,
+        group.ID, group.MemberIDs)
+    return err
+}
+
+func (s *Store) DeleteDirectoryGroup(ctx context.Context, id string) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    if _, err := s.pool.Exec(ctx, `DELETE FROM directory_groups WHERE id = $1`, id); err != nil {
+        return fmt.Errorf("failed to delete directory group: %w", err)
+    }
+    return nil
+}
+
+func (s *Store) GetUserDirectoryGroups(ctx context.Context, userID string) ([]*models.DirectoryGroup, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    rows, err := s.pool.Query(ctx, `
+        SELECT `+directoryGroupColumns+` FROM directory_groups g
+        WHERE g.id IN (SELECT group_id FROM directory_group_members WHERE user_id = $1)
+        ORDER BY g.display_name`,
+        userID)
+    if err != nil {
+        return nil, fmt.Errorf("failed to get user directory groups: %w", err)
+    }
+    return collect(rows, scanDirectoryGroup)
+}
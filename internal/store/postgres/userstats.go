@@ -0,0 +1,110 @@
+package postgres
+
+import (
+    "context"
+    "fmt"
+
+    "github.com/jackc/pgx/v5"
+
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+// AddUserMatchStats applies every delta in one statement.
+func (s *Store) AddUserMatchStats(ctx context.Context, deltas []*models.UserMatchStats) error {
+    if len(deltas) == 0 {
+        return nil
+    }
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    n := len(deltas)
+    var (
+        matchIDs  = make([]string, n)
+        userIDs   = make([]string, n)
+        messages  = make([]int, n)
+        reactions = make([]int, n)
+        correct   = make([]int, n)
+        points    = make([]int, n)
+    )
+    for i, d := range deltas {
+        matchIDs[i], userIDs[i] = d.MatchID, d.UserID
+        messages[i], reactions[i] = d.Messages, d.ReactionsReceived
+        correct[i], points[i] = d.CorrectPredictions, d.PredictionPoints
+    }
+
+    _, err := s.pool.Exec(ctx, `
+        INSERT INTO user_match_stats AS s
+            (match_id, user_id, messages, reactions_received, correct_predictions, prediction_points)
+        SELECT * FROM unnest($1::uuid[], $2::uuid[], $3::int[], $4::int[], $5::int[], $6::int[])
+        ON CONFLICT (match_id, user_id) DO UPDATE SET
+            messages = s.messages + EXCLUDED.messages,
+            reactions_received = s.reactions_received + EXCLUDED.reactions_received,
+            correct_predictions = s.correct_predictions + EXCLUDED.correct_predictions,
+            prediction_points = s.prediction_points + EXCLUDED.prediction_points,
+            updated_at = CURRENT_TIMESTAMP`,
+        matchIDs, userIDs, messages, reactions, correct, points)
+    if err != nil {
+        return fmt.Errorf("failed to add user match stats: %w", err)
+    }
+    return nil
+}
+
+func scanLeaderboardEntry(row pgx.Row) (*models.LeaderboardEntry, error) {
+    e := &models.LeaderboardEntry{}
+    err := row.Scan(&e.Rank, &e.UserID, &e.Username, &e.Score, &e.Messages, &e.ReactionsReceived, &e.CorrectPredictions)
+    if err != nil {
+        return nil, err
+    }
+    return e, nil
+}
+
+func (s *Store) GetMatchLeaderboard(ctx context.Context, matchID string, limit int) ([]*models.LeaderboardEntry, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    rows, err := s.pool.Query(ctx, `
+        SELECT (RANK() OVER (ORDER BY s.score DESC))::int, s.user_id, u.username, s.score,
+               s.messages, GREATEST(s.reactions_received, 0), s.correct_predictions
+        FROM user_match_stats s
+        JOIN users u ON u.id = s.user_id
+        WHERE s.match_id = $1 AND s.score > 0
+        ORDER BY s.score DESC, u.username
+        LIMIT $2`,
+        matchID, limit)
+    if err != nil {
+        return nil, fmt.Errorf("failed to get match leaderboard: %w", err)
+    }
+    return collect(rows, scanLeaderboardEntry)
+}
+
+// GetSeasonLeaderboard sums the season's matches: those kicking off in
+// its window, of its competition or, without one, of any.
+func (s *Store) GetSeasonLeaderboard(ctx context.Context, season *models.Season, limit int) ([]*models.LeaderboardEntry, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    rows, err := s.pool.Query(ctx, `
+        SELECT (RANK() OVER (ORDER BY t.score DESC))::int, t.user_id, u.username, t.score,
+               t.messages, t.reactions, t.correct
+        FROM (
+            SELECT s.user_id,
+                   SUM(s.score)::int AS score,
+                   SUM(s.messages)::int AS messages,
+                   SUM(GREATEST(s.reactions_received, 0))::int AS reactions,
+                   SUM(s.correct_predictions)::int AS correct
+            FROM user_match_stats s
+            JOIN matches m ON m.id = s.match_id
+            WHERE m.start_time >= $1 AND m.start_time < $2
+              AND ($3 = '' OR m.competition_id::text = $3)
+            GROUP BY s.user_id
+        ) t
+        JOIN users u ON u.id = t.user_id
+        WHERE t.score > 0
+        ORDER BY t.score DESC, u.username
+        LIMIT $4`,
+        season.StartsAt, season.EndsAt, season.CompetitionID, limit)
+    if err != nil {
+        return nil, fmt.Errorf("failed to get season leaderboard: %w", err)
+    }
+    return collect(rows, scanLeaderboardEntry)
+}
@@ -0,0 +1,360 @@
+package postgres
+
+import (
+    "context"
+    "fmt"
+    "time"
+
+    "github.com/jackc/pgx/v5"
+
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+const matchVoteColumns = `match_id, room_id, opens_at, closes_at, closed_at`
+
+func scanMatchVote(row pgx.Row) (*models.MatchVote, error) {
+    v := &models.MatchVote{}
+    if err := row.Scan(&v.MatchID, &v.RoomID, &v.OpensAt, &v.ClosesAt, &v.ClosedAt); err != nil {
+        return nil, err
+    }
+    return v, nil
+}
+
+func (s *Store) OpenMatchVote(ctx context.Context, vote *models.MatchVote, voterIDs []string) (bool, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    var created bool
+    err := s.inTx(ctx, func(tx pgx.Tx) error {
+        var err error
+        created, err = affected(tx.Exec(ctx, `
+            INSERT INTO match_votes (match_id, room_id, opens_at, closes_at) VALUES ($1, $2, $3, $4)
+            ON CONFLICT (match_id) DO NOTHING`,
+            vote.MatchID, vote.RoomID, vote.OpensAt, vote.ClosesAt))
+        if err != nil || len(voterIDs) == 0 {
+            return err
+        }
+        _, err = tx.Exec(ctx, `
+            INSERT INTO match_voters (match_id, user_id)
+            SELECT $1, unnest($2::uuid[])
+            ON CONFLICT DO NOTHING`,
+            vote.MatchID, voterIDs)
+        return err
+    })
+    if err != nil {
+        return false, fmt.Errorf("failed to open match vote: %w", err)
+    }
+    return created, nil
+}
+
+func (s *Store) GetMatchVote(ctx context.Context, matchID string) (*models.MatchVote, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    vote, err := scanMatchVote(s.pool.QueryRow(ctx, `
+        SELECT `+matchVoteColumns+` FROM match_votes WHERE match_id = $1`, matchID))
+    if err != nil {
+        return nil, notFound(err, "match vote")
+    }
+    return vote, nil
+}
+
+func (s *Store) IsMatchVoter(ctx context.Context, matchID, userID string) (bool, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    var voter bool
+    err := s.pool.QueryRow(ctx, `
+        SELECT EXISTS (SELECT 1 FROM match_voters WHERE match_id = $1 AND user_id = $2)`,
+        matchID, userID,
+    ).Scan(&voter)
+    if err != nil {
+        return false, fmt.Errorf("failed to check match voter: %w", err)
+    }
+    return voter, nil
+}
+
+func (s *Store) CastMatchBallot(ctx context.Context, ballot *models.MatchBallot) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    err := s.pool.QueryRow(ctx, `
+        INSERT INTO match_ballots (match_id, user_id, rating, player)
+        VALUES ($1, $2, NULLIF($3, 0), NULLIF($4, ''))
+        ON CONFLICT (match_id, user_id) DO UPDATE SET
+            rating = EXCLUDED.rating, player = EXCLUDED.player, updated_at = CURRENT_TIMESTAMP
+        RETURNING created_at, updated_at`,
+        ballot.MatchID, ballot.UserID, ballot.Rating, ballot.Player,
+    ).Scan(&ballot.CreatedAt, &ballot.UpdatedAt)
+    if err != nil {
+        return fmt.Errorf("failed to cast match ballot: %w", err)
+    }
+    return nil
+}
+
+func (s *Store) GetDueMatchVotes(ctx context.Context, now time.Time) ([]*models.MatchVote, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    rows, err := s.pool.Query(ctx, `
+        SELECT `+matchVoteColumns+` FROM match_votes
+        WHERE closed_at IS NULL AND closes_at <= $1
+        ORDER BY closes_at`,
+        now)
+    if err != nil {
+        return nil, fmt.Errorf("failed to get due match votes: %w", err)
+    }
+    return collect(rows, scanMatchVote)
+}
+
+// ballotTally aggregates the ballots of the vote on match $1.
+const ballotTally = `
+    SELECT COUNT(rating), COALESCE(AVG(rating), 0)::float8,
+        COALESCE((
+            SELECT jsonb_agg(jsonb_build_object('player', player, 'votes', votes) ORDER BY votes DESC, player)
+            FROM (
+                SELECT player, COUNT(*) AS votes FROM match_ballots
+                WHERE match_id = $1 AND player IS NOT NULL
+                GROUP BY player
+            ) p
+        ), '[]')
+    FROM match_ballots
+    WHERE match_id = $1`
+
+func (s *Store) CloseMatchVote(ctx context.Context, matchID string, at time.Time) (bool, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    closed, err := affected(s.pool.Exec(ctx, `
+        UPDATE match_votes v SET
+            closed_at = $2, rating_count = t.count, rating_average = t.average, players = t.players
+        FROM (`+ballotTally+`) AS t(count, average, players)
+        WHERE v.match_id = $1 AND v.closed_at IS NULL`,
+        matchID, at))
+    if err != nil {
+        return false, fmt.Errorf("failed to close match vote: %w", err)
+    }
+    return closed, nil
+}
+
+func (s *Store) GetMatchVoteResult(ctx context.Context, matchID string) (*models.MatchVoteResult, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    result := &models.MatchVoteResult{MatchID: matchID}
+    var closedAt *time.Time
+    err := s.pool.QueryRow(ctx, `
+        SELECT closes_at, closed_at, rating_count, rating_average::float8, players
+        FROM match_votes WHERE match_id = $1`,
+        matchID,
+    ).Scan(&result.ClosesAt, &closedAt, &result.Ratings, &result.AverageRating, &result.Players)
+    if err != nil {
+        return nil, notFound(err, "match vote")
+    }
+    if closedAt != nil {
+        return result, nil
+    }
+
+    result.Open = true
+    err = s.pool.QueryRow(ctx, ballotTally, matchID).Scan(&result.Ratings, &result.AverageRating, &result.Players)
+    if err != nil {
+        return nil, fmt.Errorf("failed to tally match vote: %w", err)
+    }
+    return result, nil
+}
+
+const predictionColumns = `id, match_id, user_id, home_score, away_score, correct, points, scored_at, created_at, updated_at`
+
+func scanPrediction(row pgx.Row) (*models.Prediction, error) {
+    p := &models.Prediction{}
+    err := row.Scan(&p.ID, &p.MatchID, &p.UserID, &p.HomeScore, &p.AwayScore,
+        &p.Correct, &p.Points, &p.ScoredAt, &p.CreatedAt, &p.UpdatedAt)
+    if err != nil {
+        return nil, err
+    }
+    return p, nil
+}
+
+func (s *Store) UpsertPrediction(ctx context.Context, prediction *models.Prediction) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    err := s.pool.QueryRow(ctx, `
+        INSERT INTO predictions (match_id, user_id, home_score, away_score)
+        VALUES ($1, $2, $3, $4)
+        ON CONFLICT (match_id, user_id) DO UPDATE SET
+            home_score = EXCLUDED.home_score, away_score = EXCLUDED.away_score, updated_at = CURRENT_TIMESTAMP
+        RETURNING id, created_at, updated_at`,
+        prediction.MatchID, prediction.UserID, prediction.HomeScore, prediction.AwayScore,
+    ).Scan(&prediction.ID, &prediction.CreatedAt, &prediction.UpdatedAt)
+    if err != nil {
+        return fmt.Errorf("failed to save prediction: %w", err)
+    }
+    return nil
+}
+
+func (s *Store) GetPrediction(ctx context.Context, matchID, userID string) (*models.Prediction, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    prediction, err := scanPrediction(s.pool.QueryRow(ctx, `
+        SELECT `+predictionColumns+` FROM predictions WHERE match_id = $1 AND user_id = $2`,
+        matchID, userID))
+    if err != nil {
+        return nil, notFound(err, "prediction")
+    }
+    return prediction, nil
+}
+
+func (s *Store) GetMatchPredictions(ctx context.Context, matchID string) ([]*models.Prediction, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    rows, err := s.pool.Query(ctx, `
+        SELECT `+predictionColumns+` FROM predictions WHERE match_id = $1 ORDER BY created_at`,
+        matchID)
+    if err != nil {
+        return nil, fmt.Errorf("failed to get match predictions: %w", err)
+    }
+    return collect(rows, scanPrediction)
+}
+
+func (s *Store) ScorePrediction(ctx context.Context, prediction *models.Prediction, rescore bool) (bool, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    scored, err := affected(s.pool.Exec(ctx, `
+        UPDATE predictions SET correct = $2, points = $3, scored_at = $4
+        WHERE id = $1 AND ($5 OR scored_at IS NULL)`,
+        prediction.ID, prediction.Correct, prediction.Points, prediction.ScoredAt, rescore))
+    if err != nil {
+        return false, fmt.Errorf("failed to score prediction: %w", err)
+    }
+    return scored, nil
+}
+
+func (s *Store) GetUserPredictionStats(ctx context.Context, userID string, since time.Time) (correct, total int, err error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    err = s.pool.QueryRow(ctx, `
+        SELECT COUNT(*) FILTER (WHERE correct), COUNT(*) FROM predictions
+        WHERE user_id = $1 AND scored_at >= $2`,
+        userID, since,
+    ).Scan(&correct, &total)
+    if err != nil {
+        return 0, 0, fmt.Errorf("failed to get prediction stats: %w", err)
+    }
+    return correct, total, nil
+}
+
+const pollColumns = `id, match_id, room_id, question, options, COALESCE(created_by::text, ''), closed_at, created_at`
+
+func scanPoll(row pgx.Row) (*models.Poll, error) {
+    p := &models.Poll{}
+    err := row.Scan(&p.ID, &p.MatchID, &p.RoomID, &p.Question, &p.Options, &p.CreatedBy, &p.ClosedAt, &p.CreatedAt)
+    if err != nil {
+        return nil, err
+    }
+    return p, nil
+}
+
+func (s *Store) CreatePoll(ctx context.Context, poll *models.Poll) error {
+    options, err := jsonArray(poll.Options)
+    if err != nil {
+        return fmt.Errorf("failed to encode poll options: %w", err)
+    }
+
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    err = s.pool.QueryRow(ctx, `
+        INSERT INTO polls (match_id, room_id, question, options, created_by, created_at)
+        VALUES ($1, $2, $3, $4, NULLIF($5, '')::uuid, COALESCE($6, CURRENT_TIMESTAMP))
+        RETURNING id, created_at`,
+        poll.MatchID, poll.RoomID, poll.Question, options, poll.CreatedBy, nullTime(poll.CreatedAt),
+    ).Scan(&poll.ID, &poll.CreatedAt)
+    if err != nil {
+        return fmt.Errorf("failed to create poll: %w", err)
+    }
+    return nil
+}
+
+func (s *Store) GetPoll(ctx context.Context, id string) (*models.Poll, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    poll, err := scanPoll(s.pool.QueryRow(ctx, `SELECT `+pollColumns+` FROM polls WHERE id = $1`, id))
+    if err != nil {
+        return nil, notFound(err, "poll")
+    }
+    return poll, nil
+}
+
+func (s *Store) GetMatchPolls(ctx context.Context, matchID string) ([]*models.Poll, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    rows, err := s.pool.Query(ctx, `
+        SELECT `+pollColumns+` FROM polls WHERE match_id = $1 ORDER BY created_at`,
+        matchID)
+    if err != nil {
+        return nil, fmt.Errorf("failed to get match polls: %w", err)
+    }
+    return collect(rows, scanPoll)
+}
+
+func (s *Store) CastPollVote(ctx context.Context, vote *models.PollVote) error {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    err := s.pool.QueryRow(ctx, `
+        INSERT INTO poll_votes (poll_id, user_id, option, created_at)
+        VALUES ($1, $2, $3, COALESCE($4, CURRENT_TIMESTAMP))
+        ON CONFLICT (poll_id, user_id) DO UPDATE SET option = EXCLUDED.option, created_at = EXCLUDED.created_at
+        RETURNING created_at`,
+        vote.PollID, vote.UserID, vote.Option, nullTime(vote.CreatedAt),
+    ).Scan(&vote.CreatedAt)
+    if err != nil {
+        return fmt.Errorf("failed to cast poll vote: %w", err)
+    }
+    return nil
+}
+
+func (s *Store) GetPollTally(ctx context.Context, pollID string) (*models.PollTally, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    tally := &models.PollTally{PollID: pollID}
+    err := s.pool.QueryRow(ctx, `
+        SELECT ARRAY(
+            SELECT COUNT(v.option)::int
+            FROM generate_series(0, jsonb_array_length(p.options) - 1) AS o(i)
+            LEFT JOIN poll_votes v ON v.poll_id = p.id AND v.option = o.i
+            GROUP BY o.i
+            ORDER BY o.i
+        )
+        FROM polls p WHERE p.id = $1`,
+        pollID,
+    ).Scan(&tally.Counts)
+    if err != nil {
+        return nil, notFound(err, "poll")
+    }
+    for _, n := range tally.Counts {
+        tally.Total += n
+    }
+    return tally, nil
+}
+
+func (s *Store) ClosePoll(ctx context.Context, id string, at time.Time) (bool, error) {
+    ctx, cancel := s.timeout(ctx)
+    defer cancel()
+
+    closed, err := affected(s.pool.Exec(ctx, `
+        UPDATE polls SET closed_at = $2 WHERE id = $1 AND closed_at IS NULL`,
+        id, at))
+    if err != nil {
+        return false, fmt.Errorf("failed to close poll: %w", err)
+    }
+    return closed, nil
+}
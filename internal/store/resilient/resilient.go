@@ -0,0 +1,159 @@
+// Package resilient wraps a store.Store with bounded retries for
+// failures that left the database untouched, and a breaker that fails
+// fast while the database is unreachable, so callers are not each left
+// waiting out a connect timeout.
+// The method wrappers in store_gen.go are generated from the Store
+// interface; run go generate after adding store methods.
+package resilient
+
+//go:generate go run ../gen -kind resilient -in ../store.go -out store_gen.go
+
+import (
+    "context"
+    "fmt"
+    "math/rand"
+    "sync"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/metrics"
+    "github.com/yourusername/sports-chat/internal/store"
+)
+
+const (
+    defaultMaxAttempts      = 3
+    defaultBaseDelay        = 50 * time.Millisecond
+    defaultMaxDelay         = time.Second
+    defaultBreakerThreshold = 5
+    defaultBreakerCooldown  = 5 * time.Second
+)
+
+// Policy bounds retries. Zero values use the defaults.
+type Policy struct {
+    // MaxAttempts counts the first call; 1 disables retries
+    MaxAttempts int
+    // Retries wait BaseDelay, doubling per attempt up to MaxDelay, with
+    // jitter so callers that failed together do not retry together
+    BaseDelay time.Duration
+    MaxDelay  time.Duration
+    // After BreakerThreshold calls in a row find the database
+    // unreachable, calls fail fast with store.ErrUnavailable for
+    // BreakerCooldown before one is let through to try again
+    BreakerThreshold int
+    BreakerCooldown  time.Duration
+}
+
+// Classifier sorts the wrapped store's errors.
+type Classifier func(error) store.ErrorClass
+
+// Store retries transient and unavailable errors of the wrapped store.
+// Errors it gives up on for unavailability wrap store.ErrUnavailable.
+type Store struct {
+    next     store.Store
+    classify Classifier
+    policy   Policy
+    metrics  *metrics.Metrics
+    logger   *zap.Logger
+
+    mu        sync.Mutex
+    failures  int
+    openUntil time.Time
+}
+
+func New(next store.Store, classify Classifier, policy Policy, metrics *metrics.Metrics, logger *zap.Logger) *Store {
+    if policy.MaxAttempts <= 0 {
+        policy.MaxAttempts = defaultMaxAttempts
+    }
+    if policy.BaseDelay <= 0 {
+        policy.BaseDelay = defaultBaseDelay
+    }
+    if policy.MaxDelay <= 0 {
+        policy.MaxDelay = defaultMaxDelay
+    }
+    if policy.BreakerThreshold <= 0 {
+        policy.BreakerThreshold = defaultBreakerThreshold
+    }
+    if policy.BreakerCooldown <= 0 {
+        policy.BreakerCooldown = defaultBreakerCooldown
+    }
+    return &Store{next: next, classify: classify, policy: policy, metrics: metrics, logger: logger}
+}
+
+// do runs a store call, retrying it while its errors are retryable and
+// attempts and the context allow.
+func (s *Store) do(ctx context.Context, operation string, call func(context.Context) error) error {
+    delay := s.policy.BaseDelay
+    for attempt := 1; ; attempt++ {
+        if s.open() {
+            return fmt.Errorf("%s: %w", operation, store.ErrUnavailable)
+        }
+
+        err := call(ctx)
+        class := store.ErrorPermanent
+        if err != nil {
+            class = s.classify(err)
+        }
+        s.record(class)
+
+        if err == nil || class == store.ErrorPermanent {
+            return err
+        }
+        if attempt >= s.policy.MaxAttempts {
+            return s.giveUp(class, err)
+        }
+
+        s.metrics.StoreRetries.WithLabelValues(operation).Inc()
+        wait := delay/2 + time.Duration(rand.Int63n(int64(delay)))
+        select {
+        case <-ctx.Done():
+            return s.giveUp(class, err)
+        case <-time.After(wait):
+        }
+        if delay *= 2; delay > s.policy.MaxDelay {
+            delay = s.policy.MaxDelay
+        }
+    }
+}
+
+func (s *Store) giveUp(class store.ErrorClass, err error) error {
+    if class == store.ErrorUnavailable {
+        return fmt.Errorf("%w: %w", store.ErrUnavailable, err)
+    }
+    return err
+}
+
+// open reports whether the breaker is failing calls fast.
+func (s *Store) open() bool {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    return time.Now().Before(s.openUntil)
+}
+
+// record counts calls in a row that found the database unreachable,
+// opening the breaker at the threshold. Any other outcome means the
+// database answered, and closes it.
+func (s *Store) record(class store.ErrorClass) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    if class != store.ErrorUnavailable {
+        if s.failures >= s.policy.BreakerThreshold {
+            s.logger.Info("Store reachable again")
+            s.metrics.StoreBreakerOpen.Set(0)
+        }
+        s.failures = 0
+        return
+    }
+
+    s.failures++
+    if s.failures >= s.policy.BreakerThreshold {
+        if s.failures == s.policy.BreakerThreshold {
+            s.logger.Warn("Store unreachable, failing calls fast",
+                zap.Int("failures", s.failures),
+                zap.Duration("cooldown", s.policy.BreakerCooldown))
+            s.metrics.StoreBreakerOpen.Set(1)
+        }
+        s.openUntil = time.Now().Add(s.policy.BreakerCooldown)
+    }
+}
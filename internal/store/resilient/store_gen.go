@@ -0,0 +1,1997 @@
+// Code generated by gen; DO NOT EDIT.
+
+package resilient
+
+import (
+	"context"
+	"time"
+
+	"github.com/yourusername/sports-chat/internal/models"
+	"github.com/yourusername/sports-chat/internal/store"
+)
+
+var _ store.Store = (*Store)(nil)
+
+func (s *Store) AddEmote(ctx context.Context, emote *models.Emote) (bool, error) {
+	var r0 bool
+	err := s.do(ctx, "AddEmote", func(ctx context.Context) (err error) {
+		r0, err = s.next.AddEmote(ctx, emote)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) AddProfanityWord(ctx context.Context, word *models.ProfanityWord) error {
+	return s.do(ctx, "AddProfanityWord", func(ctx context.Context) error {
+		return s.next.AddProfanityWord(ctx, word)
+	})
+}
+
+func (s *Store) AddReaction(ctx context.Context, reaction *models.Reaction) (bool, error) {
+	var r0 bool
+	err := s.do(ctx, "AddReaction", func(ctx context.Context) (err error) {
+		r0, err = s.next.AddReaction(ctx, reaction)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) AddUserMatchStats(ctx context.Context, deltas []*models.UserMatchStats) error {
+	return s.do(ctx, "AddUserMatchStats", func(ctx context.Context) error {
+		return s.next.AddUserMatchStats(ctx, deltas)
+	})
+}
+
+func (s *Store) AnonymizeUser(ctx context.Context, userID string, username string, at time.Time) error {
+	return s.do(ctx, "AnonymizeUser", func(ctx context.Context) error {
+		return s.next.AnonymizeUser(ctx, userID, username, at)
+	})
+}
+
+func (s *Store) AppendJournalEntries(ctx context.Context, entries []*models.JournalEntry) error {
+	return s.do(ctx, "AppendJournalEntries", func(ctx context.Context) error {
+		return s.next.AppendJournalEntries(ctx, entries)
+	})
+}
+
+func (s *Store) ArchiveSeason(ctx context.Context, id string, at time.Time) (bool, error) {
+	var r0 bool
+	err := s.do(ctx, "ArchiveSeason", func(ctx context.Context) (err error) {
+		r0, err = s.next.ArchiveSeason(ctx, id, at)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) AwardAchievement(ctx context.Context, achievement *models.UserAchievement) (bool, error) {
+	var r0 bool
+	err := s.do(ctx, "AwardAchievement", func(ctx context.Context) (err error) {
+		r0, err = s.next.AwardAchievement(ctx, achievement)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) BlockUser(ctx context.Context, block *models.UserBlock) error {
+	return s.do(ctx, "BlockUser", func(ctx context.Context) error {
+		return s.next.BlockUser(ctx, block)
+	})
+}
+
+func (s *Store) CastMatchBallot(ctx context.Context, ballot *models.MatchBallot) error {
+	return s.do(ctx, "CastMatchBallot", func(ctx context.Context) error {
+		return s.next.CastMatchBallot(ctx, ballot)
+	})
+}
+
+func (s *Store) CastPollVote(ctx context.Context, vote *models.PollVote) error {
+	return s.do(ctx, "CastPollVote", func(ctx context.Context) error {
+		return s.next.CastPollVote(ctx, vote)
+	})
+}
+
+func (s *Store) Close() error {
+	return s.next.Close()
+}
+
+func (s *Store) CloseMatchVote(ctx context.Context, matchID string, at time.Time) (bool, error) {
+	var r0 bool
+	err := s.do(ctx, "CloseMatchVote", func(ctx context.Context) (err error) {
+		r0, err = s.next.CloseMatchVote(ctx, matchID, at)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) ClosePoll(ctx context.Context, id string, at time.Time) (bool, error) {
+	var r0 bool
+	err := s.do(ctx, "ClosePoll", func(ctx context.Context) (err error) {
+		r0, err = s.next.ClosePoll(ctx, id, at)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) CollapseRoomTopic(ctx context.Context, id string, at time.Time) (bool, error) {
+	var r0 bool
+	err := s.do(ctx, "CollapseRoomTopic", func(ctx context.Context) (err error) {
+		r0, err = s.next.CollapseRoomTopic(ctx, id, at)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) CollapseRoomTopics(ctx context.Context, roomID string, at time.Time) (int, error) {
+	var r0 int
+	err := s.do(ctx, "CollapseRoomTopics", func(ctx context.Context) (err error) {
+		r0, err = s.next.CollapseRoomTopics(ctx, roomID, at)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) CompleteDataExport(ctx context.Context, export *models.DataExport) (bool, error) {
+	var r0 bool
+	err := s.do(ctx, "CompleteDataExport", func(ctx context.Context) (err error) {
+		r0, err = s.next.CompleteDataExport(ctx, export)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) CountMatchFollowers(ctx context.Context, matchID string) (int, error) {
+	var r0 int
+	err := s.do(ctx, "CountMatchFollowers", func(ctx context.Context) (err error) {
+		r0, err = s.next.CountMatchFollowers(ctx, matchID)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) CountPendingDeadLetters(ctx context.Context) (int, error) {
+	var r0 int
+	err := s.do(ctx, "CountPendingDeadLetters", func(ctx context.Context) (err error) {
+		r0, err = s.next.CountPendingDeadLetters(ctx)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) CreateAppeal(ctx context.Context, appeal *models.Appeal) (bool, error) {
+	var r0 bool
+	err := s.do(ctx, "CreateAppeal", func(ctx context.Context) (err error) {
+		r0, err = s.next.CreateAppeal(ctx, appeal)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) CreateAttachment(ctx context.Context, attachment *models.Attachment, data []byte) error {
+	return s.do(ctx, "CreateAttachment", func(ctx context.Context) error {
+		return s.next.CreateAttachment(ctx, attachment, data)
+	})
+}
+
+func (s *Store) CreateChatRoom(ctx context.Context, room *models.ChatRoom) error {
+	return s.do(ctx, "CreateChatRoom", func(ctx context.Context) error {
+		return s.next.CreateChatRoom(ctx, room)
+	})
+}
+
+func (s *Store) CreateDataExport(ctx context.Context, export *models.DataExport) error {
+	return s.do(ctx, "CreateDataExport", func(ctx context.Context) error {
+		return s.next.CreateDataExport(ctx, export)
+	})
+}
+
+func (s *Store) CreateDeadLetter(ctx context.Context, letter *models.DeadLetter) error {
+	return s.do(ctx, "CreateDeadLetter", func(ctx context.Context) error {
+		return s.next.CreateDeadLetter(ctx, letter)
+	})
+}
+
+func (s *Store) CreateDirectMessage(ctx context.Context, msg *models.DirectMessage) error {
+	return s.do(ctx, "CreateDirectMessage", func(ctx context.Context) error {
+		return s.next.CreateDirectMessage(ctx, msg)
+	})
+}
+
+func (s *Store) CreateDirectoryGroup(ctx context.Context, group *models.DirectoryGroup) error {
+	return s.do(ctx, "CreateDirectoryGroup", func(ctx context.Context) error {
+		return s.next.CreateDirectoryGroup(ctx, group)
+	})
+}
+
+func (s *Store) CreateEmotePack(ctx context.Context, pack *models.EmotePack) error {
+	return s.do(ctx, "CreateEmotePack", func(ctx context.Context) error {
+		return s.next.CreateEmotePack(ctx, pack)
+	})
+}
+
+func (s *Store) CreateEvasionSuspect(ctx context.Context, suspect *models.EvasionSuspect) (bool, error) {
+	var r0 bool
+	err := s.do(ctx, "CreateEvasionSuspect", func(ctx context.Context) (err error) {
+		r0, err = s.next.CreateEvasionSuspect(ctx, suspect)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) CreateHighlight(ctx context.Context, highlight *models.Highlight) error {
+	return s.do(ctx, "CreateHighlight", func(ctx context.Context) error {
+		return s.next.CreateHighlight(ctx, highlight)
+	})
+}
+
+func (s *Store) CreateIncident(ctx context.Context, incident *models.Incident) error {
+	return s.do(ctx, "CreateIncident", func(ctx context.Context) error {
+		return s.next.CreateIncident(ctx, incident)
+	})
+}
+
+func (s *Store) CreateKeywordAlert(ctx context.Context, alert *models.KeywordAlert) error {
+	return s.do(ctx, "CreateKeywordAlert", func(ctx context.Context) error {
+		return s.next.CreateKeywordAlert(ctx, alert)
+	})
+}
+
+func (s *Store) CreateMatch(ctx context.Context, match *models.Match) error {
+	return s.do(ctx, "CreateMatch", func(ctx context.Context) error {
+		return s.next.CreateMatch(ctx, match)
+	})
+}
+
+func (s *Store) CreateMatchEvent(ctx context.Context, event *models.MatchEvent) error {
+	return s.do(ctx, "CreateMatchEvent", func(ctx context.Context) error {
+		return s.next.CreateMatchEvent(ctx, event)
+	})
+}
+
+func (s *Store) CreateMessage(ctx context.Context, message *models.Message) error {
+	return s.do(ctx, "CreateMessage", func(ctx context.Context) error {
+		return s.next.CreateMessage(ctx, message)
+	})
+}
+
+func (s *Store) CreateMessageArchive(ctx context.Context, archive *models.MessageArchive, messageIDs []string) error {
+	return s.do(ctx, "CreateMessageArchive", func(ctx context.Context) error {
+		return s.next.CreateMessageArchive(ctx, archive, messageIDs)
+	})
+}
+
+func (s *Store) CreateMessageFlag(ctx context.Context, flag *models.MessageFlag) error {
+	return s.do(ctx, "CreateMessageFlag", func(ctx context.Context) error {
+		return s.next.CreateMessageFlag(ctx, flag)
+	})
+}
+
+func (s *Store) CreateMessages(ctx context.Context, messages []*models.Message) error {
+	return s.do(ctx, "CreateMessages", func(ctx context.Context) error {
+		return s.next.CreateMessages(ctx, messages)
+	})
+}
+
+func (s *Store) CreateModerationEvidence(ctx context.Context, evidence *models.ModerationEvidence) error {
+	return s.do(ctx, "CreateModerationEvidence", func(ctx context.Context) error {
+		return s.next.CreateModerationEvidence(ctx, evidence)
+	})
+}
+
+func (s *Store) CreatePoll(ctx context.Context, poll *models.Poll) error {
+	return s.do(ctx, "CreatePoll", func(ctx context.Context) error {
+		return s.next.CreatePoll(ctx, poll)
+	})
+}
+
+func (s *Store) CreateReconciliationReport(ctx context.Context, report *models.ReconciliationReport) error {
+	return s.do(ctx, "CreateReconciliationReport", func(ctx context.Context) error {
+		return s.next.CreateReconciliationReport(ctx, report)
+	})
+}
+
+func (s *Store) CreateRecoveryToken(ctx context.Context, token *models.RecoveryToken) error {
+	return s.do(ctx, "CreateRecoveryToken", func(ctx context.Context) error {
+		return s.next.CreateRecoveryToken(ctx, token)
+	})
+}
+
+func (s *Store) CreateRoomSanction(ctx context.Context, sanction *models.RoomSanction) error {
+	return s.do(ctx, "CreateRoomSanction", func(ctx context.Context) error {
+		return s.next.CreateRoomSanction(ctx, sanction)
+	})
+}
+
+func (s *Store) CreateRoomTopic(ctx context.Context, topic *models.RoomTopic) error {
+	return s.do(ctx, "CreateRoomTopic", func(ctx context.Context) error {
+		return s.next.CreateRoomTopic(ctx, topic)
+	})
+}
+
+func (s *Store) CreateSeason(ctx context.Context, season *models.Season) error {
+	return s.do(ctx, "CreateSeason", func(ctx context.Context) error {
+		return s.next.CreateSeason(ctx, season)
+	})
+}
+
+func (s *Store) CreateSport(ctx context.Context, sport *models.Sport) error {
+	return s.do(ctx, "CreateSport", func(ctx context.Context) error {
+		return s.next.CreateSport(ctx, sport)
+	})
+}
+
+func (s *Store) CreateTeam(ctx context.Context, team *models.Team) error {
+	return s.do(ctx, "CreateTeam", func(ctx context.Context) error {
+		return s.next.CreateTeam(ctx, team)
+	})
+}
+
+func (s *Store) CreateUser(ctx context.Context, user *models.User) error {
+	return s.do(ctx, "CreateUser", func(ctx context.Context) error {
+		return s.next.CreateUser(ctx, user)
+	})
+}
+
+func (s *Store) DeleteChatRoom(ctx context.Context, id string) error {
+	return s.do(ctx, "DeleteChatRoom", func(ctx context.Context) error {
+		return s.next.DeleteChatRoom(ctx, id)
+	})
+}
+
+func (s *Store) DeleteCommentaryTemplate(ctx context.Context, locale string, eventType string) error {
+	return s.do(ctx, "DeleteCommentaryTemplate", func(ctx context.Context) error {
+		return s.next.DeleteCommentaryTemplate(ctx, locale, eventType)
+	})
+}
+
+func (s *Store) DeleteDataExport(ctx context.Context, id string) error {
+	return s.do(ctx, "DeleteDataExport", func(ctx context.Context) error {
+		return s.next.DeleteDataExport(ctx, id)
+	})
+}
+
+func (s *Store) DeleteDirectoryGroup(ctx context.Context, id string) error {
+	return s.do(ctx, "DeleteDirectoryGroup", func(ctx context.Context) error {
+		return s.next.DeleteDirectoryGroup(ctx, id)
+	})
+}
+
+func (s *Store) DeleteDraft(ctx context.Context, userID string, roomID string) error {
+	return s.do(ctx, "DeleteDraft", func(ctx context.Context) error {
+		return s.next.DeleteDraft(ctx, userID, roomID)
+	})
+}
+
+func (s *Store) DeleteEmote(ctx context.Context, id string) error {
+	return s.do(ctx, "DeleteEmote", func(ctx context.Context) error {
+		return s.next.DeleteEmote(ctx, id)
+	})
+}
+
+func (s *Store) DeleteHighlight(ctx context.Context, id string) error {
+	return s.do(ctx, "DeleteHighlight", func(ctx context.Context) error {
+		return s.next.DeleteHighlight(ctx, id)
+	})
+}
+
+func (s *Store) DeleteKeywordAlert(ctx context.Context, userID string, id string) error {
+	return s.do(ctx, "DeleteKeywordAlert", func(ctx context.Context) error {
+		return s.next.DeleteKeywordAlert(ctx, userID, id)
+	})
+}
+
+func (s *Store) DeleteLocalizedName(ctx context.Context, kind string, entityID string, locale string) error {
+	return s.do(ctx, "DeleteLocalizedName", func(ctx context.Context) error {
+		return s.next.DeleteLocalizedName(ctx, kind, entityID, locale)
+	})
+}
+
+func (s *Store) DeleteMatch(ctx context.Context, id string) error {
+	return s.do(ctx, "DeleteMatch", func(ctx context.Context) error {
+		return s.next.DeleteMatch(ctx, id)
+	})
+}
+
+func (s *Store) DeleteMessage(ctx context.Context, id string) error {
+	return s.do(ctx, "DeleteMessage", func(ctx context.Context) error {
+		return s.next.DeleteMessage(ctx, id)
+	})
+}
+
+func (s *Store) DeleteMessageArchive(ctx context.Context, id string) error {
+	return s.do(ctx, "DeleteMessageArchive", func(ctx context.Context) error {
+		return s.next.DeleteMessageArchive(ctx, id)
+	})
+}
+
+func (s *Store) DeleteProfanityWord(ctx context.Context, locale string, word string) error {
+	return s.do(ctx, "DeleteProfanityWord", func(ctx context.Context) error {
+		return s.next.DeleteProfanityWord(ctx, locale, word)
+	})
+}
+
+func (s *Store) DeleteQuietHours(ctx context.Context, userID string) error {
+	return s.do(ctx, "DeleteQuietHours", func(ctx context.Context) error {
+		return s.next.DeleteQuietHours(ctx, userID)
+	})
+}
+
+func (s *Store) DeleteRoomRole(ctx context.Context, roomID string, userID string) (bool, error) {
+	var r0 bool
+	err := s.do(ctx, "DeleteRoomRole", func(ctx context.Context) (err error) {
+		r0, err = s.next.DeleteRoomRole(ctx, roomID, userID)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) DeleteRoomSanction(ctx context.Context, roomID string, userID string, kind string) error {
+	return s.do(ctx, "DeleteRoomSanction", func(ctx context.Context) error {
+		return s.next.DeleteRoomSanction(ctx, roomID, userID, kind)
+	})
+}
+
+func (s *Store) DeleteSport(ctx context.Context, id string) error {
+	return s.do(ctx, "DeleteSport", func(ctx context.Context) error {
+		return s.next.DeleteSport(ctx, id)
+	})
+}
+
+func (s *Store) DeleteTeam(ctx context.Context, id string) error {
+	return s.do(ctx, "DeleteTeam", func(ctx context.Context) error {
+		return s.next.DeleteTeam(ctx, id)
+	})
+}
+
+func (s *Store) DeleteUser(ctx context.Context, id string) error {
+	return s.do(ctx, "DeleteUser", func(ctx context.Context) error {
+		return s.next.DeleteUser(ctx, id)
+	})
+}
+
+func (s *Store) DisputeSecurityEvent(ctx context.Context, userID string, id string, at time.Time) (bool, error) {
+	var r0 bool
+	err := s.do(ctx, "DisputeSecurityEvent", func(ctx context.Context) (err error) {
+		r0, err = s.next.DisputeSecurityEvent(ctx, userID, id, at)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetAppeal(ctx context.Context, id string) (*models.Appeal, error) {
+	var r0 *models.Appeal
+	err := s.do(ctx, "GetAppeal", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetAppeal(ctx, id)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetArchiveCandidates(ctx context.Context, before time.Time, limit int) ([]store.ArchiveCandidate, error) {
+	var r0 []store.ArchiveCandidate
+	err := s.do(ctx, "GetArchiveCandidates", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetArchiveCandidates(ctx, before, limit)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetAttachment(ctx context.Context, id string) (*models.Attachment, error) {
+	var r0 *models.Attachment
+	err := s.do(ctx, "GetAttachment", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetAttachment(ctx, id)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetAttachmentData(ctx context.Context, id string) ([]byte, error) {
+	var r0 []byte
+	err := s.do(ctx, "GetAttachmentData", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetAttachmentData(ctx, id)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetBannedUserSightings(ctx context.Context, fingerprint string, ip string, since time.Time) ([]*models.DeviceSighting, error) {
+	var r0 []*models.DeviceSighting
+	err := s.do(ctx, "GetBannedUserSightings", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetBannedUserSightings(ctx, fingerprint, ip, since)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetBlockedUsers(ctx context.Context, userID string) ([]*models.UserBlock, error) {
+	var r0 []*models.UserBlock
+	err := s.do(ctx, "GetBlockedUsers", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetBlockedUsers(ctx, userID)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetChatRoom(ctx context.Context, id string) (*models.ChatRoom, error) {
+	var r0 *models.ChatRoom
+	err := s.do(ctx, "GetChatRoom", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetChatRoom(ctx, id)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetChatRoomsByState(ctx context.Context, state string) ([]*models.ChatRoom, error) {
+	var r0 []*models.ChatRoom
+	err := s.do(ctx, "GetChatRoomsByState", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetChatRoomsByState(ctx, state)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetConversation(ctx context.Context, id string) (*models.Conversation, error) {
+	var r0 *models.Conversation
+	err := s.do(ctx, "GetConversation", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetConversation(ctx, id)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetDataExport(ctx context.Context, id string) (*models.DataExport, error) {
+	var r0 *models.DataExport
+	err := s.do(ctx, "GetDataExport", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetDataExport(ctx, id)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetDeadLetter(ctx context.Context, id string) (*models.DeadLetter, error) {
+	var r0 *models.DeadLetter
+	err := s.do(ctx, "GetDeadLetter", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetDeadLetter(ctx, id)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetDirectMessagesBeforeCursor(ctx context.Context, conversationID string, before *store.MessageCursor, limit int) ([]*models.DirectMessage, error) {
+	var r0 []*models.DirectMessage
+	err := s.do(ctx, "GetDirectMessagesBeforeCursor", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetDirectMessagesBeforeCursor(ctx, conversationID, before, limit)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetDirectoryGroup(ctx context.Context, id string) (*models.DirectoryGroup, error) {
+	var r0 *models.DirectoryGroup
+	err := s.do(ctx, "GetDirectoryGroup", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetDirectoryGroup(ctx, id)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetDueMatchVotes(ctx context.Context, now time.Time) ([]*models.MatchVote, error) {
+	var r0 []*models.MatchVote
+	err := s.do(ctx, "GetDueMatchVotes", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetDueMatchVotes(ctx, now)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetDueSeasons(ctx context.Context, now time.Time) ([]*models.Season, error) {
+	var r0 []*models.Season
+	err := s.do(ctx, "GetDueSeasons", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetDueSeasons(ctx, now)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetEmotePack(ctx context.Context, id string) (*models.EmotePack, error) {
+	var r0 *models.EmotePack
+	err := s.do(ctx, "GetEmotePack", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetEmotePack(ctx, id)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetEvasionSignalStats(ctx context.Context) ([]*models.EvasionSignalStats, error) {
+	var r0 []*models.EvasionSignalStats
+	err := s.do(ctx, "GetEvasionSignalStats", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetEvasionSignalStats(ctx)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetEvasionSuspect(ctx context.Context, id string) (*models.EvasionSuspect, error) {
+	var r0 *models.EvasionSuspect
+	err := s.do(ctx, "GetEvasionSuspect", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetEvasionSuspect(ctx, id)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetEvidenceAccess(ctx context.Context, evidenceID string) ([]*models.EvidenceAccess, error) {
+	var r0 []*models.EvidenceAccess
+	err := s.do(ctx, "GetEvidenceAccess", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetEvidenceAccess(ctx, evidenceID)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetFinishedMatchesSince(ctx context.Context, since time.Time) ([]*models.Match, error) {
+	var r0 []*models.Match
+	err := s.do(ctx, "GetFinishedMatchesSince", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetFinishedMatchesSince(ctx, since)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetHeadToHeadMatches(ctx context.Context, teamAID string, teamBID string, limit int) ([]*models.Match, error) {
+	var r0 []*models.Match
+	err := s.do(ctx, "GetHeadToHeadMatches", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetHeadToHeadMatches(ctx, teamAID, teamBID, limit)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetIncident(ctx context.Context, id string) (*models.Incident, error) {
+	var r0 *models.Incident
+	err := s.do(ctx, "GetIncident", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetIncident(ctx, id)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetJournalEntries(ctx context.Context, roomID string, from time.Time, to time.Time, limit int) ([]*models.JournalEntry, error) {
+	var r0 []*models.JournalEntry
+	err := s.do(ctx, "GetJournalEntries", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetJournalEntries(ctx, roomID, from, to, limit)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetLiveMatches(ctx context.Context) ([]*models.Match, error) {
+	var r0 []*models.Match
+	err := s.do(ctx, "GetLiveMatches", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetLiveMatches(ctx)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetMatch(ctx context.Context, id string) (*models.Match, error) {
+	var r0 *models.Match
+	err := s.do(ctx, "GetMatch", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetMatch(ctx, id)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetMatchByProviderID(ctx context.Context, providerID string) (*models.Match, error) {
+	var r0 *models.Match
+	err := s.do(ctx, "GetMatchByProviderID", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetMatchByProviderID(ctx, providerID)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetMatchChatRoom(ctx context.Context, matchID string) (*models.ChatRoom, error) {
+	var r0 *models.ChatRoom
+	err := s.do(ctx, "GetMatchChatRoom", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetMatchChatRoom(ctx, matchID)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetMatchEvents(ctx context.Context, matchID string) ([]*models.MatchEvent, error) {
+	var r0 []*models.MatchEvent
+	err := s.do(ctx, "GetMatchEvents", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetMatchEvents(ctx, matchID)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetMatchHighlights(ctx context.Context, matchID string) ([]*models.Highlight, error) {
+	var r0 []*models.Highlight
+	err := s.do(ctx, "GetMatchHighlights", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetMatchHighlights(ctx, matchID)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetMatchLeaderboard(ctx context.Context, matchID string, limit int) ([]*models.LeaderboardEntry, error) {
+	var r0 []*models.LeaderboardEntry
+	err := s.do(ctx, "GetMatchLeaderboard", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetMatchLeaderboard(ctx, matchID, limit)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetMatchPolls(ctx context.Context, matchID string) ([]*models.Poll, error) {
+	var r0 []*models.Poll
+	err := s.do(ctx, "GetMatchPolls", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetMatchPolls(ctx, matchID)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetMatchPredictions(ctx context.Context, matchID string) ([]*models.Prediction, error) {
+	var r0 []*models.Prediction
+	err := s.do(ctx, "GetMatchPredictions", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetMatchPredictions(ctx, matchID)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetMatchStatistics(ctx context.Context, matchID string) (*store.MatchStatistics, error) {
+	var r0 *store.MatchStatistics
+	err := s.do(ctx, "GetMatchStatistics", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetMatchStatistics(ctx, matchID)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetMatchStatisticsRollup(ctx context.Context, matchID string) (*store.MatchStatistics, error) {
+	var r0 *store.MatchStatistics
+	err := s.do(ctx, "GetMatchStatisticsRollup", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetMatchStatisticsRollup(ctx, matchID)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetMatchVote(ctx context.Context, matchID string) (*models.MatchVote, error) {
+	var r0 *models.MatchVote
+	err := s.do(ctx, "GetMatchVote", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetMatchVote(ctx, matchID)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetMatchVoteResult(ctx context.Context, matchID string) (*models.MatchVoteResult, error) {
+	var r0 *models.MatchVoteResult
+	err := s.do(ctx, "GetMatchVoteResult", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetMatchVoteResult(ctx, matchID)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetMatchesByStatus(ctx context.Context, status string) ([]*models.Match, error) {
+	var r0 []*models.Match
+	err := s.do(ctx, "GetMatchesByStatus", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetMatchesByStatus(ctx, status)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetMessage(ctx context.Context, id string) (*models.Message, error) {
+	var r0 *models.Message
+	err := s.do(ctx, "GetMessage", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetMessage(ctx, id)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetMessageEvidence(ctx context.Context, messageID string) (*models.ModerationEvidence, error) {
+	var r0 *models.ModerationEvidence
+	err := s.do(ctx, "GetMessageEvidence", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetMessageEvidence(ctx, messageID)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetMessageFlag(ctx context.Context, id string) (*models.MessageFlag, error) {
+	var r0 *models.MessageFlag
+	err := s.do(ctx, "GetMessageFlag", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetMessageFlag(ctx, id)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetMessageReactions(ctx context.Context, messageIDs []string) (map[string][]*models.ReactionCount, error) {
+	var r0 map[string][]*models.ReactionCount
+	err := s.do(ctx, "GetMessageReactions", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetMessageReactions(ctx, messageIDs)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetMessagesAfterCursor(ctx context.Context, roomID string, filter store.MessageFilter, cursor store.MessageCursor, limit int) ([]*models.Message, error) {
+	var r0 []*models.Message
+	err := s.do(ctx, "GetMessagesAfterCursor", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetMessagesAfterCursor(ctx, roomID, filter, cursor, limit)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetMessagesAfterSeq(ctx context.Context, roomID string, seq int64, limit int) ([]*models.Message, error) {
+	var r0 []*models.Message
+	err := s.do(ctx, "GetMessagesAfterSeq", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetMessagesAfterSeq(ctx, roomID, seq, limit)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetMessagesBeforeCursor(ctx context.Context, roomID string, filter store.MessageFilter, cursor *store.MessageCursor, limit int) ([]*models.Message, error) {
+	var r0 []*models.Message
+	err := s.do(ctx, "GetMessagesBeforeCursor", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetMessagesBeforeCursor(ctx, roomID, filter, cursor, limit)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetMessagesBetween(ctx context.Context, roomID string, from time.Time, to time.Time, limit int) ([]*models.Message, error) {
+	var r0 []*models.Message
+	err := s.do(ctx, "GetMessagesBetween", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetMessagesBetween(ctx, roomID, from, to, limit)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetModerationEvidence(ctx context.Context, id string) (*models.ModerationEvidence, error) {
+	var r0 *models.ModerationEvidence
+	err := s.do(ctx, "GetModerationEvidence", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetModerationEvidence(ctx, id)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetOrCreateConversation(ctx context.Context, userA string, userB string) (*models.Conversation, error) {
+	var r0 *models.Conversation
+	err := s.do(ctx, "GetOrCreateConversation", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetOrCreateConversation(ctx, userA, userB)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetPoll(ctx context.Context, id string) (*models.Poll, error) {
+	var r0 *models.Poll
+	err := s.do(ctx, "GetPoll", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetPoll(ctx, id)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetPollTally(ctx context.Context, pollID string) (*models.PollTally, error) {
+	var r0 *models.PollTally
+	err := s.do(ctx, "GetPollTally", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetPollTally(ctx, pollID)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetPrediction(ctx context.Context, matchID string, userID string) (*models.Prediction, error) {
+	var r0 *models.Prediction
+	err := s.do(ctx, "GetPrediction", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetPrediction(ctx, matchID, userID)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetPublishedEmotePacks(ctx context.Context, teamIDs []string) ([]*models.EmotePack, error) {
+	var r0 []*models.EmotePack
+	err := s.do(ctx, "GetPublishedEmotePacks", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetPublishedEmotePacks(ctx, teamIDs)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetQuietHours(ctx context.Context, userID string) (*models.QuietHours, error) {
+	var r0 *models.QuietHours
+	err := s.do(ctx, "GetQuietHours", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetQuietHours(ctx, userID)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetRecentMatchEvents(ctx context.Context, matchID string, limit int) ([]*models.MatchEvent, error) {
+	var r0 []*models.MatchEvent
+	err := s.do(ctx, "GetRecentMatchEvents", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetRecentMatchEvents(ctx, matchID, limit)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetRecentMessages(ctx context.Context, roomID string, limit int) ([]*models.Message, error) {
+	var r0 []*models.Message
+	err := s.do(ctx, "GetRecentMessages", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetRecentMessages(ctx, roomID, limit)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetRecoveryEmail(ctx context.Context, userID string) (string, error) {
+	var r0 string
+	err := s.do(ctx, "GetRecoveryEmail", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetRecoveryEmail(ctx, userID)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetRecoveryToken(ctx context.Context, tokenHash string) (*models.RecoveryToken, error) {
+	var r0 *models.RecoveryToken
+	err := s.do(ctx, "GetRecoveryToken", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetRecoveryToken(ctx, tokenHash)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetRoomAnalytics(ctx context.Context, roomID string, from time.Time, to time.Time) (*models.RoomAnalytics, error) {
+	var r0 *models.RoomAnalytics
+	err := s.do(ctx, "GetRoomAnalytics", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetRoomAnalytics(ctx, roomID, from, to)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetRoomLanguages(ctx context.Context, roomID string) ([]*models.LanguageShare, error) {
+	var r0 []*models.LanguageShare
+	err := s.do(ctx, "GetRoomLanguages", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetRoomLanguages(ctx, roomID)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetRoomMaxSeq(ctx context.Context, roomID string) (int64, error) {
+	var r0 int64
+	err := s.do(ctx, "GetRoomMaxSeq", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetRoomMaxSeq(ctx, roomID)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetRoomMembersByUsername(ctx context.Context, roomID string, usernames []string) ([]*models.User, error) {
+	var r0 []*models.User
+	err := s.do(ctx, "GetRoomMembersByUsername", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetRoomMembersByUsername(ctx, roomID, usernames)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetRoomRole(ctx context.Context, roomID string, userID string) (string, error) {
+	var r0 string
+	err := s.do(ctx, "GetRoomRole", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetRoomRole(ctx, roomID, userID)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetRoomSanctions(ctx context.Context, roomID string, userID string) ([]*models.RoomSanction, error) {
+	var r0 []*models.RoomSanction
+	err := s.do(ctx, "GetRoomSanctions", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetRoomSanctions(ctx, roomID, userID)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetRoomStatistics(ctx context.Context, roomID string) (*store.RoomStatistics, error) {
+	var r0 *store.RoomStatistics
+	err := s.do(ctx, "GetRoomStatistics", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetRoomStatistics(ctx, roomID)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetRoomStatisticsRollup(ctx context.Context, roomID string) (*store.RoomStatistics, error) {
+	var r0 *store.RoomStatistics
+	err := s.do(ctx, "GetRoomStatisticsRollup", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetRoomStatisticsRollup(ctx, roomID)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetRoomTombstone(ctx context.Context, roomID string) (*models.RoomTombstone, error) {
+	var r0 *models.RoomTombstone
+	err := s.do(ctx, "GetRoomTombstone", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetRoomTombstone(ctx, roomID)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetRoomTopics(ctx context.Context, roomID string) ([]*models.RoomTopic, error) {
+	var r0 []*models.RoomTopic
+	err := s.do(ctx, "GetRoomTopics", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetRoomTopics(ctx, roomID)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetRoomUsers(ctx context.Context, roomID string) ([]*models.User, error) {
+	var r0 []*models.User
+	err := s.do(ctx, "GetRoomUsers", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetRoomUsers(ctx, roomID)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetSeason(ctx context.Context, id string) (*models.Season, error) {
+	var r0 *models.Season
+	err := s.do(ctx, "GetSeason", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetSeason(ctx, id)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetSeasonAt(ctx context.Context, competitionID string, at time.Time) (*models.Season, error) {
+	var r0 *models.Season
+	err := s.do(ctx, "GetSeasonAt", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetSeasonAt(ctx, competitionID, at)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetSeasonLeaderboard(ctx context.Context, season *models.Season, limit int) ([]*models.LeaderboardEntry, error) {
+	var r0 []*models.LeaderboardEntry
+	err := s.do(ctx, "GetSeasonLeaderboard", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetSeasonLeaderboard(ctx, season, limit)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetSeasonStandings(ctx context.Context, season *models.Season, limit int) ([]*models.SeasonStanding, error) {
+	var r0 []*models.SeasonStanding
+	err := s.do(ctx, "GetSeasonStandings", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetSeasonStandings(ctx, season, limit)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetShadowBanEntries(ctx context.Context, userID string) ([]*models.ShadowBanEntry, error) {
+	var r0 []*models.ShadowBanEntry
+	err := s.do(ctx, "GetShadowBanEntries", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetShadowBanEntries(ctx, userID)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetSport(ctx context.Context, id string) (*models.Sport, error) {
+	var r0 *models.Sport
+	err := s.do(ctx, "GetSport", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetSport(ctx, id)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetTeam(ctx context.Context, id string) (*models.Team, error) {
+	var r0 *models.Team
+	err := s.do(ctx, "GetTeam", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetTeam(ctx, id)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetTeamRecentMatches(ctx context.Context, teamID string, limit int) ([]*models.Match, error) {
+	var r0 []*models.Match
+	err := s.do(ctx, "GetTeamRecentMatches", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetTeamRecentMatches(ctx, teamID, limit)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetTopicMessagesBeforeCursor(ctx context.Context, topicID string, cursor *store.MessageCursor, limit int) ([]*models.Message, error) {
+	var r0 []*models.Message
+	err := s.do(ctx, "GetTopicMessagesBeforeCursor", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetTopicMessagesBeforeCursor(ctx, topicID, cursor, limit)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetUnreadCounts(ctx context.Context, userID string) ([]*models.UnreadCount, error) {
+	var r0 []*models.UnreadCount
+	err := s.do(ctx, "GetUnreadCounts", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetUnreadCounts(ctx, userID)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetUnusedRecoveryCodes(ctx context.Context, userID string) ([]*models.RecoveryCode, error) {
+	var r0 []*models.RecoveryCode
+	err := s.do(ctx, "GetUnusedRecoveryCodes", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetUnusedRecoveryCodes(ctx, userID)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetUpcomingMatches(ctx context.Context, limit int) ([]*models.Match, error) {
+	var r0 []*models.Match
+	err := s.do(ctx, "GetUpcomingMatches", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetUpcomingMatches(ctx, limit)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetUser(ctx context.Context, id string) (*models.User, error) {
+	var r0 *models.User
+	err := s.do(ctx, "GetUser", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetUser(ctx, id)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetUserAchievements(ctx context.Context, userID string) ([]*models.UserAchievement, error) {
+	var r0 []*models.UserAchievement
+	err := s.do(ctx, "GetUserAchievements", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetUserAchievements(ctx, userID)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetUserAppeals(ctx context.Context, userID string) ([]*models.Appeal, error) {
+	var r0 []*models.Appeal
+	err := s.do(ctx, "GetUserAppeals", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetUserAppeals(ctx, userID)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetUserByExternalID(ctx context.Context, externalID string) (*models.User, error) {
+	var r0 *models.User
+	err := s.do(ctx, "GetUserByExternalID", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetUserByExternalID(ctx, externalID)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetUserByUsername(ctx context.Context, username string) (*models.User, error) {
+	var r0 *models.User
+	err := s.do(ctx, "GetUserByUsername", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetUserByUsername(ctx, username)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetUserConversations(ctx context.Context, userID string, limit int) ([]*models.Conversation, error) {
+	var r0 []*models.Conversation
+	err := s.do(ctx, "GetUserConversations", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetUserConversations(ctx, userID, limit)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetUserDirectoryGroups(ctx context.Context, userID string) ([]*models.DirectoryGroup, error) {
+	var r0 []*models.DirectoryGroup
+	err := s.do(ctx, "GetUserDirectoryGroups", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetUserDirectoryGroups(ctx, userID)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetUserDrafts(ctx context.Context, userID string) ([]*models.Draft, error) {
+	var r0 []*models.Draft
+	err := s.do(ctx, "GetUserDrafts", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetUserDrafts(ctx, userID)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetUserKeywordAlerts(ctx context.Context, userID string) ([]*models.KeywordAlert, error) {
+	var r0 []*models.KeywordAlert
+	err := s.do(ctx, "GetUserKeywordAlerts", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetUserKeywordAlerts(ctx, userID)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetUserMessages(ctx context.Context, userID string, cursor *store.MessageCursor, limit int) ([]*models.Message, error) {
+	var r0 []*models.Message
+	err := s.do(ctx, "GetUserMessages", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetUserMessages(ctx, userID, cursor, limit)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetUserPredictionStats(ctx context.Context, userID string, since time.Time) (int, int, error) {
+	var r0 int
+	var r1 int
+	err := s.do(ctx, "GetUserPredictionStats", func(ctx context.Context) (err error) {
+		r0, r1, err = s.next.GetUserPredictionStats(ctx, userID, since)
+		return err
+	})
+	return r0, r1, err
+}
+
+func (s *Store) GetUserProgress(ctx context.Context, userID string) (*models.UserProgress, error) {
+	var r0 *models.UserProgress
+	err := s.do(ctx, "GetUserProgress", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetUserProgress(ctx, userID)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetUserRoomSummaries(ctx context.Context, userID string) ([]*models.RoomSummary, error) {
+	var r0 []*models.RoomSummary
+	err := s.do(ctx, "GetUserRoomSummaries", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetUserRoomSummaries(ctx, userID)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetUserRooms(ctx context.Context, userID string) ([]*models.ChatRoom, error) {
+	var r0 []*models.ChatRoom
+	err := s.do(ctx, "GetUserRooms", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetUserRooms(ctx, userID)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetUserSecurityEvents(ctx context.Context, userID string, limit int) ([]*models.SecurityEvent, error) {
+	var r0 []*models.SecurityEvent
+	err := s.do(ctx, "GetUserSecurityEvents", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetUserSecurityEvents(ctx, userID, limit)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetUserStatistics(ctx context.Context, userID string) (*store.UserStatistics, error) {
+	var r0 *store.UserStatistics
+	err := s.do(ctx, "GetUserStatistics", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetUserStatistics(ctx, userID)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetVoiceParticipant(ctx context.Context, roomID string, userID string) (*models.VoiceParticipant, error) {
+	var r0 *models.VoiceParticipant
+	err := s.do(ctx, "GetVoiceParticipant", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetVoiceParticipant(ctx, roomID, userID)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) GetVoiceParticipants(ctx context.Context, roomID string) ([]*models.VoiceParticipant, error) {
+	var r0 []*models.VoiceParticipant
+	err := s.do(ctx, "GetVoiceParticipants", func(ctx context.Context) (err error) {
+		r0, err = s.next.GetVoiceParticipants(ctx, roomID)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) IsBlocked(ctx context.Context, userA string, userB string) (bool, error) {
+	var r0 bool
+	err := s.do(ctx, "IsBlocked", func(ctx context.Context) (err error) {
+		r0, err = s.next.IsBlocked(ctx, userA, userB)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) IsMatchVoter(ctx context.Context, matchID string, userID string) (bool, error) {
+	var r0 bool
+	err := s.do(ctx, "IsMatchVoter", func(ctx context.Context) (err error) {
+		r0, err = s.next.IsMatchVoter(ctx, matchID, userID)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) JoinChatRoom(ctx context.Context, userID string, roomID string) error {
+	return s.do(ctx, "JoinChatRoom", func(ctx context.Context) error {
+		return s.next.JoinChatRoom(ctx, userID, roomID)
+	})
+}
+
+func (s *Store) JoinVoiceSession(ctx context.Context, participant *models.VoiceParticipant) error {
+	return s.do(ctx, "JoinVoiceSession", func(ctx context.Context) error {
+		return s.next.JoinVoiceSession(ctx, participant)
+	})
+}
+
+func (s *Store) LeaveChatRoom(ctx context.Context, userID string, roomID string) error {
+	return s.do(ctx, "LeaveChatRoom", func(ctx context.Context) error {
+		return s.next.LeaveChatRoom(ctx, userID, roomID)
+	})
+}
+
+func (s *Store) LeaveVoiceSession(ctx context.Context, roomID string, userID string) error {
+	return s.do(ctx, "LeaveVoiceSession", func(ctx context.Context) error {
+		return s.next.LeaveVoiceSession(ctx, roomID, userID)
+	})
+}
+
+func (s *Store) ListAppeals(ctx context.Context, status string, limit int) ([]*models.Appeal, error) {
+	var r0 []*models.Appeal
+	err := s.do(ctx, "ListAppeals", func(ctx context.Context) (err error) {
+		r0, err = s.next.ListAppeals(ctx, status, limit)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) ListChatRooms(ctx context.Context) ([]*models.ChatRoom, error) {
+	var r0 []*models.ChatRoom
+	err := s.do(ctx, "ListChatRooms", func(ctx context.Context) (err error) {
+		r0, err = s.next.ListChatRooms(ctx)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) ListCommentaryTemplates(ctx context.Context) ([]*models.CommentaryTemplate, error) {
+	var r0 []*models.CommentaryTemplate
+	err := s.do(ctx, "ListCommentaryTemplates", func(ctx context.Context) (err error) {
+		r0, err = s.next.ListCommentaryTemplates(ctx)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) ListDeadLetters(ctx context.Context, pendingOnly bool, limit int) ([]*models.DeadLetter, error) {
+	var r0 []*models.DeadLetter
+	err := s.do(ctx, "ListDeadLetters", func(ctx context.Context) (err error) {
+		r0, err = s.next.ListDeadLetters(ctx, pendingOnly, limit)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) ListDirectoryGroups(ctx context.Context) ([]*models.DirectoryGroup, error) {
+	var r0 []*models.DirectoryGroup
+	err := s.do(ctx, "ListDirectoryGroups", func(ctx context.Context) (err error) {
+		r0, err = s.next.ListDirectoryGroups(ctx)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) ListEvasionSuspects(ctx context.Context, status string, limit int) ([]*models.EvasionSuspect, error) {
+	var r0 []*models.EvasionSuspect
+	err := s.do(ctx, "ListEvasionSuspects", func(ctx context.Context) (err error) {
+		r0, err = s.next.ListEvasionSuspects(ctx, status, limit)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) ListExpiredDataExports(ctx context.Context, before time.Time, limit int) ([]*models.DataExport, error) {
+	var r0 []*models.DataExport
+	err := s.do(ctx, "ListExpiredDataExports", func(ctx context.Context) (err error) {
+		r0, err = s.next.ListExpiredDataExports(ctx, before, limit)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) ListIncidents(ctx context.Context, roomID string, limit int) ([]*models.Incident, error) {
+	var r0 []*models.Incident
+	err := s.do(ctx, "ListIncidents", func(ctx context.Context) (err error) {
+		r0, err = s.next.ListIncidents(ctx, roomID, limit)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) ListLocalizedNames(ctx context.Context) ([]*models.LocalizedName, error) {
+	var r0 []*models.LocalizedName
+	err := s.do(ctx, "ListLocalizedNames", func(ctx context.Context) (err error) {
+		r0, err = s.next.ListLocalizedNames(ctx)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) ListMessageArchives(ctx context.Context, roomID string, before time.Time, limit int) ([]*models.MessageArchive, error) {
+	var r0 []*models.MessageArchive
+	err := s.do(ctx, "ListMessageArchives", func(ctx context.Context) (err error) {
+		r0, err = s.next.ListMessageArchives(ctx, roomID, before, limit)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) ListMessageFlags(ctx context.Context, status string, limit int) ([]*models.MessageFlag, error) {
+	var r0 []*models.MessageFlag
+	err := s.do(ctx, "ListMessageFlags", func(ctx context.Context) (err error) {
+		r0, err = s.next.ListMessageFlags(ctx, status, limit)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) ListModerationEvidence(ctx context.Context, userID string, limit int) ([]*models.ModerationEvidence, error) {
+	var r0 []*models.ModerationEvidence
+	err := s.do(ctx, "ListModerationEvidence", func(ctx context.Context) (err error) {
+		r0, err = s.next.ListModerationEvidence(ctx, userID, limit)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) ListModerationFilters(ctx context.Context) ([]*models.ModerationFilter, error) {
+	var r0 []*models.ModerationFilter
+	err := s.do(ctx, "ListModerationFilters", func(ctx context.Context) (err error) {
+		r0, err = s.next.ListModerationFilters(ctx)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) ListProfanityPolicies(ctx context.Context) ([]*models.ProfanityPolicy, error) {
+	var r0 []*models.ProfanityPolicy
+	err := s.do(ctx, "ListProfanityPolicies", func(ctx context.Context) (err error) {
+		r0, err = s.next.ListProfanityPolicies(ctx)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) ListProfanityWords(ctx context.Context) ([]*models.ProfanityWord, error) {
+	var r0 []*models.ProfanityWord
+	err := s.do(ctx, "ListProfanityWords", func(ctx context.Context) (err error) {
+		r0, err = s.next.ListProfanityWords(ctx)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) ListPurgeableRoomTombstones(ctx context.Context, before time.Time, limit int) ([]*models.RoomTombstone, error) {
+	var r0 []*models.RoomTombstone
+	err := s.do(ctx, "ListPurgeableRoomTombstones", func(ctx context.Context) (err error) {
+		r0, err = s.next.ListPurgeableRoomTombstones(ctx, before, limit)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) ListReconciliationReports(ctx context.Context, limit int) ([]*models.ReconciliationReport, error) {
+	var r0 []*models.ReconciliationReport
+	err := s.do(ctx, "ListReconciliationReports", func(ctx context.Context) (err error) {
+		r0, err = s.next.ListReconciliationReports(ctx, limit)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) ListRoomMessageFlags(ctx context.Context, roomID string, from time.Time, to time.Time) ([]*models.MessageFlag, error) {
+	var r0 []*models.MessageFlag
+	err := s.do(ctx, "ListRoomMessageFlags", func(ctx context.Context) (err error) {
+		r0, err = s.next.ListRoomMessageFlags(ctx, roomID, from, to)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) ListRoomRoles(ctx context.Context, roomID string) ([]*models.RoomRole, error) {
+	var r0 []*models.RoomRole
+	err := s.do(ctx, "ListRoomRoles", func(ctx context.Context) (err error) {
+		r0, err = s.next.ListRoomRoles(ctx, roomID)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) ListRoomSanctionsBetween(ctx context.Context, roomID string, from time.Time, to time.Time) ([]*models.RoomSanction, error) {
+	var r0 []*models.RoomSanction
+	err := s.do(ctx, "ListRoomSanctionsBetween", func(ctx context.Context) (err error) {
+		r0, err = s.next.ListRoomSanctionsBetween(ctx, roomID, from, to)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) ListSeasons(ctx context.Context, competitionID string) ([]*models.Season, error) {
+	var r0 []*models.Season
+	err := s.do(ctx, "ListSeasons", func(ctx context.Context) (err error) {
+		r0, err = s.next.ListSeasons(ctx, competitionID)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) ListSports(ctx context.Context) ([]*models.Sport, error) {
+	var r0 []*models.Sport
+	err := s.do(ctx, "ListSports", func(ctx context.Context) (err error) {
+		r0, err = s.next.ListSports(ctx)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) ListTeams(ctx context.Context, sportID string) ([]*models.Team, error) {
+	var r0 []*models.Team
+	err := s.do(ctx, "ListTeams", func(ctx context.Context) (err error) {
+		r0, err = s.next.ListTeams(ctx, sportID)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) ListUserDataExports(ctx context.Context, userID string) ([]*models.DataExport, error) {
+	var r0 []*models.DataExport
+	err := s.do(ctx, "ListUserDataExports", func(ctx context.Context) (err error) {
+		r0, err = s.next.ListUserDataExports(ctx, userID)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) ListUserMessageArchives(ctx context.Context, userID string, after string, limit int) ([]*models.MessageArchive, error) {
+	var r0 []*models.MessageArchive
+	err := s.do(ctx, "ListUserMessageArchives", func(ctx context.Context) (err error) {
+		r0, err = s.next.ListUserMessageArchives(ctx, userID, after, limit)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) ListUsers(ctx context.Context, offset int, limit int) ([]*models.User, int, error) {
+	var r0 []*models.User
+	var r1 int
+	err := s.do(ctx, "ListUsers", func(ctx context.Context) (err error) {
+		r0, r1, err = s.next.ListUsers(ctx, offset, limit)
+		return err
+	})
+	return r0, r1, err
+}
+
+func (s *Store) MarkDeadLetterReplayed(ctx context.Context, id string, at time.Time) error {
+	return s.do(ctx, "MarkDeadLetterReplayed", func(ctx context.Context) error {
+		return s.next.MarkDeadLetterReplayed(ctx, id, at)
+	})
+}
+
+func (s *Store) MarkFirstMessage(ctx context.Context, userID string, at time.Time) (bool, error) {
+	var r0 bool
+	err := s.do(ctx, "MarkFirstMessage", func(ctx context.Context) (err error) {
+		r0, err = s.next.MarkFirstMessage(ctx, userID, at)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) MarkRoomRead(ctx context.Context, userID string, roomID string, at time.Time) error {
+	return s.do(ctx, "MarkRoomRead", func(ctx context.Context) error {
+		return s.next.MarkRoomRead(ctx, userID, roomID, at)
+	})
+}
+
+func (s *Store) MergeChatRooms(ctx context.Context, sourceID string, targetID string) error {
+	return s.do(ctx, "MergeChatRooms", func(ctx context.Context) error {
+		return s.next.MergeChatRooms(ctx, sourceID, targetID)
+	})
+}
+
+func (s *Store) MoveRoomMembers(ctx context.Context, fromRoomID string, toRoomID string, userIDs []string) error {
+	return s.do(ctx, "MoveRoomMembers", func(ctx context.Context) error {
+		return s.next.MoveRoomMembers(ctx, fromRoomID, toRoomID, userIDs)
+	})
+}
+
+func (s *Store) OpenMatchVote(ctx context.Context, vote *models.MatchVote, voterIDs []string) (bool, error) {
+	var r0 bool
+	err := s.do(ctx, "OpenMatchVote", func(ctx context.Context) (err error) {
+		r0, err = s.next.OpenMatchVote(ctx, vote, voterIDs)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) PublishEmotePack(ctx context.Context, id string, at time.Time) error {
+	return s.do(ctx, "PublishEmotePack", func(ctx context.Context) error {
+		return s.next.PublishEmotePack(ctx, id, at)
+	})
+}
+
+func (s *Store) PurgeChatRoom(ctx context.Context, roomID string, at time.Time) error {
+	return s.do(ctx, "PurgeChatRoom", func(ctx context.Context) error {
+		return s.next.PurgeChatRoom(ctx, roomID, at)
+	})
+}
+
+func (s *Store) PurgeExpiredModerationEvidence(ctx context.Context, now time.Time) (int64, error) {
+	var r0 int64
+	err := s.do(ctx, "PurgeExpiredModerationEvidence", func(ctx context.Context) (err error) {
+		r0, err = s.next.PurgeExpiredModerationEvidence(ctx, now)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) PurgeJournalEntries(ctx context.Context, before time.Time) (int64, error) {
+	var r0 int64
+	err := s.do(ctx, "PurgeJournalEntries", func(ctx context.Context) (err error) {
+		r0, err = s.next.PurgeJournalEntries(ctx, before)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) RecordDeviceSighting(ctx context.Context, sighting *models.DeviceSighting) error {
+	return s.do(ctx, "RecordDeviceSighting", func(ctx context.Context) error {
+		return s.next.RecordDeviceSighting(ctx, sighting)
+	})
+}
+
+func (s *Store) RecordEvidenceAccess(ctx context.Context, access *models.EvidenceAccess) error {
+	return s.do(ctx, "RecordEvidenceAccess", func(ctx context.Context) error {
+		return s.next.RecordEvidenceAccess(ctx, access)
+	})
+}
+
+func (s *Store) RecordRoomAttendance(ctx context.Context, roomID string, at time.Time, viewers int) error {
+	return s.do(ctx, "RecordRoomAttendance", func(ctx context.Context) error {
+		return s.next.RecordRoomAttendance(ctx, roomID, at, viewers)
+	})
+}
+
+func (s *Store) RecordRoomLanguage(ctx context.Context, roomID string, language string) error {
+	return s.do(ctx, "RecordRoomLanguage", func(ctx context.Context) error {
+		return s.next.RecordRoomLanguage(ctx, roomID, language)
+	})
+}
+
+func (s *Store) RecordSecurityEvent(ctx context.Context, event *models.SecurityEvent) error {
+	return s.do(ctx, "RecordSecurityEvent", func(ctx context.Context) error {
+		return s.next.RecordSecurityEvent(ctx, event)
+	})
+}
+
+func (s *Store) RecordUserActivity(ctx context.Context, userID string, at time.Time, xp int) (*models.UserProgress, error) {
+	var r0 *models.UserProgress
+	err := s.do(ctx, "RecordUserActivity", func(ctx context.Context) (err error) {
+		r0, err = s.next.RecordUserActivity(ctx, userID, at, xp)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) RefreshStatisticsRollups(ctx context.Context, since time.Time, full bool) (int, int, error) {
+	var r0 int
+	var r1 int
+	err := s.do(ctx, "RefreshStatisticsRollups", func(ctx context.Context) (err error) {
+		r0, r1, err = s.next.RefreshStatisticsRollups(ctx, since, full)
+		return err
+	})
+	return r0, r1, err
+}
+
+func (s *Store) RemoveReaction(ctx context.Context, messageID string, userID string, emoji string) (bool, error) {
+	var r0 bool
+	err := s.do(ctx, "RemoveReaction", func(ctx context.Context) (err error) {
+		r0, err = s.next.RemoveReaction(ctx, messageID, userID, emoji)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) RenameUser(ctx context.Context, userID string, username string, at time.Time) error {
+	return s.do(ctx, "RenameUser", func(ctx context.Context) error {
+		return s.next.RenameUser(ctx, userID, username, at)
+	})
+}
+
+func (s *Store) ReplaceRecoveryCodes(ctx context.Context, userID string, codes []*models.RecoveryCode) error {
+	return s.do(ctx, "ReplaceRecoveryCodes", func(ctx context.Context) error {
+		return s.next.ReplaceRecoveryCodes(ctx, userID, codes)
+	})
+}
+
+func (s *Store) RestoreChatRoom(ctx context.Context, roomID string) (bool, error) {
+	var r0 bool
+	err := s.do(ctx, "RestoreChatRoom", func(ctx context.Context) (err error) {
+		r0, err = s.next.RestoreChatRoom(ctx, roomID)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) ReviewAppeal(ctx context.Context, id string, status string, reviewerID string, note string) (bool, error) {
+	var r0 bool
+	err := s.do(ctx, "ReviewAppeal", func(ctx context.Context) (err error) {
+		r0, err = s.next.ReviewAppeal(ctx, id, status, reviewerID, note)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) ReviewEvasionSuspect(ctx context.Context, id string, status string, reviewerID string) error {
+	return s.do(ctx, "ReviewEvasionSuspect", func(ctx context.Context) error {
+		return s.next.ReviewEvasionSuspect(ctx, id, status, reviewerID)
+	})
+}
+
+func (s *Store) ReviewMessageFlag(ctx context.Context, id string, status string, reviewerID string) error {
+	return s.do(ctx, "ReviewMessageFlag", func(ctx context.Context) error {
+		return s.next.ReviewMessageFlag(ctx, id, status, reviewerID)
+	})
+}
+
+func (s *Store) ScorePrediction(ctx context.Context, prediction *models.Prediction, rescore bool) (bool, error) {
+	var r0 bool
+	err := s.do(ctx, "ScorePrediction", func(ctx context.Context) (err error) {
+		r0, err = s.next.ScorePrediction(ctx, prediction, rescore)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) SearchEmotes(ctx context.Context, teamIDs []string, prefix string, limit int) ([]*models.Emote, error) {
+	var r0 []*models.Emote
+	err := s.do(ctx, "SearchEmotes", func(ctx context.Context) (err error) {
+		r0, err = s.next.SearchEmotes(ctx, teamIDs, prefix, limit)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) SearchMatchEvents(ctx context.Context, query string, limit int) ([]*models.MatchEvent, error) {
+	var r0 []*models.MatchEvent
+	err := s.do(ctx, "SearchMatchEvents", func(ctx context.Context) (err error) {
+		r0, err = s.next.SearchMatchEvents(ctx, query, limit)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) SearchMessages(ctx context.Context, query string, limit int) ([]*models.Message, error) {
+	var r0 []*models.Message
+	err := s.do(ctx, "SearchMessages", func(ctx context.Context) (err error) {
+		r0, err = s.next.SearchMessages(ctx, query, limit)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) SetAttachmentStatus(ctx context.Context, id string, status string, reason string) (bool, error) {
+	var r0 bool
+	err := s.do(ctx, "SetAttachmentStatus", func(ctx context.Context) (err error) {
+		r0, err = s.next.SetAttachmentStatus(ctx, id, status, reason)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) SetMessagePreviews(ctx context.Context, id string, previews []*models.LinkPreview) error {
+	return s.do(ctx, "SetMessagePreviews", func(ctx context.Context) error {
+		return s.next.SetMessagePreviews(ctx, id, previews)
+	})
+}
+
+func (s *Store) SetPinnedMessage(ctx context.Context, roomID string, messageID string) error {
+	return s.do(ctx, "SetPinnedMessage", func(ctx context.Context) error {
+		return s.next.SetPinnedMessage(ctx, roomID, messageID)
+	})
+}
+
+func (s *Store) SetQuietHours(ctx context.Context, quiet *models.QuietHours) error {
+	return s.do(ctx, "SetQuietHours", func(ctx context.Context) error {
+		return s.next.SetQuietHours(ctx, quiet)
+	})
+}
+
+func (s *Store) SetRecoveryEmail(ctx context.Context, userID string, email string, verifiedAt time.Time) error {
+	return s.do(ctx, "SetRecoveryEmail", func(ctx context.Context) error {
+		return s.next.SetRecoveryEmail(ctx, userID, email, verifiedAt)
+	})
+}
+
+func (s *Store) SetRoomRole(ctx context.Context, role *models.RoomRole) error {
+	return s.do(ctx, "SetRoomRole", func(ctx context.Context) error {
+		return s.next.SetRoomRole(ctx, role)
+	})
+}
+
+func (s *Store) SetShadowBan(ctx context.Context, entry *models.ShadowBanEntry) (bool, error) {
+	var r0 bool
+	err := s.do(ctx, "SetShadowBan", func(ctx context.Context) (err error) {
+		r0, err = s.next.SetShadowBan(ctx, entry)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) TombstoneChatRoom(ctx context.Context, tombstone *models.RoomTombstone) error {
+	return s.do(ctx, "TombstoneChatRoom", func(ctx context.Context) error {
+		return s.next.TombstoneChatRoom(ctx, tombstone)
+	})
+}
+
+func (s *Store) TransitionChatRoom(ctx context.Context, id string, from string, to string, at time.Time) (bool, error) {
+	var r0 bool
+	err := s.do(ctx, "TransitionChatRoom", func(ctx context.Context) (err error) {
+		r0, err = s.next.TransitionChatRoom(ctx, id, from, to, at)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) UnblockUser(ctx context.Context, userID string, blockedID string) error {
+	return s.do(ctx, "UnblockUser", func(ctx context.Context) error {
+		return s.next.UnblockUser(ctx, userID, blockedID)
+	})
+}
+
+func (s *Store) UpdateChatRoom(ctx context.Context, room *models.ChatRoom) error {
+	return s.do(ctx, "UpdateChatRoom", func(ctx context.Context) error {
+		return s.next.UpdateChatRoom(ctx, room)
+	})
+}
+
+func (s *Store) UpdateDirectoryGroup(ctx context.Context, group *models.DirectoryGroup) error {
+	return s.do(ctx, "UpdateDirectoryGroup", func(ctx context.Context) error {
+		return s.next.UpdateDirectoryGroup(ctx, group)
+	})
+}
+
+func (s *Store) UpdateMatch(ctx context.Context, match *models.Match) error {
+	return s.do(ctx, "UpdateMatch", func(ctx context.Context) error {
+		return s.next.UpdateMatch(ctx, match)
+	})
+}
+
+func (s *Store) UpdateMessageArchive(ctx context.Context, archive *models.MessageArchive) error {
+	return s.do(ctx, "UpdateMessageArchive", func(ctx context.Context) error {
+		return s.next.UpdateMessageArchive(ctx, archive)
+	})
+}
+
+func (s *Store) UpdateSport(ctx context.Context, sport *models.Sport) error {
+	return s.do(ctx, "UpdateSport", func(ctx context.Context) error {
+		return s.next.UpdateSport(ctx, sport)
+	})
+}
+
+func (s *Store) UpdateTeam(ctx context.Context, team *models.Team) error {
+	return s.do(ctx, "UpdateTeam", func(ctx context.Context) error {
+		return s.next.UpdateTeam(ctx, team)
+	})
+}
+
+func (s *Store) UpdateUser(ctx context.Context, user *models.User) error {
+	return s.do(ctx, "UpdateUser", func(ctx context.Context) error {
+		return s.next.UpdateUser(ctx, user)
+	})
+}
+
+func (s *Store) UpdateVoiceParticipant(ctx context.Context, participant *models.VoiceParticipant) error {
+	return s.do(ctx, "UpdateVoiceParticipant", func(ctx context.Context) error {
+		return s.next.UpdateVoiceParticipant(ctx, participant)
+	})
+}
+
+func (s *Store) UpsertCommentaryTemplate(ctx context.Context, template *models.CommentaryTemplate) error {
+	return s.do(ctx, "UpsertCommentaryTemplate", func(ctx context.Context) error {
+		return s.next.UpsertCommentaryTemplate(ctx, template)
+	})
+}
+
+func (s *Store) UpsertDraft(ctx context.Context, draft *models.Draft) error {
+	return s.do(ctx, "UpsertDraft", func(ctx context.Context) error {
+		return s.next.UpsertDraft(ctx, draft)
+	})
+}
+
+func (s *Store) UpsertLocalizedNames(ctx context.Context, names []*models.LocalizedName) error {
+	return s.do(ctx, "UpsertLocalizedNames", func(ctx context.Context) error {
+		return s.next.UpsertLocalizedNames(ctx, names)
+	})
+}
+
+func (s *Store) UpsertModerationFilter(ctx context.Context, filter *models.ModerationFilter) error {
+	return s.do(ctx, "UpsertModerationFilter", func(ctx context.Context) error {
+		return s.next.UpsertModerationFilter(ctx, filter)
+	})
+}
+
+func (s *Store) UpsertPrediction(ctx context.Context, prediction *models.Prediction) error {
+	return s.do(ctx, "UpsertPrediction", func(ctx context.Context) error {
+		return s.next.UpsertPrediction(ctx, prediction)
+	})
+}
+
+func (s *Store) UpsertProfanityPolicy(ctx context.Context, policy *models.ProfanityPolicy) error {
+	return s.do(ctx, "UpsertProfanityPolicy", func(ctx context.Context) error {
+		return s.next.UpsertProfanityPolicy(ctx, policy)
+	})
+}
+
+func (s *Store) UseRecoveryCode(ctx context.Context, id string, at time.Time) (bool, error) {
+	var r0 bool
+	err := s.do(ctx, "UseRecoveryCode", func(ctx context.Context) (err error) {
+		r0, err = s.next.UseRecoveryCode(ctx, id, at)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) UseRecoveryToken(ctx context.Context, id string, at time.Time) (bool, error) {
+	var r0 bool
+	err := s.do(ctx, "UseRecoveryToken", func(ctx context.Context) (err error) {
+		r0, err = s.next.UseRecoveryToken(ctx, id, at)
+		return err
+	})
+	return r0, err
+}
+
+func (s *Store) UsernameReleasedSince(ctx context.Context, username string, since time.Time) (bool, error) {
+	var r0 bool
+	err := s.do(ctx, "UsernameReleasedSince", func(ctx context.Context) (err error) {
+		r0, err = s.next.UsernameReleasedSince(ctx, username, since)
+		return err
+	})
+	return r0, err
+}
@@ -14,6 +14,31 @@ type Store interface {
     GetUserByUsername(ctx context.Context, username string) (*models.User, error)
     UpdateUser(ctx context.Context, user *models.User) error
     DeleteUser(ctx context.Context, id string) error
+    // RenameUser changes a username and sets UsernameChangedAt, keeping
+    // the old name in the user's history. UsernameReleasedSince reports
+    // whether someone gave the name up after since.
+    RenameUser(ctx context.Context, userID, username string, at time.Time) error
+    UsernameReleasedSince(ctx context.Context, username string, since time.Time) (bool, error)
+    // MarkFirstMessage records when a user first posted. It reports true
+    // only for the call that set it.
+    MarkFirstMessage(ctx context.Context, userID string, at time.Time) (bool, error)
+    GetUserByExternalID(ctx context.Context, externalID string) (*models.User, error)
+    ListUsers(ctx context.Context, offset, limit int) ([]*models.User, int, error)
+    // AnonymizeUser renames a user to username, clears their personal
+    // details, revokes their sessions and deletes what only identifies
+    // them: username history, sign-ins, devices, memberships, roles,
+    // drafts, alerts, blocks and quiet hours. Their messages stay, shown
+    // under the new name.
+    AnonymizeUser(ctx context.Context, userID, username string, at time.Time) error
+
+    // Directory group operations. UpdateDirectoryGroup replaces the
+    // group's members along with its attributes.
+    CreateDirectoryGroup(ctx context.Context, group *models.DirectoryGroup) error
+    GetDirectoryGroup(ctx context.Context, id string) (*models.DirectoryGroup, error)
+    ListDirectoryGroups(ctx context.Context) ([]*models.DirectoryGroup, error)
+    UpdateDirectoryGroup(ctx context.Context, group *models.DirectoryGroup) error
+    DeleteDirectoryGroup(ctx context.Context, id string) error
+    GetUserDirectoryGroups(ctx context.Context, userID string) ([]*models.DirectoryGroup, error)
 
     // Sport operations
     CreateSport(ctx context.Context, sport *models.Sport) error
@@ -32,9 +57,16 @@ type Store interface {
     // Match operations
     CreateMatch(ctx context.Context, match *models.Match) error
     GetMatch(ctx context.Context, id string) (*models.Match, error)
+    GetMatchByProviderID(ctx context.Context, providerID string) (*models.Match, error)
     GetLiveMatches(ctx context.Context) ([]*models.Match, error)
     GetMatchesByStatus(ctx context.Context, status string) ([]*models.Match, error)
     GetUpcomingMatches(ctx context.Context, limit int) ([]*models.Match, error)
+    GetFinishedMatchesSince(ctx context.Context, since time.Time) ([]*models.Match, error)
+    GetHeadToHeadMatches(ctx context.Context, teamAID, teamBID string, limit int) ([]*models.Match, error)
+    GetTeamRecentMatches(ctx context.Context, teamID string, limit int) ([]*models.Match, error)
+    // CountMatchFollowers counts active users likely to turn up for a
+    // match: fans of either team and members of its rooms.
+    CountMatchFollowers(ctx context.Context, matchID string) (int, error)
     UpdateMatch(ctx context.Context, match *models.Match) error
     DeleteMatch(ctx context.Context, id string) error
 
@@ -45,42 +77,479 @@ type Store interface {
     ListChatRooms(ctx context.Context) ([]*models.ChatRoom, error)
     UpdateChatRoom(ctx context.Context, room *models.ChatRoom) error
     DeleteChatRoom(ctx context.Context, id string) error
+    MergeChatRooms(ctx context.Context, sourceID, targetID string) error
+    GetChatRoomsByState(ctx context.Context, state string) ([]*models.ChatRoom, error)
+    // TransitionChatRoom moves a room from one state to another, reporting
+    // false if it was no longer in from. Archiving also deactivates it.
+    TransitionChatRoom(ctx context.Context, id, from, to string, at time.Time) (bool, error)
+    MoveRoomMembers(ctx context.Context, fromRoomID, toRoomID string, userIDs []string) error
+
+    // Room deletion happens in two phases. TombstoneChatRoom deactivates
+    // the room and records its tombstone; RestoreChatRoom undoes that
+    // until the room is purged, reporting false if there was nothing to
+    // restore. PurgeChatRoom then deletes the room and what belongs to
+    // it, keeping the tombstone. ListPurgeableRoomTombstones returns
+    // unpurged tombstones from before the cutoff, oldest first.
+    TombstoneChatRoom(ctx context.Context, tombstone *models.RoomTombstone) error
+    GetRoomTombstone(ctx context.Context, roomID string) (*models.RoomTombstone, error)
+    RestoreChatRoom(ctx context.Context, roomID string) (bool, error)
+    ListPurgeableRoomTombstones(ctx context.Context, before time.Time, limit int) ([]*models.RoomTombstone, error)
+    PurgeChatRoom(ctx context.Context, roomID string, at time.Time) error
+
+    // Room topic operations. GetRoomTopics returns collapsed topics too,
+    // by position. CollapseRoomTopics collapses the room's open topics,
+    // reporting how many this call collapsed; CollapseRoomTopic reports
+    // false if the topic was already collapsed.
+    CreateRoomTopic(ctx context.Context, topic *models.RoomTopic) error
+    GetRoomTopics(ctx context.Context, roomID string) ([]*models.RoomTopic, error)
+    CollapseRoomTopic(ctx context.Context, id string, at time.Time) (bool, error)
+    CollapseRoomTopics(ctx context.Context, roomID string, at time.Time) (int, error)
+    // GetTopicMessagesBeforeCursor pages back through a topic's sub-feed
+    // like GetMessagesBeforeCursor.
+    GetTopicMessagesBeforeCursor(ctx context.Context, topicID string, cursor *MessageCursor, limit int) ([]*models.Message, error)
 
     // Message operations
     CreateMessage(ctx context.Context, message *models.Message) error
+    // CreateMessages inserts messages in one statement, skipping any
+    // already stored, so a retried batch does not fail on its own rows.
+    // Messages must carry their IDs.
+    CreateMessages(ctx context.Context, messages []*models.Message) error
     GetMessage(ctx context.Context, id string) (*models.Message, error)
     GetRecentMessages(ctx context.Context, roomID string, limit int) ([]*models.Message, error)
-    GetMessagesBefore(ctx context.Context, roomID string, before time.Time, limit int) ([]*models.Message, error)
+    // GetMessagesBeforeCursor returns up to limit messages matching the
+    // filter older than the cursor, newest first; a nil cursor starts from
+    // the latest message. GetMessagesAfterCursor returns messages newer
+    // than the cursor, oldest first.
+    GetMessagesBeforeCursor(ctx context.Context, roomID string, filter MessageFilter, cursor *MessageCursor, limit int) ([]*models.Message, error)
+    GetMessagesAfterCursor(ctx context.Context, roomID string, filter MessageFilter, cursor MessageCursor, limit int) ([]*models.Message, error)
+    // GetMessagesAfterSeq returns the room's messages sequenced after seq,
+    // in sequence order. GetRoomMaxSeq is the room's highest stored
+    // sequence, zero if none.
+    GetMessagesAfterSeq(ctx context.Context, roomID string, seq int64, limit int) ([]*models.Message, error)
+    GetRoomMaxSeq(ctx context.Context, roomID string) (int64, error)
+    // GetMessagesBetween returns the room's messages sent in [from, to),
+    // oldest first.
+    GetMessagesBetween(ctx context.Context, roomID string, from, to time.Time, limit int) ([]*models.Message, error)
     DeleteMessage(ctx context.Context, id string) error
+    SetMessagePreviews(ctx context.Context, id string, previews []*models.LinkPreview) error
+
+    // Message archive operations. GetArchiveCandidates returns the room
+    // days with messages sent before the cutoff, oldest first.
+    // CreateMessageArchive records an archive and deletes the messages it
+    // holds in one transaction; recording an object key twice is not an
+    // error. ListMessageArchives returns a room's archives that begin
+    // before the given time, newest first. ListUserMessageArchives returns
+    // the archives after the given ID, in ID order, that may hold the
+    // user's messages: those listing them as a sender and those with no
+    // senders recorded. UpdateMessageArchive points an archive at its
+    // rewritten object; DeleteMessageArchive removes an emptied one.
+    GetArchiveCandidates(ctx context.Context, before time.Time, limit int) ([]ArchiveCandidate, error)
+    CreateMessageArchive(ctx context.Context, archive *models.MessageArchive, messageIDs []string) error
+    ListMessageArchives(ctx context.Context, roomID string, before time.Time, limit int) ([]*models.MessageArchive, error)
+    ListUserMessageArchives(ctx context.Context, userID, after string, limit int) ([]*models.MessageArchive, error)
+    UpdateMessageArchive(ctx context.Context, archive *models.MessageArchive) error
+    DeleteMessageArchive(ctx context.Context, id string) error
+
+    // Room moderation operations. Creating a sanction replaces any of the
+    // same kind; GetRoomSanctions returns only those still in force.
+    CreateRoomSanction(ctx context.Context, sanction *models.RoomSanction) error
+    DeleteRoomSanction(ctx context.Context, roomID, userID, kind string) error
+    GetRoomSanctions(ctx context.Context, roomID, userID string) ([]*models.RoomSanction, error)
+    // ListRoomSanctionsBetween returns the sanctions created in the room
+    // in [from, to) that have not been lifted since, oldest first.
+    ListRoomSanctionsBetween(ctx context.Context, roomID string, from, to time.Time) ([]*models.RoomSanction, error)
+    // SetPinnedMessage pins a message above the room's chat; an empty
+    // messageID unpins.
+    SetPinnedMessage(ctx context.Context, roomID, messageID string) error
+
+    // Room role operations. GetRoomRole returns the user's granted role,
+    // owner for a watch party's owner, or member if they have neither.
+    // SetRoomRole replaces any role the user had in the room.
+    GetRoomRole(ctx context.Context, roomID, userID string) (string, error)
+    ListRoomRoles(ctx context.Context, roomID string) ([]*models.RoomRole, error)
+    SetRoomRole(ctx context.Context, role *models.RoomRole) error
+    DeleteRoomRole(ctx context.Context, roomID, userID string) (bool, error)
+
+    // Reaction operations. AddReaction and RemoveReaction report whether
+    // they changed anything, so repeats are not counted twice.
+    AddReaction(ctx context.Context, reaction *models.Reaction) (bool, error)
+    RemoveReaction(ctx context.Context, messageID, userID, emoji string) (bool, error)
+    GetMessageReactions(ctx context.Context, messageIDs []string) (map[string][]*models.ReactionCount, error)
+
+    // Voice session operations. JoinVoiceSession replaces any existing
+    // row for the user; GetVoiceParticipants orders by join time.
+    JoinVoiceSession(ctx context.Context, participant *models.VoiceParticipant) error
+    LeaveVoiceSession(ctx context.Context, roomID, userID string) error
+    GetVoiceParticipant(ctx context.Context, roomID, userID string) (*models.VoiceParticipant, error)
+    GetVoiceParticipants(ctx context.Context, roomID string) ([]*models.VoiceParticipant, error)
+    UpdateVoiceParticipant(ctx context.Context, participant *models.VoiceParticipant) error
+
+    // Dead letter operations. Pending letters are those never replayed;
+    // ListDeadLetters returns the newest first.
+    CreateDeadLetter(ctx context.Context, letter *models.DeadLetter) error
+    GetDeadLetter(ctx context.Context, id string) (*models.DeadLetter, error)
+    ListDeadLetters(ctx context.Context, pendingOnly bool, limit int) ([]*models.DeadLetter, error)
+    MarkDeadLetterReplayed(ctx context.Context, id string, at time.Time) error
+    CountPendingDeadLetters(ctx context.Context) (int, error)
+
+    // Direct message operations. GetOrCreateConversation orders the pair
+    // itself. GetUserConversations lists the latest active first, with
+    // Other and LastMessage joined. CreateDirectMessage also bumps the
+    // conversation's LastMessageAt; history pages newest first.
+    GetOrCreateConversation(ctx context.Context, userA, userB string) (*models.Conversation, error)
+    GetConversation(ctx context.Context, id string) (*models.Conversation, error)
+    GetUserConversations(ctx context.Context, userID string, limit int) ([]*models.Conversation, error)
+    CreateDirectMessage(ctx context.Context, msg *models.DirectMessage) error
+    GetDirectMessagesBeforeCursor(ctx context.Context, conversationID string, before *MessageCursor, limit int) ([]*models.DirectMessage, error)
+
+    // User block operations. IsBlocked checks both directions.
+    BlockUser(ctx context.Context, block *models.UserBlock) error
+    UnblockUser(ctx context.Context, userID, blockedID string) error
+    GetBlockedUsers(ctx context.Context, userID string) ([]*models.UserBlock, error)
+    IsBlocked(ctx context.Context, userA, userB string) (bool, error)
+
+    // Match vote operations. OpenMatchVote adds voterIDs as eligible
+    // voters and creates the vote unless it exists, reporting whether it
+    // did. CastMatchBallot replaces the user's earlier ballot.
+    // CloseMatchVote persists the aggregates and reports whether this
+    // call closed the vote; GetMatchVoteResult reads them back, or
+    // tallies live while the vote is open.
+    OpenMatchVote(ctx context.Context, vote *models.MatchVote, voterIDs []string) (bool, error)
+    GetMatchVote(ctx context.Context, matchID string) (*models.MatchVote, error)
+    IsMatchVoter(ctx context.Context, matchID, userID string) (bool, error)
+    CastMatchBallot(ctx context.Context, ballot *models.MatchBallot) error
+    GetDueMatchVotes(ctx context.Context, now time.Time) ([]*models.MatchVote, error)
+    CloseMatchVote(ctx context.Context, matchID string, at time.Time) (bool, error)
+    GetMatchVoteResult(ctx context.Context, matchID string) (*models.MatchVoteResult, error)
+
+    // Prediction operations. UpsertPrediction replaces the user's earlier
+    // prediction for the match. ScorePrediction reports whether it
+    // stored the score; without rescore it only scores unscored
+    // predictions, so instances racing at full time score each once.
+    UpsertPrediction(ctx context.Context, prediction *models.Prediction) error
+    GetPrediction(ctx context.Context, matchID, userID string) (*models.Prediction, error)
+    GetMatchPredictions(ctx context.Context, matchID string) ([]*models.Prediction, error)
+    ScorePrediction(ctx context.Context, prediction *models.Prediction, rescore bool) (bool, error)
+    GetUserPredictionStats(ctx context.Context, userID string, since time.Time) (correct, total int, err error)
+
+    // Season operations. GetSeasonStandings computes a running season's
+    // table from its predictions and reads an archived season's frozen
+    // one. ArchiveSeason freezes the table and awards badges, reporting
+    // whether this call archived the season.
+    CreateSeason(ctx context.Context, season *models.Season) error
+    GetSeason(ctx context.Context, id string) (*models.Season, error)
+    GetSeasonAt(ctx context.Context, competitionID string, at time.Time) (*models.Season, error)
+    ListSeasons(ctx context.Context, competitionID string) ([]*models.Season, error)
+    GetDueSeasons(ctx context.Context, now time.Time) ([]*models.Season, error)
+    GetSeasonStandings(ctx context.Context, season *models.Season, limit int) ([]*models.SeasonStanding, error)
+    ArchiveSeason(ctx context.Context, id string, at time.Time) (bool, error)
+
+    // Engagement stats operations. AddUserMatchStats adds each delta's
+    // counters to the user's totals for the match. The leaderboards rank
+    // by score, highest first; a season's sums its matches, as its
+    // prediction table does.
+    AddUserMatchStats(ctx context.Context, deltas []*models.UserMatchStats) error
+    GetMatchLeaderboard(ctx context.Context, matchID string, limit int) ([]*models.LeaderboardEntry, error)
+    GetSeasonLeaderboard(ctx context.Context, season *models.Season, limit int) ([]*models.LeaderboardEntry, error)
+
+    // Poll operations. CastPollVote replaces the user's earlier vote.
+    // ClosePoll reports whether this call closed the poll.
+    CreatePoll(ctx context.Context, poll *models.Poll) error
+    GetPoll(ctx context.Context, id string) (*models.Poll, error)
+    GetMatchPolls(ctx context.Context, matchID string) ([]*models.Poll, error)
+    CastPollVote(ctx context.Context, vote *models.PollVote) error
+    GetPollTally(ctx context.Context, pollID string) (*models.PollTally, error)
+    ClosePoll(ctx context.Context, id string, at time.Time) (bool, error)
+
+    // Highlight operations. GetMatchHighlights returns the match's clips
+    // newest first, with the events they show.
+    CreateHighlight(ctx context.Context, highlight *models.Highlight) error
+    GetMatchHighlights(ctx context.Context, matchID string) ([]*models.Highlight, error)
+    DeleteHighlight(ctx context.Context, id string) error
+
+    // Emote operations. AddEmote reports false when the pack already has
+    // the shortcode. GetPublishedEmotePacks returns the published site-wide
+    // packs and those of the given teams, with their emotes; SearchEmotes
+    // matches shortcodes by prefix across the same packs.
+    CreateEmotePack(ctx context.Context, pack *models.EmotePack) error
+    GetEmotePack(ctx context.Context, id string) (*models.EmotePack, error)
+    AddEmote(ctx context.Context, emote *models.Emote) (bool, error)
+    DeleteEmote(ctx context.Context, id string) error
+    PublishEmotePack(ctx context.Context, id string, at time.Time) error
+    GetPublishedEmotePacks(ctx context.Context, teamIDs []string) ([]*models.EmotePack, error)
+    SearchEmotes(ctx context.Context, teamIDs []string, prefix string, limit int) ([]*models.Emote, error)
 
     // Match event operations
     CreateMatchEvent(ctx context.Context, event *models.MatchEvent) error
     GetMatchEvents(ctx context.Context, matchID string) ([]*models.MatchEvent, error)
     GetRecentMatchEvents(ctx context.Context, matchID string, limit int) ([]*models.MatchEvent, error)
 
+    // Commentary template operations
+    ListCommentaryTemplates(ctx context.Context) ([]*models.CommentaryTemplate, error)
+    UpsertCommentaryTemplate(ctx context.Context, template *models.CommentaryTemplate) error
+    DeleteCommentaryTemplate(ctx context.Context, locale, eventType string) error
+
+    // Localized name operations. Upserting a provider's name leaves an
+    // admin's for the same locale in place.
+    ListLocalizedNames(ctx context.Context) ([]*models.LocalizedName, error)
+    UpsertLocalizedNames(ctx context.Context, names []*models.LocalizedName) error
+    DeleteLocalizedName(ctx context.Context, kind, entityID, locale string) error
+
     // User presence operations
     JoinChatRoom(ctx context.Context, userID, roomID string) error
     LeaveChatRoom(ctx context.Context, userID, roomID string) error
     GetRoomUsers(ctx context.Context, roomID string) ([]*models.User, error)
+    // GetRoomMembersByUsername returns the room's members among the given
+    // usernames, matched case-insensitively.
+    GetRoomMembersByUsername(ctx context.Context, roomID string, usernames []string) ([]*models.User, error)
     GetUserRooms(ctx context.Context, userID string) ([]*models.ChatRoom, error)
+    // GetUserRoomSummaries loads every joined room with its last message,
+    // unread count and match in one query; viewer counts are left to the
+    // caller. MarkRoomRead moves the user's read marker forward.
+    GetUserRoomSummaries(ctx context.Context, userID string) ([]*models.RoomSummary, error)
+    MarkRoomRead(ctx context.Context, userID, roomID string, at time.Time) error
+    // GetUnreadCounts returns the user's read state in every joined room,
+    // counting other users' messages since their read marker.
+    GetUnreadCounts(ctx context.Context, userID string) ([]*models.UnreadCount, error)
 
     // Search operations
-    SearchMessages(ctx context.Context, query string, limit int) ([]*models.Message, error)
-    SearchMatchEvents(ctx context.Context, query string, limit int) ([]*models.MatchEvent, error)
+    SearchStore
+
+    // Broadcast journal operations
+    AppendJournalEntries(ctx context.Context, entries []*models.JournalEntry) error
+    GetJournalEntries(ctx context.Context, roomID string, from, to time.Time, limit int) ([]*models.JournalEntry, error)
+    PurgeJournalEntries(ctx context.Context, before time.Time) (int64, error)
+
+    // Incident operations. The bundles themselves live in object
+    // storage; the store only indexes them.
+    CreateIncident(ctx context.Context, incident *models.Incident) error
+    GetIncident(ctx context.Context, id string) (*models.Incident, error)
+    ListIncidents(ctx context.Context, roomID string, limit int) ([]*models.Incident, error)
+
+    // Data export operations. The archives live in object storage.
+    // ListUserDataExports returns the user's exports newest first.
+    // CompleteDataExport settles a pending export as ready or failed,
+    // reporting false if it was no longer pending. GetUserMessages pages
+    // through a user's messages in every room after the cursor, oldest
+    // first; a nil cursor starts from the first.
+    CreateDataExport(ctx context.Context, export *models.DataExport) error
+    GetDataExport(ctx context.Context, id string) (*models.DataExport, error)
+    ListUserDataExports(ctx context.Context, userID string) ([]*models.DataExport, error)
+    CompleteDataExport(ctx context.Context, export *models.DataExport) (bool, error)
+    ListExpiredDataExports(ctx context.Context, before time.Time, limit int) ([]*models.DataExport, error)
+    DeleteDataExport(ctx context.Context, id string) error
+    GetUserMessages(ctx context.Context, userID string, cursor *MessageCursor, limit int) ([]*models.Message, error)
+
+    // Profanity operations
+    ListProfanityWords(ctx context.Context) ([]*models.ProfanityWord, error)
+    AddProfanityWord(ctx context.Context, word *models.ProfanityWord) error
+    DeleteProfanityWord(ctx context.Context, locale, word string) error
+    ListProfanityPolicies(ctx context.Context) ([]*models.ProfanityPolicy, error)
+    UpsertProfanityPolicy(ctx context.Context, policy *models.ProfanityPolicy) error
+
+    // Moderation filter operations
+    ListModerationFilters(ctx context.Context) ([]*models.ModerationFilter, error)
+    UpsertModerationFilter(ctx context.Context, filter *models.ModerationFilter) error
+    CreateMessageFlag(ctx context.Context, flag *models.MessageFlag) error
+    GetMessageFlag(ctx context.Context, id string) (*models.MessageFlag, error)
+    ListMessageFlags(ctx context.Context, status string, limit int) ([]*models.MessageFlag, error)
+    ReviewMessageFlag(ctx context.Context, id, status, reviewerID string) error
+    // ListRoomMessageFlags returns the room's flags raised in [from, to),
+    // oldest first.
+    ListRoomMessageFlags(ctx context.Context, roomID string, from, to time.Time) ([]*models.MessageFlag, error)
+
+    // Moderation evidence operations. Evidence is append-only: it is never
+    // changed, and is only purged once expired.
+    CreateModerationEvidence(ctx context.Context, evidence *models.ModerationEvidence) error
+    GetModerationEvidence(ctx context.Context, id string) (*models.ModerationEvidence, error)
+    // ListModerationEvidence lists unexpired evidence, of one user or of
+    // all for an empty userID, newest first.
+    ListModerationEvidence(ctx context.Context, userID string, limit int) ([]*models.ModerationEvidence, error)
+    PurgeExpiredModerationEvidence(ctx context.Context, now time.Time) (int64, error)
+    RecordEvidenceAccess(ctx context.Context, access *models.EvidenceAccess) error
+    GetEvidenceAccess(ctx context.Context, evidenceID string) ([]*models.EvidenceAccess, error)
+    // GetMessageEvidence returns the unexpired evidence of a deleted
+    // message.
+    GetMessageEvidence(ctx context.Context, messageID string) (*models.ModerationEvidence, error)
+
+    // Shadow ban operations. SetShadowBan sets the user's flag to
+    // entry.Banned and records the entry, reporting false, with nothing
+    // recorded, if the flag already had that value. The audit trail is
+    // newest first.
+    SetShadowBan(ctx context.Context, entry *models.ShadowBanEntry) (bool, error)
+    GetShadowBanEntries(ctx context.Context, userID string) ([]*models.ShadowBanEntry, error)
+
+    // Appeal operations. CreateAppeal reports false if the action was
+    // already appealed; ReviewAppeal reports false if the appeal was no
+    // longer pending.
+    CreateAppeal(ctx context.Context, appeal *models.Appeal) (bool, error)
+    GetAppeal(ctx context.Context, id string) (*models.Appeal, error)
+    GetUserAppeals(ctx context.Context, userID string) ([]*models.Appeal, error)
+    // ListAppeals lists appeals in a status, oldest first, so the review
+    // queue is worked in order.
+    ListAppeals(ctx context.Context, status string, limit int) ([]*models.Appeal, error)
+    ReviewAppeal(ctx context.Context, id, status, reviewerID, note string) (bool, error)
+
+    // Draft operations
+    UpsertDraft(ctx context.Context, draft *models.Draft) error
+    DeleteDraft(ctx context.Context, userID, roomID string) error
+    GetUserDrafts(ctx context.Context, userID string) ([]*models.Draft, error)
+
+    // Keyword alert operations
+    CreateKeywordAlert(ctx context.Context, alert *models.KeywordAlert) error
+    DeleteKeywordAlert(ctx context.Context, userID, id string) error
+    GetUserKeywordAlerts(ctx context.Context, userID string) ([]*models.KeywordAlert, error)
+
+    // Quiet hours operations. SetQuietHours replaces the user's schedule.
+    GetQuietHours(ctx context.Context, userID string) (*models.QuietHours, error)
+    SetQuietHours(ctx context.Context, quiet *models.QuietHours) error
+    DeleteQuietHours(ctx context.Context, userID string) error
+
+    // Achievement operations
+    AwardAchievement(ctx context.Context, achievement *models.UserAchievement) (bool, error)
+    GetUserAchievements(ctx context.Context, userID string) ([]*models.UserAchievement, error)
+    RecordUserActivity(ctx context.Context, userID string, at time.Time, xp int) (*models.UserProgress, error)
+    GetUserProgress(ctx context.Context, userID string) (*models.UserProgress, error)
+
+    // Ban evasion operations
+    RecordDeviceSighting(ctx context.Context, sighting *models.DeviceSighting) error
+    GetBannedUserSightings(ctx context.Context, fingerprint, ip string, since time.Time) ([]*models.DeviceSighting, error)
+    CreateEvasionSuspect(ctx context.Context, suspect *models.EvasionSuspect) (bool, error)
+    GetEvasionSuspect(ctx context.Context, id string) (*models.EvasionSuspect, error)
+    ListEvasionSuspects(ctx context.Context, status string, limit int) ([]*models.EvasionSuspect, error)
+    ReviewEvasionSuspect(ctx context.Context, id, status, reviewerID string) error
+    GetEvasionSignalStats(ctx context.Context) ([]*models.EvasionSignalStats, error)
+
+    // Security activity operations. RecordSecurityEvent refreshes the
+    // existing entry for a session's repeat sign-ins. DisputeSecurityEvent
+    // marks one of the user's entries disputed and revokes their sessions
+    // issued before at; it reports false if the user has no such entry.
+    RecordSecurityEvent(ctx context.Context, event *models.SecurityEvent) error
+    GetUserSecurityEvents(ctx context.Context, userID string, limit int) ([]*models.SecurityEvent, error)
+    DisputeSecurityEvent(ctx context.Context, userID, id string, at time.Time) (bool, error)
+
+    // Account recovery operations. ReplaceRecoveryCodes swaps the user's
+    // backup codes for a new set. UseRecoveryCode and UseRecoveryToken
+    // mark one used at, reporting false if it already was. A recovery
+    // email is set once verified; GetRecoveryEmail returns empty if the
+    // user has none, and setting an empty one removes it.
+    ReplaceRecoveryCodes(ctx context.Context, userID string, codes []*models.RecoveryCode) error
+    GetUnusedRecoveryCodes(ctx context.Context, userID string) ([]*models.RecoveryCode, error)
+    UseRecoveryCode(ctx context.Context, id string, at time.Time) (bool, error)
+    SetRecoveryEmail(ctx context.Context, userID, email string, verifiedAt time.Time) error
+    GetRecoveryEmail(ctx context.Context, userID string) (string, error)
+    CreateRecoveryToken(ctx context.Context, token *models.RecoveryToken) error
+    GetRecoveryToken(ctx context.Context, tokenHash string) (*models.RecoveryToken, error)
+    UseRecoveryToken(ctx context.Context, id string, at time.Time) (bool, error)
+
+    // Watch party analytics operations. RecordRoomAttendance keeps the
+    // highest count sampled for the minute of at. GetRoomAnalytics
+    // covers [from, to), listing the room's top members and busiest
+    // minutes.
+    RecordRoomAttendance(ctx context.Context, roomID string, at time.Time, viewers int) error
+    GetRoomAnalytics(ctx context.Context, roomID string, from, to time.Time) (*models.RoomAnalytics, error)
+
+    // Room language operations. RecordRoomLanguage counts one more
+    // message in the language; GetRoomLanguages lists a room's languages
+    // by how many messages used them, across its whole history.
+    RecordRoomLanguage(ctx context.Context, roomID, language string) error
+    GetRoomLanguages(ctx context.Context, roomID string) ([]*models.LanguageShare, error)
+
+    // Attachment operations. SetAttachmentStatus only moves a pending
+    // attachment, reporting false if it was already scanned or is gone.
+    CreateAttachment(ctx context.Context, attachment *models.Attachment, data []byte) error
+    GetAttachment(ctx context.Context, id string) (*models.Attachment, error)
+    GetAttachmentData(ctx context.Context, id string) ([]byte, error)
+    SetAttachmentStatus(ctx context.Context, id, status, reason string) (bool, error)
+
+    // Reconciliation operations
+    CreateReconciliationReport(ctx context.Context, report *models.ReconciliationReport) error
+    ListReconciliationReports(ctx context.Context, limit int) ([]*models.ReconciliationReport, error)
 
     // Statistics operations
     GetRoomStatistics(ctx context.Context, roomID string) (*RoomStatistics, error)
     GetUserStatistics(ctx context.Context, userID string) (*UserStatistics, error)
     GetMatchStatistics(ctx context.Context, matchID string) (*MatchStatistics, error)
 
+    // Statistics rollups. RefreshStatisticsRollups recomputes the rollups
+    // of active rooms and of matches that started after since, or of
+    // every room and match when full, reporting how many it wrote; a
+    // match keeps the most viewers it was rolled up with as its peak. The
+    // getters return nil until a room or match is first rolled up.
+    RefreshStatisticsRollups(ctx context.Context, since time.Time, full bool) (rooms, matches int, err error)
+    GetRoomStatisticsRollup(ctx context.Context, roomID string) (*RoomStatistics, error)
+    GetMatchStatisticsRollup(ctx context.Context, matchID string) (*MatchStatistics, error)
+
     // Utility
     Close() error
 }
 
+// SearchStore is split out so search can be served by a dedicated backend
+// (see internal/search) while everything else stays on the primary store.
+type SearchStore interface {
+    SearchMessages(ctx context.Context, query string, limit int) ([]*models.Message, error)
+    SearchMatchEvents(ctx context.Context, query string, limit int) ([]*models.MatchEvent, error)
+}
+
+// MessageCursor is a position in a room's history. Messages are ordered by
+// creation time with the ID breaking ties, so pages neither skip nor repeat
+// messages sent in the same instant.
+type MessageCursor struct {
+    CreatedAt time.Time
+    ID        string
+}
+
+// MessageFilter narrows a room's history, for moderators reviewing one
+// user and for media galleries. The zero value matches every message.
+type MessageFilter struct {
+    // UserID keeps only that user's messages
+    UserID string
+    // Kind keeps only messages of one kind, one of the MessageKind values
+    Kind string
+}
+
+// Message kinds a history can be filtered to
+const (
+    // MessageKindMedia is messages carrying link previews, such as clips
+    // and images shared by link
+    MessageKindMedia = "media"
+    // MessageKindSystem is messages the server posted rather than users
+    MessageKindSystem = "system"
+)
+
+// ValidMessageKind reports whether kind is empty or a known kind.
+func ValidMessageKind(kind string) bool {
+    return kind == "" || kind == MessageKindMedia || kind == MessageKindSystem
+}
+
+// Matches reports whether the filter keeps a message, for messages read
+// from somewhere other than a query, such as an archive.
+func (f MessageFilter) Matches(m *models.Message) bool {
+    if f.UserID != "" && m.UserID != f.UserID {
+        return false
+    }
+    switch f.Kind {
+    case MessageKindMedia:
+        return len(m.Previews) > 0
+    case MessageKindSystem:
+        return m.MessageType != "chat" && m.MessageType != "text"
+    }
+    return true
+}
+
+// ArchiveCandidate is a UTC day of a room's messages old enough to
+// archive.
+type ArchiveCandidate struct {
+    RoomID string
+    Day    time.Time
+}
+
 type RoomStatistics struct {
     MessageCount  int       `json:"message_count"`
     UserCount     int       `json:"user_count"`
     LastActivity  time.Time `json:"last_activity"`
+    // ComputedAt is when a rollup was computed; zero for live statistics
+    ComputedAt    time.Time `json:"-"`
 }
 
 type UserStatistics struct {
@@ -95,4 +564,6 @@ type MatchStatistics struct {
     MessageCount      int       `json:"message_count"`
     PeakViewerCount   int       `json:"peak_viewer_count"`
     EventCount        int       `json:"event_count"`
+    // ComputedAt is when a rollup was computed; zero for live statistics
+    ComputedAt        time.Time `json:"-"`
 }
\ No newline at end of file
@@ -0,0 +1,103 @@
+// Package tracing sets up OpenTelemetry tracing and carries trace context
+// across the places the standard propagators can't reach on their own:
+// the broker between instances and queued jobs.
+package tracing
+
+import (
+    "context"
+    "fmt"
+
+    "go.opentelemetry.io/otel"
+    "go.opentelemetry.io/otel/codes"
+    "go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+    "go.opentelemetry.io/otel/propagation"
+    "go.opentelemetry.io/otel/sdk/resource"
+    sdktrace "go.opentelemetry.io/otel/sdk/trace"
+    semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+    "go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/yourusername/sports-chat"
+
+type Options struct {
+    // Endpoint is the OTLP gRPC collector address; empty disables export
+    Endpoint    string
+    Insecure    bool
+    ServiceName string
+    // SampleRatio is the fraction of new traces kept. Spans continuing a
+    // sampled trace are always kept.
+    SampleRatio float64
+}
+
+// Setup installs the global tracer provider and propagators. The returned
+// function flushes and stops the exporter. Without an endpoint spans are
+// still created, so trace context flows through, but nothing is exported.
+func Setup(ctx context.Context, opts Options) (func(context.Context) error, error) {
+    otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+        propagation.TraceContext{},
+        propagation.Baggage{},
+    ))
+
+    if opts.Endpoint == "" {
+        return func(context.Context) error { return nil }, nil
+    }
+
+    clientOpts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(opts.Endpoint)}
+    if opts.Insecure {
+        clientOpts = append(clientOpts, otlptracegrpc.WithInsecure())
+    }
+    exporter, err := otlptracegrpc.New(ctx, clientOpts...)
+    if err != nil {
+        return nil, fmt.Errorf("failed to create trace exporter: %w", err)
+    }
+
+    res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+        semconv.SchemaURL,
+        semconv.ServiceName(opts.ServiceName),
+    ))
+    if err != nil {
+        return nil, fmt.Errorf("failed to create trace resource: %w", err)
+    }
+
+    provider := sdktrace.NewTracerProvider(
+        sdktrace.WithBatcher(exporter),
+        sdktrace.WithResource(res),
+        sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opts.SampleRatio))),
+    )
+    otel.SetTracerProvider(provider)
+    return provider.Shutdown, nil
+}
+
+// Start begins a span from the app's tracer.
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+    return otel.Tracer(instrumentationName).Start(ctx, name, opts...)
+}
+
+// End records err on the span, if any, and ends it.
+func End(span trace.Span, err error) {
+    if err != nil {
+        span.RecordError(err)
+        span.SetStatus(codes.Error, err.Error())
+    }
+    span.End()
+}
+
+// Inject returns ctx's trace context as a map that can travel with a
+// broker message or job payload. It is nil when ctx carries no trace.
+func Inject(ctx context.Context) map[string]string {
+    if !trace.SpanContextFromContext(ctx).IsValid() {
+        return nil
+    }
+    carrier := propagation.MapCarrier{}
+    otel.GetTextMapPropagator().Inject(ctx, carrier)
+    return carrier
+}
+
+// Extract returns ctx continuing the trace in carrier, as written by
+// Inject.
+func Extract(ctx context.Context, carrier map[string]string) context.Context {
+    if len(carrier) == 0 {
+        return ctx
+    }
+    return otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(carrier))
+}
@@ -0,0 +1,298 @@
+package unfurl
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "io"
+    "net"
+    "net/http"
+    "net/netip"
+    "net/url"
+    "regexp"
+    "strings"
+    "sync"
+    "syscall"
+    "time"
+
+    "golang.org/x/net/html"
+
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+const (
+    maxBodySize    = 512 * 1024
+    maxRedirects   = 3
+    maxCacheSize   = 10000
+    maxURLsPerText = 3
+)
+
+var (
+    ErrBlockedAddress = errors.New("destination address is not allowed")
+    ErrNotHTML        = errors.New("response is not html")
+
+    urlPattern = regexp.MustCompile(`https?://[^\s<>"']+`)
+
+    // blockedPrefixes are the destinations link previews never reach:
+    // every range that is not public unicast, including those that route
+    // to internal networks through carrier NAT, benchmarking setups or a
+    // NAT64 gateway. IPv4-mapped IPv6 addresses are checked as IPv4.
+    blockedPrefixes = []netip.Prefix{
+        netip.MustParsePrefix("0.0.0.0/8"),       // "this network", unspecified
+        netip.MustParsePrefix("10.0.0.0/8"),      // private
+        netip.MustParsePrefix("100.64.0.0/10"),   // carrier-grade NAT
+        netip.MustParsePrefix("127.0.0.0/8"),     // loopback
+        netip.MustParsePrefix("169.254.0.0/16"),  // link-local, cloud metadata
+        netip.MustParsePrefix("172.16.0.0/12"),   // private
+        netip.MustParsePrefix("192.0.0.0/24"),    // protocol assignments
+        netip.MustParsePrefix("192.168.0.0/16"),  // private
+        netip.MustParsePrefix("198.18.0.0/15"),   // benchmarking
+        netip.MustParsePrefix("224.0.0.0/4"),     // multicast
+        netip.MustParsePrefix("240.0.0.0/4"),     // reserved, broadcast
+        netip.MustParsePrefix("::/128"),          // unspecified
+        netip.MustParsePrefix("::1/128"),         // loopback
+        netip.MustParsePrefix("64:ff9b::/96"),    // NAT64
+        netip.MustParsePrefix("64:ff9b:1::/48"),  // local-use NAT64
+        netip.MustParsePrefix("fc00::/7"),        // unique local
+        netip.MustParsePrefix("fe80::/10"),       // link-local
+        netip.MustParsePrefix("ff00::/8"),        // multicast
+    }
+)
+
+type cacheEntry struct {
+    preview   *models.LinkPreview
+    expiresAt time.Time
+}
+
+// Service fetches OpenGraph metadata for links posted in chat. Requests
+// only go to public addresses: the dialer checks the resolved IP against
+// blockedPrefixes, so redirects and DNS rebinding cannot reach internal
+// services.
+type Service struct {
+    client   *http.Client
+    cacheTTL time.Duration
+
+    mu    sync.Mutex
+    cache map[string]cacheEntry
+}
+
+func NewService(timeout, cacheTTL time.Duration) *Service {
+    dialer := &net.Dialer{
+        Timeout: timeout,
+        Control: dialControl,
+    }
+
+    return &Service{
+        client: &http.Client{
+            Timeout: timeout,
+            Transport: &http.Transport{
+                DialContext:         dialer.DialContext,
+                Proxy:               nil,
+                TLSHandshakeTimeout: timeout,
+                MaxIdleConns:        50,
+                IdleConnTimeout:     90 * time.Second,
+            },
+            CheckRedirect: func(req *http.Request, via []*http.Request) error {
+                if len(via) >= maxRedirects {
+                    return errors.New("too many redirects")
+                }
+                return checkURL(req.URL)
+            },
+        },
+        cacheTTL: cacheTTL,
+        cache:    make(map[string]cacheEntry),
+    }
+}
+
+// ExtractURLs returns the distinct http(s) links in a message, capped so a
+// single message cannot trigger a burst of fetches.
+func ExtractURLs(content string) []string {
+    seen := make(map[string]bool)
+    var urls []string
+    for _, u := range urlPattern.FindAllString(content, -1) {
+        u = strings.TrimRight(u, ".,;:!?)")
+        if seen[u] {
+            continue
+        }
+        seen[u] = true
+        urls = append(urls, u)
+        if len(urls) == maxURLsPerText {
+            break
+        }
+    }
+    return urls
+}
+
+func (s *Service) Unfurl(ctx context.Context, rawURL string) (*models.LinkPreview, error) {
+    if preview, ok := s.cached(rawURL); ok {
+        return preview, nil
+    }
+
+    u, err := url.Parse(rawURL)
+    if err != nil {
+        return nil, err
+    }
+    if err := checkURL(u); err != nil {
+        return nil, err
+    }
+
+    req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+    if err != nil {
+        return nil, err
+    }
+    req.Header.Set("User-Agent", "SportsChatBot/1.0 (+link preview)")
+    req.Header.Set("Accept", "text/html")
+
+    resp, err := s.client.Do(req)
+    if err != nil {
+        return nil, fmt.Errorf("failed to fetch %s: %w", u.Host, err)
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode != http.StatusOK {
+        return nil, fmt.Errorf("fetching %s returned status %d", u.Host, resp.StatusCode)
+    }
+    if !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/html") {
+        return nil, ErrNotHTML
+    }
+
+    preview := parseOpenGraph(io.LimitReader(resp.Body, maxBodySize))
+    preview.URL = rawURL
+    if preview.SiteName == "" {
+        preview.SiteName = u.Hostname()
+    }
+
+    s.store(rawURL, preview)
+    return preview, nil
+}
+
+func (s *Service) cached(rawURL string) (*models.LinkPreview, bool) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    entry, ok := s.cache[rawURL]
+    if !ok || time.Now().After(entry.expiresAt) {
+        return nil, false
+    }
+    return entry.preview, true
+}
+
+func (s *Service) store(rawURL string, preview *models.LinkPreview) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    if len(s.cache) >= maxCacheSize {
+        now := time.Now()
+        for key, entry := range s.cache {
+            if now.After(entry.expiresAt) || len(s.cache) >= maxCacheSize {
+                delete(s.cache, key)
+            }
+        }
+    }
+    s.cache[rawURL] = cacheEntry{preview: preview, expiresAt: time.Now().Add(s.cacheTTL)}
+}
+
+// dialControl refuses connections to blocked addresses. It runs after
+// resolution, on the address actually dialed.
+func dialControl(network, address string, _ syscall.RawConn) error {
+    addrPort, err := netip.ParseAddrPort(address)
+    if err != nil || isBlocked(addrPort.Addr()) {
+        return ErrBlockedAddress
+    }
+    return nil
+}
+
+func checkURL(u *url.URL) error {
+    if u.Scheme != "http" && u.Scheme != "https" {
+        return fmt.Errorf("unsupported scheme %q", u.Scheme)
+    }
+    if port := u.Port(); port != "" && port != "80" && port != "443" {
+        return ErrBlockedAddress
+    }
+    if u.User != nil {
+        return ErrBlockedAddress
+    }
+    return nil
+}
+
+func isBlocked(addr netip.Addr) bool {
+    // Zoned addresses never match a prefix
+    addr = addr.Unmap().WithZone("")
+    for _, prefix := range blockedPrefixes {
+        if prefix.Contains(addr) {
+            return true
+        }
+    }
+    return false
+}
+
+func parseOpenGraph(r io.Reader) *models.LinkPreview {
+    preview := &models.LinkPreview{}
+    tokenizer := html.NewTokenizer(r)
+    var inTitle bool
+    var title string
+
+    for {
+        switch tokenizer.Next() {
+        case html.ErrorToken:
+            if preview.Title == "" {
+                preview.Title = strings.TrimSpace(title)
+            }
+            return preview
+
+        case html.StartTagToken, html.SelfClosingTagToken:
+            token := tokenizer.Token()
+            switch token.Data {
+            case "title":
+                inTitle = true
+            case "meta":
+                applyMeta(preview, token.Attr)
+            case "body":
+                // OpenGraph tags live in the head; stop early.
+                if preview.Title == "" {
+                    preview.Title = strings.TrimSpace(title)
+                }
+                return preview
+            }
+
+        case html.TextToken:
+            if inTitle {
+                title += string(tokenizer.Text())
+            }
+
+        case html.EndTagToken:
+            if tokenizer.Token().Data == "title" {
+                inTitle = false
+            }
+        }
+    }
+}
+
+func applyMeta(preview *models.LinkPreview, attrs []html.Attribute) {
+    var key, content string
+    for _, attr := range attrs {
+        switch attr.Key {
+        case "property", "name":
+            key = strings.ToLower(attr.Val)
+        case "content":
+            content = strings.TrimSpace(attr.Val)
+        }
+    }
+
+    switch key {
+    case "og:title":
+        preview.Title = content
+    case "og:description":
+        preview.Description = content
+    case "description":
+        if preview.Description == "" {
+            preview.Description = content
+        }
+    case "og:image":
+        if strings.HasPrefix(content, "https://") {
+            preview.ImageURL = content
+        }
+    case "og:site_name":
+        preview.SiteName = content
+    }
+}
@@ -0,0 +1,320 @@
+// Package userstats tracks how fans take part in each match: what they
+// post in its room, the reactions their messages get and how their
+// prediction scores. It ranks them on per-match and season leaderboards
+// and sends match rooms the changes to their leaderboard as the game
+// goes on, so fans can compete while they watch.
+package userstats
+
+import (
+    "context"
+    "encoding/json"
+    "sync"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/events"
+    "github.com/yourusername/sports-chat/internal/metrics"
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/store"
+)
+
+// roomCacheTTL bounds how long a room's match is remembered. Rooms keep
+// their match, so this only lets unused rooms fall out.
+const roomCacheTTL = 10 * time.Minute
+
+// Announcer sends a frame to every client in a room, on every instance.
+// The websocket hub implements it.
+type Announcer interface {
+    Announce(room string, message *models.WSMessage)
+}
+
+// Delta is the data of a leaderboard frame: the entries of the room's
+// top that are new or changed since the last frame, in rank order, and
+// the users who dropped out of it. Entries are absolute, so a client
+// applying a frame twice ends up where it was.
+type Delta struct {
+    MatchID string                     `json:"match_id"`
+    Entries []*models.LeaderboardEntry `json:"entries"`
+    Removed []string                   `json:"removed,omitempty"`
+}
+
+type statsKey struct {
+    matchID string
+    userID  string
+}
+
+type roomMatch struct {
+    matchID  string
+    loadedAt time.Time
+}
+
+// Tracker counts engagement from domain events and writes it every
+// interval. Each instance writes what its own users did; the rooms of the
+// matches it wrote to are then sent their leaderboard's changes.
+type Tracker struct {
+    store     store.Store
+    announcer Announcer
+    interval  time.Duration
+    size      int
+    metrics   *metrics.Metrics
+    logger    *zap.Logger
+    events    chan events.Event
+
+    mu      sync.Mutex
+    pending map[statsKey]*models.UserMatchStats
+
+    // Owned by the worker goroutine
+    rooms      map[string]roomMatch
+    matchRooms map[string]string
+    boards     map[string][]*models.LeaderboardEntry
+
+    stop chan struct{}
+    done chan struct{}
+}
+
+// NewTracker returns a tracker that keeps match rooms up to date with the
+// top size users of their leaderboard.
+func NewTracker(store store.Store, announcer Announcer, interval time.Duration, size int, metrics *metrics.Metrics, logger *zap.Logger) *Tracker {
+    return &Tracker{
+        store:      store,
+        announcer:  announcer,
+        interval:   interval,
+        size:       size,
+        metrics:    metrics,
+        logger:     logger,
+        events:     make(chan events.Event, 4096),
+        pending:    make(map[statsKey]*models.UserMatchStats),
+        rooms:      make(map[string]roomMatch),
+        matchRooms: make(map[string]string),
+        boards:     make(map[string][]*models.LeaderboardEntry),
+        stop:       make(chan struct{}),
+        done:       make(chan struct{}),
+    }
+}
+
+// Start subscribes the tracker to the events it counts and starts its
+// worker.
+func (t *Tracker) Start(bus *events.Bus) {
+    for _, eventType := range []string{
+        events.TypeMessageSent,
+        events.TypeReactionChanged,
+        events.TypePredictionScored,
+    } {
+        bus.Subscribe(eventType, t.enqueue)
+    }
+
+    go t.run()
+}
+
+// Stop counts the events still queued, writes them and stops the worker.
+func (t *Tracker) Stop() {
+    close(t.stop)
+    <-t.done
+}
+
+func (t *Tracker) enqueue(event events.Event) {
+    select {
+    case t.events <- event:
+    default:
+        t.metrics.StatsDropped.Inc()
+    }
+}
+
+func (t *Tracker) run() {
+    defer close(t.done)
+
+    ticker := time.NewTicker(t.interval)
+    defer ticker.Stop()
+
+    for {
+        select {
+        case event := <-t.events:
+            t.count(event)
+        case <-ticker.C:
+            t.flush()
+        case <-t.stop:
+            t.drain()
+            t.flush()
+            return
+        }
+    }
+}
+
+func (t *Tracker) drain() {
+    for {
+        select {
+        case event := <-t.events:
+            t.count(event)
+        default:
+            return
+        }
+    }
+}
+
+// count adds an event to the pending deltas. Only match rooms count, and
+// reactions to one's own messages do not.
+func (t *Tracker) count(event events.Event) {
+    switch e := event.(type) {
+    case events.MessageSent:
+        if matchID := t.roomMatch(e.RoomID); matchID != "" {
+            t.add(matchID, e.UserID, func(s *models.UserMatchStats) { s.Messages++ })
+        }
+    case events.ReactionChanged:
+        if e.AuthorID == "" || e.AuthorID == e.UserID {
+            return
+        }
+        if matchID := t.roomMatch(e.RoomID); matchID != "" {
+            delta := 1
+            if e.Removed {
+                delta = -1
+            }
+            t.add(matchID, e.AuthorID, func(s *models.UserMatchStats) { s.ReactionsReceived += delta })
+        }
+    case events.PredictionScored:
+        if !e.Correct {
+            return
+        }
+        t.add(e.MatchID, e.UserID, func(s *models.UserMatchStats) {
+            s.CorrectPredictions++
+            s.PredictionPoints += e.Points
+        })
+    }
+}
+
+func (t *Tracker) add(matchID, userID string, apply func(*models.UserMatchStats)) {
+    key := statsKey{matchID: matchID, userID: userID}
+    t.mu.Lock()
+    defer t.mu.Unlock()
+    stats, ok := t.pending[key]
+    if !ok {
+        stats = &models.UserMatchStats{MatchID: matchID, UserID: userID}
+        t.pending[key] = stats
+    }
+    apply(stats)
+}
+
+// roomMatch returns the match a room is for, or "" for rooms without one.
+func (t *Tracker) roomMatch(roomID string) string {
+    if cached, ok := t.rooms[roomID]; ok && time.Since(cached.loadedAt) < roomCacheTTL {
+        return cached.matchID
+    }
+
+    ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+    defer cancel()
+
+    room, err := t.store.GetChatRoom(ctx, roomID)
+    if err != nil {
+        t.logger.Warn("Failed to load room for stats", zap.Error(err), zap.String("room", roomID))
+        return ""
+    }
+    t.rooms[roomID] = roomMatch{matchID: room.MatchID, loadedAt: time.Now()}
+    if room.MatchID != "" {
+        t.matchRooms[room.MatchID] = roomID
+    }
+    return room.MatchID
+}
+
+// flush writes the pending deltas and sends the rooms of the matches they
+// touched what changed on their leaderboard. Deltas that fail to write
+// are dropped rather than retried, as the leaderboards are for fun.
+func (t *Tracker) flush() {
+    t.mu.Lock()
+    pending := t.pending
+    t.pending = make(map[statsKey]*models.UserMatchStats)
+    t.mu.Unlock()
+    if len(pending) == 0 {
+        return
+    }
+
+    ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+    defer cancel()
+
+    deltas := make([]*models.UserMatchStats, 0, len(pending))
+    matches := make(map[string]bool)
+    for key, stats := range pending {
+        deltas = append(deltas, stats)
+        matches[key.matchID] = true
+    }
+    if err := t.store.AddUserMatchStats(ctx, deltas); err != nil {
+        t.logger.Error("Failed to write user match stats", zap.Error(err), zap.Int("deltas", len(deltas)))
+        return
+    }
+    t.metrics.StatsDeltasWritten.Add(float64(len(deltas)))
+
+    for matchID := range matches {
+        t.announceBoard(ctx, matchID)
+    }
+    t.sweep()
+}
+
+func (t *Tracker) announceBoard(ctx context.Context, matchID string) {
+    room, ok := t.matchRooms[matchID]
+    if !ok {
+        chatRoom, err := t.store.GetMatchChatRoom(ctx, matchID)
+        if err != nil {
+            return
+        }
+        room = chatRoom.ID
+        t.matchRooms[matchID] = room
+    }
+
+    board, err := t.store.GetMatchLeaderboard(ctx, matchID, t.size)
+    if err != nil {
+        t.logger.Warn("Failed to get match leaderboard", zap.Error(err), zap.String("match_id", matchID))
+        return
+    }
+    delta := Diff(t.boards[matchID], board)
+    t.boards[matchID] = board
+    if len(delta.Entries) == 0 && len(delta.Removed) == 0 {
+        return
+    }
+    delta.MatchID = matchID
+
+    data, err := json.Marshal(delta)
+    if err != nil {
+        return
+    }
+    t.announcer.Announce(room, &models.WSMessage{
+        Type:      models.MessageTypeLeaderboard,
+        ChatRoom:  room,
+        Data:      data,
+        Timestamp: time.Now(),
+    })
+}
+
+// sweep forgets rooms not looked up for a while, with the boards of their
+// matches, so finished matches do not pile up.
+func (t *Tracker) sweep() {
+    for roomID, cached := range t.rooms {
+        if time.Since(cached.loadedAt) < roomCacheTTL {
+            continue
+        }
+        delete(t.rooms, roomID)
+        if cached.matchID != "" {
+            delete(t.matchRooms, cached.matchID)
+            delete(t.boards, cached.matchID)
+        }
+    }
+}
+
+// Diff returns the entries of next that are new or changed since prev,
+// and the users of prev missing from next.
+func Diff(prev, next []*models.LeaderboardEntry) *Delta {
+    old := make(map[string]*models.LeaderboardEntry, len(prev))
+    for _, entry := range prev {
+        old[entry.UserID] = entry
+    }
+
+    delta := &Delta{Entries: []*models.LeaderboardEntry{}}
+    for _, entry := range next {
+        if was, ok := old[entry.UserID]; !ok || *was != *entry {
+            delta.Entries = append(delta.Entries, entry)
+        }
+        delete(old, entry.UserID)
+    }
+    for userID := range old {
+        delta.Removed = append(delta.Removed, userID)
+    }
+    return delta
+}
@@ -0,0 +1,95 @@
+package websocket
+
+import (
+    "encoding/json"
+    "time"
+
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+const (
+    // maxClientMsgIDLength bounds the client-generated message ID.
+    maxClientMsgIDLength = 64
+    // ackTTL is how long a client message ID is remembered; retries
+    // after it are treated as new messages.
+    ackTTL = 5 * time.Minute
+)
+
+type ackKey struct {
+    userID      string
+    clientMsgID string
+}
+
+// sentAck is the acknowledgement of an accepted chat message, kept so a
+// retry of the same message is acknowledged again rather than posted
+// twice.
+type sentAck struct {
+    room      string
+    messageID string
+    seq       int64
+    timestamp time.Time
+}
+
+// acknowledged reports whether the message is a retry of one already
+// accepted, and if so acknowledges it again. Retries are recognized per
+// instance; a retry on a different instance posts again.
+func (h *Hub) acknowledged(message *models.WSMessage) bool {
+    if message.ClientMsgID == "" {
+        return false
+    }
+    h.ackMu.Lock()
+    h.pruneAcks()
+    ack, ok := h.acks[ackKey{message.User.ID, message.ClientMsgID}]
+    h.ackMu.Unlock()
+    if !ok {
+        return false
+    }
+    h.sendAck(message.User.ID, message.ClientMsgID, ack)
+    return true
+}
+
+// acknowledge records an accepted chat message and tells the sender's
+// connections its server ID, sequence and timestamp.
+func (h *Hub) acknowledge(message *models.WSMessage) {
+    if message.ClientMsgID == "" {
+        return
+    }
+    ack := &sentAck{room: message.ChatRoom, messageID: message.ID, seq: message.Seq, timestamp: message.Timestamp}
+    h.ackMu.Lock()
+    h.acks[ackKey{message.User.ID, message.ClientMsgID}] = ack
+    h.ackMu.Unlock()
+    h.sendAck(message.User.ID, message.ClientMsgID, ack)
+}
+
+func (h *Hub) sendAck(userID, clientMsgID string, ack *sentAck) {
+    payload, err := json.Marshal(&models.WSMessage{
+        ID:          ack.messageID,
+        Type:        models.MessageTypeAck,
+        ChatRoom:    ack.room,
+        ClientMsgID: clientMsgID,
+        Seq:         ack.seq,
+        Timestamp:   ack.timestamp,
+    })
+    if err != nil {
+        return
+    }
+    h.eachUserClient(userID, func(client *Client) {
+        client.trySend(payload)
+    })
+}
+
+// pruneAcks forgets expired acknowledgements, at most once a minute. The
+// caller holds ackMu.
+func (h *Hub) pruneAcks() {
+    now := time.Now()
+    if now.Sub(h.acksPrunedAt) < time.Minute {
+        return
+    }
+    h.acksPrunedAt = now
+
+    for key, ack := range h.acks {
+        if now.Sub(ack.timestamp) > ackTTL {
+            delete(h.acks, key)
+        }
+    }
+}
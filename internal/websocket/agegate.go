@@ -0,0 +1,66 @@
+package websocket
+
+import (
+    "encoding/json"
+    "time"
+
+    "github.com/yourusername/sports-chat/internal/broker"
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+// ageGate is the payload of an age_gate frame.
+type ageGate struct {
+    MinAge      int  `json:"min_age"`
+    AgeVerified bool `json:"age_verified"`
+}
+
+// AgeGateChanged tells a room's clients on every instance that its age
+// gate changed. Every instance then removes members who no longer meet
+// it; admins stay.
+func (h *Hub) AgeGateChanged(room *models.ChatRoom) {
+    data, err := json.Marshal(&ageGate{MinAge: room.MinAge, AgeVerified: room.AgeVerified})
+    if err != nil {
+        return
+    }
+    payload, err := json.Marshal(&models.WSMessage{
+        Type:      models.MessageTypeAgeGate,
+        ChatRoom:  room.ID,
+        Data:      data,
+        Timestamp: time.Now(),
+    })
+    if err != nil {
+        return
+    }
+
+    if h.journal != nil {
+        h.journal.Append(room.ID, payload)
+    }
+    h.publish(&broker.Message{Room: room.ID, Payload: payload, RoomChanged: true, AgeGated: true, Priority: PriorityHigh})
+}
+
+// UserAgeChanged applies a change to the user's birth date, verification
+// or restricted mode. Their connections are closed on every instance and
+// pick the change up when they reconnect, gated rooms included.
+func (h *Hub) UserAgeChanged(userID string) {
+    h.DisconnectUser(userID)
+}
+
+// enforceAgeGate removes this instance's members of the room who are not
+// old enough for it.
+func (h *Hub) enforceAgeGate(room string) {
+    settings := h.roomSettings(room)
+    now := time.Now()
+
+    var turnedAway []*Client
+    h.eachRoomClient(room, func(client *Client) {
+        if !client.principal.IsAdmin && !settings.Admits(client.user, now) {
+            turnedAway = append(turnedAway, client)
+        }
+    })
+    for _, client := range turnedAway {
+        h.removeFromRoom(room, client)
+        client.mu.Lock()
+        delete(client.rooms, room)
+        client.mu.Unlock()
+    }
+}
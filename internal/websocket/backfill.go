@@ -0,0 +1,89 @@
+package websocket
+
+import (
+    "context"
+    "fmt"
+    "strconv"
+    "strings"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/store"
+)
+
+// nextSeq numbers a chat message in its room. Messages the sequencer
+// cannot number go out without a sequence rather than not at all;
+// clients cannot backfill past them.
+func (h *Hub) nextSeq(ctx context.Context, room string) int64 {
+    ctx, cancel := context.WithTimeout(ctx, time.Second)
+    defer cancel()
+
+    seq, err := h.sequencer.Next(ctx, room)
+    if err != nil {
+        h.logger.Warn("Failed to sequence message",
+            zap.Error(err),
+            zap.String("room", room))
+        return 0
+    }
+    return seq
+}
+
+// initialPage loads the messages a client is sent for a room on connect.
+// A client reconnecting with the last sequence it saw gets the messages
+// after it, unless the gap is longer than a history page; otherwise, and
+// for new connections, it gets the room's recent messages. Messages
+// still being persisted when the client reconnects may be missed, and
+// ones delivered live meanwhile may arrive twice; clients drop sequences
+// they already have.
+func (h *Hub) initialPage(ctx context.Context, client *Client, room string) (*HistoryPage, error) {
+    if since, ok := client.since[room]; ok {
+        messages, err := h.store.GetMessagesAfterSeq(ctx, room, since, MaxHistoryPage+1)
+        if err != nil {
+            return nil, fmt.Errorf("failed to get missed messages: %w", err)
+        }
+        if len(messages) <= MaxHistoryPage {
+            if err := LoadReactions(ctx, h.store, messages); err != nil {
+                return nil, fmt.Errorf("failed to load reactions: %w", err)
+            }
+            page := &HistoryPage{Messages: HistoryMessages(messages), SinceSeq: since}
+            page.Returned = len(page.Messages)
+            return page, nil
+        }
+    }
+    if page, ok := h.warmHistory(room); ok {
+        h.metrics.PrewarmHits.WithLabelValues("history").Inc()
+        return page, nil
+    }
+    return LoadHistoryPage(ctx, h.store, room, store.MessageFilter{}, "", "", h.initialHistoryBudget(room))
+}
+
+// parseSinceSeq reads the since_seq connect parameter: "room:seq" pairs
+// separated by commas, or a bare sequence when connecting to one room.
+// Malformed entries and rooms not being joined are ignored.
+func parseSinceSeq(raw string, rooms map[string]bool) map[string]int64 {
+    var only string
+    if len(rooms) == 1 {
+        for room := range rooms {
+            only = room
+        }
+    }
+
+    since := make(map[string]int64)
+    for _, entry := range strings.Split(raw, ",") {
+        if entry = strings.TrimSpace(entry); entry == "" {
+            continue
+        }
+
+        room, value, paired := strings.Cut(entry, ":")
+        if !paired {
+            room, value = only, room
+        }
+        seq, err := strconv.ParseInt(value, 10, 64)
+        if err != nil || seq < 0 || !rooms[room] {
+            continue
+        }
+        since[room] = seq
+    }
+    return since
+}
@@ -0,0 +1,122 @@
+package websocket
+
+import (
+    "encoding/json"
+    "fmt"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+// Slow consumer policies, for connections whose send buffer is full.
+const (
+    // SlowConsumerDisconnect warns the connection and closes it; the
+    // client reconnects and catches up from its last sequence
+    SlowConsumerDisconnect = "disconnect"
+    // SlowConsumerDropOldest drops the oldest queued frames to make room,
+    // keeping the connection open
+    SlowConsumerDropOldest = "drop_oldest"
+)
+
+// DefaultSendBuffer is how many frames a connection queues when the hub's
+// options leave it unset.
+const DefaultSendBuffer = 256
+
+// dropOldestAttempts bounds how many queued frames one send drops before
+// dropping its own, so concurrent senders cannot spin against each other.
+const dropOldestAttempts = 4
+
+// ValidSlowConsumerPolicy reports whether policy is one of the slow
+// consumer policies.
+func ValidSlowConsumerPolicy(policy string) bool {
+    return policy == SlowConsumerDisconnect || policy == SlowConsumerDropOldest
+}
+
+// push queues a frame, applying the hub's slow consumer policy when the
+// buffer is full. It reports whether the frame was queued. The caller
+// holds sendMu for reading with the connection open.
+func (c *Client) push(frame outFrame) bool {
+    select {
+    case c.send <- frame:
+        return true
+    default:
+    }
+
+    if c.hub.slowConsumerPolicy == SlowConsumerDropOldest {
+        for i := 0; i < dropOldestAttempts; i++ {
+            select {
+            case <-c.send:
+                c.dropFrames(1)
+            default:
+            }
+            select {
+            case c.send <- frame:
+                return true
+            default:
+            }
+        }
+        c.dropFrames(1)
+        return false
+    }
+
+    c.dropFrames(1)
+    c.disconnectSlow()
+    return false
+}
+
+// dropFrames counts frames the connection never got.
+func (c *Client) dropFrames(n int) {
+    c.dropped.Add(int64(n))
+    c.hub.metrics.WSDroppedFrames.WithLabelValues(c.hub.slowConsumerPolicy).Add(float64(n))
+}
+
+// disconnectSlow closes a connection that fell behind, once. The oldest
+// queued frame makes way for a warning, so the client learns why it was
+// closed; the frames before it are still written.
+func (c *Client) disconnectSlow() {
+    if !c.slow.CompareAndSwap(false, true) {
+        return
+    }
+
+    select {
+    case <-c.send:
+        c.dropFrames(1)
+    default:
+    }
+    if payload, ok := slowConsumerWarning(len(c.send)); ok {
+        select {
+        case c.send <- outFrame{payload: payload}:
+        default:
+        }
+    }
+
+    c.hub.metrics.WSSlowConsumers.Inc()
+    c.hub.logger.Info("Disconnecting slow websocket consumer",
+        zap.String("user_id", c.user.ID),
+        zap.String("conn_id", c.id),
+        zap.Int64("dropped", c.dropped.Load()))
+    go func() { c.hub.unregister <- c }()
+}
+
+func slowConsumerWarning(buffered int) ([]byte, bool) {
+    wsErr := &models.WSError{
+        Code:    models.ErrorSlowConsumer,
+        Message: fmt.Sprintf("Disconnected for falling %d messages behind; reconnect to catch up", buffered),
+    }
+    data, err := json.Marshal(wsErr)
+    if err != nil {
+        return nil, false
+    }
+    payload, err := json.Marshal(&models.WSMessage{
+        Type:      models.MessageTypeError,
+        Content:   wsErr.Message,
+        Data:      data,
+        Timestamp: time.Now(),
+    })
+    if err != nil {
+        return nil, false
+    }
+    return payload, true
+}
@@ -0,0 +1,100 @@
+package websocket
+
+import (
+    "bytes"
+    "net/http"
+    "strconv"
+    "time"
+
+    "github.com/gorilla/websocket"
+)
+
+const (
+    // CoalesceHeader carries the coalescing window, in milliseconds, the
+    // server granted on the handshake response; absent when the client
+    // asked for none or coalescing is disabled.
+    CoalesceHeader = "X-Coalesce-Window"
+
+    // A batch is written early once it holds this many frames or bytes
+    maxBatchFrames = 256
+    maxBatchBytes  = 64 << 10
+)
+
+// negotiateCoalesce reads the window a connecting client asks for with
+// ?coalesce=<ms>, capped at the hub's maximum, and records the grant on
+// the response headers.
+func (h *Hub) negotiateCoalesce(r *http.Request, header http.Header) time.Duration {
+    ms, err := strconv.Atoi(r.URL.Query().Get("coalesce"))
+    if err != nil || ms <= 0 || h.coalesceWindow <= 0 {
+        return 0
+    }
+    window := time.Duration(ms) * time.Millisecond
+    if window > h.coalesceWindow {
+        window = h.coalesceWindow
+    }
+    header.Set(CoalesceHeader, strconv.FormatInt(window.Milliseconds(), 10))
+    return window
+}
+
+// collectBatch gathers the frames that arrive within the client's window
+// after first, writing early once the batch is full. open is false if the
+// send channel closed meanwhile.
+func (c *Client) collectBatch(first outFrame) (frames []outFrame, open bool) {
+    frames = []outFrame{first}
+    size := len(first.payload)
+
+    timer := time.NewTimer(c.coalesce)
+    defer timer.Stop()
+    for len(frames) < maxBatchFrames && size < maxBatchBytes {
+        select {
+        case frame, ok := <-c.send:
+            if !ok {
+                return frames, false
+            }
+            frames = append(frames, frame)
+            size += len(frame.payload)
+        case <-timer.C:
+            return frames, true
+        }
+    }
+    return frames, true
+}
+
+// writeBatch writes coalesced frames as one websocket message, a batch
+// envelope whose data is the frames in order:
+//
+//  {"type":"batch","data":[frame, frame, ...]}
+//
+// A lone frame is written as it is, so clients handle both.
+func (c *Client) writeBatch(frames []outFrame) error {
+    c.hub.metrics.WSBatchFrames.Observe(float64(len(frames)))
+    if len(frames) == 1 && frames[0].prepared != nil {
+        return c.writePrepared(frames[0])
+    }
+
+    var payload []byte
+    if len(frames) == 1 {
+        payload = frames[0].payload
+    } else {
+        var buf bytes.Buffer
+        buf.WriteString(`{"type":"batch","data":[`)
+        for i, frame := range frames {
+            if i > 0 {
+                buf.WriteByte(',')
+            }
+            buf.Write(frame.payload)
+        }
+        buf.WriteString(`]}`)
+        payload = buf.Bytes()
+    }
+
+    compressed := c.compresses(len(payload))
+    c.conn.EnableWriteCompression(compressed)
+    if err := c.conn.WriteMessage(websocket.TextMessage, payload); err != nil {
+        return err
+    }
+    if compressed {
+        c.hub.metrics.WSCompressedMessages.WithLabelValues("false").Inc()
+    }
+    return nil
+}
@@ -0,0 +1,100 @@
+package websocket
+
+import (
+    "encoding/json"
+    "net/http"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/broker"
+    "github.com/yourusername/sports-chat/internal/commentary"
+    "github.com/yourusername/sports-chat/internal/locale"
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+// clientLocale is the language a connection asked for match commentary
+// in: the locale query parameter, or else the user's profile locale, or
+// else its Accept-Language header.
+func clientLocale(r *http.Request, user *models.User) string {
+    if lang := r.URL.Query().Get("locale"); lang != "" {
+        return locale.Normalize(lang)
+    }
+    if user.Locale != "" {
+        return locale.Normalize(user.Locale)
+    }
+    return commentary.PreferredLocale(r.Header.Get("Accept-Language"))
+}
+
+// sendMatchEvent sends a match event to its room, to this instance's
+// clients only when local, with the description rendered in each
+// client's language on delivery. The frame published and journaled keeps
+// the provider's description.
+func (h *Hub) sendMatchEvent(room string, event *models.MatchEvent, local bool) {
+    payload, err := json.Marshal(&models.WSMessage{
+        Type:      models.MessageTypeEvent,
+        ChatRoom:  room,
+        Event:     event,
+        Timestamp: time.Now(),
+    })
+    if err != nil {
+        h.logger.Error("Failed to marshal match event", zap.Error(err), zap.String("room", room))
+        return
+    }
+
+    if h.journal != nil {
+        h.journal.Append(room, payload)
+    }
+    msg := &broker.Message{Room: room, Payload: payload, Localize: true, Priority: PriorityHigh}
+    if local {
+        h.fanout.enqueue(msg)
+    } else {
+        h.publish(msg)
+    }
+}
+
+// localizedFrames renders one match event frame per template locale for
+// a delivery, so clients sharing a language share a payload.
+type localizedFrames struct {
+    hub      *Hub
+    message  models.WSMessage
+    match    *models.Match
+    rendered map[string][]byte
+}
+
+// localize prepares a delivery's frames for each client's language, or
+// returns nil when the frame is to go out as published.
+func (h *Hub) localize(msg *broker.Message) *localizedFrames {
+    if !msg.Localize || h.commentary == nil {
+        return nil
+    }
+    frames := &localizedFrames{hub: h, rendered: make(map[string][]byte)}
+    if err := json.Unmarshal(msg.Payload, &frames.message); err != nil || frames.message.Event == nil {
+        return nil
+    }
+    frames.rendered[""] = msg.Payload
+
+    h.matchMu.RLock()
+    frames.match = h.matches[msg.Room]
+    h.matchMu.RUnlock()
+    return frames
+}
+
+// payload returns the frame for a client in locale.
+func (f *localizedFrames) payload(locale string) []byte {
+    resolved := f.hub.commentary.Resolve(locale, f.message.Event.EventType)
+    if payload, ok := f.rendered[resolved]; ok {
+        return payload
+    }
+
+    event := *f.message.Event
+    event.Description = f.hub.commentary.Render(resolved, &event, f.match)
+    message := f.message
+    message.Event = &event
+    payload, err := json.Marshal(&message)
+    if err != nil {
+        payload = f.rendered[""]
+    }
+    f.rendered[resolved] = payload
+    return payload
+}
@@ -0,0 +1,80 @@
+package websocket
+
+import (
+    "net/http"
+    "strings"
+
+    "github.com/gorilla/websocket"
+    "go.uber.org/zap"
+)
+
+// Defaults for permessage-deflate. Level 1 is flate's best speed, which
+// gets most of the saving on JSON; frames under the threshold are sent
+// as they are, since deflate framing would barely shrink them.
+const (
+    defaultCompressionLevel     = 1
+    defaultCompressionThreshold = 256
+)
+
+// compression is how the hub compresses outbound frames.
+type compression struct {
+    enabled   bool
+    level     int
+    threshold int
+}
+
+// outFrame is a queued outbound frame. Large room frames sent to
+// compressing connections carry a prepared message, built once per room
+// frame, which compresses the payload once for all of its recipients.
+// Sequenced room frames carry their room and sequence, which resumable
+// connections track as they are written.
+type outFrame struct {
+    payload  []byte
+    prepared *websocket.PreparedMessage
+    room     string
+    seq      int64
+}
+
+// offersDeflate reports whether a handshake offers permessage-deflate.
+// The upgrader accepts the extension whenever it is offered and
+// compression is enabled, so this is also whether it was negotiated.
+func offersDeflate(header http.Header) bool {
+    for _, value := range header.Values("Sec-WebSocket-Extensions") {
+        for _, ext := range strings.Split(value, ",") {
+            name, _, _ := strings.Cut(ext, ";")
+            if strings.EqualFold(strings.TrimSpace(name), "permessage-deflate") {
+                return true
+            }
+        }
+    }
+    return false
+}
+
+// compresses reports whether a payload of n bytes is worth compressing for
+// the client.
+func (c *Client) compresses(n int) bool {
+    return c.compress && n >= c.hub.compression.threshold
+}
+
+// prepare builds the shared prepared message for a room frame, or nil if
+// it cannot, in which case each recipient compresses its own copy.
+func (h *Hub) prepare(payload []byte) *websocket.PreparedMessage {
+    prepared, err := websocket.NewPreparedMessage(websocket.TextMessage, payload)
+    if err != nil {
+        h.logger.Warn("Failed to prepare frame", zap.Error(err))
+        return nil
+    }
+    h.metrics.WSPreparedFrames.Inc()
+    return prepared
+}
+
+// writePrepared writes a room frame compressed once for all of its
+// recipients.
+func (c *Client) writePrepared(message outFrame) error {
+    c.conn.EnableWriteCompression(true)
+    if err := c.conn.WritePreparedMessage(message.prepared); err != nil {
+        return err
+    }
+    c.hub.metrics.WSCompressedMessages.WithLabelValues("true").Inc()
+    return nil
+}
@@ -0,0 +1,116 @@
+package websocket
+
+import (
+    "context"
+    "encoding/json"
+    "strings"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/broker"
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/moderation"
+)
+
+// handleDM sends a direct message. It is delivered to every connection of
+// both participants, on any instance, and never to a room.
+func (c *Client) handleDM(msg *models.WSMessage) {
+    var req models.DirectMessageData
+    if err := json.Unmarshal(msg.Data, &req); err != nil || req.To == "" {
+        c.sendError(msg, models.ErrorInvalidRequest, "Invalid direct message")
+        return
+    }
+    content := strings.TrimSpace(msg.Content)
+    if content == "" {
+        c.sendError(msg, models.ErrorInvalidRequest, "Invalid direct message")
+        return
+    }
+    if req.To == c.user.ID {
+        c.sendError(msg, models.ErrorInvalidRequest, "You cannot message yourself")
+        return
+    }
+    if c.user.Restricted(msg.Timestamp) {
+        c.sendError(msg, models.ErrorForbidden, "Direct messages are off in restricted mode")
+        return
+    }
+
+    ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+    defer cancel()
+
+    recipient, err := c.hub.store.GetUser(ctx, req.To)
+    if err != nil || recipient.BannedAt != nil || recipient.DeactivatedAt != nil {
+        c.sendError(msg, models.ErrorNotFound, "User not found")
+        return
+    }
+    blocked, err := c.hub.store.IsBlocked(ctx, c.user.ID, recipient.ID)
+    if err != nil {
+        c.hub.logger.Error("Failed to check blocks", zap.Error(err), zap.String("user_id", c.user.ID))
+        c.sendError(msg, models.ErrorInternal, "Failed to send message")
+        return
+    }
+    // Restricted users cannot be messaged either
+    if blocked || recipient.Restricted(msg.Timestamp) {
+        c.sendError(msg, models.ErrorForbidden, "You cannot message this user")
+        return
+    }
+
+    result := c.hub.profanity.Check(moderation.DefaultLocale, content)
+    if result.Action == models.ActionBlock {
+        c.sendError(msg, models.ErrorContentBlocked, "Message blocked by content filter")
+        return
+    }
+
+    conversation, err := c.hub.store.GetOrCreateConversation(ctx, c.user.ID, recipient.ID)
+    if err != nil {
+        c.hub.logger.Error("Failed to get conversation", zap.Error(err), zap.String("user_id", c.user.ID))
+        c.sendError(msg, models.ErrorInternal, "Failed to send message")
+        return
+    }
+    dm := &models.DirectMessage{
+        ConversationID: conversation.ID,
+        SenderID:       c.user.ID,
+        Content:        result.Content,
+        CreatedAt:      msg.Timestamp,
+    }
+    if err := c.hub.store.CreateDirectMessage(ctx, dm); err != nil {
+        c.hub.logger.Error("Failed to save direct message", zap.Error(err), zap.String("user_id", c.user.ID))
+        c.sendError(msg, models.ErrorInternal, "Failed to send message")
+        return
+    }
+
+    data, err := json.Marshal(&models.DirectMessageData{To: recipient.ID, ConversationID: conversation.ID})
+    if err != nil {
+        return
+    }
+    payload, err := json.Marshal(&models.WSMessage{
+        ID:        dm.ID,
+        Type:      models.MessageTypeDM,
+        Content:   dm.Content,
+        User:      msg.User,
+        Data:      data,
+        Timestamp: dm.CreatedAt,
+    })
+    if err != nil {
+        return
+    }
+
+    // The sender's other connections get it too, so every open tab
+    // shows the conversation
+    c.hub.publish(&broker.Message{Users: []string{c.user.ID, recipient.ID}, Payload: payload, Priority: PriorityNormal})
+}
+
+// deliverToUsers writes a frame to every local connection of the users.
+func (h *Hub) deliverToUsers(msg *broker.Message) {
+    delivered := 0
+    for _, userID := range msg.Users {
+        h.eachUserClient(userID, func(client *Client) {
+            if client.enqueue(msg.Payload) {
+                delivered++
+            }
+        })
+    }
+    if delivered > 0 {
+        h.countOutbound(frameType(msg.Payload), roomSizeNone, len(msg.Payload), delivered)
+    }
+}
@@ -0,0 +1,159 @@
+package websocket
+
+import (
+    "context"
+    "encoding/json"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+// Drafts arrive on nearly every keystroke, so they are synced to the
+// user's other connections immediately but only written to the store once
+// the user pauses typing.
+const draftDebounce = 2 * time.Second
+
+type draftKey struct {
+    userID string
+    roomID string
+}
+
+type pendingDraft struct {
+    draft *models.Draft
+    timer *time.Timer
+}
+
+// handleDraft stores a draft frame from c and mirrors it to the user's
+// other connections. An empty draft clears it.
+func (c *Client) handleDraft(msg *models.WSMessage) {
+    draft := &models.Draft{
+        UserID:    c.user.ID,
+        RoomID:    msg.ChatRoom,
+        Content:   msg.Content,
+        UpdatedAt: time.Now(),
+    }
+    c.hub.saveDraft(draft)
+    c.hub.syncDraft(draft, c)
+}
+
+func (h *Hub) saveDraft(draft *models.Draft) {
+    key := draftKey{userID: draft.UserID, roomID: draft.RoomID}
+
+    h.draftsMu.Lock()
+    defer h.draftsMu.Unlock()
+
+    if pending, ok := h.drafts[key]; ok {
+        pending.draft = draft
+        pending.timer.Reset(draftDebounce)
+        return
+    }
+    h.drafts[key] = &pendingDraft{
+        draft: draft,
+        timer: time.AfterFunc(draftDebounce, func() { h.flushDraft(key) }),
+    }
+}
+
+func (h *Hub) flushDraft(key draftKey) {
+    h.draftsMu.Lock()
+    pending, ok := h.drafts[key]
+    if ok {
+        delete(h.drafts, key)
+    }
+    h.draftsMu.Unlock()
+    if !ok {
+        return
+    }
+
+    h.writeDraft(pending.draft)
+}
+
+func (h *Hub) writeDraft(draft *models.Draft) {
+    ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+    defer cancel()
+
+    var err error
+    if draft.Content == "" {
+        err = h.store.DeleteDraft(ctx, draft.UserID, draft.RoomID)
+    } else {
+        err = h.store.UpsertDraft(ctx, draft)
+    }
+    if err != nil {
+        h.logger.Error("Failed to save draft",
+            zap.Error(err),
+            zap.String("user_id", draft.UserID),
+            zap.String("room", draft.RoomID))
+    }
+}
+
+// FlushDrafts writes every pending draft immediately. It is called on
+// shutdown so debounced drafts are not lost.
+func (h *Hub) FlushDrafts() {
+    h.draftsMu.Lock()
+    pending := h.drafts
+    h.drafts = make(map[draftKey]*pendingDraft)
+    h.draftsMu.Unlock()
+
+    for _, p := range pending {
+        p.timer.Stop()
+        h.writeDraft(p.draft)
+    }
+}
+
+// clearDraft drops the user's draft for a room once the message is sent.
+func (h *Hub) clearDraft(userID, roomID string) {
+    draft := &models.Draft{UserID: userID, RoomID: roomID, UpdatedAt: time.Now()}
+    h.saveDraft(draft)
+    h.syncDraft(draft, nil)
+}
+
+// syncDraft sends a draft to every connection of its user except the one
+// it came from.
+func (h *Hub) syncDraft(draft *models.Draft, from *Client) {
+    payload, err := json.Marshal(draftMessage(draft))
+    if err != nil {
+        return
+    }
+
+    h.eachUserClient(draft.UserID, func(client *Client) {
+        if client != from {
+            client.trySend(payload)
+        }
+    })
+}
+
+// userDrafts returns the user's drafts keyed by room, preferring drafts
+// still waiting on the debounce over what is stored.
+func (h *Hub) userDrafts(ctx context.Context, userID string) map[string]*models.Draft {
+    drafts := make(map[string]*models.Draft)
+
+    stored, err := h.store.GetUserDrafts(ctx, userID)
+    if err != nil {
+        h.logger.Error("Failed to get drafts",
+            zap.Error(err),
+            zap.String("user_id", userID))
+    }
+    for _, d := range stored {
+        drafts[d.RoomID] = d
+    }
+
+    h.draftsMu.Lock()
+    for key, p := range h.drafts {
+        if key.userID == userID {
+            drafts[key.roomID] = p.draft
+        }
+    }
+    h.draftsMu.Unlock()
+
+    return drafts
+}
+
+func draftMessage(draft *models.Draft) *models.WSMessage {
+    return &models.WSMessage{
+        Type:      models.MessageTypeDraft,
+        ChatRoom:  draft.RoomID,
+        Content:   draft.Content,
+        Timestamp: draft.UpdatedAt,
+    }
+}
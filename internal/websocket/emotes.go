@@ -0,0 +1,135 @@
+package websocket
+
+import (
+    "context"
+    "encoding/json"
+    "strings"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/store"
+)
+
+const (
+    // DefaultEmoteSearch is the number of emotes a search returns when the
+    // client does not ask for fewer.
+    DefaultEmoteSearch = 20
+    maxEmoteSearch     = 50
+)
+
+// EmoteList is the payload of an emotes frame and of the REST emotes
+// endpoint: the packs a room can use or, for a search, the matching
+// emotes.
+type EmoteList struct {
+    Packs  []*models.EmotePack `json:"packs,omitempty"`
+    Emotes []*models.Emote     `json:"emotes,omitempty"`
+}
+
+// emotesRequest is the data of a client's emotes command. Without a query
+// it lists the room's packs.
+type emotesRequest struct {
+    Query string `json:"query"`
+    Limit int    `json:"limit"`
+}
+
+// LoadRoomEmotes lists the emotes a room can use: the site-wide packs and,
+// in a match room, both teams' packs. A query searches them by shortcode
+// prefix instead, ignoring a leading colon as typed in the composer.
+func LoadRoomEmotes(ctx context.Context, st store.Store, room *models.ChatRoom, query string, limit int) (*EmoteList, error) {
+    var teamIDs []string
+    if room.MatchID != "" {
+        match, err := st.GetMatch(ctx, room.MatchID)
+        if err != nil {
+            return nil, err
+        }
+        teamIDs = []string{match.HomeTeamID, match.AwayTeamID}
+    }
+
+    query = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(query), ":"))
+    if query == "" {
+        packs, err := st.GetPublishedEmotePacks(ctx, teamIDs)
+        if err != nil {
+            return nil, err
+        }
+        return &EmoteList{Packs: packs}, nil
+    }
+
+    if limit <= 0 || limit > maxEmoteSearch {
+        limit = DefaultEmoteSearch
+    }
+    emotes, err := st.SearchEmotes(ctx, teamIDs, query, limit)
+    if err != nil {
+        return nil, err
+    }
+    return &EmoteList{Emotes: emotes}, nil
+}
+
+// roomEmotes answers an emotes request, listing the packs of a room
+// readied for kickoff from memory.
+func (h *Hub) roomEmotes(ctx context.Context, room string, req emotesRequest) (*EmoteList, error) {
+    if strings.TrimPrefix(strings.TrimSpace(req.Query), ":") == "" {
+        if list, ok := h.warmEmotes(room); ok {
+            h.metrics.PrewarmHits.WithLabelValues("emotes").Inc()
+            return list, nil
+        }
+    }
+    return LoadRoomEmotes(ctx, h.store, h.roomSettings(room), req.Query, req.Limit)
+}
+
+// handleEmotes answers a client's emotes command with one emotes frame.
+func (c *Client) handleEmotes(msg *models.WSMessage) {
+    var req emotesRequest
+    if len(msg.Data) > 0 {
+        if err := json.Unmarshal(msg.Data, &req); err != nil {
+            c.sendError(msg, models.ErrorInvalidRequest, "Invalid emotes request")
+            return
+        }
+    }
+
+    ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+    defer cancel()
+
+    list, err := c.hub.roomEmotes(ctx, msg.ChatRoom, req)
+    if err != nil {
+        c.hub.logger.Error("Failed to load emotes",
+            zap.Error(err),
+            zap.String("room", msg.ChatRoom))
+        c.sendError(msg, models.ErrorInternal, "Failed to load emotes")
+        return
+    }
+
+    data, err := json.Marshal(list)
+    if err != nil {
+        return
+    }
+    payload, err := json.Marshal(&models.WSMessage{
+        Type:        models.MessageTypeEmotes,
+        ChatRoom:    msg.ChatRoom,
+        Data:        data,
+        ClientMsgID: msg.ClientMsgID,
+        Timestamp:   time.Now(),
+    })
+    if err != nil {
+        return
+    }
+
+    c.trySend(payload)
+}
+
+// EmotePackPublished tells every client on every instance that a pack was
+// published or republished with new emotes. The frame carries the whole
+// pack; clients whose rooms cannot use it, those of other teams, ignore
+// it.
+func (h *Hub) EmotePackPublished(pack *models.EmotePack) {
+    data, err := json.Marshal(pack)
+    if err != nil {
+        return
+    }
+    h.AnnounceEverywhere(&models.WSMessage{
+        Type:      models.MessageTypeEmotePack,
+        Data:      data,
+        Timestamp: time.Now(),
+    })
+}
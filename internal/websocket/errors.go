@@ -0,0 +1,41 @@
+package websocket
+
+import (
+    "encoding/json"
+    "time"
+
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+// sendError tells the client a message was refused. msg is the message,
+// if there was one, so the error can name it.
+func (c *Client) sendError(msg *models.WSMessage, code, content string) {
+    c.sendWSError(msg, &models.WSError{Code: code, Message: content})
+}
+
+// sendWSError sends an error frame. The frame's content stays the human
+// message, as older clients show it; the envelope is its data.
+func (c *Client) sendWSError(msg *models.WSMessage, wsErr *models.WSError) {
+    frame := &models.WSMessage{
+        Type:      models.MessageTypeError,
+        Content:   wsErr.Message,
+        Timestamp: time.Now(),
+    }
+    if msg != nil {
+        wsErr.MessageType = msg.Type
+        wsErr.ClientMsgID = msg.ClientMsgID
+        frame.ChatRoom = msg.ChatRoom
+        frame.ClientMsgID = msg.ClientMsgID
+    }
+    data, err := json.Marshal(wsErr)
+    if err != nil {
+        return
+    }
+    frame.Data = data
+
+    payload, err := json.Marshal(frame)
+    if err != nil {
+        return
+    }
+    c.trySend(payload)
+}
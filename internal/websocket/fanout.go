@@ -0,0 +1,161 @@
+package websocket
+
+import (
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/authctx"
+    "github.com/yourusername/sports-chat/internal/broker"
+    "github.com/yourusername/sports-chat/internal/metrics"
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+// Delivery priorities, highest first. Match events and moderator
+// messages must not queue behind a goal-time chat flood; presence and
+// typing frames are the first to wait.
+const (
+    PriorityHigh = iota
+    PriorityNormal
+    PriorityLow
+    numPriorities
+)
+
+var priorityNames = [numPriorities]string{"high", "normal", "low"}
+
+const (
+    defaultFanoutTick = 10 * time.Millisecond
+    fanoutQueueSize   = 4096
+)
+
+type delivery struct {
+    msg      *broker.Message
+    enqueued time.Time
+}
+
+// fanout delivers room frames in priority order. Under normal load every
+// queue drains as soon as frames arrive; during spikes each priority is
+// held to its per-tick budget, so high-priority frames keep flowing while
+// chat waits its turn, still in arrival order.
+type fanout struct {
+    queues  [numPriorities]chan *delivery
+    budgets [numPriorities]int
+    tick    time.Duration
+    notify  chan struct{}
+    deliver func(*broker.Message)
+    metrics *metrics.Metrics
+    logger  *zap.Logger
+}
+
+func newFanout(opts Options, deliver func(*broker.Message), metrics *metrics.Metrics, logger *zap.Logger) *fanout {
+    f := &fanout{
+        budgets: [numPriorities]int{
+            withDefault(opts.HighBudget, 1000),
+            withDefault(opts.NormalBudget, 200),
+            withDefault(opts.LowBudget, 50),
+        },
+        tick:    opts.FanoutTick,
+        notify:  make(chan struct{}, 1),
+        deliver: deliver,
+        metrics: metrics,
+        logger:  logger,
+    }
+    if f.tick <= 0 {
+        f.tick = defaultFanoutTick
+    }
+    for i := range f.queues {
+        f.queues[i] = make(chan *delivery, fanoutQueueSize)
+    }
+    return f
+}
+
+func withDefault(v, def int) int {
+    if v <= 0 {
+        return def
+    }
+    return v
+}
+
+func (f *fanout) enqueue(msg *broker.Message) {
+    priority := msg.Priority
+    if priority < 0 || priority >= numPriorities {
+        priority = PriorityNormal
+    }
+
+    select {
+    case f.queues[priority] <- &delivery{msg: msg, enqueued: time.Now()}:
+        f.metrics.FanoutQueueDepth.WithLabelValues(priorityNames[priority]).Inc()
+    default:
+        f.metrics.FanoutDropped.WithLabelValues(priorityNames[priority]).Inc()
+        f.logger.Warn("Fan-out queue full, dropping frame",
+            zap.String("priority", priorityNames[priority]),
+            zap.String("room", msg.Room))
+        return
+    }
+
+    select {
+    case f.notify <- struct{}{}:
+    default:
+    }
+}
+
+func (f *fanout) run() {
+    ticker := time.NewTicker(f.tick)
+    defer ticker.Stop()
+
+    var used [numPriorities]int
+    for {
+        select {
+        case <-f.notify:
+        case <-ticker.C:
+            used = [numPriorities]int{}
+        }
+        f.drain(&used)
+    }
+}
+
+// drain delivers queued frames, highest priority first, until each queue
+// is empty or out of budget for this tick.
+func (f *fanout) drain(used *[numPriorities]int) {
+    for priority := range f.queues {
+        for used[priority] < f.budgets[priority] {
+            var d *delivery
+            select {
+            case d = <-f.queues[priority]:
+            default:
+            }
+            if d == nil {
+                break
+            }
+
+            used[priority]++
+            name := priorityNames[priority]
+            f.metrics.FanoutQueueDepth.WithLabelValues(name).Dec()
+            f.metrics.FanoutDelay.WithLabelValues(name).Observe(time.Since(d.enqueued).Seconds())
+            f.deliver(d.msg)
+        }
+    }
+}
+
+// priorityOf classifies a room frame the server originated for the
+// fan-out. Frames posted through the hub loop are classified by
+// postedPriority instead, so nothing a client sends can claim a server
+// frame's priority.
+func priorityOf(message *models.WSMessage) int {
+    switch message.Type {
+    case models.MessageTypeEvent, models.MessageTypeShootout, models.MessageTypeGoalFlash, models.MessageTypeDeleted:
+        return PriorityHigh
+    case models.MessageTypeJoin, models.MessageTypeLeave, models.MessageTypeTyping, models.MessageTypeReaction:
+        return PriorityLow
+    }
+    return PriorityNormal
+}
+
+// postedPriority classifies a chat message posted through the hub loop.
+// Admins moderate rooms; their messages go out ahead of the crowd.
+func postedPriority(message *models.WSMessage) int {
+    if message.Exemption == authctx.ExemptAdmin {
+        return PriorityHigh
+    }
+    return PriorityNormal
+}
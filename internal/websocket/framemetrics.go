@@ -0,0 +1,128 @@
+package websocket
+
+import (
+    "encoding/json"
+
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+// Frame directions
+const (
+    directionIn  = "in"
+    directionOut = "out"
+)
+
+const (
+    // frameUnknown labels frames of a type the server does not define,
+    // keeping the label set bounded whatever clients send.
+    frameUnknown = "unknown"
+    // frameInvalid labels inbound frames that are not a JSON message.
+    frameInvalid = "invalid"
+    // roomSizeNone labels frames not sent to or from a room, such as
+    // direct messages and per-connection replies.
+    roomSizeNone = "none"
+)
+
+var frameTypes = map[string]bool{
+    models.MessageTypeChat:        true,
+    models.MessageTypeJoin:        true,
+    models.MessageTypeLeave:       true,
+    models.MessageTypeTyping:      true,
+    models.MessageTypeEvent:       true,
+    models.MessageTypeError:       true,
+    models.MessageTypeAchievement: true,
+    models.MessageTypeAlert:       true,
+    models.MessageTypeMention:     true,
+    models.MessageTypeTopics:      true,
+    models.MessageTypeRedirect:    true,
+    models.MessageTypeLinkPreview: true,
+    models.MessageTypeShootout:    true,
+    models.MessageTypeHistory:     true,
+    models.MessageTypeGoalFlash:   true,
+    models.MessageTypeDraft:       true,
+    models.MessageTypeRead:        true,
+    models.MessageTypeReaction:    true,
+    models.MessageTypeVoice:       true,
+    models.MessageTypeModerate:    true,
+    models.MessageTypeDeleted:     true,
+    models.MessageTypeSanctioned:  true,
+    models.MessageTypePresence:    true,
+    models.MessageTypeMatchVote:   true,
+    models.MessageTypeRoomState:   true,
+    models.MessageTypeDM:          true,
+    models.MessageTypeAck:         true,
+    models.MessageTypePoll:        true,
+    models.MessageTypeUserUpdated: true,
+    models.MessageTypeAnnouncement: true,
+    models.MessageTypeSlowMode:    true,
+    models.MessageTypeTicker:      true,
+    models.MessageTypeSubscribe:   true,
+    models.MessageTypeUnsubscribe: true,
+    models.MessageTypeAttachment:  true,
+    models.MessageTypePinned:      true,
+    models.MessageTypeRoomRole:    true,
+    models.MessageTypeHighlight:   true,
+    models.MessageTypeEmotes:      true,
+    models.MessageTypeEmotePack:   true,
+    models.MessageTypeLeaderboard: true,
+    models.MessageTypeAgeGate:     true,
+    models.MessageTypeHype:        true,
+    models.MessageTypeRoomDeleted: true,
+}
+
+func frameLabel(msgType string) string {
+    if frameTypes[msgType] {
+        return msgType
+    }
+    return frameUnknown
+}
+
+// frameType reads just the type of an outbound payload.
+func frameType(payload []byte) string {
+    var frame struct {
+        Type string `json:"type"`
+    }
+    if err := json.Unmarshal(payload, &frame); err != nil {
+        return frameUnknown
+    }
+    return frameLabel(frame.Type)
+}
+
+// roomSizeBucket groups rooms by how many connections they have on this
+// instance, which is what a frame's fan-out costs here.
+func roomSizeBucket(size int) string {
+    switch {
+    case size <= 0:
+        return roomSizeNone
+    case size <= 10:
+        return "1-10"
+    case size <= 100:
+        return "11-100"
+    case size <= 1000:
+        return "101-1000"
+    case size <= 10000:
+        return "1001-10000"
+    default:
+        return "10000+"
+    }
+}
+
+// countInbound records one frame read from a client. room is empty for
+// frames not aimed at a room.
+func (h *Hub) countInbound(room, msgType string, bytes int) {
+    h.metrics.WSFrames.WithLabelValues(directionIn, msgType).Inc()
+    bucket := roomSizeNone
+    if room != "" {
+        bucket = roomSizeBucket(h.roomSize(room))
+    }
+    h.metrics.WSBytes.WithLabelValues(directionIn, bucket).Add(float64(bytes))
+}
+
+// countOutbound records a payload queued to n connections.
+func (h *Hub) countOutbound(msgType, bucket string, bytes, n int) {
+    if n == 0 {
+        return
+    }
+    h.metrics.WSFrames.WithLabelValues(directionOut, msgType).Add(float64(n))
+    h.metrics.WSBytes.WithLabelValues(directionOut, bucket).Add(float64(bytes * n))
+}
@@ -0,0 +1,166 @@
+package websocket
+
+import (
+    "encoding/json"
+    "strings"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/quiethours"
+)
+
+// goalFlash is the compact payload of a goal_flash frame; clients in the
+// scoring match's own room get the full match update instead.
+type goalFlash struct {
+    MatchID       string `json:"match_id"`
+    CompetitionID string `json:"competition_id"`
+    HomeTeam      string `json:"home_team"`
+    AwayTeam      string `json:"away_team"`
+    HomeScore     int    `json:"home_score"`
+    AwayScore     int    `json:"away_score"`
+    ScoringSide   string `json:"scoring_side"`
+    // Localized names the match in the recipient's language and time
+    // zone
+    Localized *models.MatchLocale `json:"localized,omitempty"`
+}
+
+// SetGoalFlashOptOut updates the opt-out of a connected user so a change
+// takes effect without reconnecting.
+func (h *Hub) SetGoalFlashOptOut(userID string, optOut bool) {
+    h.usersMu.Lock()
+    defer h.usersMu.Unlock()
+
+    if user, ok := h.users[userID]; ok {
+        user.goalFlashOptOut = optOut
+    }
+}
+
+func (h *Hub) goalFlashOptedOut(userID string) bool {
+    h.usersMu.RLock()
+    defer h.usersMu.RUnlock()
+
+    user, ok := h.users[userID]
+    return ok && user.goalFlashOptOut
+}
+
+// scoringSide reports which side scored between two snapshots of a
+// match, or "" if the score did not go up. Shootout kicks are not goals.
+func scoringSide(old, new *models.Match) string {
+    if old == nil || new.Period == models.PeriodPenaltyShootout {
+        return ""
+    }
+    switch {
+    case new.HomeScore > old.HomeScore:
+        return "home"
+    case new.AwayScore > old.AwayScore:
+        return "away"
+    }
+    return ""
+}
+
+// flashGoal delivers a goal_flash frame to clients watching other matches
+// of the same competition. Each connection gets the frame once however
+// many of those rooms it is in, naming the match in its language and
+// time zone; clients in the scoring room, users who opted out and users
+// in their quiet hours are skipped. Must be called
+// with h.matchMu held.
+func (h *Hub) flashGoal(match *models.Match, side string) {
+    if match.CompetitionID == "" {
+        return
+    }
+
+    var rooms []string
+    for roomID, m := range h.matches {
+        if roomID != match.ID && m.CompetitionID == match.CompetitionID {
+            rooms = append(rooms, roomID)
+        }
+    }
+    if len(rooms) == 0 {
+        return
+    }
+
+    flash := goalFlash{
+        MatchID:       match.ID,
+        CompetitionID: match.CompetitionID,
+        HomeTeam:      match.HomeTeamID,
+        AwayTeam:      match.AwayTeamID,
+        HomeScore:     match.HomeScore,
+        AwayScore:     match.AwayScore,
+        ScoringSide:   side,
+    }
+    if match.HomeTeam != nil {
+        flash.HomeTeam = match.HomeTeam.Name
+    }
+    if match.AwayTeam != nil {
+        flash.AwayTeam = match.AwayTeam.Name
+    }
+
+    // Recipients sharing a locale and time zone share a payload
+    type localeKey struct{ locale, timezone string }
+    payloads := make(map[localeKey][]byte)
+    payloadFor := func(client *Client) []byte {
+        key := localeKey{locale: client.locale, timezone: client.timezone}
+        if payload, ok := payloads[key]; ok {
+            return payload
+        }
+        localized := flash
+        if h.localizer != nil {
+            localized.Localized = h.localizer.Localize(match, key.locale, key.timezone)
+        }
+        data, err := json.Marshal(localized)
+        if err != nil {
+            return nil
+        }
+        payload, err := json.Marshal(&models.WSMessage{
+            Type:      models.MessageTypeGoalFlash,
+            Data:      data,
+            Timestamp: time.Now(),
+        })
+        if err != nil {
+            h.logger.Error("Failed to marshal goal flash",
+                zap.Error(err),
+                zap.String("match_id", match.ID))
+        }
+        payloads[key] = payload
+        return payload
+    }
+
+    // Gather first so the rooms and users locks are never held together
+    recipients := make(map[*Client]bool)
+    for _, room := range rooms {
+        h.eachRoomClient(room, func(client *Client) {
+            recipients[client] = true
+        })
+    }
+    now := time.Now()
+    for client := range recipients {
+        if h.inRoom(match.ID, client) || h.goalFlashOptedOut(client.user.ID) {
+            continue
+        }
+        quiet, favoriteTeam := h.userQuietHours(client.user.ID)
+        if !quiethours.Allows(quiet, now, scoredForTeam(match, side, favoriteTeam)) {
+            continue
+        }
+        if payload := payloadFor(client); payload != nil {
+            client.trySend(payload)
+        }
+    }
+}
+
+// scoredForTeam reports whether the scoring side is the given favorite
+// team, which users may set by team ID or name.
+func scoredForTeam(match *models.Match, side, favoriteTeam string) bool {
+    if favoriteTeam == "" {
+        return false
+    }
+    teamID, team := match.HomeTeamID, match.HomeTeam
+    if side == "away" {
+        teamID, team = match.AwayTeamID, match.AwayTeam
+    }
+    if favoriteTeam == teamID {
+        return true
+    }
+    return team != nil && strings.EqualFold(favoriteTeam, team.Name)
+}
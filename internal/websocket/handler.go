@@ -0,0 +1,252 @@
+package websocket
+
+import (
+    "context"
+    "net"
+    "net/http"
+    "strconv"
+    "strings"
+    "time"
+
+    "github.com/google/uuid"
+    "github.com/gorilla/websocket"
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/auth"
+    "github.com/yourusername/sports-chat/internal/authctx"
+    "github.com/yourusername/sports-chat/internal/events"
+    "github.com/yourusername/sports-chat/internal/metrics"
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/securitylog"
+)
+
+// Client capabilities, negotiated with ?caps=a,b on the handshake
+const (
+    CapabilityLinkPreviews = "link_previews"
+)
+
+// Echo modes, chosen with ?echo= on the handshake. With server echo, the
+// default, a connection receives its own chat messages back like the rest
+// of the room, with their ID and sequence. With ack echo it gets only the
+// ack, and shows the message from its local copy; messages sent without
+// a client_msg_id, or rewritten by the content filters, are still echoed.
+const (
+    EchoServer = "server"
+    EchoAck    = "ack"
+)
+
+type Handler struct {
+    hub      *Hub
+    auth     *auth.Service
+    metrics  *metrics.Metrics
+    logger   *zap.Logger
+    upgrader websocket.Upgrader
+
+    // Request header the edge puts the client's country code in; empty
+    // records no country
+    countryHeader string
+}
+
+func NewHandler(hub *Hub, auth *auth.Service, countryHeader string, metrics *metrics.Metrics, logger *zap.Logger) *Handler {
+    return &Handler{
+        hub:           hub,
+        auth:          auth,
+        countryHeader: countryHeader,
+        metrics:       metrics,
+        logger:        logger,
+        upgrader: websocket.Upgrader{
+            ReadBufferSize:    1024,
+            WriteBufferSize:   1024,
+            EnableCompression: hub.compression.enabled,
+        },
+    }
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+    // Browsers cannot set headers on websocket requests, so the token may
+    // also be passed as a query parameter.
+    token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+    if token == "" {
+        token = r.URL.Query().Get("token")
+    }
+
+    claims, err := h.auth.ValidateAccessToken(token)
+    if err != nil {
+        http.Error(w, "Unauthorized", http.StatusUnauthorized)
+        return
+    }
+    principal := claims.Principal()
+    ctx := authctx.WithUser(r.Context(), principal)
+
+    if !h.admit(w, r) {
+        return
+    }
+
+    rooms := make(map[string]bool)
+    for _, room := range strings.Split(r.URL.Query().Get("rooms"), ",") {
+        if room = strings.TrimSpace(room); room != "" {
+            rooms[room] = true
+        }
+    }
+
+    caps := make(map[string]bool)
+    for _, capability := range strings.Split(r.URL.Query().Get("caps"), ",") {
+        if capability = strings.TrimSpace(capability); capability != "" {
+            caps[capability] = true
+        }
+    }
+
+    tickerComps, ok := parseTickerCompetitions(strings.Split(r.URL.Query().Get("ticker"), ","))
+    if !ok {
+        http.Error(w, "Too many ticker competitions", http.StatusBadRequest)
+        return
+    }
+
+    echo := r.URL.Query().Get("echo")
+    switch echo {
+    case "":
+        echo = EchoServer
+    case EchoServer, EchoAck:
+    default:
+        http.Error(w, "Invalid echo mode", http.StatusBadRequest)
+        return
+    }
+
+    user := h.loadUser(ctx, principal)
+    if user.BannedAt != nil {
+        http.Error(w, "Account banned", http.StatusForbidden)
+        return
+    }
+    if user.DeactivatedAt != nil {
+        http.Error(w, "Account deactivated", http.StatusForbidden)
+        return
+    }
+    if user.SessionsRevokedAt != nil && principal.IssuedAt.Before(*user.SessionsRevokedAt) {
+        http.Error(w, "Session revoked", http.StatusUnauthorized)
+        return
+    }
+    // A reconnect with a resume token carries on where its connection
+    // left off, back in the rooms it was in as well as any asked for
+    resumeToken := r.URL.Query().Get("resume")
+    restored := h.hub.takeResume(ctx, resumeToken, user.ID)
+    if restored != nil {
+        for _, room := range restored.Rooms {
+            rooms[room] = true
+        }
+    }
+    // Deleted rooms are dropped, and the client told where they went once
+    // connected
+    var deleted []*models.RoomTombstone
+    for room := range rooms {
+        if tombstone := h.hub.deletedRoom(room); tombstone != nil {
+            delete(rooms, room)
+            deleted = append(deleted, tombstone)
+        }
+    }
+    if !principal.IsAdmin {
+        now := time.Now()
+        for room := range rooms {
+            if _, banned := h.hub.sanctionsFor(room, user.ID); banned {
+                delete(rooms, room)
+            } else if !h.hub.roomSettings(room).Admits(user, now) {
+                delete(rooms, room)
+            }
+        }
+    }
+
+    responseHeader := http.Header{}
+    coalesce := h.hub.negotiateCoalesce(r, responseHeader)
+
+    conn, err := h.upgrader.Upgrade(w, r, responseHeader)
+    if err != nil {
+        h.logger.Warn("Websocket upgrade failed",
+            zap.Error(err),
+            zap.String("user_id", principal.UserID))
+        h.hub.returnResume(resumeToken, restored)
+        return
+    }
+
+    compress := h.upgrader.EnableCompression && offersDeflate(r.Header)
+    if compress {
+        conn.SetCompressionLevel(h.hub.compression.level)
+    }
+    h.metrics.WSCompressionNegotiated.WithLabelValues(strconv.FormatBool(compress)).Inc()
+
+    client := &Client{
+        id:        uuid.NewString(),
+        hub:       h.hub,
+        conn:      conn,
+        send:      make(chan outFrame, h.hub.sendBuffer),
+        user:      user,
+        principal: principal,
+        rooms:     rooms,
+        caps:      caps,
+        since:     resumeSince(parseSinceSeq(r.URL.Query().Get("since_seq"), rooms), restored, rooms),
+        echo:      echo,
+        locale:    clientLocale(r, user),
+        timezone:  user.Timezone,
+
+        tickerComps: tickerComps,
+        compress:    compress,
+        coalesce:    coalesce,
+
+        ip:          remoteIP(r),
+        connectedAt: time.Now(),
+    }
+
+    for _, tombstone := range deleted {
+        h.hub.sendRoomDeleted(client, tombstone)
+    }
+    h.hub.trackResume(client, restored)
+    h.hub.register <- client
+
+    // Device details feed ban evasion detection. Browsers cannot set
+    // headers on websocket requests, so the fingerprint may also come as
+    // a query parameter.
+    fingerprint := r.Header.Get("X-Device-Fingerprint")
+    if fingerprint == "" {
+        fingerprint = r.URL.Query().Get("fp")
+    }
+    h.hub.events.Publish(events.UserConnected{
+        UserID:      user.ID,
+        SessionID:   principal.SessionID,
+        Fingerprint: fingerprint,
+        UserAgent:   r.UserAgent(),
+        IP:          remoteIP(r),
+        Country:     securitylog.Country(r, h.countryHeader),
+        At:          time.Now(),
+    })
+
+    go client.writePump()
+    go client.readPump()
+}
+
+func remoteIP(r *http.Request) string {
+    host, _, err := net.SplitHostPort(r.RemoteAddr)
+    if err != nil {
+        return r.RemoteAddr
+    }
+    return host
+}
+
+// loadUser hydrates the profile fields used in user summaries, falling
+// back to the token claims if the store is unavailable.
+func (h *Handler) loadUser(ctx context.Context, principal *authctx.Principal) *models.User {
+    ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+    defer cancel()
+
+    user, err := h.hub.store.GetUser(ctx, principal.UserID)
+    if err != nil {
+        h.logger.Warn("Failed to load user for websocket",
+            zap.Error(err),
+            zap.String("user_id", principal.UserID))
+        return &models.User{
+            ID:              principal.UserID,
+            Username:        principal.Username,
+            IsAdmin:         principal.IsAdmin,
+            AccountType:     principal.AccountType,
+            RateLimitExempt: principal.RateLimitExempt,
+        }
+    }
+    return user
+}
@@ -0,0 +1,222 @@
+package websocket
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "time"
+
+    "github.com/google/uuid"
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/store"
+)
+
+const (
+    // DefaultInitialHistory is the number of messages sent per room on
+    // connect when the room does not set its own budget.
+    DefaultInitialHistory = 10
+    // MaxHistoryPage caps a single history request, over WS or REST.
+    MaxHistoryPage = 100
+)
+
+// HistoryPage is the payload of a history frame and of the REST history
+// endpoint. On connect it carries only the counts; in reply to a history
+// command it also carries the messages, oldest first. NextCursor continues
+// in the direction that was requested.
+type HistoryPage struct {
+    Messages   []*models.WSMessage `json:"messages,omitempty"`
+    Returned   int                 `json:"returned"`
+    Total      int                 `json:"total,omitempty"`
+    HasMore    bool                `json:"has_more"`
+    NextCursor string              `json:"next_cursor,omitempty"`
+    // SinceSeq is set when the messages fill a reconnecting client's gap
+    // after that sequence, to be appended rather than replace its view
+    SinceSeq int64 `json:"since_seq,omitempty"`
+}
+
+// historyRequest is the data of a client's history command. Before and
+// After are cursors from an earlier page; at most one may be set.
+type historyRequest struct {
+    Before string `json:"before"`
+    After  string `json:"after"`
+    Limit  int    `json:"limit"`
+    // UserID and Kind filter the history, as store.MessageFilter does
+    UserID string `json:"user_id"`
+    Kind   string `json:"kind"`
+}
+
+var (
+    // ErrInvalidCursor is returned for malformed or conflicting cursors.
+    ErrInvalidCursor = errors.New("invalid history cursor")
+    // ErrInvalidFilter is returned for unknown kinds and malformed user IDs.
+    ErrInvalidFilter = errors.New("invalid history filter")
+)
+
+// ClampHistoryLimit bounds a requested page size to (0, MaxHistoryPage].
+func ClampHistoryLimit(limit int) int {
+    if limit <= 0 || limit > MaxHistoryPage {
+        return MaxHistoryPage
+    }
+    return limit
+}
+
+// LoadHistoryPage pages through a room's history by keyset on (created_at,
+// id), so messages sharing a timestamp are neither skipped nor repeated.
+// Without a cursor it returns the latest messages; before pages back and
+// after catches up on messages sent since. Cursors stay valid across
+// filters, so a client can switch filter without losing its place.
+func LoadHistoryPage(ctx context.Context, st store.Store, roomID string, filter store.MessageFilter, before, after string, limit int) (*HistoryPage, error) {
+    if before != "" && after != "" {
+        return nil, ErrInvalidCursor
+    }
+    if !store.ValidMessageKind(filter.Kind) {
+        return nil, ErrInvalidFilter
+    }
+    if filter.UserID != "" {
+        if _, err := uuid.Parse(filter.UserID); err != nil {
+            return nil, ErrInvalidFilter
+        }
+    }
+
+    // One extra row tells us whether another page exists
+    var messages []*models.Message
+    if after != "" {
+        cursor, err := store.DecodeMessageCursor(after)
+        if err != nil {
+            return nil, ErrInvalidCursor
+        }
+        messages, err = st.GetMessagesAfterCursor(ctx, roomID, filter, cursor, limit+1)
+        if err != nil {
+            return nil, fmt.Errorf("failed to get messages: %w", err)
+        }
+    } else {
+        var cursor *store.MessageCursor
+        if before != "" {
+            c, err := store.DecodeMessageCursor(before)
+            if err != nil {
+                return nil, ErrInvalidCursor
+            }
+            cursor = &c
+        }
+        var err error
+        messages, err = st.GetMessagesBeforeCursor(ctx, roomID, filter, cursor, limit+1)
+        if err != nil {
+            return nil, fmt.Errorf("failed to get messages: %w", err)
+        }
+    }
+
+    page := &HistoryPage{HasMore: len(messages) > limit}
+    if page.HasMore {
+        messages = messages[:limit]
+    }
+
+    if after != "" {
+        // Catching up never runs dry: hand back a cursor to poll from even
+        // when nothing new has arrived.
+        page.NextCursor = after
+        if len(messages) > 0 {
+            page.NextCursor = store.CursorOf(messages[len(messages)-1]).Encode()
+        }
+    } else {
+        if page.HasMore {
+            page.NextCursor = store.CursorOf(messages[len(messages)-1]).Encode()
+        }
+        for i, j := 0, len(messages)-1; i < j; i, j = i+1, j-1 {
+            messages[i], messages[j] = messages[j], messages[i]
+        }
+    }
+
+    if err := LoadReactions(ctx, st, messages); err != nil {
+        return nil, fmt.Errorf("failed to load reactions: %w", err)
+    }
+
+    page.Messages = HistoryMessages(messages)
+    page.Returned = len(page.Messages)
+    return page, nil
+}
+
+// HistoryMessages converts stored messages into chat frames, keeping
+// their order.
+func HistoryMessages(messages []*models.Message) []*models.WSMessage {
+    frames := make([]*models.WSMessage, 0, len(messages))
+    for _, msg := range messages {
+        frames = append(frames, historyMessage(msg))
+    }
+    return frames
+}
+
+func historyMessage(msg *models.Message) *models.WSMessage {
+    return &models.WSMessage{
+        ID:        msg.ID,
+        Type:      models.MessageTypeChat,
+        ChatRoom:  msg.ChatRoomID,
+        Content:   msg.Content,
+        User:      msg.User,
+        Timestamp: msg.CreatedAt,
+        Reactions: msg.Reactions,
+        Mentions:  msg.Mentions,
+        TopicID:   msg.TopicID,
+        Seq:       msg.Seq,
+    }
+}
+
+// initialHistoryBudget returns how many messages a room sends on connect.
+func (h *Hub) initialHistoryBudget(room string) int {
+    if budget := h.roomSettings(room).InitialHistory; budget > 0 {
+        return budget
+    }
+    return DefaultInitialHistory
+}
+
+// handleHistoryRequest answers a client's history command with one frame
+// holding the page of older messages.
+func (c *Client) handleHistoryRequest(msg *models.WSMessage) {
+    var req historyRequest
+    if len(msg.Data) > 0 {
+        if err := json.Unmarshal(msg.Data, &req); err != nil {
+            c.sendError(msg, models.ErrorInvalidRequest, "Invalid history request")
+            return
+        }
+    }
+
+    ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+    defer cancel()
+
+    filter := store.MessageFilter{UserID: req.UserID, Kind: req.Kind}
+    page, err := LoadHistoryPage(ctx, c.hub.store, msg.ChatRoom, filter, req.Before, req.After, ClampHistoryLimit(req.Limit))
+    if errors.Is(err, ErrInvalidCursor) {
+        c.sendError(msg, models.ErrorInvalidRequest, "Invalid history cursor")
+        return
+    }
+    if errors.Is(err, ErrInvalidFilter) {
+        c.sendError(msg, models.ErrorInvalidRequest, "Invalid history filter")
+        return
+    }
+    if err != nil {
+        c.hub.logger.Error("Failed to load message history",
+            zap.Error(err),
+            zap.String("room", msg.ChatRoom))
+        c.sendError(msg, models.ErrorInternal, "Failed to load history")
+        return
+    }
+
+    data, err := json.Marshal(page)
+    if err != nil {
+        return
+    }
+    payload, err := json.Marshal(&models.WSMessage{
+        Type:      models.MessageTypeHistory,
+        ChatRoom:  msg.ChatRoom,
+        Data:      data,
+        Timestamp: time.Now(),
+    })
+    if err != nil {
+        return
+    }
+
+    c.trySend(payload)
+}
+
@@ -5,165 +5,647 @@ import (
     "encoding/json"
     "fmt"
     "sync"
+    "sync/atomic"
     "time"
+    "unicode/utf8"
 
+    "github.com/google/uuid"
     "github.com/gorilla/websocket"
+    "go.opentelemetry.io/otel/attribute"
+    "go.opentelemetry.io/otel/trace"
     "go.uber.org/zap"
     "golang.org/x/time/rate"
 
+    "github.com/yourusername/sports-chat/internal/alerts"
+    "github.com/yourusername/sports-chat/internal/authctx"
+    "github.com/yourusername/sports-chat/internal/broker"
+    "github.com/yourusername/sports-chat/internal/commentary"
+    "github.com/yourusername/sports-chat/internal/events"
+    "github.com/yourusername/sports-chat/internal/jobs"
+    "github.com/yourusername/sports-chat/internal/journal"
+    "github.com/yourusername/sports-chat/internal/localization"
     "github.com/yourusername/sports-chat/internal/metrics"
     "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/moderation"
+    "github.com/yourusername/sports-chat/internal/presence"
+    "github.com/yourusername/sports-chat/internal/quiethours"
+    "github.com/yourusername/sports-chat/internal/ratelimit"
+    "github.com/yourusername/sports-chat/internal/rbac"
+    "github.com/yourusername/sports-chat/internal/resume"
+    "github.com/yourusername/sports-chat/internal/sequence"
+    "github.com/yourusername/sports-chat/internal/sportsdata"
     "github.com/yourusername/sports-chat/internal/store"
+    "github.com/yourusername/sports-chat/internal/tracing"
+    "github.com/yourusername/sports-chat/internal/unfurl"
 )
 
 type Client struct {
-    hub      *Hub
-    conn     *websocket.Conn
-    send     chan []byte
-    user     *models.User
-    rooms    map[string]bool
-    limiter  *rate.Limiter
-    mu       sync.RWMutex
+    // Identifies the connection across instances
+    id        string
+    hub       *Hub
+    conn      *websocket.Conn
+    send      chan outFrame
+    user      *models.User
+    principal *authctx.Principal
+    rooms     map[string]bool
+    caps      map[string]bool
+    mu        sync.RWMutex
+    // Set once the client is unregistered; guarded by mu
+    gone bool
+
+    // Last sequence seen per room by a reconnecting client
+    since map[string]int64
+
+    // Whether the connection's own chat messages are echoed back to it
+    echo string
+
+    // Language match commentary and names are shown in; empty for the
+    // default
+    locale string
+    // Time zone match times are shown in, from the user's profile
+    timezone string
+
+    // Typing indicators this client has open, by room
+    typing   map[string]*typingState
+    typingMu sync.Mutex
+
+    // Rooms whose voice session this connection joined
+    voice   map[string]bool
+    voiceMu sync.Mutex
+
+    // Guards user's profile fields, which a rename may change
+    profileMu sync.RWMutex
+
+    // Guards closing send against concurrent deliveries
+    sendMu sync.RWMutex
+    closed bool
+
+    // Frames the connection never got because its buffer was full, and
+    // whether it is being disconnected for it
+    dropped atomic.Int64
+    slow    atomic.Bool
+
+    // Competitions the connection asked to follow on the handshake
+    tickerComps []string
+
+    // Whether the connection negotiated permessage-deflate
+    compress bool
+
+    // How long outbound frames wait to be batched together; zero writes
+    // them as they come
+    coalesce time.Duration
+
+    // Where and when the connection was opened, for operators
+    ip          string
+    connectedAt time.Time
+
+    // Where the connection stands, for a reconnect to resume; nil when
+    // it is not resumable
+    resume *resumeTracker
+}
+
+// inbound is a frame read from a client on its way to the hub loop. ctx
+// carries the frame's span, which the hub loop ends once it has handled
+// it.
+type inbound struct {
+    ctx     context.Context
+    message *models.WSMessage
 }
 
 type Hub struct {
-    // Clients and rooms
-    clients    map[*Client]bool
+    // Local connections by room and by user
     rooms      map[string]map[*Client]bool
+    roomsMu    sync.RWMutex
+    users      map[string]*connectedUser
+    usersMu    sync.RWMutex
     
     // Channels for client registration and message broadcasting
     register   chan *Client
     unregister chan *Client
-    broadcast  chan *models.WSMessage
+    broadcast  chan *inbound
+
+    // Hub loop health, for the watchdog: the running loop's generation,
+    // when it last turned over in Unix nanoseconds, how long it may take,
+    // and the read and write pumps running
+    loopGen      atomic.Int64
+    lastLoop     atomic.Int64
+    stallTimeout time.Duration
+    pumps        atomic.Int64
     
+    // Prioritized local delivery of room frames
+    fanout     *fanout
+
     // Dependencies
     store      store.Store
+    broker     broker.Broker
+    events     *events.Bus
+    journal    *journal.Journal
+    profanity  *moderation.ProfanityFilter
+    filters    *moderation.Chain
     metrics    *metrics.Metrics
     logger     *zap.Logger
     
-    // Synchronization
-    mu         sync.RWMutex
-    
     // Match updates
     matches    map[string]*models.Match
     matchMu    sync.RWMutex
     
     // Rate limiting
     roomLimiters map[string]*rate.Limiter
+    limiterMu    sync.Mutex
+    userLimiter  ratelimit.Limiter
+    userLimits   ratelimit.Rules
+
+    // Keyword alerts of connected users
+    alerts     *alerts.Matcher
+
+    // Merged and split rooms, mapped to the rooms that replace them
+    redirects  map[string][]string
+    redirectMu sync.RWMutex
+
+    // Room settings cache (language channel, link preview policy)
+    roomCache   map[string]*cachedRoom
+    roomCacheMu sync.RWMutex
+
+    // Link preview unfurling; nil when disabled
+    unfurler   *unfurl.Service
+
+    // External source of live scores; nil serves matches from the store
+    // as they are
+    provider   sportsdata.Provider
+
+    // Queue chat messages are persisted through; nil persists them
+    // from a goroutine without retries
+    jobs *jobs.Queue
+
+    // Batches chat messages into multi-row inserts
+    writeBehind *writeBehind
+
+    // Drafts waiting on the debounce before being stored
+    drafts   map[draftKey]*pendingDraft
+    draftsMu sync.Mutex
+
+    // Room membership across instances
+    presence         presence.Tracker
+    presenceInterval time.Duration
+    // When watch party attendance was last sampled; owned by the
+    // presence loop
+    attendanceAt time.Time
+
+    // Acknowledged chat messages by sender and client ID. Locked, as a
+    // stalled hub loop may finish a frame after its replacement started.
+    acks         map[ackKey]*sentAck
+    acksPrunedAt time.Time
+    ackMu        sync.Mutex
+
+    // Room mutes and bans, cached per room and user
+    sanctionCache map[sanctionKey]*cachedSanctions
+    sanctionMu    sync.RWMutex
+
+    // Numbers chat messages per room for reconnect backfill
+    sequencer sequence.Sequencer
+
+    // Frames delivered per room on this instance, for operators
+    throughput *throughput
+
+    // Connections following the goal ticker, by competition
+    ticker *ticker
+
+    // How outbound frames are compressed
+    compression compression
+
+    // Longest coalescing window a client may negotiate
+    coalesceWindow time.Duration
+
+    // Frames each connection queues, and what happens when it is full
+    sendBuffer         int
+    slowConsumerPolicy string
+
+    // Keeps a sealed copy of messages moderators delete; nil keeps none
+    evidence EvidenceRetainer
+
+    // Renders match event descriptions in clients' languages; nil sends
+    // them as the provider wrote them
+    commentary *commentary.Renderer
+    // Shows match names and times in clients' locales and zones; nil
+    // leaves them as stored
+    localizer *localization.Localizer
+
+    // Room-scoped roles and what they allow
+    roles *rbac.Checker
+
+    // Turns new connections away near capacity
+    shedder *shedder
+
+    // Read-only streams of rooms, for clients without websockets
+    streams *streams
+
+    // Rooms readied ahead of busy kickoffs
+    warm *warmRooms
+
+    // Reactions per room over the last minute
+    hype *hypeMeter
+
+    // Keeps dropped connections' state for reconnects to resume; nil
+    // when connections are not resumable
+    resume       resume.Store
+    resumeWindow time.Duration
+}
+
+type cachedRoom struct {
+    room     *models.ChatRoom
+    loadedAt time.Time
 }
 
-func NewHub(store store.Store, metrics *metrics.Metrics, logger *zap.Logger) *Hub {
-    return &Hub{
-        clients:      make(map[*Client]bool),
+const roomCacheTTL = time.Minute
+
+// Options configures the hub. Zero values use the defaults.
+type Options struct {
+    // FanoutTick is how often the fan-out budgets reset.
+    FanoutTick time.Duration
+    // Per-tick delivery budgets for each priority, in room frames.
+    HighBudget   int
+    NormalBudget int
+    LowBudget    int
+
+    // RateLimiter holds the per-user message buckets, shared across
+    // instances when backed by Redis. Nil uses in-process buckets.
+    RateLimiter ratelimit.Limiter
+    // RateLimits are the per-message-type rules. Nil uses
+    // DefaultRateLimits.
+    RateLimits ratelimit.Rules
+
+    // Provider feeds live scores and match events. Nil leaves match
+    // updates to whatever writes the store.
+    Provider sportsdata.Provider
+
+    // Presence aggregates room members across instances; nil tracks this
+    // instance only. PresenceInterval is how often members are reported
+    // and rooms sent their counts.
+    Presence         presence.Tracker
+    PresenceInterval time.Duration
+
+    // Jobs persists chat messages with retries, dead-lettering those
+    // that exhaust them. Nil persists each message once.
+    Jobs *jobs.Queue
+
+    // Chat messages are written in batches of up to PersistBatchSize,
+    // at least every PersistFlushInterval. PersistQueueSize bounds the
+    // messages waiting; past it, messages are persisted one by one.
+    PersistBatchSize     int
+    PersistFlushInterval time.Duration
+    PersistQueueSize     int
+
+    // Filters moderates chat messages before they are broadcast. Nil
+    // applies the profanity filter alone.
+    Filters *moderation.Chain
+
+    // Sequencer numbers chat messages per room, shared across instances
+    // when backed by Redis. Nil numbers them in process, continuing from
+    // the store.
+    Sequencer sequence.Sequencer
+
+    // Roles checks room-scoped permissions, shared with the API so both
+    // see role changes at once. Nil checks against the store alone.
+    Roles *rbac.Checker
+
+    // Shedding refuses new connections while the instance is past any
+    // of these limits; zero limits never shed.
+    Shedding ShedLimits
+
+    // Compression negotiates permessage-deflate with clients that offer
+    // it. Frames smaller than CompressionThreshold bytes are sent
+    // uncompressed; zero level and threshold use the defaults.
+    Compression          bool
+    CompressionLevel     int
+    CompressionThreshold int
+
+    // CoalesceWindow is the longest a client may ask its outbound frames
+    // to be held to batch them into one websocket message. Zero turns
+    // coalescing off.
+    CoalesceWindow time.Duration
+
+    // SendBuffer is how many outbound frames each connection queues;
+    // zero uses DefaultSendBuffer. SlowConsumerPolicy decides what
+    // happens to a connection whose buffer is full; empty disconnects it.
+    SendBuffer         int
+    SlowConsumerPolicy string
+
+    // Evidence keeps a sealed copy of each message a moderator deletes,
+    // and the deletion fails if it cannot. Nil deletes them outright.
+    Evidence EvidenceRetainer
+
+    // Commentary renders match event descriptions in each client's
+    // language. Nil sends them as the provider wrote them.
+    Commentary *commentary.Renderer
+
+    // Localizer shows team names in each client's language in match
+    // notifications, and stores names the provider sends. Nil leaves
+    // names as stored.
+    Localizer *localization.Localizer
+
+    // Resume keeps where connections stand, so a client that drops and
+    // reconnects within ResumeWindow carries on without rejoining; shared
+    // across instances when backed by Redis. Nil makes connections not
+    // resumable.
+    Resume       resume.Store
+    ResumeWindow time.Duration
+}
+
+// NewHub creates a hub. journal and unfurler may be nil to disable the
+// broadcast journal and link previews.
+func NewHub(store store.Store, broker broker.Broker, bus *events.Bus, journal *journal.Journal, profanity *moderation.ProfanityFilter, unfurler *unfurl.Service, opts Options, metrics *metrics.Metrics, logger *zap.Logger) *Hub {
+    h := &Hub{
         rooms:        make(map[string]map[*Client]bool),
-        register:     make(chan *Client),
-        unregister:   make(chan *Client),
-        broadcast:    make(chan *models.WSMessage),
+        users:        make(map[string]*connectedUser),
+        register:     make(chan *Client, hubQueueSize),
+        unregister:   make(chan *Client, hubQueueSize),
+        broadcast:    make(chan *inbound, hubQueueSize),
         store:        store,
+        broker:       broker,
+        events:       bus,
+        journal:      journal,
+        profanity:    profanity,
+        unfurler:     unfurler,
         metrics:      metrics,
         logger:       logger,
         matches:      make(map[string]*models.Match),
         roomLimiters: make(map[string]*rate.Limiter),
+        alerts:       alerts.NewMatcher(),
+        redirects:    make(map[string][]string),
+        roomCache:    make(map[string]*cachedRoom),
+
+        drafts:        make(map[draftKey]*pendingDraft),
+        sanctionCache: make(map[sanctionKey]*cachedSanctions),
+        acks:          make(map[ackKey]*sentAck),
+        throughput:    newThroughput(),
+        ticker:        newTicker(),
+        streams:       newStreams(),
+        warm:          newWarmRooms(),
+        hype:          newHypeMeter(),
+    }
+    h.fanout = newFanout(opts, h.deliverToRoom, metrics, logger)
+    h.userLimiter = opts.RateLimiter
+    if h.userLimiter == nil {
+        h.userLimiter = ratelimit.NewMemory()
+    }
+    h.userLimits = opts.RateLimits
+    h.provider = opts.Provider
+    h.filters = opts.Filters
+    if h.filters == nil {
+        h.filters = moderation.NewChain(store, profanity, nil, metrics, logger)
+    }
+    h.jobs = opts.Jobs
+    if h.jobs != nil {
+        h.registerPersistJob()
+    }
+    h.writeBehind = newWriteBehind(h, opts)
+    h.sequencer = opts.Sequencer
+    if h.sequencer == nil {
+        h.sequencer = sequence.NewLocal(store.GetRoomMaxSeq)
+    }
+    h.presence = opts.Presence
+    if h.presence == nil {
+        h.presence = presence.NewLocal()
+    }
+    h.presenceInterval = opts.PresenceInterval
+    if h.presenceInterval <= 0 {
+        h.presenceInterval = defaultPresenceInterval
+    }
+    if h.userLimits == nil {
+        h.userLimits = DefaultRateLimits
+    }
+    h.shedder = newShedder(opts.Shedding)
+    h.stallTimeout = opts.Shedding.StallTimeout
+    if h.stallTimeout <= 0 {
+        h.stallTimeout = DefaultStallTimeout
+    }
+    h.roles = opts.Roles
+    if h.roles == nil {
+        h.roles = rbac.NewChecker(store, logger)
     }
+    h.compression = compression{
+        enabled:   opts.Compression,
+        level:     opts.CompressionLevel,
+        threshold: opts.CompressionThreshold,
+    }
+    if h.compression.level == 0 {
+        h.compression.level = defaultCompressionLevel
+    }
+    if h.compression.threshold <= 0 {
+        h.compression.threshold = defaultCompressionThreshold
+    }
+    h.coalesceWindow = opts.CoalesceWindow
+    h.sendBuffer = opts.SendBuffer
+    if h.sendBuffer <= 0 {
+        h.sendBuffer = DefaultSendBuffer
+    }
+    h.slowConsumerPolicy = opts.SlowConsumerPolicy
+    if h.slowConsumerPolicy == "" {
+        h.slowConsumerPolicy = SlowConsumerDisconnect
+    }
+    h.evidence = opts.Evidence
+    h.commentary = opts.Commentary
+    h.localizer = opts.Localizer
+    h.resume = opts.Resume
+    h.resumeWindow = opts.ResumeWindow
+    if h.resumeWindow <= 0 {
+        h.resumeWindow = DefaultResumeWindow
+    }
+    return h
 }
 
 func (h *Hub) Run() {
     // Start match update goroutine
     go h.updateMatches()
+    go h.fanout.run()
+    go h.writeBehind.run()
+    go h.presenceLoop()
+    go h.hypeLoop()
+
+    h.lastLoop.Store(time.Now().UnixNano())
+    go h.watchdog()
+    h.loop(h.loopGen.Load())
+}
 
-    for {
-        select {
-        case client := <-h.register:
-            h.handleRegister(client)
-
-        case client := <-h.unregister:
-            h.handleUnregister(client)
-
-        case message := <-h.broadcast:
-            h.handleBroadcast(message)
+func (h *Hub) handleRegister(client *Client) {
+    h.usersMu.Lock()
+    user, connected := h.users[client.user.ID]
+    if !connected {
+        user = &connectedUser{
+            clients:         make(map[*Client]bool),
+            goalFlashOptOut: client.user.GoalFlashOptOut,
+            favoriteTeam:    client.user.FavoriteTeam,
         }
+        h.users[client.user.ID] = user
     }
-}
+    user.clients[client] = true
+    h.usersMu.Unlock()
 
-func (h *Hub) handleRegister(client *Client) {
-    h.mu.Lock()
-    defer h.mu.Unlock()
+    if len(client.tickerComps) > 0 {
+        h.ticker.set(client, client.tickerComps)
+    }
 
-    h.clients[client] = true
+    if !connected {
+        go h.ReloadUserAlerts(client.user.ID)
+        go h.ReloadQuietHours(client.user.ID)
+    }
 
     // Send recent match events and chat history
     go h.sendInitialData(client)
 
-    // Broadcast user join to relevant rooms
+    // Redirects are held until the client has joined, so a room cannot
+    // be redirected between resolving and joining it
+    h.redirectMu.RLock()
+    h.resolveRooms(client)
+    var joins []*models.WSMessage
+    client.mu.RLock()
     for room := range client.rooms {
-        if _, exists := h.rooms[room]; !exists {
-            h.rooms[room] = make(map[*Client]bool)
+        h.addToRoom(room, client)
+
+        // Rooms restored from a resumed connection were joined on it
+        if client.restoredRoom(room) {
+            continue
         }
-        h.rooms[room][client] = true
 
-        joinMsg := &models.WSMessage{
+        joins = append(joins, &models.WSMessage{
             Type:      models.MessageTypeJoin,
             ChatRoom:  room,
-            User:      client.user,
+            User:      client.summary(),
             Timestamp: time.Now(),
-        }
-        h.broadcastToRoom(room, joinMsg)
+        })
+    }
+    client.mu.RUnlock()
+    h.redirectMu.RUnlock()
+
+    for _, joinMsg := range joins {
+        h.broadcastToRoom(joinMsg.ChatRoom, joinMsg)
+        h.events.Publish(events.RoomJoined{
+            UserID: client.user.ID,
+            RoomID: joinMsg.ChatRoom,
+            At:     joinMsg.Timestamp,
+        })
     }
 
     // Update metrics
     h.metrics.ConnectedClients.Inc()
+    h.shedder.connections.Add(1)
 }
 
 func (h *Hub) handleUnregister(client *Client) {
-    h.mu.Lock()
-    defer h.mu.Unlock()
-
-    if _, ok := h.clients[client]; ok {
-        delete(h.clients, client)
-        close(client.send)
-
-        // Remove from all rooms and broadcast leave message
-        for room := range client.rooms {
-            if clients, exists := h.rooms[room]; exists {
-                delete(clients, client)
-                if len(clients) == 0 {
-                    delete(h.rooms, room)
-                }
+    // A client can be unregistered by its read pump and by a full send
+    // buffer; only the first counts
+    h.usersMu.Lock()
+    user, connected := h.users[client.user.ID]
+    if !connected || !user.clients[client] {
+        h.usersMu.Unlock()
+        return
+    }
+    delete(user.clients, client)
+    last := len(user.clients) == 0
+    if last {
+        delete(h.users, client.user.ID)
+    }
+    h.usersMu.Unlock()
 
-                leaveMsg := &models.WSMessage{
-                    Type:      models.MessageTypeLeave,
-                    ChatRoom:  room,
-                    User:      client.user,
-                    Timestamp: time.Now(),
-                }
-                h.broadcastToRoom(room, leaveMsg)
-            }
+    if last {
+        h.alerts.RemoveUser(client.user.ID)
+    }
+    h.ticker.remove(client)
+
+    // Remove from all rooms, announcing the leaves. Marked gone under the
+    // same lock, so a subscription racing the disconnect cannot add the
+    // client back to a room.
+    var leaves []*models.WSMessage
+    client.mu.Lock()
+    client.gone = true
+    for room := range client.rooms {
+        if h.removeFromRoom(room, client) {
+            leaves = append(leaves, &models.WSMessage{
+                Type:      models.MessageTypeLeave,
+                ChatRoom:  room,
+                User:      client.summary(),
+                Timestamp: time.Now(),
+            })
         }
-
-        // Update metrics
-        h.metrics.ConnectedClients.Dec()
     }
+    client.mu.Unlock()
+    client.closeSend()
+
+    // A resumable connection's leaves wait to see whether it comes back
+    if client.resume != nil {
+        go h.holdLeaves(client, leaves)
+    } else {
+        for _, leaveMsg := range leaves {
+            h.broadcastToRoom(leaveMsg.ChatRoom, leaveMsg)
+        }
+    }
+    go client.leaveAllVoice()
+
+    // Update metrics
+    h.metrics.ConnectedClients.Dec()
+    h.shedder.connections.Add(-1)
 }
 
-func (h *Hub) handleBroadcast(message *models.WSMessage) {
+func (h *Hub) handleBroadcast(ctx context.Context, message *models.WSMessage) {
+    // Only chat is posted through the hub loop. Match events, goal flashes
+    // and the like are the server's, and a client's copy is dropped here
+    // as well as in readPump.
+    if message.Type != models.MessageTypeChat {
+        h.logger.Warn("Dropped posted frame of a server-only type",
+            zap.String("type", message.Type),
+            zap.String("room", message.ChatRoom))
+        return
+    }
+
+    // Shadow-banned senders see their messages; no one else does
+    if message.Shadowed {
+        h.echoShadowed(ctx, message)
+        return
+    }
+
     // Validate rate limits
-    if !h.checkRateLimit(message.ChatRoom) {
+    if message.Exemption != "" {
+        h.metrics.RateLimitExemptions.WithLabelValues("ws_room", message.Exemption).Inc()
+    } else if !h.checkRateLimit(message.ChatRoom) {
+        h.metrics.RateLimited.WithLabelValues("ws_room").Inc()
         return
     }
 
-    // Store chat message if it's a chat type message
-    if message.Type == models.MessageTypeChat {
-        go h.persistMessage(message)
+    // Retries of an accepted message are acknowledged, not posted again
+    if h.acknowledged(message) {
+        return
     }
 
+    // Store the message
+    message.ID = uuid.NewString()
+    message.Seq = h.nextSeq(ctx, message.ChatRoom)
+    h.acknowledge(message)
+    message.MatchMinute, message.MatchPeriod = h.matchClock(message.ChatRoom)
+    h.persistMessage(ctx, message)
+    h.events.Publish(events.MessageSent{
+        UserID: message.User.ID,
+        RoomID: message.ChatRoom,
+        At:     message.Timestamp,
+    })
+    h.notifyMentions(message)
+    h.notifyKeywordAlerts(message)
+    h.clearDraft(message.User.ID, message.ChatRoom)
+
     // Broadcast to room
-    h.broadcastToRoom(message.ChatRoom, message)
+    h.broadcastToRoomContext(ctx, message.ChatRoom, message, postedPriority(message))
 
     // Update metrics
     h.metrics.MessagesSent.Inc()
 }
 
 func (h *Hub) broadcastToRoom(room string, message *models.WSMessage) {
+    h.broadcastToRoomContext(context.Background(), room, message, priorityOf(message))
+}
+
+// broadcastToRoomContext is broadcastToRoom continuing the trace in ctx
+// on every instance that delivers the frame, at the given fan-out
+// priority.
+func (h *Hub) broadcastToRoomContext(ctx context.Context, room string, message *models.WSMessage, priority int) {
     payload, err := json.Marshal(message)
     if err != nil {
         h.logger.Error("Failed to marshal message",
@@ -172,103 +654,480 @@ func (h *Hub) broadcastToRoom(room string, message *models.WSMessage) {
         return
     }
 
-    h.mu.RLock()
-    clients := h.rooms[room]
-    h.mu.RUnlock()
+    if h.journal != nil {
+        h.journal.Append(room, payload)
+    }
+    h.publish(&broker.Message{
+        Room:        room,
+        Payload:     payload,
+        ExcludeConn: message.ExcludeConn,
+        Priority:    priority,
+        Trace:       tracing.Inject(ctx),
+    })
+}
+
+// Announce sends a server-originated frame to a room on every instance.
+// Callers must make sure only one instance announces each frame.
+func (h *Hub) Announce(room string, message *models.WSMessage) {
+    h.broadcastToRoom(room, message)
+}
 
-    for client := range clients {
-        select {
-        case client.send <- payload:
-        default:
-            go h.unregister <- client
+// RoomStateChanged tells a room's clients on every instance that the room
+// opened, became read-only or was archived.
+func (h *Hub) RoomStateChanged(change *models.RoomStateChange) {
+    data, err := json.Marshal(change)
+    if err != nil {
+        return
+    }
+    message := &models.WSMessage{
+        Type:      models.MessageTypeRoomState,
+        ChatRoom:  change.RoomID,
+        Data:      data,
+        Timestamp: change.ChangedAt,
+    }
+    payload, err := json.Marshal(message)
+    if err != nil {
+        return
+    }
+
+    if h.journal != nil {
+        h.journal.Append(change.RoomID, payload)
+    }
+    h.publish(&broker.Message{Room: change.RoomID, Payload: payload, RoomChanged: true, Priority: PriorityHigh})
+}
+
+func (h *Hub) publish(msg *broker.Message) {
+    ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+    defer cancel()
+
+    if err := h.broker.Publish(ctx, msg); err != nil {
+        h.logger.Error("Failed to publish to room",
+            zap.Error(err),
+            zap.String("room", msg.Room))
+        // Keep the room working for this instance's clients at least
+        h.fanout.enqueue(msg)
+    }
+}
+
+// broadcastLocal delivers to this instance's clients only. It is for
+// frames every instance produces on its own, such as match updates from
+// polling, where publishing would deliver duplicates.
+func (h *Hub) broadcastLocal(room string, message *models.WSMessage) {
+    payload, err := json.Marshal(message)
+    if err != nil {
+        h.logger.Error("Failed to marshal message",
+            zap.Error(err),
+            zap.String("room", room))
+        return
+    }
+
+    if h.journal != nil {
+        h.journal.Append(room, payload)
+    }
+    h.fanout.enqueue(&broker.Message{Room: room, Payload: payload, Priority: priorityOf(message)})
+}
+
+// deliverToRoom writes a frame to the room's clients connected to this
+// instance. Frames reach it through the fan-out scheduler.
+func (h *Hub) deliverToRoom(msg *broker.Message) {
+    // Only frames published under a trace get a delivery span
+    if ctx := tracing.Extract(context.Background(), msg.Trace); trace.SpanContextFromContext(ctx).IsValid() {
+        _, span := tracing.Start(ctx, "ws.deliver", trace.WithSpanKind(trace.SpanKindConsumer),
+            trace.WithAttributes(attribute.String("room", msg.Room)))
+        defer span.End()
+    }
+
+    if msg.Disconnect != "" {
+        h.disconnectUser(msg.Disconnect)
+        return
+    }
+    if msg.ShadowBanned != "" {
+        go h.reloadShadowBan(msg.ShadowBanned)
+        return
+    }
+    if msg.MatchPushed {
+        h.applyPushedMatch(msg.Payload)
+        return
+    }
+    if msg.Hype {
+        h.applyHype(msg)
+        return
+    }
+    if msg.Everyone {
+        h.deliverToEveryone(msg)
+        return
+    }
+    if msg.Ticker != "" {
+        h.deliverToTicker(msg)
+        return
+    }
+    if len(msg.Users) > 0 {
+        h.deliverToUsers(msg)
+        return
+    }
+    if msg.Sanctioned != "" {
+        // Deferred before the read lock so it runs after the notice has
+        // gone out: a banned user learns why before being evicted.
+        defer func() { go h.refreshSanctions(msg.Room, msg.Sanctioned) }()
+    }
+    if msg.Renamed != "" {
+        h.applyProfile(msg.Renamed, msg.Payload)
+    }
+    if msg.RoleChanged != "" {
+        h.roles.Forget(msg.Room, msg.RoleChanged)
+    }
+    if msg.RoomChanged {
+        h.roomCacheMu.Lock()
+        delete(h.roomCache, msg.Room)
+        h.roomCacheMu.Unlock()
+    }
+    if msg.AgeGated {
+        // After the notice, like a ban, so members turned away see why
+        defer func() { go h.enforceAgeGate(msg.Room) }()
+    }
+    if msg.RoomDeleted {
+        // After the room_deleted frame, so clients learn where to go
+        // rather than being dropped silently
+        defer func() { go h.closeRoom(msg.Room) }()
+    }
+    h.touchWarm(msg)
+
+    // Compressing connections share one prepared message, so the payload
+    // is compressed once however many of them are in the room
+    var prepared *websocket.PreparedMessage
+    localized := h.localize(msg)
+    var seq int64
+    if h.resume != nil {
+        seq = frameSeq(msg.Payload)
+    }
+    size, delivered := 0, 0
+    h.eachRoomClient(msg.Room, func(client *Client) {
+        size++
+        if msg.ExcludeUser != "" && client.user.ID == msg.ExcludeUser {
+            return
+        }
+        if msg.ExcludeConn != "" && client.id == msg.ExcludeConn {
+            return
         }
+        if msg.TargetUser != "" && client.user.ID != msg.TargetUser {
+            return
+        }
+        frame := outFrame{payload: msg.Payload, room: msg.Room, seq: seq}
+        if localized != nil {
+            frame.payload = localized.payload(client.locale)
+        } else if client.compresses(len(msg.Payload)) {
+            if prepared == nil {
+                prepared = h.prepare(msg.Payload)
+            }
+            frame.prepared = prepared
+        }
+        if client.enqueueFrame(frame) {
+            delivered++
+        }
+    })
+    if delivered > 0 {
+        msgType := frameType(msg.Payload)
+        h.countOutbound(msgType, roomSizeBucket(size), len(msg.Payload), delivered)
+        h.throughput.record(msg.Room, msgType == models.MessageTypeChat, delivered, time.Now())
     }
+    h.deliverToStreams(msg)
+}
+
+// Subscribe connects the hub to its broker. It must be called once
+// before Run.
+func (h *Hub) Subscribe() error {
+    return h.broker.Subscribe(h.fanout.enqueue)
+}
+
+// SendToUser delivers a message to every connection of the given user,
+// regardless of which rooms they are in.
+func (h *Hub) SendToUser(userID string, message *models.WSMessage) {
+    payload, err := json.Marshal(message)
+    if err != nil {
+        h.logger.Error("Failed to marshal message",
+            zap.Error(err),
+            zap.String("user_id", userID))
+        return
+    }
+
+    delivered := 0
+    h.eachUserClient(userID, func(client *Client) {
+        if client.enqueue(payload) {
+            delivered++
+        }
+    })
+    h.countOutbound(frameLabel(message.Type), roomSizeNone, len(payload), delivered)
+}
+
+// ReloadUserAlerts refreshes the active keyword alerts of a connected
+// user after they change.
+func (h *Hub) ReloadUserAlerts(userID string) {
+    if !h.isConnected(userID) {
+        return
+    }
+
+    ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+    defer cancel()
+
+    userAlerts, err := h.store.GetUserKeywordAlerts(ctx, userID)
+    if err != nil {
+        h.logger.Error("Failed to load keyword alerts",
+            zap.Error(err),
+            zap.String("user_id", userID))
+        return
+    }
+    h.alerts.SetUserAlerts(userID, userAlerts)
+}
+
+func (h *Hub) notifyKeywordAlerts(message *models.WSMessage) {
+    for _, hit := range h.alerts.Match(message.ChatRoom, message.Content) {
+        // Mentioned users already get a mention notification
+        if hit.UserID == message.User.ID || mentions(message, hit.UserID) {
+            continue
+        }
+        if quiet, _ := h.userQuietHours(hit.UserID); !quiethours.Allows(quiet, time.Now(), false) {
+            continue
+        }
+
+        data, err := json.Marshal(map[string]string{"keyword": hit.Keyword})
+        if err != nil {
+            continue
+        }
+        h.SendToUser(hit.UserID, &models.WSMessage{
+            Type:      models.MessageTypeAlert,
+            ChatRoom:  message.ChatRoom,
+            Content:   message.Content,
+            User:      message.User,
+            Timestamp: message.Timestamp,
+            Data:      data,
+        })
+    }
+}
+
+// roomSettings returns a room's settings, caching lookups briefly so
+// admin changes still propagate. It may hit the store, so it is called
+// from client and persistence goroutines, never from the hub loop.
+func (h *Hub) roomSettings(room string) *models.ChatRoom {
+    h.roomCacheMu.RLock()
+    cached, ok := h.roomCache[room]
+    h.roomCacheMu.RUnlock()
+    if ok && time.Since(cached.loadedAt) < roomCacheTTL {
+        return cached.room
+    }
+
+    ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+    defer cancel()
+
+    chatRoom, err := h.store.GetChatRoom(ctx, room)
+    if err != nil {
+        h.logger.Warn("Failed to load room settings",
+            zap.Error(err),
+            zap.String("room", room))
+        return &models.ChatRoom{ID: room, Language: moderation.DefaultLocale}
+    }
+    if chatRoom.Topics, err = h.store.GetRoomTopics(ctx, room); err != nil {
+        h.logger.Warn("Failed to load room topics",
+            zap.Error(err),
+            zap.String("room", room))
+    }
+
+    h.roomCacheMu.Lock()
+    h.roomCache[room] = &cachedRoom{room: chatRoom, loadedAt: time.Now()}
+    h.roomCacheMu.Unlock()
+
+    return chatRoom
+}
+
+func (h *Hub) roomLocale(room string) string {
+    return h.roomSettings(room).Language
+}
+
+func (h *Hub) roomUserIDs(room string) []string {
+    seen := make(map[string]bool)
+    var userIDs []string
+    h.eachRoomClient(room, func(client *Client) {
+        if !seen[client.user.ID] {
+            seen[client.user.ID] = true
+            userIDs = append(userIDs, client.user.ID)
+        }
+    })
+    return userIDs
 }
 
 func (h *Hub) checkRateLimit(room string) bool {
-    h.mu.Lock()
+    h.limiterMu.Lock()
     limiter, exists := h.roomLimiters[room]
     if !exists {
         limiter = rate.NewLimiter(rate.Every(time.Second), 10) // 10 messages per second per room
         h.roomLimiters[room] = limiter
     }
-    h.mu.Unlock()
+    h.limiterMu.Unlock()
 
     return limiter.Allow()
 }
 
-func (h *Hub) persistMessage(message *models.WSMessage) {
-    ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+// unfurlMessage fetches previews for links in a persisted message, stores
+// them and sends them as a follow-up frame to clients that support
+// previews. The chat message itself is never held back for unfurling.
+func (h *Hub) unfurlMessage(message *models.WSMessage) {
+    urls := unfurl.ExtractURLs(message.Content)
+    if len(urls) == 0 {
+        return
+    }
+
+    ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
     defer cancel()
 
-    msg := &models.Message{
-        ChatRoomID:  message.ChatRoom,
-        UserID:      message.User.ID,
-        Content:     message.Content,
-        MessageType: models.MessageTypeChat,
-        CreatedAt:   message.Timestamp,
+    var previews []*models.LinkPreview
+    for _, u := range urls {
+        preview, err := h.unfurler.Unfurl(ctx, u)
+        if err != nil {
+            h.logger.Debug("Failed to unfurl link", zap.Error(err), zap.String("url", u))
+            continue
+        }
+        previews = append(previews, preview)
+    }
+    if len(previews) == 0 {
+        return
     }
 
-    if err := h.store.CreateMessage(ctx, msg); err != nil {
-        h.logger.Error("Failed to persist message",
+    if err := h.store.SetMessagePreviews(ctx, message.ID, previews); err != nil {
+        h.logger.Error("Failed to store link previews",
             zap.Error(err),
-            zap.String("room", message.ChatRoom),
-            zap.String("user_id", message.User.ID))
+            zap.String("message_id", message.ID))
     }
+
+    data, err := json.Marshal(previews)
+    if err != nil {
+        return
+    }
+    payload, err := json.Marshal(&models.WSMessage{
+        ID:        message.ID,
+        Type:      models.MessageTypeLinkPreview,
+        ChatRoom:  message.ChatRoom,
+        Data:      data,
+        Timestamp: time.Now(),
+    })
+    if err != nil {
+        return
+    }
+
+    h.eachRoomClient(message.ChatRoom, func(client *Client) {
+        if client.caps[CapabilityLinkPreviews] {
+            client.trySend(payload)
+        }
+    })
 }
 
 func (h *Hub) sendInitialData(client *Client) {
     ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
     defer cancel()
 
+    drafts := h.userDrafts(ctx, client.user.ID)
+
+    client.mu.RLock()
+    rooms := make([]string, 0, len(client.rooms))
     for room := range client.rooms {
-        // Send recent messages
-        messages, err := h.store.GetRecentMessages(ctx, room, 50)
+        rooms = append(rooms, room)
+    }
+    client.mu.RUnlock()
+
+    for _, room := range rooms {
+        if !h.sendRoomData(ctx, client, room, drafts[room]) {
+            return
+        }
+    }
+}
+
+// sendRoomData sends what a client joining a room starts from: its
+// recent history, topics, the user's draft and the match. It reports
+// false once the client's buffer is full.
+func (h *Hub) sendRoomData(ctx context.Context, client *Client, room string, draft *models.Draft) bool {
+    // Send only the room's initial budget of recent messages, or what a
+    // reconnecting client missed, followed by a history frame with the
+    // counts; clients fetch older messages on demand with a history
+    // command.
+    page, err := h.initialPage(ctx, client, room)
+    if err != nil {
+        h.logger.Error("Failed to get recent messages",
+            zap.Error(err),
+            zap.String("room", room))
+        return true
+    }
+
+    for _, wsMsg := range page.Messages {
+        payload, err := json.Marshal(wsMsg)
         if err != nil {
-            h.logger.Error("Failed to get recent messages",
-                zap.Error(err),
-                zap.String("room", room))
             continue
         }
 
-        for _, msg := range messages {
-            wsMsg := &models.WSMessage{
-                Type:      models.MessageTypeChat,
-                ChatRoom:  room,
-                Content:   msg.Content,
-                User:      msg.User,
-                Timestamp: msg.CreatedAt,
-            }
+        if !client.trySend(payload) {
+            return false
+        }
+    }
 
-            payload, err := json.Marshal(wsMsg)
-            if err != nil {
-                continue
+    page.Messages = nil
+    if stats, err := h.store.GetRoomStatistics(ctx, room); err == nil {
+        page.Total = stats.MessageCount
+    }
+    if data, err := json.Marshal(page); err == nil {
+        payload, err := json.Marshal(&models.WSMessage{
+            Type:      models.MessageTypeHistory,
+            ChatRoom:  room,
+            Data:      data,
+            Timestamp: time.Now(),
+        })
+        if err == nil {
+            if !client.trySend(payload) {
+                return false
             }
+        }
+    }
 
-            select {
-            case client.send <- payload:
-            default:
-                return
-            }
+    // Rooms in topics mode tell the client which threads to show
+    if topics := h.roomSettings(room).Topics; len(topics) > 0 {
+        if payload, ok := topicsFrame(room, topics); ok && !client.trySend(payload) {
+            return false
         }
+    }
 
-        // Send match data if available
-        // Send match data if available
-        h.matchMu.RLock()
-        if match, exists := h.matches[room]; exists {
-            matchMsg := &models.WSMessage{
-                Type:      models.MessageTypeEvent,
-                ChatRoom:  room,
-                Match:     match,
-                Timestamp: time.Now(),
+    // Restore the user's unsent draft, possibly from another device
+    if draft != nil && draft.Content != "" {
+        if payload, err := json.Marshal(draftMessage(draft)); err == nil {
+            if !client.trySend(payload) {
+                return false
             }
+        }
+    }
 
-            payload, err := json.Marshal(matchMsg)
-            if err == nil {
-                client.send <- payload
-            }
+    // Send match data if available, or the match as scheduled when the
+    // room was readied for its kickoff
+    h.matchMu.RLock()
+    match, live := h.matches[room]
+    if live {
+        sendMatchFrame(client, room, match)
+    }
+    h.matchMu.RUnlock()
+    if !live {
+        if match, ok := h.warmMatch(room); ok {
+            h.metrics.PrewarmHits.WithLabelValues("match").Inc()
+            sendMatchFrame(client, room, match)
         }
-        h.matchMu.RUnlock()
+    }
+    return true
+}
+
+func sendMatchFrame(client *Client, room string, match *models.Match) {
+    matchMsg := &models.WSMessage{
+        Type:      models.MessageTypeEvent,
+        ChatRoom:  room,
+        Match:     match,
+        Timestamp: time.Now(),
+    }
+
+    payload, err := json.Marshal(matchMsg)
+    if err == nil {
+        client.trySend(payload)
     }
 }
 
@@ -280,6 +1139,9 @@ func (h *Hub) updateMatches() {
     for {
         select {
         case <-ticker.C:
+            if h.provider != nil {
+                h.syncFromProvider()
+            }
             h.fetchMatchUpdates()
         }
     }
@@ -299,43 +1161,164 @@ func (h *Hub) fetchMatchUpdates() {
     h.matchMu.Lock()
     defer h.matchMu.Unlock()
 
+    live := make(map[string]bool, len(matches))
     for _, match := range matches {
-        roomID := match.ID // Using match ID as room ID
-        existingMatch, exists := h.matches[roomID]
-
-        // Check if match needs update
-        if !exists || matchNeedsUpdate(existingMatch, match) {
-            h.matches[roomID] = match
-
-            // Broadcast update
-            updateMsg := &models.WSMessage{
-                Type:      models.MessageTypeEvent,
-                ChatRoom:  roomID,
-                Match:     match,
-                Timestamp: time.Now(),
-            }
+        live[match.ID] = true
+        h.updateMatch(match)
+    }
+
+    // Matches that dropped out of the live set have finished or been
+    // cancelled; look them up once to find out which.
+    for roomID := range h.matches {
+        if live[roomID] {
+            continue
+        }
+        delete(h.matches, roomID)
 
-            h.broadcast <- updateMsg
+        match, err := h.store.GetMatch(ctx, roomID)
+        if err != nil {
+            h.logger.Error("Failed to fetch match",
+                zap.Error(err),
+                zap.String("match_id", roomID))
+            continue
+        }
+        if match.Status == models.MatchStatusFinished {
+            h.events.Publish(events.MatchFinished{
+                MatchID: match.ID,
+                RoomID:  roomID,
+                Derby:   match.IsDerby(),
+                UserIDs: h.roomUserIDs(roomID),
+            })
         }
     }
 }
 
+// updateMatch sends this instance's clients a live match's changes since
+// the copy it holds, then holds the new one. Must be called with matchMu
+// held.
+func (h *Hub) updateMatch(match *models.Match) {
+    roomID := match.ID // Using match ID as room ID
+    existingMatch, exists := h.matches[roomID]
+    // Kept current even when unchanged so the match clock advances
+    h.matches[roomID] = match
+
+    // Check if match needs update
+    if exists && !matchNeedsUpdate(existingMatch, match) {
+        return
+    }
+
+    // Shootouts are rendered kick-by-kick, so each new kick gets its own
+    // frame in addition to the match update.
+    for _, kick := range newShootoutKicks(existingMatch, match) {
+        h.broadcastLocal(roomID, shootoutMessage(roomID, match.Shootout, kick))
+    }
+
+    // Broadcast update
+    updateMsg := &models.WSMessage{
+        Type:      models.MessageTypeEvent,
+        ChatRoom:  roomID,
+        Match:     match,
+        Timestamp: time.Now(),
+    }
+
+    h.broadcastLocal(roomID, updateMsg)
+
+    if side := scoringSide(existingMatch, match); side != "" {
+        h.flashGoal(match, side)
+        h.tickGoal(match, side)
+    }
+}
+
+// matchClock returns the current minute and period of a live match
+// room, or nil when the room is not live or the minute is unknown. Match
+// rooms share their match's ID.
+func (h *Hub) matchClock(room string) (*int, string) {
+    h.matchMu.RLock()
+    defer h.matchMu.RUnlock()
+
+    match, ok := h.matches[room]
+    if !ok || match.Status != models.MatchStatusLive || match.Minute <= 0 {
+        return nil, ""
+    }
+    minute := match.Minute
+    return &minute, match.Period
+}
+
 func matchNeedsUpdate(old, new *models.Match) bool {
     if old.HomeScore != new.HomeScore || old.AwayScore != new.AwayScore {
         return true
     }
-    if old.Status != new.Status {
+    if old.Status != new.Status || old.Period != new.Period {
+        return true
+    }
+    if len(newShootoutKicks(old, new)) > 0 {
+        return true
+    }
+    if old.Shootout != nil && new.Shootout != nil && old.Shootout.Winner != new.Shootout.Winner {
         return true
     }
     return false
 }
 
+// newShootoutKicks returns the kicks in new that old had not seen yet.
+func newShootoutKicks(old, new *models.Match) []*models.ShootoutKick {
+    if new.Shootout == nil {
+        return nil
+    }
+    seen := 0
+    if old != nil && old.Shootout != nil {
+        seen = len(old.Shootout.Kicks)
+    }
+    if len(new.Shootout.Kicks) <= seen {
+        return nil
+    }
+    return new.Shootout.Kicks[seen:]
+}
+
+type shootoutFrame struct {
+    Kick      *models.ShootoutKick `json:"kick"`
+    HomeScore int                  `json:"home_score"`
+    AwayScore int                  `json:"away_score"`
+    Winner    string               `json:"winner,omitempty"`
+}
+
+func shootoutMessage(room string, shootout *models.Shootout, kick *models.ShootoutKick) *models.WSMessage {
+    // Running score as of this kick, so clients replaying frames in order
+    // render the shootout progressively.
+    frame := shootoutFrame{Kick: kick}
+    for _, k := range shootout.Kicks {
+        if k.Order > kick.Order {
+            break
+        }
+        if k.Outcome == models.KickScored {
+            if k.Side == "home" {
+                frame.HomeScore++
+            } else {
+                frame.AwayScore++
+            }
+        }
+    }
+    if kick.Order == len(shootout.Kicks) {
+        frame.Winner = shootout.Winner
+    }
+
+    data, _ := json.Marshal(frame)
+    return &models.WSMessage{
+        Type:      models.MessageTypeShootout,
+        ChatRoom:  room,
+        Data:      data,
+        Timestamp: time.Now(),
+    }
+}
+
 // Client write pump
 func (c *Client) writePump() {
+    c.hub.pumps.Add(1)
     ticker := time.NewTicker(54 * time.Second)
     defer func() {
         ticker.Stop()
         c.conn.Close()
+        c.hub.pumps.Add(-1)
     }()
 
     for {
@@ -347,27 +1330,73 @@ func (c *Client) writePump() {
                 return
             }
 
+            if c.coalesce > 0 {
+                frames, open := c.collectBatch(message)
+                c.conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
+                if err := c.writeBatch(frames); err != nil {
+                    return
+                }
+                for _, frame := range frames {
+                    c.wrote(frame)
+                }
+                if !open {
+                    c.conn.WriteMessage(websocket.CloseMessage, []byte{})
+                    return
+                }
+                continue
+            }
+
+            if message.prepared != nil {
+                if err := c.writePrepared(message); err != nil {
+                    return
+                }
+                c.wrote(message)
+                continue
+            }
+
+            // A batch is at least as large as its first frame
+            compressed := c.compresses(len(message.payload))
+            c.conn.EnableWriteCompression(compressed)
             w, err := c.conn.NextWriter(websocket.TextMessage)
             if err != nil {
                 return
             }
 
-            w.Write(message)
+            w.Write(message.payload)
+            written := []outFrame{message}
 
-            // Add queued chat messages to the current websocket message
+            // Add queued chat messages to the current websocket message.
+            // Senders dropping the oldest frames may empty the buffer
+            // meanwhile, so this never waits for one.
             n := len(c.send)
+        queued:
             for i := 0; i < n; i++ {
-                w.Write([]byte{'\n'})
-                w.Write(<-c.send)
+                select {
+                case next, ok := <-c.send:
+                    if !ok {
+                        break queued
+                    }
+                    w.Write([]byte{'\n'})
+                    w.Write(next.payload)
+                    written = append(written, next)
+                default:
+                    break queued
+                }
             }
 
             if err := w.Close(); err != nil {
                 return
             }
+            for _, frame := range written {
+                c.wrote(frame)
+            }
+            if compressed {
+                c.hub.metrics.WSCompressedMessages.WithLabelValues("false").Inc()
+            }
 
         case <-ticker.C:
             c.conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
-            if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+            if err := c.conn.WriteMessage(websocket.PingMessage, c.pingPayload()); err != nil {
                 return
             }
         }
@@ -376,21 +1405,28 @@ func (c *Client) writePump() {
 
 // Client read pump
 func (c *Client) readPump() {
+    c.hub.pumps.Add(1)
     defer func() {
         c.hub.unregister <- c
         c.conn.Close()
+        c.hub.pumps.Add(-1)
     }()
 
     c.conn.SetReadLimit(maxMessageSize)
     c.conn.SetReadDeadline(time.Now().Add(pongWait))
-    c.conn.SetPongHandler(func(string) error {
+    c.conn.SetPongHandler(func(appData string) error {
         c.conn.SetReadDeadline(time.Now().Add(pongWait))
+        c.ponged(appData)
         return nil
     })
 
     for {
         _, message, err := c.conn.ReadMessage()
         if err != nil {
+            // A client closing on purpose is gone, not dropped
+            if c.resume != nil && websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+                c.resume.ended.Store(true)
+            }
             if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
                 c.hub.logger.Error("Websocket read error",
                     zap.Error(err),
@@ -401,44 +1437,204 @@ func (c *Client) readPump() {
 
         var wsMessage models.WSMessage
         if err := json.Unmarshal(message, &wsMessage); err != nil {
+            c.hub.countInbound("", frameInvalid, len(message))
             c.hub.logger.Error("Failed to unmarshal message",
                 zap.Error(err),
                 zap.String("user_id", c.user.ID))
             continue
         }
+        if wsMessage.Type == models.MessageTypeDM || wsMessage.Type == models.MessageTypeTicker || !c.canAccessRoom(wsMessage.ChatRoom) {
+            c.hub.countInbound("", frameLabel(wsMessage.Type), len(message))
+        } else {
+            c.hub.countInbound(wsMessage.ChatRoom, frameLabel(wsMessage.Type), len(message))
+        }
+
+        // Everything else is the server's to send; a client's copy would
+        // go out to the room as if the server had sent it
+        if !clientMessageTypes[wsMessage.Type] {
+            c.sendError(&wsMessage, models.ErrorInvalidRequest, "Unsupported message type")
+            continue
+        }
 
         // Rate limit check
-        if !c.limiter.Allow() {
-            errorMsg := &models.WSMessage{
-                Type:    models.MessageTypeError,
-                Content: "Rate limit exceeded",
-            }
-            if payload, err := json.Marshal(errorMsg); err == nil {
-                c.send <- payload
-            }
+        if exemption := c.principal.Exemption(); exemption != "" {
+            wsMessage.Exemption = exemption
+            c.hub.metrics.RateLimitExemptions.WithLabelValues("ws_client", exemption).Inc()
+        } else if !skipsRateLimit(wsMessage.Type) && !c.allow(&wsMessage) {
             continue
         }
 
         // Add user and timestamp to message
-        wsMessage.User = c.user
+        wsMessage.User = c.summary()
         wsMessage.Timestamp = time.Now()
 
+        // Direct messages are not sent to a room
+        if wsMessage.Type == models.MessageTypeDM {
+            c.handleDM(&wsMessage)
+            continue
+        }
+
+        // Nor are ticker subscriptions, which follow competitions
+        if wsMessage.Type == models.MessageTypeTicker {
+            c.handleTicker(&wsMessage)
+            continue
+        }
+
+        // Room subscriptions change which rooms the connection is in
+        switch wsMessage.Type {
+        case models.MessageTypeSubscribe:
+            c.handleSubscribe(&wsMessage)
+            continue
+        case models.MessageTypeUnsubscribe:
+            c.handleUnsubscribe(&wsMessage)
+            continue
+        }
+
         // Validate room membership
         if !c.canAccessRoom(wsMessage.ChatRoom) {
-            errorMsg := &models.WSMessage{
-                Type:    models.MessageTypeError,
-                Content: "Room access denied",
+            c.sendError(&wsMessage, models.ErrorRoomAccessDenied, "Room access denied")
+            continue
+        }
+
+        // Enforce room mutes and bans; admins cannot be sanctioned
+        if !c.principal.IsAdmin {
+            muted, banned := c.hub.sanctionsFor(wsMessage.ChatRoom, c.user.ID)
+            if banned {
+                c.sendError(&wsMessage, models.ErrorBanned, "You are banned from this room")
+                continue
+            }
+            // Rooms gated after the user joined turn them away here
+            if !c.hub.roomSettings(wsMessage.ChatRoom).Admits(c.user, wsMessage.Timestamp) {
+                c.sendError(&wsMessage, models.ErrorAgeRestricted, "This room is age restricted")
+                continue
             }
-            if payload, err := json.Marshal(errorMsg); err == nil {
-                c.send <- payload
+            if muted && blockedWhenMuted(wsMessage.Type) {
+                c.sendError(&wsMessage, models.ErrorMuted, "You are muted in this room")
+                continue
+            }
+            if blockedWhenMuted(wsMessage.Type) && !c.hub.roomSettings(wsMessage.ChatRoom).AcceptsPostsIn(wsMessage.TopicID) {
+                c.sendError(&wsMessage, models.ErrorRoomClosed, "This room is not open for chat")
+                continue
             }
+            if !c.mayPost(&wsMessage) {
+                c.sendError(&wsMessage, models.ErrorBroadcastOnly, "Only broadcasters can post in this room")
+                continue
+            }
+        }
+
+        // History and emote requests, drafts, typing indicators and
+        // reactions are handled directly rather than through the hub loop
+        switch wsMessage.Type {
+        case models.MessageTypeHistory:
+            c.handleHistoryRequest(&wsMessage)
+            continue
+        case models.MessageTypeEmotes:
+            c.handleEmotes(&wsMessage)
+            continue
+        case models.MessageTypeDraft:
+            c.handleDraft(&wsMessage)
+            continue
+        case models.MessageTypeRead:
+            c.handleRead(&wsMessage)
             continue
+        case models.MessageTypeTyping:
+            // Typists never see their own indicator, so a shadow-banned
+            // one's can be dropped unnoticed
+            if !c.shadowBanned() {
+                c.handleTyping(&wsMessage)
+            }
+            continue
+        case models.MessageTypeReaction:
+            c.handleReaction(&wsMessage)
+            continue
+        case models.MessageTypeVoice:
+            c.handleVoice(&wsMessage)
+            continue
+        case models.MessageTypeModerate:
+            c.handleModerate(&wsMessage)
+            continue
+        case models.MessageTypeChat:
+            if len(wsMessage.ClientMsgID) > maxClientMsgIDLength {
+                c.sendError(&wsMessage, models.ErrorInvalidRequest, "Client message ID is too long")
+                continue
+            }
+            if utf8.RuneCountInString(wsMessage.Content) > maxChatLength {
+                c.sendError(&wsMessage, models.ErrorMessageTooLong,
+                    fmt.Sprintf("Messages are limited to %d characters", maxChatLength))
+                continue
+            }
+            c.stopTyping(wsMessage.ChatRoom)
+            if !c.resolveTopic(&wsMessage) {
+                continue
+            }
+            if c.principal.Exemption() == "" && !c.allowSlowMode(&wsMessage) {
+                continue
+            }
         }
 
-        c.hub.broadcast <- &wsMessage
+        // Run the moderation filters, with the room's profanity policy,
+        // or strictly for restricted users
+        if wsMessage.Type == models.MessageTypeChat {
+            verdict := c.hub.filters.Run(moderation.Input{
+                UserID:  c.user.ID,
+                Room:    wsMessage.ChatRoom,
+                Locale:  c.hub.roomLocale(wsMessage.ChatRoom),
+                Content: wsMessage.Content,
+                At:      wsMessage.Timestamp,
+                Strict:  c.user.Restricted(wsMessage.Timestamp),
+            })
+            if verdict.Action == models.ActionBlock {
+                c.sendError(&wsMessage, models.ErrorContentBlocked, "Message blocked by content filter")
+                continue
+            }
+            if c.echo == EchoAck && wsMessage.ClientMsgID != "" && verdict.Content == wsMessage.Content {
+                wsMessage.ExcludeConn = c.id
+            }
+            wsMessage.Content = verdict.Content
+            if verdict.Action == models.ActionFlag {
+                wsMessage.Flag = &models.MessageFlag{Filter: verdict.Filter, Reason: verdict.Reason}
+            }
+            wsMessage.Mentions = c.resolveMentions(wsMessage.ChatRoom, wsMessage.Content)
+            wsMessage.Shadowed = c.shadowBanned()
+        }
+
+        ctx, _ := tracing.Start(context.Background(), "ws.message", trace.WithSpanKind(trace.SpanKindServer),
+            trace.WithAttributes(
+                attribute.String("room", wsMessage.ChatRoom),
+                attribute.String("message.type", wsMessage.Type),
+            ))
+        if !c.hub.submit(&inbound{ctx: ctx, message: &wsMessage}) {
+            trace.SpanFromContext(ctx).End()
+            c.sendError(&wsMessage, models.ErrorServerBusy, "Server is busy, try again shortly")
+        }
     }
 }
 
+// clientMessageTypes are the frame types clients may send: chat, which
+// goes out to the room, and the requests handled for the client alone.
+var clientMessageTypes = map[string]bool{
+    models.MessageTypeChat:        true,
+    models.MessageTypeDM:          true,
+    models.MessageTypeTicker:      true,
+    models.MessageTypeSubscribe:   true,
+    models.MessageTypeUnsubscribe: true,
+    models.MessageTypeHistory:     true,
+    models.MessageTypeEmotes:      true,
+    models.MessageTypeDraft:       true,
+    models.MessageTypeRead:        true,
+    models.MessageTypeTyping:      true,
+    models.MessageTypeReaction:    true,
+    models.MessageTypeVoice:       true,
+    models.MessageTypeModerate:    true,
+}
+
+// skipsRateLimit reports whether a client frame type is exempt from the
+// per-user limiter. Drafts are debounced and typing is throttled
+// instead.
+func skipsRateLimit(msgType string) bool {
+    return msgType == models.MessageTypeDraft || msgType == models.MessageTypeTyping
+}
+
 func (c *Client) canAccessRoom(room string) bool {
     c.mu.RLock()
     defer c.mu.RUnlock()
@@ -450,4 +1646,162 @@ const (
     pongWait = 60 * time.Second
     pingPeriod = (pongWait * 9) / 10
     maxMessageSize = 4096
-)
\ No newline at end of file
+    // maxChatLength is the longest chat message, in characters, well
+    // inside maxMessageSize so overlong messages get an error rather
+    // than a closed connection
+    maxChatLength = 1000
+)
+
+// connectedUser is a user with at least one connection to this instance.
+type connectedUser struct {
+    clients map[*Client]bool
+    // Opted out of cross-room goal flashes
+    goalFlashOptOut bool
+    favoriteTeam    string
+    // Nil when the user has no quiet hours
+    quietHours *models.QuietHours
+}
+
+// addToRoom adds a local connection to a room.
+func (h *Hub) addToRoom(room string, client *Client) {
+    h.roomsMu.Lock()
+    defer h.roomsMu.Unlock()
+
+    clients, exists := h.rooms[room]
+    if !exists {
+        // Rooms readied for a busy kickoff are sized for the crowd, so
+        // the joins at kickoff do not keep growing the map under the lock
+        clients = make(map[*Client]bool, h.warm.capacity(room))
+        h.rooms[room] = clients
+    }
+    clients[client] = true
+}
+
+// removeFromRoom removes a local connection from a room, reporting
+// whether it was there.
+func (h *Hub) removeFromRoom(room string, client *Client) bool {
+    h.roomsMu.Lock()
+    defer h.roomsMu.Unlock()
+
+    clients, exists := h.rooms[room]
+    if !exists || !clients[client] {
+        return false
+    }
+    delete(clients, client)
+    if len(clients) == 0 {
+        delete(h.rooms, room)
+    }
+    return true
+}
+
+// takeRoom empties a room, returning the connections it had.
+func (h *Hub) takeRoom(room string) []*Client {
+    h.roomsMu.Lock()
+    defer h.roomsMu.Unlock()
+
+    clients := make([]*Client, 0, len(h.rooms[room]))
+    for client := range h.rooms[room] {
+        clients = append(clients, client)
+    }
+    delete(h.rooms, room)
+    return clients
+}
+
+// eachRoomClient calls fn for every local connection in a room, holding
+// the rooms read lock. fn must not block or take hub locks.
+func (h *Hub) eachRoomClient(room string, fn func(*Client)) {
+    h.roomsMu.RLock()
+    defer h.roomsMu.RUnlock()
+
+    for client := range h.rooms[room] {
+        fn(client)
+    }
+}
+
+// roomSize is how many local connections a room has.
+func (h *Hub) roomSize(room string) int {
+    h.roomsMu.RLock()
+    defer h.roomsMu.RUnlock()
+    return len(h.rooms[room])
+}
+
+// inRoom reports whether a connection is in a room on this instance.
+func (h *Hub) inRoom(room string, client *Client) bool {
+    h.roomsMu.RLock()
+    defer h.roomsMu.RUnlock()
+    return h.rooms[room][client]
+}
+
+// eachRoom calls fn for every room with local connections, holding the
+// rooms read lock. fn must not block or take hub locks.
+func (h *Hub) eachRoom(fn func(room string, clients map[*Client]bool)) {
+    h.roomsMu.RLock()
+    defer h.roomsMu.RUnlock()
+
+    for room, clients := range h.rooms {
+        fn(room, clients)
+    }
+}
+
+// eachUserClient calls fn for every local connection of a user, holding
+// the users read lock. fn must not block or take hub locks.
+func (h *Hub) eachUserClient(userID string, fn func(*Client)) {
+    h.usersMu.RLock()
+    defer h.usersMu.RUnlock()
+
+    if user, ok := h.users[userID]; ok {
+        for client := range user.clients {
+            fn(client)
+        }
+    }
+}
+
+// isConnected reports whether a user has a connection to this instance.
+func (h *Hub) isConnected(userID string) bool {
+    h.usersMu.RLock()
+    defer h.usersMu.RUnlock()
+    _, ok := h.users[userID]
+    return ok
+}
+
+// trySend queues a frame without blocking, reporting false if the
+// connection's buffer was full and the frame dropped; the hub's slow
+// consumer policy decides what else happens to the connection. Frames to
+// a closed connection are silently dropped, so senders need no hub lock
+// to stay safe.
+func (c *Client) trySend(payload []byte) bool {
+    if !c.enqueue(payload) {
+        return false
+    }
+    c.hub.countOutbound(frameType(payload), roomSizeNone, len(payload), 1)
+    return true
+}
+
+// enqueue is trySend without counting the frame, for room fan-out, which
+// counts each room frame once for all its recipients.
+func (c *Client) enqueue(payload []byte) bool {
+    return c.enqueueFrame(outFrame{payload: payload})
+}
+
+// enqueueFrame is enqueue for a room frame, which may carry a prepared
+// message shared by its recipients and the frame's sequence.
+func (c *Client) enqueueFrame(frame outFrame) bool {
+    c.sendMu.RLock()
+    defer c.sendMu.RUnlock()
+
+    if c.closed {
+        return true
+    }
+    return c.push(frame)
+}
+
+// closeSend closes the send channel once, ending the write pump.
+func (c *Client) closeSend() {
+    c.sendMu.Lock()
+    defer c.sendMu.Unlock()
+
+    if !c.closed {
+        c.closed = true
+        close(c.send)
+    }
+}
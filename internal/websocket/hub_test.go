@@ -0,0 +1,226 @@
+package websocket
+
+import (
+    "encoding/json"
+    "runtime"
+    "strconv"
+    "sync"
+    "sync/atomic"
+    "testing"
+    "time"
+
+    "github.com/prometheus/client_golang/prometheus"
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/broker"
+    "github.com/yourusername/sports-chat/internal/events"
+    "github.com/yourusername/sports-chat/internal/metrics"
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/store/memory"
+)
+
+const (
+    // benchClients is how many connections the hub holds before a
+    // benchmark starts, spread over benchRooms rooms
+    benchClients = 10000
+    benchRooms   = 100
+    // benchBusyRoom holds every connection in the single room benchmarks
+    benchBusyRoom = "busy"
+)
+
+var benchConnID atomic.Int64
+
+func newBenchHub(b *testing.B) *Hub {
+    b.Helper()
+    logger := zap.NewNop()
+    return NewHub(memory.New(), broker.NewLocal(), events.NewBus(logger), nil, nil, nil,
+        Options{}, metrics.NewMetrics(prometheus.NewRegistry()), logger)
+}
+
+// newBenchClient is a connection without a socket, joined to room. Its
+// frames are drained as they come, like a write pump keeping up.
+func newBenchClient(h *Hub, room string) *Client {
+    n := strconv.FormatInt(benchConnID.Add(1), 10)
+    client := &Client{
+        id:    "conn-" + n,
+        hub:   h,
+        send:  make(chan outFrame, h.sendBuffer),
+        user:  &models.User{ID: "user-" + n, Username: "fan" + n},
+        rooms: map[string]bool{room: true},
+    }
+    go func() {
+        for range client.send {
+        }
+    }()
+    return client
+}
+
+func benchRoom(i int) string {
+    return "room-" + strconv.Itoa(i%benchRooms)
+}
+
+// connectBenchClients registers n connections, in room if it is set and
+// otherwise spread over benchRooms rooms.
+func connectBenchClients(h *Hub, n int, room string) []*Client {
+    clients := make([]*Client, n)
+    for i := range clients {
+        r := room
+        if r == "" {
+            r = benchRoom(i)
+        }
+        clients[i] = newBenchClient(h, r)
+        h.handleRegister(clients[i])
+    }
+    return clients
+}
+
+// settle waits for the goroutines connecting clients starts, such as
+// their initial data sends, to finish, so they are not timed.
+func settle() {
+    for prev := -1; ; {
+        time.Sleep(20 * time.Millisecond)
+        n := runtime.NumGoroutine()
+        if n == prev {
+            return
+        }
+        prev = n
+    }
+}
+
+func disconnectBenchClients(h *Hub, clients []*Client) {
+    for _, client := range clients {
+        h.handleUnregister(client)
+    }
+}
+
+// churn connects and disconnects clients in rooms other than the busy
+// one until stop is closed, as fans come and go during a match.
+func churn(h *Hub, stop <-chan struct{}, wg *sync.WaitGroup) {
+    defer wg.Done()
+    for i := 0; ; i++ {
+        select {
+        case <-stop:
+            return
+        default:
+        }
+        client := newBenchClient(h, benchRoom(i))
+        h.handleRegister(client)
+        h.handleUnregister(client)
+    }
+}
+
+func benchChatFrame(b *testing.B, room string) *broker.Message {
+    b.Helper()
+    payload, err := json.Marshal(&models.WSMessage{
+        Type:      models.MessageTypeChat,
+        ChatRoom:  room,
+        Content:   "What a save!",
+        Timestamp: time.Now(),
+    })
+    if err != nil {
+        b.Fatal(err)
+    }
+    return &broker.Message{Room: room, Payload: payload}
+}
+
+// BenchmarkRegister connects clients to a hub already holding
+// benchClients, from many goroutines at once.
+func BenchmarkRegister(b *testing.B) {
+    h := newBenchHub(b)
+    defer disconnectBenchClients(h, connectBenchClients(h, benchClients, ""))
+    settle()
+
+    var mu sync.Mutex
+    var registered []*Client
+    var i atomic.Int64
+    b.ReportAllocs()
+    b.ResetTimer()
+    b.RunParallel(func(pb *testing.PB) {
+        var mine []*Client
+        for pb.Next() {
+            client := newBenchClient(h, benchRoom(int(i.Add(1))))
+            h.handleRegister(client)
+            mine = append(mine, client)
+        }
+        mu.Lock()
+        registered = append(registered, mine...)
+        mu.Unlock()
+    })
+    b.StopTimer()
+    disconnectBenchClients(h, registered)
+}
+
+// BenchmarkUnregister disconnects clients from a hub holding
+// benchClients besides them, from many goroutines at once.
+func BenchmarkUnregister(b *testing.B) {
+    h := newBenchHub(b)
+    defer disconnectBenchClients(h, connectBenchClients(h, benchClients, ""))
+    leaving := connectBenchClients(h, b.N, "")
+    settle()
+
+    var i atomic.Int64
+    b.ReportAllocs()
+    b.ResetTimer()
+    b.RunParallel(func(pb *testing.PB) {
+        for pb.Next() {
+            h.handleUnregister(leaving[i.Add(1)-1])
+        }
+    })
+}
+
+// BenchmarkRoomDelivery delivers a frame to a room of benchClients while
+// other rooms' fans connect and disconnect.
+func BenchmarkRoomDelivery(b *testing.B) {
+    h := newBenchHub(b)
+    defer disconnectBenchClients(h, connectBenchClients(h, benchClients, benchBusyRoom))
+    settle()
+    msg := benchChatFrame(b, benchBusyRoom)
+
+    stop := make(chan struct{})
+    var wg sync.WaitGroup
+    for i := 0; i < 4; i++ {
+        wg.Add(1)
+        go churn(h, stop, &wg)
+    }
+
+    b.ReportAllocs()
+    b.ResetTimer()
+    for i := 0; i < b.N; i++ {
+        h.deliverToRoom(msg)
+    }
+    b.StopTimer()
+    close(stop)
+    wg.Wait()
+}
+
+// BenchmarkRoomDeliveryParallel delivers frames to benchRooms rooms
+// sharing benchClients from many goroutines at once, while fans connect
+// and disconnect.
+func BenchmarkRoomDeliveryParallel(b *testing.B) {
+    h := newBenchHub(b)
+    defer disconnectBenchClients(h, connectBenchClients(h, benchClients, ""))
+    frames := make([]*broker.Message, benchRooms)
+    for i := range frames {
+        frames[i] = benchChatFrame(b, benchRoom(i))
+    }
+    settle()
+
+    stop := make(chan struct{})
+    var wg sync.WaitGroup
+    for i := 0; i < 4; i++ {
+        wg.Add(1)
+        go churn(h, stop, &wg)
+    }
+
+    var i atomic.Int64
+    b.ReportAllocs()
+    b.ResetTimer()
+    b.RunParallel(func(pb *testing.PB) {
+        for pb.Next() {
+            h.deliverToRoom(frames[i.Add(1)%benchRooms])
+        }
+    })
+    b.StopTimer()
+    close(stop)
+    wg.Wait()
+}
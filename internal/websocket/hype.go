@@ -0,0 +1,245 @@
+package websocket
+
+import (
+    "encoding/json"
+    "sort"
+    "sync"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/broker"
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+const (
+    // HypeWindow is how far back a room's hype meter counts reactions,
+    // kept in slots of hypeSlotLength.
+    HypeWindow     = time.Minute
+    hypeSlotLength = 5 * time.Second
+    // hypeFlushInterval is how often an instance shares the reactions it
+    // counted and sends rooms whose meter moved their totals.
+    hypeFlushInterval = 2 * time.Second
+    // maxAnonymousHype caps the logged-out reactions an instance counts
+    // per room between flushes, so addresses that each stay within their
+    // own limit still cannot drive a room's meter between them.
+    maxAnonymousHype = 100
+    // maxHypeEmoji bounds the emoji a hype frame lists; the total covers
+    // them all.
+    maxHypeEmoji = 10
+)
+
+// hypeCounts are reactions by emoji.
+type hypeCounts map[string]int
+
+type hypeSlot struct {
+    start  time.Time
+    counts hypeCounts
+}
+
+// hypeMeter counts reactions per room over HypeWindow. A reaction is
+// counted as pending where it was sent, shared through the broker on the
+// next flush and added to every instance's window as it comes back, so
+// each instance holds the same totals.
+type hypeMeter struct {
+    mu        sync.Mutex
+    pending   map[string]hypeCounts
+    anonymous map[string]int
+    rooms     map[string][]*hypeSlot // oldest first
+    changed   map[string]bool
+}
+
+func newHypeMeter() *hypeMeter {
+    return &hypeMeter{
+        pending:   make(map[string]hypeCounts),
+        anonymous: make(map[string]int),
+        rooms:     make(map[string][]*hypeSlot),
+        changed:   make(map[string]bool),
+    }
+}
+
+// count adds a reaction to the room's pending counts. An anonymous one
+// past the room's cap is refused.
+func (m *hypeMeter) count(room, emoji string, anonymous bool) bool {
+    m.mu.Lock()
+    defer m.mu.Unlock()
+
+    if anonymous {
+        if m.anonymous[room] >= maxAnonymousHype {
+            return false
+        }
+        m.anonymous[room]++
+    }
+    if m.pending[room] == nil {
+        m.pending[room] = make(hypeCounts)
+    }
+    m.pending[room][emoji]++
+    return true
+}
+
+// takePending returns the counts since the last flush, starting afresh.
+func (m *hypeMeter) takePending() map[string]hypeCounts {
+    m.mu.Lock()
+    defer m.mu.Unlock()
+
+    pending := m.pending
+    m.pending = make(map[string]hypeCounts)
+    m.anonymous = make(map[string]int)
+    return pending
+}
+
+// add adds counts an instance shared to the room's current slot.
+func (m *hypeMeter) add(room string, counts hypeCounts, now time.Time) {
+    start := now.Truncate(hypeSlotLength)
+
+    m.mu.Lock()
+    defer m.mu.Unlock()
+
+    slots := m.rooms[room]
+    if n := len(slots); n == 0 || slots[n-1].start.Before(start) {
+        slots = append(slots, &hypeSlot{start: start, counts: make(hypeCounts)})
+        m.rooms[room] = slots
+    }
+    current := slots[len(slots)-1].counts
+    for emoji, n := range counts {
+        current[emoji] += n
+    }
+    m.changed[room] = true
+}
+
+// expire drops slots that have left the window and returns the rooms
+// whose totals changed since it was last called.
+func (m *hypeMeter) expire(now time.Time) []string {
+    cutoff := now.Add(-HypeWindow)
+
+    m.mu.Lock()
+    defer m.mu.Unlock()
+
+    for room, slots := range m.rooms {
+        i := 0
+        for i < len(slots) && !slots[i].start.Add(hypeSlotLength).After(cutoff) {
+            i++
+        }
+        if i == 0 {
+            continue
+        }
+        m.changed[room] = true
+        if i == len(slots) {
+            delete(m.rooms, room)
+        } else {
+            m.rooms[room] = slots[i:]
+        }
+    }
+
+    rooms := make([]string, 0, len(m.changed))
+    for room := range m.changed {
+        rooms = append(rooms, room)
+    }
+    m.changed = make(map[string]bool)
+    return rooms
+}
+
+// totals returns the room's meter, its most used emoji first.
+func (m *hypeMeter) totals(room string, now time.Time) *models.RoomHype {
+    cutoff := now.Add(-HypeWindow)
+    hype := &models.RoomHype{
+        RoomID:        room,
+        Reactions:     []*models.ReactionCount{},
+        WindowSeconds: int(HypeWindow / time.Second),
+    }
+
+    sum := make(hypeCounts)
+    m.mu.Lock()
+    for _, slot := range m.rooms[room] {
+        if !slot.start.Add(hypeSlotLength).After(cutoff) {
+            continue
+        }
+        for emoji, n := range slot.counts {
+            sum[emoji] += n
+            hype.Total += n
+        }
+    }
+    m.mu.Unlock()
+
+    for emoji, n := range sum {
+        hype.Reactions = append(hype.Reactions, &models.ReactionCount{Emoji: emoji, Count: n})
+    }
+    sort.Slice(hype.Reactions, func(i, j int) bool {
+        a, b := hype.Reactions[i], hype.Reactions[j]
+        if a.Count != b.Count {
+            return a.Count > b.Count
+        }
+        return a.Emoji < b.Emoji
+    })
+    if len(hype.Reactions) > maxHypeEmoji {
+        hype.Reactions = hype.Reactions[:maxHypeEmoji]
+    }
+    return hype
+}
+
+// CountAnonymousReaction counts a logged-out viewer's reaction into the
+// room's hype meter. It reports false when the room has taken as many as
+// this instance counts until the next flush.
+func (h *Hub) CountAnonymousReaction(room, emoji string) bool {
+    return h.hype.count(room, emoji, true)
+}
+
+// RoomHype returns the room's hype meter.
+func (h *Hub) RoomHype(room string) *models.RoomHype {
+    return h.hype.totals(room, time.Now())
+}
+
+// hypeLoop flushes the hype meter on a schedule.
+func (h *Hub) hypeLoop() {
+    ticker := time.NewTicker(hypeFlushInterval)
+    defer ticker.Stop()
+
+    for range ticker.C {
+        h.flushHype()
+    }
+}
+
+// flushHype publishes the reactions counted here since the last flush,
+// one message per room, and sends this instance's clients the totals of
+// rooms whose meter moved.
+func (h *Hub) flushHype() {
+    for room, counts := range h.hype.takePending() {
+        payload, err := json.Marshal(counts)
+        if err != nil {
+            continue
+        }
+        h.publish(&broker.Message{Room: room, Payload: payload, Hype: true, Priority: PriorityLow})
+    }
+
+    now := time.Now()
+    for _, room := range h.hype.expire(now) {
+        data, err := json.Marshal(h.hype.totals(room, now))
+        if err != nil {
+            continue
+        }
+        payload, err := json.Marshal(&models.WSMessage{
+            Type:      models.MessageTypeHype,
+            ChatRoom:  room,
+            Data:      data,
+            Timestamp: now,
+        })
+        if err != nil {
+            continue
+        }
+        // Every instance holds the same totals and sends its own clients
+        // them, as with presence
+        h.fanout.enqueue(&broker.Message{Room: room, Payload: payload, Priority: PriorityLow})
+    }
+}
+
+// applyHype adds reaction counts an instance shared to the meter.
+func (h *Hub) applyHype(msg *broker.Message) {
+    var counts hypeCounts
+    if err := json.Unmarshal(msg.Payload, &counts); err != nil {
+        h.logger.Error("Failed to unmarshal hype counts",
+            zap.Error(err),
+            zap.String("room", msg.Room))
+        return
+    }
+    h.hype.add(msg.Room, counts, time.Now())
+}
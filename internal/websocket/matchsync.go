@@ -0,0 +1,247 @@
+package websocket
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "strings"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/broker"
+    "github.com/yourusername/sports-chat/internal/events"
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/sportsdata"
+    "github.com/yourusername/sports-chat/internal/tracing"
+)
+
+// ErrUnknownMatch is returned for pushes about matches not known locally
+// by their provider ID.
+var ErrUnknownMatch = errors.New("match not known locally")
+
+// syncFromProvider pulls live scores and events from the sports data
+// provider into the store, so the live set fetchMatchUpdates reads
+// reflects the real games. Only matches already known locally by their
+// provider ID are synced.
+func (h *Hub) syncFromProvider() {
+    // Leaves room for the provider client's retries within one poll
+    ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+    defer cancel()
+    ctx, span := tracing.Start(ctx, "sportsdata.sync")
+    defer span.End()
+
+    live, err := h.provider.GetLiveMatches(ctx)
+    if err != nil {
+        h.logger.Error("Failed to fetch live matches from provider", zap.Error(err))
+        return
+    }
+
+    inPlay := make(map[string]bool, len(live))
+    for _, remote := range live {
+        inPlay[remote.ProviderID] = true
+
+        match, err := h.store.GetMatchByProviderID(ctx, remote.ProviderID)
+        if err != nil || match == nil {
+            continue
+        }
+        if _, err := h.applyProviderMatch(ctx, match, remote); err != nil {
+            h.logger.Error("Failed to sync match", zap.Error(err), zap.String("match_id", match.ID))
+            continue
+        }
+        if err := h.syncMatchEvents(ctx, match); err != nil {
+            h.logger.Error("Failed to sync match events", zap.Error(err), zap.String("match_id", match.ID))
+        }
+    }
+
+    // Matches we still hold as live that the provider no longer lists
+    // have ended; fetch their final state so they leave the live set.
+    stored, err := h.store.GetLiveMatches(ctx)
+    if err != nil {
+        h.logger.Error("Failed to fetch live matches", zap.Error(err))
+        return
+    }
+    for _, match := range stored {
+        if match.ProviderID == "" || inPlay[match.ProviderID] {
+            continue
+        }
+        final, err := h.provider.GetMatchResult(ctx, match.ProviderID)
+        if err != nil {
+            h.logger.Warn("Failed to fetch match result", zap.Error(err), zap.String("match_id", match.ID))
+            continue
+        }
+        if _, err := h.applyProviderMatch(ctx, match, final); err != nil {
+            h.logger.Error("Failed to sync match", zap.Error(err), zap.String("match_id", match.ID))
+        }
+    }
+}
+
+// applyProviderMatch copies the provider's live fields onto the stored
+// match and saves it if anything changed, returning the match as saved.
+func (h *Hub) applyProviderMatch(ctx context.Context, match, remote *models.Match) (*models.Match, error) {
+    updated := *match
+    updated.Status = remote.Status
+    updated.Period = remote.Period
+    updated.Minute = remote.Minute
+    updated.HomeScore = remote.HomeScore
+    updated.AwayScore = remote.AwayScore
+    if remote.Shootout != nil {
+        updated.Shootout = remote.Shootout
+    }
+    venueChanged := remote.Venue != nil && (match.Venue == nil || *remote.Venue != *match.Venue)
+    if venueChanged {
+        updated.Venue = remote.Venue
+    }
+    h.storeProviderNames(ctx, match, remote.Names)
+
+    // The clock alone is saved too, so chat can be stamped with it
+    changed := matchNeedsUpdate(match, &updated)
+    if !changed && match.Minute == updated.Minute && !venueChanged {
+        return match, nil
+    }
+    updated.UpdatedAt = time.Now()
+    if err := h.store.UpdateMatch(ctx, &updated); err != nil {
+        return nil, fmt.Errorf("failed to update match: %w", err)
+    }
+    if changed {
+        h.events.Publish(events.MatchUpdated{
+            MatchID: updated.ID,
+            Status:  updated.Status,
+            At:      updated.UpdatedAt,
+        })
+    }
+    return &updated, nil
+}
+
+// storeProviderNames stores the localized team and competition names a
+// provider sent for a match, when they differ from those held. Failing
+// to store them leaves the names as they were and the match is synced
+// regardless.
+func (h *Hub) storeProviderNames(ctx context.Context, match *models.Match, names *models.MatchNames) {
+    if h.localizer == nil || names == nil {
+        return
+    }
+    var missing []*models.LocalizedName
+    missing = append(missing, h.localizer.Missing(models.NameKindTeam, match.HomeTeamID, names.HomeTeam)...)
+    missing = append(missing, h.localizer.Missing(models.NameKindTeam, match.AwayTeamID, names.AwayTeam)...)
+    missing = append(missing, h.localizer.Missing(models.NameKindCompetition, match.CompetitionID, names.Competition)...)
+    if len(missing) == 0 {
+        return
+    }
+    if err := h.store.UpsertLocalizedNames(ctx, missing); err != nil {
+        h.logger.Warn("Failed to store localized names", zap.Error(err), zap.String("match_id", match.ID))
+        return
+    }
+    h.localizer.Learn(missing)
+}
+
+// syncMatchEvents stores provider events not seen before and sends each
+// to the match room.
+func (h *Hub) syncMatchEvents(ctx context.Context, match *models.Match) error {
+    remote, err := h.provider.GetMatchEvents(ctx, match.ProviderID)
+    if err != nil {
+        return fmt.Errorf("failed to fetch provider events: %w", err)
+    }
+    fresh, err := h.storeMatchEvents(ctx, match, remote)
+    for _, event := range fresh {
+        h.sendMatchEvent(match.ID, event, true)
+        // Providers' event types are stored lowercased
+        if strings.EqualFold(event.EventType, models.EventTypeRedCard) {
+            h.tickRedCard(match, event)
+        }
+    }
+    return err
+}
+
+// storeMatchEvents stores the events not seen before, returning them. On
+// error, the events stored before it are still returned.
+func (h *Hub) storeMatchEvents(ctx context.Context, match *models.Match, remote []*models.MatchEvent) ([]*models.MatchEvent, error) {
+    if len(remote) == 0 {
+        return nil, nil
+    }
+
+    stored, err := h.store.GetMatchEvents(ctx, match.ID)
+    if err != nil {
+        return nil, fmt.Errorf("failed to get match events: %w", err)
+    }
+    seen := make(map[string]bool, len(stored))
+    for _, e := range stored {
+        seen[eventKey(e)] = true
+    }
+
+    var fresh []*models.MatchEvent
+    for _, event := range remote {
+        if seen[eventKey(event)] {
+            continue
+        }
+        event.MatchID = match.ID
+        event.CreatedAt = time.Now()
+        if err := h.store.CreateMatchEvent(ctx, event); err != nil {
+            return fresh, fmt.Errorf("failed to create match event: %w", err)
+        }
+        seen[eventKey(event)] = true
+        fresh = append(fresh, event)
+    }
+    return fresh, nil
+}
+
+// IngestMatchPush applies a match update the provider pushed, without
+// waiting for the next poll. Only the receiving instance stores it; new
+// events go to the room through the broker, and every instance sends its
+// own clients the match's changes, so none sends them again on its next
+// poll.
+func (h *Hub) IngestMatchPush(ctx context.Context, push *sportsdata.Push) (*models.Match, error) {
+    match, err := h.store.GetMatchByProviderID(ctx, push.Match.ProviderID)
+    if err != nil || match == nil {
+        return nil, ErrUnknownMatch
+    }
+    return h.PushMatch(ctx, match, push)
+}
+
+// PushMatch applies a push to a stored match, as IngestMatchPush does once
+// it has found the match. The simulator calls it directly, so matches the
+// provider does not know can be pushed to too.
+func (h *Hub) PushMatch(ctx context.Context, match *models.Match, push *sportsdata.Push) (*models.Match, error) {
+    match, err := h.applyProviderMatch(ctx, match, push.Match)
+    if err != nil {
+        return nil, err
+    }
+
+    fresh, err := h.storeMatchEvents(ctx, match, push.Events)
+    for _, event := range fresh {
+        h.sendMatchEvent(match.ID, event, false)
+        if strings.EqualFold(event.EventType, models.EventTypeRedCard) {
+            h.tickRedCard(match, event)
+        }
+    }
+    if err != nil {
+        return nil, err
+    }
+
+    payload, err := json.Marshal(match)
+    if err != nil {
+        return nil, fmt.Errorf("failed to marshal match: %w", err)
+    }
+    h.publish(&broker.Message{Room: match.ID, Payload: payload, MatchPushed: true, Priority: PriorityHigh})
+    return match, nil
+}
+
+// applyPushedMatch is this instance's part of a pushed match update.
+func (h *Hub) applyPushedMatch(payload []byte) {
+    var match models.Match
+    if err := json.Unmarshal(payload, &match); err != nil {
+        h.logger.Error("Failed to unmarshal pushed match", zap.Error(err))
+        return
+    }
+
+    h.matchMu.Lock()
+    defer h.matchMu.Unlock()
+    h.updateMatch(&match)
+}
+
+// eventKey identifies an event across polls; providers do not give events
+// stable IDs.
+func eventKey(e *models.MatchEvent) string {
+    return fmt.Sprintf("%s|%d|%s", e.EventType, e.EventTime, e.Description)
+}
@@ -0,0 +1,108 @@
+package websocket
+
+import (
+    "context"
+    "encoding/json"
+    "regexp"
+    "strings"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/quiethours"
+)
+
+// maxMentions caps how many users one message can notify, so a message
+// can't page a whole room.
+const maxMentions = 10
+
+// mentionPattern matches @username where the @ starts a word, so email
+// addresses aren't read as mentions.
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w@])@([A-Za-z][A-Za-z0-9_]{1,49})`)
+
+// parseMentions returns the distinct usernames mentioned in content, in
+// order of first appearance and at most maxMentions of them.
+func parseMentions(content string) []string {
+    seen := make(map[string]bool)
+    var usernames []string
+    for _, match := range mentionPattern.FindAllStringSubmatch(content, -1) {
+        key := strings.ToLower(match[1])
+        if seen[key] {
+            continue
+        }
+        seen[key] = true
+        usernames = append(usernames, match[1])
+        if len(usernames) == maxMentions {
+            break
+        }
+    }
+    return usernames
+}
+
+// resolveMentions returns the IDs of the room's members mentioned in
+// content, leaving out the sender and anyone blocked either way. Names
+// that aren't members are plain text. It hits the store, so it runs on
+// the client's goroutine.
+func (c *Client) resolveMentions(room, content string) []string {
+    usernames := parseMentions(content)
+    if len(usernames) == 0 {
+        return nil
+    }
+
+    ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+    defer cancel()
+
+    members, err := c.hub.store.GetRoomMembersByUsername(ctx, room, usernames)
+    if err != nil {
+        c.hub.logger.Warn("Failed to resolve mentions",
+            zap.Error(err),
+            zap.String("room", room))
+        return nil
+    }
+
+    var userIDs []string
+    for _, member := range members {
+        if member.ID == c.user.ID {
+            continue
+        }
+        if blocked, err := c.hub.store.IsBlocked(ctx, c.user.ID, member.ID); err != nil || blocked {
+            continue
+        }
+        userIDs = append(userIDs, member.ID)
+    }
+    return userIDs
+}
+
+// mentions reports whether a chat message mentions the user.
+func mentions(message *models.WSMessage, userID string) bool {
+    for _, id := range message.Mentions {
+        if id == userID {
+            return true
+        }
+    }
+    return false
+}
+
+// notifyMentions sends a mention frame to every connection of each
+// mentioned user, on any instance and whatever room they are viewing.
+func (h *Hub) notifyMentions(message *models.WSMessage) {
+    for _, userID := range message.Mentions {
+        if quiet, _ := h.userQuietHours(userID); !quiethours.Allows(quiet, time.Now(), false) {
+            continue
+        }
+
+        data, err := json.Marshal(map[string]string{"message_id": message.ID})
+        if err != nil {
+            continue
+        }
+        h.SendToUser(userID, &models.WSMessage{
+            Type:      models.MessageTypeMention,
+            ChatRoom:  message.ChatRoom,
+            Content:   message.Content,
+            User:      message.User,
+            Timestamp: message.Timestamp,
+            Data:      data,
+        })
+    }
+}
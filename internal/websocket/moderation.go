@@ -0,0 +1,357 @@
+package websocket
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/broker"
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/rbac"
+)
+
+// Sanctions are checked on every incoming frame, so they are cached
+// briefly. Changes reach other instances through the broker at once; the
+// TTL only bounds how long an expired sanction lingers.
+const sanctionCacheTTL = 15 * time.Second
+
+var (
+    ErrMessageNotFound = errors.New("message not found in room")
+    ErrCannotSanction  = errors.New("admins cannot be muted or banned")
+    ErrOutranked       = errors.New("user does not rank below the moderator")
+)
+
+// Moderation actions of the websocket moderate command.
+const (
+    ModerateDelete = "delete"
+    ModerateMute   = "mute"
+    ModerateUnmute = "unmute"
+    ModerateBan    = "ban"
+    ModerateUnban  = "unban"
+    ModeratePin    = "pin"
+    ModerateUnpin  = "unpin"
+)
+
+// moderatePermissions is the room permission each action needs.
+var moderatePermissions = map[string]rbac.Permission{
+    ModerateDelete: rbac.PermDelete,
+    ModerateMute:   rbac.PermSanction,
+    ModerateUnmute: rbac.PermSanction,
+    ModerateBan:    rbac.PermSanction,
+    ModerateUnban:  rbac.PermSanction,
+    ModeratePin:    rbac.PermPin,
+    ModerateUnpin:  rbac.PermPin,
+}
+
+type sanctionKey struct {
+    room string
+    user string
+}
+
+type cachedSanctions struct {
+    sanctions []*models.RoomSanction
+    loadedAt  time.Time
+}
+
+// moderateRequest is the data of a moderator's moderate command. Duration
+// is in seconds; zero sanctions until lifted.
+type moderateRequest struct {
+    Action    string `json:"action"`
+    MessageID string `json:"message_id,omitempty"`
+    UserID    string `json:"user_id,omitempty"`
+    Duration  int    `json:"duration,omitempty"`
+    Reason    string `json:"reason,omitempty"`
+}
+
+// EvidenceRetainer keeps a copy of a message moderation is about to
+// delete.
+type EvidenceRetainer interface {
+    Retain(ctx context.Context, msg *models.Message, deletedBy, reason string) error
+}
+
+// DeleteMessage removes a message on a moderator's behalf and tells the
+// room's clients to drop it. The deletion is journaled so replays remove
+// it too. With evidence retention on, the message is kept sealed first,
+// and stays in chat if that fails.
+func (h *Hub) DeleteMessage(ctx context.Context, room, messageID, deletedBy, reason string) error {
+    msg, err := h.store.GetMessage(ctx, messageID)
+    if err != nil || msg == nil || msg.ChatRoomID != room {
+        return ErrMessageNotFound
+    }
+    if h.evidence != nil {
+        if err := h.evidence.Retain(ctx, msg, deletedBy, reason); err != nil {
+            return fmt.Errorf("failed to retain evidence: %w", err)
+        }
+    }
+    if err := h.store.DeleteMessage(ctx, messageID); err != nil {
+        return fmt.Errorf("failed to delete message: %w", err)
+    }
+
+    h.broadcastToRoom(room, &models.WSMessage{
+        ID:        messageID,
+        Type:      models.MessageTypeDeleted,
+        ChatRoom:  room,
+        Timestamp: time.Now(),
+    })
+    return nil
+}
+
+// PinMessage pins a message of the room above its chat, replacing any
+// pinned before; an empty messageID unpins. Every instance drops its
+// cached room settings and the room's clients are told.
+func (h *Hub) PinMessage(ctx context.Context, room, messageID string) error {
+    var data json.RawMessage
+    if messageID != "" {
+        msg, err := h.store.GetMessage(ctx, messageID)
+        if err != nil || msg == nil || msg.ChatRoomID != room {
+            return ErrMessageNotFound
+        }
+        data, _ = json.Marshal(msg)
+    }
+    if err := h.store.SetPinnedMessage(ctx, room, messageID); err != nil {
+        return fmt.Errorf("failed to pin message: %w", err)
+    }
+
+    payload, err := json.Marshal(&models.WSMessage{
+        ID:        messageID,
+        Type:      models.MessageTypePinned,
+        ChatRoom:  room,
+        Data:      data,
+        Timestamp: time.Now(),
+    })
+    if err != nil {
+        return nil
+    }
+    if h.journal != nil {
+        h.journal.Append(room, payload)
+    }
+    h.publish(&broker.Message{Room: room, Payload: payload, RoomChanged: true, Priority: PriorityHigh})
+    return nil
+}
+
+// Sanction mutes or bans a user in a room.
+func (h *Hub) Sanction(ctx context.Context, sanction *models.RoomSanction) error {
+    user, err := h.store.GetUser(ctx, sanction.UserID)
+    if err != nil {
+        return fmt.Errorf("failed to get user: %w", err)
+    }
+    if user.IsAdmin {
+        return ErrCannotSanction
+    }
+
+    if err := h.store.CreateRoomSanction(ctx, sanction); err != nil {
+        return fmt.Errorf("failed to create sanction: %w", err)
+    }
+    h.publishSanction(sanction.RoomID, sanction.UserID, sanction.Kind, sanction)
+    return nil
+}
+
+// LiftSanction ends a user's mute or ban in a room.
+func (h *Hub) LiftSanction(ctx context.Context, room, userID, kind string) error {
+    if err := h.store.DeleteRoomSanction(ctx, room, userID, kind); err != nil {
+        return fmt.Errorf("failed to delete sanction: %w", err)
+    }
+    h.publishSanction(room, userID, "un"+kind, nil)
+    return nil
+}
+
+// publishSanction tells the sanctioned user what happened and has every
+// instance refresh its view of their sanctions.
+func (h *Hub) publishSanction(room, userID, action string, sanction *models.RoomSanction) {
+    var data json.RawMessage
+    if sanction != nil {
+        data, _ = json.Marshal(sanction)
+    }
+    payload, err := json.Marshal(&models.WSMessage{
+        Type:      models.MessageTypeSanctioned,
+        ChatRoom:  room,
+        Content:   action,
+        Data:      data,
+        Timestamp: time.Now(),
+    })
+    if err != nil {
+        return
+    }
+    h.publish(&broker.Message{
+        Room:       room,
+        Payload:    payload,
+        TargetUser: userID,
+        Sanctioned: userID,
+        Priority:   PriorityHigh,
+    })
+}
+
+// sanctionsFor reports whether a user is muted or banned in a room. Lookup
+// failures let the user through rather than silencing a room.
+func (h *Hub) sanctionsFor(room, userID string) (muted, banned bool) {
+    key := sanctionKey{room: room, user: userID}
+
+    h.sanctionMu.RLock()
+    cached, ok := h.sanctionCache[key]
+    h.sanctionMu.RUnlock()
+
+    if !ok || time.Since(cached.loadedAt) >= sanctionCacheTTL {
+        ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+        defer cancel()
+
+        sanctions, err := h.store.GetRoomSanctions(ctx, room, userID)
+        if err != nil {
+            h.logger.Warn("Failed to load room sanctions",
+                zap.Error(err),
+                zap.String("room", room),
+                zap.String("user_id", userID))
+            return false, false
+        }
+        cached = &cachedSanctions{sanctions: sanctions, loadedAt: time.Now()}
+
+        h.sanctionMu.Lock()
+        h.sanctionCache[key] = cached
+        h.sanctionMu.Unlock()
+    }
+
+    now := time.Now()
+    for _, s := range cached.sanctions {
+        if !s.Active(now) {
+            continue
+        }
+        switch s.Kind {
+        case models.SanctionMute:
+            muted = true
+        case models.SanctionBan:
+            banned = true
+        }
+    }
+    return muted, banned
+}
+
+// refreshSanctions drops the cached sanctions of a user and, if they are
+// now banned, removes their connections on this instance from the room.
+func (h *Hub) refreshSanctions(room, userID string) {
+    h.sanctionMu.Lock()
+    delete(h.sanctionCache, sanctionKey{room: room, user: userID})
+    h.sanctionMu.Unlock()
+
+    if _, banned := h.sanctionsFor(room, userID); !banned {
+        return
+    }
+
+    var clients []*Client
+    h.eachUserClient(userID, func(client *Client) {
+        clients = append(clients, client)
+    })
+    for _, client := range clients {
+        h.removeFromRoom(room, client)
+        client.mu.Lock()
+        delete(client.rooms, room)
+        client.mu.Unlock()
+    }
+}
+
+// blockedWhenMuted reports whether a muted user may not send a frame type.
+// Muted users can still read history and keep drafts.
+// mayPost reports whether the client may send a chat message or typing
+// indicator in a broadcaster-only room. Reactions stay open to everyone.
+func (c *Client) mayPost(msg *models.WSMessage) bool {
+    if msg.Type != models.MessageTypeChat && msg.Type != models.MessageTypeTyping {
+        return true
+    }
+    if !c.hub.roomSettings(msg.ChatRoom).BroadcastOnly {
+        return true
+    }
+
+    ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+    defer cancel()
+    return c.hub.roles.Can(ctx, c.principal, msg.ChatRoom, rbac.PermBroadcast)
+}
+
+func blockedWhenMuted(msgType string) bool {
+    switch msgType {
+    case models.MessageTypeChat, models.MessageTypeReaction, models.MessageTypeTyping, models.MessageTypeVoice:
+        return true
+    }
+    return false
+}
+
+// handleModerate applies a moderator's command from the websocket, the
+// counterpart of the room moderation REST routes. Each action needs its
+// permission in the room; mutes and bans also need the moderator to
+// outrank the user.
+func (c *Client) handleModerate(msg *models.WSMessage) {
+    var req moderateRequest
+    if err := json.Unmarshal(msg.Data, &req); err != nil {
+        c.sendError(msg, models.ErrorInvalidRequest, "Invalid moderation request")
+        return
+    }
+    perm, ok := moderatePermissions[req.Action]
+    if !ok {
+        c.sendError(msg, models.ErrorInvalidRequest, "Unknown moderation action")
+        return
+    }
+
+    ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+    defer cancel()
+
+    room := msg.ChatRoom
+    if !c.hub.roles.Can(ctx, c.principal, room, perm) {
+        c.sendError(msg, models.ErrorForbidden, "Moderator access required")
+        return
+    }
+
+    var err error
+    switch req.Action {
+    case ModerateDelete:
+        err = c.hub.DeleteMessage(ctx, room, req.MessageID, c.user.ID, req.Reason)
+    case ModeratePin:
+        err = c.hub.PinMessage(ctx, room, req.MessageID)
+    case ModerateUnpin:
+        err = c.hub.PinMessage(ctx, room, "")
+    case ModerateMute, ModerateBan:
+        if !c.hub.roles.Outranks(ctx, c.principal, room, req.UserID) {
+            err = ErrOutranked
+            break
+        }
+        sanction := &models.RoomSanction{
+            RoomID:    room,
+            UserID:    req.UserID,
+            Kind:      req.Action,
+            Reason:    req.Reason,
+            CreatedBy: c.user.ID,
+            CreatedAt: time.Now(),
+        }
+        if req.Duration > 0 {
+            expires := sanction.CreatedAt.Add(time.Duration(req.Duration) * time.Second)
+            sanction.ExpiresAt = &expires
+        }
+        err = c.hub.Sanction(ctx, sanction)
+    case ModerateUnmute:
+        err = c.hub.LiftSanction(ctx, room, req.UserID, models.SanctionMute)
+    case ModerateUnban:
+        err = c.hub.LiftSanction(ctx, room, req.UserID, models.SanctionBan)
+    }
+
+    switch {
+    case errors.Is(err, ErrMessageNotFound):
+        c.sendError(msg, models.ErrorNotFound, "Message not found")
+    case errors.Is(err, ErrCannotSanction):
+        c.sendError(msg, models.ErrorForbidden, "Admins cannot be muted or banned")
+    case errors.Is(err, ErrOutranked):
+        c.sendError(msg, models.ErrorForbidden, "You can only sanction users below your role")
+    case err != nil:
+        c.hub.logger.Error("Failed to apply moderation action",
+            zap.Error(err),
+            zap.String("action", req.Action),
+            zap.String("room", room))
+        c.sendError(msg, models.ErrorInternal, "Moderation action failed")
+    default:
+        c.hub.logger.Info("Moderation action applied",
+            zap.String("action", req.Action),
+            zap.String("room", room),
+            zap.String("target_user", req.UserID),
+            zap.String("message_id", req.MessageID),
+            zap.String("actor", c.user.ID))
+    }
+}
@@ -0,0 +1,325 @@
+package websocket
+
+import (
+    "encoding/json"
+    "runtime"
+    "sort"
+    "sync"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/broker"
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+// Connection describes one websocket connection to this instance.
+type Connection struct {
+    UserID      string    `json:"user_id"`
+    Username    string    `json:"username"`
+    Rooms       []string  `json:"rooms"`
+    IP          string    `json:"ip"`
+    ConnectedAt time.Time `json:"connected_at"`
+    // Frames dropped because the connection's buffer was full
+    DroppedFrames int64 `json:"dropped_frames"`
+}
+
+// Connections lists this instance's connections, oldest first, optionally
+// only those in a room. Other instances report their own.
+func (h *Hub) Connections(room string) []*Connection {
+    var clients []*Client
+    if room != "" {
+        h.eachRoomClient(room, func(client *Client) {
+            clients = append(clients, client)
+        })
+    } else {
+        h.usersMu.RLock()
+        for _, user := range h.users {
+            for client := range user.clients {
+                clients = append(clients, client)
+            }
+        }
+        h.usersMu.RUnlock()
+    }
+
+    // Rooms are read after the users lock is released, as joins take the
+    // client lock before the rooms lock
+    connections := make([]*Connection, 0, len(clients))
+    for _, client := range clients {
+        client.mu.RLock()
+        rooms := make([]string, 0, len(client.rooms))
+        for joined := range client.rooms {
+            rooms = append(rooms, joined)
+        }
+        client.mu.RUnlock()
+        sort.Strings(rooms)
+
+        connections = append(connections, &Connection{
+            UserID:        client.user.ID,
+            Username:      client.summary().Username,
+            Rooms:         rooms,
+            IP:            client.ip,
+            ConnectedAt:   client.connectedAt,
+            DroppedFrames: client.dropped.Load(),
+        })
+    }
+    sort.Slice(connections, func(i, j int) bool {
+        return connections[i].ConnectedAt.Before(connections[j].ConnectedAt)
+    })
+    return connections
+}
+
+// DisconnectUser closes every connection of the user on every instance.
+// Clients reconnect unless their token was revoked or they were banned.
+func (h *Hub) DisconnectUser(userID string) {
+    h.publish(&broker.Message{Disconnect: userID, Priority: PriorityHigh})
+}
+
+// disconnectUser closes the user's connections to this instance.
+func (h *Hub) disconnectUser(userID string) {
+    h.eachUserClient(userID, func(client *Client) {
+        go func(c *Client) { h.unregister <- c }(client)
+    })
+}
+
+// AnnounceEverywhere sends a server-originated frame to every connection
+// on every instance. Like Announce, only one instance may send each
+// frame. It is not journaled, so clients that reconnect miss it.
+func (h *Hub) AnnounceEverywhere(message *models.WSMessage) {
+    payload, err := json.Marshal(message)
+    if err != nil {
+        h.logger.Error("Failed to marshal announcement", zap.Error(err))
+        return
+    }
+    h.publish(&broker.Message{Everyone: true, Payload: payload, Priority: PriorityHigh})
+}
+
+// NotifyUser sends a server-originated frame to every connection of the
+// user on every instance. Unlike SendToUser it reaches users connected
+// elsewhere, so work done away from their socket, such as a background
+// job, can still tell them about it.
+func (h *Hub) NotifyUser(userID string, message *models.WSMessage) {
+    payload, err := json.Marshal(message)
+    if err != nil {
+        h.logger.Error("Failed to marshal notification",
+            zap.Error(err),
+            zap.String("user_id", userID))
+        return
+    }
+    h.publish(&broker.Message{Users: []string{userID}, Payload: payload, Priority: PriorityNormal})
+}
+
+// deliverToEveryone writes a frame to every local connection.
+func (h *Hub) deliverToEveryone(msg *broker.Message) {
+    delivered := 0
+    h.usersMu.RLock()
+    for _, user := range h.users {
+        for client := range user.clients {
+            if client.enqueue(msg.Payload) {
+                delivered++
+            }
+        }
+    }
+    h.usersMu.RUnlock()
+    if delivered > 0 {
+        h.countOutbound(frameType(msg.Payload), roomSizeNone, len(msg.Payload), delivered)
+    }
+}
+
+// SlowModeChanged tells a room's clients on every instance how often each
+// of them may now post, and drops the room's cached settings so the new
+// limit applies at once.
+func (h *Hub) SlowModeChanged(room string, seconds int) {
+    data, err := json.Marshal(map[string]int{"seconds": seconds})
+    if err != nil {
+        return
+    }
+    payload, err := json.Marshal(&models.WSMessage{
+        Type:      models.MessageTypeSlowMode,
+        ChatRoom:  room,
+        Data:      data,
+        Timestamp: time.Now(),
+    })
+    if err != nil {
+        return
+    }
+
+    if h.journal != nil {
+        h.journal.Append(room, payload)
+    }
+    h.publish(&broker.Message{Room: room, Payload: payload, RoomChanged: true, Priority: PriorityHigh})
+}
+
+// BroadcastOnlyChanged tells a room's clients on every instance whether
+// only its broadcasters may now post, with a room_state frame, and drops
+// the room's cached settings so the mode applies at once.
+func (h *Hub) BroadcastOnlyChanged(room *models.ChatRoom) {
+    h.RoomStateChanged(&models.RoomStateChange{
+        RoomID:        room.ID,
+        State:         room.State,
+        ChangedAt:     time.Now(),
+        BroadcastOnly: room.BroadcastOnly,
+    })
+}
+
+// RoomThroughput is a room's traffic on this instance over the last
+// complete minute.
+type RoomThroughput struct {
+    Room        string `json:"room"`
+    Connections int    `json:"connections"`
+    // Chat messages delivered to the room, counted once per message
+    ChatMessages int64 `json:"chat_messages"`
+    // Frames of any type delivered to the room, counted once per frame
+    Frames int64 `json:"frames"`
+    // Frames written to connections: one per frame per recipient
+    Deliveries int64 `json:"deliveries"`
+}
+
+type frameCounts struct {
+    chat, frames, deliveries int64
+}
+
+// roomCounter counts a room's frames in the current minute and keeps the
+// totals of the minute before.
+type roomCounter struct {
+    mu            sync.Mutex
+    minute        int64
+    current, last frameCounts
+}
+
+// rotate moves the counts on to the given minute.
+func (c *roomCounter) rotate(minute int64) {
+    if c.minute == minute {
+        return
+    }
+    c.last = frameCounts{}
+    if c.minute == minute-1 {
+        c.last = c.current
+    }
+    c.current = frameCounts{}
+    c.minute = minute
+}
+
+// throughput counts frames delivered per room. Each room has its own
+// counter, so busy rooms do not contend with each other.
+type throughput struct {
+    rooms sync.Map // room -> *roomCounter
+}
+
+func newThroughput() *throughput {
+    return &throughput{}
+}
+
+func (t *throughput) record(room string, chat bool, deliveries int, now time.Time) {
+    value, ok := t.rooms.Load(room)
+    if !ok {
+        value, _ = t.rooms.LoadOrStore(room, &roomCounter{})
+    }
+    counter := value.(*roomCounter)
+
+    counter.mu.Lock()
+    counter.rotate(now.Unix() / 60)
+    if chat {
+        counter.current.chat++
+    }
+    counter.current.frames++
+    counter.current.deliveries += int64(deliveries)
+    counter.mu.Unlock()
+}
+
+// snapshot returns the last complete minute of every room with traffic,
+// forgetting rooms that had none.
+func (t *throughput) snapshot(now time.Time) map[string]*RoomThroughput {
+    minute := now.Unix() / 60
+    rooms := make(map[string]*RoomThroughput)
+    t.rooms.Range(func(key, value any) bool {
+        counter := value.(*roomCounter)
+        counter.mu.Lock()
+        counter.rotate(minute)
+        last, idle := counter.last, counter.current.frames == 0 && counter.last.frames == 0
+        counter.mu.Unlock()
+
+        if idle {
+            t.rooms.Delete(key)
+            return true
+        }
+        rooms[key.(string)] = &RoomThroughput{
+            Room:         key.(string),
+            ChatMessages: last.chat,
+            Frames:       last.frames,
+            Deliveries:   last.deliveries,
+        }
+        return true
+    })
+    return rooms
+}
+
+// RoomThroughput reports each room's traffic on this instance over the
+// last complete minute, busiest first. Rooms with connections but no
+// traffic are included.
+func (h *Hub) RoomThroughput() []*RoomThroughput {
+    rooms := h.throughput.snapshot(time.Now())
+    h.eachRoom(func(room string, clients map[*Client]bool) {
+        stats, ok := rooms[room]
+        if !ok {
+            stats = &RoomThroughput{Room: room}
+            rooms[room] = stats
+        }
+        stats.Connections = len(clients)
+    })
+
+    list := make([]*RoomThroughput, 0, len(rooms))
+    for _, stats := range rooms {
+        list = append(list, stats)
+    }
+    sort.Slice(list, func(i, j int) bool {
+        if list[i].Deliveries != list[j].Deliveries {
+            return list[i].Deliveries > list[j].Deliveries
+        }
+        return list[i].Room < list[j].Room
+    })
+    return list
+}
+
+// HubStats is a snapshot of this instance's hub, with one room's share of
+// it, for incident bundles. Other instances have their own.
+type HubStats struct {
+    CapturedAt time.Time `json:"captured_at"`
+    // The room's traffic here over the last complete minute
+    Room *RoomThroughput `json:"room"`
+    // Read-only streams of the room open here
+    RoomStreams int `json:"room_streams"`
+    // Instance-wide load
+    Connections int64 `json:"connections"`
+    Goroutines  int   `json:"goroutines"`
+    Shedding    bool  `json:"shedding"`
+    // Frames waiting for fan-out, by priority
+    FanoutQueued map[string]int `json:"fanout_queued"`
+}
+
+// Stats snapshots the hub and the given room on this instance.
+func (h *Hub) Stats(room string) *HubStats {
+    now := time.Now()
+    stats := &HubStats{
+        CapturedAt:   now,
+        Room:         &RoomThroughput{Room: room},
+        Connections:  h.shedder.connections.Load(),
+        Goroutines:   runtime.NumGoroutine(),
+        FanoutQueued: make(map[string]int, numPriorities),
+    }
+    if counts, ok := h.throughput.snapshot(now)[room]; ok {
+        stats.Room = counts
+    }
+    stats.Room.Connections = h.roomSize(room)
+
+    h.streams.mu.Lock()
+    stats.RoomStreams = len(h.streams.byRoom[room])
+    h.streams.mu.Unlock()
+
+    reason, _ := h.shedder.check()
+    stats.Shedding = reason != ""
+    for i, queue := range h.fanout.queues {
+        stats.FanoutQueued[priorityNames[i]] = len(queue)
+    }
+    return stats
+}
@@ -0,0 +1,171 @@
+package websocket
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+    "time"
+
+    "go.opentelemetry.io/otel/attribute"
+    "go.opentelemetry.io/otel/trace"
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/events"
+    "github.com/yourusername/sports-chat/internal/jobs"
+    "github.com/yourusername/sports-chat/internal/langdetect"
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/tracing"
+)
+
+const persistJobName = "message.persist"
+
+// persistJob stores a chat message through the job queue, so a store
+// outage is retried and, if it outlasts the retries, dead-lettered for
+// replay instead of losing the message.
+type persistJob struct {
+    hub     *Hub
+    Message *models.Message `json:"message"`
+    // Flag queues the message for moderator review once stored
+    Flag *models.MessageFlag `json:"flag,omitempty"`
+    // Trace is the sending frame's trace context, so persisting joins
+    // its trace even from a worker or a replay
+    Trace map[string]string `json:"trace,omitempty"`
+}
+
+func (j *persistJob) Name() string { return persistJobName }
+
+func (j *persistJob) Payload() ([]byte, error) { return json.Marshal(j) }
+
+func (j *persistJob) Run(ctx context.Context) (err error) {
+    ctx, span := tracing.Start(tracing.Extract(ctx, j.Trace), persistJobName,
+        trace.WithAttributes(attribute.String("room", j.Message.ChatRoomID)))
+    defer func() { tracing.End(span, err) }()
+
+    h := j.hub
+    if err := h.store.CreateMessage(ctx, j.Message); err != nil {
+        // An attempt that timed out may still have landed; retries and
+        // replays must not fail on it
+        if _, getErr := h.store.GetMessage(ctx, j.Message.ID); getErr != nil {
+            return fmt.Errorf("failed to persist message: %w", err)
+        }
+        return nil
+    }
+
+    j.afterStore(ctx)
+    return nil
+}
+
+// afterStore does what follows a message landing in the store. Its
+// failures are only logged, as the message itself is safe.
+func (j *persistJob) afterStore(ctx context.Context) {
+    h := j.hub
+    if j.Flag != nil {
+        if flagErr := h.store.CreateMessageFlag(ctx, j.Flag); flagErr != nil {
+            h.logger.Error("Failed to flag message for review", zap.Error(flagErr), zap.String("message_id", j.Message.ID))
+        }
+    }
+
+    if j.Message.Language != "" {
+        if langErr := h.store.RecordRoomLanguage(ctx, j.Message.ChatRoomID, j.Message.Language); langErr != nil {
+            h.logger.Warn("Failed to record room language", zap.Error(langErr), zap.String("room", j.Message.ChatRoomID))
+        }
+    }
+
+    if first, markErr := h.store.MarkFirstMessage(ctx, j.Message.UserID, j.Message.CreatedAt); markErr != nil {
+        h.logger.Warn("Failed to record first message", zap.Error(markErr), zap.String("user_id", j.Message.UserID))
+    } else if first {
+        h.events.Publish(events.FirstMessage{
+            UserID: j.Message.UserID,
+            RoomID: j.Message.ChatRoomID,
+            At:     j.Message.CreatedAt,
+        })
+    }
+
+    if h.unfurler != nil && h.roomSettings(j.Message.ChatRoomID).AllowLinkPreviews {
+        go h.unfurlMessage(&models.WSMessage{
+            ID:       j.Message.ID,
+            ChatRoom: j.Message.ChatRoomID,
+            Content:  j.Message.Content,
+        })
+    }
+}
+
+// registerPersistJob lets dead-lettered messages be replayed.
+func (h *Hub) registerPersistJob() {
+    h.jobs.Register(persistJobName, func(payload []byte) (jobs.Job, error) {
+        job := &persistJob{hub: h}
+        if err := json.Unmarshal(payload, job); err != nil {
+            return nil, err
+        }
+        if job.Message == nil {
+            return nil, fmt.Errorf("message missing from payload")
+        }
+        return job, nil
+    })
+}
+
+// persistMessage queues a chat message to be written in the next batch.
+// When the write-behind queue is full or stopped, the message is stored
+// on its own instead.
+func (h *Hub) persistMessage(ctx context.Context, message *models.WSMessage) {
+    job := &persistJob{hub: h, Message: &models.Message{
+        ID:          message.ID,
+        ChatRoomID:  message.ChatRoom,
+        UserID:      message.User.ID,
+        Content:     message.Content,
+        MessageType: models.MessageTypeChat,
+        CreatedAt:   message.Timestamp,
+        MatchMinute: message.MatchMinute,
+        MatchPeriod: message.MatchPeriod,
+        ClientMsgID: message.ClientMsgID,
+        Mentions:    message.Mentions,
+        TopicID:     message.TopicID,
+        Seq:         message.Seq,
+    }, Trace: tracing.Inject(ctx)}
+    job.Message.Language, _ = langdetect.Detect(message.Content)
+    if message.Flag != nil {
+        job.Flag = &models.MessageFlag{
+            MessageID: message.ID,
+            RoomID:    message.ChatRoom,
+            UserID:    message.User.ID,
+            Content:   message.Content,
+            Filter:    message.Flag.Filter,
+            Reason:    message.Flag.Reason,
+            Status:    models.FlagPending,
+            CreatedAt: message.Timestamp,
+        }
+    }
+
+    if h.writeBehind.enqueue(job) {
+        return
+    }
+    h.metrics.PersistOverflow.Inc()
+    h.persistOne(job)
+}
+
+// persistOne stores a single message through the job queue, with its
+// retries and dead letters. Without a queue, or when it is full, the
+// message is stored once from its own goroutine.
+func (h *Hub) persistOne(job *persistJob) {
+    if h.jobs != nil {
+        err := h.jobs.Enqueue(job)
+        if err == nil {
+            return
+        }
+        h.logger.Warn("Failed to enqueue message persistence",
+            zap.Error(err),
+            zap.String("room", job.Message.ChatRoomID))
+    }
+
+    go func() {
+        ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+        defer cancel()
+
+        if err := job.Run(ctx); err != nil {
+            h.logger.Error("Failed to persist message",
+                zap.Error(err),
+                zap.String("room", job.Message.ChatRoomID),
+                zap.String("user_id", job.Message.UserID))
+        }
+    }()
+}
@@ -0,0 +1,70 @@
+package websocket
+
+import (
+    "context"
+    "errors"
+    "strings"
+    "time"
+    "unicode/utf8"
+
+    "go.opentelemetry.io/otel/attribute"
+    "go.opentelemetry.io/otel/trace"
+
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/moderation"
+    "github.com/yourusername/sports-chat/internal/tracing"
+)
+
+// integrationExemption is the rate limit exemption messages posted by
+// internal integrations are counted under.
+const integrationExemption = "integration"
+
+var (
+    ErrEmptyMessage   = errors.New("message is empty")
+    ErrMessageTooLong = errors.New("message is too long")
+    ErrMessageBlocked = errors.New("message blocked by content filter")
+)
+
+// PostMessage posts a chat message from user into room on behalf of an
+// internal integration, the way a client's message is posted: run
+// through the moderation filters with the room's policy, sequenced,
+// stored and sent to every instance. The room's rate limit does not
+// apply, and mentions in it are not notified.
+func (h *Hub) PostMessage(ctx context.Context, room string, user *models.User, content string) error {
+    if strings.TrimSpace(content) == "" {
+        return ErrEmptyMessage
+    }
+    if utf8.RuneCountInString(content) > maxChatLength {
+        return ErrMessageTooLong
+    }
+
+    now := time.Now()
+    verdict := h.filters.Run(moderation.Input{
+        UserID:  user.ID,
+        Room:    room,
+        Locale:  h.roomLocale(room),
+        Content: content,
+        At:      now,
+        Strict:  user.Restricted(now),
+    })
+    if verdict.Action == models.ActionBlock {
+        return ErrMessageBlocked
+    }
+    msg := &models.WSMessage{
+        Type:      models.MessageTypeChat,
+        ChatRoom:  room,
+        User:      user.Summary(),
+        Content:   verdict.Content,
+        Timestamp: now,
+        Exemption: integrationExemption,
+    }
+    if verdict.Action == models.ActionFlag {
+        msg.Flag = &models.MessageFlag{Filter: verdict.Filter, Reason: verdict.Reason}
+    }
+
+    // The hub persists the message after the call has returned
+    ctx, _ = tracing.Start(context.WithoutCancel(ctx), "ws.integration_message",
+        trace.WithAttributes(attribute.String("room", room)))
+    h.broadcast <- &inbound{ctx: ctx, message: msg}
+    return nil
+}
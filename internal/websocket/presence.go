@@ -0,0 +1,143 @@
+package websocket
+
+import (
+    "context"
+    "encoding/json"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/broker"
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+const (
+    defaultPresenceInterval = 10 * time.Second
+    // attendanceInterval is how often watch party attendance is sampled
+    // for the room's owner.
+    attendanceInterval = time.Minute
+    // MaxPresenceUsers caps the user list of a presence response; the
+    // count always covers everyone.
+    MaxPresenceUsers = 100
+)
+
+// presenceLoop reports this instance's room members to the tracker and
+// sends every room it serves a presence frame with the room-wide count.
+func (h *Hub) presenceLoop() {
+    ticker := time.NewTicker(h.presenceInterval)
+    defer ticker.Stop()
+
+    for range ticker.C {
+        h.reportPresence()
+    }
+}
+
+func (h *Hub) reportPresence() {
+    ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+    defer cancel()
+
+    rooms := h.localPresence()
+    if err := h.presence.Report(ctx, rooms); err != nil {
+        h.logger.Error("Failed to report presence", zap.Error(err))
+        return
+    }
+
+    now := time.Now()
+    sampleAttendance := now.Sub(h.attendanceAt) >= attendanceInterval
+    if sampleAttendance {
+        h.attendanceAt = now
+    }
+
+    for room := range rooms {
+        members, err := h.presence.Members(ctx, room)
+        if err != nil {
+            h.logger.Warn("Failed to read presence", zap.Error(err), zap.String("room", room))
+            continue
+        }
+        // Every instance serving the room samples the same room-wide
+        // count; the store keeps one per minute
+        if sampleAttendance && h.roomSettings(room).OwnerID != "" {
+            if err := h.store.RecordRoomAttendance(ctx, room, now, len(members)); err != nil {
+                h.logger.Warn("Failed to record attendance", zap.Error(err), zap.String("room", room))
+            }
+        }
+
+        data, err := json.Marshal(&models.RoomPresence{RoomID: room, UserCount: len(members)})
+        if err != nil {
+            continue
+        }
+        payload, err := json.Marshal(&models.WSMessage{
+            Type:      models.MessageTypePresence,
+            ChatRoom:  room,
+            Data:      data,
+            Timestamp: time.Now(),
+        })
+        if err != nil {
+            continue
+        }
+        // Every instance sends its own clients the count, so presence
+        // frames never cross the broker
+        h.fanout.enqueue(&broker.Message{Room: room, Payload: payload, Priority: PriorityLow})
+    }
+}
+
+// localPresence returns the distinct users connected to each room on this
+// instance.
+func (h *Hub) localPresence() map[string][]string {
+    rooms := make(map[string][]string)
+    h.eachRoom(func(room string, clients map[*Client]bool) {
+        seen := make(map[string]bool, len(clients))
+        for client := range clients {
+            if !seen[client.user.ID] {
+                seen[client.user.ID] = true
+                rooms[room] = append(rooms[room], client.user.ID)
+            }
+        }
+    })
+    return rooms
+}
+
+// RoomPresence returns who is in a room on any instance. Users connected
+// here are always current; those on other instances are as of their last
+// report.
+func (h *Hub) RoomPresence(ctx context.Context, room string) (*models.RoomPresence, error) {
+    members, err := h.presence.Members(ctx, room)
+    if err != nil {
+        return nil, err
+    }
+
+    // Local users come with their summaries and may be newer than the
+    // last report
+    local := make(map[string]*models.UserSummary)
+    h.eachRoomClient(room, func(client *Client) {
+        local[client.user.ID] = client.summary()
+    })
+
+    presence := &models.RoomPresence{RoomID: room}
+    seen := make(map[string]bool, len(members)+len(local))
+    for id, summary := range local {
+        seen[id] = true
+        if len(presence.Users) < MaxPresenceUsers {
+            presence.Users = append(presence.Users, summary)
+        }
+    }
+    for _, id := range members {
+        if seen[id] {
+            continue
+        }
+        seen[id] = true
+        if len(presence.Users) < MaxPresenceUsers {
+            if user, err := h.store.GetUser(ctx, id); err == nil {
+                presence.Users = append(presence.Users, user.Summary())
+            }
+        }
+    }
+    presence.UserCount = len(seen)
+    return presence, nil
+}
+
+// RoomViewerCounts returns how many users are connected to each room
+// across instances, as of the last presence report.
+func (h *Hub) RoomViewerCounts(ctx context.Context, rooms []string) (map[string]int, error) {
+    return h.presence.Counts(ctx, rooms)
+}
@@ -0,0 +1,180 @@
+package websocket
+
+import (
+    "context"
+    "fmt"
+    "sync"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/broker"
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/store"
+)
+
+const (
+    // warmHold is how long after kickoff a pre-warmed room keeps what was
+    // loaded for it; by then the live match and the store's caches serve
+    // its clients.
+    warmHold = 15 * time.Minute
+    // maxWarmCapacity bounds the members a room's map is sized for up
+    // front, however many followers its match has.
+    maxWarmCapacity = 10000
+)
+
+// warmRoom is what was loaded ahead of kickoff for a room expected to be
+// busy, so the connections arriving in the first minute are answered
+// from memory.
+type warmRoom struct {
+    // The match as scheduled, for clients joining before it goes live
+    match *models.Match
+    // The page of recent messages sent on connect; nil once the room has
+    // moved on from it
+    history *HistoryPage
+    // The room's emote packs
+    emotes *EmoteList
+    // Members the room's map is sized for when it is first joined
+    capacity int
+    until    time.Time
+}
+
+// warmRooms holds the pre-warmed rooms of this instance.
+type warmRooms struct {
+    mu    sync.RWMutex
+    rooms map[string]*warmRoom
+}
+
+func newWarmRooms() *warmRooms {
+    return &warmRooms{rooms: make(map[string]*warmRoom)}
+}
+
+func (w *warmRooms) get(room string) (*warmRoom, bool) {
+    w.mu.RLock()
+    defer w.mu.RUnlock()
+    warm, ok := w.rooms[room]
+    if !ok || time.Now().After(warm.until) {
+        return nil, false
+    }
+    return warm, true
+}
+
+// put stores a room's warm state and forgets rooms whose hold has passed.
+func (w *warmRooms) put(room string, warm *warmRoom) {
+    w.mu.Lock()
+    defer w.mu.Unlock()
+    now := time.Now()
+    for name, other := range w.rooms {
+        if now.After(other.until) {
+            delete(w.rooms, name)
+        }
+    }
+    w.rooms[room] = warm
+}
+
+// staleHistory drops a room's warm history once a frame that changes it,
+// such as a chat message or a deletion, is delivered.
+func (w *warmRooms) staleHistory(room string) {
+    w.mu.RLock()
+    warm, ok := w.rooms[room]
+    stale := ok && warm.history != nil
+    w.mu.RUnlock()
+    if !stale {
+        return
+    }
+
+    w.mu.Lock()
+    if warm, ok := w.rooms[room]; ok {
+        warm.history = nil
+    }
+    w.mu.Unlock()
+}
+
+func (w *warmRooms) capacity(room string) int {
+    if warm, ok := w.get(room); ok {
+        return warm.capacity
+    }
+    return 0
+}
+
+// Prewarm readies this instance for a match room expected to draw
+// followers users at kickoff: the room's settings, first page of history
+// and emote packs are loaded, the match is kept for clients that join
+// before it goes live, and the room's member map is sized for the crowd
+// when it is first joined. Running it again refreshes what was loaded.
+func (h *Hub) Prewarm(ctx context.Context, match *models.Match, room string, followers int) error {
+    h.roomCacheMu.Lock()
+    delete(h.roomCache, room)
+    h.roomCacheMu.Unlock()
+    settings := h.roomSettings(room)
+
+    history, err := LoadHistoryPage(ctx, h.store, room, store.MessageFilter{}, "", "", h.initialHistoryBudget(room))
+    if err != nil {
+        return fmt.Errorf("failed to warm history: %w", err)
+    }
+    emotes, err := LoadRoomEmotes(ctx, h.store, settings, "", 0)
+    if err != nil {
+        return fmt.Errorf("failed to warm emotes: %w", err)
+    }
+
+    capacity := followers
+    if capacity > maxWarmCapacity {
+        capacity = maxWarmCapacity
+    }
+    h.warm.put(room, &warmRoom{
+        match:    match,
+        history:  history,
+        emotes:   emotes,
+        capacity: capacity,
+        until:    match.StartTime.Add(warmHold),
+    })
+
+    h.metrics.RoomsPrewarmed.Inc()
+    h.logger.Debug("Pre-warmed room",
+        zap.String("room", room),
+        zap.String("match_id", match.ID),
+        zap.Int("followers", followers))
+    return nil
+}
+
+// warmHistory returns a copy of a room's pre-warmed first page, if it is
+// still current. Reaction counts on it may lag by up to a pre-warm run.
+func (h *Hub) warmHistory(room string) (*HistoryPage, bool) {
+    warm, ok := h.warm.get(room)
+    if !ok {
+        return nil, false
+    }
+    h.warm.mu.RLock()
+    history := warm.history
+    h.warm.mu.RUnlock()
+    if history == nil {
+        return nil, false
+    }
+    page := *history
+    return &page, true
+}
+
+// warmMatch returns the scheduled match of a pre-warmed room.
+func (h *Hub) warmMatch(room string) (*models.Match, bool) {
+    if warm, ok := h.warm.get(room); ok && warm.match != nil {
+        return warm.match, true
+    }
+    return nil, false
+}
+
+// warmEmotes returns a pre-warmed room's emote packs.
+func (h *Hub) warmEmotes(room string) (*EmoteList, bool) {
+    if warm, ok := h.warm.get(room); ok && warm.emotes != nil {
+        return warm.emotes, true
+    }
+    return nil, false
+}
+
+// touchWarm keeps a pre-warmed room's history current with a frame
+// delivered to it. Presence, typing and reactions leave the messages as
+// they were.
+func (h *Hub) touchWarm(msg *broker.Message) {
+    if msg.Priority != PriorityLow {
+        h.warm.staleHistory(msg.Room)
+    }
+}
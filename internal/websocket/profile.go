@@ -0,0 +1,67 @@
+package websocket
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+    "time"
+
+    "github.com/yourusername/sports-chat/internal/broker"
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+// summary returns the client's user as other members see it. Profile
+// fields may change while the connection is open, so read them under
+// profileMu rather than through c.user directly.
+func (c *Client) summary() *models.UserSummary {
+    c.profileMu.RLock()
+    defer c.profileMu.RUnlock()
+    return c.user.Summary()
+}
+
+// UserUpdated tells every room the user has joined about their new
+// username, avatar or favorite team, so chat shows them without anyone
+// reconnecting. The frame is journaled, so clients replaying a room pick
+// the change up in order, and every instance updates its connections for
+// the user before delivering it.
+func (h *Hub) UserUpdated(ctx context.Context, user *models.User) error {
+    rooms, err := h.store.GetUserRooms(ctx, user.ID)
+    if err != nil {
+        return fmt.Errorf("failed to get user rooms: %w", err)
+    }
+
+    for _, room := range rooms {
+        payload, err := json.Marshal(&models.WSMessage{
+            Type:      models.MessageTypeUserUpdated,
+            ChatRoom:  room.ID,
+            User:      user.Summary(),
+            Timestamp: time.Now(),
+        })
+        if err != nil {
+            return fmt.Errorf("failed to marshal user update: %w", err)
+        }
+
+        if h.journal != nil {
+            h.journal.Append(room.ID, payload)
+        }
+        h.publish(&broker.Message{Room: room.ID, Payload: payload, Renamed: user.ID})
+    }
+    return nil
+}
+
+// applyProfile updates this instance's connections for a user from the
+// user_updated frame. It runs once per joined room; repeats are no-ops.
+func (h *Hub) applyProfile(userID string, payload []byte) {
+    var message models.WSMessage
+    if err := json.Unmarshal(payload, &message); err != nil || message.User == nil {
+        return
+    }
+
+    h.eachUserClient(userID, func(client *Client) {
+        client.profileMu.Lock()
+        client.user.Username = message.User.Username
+        client.user.AvatarURL = message.User.AvatarURL
+        client.user.FavoriteTeam = message.User.Flair
+        client.profileMu.Unlock()
+    })
+}
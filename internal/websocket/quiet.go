@@ -0,0 +1,44 @@
+package websocket
+
+import (
+    "context"
+    "time"
+
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+// ReloadQuietHours refreshes the quiet hours of a connected user after
+// they change. A user without a schedule is never held back.
+func (h *Hub) ReloadQuietHours(userID string) {
+    if !h.isConnected(userID) {
+        return
+    }
+
+    ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+    defer cancel()
+
+    quiet, err := h.store.GetQuietHours(ctx, userID)
+    if err != nil {
+        quiet = nil
+    }
+
+    h.usersMu.Lock()
+    defer h.usersMu.Unlock()
+
+    if user, ok := h.users[userID]; ok {
+        user.quietHours = quiet
+    }
+}
+
+// userQuietHours returns a connected user's quiet hours and favorite
+// team.
+func (h *Hub) userQuietHours(userID string) (*models.QuietHours, string) {
+    h.usersMu.RLock()
+    defer h.usersMu.RUnlock()
+
+    user, ok := h.users[userID]
+    if !ok {
+        return nil, ""
+    }
+    return user.quietHours, user.favoriteTeam
+}
@@ -0,0 +1,89 @@
+package websocket
+
+import (
+    "context"
+    "strconv"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/ratelimit"
+    "github.com/yourusername/sports-chat/internal/rbac"
+)
+
+// DefaultRateLimits matches the old per-connection limit, now applied per
+// user. Voice signaling trickles many ICE candidates during call setup.
+var DefaultRateLimits = ratelimit.Rules{
+    ratelimit.DefaultRule:   {Requests: 5, Window: time.Second},
+    models.MessageTypeVoice: {Requests: 50, Window: time.Second},
+}
+
+// allow takes a token from the user's bucket for the message type and
+// tells the client when it may retry if there is none. Buckets are keyed
+// by user so every connection of a user draws from the same one.
+func (c *Client) allow(msg *models.WSMessage) bool {
+    msgType := msg.Type
+    ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+    defer cancel()
+
+    rule := c.hub.userLimits.For(msgType)
+    result, err := c.hub.userLimiter.Allow(ctx, "ws:"+c.user.ID+":"+msgType, rule)
+    if err != nil {
+        // Losing the limiter must not take chat down with it
+        c.hub.logger.Warn("Rate limit check failed", zap.Error(err), zap.String("user_id", c.user.ID))
+        return true
+    }
+    if result.Allowed {
+        return true
+    }
+
+    c.hub.metrics.RateLimited.WithLabelValues("ws_client").Inc()
+    c.sendRateLimited(msg, models.ErrorRateLimited, "Rate limit exceeded", result.RetryAfter)
+    return false
+}
+
+// allowSlowMode lets the user post to a room in slow mode once per the
+// room's interval, across all their connections. Roles with the bypass
+// permission, such as moderators and VIPs, are not held to it.
+func (c *Client) allowSlowMode(msg *models.WSMessage) bool {
+    room := msg.ChatRoom
+    seconds := c.hub.roomSettings(room).SlowModeSeconds
+    if seconds <= 0 {
+        return true
+    }
+
+    ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+    defer cancel()
+
+    if c.hub.roles.Can(ctx, c.principal, room, rbac.PermBypassSlowMode) {
+        return true
+    }
+
+    // The interval is part of the key, so changing it starts afresh
+    rule := ratelimit.Rule{Requests: 1, Window: time.Duration(seconds) * time.Second}
+    key := "slow:" + room + ":" + strconv.Itoa(seconds) + ":" + c.user.ID
+    result, err := c.hub.userLimiter.Allow(ctx, key, rule)
+    if err != nil {
+        c.hub.logger.Warn("Slow mode check failed", zap.Error(err), zap.String("user_id", c.user.ID))
+        return true
+    }
+    if result.Allowed {
+        c.postedSlowMode(room, time.Now().Add(rule.Window))
+        return true
+    }
+
+    c.hub.metrics.RateLimited.WithLabelValues("ws_slow_mode").Inc()
+    c.sendRateLimited(msg, models.ErrorSlowMode, "This room is in slow mode", result.RetryAfter)
+    return false
+}
+
+// sendRateLimited tells the client a message was refused and when it
+// may retry.
+func (c *Client) sendRateLimited(msg *models.WSMessage, code, content string, retryAfter time.Duration) {
+    c.sendWSError(msg, &models.WSError{
+        Code:         code,
+        Message:      content,
+        RetryAfterMs: retryAfter.Milliseconds(),
+    })
+}
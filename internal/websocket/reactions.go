@@ -0,0 +1,155 @@
+package websocket
+
+import (
+    "context"
+    "encoding/json"
+    "time"
+    "unicode"
+    "unicode/utf8"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/events"
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/store"
+)
+
+// Reaction actions in a client's reaction frame
+const (
+    ReactionAdd    = "add"
+    ReactionRemove = "remove"
+)
+
+const maxEmojiRunes = 8
+
+type reactionRequest struct {
+    MessageID string `json:"message_id"`
+    Emoji     string `json:"emoji"`
+    Action    string `json:"action"`
+}
+
+// reactionUpdate is the payload broadcast after a reaction changes: the
+// message's full counts, so clients never have to apply deltas.
+type reactionUpdate struct {
+    MessageID string                  `json:"message_id"`
+    Reactions []*models.ReactionCount `json:"reactions"`
+}
+
+// handleReaction applies a reaction frame from c and broadcasts the
+// message's new counts to the room.
+func (c *Client) handleReaction(msg *models.WSMessage) {
+    var req reactionRequest
+    if err := json.Unmarshal(msg.Data, &req); err != nil || req.MessageID == "" || !ValidEmoji(req.Emoji) {
+        c.sendError(msg, models.ErrorInvalidRequest, "Invalid reaction")
+        return
+    }
+
+    ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+    defer cancel()
+
+    message, err := c.hub.store.GetMessage(ctx, req.MessageID)
+    if err != nil || message.ChatRoomID != msg.ChatRoom {
+        c.sendError(msg, models.ErrorNotFound, "Message not found")
+        return
+    }
+
+    var changed bool
+    switch req.Action {
+    case ReactionAdd, "":
+        changed, err = c.hub.store.AddReaction(ctx, &models.Reaction{
+            MessageID: message.ID,
+            UserID:    c.user.ID,
+            Emoji:     req.Emoji,
+            CreatedAt: time.Now(),
+        })
+    case ReactionRemove:
+        changed, err = c.hub.store.RemoveReaction(ctx, message.ID, c.user.ID, req.Emoji)
+    default:
+        c.sendError(msg, models.ErrorInvalidRequest, "Invalid reaction")
+        return
+    }
+    if err != nil {
+        c.hub.logger.Error("Failed to update reaction",
+            zap.Error(err),
+            zap.String("message_id", message.ID),
+            zap.String("user_id", c.user.ID))
+        c.sendError(msg, models.ErrorInternal, "Failed to update reaction")
+        return
+    }
+    if changed && req.Action != ReactionRemove {
+        c.hub.hype.count(msg.ChatRoom, req.Emoji, false)
+    }
+    if changed {
+        c.hub.events.Publish(events.ReactionChanged{
+            MessageID: message.ID,
+            AuthorID:  message.UserID,
+            UserID:    c.user.ID,
+            RoomID:    msg.ChatRoom,
+            Removed:   req.Action == ReactionRemove,
+            At:        time.Now(),
+        })
+    }
+
+    counts, err := c.hub.store.GetMessageReactions(ctx, []string{message.ID})
+    if err != nil {
+        c.hub.logger.Error("Failed to get reactions",
+            zap.Error(err),
+            zap.String("message_id", message.ID))
+        return
+    }
+
+    data, err := json.Marshal(reactionUpdate{MessageID: message.ID, Reactions: counts[message.ID]})
+    if err != nil {
+        return
+    }
+    c.hub.broadcastToRoom(msg.ChatRoom, &models.WSMessage{
+        Type:      models.MessageTypeReaction,
+        ChatRoom:  msg.ChatRoom,
+        User:      c.summary(),
+        Data:      data,
+        Timestamp: time.Now(),
+    })
+}
+
+// ValidEmoji accepts a single short emoji sequence: symbols plus the
+// joiners, variation selectors and skin tone modifiers that compose them.
+func ValidEmoji(emoji string) bool {
+    if emoji == "" || utf8.RuneCountInString(emoji) > maxEmojiRunes {
+        return false
+    }
+    hasSymbol := false
+    for _, r := range emoji {
+        switch {
+        case unicode.Is(unicode.So, r),
+            r >= 0x1F1E6 && r <= 0x1F1FF: // regional indicators (flags)
+            hasSymbol = true
+        case r == 0x200D, // zero width joiner
+            r >= 0xFE00 && r <= 0xFE0F,   // variation selectors
+            r >= 0x1F3FB && r <= 0x1F3FF, // skin tone modifiers
+            r >= 0xE0020 && r <= 0xE007F: // tag sequences
+        default:
+            return false
+        }
+    }
+    return hasSymbol
+}
+
+// LoadReactions attaches aggregated reaction counts to messages.
+func LoadReactions(ctx context.Context, st store.Store, messages []*models.Message) error {
+    if len(messages) == 0 {
+        return nil
+    }
+    ids := make([]string, len(messages))
+    for i, m := range messages {
+        ids[i] = m.ID
+    }
+
+    counts, err := st.GetMessageReactions(ctx, ids)
+    if err != nil {
+        return err
+    }
+    for _, m := range messages {
+        m.Reactions = counts[m.ID]
+    }
+    return nil
+}
@@ -0,0 +1,81 @@
+package websocket
+
+import (
+    "context"
+    "encoding/json"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+// readRequest is the data of a client's read command. At is how far the
+// user has read; zero means everything up to now.
+type readRequest struct {
+    At time.Time `json:"at"`
+}
+
+// readState is the data of a read frame sent to the user's connections.
+type readState struct {
+    LastReadAt  time.Time `json:"last_read_at"`
+    UnreadCount int       `json:"unread_count"`
+}
+
+// handleRead moves the user's read marker for a room and syncs it to
+// their other devices.
+func (c *Client) handleRead(msg *models.WSMessage) {
+    var req readRequest
+    if len(msg.Data) > 0 {
+        if err := json.Unmarshal(msg.Data, &req); err != nil {
+            c.sendError(msg, models.ErrorInvalidRequest, "Invalid read request")
+            return
+        }
+    }
+    now := time.Now()
+    if req.At.IsZero() || req.At.After(now) {
+        req.At = now
+    }
+
+    ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+    defer cancel()
+
+    if err := c.hub.MarkRoomRead(ctx, c.user.ID, msg.ChatRoom, req.At); err != nil {
+        c.hub.logger.Error("Failed to mark room read",
+            zap.Error(err),
+            zap.String("room", msg.ChatRoom),
+            zap.String("user_id", c.user.ID))
+        c.sendError(msg, models.ErrorInternal, "Failed to mark room read")
+    }
+}
+
+// MarkRoomRead moves a user's read marker for a room forward and sends
+// the room's read state to every connection of the user, on any
+// instance, so badges clear on all their devices.
+func (h *Hub) MarkRoomRead(ctx context.Context, userID, room string, at time.Time) error {
+    if err := h.store.MarkRoomRead(ctx, userID, room, at); err != nil {
+        return err
+    }
+
+    // The marker only moves forward, so send where it ended up
+    counts, err := h.store.GetUnreadCounts(ctx, userID)
+    if err != nil {
+        return err
+    }
+    for _, count := range counts {
+        if count.RoomID != room {
+            continue
+        }
+        data, err := json.Marshal(readState{LastReadAt: count.LastReadAt, UnreadCount: count.UnreadCount})
+        if err != nil {
+            return err
+        }
+        h.SendToUser(userID, &models.WSMessage{
+            Type:      models.MessageTypeRead,
+            ChatRoom:  room,
+            Data:      data,
+            Timestamp: time.Now(),
+        })
+    }
+    return nil
+}
@@ -0,0 +1,380 @@
+package websocket
+
+import (
+    "bytes"
+    "context"
+    "encoding/json"
+    "strconv"
+    "sync"
+    "sync/atomic"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/resume"
+)
+
+const (
+    // DefaultResumeWindow is how long after a drop a connection can be
+    // resumed when the hub's options leave it unset.
+    DefaultResumeWindow = 2 * time.Minute
+
+    // resumeGrace keeps a dropped connection's state a little past the
+    // window, so the timer that ends the window still finds it there.
+    resumeGrace = 30 * time.Second
+)
+
+// resumeTracker follows where a connection stands, so that a reconnect
+// with its token can carry on from there. The client is known to have
+// received a frame once it answers a ping sent after it: frames arrive in
+// order, so a pong confirms the sequences written before its ping.
+// Frames written since the last pong may arrive twice after a resume;
+// clients drop sequences they already have.
+type resumeTracker struct {
+    token string
+    // Rooms the connection was restored to, whose joins went out on the
+    // connection it resumed; never changed after connecting
+    restored map[string]bool
+
+    mu sync.Mutex
+    // Last sequence written per room
+    written map[string]int64
+    // Snapshot of written taken with the outstanding ping
+    pingID  uint64
+    pending map[string]int64
+    // Last sequence per room the client confirmed receiving
+    confirmed map[string]int64
+    // When the user may next post in each slow mode room
+    slowUntil map[string]time.Time
+
+    // Set once a reconnect resumed the connection's state, or the client
+    // closed the connection deliberately; either way nothing is held for
+    // it when it goes
+    superseded atomic.Bool
+    ended      atomic.Bool
+}
+
+// frameSeq reads a room frame's sequence, zero if it has none. Only chat
+// messages are sequenced, so most frames are passed over unparsed.
+func frameSeq(payload []byte) int64 {
+    if !bytes.Contains(payload, []byte(`"seq":`)) {
+        return 0
+    }
+    var frame struct {
+        Seq int64 `json:"seq"`
+    }
+    if err := json.Unmarshal(payload, &frame); err != nil {
+        return 0
+    }
+    return frame.Seq
+}
+
+// wrote records a frame the write pump wrote.
+func (c *Client) wrote(frame outFrame) {
+    t := c.resume
+    if t == nil || frame.seq == 0 {
+        return
+    }
+    t.mu.Lock()
+    if frame.seq > t.written[frame.room] {
+        t.written[frame.room] = frame.seq
+    }
+    t.mu.Unlock()
+}
+
+// pingPayload snapshots what has been written under a new ping, whose
+// payload the client's pong echoes back; nil for connections that are
+// not resumable.
+func (c *Client) pingPayload() []byte {
+    t := c.resume
+    if t == nil {
+        return nil
+    }
+    t.mu.Lock()
+    defer t.mu.Unlock()
+
+    t.pingID++
+    t.pending = make(map[string]int64, len(t.written))
+    for room, seq := range t.written {
+        t.pending[room] = seq
+    }
+    return []byte(strconv.FormatUint(t.pingID, 10))
+}
+
+// ponged confirms the snapshot taken with the ping a pong answers and
+// saves the connection's state afresh. Pongs answering no ping of ours
+// are ignored.
+func (c *Client) ponged(appData string) {
+    t := c.resume
+    if t == nil {
+        return
+    }
+    t.mu.Lock()
+    if t.pending == nil || appData != strconv.FormatUint(t.pingID, 10) {
+        t.mu.Unlock()
+        return
+    }
+    for room, seq := range t.pending {
+        if seq > t.confirmed[room] {
+            t.confirmed[room] = seq
+        }
+    }
+    t.pending = nil
+    t.mu.Unlock()
+
+    go c.hub.refreshResume(c)
+}
+
+// postedSlowMode records when the user may next post in a slow mode room.
+func (c *Client) postedSlowMode(room string, until time.Time) {
+    t := c.resume
+    if t == nil {
+        return
+    }
+    t.mu.Lock()
+    t.slowUntil[room] = until
+    t.mu.Unlock()
+}
+
+// restoredRoom reports whether the connection was restored to room from
+// the connection it resumed.
+func (c *Client) restoredRoom(room string) bool {
+    return c.resume != nil && c.resume.restored[room]
+}
+
+// resumeState is where the connection stands.
+func (c *Client) resumeState() *resume.State {
+    state := &resume.State{
+        UserID:        c.user.ID,
+        ConnID:        c.id,
+        Since:         make(map[string]int64),
+        SlowModeUntil: make(map[string]time.Time),
+    }
+    c.mu.RLock()
+    for room := range c.rooms {
+        state.Rooms = append(state.Rooms, room)
+    }
+    c.mu.RUnlock()
+
+    now := time.Now()
+    t := c.resume
+    t.mu.Lock()
+    for _, room := range state.Rooms {
+        if seq, ok := t.confirmed[room]; ok {
+            state.Since[room] = seq
+        }
+        if until, ok := t.slowUntil[room]; ok && until.After(now) {
+            state.SlowModeUntil[room] = until
+        }
+    }
+    t.mu.Unlock()
+    return state
+}
+
+// takeResume claims the state a reconnect's token was saved with; nil if
+// resuming is off, or the token is unknown, expired or another user's.
+// Only one reconnect can claim a state.
+func (h *Hub) takeResume(ctx context.Context, token, userID string) *resume.State {
+    if h.resume == nil || token == "" {
+        return nil
+    }
+    ctx, cancel := context.WithTimeout(ctx, time.Second)
+    defer cancel()
+
+    state, err := h.resume.Take(ctx, token)
+    if err != nil {
+        h.logger.Warn("Failed to take resume state", zap.Error(err), zap.String("user_id", userID))
+        return nil
+    }
+    if state == nil || state.UserID != userID {
+        return nil
+    }
+    return state
+}
+
+// returnResume puts back a state taken by a reconnect that failed before
+// connecting, so the client can try again.
+func (h *Hub) returnResume(token string, state *resume.State) {
+    if state == nil {
+        return
+    }
+    ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+    defer cancel()
+
+    if err := h.resume.Save(ctx, token, state, h.resumeWindow); err != nil {
+        h.logger.Warn("Failed to return resume state", zap.Error(err), zap.String("user_id", state.UserID))
+    }
+}
+
+// resumeSince is where a connection starts in each room: the sequences
+// the client sent, and for other rooms those of the state it resumed.
+func resumeSince(since map[string]int64, restored *resume.State, rooms map[string]bool) map[string]int64 {
+    if restored == nil {
+        return since
+    }
+    for room, seq := range restored.Since {
+        if _, sent := since[room]; !sent && rooms[room] {
+            since[room] = seq
+        }
+    }
+    return since
+}
+
+// trackResume makes a new connection resumable: it saves the
+// connection's state under a fresh token and sends the client the token,
+// with where it stands in the rooms restored from restored, if any. If
+// the state cannot be saved the connection is simply not resumable.
+func (h *Hub) trackResume(client *Client, restored *resume.State) {
+    if h.resume == nil {
+        return
+    }
+    token, err := resume.NewToken()
+    if err != nil {
+        h.logger.Error("Failed to generate resume token", zap.Error(err))
+        return
+    }
+
+    t := &resumeTracker{
+        token:     token,
+        restored:  make(map[string]bool),
+        written:   make(map[string]int64),
+        confirmed: make(map[string]int64),
+        slowUntil: make(map[string]time.Time),
+    }
+    for room, seq := range client.since {
+        t.confirmed[room] = seq
+    }
+    now := time.Now()
+    if restored != nil {
+        for _, room := range restored.Rooms {
+            if client.rooms[room] {
+                t.restored[room] = true
+            }
+        }
+        for room, until := range restored.SlowModeUntil {
+            if until.After(now) {
+                t.slowUntil[room] = until
+            }
+        }
+    }
+    client.resume = t
+
+    ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+    defer cancel()
+    if err := h.resume.Save(ctx, token, client.resumeState(), pongWait+h.resumeWindow); err != nil {
+        h.logger.Warn("Failed to save resume state", zap.Error(err), zap.String("user_id", client.user.ID))
+        client.resume = nil
+        return
+    }
+
+    info := &models.ResumeInfo{
+        Token:     token,
+        ExpiresIn: int(h.resumeWindow / time.Second),
+        Resumed:   restored != nil,
+    }
+    for room := range t.restored {
+        resumed := &models.ResumedRoom{RoomID: room, LastSeq: client.since[room]}
+        resumed.Muted, _ = h.sanctionsFor(room, client.user.ID)
+        if until, ok := t.slowUntil[room]; ok && h.roomSettings(room).SlowModeSeconds > 0 {
+            resumed.SlowModeUntil = &until
+        }
+        info.Rooms = append(info.Rooms, resumed)
+    }
+    data, err := json.Marshal(info)
+    if err != nil {
+        return
+    }
+    payload, err := json.Marshal(&models.WSMessage{
+        Type:      models.MessageTypeResume,
+        Data:      data,
+        Timestamp: now,
+    })
+    if err != nil {
+        return
+    }
+    client.trySend(payload)
+}
+
+// refreshResume saves a live connection's state afresh. Finding it gone
+// means a reconnect resumed it: this connection is then a leftover of
+// the one the client dropped, and keeps nothing for it when it closes.
+func (h *Hub) refreshResume(client *Client) {
+    t := client.resume
+    if t.superseded.Load() {
+        return
+    }
+    ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+    defer cancel()
+
+    saved, err := h.resume.Refresh(ctx, t.token, client.resumeState(), pongWait+h.resumeWindow)
+    if err != nil {
+        h.logger.Warn("Failed to refresh resume state", zap.Error(err), zap.String("user_id", client.user.ID))
+        return
+    }
+    if !saved {
+        t.superseded.Store(true)
+    }
+}
+
+// holdLeaves keeps a dropped connection's leaves back for the resume
+// window, saving where it stood. A reconnect resuming it within the
+// window claims the state and the leaves never go out; otherwise they go
+// when the window ends. A connection already resumed elsewhere sends
+// none, and one the client closed deliberately sends them at once.
+func (h *Hub) holdLeaves(client *Client, leaves []*models.WSMessage) {
+    t := client.resume
+    if t.superseded.Load() {
+        return
+    }
+    ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+    defer cancel()
+
+    if t.ended.Load() {
+        if _, err := h.resume.Take(ctx, t.token); err != nil {
+            h.logger.Warn("Failed to discard resume state", zap.Error(err), zap.String("user_id", client.user.ID))
+        }
+        h.sendLeaves(client.user.ID, leaves)
+        return
+    }
+
+    saved, err := h.resume.Refresh(ctx, t.token, client.resumeState(), h.resumeWindow+resumeGrace)
+    if err != nil {
+        h.logger.Warn("Failed to save resume state", zap.Error(err), zap.String("user_id", client.user.ID))
+        h.sendLeaves(client.user.ID, leaves)
+        return
+    }
+    if !saved {
+        // Resumed while this connection was still closing
+        return
+    }
+
+    time.AfterFunc(h.resumeWindow, func() {
+        ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+        defer cancel()
+
+        state, err := h.resume.Take(ctx, t.token)
+        if err != nil {
+            h.logger.Warn("Failed to take resume state", zap.Error(err), zap.String("user_id", client.user.ID))
+        } else if state == nil {
+            return
+        }
+        h.sendLeaves(client.user.ID, leaves)
+    })
+}
+
+// sendLeaves announces leaves held back, except from rooms the user is
+// back in on this instance, where a join has been announced since.
+func (h *Hub) sendLeaves(userID string, leaves []*models.WSMessage) {
+    for _, leaveMsg := range leaves {
+        present := false
+        h.eachRoomClient(leaveMsg.ChatRoom, func(client *Client) {
+            if client.user.ID == userID {
+                present = true
+            }
+        })
+        if !present {
+            h.broadcastToRoom(leaveMsg.ChatRoom, leaveMsg)
+        }
+    }
+}
@@ -0,0 +1,35 @@
+package websocket
+
+import (
+    "encoding/json"
+    "time"
+
+    "github.com/yourusername/sports-chat/internal/broker"
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+// RoomRoleChanged has every instance drop its cached role for the user
+// and tells the user's connections in the room their new role, so
+// clients can show or hide moderation controls.
+func (h *Hub) RoomRoleChanged(room, userID, role string) {
+    data, err := json.Marshal(map[string]string{"user_id": userID, "role": role})
+    if err != nil {
+        return
+    }
+    payload, err := json.Marshal(&models.WSMessage{
+        Type:      models.MessageTypeRoomRole,
+        ChatRoom:  room,
+        Data:      data,
+        Timestamp: time.Now(),
+    })
+    if err != nil {
+        return
+    }
+    h.publish(&broker.Message{
+        Room:        room,
+        Payload:     payload,
+        TargetUser:  userID,
+        RoleChanged: userID,
+        Priority:    PriorityHigh,
+    })
+}
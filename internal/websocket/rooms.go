@@ -0,0 +1,162 @@
+package websocket
+
+import (
+    "context"
+    "encoding/json"
+    "hash/fnv"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/broker"
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+// RedirectRoom moves every connected client of room from into one of
+// targets and remembers the redirect so later connections asking for from
+// follow it. With several targets (a split) users are distributed by a
+// stable hash of their ID, so all of a user's connections land together.
+func (h *Hub) RedirectRoom(from string, targets []string, reason string) {
+    if len(targets) == 0 {
+        return
+    }
+
+    // Held throughout so no client joins from while its members move
+    h.redirectMu.Lock()
+    defer h.redirectMu.Unlock()
+
+    h.redirects[from] = targets
+
+    for _, client := range h.takeRoom(from) {
+        to := targets[shardFor(client.user.ID, len(targets))]
+        h.addToRoom(to, client)
+
+        client.mu.Lock()
+        delete(client.rooms, from)
+        client.rooms[to] = true
+        client.mu.Unlock()
+
+        h.sendRedirect(client, from, to, reason)
+    }
+}
+
+// ShardFor reports which of n shards a user is assigned to when a room is
+// split. The API uses it to move stored memberships consistently with the
+// hub's redirect of live connections.
+func ShardFor(userID string, n int) int {
+    return shardFor(userID, n)
+}
+
+func shardFor(userID string, n int) int {
+    hash := fnv.New32a()
+    hash.Write([]byte(userID))
+    return int(hash.Sum32() % uint32(n))
+}
+
+// resolveRooms rewrites a connecting client's rooms through any redirects.
+// Must be called with h.redirectMu held.
+func (h *Hub) resolveRooms(client *Client) {
+    client.mu.Lock()
+    defer client.mu.Unlock()
+
+    for room := range client.rooms {
+        targets, ok := h.redirects[room]
+        if !ok {
+            continue
+        }
+        to := targets[shardFor(client.user.ID, len(targets))]
+        delete(client.rooms, room)
+        client.rooms[to] = true
+
+        h.sendRedirect(client, room, to, "redirected")
+    }
+}
+
+// sendRedirect tells one connection it was moved. Redirects are only
+// ever sent this way, by the server to each connection it moved; they
+// never go out to a room, and readPump refuses them from clients.
+func (h *Hub) sendRedirect(client *Client, from, to, reason string) {
+    data, err := json.Marshal(models.RoomRedirect{From: from, To: to, Reason: reason})
+    if err != nil {
+        return
+    }
+    payload, err := json.Marshal(&models.WSMessage{
+        Type:      models.MessageTypeRedirect,
+        ChatRoom:  to,
+        Data:      data,
+        Timestamp: time.Now(),
+    })
+    if err != nil {
+        h.logger.Error("Failed to marshal redirect", zap.Error(err))
+        return
+    }
+
+    client.trySend(payload)
+}
+
+// RoomDeleted tells a deleted room's clients on every instance where it
+// went, with a room_deleted frame carrying its tombstone. Every instance
+// then removes its clients from the room; they stay connected to their
+// other rooms.
+func (h *Hub) RoomDeleted(tombstone *models.RoomTombstone) {
+    payload, err := roomDeletedFrame(tombstone)
+    if err != nil {
+        h.logger.Error("Failed to marshal room deletion", zap.Error(err))
+        return
+    }
+
+    if h.journal != nil {
+        h.journal.Append(tombstone.RoomID, payload)
+    }
+    h.publish(&broker.Message{Room: tombstone.RoomID, Payload: payload, RoomChanged: true, RoomDeleted: true, Priority: PriorityHigh})
+}
+
+// closeRoom removes this instance's clients from a deleted room.
+func (h *Hub) closeRoom(room string) {
+    for _, client := range h.takeRoom(room) {
+        client.mu.Lock()
+        delete(client.rooms, room)
+        client.mu.Unlock()
+    }
+}
+
+// deletedRoom returns the room's tombstone if it was deleted. Only
+// inactive rooms are looked up, as rooms are deactivated when deleted;
+// a failed lookup lets the client in, as for other room settings.
+func (h *Hub) deletedRoom(room string) *models.RoomTombstone {
+    if h.roomSettings(room).IsActive {
+        return nil
+    }
+
+    ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+    defer cancel()
+
+    tombstone, err := h.store.GetRoomTombstone(ctx, room)
+    if err != nil {
+        return nil
+    }
+    return tombstone
+}
+
+// sendRoomDeleted tells one client a room it asked for was deleted.
+func (h *Hub) sendRoomDeleted(client *Client, tombstone *models.RoomTombstone) {
+    payload, err := roomDeletedFrame(tombstone)
+    if err != nil {
+        h.logger.Error("Failed to marshal room deletion", zap.Error(err))
+        return
+    }
+    client.trySend(payload)
+}
+
+func roomDeletedFrame(tombstone *models.RoomTombstone) ([]byte, error) {
+    data, err := json.Marshal(tombstone)
+    if err != nil {
+        return nil, err
+    }
+    return json.Marshal(&models.WSMessage{
+        Type:      models.MessageTypeRoomDeleted,
+        ChatRoom:  tombstone.RoomID,
+        Data:      data,
+        Timestamp: time.Now(),
+    })
+}
@@ -0,0 +1,80 @@
+package websocket
+
+import (
+    "context"
+    "encoding/json"
+    "time"
+
+    "github.com/google/uuid"
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/broker"
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/tracing"
+)
+
+// shadowBanned reports whether the connection's user is shadow-banned.
+func (c *Client) shadowBanned() bool {
+    c.profileMu.RLock()
+    defer c.profileMu.RUnlock()
+    return c.user.ShadowBanned
+}
+
+// echoShadowed handles a shadow-banned user's chat message as if it were
+// posted: it is numbered with an ID, acknowledged and delivered to the
+// sender's connections in the room on every instance. It is not stored,
+// sequenced or journaled, and mentions and alerts are not sent, so no one
+// else ever sees it.
+func (h *Hub) echoShadowed(ctx context.Context, message *models.WSMessage) {
+    if h.acknowledged(message) {
+        return
+    }
+    message.ID = uuid.NewString()
+    h.acknowledge(message)
+    message.MatchMinute, message.MatchPeriod = h.matchClock(message.ChatRoom)
+
+    payload, err := json.Marshal(message)
+    if err != nil {
+        h.logger.Error("Failed to marshal message",
+            zap.Error(err),
+            zap.String("room", message.ChatRoom))
+        return
+    }
+    h.publish(&broker.Message{
+        Room:        message.ChatRoom,
+        Payload:     payload,
+        TargetUser:  message.User.ID,
+        ExcludeConn: message.ExcludeConn,
+        Priority:    postedPriority(message),
+        Trace:       tracing.Inject(ctx),
+    })
+}
+
+// ShadowBanChanged tells every instance that a user's shadow ban was
+// applied or removed, so their open connections pick it up without
+// reconnecting.
+func (h *Hub) ShadowBanChanged(userID string) {
+    h.publish(&broker.Message{ShadowBanned: userID, Priority: PriorityHigh})
+}
+
+// reloadShadowBan refreshes the shadow ban of a user's connections to
+// this instance from the store.
+func (h *Hub) reloadShadowBan(userID string) {
+    if !h.isConnected(userID) {
+        return
+    }
+
+    ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+    defer cancel()
+    user, err := h.store.GetUser(ctx, userID)
+    if err != nil {
+        h.logger.Warn("Failed to reload shadow ban", zap.Error(err), zap.String("user_id", userID))
+        return
+    }
+
+    h.eachUserClient(userID, func(client *Client) {
+        client.profileMu.Lock()
+        client.user.ShadowBanned = user.ShadowBanned
+        client.profileMu.Unlock()
+    })
+}
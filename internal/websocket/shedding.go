@@ -0,0 +1,170 @@
+package websocket
+
+import (
+    "fmt"
+    "net/http"
+    "runtime"
+    rtmetrics "runtime/metrics"
+    "strconv"
+    "sync"
+    "sync/atomic"
+    "time"
+
+    "github.com/gorilla/websocket"
+    "go.uber.org/zap"
+)
+
+// Load shedding reasons, reported in metrics and the close reason.
+const (
+    ShedConnections = "connections"
+    ShedGoroutines  = "goroutines"
+    ShedHeap        = "heap"
+    // ShedStalled is shed while the hub loop has stopped turning over
+    ShedStalled = "stalled"
+)
+
+// shedResume is the fraction of each limit load must fall back under
+// before upgrades are accepted again, so an instance hovering at a limit
+// does not flap.
+const shedResume = 0.9
+
+// heapSampleEvery bounds how often the heap size is read.
+const heapSampleEvery = time.Second
+
+const heapMetric = "/memory/classes/heap/objects:bytes"
+
+// ShedLimits are the loads above which an instance refuses new
+// websocket connections. Zero disables a limit.
+type ShedLimits struct {
+    MaxConnections int
+    MaxGoroutines  int
+    MaxHeapBytes   uint64
+    // StallTimeout is how long the hub loop may go without turning over
+    // before the instance sheds; zero uses DefaultStallTimeout
+    StallTimeout time.Duration
+    // RetryAfter is how long refused clients are told to wait
+    RetryAfter time.Duration
+}
+
+// shedder decides whether new connections would push the instance past
+// its limits. Existing connections are never dropped: shedding only
+// turns new ones away, to be retried elsewhere or later.
+type shedder struct {
+    limits      ShedLimits
+    connections atomic.Int64
+    // Set by the hub's watchdog while its loop is stalled
+    stalled atomic.Bool
+
+    mu       sync.Mutex
+    shedding bool
+    heap     uint64
+    heapAt   time.Time
+}
+
+func newShedder(limits ShedLimits) *shedder {
+    if limits.RetryAfter <= 0 {
+        limits.RetryAfter = 30 * time.Second
+    }
+    return &shedder{limits: limits}
+}
+
+// check reports why a new connection should be refused, or "" to accept
+// it, and whether this check started shedding.
+func (s *shedder) check() (reason string, started bool) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    conns := int(s.connections.Load())
+    goroutines := runtime.NumGoroutine()
+    heap := s.heapLocked()
+
+    // While shedding, every limit must drop clear of its resume mark
+    threshold := 1.0
+    if s.shedding {
+        threshold = shedResume
+    }
+    switch {
+    case s.stalled.Load():
+        reason = ShedStalled
+    case over(float64(conns), float64(s.limits.MaxConnections), threshold):
+        reason = ShedConnections
+    case over(float64(goroutines), float64(s.limits.MaxGoroutines), threshold):
+        reason = ShedGoroutines
+    case over(float64(heap), float64(s.limits.MaxHeapBytes), threshold):
+        reason = ShedHeap
+    }
+    started = reason != "" && !s.shedding
+    s.shedding = reason != ""
+    return reason, started
+}
+
+func over(value, limit, threshold float64) bool {
+    return limit > 0 && value >= limit*threshold
+}
+
+func (s *shedder) heapLocked() uint64 {
+    if s.limits.MaxHeapBytes == 0 {
+        return 0
+    }
+    if time.Since(s.heapAt) < heapSampleEvery {
+        return s.heap
+    }
+    sample := []rtmetrics.Sample{{Name: heapMetric}}
+    rtmetrics.Read(sample)
+    if sample[0].Value.Kind() == rtmetrics.KindUint64 {
+        s.heap = sample[0].Value.Uint64()
+    }
+    s.heapAt = time.Now()
+    return s.heap
+}
+
+// shed turns a websocket request away. Browsers cannot see the status of
+// a failed handshake, so the connection is upgraded and closed at once
+// with Try Again Later; the Retry-After and X-Load-Shed headers on the
+// handshake tell proxies and load balancers the same.
+func (h *Handler) shed(w http.ResponseWriter, r *http.Request, reason string) {
+    retryAfter := h.hub.shedder.limits.RetryAfter
+    h.metrics.WSUpgradesShed.WithLabelValues(reason).Inc()
+
+    header := http.Header{}
+    header.Set("Retry-After", strconv.Itoa(int(retryAfter.Seconds())))
+    header.Set("X-Load-Shed", reason)
+    conn, err := h.upgrader.Upgrade(w, r, header)
+    if err != nil {
+        return
+    }
+    defer conn.Close()
+
+    message := websocket.FormatCloseMessage(websocket.CloseTryAgainLater,
+        fmt.Sprintf("server at capacity (%s), retry after %ds", reason, int(retryAfter.Seconds())))
+    conn.WriteControl(websocket.CloseMessage, message, time.Now().Add(time.Second))
+}
+
+// ServeReady is the load balancer's readiness probe. It fails while the
+// instance is shedding, so new traffic is steered elsewhere while the
+// connections it already has carry on.
+func (h *Handler) ServeReady(w http.ResponseWriter, r *http.Request) {
+    if reason, _ := h.hub.shedder.check(); reason != "" {
+        w.Header().Set("Retry-After", strconv.Itoa(int(h.hub.shedder.limits.RetryAfter.Seconds())))
+        http.Error(w, "Shedding load: "+reason, http.StatusServiceUnavailable)
+        return
+    }
+    w.Write([]byte("OK"))
+}
+
+// admit reports whether the instance can take a new connection, turning
+// the request away if not.
+func (h *Handler) admit(w http.ResponseWriter, r *http.Request) bool {
+    reason, started := h.hub.shedder.check()
+    if reason == "" {
+        return true
+    }
+    if started {
+        h.logger.Warn("Shedding websocket upgrades",
+            zap.String("reason", reason),
+            zap.Int64("connections", h.hub.shedder.connections.Load()),
+            zap.Int("goroutines", runtime.NumGoroutine()))
+    }
+    h.shed(w, r, reason)
+    return false
+}
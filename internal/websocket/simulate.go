@@ -0,0 +1,60 @@
+package websocket
+
+import (
+    "context"
+    "fmt"
+    "time"
+
+    "github.com/google/uuid"
+    "go.opentelemetry.io/otel/attribute"
+    "go.opentelemetry.io/otel/trace"
+
+    "github.com/yourusername/sports-chat/internal/events"
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/tracing"
+)
+
+// simulatedExemption is the rate limit exemption simulated messages are
+// counted under.
+const simulatedExemption = "simulator"
+
+// SimulateMessage posts a chat message from user into room the way a
+// client's message is posted: sequenced, stored, queued for review when
+// flag is set, announced on the event bus and sent to every instance.
+// It skips the room's rate limit and the moderation filters.
+func (h *Hub) SimulateMessage(room string, user *models.User, content string, flag *models.MessageFlag) {
+    ctx, _ := tracing.Start(context.Background(), "ws.simulated_message",
+        trace.WithAttributes(attribute.String("room", room)))
+    h.broadcast <- &inbound{ctx: ctx, message: &models.WSMessage{
+        Type:      models.MessageTypeChat,
+        ChatRoom:  room,
+        User:      user.Summary(),
+        Content:   content,
+        Timestamp: time.Now(),
+        Flag:      flag,
+        Exemption: simulatedExemption,
+    }}
+}
+
+// SimulateJoins sends room n join frames from made-up users and publishes
+// a RoomJoined event for each, as a burst of real connections would. The
+// users do not exist, so nothing else about the room changes.
+func (h *Hub) SimulateJoins(room string, n int) {
+    for i := 0; i < n; i++ {
+        join := &models.WSMessage{
+            Type:     models.MessageTypeJoin,
+            ChatRoom: room,
+            User: &models.UserSummary{
+                ID:       uuid.NewString(),
+                Username: fmt.Sprintf("simulated-%d", i+1),
+            },
+            Timestamp: time.Now(),
+        }
+        h.broadcastToRoom(room, join)
+        h.events.Publish(events.RoomJoined{
+            UserID: join.User.ID,
+            RoomID: room,
+            At:     join.Timestamp,
+        })
+    }
+}
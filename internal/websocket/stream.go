@@ -0,0 +1,152 @@
+package websocket
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "sync"
+
+    "github.com/yourusername/sports-chat/internal/broker"
+    "github.com/yourusername/sports-chat/internal/store"
+)
+
+// streamBuffer is how many frames a read-only stream may fall behind by
+// before it is dropped. Its client reconnects with the last sequence it
+// saw and is caught up from the store.
+const streamBuffer = 256
+
+// ErrOverloaded is returned when the instance is shedding load and takes
+// no new streams.
+var ErrOverloaded = errors.New("server at capacity")
+
+// Stream is a read-only subscription to a room's frames, for clients that
+// cannot hold a websocket, such as server-sent events. It sees the frames
+// a room member would, apart from those addressed to one user.
+type Stream struct {
+    Room   string
+    frames chan []byte
+}
+
+// Frames delivers the room's frames in order. It is closed when the
+// stream is unwatched or dropped for falling behind.
+func (s *Stream) Frames() <-chan []byte {
+    return s.frames
+}
+
+// streams tracks the read-only streams open on this instance, by room.
+type streams struct {
+    mu     sync.Mutex
+    byRoom map[string]map[*Stream]bool
+}
+
+func newStreams() *streams {
+    return &streams{byRoom: make(map[string]map[*Stream]bool)}
+}
+
+// removeLocked reports whether the stream was still open, so it is only
+// closed once.
+func (s *streams) removeLocked(stream *Stream) bool {
+    watchers := s.byRoom[stream.Room]
+    if !watchers[stream] {
+        return false
+    }
+    delete(watchers, stream)
+    if len(watchers) == 0 {
+        delete(s.byRoom, stream.Room)
+    }
+    close(stream.frames)
+    return true
+}
+
+// Watch opens a read-only stream of a room. Streams count as connections
+// for load shedding.
+func (h *Hub) Watch(room string) (*Stream, error) {
+    if reason, _ := h.shedder.check(); reason != "" {
+        h.metrics.WSUpgradesShed.WithLabelValues(reason).Inc()
+        return nil, ErrOverloaded
+    }
+
+    stream := &Stream{Room: room, frames: make(chan []byte, streamBuffer)}
+    h.streams.mu.Lock()
+    watchers, ok := h.streams.byRoom[room]
+    if !ok {
+        watchers = make(map[*Stream]bool)
+        h.streams.byRoom[room] = watchers
+    }
+    watchers[stream] = true
+    h.streams.mu.Unlock()
+
+    h.shedder.connections.Add(1)
+    h.metrics.SSEStreams.Inc()
+    return stream, nil
+}
+
+// Unwatch closes a stream. It is safe to call after the stream was
+// dropped.
+func (h *Hub) Unwatch(stream *Stream) {
+    h.streams.mu.Lock()
+    removed := h.streams.removeLocked(stream)
+    h.streams.mu.Unlock()
+
+    if removed {
+        h.shedder.connections.Add(-1)
+        h.metrics.SSEStreams.Dec()
+    }
+}
+
+// deliverToStreams hands a room frame to the room's streams on this
+// instance, dropping any that are full.
+func (h *Hub) deliverToStreams(msg *broker.Message) {
+    if msg.TargetUser != "" {
+        return
+    }
+
+    h.streams.mu.Lock()
+    defer h.streams.mu.Unlock()
+
+    for stream := range h.streams.byRoom[msg.Room] {
+        select {
+        case stream.frames <- msg.Payload:
+        default:
+            h.streams.removeLocked(stream)
+            h.shedder.connections.Add(-1)
+            h.metrics.SSEStreams.Dec()
+            h.metrics.SSEStreamsDropped.Inc()
+        }
+    }
+}
+
+// StreamBackfill loads the history a stream opens with. A stream resuming
+// after a sequence gets the messages it missed when they fit on a history
+// page; new streams, and ones too far behind, get the room's recent
+// messages, as a websocket connection would.
+func (h *Hub) StreamBackfill(ctx context.Context, room string, since int64) (*HistoryPage, error) {
+    if since > 0 {
+        messages, err := h.store.GetMessagesAfterSeq(ctx, room, since, MaxHistoryPage+1)
+        if err != nil {
+            return nil, fmt.Errorf("failed to get missed messages: %w", err)
+        }
+        if len(messages) <= MaxHistoryPage {
+            if err := LoadReactions(ctx, h.store, messages); err != nil {
+                return nil, fmt.Errorf("failed to load reactions: %w", err)
+            }
+            page := &HistoryPage{Messages: HistoryMessages(messages), SinceSeq: since}
+            page.Returned = len(page.Messages)
+            return page, nil
+        }
+    }
+    return LoadHistoryPage(ctx, h.store, room, store.MessageFilter{}, "", "", h.initialHistoryBudget(room))
+}
+
+// FrameSeq reads the room sequence of an outbound chat message, which
+// streams use as event IDs. Other frames have none and return zero.
+func FrameSeq(payload []byte) int64 {
+    var frame struct {
+        Seq int64 `json:"seq"`
+    }
+    if err := json.Unmarshal(payload, &frame); err != nil {
+        return 0
+    }
+    return frame.Seq
+}
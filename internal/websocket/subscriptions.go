@@ -0,0 +1,155 @@
+package websocket
+
+import (
+    "context"
+    "encoding/json"
+    "time"
+
+    "github.com/yourusername/sports-chat/internal/events"
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+// maxSubscriptions bounds the rooms one connection can be in, so a
+// client following several matches cannot subscribe to every room.
+const maxSubscriptions = 50
+
+// handleSubscribe adds a room to the connection, with the same checks as
+// connecting to it: redirects are followed, deleted rooms are refused
+// with their tombstone, and banned users and users a room does not
+// admit are refused. The room's members are told, and
+// the client is sent the room's history as on connect.
+func (c *Client) handleSubscribe(msg *models.WSMessage) {
+    room := msg.ChatRoom
+    if room == "" {
+        c.sendError(msg, models.ErrorInvalidRequest, "Missing room")
+        return
+    }
+    h := c.hub
+
+    // Held until the client has joined, as on connect, so the room cannot
+    // be redirected in between
+    h.redirectMu.RLock()
+    if targets, ok := h.redirects[room]; ok {
+        to := targets[shardFor(c.user.ID, len(targets))]
+        h.sendRedirect(c, room, to, "redirected")
+        room = to
+    }
+    if tombstone := h.deletedRoom(room); tombstone != nil {
+        h.redirectMu.RUnlock()
+        h.sendRoomDeleted(c, tombstone)
+        c.sendError(msg, models.ErrorRoomDeleted, "This room was deleted")
+        return
+    }
+
+    if !c.principal.IsAdmin {
+        if _, banned := h.sanctionsFor(room, c.user.ID); banned {
+            h.redirectMu.RUnlock()
+            c.sendError(msg, models.ErrorBanned, "You are banned from this room")
+            return
+        }
+        if !h.roomSettings(room).Admits(c.user, time.Now()) {
+            h.redirectMu.RUnlock()
+            c.sendError(msg, models.ErrorAgeRestricted, "This room is age restricted")
+            return
+        }
+    }
+
+    c.mu.Lock()
+    if c.gone {
+        c.mu.Unlock()
+        h.redirectMu.RUnlock()
+        return
+    }
+    if c.rooms[room] {
+        // Already in it; confirm so the client's state settles
+        c.mu.Unlock()
+        h.redirectMu.RUnlock()
+        c.confirmSubscription(msg, room)
+        return
+    }
+    if len(c.rooms) >= maxSubscriptions {
+        c.mu.Unlock()
+        h.redirectMu.RUnlock()
+        c.sendError(msg, models.ErrorInvalidRequest, "Too many rooms")
+        return
+    }
+    c.rooms[room] = true
+    h.addToRoom(room, c)
+    c.mu.Unlock()
+    h.redirectMu.RUnlock()
+
+    joinMsg := &models.WSMessage{
+        Type:      models.MessageTypeJoin,
+        ChatRoom:  room,
+        User:      c.summary(),
+        Timestamp: time.Now(),
+    }
+    h.broadcastToRoom(room, joinMsg)
+    h.events.Publish(events.RoomJoined{
+        UserID: c.user.ID,
+        RoomID: room,
+        At:     joinMsg.Timestamp,
+    })
+    c.confirmSubscription(msg, room)
+
+    go func() {
+        ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+        defer cancel()
+        h.sendRoomData(ctx, c, room, h.userDrafts(ctx, c.user.ID)[room])
+    }()
+}
+
+// handleUnsubscribe takes a room off the connection, ending its typing
+// indicator and voice session there, and tells the room's members.
+func (c *Client) handleUnsubscribe(msg *models.WSMessage) {
+    room := msg.ChatRoom
+    h := c.hub
+
+    c.mu.Lock()
+    if c.gone || !c.rooms[room] {
+        c.mu.Unlock()
+        c.sendError(msg, models.ErrorNotFound, "Not subscribed to this room")
+        return
+    }
+    delete(c.rooms, room)
+    left := h.removeFromRoom(room, c)
+    c.mu.Unlock()
+
+    c.stopTyping(room)
+    c.voiceMu.Lock()
+    inVoice := c.voice[room]
+    c.voiceMu.Unlock()
+    if inVoice {
+        go func() {
+            ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+            defer cancel()
+            c.leaveVoice(ctx, room)
+        }()
+    }
+
+    if left {
+        h.broadcastToRoom(room, &models.WSMessage{
+            Type:      models.MessageTypeLeave,
+            ChatRoom:  room,
+            User:      c.summary(),
+            Timestamp: time.Now(),
+        })
+    }
+    c.confirmSubscription(msg, room)
+}
+
+// confirmSubscription answers a subscribe or unsubscribe frame with one
+// of the same type, naming the room the connection joined or left; for
+// a redirected room it is the room redirected to.
+func (c *Client) confirmSubscription(msg *models.WSMessage, room string) {
+    payload, err := json.Marshal(&models.WSMessage{
+        Type:        msg.Type,
+        ChatRoom:    room,
+        ClientMsgID: msg.ClientMsgID,
+        Timestamp:   time.Now(),
+    })
+    if err != nil {
+        return
+    }
+    c.trySend(payload)
+}
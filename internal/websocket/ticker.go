@@ -0,0 +1,234 @@
+package websocket
+
+import (
+    "encoding/json"
+    "strings"
+    "sync"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/broker"
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+// TickerRoom is the virtual room ticker frames are addressed to. It is not
+// a chat room and has no members: connections follow competitions, with
+// ?ticker=a,b on the handshake or a ticker frame, and get the goals and
+// red cards of every live match in them.
+const TickerRoom = "ticker"
+
+// Ticker item kinds
+const (
+    TickerGoal    = "goal"
+    TickerRedCard = "red_card"
+)
+
+// maxTickerCompetitions bounds how many competitions one connection may
+// follow.
+const maxTickerCompetitions = 32
+
+// tickerItem is the data of a ticker frame.
+type tickerItem struct {
+    Kind          string `json:"kind"`
+    MatchID       string `json:"match_id"`
+    CompetitionID string `json:"competition_id"`
+    HomeTeam      string `json:"home_team"`
+    AwayTeam      string `json:"away_team"`
+    HomeScore     int    `json:"home_score"`
+    AwayScore     int    `json:"away_score"`
+    // Side is the scoring side of a goal
+    Side        string `json:"side,omitempty"`
+    Minute      int    `json:"minute,omitempty"`
+    Description string `json:"description,omitempty"`
+}
+
+// tickerSubscription is the data of a client's ticker frame. It replaces
+// the connection's competitions; an empty list stops the ticker.
+type tickerSubscription struct {
+    Competitions []string `json:"competitions"`
+}
+
+// ticker tracks which local connections follow which competitions.
+type ticker struct {
+    mu       sync.RWMutex
+    byComp   map[string]map[*Client]bool
+    byClient map[*Client][]string
+}
+
+func newTicker() *ticker {
+    return &ticker{
+        byComp:   make(map[string]map[*Client]bool),
+        byClient: make(map[*Client][]string),
+    }
+}
+
+// set replaces the competitions a connection follows.
+func (t *ticker) set(client *Client, competitions []string) {
+    t.mu.Lock()
+    defer t.mu.Unlock()
+
+    t.removeLocked(client)
+    if len(competitions) == 0 {
+        return
+    }
+    for _, comp := range competitions {
+        clients, ok := t.byComp[comp]
+        if !ok {
+            clients = make(map[*Client]bool)
+            t.byComp[comp] = clients
+        }
+        clients[client] = true
+    }
+    t.byClient[client] = competitions
+}
+
+func (t *ticker) remove(client *Client) {
+    t.mu.Lock()
+    defer t.mu.Unlock()
+    t.removeLocked(client)
+}
+
+func (t *ticker) removeLocked(client *Client) {
+    for _, comp := range t.byClient[client] {
+        clients := t.byComp[comp]
+        delete(clients, client)
+        if len(clients) == 0 {
+            delete(t.byComp, comp)
+        }
+    }
+    delete(t.byClient, client)
+}
+
+func (t *ticker) subscribers(comp string) []*Client {
+    t.mu.RLock()
+    defer t.mu.RUnlock()
+
+    clients := make([]*Client, 0, len(t.byComp[comp]))
+    for client := range t.byComp[comp] {
+        clients = append(clients, client)
+    }
+    return clients
+}
+
+// parseTickerCompetitions reads a comma-separated list of competition IDs,
+// dropping blanks and duplicates. ok is false if there are too many.
+func parseTickerCompetitions(list []string) ([]string, bool) {
+    seen := make(map[string]bool)
+    var competitions []string
+    for _, comp := range list {
+        if comp = strings.TrimSpace(comp); comp != "" && !seen[comp] {
+            seen[comp] = true
+            competitions = append(competitions, comp)
+        }
+    }
+    return competitions, len(competitions) <= maxTickerCompetitions
+}
+
+// handleTicker replaces the competitions c follows and confirms them.
+func (c *Client) handleTicker(msg *models.WSMessage) {
+    var req tickerSubscription
+    if err := json.Unmarshal(msg.Data, &req); err != nil {
+        c.sendError(msg, models.ErrorInvalidRequest, "Invalid ticker subscription")
+        return
+    }
+    competitions, ok := parseTickerCompetitions(req.Competitions)
+    if !ok {
+        c.sendError(msg, models.ErrorInvalidRequest, "Too many ticker competitions")
+        return
+    }
+    c.hub.ticker.set(c, competitions)
+
+    data, err := json.Marshal(&tickerSubscription{Competitions: competitions})
+    if err != nil {
+        return
+    }
+    payload, err := json.Marshal(&models.WSMessage{
+        Type:      models.MessageTypeTicker,
+        ChatRoom:  TickerRoom,
+        Data:      data,
+        Timestamp: time.Now(),
+    })
+    if err != nil {
+        return
+    }
+    c.trySend(payload)
+}
+
+// tickGoal sends a goal to the ticker. Every instance sees score changes
+// while polling, so each delivers to its own connections only.
+func (h *Hub) tickGoal(match *models.Match, side string) {
+    item := newTickerItem(TickerGoal, match)
+    item.Side = side
+    item.Minute = match.Minute
+    if payload, ok := h.tickerFrame(item); ok {
+        h.deliverToTicker(&broker.Message{Ticker: match.CompetitionID, Payload: payload})
+    }
+}
+
+// tickRedCard sends a red card to the ticker on every instance; only the
+// instance that stored the event sees it.
+func (h *Hub) tickRedCard(match *models.Match, event *models.MatchEvent) {
+    item := newTickerItem(TickerRedCard, match)
+    item.Minute = event.EventTime
+    item.Description = event.Description
+    if payload, ok := h.tickerFrame(item); ok {
+        h.publish(&broker.Message{Ticker: match.CompetitionID, Payload: payload, Priority: PriorityHigh})
+    }
+}
+
+func newTickerItem(kind string, match *models.Match) *tickerItem {
+    item := &tickerItem{
+        Kind:          kind,
+        MatchID:       match.ID,
+        CompetitionID: match.CompetitionID,
+        HomeTeam:      match.HomeTeamID,
+        AwayTeam:      match.AwayTeamID,
+        HomeScore:     match.HomeScore,
+        AwayScore:     match.AwayScore,
+    }
+    if match.HomeTeam != nil {
+        item.HomeTeam = match.HomeTeam.Name
+    }
+    if match.AwayTeam != nil {
+        item.AwayTeam = match.AwayTeam.Name
+    }
+    return item
+}
+
+// tickerFrame marshals an item, reporting false for matches outside any
+// competition, which no one can follow.
+func (h *Hub) tickerFrame(item *tickerItem) ([]byte, bool) {
+    if item.CompetitionID == "" {
+        return nil, false
+    }
+    data, err := json.Marshal(item)
+    if err != nil {
+        return nil, false
+    }
+    payload, err := json.Marshal(&models.WSMessage{
+        Type:      models.MessageTypeTicker,
+        ChatRoom:  TickerRoom,
+        Data:      data,
+        Timestamp: time.Now(),
+    })
+    if err != nil {
+        h.logger.Error("Failed to marshal ticker item",
+            zap.Error(err),
+            zap.String("match_id", item.MatchID))
+        return nil, false
+    }
+    return payload, true
+}
+
+// deliverToTicker writes a ticker frame to the local connections following
+// its competition.
+func (h *Hub) deliverToTicker(msg *broker.Message) {
+    delivered := 0
+    for _, client := range h.ticker.subscribers(msg.Ticker) {
+        if client.enqueue(msg.Payload) {
+            delivered++
+        }
+    }
+    h.countOutbound(models.MessageTypeTicker, roomSizeNone, len(msg.Payload), delivered)
+}
@@ -0,0 +1,61 @@
+package websocket
+
+import (
+    "encoding/json"
+    "time"
+
+    "github.com/yourusername/sports-chat/internal/broker"
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+// resolveTopic checks the topic a chat message is posted to. A message
+// for a collapsed topic goes to the main feed instead, so clients that
+// missed the collapse keep chatting. It reports false, after telling the
+// client, if the room has no such topic.
+func (c *Client) resolveTopic(msg *models.WSMessage) bool {
+    if msg.TopicID == "" {
+        return true
+    }
+
+    topic := c.hub.roomSettings(msg.ChatRoom).Topic(msg.TopicID)
+    if topic == nil {
+        c.sendError(msg, models.ErrorNotFound, "Unknown topic")
+        return false
+    }
+    if !topic.Open() {
+        msg.TopicID = ""
+    }
+    return true
+}
+
+// TopicsChanged sends a room's topics, open and collapsed, to its clients
+// on every instance after one is added or collapsed. Every instance drops
+// its cached settings for the room, so posts follow the change.
+func (h *Hub) TopicsChanged(room string, topics []*models.RoomTopic) {
+    payload, ok := topicsFrame(room, topics)
+    if !ok {
+        return
+    }
+
+    if h.journal != nil {
+        h.journal.Append(room, payload)
+    }
+    h.publish(&broker.Message{Room: room, Payload: payload, RoomChanged: true, Priority: PriorityHigh})
+}
+
+func topicsFrame(room string, topics []*models.RoomTopic) ([]byte, bool) {
+    data, err := json.Marshal(topics)
+    if err != nil {
+        return nil, false
+    }
+    payload, err := json.Marshal(&models.WSMessage{
+        Type:      models.MessageTypeTopics,
+        ChatRoom:  room,
+        Data:      data,
+        Timestamp: time.Now(),
+    })
+    if err != nil {
+        return nil, false
+    }
+    return payload, true
+}
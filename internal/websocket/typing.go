@@ -0,0 +1,107 @@
+package websocket
+
+import (
+    "encoding/json"
+    "time"
+
+    "github.com/yourusername/sports-chat/internal/broker"
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+// Typing frames carry "start" or "stop" as their content.
+const (
+    TypingStart = "start"
+    TypingStop  = "stop"
+)
+
+const (
+    // typingThrottle is the minimum interval between start frames a
+    // client forwards for one room; clients typically send one per
+    // keystroke.
+    typingThrottle = 3 * time.Second
+    // typingTimeout stops a typing indicator the client never stopped,
+    // e.g. because the user walked away or the connection dropped.
+    typingTimeout = 6 * time.Second
+)
+
+type typingState struct {
+    lastSent time.Time
+    timer    *time.Timer
+}
+
+// handleTyping throttles and forwards a typing frame from c. Only
+// transitions and periodic refreshes reach the room.
+func (c *Client) handleTyping(msg *models.WSMessage) {
+    room := msg.ChatRoom
+
+    c.typingMu.Lock()
+    defer c.typingMu.Unlock()
+
+    if c.typing == nil {
+        c.typing = make(map[string]*typingState)
+    }
+    state, active := c.typing[room]
+
+    if msg.Content == TypingStop {
+        if active {
+            state.timer.Stop()
+            delete(c.typing, room)
+            c.hub.publishTyping(c.summary(), room, TypingStop)
+        }
+        return
+    }
+
+    if !active {
+        state = &typingState{}
+        state.timer = time.AfterFunc(typingTimeout, func() { c.expireTyping(room, state) })
+        c.typing[room] = state
+    } else {
+        state.timer.Reset(typingTimeout)
+    }
+
+    if time.Since(state.lastSent) >= typingThrottle {
+        state.lastSent = time.Now()
+        c.hub.publishTyping(c.summary(), room, TypingStart)
+    }
+}
+
+func (c *Client) expireTyping(room string, state *typingState) {
+    c.typingMu.Lock()
+    defer c.typingMu.Unlock()
+
+    // A newer indicator may have replaced this one since the timer fired
+    if c.typing[room] != state {
+        return
+    }
+    delete(c.typing, room)
+    c.hub.publishTyping(c.summary(), room, TypingStop)
+}
+
+// stopTyping ends any indicator the client has in room, e.g. because it
+// just sent its message.
+func (c *Client) stopTyping(room string) {
+    c.typingMu.Lock()
+    defer c.typingMu.Unlock()
+
+    if state, ok := c.typing[room]; ok {
+        state.timer.Stop()
+        delete(c.typing, room)
+        c.hub.publishTyping(c.summary(), room, TypingStop)
+    }
+}
+
+// publishTyping sends a typing frame to the room's other members. Typing
+// frames are ephemeral, so they are not journaled.
+func (h *Hub) publishTyping(user *models.UserSummary, room, state string) {
+    payload, err := json.Marshal(&models.WSMessage{
+        Type:      models.MessageTypeTyping,
+        ChatRoom:  room,
+        Content:   state,
+        User:      user,
+        Timestamp: time.Now(),
+    })
+    if err != nil {
+        return
+    }
+    h.publish(&broker.Message{Room: room, Payload: payload, ExcludeUser: user.ID, Priority: PriorityLow})
+}
@@ -0,0 +1,283 @@
+package websocket
+
+import (
+    "context"
+    "encoding/json"
+    "time"
+
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/broker"
+    "github.com/yourusername/sports-chat/internal/models"
+)
+
+// Voice actions, carried in the data of voice frames. Clients send join,
+// leave, raise_hand, lower_hand, grant, revoke, offer, answer and ice; the
+// server answers with roster and announces joined, left, hand and role.
+const (
+    VoiceJoin      = "join"
+    VoiceLeave     = "leave"
+    VoiceRaiseHand = "raise_hand"
+    VoiceLowerHand = "lower_hand"
+    VoiceGrant     = "grant"
+    VoiceRevoke    = "revoke"
+    VoiceOffer     = "offer"
+    VoiceAnswer    = "answer"
+    VoiceICE       = "ice"
+
+    VoiceRoster = "roster"
+    VoiceJoined = "joined"
+    VoiceLeft   = "left"
+    VoiceHand   = "hand"
+    VoiceRole   = "role"
+)
+
+// voiceData is the data of a voice frame in both directions. SDP and ICE
+// payloads are relayed untouched; the SFU is one of the peers.
+type voiceData struct {
+    Action       string                     `json:"action"`
+    Target       string                     `json:"target,omitempty"`
+    From         string                     `json:"from,omitempty"`
+    SDP          string                     `json:"sdp,omitempty"`
+    Candidate    json.RawMessage            `json:"candidate,omitempty"`
+    Publish      bool                       `json:"publish,omitempty"`
+    Participant  *models.VoiceParticipant   `json:"participant,omitempty"`
+    Participants []*models.VoiceParticipant `json:"participants,omitempty"`
+}
+
+// handleVoice applies a client's voice action. Session state lives in the
+// store so roles hold across instances.
+func (c *Client) handleVoice(msg *models.WSMessage) {
+    var req voiceData
+    if err := json.Unmarshal(msg.Data, &req); err != nil {
+        c.sendError(msg, models.ErrorInvalidRequest, "Invalid voice request")
+        return
+    }
+
+    ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+    defer cancel()
+
+    room := msg.ChatRoom
+    if req.Action == VoiceJoin {
+        c.joinVoice(ctx, room)
+        return
+    }
+
+    self, err := c.hub.store.GetVoiceParticipant(ctx, room, c.user.ID)
+    if err != nil || self == nil {
+        c.sendError(msg, models.ErrorForbidden, "Not in the voice session")
+        return
+    }
+
+    switch req.Action {
+    case VoiceLeave:
+        c.leaveVoice(ctx, room)
+
+    case VoiceRaiseHand, VoiceLowerHand:
+        self.HandRaised = req.Action == VoiceRaiseHand
+        if err := c.hub.store.UpdateVoiceParticipant(ctx, self); err != nil {
+            c.hub.logger.Error("Failed to update voice participant", zap.Error(err), zap.String("room", room))
+            c.sendError(msg, models.ErrorInternal, "Failed to update voice session")
+            return
+        }
+        self.User = c.summary()
+        c.hub.publishVoice(room, "", &voiceData{Action: VoiceHand, Participant: self})
+
+    case VoiceGrant, VoiceRevoke:
+        c.setSpeaker(ctx, room, self, req)
+
+    case VoiceOffer, VoiceAnswer, VoiceICE:
+        if req.Target == "" || req.Target == c.user.ID {
+            c.sendError(msg, models.ErrorInvalidRequest, "Signaling needs a target")
+            return
+        }
+        // Publishing audio is what speaking means; the SFU should enforce
+        // this as well, but listeners never get a publish offer through.
+        if req.Action == VoiceOffer && req.Publish && self.Role == models.VoiceRoleListener {
+            c.sendError(msg, models.ErrorForbidden, "Only speakers can publish audio")
+            return
+        }
+        c.hub.publishVoice(room, req.Target, &voiceData{
+            Action:    req.Action,
+            From:      c.user.ID,
+            SDP:       req.SDP,
+            Candidate: req.Candidate,
+            Publish:   req.Publish,
+        })
+
+    default:
+        c.sendError(msg, models.ErrorInvalidRequest, "Unknown voice action")
+    }
+}
+
+// joinVoice adds the user to the room's session. Whoever starts a session
+// hosts it, as do admins; everyone else joins as a listener.
+func (c *Client) joinVoice(ctx context.Context, room string) {
+    participants, err := c.hub.store.GetVoiceParticipants(ctx, room)
+    if err != nil {
+        c.hub.logger.Error("Failed to get voice participants", zap.Error(err), zap.String("room", room))
+        c.sendError(nil, models.ErrorInternal, "Failed to join voice session")
+        return
+    }
+
+    var self *models.VoiceParticipant
+    for _, p := range participants {
+        if p.UserID == c.user.ID {
+            self = p
+        }
+    }
+
+    // Another tab or a reconnect rejoins with the role already held
+    if self == nil {
+        role := models.VoiceRoleListener
+        if len(participants) == 0 || c.principal.IsAdmin {
+            role = models.VoiceRoleHost
+        }
+        self = &models.VoiceParticipant{
+            RoomID:   room,
+            UserID:   c.user.ID,
+            Role:     role,
+            JoinedAt: time.Now(),
+        }
+        if err := c.hub.store.JoinVoiceSession(ctx, self); err != nil {
+            c.hub.logger.Error("Failed to join voice session", zap.Error(err), zap.String("room", room))
+            c.sendError(nil, models.ErrorInternal, "Failed to join voice session")
+            return
+        }
+        participants = append(participants, self)
+    }
+
+    c.voiceMu.Lock()
+    if c.voice == nil {
+        c.voice = make(map[string]bool)
+    }
+    c.voice[room] = true
+    c.voiceMu.Unlock()
+
+    self.User = c.summary()
+    c.sendVoice(room, &voiceData{Action: VoiceRoster, Participants: participants})
+    c.hub.publishVoice(room, "", &voiceData{Action: VoiceJoined, Participant: self})
+}
+
+// setSpeaker lets a host move a participant between listener and speaker.
+func (c *Client) setSpeaker(ctx context.Context, room string, self *models.VoiceParticipant, req voiceData) {
+    if self.Role != models.VoiceRoleHost {
+        c.sendError(nil, models.ErrorForbidden, "Only the host can change speakers")
+        return
+    }
+
+    target, err := c.hub.store.GetVoiceParticipant(ctx, room, req.Target)
+    if err != nil || target == nil {
+        c.sendError(nil, models.ErrorForbidden, "Not in the voice session")
+        return
+    }
+    if target.Role == models.VoiceRoleHost {
+        c.sendError(nil, models.ErrorForbidden, "Cannot change the host's role")
+        return
+    }
+
+    target.Role = models.VoiceRoleListener
+    if req.Action == VoiceGrant {
+        target.Role = models.VoiceRoleSpeaker
+    }
+    target.HandRaised = false
+    if err := c.hub.store.UpdateVoiceParticipant(ctx, target); err != nil {
+        c.hub.logger.Error("Failed to update voice participant", zap.Error(err), zap.String("room", room))
+        c.sendError(nil, models.ErrorInternal, "Failed to update voice session")
+        return
+    }
+
+    c.hub.publishVoice(room, "", &voiceData{Action: VoiceRole, Participant: target})
+}
+
+// leaveVoice removes the user from the session, handing the host role to
+// the longest-present participant if the host left.
+func (c *Client) leaveVoice(ctx context.Context, room string) {
+    c.voiceMu.Lock()
+    delete(c.voice, room)
+    c.voiceMu.Unlock()
+
+    self, err := c.hub.store.GetVoiceParticipant(ctx, room, c.user.ID)
+    if err != nil || self == nil {
+        return
+    }
+    if err := c.hub.store.LeaveVoiceSession(ctx, room, c.user.ID); err != nil {
+        c.hub.logger.Error("Failed to leave voice session", zap.Error(err), zap.String("room", room))
+        return
+    }
+    c.hub.publishVoice(room, "", &voiceData{Action: VoiceLeft, From: c.user.ID})
+
+    if self.Role != models.VoiceRoleHost {
+        return
+    }
+    participants, err := c.hub.store.GetVoiceParticipants(ctx, room)
+    if err != nil || len(participants) == 0 {
+        return
+    }
+    for _, p := range participants {
+        if p.Role == models.VoiceRoleHost {
+            return
+        }
+    }
+    successor := participants[0]
+    successor.Role = models.VoiceRoleHost
+    successor.HandRaised = false
+    if err := c.hub.store.UpdateVoiceParticipant(ctx, successor); err != nil {
+        c.hub.logger.Error("Failed to hand over voice host", zap.Error(err), zap.String("room", room))
+        return
+    }
+    c.hub.publishVoice(room, "", &voiceData{Action: VoiceRole, Participant: successor})
+}
+
+// leaveAllVoice drops the connection from every session it joined once it
+// disconnects; the peer connection to the SFU goes with it.
+func (c *Client) leaveAllVoice() {
+    c.voiceMu.Lock()
+    rooms := make([]string, 0, len(c.voice))
+    for room := range c.voice {
+        rooms = append(rooms, room)
+    }
+    c.voiceMu.Unlock()
+
+    if len(rooms) == 0 {
+        return
+    }
+
+    ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+    defer cancel()
+    for _, room := range rooms {
+        c.leaveVoice(ctx, room)
+    }
+}
+
+func (c *Client) sendVoice(room string, data *voiceData) {
+    payload, err := voiceFrame(room, data)
+    if err != nil {
+        return
+    }
+    c.trySend(payload)
+}
+
+// publishVoice sends a voice frame to the room, or only to target when
+// set. Signaling is ephemeral, so it is not journaled, and it goes out
+// ahead of chat so call setup does not stall during a goal rush.
+func (h *Hub) publishVoice(room, target string, data *voiceData) {
+    payload, err := voiceFrame(room, data)
+    if err != nil {
+        return
+    }
+    h.publish(&broker.Message{Room: room, Payload: payload, TargetUser: target, Priority: PriorityHigh})
+}
+
+func voiceFrame(room string, data *voiceData) ([]byte, error) {
+    raw, err := json.Marshal(data)
+    if err != nil {
+        return nil, err
+    }
+    return json.Marshal(&models.WSMessage{
+        Type:      models.MessageTypeVoice,
+        ChatRoom:  room,
+        Data:      raw,
+        Timestamp: time.Now(),
+    })
+}
@@ -0,0 +1,112 @@
+package websocket
+
+import (
+    "runtime"
+    "time"
+
+    "go.opentelemetry.io/otel/trace"
+    "go.uber.org/zap"
+)
+
+const (
+    // hubQueueSize is how many registrations, unregistrations and inbound
+    // frames each wait for the hub loop before senders block.
+    hubQueueSize = 1024
+    // hubHeartbeat is how often an idle hub loop turns over, so the
+    // watchdog can tell idle from stalled.
+    hubHeartbeat = time.Second
+    // watchdogInterval is how often the watchdog samples the hub.
+    watchdogInterval = time.Second
+)
+
+// DefaultStallTimeout is how long the hub loop may go without turning
+// over before the instance sheds load, when the limits leave it unset.
+const DefaultStallTimeout = 10 * time.Second
+
+// loop handles registrations and inbound frames until the watchdog
+// replaces it with a newer generation.
+func (h *Hub) loop(gen int64) {
+    heartbeat := time.NewTicker(hubHeartbeat)
+    defer heartbeat.Stop()
+
+    for h.loopGen.Load() == gen {
+        h.lastLoop.Store(time.Now().UnixNano())
+
+        select {
+        case client := <-h.register:
+            h.handleRegister(client)
+
+        case client := <-h.unregister:
+            h.handleUnregister(client)
+
+        case in := <-h.broadcast:
+            h.handleBroadcast(in.ctx, in.message)
+            trace.SpanFromContext(in.ctx).End()
+
+        case <-heartbeat.C:
+        }
+    }
+}
+
+// watchdog reports the hub loop's health and heals it. A loop that has
+// not turned over for the stall timeout, usually stuck on a slow store
+// or broker call, puts the instance into shedding: new connections are
+// refused and inbound frames that do not fit the queue are turned back
+// instead of blocking readers. A loop stuck for twice as long is replaced
+// with a new one; the stuck one exits once its call returns.
+func (h *Hub) watchdog() {
+    ticker := time.NewTicker(watchdogInterval)
+    defer ticker.Stop()
+
+    for range ticker.C {
+        lag := time.Since(time.Unix(0, h.lastLoop.Load()))
+        h.metrics.HubQueueDepth.WithLabelValues("register").Set(float64(len(h.register)))
+        h.metrics.HubQueueDepth.WithLabelValues("unregister").Set(float64(len(h.unregister)))
+        h.metrics.HubQueueDepth.WithLabelValues("broadcast").Set(float64(len(h.broadcast)))
+        h.metrics.HubClientGoroutines.Set(float64(h.pumps.Load()))
+        h.metrics.HubLoopLag.Set(lag.Seconds())
+
+        stalled := lag >= h.stallTimeout
+        if h.shedder.stalled.Swap(stalled) != stalled {
+            if stalled {
+                h.metrics.HubStalls.Inc()
+                h.logger.Error("Hub loop stalled, shedding load",
+                    zap.Duration("lag", lag),
+                    zap.Int("register_queue", len(h.register)),
+                    zap.Int("unregister_queue", len(h.unregister)),
+                    zap.Int("broadcast_queue", len(h.broadcast)),
+                    zap.Int64("client_goroutines", h.pumps.Load()),
+                    zap.Int("goroutines", runtime.NumGoroutine()))
+            } else {
+                h.logger.Info("Hub loop recovered", zap.Duration("lag", lag))
+            }
+        }
+
+        if lag >= 2*h.stallTimeout {
+            gen := h.loopGen.Add(1)
+            h.lastLoop.Store(time.Now().UnixNano())
+            h.metrics.HubLoopRestarts.Inc()
+            h.logger.Error("Restarting stalled hub loop",
+                zap.Duration("lag", lag),
+                zap.Int64("generation", gen))
+            go h.loop(gen)
+        }
+    }
+}
+
+// submit queues a client's frame for the hub loop. While the loop is
+// stalled, a frame that does not fit the queue is refused, reporting
+// false, rather than blocking the connection's reader.
+func (h *Hub) submit(in *inbound) bool {
+    if !h.shedder.stalled.Load() {
+        h.broadcast <- in
+        return true
+    }
+    select {
+    case h.broadcast <- in:
+        return true
+    default:
+        h.metrics.HubFramesShed.Inc()
+        return false
+    }
+}
@@ -0,0 +1,270 @@
+package websocket
+
+import (
+    "context"
+    "errors"
+    "sync"
+    "time"
+
+    "go.opentelemetry.io/otel/attribute"
+    "go.opentelemetry.io/otel/trace"
+    "go.uber.org/zap"
+
+    "github.com/yourusername/sports-chat/internal/models"
+    "github.com/yourusername/sports-chat/internal/store"
+    "github.com/yourusername/sports-chat/internal/tracing"
+)
+
+const (
+    defaultPersistBatchSize     = 100
+    defaultPersistFlushInterval = 50 * time.Millisecond
+    defaultPersistQueueSize     = 10000
+
+    // persistBatchTimeout bounds one batch write and its follow-up work
+    persistBatchTimeout = 10 * time.Second
+)
+
+// writeBehind batches chat messages into multi-row inserts, so a busy
+// room costs the store one statement per batch rather than one per
+// message. A batch is written when it fills or when the flush interval
+// passes, whichever is first. A batch that fails is handed message by
+// message to the job queue, which retries and dead-letters as before.
+//
+// While the store is unavailable, batches are held in memory instead and
+// retried every interval, so chat carries on through a short database
+// outage and its messages land in order once it ends. Past the hold
+// limit, messages go to the job queue.
+type writeBehind struct {
+    hub       *Hub
+    queue     chan *persistJob
+    batchSize int
+    interval  time.Duration
+
+    // Messages held through an outage, oldest first, and how many may
+    // be; owned by the batching loop
+    held      []*persistJob
+    holdLimit int
+
+    // stopped is set under the write lock, so no message is queued once
+    // the final drain has begun
+    mu      sync.RWMutex
+    stopped bool
+    stop    chan struct{}
+    done    chan struct{}
+}
+
+func newWriteBehind(h *Hub, opts Options) *writeBehind {
+    w := &writeBehind{
+        hub:       h,
+        batchSize: opts.PersistBatchSize,
+        interval:  opts.PersistFlushInterval,
+        stop:      make(chan struct{}),
+        done:      make(chan struct{}),
+    }
+    if w.batchSize <= 0 {
+        w.batchSize = defaultPersistBatchSize
+    }
+    if w.interval <= 0 {
+        w.interval = defaultPersistFlushInterval
+    }
+    queueSize := opts.PersistQueueSize
+    if queueSize <= 0 {
+        queueSize = defaultPersistQueueSize
+    }
+    w.queue = make(chan *persistJob, queueSize)
+    w.holdLimit = queueSize
+    return w
+}
+
+// enqueue adds a message to the next batch without blocking. It reports
+// false when the queue is full or stopped, leaving the caller to store
+// the message another way.
+func (w *writeBehind) enqueue(job *persistJob) bool {
+    w.mu.RLock()
+    defer w.mu.RUnlock()
+    if w.stopped {
+        return false
+    }
+    select {
+    case w.queue <- job:
+        w.hub.metrics.PersistQueueDepth.Inc()
+        return true
+    default:
+        return false
+    }
+}
+
+func (w *writeBehind) run() {
+    defer close(w.done)
+
+    ticker := time.NewTicker(w.interval)
+    defer ticker.Stop()
+
+    batch := make([]*persistJob, 0, w.batchSize)
+    for {
+        select {
+        case job := <-w.queue:
+            w.hub.metrics.PersistQueueDepth.Dec()
+            batch = append(batch, job)
+            if len(batch) >= w.batchSize {
+                w.flush(batch)
+                batch = make([]*persistJob, 0, w.batchSize)
+            }
+        case <-ticker.C:
+            w.retryHeld()
+            if len(batch) > 0 {
+                w.flush(batch)
+                batch = make([]*persistJob, 0, w.batchSize)
+            }
+        case <-w.stop:
+            for len(w.queue) > 0 {
+                w.hub.metrics.PersistQueueDepth.Dec()
+                batch = append(batch, <-w.queue)
+                if len(batch) >= w.batchSize {
+                    w.flush(batch)
+                    batch = make([]*persistJob, 0, w.batchSize)
+                }
+            }
+            if len(batch) > 0 {
+                w.flush(batch)
+            }
+            w.retryHeld()
+            if len(w.held) > 0 {
+                // Last resort: the job queue drains before shutdown ends
+                w.hub.logger.Error("Store still unavailable at shutdown, queueing held messages as jobs",
+                    zap.Int("messages", len(w.held)))
+                for _, job := range w.held {
+                    w.hub.persistOne(job)
+                }
+                w.held = nil
+                w.hub.metrics.PersistHeld.Set(0)
+            }
+            return
+        }
+    }
+}
+
+// flush writes a batch, or holds it while the store is unavailable or
+// older messages are still held, so messages land in order.
+func (w *writeBehind) flush(batch []*persistJob) {
+    if len(w.held) > 0 {
+        w.hold(batch)
+        return
+    }
+    err := w.write(batch)
+    if errors.Is(err, store.ErrUnavailable) {
+        w.hold(batch)
+        return
+    }
+    if err != nil {
+        w.spill(batch, err)
+    }
+}
+
+// hold keeps a batch for retryHeld, spilling what does not fit to the job
+// queue.
+func (w *writeBehind) hold(batch []*persistJob) {
+    h := w.hub
+    if len(w.held) == 0 {
+        h.logger.Warn("Store unavailable, holding chat messages in memory")
+    }
+    if room := w.holdLimit - len(w.held); len(batch) > room {
+        for _, job := range batch[room:] {
+            h.metrics.PersistOverflow.Inc()
+            h.persistOne(job)
+        }
+        batch = batch[:room]
+    }
+    w.held = append(w.held, batch...)
+    h.metrics.PersistHeld.Set(float64(len(w.held)))
+}
+
+// retryHeld writes held messages a batch at a time, stopping at the first
+// batch that finds the store still unavailable.
+func (w *writeBehind) retryHeld() {
+    if len(w.held) == 0 {
+        return
+    }
+    h := w.hub
+    for len(w.held) > 0 {
+        n := min(len(w.held), w.batchSize)
+        err := w.write(w.held[:n])
+        if errors.Is(err, store.ErrUnavailable) {
+            h.metrics.PersistHeld.Set(float64(len(w.held)))
+            return
+        }
+        if err != nil {
+            w.spill(w.held[:n], err)
+        }
+        w.held = w.held[n:]
+    }
+    w.held = nil
+    h.metrics.PersistHeld.Set(0)
+    h.logger.Info("Store available again, held chat messages written")
+}
+
+// spill hands a failed batch to the job queue message by message.
+func (w *writeBehind) spill(batch []*persistJob, err error) {
+    h := w.hub
+    h.metrics.PersistBatchFailures.Inc()
+    h.logger.Warn("Failed to persist message batch, persisting one by one",
+        zap.Error(err),
+        zap.Int("messages", len(batch)))
+    for _, job := range batch {
+        h.persistOne(job)
+    }
+}
+
+// write stores a batch, then runs each message's follow-up work, such as
+// review flags and link previews, off the batching loop.
+func (w *writeBehind) write(batch []*persistJob) error {
+    h := w.hub
+    ctx, cancel := context.WithTimeout(context.Background(), persistBatchTimeout)
+    defer cancel()
+
+    messages := make([]*models.Message, len(batch))
+    for i, job := range batch {
+        messages[i] = job.Message
+    }
+
+    ctx, span := tracing.Start(ctx, "message.persist_batch",
+        trace.WithAttributes(attribute.Int("messages", len(messages))))
+    start := time.Now()
+    err := h.store.CreateMessages(ctx, messages)
+    tracing.End(span, err)
+    h.metrics.PersistFlushDuration.Observe(time.Since(start).Seconds())
+    h.metrics.PersistBatchSize.Observe(float64(len(messages)))
+
+    if err != nil {
+        return err
+    }
+
+    go func() {
+        ctx, cancel := context.WithTimeout(context.Background(), persistBatchTimeout)
+        defer cancel()
+        for _, job := range batch {
+            job.afterStore(ctx)
+        }
+    }()
+    return nil
+}
+
+// FlushMessages writes every queued chat message and stops batching;
+// messages sent afterwards are stored one by one. It is called on
+// shutdown, before the job queue stops, so queued messages are not lost.
+func (h *Hub) FlushMessages(ctx context.Context) error {
+    w := h.writeBehind
+    w.mu.Lock()
+    if !w.stopped {
+        w.stopped = true
+        close(w.stop)
+    }
+    w.mu.Unlock()
+
+    select {
+    case <-w.done:
+        return nil
+    case <-ctx.Done():
+        return ctx.Err()
+    }
+}
@@ -0,0 +1,18 @@
+-- Create user_progress table for XP, levels and activity streaks
+CREATE TABLE user_progress (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    xp INTEGER NOT NULL DEFAULT 0,
+    current_streak INTEGER NOT NULL DEFAULT 0,
+    longest_streak INTEGER NOT NULL DEFAULT 0,
+    last_active_on DATE
+);
+
+-- Create user_achievements table for unlocked achievements
+CREATE TABLE user_achievements (
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    achievement_code VARCHAR(50) NOT NULL,
+    unlocked_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, achievement_code)
+);
+
+CREATE INDEX idx_user_achievements_user_id ON user_achievements(user_id);
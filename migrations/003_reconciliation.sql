@@ -0,0 +1,13 @@
+-- Map matches to the sports data provider's identifiers
+ALTER TABLE matches ADD COLUMN provider_id VARCHAR(255) UNIQUE;
+
+-- Create reconciliation_reports table for nightly provider reconciliation
+CREATE TABLE reconciliation_reports (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    finished_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    matches_checked INTEGER NOT NULL DEFAULT 0,
+    conflicts JSONB NOT NULL DEFAULT '[]'
+);
+
+CREATE INDEX idx_reconciliation_reports_started_at ON reconciliation_reports(started_at);
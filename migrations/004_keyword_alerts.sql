@@ -0,0 +1,10 @@
+-- Create keyword_alerts table for per-user keyword notifications
+CREATE TABLE keyword_alerts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    keyword VARCHAR(50) NOT NULL,
+    chat_room_id UUID REFERENCES chat_rooms(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_keyword_alerts_user_id ON keyword_alerts(user_id);
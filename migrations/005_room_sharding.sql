@@ -0,0 +1,4 @@
+-- Track sub-rooms created by splitting an overloaded room
+ALTER TABLE chat_rooms ADD COLUMN parent_id UUID REFERENCES chat_rooms(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_chat_rooms_parent_id ON chat_rooms(parent_id);
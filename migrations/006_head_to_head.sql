@@ -0,0 +1,3 @@
+-- Speed up head-to-head and recent form lookups over finished matches
+CREATE INDEX idx_matches_home_team_start_time ON matches(home_team_id, start_time DESC);
+CREATE INDEX idx_matches_away_team_start_time ON matches(away_team_id, start_time DESC);
@@ -0,0 +1,11 @@
+-- Create broadcast_journal table recording every frame broadcast to a room.
+-- Rows are purged by the server after JOURNAL_TTL.
+CREATE TABLE broadcast_journal (
+    id BIGSERIAL PRIMARY KEY,
+    chat_room_id VARCHAR(255) NOT NULL,
+    frame JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_broadcast_journal_room_created_at ON broadcast_journal(chat_room_id, created_at);
+CREATE INDEX idx_broadcast_journal_created_at ON broadcast_journal(created_at);
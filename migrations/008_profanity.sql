@@ -0,0 +1,23 @@
+-- Room language channel used to pick locale-specific moderation
+ALTER TABLE chat_rooms ADD COLUMN language VARCHAR(16);
+
+-- Create profanity_words table with locale-specific wordlists
+CREATE TABLE profanity_words (
+    locale VARCHAR(16) NOT NULL,
+    word VARCHAR(100) NOT NULL,
+    severity SMALLINT NOT NULL CHECK (severity BETWEEN 1 AND 3),
+    PRIMARY KEY (locale, word)
+);
+
+-- Create profanity_policies table with admin overrides per locale
+CREATE TABLE profanity_policies (
+    locale VARCHAR(16) PRIMARY KEY,
+    mild_action VARCHAR(10) NOT NULL,
+    moderate_action VARCHAR(10) NOT NULL,
+    severe_action VARCHAR(10) NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Fallback policy used when no locale-specific policy exists
+INSERT INTO profanity_policies (locale, mild_action, moderate_action, severe_action)
+VALUES ('default', 'allow', 'mask', 'block');
@@ -0,0 +1,5 @@
+-- Per-room policy for unfurling links posted in chat
+ALTER TABLE chat_rooms ADD COLUMN allow_link_previews BOOLEAN DEFAULT true;
+
+-- Unfurled OpenGraph previews attached to messages
+ALTER TABLE messages ADD COLUMN previews JSONB;
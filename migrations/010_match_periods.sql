@@ -0,0 +1,3 @@
+-- Richer match state: current period and penalty shootout kicks
+ALTER TABLE matches ADD COLUMN period VARCHAR(10);
+ALTER TABLE matches ADD COLUMN shootout JSONB;
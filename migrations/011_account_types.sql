@@ -0,0 +1,6 @@
+-- Service accounts (bots, broadcasters) and rate limit exemptions
+ALTER TABLE users ADD COLUMN account_type VARCHAR(20) NOT NULL DEFAULT 'user';
+ALTER TABLE users ADD COLUMN rate_limit_exempt BOOLEAN NOT NULL DEFAULT false;
+
+ALTER TABLE users ADD CONSTRAINT users_account_type_check
+    CHECK (account_type IN ('user', 'bot', 'broadcaster'));
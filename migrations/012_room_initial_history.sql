@@ -0,0 +1,3 @@
+-- Per-room number of messages sent on connect; 0 uses the server default
+ALTER TABLE chat_rooms ADD COLUMN initial_history INTEGER NOT NULL DEFAULT 0
+    CHECK (initial_history >= 0 AND initial_history <= 100);
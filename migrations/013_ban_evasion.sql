@@ -0,0 +1,32 @@
+-- Bans and ban evasion detection
+ALTER TABLE users ADD COLUMN banned_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE users ADD COLUMN ban_reason TEXT;
+
+CREATE TABLE device_sightings (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    fingerprint VARCHAR(255) NOT NULL DEFAULT '',
+    ip INET NOT NULL,
+    seen_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, fingerprint, ip)
+);
+
+CREATE INDEX idx_device_sightings_fingerprint ON device_sightings(fingerprint, seen_at) WHERE fingerprint <> '';
+CREATE INDEX idx_device_sightings_ip ON device_sightings(ip, seen_at);
+
+-- One row per (suspect, banned account) pair; reviewed pairs are never
+-- re-flagged.
+CREATE TABLE evasion_suspects (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    banned_user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    score DOUBLE PRECISION NOT NULL,
+    signals JSONB NOT NULL DEFAULT '[]',
+    status VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'confirmed', 'dismissed')),
+    reviewed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, banned_user_id)
+);
+
+CREATE INDEX idx_evasion_suspects_status ON evasion_suspects(status, created_at DESC);
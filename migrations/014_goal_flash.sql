@@ -0,0 +1,13 @@
+-- Competitions and cross-room goal flashes
+CREATE TABLE competitions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(255) NOT NULL,
+    sport_id UUID REFERENCES sports(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(name, sport_id)
+);
+
+ALTER TABLE matches ADD COLUMN competition_id UUID REFERENCES competitions(id) ON DELETE SET NULL;
+CREATE INDEX idx_matches_competition ON matches(competition_id, status);
+
+ALTER TABLE users ADD COLUMN goal_flash_opt_out BOOLEAN NOT NULL DEFAULT false;
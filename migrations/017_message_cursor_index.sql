@@ -0,0 +1,2 @@
+-- Keyset pagination of room history orders by (created_at, id)
+CREATE INDEX idx_messages_room_created_id ON messages(chat_room_id, created_at, id);
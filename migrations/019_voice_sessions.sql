@@ -0,0 +1,10 @@
+-- Voice sessions of watch parties; media goes through the SFU
+CREATE TABLE voice_participants (
+    room_id UUID NOT NULL REFERENCES chat_rooms(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL DEFAULT 'listener'
+        CHECK (role IN ('listener', 'speaker', 'host')),
+    hand_raised BOOLEAN NOT NULL DEFAULT false,
+    joined_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (room_id, user_id)
+);
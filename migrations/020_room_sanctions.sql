@@ -0,0 +1,13 @@
+-- Room-level mutes and bans
+CREATE TABLE room_sanctions (
+    room_id UUID NOT NULL REFERENCES chat_rooms(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kind VARCHAR(10) NOT NULL CHECK (kind IN ('mute', 'ban')),
+    reason TEXT,
+    created_by VARCHAR(255) NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (room_id, user_id, kind)
+);
+
+CREATE INDEX idx_room_sanctions_user ON room_sanctions(user_id);
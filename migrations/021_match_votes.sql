@@ -0,0 +1,31 @@
+-- Post-match ratings and player of the match votes
+CREATE TABLE match_votes (
+    match_id UUID PRIMARY KEY REFERENCES matches(id) ON DELETE CASCADE,
+    room_id UUID NOT NULL REFERENCES chat_rooms(id) ON DELETE CASCADE,
+    opens_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    closes_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    closed_at TIMESTAMP WITH TIME ZONE,
+    -- Aggregates, filled in when the vote closes
+    rating_count INTEGER NOT NULL DEFAULT 0,
+    rating_average NUMERIC(4, 2) NOT NULL DEFAULT 0,
+    players JSONB NOT NULL DEFAULT '[]'
+);
+
+CREATE INDEX idx_match_votes_due ON match_votes(closes_at) WHERE closed_at IS NULL;
+
+CREATE TABLE match_voters (
+    match_id UUID NOT NULL REFERENCES match_votes(match_id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    PRIMARY KEY (match_id, user_id)
+);
+
+CREATE TABLE match_ballots (
+    match_id UUID NOT NULL REFERENCES match_votes(match_id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    rating SMALLINT CHECK (rating BETWEEN 1 AND 10),
+    player VARCHAR(100),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (match_id, user_id),
+    CHECK (rating IS NOT NULL OR player IS NOT NULL)
+);
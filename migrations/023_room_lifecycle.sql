@@ -0,0 +1,7 @@
+-- Match room lifecycle. Existing rooms are left open.
+ALTER TABLE chat_rooms
+    ADD COLUMN state VARCHAR(20) NOT NULL DEFAULT 'open'
+        CHECK (state IN ('scheduled', 'open', 'read_only', 'archived')),
+    ADD COLUMN state_changed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP;
+
+CREATE INDEX idx_chat_rooms_state ON chat_rooms(state) WHERE state <> 'archived';
@@ -0,0 +1,13 @@
+-- Background work that exhausted its retries
+CREATE TABLE dead_letters (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    job_name VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL,
+    error TEXT NOT NULL,
+    attempts INTEGER NOT NULL,
+    replay_count INTEGER NOT NULL DEFAULT 0,
+    replayed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_dead_letters_pending ON dead_letters(created_at DESC) WHERE replayed_at IS NULL;
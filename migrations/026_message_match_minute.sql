@@ -0,0 +1,7 @@
+-- Match clock, and the minute each chat message was sent at
+ALTER TABLE matches ADD COLUMN minute INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE messages ADD COLUMN match_minute INTEGER;
+ALTER TABLE messages ADD COLUMN match_period VARCHAR(10);
+
+CREATE INDEX idx_messages_match_minute ON messages(chat_room_id, match_minute) WHERE match_minute IS NOT NULL;
@@ -0,0 +1,3 @@
+-- The sender's own ID for a chat message, so clients can reconcile
+-- history with what they sent
+ALTER TABLE messages ADD COLUMN client_msg_id VARCHAR(64);
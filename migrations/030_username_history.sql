@@ -0,0 +1,11 @@
+-- Username changes and the names users gave up
+ALTER TABLE users ADD COLUMN username_changed_at TIMESTAMP WITH TIME ZONE;
+
+CREATE TABLE username_history (
+    id BIGSERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    username VARCHAR(255) NOT NULL,
+    changed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_username_history_name ON username_history (LOWER(username), changed_at DESC);
@@ -0,0 +1,2 @@
+-- Room members @mentioned in a chat message
+ALTER TABLE messages ADD COLUMN mentions JSONB NOT NULL DEFAULT '[]';
@@ -0,0 +1,6 @@
+-- When each user first posted, for the first-message funnel step
+ALTER TABLE users ADD COLUMN first_message_at TIMESTAMP WITH TIME ZONE;
+
+UPDATE users SET first_message_at = first.created_at
+FROM (SELECT user_id, MIN(created_at) AS created_at FROM messages GROUP BY user_id) first
+WHERE users.id = first.user_id;
@@ -0,0 +1,20 @@
+-- Structured topic threads within a room before kickoff and after full
+-- time
+CREATE TABLE room_topics (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    room_id UUID NOT NULL REFERENCES chat_rooms(id) ON DELETE CASCADE,
+    slug VARCHAR(32) NOT NULL,
+    title VARCHAR(100) NOT NULL,
+    position INTEGER NOT NULL DEFAULT 0,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    collapsed_at TIMESTAMP WITH TIME ZONE
+);
+
+-- A slug is reused once the earlier topic has collapsed, e.g. ratings
+-- after one match and the next
+CREATE UNIQUE INDEX idx_room_topics_open_slug ON room_topics (room_id, slug) WHERE collapsed_at IS NULL;
+
+ALTER TABLE messages ADD COLUMN topic_id UUID REFERENCES room_topics(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_messages_topic ON messages (topic_id, created_at DESC, id DESC) WHERE topic_id IS NOT NULL;
@@ -0,0 +1,6 @@
+-- Per-room sequence numbers stamped on chat messages as they are
+-- broadcast, so reconnecting clients can ask for just the ones they
+-- missed. Messages from before sequencing have none.
+ALTER TABLE messages ADD COLUMN seq BIGINT;
+
+CREATE UNIQUE INDEX idx_messages_room_seq ON messages(chat_room_id, seq) WHERE seq IS NOT NULL;
@@ -0,0 +1,16 @@
+-- Account activity shown to each user: the security log gains one
+-- sign-in entry per session, refreshed as it reconnects. Disputing an
+-- entry signs out every session issued before the dispute.
+ALTER TABLE security_events
+    ADD COLUMN session_id VARCHAR(64),
+    ADD COLUMN country VARCHAR(2),
+    ADD COLUMN last_seen_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    ADD COLUMN disputed_at TIMESTAMP WITH TIME ZONE;
+
+UPDATE security_events SET last_seen_at = created_at;
+
+DROP INDEX idx_security_events_user;
+CREATE INDEX idx_security_events_user ON security_events(user_id, last_seen_at DESC);
+CREATE UNIQUE INDEX idx_security_events_sign_in ON security_events(user_id, session_id) WHERE kind = 'sign_in';
+
+ALTER TABLE users ADD COLUMN sessions_revoked_at TIMESTAMP WITH TIME ZONE;
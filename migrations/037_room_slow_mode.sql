@@ -0,0 +1,3 @@
+-- Slow mode: each user may post to the room at most once per this many
+-- seconds. Zero is off.
+ALTER TABLE chat_rooms ADD COLUMN slow_mode_seconds INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,13 @@
+-- Watch parties: rooms hosted by a user, who can see the room's analytics
+ALTER TABLE chat_rooms ADD COLUMN owner_id UUID REFERENCES users(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_chat_rooms_owner ON chat_rooms(owner_id) WHERE owner_id IS NOT NULL;
+
+-- Viewers of a watch party, sampled once a minute. Every instance samples
+-- the room-wide count, so concurrent samples of a minute keep the highest.
+CREATE TABLE room_attendance (
+    chat_room_id UUID NOT NULL REFERENCES chat_rooms(id) ON DELETE CASCADE,
+    minute TIMESTAMP WITH TIME ZONE NOT NULL,
+    viewers INTEGER NOT NULL,
+    PRIMARY KEY (chat_room_id, minute)
+);
@@ -0,0 +1,17 @@
+-- Images uploaded to rooms. Content stays in the row; an upload is only
+-- shown to others once the scanner marks it clean.
+CREATE TABLE attachments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    chat_room_id UUID NOT NULL REFERENCES chat_rooms(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    content_type VARCHAR(100) NOT NULL,
+    size INTEGER NOT NULL,
+    data BYTEA NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    reason TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    scanned_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_attachments_room ON attachments(chat_room_id, created_at) WHERE status = 'clean';
+CREATE INDEX idx_attachments_pending ON attachments(created_at) WHERE status = 'pending';
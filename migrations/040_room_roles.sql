@@ -0,0 +1,15 @@
+-- Per-room roles. Users without a row are members; a watch party's owner
+-- is an owner unless granted another role.
+CREATE TABLE room_roles (
+    room_id UUID NOT NULL REFERENCES chat_rooms(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('owner', 'moderator', 'vip')),
+    granted_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (room_id, user_id)
+);
+
+CREATE INDEX idx_room_roles_user ON room_roles(user_id);
+
+-- The message shown above a room's chat
+ALTER TABLE chat_rooms ADD COLUMN pinned_message_id UUID REFERENCES messages(id) ON DELETE SET NULL;
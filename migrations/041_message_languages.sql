@@ -0,0 +1,12 @@
+-- Detected language of each message, empty when it was too short to tell
+ALTER TABLE messages ADD COLUMN language VARCHAR(8);
+
+-- Running count of each room's messages by detected language, for routing
+-- readers to a language channel without scanning the room's history
+CREATE TABLE room_languages (
+    chat_room_id UUID NOT NULL REFERENCES chat_rooms(id) ON DELETE CASCADE,
+    language VARCHAR(8) NOT NULL,
+    messages BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (chat_room_id, language)
+);
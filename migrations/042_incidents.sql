@@ -0,0 +1,16 @@
+-- Room snapshots captured for postmortems. The bundles live in object
+-- storage; rows outlive their rooms so incidents stay findable.
+CREATE TABLE incidents (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    chat_room_id UUID NOT NULL,
+    captured_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    note TEXT,
+    window_start TIMESTAMP WITH TIME ZONE NOT NULL,
+    window_end TIMESTAMP WITH TIME ZONE NOT NULL,
+    object_key TEXT NOT NULL,
+    size INTEGER NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_incidents_room ON incidents(chat_room_id, created_at DESC);
+CREATE INDEX idx_incidents_created ON incidents(created_at DESC);
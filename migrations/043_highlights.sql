@@ -0,0 +1,12 @@
+-- Video clips of match moments, shown in match rooms as cards
+CREATE TABLE highlights (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    match_id UUID NOT NULL REFERENCES matches(id) ON DELETE CASCADE,
+    event_id UUID REFERENCES match_events(id) ON DELETE SET NULL,
+    title VARCHAR(200) NOT NULL,
+    video_url TEXT NOT NULL,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_highlights_match ON highlights(match_id, created_at DESC);
@@ -0,0 +1,5 @@
+-- Indexes for filtered room history: one user's messages for moderator
+-- review, and media and system messages for gallery views
+CREATE INDEX idx_messages_room_user ON messages(chat_room_id, user_id, created_at DESC, id DESC);
+CREATE INDEX idx_messages_room_media ON messages(chat_room_id, created_at DESC, id DESC) WHERE previews IS NOT NULL;
+CREATE INDEX idx_messages_room_system ON messages(chat_room_id, created_at DESC, id DESC) WHERE message_type NOT IN ('chat', 'text');
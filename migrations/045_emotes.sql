@@ -0,0 +1,20 @@
+-- Emote packs, site-wide or for one team's rooms, and their emotes
+CREATE TABLE emote_packs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(100) NOT NULL,
+    team_id UUID REFERENCES teams(id) ON DELETE CASCADE,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    published_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_emote_packs_team ON emote_packs(team_id) WHERE published_at IS NOT NULL;
+
+CREATE TABLE emotes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    pack_id UUID NOT NULL REFERENCES emote_packs(id) ON DELETE CASCADE,
+    shortcode VARCHAR(32) NOT NULL,
+    image_url TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (pack_id, shortcode)
+);
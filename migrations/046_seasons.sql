@@ -0,0 +1,30 @@
+-- Prediction seasons, per competition or site-wide, and the standings
+-- frozen when each one ends
+CREATE TABLE seasons (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(100) NOT NULL,
+    competition_id UUID REFERENCES competitions(id) ON DELETE CASCADE,
+    starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ends_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    archived_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK (ends_at > starts_at)
+);
+
+CREATE UNIQUE INDEX idx_seasons_start ON seasons(
+    COALESCE(competition_id, '00000000-0000-0000-0000-000000000000'::uuid), starts_at);
+CREATE INDEX idx_seasons_due ON seasons(ends_at) WHERE archived_at IS NULL;
+
+CREATE TABLE season_standings (
+    season_id UUID NOT NULL REFERENCES seasons(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    rank INTEGER NOT NULL,
+    points INTEGER NOT NULL,
+    correct INTEGER NOT NULL,
+    predictions INTEGER NOT NULL,
+    badge VARCHAR(32),
+    PRIMARY KEY (season_id, user_id)
+);
+
+CREATE INDEX idx_season_standings_rank ON season_standings(season_id, rank);
+CREATE INDEX idx_season_standings_user ON season_standings(user_id) WHERE badge IS NOT NULL;
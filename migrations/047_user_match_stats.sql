@@ -0,0 +1,18 @@
+-- Per-match engagement counters behind the live leaderboards. A message
+-- scores 1, a reaction received 2 and each prediction point 10. Reaction
+-- counts can dip below zero when a reaction from before the counters
+-- existed is removed; the score ignores that.
+CREATE TABLE user_match_stats (
+    match_id UUID NOT NULL REFERENCES matches(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    messages INTEGER NOT NULL DEFAULT 0,
+    reactions_received INTEGER NOT NULL DEFAULT 0,
+    correct_predictions INTEGER NOT NULL DEFAULT 0,
+    prediction_points INTEGER NOT NULL DEFAULT 0,
+    score INTEGER GENERATED ALWAYS AS (messages + 2 * GREATEST(reactions_received, 0) + 10 * prediction_points) STORED,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (match_id, user_id)
+);
+
+CREATE INDEX idx_user_match_stats_score ON user_match_stats(match_id, score DESC);
+CREATE INDEX idx_user_match_stats_user ON user_match_stats(user_id);
@@ -0,0 +1,14 @@
+-- Index of messages moved to object storage by the retention job
+CREATE TABLE message_archives (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    chat_room_id UUID NOT NULL REFERENCES chat_rooms(id) ON DELETE CASCADE,
+    day DATE NOT NULL,
+    object_key TEXT NOT NULL UNIQUE,
+    messages INTEGER NOT NULL,
+    size INTEGER NOT NULL,
+    first_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_message_archives_room ON message_archives(chat_room_id, first_at DESC);
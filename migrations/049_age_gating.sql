@@ -0,0 +1,9 @@
+-- Ages for parental controls. A user declares a birth date once; an admin
+-- who verified it may set it again, stamping age_verified_at. Rooms may be
+-- gated to a minimum age, optionally verified.
+ALTER TABLE users ADD COLUMN birth_date DATE;
+ALTER TABLE users ADD COLUMN age_verified_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE users ADD COLUMN restricted_mode BOOLEAN NOT NULL DEFAULT false;
+
+ALTER TABLE chat_rooms ADD COLUMN min_age INTEGER NOT NULL DEFAULT 0 CHECK (min_age >= 0);
+ALTER TABLE chat_rooms ADD COLUMN age_verified BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,8 @@
+-- Broadcaster-only rooms take chat from designated accounts alone, like a
+-- press conference; everyone else can still react and vote. Broadcasters
+-- are designated per room with the broadcaster role.
+ALTER TABLE chat_rooms ADD COLUMN broadcast_only BOOLEAN NOT NULL DEFAULT false;
+
+ALTER TABLE room_roles DROP CONSTRAINT room_roles_role_check;
+ALTER TABLE room_roles ADD CONSTRAINT room_roles_role_check
+    CHECK (role IN ('owner', 'moderator', 'vip', 'broadcaster'));
@@ -0,0 +1,3 @@
+-- A short bio shown on user profiles. Avatars are stored in object storage
+-- and only their URL is kept in avatar_url.
+ALTER TABLE users ADD COLUMN bio TEXT NOT NULL DEFAULT '' CHECK (char_length(bio) <= 280);
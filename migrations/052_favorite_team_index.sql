@@ -0,0 +1,3 @@
+-- Favorite teams are matched case-insensitively against team IDs and
+-- names when counting a match's followers for pre-warming.
+CREATE INDEX idx_users_favorite_team ON users(LOWER(favorite_team)) WHERE favorite_team IS NOT NULL;
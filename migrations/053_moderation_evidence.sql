@@ -0,0 +1,62 @@
+-- Sealed copies of messages deleted by moderation, kept for appeals and
+-- legal requests. The message is encrypted by the application; only the
+-- metadata needed to find it is in the clear. Rows are append-only: they
+-- can never be changed, and are only deleted once they have expired.
+CREATE TABLE moderation_evidence (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    message_id UUID NOT NULL,
+    chat_room_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    deleted_by UUID NOT NULL,
+    reason TEXT,
+    key_id TEXT NOT NULL,
+    sealed BYTEA NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX idx_moderation_evidence_user ON moderation_evidence(user_id, created_at DESC);
+CREATE INDEX idx_moderation_evidence_created ON moderation_evidence(created_at DESC);
+CREATE INDEX idx_moderation_evidence_expires ON moderation_evidence(expires_at);
+
+-- Every time an admin opened a piece of evidence, and why. Kept as long
+-- as the evidence itself.
+CREATE TABLE evidence_access (
+    id BIGSERIAL PRIMARY KEY,
+    evidence_id UUID NOT NULL REFERENCES moderation_evidence(id) ON DELETE CASCADE,
+    admin_id UUID NOT NULL,
+    purpose TEXT NOT NULL,
+    accessed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_evidence_access_evidence ON evidence_access(evidence_id, accessed_at);
+
+CREATE OR REPLACE FUNCTION guard_moderation_evidence()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'UPDATE' THEN
+        RAISE EXCEPTION 'moderation evidence is append-only';
+    END IF;
+    IF OLD.expires_at > CURRENT_TIMESTAMP THEN
+        RAISE EXCEPTION 'moderation evidence % has not expired', OLD.id;
+    END IF;
+    RETURN OLD;
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER guard_moderation_evidence
+    BEFORE UPDATE OR DELETE ON moderation_evidence
+    FOR EACH ROW
+    EXECUTE FUNCTION guard_moderation_evidence();
+
+CREATE OR REPLACE FUNCTION guard_evidence_access()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'evidence access log is append-only';
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER guard_evidence_access
+    BEFORE UPDATE ON evidence_access
+    FOR EACH ROW
+    EXECUTE FUNCTION guard_evidence_access();
@@ -0,0 +1,27 @@
+-- Users' appeals against moderation actions. An action is identified by
+-- its kind, room or message and when it was taken, so a ban lifted and
+-- imposed again can be appealed again.
+CREATE TABLE appeals (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    action VARCHAR(20) NOT NULL
+        CHECK (action IN ('ban', 'room_ban', 'room_mute', 'message_removal')),
+    chat_room_id UUID,
+    message_id UUID,
+    action_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    statement TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'accepted', 'rejected')),
+    reviewed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    review_note TEXT,
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_appeals_action ON appeals(
+    user_id, action,
+    COALESCE(chat_room_id, '00000000-0000-0000-0000-000000000000'::uuid),
+    COALESCE(message_id, '00000000-0000-0000-0000-000000000000'::uuid),
+    action_at);
+CREATE INDEX idx_appeals_status ON appeals(status, created_at);
+CREATE INDEX idx_appeals_user ON appeals(user_id, created_at DESC);
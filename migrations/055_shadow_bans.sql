@@ -0,0 +1,14 @@
+-- Shadow bans: a shadow-banned user's chat messages are echoed back to
+-- them alone and never stored. Each change is kept as an audit trail.
+ALTER TABLE users ADD COLUMN shadow_banned BOOLEAN NOT NULL DEFAULT false;
+
+CREATE TABLE shadow_ban_audit (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    banned BOOLEAN NOT NULL,
+    actor_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    reason TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_shadow_ban_audit_user ON shadow_ban_audit(user_id, created_at DESC);
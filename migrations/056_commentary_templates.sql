@@ -0,0 +1,10 @@
+-- Templates for system-generated match event messages, per locale and
+-- event type. Locales fall back from "pt-br" to "pt" to "default"; events
+-- without a template keep the provider's description.
+CREATE TABLE commentary_templates (
+    locale VARCHAR(35) NOT NULL,
+    event_type VARCHAR(50) NOT NULL,
+    template TEXT NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (locale, event_type)
+);
@@ -0,0 +1,18 @@
+-- Localized team and competition names, match venues, and the locale and
+-- time zone users see matches in. Names come from the sports data
+-- provider or an admin; an admin's name is never replaced by the
+-- provider's.
+ALTER TABLE matches ADD COLUMN venue JSONB;
+
+ALTER TABLE users ADD COLUMN locale VARCHAR(35) NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN timezone VARCHAR(64) NOT NULL DEFAULT '';
+
+CREATE TABLE localized_names (
+    kind VARCHAR(20) NOT NULL,
+    entity_id UUID NOT NULL,
+    locale VARCHAR(35) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    source VARCHAR(20) NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (kind, entity_id, locale)
+);
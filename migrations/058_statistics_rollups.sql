@@ -0,0 +1,19 @@
+-- Room and match statistics rolled up by a scheduled job, so reading
+-- them does not count the messages table. computed_at tells readers how
+-- fresh each row is.
+CREATE TABLE room_statistics_rollups (
+    chat_room_id UUID PRIMARY KEY REFERENCES chat_rooms(id) ON DELETE CASCADE,
+    message_count INTEGER NOT NULL DEFAULT 0,
+    user_count INTEGER NOT NULL DEFAULT 0,
+    last_activity TIMESTAMP WITH TIME ZONE,
+    computed_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE TABLE match_statistics_rollups (
+    match_id UUID PRIMARY KEY REFERENCES matches(id) ON DELETE CASCADE,
+    viewer_count INTEGER NOT NULL DEFAULT 0,
+    message_count INTEGER NOT NULL DEFAULT 0,
+    peak_viewer_count INTEGER NOT NULL DEFAULT 0,
+    event_count INTEGER NOT NULL DEFAULT 0,
+    computed_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
@@ -0,0 +1,16 @@
+-- What is left of deleted rooms. A deleted room is deactivated at once
+-- and purged after a grace period, until which the deletion can be
+-- undone; its tombstone outlives it, so requests for the room get 410
+-- Gone with its replacement, and has no foreign key to it.
+CREATE TABLE room_tombstones (
+    room_id UUID PRIMARY KEY,
+    name VARCHAR(255) NOT NULL DEFAULT '',
+    match_id UUID,
+    replacement_id UUID REFERENCES chat_rooms(id) ON DELETE SET NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    deleted_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    deleted_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    purged_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_room_tombstones_unpurged ON room_tombstones(deleted_at) WHERE purged_at IS NULL;
@@ -0,0 +1,6 @@
+-- Senders of each archive's messages, so erasing a user rewrites only
+-- the archives they posted to. Archives written before this are left
+-- NULL and are read in full by an erasure.
+ALTER TABLE message_archives ADD COLUMN user_ids JSONB;
+
+CREATE INDEX idx_message_archives_users ON message_archives USING GIN (user_ids);
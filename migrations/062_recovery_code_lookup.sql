@@ -0,0 +1,4 @@
+-- Keyed digest of each backup code, so redeeming one runs the slow hash
+-- check against the single code that can match. Codes generated before
+-- are left NULL and checked one by one, as they were.
+ALTER TABLE recovery_codes ADD COLUMN code_lookup CHAR(64);